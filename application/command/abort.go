@@ -0,0 +1,14 @@
+package command
+
+import (
+	"context"
+	"fmt"
+)
+
+// abortIfDone stops a command whose request was cancelled or timed out before it changes any state
+func abortIfDone(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("command aborted: %w", err)
+	}
+	return nil
+}
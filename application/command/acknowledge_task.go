@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AcknowledgeTaskCommand represents a command for an assignee to confirm they have seen a
+// task assigned to them
+type AcknowledgeTaskCommand struct {
+	TaskID string
+	UserID string
+	OnView bool // acknowledge by opening the task, doing nothing unless it awaits this user
+}
+
+// AcknowledgeTaskCommandHandler handles AcknowledgeTaskCommand
+type AcknowledgeTaskCommandHandler struct {
+	taskRepository domain.TaskRepository
+	eventPublisher event.EventPublisher
+}
+
+// NewAcknowledgeTaskCommandHandler creates a new AcknowledgeTaskCommandHandler
+func NewAcknowledgeTaskCommandHandler(
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+) *AcknowledgeTaskCommandHandler {
+	return &AcknowledgeTaskCommandHandler{
+		taskRepository: taskRepository,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// AcknowledgeTaskResult represents the result of acknowledging a task
+type AcknowledgeTaskResult struct {
+	Acknowledged bool // false when a view did not acknowledge anything
+	Error        error
+}
+
+// Handle handles the AcknowledgeTaskCommand
+func (h *AcknowledgeTaskCommandHandler) Handle(ctx context.Context, cmd AcknowledgeTaskCommand) (*AcknowledgeTaskResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Only the first view of the assignee counts
+	source := aggregate.AcknowledgementExplicit
+	if cmd.OnView {
+		assignee := task.Assignee()
+		if assignee == nil || !assignee.IsAssignedTo(userID) || assignee.IsAcknowledged() {
+			return &AcknowledgeTaskResult{}, nil
+		}
+		source = aggregate.AcknowledgementOnView
+	}
+
+	// Acknowledge assignment
+	if err := task.AcknowledgeAssignment(userID, source); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &AcknowledgeTaskResult{Acknowledged: true}, nil
+}
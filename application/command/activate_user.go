@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ActivateUserCommand represents a command to activate a deactivated user again
+type ActivateUserCommand struct {
+	UserID      string
+	RequestedBy string
+}
+
+// ActivateUserCommandHandler handles ActivateUserCommand
+type ActivateUserCommandHandler struct {
+	userRepository domain.UserRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewActivateUserCommandHandler creates a new ActivateUserCommandHandler
+func NewActivateUserCommandHandler(
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *ActivateUserCommandHandler {
+	return &ActivateUserCommandHandler{
+		userRepository: userRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// ActivateUserResult represents the result of activating a user
+type ActivateUserResult struct {
+	UserID string
+	Error  error
+}
+
+// Handle handles the ActivateUserCommand.
+// Tasks handed off on deactivation stay with their new assignees.
+func (h *ActivateUserCommandHandler) Handle(ctx context.Context, cmd ActivateUserCommand) (*ActivateUserResult, error) {
+	// Parse IDs
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Activate user
+	if err := user.Activate(requestedBy); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	user.ClearDomainEvents()
+
+	return &ActivateUserResult{
+		UserID: userID.Value(),
+	}, nil
+}
@@ -0,0 +1,133 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AddAttachmentCommand represents a command to record a file attached to a task
+type AddAttachmentCommand struct {
+	TaskID      string
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	UploadedBy  string
+}
+
+// AddAttachmentCommandHandler handles AddAttachmentCommand
+type AddAttachmentCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+	quotaService      *service.QuotaEnforcementService
+}
+
+// NewAddAttachmentCommandHandler creates a new AddAttachmentCommandHandler
+func NewAddAttachmentCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+	quotaService *service.QuotaEnforcementService,
+) *AddAttachmentCommandHandler {
+	return &AddAttachmentCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+		quotaService:      quotaService,
+	}
+}
+
+// AddAttachmentResult represents the result of adding an attachment
+type AddAttachmentResult struct {
+	AttachmentID string
+	Error        error
+}
+
+// Handle handles the AddAttachmentCommand
+func (h *AddAttachmentCommandHandler) Handle(ctx context.Context, cmd AddAttachmentCommand) (*AddAttachmentResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	uploadedBy, err := value.NewUserID(cmd.UploadedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Validate uploader exists
+	_, err = h.userRepository.GetByID(ctx, uploadedBy)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Verify the project's organization has room for the file
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.UploadedBy, service.PermissionEditTask, project, task); err != nil {
+		return nil, err
+	}
+
+	if err := h.quotaService.CheckAttachmentQuota(ctx, project, cmd.SizeBytes); err != nil {
+		return nil, err
+	}
+
+	// Create and add attachment
+	attachment, err := entity.NewAttachment(taskID, cmd.FileName, cmd.ContentType, cmd.SizeBytes, uploadedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid attachment: %w", err)
+	}
+
+	if err := task.AddAttachment(attachment); err != nil {
+		return nil, fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &AddAttachmentResult{
+		AttachmentID: attachment.ID(),
+	}, nil
+}
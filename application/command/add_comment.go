@@ -0,0 +1,128 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AddCommentCommand represents a command to add a comment to a task
+type AddCommentCommand struct {
+	TaskID   string
+	AuthorID string
+	Content  string
+}
+
+// AddCommentCommandHandler handles AddCommentCommand
+type AddCommentCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewAddCommentCommandHandler creates a new AddCommentCommandHandler
+func NewAddCommentCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *AddCommentCommandHandler {
+	return &AddCommentCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// AddCommentResult represents the result of adding a comment
+type AddCommentResult struct {
+	CommentID string
+	Error     error
+}
+
+// Handle handles the AddCommentCommand
+func (h *AddCommentCommandHandler) Handle(ctx context.Context, cmd AddCommentCommand) (*AddCommentResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	authorID, err := value.NewUserID(cmd.AuthorID)
+	if err != nil {
+		return nil, errs.Invalid("invalid author id: %w", err)
+	}
+
+	// Validate author exists
+	_, err = h.userRepository.GetByID(ctx, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Check the project accepts comments
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.AuthorID, service.PermissionDiscussTask, project, task); err != nil {
+		return nil, err
+	}
+
+	if !project.Settings().AllowComments() {
+		return nil, errs.Conflict("comments are disabled for this project")
+	}
+
+	// Create and add comment
+	comment, err := entity.NewComment(taskID, authorID, cmd.Content)
+	if err != nil {
+		return nil, errs.Invalid("invalid comment: %w", err)
+	}
+
+	if err := task.AddComment(comment); err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &AddCommentResult{
+		CommentID: comment.ID(),
+	}, nil
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AddOrganizationMemberCommand represents a command to add a user to an organization
+type AddOrganizationMemberCommand struct {
+	OrganizationID string
+	UserID         string
+	RequestedBy    string
+}
+
+// AddOrganizationMemberCommandHandler handles AddOrganizationMemberCommand
+type AddOrganizationMemberCommandHandler struct {
+	organizationRepository domain.OrganizationRepository
+	userRepository         domain.UserRepository
+	eventPublisher         event.EventPublisher
+	authorizer             *Authorizer
+}
+
+// NewAddOrganizationMemberCommandHandler creates a new AddOrganizationMemberCommandHandler
+func NewAddOrganizationMemberCommandHandler(
+	organizationRepository domain.OrganizationRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *AddOrganizationMemberCommandHandler {
+	return &AddOrganizationMemberCommandHandler{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+		eventPublisher:         eventPublisher,
+		authorizer:             authorizer,
+	}
+}
+
+// AddOrganizationMemberResult represents the result of adding an organization member
+type AddOrganizationMemberResult struct {
+	OrganizationID string
+	UserID         string
+	Error          error
+}
+
+// Handle handles the AddOrganizationMemberCommand
+func (h *AddOrganizationMemberCommandHandler) Handle(ctx context.Context, cmd AddOrganizationMemberCommand) (*AddOrganizationMemberResult, error) {
+	// Parse IDs
+	organizationID, err := value.NewOrganizationID(cmd.OrganizationID)
+	if err != nil {
+		return nil, errs.Invalid("invalid organization id: %w", err)
+	}
+
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get organization
+	organization, err := h.organizationRepository.GetByID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+
+	// Add member
+	if err := verifyOrganizationCandidate(ctx, h.userRepository, h.organizationRepository, userID); err != nil {
+		return nil, err
+	}
+
+	if err := organization.AddMember(userID); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save organization
+	err = h.organizationRepository.Update(ctx, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save organization: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range organization.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	organization.ClearDomainEvents()
+
+	return &AddOrganizationMemberResult{
+		OrganizationID: organizationID.Value(),
+		UserID:         userID.Value(),
+	}, nil
+}
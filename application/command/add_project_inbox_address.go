@@ -0,0 +1,111 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AddProjectInboxAddressCommand represents a command to generate a new inbound email
+// address feeding a project
+type AddProjectInboxAddressCommand struct {
+	ProjectID   string
+	Label       string // what the address is for, such as the mailing list sending to it
+	RequestedBy string
+}
+
+// AddProjectInboxAddressCommandHandler handles AddProjectInboxAddressCommand
+type AddProjectInboxAddressCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	inboxService      *service.InboxAddressService
+	authorizer        *Authorizer
+}
+
+// NewAddProjectInboxAddressCommandHandler creates a new AddProjectInboxAddressCommandHandler
+func NewAddProjectInboxAddressCommandHandler(
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	inboxService *service.InboxAddressService,
+	authorizer *Authorizer,
+) *AddProjectInboxAddressCommandHandler {
+	return &AddProjectInboxAddressCommandHandler{
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		inboxService:      inboxService,
+		authorizer:        authorizer,
+	}
+}
+
+// AddProjectInboxAddressResult represents the result of adding an inbox address
+type AddProjectInboxAddressResult struct {
+	Token   string
+	Address string
+	Error   error
+}
+
+// Handle handles the AddProjectInboxAddressCommand
+func (h *AddProjectInboxAddressCommandHandler) Handle(ctx context.Context, cmd AddProjectInboxAddressCommand) (*AddProjectInboxAddressResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Generate address, drawing again in the unlikely case another project has the token
+	inbox, err := value.GenerateInboxAddress(cmd.Label)
+	for err == nil {
+		if _, taken := h.projectRepository.GetByInboxToken(ctx, inbox.Token()); taken != nil {
+			break
+		}
+		inbox, err = value.GenerateInboxAddress(cmd.Label)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.AddInboxAddress(inbox); err != nil {
+		return nil, fmt.Errorf("failed to add inbox address: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &AddProjectInboxAddressResult{
+		Token:   inbox.Token(),
+		Address: h.inboxService.AddressOf(inbox),
+	}, nil
+}
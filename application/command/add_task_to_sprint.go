@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AddTaskToSprintCommand represents a command to plan a task into a sprint
+type AddTaskToSprintCommand struct {
+	SprintID    string
+	TaskID      string
+	RequestedBy string
+}
+
+// AddTaskToSprintCommandHandler handles AddTaskToSprintCommand
+type AddTaskToSprintCommandHandler struct {
+	sprintRepository  domain.SprintRepository
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewAddTaskToSprintCommandHandler creates a new AddTaskToSprintCommandHandler
+func NewAddTaskToSprintCommandHandler(
+	sprintRepository domain.SprintRepository,
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *AddTaskToSprintCommandHandler {
+	return &AddTaskToSprintCommandHandler{
+		sprintRepository:  sprintRepository,
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// AddTaskToSprintResult represents the result of adding a task to a sprint
+type AddTaskToSprintResult struct {
+	Error error
+}
+
+// Handle handles the AddTaskToSprintCommand
+func (h *AddTaskToSprintCommandHandler) Handle(ctx context.Context, cmd AddTaskToSprintCommand) (*AddTaskToSprintResult, error) {
+	// Parse IDs
+	sprintID, err := value.NewSprintID(cmd.SprintID)
+	if err != nil {
+		return nil, errs.Invalid("invalid sprint id: %w", err)
+	}
+
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	// Get sprint and task
+	sprint, err := h.sprintRepository.GetByID(ctx, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("sprint not found: %w", err)
+	}
+
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	if !task.ProjectID().Equals(sprint.ProjectID()) {
+		return nil, errs.Conflict("task belongs to a different project")
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, sprint.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionPlanSprints, project, nil); err != nil {
+		return nil, err
+	}
+
+	// A task can only be planned into one open sprint at a time
+	sprints, err := h.sprintRepository.GetByProjectID(ctx, sprint.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project sprints: %w", err)
+	}
+
+	for _, other := range sprints {
+		if !other.ID().Equals(sprintID) && other.IsOpen() && other.HasTask(taskID) {
+			return nil, errs.Conflict("task already planned into sprint %s", other.Name())
+		}
+	}
+
+	// Add task
+	err = sprint.AddTask(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add task to sprint: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save sprint
+	err = h.sprintRepository.Update(ctx, sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save sprint: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range sprint.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	sprint.ClearDomainEvents()
+
+	return &AddTaskToSprintResult{}, nil
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AddTeamMemberCommand represents a command to add a user to a team
+type AddTeamMemberCommand struct {
+	TeamID      string
+	UserID      string
+	RequestedBy string
+}
+
+// AddTeamMemberCommandHandler handles AddTeamMemberCommand
+type AddTeamMemberCommandHandler struct {
+	teamRepository domain.TeamRepository
+	userRepository domain.UserRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewAddTeamMemberCommandHandler creates a new AddTeamMemberCommandHandler
+func NewAddTeamMemberCommandHandler(
+	teamRepository domain.TeamRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *AddTeamMemberCommandHandler {
+	return &AddTeamMemberCommandHandler{
+		teamRepository: teamRepository,
+		userRepository: userRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// AddTeamMemberResult represents the result of adding a team member
+type AddTeamMemberResult struct {
+	Error error
+}
+
+// Handle handles the AddTeamMemberCommand
+func (h *AddTeamMemberCommandHandler) Handle(ctx context.Context, cmd AddTeamMemberCommand) (*AddTeamMemberResult, error) {
+	// Parse IDs
+	teamID, err := value.NewTeamID(cmd.TeamID)
+	if err != nil {
+		return nil, errs.Invalid("invalid team id: %w", err)
+	}
+
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get team
+	team, err := h.teamRepository.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeTeam(ctx, cmd.RequestedBy, team); err != nil {
+		return nil, err
+	}
+
+	// Add member
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if !user.IsActive() {
+		return nil, errs.Conflict("user is deactivated")
+	}
+
+	if err := team.AddMember(userID); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save team
+	err = h.teamRepository.Update(ctx, team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save team: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range team.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	team.ClearDomainEvents()
+
+	return &AddTeamMemberResult{}, nil
+}
@@ -0,0 +1,213 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DefaultWorkflowMigrationBatchSize is how many tasks a workflow migration moves between progress reports
+const DefaultWorkflowMigrationBatchSize = 100
+
+// ApplyProjectWorkflowMigrationCommand represents a command to move a project's tasks as its
+// planned workflow migration records, the second phase of switching workflows. A migration
+// cancelled between batches keeps its progress and resumes where it stopped.
+type ApplyProjectWorkflowMigrationCommand struct {
+	ProjectID   string
+	BatchSize   int // 0 uses DefaultWorkflowMigrationBatchSize
+	RequestedBy string
+}
+
+// ApplyProjectWorkflowMigrationCommandHandler handles ApplyProjectWorkflowMigrationCommand
+type ApplyProjectWorkflowMigrationCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewApplyProjectWorkflowMigrationCommandHandler creates a new ApplyProjectWorkflowMigrationCommandHandler
+func NewApplyProjectWorkflowMigrationCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *ApplyProjectWorkflowMigrationCommandHandler {
+	return &ApplyProjectWorkflowMigrationCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// ApplyProjectWorkflowMigrationResult represents the result of applying a workflow migration
+type ApplyProjectWorkflowMigrationResult struct {
+	MigrationID string
+	Applied     int
+	Total       int
+	Status      string
+	Error       error
+}
+
+// Handle handles the ApplyProjectWorkflowMigrationCommand
+func (h *ApplyProjectWorkflowMigrationCommandHandler) Handle(ctx context.Context, cmd ApplyProjectWorkflowMigrationCommand) (*ApplyProjectWorkflowMigrationResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	batchSize := cmd.BatchSize
+	if batchSize == 0 {
+		batchSize = DefaultWorkflowMigrationBatchSize
+	}
+	if batchSize < 0 {
+		return nil, errs.Invalid("invalid batch size: %d", cmd.BatchSize)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	migration := project.WorkflowMigration()
+	if migration == nil {
+		return nil, errs.NotFound("workflow migration not found: plan one first")
+	}
+	if migration.Status() != entity.WorkflowMigrationPlanned && !migration.IsInProgress() {
+		return nil, errs.Conflict("workflow migration is %s: cannot apply it", migration.Status())
+	}
+
+	// Check the plan still holds before moving any task
+	pending, err := h.pendingTasks(ctx, project, migration)
+	if err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(pending); start += batchSize {
+		// Stop between batches if the request was cancelled or timed out
+		if err := abortIfDone(ctx); err != nil {
+			return nil, err
+		}
+
+		end := start + batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		steps := migration.Pending()[:end-start]
+		for i, step := range steps {
+			task := pending[start+i]
+
+			// Migrate task
+			if err := task.MigrateWorkflow(migration.ToVersion(), step.ToStatus); err != nil {
+				return nil, fmt.Errorf("cannot migrate task %s: %w", task.ID().Value(), err)
+			}
+
+			// Save task
+			if err := h.taskRepository.Update(ctx, task); err != nil {
+				return nil, fmt.Errorf("failed to save task: %w", err)
+			}
+
+			// Publish domain events
+			for _, domainEvent := range task.DomainEvents() {
+				if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+					return nil, fmt.Errorf("failed to publish event: %w", err)
+				}
+			}
+			task.ClearDomainEvents()
+		}
+
+		// Record the batch so a later run resumes after it
+		if err := project.RecordWorkflowMigrationProgress(len(steps)); err != nil {
+			return nil, err
+		}
+		if err := h.saveProject(ctx, project); err != nil {
+			return nil, err
+		}
+	}
+
+	// Switch the project once every task has moved
+	if err := project.CompleteWorkflowMigration(); err != nil {
+		return nil, err
+	}
+	if err := h.saveProject(ctx, project); err != nil {
+		return nil, err
+	}
+
+	return &ApplyProjectWorkflowMigrationResult{
+		MigrationID: migration.ID(),
+		Applied:     migration.Applied(),
+		Total:       migration.Total(),
+		Status:      string(migration.Status()),
+	}, nil
+}
+
+// pendingTasks loads the tasks of the steps left to apply, refusing a plan that no longer
+// matches the project: a task moved since planning, or one the plan does not cover
+func (h *ApplyProjectWorkflowMigrationCommandHandler) pendingTasks(ctx context.Context, project *aggregate.Project, migration *entity.WorkflowMigration) ([]*aggregate.Task, error) {
+	tasks, err := h.taskRepository.GetByProjectID(ctx, project.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	byID := make(map[string]*aggregate.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID().Value()] = task
+	}
+
+	planned := make(map[string]bool, migration.Total())
+	for _, step := range migration.Steps() {
+		planned[step.TaskID.Value()] = true
+	}
+	for _, task := range tasks {
+		if !planned[task.ID().Value()] {
+			return nil, errs.Conflict("workflow migration plan is stale: task %s was created since it was planned, plan the migration again", task.ID().Value())
+		}
+	}
+
+	pending := make([]*aggregate.Task, 0, migration.Total()-migration.Applied())
+	for _, step := range migration.Pending() {
+		task, ok := byID[step.TaskID.Value()]
+		if !ok {
+			return nil, errs.Conflict("workflow migration plan is stale: task %s was deleted since it was planned, plan the migration again", step.TaskID.Value())
+		}
+		if !task.WorkflowStatus().Equals(step.FromStatus) {
+			return nil, errs.Conflict("workflow migration plan is stale: task %s moved from %s to %s since it was planned, plan the migration again",
+				task.ID().Value(), step.FromStatus.Name(), task.WorkflowStatus().Name())
+		}
+		pending = append(pending, task)
+	}
+
+	return pending, nil
+}
+
+// saveProject saves the project and publishes its events
+func (h *ApplyProjectWorkflowMigrationCommandHandler) saveProject(ctx context.Context, project *aggregate.Project) error {
+	if err := h.projectRepository.Update(ctx, project); err != nil {
+		return fmt.Errorf("failed to save project: %w", err)
+	}
+
+	for _, domainEvent := range project.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ApproveTaskCommand represents a command to approve a task in its current status.
+// When the status needs sign-off only its designated reviewers may approve.
+type ApproveTaskCommand struct {
+	TaskID     string
+	ApproverID string
+}
+
+// ApproveTaskCommandHandler handles ApproveTaskCommand
+type ApproveTaskCommandHandler struct {
+	taskRepository          domain.TaskRepository
+	projectRepository       domain.ProjectRepository
+	eventPublisher          event.EventPublisher
+	statusTransitionService *service.StatusTransitionService
+	authorizer              *Authorizer
+}
+
+// NewApproveTaskCommandHandler creates a new ApproveTaskCommandHandler
+func NewApproveTaskCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	statusTransitionService *service.StatusTransitionService,
+	authorizer *Authorizer,
+) *ApproveTaskCommandHandler {
+	return &ApproveTaskCommandHandler{
+		taskRepository:          taskRepository,
+		projectRepository:       projectRepository,
+		eventPublisher:          eventPublisher,
+		statusTransitionService: statusTransitionService,
+		authorizer:              authorizer,
+	}
+}
+
+// ApproveTaskResult represents the result of approving a task
+type ApproveTaskResult struct {
+	ApprovalCount     int
+	RequiredApprovals int // sign-off the current status needs, 0 when it needs none
+	Error             error
+}
+
+// Handle handles the ApproveTaskCommand
+func (h *ApproveTaskCommandHandler) Handle(ctx context.Context, cmd ApproveTaskCommand) (*ApproveTaskResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	step := h.statusTransitionService.ApprovalStepOf(ctx, task)
+	if err := authorizeReview(ctx, h.authorizer, step, cmd.ApproverID, project, task); err != nil {
+		return nil, err
+	}
+
+	approverID, err := value.NewUserID(cmd.ApproverID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Record approval
+	if err := task.Approve(approverID); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	result := &ApproveTaskResult{
+		ApprovalCount: task.ApprovalCount(),
+	}
+	if step != nil {
+		result.RequiredApprovals = step.RequiredApprovals()
+	}
+
+	return result, nil
+}
+
+// authorizeReview checks that a user may approve or reject a task: the designated reviewers
+// when its status needs sign-off, otherwise those who may move the task on
+func authorizeReview(ctx context.Context, authorizer *Authorizer, step *value.ApprovalStep, reviewerID string, project *aggregate.Project, task *aggregate.Task) error {
+	if step == nil {
+		return authorizer.Authorize(ctx, reviewerID, service.PermissionTransitionTask, project, task)
+	}
+
+	userID, err := value.NewUserID(reviewerID)
+	if err != nil || !step.IsReviewer(userID) {
+		return errs.PermissionDenied("permission denied: only the designated reviewers can sign off tasks in %s", task.Status().Value())
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ArchiveProjectCommand represents a command to archive a project and its open tasks
+type ArchiveProjectCommand struct {
+	ProjectID   string
+	Policy      string // FREEZE or CANCEL, defaults to FREEZE
+	RequestedBy string
+}
+
+// ArchiveProjectCommandHandler handles ArchiveProjectCommand
+type ArchiveProjectCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewArchiveProjectCommandHandler creates a new ArchiveProjectCommandHandler
+func NewArchiveProjectCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *ArchiveProjectCommandHandler {
+	return &ArchiveProjectCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// ArchiveProjectResult represents the result of archiving a project
+type ArchiveProjectResult struct {
+	AffectedTaskIDs []string
+	Error           error
+}
+
+// Handle handles the ArchiveProjectCommand
+func (h *ArchiveProjectCommandHandler) Handle(ctx context.Context, cmd ArchiveProjectCommand) (*ArchiveProjectResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse policy
+	policy := value.ArchivePolicyFreeze
+	if cmd.Policy != "" {
+		policy, err = value.NewArchivePolicy(cmd.Policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	if project.IsArchived() {
+		return nil, errs.Conflict("project is archived already")
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	// Cancel or freeze open tasks
+	affected := make([]*aggregate.Task, 0, len(tasks))
+	affectedIDs := make([]value.TaskID, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.IsOpen() {
+			continue
+		}
+
+		if policy == value.ArchivePolicyCancel {
+			err = task.ChangeStatus(value.TaskStatusCancelled)
+		} else {
+			err = task.Freeze()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to archive task %s: %w", task.ID().Value(), err)
+		}
+
+		affected = append(affected, task)
+		affectedIDs = append(affectedIDs, task.ID())
+	}
+
+	// Archive project
+	err = project.Archive(policy, affectedIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive project: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save tasks and project
+	for _, task := range affected {
+		err = h.taskRepository.Update(ctx, task)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, task := range affected {
+		for _, domainEvent := range task.DomainEvents() {
+			err = h.eventPublisher.Publish(ctx, domainEvent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+		task.ClearDomainEvents()
+	}
+
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	affectedTaskIDs := make([]string, 0, len(affectedIDs))
+	for _, taskID := range affectedIDs {
+		affectedTaskIDs = append(affectedTaskIDs, taskID.Value())
+	}
+
+	return &ArchiveProjectResult{
+		AffectedTaskIDs: affectedTaskIDs,
+	}, nil
+}
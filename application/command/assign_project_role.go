@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AssignProjectRoleCommand represents a command to give a user a role in a project
+type AssignProjectRoleCommand struct {
+	ProjectID   string
+	UserID      string
+	Role        string // VIEWER, MEMBER or ADMIN, empty revokes the assigned role
+	RequestedBy string
+}
+
+// AssignProjectRoleCommandHandler handles AssignProjectRoleCommand
+type AssignProjectRoleCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewAssignProjectRoleCommandHandler creates a new AssignProjectRoleCommandHandler
+func NewAssignProjectRoleCommandHandler(
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *AssignProjectRoleCommandHandler {
+	return &AssignProjectRoleCommandHandler{
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// AssignProjectRoleResult represents the result of assigning a project role
+type AssignProjectRoleResult struct {
+	Error error
+}
+
+// Handle handles the AssignProjectRoleCommand
+func (h *AssignProjectRoleCommandHandler) Handle(ctx context.Context, cmd AssignProjectRoleCommand) (*AssignProjectRoleResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Get user
+	if _, err := h.userRepository.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Assign or revoke role
+	if cmd.Role == "" {
+		err = project.RevokeRole(userID)
+	} else {
+		var role value.ProjectRole
+		role, err = value.NewProjectRole(cmd.Role)
+		if err == nil {
+			err = project.AssignRole(userID, role)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	project.ClearDomainEvents()
+
+	return &AssignProjectRoleResult{}, nil
+}
@@ -1,9 +1,11 @@
 package command
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/service"
 	"github.com/miladev95/ddd-task/domain/value"
@@ -19,67 +21,90 @@ type AssignTaskCommand struct {
 // AssignTaskCommandHandler handles AssignTaskCommand
 type AssignTaskCommandHandler struct {
 	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
 	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
 	assignmentService *service.TaskAssignmentService
 }
 
 // NewAssignTaskCommandHandler creates a new AssignTaskCommandHandler
 func NewAssignTaskCommandHandler(
 	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
 	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
 	assignmentService *service.TaskAssignmentService,
 ) *AssignTaskCommandHandler {
 	return &AssignTaskCommandHandler{
 		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
 		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
 		assignmentService: assignmentService,
 	}
 }
 
 // AssignTaskResult represents the result of assigning a task
 type AssignTaskResult struct {
-	Error error
+	Warnings []string // set when the assignee is now over their open task limit
+	Error    error
 }
 
 // Handle handles the AssignTaskCommand
-func (h *AssignTaskCommandHandler) Handle(cmd AssignTaskCommand) (*AssignTaskResult, error) {
+func (h *AssignTaskCommandHandler) Handle(ctx context.Context, cmd AssignTaskCommand) (*AssignTaskResult, error) {
 	// Parse IDs
 	taskID, err := value.NewTaskID(cmd.TaskID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid task id: %w", err)
+		return nil, errs.Invalid("invalid task id: %w", err)
 	}
 
 	assigneeID, err := value.NewUserID(cmd.AssigneeID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid assignee id: %w", err)
+		return nil, errs.Invalid("invalid assignee id: %w", err)
 	}
 
 	assignedByID, err := value.NewUserID(cmd.AssignedBy)
 	if err != nil {
-		return nil, fmt.Errorf("invalid assigner id: %w", err)
+		return nil, errs.Invalid("invalid assigner id: %w", err)
 	}
 
 	// Get task
-	task, err := h.taskRepository.GetByID(taskID)
+	task, err := h.taskRepository.GetByID(ctx, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("task not found: %w", err)
 	}
 
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.AssignedBy, service.PermissionAssignTask, project, task); err != nil {
+		return nil, err
+	}
+
 	// Assign task
-	err = h.assignmentService.AssignTask(task, assigneeID, assignedByID)
+	warning, err := h.assignmentService.AssignTask(ctx, task, assigneeID, assignedByID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to assign task: %w", err)
 	}
 
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
 	// Save task
-	err = h.taskRepository.Update(task)
+	err = h.taskRepository.Update(ctx, task)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save task: %w", err)
 	}
 
 	// Publish domain events
 	for _, domainEvent := range task.DomainEvents() {
-		err = h.eventPublisher.Publish(domainEvent)
+		err = h.eventPublisher.Publish(ctx, domainEvent)
 		if err != nil {
 			return nil, fmt.Errorf("failed to publish event: %w", err)
 		}
@@ -87,5 +112,10 @@ func (h *AssignTaskCommandHandler) Handle(cmd AssignTaskCommand) (*AssignTaskRes
 
 	task.ClearDomainEvents()
 
-	return &AssignTaskResult{}, nil
-}
\ No newline at end of file
+	result := &AssignTaskResult{Warnings: make([]string, 0)}
+	if warning != nil {
+		result.Warnings = append(result.Warnings, warning.Message())
+	}
+
+	return result, nil
+}
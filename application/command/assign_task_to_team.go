@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// AssignTaskToTeamCommand represents a command to hand a task to a team
+type AssignTaskToTeamCommand struct {
+	TaskID     string
+	TeamID     string
+	AssignedBy string
+}
+
+// AssignTaskToTeamCommandHandler handles AssignTaskToTeamCommand
+type AssignTaskToTeamCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	teamRepository    domain.TeamRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewAssignTaskToTeamCommandHandler creates a new AssignTaskToTeamCommandHandler
+func NewAssignTaskToTeamCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	teamRepository domain.TeamRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *AssignTaskToTeamCommandHandler {
+	return &AssignTaskToTeamCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		teamRepository:    teamRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// AssignTaskToTeamResult represents the result of assigning a task to a team
+type AssignTaskToTeamResult struct {
+	Error error
+}
+
+// Handle handles the AssignTaskToTeamCommand
+func (h *AssignTaskToTeamCommandHandler) Handle(ctx context.Context, cmd AssignTaskToTeamCommand) (*AssignTaskToTeamResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	teamID, err := value.NewTeamID(cmd.TeamID)
+	if err != nil {
+		return nil, errs.Invalid("invalid team id: %w", err)
+	}
+
+	assignedByID, err := value.NewUserID(cmd.AssignedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid assigner id: %w", err)
+	}
+
+	// Get task and team
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	if _, err := h.teamRepository.GetByID(ctx, teamID); err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.AssignedBy, service.PermissionAssignTask, project, task); err != nil {
+		return nil, err
+	}
+
+	// Assign task
+	err = task.AssignToTeam(teamID, assignedByID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign task to team: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &AssignTaskToTeamResult{}, nil
+}
@@ -0,0 +1,61 @@
+package command
+
+import "time"
+
+// Session is an authenticated login of a user
+type Session struct {
+	UserID    string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// SessionStore issues and ends opaque session tokens
+type SessionStore interface {
+	// Issue creates a session for a user and returns its token
+	Issue(userID string) (string, Session, error)
+
+	// Revoke ends the session of a token, returning false if it was unknown
+	Revoke(token string) bool
+
+	// RevokeUser ends every session of a user and returns how many were ended
+	RevokeUser(userID string) int
+}
+
+// TokenPair is an access token with the refresh token that renews it
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	IssuedAt         time.Time
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+}
+
+// TokenIssuer issues access and refresh tokens
+type TokenIssuer interface {
+	// Issue creates an access and a refresh token for a user
+	Issue(userID string, roles []string) (TokenPair, error)
+
+	// Redeem uses up a valid refresh token and returns the ID of the user it was issued to
+	Redeem(refreshToken string) (string, error)
+}
+
+// AccessClaims are what a valid access token says about its bearer
+type AccessClaims struct {
+	UserID string
+	Roles  []string
+}
+
+// TokenVerifier verifies access tokens
+type TokenVerifier interface {
+	// Verify returns the claims of a valid access token
+	Verify(accessToken string) (AccessClaims, error)
+}
+
+// VerificationTokens generates and redeems single use email verification tokens
+type VerificationTokens interface {
+	// Generate creates a token that verifies the given email address of a user
+	Generate(userID, email string) (string, time.Time, error)
+
+	// Redeem uses up a token and returns the user and email address it verifies
+	Redeem(token string) (string, string, error)
+}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Authorizer checks the acting user of a command against the authorization policy
+type Authorizer struct {
+	userRepository domain.UserRepository
+	policy         service.AuthorizationPolicy
+}
+
+// NewAuthorizer creates a new Authorizer
+func NewAuthorizer(userRepository domain.UserRepository, policy service.AuthorizationPolicy) *Authorizer {
+	return &Authorizer{
+		userRepository: userRepository,
+		policy:         policy,
+	}
+}
+
+// Authorize loads the acting user and checks a permission on a project or one of its tasks
+func (a *Authorizer) Authorize(
+	ctx context.Context,
+	actorID string,
+	permission service.Permission,
+	project *aggregate.Project,
+	task *aggregate.Task,
+) error {
+	userID, err := value.NewUserID(actorID)
+	if err != nil {
+		return errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	actor, err := a.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return errs.PermissionDenied("permission denied: acting user not found")
+	}
+
+	return a.policy.Authorize(actor, permission, project, task)
+}
+
+// AuthorizeTeam checks that the acting user may manage a team: its lead or a global admin
+func (a *Authorizer) AuthorizeTeam(ctx context.Context, actorID string, team *aggregate.Team) error {
+	userID, err := value.NewUserID(actorID)
+	if err != nil {
+		return errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	actor, err := a.userRepository.GetByID(ctx, userID)
+	if err != nil || !actor.IsActive() {
+		return errs.PermissionDenied("permission denied: acting user not found")
+	}
+
+	if team.IsLead(actor.ID()) || actor.HasRole(value.GlobalRoleAdmin) {
+		return nil
+	}
+
+	return errs.PermissionDenied("permission denied: only the team lead can manage the team")
+}
+
+// AuthorizeAdmin checks that the acting user is a global admin
+func (a *Authorizer) AuthorizeAdmin(ctx context.Context, actorID string) error {
+	userID, err := value.NewUserID(actorID)
+	if err != nil {
+		return errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	actor, err := a.userRepository.GetByID(ctx, userID)
+	if err != nil || !actor.IsActive() {
+		return errs.PermissionDenied("permission denied: acting user not found")
+	}
+
+	if !actor.HasRole(value.GlobalRoleAdmin) {
+		return errs.PermissionDenied("permission denied: only an admin can do this")
+	}
+
+	return nil
+}
+
+// statusPermission returns the permission needed to move a task to a status
+func statusPermission(task *aggregate.Task, newStatus value.TaskStatus) service.Permission {
+	if newStatus == value.TaskStatusInProgress && task.Status() != value.TaskStatusInProgress {
+		return service.PermissionStartTask
+	}
+	return service.PermissionTransitionTask
+}
@@ -0,0 +1,111 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ChangeEmailCommand represents a command to change a user's email address
+type ChangeEmailCommand struct {
+	UserID      string
+	NewEmail    string
+	RequestedBy string
+}
+
+// ChangeEmailCommandHandler handles ChangeEmailCommand
+type ChangeEmailCommandHandler struct {
+	userRepository domain.UserRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewChangeEmailCommandHandler creates a new ChangeEmailCommandHandler
+func NewChangeEmailCommandHandler(
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *ChangeEmailCommandHandler {
+	return &ChangeEmailCommandHandler{
+		userRepository: userRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// ChangeEmailResult represents the result of changing an email address
+type ChangeEmailResult struct {
+	UserID string
+	Email  string
+	Error  error
+}
+
+// Handle handles the ChangeEmailCommand.
+// Users change their own address; admins may change anyone's. The new address must be verified again.
+func (h *ChangeEmailCommandHandler) Handle(ctx context.Context, cmd ChangeEmailCommand) (*ChangeEmailResult, error) {
+	// Parse IDs
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	// Check permission
+	if !requestedBy.Equals(userID) {
+		if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	newEmail := strings.TrimSpace(cmd.NewEmail)
+	if newEmail == user.Email() {
+		return nil, errs.Invalid("invalid email: the address is unchanged")
+	}
+
+	if _, err := h.userRepository.GetByEmail(ctx, newEmail); err == nil {
+		return nil, errs.Conflict("email is already registered")
+	}
+
+	// Change email
+	if err := user.UpdateEmail(newEmail); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	user.ClearDomainEvents()
+
+	return &ChangeEmailResult{
+		UserID: userID.Value(),
+		Email:  user.Email(),
+	}, nil
+}
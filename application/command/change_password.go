@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ChangePasswordCommand represents a command to change a user's password
+type ChangePasswordCommand struct {
+	UserID          string
+	CurrentPassword string
+	NewPassword     string
+}
+
+// ChangePasswordCommandHandler handles ChangePasswordCommand
+type ChangePasswordCommandHandler struct {
+	userRepository domain.UserRepository
+	passwordHasher service.PasswordHasher
+	sessionStore   SessionStore
+	eventPublisher event.EventPublisher
+}
+
+// NewChangePasswordCommandHandler creates a new ChangePasswordCommandHandler
+func NewChangePasswordCommandHandler(
+	userRepository domain.UserRepository,
+	passwordHasher service.PasswordHasher,
+	sessionStore SessionStore,
+	eventPublisher event.EventPublisher,
+) *ChangePasswordCommandHandler {
+	return &ChangePasswordCommandHandler{
+		userRepository: userRepository,
+		passwordHasher: passwordHasher,
+		sessionStore:   sessionStore,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// ChangePasswordResult represents the result of changing a password
+type ChangePasswordResult struct {
+	RevokedSessions int
+	Error           error
+}
+
+// Handle handles the ChangePasswordCommand
+func (h *ChangePasswordCommandHandler) Handle(ctx context.Context, cmd ChangePasswordCommand) (*ChangePasswordResult, error) {
+	// Parse user ID
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Check current password
+	current := user.PasswordHash()
+	if current == nil || !h.passwordHasher.Matches(*current, cmd.CurrentPassword) {
+		return nil, errs.Unauthenticated("current password is incorrect")
+	}
+
+	// Hash new password
+	hash, err := h.passwordHasher.Hash(cmd.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// Change password
+	if err := user.ChangePassword(hash); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	err = h.userRepository.Update(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Sessions opened with the old password must not outlive it
+	revoked := h.sessionStore.RevokeUser(userID.Value())
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	user.ClearDomainEvents()
+
+	return &ChangePasswordResult{
+		RevokedSessions: revoked,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ChangeProjectWorkflowCommand represents a command to move a project onto another workflow
+type ChangeProjectWorkflowCommand struct {
+	ProjectID   string
+	WorkflowID  string
+	RequestedBy string
+}
+
+// ChangeProjectWorkflowCommandHandler handles ChangeProjectWorkflowCommand
+type ChangeProjectWorkflowCommandHandler struct {
+	projectRepository  domain.ProjectRepository
+	workflowRepository domain.WorkflowRepository
+	eventPublisher     event.EventPublisher
+	authorizer         *Authorizer
+}
+
+// NewChangeProjectWorkflowCommandHandler creates a new ChangeProjectWorkflowCommandHandler
+func NewChangeProjectWorkflowCommandHandler(
+	projectRepository domain.ProjectRepository,
+	workflowRepository domain.WorkflowRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *ChangeProjectWorkflowCommandHandler {
+	return &ChangeProjectWorkflowCommandHandler{
+		projectRepository:  projectRepository,
+		workflowRepository: workflowRepository,
+		eventPublisher:     eventPublisher,
+		authorizer:         authorizer,
+	}
+}
+
+// ChangeProjectWorkflowResult represents the result of changing a project's workflow
+type ChangeProjectWorkflowResult struct {
+	Error error
+}
+
+// Handle handles the ChangeProjectWorkflowCommand
+func (h *ChangeProjectWorkflowCommandHandler) Handle(ctx context.Context, cmd ChangeProjectWorkflowCommand) (*ChangeProjectWorkflowResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	workflowID, err := value.NewWorkflowID(cmd.WorkflowID)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Get workflow
+	if _, err := h.workflowRepository.GetByID(ctx, workflowID); err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	// Change workflow
+	err = project.ChangeWorkflow(workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change workflow: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	project.ClearDomainEvents()
+
+	return &ChangeProjectWorkflowResult{}, nil
+}
@@ -0,0 +1,148 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ChangeTaskDeadlineCommand represents a command to set or move a task's deadline
+type ChangeTaskDeadlineCommand struct {
+	TaskID       string
+	Deadline     string // RFC 3339
+	BusinessDays int    // instead of Deadline, the business days from now the task is due in
+	Reason       string // required when postponing the deadline
+	RequestedBy  string
+}
+
+// ChangeTaskDeadlineCommandHandler handles ChangeTaskDeadlineCommand
+type ChangeTaskDeadlineCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	deadlineService   *service.DeadlineEnforcementService
+	holidayService    *service.HolidayCalendarService
+	authorizer        *Authorizer
+}
+
+// NewChangeTaskDeadlineCommandHandler creates a new ChangeTaskDeadlineCommandHandler
+func NewChangeTaskDeadlineCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	deadlineService *service.DeadlineEnforcementService,
+	holidayService *service.HolidayCalendarService,
+	authorizer *Authorizer,
+) *ChangeTaskDeadlineCommandHandler {
+	return &ChangeTaskDeadlineCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		deadlineService:   deadlineService,
+		holidayService:    holidayService,
+		authorizer:        authorizer,
+	}
+}
+
+// ChangeTaskDeadlineResult represents the result of changing a task's deadline
+type ChangeTaskDeadlineResult struct {
+	Deadline  time.Time
+	SlipCount int      // times the deadline has been postponed
+	Warnings  []string // set when the deadline falls while the assignee is out of office
+	Error     error
+}
+
+// Handle handles the ChangeTaskDeadlineCommand
+func (h *ChangeTaskDeadlineCommandHandler) Handle(ctx context.Context, cmd ChangeTaskDeadlineCommand) (*ChangeTaskDeadlineResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	if cmd.BusinessDays < 0 || (cmd.BusinessDays > 0) == (cmd.Deadline != "") {
+		return nil, errs.Invalid("invalid deadline: give either a deadline or a positive number of business days")
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Parse deadline, counting business days around the project's holidays
+	var dueDate time.Time
+	if cmd.BusinessDays > 0 {
+		calendar := h.holidayService.CalendarOf(ctx, task.ProjectID())
+		dueDate = h.holidayService.AddBusinessDays(calendar, time.Now(), cmd.BusinessDays)
+	} else {
+		dueDate, err = time.Parse(time.RFC3339, cmd.Deadline)
+		if err != nil {
+			return nil, errs.Invalid("invalid deadline format: %w", err)
+		}
+	}
+
+	deadline, err := value.NewDeadline(dueDate)
+	if err != nil {
+		return nil, errs.Invalid("invalid deadline: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionScheduleTask, project, task); err != nil {
+		return nil, err
+	}
+
+	changedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Change deadline
+	if err := h.deadlineService.SetDeadline(task, deadline, changedBy, cmd.Reason); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	warnings := make([]string, 0)
+	if outOfOfficeWarning := h.deadlineService.CheckAssigneeAvailability(ctx, task); outOfOfficeWarning != nil {
+		warnings = append(warnings, outOfOfficeWarning.Message())
+	}
+
+	return &ChangeTaskDeadlineResult{
+		Deadline:  deadline.Value(),
+		SlipCount: task.DeadlineSlipCount(),
+		Warnings:  warnings,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ChangeTeamLeadCommand represents a command to make a team member the team lead
+type ChangeTeamLeadCommand struct {
+	TeamID      string
+	UserID      string
+	RequestedBy string
+}
+
+// ChangeTeamLeadCommandHandler handles ChangeTeamLeadCommand
+type ChangeTeamLeadCommandHandler struct {
+	teamRepository domain.TeamRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewChangeTeamLeadCommandHandler creates a new ChangeTeamLeadCommandHandler
+func NewChangeTeamLeadCommandHandler(
+	teamRepository domain.TeamRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *ChangeTeamLeadCommandHandler {
+	return &ChangeTeamLeadCommandHandler{
+		teamRepository: teamRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// ChangeTeamLeadResult represents the result of changing the team lead
+type ChangeTeamLeadResult struct {
+	Error error
+}
+
+// Handle handles the ChangeTeamLeadCommand
+func (h *ChangeTeamLeadCommandHandler) Handle(ctx context.Context, cmd ChangeTeamLeadCommand) (*ChangeTeamLeadResult, error) {
+	// Parse IDs
+	teamID, err := value.NewTeamID(cmd.TeamID)
+	if err != nil {
+		return nil, errs.Invalid("invalid team id: %w", err)
+	}
+
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get team
+	team, err := h.teamRepository.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeTeam(ctx, cmd.RequestedBy, team); err != nil {
+		return nil, err
+	}
+
+	// Change lead
+	if err := team.ChangeLead(userID); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save team
+	err = h.teamRepository.Update(ctx, team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save team: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range team.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	team.ClearDomainEvents()
+
+	return &ChangeTeamLeadResult{}, nil
+}
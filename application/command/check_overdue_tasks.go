@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// CheckOverdueTasksCommand represents a command to sweep a project for overdue tasks
+type CheckOverdueTasksCommand struct {
+	ProjectID string
+}
+
+// CheckOverdueTasksCommandHandler handles CheckOverdueTasksCommand
+type CheckOverdueTasksCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	deadlineService   *service.DeadlineEnforcementService
+}
+
+// NewCheckOverdueTasksCommandHandler creates a new CheckOverdueTasksCommandHandler
+func NewCheckOverdueTasksCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	deadlineService *service.DeadlineEnforcementService,
+) *CheckOverdueTasksCommandHandler {
+	return &CheckOverdueTasksCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		deadlineService:   deadlineService,
+	}
+}
+
+// CheckOverdueTasksResult represents the result of sweeping a project for overdue tasks
+type CheckOverdueTasksResult struct {
+	OverdueTaskIDs []string
+	Error          error
+}
+
+// Handle handles the CheckOverdueTasksCommand.
+// An open overdue task is reported again, with its current days overdue, once the
+// reminder interval passed since it was last reported.
+func (h *CheckOverdueTasksCommandHandler) Handle(ctx context.Context, cmd CheckOverdueTasksCommand) (*CheckOverdueTasksResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	result := &CheckOverdueTasksResult{OverdueTaskIDs: make([]string, 0)}
+
+	// Archived projects are frozen, their deadlines no longer matter
+	if project.IsArchived() {
+		return result, nil
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		// Check deadline
+		raised := len(task.DomainEvents())
+		if err := h.deadlineService.CheckOverdueStatus(task); err != nil {
+			return nil, fmt.Errorf("failed to check deadline: %w", err)
+		}
+
+		if len(task.DomainEvents()) == raised {
+			continue
+		}
+
+		// Stop if the request was cancelled or timed out
+		if err := abortIfDone(ctx); err != nil {
+			return nil, err
+		}
+
+		// Save task
+		if err := h.taskRepository.Update(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+
+		// Publish domain events
+		for _, domainEvent := range task.DomainEvents() {
+			if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+				return nil, fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+		task.ClearDomainEvents()
+
+		result.OverdueTaskIDs = append(result.OverdueTaskIDs, task.ID().Value())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// CompareAndSetTaskStatusCommand represents a status change that only applies
+// when the task is still in the expected status
+type CompareAndSetTaskStatusCommand struct {
+	TaskID         string
+	ExpectedStatus string
+	NewStatus      string
+	Reason         string            // required when moving the task back to an earlier stage
+	Metadata       map[string]string // optional context recorded with the change
+	RequestedBy    string
+}
+
+// CompareAndSetTaskStatusCommandHandler handles CompareAndSetTaskStatusCommand
+type CompareAndSetTaskStatusCommandHandler struct {
+	taskRepository          domain.TaskRepository
+	projectRepository       domain.ProjectRepository
+	eventPublisher          event.EventPublisher
+	statusTransitionService *service.StatusTransitionService
+	authorizer              *Authorizer
+}
+
+// NewCompareAndSetTaskStatusCommandHandler creates a new CompareAndSetTaskStatusCommandHandler
+func NewCompareAndSetTaskStatusCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	statusTransitionService *service.StatusTransitionService,
+	authorizer *Authorizer,
+) *CompareAndSetTaskStatusCommandHandler {
+	return &CompareAndSetTaskStatusCommandHandler{
+		taskRepository:          taskRepository,
+		projectRepository:       projectRepository,
+		eventPublisher:          eventPublisher,
+		statusTransitionService: statusTransitionService,
+		authorizer:              authorizer,
+	}
+}
+
+// CompareAndSetTaskStatusResult represents the result of a compare-and-set.
+// When Applied is false, CurrentStatus holds the status the task actually has.
+type CompareAndSetTaskStatusResult struct {
+	Applied       bool
+	CurrentStatus string
+	Error         error
+}
+
+// Handle handles the CompareAndSetTaskStatusCommand
+func (h *CompareAndSetTaskStatusCommandHandler) Handle(ctx context.Context, cmd CompareAndSetTaskStatusCommand) (*CompareAndSetTaskStatusResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Resolve statuses in the task's workflow
+	expectedStatus, err := h.statusTransitionService.ResolveStatus(ctx, task, cmd.ExpectedStatus)
+	if err != nil {
+		return nil, errs.Invalid("invalid expected status: %w", err)
+	}
+
+	newStatus, err := h.statusTransitionService.ResolveStatus(ctx, task, cmd.NewStatus)
+	if err != nil {
+		return nil, errs.Invalid("invalid status: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, statusPermission(task, h.statusTransitionService.StageOf(ctx, task, newStatus)), project, task); err != nil {
+		return nil, err
+	}
+
+	// Compare
+	if current := h.statusTransitionService.ScopedStatusOf(ctx, task); !current.Equals(expectedStatus) {
+		return &CompareAndSetTaskStatusResult{
+			Applied:       false,
+			CurrentStatus: current.Name(),
+		}, nil
+	}
+
+	// Transition task status, running the workflow's actions as the requester
+	actorID, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+	err = h.statusTransitionService.TransitionTaskBy(ctx, task, newStatus, cmd.Reason, cmd.Metadata, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task; the repository refuses it if another change was stored since it was read
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &CompareAndSetTaskStatusResult{
+		Applied:       true,
+		CurrentStatus: task.Status().Value(),
+	}, nil
+}
@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// CompleteSprintCommand represents a command to close an active sprint
+type CompleteSprintCommand struct {
+	SprintID    string
+	RequestedBy string
+}
+
+// CompleteSprintCommandHandler handles CompleteSprintCommand
+type CompleteSprintCommandHandler struct {
+	sprintRepository  domain.SprintRepository
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewCompleteSprintCommandHandler creates a new CompleteSprintCommandHandler
+func NewCompleteSprintCommandHandler(
+	sprintRepository domain.SprintRepository,
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *CompleteSprintCommandHandler {
+	return &CompleteSprintCommandHandler{
+		sprintRepository:  sprintRepository,
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// CompleteSprintResult represents the result of completing a sprint
+type CompleteSprintResult struct {
+	CarriedOverTaskIDs []string // unfinished tasks returned to the backlog
+	Error              error
+}
+
+// Handle handles the CompleteSprintCommand
+func (h *CompleteSprintCommandHandler) Handle(ctx context.Context, cmd CompleteSprintCommand) (*CompleteSprintResult, error) {
+	// Parse IDs
+	sprintID, err := value.NewSprintID(cmd.SprintID)
+	if err != nil {
+		return nil, errs.Invalid("invalid sprint id: %w", err)
+	}
+
+	// Get sprint
+	sprint, err := h.sprintRepository.GetByID(ctx, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("sprint not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, sprint.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionPlanSprints, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Collect unfinished tasks; tasks deleted meanwhile are removed from the sprint
+	unfinished := make([]value.TaskID, 0)
+	carriedOverIDs := make([]string, 0)
+	for _, taskID := range sprint.TaskIDs() {
+		task, err := h.taskRepository.GetByID(ctx, taskID)
+		if err != nil {
+			if removeErr := sprint.RemoveTask(taskID); removeErr != nil {
+				return nil, fmt.Errorf("failed to remove missing task: %w", removeErr)
+			}
+			continue
+		}
+
+		if task.Status() != value.TaskStatusCompleted && task.Status() != value.TaskStatusCancelled {
+			unfinished = append(unfinished, taskID)
+			carriedOverIDs = append(carriedOverIDs, taskID.Value())
+		}
+	}
+
+	// Complete sprint
+	err = sprint.Complete(unfinished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete sprint: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save sprint
+	err = h.sprintRepository.Update(ctx, sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save sprint: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range sprint.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	sprint.ClearDomainEvents()
+
+	return &CompleteSprintResult{
+		CarriedOverTaskIDs: carriedOverIDs,
+	}, nil
+}
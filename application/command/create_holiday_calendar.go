@@ -0,0 +1,132 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// HolidayInput is one holiday of a holiday calendar command, with the fields its rule uses
+type HolidayInput struct {
+	Name    string
+	Rule    string // DATE, ANNUAL or NTH_WEEKDAY
+	Date    string // DATE only, e.g. 2025-12-24
+	Month   int    // ANNUAL and NTH_WEEKDAY
+	Day     int    // ANNUAL only
+	Weekday string // NTH_WEEKDAY only, e.g. THURSDAY
+	Nth     int    // NTH_WEEKDAY only, 1 to 4 or -1 for the last
+}
+
+// CreateHolidayCalendarCommand represents a command to set up the holidays an organization
+// observes in a region
+type CreateHolidayCalendarCommand struct {
+	OrganizationID string
+	Region         string
+	Name           string
+	Holidays       []HolidayInput
+	RequestedBy    string
+}
+
+// CreateHolidayCalendarCommandHandler handles CreateHolidayCalendarCommand
+type CreateHolidayCalendarCommandHandler struct {
+	calendarRepository     domain.HolidayCalendarRepository
+	organizationRepository domain.OrganizationRepository
+	eventPublisher         event.EventPublisher
+	authorizer             *Authorizer
+}
+
+// NewCreateHolidayCalendarCommandHandler creates a new CreateHolidayCalendarCommandHandler
+func NewCreateHolidayCalendarCommandHandler(
+	calendarRepository domain.HolidayCalendarRepository,
+	organizationRepository domain.OrganizationRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *CreateHolidayCalendarCommandHandler {
+	return &CreateHolidayCalendarCommandHandler{
+		calendarRepository:     calendarRepository,
+		organizationRepository: organizationRepository,
+		eventPublisher:         eventPublisher,
+		authorizer:             authorizer,
+	}
+}
+
+// CreateHolidayCalendarResult represents the result of creating a holiday calendar
+type CreateHolidayCalendarResult struct {
+	CalendarID string
+	Error      error
+}
+
+// Handle handles the CreateHolidayCalendarCommand
+func (h *CreateHolidayCalendarCommandHandler) Handle(ctx context.Context, cmd CreateHolidayCalendarCommand) (*CreateHolidayCalendarResult, error) {
+	// Parse IDs
+	organizationID, err := value.NewOrganizationID(cmd.OrganizationID)
+	if err != nil {
+		return nil, errs.Invalid("invalid organization id: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Parse holidays
+	holidays, err := parseHolidays(cmd.Holidays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate organization exists
+	if _, err := h.organizationRepository.GetByID(ctx, organizationID); err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+
+	// Create holiday calendar aggregate
+	calendarID := value.GenerateHolidayCalendarID()
+	calendar, err := aggregate.NewHolidayCalendar(calendarID, organizationID, cmd.Region, cmd.Name, holidays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create holiday calendar: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save holiday calendar
+	err = h.calendarRepository.Save(ctx, calendar)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save holiday calendar: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range calendar.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	calendar.ClearDomainEvents()
+
+	return &CreateHolidayCalendarResult{
+		CalendarID: calendarID.Value(),
+	}, nil
+}
+
+// parseHolidays turns holiday inputs into holidays
+func parseHolidays(inputs []HolidayInput) ([]value.Holiday, error) {
+	holidays := make([]value.Holiday, 0, len(inputs))
+	for i, input := range inputs {
+		holiday, err := value.ParseHoliday(input.Name, input.Rule, input.Date, input.Month, input.Day, input.Weekday, input.Nth)
+		if err != nil {
+			return nil, fmt.Errorf("holiday %d: %w", i+1, err)
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, nil
+}
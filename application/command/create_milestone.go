@@ -0,0 +1,156 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// CreateMilestoneCommand represents a command to add a milestone to a project
+type CreateMilestoneCommand struct {
+	ProjectID   string
+	Name        string
+	Description string
+	DueDate     string // RFC3339 format
+	TaskIDs     []string
+	RequestedBy string
+}
+
+// CreateMilestoneCommandHandler handles CreateMilestoneCommand
+type CreateMilestoneCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	progressService   *service.MilestoneProgressService
+	authorizer        *Authorizer
+}
+
+// NewCreateMilestoneCommandHandler creates a new CreateMilestoneCommandHandler
+func NewCreateMilestoneCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	progressService *service.MilestoneProgressService,
+	authorizer *Authorizer,
+) *CreateMilestoneCommandHandler {
+	return &CreateMilestoneCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		progressService:   progressService,
+		authorizer:        authorizer,
+	}
+}
+
+// CreateMilestoneResult represents the result of creating a milestone
+type CreateMilestoneResult struct {
+	MilestoneID string
+	Error       error
+}
+
+// Handle handles the CreateMilestoneCommand
+func (h *CreateMilestoneCommandHandler) Handle(ctx context.Context, cmd CreateMilestoneCommand) (*CreateMilestoneResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse due date
+	dueDate, err := time.Parse(time.RFC3339, cmd.DueDate)
+	if err != nil {
+		return nil, errs.Invalid("invalid due date format: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Milestone tasks must belong to the project
+	taskIDs, err := parseMilestoneTaskIDs(ctx, h.taskRepository, projectID, cmd.TaskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add milestone
+	milestone, err := project.AddMilestone(cmd.Name, cmd.Description, dueDate, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add milestone: %w", err)
+	}
+
+	// A milestone whose tasks are already done is reached right away
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	if _, err := h.progressService.EvaluateMilestones(project, tasks, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to evaluate milestones: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &CreateMilestoneResult{
+		MilestoneID: milestone.ID(),
+	}, nil
+}
+
+// parseMilestoneTaskIDs parses task IDs and checks that each task belongs to the project
+func parseMilestoneTaskIDs(
+	ctx context.Context,
+	taskRepository domain.TaskRepository,
+	projectID value.ProjectID,
+	rawIDs []string,
+) ([]value.TaskID, error) {
+	taskIDs := make([]value.TaskID, 0, len(rawIDs))
+	for _, rawID := range rawIDs {
+		taskID, err := value.NewTaskID(rawID)
+		if err != nil {
+			return nil, errs.Invalid("invalid task id: %w", err)
+		}
+
+		task, err := taskRepository.GetByID(ctx, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("task not found: %w", err)
+		}
+
+		if !task.ProjectID().Equals(projectID) {
+			return nil, errs.Invalid("task %s does not belong to project", rawID)
+		}
+
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	return taskIDs, nil
+}
@@ -0,0 +1,138 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// OrganizationQuotaInput holds the limits of an organization, zero meaning unlimited
+type OrganizationQuotaInput struct {
+	MaxProjects        int
+	MaxTasks           int
+	MaxAttachmentBytes int64
+	APICallsPerMinute  int
+}
+
+// CreateOrganizationCommand represents a command to set up an organization with its members and quota
+type CreateOrganizationCommand struct {
+	Name        string
+	MemberIDs   []string
+	Quota       OrganizationQuotaInput
+	RequestedBy string
+}
+
+// CreateOrganizationCommandHandler handles CreateOrganizationCommand
+type CreateOrganizationCommandHandler struct {
+	organizationRepository domain.OrganizationRepository
+	userRepository         domain.UserRepository
+	eventPublisher         event.EventPublisher
+	authorizer             *Authorizer
+}
+
+// NewCreateOrganizationCommandHandler creates a new CreateOrganizationCommandHandler
+func NewCreateOrganizationCommandHandler(
+	organizationRepository domain.OrganizationRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *CreateOrganizationCommandHandler {
+	return &CreateOrganizationCommandHandler{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+		eventPublisher:         eventPublisher,
+		authorizer:             authorizer,
+	}
+}
+
+// CreateOrganizationResult represents the result of creating an organization
+type CreateOrganizationResult struct {
+	OrganizationID string
+	Error          error
+}
+
+// Handle handles the CreateOrganizationCommand. A user belongs to one organization at most.
+func (h *CreateOrganizationCommandHandler) Handle(ctx context.Context, cmd CreateOrganizationCommand) (*CreateOrganizationResult, error) {
+	// Parse IDs
+	memberIDs := make([]value.UserID, 0, len(cmd.MemberIDs))
+	for _, id := range cmd.MemberIDs {
+		memberID, err := value.NewUserID(id)
+		if err != nil {
+			return nil, errs.Invalid("invalid member id: %w", err)
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	quota, err := value.NewQuota(cmd.Quota.MaxProjects, cmd.Quota.MaxTasks, cmd.Quota.MaxAttachmentBytes, cmd.Quota.APICallsPerMinute)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Verify the members exist and are free to join
+	for _, memberID := range memberIDs {
+		if err := verifyOrganizationCandidate(ctx, h.userRepository, h.organizationRepository, memberID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create organization aggregate
+	organizationID := value.GenerateOrganizationID()
+	organization, err := aggregate.NewOrganization(organizationID, cmd.Name, memberIDs)
+	if err != nil {
+		return nil, errs.Invalid("invalid organization: %w", err)
+	}
+	organization.SetQuota(quota)
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save organization
+	err = h.organizationRepository.Save(ctx, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save organization: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range organization.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	organization.ClearDomainEvents()
+
+	return &CreateOrganizationResult{
+		OrganizationID: organizationID.Value(),
+	}, nil
+}
+
+// verifyOrganizationCandidate checks that a user exists and is not in an organization yet
+func verifyOrganizationCandidate(
+	ctx context.Context,
+	userRepository domain.UserRepository,
+	organizationRepository domain.OrganizationRepository,
+	userID value.UserID,
+) error {
+	if _, err := userRepository.GetByID(ctx, userID); err != nil {
+		return errs.NotFound("organization member not found: %s", userID.Value())
+	}
+
+	if _, err := organizationRepository.GetByMemberID(ctx, userID); err == nil {
+		return errs.Conflict("user %s is already an organization member", userID.Value())
+	}
+
+	return nil
+}
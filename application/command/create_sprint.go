@@ -0,0 +1,119 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// CreateSprintCommand represents a command to plan a sprint for a project
+type CreateSprintCommand struct {
+	ProjectID   string
+	Name        string
+	Goal        string
+	StartDate   string // RFC3339
+	EndDate     string // RFC3339
+	RequestedBy string
+}
+
+// CreateSprintCommandHandler handles CreateSprintCommand
+type CreateSprintCommandHandler struct {
+	sprintRepository  domain.SprintRepository
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewCreateSprintCommandHandler creates a new CreateSprintCommandHandler
+func NewCreateSprintCommandHandler(
+	sprintRepository domain.SprintRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *CreateSprintCommandHandler {
+	return &CreateSprintCommandHandler{
+		sprintRepository:  sprintRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// CreateSprintResult represents the result of creating a sprint
+type CreateSprintResult struct {
+	SprintID string
+	Error    error
+}
+
+// Handle handles the CreateSprintCommand
+func (h *CreateSprintCommandHandler) Handle(ctx context.Context, cmd CreateSprintCommand) (*CreateSprintResult, error) {
+	// Validate project exists
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionPlanSprints, project, nil); err != nil {
+		return nil, err
+	}
+
+	if project.IsArchived() {
+		return nil, errs.Conflict("cannot plan sprint for archived project")
+	}
+
+	// Parse dates
+	startDate, err := time.Parse(time.RFC3339, cmd.StartDate)
+	if err != nil {
+		return nil, errs.Invalid("invalid start date format: %w", err)
+	}
+
+	endDate, err := time.Parse(time.RFC3339, cmd.EndDate)
+	if err != nil {
+		return nil, errs.Invalid("invalid end date format: %w", err)
+	}
+
+	// Create sprint aggregate
+	sprintID := value.GenerateSprintID()
+	sprint, err := aggregate.NewSprint(sprintID, projectID, cmd.Name, cmd.Goal, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sprint: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save sprint
+	err = h.sprintRepository.Save(ctx, sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save sprint: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range sprint.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	sprint.ClearDomainEvents()
+
+	return &CreateSprintResult{
+		SprintID: sprintID.Value(),
+	}, nil
+}
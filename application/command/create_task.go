@@ -1,11 +1,14 @@
 package command
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/miladev95/ddd-task/domain"
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/service"
 	"github.com/miladev95/ddd-task/domain/value"
@@ -13,83 +16,152 @@ import (
 
 // CreateTaskCommand represents a command to create a task
 type CreateTaskCommand struct {
-	ProjectID   string
-	Title       string
-	Description string
-	Priority    string
-	AssigneeID  string
-	Deadline    string
-	CreatedBy   string
+	ProjectID      string
+	Title          string
+	Description    string
+	Priority       string
+	AssigneeID     string
+	Deadline       string
+	EstimatedHours float64
+	CreatedBy      string
+	EnforceUnique  bool // reject instead of warn when a similar task exists
 }
 
 // CreateTaskCommandHandler handles CreateTaskCommand
 type CreateTaskCommandHandler struct {
-	taskRepository       domain.TaskRepository
-	projectRepository    domain.ProjectRepository
-	userRepository       domain.UserRepository
-	workflowRepository   domain.WorkflowRepository
-	eventPublisher       event.EventPublisher
-	assignmentService    *service.TaskAssignmentService
-	deadlineService      *service.DeadlineEnforcementService
+	unitOfWork          domain.UnitOfWorkFactory
+	eventPublisher      event.EventPublisher
+	authorizer          *Authorizer
+	assignmentService   *service.TaskAssignmentService
+	deadlineService     *service.DeadlineEnforcementService
+	duplicateService    *service.DuplicateDetectionService
+	quotaService        *service.QuotaEnforcementService
+	transactionAttempts int           // tries to save a task together with its project
+	transactionBackoff  time.Duration // first wait between tries, doubling after each
 }
 
-// NewCreateTaskCommandHandler creates a new CreateTaskCommandHandler
+// DefaultTransactionAttempts is how often creating a task tries the transaction saving it
+// and its project before giving up, and DefaultTransactionBackoff the first wait between
+// tries, doubling after each
+const (
+	DefaultTransactionAttempts = 3
+	DefaultTransactionBackoff  = 50 * time.Millisecond
+)
+
+// NewCreateTaskCommandHandler creates a new CreateTaskCommandHandler. The task, its project
+// and their events are saved in one transaction of a unit of work from the factory;
+// eventPublisher reports a creation that failed.
 func NewCreateTaskCommandHandler(
-	taskRepository domain.TaskRepository,
-	projectRepository domain.ProjectRepository,
-	userRepository domain.UserRepository,
-	workflowRepository domain.WorkflowRepository,
+	unitOfWork domain.UnitOfWorkFactory,
 	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
 	assignmentService *service.TaskAssignmentService,
 	deadlineService *service.DeadlineEnforcementService,
+	duplicateService *service.DuplicateDetectionService,
+	quotaService *service.QuotaEnforcementService,
 ) *CreateTaskCommandHandler {
 	return &CreateTaskCommandHandler{
-		taskRepository:       taskRepository,
-		projectRepository:    projectRepository,
-		userRepository:       userRepository,
-		workflowRepository:   workflowRepository,
-		eventPublisher:       eventPublisher,
-		assignmentService:    assignmentService,
-		deadlineService:      deadlineService,
+		unitOfWork:          unitOfWork,
+		eventPublisher:      eventPublisher,
+		authorizer:          authorizer,
+		assignmentService:   assignmentService,
+		deadlineService:     deadlineService,
+		duplicateService:    duplicateService,
+		quotaService:        quotaService,
+		transactionAttempts: DefaultTransactionAttempts,
+		transactionBackoff:  DefaultTransactionBackoff,
+	}
+}
+
+// SetTransactionRetry sets how often and with what first backoff a failed transaction is retried
+func (h *CreateTaskCommandHandler) SetTransactionRetry(attempts int, backoff time.Duration) {
+	if attempts < 1 {
+		attempts = 1
 	}
+	h.transactionAttempts = attempts
+	h.transactionBackoff = backoff
 }
 
 // CreateTaskResult represents the result of creating a task
 type CreateTaskResult struct {
-	TaskID string
-	Error  error
+	TaskID             string
+	PossibleDuplicates []string // IDs of existing tasks with similar titles
+	Warnings           []string // set when the assignee is now over their open task limit or away on the deadline
+	Error              error
 }
 
 // Handle handles the CreateTaskCommand
-func (h *CreateTaskCommandHandler) Handle(cmd CreateTaskCommand) (*CreateTaskResult, error) {
+func (h *CreateTaskCommandHandler) Handle(ctx context.Context, cmd CreateTaskCommand) (*CreateTaskResult, error) {
+	uow := h.unitOfWork()
+
 	// Validate project exists
 	projectID, err := value.NewProjectID(cmd.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid project id: %w", err)
+		return nil, errs.Invalid("invalid project id: %w", err)
 	}
 
-	project, err := h.projectRepository.GetByID(projectID)
+	project, err := uow.GetProjectRepository().GetByID(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("project not found: %w", err)
 	}
 
-	// Validate priority
-	priority, err := value.NewPriority(cmd.Priority)
-	if err != nil {
-		return nil, fmt.Errorf("invalid priority: %w", err)
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.CreatedBy, service.PermissionCreateTask, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Archived projects accept no new work
+	if project.IsArchived() {
+		return nil, errs.Conflict("project is archived: cannot create tasks")
+	}
+
+	// Verify the project's organization may hold another task
+	if err := h.quotaService.CheckTaskQuota(ctx, project, 1); err != nil {
+		return nil, err
+	}
+
+	settings := project.Settings()
+
+	// Validate priority, falling back to the project default
+	priority := settings.DefaultPriority()
+	if cmd.Priority != "" {
+		priority, err = project.PriorityScheme().Parse(cmd.Priority)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if settings.RequireDeadlineOnCreate() && cmd.Deadline == "" {
+		return nil, errs.Invalid("deadline is required by project settings")
 	}
 
 	// Validate created by user
 	createdByID, err := value.NewUserID(cmd.CreatedBy)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user id: %w", err)
+		return nil, errs.Invalid("invalid user id: %w", err)
 	}
 
-	_, err = h.userRepository.GetByID(createdByID)
+	_, err = uow.GetUserRepository().GetByID(ctx, createdByID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
+	// Check for similar tasks in the project
+	existingTasks, err := uow.GetTaskRepository().GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	duplicates := h.duplicateService.FindSimilar(cmd.Title, existingTasks, service.DefaultDuplicateThreshold)
+	duplicateIDs := make([]string, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		duplicateIDs = append(duplicateIDs, duplicate.Task.ID().Value())
+	}
+
+	if cmd.EnforceUnique && len(duplicateIDs) > 0 {
+		return nil, errs.Conflict("duplicate task detected: similar to %s", strings.Join(duplicateIDs, ", "))
+	}
+
 	// Generate new task ID
 	taskID := value.GenerateTaskID()
 
@@ -106,66 +178,186 @@ func (h *CreateTaskCommandHandler) Handle(cmd CreateTaskCommand) (*CreateTaskRes
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
+	// Pin the task to the current version of the project's workflow
+	workflow, err := uow.GetWorkflowRepository().GetByID(ctx, project.WorkflowID())
+	if err != nil {
+		workflow, err = uow.GetWorkflowRepository().GetByID(ctx, value.DefaultWorkflowID)
+	}
+	if err == nil {
+		task.PinWorkflowVersion(workflow.WorkflowVersion())
+	}
+
 	// Assign task if assignee provided
+	var capacityWarning *service.CapacityWarning
 	if cmd.AssigneeID != "" {
 		assigneeID, err := value.NewUserID(cmd.AssigneeID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid assignee id: %w", err)
+			return nil, errs.Invalid("invalid assignee id: %w", err)
 		}
 
-		err = h.assignmentService.AssignTask(task, assigneeID, createdByID)
+		capacityWarning, err = h.assignmentService.AssignTask(ctx, task, assigneeID, createdByID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to assign task: %w", err)
 		}
+	} else if settings.HasDefaultAssignee() {
+		capacityWarning, err = h.assignmentService.AssignTask(ctx, task, *settings.DefaultAssigneeID(), createdByID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to assign task to default assignee: %w", err)
+		}
 	}
 
 	// Set deadline if provided
 	if cmd.Deadline != "" {
 		dueDate, err := time.Parse(time.RFC3339, cmd.Deadline)
 		if err != nil {
-			return nil, fmt.Errorf("invalid deadline format: %w", err)
+			return nil, errs.Invalid("invalid deadline format: %w", err)
 		}
 
 		deadline, err := value.NewDeadline(dueDate)
 		if err != nil {
-			return nil, fmt.Errorf("invalid deadline: %w", err)
+			return nil, errs.Invalid("invalid deadline: %w", err)
 		}
 
-		err = h.deadlineService.SetDeadline(task, deadline)
+		err = h.deadlineService.SetDeadline(task, deadline, createdByID, "")
 		if err != nil {
 			return nil, fmt.Errorf("failed to set deadline: %w", err)
 		}
 	}
 
+	// Set estimate if provided
+	if cmd.EstimatedHours != 0 {
+		err = task.SetEstimate(cmd.EstimatedHours)
+		if err != nil {
+			return nil, errs.Invalid("invalid estimate: %w", err)
+		}
+	}
+
 	// Add task to project
 	err = project.AddTask(taskID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add task to project: %w", err)
 	}
 
-	// Save task
-	err = h.taskRepository.Save(task)
-	if err != nil {
-		return nil, fmt.Errorf("failed to save task: %w", err)
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
 	}
 
-	// Update project
-	err = h.projectRepository.Update(project)
+	// Save the task, update its project and write their events in one transaction
+	err = h.saveWithRetry(ctx, uow, task, project)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update project: %w", err)
+		return nil, h.reportFailure(ctx, task, project, err)
 	}
 
-	// Publish domain events
-	for _, domainEvent := range task.DomainEvents() {
-		err = h.eventPublisher.Publish(domainEvent)
-		if err != nil {
-			return nil, fmt.Errorf("failed to publish event: %w", err)
+	task.ClearDomainEvents()
+	project.ClearDomainEvents()
+
+	warnings := make([]string, 0)
+	if capacityWarning != nil {
+		warnings = append(warnings, capacityWarning.Message())
+	}
+	if outOfOfficeWarning := h.deadlineService.CheckAssigneeAvailability(ctx, task); outOfOfficeWarning != nil {
+		warnings = append(warnings, outOfOfficeWarning.Message())
+	}
+
+	return &CreateTaskResult{
+		TaskID:             taskID.Value(),
+		PossibleDuplicates: duplicateIDs,
+		Warnings:           warnings,
+	}, nil
+}
+
+// transactionError is a failed transaction saving a new task, with the step that failed
+// and whether rolling it back failed too
+type transactionError struct {
+	step        string
+	cause       error
+	rollbackErr error
+}
+
+func (e *transactionError) Error() string {
+	if e.rollbackErr != nil {
+		return fmt.Sprintf("failed to %s: %v (and %v)", e.step, e.cause, e.rollbackErr)
+	}
+	return fmt.Sprintf("failed to %s: %v", e.step, e.cause)
+}
+
+func (e *transactionError) Unwrap() error {
+	return e.cause
+}
+
+// saveWithRetry runs the transaction saving a new task, retrying with a doubling backoff
+// until the attempts run out or the request is cancelled
+func (h *CreateTaskCommandHandler) saveWithRetry(ctx context.Context, uow domain.UnitOfWork, task *aggregate.Task, project *aggregate.Project) error {
+	backoff := h.transactionBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = h.save(ctx, uow, task, project); err == nil || attempt >= h.transactionAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
 		}
+		backoff *= 2
 	}
+}
 
+// save saves the task, updates its project and records their events in one transaction,
+// rolling it back when a step fails
+func (h *CreateTaskCommandHandler) save(ctx context.Context, uow domain.UnitOfWork, task *aggregate.Task, project *aggregate.Project) error {
+	if err := uow.BeginTransaction(ctx); err != nil {
+		return &transactionError{step: "begin transaction", cause: err}
+	}
+
+	fail := func(step string, cause error) error {
+		return &transactionError{step: step, cause: cause, rollbackErr: uow.Rollback()}
+	}
+
+	if err := uow.GetTaskRepository().Save(ctx, task); err != nil {
+		return fail("save task", err)
+	}
+	if err := uow.GetProjectRepository().Update(ctx, project); err != nil {
+		return fail("update project", err)
+	}
+
+	uow.RecordEvents(task.DomainEvents()...)
+	uow.RecordEvents(project.DomainEvents()...)
+
+	// A failed commit has already rolled the transaction back
+	if err := uow.Commit(); err != nil {
+		return &transactionError{step: "commit task creation", cause: err}
+	}
+	return nil
+}
+
+// reportFailure reverts the project to before the task was added, so the caller's copy matches
+// what was rolled back, and reports the failure with a TaskCreationFailedEvent
+func (h *CreateTaskCommandHandler) reportFailure(ctx context.Context, task *aggregate.Task, project *aggregate.Project, err error) error {
+	// Nothing of the creation was written, so its events are dropped with it
 	task.ClearDomainEvents()
+	project.RemoveTask(task.ID())
+	project.ClearDomainEvents()
 
-	return &CreateTaskResult{
-		TaskID: taskID.Value(),
-	}, nil
-}
\ No newline at end of file
+	reason := err.Error()
+	rolledBack := true
+	if failed, ok := err.(*transactionError); ok {
+		reason = failed.cause.Error()
+		rolledBack = failed.rollbackErr == nil
+	}
+
+	failedEvent := event.NewTaskCreationFailedEvent(
+		task.ID().Value(),
+		project.ID().Value(),
+		task.Title(),
+		task.CreatedBy().Value(),
+		reason,
+		rolledBack,
+	)
+	// The creation failure is what the caller sees, even when the event cannot be published
+	_ = h.eventPublisher.Publish(ctx, failedEvent)
+
+	return err
+}
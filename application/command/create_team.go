@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// CreateTeamCommand represents a command to form a team
+type CreateTeamCommand struct {
+	Name        string
+	LeadID      string // optional, defaults to the requesting user
+	MemberIDs   []string
+	RequestedBy string
+}
+
+// CreateTeamCommandHandler handles CreateTeamCommand
+type CreateTeamCommandHandler struct {
+	teamRepository domain.TeamRepository
+	userRepository domain.UserRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewCreateTeamCommandHandler creates a new CreateTeamCommandHandler
+func NewCreateTeamCommandHandler(
+	teamRepository domain.TeamRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *CreateTeamCommandHandler {
+	return &CreateTeamCommandHandler{
+		teamRepository: teamRepository,
+		userRepository: userRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// CreateTeamResult represents the result of creating a team
+type CreateTeamResult struct {
+	TeamID string
+	Error  error
+}
+
+// Handle handles the CreateTeamCommand
+func (h *CreateTeamCommandHandler) Handle(ctx context.Context, cmd CreateTeamCommand) (*CreateTeamResult, error) {
+	// Parse IDs
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	leadID := requestedBy
+	if cmd.LeadID != "" {
+		leadID, err = value.NewUserID(cmd.LeadID)
+		if err != nil {
+			return nil, errs.Invalid("invalid lead id: %w", err)
+		}
+	}
+
+	memberIDs := make([]value.UserID, 0, len(cmd.MemberIDs))
+	for _, id := range cmd.MemberIDs {
+		memberID, err := value.NewUserID(id)
+		if err != nil {
+			return nil, errs.Invalid("invalid member id: %w", err)
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	// Check permission: anyone may form a team they lead, naming another lead is an admin's call
+	if !leadID.Equals(requestedBy) {
+		if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// Verify the lead and members exist
+	for _, userID := range append([]value.UserID{leadID}, memberIDs...) {
+		if _, err := h.userRepository.GetByID(ctx, userID); err != nil {
+			return nil, errs.NotFound("team member not found: %s", userID.Value())
+		}
+	}
+
+	// Create team aggregate
+	teamID := value.GenerateTeamID()
+	team, err := aggregate.NewTeam(teamID, cmd.Name, leadID, memberIDs)
+	if err != nil {
+		return nil, errs.Invalid("invalid team: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save team
+	err = h.teamRepository.Save(ctx, team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save team: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range team.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	team.ClearDomainEvents()
+
+	return &CreateTeamResult{
+		TeamID: teamID.Value(),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// CreateWidgetCommand represents a command to create a dashboard widget
+type CreateWidgetCommand struct {
+	OwnerID    string
+	Title      string
+	Type       string
+	Parameters map[string]string
+	Column     int
+	Row        int
+	Width      int
+	Height     int
+}
+
+// CreateWidgetCommandHandler handles CreateWidgetCommand
+type CreateWidgetCommandHandler struct {
+	widgetRepository domain.WidgetRepository
+	userRepository   domain.UserRepository
+}
+
+// NewCreateWidgetCommandHandler creates a new CreateWidgetCommandHandler
+func NewCreateWidgetCommandHandler(
+	widgetRepository domain.WidgetRepository,
+	userRepository domain.UserRepository,
+) *CreateWidgetCommandHandler {
+	return &CreateWidgetCommandHandler{
+		widgetRepository: widgetRepository,
+		userRepository:   userRepository,
+	}
+}
+
+// CreateWidgetResult represents the result of creating a widget
+type CreateWidgetResult struct {
+	WidgetID string
+	Error    error
+}
+
+// Handle handles the CreateWidgetCommand
+func (h *CreateWidgetCommandHandler) Handle(ctx context.Context, cmd CreateWidgetCommand) (*CreateWidgetResult, error) {
+	// Validate owner exists
+	ownerID, err := value.NewUserID(cmd.OwnerID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	_, err = h.userRepository.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Parse widget type and layout
+	widgetType, err := value.NewWidgetType(cmd.Type)
+	if err != nil {
+		return nil, errs.Invalid("invalid widget type: %w", err)
+	}
+
+	layout, err := value.NewWidgetLayout(cmd.Column, cmd.Row, cmd.Width, cmd.Height)
+	if err != nil {
+		return nil, errs.Invalid("invalid layout: %w", err)
+	}
+
+	// Create widget aggregate
+	widgetID := value.GenerateWidgetID()
+	widget, err := aggregate.NewWidget(widgetID, ownerID, cmd.Title, widgetType, cmd.Parameters, layout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create widget: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save widget
+	err = h.widgetRepository.Save(ctx, widget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save widget: %w", err)
+	}
+
+	return &CreateWidgetResult{
+		WidgetID: widgetID.Value(),
+	}, nil
+}
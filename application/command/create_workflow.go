@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// WorkflowStatusInput is one status of a new workflow
+type WorkflowStatusInput struct {
+	Name        string
+	Description string
+	Order       int
+	IsFinal     bool
+	WIPLimit    int // 0 means no limit
+}
+
+// WorkflowTransitionInput is a move a new workflow allows
+type WorkflowTransitionInput struct {
+	From string
+	To   string
+}
+
+// CreateWorkflowCommand represents a command to create a workflow
+type CreateWorkflowCommand struct {
+	Name        string
+	Description string
+	Statuses    []WorkflowStatusInput
+	Transitions []WorkflowTransitionInput // empty follows the regular task status rules
+	RequestedBy string
+}
+
+// CreateWorkflowCommandHandler handles CreateWorkflowCommand
+type CreateWorkflowCommandHandler struct {
+	workflowRepository domain.WorkflowRepository
+	eventPublisher     event.EventPublisher
+	authorizer         *Authorizer
+}
+
+// NewCreateWorkflowCommandHandler creates a new CreateWorkflowCommandHandler
+func NewCreateWorkflowCommandHandler(
+	workflowRepository domain.WorkflowRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *CreateWorkflowCommandHandler {
+	return &CreateWorkflowCommandHandler{
+		workflowRepository: workflowRepository,
+		eventPublisher:     eventPublisher,
+		authorizer:         authorizer,
+	}
+}
+
+// CreateWorkflowResult represents the result of creating a workflow
+type CreateWorkflowResult struct {
+	WorkflowID  string
+	Name        string
+	Description string
+	Error       error
+}
+
+// Handle handles the CreateWorkflowCommand
+func (h *CreateWorkflowCommandHandler) Handle(ctx context.Context, cmd CreateWorkflowCommand) (*CreateWorkflowResult, error) {
+	// Check permission, workflows are shared by every project using them
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	if len(cmd.Statuses) == 0 {
+		return nil, errs.Invalid("invalid workflow: at least one status is required")
+	}
+
+	// Convert statuses
+	statuses := make([]aggregate.WorkflowStatus, len(cmd.Statuses))
+	for i, s := range cmd.Statuses {
+		statuses[i] = aggregate.NewWorkflowStatus(s.Name, s.Description, s.Order, s.IsFinal).WithWIPLimit(s.WIPLimit)
+	}
+
+	// Create workflow aggregate
+	workflowID := value.GenerateWorkflowID()
+	workflow, err := aggregate.NewWorkflow(workflowID, cmd.Name, cmd.Description, statuses)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow: %w", err)
+	}
+
+	// Restrict moves to the given transitions
+	transitions := make([]aggregate.WorkflowTransition, 0, len(cmd.Transitions))
+	for _, t := range cmd.Transitions {
+		transitions = append(transitions, aggregate.WorkflowTransition{From: t.From, To: t.To})
+	}
+	if err := workflow.SetTransitions(transitions); err != nil {
+		return nil, errs.Invalid("invalid workflow: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save workflow
+	err = h.workflowRepository.Save(ctx, workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range workflow.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	workflow.ClearDomainEvents()
+
+	return &CreateWorkflowResult{
+		WorkflowID:  workflowID.Value(),
+		Name:        workflow.Name(),
+		Description: workflow.Description(),
+	}, nil
+}
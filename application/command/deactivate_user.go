@@ -0,0 +1,204 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DeactivateUserCommand represents a command to deactivate a user and hand off their open tasks
+type DeactivateUserCommand struct {
+	UserID             string
+	FallbackAssigneeID string // optional, takes over the open tasks instead of leaving them unassigned
+	RequestedBy        string
+}
+
+// DeactivateUserCommandHandler handles DeactivateUserCommand
+type DeactivateUserCommandHandler struct {
+	userRepository    domain.UserRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	assignmentService *service.TaskAssignmentService
+	authorizer        *Authorizer
+}
+
+// NewDeactivateUserCommandHandler creates a new DeactivateUserCommandHandler
+func NewDeactivateUserCommandHandler(
+	userRepository domain.UserRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	assignmentService *service.TaskAssignmentService,
+	authorizer *Authorizer,
+) *DeactivateUserCommandHandler {
+	return &DeactivateUserCommandHandler{
+		userRepository:    userRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		assignmentService: assignmentService,
+		authorizer:        authorizer,
+	}
+}
+
+// DeactivateUserResult represents the result of deactivating a user
+type DeactivateUserResult struct {
+	UserID string
+	Error  error
+}
+
+// Handle handles the DeactivateUserCommand.
+// The open tasks are handed off by OnUserDeactivated once the UserDeactivated event is published.
+func (h *DeactivateUserCommandHandler) Handle(ctx context.Context, cmd DeactivateUserCommand) (*DeactivateUserResult, error) {
+	// Parse IDs
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	var fallbackID *value.UserID
+	if cmd.FallbackAssigneeID != "" {
+		parsed, err := value.NewUserID(cmd.FallbackAssigneeID)
+		if err != nil {
+			return nil, errs.Invalid("invalid fallback assignee id: %w", err)
+		}
+		fallbackID = &parsed
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Verify the fallback assignee can take over the tasks
+	if fallbackID != nil && !fallbackID.Equals(userID) {
+		fallback, err := h.userRepository.GetByID(ctx, *fallbackID)
+		if err != nil {
+			return nil, fmt.Errorf("fallback assignee not found: %w", err)
+		}
+		if !fallback.IsActive() {
+			return nil, errs.Conflict("fallback assignee is deactivated")
+		}
+		if !fallback.IsEmailVerified() {
+			return nil, errs.Conflict("fallback assignee's email is not verified")
+		}
+	}
+
+	// Deactivate user
+	if err := user.Deactivate(requestedBy, fallbackID); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	user.ClearDomainEvents()
+
+	return &DeactivateUserResult{
+		UserID: userID.Value(),
+	}, nil
+}
+
+// OnUserDeactivated hands the open tasks of a deactivated user to the fallback assignee,
+// or unassigns them. A task the fallback cannot take, for instance over capacity, is unassigned
+// instead. Tasks frozen in an archived project keep their assignee.
+func (h *DeactivateUserCommandHandler) OnUserDeactivated(evt event.DomainEvent) error {
+	ctx := context.Background()
+
+	deactivated, ok := evt.(event.UserDeactivatedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event %s", evt.EventType())
+	}
+
+	userID, err := value.NewUserID(deactivated.AggregateID())
+	if err != nil {
+		return errs.Invalid("invalid user id: %w", err)
+	}
+
+	deactivatedBy, err := value.NewUserID(deactivated.DeactivatedBy)
+	if err != nil {
+		return errs.Invalid("invalid user id: %w", err)
+	}
+
+	var fallbackID *value.UserID
+	if deactivated.FallbackAssigneeID != "" {
+		parsed, err := value.NewUserID(deactivated.FallbackAssigneeID)
+		if err != nil {
+			return errs.Invalid("invalid fallback assignee id: %w", err)
+		}
+		fallbackID = &parsed
+	}
+
+	// Get the user's tasks
+	tasks, err := h.taskRepository.GetByAssigneeID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get assigned tasks: %w", err)
+	}
+
+	handedOff := make([]*aggregate.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.IsOpen() || task.IsFrozen() {
+			continue
+		}
+
+		reassigned := false
+		if fallbackID != nil {
+			_, err := h.assignmentService.ReassignTask(ctx, task, *fallbackID, deactivatedBy)
+			reassigned = err == nil
+		}
+		if !reassigned {
+			if err := h.assignmentService.UnassignTask(task, deactivatedBy); err != nil {
+				return fmt.Errorf("failed to unassign task %s: %w", task.ID().Value(), err)
+			}
+		}
+
+		handedOff = append(handedOff, task)
+	}
+
+	// Save tasks
+	for _, task := range handedOff {
+		if err := h.taskRepository.Update(ctx, task); err != nil {
+			return fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	// Publish domain events
+	for _, task := range handedOff {
+		for _, domainEvent := range task.DomainEvents() {
+			if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+				return fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+		task.ClearDomainEvents()
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DeleteHolidayCalendarCommand represents a command to delete a holiday calendar no project follows
+type DeleteHolidayCalendarCommand struct {
+	CalendarID  string
+	RequestedBy string
+}
+
+// DeleteHolidayCalendarCommandHandler handles DeleteHolidayCalendarCommand
+type DeleteHolidayCalendarCommandHandler struct {
+	calendarRepository domain.HolidayCalendarRepository
+	projectRepository  domain.ProjectRepository
+	authorizer         *Authorizer
+}
+
+// NewDeleteHolidayCalendarCommandHandler creates a new DeleteHolidayCalendarCommandHandler
+func NewDeleteHolidayCalendarCommandHandler(
+	calendarRepository domain.HolidayCalendarRepository,
+	projectRepository domain.ProjectRepository,
+	authorizer *Authorizer,
+) *DeleteHolidayCalendarCommandHandler {
+	return &DeleteHolidayCalendarCommandHandler{
+		calendarRepository: calendarRepository,
+		projectRepository:  projectRepository,
+		authorizer:         authorizer,
+	}
+}
+
+// DeleteHolidayCalendarResult represents the result of deleting a holiday calendar
+type DeleteHolidayCalendarResult struct {
+	Error error
+}
+
+// Handle handles the DeleteHolidayCalendarCommand
+func (h *DeleteHolidayCalendarCommandHandler) Handle(ctx context.Context, cmd DeleteHolidayCalendarCommand) (*DeleteHolidayCalendarResult, error) {
+	// Parse IDs
+	calendarID, err := value.NewHolidayCalendarID(cmd.CalendarID)
+	if err != nil {
+		return nil, errs.Invalid("invalid holiday calendar id: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get holiday calendar
+	calendar, err := h.calendarRepository.GetByID(ctx, calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("holiday calendar not found: %w", err)
+	}
+
+	// Projects following the calendar must move off it first
+	projects, err := h.projectRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	following := 0
+	for _, project := range projects {
+		if id := project.HolidayCalendarID(); id != nil && id.Equals(calendarID) {
+			following++
+		}
+	}
+	if following > 0 {
+		return nil, errs.Conflict("holiday calendar %s is in use by %d projects, move them off it first", calendar.Region(), following)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Delete holiday calendar
+	err = h.calendarRepository.Delete(ctx, calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete holiday calendar: %w", err)
+	}
+
+	return &DeleteHolidayCalendarResult{}, nil
+}
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DeleteMilestoneCommand represents a command to remove a project milestone
+type DeleteMilestoneCommand struct {
+	ProjectID   string
+	MilestoneID string
+	RequestedBy string
+}
+
+// DeleteMilestoneCommandHandler handles DeleteMilestoneCommand
+type DeleteMilestoneCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	authorizer        *Authorizer
+}
+
+// NewDeleteMilestoneCommandHandler creates a new DeleteMilestoneCommandHandler
+func NewDeleteMilestoneCommandHandler(
+	projectRepository domain.ProjectRepository,
+	authorizer *Authorizer,
+) *DeleteMilestoneCommandHandler {
+	return &DeleteMilestoneCommandHandler{
+		projectRepository: projectRepository,
+		authorizer:        authorizer,
+	}
+}
+
+// DeleteMilestoneResult represents the result of deleting a milestone
+type DeleteMilestoneResult struct {
+	Error error
+}
+
+// Handle handles the DeleteMilestoneCommand
+func (h *DeleteMilestoneCommandHandler) Handle(ctx context.Context, cmd DeleteMilestoneCommand) (*DeleteMilestoneResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Remove milestone
+	err = project.RemoveMilestone(cmd.MilestoneID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove milestone: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	return &DeleteMilestoneResult{}, nil
+}
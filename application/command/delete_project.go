@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DeleteProjectCommand represents a command to delete a project along with its tasks
+type DeleteProjectCommand struct {
+	ProjectID   string
+	RequestedBy string
+}
+
+// DeleteProjectCommandHandler handles DeleteProjectCommand
+type DeleteProjectCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewDeleteProjectCommandHandler creates a new DeleteProjectCommandHandler
+func NewDeleteProjectCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *DeleteProjectCommandHandler {
+	return &DeleteProjectCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// DeleteProjectResult represents the result of deleting a project
+type DeleteProjectResult struct {
+	DeletedTaskIDs []string
+	Error          error
+}
+
+// Handle handles the DeleteProjectCommand
+func (h *DeleteProjectCommandHandler) Handle(ctx context.Context, cmd DeleteProjectCommand) (*DeleteProjectResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionDeleteProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	// Delete the tasks with the project
+	deletedIDs := make([]value.TaskID, 0, len(tasks))
+	for _, task := range tasks {
+		task.MarkDeleted()
+		deletedIDs = append(deletedIDs, task.ID())
+	}
+	project.MarkDeleted(deletedIDs)
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, taskID := range deletedIDs {
+		err = h.taskRepository.Delete(ctx, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete task: %w", err)
+		}
+	}
+
+	err = h.projectRepository.Delete(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	// Publish domain events
+	for _, task := range tasks {
+		for _, domainEvent := range task.DomainEvents() {
+			err = h.eventPublisher.Publish(ctx, domainEvent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+		task.ClearDomainEvents()
+	}
+
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	deletedTaskIDs := make([]string, 0, len(deletedIDs))
+	for _, taskID := range deletedIDs {
+		deletedTaskIDs = append(deletedTaskIDs, taskID.Value())
+	}
+
+	return &DeleteProjectResult{
+		DeletedTaskIDs: deletedTaskIDs,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// TaskDeletionMode decides whether deleted tasks are removed from storage or kept aside
+type TaskDeletionMode string
+
+const (
+	TaskDeletionHard TaskDeletionMode = "HARD" // remove the task from the repository
+	TaskDeletionSoft TaskDeletionMode = "SOFT" // keep the task, marked deleted, out of every lookup
+)
+
+// NewTaskDeletionMode creates a TaskDeletionMode from string
+func NewTaskDeletionMode(mode string) (TaskDeletionMode, error) {
+	switch TaskDeletionMode(mode) {
+	case TaskDeletionHard, TaskDeletionSoft:
+		return TaskDeletionMode(mode), nil
+	default:
+		return "", errs.Invalid("invalid task deletion mode: %s", mode)
+	}
+}
+
+// DeleteTaskCommand represents a command to delete a task from its project
+type DeleteTaskCommand struct {
+	TaskID      string
+	RequestedBy string
+}
+
+// DeleteTaskCommandHandler handles DeleteTaskCommand
+type DeleteTaskCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+	mode              TaskDeletionMode
+}
+
+// NewDeleteTaskCommandHandler creates a new DeleteTaskCommandHandler
+func NewDeleteTaskCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *DeleteTaskCommandHandler {
+	return &DeleteTaskCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+		mode:              TaskDeletionHard,
+	}
+}
+
+// SetDeletionMode chooses between hard deletes (the default) and soft deletes
+func (h *DeleteTaskCommandHandler) SetDeletionMode(mode TaskDeletionMode) {
+	h.mode = mode
+}
+
+// DeleteTaskResult represents the result of deleting a task
+type DeleteTaskResult struct {
+	Error error
+}
+
+// Handle handles the DeleteTaskCommand
+func (h *DeleteTaskCommandHandler) Handle(ctx context.Context, cmd DeleteTaskCommand) (*DeleteTaskResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionDeleteTask, project, task); err != nil {
+		return nil, err
+	}
+
+	// Remove task from project
+	err = project.RemoveTask(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove task from project: %w", err)
+	}
+	task.MarkDeleted()
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project and delete task; a soft delete stores the task marked deleted instead
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	if h.mode == TaskDeletionSoft {
+		err = h.taskRepository.Update(ctx, task)
+	} else {
+		err = h.taskRepository.Delete(ctx, taskID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range append(project.DomainEvents(), task.DomainEvents()...) {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	project.ClearDomainEvents()
+	task.ClearDomainEvents()
+
+	return &DeleteTaskResult{}, nil
+}
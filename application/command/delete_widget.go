@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DeleteWidgetCommand represents a command to delete a dashboard widget
+type DeleteWidgetCommand struct {
+	WidgetID    string
+	RequestedBy string
+}
+
+// DeleteWidgetCommandHandler handles DeleteWidgetCommand
+type DeleteWidgetCommandHandler struct {
+	widgetRepository domain.WidgetRepository
+}
+
+// NewDeleteWidgetCommandHandler creates a new DeleteWidgetCommandHandler
+func NewDeleteWidgetCommandHandler(
+	widgetRepository domain.WidgetRepository,
+) *DeleteWidgetCommandHandler {
+	return &DeleteWidgetCommandHandler{
+		widgetRepository: widgetRepository,
+	}
+}
+
+// DeleteWidgetResult represents the result of deleting a widget
+type DeleteWidgetResult struct {
+	Error error
+}
+
+// Handle handles the DeleteWidgetCommand
+func (h *DeleteWidgetCommandHandler) Handle(ctx context.Context, cmd DeleteWidgetCommand) (*DeleteWidgetResult, error) {
+	// Parse IDs
+	widgetID, err := value.NewWidgetID(cmd.WidgetID)
+	if err != nil {
+		return nil, errs.Invalid("invalid widget id: %w", err)
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get widget
+	widget, err := h.widgetRepository.GetByID(ctx, widgetID)
+	if err != nil {
+		return nil, fmt.Errorf("widget not found: %w", err)
+	}
+
+	if !widget.IsOwnedBy(requestedBy) {
+		return nil, errs.PermissionDenied("widget belongs to another user")
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Delete widget
+	err = h.widgetRepository.Delete(ctx, widgetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete widget: %w", err)
+	}
+
+	return &DeleteWidgetResult{}, nil
+}
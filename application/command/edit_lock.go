@@ -0,0 +1,150 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Edit lock actions
+const (
+	EditLockAcquire = "ACQUIRE"
+	EditLockRenew   = "RENEW"
+	EditLockRelease = "RELEASE"
+)
+
+// EditLockCommand represents a command to acquire, renew or release the edit lock on a task description
+type EditLockCommand struct {
+	TaskID     string
+	UserID     string
+	Action     string
+	TTLSeconds int // 0 uses the default lock duration
+}
+
+// EditLockCommandHandler handles EditLockCommand
+type EditLockCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewEditLockCommandHandler creates a new EditLockCommandHandler
+func NewEditLockCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *EditLockCommandHandler {
+	return &EditLockCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// EditLockResult represents the result of changing an edit lock
+type EditLockResult struct {
+	ExpiresAt *time.Time // nil once released
+	Error     error
+}
+
+// Handle handles the EditLockCommand
+func (h *EditLockCommandHandler) Handle(ctx context.Context, cmd EditLockCommand) (*EditLockResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	if cmd.TTLSeconds < 0 {
+		return nil, errs.Invalid("invalid lock duration: %d", cmd.TTLSeconds)
+	}
+	ttl := value.DefaultEditLockTTL
+	if cmd.TTLSeconds > 0 {
+		ttl = time.Duration(cmd.TTLSeconds) * time.Second
+	}
+
+	// Validate user exists
+	_, err = h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.UserID, service.PermissionEditTask, project, task); err != nil {
+		return nil, err
+	}
+
+	// Apply lock action
+	now := time.Now()
+	switch cmd.Action {
+	case EditLockAcquire:
+		err = task.AcquireEditLock(userID, now, ttl)
+	case EditLockRenew:
+		err = task.RenewEditLock(userID, now, ttl)
+	case EditLockRelease:
+		err = task.ReleaseEditLock(userID, now)
+	default:
+		return nil, errs.Invalid("invalid edit lock action: %s", cmd.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	result := &EditLockResult{}
+	if lock := task.ActiveEditLock(now); lock != nil {
+		expiresAt := lock.ExpiresAt()
+		result.ExpiresAt = &expiresAt
+	}
+
+	return result, nil
+}
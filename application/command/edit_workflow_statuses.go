@@ -0,0 +1,173 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Workflow status actions
+const (
+	WorkflowStatusAdd     = "ADD"
+	WorkflowStatusRemove  = "REMOVE"
+	WorkflowStatusReorder = "REORDER"
+)
+
+// EditWorkflowStatusesCommand represents a command to add, remove or reorder the statuses of a workflow
+type EditWorkflowStatusesCommand struct {
+	WorkflowID  string
+	Action      string
+	Name        string // status to add or remove
+	Description string
+	IsFinal     bool
+	WIPLimit    int
+	Order       []string // every status name in the new order, for REORDER
+	RequestedBy string
+}
+
+// EditWorkflowStatusesCommandHandler handles EditWorkflowStatusesCommand
+type EditWorkflowStatusesCommandHandler struct {
+	workflowRepository domain.WorkflowRepository
+	projectRepository  domain.ProjectRepository
+	taskRepository     domain.TaskRepository
+	eventPublisher     event.EventPublisher
+	authorizer         *Authorizer
+}
+
+// NewEditWorkflowStatusesCommandHandler creates a new EditWorkflowStatusesCommandHandler
+func NewEditWorkflowStatusesCommandHandler(
+	workflowRepository domain.WorkflowRepository,
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *EditWorkflowStatusesCommandHandler {
+	return &EditWorkflowStatusesCommandHandler{
+		workflowRepository: workflowRepository,
+		projectRepository:  projectRepository,
+		taskRepository:     taskRepository,
+		eventPublisher:     eventPublisher,
+		authorizer:         authorizer,
+	}
+}
+
+// EditWorkflowStatusesResult represents the result of editing a workflow's statuses
+type EditWorkflowStatusesResult struct {
+	Statuses []string // status names in order
+	Version  int      // the workflow version the edit created
+	Error    error
+}
+
+// Handle handles the EditWorkflowStatusesCommand
+func (h *EditWorkflowStatusesCommandHandler) Handle(ctx context.Context, cmd EditWorkflowStatusesCommand) (*EditWorkflowStatusesResult, error) {
+	// Parse IDs
+	workflowID, err := value.NewWorkflowID(cmd.WorkflowID)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow id: %w", err)
+	}
+
+	// Check permission, workflows are shared by every project using them
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get workflow
+	workflow, err := h.workflowRepository.GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	// Edit a new version, tasks pinned to the current one keep it
+	workflow = workflow.NextVersion()
+
+	// Apply status action
+	switch cmd.Action {
+	case WorkflowStatusAdd:
+		err = workflow.AddStatus(aggregate.NewWorkflowStatus(cmd.Name, cmd.Description, 0, cmd.IsFinal).WithWIPLimit(cmd.WIPLimit))
+	case WorkflowStatusRemove:
+		if err := h.ensureStatusUnused(ctx, workflow, cmd.Name); err != nil {
+			return nil, err
+		}
+		err = workflow.RemoveStatus(cmd.Name)
+	case WorkflowStatusReorder:
+		err = workflow.ReorderStatuses(cmd.Order)
+	default:
+		return nil, errs.Invalid("invalid workflow status action: %s", cmd.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save workflow
+	err = h.workflowRepository.Update(ctx, workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range workflow.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	workflow.ClearDomainEvents()
+
+	statuses := workflow.Statuses()
+	names := make([]string, len(statuses))
+	for i := range statuses {
+		names[i] = statuses[i].GetName()
+	}
+
+	return &EditWorkflowStatusesResult{Statuses: names, Version: workflow.Version()}, nil
+}
+
+// ensureStatusUnused refuses to remove a status that tasks of the workflow's projects are in.
+// Tasks pinned to a version of the workflow keep their version and do not count.
+func (h *EditWorkflowStatusesCommandHandler) ensureStatusUnused(ctx context.Context, workflow *aggregate.Workflow, name string) error {
+	status := workflow.StatusNameFor(name)
+	if status == "" {
+		return nil
+	}
+
+	projects, err := h.projectRepository.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	inUse := 0
+	for _, project := range projects {
+		if !project.WorkflowID().Equals(workflow.ID()) {
+			continue
+		}
+
+		tasks, err := h.taskRepository.GetByProjectID(ctx, project.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get tasks: %w", err)
+		}
+		for _, task := range tasks {
+			if pinned := task.WorkflowVersion(); pinned != nil && pinned.WorkflowID().Equals(workflow.ID()) {
+				continue
+			}
+			if workflow.StatusNameFor(task.StatusIn(workflow)) == status {
+				inUse++
+			}
+		}
+	}
+
+	if inUse > 0 {
+		return errs.Conflict("status %s is in use by %d tasks, move them first", status, inUse)
+	}
+	return nil
+}
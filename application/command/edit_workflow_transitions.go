@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Workflow transition actions
+const (
+	WorkflowTransitionAllow    = "ALLOW"
+	WorkflowTransitionDisallow = "DISALLOW"
+)
+
+// EditWorkflowTransitionsCommand represents a command to allow or disallow a move between two workflow statuses
+type EditWorkflowTransitionsCommand struct {
+	WorkflowID  string
+	Action      string
+	From        string
+	To          string
+	RequestedBy string
+}
+
+// EditWorkflowTransitionsCommandHandler handles EditWorkflowTransitionsCommand
+type EditWorkflowTransitionsCommandHandler struct {
+	workflowRepository domain.WorkflowRepository
+	eventPublisher     event.EventPublisher
+	authorizer         *Authorizer
+}
+
+// NewEditWorkflowTransitionsCommandHandler creates a new EditWorkflowTransitionsCommandHandler
+func NewEditWorkflowTransitionsCommandHandler(
+	workflowRepository domain.WorkflowRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *EditWorkflowTransitionsCommandHandler {
+	return &EditWorkflowTransitionsCommandHandler{
+		workflowRepository: workflowRepository,
+		eventPublisher:     eventPublisher,
+		authorizer:         authorizer,
+	}
+}
+
+// EditWorkflowTransitionsResult represents the result of editing a workflow's transitions
+type EditWorkflowTransitionsResult struct {
+	Transitions []aggregate.WorkflowTransition // the workflow's matrix after the change
+	Version     int                            // the workflow version the edit created
+	Error       error
+}
+
+// Handle handles the EditWorkflowTransitionsCommand
+func (h *EditWorkflowTransitionsCommandHandler) Handle(ctx context.Context, cmd EditWorkflowTransitionsCommand) (*EditWorkflowTransitionsResult, error) {
+	// Parse IDs
+	workflowID, err := value.NewWorkflowID(cmd.WorkflowID)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow id: %w", err)
+	}
+
+	// Check permission, workflows are shared by every project using them
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get workflow
+	workflow, err := h.workflowRepository.GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	// Edit a new version, tasks pinned to the current one keep it
+	workflow = workflow.NextVersion()
+
+	// Apply transition action
+	switch cmd.Action {
+	case WorkflowTransitionAllow:
+		err = workflow.AllowTransition(cmd.From, cmd.To)
+	case WorkflowTransitionDisallow:
+		err = workflow.DisallowTransition(cmd.From, cmd.To)
+	default:
+		return nil, errs.Invalid("invalid workflow transition action: %s", cmd.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save workflow
+	err = h.workflowRepository.Update(ctx, workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range workflow.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	workflow.ClearDomainEvents()
+
+	return &EditWorkflowTransitionsResult{Transitions: workflow.Transitions(), Version: workflow.Version()}, nil
+}
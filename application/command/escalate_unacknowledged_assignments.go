@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// EscalateUnacknowledgedAssignmentsCommand represents a command to sweep a project for
+// assignments their assignees have not acknowledged within the acknowledgement window
+type EscalateUnacknowledgedAssignmentsCommand struct {
+	ProjectID string
+}
+
+// EscalateUnacknowledgedAssignmentsCommandHandler handles EscalateUnacknowledgedAssignmentsCommand
+type EscalateUnacknowledgedAssignmentsCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	assignmentService *service.TaskAssignmentService
+}
+
+// NewEscalateUnacknowledgedAssignmentsCommandHandler creates a new EscalateUnacknowledgedAssignmentsCommandHandler
+func NewEscalateUnacknowledgedAssignmentsCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	assignmentService *service.TaskAssignmentService,
+) *EscalateUnacknowledgedAssignmentsCommandHandler {
+	return &EscalateUnacknowledgedAssignmentsCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		assignmentService: assignmentService,
+	}
+}
+
+// EscalateUnacknowledgedAssignmentsResult represents the result of sweeping a project for
+// unacknowledged assignments
+type EscalateUnacknowledgedAssignmentsResult struct {
+	EscalatedTaskIDs []string
+	Error            error
+}
+
+// Handle handles the EscalateUnacknowledgedAssignmentsCommand.
+// Unlike overdue sweeps, an assignment is only escalated once.
+func (h *EscalateUnacknowledgedAssignmentsCommandHandler) Handle(ctx context.Context, cmd EscalateUnacknowledgedAssignmentsCommand) (*EscalateUnacknowledgedAssignmentsResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	result := &EscalateUnacknowledgedAssignmentsResult{EscalatedTaskIDs: make([]string, 0)}
+
+	// Archived projects are frozen, nobody is expected to pick their tasks up
+	if project.IsArchived() {
+		return result, nil
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	now := time.Now()
+	for _, task := range tasks {
+		// Check acknowledgement
+		if !h.assignmentService.EscalateUnacknowledged(task, now) {
+			continue
+		}
+
+		// Stop if the request was cancelled or timed out
+		if err := abortIfDone(ctx); err != nil {
+			return nil, err
+		}
+
+		// Save task
+		if err := h.taskRepository.Update(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+
+		// Publish domain events
+		for _, domainEvent := range task.DomainEvents() {
+			if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+				return nil, fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+		task.ClearDomainEvents()
+
+		result.EscalatedTaskIDs = append(result.EscalatedTaskIDs, task.ID().Value())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// EvaluateBudgetCommand represents a command to compare a project's spend to its budget
+type EvaluateBudgetCommand struct {
+	ProjectID string
+}
+
+// EvaluateBudgetCommandHandler handles EvaluateBudgetCommand
+type EvaluateBudgetCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	budgetService     *service.BudgetService
+}
+
+// NewEvaluateBudgetCommandHandler creates a new EvaluateBudgetCommandHandler
+func NewEvaluateBudgetCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	budgetService *service.BudgetService,
+) *EvaluateBudgetCommandHandler {
+	return &EvaluateBudgetCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		budgetService:     budgetService,
+	}
+}
+
+// EvaluateBudgetResult represents the result of evaluating a budget
+type EvaluateBudgetResult struct {
+	Exceeded bool
+	Error    error
+}
+
+// Handle handles the EvaluateBudgetCommand
+func (h *EvaluateBudgetCommandHandler) Handle(ctx context.Context, cmd EvaluateBudgetCommand) (*EvaluateBudgetResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	// Evaluate budget
+	changed, err := h.budgetService.EvaluateBudget(project, tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate budget: %w", err)
+	}
+
+	if !changed {
+		return &EvaluateBudgetResult{Exceeded: project.IsBudgetExceeded()}, nil
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &EvaluateBudgetResult{Exceeded: project.IsBudgetExceeded()}, nil
+}
+
+// OnTaskCostRecorded re-evaluates the budget of the project a cost was recorded in
+func (h *EvaluateBudgetCommandHandler) OnTaskCostRecorded(evt event.DomainEvent) error {
+	ctx := context.Background()
+
+	recorded, ok := evt.(event.TaskCostRecordedEvent)
+	if !ok {
+		return nil
+	}
+
+	// Tasks outside a known project have no budget to exceed
+	projectID, err := value.NewProjectID(recorded.ProjectID)
+	if err != nil {
+		return errs.Invalid("invalid project id: %w", err)
+	}
+	if _, err := h.projectRepository.GetByID(ctx, projectID); err != nil {
+		return nil
+	}
+
+	_, err = h.Handle(context.Background(), EvaluateBudgetCommand{ProjectID: recorded.ProjectID})
+	return err
+}
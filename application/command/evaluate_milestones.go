@@ -0,0 +1,132 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// EvaluateMilestonesCommand represents a command to re-check a project's milestones
+type EvaluateMilestonesCommand struct {
+	ProjectID string
+}
+
+// EvaluateMilestonesCommandHandler handles EvaluateMilestonesCommand
+type EvaluateMilestonesCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	progressService   *service.MilestoneProgressService
+}
+
+// NewEvaluateMilestonesCommandHandler creates a new EvaluateMilestonesCommandHandler
+func NewEvaluateMilestonesCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	progressService *service.MilestoneProgressService,
+) *EvaluateMilestonesCommandHandler {
+	return &EvaluateMilestonesCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		progressService:   progressService,
+	}
+}
+
+// EvaluateMilestonesResult represents the result of evaluating milestones
+type EvaluateMilestonesResult struct {
+	Reached bool
+	Error   error
+}
+
+// Handle handles the EvaluateMilestonesCommand
+func (h *EvaluateMilestonesCommandHandler) Handle(ctx context.Context, cmd EvaluateMilestonesCommand) (*EvaluateMilestonesResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	// Evaluate milestones
+	reached, err := h.progressService.EvaluateMilestones(project, tasks, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate milestones: %w", err)
+	}
+
+	if !reached {
+		return &EvaluateMilestonesResult{}, nil
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &EvaluateMilestonesResult{Reached: true}, nil
+}
+
+// OnTaskStatusChanged re-evaluates the milestones of the project a task belongs to.
+// Cancelling the last open task can reach a milestone just like completing it.
+func (h *EvaluateMilestonesCommandHandler) OnTaskStatusChanged(evt event.DomainEvent) error {
+	ctx := context.Background()
+
+	changed, ok := evt.(event.TaskStatusChangedEvent)
+	if !ok {
+		return nil
+	}
+
+	if changed.NewStatus != value.TaskStatusCompleted.Value() && changed.NewStatus != value.TaskStatusCancelled.Value() {
+		return nil
+	}
+
+	taskID, err := value.NewTaskID(evt.AggregateID())
+	if err != nil {
+		return errs.Invalid("invalid task id: %w", err)
+	}
+
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("task not found: %w", err)
+	}
+
+	// Tasks outside a known project have no milestones to reach
+	if _, err := h.projectRepository.GetByID(ctx, task.ProjectID()); err != nil {
+		return nil
+	}
+
+	_, err = h.Handle(context.Background(), EvaluateMilestonesCommand{ProjectID: task.ProjectID().Value()})
+	return err
+}
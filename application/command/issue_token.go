@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/service"
+)
+
+// IssueTokenCommand represents a command to exchange an email and password for JSON Web Tokens
+type IssueTokenCommand struct {
+	Email    string
+	Password string
+}
+
+// IssueTokenCommandHandler handles IssueTokenCommand
+type IssueTokenCommandHandler struct {
+	userRepository domain.UserRepository
+	passwordHasher service.PasswordHasher
+	tokenIssuer    TokenIssuer
+}
+
+// NewIssueTokenCommandHandler creates a new IssueTokenCommandHandler
+func NewIssueTokenCommandHandler(
+	userRepository domain.UserRepository,
+	passwordHasher service.PasswordHasher,
+	tokenIssuer TokenIssuer,
+) *IssueTokenCommandHandler {
+	return &IssueTokenCommandHandler{
+		userRepository: userRepository,
+		passwordHasher: passwordHasher,
+		tokenIssuer:    tokenIssuer,
+	}
+}
+
+// TokenResult represents issued access and refresh tokens
+type TokenResult struct {
+	UserID string
+	Roles  []string
+	Tokens TokenPair
+	Error  error
+}
+
+// Handle handles the IssueTokenCommand
+func (h *IssueTokenCommandHandler) Handle(ctx context.Context, cmd IssueTokenCommand) (*TokenResult, error) {
+	// Verify credentials
+	user, err := authenticate(ctx, h.userRepository, h.passwordHasher, cmd.Email, cmd.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Issue tokens
+	tokens, err := h.tokenIssuer.Issue(user.ID().Value(), roleNames(user))
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue tokens: %w", err)
+	}
+
+	// Save user
+	user.UpdateLastLogin()
+	err = h.userRepository.Update(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	return &TokenResult{
+		UserID: user.ID().Value(),
+		Roles:  roleNames(user),
+		Tokens: tokens,
+	}, nil
+}
+
+// roleNames returns the names of a user's global roles as carried in tokens
+func roleNames(user *aggregate.User) []string {
+	names := make([]string, 0, len(user.Roles()))
+	for _, role := range user.Roles() {
+		names = append(names, role.Value())
+	}
+	return names
+}
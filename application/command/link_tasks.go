@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// LinkTasksCommand represents a command to link two tasks
+type LinkTasksCommand struct {
+	TaskID       string
+	TargetTaskID string
+	LinkType     string
+	LinkedBy     string
+}
+
+// LinkTasksCommandHandler handles LinkTasksCommand
+type LinkTasksCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+	taskLinkService   *service.TaskLinkService
+}
+
+// NewLinkTasksCommandHandler creates a new LinkTasksCommandHandler
+func NewLinkTasksCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+	taskLinkService *service.TaskLinkService,
+) *LinkTasksCommandHandler {
+	return &LinkTasksCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+		taskLinkService:   taskLinkService,
+	}
+}
+
+// LinkTasksResult represents the result of linking tasks
+type LinkTasksResult struct {
+	Error error
+}
+
+// Handle handles the LinkTasksCommand
+func (h *LinkTasksCommandHandler) Handle(ctx context.Context, cmd LinkTasksCommand) (*LinkTasksResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	targetTaskID, err := value.NewTaskID(cmd.TargetTaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid target task id: %w", err)
+	}
+
+	linkedBy, err := value.NewUserID(cmd.LinkedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Parse link type
+	linkType, err := value.NewLinkType(cmd.LinkType)
+	if err != nil {
+		return nil, errs.Invalid("invalid link type: %w", err)
+	}
+
+	// Get tasks
+	source, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	target, err := h.taskRepository.GetByID(ctx, targetTaskID)
+	if err != nil {
+		return nil, fmt.Errorf("target task not found: %w", err)
+	}
+
+	// Check permission on both tasks, which may belong to different projects
+	for _, task := range []*aggregate.Task{source, target} {
+		project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+		if err != nil {
+			return nil, fmt.Errorf("project not found: %w", err)
+		}
+
+		if err := h.authorizer.Authorize(ctx, cmd.LinkedBy, service.PermissionEditTask, project, task); err != nil {
+			return nil, err
+		}
+	}
+
+	// Link tasks
+	err = h.taskLinkService.LinkTasks(source, target, linkType, linkedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link tasks: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save tasks
+	err = h.taskRepository.Update(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	err = h.taskRepository.Update(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save target task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range append(source.DomainEvents(), target.DomainEvents()...) {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	source.ClearDomainEvents()
+	target.ClearDomainEvents()
+
+	return &LinkTasksResult{}, nil
+}
@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// LoginCommand represents a command to sign in with an email and password
+type LoginCommand struct {
+	Email    string
+	Password string
+}
+
+// LoginCommandHandler handles LoginCommand
+type LoginCommandHandler struct {
+	userRepository domain.UserRepository
+	passwordHasher service.PasswordHasher
+	sessionStore   SessionStore
+}
+
+// NewLoginCommandHandler creates a new LoginCommandHandler
+func NewLoginCommandHandler(
+	userRepository domain.UserRepository,
+	passwordHasher service.PasswordHasher,
+	sessionStore SessionStore,
+) *LoginCommandHandler {
+	return &LoginCommandHandler{
+		userRepository: userRepository,
+		passwordHasher: passwordHasher,
+		sessionStore:   sessionStore,
+	}
+}
+
+// LoginResult represents the result of signing in
+type LoginResult struct {
+	Token     string
+	UserID    string
+	ExpiresAt time.Time
+	Error     error
+}
+
+// Handle handles the LoginCommand
+func (h *LoginCommandHandler) Handle(ctx context.Context, cmd LoginCommand) (*LoginResult, error) {
+	// Verify credentials
+	user, err := authenticate(ctx, h.userRepository, h.passwordHasher, cmd.Email, cmd.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Issue session
+	token, session, err := h.sessionStore.Issue(user.ID().Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue session: %w", err)
+	}
+
+	// Save user
+	user.UpdateLastLogin()
+	err = h.userRepository.Update(ctx, user)
+	if err != nil {
+		h.sessionStore.Revoke(token)
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	return &LoginResult{
+		Token:     token,
+		UserID:    session.UserID,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// authenticate returns the active user with the given email and password.
+// Every failed check returns the same error after the same hashing work, so callers
+// cannot probe which emails exist by the answer or by how long it takes.
+func authenticate(
+	ctx context.Context,
+	userRepository domain.UserRepository,
+	passwordHasher service.PasswordHasher,
+	email, password string,
+) (*aggregate.User, error) {
+	user, err := userRepository.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		// Check against a hash nobody has, which never matches
+		passwordHasher.Matches(value.PasswordHash{}, password)
+		return nil, errs.Unauthenticated("invalid email or password")
+	}
+
+	hash := user.PasswordHash()
+	if hash == nil {
+		hash = &value.PasswordHash{}
+	}
+	if !passwordHasher.Matches(*hash, password) || !user.IsActive() {
+		return nil, errs.Unauthenticated("invalid email or password")
+	}
+
+	return user, nil
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// LogoutCommand represents a command to end a session
+type LogoutCommand struct {
+	Token string
+}
+
+// LogoutCommandHandler handles LogoutCommand
+type LogoutCommandHandler struct {
+	sessionStore SessionStore
+}
+
+// NewLogoutCommandHandler creates a new LogoutCommandHandler
+func NewLogoutCommandHandler(sessionStore SessionStore) *LogoutCommandHandler {
+	return &LogoutCommandHandler{
+		sessionStore: sessionStore,
+	}
+}
+
+// LogoutResult represents the result of ending a session
+type LogoutResult struct {
+	Error error
+}
+
+// Handle handles the LogoutCommand
+func (h *LogoutCommandHandler) Handle(ctx context.Context, cmd LogoutCommand) (*LogoutResult, error) {
+	if cmd.Token == "" {
+		return nil, errs.Invalid("session token is required")
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	if !h.sessionStore.Revoke(cmd.Token) {
+		return nil, errs.Unauthenticated("invalid session token")
+	}
+
+	return &LogoutResult{}, nil
+}
@@ -0,0 +1,189 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// MigrateProjectWorkflowCommand represents a command to move a project's tasks to the latest version of its workflow
+type MigrateProjectWorkflowCommand struct {
+	ProjectID     string
+	StatusMapping map[string]string // task status, as its workflow names it -> status of the latest version, for statuses it dropped
+	RequestedBy   string
+}
+
+// MigrateProjectWorkflowCommandHandler handles MigrateProjectWorkflowCommand
+type MigrateProjectWorkflowCommandHandler struct {
+	projectRepository       domain.ProjectRepository
+	taskRepository          domain.TaskRepository
+	eventPublisher          event.EventPublisher
+	statusTransitionService *service.StatusTransitionService
+	authorizer              *Authorizer
+}
+
+// NewMigrateProjectWorkflowCommandHandler creates a new MigrateProjectWorkflowCommandHandler
+func NewMigrateProjectWorkflowCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	statusTransitionService *service.StatusTransitionService,
+	authorizer *Authorizer,
+) *MigrateProjectWorkflowCommandHandler {
+	return &MigrateProjectWorkflowCommandHandler{
+		projectRepository:       projectRepository,
+		taskRepository:          taskRepository,
+		eventPublisher:          eventPublisher,
+		statusTransitionService: statusTransitionService,
+		authorizer:              authorizer,
+	}
+}
+
+// MigrateProjectWorkflowResult represents the result of migrating a project's tasks
+type MigrateProjectWorkflowResult struct {
+	WorkflowVersion string // the version the tasks are now on
+	Migrated        int
+	Error           error
+}
+
+// taskMigration is the status a task takes on the latest workflow version
+type taskMigration struct {
+	task   *aggregate.Task
+	status value.ScopedStatus
+}
+
+// statusMapping maps task statuses, by the name their workflow gives them, to statuses of
+// the workflow the tasks migrate to
+type statusMapping map[string]value.ScopedStatus
+
+// parseStatusMapping validates a status mapping against the workflow the tasks migrate to.
+// Source statuses may be regular or custom ones, the target must be a status of the workflow.
+func parseStatusMapping(raw map[string]string, target *aggregate.Workflow) (statusMapping, error) {
+	mapping := make(statusMapping, len(raw))
+	for from, to := range raw {
+		from = strings.TrimSpace(from)
+		if from == "" {
+			return nil, errs.Invalid("invalid status mapping: status name cannot be empty")
+		}
+		scoped, err := target.ScopedStatus(to)
+		if err != nil {
+			return nil, errs.Invalid("invalid status mapping: %w", err)
+		}
+		mapping[from] = scoped
+	}
+	return mapping, nil
+}
+
+// statusFor returns the status a task takes on the target workflow: the one its status is
+// mapped to, or else the target's status of the same name
+func (m statusMapping) statusFor(task *aggregate.Task, target *aggregate.Workflow) (value.ScopedStatus, error) {
+	for from, to := range m {
+		if task.InStatus(from) {
+			return to, nil
+		}
+	}
+
+	current := task.WorkflowStatus().Name()
+	status, err := target.ScopedStatus(current)
+	if err != nil {
+		return value.ScopedStatus{}, errs.Invalid("invalid status mapping: task %s is in %s, which workflow %s does not have, map it to one of its statuses",
+			task.ID().Value(), current, target.WorkflowVersion())
+	}
+	return status, nil
+}
+
+// Handle handles the MigrateProjectWorkflowCommand
+func (h *MigrateProjectWorkflowCommandHandler) Handle(ctx context.Context, cmd MigrateProjectWorkflowCommand) (*MigrateProjectWorkflowResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Get the latest workflow version
+	latest := h.statusTransitionService.LatestWorkflowOf(ctx, project.ID())
+	if latest == nil {
+		return nil, errs.NotFound("workflow not found: project %s has no workflow to migrate to", project.ID().Value())
+	}
+	version := latest.WorkflowVersion()
+
+	// Parse status mapping
+	mapping, err := parseStatusMapping(cmd.StatusMapping, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan every task's move before changing any
+	tasks, err := h.taskRepository.GetByProjectID(ctx, project.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	migrations := make([]taskMigration, 0, len(tasks))
+	for _, task := range tasks {
+		if pinned := task.WorkflowVersion(); pinned != nil && pinned.Equals(version) {
+			continue
+		}
+
+		status, err := mapping.statusFor(task, latest)
+		if err != nil {
+			return nil, err
+		}
+		if task.IsFrozen() && !task.InStatus(status.Name()) {
+			return nil, errs.Conflict("cannot migrate task %s: cannot change status of a frozen task", task.ID().Value())
+		}
+
+		migrations = append(migrations, taskMigration{task: task, status: status})
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, migration := range migrations {
+		// Migrate task
+		if err := migration.task.MigrateWorkflow(version, migration.status); err != nil {
+			return nil, err
+		}
+
+		// Save task
+		err = h.taskRepository.Update(ctx, migration.task)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+
+		// Publish domain events
+		for _, domainEvent := range migration.task.DomainEvents() {
+			err = h.eventPublisher.Publish(ctx, domainEvent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+
+		migration.task.ClearDomainEvents()
+	}
+
+	return &MigrateProjectWorkflowResult{
+		WorkflowVersion: version.String(),
+		Migrated:        len(migrations),
+	}, nil
+}
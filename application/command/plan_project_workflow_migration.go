@@ -0,0 +1,179 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// PlanProjectWorkflowMigrationCommand represents a command to validate and record how a
+// live project's tasks move onto another workflow, the first phase of switching workflows
+type PlanProjectWorkflowMigrationCommand struct {
+	ProjectID     string
+	WorkflowID    string
+	StatusMapping map[string]string // task status, as its workflow names it -> status of the new workflow, for statuses it does not have
+	RequestedBy   string
+}
+
+// PlanProjectWorkflowMigrationCommandHandler handles PlanProjectWorkflowMigrationCommand
+type PlanProjectWorkflowMigrationCommandHandler struct {
+	projectRepository       domain.ProjectRepository
+	workflowRepository      domain.WorkflowRepository
+	taskRepository          domain.TaskRepository
+	eventPublisher          event.EventPublisher
+	statusTransitionService *service.StatusTransitionService
+	authorizer              *Authorizer
+}
+
+// NewPlanProjectWorkflowMigrationCommandHandler creates a new PlanProjectWorkflowMigrationCommandHandler
+func NewPlanProjectWorkflowMigrationCommandHandler(
+	projectRepository domain.ProjectRepository,
+	workflowRepository domain.WorkflowRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	statusTransitionService *service.StatusTransitionService,
+	authorizer *Authorizer,
+) *PlanProjectWorkflowMigrationCommandHandler {
+	return &PlanProjectWorkflowMigrationCommandHandler{
+		projectRepository:       projectRepository,
+		workflowRepository:      workflowRepository,
+		taskRepository:          taskRepository,
+		eventPublisher:          eventPublisher,
+		statusTransitionService: statusTransitionService,
+		authorizer:              authorizer,
+	}
+}
+
+// PlanProjectWorkflowMigrationResult represents the result of planning a workflow migration
+type PlanProjectWorkflowMigrationResult struct {
+	MigrationID string
+	Total       int // tasks the migration moves
+	Remapped    int // of those, tasks that change status
+	Error       error
+}
+
+// Handle handles the PlanProjectWorkflowMigrationCommand. Nothing moves until the plan is applied.
+func (h *PlanProjectWorkflowMigrationCommandHandler) Handle(ctx context.Context, cmd PlanProjectWorkflowMigrationCommand) (*PlanProjectWorkflowMigrationResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	workflowID, err := value.NewWorkflowID(cmd.WorkflowID)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow id: %w", err)
+	}
+
+	plannedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Get the workflows the project leaves and moves to
+	current := h.statusTransitionService.LatestWorkflowOf(ctx, project.ID())
+	if current == nil || !current.ID().Equals(project.WorkflowID()) {
+		return nil, errs.NotFound("workflow not found: project %s has no workflow to migrate from", project.ID().Value())
+	}
+
+	target, err := h.workflowRepository.GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	// Parse status mapping
+	mapping, err := parseStatusMapping(cmd.StatusMapping, target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan every task's move, recording what rolling it back restores
+	tasks, err := h.taskRepository.GetByProjectID(ctx, project.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	steps := make([]entity.WorkflowMigrationStep, 0, len(tasks))
+	remapped := 0
+	for _, task := range tasks {
+		status, err := mapping.statusFor(task, target)
+		if err != nil {
+			return nil, err
+		}
+		renamed := !task.InStatus(status.Name())
+		if task.IsFrozen() && renamed {
+			return nil, errs.Conflict("cannot migrate task %s: cannot change status of a frozen task", task.ID().Value())
+		}
+
+		fromVersion := current.WorkflowVersion()
+		if pinned := task.WorkflowVersion(); pinned != nil && pinned.WorkflowID().Equals(current.ID()) {
+			fromVersion = *pinned
+		}
+
+		if renamed {
+			remapped++
+		}
+		steps = append(steps, entity.WorkflowMigrationStep{
+			TaskID:      task.ID(),
+			FromVersion: fromVersion,
+			FromStatus:  task.WorkflowStatus(),
+			ToStatus:    status,
+		})
+	}
+
+	// Batches always cover the tasks in the same order
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].TaskID.Value() < steps[j].TaskID.Value()
+	})
+
+	migration, err := entity.NewWorkflowMigration(project.WorkflowID(), target.WorkflowVersion(), steps, plannedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.PlanWorkflowMigration(migration); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	if err := h.projectRepository.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &PlanProjectWorkflowMigrationResult{
+		MigrationID: migration.ID(),
+		Total:       migration.Total(),
+		Remapped:    remapped,
+	}, nil
+}
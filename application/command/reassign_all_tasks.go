@@ -0,0 +1,224 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ReassignAllTasksCommand represents a command to hand every open task of a user to another user,
+// typically when the first user leaves a project
+type ReassignAllTasksCommand struct {
+	FromUserID  string
+	ToUserID    string
+	ProjectID   string // optional, limits the reassignment to one project
+	RequestedBy string
+}
+
+// ReassignAllTasksCommandHandler handles ReassignAllTasksCommand
+type ReassignAllTasksCommandHandler struct {
+	unitOfWork        domain.UnitOfWorkFactory
+	assignmentService *service.TaskAssignmentService
+	authorizer        *Authorizer
+	mu                sync.Mutex // keeps concurrent bulk reassignments from interleaving
+}
+
+// NewReassignAllTasksCommandHandler creates a new ReassignAllTasksCommandHandler. The tasks
+// and their events are saved in one transaction of a unit of work from the factory.
+func NewReassignAllTasksCommandHandler(
+	unitOfWork domain.UnitOfWorkFactory,
+	assignmentService *service.TaskAssignmentService,
+	authorizer *Authorizer,
+) *ReassignAllTasksCommandHandler {
+	return &ReassignAllTasksCommandHandler{
+		unitOfWork:        unitOfWork,
+		assignmentService: assignmentService,
+		authorizer:        authorizer,
+	}
+}
+
+// ReassignedTask is a task that moved to the new assignee
+type ReassignedTask struct {
+	TaskID    string
+	ProjectID string
+	Title     string
+	Status    string
+}
+
+// SkippedTask is an open task that stayed with the old assignee
+type SkippedTask struct {
+	TaskID string
+	Reason string
+}
+
+// ReassignAllTasksResult represents the report of a bulk reassignment
+type ReassignAllTasksResult struct {
+	Reassigned []ReassignedTask
+	Skipped    []SkippedTask
+	ByProject  map[string]int // project ID -> number of tasks moved
+	Warnings   []string       // set when the new assignee ends up over their open task limit
+	Error      error
+}
+
+// Handle handles the ReassignAllTasksCommand.
+// Every task is checked before any is changed, and the tasks are saved in one transaction that
+// is rolled back when a step fails, so either all movable tasks are reassigned or none are.
+func (h *ReassignAllTasksCommandHandler) Handle(ctx context.Context, cmd ReassignAllTasksCommand) (*ReassignAllTasksResult, error) {
+	// Parse IDs
+	fromUserID, err := value.NewUserID(cmd.FromUserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	toUserID, err := value.NewUserID(cmd.ToUserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	if fromUserID.Equals(toUserID) {
+		return nil, errs.Invalid("invalid reassignment: tasks would stay with the same user")
+	}
+
+	var scope *value.ProjectID
+	if cmd.ProjectID != "" {
+		projectID, err := value.NewProjectID(cmd.ProjectID)
+		if err != nil {
+			return nil, errs.Invalid("invalid project id: %w", err)
+		}
+		scope = &projectID
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	uow := h.unitOfWork()
+
+	// Get the user's tasks
+	tasks, err := uow.GetTaskRepository().GetByAssigneeID(ctx, fromUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned tasks: %w", err)
+	}
+
+	// Select open tasks in scope, setting aside those that cannot move
+	result := &ReassignAllTasksResult{
+		Reassigned: make([]ReassignedTask, 0),
+		Skipped:    make([]SkippedTask, 0),
+		ByProject:  make(map[string]int),
+		Warnings:   make([]string, 0),
+	}
+	movable := make([]*aggregate.Task, 0, len(tasks))
+	projects := make(map[value.ProjectID]*aggregate.Project)
+	for _, task := range tasks {
+		if !task.IsOpen() || (scope != nil && task.ProjectID() != *scope) {
+			continue
+		}
+
+		if task.IsFrozen() {
+			result.Skipped = append(result.Skipped, SkippedTask{
+				TaskID: task.ID().Value(),
+				Reason: "task is frozen in an archived project",
+			})
+			continue
+		}
+
+		if _, loaded := projects[task.ProjectID()]; !loaded {
+			project, err := uow.GetProjectRepository().GetByID(ctx, task.ProjectID())
+			if err != nil {
+				return nil, fmt.Errorf("project not found: %w", err)
+			}
+			projects[task.ProjectID()] = project
+		}
+
+		movable = append(movable, task)
+	}
+
+	// Check permission on every affected project before changing anything
+	for _, project := range projects {
+		if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionReassignTasks, project, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// Verify the new assignee can take on all tasks at once,
+	// since the per-task checks below do not see the moves that are not saved yet
+	warning, err := h.assignmentService.CheckCapacity(ctx, toUserID, len(movable))
+	if err != nil {
+		return nil, err
+	}
+	if warning != nil {
+		result.Warnings = append(result.Warnings, warning.Message())
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Reassign and save the tasks in one transaction
+	reassigned, err := h.reassign(ctx, uow, movable, toUserID, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range reassigned {
+		result.Reassigned = append(result.Reassigned, ReassignedTask{
+			TaskID:    task.ID().Value(),
+			ProjectID: task.ProjectID().Value(),
+			Title:     task.Title(),
+			Status:    task.Status().Value(),
+		})
+		result.ByProject[task.ProjectID().Value()]++
+		task.ClearDomainEvents()
+	}
+
+	return result, nil
+}
+
+// reassign hands copies of the tasks to the new assignee, saves them and records one assignment
+// event per task in one transaction, rolling it back when a step fails. Working on copies keeps
+// a task whose save fails unchanged in a repository that shares aggregates with its callers.
+func (h *ReassignAllTasksCommandHandler) reassign(ctx context.Context, uow domain.UnitOfWork, tasks []*aggregate.Task, toUserID, requestedBy value.UserID) ([]*aggregate.Task, error) {
+	if err := uow.BeginTransaction(ctx); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	fail := func(err error) ([]*aggregate.Task, error) {
+		if rollbackErr := uow.Rollback(); rollbackErr != nil {
+			return nil, fmt.Errorf("%w (and %v)", err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	reassigned := make([]*aggregate.Task, 0, len(tasks))
+	for _, original := range tasks {
+		task, err := aggregate.TaskFromState(original.ToState())
+		if err != nil {
+			return fail(fmt.Errorf("failed to copy task %s: %w", original.ID().Value(), err))
+		}
+		if _, err := h.assignmentService.ReassignTask(ctx, task, toUserID, requestedBy); err != nil {
+			return fail(fmt.Errorf("failed to reassign task %s: %w", task.ID().Value(), err))
+		}
+		if err := uow.GetTaskRepository().Update(ctx, task); err != nil {
+			return fail(fmt.Errorf("failed to save task: %w", err))
+		}
+		uow.RecordEvents(task.DomainEvents()...)
+		reassigned = append(reassigned, task)
+	}
+
+	// A failed commit has already rolled the transaction back
+	if err := uow.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reassignment: %w", err)
+	}
+	return reassigned, nil
+}
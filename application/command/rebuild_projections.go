@@ -0,0 +1,28 @@
+package command
+
+import (
+	"context"
+	"time"
+)
+
+// RebuildProgress reports how far a replay has got
+type RebuildProgress struct {
+	Processed int
+	Total     int
+}
+
+// RebuildReport summarizes a completed replay
+type RebuildReport struct {
+	Projections    []string
+	EventsReplayed int
+	Duration       time.Duration
+}
+
+// ProjectionRebuilder truncates read models and replays the event store through them
+type ProjectionRebuilder interface {
+	// Rebuild resets every projection and replays all stored events, calling progress after each
+	Rebuild(ctx context.Context, progress func(RebuildProgress)) (*RebuildReport, error)
+
+	// RebuildOnly resets and replays the named projections, every projection when names is empty
+	RebuildOnly(ctx context.Context, names []string, progress func(RebuildProgress)) (*RebuildReport, error)
+}
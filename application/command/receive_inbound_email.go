@@ -0,0 +1,103 @@
+package command
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ReceiveInboundEmailCommand represents a command to turn an email sent to a project inbox
+// address into a task of that project
+type ReceiveInboundEmailCommand struct {
+	Recipients []string // To and Cc addresses, the first inbox address among them picks the project
+	From       string
+	Subject    string
+	Body       string // plain text
+}
+
+// ReceiveInboundEmailCommandHandler handles ReceiveInboundEmailCommand
+type ReceiveInboundEmailCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	createTask        *CreateTaskCommandHandler
+}
+
+// NewReceiveInboundEmailCommandHandler creates a new ReceiveInboundEmailCommandHandler
+func NewReceiveInboundEmailCommandHandler(
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	createTask *CreateTaskCommandHandler,
+) *ReceiveInboundEmailCommandHandler {
+	return &ReceiveInboundEmailCommandHandler{
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		createTask:        createTask,
+	}
+}
+
+// ReceiveInboundEmailResult represents the result of receiving an inbound email
+type ReceiveInboundEmailResult struct {
+	ProjectID string
+	TaskID    string
+	CreatedBy string // the sender when they are a member of the project, else its owner
+	Error     error
+}
+
+// Handle handles the ReceiveInboundEmailCommand
+func (h *ReceiveInboundEmailCommandHandler) Handle(ctx context.Context, cmd ReceiveInboundEmailCommand) (*ReceiveInboundEmailResult, error) {
+	// Parse sender
+	sender, err := mail.ParseAddress(cmd.From)
+	if err != nil {
+		return nil, errs.Invalid("invalid sender address: %w", err)
+	}
+
+	// Get project of the first recipient that is an inbox address
+	var project *aggregate.Project
+	for _, recipient := range cmd.Recipients {
+		token, ok := value.InboxTokenOf(recipient)
+		if !ok {
+			continue
+		}
+		if project, err = h.projectRepository.GetByInboxToken(ctx, token); err == nil {
+			break
+		}
+	}
+	if project == nil {
+		return nil, errs.NotFound("inbox address not found")
+	}
+
+	// Members file mail as themselves, anyone else through the project owner
+	createdBy := project.OwnerID()
+	if user, err := h.userRepository.GetByEmail(ctx, sender.Address); err == nil &&
+		user.IsActive() && project.IsMember(user.ID()) {
+		createdBy = user.ID()
+	}
+
+	title := strings.TrimSpace(cmd.Subject)
+	if title == "" {
+		title = "Email from " + sender.Address
+	}
+	description := strings.TrimSpace(strings.TrimSpace(cmd.Body) + "\n\nSent by " + sender.String())
+
+	// Create task
+	created, err := h.createTask.Handle(ctx, CreateTaskCommand{
+		ProjectID:   project.ID().Value(),
+		Title:       title,
+		Description: description,
+		CreatedBy:   createdBy.Value(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReceiveInboundEmailResult{
+		ProjectID: project.ID().Value(),
+		TaskID:    created.TaskID,
+		CreatedBy: createdBy.Value(),
+	}, nil
+}
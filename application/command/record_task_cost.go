@@ -0,0 +1,150 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RecordTaskCostCommand represents a command to record money spent on a task
+type RecordTaskCostCommand struct {
+	TaskID      string
+	Amount      string // decimal amount, e.g. "99.95"
+	Currency    string
+	Description string
+	IncurredAt  string // RFC3339, empty means now
+	RecordedBy  string
+}
+
+// RecordTaskCostCommandHandler handles RecordTaskCostCommand
+type RecordTaskCostCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewRecordTaskCostCommandHandler creates a new RecordTaskCostCommandHandler
+func NewRecordTaskCostCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *RecordTaskCostCommandHandler {
+	return &RecordTaskCostCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// RecordTaskCostResult represents the result of recording a cost
+type RecordTaskCostResult struct {
+	EntryID string
+	Error   error
+}
+
+// Handle handles the RecordTaskCostCommand
+func (h *RecordTaskCostCommandHandler) Handle(ctx context.Context, cmd RecordTaskCostCommand) (*RecordTaskCostResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	recordedBy, err := value.NewUserID(cmd.RecordedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Parse amount and date
+	amount, err := value.ParseMoney(cmd.Amount, cmd.Currency)
+	if err != nil {
+		return nil, errs.Invalid("invalid amount: %w", err)
+	}
+
+	var incurredAt time.Time
+	if cmd.IncurredAt != "" {
+		incurredAt, err = time.Parse(time.RFC3339, cmd.IncurredAt)
+		if err != nil {
+			return nil, errs.Invalid("invalid incurred at: %w", err)
+		}
+	}
+
+	// Validate user exists
+	_, err = h.userRepository.GetByID(ctx, recordedBy)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RecordedBy, service.PermissionEditTask, project, task); err != nil {
+		return nil, err
+	}
+
+	// Costs must be in the currency of the project budget
+	if project.IsArchived() {
+		return nil, errs.Conflict("project is archived: cannot record costs")
+	}
+	if budget := project.Budget(); budget != nil && budget.Currency() != amount.Currency() {
+		return nil, errs.Invalid("cost currency %s does not match project budget currency %s", amount.Currency(), budget.Currency())
+	}
+
+	// Create and add cost entry
+	entry, err := entity.NewCostEntry(taskID, amount, cmd.Description, recordedBy, incurredAt)
+	if err != nil {
+		return nil, errs.Invalid("invalid cost entry: %w", err)
+	}
+
+	if err := task.AddCostEntry(entry); err != nil {
+		return nil, fmt.Errorf("failed to record cost: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &RecordTaskCostResult{
+		EntryID: entry.ID(),
+	}, nil
+}
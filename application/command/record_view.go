@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RecordViewCommand represents a command to record that a user opened a task or project
+type RecordViewCommand struct {
+	UserID string
+	Kind   string // "TASK" or "PROJECT"
+	ItemID string
+}
+
+// RecordViewCommandHandler handles RecordViewCommand
+type RecordViewCommandHandler struct {
+	recentViewRepository domain.RecentViewRepository
+}
+
+// NewRecordViewCommandHandler creates a new RecordViewCommandHandler
+func NewRecordViewCommandHandler(
+	recentViewRepository domain.RecentViewRepository,
+) *RecordViewCommandHandler {
+	return &RecordViewCommandHandler{
+		recentViewRepository: recentViewRepository,
+	}
+}
+
+// RecordViewResult represents the result of recording a view
+type RecordViewResult struct {
+	Error error
+}
+
+// Handle handles the RecordViewCommand
+func (h *RecordViewCommandHandler) Handle(ctx context.Context, cmd RecordViewCommand) (*RecordViewResult, error) {
+	// Parse user ID
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Build view
+	kind, err := value.NewViewedItemKind(cmd.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := value.NewRecentView(kind, cmd.ItemID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Record view
+	err = h.recentViewRepository.Record(ctx, userID, view)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record view: %w", err)
+	}
+
+	return &RecordViewResult{}, nil
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RefreshTokenCommand represents a command to exchange a refresh token for new tokens
+type RefreshTokenCommand struct {
+	RefreshToken string
+}
+
+// RefreshTokenCommandHandler handles RefreshTokenCommand
+type RefreshTokenCommandHandler struct {
+	userRepository domain.UserRepository
+	tokenIssuer    TokenIssuer
+}
+
+// NewRefreshTokenCommandHandler creates a new RefreshTokenCommandHandler
+func NewRefreshTokenCommandHandler(
+	userRepository domain.UserRepository,
+	tokenIssuer TokenIssuer,
+) *RefreshTokenCommandHandler {
+	return &RefreshTokenCommandHandler{
+		userRepository: userRepository,
+		tokenIssuer:    tokenIssuer,
+	}
+}
+
+// Handle handles the RefreshTokenCommand.
+// The refresh token is used up, and the new access token carries the user's current roles.
+func (h *RefreshTokenCommandHandler) Handle(ctx context.Context, cmd RefreshTokenCommand) (*TokenResult, error) {
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Redeem refresh token
+	subject, err := h.tokenIssuer.Redeem(cmd.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get user
+	userID, err := value.NewUserID(subject)
+	if err != nil {
+		return nil, errs.Unauthenticated("invalid refresh token")
+	}
+
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil || !user.IsActive() {
+		return nil, errs.Unauthenticated("invalid refresh token")
+	}
+
+	// Issue tokens
+	tokens, err := h.tokenIssuer.Issue(user.ID().Value(), roleNames(user))
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue tokens: %w", err)
+	}
+
+	return &TokenResult{
+		UserID: user.ID().Value(),
+		Roles:  roleNames(user),
+		Tokens: tokens,
+	}, nil
+}
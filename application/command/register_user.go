@@ -0,0 +1,100 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RegisterUserCommand represents a command to register a user who signs in with a password
+type RegisterUserCommand struct {
+	Email     string
+	FirstName string
+	LastName  string
+	Password  string
+}
+
+// RegisterUserCommandHandler handles RegisterUserCommand
+type RegisterUserCommandHandler struct {
+	userRepository domain.UserRepository
+	passwordHasher service.PasswordHasher
+	eventPublisher event.EventPublisher
+}
+
+// NewRegisterUserCommandHandler creates a new RegisterUserCommandHandler
+func NewRegisterUserCommandHandler(
+	userRepository domain.UserRepository,
+	passwordHasher service.PasswordHasher,
+	eventPublisher event.EventPublisher,
+) *RegisterUserCommandHandler {
+	return &RegisterUserCommandHandler{
+		userRepository: userRepository,
+		passwordHasher: passwordHasher,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// RegisterUserResult represents the result of registering a user
+type RegisterUserResult struct {
+	UserID string
+	Error  error
+}
+
+// Handle handles the RegisterUserCommand
+func (h *RegisterUserCommandHandler) Handle(ctx context.Context, cmd RegisterUserCommand) (*RegisterUserResult, error) {
+	email := strings.TrimSpace(cmd.Email)
+
+	// Emails identify users at login, so they must be unique
+	if _, err := h.userRepository.GetByEmail(ctx, email); err == nil {
+		return nil, errs.Conflict("email is already registered")
+	}
+
+	// Hash password
+	hash, err := h.passwordHasher.Hash(cmd.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create user aggregate
+	userID := value.GenerateUserID()
+	user, err := aggregate.NewUser(userID, email, cmd.FirstName, cmd.LastName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if err := user.SetPassword(hash); err != nil {
+		return nil, fmt.Errorf("failed to set password: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	err = h.userRepository.Save(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	user.ClearDomainEvents()
+
+	return &RegisterUserResult{
+		UserID: userID.Value(),
+	}, nil
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RejectTaskCommand represents a command to reject a task in its current status.
+// A standing rejection keeps the task from moving on until it is sent back or the
+// reviewer approves after all.
+type RejectTaskCommand struct {
+	TaskID     string
+	ReviewerID string
+	Reason     string
+}
+
+// RejectTaskCommandHandler handles RejectTaskCommand
+type RejectTaskCommandHandler struct {
+	taskRepository          domain.TaskRepository
+	projectRepository       domain.ProjectRepository
+	eventPublisher          event.EventPublisher
+	statusTransitionService *service.StatusTransitionService
+	authorizer              *Authorizer
+}
+
+// NewRejectTaskCommandHandler creates a new RejectTaskCommandHandler
+func NewRejectTaskCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	statusTransitionService *service.StatusTransitionService,
+	authorizer *Authorizer,
+) *RejectTaskCommandHandler {
+	return &RejectTaskCommandHandler{
+		taskRepository:          taskRepository,
+		projectRepository:       projectRepository,
+		eventPublisher:          eventPublisher,
+		statusTransitionService: statusTransitionService,
+		authorizer:              authorizer,
+	}
+}
+
+// RejectTaskResult represents the result of rejecting a task
+type RejectTaskResult struct {
+	ApprovalCount int
+	Error         error
+}
+
+// Handle handles the RejectTaskCommand
+func (h *RejectTaskCommandHandler) Handle(ctx context.Context, cmd RejectTaskCommand) (*RejectTaskResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	step := h.statusTransitionService.ApprovalStepOf(ctx, task)
+	if err := authorizeReview(ctx, h.authorizer, step, cmd.ReviewerID, project, task); err != nil {
+		return nil, err
+	}
+
+	reviewerID, err := value.NewUserID(cmd.ReviewerID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Record rejection
+	if err := task.Reject(reviewerID, cmd.Reason); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &RejectTaskResult{
+		ApprovalCount: task.ApprovalCount(),
+	}, nil
+}
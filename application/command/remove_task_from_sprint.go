@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RemoveTaskFromSprintCommand represents a command to take a task out of a sprint
+type RemoveTaskFromSprintCommand struct {
+	SprintID    string
+	TaskID      string
+	RequestedBy string
+}
+
+// RemoveTaskFromSprintCommandHandler handles RemoveTaskFromSprintCommand
+type RemoveTaskFromSprintCommandHandler struct {
+	sprintRepository  domain.SprintRepository
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewRemoveTaskFromSprintCommandHandler creates a new RemoveTaskFromSprintCommandHandler
+func NewRemoveTaskFromSprintCommandHandler(
+	sprintRepository domain.SprintRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *RemoveTaskFromSprintCommandHandler {
+	return &RemoveTaskFromSprintCommandHandler{
+		sprintRepository:  sprintRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// RemoveTaskFromSprintResult represents the result of removing a task from a sprint
+type RemoveTaskFromSprintResult struct {
+	Error error
+}
+
+// Handle handles the RemoveTaskFromSprintCommand
+func (h *RemoveTaskFromSprintCommandHandler) Handle(ctx context.Context, cmd RemoveTaskFromSprintCommand) (*RemoveTaskFromSprintResult, error) {
+	// Parse IDs
+	sprintID, err := value.NewSprintID(cmd.SprintID)
+	if err != nil {
+		return nil, errs.Invalid("invalid sprint id: %w", err)
+	}
+
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	// Get sprint
+	sprint, err := h.sprintRepository.GetByID(ctx, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("sprint not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, sprint.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionPlanSprints, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Remove task
+	err = sprint.RemoveTask(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove task from sprint: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save sprint
+	err = h.sprintRepository.Update(ctx, sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save sprint: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range sprint.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	sprint.ClearDomainEvents()
+
+	return &RemoveTaskFromSprintResult{}, nil
+}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RemoveTeamMemberCommand represents a command to remove a user from a team
+type RemoveTeamMemberCommand struct {
+	TeamID      string
+	UserID      string
+	RequestedBy string
+}
+
+// RemoveTeamMemberCommandHandler handles RemoveTeamMemberCommand
+type RemoveTeamMemberCommandHandler struct {
+	teamRepository domain.TeamRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewRemoveTeamMemberCommandHandler creates a new RemoveTeamMemberCommandHandler
+func NewRemoveTeamMemberCommandHandler(
+	teamRepository domain.TeamRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *RemoveTeamMemberCommandHandler {
+	return &RemoveTeamMemberCommandHandler{
+		teamRepository: teamRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// RemoveTeamMemberResult represents the result of removing a team member
+type RemoveTeamMemberResult struct {
+	Error error
+}
+
+// Handle handles the RemoveTeamMemberCommand.
+// The lead or a global admin may remove anyone but the lead, and members may always leave.
+func (h *RemoveTeamMemberCommandHandler) Handle(ctx context.Context, cmd RemoveTeamMemberCommand) (*RemoveTeamMemberResult, error) {
+	// Parse IDs
+	teamID, err := value.NewTeamID(cmd.TeamID)
+	if err != nil {
+		return nil, errs.Invalid("invalid team id: %w", err)
+	}
+
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get team
+	team, err := h.teamRepository.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+
+	// Check permission
+	if cmd.RequestedBy != cmd.UserID {
+		if err := h.authorizer.AuthorizeTeam(ctx, cmd.RequestedBy, team); err != nil {
+			return nil, err
+		}
+	}
+
+	// Remove member
+	if err := team.RemoveMember(userID); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save team
+	err = h.teamRepository.Update(ctx, team)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save team: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range team.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	team.ClearDomainEvents()
+
+	return &RemoveTeamMemberResult{}, nil
+}
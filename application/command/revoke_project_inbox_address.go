@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RevokeProjectInboxAddressCommand represents a command to stop an inbound email address
+// feeding a project
+type RevokeProjectInboxAddressCommand struct {
+	ProjectID   string
+	Token       string
+	RequestedBy string
+}
+
+// RevokeProjectInboxAddressCommandHandler handles RevokeProjectInboxAddressCommand
+type RevokeProjectInboxAddressCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewRevokeProjectInboxAddressCommandHandler creates a new RevokeProjectInboxAddressCommandHandler
+func NewRevokeProjectInboxAddressCommandHandler(
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *RevokeProjectInboxAddressCommandHandler {
+	return &RevokeProjectInboxAddressCommandHandler{
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// RevokeProjectInboxAddressResult represents the result of revoking an inbox address
+type RevokeProjectInboxAddressResult struct {
+	Error error
+}
+
+// Handle handles the RevokeProjectInboxAddressCommand
+func (h *RevokeProjectInboxAddressCommandHandler) Handle(ctx context.Context, cmd RevokeProjectInboxAddressCommand) (*RevokeProjectInboxAddressResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Revoke address, mail sent to it is refused from now on
+	if err := project.RevokeInboxAddress(cmd.Token); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &RevokeProjectInboxAddressResult{}, nil
+}
@@ -0,0 +1,147 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// RollBackProjectWorkflowMigrationCommand represents a command to restore the tasks a
+// workflow migration moved to the workflow version and status its plan recorded
+type RollBackProjectWorkflowMigrationCommand struct {
+	ProjectID   string
+	RequestedBy string
+}
+
+// RollBackProjectWorkflowMigrationCommandHandler handles RollBackProjectWorkflowMigrationCommand
+type RollBackProjectWorkflowMigrationCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewRollBackProjectWorkflowMigrationCommandHandler creates a new RollBackProjectWorkflowMigrationCommandHandler
+func NewRollBackProjectWorkflowMigrationCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *RollBackProjectWorkflowMigrationCommandHandler {
+	return &RollBackProjectWorkflowMigrationCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// RollBackProjectWorkflowMigrationResult represents the result of rolling back a workflow migration
+type RollBackProjectWorkflowMigrationResult struct {
+	MigrationID string
+	Restored    int
+	Error       error
+}
+
+// Handle handles the RollBackProjectWorkflowMigrationCommand. Tasks moved again since the
+// migration are not overwritten: the rollback is refused instead.
+func (h *RollBackProjectWorkflowMigrationCommandHandler) Handle(ctx context.Context, cmd RollBackProjectWorkflowMigrationCommand) (*RollBackProjectWorkflowMigrationResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	migration := project.WorkflowMigration()
+	if migration == nil {
+		return nil, errs.NotFound("workflow migration not found: plan one first")
+	}
+	if !migration.IsInProgress() && migration.Status() != entity.WorkflowMigrationApplied {
+		return nil, errs.Conflict("workflow migration is %s: only applied migrations can be rolled back", migration.Status())
+	}
+
+	// Check every moved task is still where the migration left it
+	applied := migration.Steps()[:migration.Applied()]
+	tasks := make([]*aggregate.Task, 0, len(applied))
+	for _, step := range applied {
+		task, err := h.taskRepository.GetByID(ctx, step.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("task not found: %w", err)
+		}
+
+		pinned := task.WorkflowVersion()
+		if pinned == nil || !pinned.Equals(migration.ToVersion()) || !task.WorkflowStatus().Equals(step.ToStatus) {
+			return nil, errs.Conflict("cannot roll back workflow migration: task %s changed since it was migrated", task.ID().Value())
+		}
+		if task.IsFrozen() && !task.InStatus(step.FromStatus.Name()) {
+			return nil, errs.Conflict("cannot roll back workflow migration: cannot change status of frozen task %s", task.ID().Value())
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	for i, task := range tasks {
+		// Restore task
+		if err := task.MigrateWorkflow(applied[i].FromVersion, applied[i].FromStatus); err != nil {
+			return nil, err
+		}
+
+		// Save task
+		if err := h.taskRepository.Update(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+
+		// Publish domain events
+		for _, domainEvent := range task.DomainEvents() {
+			if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+				return nil, fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+		task.ClearDomainEvents()
+	}
+
+	if err := project.RollBackWorkflowMigration(); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	if err := h.projectRepository.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &RollBackProjectWorkflowMigrationResult{
+		MigrationID: migration.ID(),
+		Restored:    len(tasks),
+	}, nil
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SendVerificationEmailCommand represents a command to email a user a token that verifies their address
+type SendVerificationEmailCommand struct {
+	UserID string
+}
+
+// SendVerificationEmailCommandHandler handles SendVerificationEmailCommand
+type SendVerificationEmailCommandHandler struct {
+	userRepository      domain.UserRepository
+	verificationTokens  VerificationTokens
+	notificationService service.NotificationService
+}
+
+// NewSendVerificationEmailCommandHandler creates a new SendVerificationEmailCommandHandler
+func NewSendVerificationEmailCommandHandler(
+	userRepository domain.UserRepository,
+	verificationTokens VerificationTokens,
+	notificationService service.NotificationService,
+) *SendVerificationEmailCommandHandler {
+	return &SendVerificationEmailCommandHandler{
+		userRepository:      userRepository,
+		verificationTokens:  verificationTokens,
+		notificationService: notificationService,
+	}
+}
+
+// SendVerificationEmailResult represents the result of sending a verification email
+type SendVerificationEmailResult struct {
+	Email string
+	Error error
+}
+
+// Handle handles the SendVerificationEmailCommand.
+// Sending again replaces the previous token, so only the latest email works.
+func (h *SendVerificationEmailCommandHandler) Handle(ctx context.Context, cmd SendVerificationEmailCommand) (*SendVerificationEmailResult, error) {
+	// Parse user ID
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if user.IsEmailVerified() {
+		return nil, errs.Conflict("email is already verified")
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Generate token
+	token, _, err := h.verificationTokens.Generate(user.ID().Value(), user.Email())
+	if err != nil {
+		return nil, err
+	}
+
+	// Send email
+	if err := h.notificationService.SendVerificationEmail(user, token); err != nil {
+		return nil, fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return &SendVerificationEmailResult{
+		Email: user.Email(),
+	}, nil
+}
+
+// OnUserRegistered sends the verification email to a newly registered user
+func (h *SendVerificationEmailCommandHandler) OnUserRegistered(evt event.DomainEvent) error {
+	_, err := h.Handle(context.Background(), SendVerificationEmailCommand{UserID: evt.AggregateID()})
+	return err
+}
+
+// OnUserEmailChanged sends the verification email to a user's new address
+func (h *SendVerificationEmailCommandHandler) OnUserEmailChanged(evt event.DomainEvent) error {
+	_, err := h.Handle(context.Background(), SendVerificationEmailCommand{UserID: evt.AggregateID()})
+	return err
+}
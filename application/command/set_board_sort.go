@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetBoardSortCommand represents a command to set or clear how the acting user sorts
+// the tasks within the columns of a project's board
+type SetBoardSortCommand struct {
+	ProjectID   string
+	Sort        string // CREATED or PRIORITY, empty clears it
+	RequestedBy string
+}
+
+// SetBoardSortCommandHandler handles SetBoardSortCommand
+type SetBoardSortCommandHandler struct {
+	userRepository    domain.UserRepository
+	projectRepository domain.ProjectRepository
+}
+
+// NewSetBoardSortCommandHandler creates a new SetBoardSortCommandHandler
+func NewSetBoardSortCommandHandler(
+	userRepository domain.UserRepository,
+	projectRepository domain.ProjectRepository,
+) *SetBoardSortCommandHandler {
+	return &SetBoardSortCommandHandler{
+		userRepository:    userRepository,
+		projectRepository: projectRepository,
+	}
+}
+
+// SetBoardSortResult represents the result of setting a board sort preference
+type SetBoardSortResult struct {
+	Sort  string // the sort the board now uses for the user
+	Error error
+}
+
+// Handle handles the SetBoardSortCommand. The preference belongs to the acting user.
+func (h *SetBoardSortCommandHandler) Handle(ctx context.Context, cmd SetBoardSortCommand) (*SetBoardSortResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	userID, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	var sort *value.BoardSort
+	if cmd.Sort != "" {
+		parsed, err := value.NewBoardSort(cmd.Sort)
+		if err != nil {
+			return nil, err
+		}
+		sort = &parsed
+	}
+
+	// Get project and user
+	if _, err := h.projectRepository.GetByID(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: acting user not found")
+	}
+
+	user.SetBoardSort(projectID, sort)
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	result := &SetBoardSortResult{Sort: value.BoardSortCreated.Value()}
+	if sort != nil {
+		result.Sort = sort.Value()
+	}
+	return result, nil
+}
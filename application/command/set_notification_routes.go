@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// NotificationRouteInput is one routing rule of SetNotificationRoutesCommand
+type NotificationRouteInput struct {
+	EventType string
+	Channel   string
+	Target    string
+	Delivery  string // defaults to IMMEDIATE
+}
+
+// SetNotificationRoutesCommand represents a command to replace a project's notification routing rules
+type SetNotificationRoutesCommand struct {
+	ProjectID   string
+	Routes      []NotificationRouteInput
+	RequestedBy string
+}
+
+// SetNotificationRoutesCommandHandler handles SetNotificationRoutesCommand
+type SetNotificationRoutesCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	authorizer        *Authorizer
+}
+
+// NewSetNotificationRoutesCommandHandler creates a new SetNotificationRoutesCommandHandler
+func NewSetNotificationRoutesCommandHandler(
+	projectRepository domain.ProjectRepository,
+	authorizer *Authorizer,
+) *SetNotificationRoutesCommandHandler {
+	return &SetNotificationRoutesCommandHandler{
+		projectRepository: projectRepository,
+		authorizer:        authorizer,
+	}
+}
+
+// SetNotificationRoutesResult represents the result of setting notification routes
+type SetNotificationRoutesResult struct {
+	Error error
+}
+
+// Handle handles the SetNotificationRoutesCommand
+func (h *SetNotificationRoutesCommandHandler) Handle(ctx context.Context, cmd SetNotificationRoutesCommand) (*SetNotificationRoutesResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse routes
+	routes := make([]value.NotificationRoute, 0, len(cmd.Routes))
+	for i, input := range cmd.Routes {
+		channel, err := value.NewNotificationChannel(input.Channel)
+		if err != nil {
+			return nil, fmt.Errorf("route %d: %w", i+1, err)
+		}
+
+		delivery := value.NotificationDeliveryImmediate
+		if input.Delivery != "" {
+			delivery, err = value.NewNotificationDelivery(input.Delivery)
+			if err != nil {
+				return nil, fmt.Errorf("route %d: %w", i+1, err)
+			}
+		}
+
+		route, err := value.NewNotificationRoute(input.EventType, channel, input.Target, delivery)
+		if err != nil {
+			return nil, fmt.Errorf("route %d: %w", i+1, err)
+		}
+		routes = append(routes, route)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Apply routes
+	if err := project.SetNotificationRoutes(routes); err != nil {
+		return nil, fmt.Errorf("failed to set notification routes: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	return &SetNotificationRoutesResult{}, nil
+}
@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetOrganizationQuotaCommand represents a command to replace an organization's limits
+type SetOrganizationQuotaCommand struct {
+	OrganizationID string
+	Quota          OrganizationQuotaInput
+	RequestedBy    string
+}
+
+// SetOrganizationQuotaCommandHandler handles SetOrganizationQuotaCommand
+type SetOrganizationQuotaCommandHandler struct {
+	organizationRepository domain.OrganizationRepository
+	eventPublisher         event.EventPublisher
+	authorizer             *Authorizer
+}
+
+// NewSetOrganizationQuotaCommandHandler creates a new SetOrganizationQuotaCommandHandler
+func NewSetOrganizationQuotaCommandHandler(
+	organizationRepository domain.OrganizationRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetOrganizationQuotaCommandHandler {
+	return &SetOrganizationQuotaCommandHandler{
+		organizationRepository: organizationRepository,
+		eventPublisher:         eventPublisher,
+		authorizer:             authorizer,
+	}
+}
+
+// SetOrganizationQuotaResult represents the result of setting an organization's quota
+type SetOrganizationQuotaResult struct {
+	OrganizationID string
+	Error          error
+}
+
+// Handle handles the SetOrganizationQuotaCommand
+func (h *SetOrganizationQuotaCommandHandler) Handle(ctx context.Context, cmd SetOrganizationQuotaCommand) (*SetOrganizationQuotaResult, error) {
+	// Parse organization ID
+	organizationID, err := value.NewOrganizationID(cmd.OrganizationID)
+	if err != nil {
+		return nil, errs.Invalid("invalid organization id: %w", err)
+	}
+
+	quota, err := value.NewQuota(cmd.Quota.MaxProjects, cmd.Quota.MaxTasks, cmd.Quota.MaxAttachmentBytes, cmd.Quota.APICallsPerMinute)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get organization
+	organization, err := h.organizationRepository.GetByID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+
+	organization.SetQuota(quota)
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save organization
+	err = h.organizationRepository.Update(ctx, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save organization: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range organization.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	organization.ClearDomainEvents()
+
+	return &SetOrganizationQuotaResult{
+		OrganizationID: organizationID.Value(),
+	}, nil
+}
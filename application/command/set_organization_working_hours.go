@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetOrganizationWorkingHoursCommand represents a command to set or reset the working hours an
+// organization's members default to
+type SetOrganizationWorkingHoursCommand struct {
+	OrganizationID string
+	WorkingHours   *WorkingHoursInput // nil returns the members to the built-in default
+	RequestedBy    string
+}
+
+// SetOrganizationWorkingHoursCommandHandler handles SetOrganizationWorkingHoursCommand
+type SetOrganizationWorkingHoursCommandHandler struct {
+	organizationRepository domain.OrganizationRepository
+	eventPublisher         event.EventPublisher
+	authorizer             *Authorizer
+}
+
+// NewSetOrganizationWorkingHoursCommandHandler creates a new SetOrganizationWorkingHoursCommandHandler
+func NewSetOrganizationWorkingHoursCommandHandler(
+	organizationRepository domain.OrganizationRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetOrganizationWorkingHoursCommandHandler {
+	return &SetOrganizationWorkingHoursCommandHandler{
+		organizationRepository: organizationRepository,
+		eventPublisher:         eventPublisher,
+		authorizer:             authorizer,
+	}
+}
+
+// SetOrganizationWorkingHoursResult represents the result of setting an organization's working hours
+type SetOrganizationWorkingHoursResult struct {
+	OrganizationID string
+	Error          error
+}
+
+// Handle handles the SetOrganizationWorkingHoursCommand
+func (h *SetOrganizationWorkingHoursCommandHandler) Handle(ctx context.Context, cmd SetOrganizationWorkingHoursCommand) (*SetOrganizationWorkingHoursResult, error) {
+	// Parse organization ID
+	organizationID, err := value.NewOrganizationID(cmd.OrganizationID)
+	if err != nil {
+		return nil, errs.Invalid("invalid organization id: %w", err)
+	}
+
+	hours, err := parseWorkingHours(cmd.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get organization
+	organization, err := h.organizationRepository.GetByID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+
+	organization.SetDefaultWorkingHours(hours)
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save organization
+	err = h.organizationRepository.Update(ctx, organization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save organization: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range organization.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	organization.ClearDomainEvents()
+
+	return &SetOrganizationWorkingHoursResult{
+		OrganizationID: organizationID.Value(),
+	}, nil
+}
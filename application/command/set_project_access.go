@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetProjectAccessCommand represents a command to replace a project's visibility and members
+type SetProjectAccessCommand struct {
+	ProjectID   string
+	Visibility  string // WORKSPACE or RESTRICTED
+	MemberIDs   []string
+	RequestedBy string
+}
+
+// SetProjectAccessCommandHandler handles SetProjectAccessCommand
+type SetProjectAccessCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewSetProjectAccessCommandHandler creates a new SetProjectAccessCommandHandler
+func NewSetProjectAccessCommandHandler(
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetProjectAccessCommandHandler {
+	return &SetProjectAccessCommandHandler{
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// SetProjectAccessResult represents the result of setting project access
+type SetProjectAccessResult struct {
+	Error error
+}
+
+// Handle handles the SetProjectAccessCommand
+func (h *SetProjectAccessCommandHandler) Handle(ctx context.Context, cmd SetProjectAccessCommand) (*SetProjectAccessResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse access
+	visibility, err := value.NewProjectVisibility(cmd.Visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs := make([]value.UserID, 0, len(cmd.MemberIDs))
+	for _, rawMemberID := range cmd.MemberIDs {
+		memberID, err := value.NewUserID(rawMemberID)
+		if err != nil {
+			return nil, errs.Invalid("invalid member id: %w", err)
+		}
+
+		_, err = h.userRepository.GetByID(ctx, memberID)
+		if err != nil {
+			return nil, fmt.Errorf("member not found: %w", err)
+		}
+		memberIDs = append(memberIDs, memberID)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Apply access
+	if err := project.SetAccess(visibility, memberIDs); err != nil {
+		return nil, fmt.Errorf("failed to set project access: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &SetProjectAccessResult{}, nil
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetProjectBudgetCommand represents a command to set or clear a project's budget
+type SetProjectBudgetCommand struct {
+	ProjectID   string
+	Amount      string // decimal amount, empty clears the budget
+	Currency    string
+	RequestedBy string
+}
+
+// SetProjectBudgetCommandHandler handles SetProjectBudgetCommand
+type SetProjectBudgetCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	budgetService     *service.BudgetService
+	authorizer        *Authorizer
+}
+
+// NewSetProjectBudgetCommandHandler creates a new SetProjectBudgetCommandHandler
+func NewSetProjectBudgetCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	budgetService *service.BudgetService,
+	authorizer *Authorizer,
+) *SetProjectBudgetCommandHandler {
+	return &SetProjectBudgetCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		budgetService:     budgetService,
+		authorizer:        authorizer,
+	}
+}
+
+// SetProjectBudgetResult represents the result of setting a project budget
+type SetProjectBudgetResult struct {
+	Exceeded bool
+	Error    error
+}
+
+// Handle handles the SetProjectBudgetCommand
+func (h *SetProjectBudgetCommandHandler) Handle(ctx context.Context, cmd SetProjectBudgetCommand) (*SetProjectBudgetResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse budget
+	var budget *value.Money
+	if cmd.Amount != "" {
+		amount, err := value.ParseMoney(cmd.Amount, cmd.Currency)
+		if err != nil {
+			return nil, errs.Invalid("invalid budget: %w", err)
+		}
+		budget = &amount
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	// Apply budget and check the spend so far against it
+	if err := project.SetBudget(budget); err != nil {
+		return nil, fmt.Errorf("failed to set budget: %w", err)
+	}
+
+	if _, err := h.budgetService.EvaluateBudget(project, tasks); err != nil {
+		return nil, fmt.Errorf("failed to evaluate budget: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &SetProjectBudgetResult{Exceeded: project.IsBudgetExceeded()}, nil
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetProjectHolidayCalendarCommand represents a command to make a project follow a holiday
+// calendar of its owner's organization
+type SetProjectHolidayCalendarCommand struct {
+	ProjectID   string
+	CalendarID  string // empty stops the project observing holidays
+	RequestedBy string
+}
+
+// SetProjectHolidayCalendarCommandHandler handles SetProjectHolidayCalendarCommand
+type SetProjectHolidayCalendarCommandHandler struct {
+	projectRepository      domain.ProjectRepository
+	calendarRepository     domain.HolidayCalendarRepository
+	organizationRepository domain.OrganizationRepository
+	eventPublisher         event.EventPublisher
+	authorizer             *Authorizer
+}
+
+// NewSetProjectHolidayCalendarCommandHandler creates a new SetProjectHolidayCalendarCommandHandler
+func NewSetProjectHolidayCalendarCommandHandler(
+	projectRepository domain.ProjectRepository,
+	calendarRepository domain.HolidayCalendarRepository,
+	organizationRepository domain.OrganizationRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetProjectHolidayCalendarCommandHandler {
+	return &SetProjectHolidayCalendarCommandHandler{
+		projectRepository:      projectRepository,
+		calendarRepository:     calendarRepository,
+		organizationRepository: organizationRepository,
+		eventPublisher:         eventPublisher,
+		authorizer:             authorizer,
+	}
+}
+
+// SetProjectHolidayCalendarResult represents the result of setting a project's holiday calendar
+type SetProjectHolidayCalendarResult struct {
+	Region string // empty when the project observes no holidays
+	Error  error
+}
+
+// Handle handles the SetProjectHolidayCalendarCommand
+func (h *SetProjectHolidayCalendarCommandHandler) Handle(ctx context.Context, cmd SetProjectHolidayCalendarCommand) (*SetProjectHolidayCalendarResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Get holiday calendar, which must be one of the owner's organization
+	var calendarID *value.HolidayCalendarID
+	region := ""
+	if cmd.CalendarID != "" {
+		id, err := value.NewHolidayCalendarID(cmd.CalendarID)
+		if err != nil {
+			return nil, errs.Invalid("invalid holiday calendar id: %w", err)
+		}
+		calendar, err := h.calendarRepository.GetByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("holiday calendar not found: %w", err)
+		}
+		organization, err := h.organizationRepository.GetByMemberID(ctx, project.OwnerID())
+		if err != nil || !organization.ID().Equals(calendar.OrganizationID()) {
+			return nil, errs.Invalid("invalid holiday calendar: %s belongs to another organization than the project owner's", calendar.Region())
+		}
+		calendarID = &id
+		region = calendar.Region()
+	}
+
+	// Follow calendar
+	if err := project.SetHolidayCalendar(calendarID); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	project.ClearDomainEvents()
+
+	return &SetProjectHolidayCalendarResult{
+		Region: region,
+	}, nil
+}
@@ -0,0 +1,183 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// PriorityLevelInput describes one level of a priority scheme
+type PriorityLevelInput struct {
+	Name string
+	Rank int // higher is more urgent
+}
+
+// SetProjectPrioritySchemeCommand represents a command to replace a project's priority ladder
+type SetProjectPrioritySchemeCommand struct {
+	ProjectID       string
+	Levels          []PriorityLevelInput // empty restores LOW, MEDIUM, HIGH, CRITICAL
+	DefaultPriority string               // empty keeps the current default, which must then be in the scheme
+	Remap           map[string]string    // priority the scheme drops -> priority tasks move to
+	RequestedBy     string
+}
+
+// SetProjectPrioritySchemeCommandHandler handles SetProjectPrioritySchemeCommand
+type SetProjectPrioritySchemeCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewSetProjectPrioritySchemeCommandHandler creates a new SetProjectPrioritySchemeCommandHandler
+func NewSetProjectPrioritySchemeCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetProjectPrioritySchemeCommandHandler {
+	return &SetProjectPrioritySchemeCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// SetProjectPrioritySchemeResult represents the result of setting a project's priority scheme
+type SetProjectPrioritySchemeResult struct {
+	Priorities      []string // least urgent first
+	DefaultPriority string
+	Remapped        int // tasks moved off a priority the scheme dropped
+	Error           error
+}
+
+// Handle handles the SetProjectPrioritySchemeCommand.
+// Every task must end up with a priority of the new scheme, or nothing is changed.
+func (h *SetProjectPrioritySchemeCommandHandler) Handle(ctx context.Context, cmd SetProjectPrioritySchemeCommand) (*SetProjectPrioritySchemeResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse scheme
+	scheme := value.DefaultPriorityScheme()
+	if len(cmd.Levels) > 0 {
+		levels := make([]value.PriorityLevel, 0, len(cmd.Levels))
+		for _, input := range cmd.Levels {
+			level, err := value.NewPriorityLevel(input.Name, input.Rank)
+			if err != nil {
+				return nil, err
+			}
+			levels = append(levels, level)
+		}
+		if scheme, err = value.NewPriorityScheme(levels); err != nil {
+			return nil, err
+		}
+	}
+
+	remap := make(map[value.Priority]value.Priority, len(cmd.Remap))
+	for from, to := range cmd.Remap {
+		fromPriority, err := value.ParsePriority(from)
+		if err != nil {
+			return nil, errs.Invalid("invalid priority mapping: %w", err)
+		}
+		toPriority, err := scheme.Parse(to)
+		if err != nil {
+			return nil, errs.Invalid("invalid priority mapping: %w", err)
+		}
+		remap[fromPriority] = toPriority
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	defaultPriority := project.Settings().DefaultPriority()
+	if cmd.DefaultPriority != "" {
+		if defaultPriority, err = scheme.Parse(cmd.DefaultPriority); err != nil {
+			return nil, err
+		}
+	}
+
+	// Plan every task's new priority before changing any
+	tasks, err := h.taskRepository.GetByProjectID(ctx, project.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	remapped := make([]*aggregate.Task, 0)
+	for _, task := range tasks {
+		if scheme.Contains(task.Priority()) {
+			continue
+		}
+		if _, mapped := remap[task.Priority()]; !mapped {
+			return nil, errs.Invalid("invalid priority mapping: task %s has priority %s, which the priority scheme drops, map it to one of its priorities",
+				task.ID().Value(), task.Priority().Value())
+		}
+		remapped = append(remapped, task)
+	}
+
+	// Apply scheme
+	if err := project.SetPriorityScheme(scheme, defaultPriority); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, task := range remapped {
+		// Remap task priority
+		if err := task.UpdatePriority(remap[task.Priority()]); err != nil {
+			return nil, err
+		}
+
+		// Save task
+		err = h.taskRepository.Update(ctx, task)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save task: %w", err)
+		}
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	result := &SetProjectPrioritySchemeResult{
+		Priorities:      make([]string, 0, len(scheme.Levels())),
+		DefaultPriority: defaultPriority.Value(),
+		Remapped:        len(remapped),
+	}
+	for _, level := range scheme.Levels() {
+		result.Priorities = append(result.Priorities, level.Priority().Value())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetProjectSettingsCommand represents a command to replace a project's settings
+type SetProjectSettingsCommand struct {
+	ProjectID               string
+	DefaultPriority         string // empty means MEDIUM, which must then be in the project's priority scheme
+	DefaultAssigneeID       string // empty means no default assignee
+	RequireDeadlineOnCreate bool
+	AllowComments           bool
+	RequestedBy             string
+}
+
+// SetProjectSettingsCommandHandler handles SetProjectSettingsCommand
+type SetProjectSettingsCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewSetProjectSettingsCommandHandler creates a new SetProjectSettingsCommandHandler
+func NewSetProjectSettingsCommandHandler(
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetProjectSettingsCommandHandler {
+	return &SetProjectSettingsCommandHandler{
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// SetProjectSettingsResult represents the result of setting project settings
+type SetProjectSettingsResult struct {
+	Error error
+}
+
+// Handle handles the SetProjectSettingsCommand
+func (h *SetProjectSettingsCommandHandler) Handle(ctx context.Context, cmd SetProjectSettingsCommand) (*SetProjectSettingsResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse settings
+	defaultPriority := value.PriorityMedium
+	if cmd.DefaultPriority != "" {
+		defaultPriority, err = value.ParsePriority(cmd.DefaultPriority)
+		if err != nil {
+			return nil, errs.Invalid("invalid default priority: %w", err)
+		}
+	}
+
+	var defaultAssigneeID *value.UserID
+	if cmd.DefaultAssigneeID != "" {
+		assigneeID, err := value.NewUserID(cmd.DefaultAssigneeID)
+		if err != nil {
+			return nil, errs.Invalid("invalid default assignee id: %w", err)
+		}
+
+		_, err = h.userRepository.GetByID(ctx, assigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("default assignee not found: %w", err)
+		}
+		defaultAssigneeID = &assigneeID
+	}
+
+	settings, err := value.NewProjectSettings(
+		defaultPriority,
+		defaultAssigneeID,
+		cmd.RequireDeadlineOnCreate,
+		cmd.AllowComments,
+	)
+	if err != nil {
+		return nil, errs.Invalid("invalid project settings: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Apply settings
+	if err := project.UpdateSettings(settings); err != nil {
+		return nil, fmt.Errorf("failed to set project settings: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &SetProjectSettingsResult{}, nil
+}
@@ -0,0 +1,117 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetProjectSLOCommand represents a command to configure a project's SLO targets
+type SetProjectSLOCommand struct {
+	ProjectID     string
+	FirstResponse string // Go duration format, empty means no target
+	Resolution    string // Go duration format, empty means no target
+	RequestedBy   string
+}
+
+// SetProjectSLOCommandHandler handles SetProjectSLOCommand
+type SetProjectSLOCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewSetProjectSLOCommandHandler creates a new SetProjectSLOCommandHandler
+func NewSetProjectSLOCommandHandler(
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetProjectSLOCommandHandler {
+	return &SetProjectSLOCommandHandler{
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// SetProjectSLOResult represents the result of setting project SLO targets
+type SetProjectSLOResult struct {
+	Error error
+}
+
+// Handle handles the SetProjectSLOCommand
+func (h *SetProjectSLOCommandHandler) Handle(ctx context.Context, cmd SetProjectSLOCommand) (*SetProjectSLOResult, error) {
+	// Parse project ID
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse targets
+	firstResponse, err := parseOptionalDuration(cmd.FirstResponse)
+	if err != nil {
+		return nil, errs.Invalid("invalid first response target: %w", err)
+	}
+
+	resolution, err := parseOptionalDuration(cmd.Resolution)
+	if err != nil {
+		return nil, errs.Invalid("invalid resolution target: %w", err)
+	}
+
+	targets, err := value.NewSLOTargets(firstResponse, resolution)
+	if err != nil {
+		return nil, errs.Invalid("invalid SLO targets: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Apply targets
+	if err := project.SetSLOTargets(targets); err != nil {
+		return nil, fmt.Errorf("failed to set SLO targets: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &SetProjectSLOResult{}, nil
+}
+
+// parseOptionalDuration parses a duration, treating an empty string as zero
+func parseOptionalDuration(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(raw)
+}
@@ -0,0 +1,176 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// TransitionGuardInput is one guard of SetTransitionRulesCommand
+type TransitionGuardInput struct {
+	Kind      string
+	Approvals int // REQUIRES_APPROVALS only
+}
+
+// TransitionActionInput is one action of SetTransitionRulesCommand
+type TransitionActionInput struct {
+	Kind       string
+	Channel    string // NOTIFY only
+	Target     string // NOTIFY only
+	AssigneeID string // AUTO_ASSIGN only
+}
+
+// SetTransitionRulesCommand represents a command to replace the guards and actions of a workflow transition
+type SetTransitionRulesCommand struct {
+	WorkflowID  string
+	From        string
+	To          string
+	Guards      []TransitionGuardInput
+	Actions     []TransitionActionInput
+	RequestedBy string
+}
+
+// SetTransitionRulesCommandHandler handles SetTransitionRulesCommand
+type SetTransitionRulesCommandHandler struct {
+	workflowRepository domain.WorkflowRepository
+	userRepository     domain.UserRepository
+	eventPublisher     event.EventPublisher
+	authorizer         *Authorizer
+}
+
+// NewSetTransitionRulesCommandHandler creates a new SetTransitionRulesCommandHandler
+func NewSetTransitionRulesCommandHandler(
+	workflowRepository domain.WorkflowRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetTransitionRulesCommandHandler {
+	return &SetTransitionRulesCommandHandler{
+		workflowRepository: workflowRepository,
+		userRepository:     userRepository,
+		eventPublisher:     eventPublisher,
+		authorizer:         authorizer,
+	}
+}
+
+// SetTransitionRulesResult represents the result of setting a transition's rules
+type SetTransitionRulesResult struct {
+	Rules   aggregate.TransitionRules
+	Version int // the workflow version the edit created
+	Error   error
+}
+
+// Handle handles the SetTransitionRulesCommand
+func (h *SetTransitionRulesCommandHandler) Handle(ctx context.Context, cmd SetTransitionRulesCommand) (*SetTransitionRulesResult, error) {
+	// Parse IDs
+	workflowID, err := value.NewWorkflowID(cmd.WorkflowID)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow id: %w", err)
+	}
+
+	// Check permission, workflows are shared by every project using them
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Parse rules
+	rules, err := h.parseRules(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get workflow
+	workflow, err := h.workflowRepository.GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	// Edit a new version, tasks pinned to the current one keep it
+	workflow = workflow.NextVersion()
+
+	// Replace rules
+	if err := workflow.SetTransitionRules(cmd.From, cmd.To, rules); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save workflow
+	err = h.workflowRepository.Update(ctx, workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range workflow.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	workflow.ClearDomainEvents()
+
+	return &SetTransitionRulesResult{Rules: workflow.TransitionRules(cmd.From, cmd.To), Version: workflow.Version()}, nil
+}
+
+// parseRules turns the command's inputs into guards and actions. Users a task is
+// auto-assigned to must exist and be active.
+func (h *SetTransitionRulesCommandHandler) parseRules(ctx context.Context, cmd SetTransitionRulesCommand) (aggregate.TransitionRules, error) {
+	rules := aggregate.TransitionRules{
+		Guards:  make([]value.TransitionGuard, 0, len(cmd.Guards)),
+		Actions: make([]value.TransitionAction, 0, len(cmd.Actions)),
+	}
+
+	for i, input := range cmd.Guards {
+		guard, err := value.NewTransitionGuard(input.Kind, input.Approvals)
+		if err != nil {
+			return aggregate.TransitionRules{}, fmt.Errorf("guard %d: %w", i+1, err)
+		}
+		rules.Guards = append(rules.Guards, guard)
+	}
+
+	for i, input := range cmd.Actions {
+		var action value.TransitionAction
+		switch value.TransitionActionKind(input.Kind) {
+		case value.TransitionActionNotify:
+			channel, err := value.NewNotificationChannel(input.Channel)
+			if err != nil {
+				return aggregate.TransitionRules{}, fmt.Errorf("action %d: %w", i+1, err)
+			}
+			action, err = value.NewNotifyAction(channel, input.Target)
+			if err != nil {
+				return aggregate.TransitionRules{}, fmt.Errorf("action %d: %w", i+1, err)
+			}
+		case value.TransitionActionAutoAssign:
+			assigneeID, err := value.NewUserID(input.AssigneeID)
+			if err != nil {
+				return aggregate.TransitionRules{}, fmt.Errorf("action %d: invalid user id: %w", i+1, err)
+			}
+			assignee, err := h.userRepository.GetByID(ctx, assigneeID)
+			if err != nil {
+				return aggregate.TransitionRules{}, fmt.Errorf("action %d: user not found: %w", i+1, err)
+			}
+			if !assignee.IsActive() {
+				return aggregate.TransitionRules{}, errs.Conflict("action %d: cannot auto-assign to an inactive user", i+1)
+			}
+			action, err = value.NewAutoAssignAction(assigneeID)
+			if err != nil {
+				return aggregate.TransitionRules{}, fmt.Errorf("action %d: %w", i+1, err)
+			}
+		default:
+			return aggregate.TransitionRules{}, errs.Invalid("action %d: invalid transition action: %s", i+1, input.Kind)
+		}
+		rules.Actions = append(rules.Actions, action)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetUserLocaleCommand represents a command to set or clear the locale a user reads in
+type SetUserLocaleCommand struct {
+	UserID      string
+	Locale      string // BCP 47 tag such as "de" or "pt-BR", empty clears it
+	RequestedBy string
+}
+
+// SetUserLocaleCommandHandler handles SetUserLocaleCommand
+type SetUserLocaleCommandHandler struct {
+	userRepository domain.UserRepository
+	authorizer     *Authorizer
+}
+
+// NewSetUserLocaleCommandHandler creates a new SetUserLocaleCommandHandler
+func NewSetUserLocaleCommandHandler(
+	userRepository domain.UserRepository,
+	authorizer *Authorizer,
+) *SetUserLocaleCommandHandler {
+	return &SetUserLocaleCommandHandler{
+		userRepository: userRepository,
+		authorizer:     authorizer,
+	}
+}
+
+// SetUserLocaleResult represents the result of setting a user's locale
+type SetUserLocaleResult struct {
+	Locale string // normalized, empty when cleared
+	Error  error
+}
+
+// Handle handles the SetUserLocaleCommand.
+// Users set their own locale; admins may set anyone's.
+func (h *SetUserLocaleCommandHandler) Handle(ctx context.Context, cmd SetUserLocaleCommand) (*SetUserLocaleResult, error) {
+	// Parse IDs
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	var locale *value.Locale
+	if cmd.Locale != "" {
+		parsed, err := value.NewLocale(cmd.Locale)
+		if err != nil {
+			return nil, err
+		}
+		locale = &parsed
+	}
+
+	// Check permission
+	if !requestedBy.Equals(userID) {
+		if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	user.SetLocale(locale)
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	result := &SetUserLocaleResult{}
+	if locale != nil {
+		result.Locale = locale.Value()
+	}
+	return result, nil
+}
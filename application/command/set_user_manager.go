@@ -0,0 +1,111 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetUserManagerCommand represents a command to set or clear who a user reports to
+type SetUserManagerCommand struct {
+	UserID      string
+	ManagerID   string // empty clears the manager
+	RequestedBy string
+}
+
+// SetUserManagerCommandHandler handles SetUserManagerCommand
+type SetUserManagerCommandHandler struct {
+	userRepository       domain.UserRepository
+	reportingLineService *service.ReportingLineService
+	eventPublisher       event.EventPublisher
+	authorizer           *Authorizer
+}
+
+// NewSetUserManagerCommandHandler creates a new SetUserManagerCommandHandler
+func NewSetUserManagerCommandHandler(
+	userRepository domain.UserRepository,
+	reportingLineService *service.ReportingLineService,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetUserManagerCommandHandler {
+	return &SetUserManagerCommandHandler{
+		userRepository:       userRepository,
+		reportingLineService: reportingLineService,
+		eventPublisher:       eventPublisher,
+		authorizer:           authorizer,
+	}
+}
+
+// SetUserManagerResult represents the result of setting a user's manager
+type SetUserManagerResult struct {
+	UserID    string
+	ManagerID string // empty when cleared
+	Error     error
+}
+
+// Handle handles the SetUserManagerCommand. Only admins change reporting lines.
+func (h *SetUserManagerCommandHandler) Handle(ctx context.Context, cmd SetUserManagerCommand) (*SetUserManagerResult, error) {
+	// Parse IDs
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	var managerID *value.UserID
+	if cmd.ManagerID != "" {
+		parsed, err := value.NewUserID(cmd.ManagerID)
+		if err != nil {
+			return nil, errs.Invalid("invalid manager id: %w", err)
+		}
+		managerID = &parsed
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if managerID != nil {
+		if err := h.reportingLineService.CheckManager(ctx, userID, *managerID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := user.SetManager(managerID); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	user.ClearDomainEvents()
+
+	return &SetUserManagerResult{
+		UserID:    userID.Value(),
+		ManagerID: cmd.ManagerID,
+	}, nil
+}
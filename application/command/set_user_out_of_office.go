@@ -0,0 +1,127 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// OutOfOfficeInput holds a period a user is away, times in RFC 3339
+type OutOfOfficeInput struct {
+	Start string
+	End   string
+	Note  string
+}
+
+// SetUserOutOfOfficeCommand represents a command to replace the periods a user is away
+type SetUserOutOfOfficeCommand struct {
+	UserID      string
+	Periods     []OutOfOfficeInput // none clears the user's out-of-office periods
+	RequestedBy string
+}
+
+// SetUserOutOfOfficeCommandHandler handles SetUserOutOfOfficeCommand
+type SetUserOutOfOfficeCommandHandler struct {
+	userRepository domain.UserRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewSetUserOutOfOfficeCommandHandler creates a new SetUserOutOfOfficeCommandHandler
+func NewSetUserOutOfOfficeCommandHandler(
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetUserOutOfOfficeCommandHandler {
+	return &SetUserOutOfOfficeCommandHandler{
+		userRepository: userRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// SetUserOutOfOfficeResult represents the result of setting a user's out-of-office periods
+type SetUserOutOfOfficeResult struct {
+	UserID  string
+	Periods []value.OutOfOffice
+	Error   error
+}
+
+// Handle handles the SetUserOutOfOfficeCommand.
+// Users register their own time away; admins may register anyone's.
+func (h *SetUserOutOfOfficeCommandHandler) Handle(ctx context.Context, cmd SetUserOutOfOfficeCommand) (*SetUserOutOfOfficeResult, error) {
+	// Parse IDs
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	// Parse periods
+	periods := make([]value.OutOfOffice, 0, len(cmd.Periods))
+	for _, input := range cmd.Periods {
+		start, err := time.Parse(time.RFC3339, input.Start)
+		if err != nil {
+			return nil, errs.Invalid("invalid out-of-office period: start: %w", err)
+		}
+		end, err := time.Parse(time.RFC3339, input.End)
+		if err != nil {
+			return nil, errs.Invalid("invalid out-of-office period: end: %w", err)
+		}
+
+		period, err := value.NewOutOfOffice(start, end, input.Note)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	// Check permission
+	if !requestedBy.Equals(userID) {
+		if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if err := user.SetOutOfOffice(periods); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	user.ClearDomainEvents()
+
+	return &SetUserOutOfOfficeResult{
+		UserID:  userID.Value(),
+		Periods: user.OutOfOffice(),
+	}, nil
+}
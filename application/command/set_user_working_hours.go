@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// WorkingHoursInput holds working hours, days named like "MONDAY" or "Mon"
+type WorkingHoursInput struct {
+	HoursPerDay float64
+	WorkingDays []string
+}
+
+// parseWorkingHours turns the input into working hours, nil when no input is given
+func parseWorkingHours(input *WorkingHoursInput) (*value.WorkingHours, error) {
+	if input == nil {
+		return nil, nil
+	}
+
+	hours, err := value.ParseWorkingHours(input.HoursPerDay, input.WorkingDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hours, nil
+}
+
+// SetUserWorkingHoursCommand represents a command to set or reset a user's working hours
+type SetUserWorkingHoursCommand struct {
+	UserID       string
+	WorkingHours *WorkingHoursInput // nil returns the user to their organization's default
+	RequestedBy  string
+}
+
+// SetUserWorkingHoursCommandHandler handles SetUserWorkingHoursCommand
+type SetUserWorkingHoursCommandHandler struct {
+	userRepository domain.UserRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewSetUserWorkingHoursCommandHandler creates a new SetUserWorkingHoursCommandHandler
+func NewSetUserWorkingHoursCommandHandler(
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetUserWorkingHoursCommandHandler {
+	return &SetUserWorkingHoursCommandHandler{
+		userRepository: userRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// SetUserWorkingHoursResult represents the result of setting a user's working hours
+type SetUserWorkingHoursResult struct {
+	UserID string
+	Error  error
+}
+
+// Handle handles the SetUserWorkingHoursCommand.
+// Users set their own working hours; admins may set anyone's.
+func (h *SetUserWorkingHoursCommandHandler) Handle(ctx context.Context, cmd SetUserWorkingHoursCommand) (*SetUserWorkingHoursResult, error) {
+	// Parse IDs
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	hours, err := parseWorkingHours(cmd.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	if !requestedBy.Equals(userID) {
+		if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	user.SetWorkingHours(hours)
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	user.ClearDomainEvents()
+
+	return &SetUserWorkingHoursResult{
+		UserID: userID.Value(),
+	}, nil
+}
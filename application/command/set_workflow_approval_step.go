@@ -0,0 +1,138 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SetWorkflowApprovalStepCommand represents a command to make tasks need sign-off from
+// designated reviewers before they move on from a workflow status
+type SetWorkflowApprovalStepCommand struct {
+	WorkflowID        string
+	Status            string
+	RequiredApprovals int
+	ReviewerIDs       []string // none removes the step
+	RequestedBy       string
+}
+
+// SetWorkflowApprovalStepCommandHandler handles SetWorkflowApprovalStepCommand
+type SetWorkflowApprovalStepCommandHandler struct {
+	workflowRepository domain.WorkflowRepository
+	userRepository     domain.UserRepository
+	eventPublisher     event.EventPublisher
+	authorizer         *Authorizer
+}
+
+// NewSetWorkflowApprovalStepCommandHandler creates a new SetWorkflowApprovalStepCommandHandler
+func NewSetWorkflowApprovalStepCommandHandler(
+	workflowRepository domain.WorkflowRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *SetWorkflowApprovalStepCommandHandler {
+	return &SetWorkflowApprovalStepCommandHandler{
+		workflowRepository: workflowRepository,
+		userRepository:     userRepository,
+		eventPublisher:     eventPublisher,
+		authorizer:         authorizer,
+	}
+}
+
+// SetWorkflowApprovalStepResult represents the result of setting a status's approval step
+type SetWorkflowApprovalStepResult struct {
+	Step    *value.ApprovalStep // nil when the step was removed
+	Version int                 // the workflow version the edit created
+	Error   error
+}
+
+// Handle handles the SetWorkflowApprovalStepCommand
+func (h *SetWorkflowApprovalStepCommandHandler) Handle(ctx context.Context, cmd SetWorkflowApprovalStepCommand) (*SetWorkflowApprovalStepResult, error) {
+	// Parse IDs
+	workflowID, err := value.NewWorkflowID(cmd.WorkflowID)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow id: %w", err)
+	}
+
+	// Check permission, workflows are shared by every project using them
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Parse step
+	step, err := h.parseStep(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get workflow
+	workflow, err := h.workflowRepository.GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	// Edit a new version, tasks pinned to the current one keep it
+	workflow = workflow.NextVersion()
+
+	// Replace step
+	if err := workflow.SetApprovalStep(cmd.Status, step); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save workflow
+	err = h.workflowRepository.Update(ctx, workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range workflow.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	workflow.ClearDomainEvents()
+
+	return &SetWorkflowApprovalStepResult{Step: step, Version: workflow.Version()}, nil
+}
+
+// parseStep turns the command's inputs into an approval step, nil when it names no
+// reviewers. Reviewers must exist and be active.
+func (h *SetWorkflowApprovalStepCommandHandler) parseStep(ctx context.Context, cmd SetWorkflowApprovalStepCommand) (*value.ApprovalStep, error) {
+	if len(cmd.ReviewerIDs) == 0 {
+		return nil, nil
+	}
+
+	reviewerIDs := make([]value.UserID, 0, len(cmd.ReviewerIDs))
+	for _, id := range cmd.ReviewerIDs {
+		reviewerID, err := value.NewUserID(id)
+		if err != nil {
+			return nil, errs.Invalid("invalid reviewer id: %w", err)
+		}
+		reviewer, err := h.userRepository.GetByID(ctx, reviewerID)
+		if err != nil {
+			return nil, fmt.Errorf("user not found: %w", err)
+		}
+		if !reviewer.IsActive() {
+			return nil, errs.Conflict("cannot designate an inactive user as a reviewer")
+		}
+		reviewerIDs = append(reviewerIDs, reviewerID)
+	}
+
+	step, err := value.NewApprovalStep(cmd.RequiredApprovals, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &step, nil
+}
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SampleProjectName is the name of the project every new tenant starts with
+const SampleProjectName = "Getting Started"
+
+// SignUpTenantCommand represents a command to provision a new tenant: an organization,
+// its owner, a workflow of its own and a sample project
+type SignUpTenantCommand struct {
+	OrganizationName string
+	Email            string
+	FirstName        string
+	LastName         string
+	Password         string
+}
+
+// SignUpTenantCommandHandler handles SignUpTenantCommand
+type SignUpTenantCommandHandler struct {
+	organizationRepository domain.OrganizationRepository
+	userRepository         domain.UserRepository
+	workflowRepository     domain.WorkflowRepository
+	projectRepository      domain.ProjectRepository
+	passwordHasher         service.PasswordHasher
+	eventPublisher         event.EventPublisher
+	tokenIssuer            TokenIssuer
+}
+
+// NewSignUpTenantCommandHandler creates a new SignUpTenantCommandHandler
+func NewSignUpTenantCommandHandler(
+	organizationRepository domain.OrganizationRepository,
+	userRepository domain.UserRepository,
+	workflowRepository domain.WorkflowRepository,
+	projectRepository domain.ProjectRepository,
+	passwordHasher service.PasswordHasher,
+	eventPublisher event.EventPublisher,
+	tokenIssuer TokenIssuer,
+) *SignUpTenantCommandHandler {
+	return &SignUpTenantCommandHandler{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+		workflowRepository:     workflowRepository,
+		projectRepository:      projectRepository,
+		passwordHasher:         passwordHasher,
+		eventPublisher:         eventPublisher,
+		tokenIssuer:            tokenIssuer,
+	}
+}
+
+// SignUpTenantResult represents the result of provisioning a tenant
+type SignUpTenantResult struct {
+	OrganizationID string
+	UserID         string
+	WorkflowID     string
+	ProjectID      string
+	Token          *TokenResult
+	Error          error
+}
+
+// Handle handles the SignUpTenantCommand. Everything is saved or nothing is: when a save
+// fails the ones before it are deleted again, and events are only published once all
+// succeeded, so the owner's verification email goes out for complete tenants only.
+func (h *SignUpTenantCommandHandler) Handle(ctx context.Context, cmd SignUpTenantCommand) (*SignUpTenantResult, error) {
+	email := strings.TrimSpace(cmd.Email)
+
+	// Emails identify users at login, so they must be unique
+	if _, err := h.userRepository.GetByEmail(ctx, email); err == nil {
+		return nil, errs.Conflict("email is already registered")
+	}
+
+	// Hash password
+	hash, err := h.passwordHasher.Hash(cmd.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create owner
+	userID := value.GenerateUserID()
+	owner, err := aggregate.NewUser(userID, email, cmd.FirstName, cmd.LastName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	if err := owner.SetPassword(hash); err != nil {
+		return nil, fmt.Errorf("failed to set password: %w", err)
+	}
+
+	// Create organization with the owner as its first member
+	organizationID := value.GenerateOrganizationID()
+	organization, err := aggregate.NewOrganization(organizationID, cmd.OrganizationName, []value.UserID{userID})
+	if err != nil {
+		return nil, errs.Invalid("invalid organization: %w", err)
+	}
+
+	// Copy the default statuses into a workflow the tenant can edit without affecting others
+	workflowID := value.GenerateWorkflowID()
+	workflow, err := aggregate.NewWorkflow(workflowID, organization.Name()+" Workflow",
+		"Regular task statuses", aggregate.NewDefaultWorkflow().Statuses())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow: %w", err)
+	}
+
+	// Create sample project owned by the owner
+	projectID := value.GenerateProjectID()
+	project, err := aggregate.NewProject(projectID, SampleProjectName,
+		"A first project to try tasks and the board in", userID, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save everything, the organization last as it cannot be deleted again
+	var saved []func() error
+	undo := func(cause error) error {
+		for i := len(saved) - 1; i >= 0; i-- {
+			if err := saved[i](); err != nil {
+				return fmt.Errorf("%w (and failed to undo the signup: %v)", cause, err)
+			}
+		}
+		return cause
+	}
+
+	if err := h.userRepository.Save(ctx, owner); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+	saved = append(saved, func() error { return h.userRepository.Delete(ctx, userID) })
+
+	if err := h.workflowRepository.Save(ctx, workflow); err != nil {
+		return nil, undo(fmt.Errorf("failed to save workflow: %w", err))
+	}
+	saved = append(saved, func() error { return h.workflowRepository.Delete(ctx, workflowID) })
+
+	if err := h.projectRepository.Save(ctx, project); err != nil {
+		return nil, undo(fmt.Errorf("failed to save project: %w", err))
+	}
+	saved = append(saved, func() error { return h.projectRepository.Delete(ctx, projectID) })
+
+	if err := h.organizationRepository.Save(ctx, organization); err != nil {
+		return nil, undo(fmt.Errorf("failed to save organization: %w", err))
+	}
+
+	// Publish domain events in one batch
+	events := owner.DomainEvents()
+	events = append(events, workflow.DomainEvents()...)
+	events = append(events, project.DomainEvents()...)
+	events = append(events, organization.DomainEvents()...)
+	if err := h.eventPublisher.PublishAll(ctx, events); err != nil {
+		return nil, fmt.Errorf("failed to publish events: %w", err)
+	}
+	owner.ClearDomainEvents()
+	workflow.ClearDomainEvents()
+	project.ClearDomainEvents()
+	organization.ClearDomainEvents()
+
+	// Sign the owner in
+	tokens, err := h.tokenIssuer.Issue(userID.Value(), roleNames(owner))
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue tokens: %w", err)
+	}
+
+	return &SignUpTenantResult{
+		OrganizationID: organizationID.Value(),
+		UserID:         userID.Value(),
+		WorkflowID:     workflowID.Value(),
+		ProjectID:      projectID.Value(),
+		Token: &TokenResult{
+			UserID: userID.Value(),
+			Roles:  roleNames(owner),
+			Tokens: tokens,
+		},
+	}, nil
+}
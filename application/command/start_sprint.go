@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// StartSprintCommand represents a command to start a planned sprint
+type StartSprintCommand struct {
+	SprintID    string
+	RequestedBy string
+}
+
+// StartSprintCommandHandler handles StartSprintCommand
+type StartSprintCommandHandler struct {
+	sprintRepository  domain.SprintRepository
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewStartSprintCommandHandler creates a new StartSprintCommandHandler
+func NewStartSprintCommandHandler(
+	sprintRepository domain.SprintRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *StartSprintCommandHandler {
+	return &StartSprintCommandHandler{
+		sprintRepository:  sprintRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// StartSprintResult represents the result of starting a sprint
+type StartSprintResult struct {
+	Error error
+}
+
+// Handle handles the StartSprintCommand
+func (h *StartSprintCommandHandler) Handle(ctx context.Context, cmd StartSprintCommand) (*StartSprintResult, error) {
+	// Parse IDs
+	sprintID, err := value.NewSprintID(cmd.SprintID)
+	if err != nil {
+		return nil, errs.Invalid("invalid sprint id: %w", err)
+	}
+
+	// Get sprint
+	sprint, err := h.sprintRepository.GetByID(ctx, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("sprint not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, sprint.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionPlanSprints, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Only one sprint per project may be active
+	sprints, err := h.sprintRepository.GetByProjectID(ctx, sprint.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project sprints: %w", err)
+	}
+
+	for _, other := range sprints {
+		if other.Status() == value.SprintStatusActive {
+			return nil, errs.Conflict("sprint %s is already active", other.Name())
+		}
+	}
+
+	// Start sprint
+	err = sprint.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start sprint: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save sprint
+	err = h.sprintRepository.Update(ctx, sprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save sprint: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range sprint.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	sprint.ClearDomainEvents()
+
+	return &StartSprintResult{}, nil
+}
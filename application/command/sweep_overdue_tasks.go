@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+)
+
+// SweepOverdueTasksCommand represents a command to check the deadlines of every project's tasks
+type SweepOverdueTasksCommand struct{}
+
+// SweepOverdueTasksCommandHandler handles SweepOverdueTasksCommand
+type SweepOverdueTasksCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	checkHandler      *CheckOverdueTasksCommandHandler
+}
+
+// NewSweepOverdueTasksCommandHandler creates a new SweepOverdueTasksCommandHandler
+func NewSweepOverdueTasksCommandHandler(
+	projectRepository domain.ProjectRepository,
+	checkHandler *CheckOverdueTasksCommandHandler,
+) *SweepOverdueTasksCommandHandler {
+	return &SweepOverdueTasksCommandHandler{
+		projectRepository: projectRepository,
+		checkHandler:      checkHandler,
+	}
+}
+
+// SweepOverdueTasksResult represents the result of sweeping every project for overdue tasks
+type SweepOverdueTasksResult struct {
+	ProjectsChecked int
+	OverdueTaskIDs  []string
+	Error           error
+}
+
+// Handle handles the SweepOverdueTasksCommand. A project that fails to be checked does not
+// keep the others from it; the first failure is returned once all were tried.
+func (h *SweepOverdueTasksCommandHandler) Handle(ctx context.Context, cmd SweepOverdueTasksCommand) (*SweepOverdueTasksResult, error) {
+	projects, err := h.projectRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	result := &SweepOverdueTasksResult{OverdueTaskIDs: make([]string, 0)}
+	var failed error
+	for _, project := range projects {
+		// Stop if the sweep was cancelled
+		if err := abortIfDone(ctx); err != nil {
+			return nil, err
+		}
+
+		checked, err := h.checkHandler.Handle(ctx, CheckOverdueTasksCommand{ProjectID: project.ID().Value()})
+		if err != nil {
+			if failed == nil {
+				failed = fmt.Errorf("failed to sweep project %s: %w", project.ID().Value(), err)
+			}
+			continue
+		}
+
+		result.ProjectsChecked++
+		result.OverdueTaskIDs = append(result.OverdueTaskIDs, checked.OverdueTaskIDs...)
+	}
+
+	if failed != nil {
+		return nil, failed
+	}
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UnlinkTasksCommand represents a command to remove a link between two tasks
+type UnlinkTasksCommand struct {
+	TaskID       string
+	TargetTaskID string
+	LinkType     string
+	RequestedBy  string
+}
+
+// UnlinkTasksCommandHandler handles UnlinkTasksCommand
+type UnlinkTasksCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+	taskLinkService   *service.TaskLinkService
+}
+
+// NewUnlinkTasksCommandHandler creates a new UnlinkTasksCommandHandler
+func NewUnlinkTasksCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+	taskLinkService *service.TaskLinkService,
+) *UnlinkTasksCommandHandler {
+	return &UnlinkTasksCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+		taskLinkService:   taskLinkService,
+	}
+}
+
+// UnlinkTasksResult represents the result of unlinking tasks
+type UnlinkTasksResult struct {
+	Error error
+}
+
+// Handle handles the UnlinkTasksCommand
+func (h *UnlinkTasksCommandHandler) Handle(ctx context.Context, cmd UnlinkTasksCommand) (*UnlinkTasksResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	targetTaskID, err := value.NewTaskID(cmd.TargetTaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid target task id: %w", err)
+	}
+
+	// Parse link type
+	linkType, err := value.NewLinkType(cmd.LinkType)
+	if err != nil {
+		return nil, errs.Invalid("invalid link type: %w", err)
+	}
+
+	// Get tasks
+	source, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	target, err := h.taskRepository.GetByID(ctx, targetTaskID)
+	if err != nil {
+		return nil, fmt.Errorf("target task not found: %w", err)
+	}
+
+	// Check permission on both tasks, which may belong to different projects
+	for _, task := range []*aggregate.Task{source, target} {
+		project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+		if err != nil {
+			return nil, fmt.Errorf("project not found: %w", err)
+		}
+
+		if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionEditTask, project, task); err != nil {
+			return nil, err
+		}
+	}
+
+	// Unlink tasks
+	err = h.taskLinkService.UnlinkTasks(source, target, linkType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlink tasks: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save tasks
+	err = h.taskRepository.Update(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	err = h.taskRepository.Update(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save target task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range append(source.DomainEvents(), target.DomainEvents()...) {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	source.ClearDomainEvents()
+	target.ClearDomainEvents()
+
+	return &UnlinkTasksResult{}, nil
+}
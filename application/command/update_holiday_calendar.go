@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UpdateHolidayCalendarCommand represents a command to rename a holiday calendar and replace its holidays
+type UpdateHolidayCalendarCommand struct {
+	CalendarID  string
+	Name        string
+	Holidays    []HolidayInput
+	RequestedBy string
+}
+
+// UpdateHolidayCalendarCommandHandler handles UpdateHolidayCalendarCommand
+type UpdateHolidayCalendarCommandHandler struct {
+	calendarRepository domain.HolidayCalendarRepository
+	eventPublisher     event.EventPublisher
+	authorizer         *Authorizer
+}
+
+// NewUpdateHolidayCalendarCommandHandler creates a new UpdateHolidayCalendarCommandHandler
+func NewUpdateHolidayCalendarCommandHandler(
+	calendarRepository domain.HolidayCalendarRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *UpdateHolidayCalendarCommandHandler {
+	return &UpdateHolidayCalendarCommandHandler{
+		calendarRepository: calendarRepository,
+		eventPublisher:     eventPublisher,
+		authorizer:         authorizer,
+	}
+}
+
+// UpdateHolidayCalendarResult represents the result of updating a holiday calendar
+type UpdateHolidayCalendarResult struct {
+	HolidayCount int
+	Error        error
+}
+
+// Handle handles the UpdateHolidayCalendarCommand
+func (h *UpdateHolidayCalendarCommandHandler) Handle(ctx context.Context, cmd UpdateHolidayCalendarCommand) (*UpdateHolidayCalendarResult, error) {
+	// Parse IDs
+	calendarID, err := value.NewHolidayCalendarID(cmd.CalendarID)
+	if err != nil {
+		return nil, errs.Invalid("invalid holiday calendar id: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Parse holidays
+	holidays, err := parseHolidays(cmd.Holidays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get holiday calendar
+	calendar, err := h.calendarRepository.GetByID(ctx, calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("holiday calendar not found: %w", err)
+	}
+
+	// Replace holidays
+	if err := calendar.Update(cmd.Name, holidays); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save holiday calendar
+	err = h.calendarRepository.Update(ctx, calendar)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save holiday calendar: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range calendar.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	calendar.ClearDomainEvents()
+
+	return &UpdateHolidayCalendarResult{
+		HolidayCount: len(calendar.Holidays()),
+	}, nil
+}
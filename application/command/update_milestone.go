@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UpdateMilestoneCommand represents a command to change a project milestone
+type UpdateMilestoneCommand struct {
+	ProjectID   string
+	MilestoneID string
+	Name        string
+	Description string
+	DueDate     string // RFC3339 format
+	TaskIDs     []string
+	RequestedBy string
+}
+
+// UpdateMilestoneCommandHandler handles UpdateMilestoneCommand
+type UpdateMilestoneCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+	progressService   *service.MilestoneProgressService
+	authorizer        *Authorizer
+}
+
+// NewUpdateMilestoneCommandHandler creates a new UpdateMilestoneCommandHandler
+func NewUpdateMilestoneCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+	progressService *service.MilestoneProgressService,
+	authorizer *Authorizer,
+) *UpdateMilestoneCommandHandler {
+	return &UpdateMilestoneCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+		progressService:   progressService,
+		authorizer:        authorizer,
+	}
+}
+
+// UpdateMilestoneResult represents the result of updating a milestone
+type UpdateMilestoneResult struct {
+	Error error
+}
+
+// Handle handles the UpdateMilestoneCommand
+func (h *UpdateMilestoneCommandHandler) Handle(ctx context.Context, cmd UpdateMilestoneCommand) (*UpdateMilestoneResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Parse due date
+	dueDate, err := time.Parse(time.RFC3339, cmd.DueDate)
+	if err != nil {
+		return nil, errs.Invalid("invalid due date format: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	// Milestone tasks must belong to the project
+	taskIDs, err := parseMilestoneTaskIDs(ctx, h.taskRepository, projectID, cmd.TaskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update milestone
+	err = project.UpdateMilestone(cmd.MilestoneID, cmd.Name, cmd.Description, dueDate, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	// A changed task set may already be complete
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	if _, err := h.progressService.EvaluateMilestones(project, tasks, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to evaluate milestones: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &UpdateMilestoneResult{}, nil
+}
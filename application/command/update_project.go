@@ -0,0 +1,112 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UpdateProjectCommand represents a command to change a project's name or description.
+// Nil fields are left as they are.
+type UpdateProjectCommand struct {
+	ProjectID   string
+	Name        *string
+	Description *string
+	RequestedBy string
+}
+
+// UpdateProjectCommandHandler handles UpdateProjectCommand
+type UpdateProjectCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewUpdateProjectCommandHandler creates a new UpdateProjectCommandHandler
+func NewUpdateProjectCommandHandler(
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *UpdateProjectCommandHandler {
+	return &UpdateProjectCommandHandler{
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// UpdateProjectResult represents the result of updating a project
+type UpdateProjectResult struct {
+	ProjectID   string
+	Name        string
+	Description string
+	Error       error
+}
+
+// Handle handles the UpdateProjectCommand
+func (h *UpdateProjectCommandHandler) Handle(ctx context.Context, cmd UpdateProjectCommand) (*UpdateProjectResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, service.PermissionManageProject, project, nil); err != nil {
+		return nil, err
+	}
+
+	if project.IsArchived() {
+		return nil, errs.Conflict("cannot update an archived project")
+	}
+
+	// Apply changes
+	if cmd.Name != nil {
+		if err := project.UpdateName(*cmd.Name); err != nil {
+			return nil, errs.Invalid("invalid name: %w", err)
+		}
+	}
+
+	if cmd.Description != nil {
+		if err := project.UpdateDescription(*cmd.Description); err != nil {
+			return nil, errs.Invalid("invalid description: %w", err)
+		}
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range project.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return &UpdateProjectResult{
+		ProjectID:   project.ID().Value(),
+		Name:        project.Name(),
+		Description: project.Description(),
+	}, nil
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UpdateProjectProgressCommand represents a command to recount a project's completed tasks
+type UpdateProjectProgressCommand struct {
+	ProjectID string
+}
+
+// UpdateProjectProgressCommandHandler handles UpdateProjectProgressCommand
+type UpdateProjectProgressCommandHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	eventPublisher    event.EventPublisher
+}
+
+// NewUpdateProjectProgressCommandHandler creates a new UpdateProjectProgressCommandHandler
+func NewUpdateProjectProgressCommandHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	eventPublisher event.EventPublisher,
+) *UpdateProjectProgressCommandHandler {
+	return &UpdateProjectProgressCommandHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		eventPublisher:    eventPublisher,
+	}
+}
+
+// UpdateProjectProgressResult represents the result of updating project progress
+type UpdateProjectProgressResult struct {
+	Completed int
+	Total     int
+	Error     error
+}
+
+// Handle handles the UpdateProjectProgressCommand
+func (h *UpdateProjectProgressCommandHandler) Handle(ctx context.Context, cmd UpdateProjectProgressCommand) (*UpdateProjectProgressResult, error) {
+	// Parse IDs
+	projectID, err := value.NewProjectID(cmd.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	// Count the completed tasks the project still holds
+	completed := 0
+	for _, task := range tasks {
+		if task.Status() == value.TaskStatusCompleted && project.HasTask(task.ID()) {
+			completed++
+		}
+	}
+
+	// Only the progress is published here: the project may be shared with a command
+	// that is still publishing its own events, the ones that triggered this update
+	raised := len(project.DomainEvents())
+	if err := project.RecordProgress(completed); err != nil {
+		return nil, fmt.Errorf("failed to record progress: %w", err)
+	}
+
+	result := &UpdateProjectProgressResult{Completed: completed, Total: project.TaskCount()}
+	progressEvents := project.DomainEvents()[raised:]
+	if len(progressEvents) == 0 {
+		return result, nil
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save project
+	err = h.projectRepository.Update(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save project: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range progressEvents {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	project.ClearDomainEvents()
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UpdateTaskDescriptionCommand represents a command to edit a task description
+type UpdateTaskDescriptionCommand struct {
+	TaskID      string
+	EditorID    string
+	Description string
+}
+
+// UpdateTaskDescriptionCommandHandler handles UpdateTaskDescriptionCommand
+type UpdateTaskDescriptionCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewUpdateTaskDescriptionCommandHandler creates a new UpdateTaskDescriptionCommandHandler
+func NewUpdateTaskDescriptionCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *UpdateTaskDescriptionCommandHandler {
+	return &UpdateTaskDescriptionCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// UpdateTaskDescriptionResult represents the result of editing a task description
+type UpdateTaskDescriptionResult struct {
+	Error error
+}
+
+// Handle handles the UpdateTaskDescriptionCommand
+func (h *UpdateTaskDescriptionCommandHandler) Handle(ctx context.Context, cmd UpdateTaskDescriptionCommand) (*UpdateTaskDescriptionResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	editorID, err := value.NewUserID(cmd.EditorID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.EditorID, service.PermissionEditTask, project, task); err != nil {
+		return nil, err
+	}
+
+	// Edit description, respecting another user's edit lock
+	if err := task.EditDescription(editorID, cmd.Description, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &UpdateTaskDescriptionResult{}, nil
+}
@@ -1,9 +1,11 @@
 package command
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/service"
 	"github.com/miladev95/ddd-task/domain/value"
@@ -11,27 +13,36 @@ import (
 
 // UpdateTaskStatusCommand represents a command to update task status
 type UpdateTaskStatusCommand struct {
-	TaskID    string
-	NewStatus string
+	TaskID      string
+	NewStatus   string
+	Reason      string            // required when moving the task back to an earlier stage
+	Metadata    map[string]string // optional context recorded with the change
+	RequestedBy string
 }
 
 // UpdateTaskStatusCommandHandler handles UpdateTaskStatusCommand
 type UpdateTaskStatusCommandHandler struct {
 	taskRepository        domain.TaskRepository
+	projectRepository     domain.ProjectRepository
 	eventPublisher        event.EventPublisher
 	statusTransitionService *service.StatusTransitionService
+	authorizer            *Authorizer
 }
 
 // NewUpdateTaskStatusCommandHandler creates a new UpdateTaskStatusCommandHandler
 func NewUpdateTaskStatusCommandHandler(
 	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
 	eventPublisher event.EventPublisher,
 	statusTransitionService *service.StatusTransitionService,
+	authorizer *Authorizer,
 ) *UpdateTaskStatusCommandHandler {
 	return &UpdateTaskStatusCommandHandler{
 		taskRepository:        taskRepository,
+		projectRepository:     projectRepository,
 		eventPublisher:        eventPublisher,
 		statusTransitionService: statusTransitionService,
+		authorizer:            authorizer,
 	}
 }
 
@@ -41,40 +52,60 @@ type UpdateTaskStatusResult struct {
 }
 
 // Handle handles the UpdateTaskStatusCommand
-func (h *UpdateTaskStatusCommandHandler) Handle(cmd UpdateTaskStatusCommand) (*UpdateTaskStatusResult, error) {
+func (h *UpdateTaskStatusCommandHandler) Handle(ctx context.Context, cmd UpdateTaskStatusCommand) (*UpdateTaskStatusResult, error) {
 	// Parse IDs
 	taskID, err := value.NewTaskID(cmd.TaskID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid task id: %w", err)
+		return nil, errs.Invalid("invalid task id: %w", err)
 	}
 
-	// Parse new status
-	newStatus, err := value.NewTaskStatus(cmd.NewStatus)
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid status: %w", err)
+		return nil, fmt.Errorf("task not found: %w", err)
 	}
 
-	// Get task
-	task, err := h.taskRepository.GetByID(taskID)
+	// Resolve new status in the task's workflow
+	newStatus, err := h.statusTransitionService.ResolveStatus(ctx, task, cmd.NewStatus)
 	if err != nil {
-		return nil, fmt.Errorf("task not found: %w", err)
+		return nil, errs.Invalid("invalid status: %w", err)
 	}
 
-	// Transition task status
-	err = h.statusTransitionService.TransitionTask(task, newStatus)
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.RequestedBy, statusPermission(task, h.statusTransitionService.StageOf(ctx, task, newStatus)), project, task); err != nil {
+		return nil, err
+	}
+
+	// Transition task status, running the workflow's actions as the requester
+	actorID, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+	err = h.statusTransitionService.TransitionTaskBy(ctx, task, newStatus, cmd.Reason, cmd.Metadata, actorID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update status: %w", err)
 	}
 
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
 	// Save task
-	err = h.taskRepository.Update(task)
+	err = h.taskRepository.Update(ctx, task)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save task: %w", err)
 	}
 
 	// Publish domain events
 	for _, domainEvent := range task.DomainEvents() {
-		err = h.eventPublisher.Publish(domainEvent)
+		err = h.eventPublisher.Publish(ctx, domainEvent)
 		if err != nil {
 			return nil, fmt.Errorf("failed to publish event: %w", err)
 		}
@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UpdateUserCommand represents a command to change a user's name or email address.
+// Nil fields are left as they are.
+type UpdateUserCommand struct {
+	UserID      string
+	FirstName   *string
+	LastName    *string
+	Email       *string
+	RequestedBy string
+}
+
+// UpdateUserCommandHandler handles UpdateUserCommand
+type UpdateUserCommandHandler struct {
+	userRepository domain.UserRepository
+	eventPublisher event.EventPublisher
+	authorizer     *Authorizer
+}
+
+// NewUpdateUserCommandHandler creates a new UpdateUserCommandHandler
+func NewUpdateUserCommandHandler(
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *UpdateUserCommandHandler {
+	return &UpdateUserCommandHandler{
+		userRepository: userRepository,
+		eventPublisher: eventPublisher,
+		authorizer:     authorizer,
+	}
+}
+
+// UpdateUserResult represents the result of updating a user
+type UpdateUserResult struct {
+	UserID        string
+	FirstName     string
+	LastName      string
+	Email         string
+	EmailVerified bool
+	Error         error
+}
+
+// Handle handles the UpdateUserCommand.
+// Users update their own profile; admins may update anyone's. A changed address must be verified again.
+func (h *UpdateUserCommandHandler) Handle(ctx context.Context, cmd UpdateUserCommand) (*UpdateUserResult, error) {
+	// Parse IDs
+	userID, err := value.NewUserID(cmd.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.PermissionDenied("permission denied: an acting user is required")
+	}
+
+	// Check permission
+	if !requestedBy.Equals(userID) {
+		if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Change name
+	if cmd.FirstName != nil || cmd.LastName != nil {
+		firstName, lastName := user.FirstName(), user.LastName()
+		if cmd.FirstName != nil {
+			firstName = strings.TrimSpace(*cmd.FirstName)
+		}
+		if cmd.LastName != nil {
+			lastName = strings.TrimSpace(*cmd.LastName)
+		}
+
+		if err := user.UpdateName(firstName, lastName); err != nil {
+			return nil, errs.Invalid("invalid name: %w", err)
+		}
+	}
+
+	// Change email, which has to stay unique
+	if cmd.Email != nil {
+		newEmail := strings.TrimSpace(*cmd.Email)
+		if newEmail != user.Email() {
+			if _, err := h.userRepository.GetByEmail(ctx, newEmail); err == nil {
+				return nil, errs.Conflict("email is already registered")
+			}
+
+			if err := user.UpdateEmail(newEmail); err != nil {
+				return nil, errs.Invalid("invalid email: %w", err)
+			}
+		}
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	if err := h.userRepository.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		if err := h.eventPublisher.Publish(ctx, domainEvent); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	user.ClearDomainEvents()
+
+	return &UpdateUserResult{
+		UserID:        userID.Value(),
+		FirstName:     user.FirstName(),
+		LastName:      user.LastName(),
+		Email:         user.Email(),
+		EmailVerified: user.IsEmailVerified(),
+	}, nil
+}
@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UpdateWidgetCommand represents a command to update a dashboard widget.
+// Empty fields are left unchanged.
+type UpdateWidgetCommand struct {
+	WidgetID    string
+	RequestedBy string
+	Title       string
+	Parameters  map[string]string
+	Layout      *WidgetLayoutInput
+}
+
+// WidgetLayoutInput carries a new widget position and size
+type WidgetLayoutInput struct {
+	Column int
+	Row    int
+	Width  int
+	Height int
+}
+
+// UpdateWidgetCommandHandler handles UpdateWidgetCommand
+type UpdateWidgetCommandHandler struct {
+	widgetRepository domain.WidgetRepository
+}
+
+// NewUpdateWidgetCommandHandler creates a new UpdateWidgetCommandHandler
+func NewUpdateWidgetCommandHandler(
+	widgetRepository domain.WidgetRepository,
+) *UpdateWidgetCommandHandler {
+	return &UpdateWidgetCommandHandler{
+		widgetRepository: widgetRepository,
+	}
+}
+
+// UpdateWidgetResult represents the result of updating a widget
+type UpdateWidgetResult struct {
+	Error error
+}
+
+// Handle handles the UpdateWidgetCommand
+func (h *UpdateWidgetCommandHandler) Handle(ctx context.Context, cmd UpdateWidgetCommand) (*UpdateWidgetResult, error) {
+	// Parse IDs
+	widgetID, err := value.NewWidgetID(cmd.WidgetID)
+	if err != nil {
+		return nil, errs.Invalid("invalid widget id: %w", err)
+	}
+
+	requestedBy, err := value.NewUserID(cmd.RequestedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get widget
+	widget, err := h.widgetRepository.GetByID(ctx, widgetID)
+	if err != nil {
+		return nil, fmt.Errorf("widget not found: %w", err)
+	}
+
+	if !widget.IsOwnedBy(requestedBy) {
+		return nil, errs.PermissionDenied("widget belongs to another user")
+	}
+
+	// Apply changes
+	if cmd.Title != "" {
+		if err := widget.UpdateTitle(cmd.Title); err != nil {
+			return nil, fmt.Errorf("failed to update title: %w", err)
+		}
+	}
+
+	if cmd.Parameters != nil {
+		if err := widget.UpdateParameters(cmd.Parameters); err != nil {
+			return nil, fmt.Errorf("failed to update parameters: %w", err)
+		}
+	}
+
+	if cmd.Layout != nil {
+		layout, err := value.NewWidgetLayout(cmd.Layout.Column, cmd.Layout.Row, cmd.Layout.Width, cmd.Layout.Height)
+		if err != nil {
+			return nil, errs.Invalid("invalid layout: %w", err)
+		}
+		widget.MoveTo(layout)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save widget
+	err = h.widgetRepository.Update(ctx, widget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save widget: %w", err)
+	}
+
+	return &UpdateWidgetResult{}, nil
+}
@@ -0,0 +1,112 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UpdateWorkflowCommand represents a command to change a workflow's name or description.
+// Nil fields are left as they are.
+type UpdateWorkflowCommand struct {
+	WorkflowID  string
+	Name        *string
+	Description *string
+	RequestedBy string
+}
+
+// UpdateWorkflowCommandHandler handles UpdateWorkflowCommand
+type UpdateWorkflowCommandHandler struct {
+	workflowRepository domain.WorkflowRepository
+	eventPublisher     event.EventPublisher
+	authorizer         *Authorizer
+}
+
+// NewUpdateWorkflowCommandHandler creates a new UpdateWorkflowCommandHandler
+func NewUpdateWorkflowCommandHandler(
+	workflowRepository domain.WorkflowRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *UpdateWorkflowCommandHandler {
+	return &UpdateWorkflowCommandHandler{
+		workflowRepository: workflowRepository,
+		eventPublisher:     eventPublisher,
+		authorizer:         authorizer,
+	}
+}
+
+// UpdateWorkflowResult represents the result of updating a workflow
+type UpdateWorkflowResult struct {
+	WorkflowID  string
+	Name        string
+	Description string
+	Version     int // the workflow version the edit created
+	Error       error
+}
+
+// Handle handles the UpdateWorkflowCommand
+func (h *UpdateWorkflowCommandHandler) Handle(ctx context.Context, cmd UpdateWorkflowCommand) (*UpdateWorkflowResult, error) {
+	// Parse IDs
+	workflowID, err := value.NewWorkflowID(cmd.WorkflowID)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow id: %w", err)
+	}
+
+	// Check permission, workflows are shared by every project using them
+	if err := h.authorizer.AuthorizeAdmin(ctx, cmd.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	// Get workflow
+	workflow, err := h.workflowRepository.GetByID(ctx, workflowID)
+	if err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	// Edit a new version, tasks pinned to the current one keep it
+	workflow = workflow.NextVersion()
+
+	name, description := workflow.Name(), workflow.Description()
+	if cmd.Name != nil {
+		name = *cmd.Name
+	}
+	if cmd.Description != nil {
+		description = *cmd.Description
+	}
+
+	if err := workflow.UpdateDetails(name, description); err != nil {
+		return nil, errs.Invalid("invalid workflow: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save workflow
+	err = h.workflowRepository.Update(ctx, workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save workflow: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range workflow.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	workflow.ClearDomainEvents()
+
+	return &UpdateWorkflowResult{
+		WorkflowID:  workflowID.Value(),
+		Name:        workflow.Name(),
+		Description: workflow.Description(),
+		Version:     workflow.Version(),
+	}, nil
+}
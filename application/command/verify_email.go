@@ -0,0 +1,95 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// VerifyEmailCommand represents a command to verify a user's email address with the token sent to it
+type VerifyEmailCommand struct {
+	Token string
+}
+
+// VerifyEmailCommandHandler handles VerifyEmailCommand
+type VerifyEmailCommandHandler struct {
+	userRepository     domain.UserRepository
+	verificationTokens VerificationTokens
+	eventPublisher     event.EventPublisher
+}
+
+// NewVerifyEmailCommandHandler creates a new VerifyEmailCommandHandler
+func NewVerifyEmailCommandHandler(
+	userRepository domain.UserRepository,
+	verificationTokens VerificationTokens,
+	eventPublisher event.EventPublisher,
+) *VerifyEmailCommandHandler {
+	return &VerifyEmailCommandHandler{
+		userRepository:     userRepository,
+		verificationTokens: verificationTokens,
+		eventPublisher:     eventPublisher,
+	}
+}
+
+// VerifyEmailResult represents the result of verifying an email address
+type VerifyEmailResult struct {
+	UserID string
+	Email  string
+	Error  error
+}
+
+// Handle handles the VerifyEmailCommand.
+// The token is used up, and it only counts for the address it was sent to.
+func (h *VerifyEmailCommandHandler) Handle(ctx context.Context, cmd VerifyEmailCommand) (*VerifyEmailResult, error) {
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Redeem token
+	subject, email, err := h.verificationTokens.Redeem(cmd.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get user
+	userID, err := value.NewUserID(subject)
+	if err != nil {
+		return nil, errs.Invalid("invalid verification token")
+	}
+
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil || user.Email() != email {
+		return nil, errs.Invalid("invalid verification token")
+	}
+
+	// Verify email
+	if err := user.VerifyEmail(); err != nil {
+		return nil, err
+	}
+
+	// Save user
+	err = h.userRepository.Update(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range user.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	user.ClearDomainEvents()
+
+	return &VerifyEmailResult{
+		UserID: user.ID().Value(),
+		Email:  user.Email(),
+	}, nil
+}
@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// VoteTaskCommand represents a command to vote for a task, or withdraw a vote
+type VoteTaskCommand struct {
+	TaskID  string
+	VoterID string
+	Remove  bool
+}
+
+// VoteTaskCommandHandler handles VoteTaskCommand
+type VoteTaskCommandHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	eventPublisher    event.EventPublisher
+	authorizer        *Authorizer
+}
+
+// NewVoteTaskCommandHandler creates a new VoteTaskCommandHandler
+func NewVoteTaskCommandHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	userRepository domain.UserRepository,
+	eventPublisher event.EventPublisher,
+	authorizer *Authorizer,
+) *VoteTaskCommandHandler {
+	return &VoteTaskCommandHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		eventPublisher:    eventPublisher,
+		authorizer:        authorizer,
+	}
+}
+
+// VoteTaskResult represents the result of voting for a task
+type VoteTaskResult struct {
+	VoteCount int
+	Error     error
+}
+
+// Handle handles the VoteTaskCommand
+func (h *VoteTaskCommandHandler) Handle(ctx context.Context, cmd VoteTaskCommand) (*VoteTaskResult, error) {
+	// Parse IDs
+	taskID, err := value.NewTaskID(cmd.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	voterID, err := value.NewUserID(cmd.VoterID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Validate voter exists
+	_, err = h.userRepository.GetByID(ctx, voterID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Get task
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, task.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Check permission
+	if err := h.authorizer.Authorize(ctx, cmd.VoterID, service.PermissionDiscussTask, project, task); err != nil {
+		return nil, err
+	}
+
+	// Record or withdraw vote
+	if cmd.Remove {
+		err = task.RemoveVote(voterID)
+	} else {
+		err = task.Vote(voterID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update vote: %w", err)
+	}
+
+	// Stop if the request was cancelled or timed out
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Save task
+	err = h.taskRepository.Update(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save task: %w", err)
+	}
+
+	// Publish domain events
+	for _, domainEvent := range task.DomainEvents() {
+		err = h.eventPublisher.Publish(ctx, domainEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+
+	task.ClearDomainEvents()
+
+	return &VoteTaskResult{
+		VoteCount: task.VoteCount(),
+	}, nil
+}
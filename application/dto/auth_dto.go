@@ -0,0 +1,109 @@
+package dto
+
+import "time"
+
+// SessionDTO is the data transfer object for an issued session token
+type SessionDTO struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"token_type"`
+	UserID    string    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RegisterRequest represents the request to register a user with a password
+type RegisterRequest struct {
+	Email     string `json:"email" binding:"required"`
+	FirstName string `json:"first_name" binding:"required"`
+	LastName  string `json:"last_name" binding:"required"`
+	Password  string `json:"password" binding:"required"`
+}
+
+// SignUpRequest represents the request to provision a new organization with its owner
+type SignUpRequest struct {
+	OrganizationName string `json:"organization_name" binding:"required"`
+	Email            string `json:"email" binding:"required"`
+	FirstName        string `json:"first_name" binding:"required"`
+	LastName         string `json:"last_name" binding:"required"`
+	Password         string `json:"password" binding:"required"`
+}
+
+// SignUpDTO is the data transfer object for a provisioned tenant, its owner signed in
+type SignUpDTO struct {
+	OrganizationID string   `json:"organization_id"`
+	UserID         string   `json:"user_id"`
+	WorkflowID     string   `json:"workflow_id"`
+	ProjectID      string   `json:"project_id"`
+	Tokens         TokenDTO `json:"tokens"`
+}
+
+// LoginRequest represents the request to sign in
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ChangePasswordRequest represents the request to change a password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// TokenDTO is the data transfer object for issued JSON Web Tokens
+type TokenDTO struct {
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresIn        int       `json:"expires_in"`
+	UserID           string    `json:"user_id"`
+	Roles            []string  `json:"roles"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// RefreshTokenRequest represents the request to exchange a refresh token for new tokens
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// VerifyEmailRequest represents the request to verify an email address with the token sent to it
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// DeactivateUserRequest represents the request to deactivate a user
+type DeactivateUserRequest struct {
+	FallbackAssigneeID string `json:"fallback_assignee_id"` // optional, takes over the user's open tasks
+}
+
+// ChangeEmailRequest represents the request to change a user's email address
+type ChangeEmailRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// UpdateUserRequest represents the request to update a user's profile, omitted fields are kept
+type UpdateUserRequest struct {
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+	Email     *string `json:"email,omitempty"` // a new address has to be verified again
+}
+
+// UserLocaleRequest represents the request to set the locale a user reads in
+type UserLocaleRequest struct {
+	Locale string `json:"locale" binding:"required"` // BCP 47 tag such as "de" or "pt-BR"
+}
+
+// UserManagerRequest represents the request to set who a user reports to
+type UserManagerRequest struct {
+	ManagerID string `json:"manager_id" binding:"required"`
+}
+
+// OutOfOfficeDTO represents a period a user is away
+type OutOfOfficeDTO struct {
+	Start string `json:"start"` // RFC 3339
+	End   string `json:"end"`   // RFC 3339, exclusive
+	Note  string `json:"note,omitempty"`
+}
+
+// UserOutOfOfficeRequest represents the request to replace the periods a user is away
+type UserOutOfOfficeRequest struct {
+	Periods []OutOfOfficeDTO `json:"periods" binding:"required"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+// WIP states of a board column
+const (
+	WIPStateNoLimit    = "NO_LIMIT"
+	WIPStateUnderLimit = "UNDER_LIMIT"
+	WIPStateAtLimit    = "AT_LIMIT"
+	WIPStateOverLimit  = "OVER_LIMIT"
+)
+
+// BoardDTO is the data transfer object for a project's kanban board
+type BoardDTO struct {
+	ProjectID  string           `json:"project_id"`
+	WorkflowID string           `json:"workflow_id"`
+	TaskCount  int              `json:"task_count"`
+	Sort       string           `json:"sort"` // how tasks are ordered within columns, CREATED or PRIORITY
+	Columns    []BoardColumnDTO `json:"columns"`
+}
+
+// BoardColumnDTO is one workflow column of a board with its tasks
+type BoardColumnDTO struct {
+	Status      string     `json:"status"`
+	Description string     `json:"description"`
+	Order       int        `json:"order"`
+	IsFinal     bool       `json:"is_final"`
+	Count       int        `json:"count"`
+	WIPLimit    int        `json:"wip_limit"`
+	WIPState    string     `json:"wip_state"`
+	Tasks       []*TaskDTO `json:"tasks"`
+}
+
+// SetBoardSortRequest represents the request to choose how the caller's board of a project is sorted
+type SetBoardSortRequest struct {
+	Sort string `json:"sort" binding:"required,oneof=CREATED PRIORITY"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// EventRecordDTO is one stored domain event as the audit log shows it
+type EventRecordDTO struct {
+	Position      int64                  `json:"position"` // place in the whole log, the cursor of the next page
+	EventID       string                 `json:"event_id,omitempty"`
+	EventType     string                 `json:"event_type"`
+	AggregateID   string                 `json:"aggregate_id"`
+	AggregateType string                 `json:"aggregate_type"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+	Payload       map[string]interface{} `json:"payload"`
+}
+
+// EventPageDTO is one page of the audit log
+type EventPageDTO struct {
+	Events    []EventRecordDTO `json:"events"`
+	Count     int              `json:"count"`
+	NextAfter int64            `json:"next_after"` // pass as after to read the next page, 0 when the page is empty
+}
+
+// EventEnvelopeDTO is the wire format of a serialized domain event
+type EventEnvelopeDTO struct {
+	EventID       string                 `json:"event_id,omitempty"`
+	EventType     string                 `json:"event_type"`
+	SchemaVersion int                    `json:"schema_version"`
+	AggregateID   string                 `json:"aggregate_id"`
+	AggregateType string                 `json:"aggregate_type"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+	Payload       map[string]interface{} `json:"payload"`
+}
+
+// EventSchemaDTO describes the current wire schema of one event type
+type EventSchemaDTO struct {
+	EventType string                 `json:"event_type"`
+	Version   int                    `json:"version"`
+	Schema    map[string]interface{} `json:"schema"`
+}
@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+// SubscriberMetricsDTO reports how one subscriber or projection keeps up with the event stream
+type SubscriberMetricsDTO struct {
+	Name            string     `json:"name"`
+	Processed       int64      `json:"processed"`
+	Errors          int64      `json:"errors"`
+	ErrorRate       float64    `json:"error_rate"`
+	LastEventAt     *time.Time `json:"last_event_at,omitempty"`
+	LastProcessedAt *time.Time `json:"last_processed_at,omitempty"`
+	LagSeconds      float64    `json:"lag_seconds"`
+	AvgLatencyMs    float64    `json:"avg_latency_ms"`
+	MaxLatencyMs    float64    `json:"max_latency_ms"`
+}
+
+// EventMetricsSnapshotDTO is a point-in-time view of event delivery health
+type EventMetricsSnapshotDTO struct {
+	EventsStored      int64                  `json:"events_stored"`
+	EventsDelivered   int64                  `json:"events_delivered"`
+	OutboxBacklog     int64                  `json:"outbox_backlog"`
+	LastStoredEventAt *time.Time             `json:"last_stored_event_at,omitempty"`
+	Subscribers       []SubscriberMetricsDTO `json:"subscribers"`
+}
+
+// EventTypeCountDTO is how many events of one type were stored
+type EventTypeCountDTO struct {
+	EventType string `json:"event_type"`
+	Count     int64  `json:"count"`
+}
+
+// AggregateTypeCountDTO is how many events aggregates of one type raised
+type AggregateTypeCountDTO struct {
+	AggregateType string `json:"aggregate_type"`
+	Count         int64  `json:"count"`
+}
+
+// EventProducerDTO is one aggregate and how many events it raised
+type EventProducerDTO struct {
+	AggregateType string    `json:"aggregate_type"`
+	AggregateID   string    `json:"aggregate_id"`
+	Events        int64     `json:"events"`
+	LastEventAt   time.Time `json:"last_event_at"`
+}
+
+// ProjectEventStatsDTO are the events raised within one project and its busiest aggregates
+type ProjectEventStatsDTO struct {
+	ProjectID    string             `json:"project_id"`
+	Events       int64              `json:"events"`
+	TopProducers []EventProducerDTO `json:"top_producers"`
+}
+
+// EventStatsDTO breaks the stored events down by type, aggregate and project for capacity analysis
+type EventStatsDTO struct {
+	EventTypes     []EventTypeCountDTO     `json:"event_types"`
+	AggregateTypes []AggregateTypeCountDTO `json:"aggregate_types"`
+	Projects       []ProjectEventStatsDTO  `json:"projects"`
+}
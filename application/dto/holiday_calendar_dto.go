@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// HolidayDTO is the data transfer object for a holiday, with the fields its rule uses
+type HolidayDTO struct {
+	Name    string `json:"name" binding:"required"`
+	Rule    string `json:"rule" binding:"required"` // DATE, ANNUAL or NTH_WEEKDAY
+	Date    string `json:"date,omitempty"`          // DATE only, e.g. 2025-12-24
+	Month   int    `json:"month,omitempty"`         // ANNUAL and NTH_WEEKDAY
+	Day     int    `json:"day,omitempty"`           // ANNUAL only
+	Weekday string `json:"weekday,omitempty"`       // NTH_WEEKDAY only, e.g. THURSDAY
+	Nth     int    `json:"nth,omitempty"`           // NTH_WEEKDAY only, 1 to 4 or -1 for the last
+}
+
+// HolidayCalendarDTO is the data transfer object for a HolidayCalendar
+type HolidayCalendarDTO struct {
+	ID             string       `json:"id"`
+	OrganizationID string       `json:"organization_id"`
+	Region         string       `json:"region"`
+	Name           string       `json:"name"`
+	Holidays       []HolidayDTO `json:"holidays"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+// CreateHolidayCalendarRequest represents the request to create a holiday calendar
+type CreateHolidayCalendarRequest struct {
+	OrganizationID string       `json:"organization_id" binding:"required"`
+	Region         string       `json:"region" binding:"required"` // e.g. DE-BY
+	Name           string       `json:"name" binding:"required"`
+	Holidays       []HolidayDTO `json:"holidays"`
+}
+
+// UpdateHolidayCalendarRequest represents the request to rename a holiday calendar and replace its holidays
+type UpdateHolidayCalendarRequest struct {
+	Name     string       `json:"name" binding:"required"`
+	Holidays []HolidayDTO `json:"holidays"`
+}
+
+// SetProjectHolidayCalendarRequest represents the request to make a project follow a holiday calendar
+type SetProjectHolidayCalendarRequest struct {
+	CalendarID string `json:"calendar_id" binding:"required"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// IntegrationHealthDTO is what the health dashboard shows about one integration
+type IntegrationHealthDTO struct {
+	Name                string     `json:"name"`
+	Kind                string     `json:"kind"`
+	State               string     `json:"state"` // CLOSED, OPEN or HALF_OPEN
+	Healthy             bool       `json:"healthy"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastFailureAt       *time.Time `json:"last_failure_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	RecentFailures      int        `json:"recent_failures"`
+	Calls               int64      `json:"calls"`
+	Rejected            int64      `json:"rejected"`
+	NextRetryAt         *time.Time `json:"next_retry_at,omitempty"`
+}
+
+// IntegrationHealthReportDTO summarizes every configured integration
+type IntegrationHealthReportDTO struct {
+	CheckedAt     time.Time              `json:"checked_at"`
+	WindowSeconds int64                  `json:"window_seconds"`
+	Integrations  []IntegrationHealthDTO `json:"integrations"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// IntegrityIssueDTO is one disagreement between a project's task list and the tasks themselves
+type IntegrityIssueDTO struct {
+	Kind      string `json:"kind"` // MISSING_TASK, ORPHANED_TASK, UNLISTED_TASK or WRONG_PROJECT
+	ProjectID string `json:"project_id"`
+	TaskID    string `json:"task_id"`
+	Detail    string `json:"detail"`
+}
+
+// IntegrityReportDTO is the data transfer object for a project and task reconciliation
+type IntegrityReportDTO struct {
+	ProjectsChecked int                 `json:"projects_checked"`
+	TasksChecked    int                 `json:"tasks_checked"`
+	Issues          []IntegrityIssueDTO `json:"issues"`
+}
@@ -0,0 +1,71 @@
+package dto
+
+// OrganizationQuotaRequest holds the limits of an organization, zero meaning unlimited
+type OrganizationQuotaRequest struct {
+	MaxProjects        int   `json:"max_projects"`
+	MaxTasks           int   `json:"max_tasks"`
+	MaxAttachmentBytes int64 `json:"max_attachment_bytes"`
+	APICallsPerMinute  int   `json:"api_calls_per_minute"`
+}
+
+// CreateOrganizationRequest represents the request to create an organization
+type CreateOrganizationRequest struct {
+	Name      string                   `json:"name" binding:"required"`
+	MemberIDs []string                 `json:"member_ids"`
+	Quota     OrganizationQuotaRequest `json:"quota"`
+}
+
+// OrganizationMemberRequest represents the request to add a member to an organization
+type OrganizationMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// QuotaLineDTO compares the usage of one resource to its limit
+type QuotaLineDTO struct {
+	Used      int64 `json:"used"`
+	Limit     int64 `json:"limit"` // 0 when unlimited
+	Unlimited bool  `json:"unlimited"`
+	Exceeded  bool  `json:"exceeded"` // usage is over a limit lowered after the fact
+}
+
+// OrganizationUsageDTO reports an organization's usage against its quota
+type OrganizationUsageDTO struct {
+	OrganizationID    string       `json:"organization_id"`
+	Name              string       `json:"name"`
+	Members           int          `json:"members"`
+	Projects          QuotaLineDTO `json:"projects"`
+	Tasks             QuotaLineDTO `json:"tasks"`
+	AttachmentBytes   QuotaLineDTO `json:"attachment_bytes"`
+	APICallsPerMinute QuotaLineDTO `json:"api_calls_per_minute"` // used counts the current minute
+}
+
+// OrganizationDirectoryDTO lists the people of an organization with their teams and reporting lines
+type OrganizationDirectoryDTO struct {
+	OrganizationID string               `json:"organization_id"`
+	Name           string               `json:"name"`
+	Members        []DirectoryMemberDTO `json:"members"` // ordered by name
+	Teams          []TeamDTO            `json:"teams"`   // teams led by a member, ordered by name
+}
+
+// DirectoryMemberDTO is one member of an organization directory
+type DirectoryMemberDTO struct {
+	UserID          string   `json:"user_id"`
+	FullName        string   `json:"full_name"`
+	Email           string   `json:"email"`
+	Active          bool     `json:"active"`
+	Roles           []string `json:"roles"`
+	ManagerID       string   `json:"manager_id,omitempty"`
+	DirectReportIDs []string `json:"direct_report_ids"`
+	EscalationChain []string `json:"escalation_chain"` // the manager first, then their manager and so on
+	TeamIDs         []string `json:"team_ids"`
+}
+
+// MonthlyUsageDTO totals an organization's metered usage in one billing period
+type MonthlyUsageDTO struct {
+	OrganizationID  string `json:"organization_id"`
+	Period          string `json:"period"` // UTC month, as 2006-01
+	TasksCreated    int64  `json:"tasks_created"`
+	AttachmentBytes int64  `json:"attachment_bytes"`
+	ActiveUsers     int64  `json:"active_users"`
+	Events          int    `json:"events"`
+}
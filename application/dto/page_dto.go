@@ -0,0 +1,51 @@
+package dto
+
+// PageDTO tells where a page of a list falls
+type PageDTO struct {
+	Count      int    `json:"count"`
+	Total      int    `json:"total"` // matches of the whole list
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"` // pass as cursor to read the next page, empty on the last one
+}
+
+// ProjectPageDTO is one page of projects
+type ProjectPageDTO struct {
+	Projects []*ProjectDTO `json:"projects"`
+	PageDTO
+}
+
+// UserPageDTO is one page of users
+type UserPageDTO struct {
+	Users []*UserDTO `json:"users"`
+	PageDTO
+}
+
+// WorkflowPageDTO is one page of workflows
+type WorkflowPageDTO struct {
+	Workflows []*WorkflowDTO `json:"workflows"`
+	PageDTO
+}
+
+// TaskPageDTO is one page of tasks
+type TaskPageDTO struct {
+	Tasks []*TaskDTO `json:"tasks"`
+	PageDTO
+}
+
+// SprintPageDTO is one page of sprints
+type SprintPageDTO struct {
+	Sprints []*SprintDTO `json:"sprints"`
+	PageDTO
+}
+
+// WidgetPageDTO is one page of dashboard widgets
+type WidgetPageDTO struct {
+	Widgets []*WidgetDTO `json:"widgets"`
+	PageDTO
+}
+
+// HolidayCalendarPageDTO is one page of holiday calendars
+type HolidayCalendarPageDTO struct {
+	Calendars []*HolidayCalendarDTO `json:"calendars"`
+	PageDTO
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// PresenceViewerDTO is a user currently viewing a task or board
+type PresenceViewerDTO struct {
+	UserID string    `json:"user_id"`
+	Name   string    `json:"name"`
+	Since  time.Time `json:"since"`
+}
+
+// PresenceDTO lists who is viewing a task or board
+type PresenceDTO struct {
+	Kind    string              `json:"kind"`
+	ItemID  string              `json:"item_id"`
+	Viewers []PresenceViewerDTO `json:"viewers"`
+}
+
+// PresenceHeartbeatRequest represents a REST presence heartbeat
+type PresenceHeartbeatRequest struct {
+	Kind      string `json:"kind" binding:"required,oneof=TASK BOARD"`
+	ItemID    string `json:"item_id" binding:"required"`
+	SessionID string `json:"session_id"` // empty starts a new session
+}
+
+// PresenceMessage is a message of the presence WebSocket protocol.
+// Clients send "view" (with kind and item_id) and "leave", the server
+// answers with "presence" updates, "edit_lock" changes of a viewed task
+// (no edit_lock means the description is free) and "error" messages.
+type PresenceMessage struct {
+	Type     string              `json:"type"`
+	Kind     string              `json:"kind,omitempty"`
+	ItemID   string              `json:"item_id,omitempty"`
+	Viewers  []PresenceViewerDTO `json:"viewers,omitempty"`
+	EditLock *EditLockDTO        `json:"edit_lock,omitempty"`
+	Message  string              `json:"message,omitempty"`
+}
@@ -4,26 +4,251 @@ import "time"
 
 // ProjectDTO is the data transfer object for Project
 type ProjectDTO struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	OwnerID     string    `json:"owner_id"`
-	WorkflowID  string    `json:"workflow_id"`
-	TaskCount   int       `json:"task_count"`
-	Archived    bool      `json:"archived"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description"`
+	OwnerID           string    `json:"owner_id"`
+	WorkflowID        string    `json:"workflow_id"`
+	HolidayCalendarID string    `json:"holiday_calendar_id"` // empty when the project observes no holidays
+	TaskCount         int       `json:"task_count"`
+	CompletedTasks    int       `json:"completed_tasks"`
+	Archived          bool      `json:"archived"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // CreateProjectRequest represents the request to create a project
 type CreateProjectRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
-	WorkflowID  string `json:"workflow_id" binding:"required"`
+	WorkflowID  string `json:"workflow_id"` // empty uses the default workflow
 }
 
-// UpdateProjectRequest represents the request to update a project
+// UpdateProjectRequest represents the request to update a project, omitted fields are kept
 type UpdateProjectRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-}
\ No newline at end of file
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+// ProjectStatsDTO is the data transfer object for project statistics
+type ProjectStatsDTO struct {
+	ProjectID     string         `json:"project_id"`
+	TaskCount     int            `json:"task_count"`
+	TasksByStatus map[string]int `json:"tasks_by_status"`
+	SLI           SLIStatsDTO    `json:"sli"`
+
+	DeadlineSlipCount int `json:"deadline_slip_count"` // deadline postponements across all tasks
+	SlippedTaskCount  int `json:"slipped_task_count"`  // tasks whose deadline was postponed at least once
+}
+
+// SLIStatsDTO is the data transfer object for service level indicators
+type SLIStatsDTO struct {
+	FirstResponseTargetSeconds  int64 `json:"first_response_target_seconds"`
+	ResolutionTargetSeconds     int64 `json:"resolution_target_seconds"`
+	RespondedCount              int   `json:"responded_count"`
+	ResolvedCount               int   `json:"resolved_count"`
+	AvgFirstResponseSeconds     int64 `json:"avg_first_response_seconds"`
+	AvgResolutionSeconds        int64 `json:"avg_resolution_seconds"`
+	FirstResponseBreachCount    int   `json:"first_response_breach_count"`
+	ResolutionBreachCount       int   `json:"resolution_breach_count"`
+}
+
+// SetProjectSLORequest represents the request to configure project SLO targets
+type SetProjectSLORequest struct {
+	FirstResponse string `json:"first_response"` // Go duration, e.g. "4h"
+	Resolution    string `json:"resolution"`     // Go duration, e.g. "72h"
+}
+
+// ArchiveProjectRequest represents the request to archive a project
+type ArchiveProjectRequest struct {
+	Policy string `json:"policy" binding:"oneof=FREEZE CANCEL"` // FREEZE by default
+}
+
+// NotificationRouteDTO is the data transfer object for a project notification routing rule
+type NotificationRouteDTO struct {
+	EventType string `json:"event_type" binding:"required"`                        // event type or "*" for all events
+	Channel   string `json:"channel" binding:"required,oneof=SLACK EMAIL WEBHOOK"` // SLACK, EMAIL or WEBHOOK
+	Target    string `json:"target" binding:"required"`                            // Slack channel, email address or URL
+	Delivery  string `json:"delivery"`                                             // IMMEDIATE (default), DAILY_DIGEST or WEEKLY_DIGEST
+}
+
+// SetNotificationRoutesRequest represents the request to replace a project's notification routes
+type SetNotificationRoutesRequest struct {
+	Routes []NotificationRouteDTO `json:"routes"`
+}
+
+// MilestoneDTO is the data transfer object for a project milestone
+type MilestoneDTO struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	DueDate     time.Time            `json:"due_date"`
+	TaskIDs     []string             `json:"task_ids"`
+	Progress    MilestoneProgressDTO `json:"progress"`
+	Overdue     bool                 `json:"overdue"`
+	ReachedAt   *time.Time           `json:"reached_at,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// MilestoneProgressDTO is the data transfer object for milestone progress
+type MilestoneProgressDTO struct {
+	Total     int     `json:"total"`
+	Completed int     `json:"completed"`
+	Percent   float64 `json:"percent"`
+}
+
+// MilestoneRequest represents the request to create or update a milestone
+type MilestoneRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	DueDate     string   `json:"due_date" binding:"required"`
+	TaskIDs     []string `json:"task_ids"`
+}
+
+// ProjectSettingsDTO is the data transfer object for project settings
+type ProjectSettingsDTO struct {
+	DefaultPriority         string             `json:"default_priority"`
+	DefaultAssigneeID       string             `json:"default_assignee_id,omitempty"`
+	RequireDeadlineOnCreate bool               `json:"require_deadline_on_create"`
+	AllowComments           bool               `json:"allow_comments"`
+	PriorityScheme          []PriorityLevelDTO `json:"priority_scheme"` // least urgent first
+	InboxAddresses          []InboxAddressDTO  `json:"inbox_addresses"`
+}
+
+// PriorityLevelDTO is the data transfer object for a level of a project's priority scheme
+type PriorityLevelDTO struct {
+	Name string `json:"name"`
+	Rank int    `json:"rank"` // higher is more urgent
+}
+
+// SetPrioritySchemeRequest represents the request to replace a project's priority ladder
+type SetPrioritySchemeRequest struct {
+	Levels          []PriorityLevelDTO `json:"levels"` // empty restores LOW, MEDIUM, HIGH, CRITICAL
+	DefaultPriority string             `json:"default_priority"`
+	Remap           map[string]string  `json:"remap"` // dropped priority -> priority its tasks move to
+}
+
+// InboxAddressDTO is the data transfer object for an inbound email address feeding a project
+type InboxAddressDTO struct {
+	Token     string    `json:"token"`
+	Address   string    `json:"address"`
+	Label     string    `json:"label,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddInboxAddressRequest represents the request to generate a project inbox address
+type AddInboxAddressRequest struct {
+	Label string `json:"label"`
+}
+
+// InboundEmailRequest represents an email relayed by the inbound mail provider
+type InboundEmailRequest struct {
+	To      []string `json:"to" binding:"required"`
+	Cc      []string `json:"cc"`
+	From    string   `json:"from" binding:"required"`
+	Subject string   `json:"subject"`
+	Text    string   `json:"text"`
+}
+
+// SetProjectSettingsRequest represents the request to replace a project's settings
+type SetProjectSettingsRequest struct {
+	DefaultPriority         string `json:"default_priority"`
+	DefaultAssigneeID       string `json:"default_assignee_id"`
+	RequireDeadlineOnCreate bool   `json:"require_deadline_on_create"`
+	AllowComments           *bool  `json:"allow_comments"` // omitted keeps comments enabled
+}
+
+// MoneyDTO is the data transfer object for an amount of money
+type MoneyDTO struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// TaskCostDTO is one task's share of a project's spend
+type TaskCostDTO struct {
+	TaskID string   `json:"task_id"`
+	Title  string   `json:"title"`
+	Spent  MoneyDTO `json:"spent"`
+}
+
+// BudgetSummaryDTO summarizes a project's spend against its budget
+type BudgetSummaryDTO struct {
+	ProjectID   string        `json:"project_id"`
+	Budget      *MoneyDTO     `json:"budget,omitempty"`
+	Spent       MoneyDTO      `json:"spent"`
+	Remaining   *MoneyDTO     `json:"remaining,omitempty"`
+	PercentUsed float64       `json:"percent_used"`
+	Exceeded    bool          `json:"exceeded"`
+	Tasks       []TaskCostDTO `json:"tasks"`
+}
+
+// SetProjectBudgetRequest represents the request to set or clear a project's budget
+type SetProjectBudgetRequest struct {
+	Amount   string `json:"amount"` // empty clears the budget
+	Currency string `json:"currency"`
+}
+
+// SetProjectAccessRequest represents the request to replace a project's visibility and members
+type SetProjectAccessRequest struct {
+	Visibility string   `json:"visibility" binding:"required,oneof=WORKSPACE RESTRICTED"`
+	MemberIDs  []string `json:"member_ids"`
+}
+
+// ChangeProjectWorkflowRequest represents the request to move a project onto another workflow
+type ChangeProjectWorkflowRequest struct {
+	WorkflowID string `json:"workflow_id" binding:"required"`
+}
+
+// MigrateProjectWorkflowRequest represents the request to move a project's tasks to the latest workflow version
+type MigrateProjectWorkflowRequest struct {
+	StatusMapping map[string]string `json:"status_mapping"` // task status, as its workflow names it -> status of the latest version, for statuses it dropped
+}
+
+// PlanWorkflowMigrationRequest represents the request to plan moving a project's tasks onto another workflow
+type PlanWorkflowMigrationRequest struct {
+	WorkflowID    string            `json:"workflow_id" binding:"required"`
+	StatusMapping map[string]string `json:"status_mapping"` // task status, as its workflow names it -> status of the new workflow, for statuses it does not have
+}
+
+// ApplyWorkflowMigrationRequest represents the request to apply a project's planned workflow migration
+type ApplyWorkflowMigrationRequest struct {
+	BatchSize int `json:"batch_size"` // omitted moves 100 tasks between progress reports
+}
+
+// WorkflowMigrationDTO represents a project's recorded workflow migration plan and its progress
+type WorkflowMigrationDTO struct {
+	ID             string                     `json:"id"`
+	FromWorkflowID string                     `json:"from_workflow_id"`
+	ToWorkflowID   string                     `json:"to_workflow_id"`
+	ToVersion      int                        `json:"to_version"`
+	Status         string                     `json:"status"` // PLANNED, APPLYING, APPLIED or ROLLED_BACK
+	Total          int                        `json:"total"`
+	Applied        int                        `json:"applied"`
+	Moves          []WorkflowMigrationMoveDTO `json:"moves"`
+	PlannedBy      string                     `json:"planned_by"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+}
+
+// WorkflowMigrationMoveDTO counts the tasks a workflow migration moves from one status to another
+type WorkflowMigrationMoveDTO struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Tasks      int    `json:"tasks"`
+}
+
+// AssignProjectRoleRequest represents the request to give a user a role in a project
+type AssignProjectRoleRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"oneof=VIEWER MEMBER ADMIN"` // empty revokes the assigned role
+}
+
+// ActivityEntryDTO represents one entry of a project's activity stream
+type ActivityEntryDTO struct {
+	Sequence   int       `json:"sequence"` // position in the project's stream from 1, the cursor of the next page
+	EventType  string    `json:"event_type"`
+	TaskID     string    `json:"task_id,omitempty"`
+	ActorID    string    `json:"actor_id,omitempty"`
+	Summary    string    `json:"summary"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// SuggestionDTO is the data transfer object for a search suggestion
+type SuggestionDTO struct {
+	Type  string  `json:"type"`
+	ID    string  `json:"id"`
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+// RecentViewDTO is the data transfer object for a recently viewed item
+type RecentViewDTO struct {
+	Type     string    `json:"type"`
+	ID       string    `json:"id"`
+	Label    string    `json:"label"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+// SearchResultDTO is the data transfer object for a workspace search result
+type SearchResultDTO struct {
+	Type      string  `json:"type"` // task, comment or attachment
+	ID        string  `json:"id"`
+	TaskID    string  `json:"task_id"`
+	ProjectID string  `json:"project_id"`
+	Title     string  `json:"title"`
+	Snippet   string  `json:"snippet"` // HTML-escaped, matched words wrapped in <mark>
+	Score     float64 `json:"score"`
+}
+
+// TaskSearchResultDTO is the data transfer object for a task found by a task search
+type TaskSearchResultDTO struct {
+	Task    *TaskDTO `json:"task"`
+	Snippet string   `json:"snippet,omitempty"` // best matching excerpt as in SearchResultDTO, empty without search text
+	Score   float64  `json:"score"`
+}
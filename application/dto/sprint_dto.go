@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// SprintDTO is the data transfer object for a Sprint
+type SprintDTO struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"project_id"`
+	Name        string     `json:"name"`
+	Goal        string     `json:"goal"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	Status      string     `json:"status"`
+	TaskIDs     []string   `json:"task_ids"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// CreateSprintRequest represents the request to create a sprint
+type CreateSprintRequest struct {
+	ProjectID string `json:"project_id" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+}
+
+// SprintTaskRequest represents the request to add a task to a sprint
+type SprintTaskRequest struct {
+	TaskID string `json:"task_id" binding:"required"`
+}
@@ -11,8 +11,17 @@ type TaskDTO struct {
 	Status      string            `json:"status"`
 	Priority    string            `json:"priority"`
 	Assignee    *AssignmentDTO    `json:"assignee,omitempty"`
+	TeamID      string            `json:"team_id,omitempty"`
+	WorkflowVersion string        `json:"workflow_version,omitempty"` // the workflow version the task is pinned to, such as default/v2
 	Deadline    *DeadlineDTO      `json:"deadline,omitempty"`
+	EditLock    *EditLockDTO      `json:"edit_lock,omitempty"`
+	EstimatedHours float64        `json:"estimated_hours,omitempty"`
 	Comments    []CommentDTO      `json:"comments,omitempty"`
+	Links       []TaskLinkDTO     `json:"links,omitempty"`
+	Attachments []AttachmentDTO   `json:"attachments,omitempty"`
+	Costs       []CostEntryDTO    `json:"costs,omitempty"`
+	VoteCount   int               `json:"vote_count"`
+	ApprovalCount int             `json:"approval_count"`
 	CreatedAt   time.Time         `json:"created_at"`
 	UpdatedAt   time.Time         `json:"updated_at"`
 	CreatedBy   string            `json:"created_by"`
@@ -20,18 +29,51 @@ type TaskDTO struct {
 
 // CommentDTO is the data transfer object for Comment
 type CommentDTO struct {
-	ID        string    `json:"id"`
-	Content   string    `json:"content"`
-	AuthorID  string    `json:"author_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                string    `json:"id"`
+	Content           string    `json:"content"`
+	Version           int       `json:"version"`
+	TranslatedContent string    `json:"translated_content,omitempty"` // with ?translate=true
+	TranslatedLocale  string    `json:"translated_locale,omitempty"`
+	AuthorID          string    `json:"author_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// AttachmentDTO is the data transfer object for Attachment
+type AttachmentDTO struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	UploadedBy  string    `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// CostEntryDTO is the data transfer object for CostEntry
+type CostEntryDTO struct {
+	ID          string    `json:"id"`
+	Amount      MoneyDTO  `json:"amount"`
+	Description string    `json:"description"`
+	RecordedBy  string    `json:"recorded_by"`
+	IncurredAt  time.Time `json:"incurred_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TaskLinkDTO is the data transfer object for TaskLink
+type TaskLinkDTO struct {
+	TargetTaskID string    `json:"target_task_id"`
+	LinkType     string    `json:"link_type"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    string    `json:"created_by"`
 }
 
 // AssignmentDTO is the data transfer object for Assignment
 type AssignmentDTO struct {
-	AssigneeID string    `json:"assignee_id"`
-	AssignedAt time.Time `json:"assigned_at"`
-	AssignedBy string    `json:"assigned_by"`
+	AssigneeID     string     `json:"assignee_id"`
+	AssignedAt     time.Time  `json:"assigned_at"`
+	AssignedBy     string     `json:"assigned_by"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	EscalatedAt    *time.Time `json:"escalated_at,omitempty"`
 }
 
 // DeadlineDTO is the data transfer object for Deadline
@@ -41,6 +83,13 @@ type DeadlineDTO struct {
 	DaysUntil   int       `json:"days_until"`
 }
 
+// EditLockDTO is the data transfer object for an active edit lock on a task description
+type EditLockDTO struct {
+	HolderID   string    `json:"holder_id"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
 // CreateTaskRequest represents the request to create a task
 type CreateTaskRequest struct {
 	ProjectID   string `json:"project_id" binding:"required"`
@@ -49,6 +98,8 @@ type CreateTaskRequest struct {
 	Priority    string `json:"priority" binding:"required"`
 	AssigneeID  string `json:"assignee_id"`
 	Deadline    string `json:"deadline"`
+	EstimatedHours float64 `json:"estimated_hours"`
+	EnforceUnique bool `json:"enforce_unique"`
 }
 
 // UpdateTaskRequest represents the request to update a task
@@ -67,7 +118,39 @@ type AssignTaskRequest struct {
 
 // UpdateTaskStatusRequest represents the request to update task status
 type UpdateTaskStatusRequest struct {
-	Status string `json:"status" binding:"required"`
+	Status   string            `json:"status" binding:"required"`
+	Reason   string            `json:"reason"` // required when moving back to an earlier status
+	Metadata map[string]string `json:"metadata"`
+}
+
+// CompareAndSetStatusRequest represents a status change guarded by the expected current status
+type CompareAndSetStatusRequest struct {
+	ExpectedStatus string            `json:"expected_status" binding:"required"`
+	Status         string            `json:"status" binding:"required"`
+	Reason         string            `json:"reason"` // required when moving back to an earlier status
+	Metadata       map[string]string `json:"metadata"`
+}
+
+// EditLockRequest represents the request to acquire or renew an edit lock
+type EditLockRequest struct {
+	TTLSeconds int `json:"ttl_seconds"` // 0 uses the default lock duration
+}
+
+// UpdateTaskDescriptionRequest represents the request to edit a task description
+type UpdateTaskDescriptionRequest struct {
+	Description string `json:"description"`
+}
+
+// ChangeTaskDeadlineRequest represents the request to set or move a task deadline
+type ChangeTaskDeadlineRequest struct {
+	Deadline     string `json:"deadline"`      // RFC 3339
+	BusinessDays int    `json:"business_days"` // instead of deadline, due this many business days from now
+	Reason       string `json:"reason"`        // required when postponing the deadline
+}
+
+// RejectTaskRequest represents the request to reject a task in its current status
+type RejectTaskRequest struct {
+	Reason string `json:"reason" binding:"required"`
 }
 
 // AddCommentRequest represents the request to add a comment
@@ -75,7 +158,85 @@ type AddCommentRequest struct {
 	Content string `json:"content" binding:"required"`
 }
 
+// AddAttachmentRequest represents the request to record a file attached to a task
+type AddAttachmentRequest struct {
+	FileName    string `json:"file_name" binding:"required"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+}
+
 // SetDeadlineRequest represents the request to set a deadline
 type SetDeadlineRequest struct {
 	DueDate string `json:"due_date" binding:"required"`
-}
\ No newline at end of file
+}
+
+// LinkTaskRequest represents the request to link a task to another task
+type LinkTaskRequest struct {
+	TargetTaskID string `json:"target_task_id" binding:"required"`
+	LinkType     string `json:"link_type" binding:"required"`
+}
+
+// DuplicateCandidateDTO represents an existing task that resembles a proposed title
+type DuplicateCandidateDTO struct {
+	TaskID     string  `json:"task_id"`
+	Title      string  `json:"title"`
+	Status     string  `json:"status"`
+	Similarity float64 `json:"similarity"`
+}
+
+// RecordCostRequest represents the request to record money spent on a task
+type RecordCostRequest struct {
+	Amount      string `json:"amount"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	IncurredAt  string `json:"incurred_at"` // RFC3339, omitted means now
+}
+
+// ReassignAllTasksRequest represents the request to hand a user's open tasks to another user
+type ReassignAllTasksRequest struct {
+	FromUserID string `json:"from_user_id" binding:"required"`
+	ToUserID   string `json:"to_user_id" binding:"required"`
+	ProjectID  string `json:"project_id"` // optional, limits the reassignment to one project
+}
+
+// ReassignedTaskDTO represents a task moved by a bulk reassignment
+type ReassignedTaskDTO struct {
+	TaskID    string `json:"task_id"`
+	ProjectID string `json:"project_id"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+}
+
+// SkippedTaskDTO represents an open task a bulk reassignment left in place
+type SkippedTaskDTO struct {
+	TaskID string `json:"task_id"`
+	Reason string `json:"reason"`
+}
+
+// ReassignmentReportDTO summarizes what a bulk reassignment moved
+type ReassignmentReportDTO struct {
+	FromUserID string              `json:"from_user_id"`
+	ToUserID   string              `json:"to_user_id"`
+	ProjectID  string              `json:"project_id,omitempty"`
+	MovedCount int                 `json:"moved_count"`
+	ByProject  map[string]int      `json:"by_project"`
+	Reassigned []ReassignedTaskDTO `json:"reassigned"`
+	Skipped    []SkippedTaskDTO    `json:"skipped"`
+	Warnings   []string            `json:"warnings,omitempty"`
+}
+
+// TaskHistoryEntryDTO represents one entry of a task's history feed
+type TaskHistoryEntryDTO struct {
+	Version    int               `json:"version"` // position in the task's history from 1, the cursor of the next page
+	EventType  string            `json:"event_type"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Summary    string            `json:"summary"` // e.g. "moved back to IN_PROGRESS: failed QA"
+	FromStatus string            `json:"from_status,omitempty"`
+	ToStatus   string            `json:"to_status,omitempty"`
+	Reason     string            `json:"reason,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+
+	PreviousDeadline string `json:"previous_deadline,omitempty"`
+	Deadline         string `json:"deadline,omitempty"`
+	ChangedBy        string `json:"changed_by,omitempty"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// TeamDTO is the data transfer object for a Team
+type TeamDTO struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	LeadID    string    `json:"lead_id"`
+	MemberIDs []string  `json:"member_ids"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateTeamRequest represents the request to create a team
+type CreateTeamRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	LeadID    string   `json:"lead_id"` // defaults to the caller
+	MemberIDs []string `json:"member_ids"`
+}
+
+// TeamMemberRequest represents the request to add a member to a team or make one its lead
+type TeamMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// AssignTaskToTeamRequest represents the request to assign a task to a team
+type AssignTaskToTeamRequest struct {
+	TeamID string `json:"team_id" binding:"required"`
+}
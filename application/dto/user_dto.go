@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// UserDTO is the data transfer object for a user
+type UserDTO struct {
+	ID            string           `json:"id"`
+	Email         string           `json:"email"`
+	FirstName     string           `json:"first_name"`
+	LastName      string           `json:"last_name"`
+	FullName      string           `json:"full_name"`
+	Active        bool             `json:"active"`
+	EmailVerified bool             `json:"email_verified"`
+	Roles         []string         `json:"roles"`
+	Locale        string           `json:"locale"`
+	ManagerID     string           `json:"manager_id"`
+	OutOfOffice   []OutOfOfficeDTO `json:"out_of_office"`
+	WorkingHours  WorkingHoursDTO  `json:"working_hours"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// WidgetDTO is the data transfer object for a dashboard Widget
+type WidgetDTO struct {
+	ID         string            `json:"id"`
+	OwnerID    string            `json:"owner_id"`
+	Title      string            `json:"title"`
+	Type       string            `json:"type"`
+	Parameters map[string]string `json:"parameters"`
+	Layout     WidgetLayoutDTO   `json:"layout"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// WidgetLayoutDTO is the data transfer object for a widget layout
+type WidgetLayoutDTO struct {
+	Column int `json:"column"`
+	Row    int `json:"row"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// WidgetResultDTO holds the evaluated data of a single widget
+type WidgetResultDTO struct {
+	WidgetID string      `json:"widget_id"`
+	Type     string      `json:"type"`
+	Data     interface{} `json:"data,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// CreateWidgetRequest represents the request to create a widget
+type CreateWidgetRequest struct {
+	Title      string            `json:"title" binding:"required"`
+	Type       string            `json:"type" binding:"required"`
+	Parameters map[string]string `json:"parameters"`
+	Layout     WidgetLayoutDTO   `json:"layout" binding:"required"`
+}
+
+// UpdateWidgetRequest represents the request to update a widget
+type UpdateWidgetRequest struct {
+	Title      string            `json:"title"`
+	Parameters map[string]string `json:"parameters"`
+	Layout     *WidgetLayoutDTO  `json:"layout"`
+}
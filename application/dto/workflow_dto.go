@@ -0,0 +1,130 @@
+package dto
+
+import "time"
+
+// WorkflowDTO is the data transfer object for one version of a workflow
+type WorkflowDTO struct {
+	ID              string                    `json:"id"`
+	Name            string                    `json:"name"`
+	Description     string                    `json:"description"`
+	Version         int                       `json:"version"`
+	Active          bool                      `json:"active"`
+	Statuses        []WorkflowStatusDTO       `json:"statuses"`
+	Transitions     []WorkflowTransitionInput `json:"transitions,omitempty"` // omitted follows the regular task status rules
+	TransitionRules []TransitionRulesInput    `json:"transition_rules,omitempty"`
+	ApprovalSteps   []WorkflowApprovalStepDTO `json:"approval_steps,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+}
+
+// WorkflowStatusDTO is the data transfer object for a status of a workflow
+type WorkflowStatusDTO struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Order       int    `json:"order"`
+	IsFinal     bool   `json:"is_final"`
+	WIPLimit    int    `json:"wip_limit"` // 0 means no limit
+}
+
+// WorkflowStatusInput is one status of a proposed workflow
+type WorkflowStatusInput struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Order       int    `json:"order" binding:"required"`
+	IsFinal     bool   `json:"is_final"`
+	WIPLimit    int    `json:"wip_limit"`
+}
+
+// WorkflowTransitionInput is an allowed move between two statuses of a proposed workflow
+type WorkflowTransitionInput struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+// SimulateWorkflowRequest represents the request to check a proposed workflow against a project's tasks
+type SimulateWorkflowRequest struct {
+	Statuses    []WorkflowStatusInput     `json:"statuses" binding:"required"`
+	Transitions []WorkflowTransitionInput `json:"transitions"` // omitted uses the regular task status rules
+	TaskIDs     []string                  `json:"task_ids"`    // omitted samples the most recently updated tasks
+	SampleSize  int                       `json:"sample_size"`
+}
+
+// WorkflowSimulationDTO reports what a proposed workflow would break before it is applied
+type WorkflowSimulationDTO struct {
+	ProjectID    string                   `json:"project_id"`
+	Valid        bool                     `json:"valid"`
+	TasksChecked int                      `json:"tasks_checked"`
+	TotalTasks   int                      `json:"total_tasks"`
+	Issues       []WorkflowIssueDTO       `json:"issues"`
+	InvalidTasks []InvalidWorkflowTaskDTO `json:"invalid_tasks"`
+}
+
+// WorkflowIssueDTO is a structural problem of a proposed workflow
+type WorkflowIssueDTO struct {
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	Statuses []string `json:"statuses,omitempty"`
+}
+
+// InvalidWorkflowTaskDTO is a task that a proposed workflow would leave invalid
+type InvalidWorkflowTaskDTO struct {
+	TaskID  string `json:"task_id"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	Problem string `json:"problem"`
+}
+
+// UpdateWorkflowRequest represents the request to change a workflow's details, omitted fields are kept
+type UpdateWorkflowRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// AddWorkflowStatusRequest represents the request to add a status after a workflow's existing ones
+type AddWorkflowStatusRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	IsFinal     bool   `json:"is_final"`
+	WIPLimit    int    `json:"wip_limit"`
+}
+
+// ReorderWorkflowStatusesRequest represents the request to put a workflow's statuses in a new order
+type ReorderWorkflowStatusesRequest struct {
+	Statuses []string `json:"statuses" binding:"required"` // every status name, in the new order
+}
+
+// WorkflowApprovalStepRequest represents the request to make tasks need sign-off before moving on from a status
+type WorkflowApprovalStepRequest struct {
+	Status            string   `json:"status" binding:"required"`
+	RequiredApprovals int      `json:"required_approvals" binding:"required"`
+	ReviewerIDs       []string `json:"reviewer_ids" binding:"required"`
+}
+
+// WorkflowApprovalStepDTO is the sign-off tasks need before moving on from a workflow status
+type WorkflowApprovalStepDTO struct {
+	Status            string   `json:"status"`
+	RequiredApprovals int      `json:"required_approvals"`
+	ReviewerIDs       []string `json:"reviewer_ids"`
+}
+
+// TransitionGuardInput is a condition a task must meet to take a workflow transition
+type TransitionGuardInput struct {
+	Kind      string `json:"kind" binding:"required,oneof=REQUIRES_DEADLINE REQUIRES_ASSIGNEE REQUIRES_APPROVALS"`
+	Approvals int    `json:"approvals,omitempty"` // REQUIRES_APPROVALS only
+}
+
+// TransitionActionInput is run after a task takes a workflow transition
+type TransitionActionInput struct {
+	Kind       string `json:"kind" binding:"required,oneof=NOTIFY AUTO_ASSIGN"`
+	Channel    string `json:"channel,omitempty"`     // NOTIFY only
+	Target     string `json:"target,omitempty"`      // NOTIFY only
+	AssigneeID string `json:"assignee_id,omitempty"` // AUTO_ASSIGN only
+}
+
+// TransitionRulesInput is the guards and actions of a workflow transition
+type TransitionRulesInput struct {
+	From    string                  `json:"from" binding:"required"`
+	To      string                  `json:"to" binding:"required"`
+	Guards  []TransitionGuardInput  `json:"guards"`
+	Actions []TransitionActionInput `json:"actions"`
+}
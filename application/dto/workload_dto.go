@@ -0,0 +1,44 @@
+package dto
+
+// WorkloadHeatmapDTO is the data transfer object for the assignee x day workload matrix
+type WorkloadHeatmapDTO struct {
+	StartDate string           `json:"start_date"`
+	EndDate   string           `json:"end_date"`
+	Days      []string         `json:"days"`
+	Rows      []WorkloadRowDTO `json:"rows"`
+}
+
+// WorkloadRowDTO holds the per-day workload of a single assignee
+type WorkloadRowDTO struct {
+	AssigneeID          string            `json:"assignee_id"`
+	HoursPerDay         float64           `json:"hours_per_day"`
+	WorkingDays         []string          `json:"working_days"`
+	TotalTasksDue       int               `json:"total_tasks_due"`
+	TotalEstimatedHours float64           `json:"total_estimated_hours"`
+	TotalCapacityHours  float64           `json:"total_capacity_hours"`
+	Cells               []WorkloadCellDTO `json:"cells"`
+}
+
+// WorkloadCellDTO holds the workload of one assignee on one day
+type WorkloadCellDTO struct {
+	Date           string  `json:"date"`
+	TasksDue       int     `json:"tasks_due"`
+	EstimatedHours float64 `json:"estimated_hours"`
+	CapacityHours  float64 `json:"capacity_hours"`    // 0 on days off
+	OverCapacity   bool    `json:"over_capacity"`     // more estimated hours due than the day offers
+	Holiday        string  `json:"holiday,omitempty"` // name of the project's holiday on the day
+}
+
+// WorkingHoursRequest represents the request to set working hours
+type WorkingHoursRequest struct {
+	HoursPerDay float64  `json:"hours_per_day" binding:"required"`
+	WorkingDays []string `json:"working_days" binding:"required"` // e.g. ["MONDAY", "TUESDAY"]
+}
+
+// WorkingHoursDTO is the data transfer object for the working hours a user follows
+type WorkingHoursDTO struct {
+	HoursPerDay float64  `json:"hours_per_day"`
+	WorkingDays []string `json:"working_days"`
+	WeeklyHours float64  `json:"weekly_hours"`
+	Inherited   bool     `json:"inherited"` // follows the organization's default
+}
@@ -0,0 +1,16 @@
+package mapper
+
+import (
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+)
+
+// PageToDTO describes a page of count items a repository listed
+func PageToDTO(count int, result domain.PagedResult) dto.PageDTO {
+	return dto.PageDTO{
+		Count:      count,
+		Total:      result.Total,
+		Offset:     result.Offset,
+		NextCursor: result.NextCursor,
+	}
+}
@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+)
+
+// ProjectToDTO converts a project aggregate to its DTO
+func ProjectToDTO(project *aggregate.Project) *dto.ProjectDTO {
+	projectDTO := &dto.ProjectDTO{
+		ID:             project.ID().Value(),
+		Name:           project.Name(),
+		Description:    project.Description(),
+		OwnerID:        project.OwnerID().Value(),
+		WorkflowID:     project.WorkflowID().Value(),
+		TaskCount:      project.TaskCount(),
+		CompletedTasks: project.CompletedTaskCount(),
+		Archived:       project.IsArchived(),
+		CreatedAt:      project.CreatedAt(),
+		UpdatedAt:      project.UpdatedAt(),
+	}
+
+	if calendarID := project.HolidayCalendarID(); calendarID != nil {
+		projectDTO.HolidayCalendarID = calendarID.Value()
+	}
+
+	return projectDTO
+}
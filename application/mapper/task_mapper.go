@@ -0,0 +1,105 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+)
+
+// TaskToDTO converts a task aggregate to its DTO, with its assignment, deadline,
+// comments, links, attachments and costs
+func TaskToDTO(task *aggregate.Task) *dto.TaskDTO {
+	taskDTO := &dto.TaskDTO{
+		ID:             task.ID().Value(),
+		ProjectID:      task.ProjectID().Value(),
+		Title:          task.Title(),
+		Description:    task.Description(),
+		Status:         task.Status().Value(),
+		Priority:       task.Priority().Value(),
+		EstimatedHours: task.EstimatedHours(),
+		VoteCount:      task.VoteCount(),
+		ApprovalCount:  task.ApprovalCount(),
+		CreatedAt:      task.CreatedAt(),
+		UpdatedAt:      task.UpdatedAt(),
+		CreatedBy:      task.CreatedBy().Value(),
+	}
+
+	if assignee := task.Assignee(); assignee != nil {
+		taskDTO.Assignee = &dto.AssignmentDTO{
+			AssigneeID:     assignee.AssigneeID().Value(),
+			AssignedAt:     assignee.AssignedAt(),
+			AssignedBy:     assignee.AssignedBy().Value(),
+			AcknowledgedAt: assignee.AcknowledgedAt(),
+			EscalatedAt:    assignee.EscalatedAt(),
+		}
+	}
+
+	if teamID := task.TeamID(); teamID != nil {
+		taskDTO.TeamID = teamID.Value()
+	}
+
+	if version := task.WorkflowVersion(); version != nil {
+		taskDTO.WorkflowVersion = version.String()
+	}
+
+	if lock := task.ActiveEditLock(time.Now()); lock != nil {
+		taskDTO.EditLock = &dto.EditLockDTO{
+			HolderID:   lock.HolderID().Value(),
+			AcquiredAt: lock.AcquiredAt(),
+			ExpiresAt:  lock.ExpiresAt(),
+		}
+	}
+
+	if deadline := task.Deadline(); deadline != nil {
+		taskDTO.Deadline = &dto.DeadlineDTO{
+			DueDate:   deadline.Value(),
+			IsOverdue: deadline.IsOverdue(),
+			DaysUntil: deadline.DaysUntilDue(),
+		}
+	}
+
+	for _, comment := range task.Comments() {
+		taskDTO.Comments = append(taskDTO.Comments, dto.CommentDTO{
+			ID:        comment.ID(),
+			Content:   comment.Content(),
+			Version:   comment.Version(),
+			AuthorID:  comment.AuthorID().Value(),
+			CreatedAt: comment.CreatedAt(),
+			UpdatedAt: comment.UpdatedAt(),
+		})
+	}
+
+	for _, link := range task.Links() {
+		taskDTO.Links = append(taskDTO.Links, dto.TaskLinkDTO{
+			TargetTaskID: link.TargetTaskID().Value(),
+			LinkType:     link.LinkType().Value(),
+			CreatedAt:    link.CreatedAt(),
+			CreatedBy:    link.CreatedBy().Value(),
+		})
+	}
+
+	for _, attachment := range task.Attachments() {
+		taskDTO.Attachments = append(taskDTO.Attachments, dto.AttachmentDTO{
+			ID:          attachment.ID(),
+			FileName:    attachment.FileName(),
+			ContentType: attachment.ContentType(),
+			SizeBytes:   attachment.SizeBytes(),
+			UploadedBy:  attachment.UploadedBy().Value(),
+			UploadedAt:  attachment.UploadedAt(),
+		})
+	}
+
+	for _, entry := range task.CostEntries() {
+		taskDTO.Costs = append(taskDTO.Costs, dto.CostEntryDTO{
+			ID:          entry.ID(),
+			Amount:      dto.MoneyDTO{Amount: entry.Amount().Amount(), Currency: entry.Amount().Currency()},
+			Description: entry.Description(),
+			RecordedBy:  entry.RecordedBy().Value(),
+			IncurredAt:  entry.IncurredAt(),
+			CreatedAt:   entry.CreatedAt(),
+		})
+	}
+
+	return taskDTO
+}
@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UserToDTO converts a user aggregate to its DTO. The working hours are the ones the user
+// follows, own tells whether they are the user's or inherited from the organization.
+func UserToDTO(user *aggregate.User, hours value.WorkingHours, own bool) *dto.UserDTO {
+	locale, _ := user.Locale()
+
+	userDTO := &dto.UserDTO{
+		ID:            user.ID().Value(),
+		Email:         user.Email(),
+		FirstName:     user.FirstName(),
+		LastName:      user.LastName(),
+		FullName:      user.FullName(),
+		Active:        user.IsActive(),
+		EmailVerified: user.IsEmailVerified(),
+		Roles:         make([]string, 0, len(user.Roles())),
+		Locale:        locale.Value(),
+		OutOfOffice:   OutOfOfficeToDTOs(user.OutOfOffice()),
+		WorkingHours: dto.WorkingHoursDTO{
+			HoursPerDay: hours.HoursPerDay(),
+			WorkingDays: hours.WorkingDayNames(),
+			WeeklyHours: hours.WeeklyHours(),
+			Inherited:   !own,
+		},
+		CreatedAt: user.CreatedAt(),
+		UpdatedAt: user.UpdatedAt(),
+	}
+
+	for _, role := range user.Roles() {
+		userDTO.Roles = append(userDTO.Roles, role.Value())
+	}
+
+	if managerID := user.ManagerID(); managerID != nil {
+		userDTO.ManagerID = managerID.Value()
+	}
+
+	return userDTO
+}
+
+// OutOfOfficeToDTOs converts out-of-office periods to their DTOs
+func OutOfOfficeToDTOs(periods []value.OutOfOffice) []dto.OutOfOfficeDTO {
+	dtos := make([]dto.OutOfOfficeDTO, 0, len(periods))
+	for _, period := range periods {
+		dtos = append(dtos, dto.OutOfOfficeDTO{
+			Start: period.Start().Format(time.RFC3339),
+			End:   period.End().Format(time.RFC3339),
+			Note:  period.Note(),
+		})
+	}
+	return dtos
+}
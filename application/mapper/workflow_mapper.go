@@ -0,0 +1,81 @@
+package mapper
+
+import (
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// WorkflowToDTO converts a version of a workflow aggregate to its DTO, with its statuses,
+// transitions, transition rules and approval steps
+func WorkflowToDTO(workflow *aggregate.Workflow) *dto.WorkflowDTO {
+	workflowDTO := &dto.WorkflowDTO{
+		ID:          workflow.ID().Value(),
+		Name:        workflow.Name(),
+		Description: workflow.Description(),
+		Version:     workflow.Version(),
+		Active:      workflow.IsActive(),
+		Statuses:    make([]dto.WorkflowStatusDTO, 0, len(workflow.Statuses())),
+		CreatedAt:   workflow.CreatedAt(),
+		UpdatedAt:   workflow.UpdatedAt(),
+	}
+
+	for _, status := range workflow.Statuses() {
+		workflowDTO.Statuses = append(workflowDTO.Statuses, dto.WorkflowStatusDTO{
+			Name:        status.GetName(),
+			Description: status.GetDescription(),
+			Order:       status.GetOrder(),
+			IsFinal:     status.IsFinal(),
+			WIPLimit:    status.GetWIPLimit(),
+		})
+		if step := status.GetApprovalStep(); step != nil {
+			workflowDTO.ApprovalSteps = append(workflowDTO.ApprovalSteps, ApprovalStepToDTO(status.GetName(), *step))
+		}
+	}
+
+	for _, transition := range workflow.Transitions() {
+		workflowDTO.Transitions = append(workflowDTO.Transitions, dto.WorkflowTransitionInput{From: transition.From, To: transition.To})
+	}
+
+	for _, transition := range workflow.RuledTransitions() {
+		rules := workflow.TransitionRules(transition.From, transition.To)
+		workflowDTO.TransitionRules = append(workflowDTO.TransitionRules, TransitionRulesToDTO(transition.From, transition.To, rules))
+	}
+
+	return workflowDTO
+}
+
+// ApprovalStepToDTO converts the approval step of a status to its DTO
+func ApprovalStepToDTO(status string, step value.ApprovalStep) dto.WorkflowApprovalStepDTO {
+	reviewerIDs := make([]string, 0, len(step.ReviewerIDs()))
+	for _, reviewerID := range step.ReviewerIDs() {
+		reviewerIDs = append(reviewerIDs, reviewerID.Value())
+	}
+	return dto.WorkflowApprovalStepDTO{
+		Status:            status,
+		RequiredApprovals: step.RequiredApprovals(),
+		ReviewerIDs:       reviewerIDs,
+	}
+}
+
+// TransitionRulesToDTO converts the rules of a transition to their DTO
+func TransitionRulesToDTO(from, to string, rules aggregate.TransitionRules) dto.TransitionRulesInput {
+	input := dto.TransitionRulesInput{
+		From:    from,
+		To:      to,
+		Guards:  make([]dto.TransitionGuardInput, 0, len(rules.Guards)),
+		Actions: make([]dto.TransitionActionInput, 0, len(rules.Actions)),
+	}
+	for _, guard := range rules.Guards {
+		input.Guards = append(input.Guards, dto.TransitionGuardInput{Kind: string(guard.Kind()), Approvals: guard.Approvals()})
+	}
+	for _, action := range rules.Actions {
+		input.Actions = append(input.Actions, dto.TransitionActionInput{
+			Kind:       string(action.Kind()),
+			Channel:    action.Channel().Value(),
+			Target:     action.Target(),
+			AssigneeID: action.AssigneeID().Value(),
+		})
+	}
+	return input
+}
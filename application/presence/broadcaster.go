@@ -0,0 +1,48 @@
+package presence
+
+import "sync"
+
+// Broadcaster fans out notices about a resource to everyone connected for presence
+type Broadcaster struct {
+	listeners    map[int]func(resource Resource, notice interface{})
+	nextListener int
+	mu           sync.RWMutex
+}
+
+// NewBroadcaster creates a new Broadcaster
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		listeners: make(map[int]func(resource Resource, notice interface{})),
+	}
+}
+
+// Publish hands a notice about a resource to every listener
+func (b *Broadcaster) Publish(resource Resource, notice interface{}) {
+	b.mu.RLock()
+	listeners := make([]func(resource Resource, notice interface{}), 0, len(b.listeners))
+	for _, listener := range b.listeners {
+		listeners = append(listeners, listener)
+	}
+	b.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(resource, notice)
+	}
+}
+
+// Subscribe registers a listener and returns a function that removes it
+func (b *Broadcaster) Subscribe(listener func(resource Resource, notice interface{})) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextListener
+	b.nextListener++
+	b.listeners[id] = listener
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.listeners, id)
+	}
+}
@@ -0,0 +1,58 @@
+package presence
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// EditLockNotice tells viewers of a task who holds its description edit lock
+type EditLockNotice struct {
+	TaskID     string
+	HolderID   string
+	AcquiredAt time.Time
+	ExpiresAt  *time.Time // nil once the lock is released
+}
+
+// EditLockRelay forwards edit lock events to the presence broadcaster
+type EditLockRelay struct {
+	broadcaster *Broadcaster
+}
+
+// NewEditLockRelay creates a new EditLockRelay
+func NewEditLockRelay(broadcaster *Broadcaster) *EditLockRelay {
+	return &EditLockRelay{
+		broadcaster: broadcaster,
+	}
+}
+
+// Register subscribes the relay to edit lock events
+func (r *EditLockRelay) Register(subscriber event.EventSubscriber) {
+	subscriber.Subscribe("TaskEditLockAcquired", r.handle)
+	subscriber.Subscribe("TaskEditLockReleased", r.handle)
+}
+
+// handle turns an edit lock event into a notice for the task's viewers
+func (r *EditLockRelay) handle(evt event.DomainEvent) error {
+	var notice EditLockNotice
+
+	switch e := evt.(type) {
+	case event.TaskEditLockAcquiredEvent:
+		acquiredAt, err := time.Parse(time.RFC3339, e.AcquiredAt)
+		if err != nil {
+			return err
+		}
+		expiresAt, err := time.Parse(time.RFC3339, e.ExpiresAt)
+		if err != nil {
+			return err
+		}
+		notice = EditLockNotice{TaskID: e.AggregateID(), HolderID: e.HolderID, AcquiredAt: acquiredAt, ExpiresAt: &expiresAt}
+	case event.TaskEditLockReleasedEvent:
+		notice = EditLockNotice{TaskID: e.AggregateID(), HolderID: e.HolderID}
+	default:
+		return nil
+	}
+
+	r.broadcaster.Publish(Resource{Kind: KindTask, ID: notice.TaskID}, notice)
+	return nil
+}
@@ -0,0 +1,251 @@
+package presence
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long a session stays present without a heartbeat
+const DefaultTTL = 30 * time.Second
+
+// Kind is the kind of resource users can be viewing
+type Kind string
+
+const (
+	KindTask  Kind = "TASK"
+	KindBoard Kind = "BOARD"
+)
+
+// NewKind creates a new Kind from string
+func NewKind(kind string) (Kind, error) {
+	k := Kind(kind)
+	switch k {
+	case KindTask, KindBoard:
+		return k, nil
+	default:
+		return "", fmt.Errorf("invalid presence kind: %s", kind)
+	}
+}
+
+// Resource identifies a task or a project board
+type Resource struct {
+	Kind Kind
+	ID   string
+}
+
+// Viewer is a user currently viewing a resource
+type Viewer struct {
+	UserID string
+	Since  time.Time
+}
+
+// Listener is told the current viewers of a resource whenever they change
+type Listener func(resource Resource, viewers []Viewer)
+
+// session is one open tab or connection of a user
+type session struct {
+	userID   string
+	resource Resource
+	since    time.Time
+	lastSeen time.Time
+}
+
+// Tracker keeps soft real-time presence in memory. Every session views at most one
+// resource and expires unless it is refreshed within the TTL.
+type Tracker struct {
+	ttl          time.Duration
+	sessions     map[string]*session
+	listeners    map[int]Listener
+	nextListener int
+	now          func() time.Time
+	mu           sync.Mutex
+}
+
+// NewTracker creates a new Tracker
+func NewTracker(ttl time.Duration) *Tracker {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Tracker{
+		ttl:       ttl,
+		sessions:  make(map[string]*session),
+		listeners: make(map[int]Listener),
+		now:       time.Now,
+	}
+}
+
+// SetClock replaces the tracker's clock, for tests
+func (t *Tracker) SetClock(now func() time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.now = now
+}
+
+// TTL returns how long a session stays present without a heartbeat
+func (t *Tracker) TTL() time.Duration {
+	return t.ttl
+}
+
+// View records that a session is viewing a resource, moving it off its previous one
+func (t *Tracker) View(sessionID, userID string, resource Resource) {
+	t.mu.Lock()
+	now := t.now()
+	changed := make([]Resource, 0, 2)
+
+	current, exists := t.sessions[sessionID]
+	switch {
+	case exists && current.resource == resource && current.userID == userID:
+		current.lastSeen = now
+	case exists:
+		changed = append(changed, current.resource)
+		fallthrough
+	default:
+		t.sessions[sessionID] = &session{
+			userID:   userID,
+			resource: resource,
+			since:    now,
+			lastSeen: now,
+		}
+		changed = append(changed, resource)
+	}
+	t.mu.Unlock()
+
+	t.notify(changed)
+}
+
+// Touch refreshes a session, returning false if it is unknown or expired
+func (t *Tracker) Touch(sessionID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current, exists := t.sessions[sessionID]
+	if !exists || t.expired(current) {
+		return false
+	}
+
+	current.lastSeen = t.now()
+	return true
+}
+
+// Leave removes a session
+func (t *Tracker) Leave(sessionID string) {
+	t.mu.Lock()
+	current, exists := t.sessions[sessionID]
+	if exists {
+		delete(t.sessions, sessionID)
+	}
+	t.mu.Unlock()
+
+	if exists {
+		t.notify([]Resource{current.resource})
+	}
+}
+
+// Sweep removes expired sessions and tells listeners about the resources they left
+func (t *Tracker) Sweep() int {
+	t.mu.Lock()
+	changed := make([]Resource, 0)
+	seen := make(map[Resource]bool)
+	for sessionID, current := range t.sessions {
+		if !t.expired(current) {
+			continue
+		}
+
+		delete(t.sessions, sessionID)
+		if !seen[current.resource] {
+			seen[current.resource] = true
+			changed = append(changed, current.resource)
+		}
+	}
+	t.mu.Unlock()
+
+	t.notify(changed)
+	return len(changed)
+}
+
+// Viewers returns the users viewing a resource, each user once, earliest first
+func (t *Tracker) Viewers(resource Resource) []Viewer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.viewers(resource)
+}
+
+// Subscribe registers a listener and returns a function that removes it
+func (t *Tracker) Subscribe(listener Listener) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.nextListener
+	t.nextListener++
+	t.listeners[id] = listener
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		delete(t.listeners, id)
+	}
+}
+
+// viewers collects the live viewers of a resource, the caller must hold the lock
+func (t *Tracker) viewers(resource Resource) []Viewer {
+	byUser := make(map[string]Viewer)
+	for _, current := range t.sessions {
+		if current.resource != resource || t.expired(current) {
+			continue
+		}
+
+		viewer, exists := byUser[current.userID]
+		if !exists || current.since.Before(viewer.Since) {
+			byUser[current.userID] = Viewer{UserID: current.userID, Since: current.since}
+		}
+	}
+
+	viewers := make([]Viewer, 0, len(byUser))
+	for _, viewer := range byUser {
+		viewers = append(viewers, viewer)
+	}
+
+	sort.Slice(viewers, func(i, j int) bool {
+		if !viewers[i].Since.Equal(viewers[j].Since) {
+			return viewers[i].Since.Before(viewers[j].Since)
+		}
+		return viewers[i].UserID < viewers[j].UserID
+	})
+
+	return viewers
+}
+
+// expired checks if a session missed its heartbeat, the caller must hold the lock
+func (t *Tracker) expired(current *session) bool {
+	return t.now().Sub(current.lastSeen) > t.ttl
+}
+
+// notify tells every listener the current viewers of the changed resources
+func (t *Tracker) notify(resources []Resource) {
+	if len(resources) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	listeners := make([]Listener, 0, len(t.listeners))
+	for _, listener := range t.listeners {
+		listeners = append(listeners, listener)
+	}
+	snapshots := make([][]Viewer, len(resources))
+	for i, resource := range resources {
+		snapshots[i] = t.viewers(resource)
+	}
+	t.mu.Unlock()
+
+	for i, resource := range resources {
+		for _, listener := range listeners {
+			listener(resource, snapshots[i])
+		}
+	}
+}
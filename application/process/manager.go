@@ -0,0 +1,197 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// Reaction is a follow-up to domain events that issues commands, usually against other
+// aggregates than the one the event came from. React may run more than once for the
+// same event when an attempt fails, so it must check what is left to do first.
+type Reaction interface {
+	Name() string
+	EventTypes() []string
+	React(ctx context.Context, evt event.DomainEvent) error
+}
+
+// RetryPolicy configures how failed reactions are retried
+type RetryPolicy struct {
+	// MaxAttempts is how often a reaction is tried before it is given up
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every further one up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used unless configured otherwise
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    8,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+	}
+}
+
+// Manager runs reactions to published domain events and records how each one went.
+// A failed reaction does not fail the publisher: it is kept pending and tried again by
+// RetryDue with backoff, until it completes or runs out of attempts.
+type Manager struct {
+	store     StateStore
+	policy    RetryPolicy
+	reactions map[string]Reaction // name -> reaction
+	order     []Reaction
+	now       func() time.Time
+	retrying  sync.Mutex
+}
+
+// NewManager creates a new Manager
+func NewManager(store StateStore, policy RetryPolicy, reactions ...Reaction) *Manager {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.MaxBackoff < policy.InitialBackoff {
+		policy.MaxBackoff = policy.InitialBackoff
+	}
+
+	manager := &Manager{
+		store:     store,
+		policy:    policy,
+		reactions: make(map[string]Reaction, len(reactions)),
+		order:     reactions,
+		now:       time.Now,
+	}
+	for _, reaction := range reactions {
+		manager.reactions[reaction.Name()] = reaction
+	}
+	return manager
+}
+
+// SetClock replaces the manager's clock, for tests
+func (m *Manager) SetClock(now func() time.Time) {
+	m.now = now
+}
+
+// Register subscribes the manager's reactions to the events they follow up on
+func (m *Manager) Register(subscriber event.EventSubscriber) error {
+	for _, reaction := range m.order {
+		reaction := reaction
+		for _, eventType := range reaction.EventTypes() {
+			err := subscriber.Subscribe(eventType, func(evt event.DomainEvent) error {
+				return m.Handle(reaction, evt)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to subscribe %s to %s: %w", reaction.Name(), eventType, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Handle runs a reaction to an event it did not see before. Failures are recorded
+// for a retry instead of returned; only failing to record them is an error.
+func (m *Manager) Handle(reaction Reaction, evt event.DomainEvent) error {
+	ctx := context.Background()
+	id := stateID(reaction.Name(), evt)
+	if _, err := m.store.GetByID(ctx, id); err == nil {
+		return nil // redelivered
+	}
+
+	state := State{
+		ID:       id,
+		Reaction: reaction.Name(),
+		Event:    evt,
+		Status:   StatusPending,
+	}
+	_, err := m.attempt(ctx, reaction, state)
+	return err
+}
+
+// RetryDue tries the pending reactions whose backoff has passed again.
+// It returns how many of them completed.
+func (m *Manager) RetryDue(ctx context.Context) (int, error) {
+	m.retrying.Lock()
+	defer m.retrying.Unlock()
+
+	due, err := m.store.GetDue(ctx, m.now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due reactions: %w", err)
+	}
+
+	completed := 0
+	for _, state := range due {
+		if err := ctx.Err(); err != nil {
+			return completed, err
+		}
+
+		reaction, exists := m.reactions[state.Reaction]
+		if !exists {
+			continue // registered by another build, left for it
+		}
+
+		status, err := m.attempt(ctx, reaction, state)
+		if err != nil {
+			return completed, err
+		}
+		if status == StatusCompleted {
+			completed++
+		}
+	}
+	return completed, nil
+}
+
+// Failed returns the reactions that were given up, for an operator to look into
+func (m *Manager) Failed(ctx context.Context) ([]State, error) {
+	return m.store.GetByStatus(ctx, StatusFailed)
+}
+
+// attempt runs a reaction once and records the outcome. The state is claimed until the
+// next retry would be due first, so the event coming back while the reaction publishes its
+// own events, or a retry run alongside, does not start the reaction again.
+func (m *Manager) attempt(ctx context.Context, reaction Reaction, state State) (Status, error) {
+	state.Attempts++
+	state.Status = StatusPending
+	state.UpdatedAt = m.now()
+	state.NextAttemptAt = state.UpdatedAt.Add(m.backoff(state.Attempts))
+	if err := m.store.Save(ctx, state); err != nil {
+		return "", fmt.Errorf("failed to save %s state: %w", reaction.Name(), err)
+	}
+
+	err := reaction.React(ctx, state.Event)
+
+	state.UpdatedAt = m.now()
+	switch {
+	case err == nil:
+		state.Status = StatusCompleted
+		state.LastError = ""
+		state.NextAttemptAt = time.Time{}
+	case state.Attempts >= m.policy.MaxAttempts:
+		state.Status = StatusFailed
+		state.LastError = err.Error()
+		state.NextAttemptAt = time.Time{}
+	default:
+		state.Status = StatusPending
+		state.LastError = err.Error()
+		state.NextAttemptAt = state.UpdatedAt.Add(m.backoff(state.Attempts))
+	}
+
+	if err := m.store.Save(ctx, state); err != nil {
+		return "", fmt.Errorf("failed to save %s state: %w", reaction.Name(), err)
+	}
+	return state.Status, nil
+}
+
+// backoff returns the wait after the given number of failed attempts
+func (m *Manager) backoff(attempts int) time.Duration {
+	backoff := m.policy.InitialBackoff
+	for i := 1; i < attempts && backoff < m.policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > m.policy.MaxBackoff {
+		backoff = m.policy.MaxBackoff
+	}
+	return backoff
+}
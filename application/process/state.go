@@ -0,0 +1,43 @@
+package process
+
+import (
+	"context"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// Status is how far a reaction to a domain event got
+type Status string
+
+const (
+	StatusPending   Status = "PENDING"   // failed and waiting for a retry
+	StatusCompleted Status = "COMPLETED" // ran to the end
+	StatusFailed    Status = "FAILED"    // given up after the last attempt
+)
+
+// State is the progress of one reaction to one domain event
+type State struct {
+	ID            string // the reaction's name and the event's ID
+	Reaction      string
+	Event         event.DomainEvent
+	Status        Status
+	Attempts      int
+	LastError     string    // empty once the reaction completed
+	NextAttemptAt time.Time // when a pending reaction is tried again
+	UpdatedAt     time.Time
+}
+
+// StateStore persists the progress of a process manager's reactions, so a reaction
+// runs once per event and failed ones are retried after a restart
+type StateStore interface {
+	Save(ctx context.Context, state State) error
+	GetByID(ctx context.Context, id string) (State, error)
+	GetDue(ctx context.Context, now time.Time) ([]State, error)
+	GetByStatus(ctx context.Context, status Status) ([]State, error)
+}
+
+// stateID identifies the state of a reaction to an event
+func stateID(reaction string, evt event.DomainEvent) string {
+	return reaction + ":" + evt.EventID()
+}
@@ -0,0 +1,171 @@
+package process
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ProjectProgressReaction recounts the completed tasks of a project when one of its tasks
+// is completed or reopened, or a task leaves the project
+type ProjectProgressReaction struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	progressHandler   *command.UpdateProjectProgressCommandHandler
+}
+
+// NewProjectProgressReaction creates a new ProjectProgressReaction
+func NewProjectProgressReaction(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	progressHandler *command.UpdateProjectProgressCommandHandler,
+) *ProjectProgressReaction {
+	return &ProjectProgressReaction{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		progressHandler:   progressHandler,
+	}
+}
+
+// Name identifies the reaction in its stored states
+func (r *ProjectProgressReaction) Name() string {
+	return "project_progress"
+}
+
+// EventTypes returns the events the reaction follows up on
+func (r *ProjectProgressReaction) EventTypes() []string {
+	return []string{"TaskStatusChanged", "TaskRemovedFromProject"}
+}
+
+// React updates the progress of the project the event concerns
+func (r *ProjectProgressReaction) React(ctx context.Context, evt event.DomainEvent) error {
+	var projectID value.ProjectID
+	switch e := evt.(type) {
+	case event.TaskStatusChangedEvent:
+		completed := value.TaskStatusCompleted.Value()
+		if e.OldStatus != completed && e.NewStatus != completed {
+			return nil
+		}
+
+		taskID, err := value.NewTaskID(e.AggregateID())
+		if err != nil {
+			return errs.Invalid("invalid task id: %w", err)
+		}
+		task, err := r.taskRepository.GetByID(ctx, taskID)
+		if err != nil {
+			return nil // deleted since, its project is recounted when it leaves
+		}
+		projectID = task.ProjectID()
+	case event.TaskRemovedFromProjectEvent:
+		parsed, err := value.NewProjectID(e.AggregateID())
+		if err != nil {
+			return errs.Invalid("invalid project id: %w", err)
+		}
+		projectID = parsed
+	default:
+		return nil
+	}
+
+	// Tasks outside a known project have no progress to update
+	if _, err := r.projectRepository.GetByID(ctx, projectID); err != nil {
+		return nil
+	}
+
+	_, err := r.progressHandler.Handle(ctx, command.UpdateProjectProgressCommand{ProjectID: projectID.Value()})
+	return err
+}
+
+// ParentTaskCompletionReaction completes a parent task once none of its subtasks is open
+// anymore. The parent is completed on behalf of its project's owner, so the workflow must
+// allow completing it from the status it is in; until it does, the reaction fails and is retried.
+type ParentTaskCompletionReaction struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	taskLinkService   *service.TaskLinkService
+	statusHandler     *command.UpdateTaskStatusCommandHandler
+}
+
+// NewParentTaskCompletionReaction creates a new ParentTaskCompletionReaction
+func NewParentTaskCompletionReaction(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	taskLinkService *service.TaskLinkService,
+	statusHandler *command.UpdateTaskStatusCommandHandler,
+) *ParentTaskCompletionReaction {
+	return &ParentTaskCompletionReaction{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		taskLinkService:   taskLinkService,
+		statusHandler:     statusHandler,
+	}
+}
+
+// Name identifies the reaction in its stored states
+func (r *ParentTaskCompletionReaction) Name() string {
+	return "parent_task_completion"
+}
+
+// EventTypes returns the events the reaction follows up on
+func (r *ParentTaskCompletionReaction) EventTypes() []string {
+	return []string{"TaskCompleted"}
+}
+
+// React completes the parent of the completed task when it was the last open subtask
+func (r *ParentTaskCompletionReaction) React(ctx context.Context, evt event.DomainEvent) error {
+	taskID, err := value.NewTaskID(evt.AggregateID())
+	if err != nil {
+		return errs.Invalid("invalid task id: %w", err)
+	}
+
+	task, err := r.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil // deleted since
+	}
+
+	parentID := r.taskLinkService.ParentTaskID(task)
+	if parentID == nil {
+		return nil
+	}
+
+	parent, err := r.taskRepository.GetByID(ctx, *parentID)
+	if err != nil || !parent.IsOpen() {
+		return nil // nothing left to close, also when an earlier attempt did
+	}
+
+	// Deleted subtasks no longer hold the parent open
+	for _, subtaskID := range r.taskLinkService.SubtaskIDs(parent) {
+		subtask, err := r.taskRepository.GetByID(ctx, subtaskID)
+		if err == nil && subtask.IsOpen() {
+			return nil
+		}
+	}
+
+	project, err := r.projectRepository.GetByID(ctx, parent.ProjectID())
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	_, err = r.statusHandler.Handle(ctx, command.UpdateTaskStatusCommand{
+		TaskID:      parent.ID().Value(),
+		NewStatus:   value.TaskStatusCompleted.Value(),
+		Reason:      "all subtasks are closed",
+		Metadata:    map[string]string{"last_subtask_id": task.ID().Value()},
+		RequestedBy: project.OwnerID().Value(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete parent task %s: %w", parent.ID().Value(), err)
+	}
+	return nil
+}
+
+// Ensure the reactions implement Reaction
+var (
+	_ Reaction = (*ProjectProgressReaction)(nil)
+	_ Reaction = (*ParentTaskCompletionReaction)(nil)
+)
@@ -0,0 +1,14 @@
+package query
+
+import (
+	"context"
+	"fmt"
+)
+
+// abortIfDone stops a query whose request was cancelled or timed out, so nobody waits on an answer no one reads
+func abortIfDone(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("query aborted: %w", err)
+	}
+	return nil
+}
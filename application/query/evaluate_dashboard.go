@@ -0,0 +1,123 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// EvaluateDashboardQuery represents a query that runs all of a user's widget queries at once
+type EvaluateDashboardQuery struct {
+	OwnerID string
+}
+
+// EvaluateDashboardQueryHandler handles EvaluateDashboardQuery
+type EvaluateDashboardQueryHandler struct {
+	widgetRepository       domain.WidgetRepository
+	listTasksHandler       *ListTasksByProjectQueryHandler
+	projectStatsHandler    *GetProjectStatsQueryHandler
+	workloadHeatmapHandler *GetWorkloadHeatmapQueryHandler
+	unacknowledgedHandler  *ListUnacknowledgedAssignmentsQueryHandler
+}
+
+// NewEvaluateDashboardQueryHandler creates a new EvaluateDashboardQueryHandler
+func NewEvaluateDashboardQueryHandler(
+	widgetRepository domain.WidgetRepository,
+	listTasksHandler *ListTasksByProjectQueryHandler,
+	projectStatsHandler *GetProjectStatsQueryHandler,
+	workloadHeatmapHandler *GetWorkloadHeatmapQueryHandler,
+	unacknowledgedHandler *ListUnacknowledgedAssignmentsQueryHandler,
+) *EvaluateDashboardQueryHandler {
+	return &EvaluateDashboardQueryHandler{
+		widgetRepository:       widgetRepository,
+		listTasksHandler:       listTasksHandler,
+		projectStatsHandler:    projectStatsHandler,
+		workloadHeatmapHandler: workloadHeatmapHandler,
+		unacknowledgedHandler:  unacknowledgedHandler,
+	}
+}
+
+// Handle handles the EvaluateDashboardQuery.
+// A failing widget reports its error in its own result instead of failing the whole batch.
+func (h *EvaluateDashboardQueryHandler) Handle(ctx context.Context, query EvaluateDashboardQuery) ([]*dto.WidgetResultDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse owner ID
+	ownerID, err := value.NewUserID(query.OwnerID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get widgets
+	widgets, err := h.widgetRepository.GetByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get widgets: %w", err)
+	}
+
+	results := make([]*dto.WidgetResultDTO, 0, len(widgets))
+	for _, widget := range widgets {
+		result := &dto.WidgetResultDTO{
+			WidgetID: widget.ID().Value(),
+			Type:     widget.Type().Value(),
+		}
+
+		data, err := h.evaluate(ctx, widget)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Data = data
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// evaluate runs the query backing a single widget
+func (h *EvaluateDashboardQueryHandler) evaluate(ctx context.Context, widget *aggregate.Widget) (interface{}, error) {
+	switch widget.Type() {
+	case value.WidgetTypeTaskList:
+		return h.listTasksHandler.Handle(ctx, ListTasksByProjectQuery{
+			ProjectID: widget.Parameter("project_id"),
+			Status:    widget.Parameter("status"),
+		})
+
+	case value.WidgetTypeProjectStats:
+		return h.projectStatsHandler.Handle(ctx, GetProjectStatsQuery{
+			ProjectID: widget.Parameter("project_id"),
+		})
+
+	case value.WidgetTypeWorkloadHeatmap:
+		weeks := 0
+		if raw := widget.Parameter("weeks"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, errs.Invalid("invalid weeks parameter: %w", err)
+			}
+			weeks = parsed
+		}
+		return h.workloadHeatmapHandler.Handle(ctx, GetWorkloadHeatmapQuery{
+			Weeks:     weeks,
+			ProjectID: widget.Parameter("project_id"),
+		})
+
+	case value.WidgetTypeUnacknowledgedAssignments:
+		// The assignments the dashboard owner made that are still waiting on their assignees
+		return h.unacknowledgedHandler.Handle(ctx, ListUnacknowledgedAssignmentsQuery{
+			AssignedBy: widget.OwnerID().Value(),
+			ProjectID:  widget.Parameter("project_id"),
+		})
+
+	default:
+		return nil, fmt.Errorf("unsupported widget type: %s", widget.Type().Value())
+	}
+}
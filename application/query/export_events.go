@@ -0,0 +1,19 @@
+package query
+
+import (
+	"context"
+	"io"
+
+	"github.com/miladev95/ddd-task/application/dto"
+)
+
+// EventSchemas lists the current wire schema of every published event type
+type EventSchemas interface {
+	Schemas() []dto.EventSchemaDTO
+}
+
+// EventExporter writes the whole event log as a backup that can be imported again
+type EventExporter interface {
+	// Export writes every stored event in append order and returns how many were written
+	Export(ctx context.Context, w io.Writer) (int, error)
+}
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// FindDuplicateTasksQuery represents a query for tasks similar to a proposed title
+type FindDuplicateTasksQuery struct {
+	ProjectID string
+	Title     string
+	Threshold float64 // 0 uses the default threshold
+}
+
+// FindDuplicateTasksQueryHandler handles FindDuplicateTasksQuery
+type FindDuplicateTasksQueryHandler struct {
+	taskRepository   domain.TaskRepository
+	duplicateService *service.DuplicateDetectionService
+}
+
+// NewFindDuplicateTasksQueryHandler creates a new FindDuplicateTasksQueryHandler
+func NewFindDuplicateTasksQueryHandler(
+	taskRepository domain.TaskRepository,
+	duplicateService *service.DuplicateDetectionService,
+) *FindDuplicateTasksQueryHandler {
+	return &FindDuplicateTasksQueryHandler{
+		taskRepository:   taskRepository,
+		duplicateService: duplicateService,
+	}
+}
+
+// Handle handles the FindDuplicateTasksQuery
+func (h *FindDuplicateTasksQueryHandler) Handle(ctx context.Context, query FindDuplicateTasksQuery) ([]*dto.DuplicateCandidateDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	if query.Threshold < 0 || query.Threshold > 1 {
+		return nil, errs.Invalid("threshold must be between 0 and 1")
+	}
+
+	// Get tasks
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	// Find candidates
+	candidates := h.duplicateService.FindSimilar(query.Title, tasks, query.Threshold)
+
+	candidateDTOs := make([]*dto.DuplicateCandidateDTO, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateDTOs = append(candidateDTOs, &dto.DuplicateCandidateDTO{
+			TaskID:     candidate.Task.ID().Value(),
+			Title:      candidate.Task.Title(),
+			Status:     candidate.Task.Status().Value(),
+			Similarity: candidate.Similarity,
+		})
+	}
+
+	return candidateDTOs, nil
+}
@@ -0,0 +1,51 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetHolidayCalendarQuery represents a query to get a single holiday calendar
+type GetHolidayCalendarQuery struct {
+	CalendarID string
+}
+
+// GetHolidayCalendarQueryHandler handles GetHolidayCalendarQuery
+type GetHolidayCalendarQueryHandler struct {
+	calendarRepository domain.HolidayCalendarRepository
+}
+
+// NewGetHolidayCalendarQueryHandler creates a new GetHolidayCalendarQueryHandler
+func NewGetHolidayCalendarQueryHandler(
+	calendarRepository domain.HolidayCalendarRepository,
+) *GetHolidayCalendarQueryHandler {
+	return &GetHolidayCalendarQueryHandler{
+		calendarRepository: calendarRepository,
+	}
+}
+
+// Handle handles the GetHolidayCalendarQuery
+func (h *GetHolidayCalendarQueryHandler) Handle(ctx context.Context, query GetHolidayCalendarQuery) (*dto.HolidayCalendarDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse calendar ID
+	calendarID, err := value.NewHolidayCalendarID(query.CalendarID)
+	if err != nil {
+		return nil, errs.Invalid("invalid holiday calendar id: %w", err)
+	}
+
+	// Get holiday calendar
+	calendar, err := h.calendarRepository.GetByID(ctx, calendarID)
+	if err != nil {
+		return nil, fmt.Errorf("holiday calendar not found: %w", err)
+	}
+
+	return convertHolidayCalendarToDTO(calendar), nil
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetNotificationRoutesQuery represents a query for a project's notification routing rules
+type GetNotificationRoutesQuery struct {
+	ProjectID string
+}
+
+// GetNotificationRoutesQueryHandler handles GetNotificationRoutesQuery
+type GetNotificationRoutesQueryHandler struct {
+	projectRepository domain.ProjectRepository
+}
+
+// NewGetNotificationRoutesQueryHandler creates a new GetNotificationRoutesQueryHandler
+func NewGetNotificationRoutesQueryHandler(
+	projectRepository domain.ProjectRepository,
+) *GetNotificationRoutesQueryHandler {
+	return &GetNotificationRoutesQueryHandler{
+		projectRepository: projectRepository,
+	}
+}
+
+// Handle handles the GetNotificationRoutesQuery
+func (h *GetNotificationRoutesQueryHandler) Handle(ctx context.Context, query GetNotificationRoutesQuery) ([]*dto.NotificationRouteDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Convert to DTOs
+	routeDTOs := make([]*dto.NotificationRouteDTO, 0, len(project.NotificationRoutes()))
+	for _, route := range project.NotificationRoutes() {
+		routeDTOs = append(routeDTOs, &dto.NotificationRouteDTO{
+			EventType: route.EventType(),
+			Channel:   route.Channel().Value(),
+			Target:    route.Target(),
+			Delivery:  route.Delivery().Value(),
+		})
+	}
+
+	return routeDTOs, nil
+}
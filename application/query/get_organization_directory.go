@@ -0,0 +1,130 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetOrganizationDirectoryQuery represents a query for the directory of the caller's organization
+type GetOrganizationDirectoryQuery struct {
+	UserID string
+}
+
+// GetOrganizationDirectoryQueryHandler handles GetOrganizationDirectoryQuery
+type GetOrganizationDirectoryQueryHandler struct {
+	organizationRepository domain.OrganizationRepository
+	userRepository         domain.UserRepository
+	teamRepository         domain.TeamRepository
+	reportingLineService   *service.ReportingLineService
+}
+
+// NewGetOrganizationDirectoryQueryHandler creates a new GetOrganizationDirectoryQueryHandler
+func NewGetOrganizationDirectoryQueryHandler(
+	organizationRepository domain.OrganizationRepository,
+	userRepository domain.UserRepository,
+	teamRepository domain.TeamRepository,
+	reportingLineService *service.ReportingLineService,
+) *GetOrganizationDirectoryQueryHandler {
+	return &GetOrganizationDirectoryQueryHandler{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+		teamRepository:         teamRepository,
+		reportingLineService:   reportingLineService,
+	}
+}
+
+// Handle handles the GetOrganizationDirectoryQuery. Members whose user no longer exists are left out.
+func (h *GetOrganizationDirectoryQueryHandler) Handle(ctx context.Context, query GetOrganizationDirectoryQuery) (*dto.OrganizationDirectoryDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	userID, err := value.NewUserID(query.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get organization
+	organization, err := h.organizationRepository.GetByMemberID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+
+	// Get members
+	members := make([]*aggregate.User, 0, len(organization.MemberIDs()))
+	for _, memberID := range organization.MemberIDs() {
+		if member, err := h.userRepository.GetByID(ctx, memberID); err == nil {
+			members = append(members, member)
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].FullName() < members[j].FullName()
+	})
+
+	// Get teams led by a member
+	allTeams, err := h.teamRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+	teams := make([]dto.TeamDTO, 0)
+	teamIDsOf := make(map[string][]string)
+	for _, team := range allTeams {
+		if !organization.HasMember(team.LeadID()) {
+			continue
+		}
+		teams = append(teams, *convertTeamToDTO(team))
+		for _, memberID := range team.MemberIDs() {
+			teamIDsOf[memberID.Value()] = append(teamIDsOf[memberID.Value()], team.ID().Value())
+		}
+	}
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].Name < teams[j].Name
+	})
+
+	// Collect direct reports
+	reportsOf := make(map[string][]string)
+	for _, member := range members {
+		if managerID := member.ManagerID(); managerID != nil {
+			reportsOf[managerID.Value()] = append(reportsOf[managerID.Value()], member.ID().Value())
+		}
+	}
+
+	directory := &dto.OrganizationDirectoryDTO{
+		OrganizationID: organization.ID().Value(),
+		Name:           organization.Name(),
+		Members:        make([]dto.DirectoryMemberDTO, 0, len(members)),
+		Teams:          teams,
+	}
+	for _, member := range members {
+		entry := dto.DirectoryMemberDTO{
+			UserID:          member.ID().Value(),
+			FullName:        member.FullName(),
+			Email:           member.Email(),
+			Active:          member.IsActive(),
+			Roles:           make([]string, 0),
+			DirectReportIDs: append([]string{}, reportsOf[member.ID().Value()]...),
+			EscalationChain: make([]string, 0),
+			TeamIDs:         append([]string{}, teamIDsOf[member.ID().Value()]...),
+		}
+		for _, role := range member.Roles() {
+			entry.Roles = append(entry.Roles, role.Value())
+		}
+		if managerID := member.ManagerID(); managerID != nil {
+			entry.ManagerID = managerID.Value()
+		}
+		for _, managerID := range h.reportingLineService.EscalationChain(ctx, member.ID()) {
+			entry.EscalationChain = append(entry.EscalationChain, managerID.Value())
+		}
+		directory.Members = append(directory.Members, entry)
+	}
+
+	return directory, nil
+}
@@ -0,0 +1,102 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// APICallCounter reports how many API calls an organization made in the current minute
+type APICallCounter interface {
+	CallsThisMinute(organizationID value.OrganizationID) int
+}
+
+// GetOrganizationUsageQuery represents a query for an organization's usage against its quota
+type GetOrganizationUsageQuery struct {
+	OrganizationID string // optional, defaults to the organization of UserID
+	UserID         string
+}
+
+// GetOrganizationUsageQueryHandler handles GetOrganizationUsageQuery
+type GetOrganizationUsageQueryHandler struct {
+	organizationRepository domain.OrganizationRepository
+	quotaService           *service.QuotaEnforcementService
+	apiCalls               APICallCounter
+}
+
+// NewGetOrganizationUsageQueryHandler creates a new GetOrganizationUsageQueryHandler
+func NewGetOrganizationUsageQueryHandler(
+	organizationRepository domain.OrganizationRepository,
+	quotaService *service.QuotaEnforcementService,
+	apiCalls APICallCounter,
+) *GetOrganizationUsageQueryHandler {
+	return &GetOrganizationUsageQueryHandler{
+		organizationRepository: organizationRepository,
+		quotaService:           quotaService,
+		apiCalls:               apiCalls,
+	}
+}
+
+// Handle handles the GetOrganizationUsageQuery
+func (h *GetOrganizationUsageQueryHandler) Handle(ctx context.Context, query GetOrganizationUsageQuery) (*dto.OrganizationUsageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Get organization
+	var organization *aggregate.Organization
+	if query.OrganizationID != "" {
+		organizationID, err := value.NewOrganizationID(query.OrganizationID)
+		if err != nil {
+			return nil, errs.Invalid("invalid organization id: %w", err)
+		}
+
+		organization, err = h.organizationRepository.GetByID(ctx, organizationID)
+		if err != nil {
+			return nil, fmt.Errorf("organization not found: %w", err)
+		}
+	} else {
+		userID, err := value.NewUserID(query.UserID)
+		if err != nil {
+			return nil, errs.Invalid("invalid user id: %w", err)
+		}
+
+		organization, err = h.organizationRepository.GetByMemberID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("organization not found: %w", err)
+		}
+	}
+
+	// Count usage
+	usage, err := h.quotaService.Usage(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := organization.Quota()
+	return &dto.OrganizationUsageDTO{
+		OrganizationID:    organization.ID().Value(),
+		Name:              organization.Name(),
+		Members:           len(organization.MemberIDs()),
+		Projects:          quotaLine(int64(usage.Projects), int64(quota.MaxProjects())),
+		Tasks:             quotaLine(int64(usage.Tasks), int64(quota.MaxTasks())),
+		AttachmentBytes:   quotaLine(usage.AttachmentBytes, quota.MaxAttachmentBytes()),
+		APICallsPerMinute: quotaLine(int64(h.apiCalls.CallsThisMinute(organization.ID())), int64(quota.APICallsPerMinute())),
+	}, nil
+}
+
+// quotaLine compares a usage to its limit, where a zero limit means unlimited
+func quotaLine(used, limit int64) dto.QuotaLineDTO {
+	return dto.QuotaLineDTO{
+		Used:      used,
+		Limit:     limit,
+		Unlimited: limit == 0,
+		Exceeded:  limit > 0 && used > limit,
+	}
+}
@@ -0,0 +1,54 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetProjectQuery represents a query to get a project by ID
+type GetProjectQuery struct {
+	ProjectID string
+	ViewerID  string // may be empty for anonymous requests
+}
+
+// GetProjectQueryHandler handles GetProjectQuery
+type GetProjectQueryHandler struct {
+	projectRepository domain.ProjectRepository
+}
+
+// NewGetProjectQueryHandler creates a new GetProjectQueryHandler
+func NewGetProjectQueryHandler(projectRepository domain.ProjectRepository) *GetProjectQueryHandler {
+	return &GetProjectQueryHandler{
+		projectRepository: projectRepository,
+	}
+}
+
+// Handle handles the GetProjectQuery
+func (h *GetProjectQueryHandler) Handle(ctx context.Context, query GetProjectQuery) (*dto.ProjectDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	if err := requireViewer(project, query.ViewerID); err != nil {
+		return nil, err
+	}
+
+	return mapper.ProjectToDTO(project), nil
+}
@@ -0,0 +1,211 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetProjectBoardQuery represents a query for a project's kanban board
+type GetProjectBoardQuery struct {
+	ProjectID string
+	Sort      string // CREATED or PRIORITY; empty uses the viewer's preference for the project
+	ViewerID  string // whose sort preference applies, may be empty
+}
+
+// GetProjectBoardQueryHandler handles GetProjectBoardQuery
+type GetProjectBoardQueryHandler struct {
+	projectRepository  domain.ProjectRepository
+	taskRepository     domain.TaskRepository
+	workflowRepository domain.WorkflowRepository
+	userRepository     domain.UserRepository
+}
+
+// NewGetProjectBoardQueryHandler creates a new GetProjectBoardQueryHandler
+func NewGetProjectBoardQueryHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	workflowRepository domain.WorkflowRepository,
+	userRepository domain.UserRepository,
+) *GetProjectBoardQueryHandler {
+	return &GetProjectBoardQueryHandler{
+		projectRepository:  projectRepository,
+		taskRepository:     taskRepository,
+		workflowRepository: workflowRepository,
+		userRepository:     userRepository,
+	}
+}
+
+// Handle handles the GetProjectBoardQuery. The board is built from one project, one
+// workflow and one task lookup, tasks are grouped in memory instead of fetched per column.
+func (h *GetProjectBoardQueryHandler) Handle(ctx context.Context, query GetProjectBoardQuery) (*dto.BoardDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	boardSort, err := h.boardSort(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Get all project tasks at once
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt().Equal(tasks[j].CreatedAt()) {
+			return tasks[i].CreatedAt().Before(tasks[j].CreatedAt())
+		}
+		return tasks[i].ID().Value() < tasks[j].ID().Value()
+	})
+	if boardSort == value.BoardSortPriority {
+		sortByPriorityThenDeadline(tasks, project.PriorityScheme())
+	}
+
+	// Build columns from the workflow, or from the task statuses if it is unavailable
+	columns := make([]dto.BoardColumnDTO, 0)
+	columnIndex := make(map[string]int)
+
+	workflow, err := h.workflowRepository.GetByID(ctx, project.WorkflowID())
+	if err != nil {
+		// Projects on a workflow that does not exist follow the default one
+		workflow, err = h.workflowRepository.GetByID(ctx, value.DefaultWorkflowID)
+	}
+	if err == nil {
+		statuses := workflow.Statuses()
+		sort.SliceStable(statuses, func(i, j int) bool {
+			return statuses[i].GetOrder() < statuses[j].GetOrder()
+		})
+
+		for _, status := range statuses {
+			columnIndex[status.GetName()] = len(columns)
+			columns = append(columns, dto.BoardColumnDTO{
+				Status:      status.GetName(),
+				Description: status.GetDescription(),
+				Order:       status.GetOrder(),
+				IsFinal:     status.IsFinal(),
+				WIPLimit:    status.GetWIPLimit(),
+				Tasks:       make([]*dto.TaskDTO, 0),
+			})
+		}
+	} else {
+		for i, status := range boardFallbackStatuses {
+			columnIndex[status.Value()] = len(columns)
+			columns = append(columns, dto.BoardColumnDTO{
+				Status:  status.Value(),
+				Order:   i + 1,
+				IsFinal: status == value.TaskStatusCompleted || status == value.TaskStatusCancelled,
+				Tasks:   make([]*dto.TaskDTO, 0),
+			})
+		}
+	}
+
+	// Group tasks into columns, statuses the workflow lacks get a trailing column
+	for _, task := range tasks {
+		status := task.Status().Value()
+		index, exists := columnIndex[status]
+		if !exists {
+			index = len(columns)
+			columnIndex[status] = index
+			columns = append(columns, dto.BoardColumnDTO{
+				Status: status,
+				Order:  len(columns) + 1,
+				Tasks:  make([]*dto.TaskDTO, 0),
+			})
+		}
+
+		columns[index].Tasks = append(columns[index].Tasks, mapper.TaskToDTO(task))
+	}
+
+	for i := range columns {
+		columns[i].Count = len(columns[i].Tasks)
+		columns[i].WIPState = wipState(columns[i].Count, columns[i].WIPLimit)
+	}
+
+	return &dto.BoardDTO{
+		ProjectID:  project.ID().Value(),
+		WorkflowID: project.WorkflowID().Value(),
+		TaskCount:  len(tasks),
+		Sort:       boardSort.Value(),
+		Columns:    columns,
+	}, nil
+}
+
+// boardSort resolves how the board is sorted: as the query asks, else as the viewer prefers
+// for the project, else in creation order
+func (h *GetProjectBoardQueryHandler) boardSort(ctx context.Context, query GetProjectBoardQuery, projectID value.ProjectID) (value.BoardSort, error) {
+	if query.Sort != "" {
+		return value.NewBoardSort(query.Sort)
+	}
+
+	if viewerID, err := value.NewUserID(query.ViewerID); err == nil {
+		if viewer, err := h.userRepository.GetByID(ctx, viewerID); err == nil {
+			if preferred, ok := viewer.BoardSort(projectID); ok {
+				return preferred, nil
+			}
+		}
+	}
+
+	return value.BoardSortCreated, nil
+}
+
+// sortByPriorityThenDeadline puts the most urgent tasks of the scheme first and, among equal
+// priorities, the earliest deadline first; tasks without a deadline keep their order at the end
+func sortByPriorityThenDeadline(tasks []*aggregate.Task, scheme value.PriorityScheme) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if ri, rj := scheme.Rank(tasks[i].Priority()), scheme.Rank(tasks[j].Priority()); ri != rj {
+			return ri > rj
+		}
+
+		di, dj := tasks[i].Deadline(), tasks[j].Deadline()
+		if di == nil || dj == nil {
+			return di != nil && dj == nil
+		}
+		return di.Value().Before(dj.Value())
+	})
+}
+
+// boardFallbackStatuses are the columns of a board whose workflow cannot be loaded
+var boardFallbackStatuses = []value.TaskStatus{
+	value.TaskStatusBacklog,
+	value.TaskStatusToDo,
+	value.TaskStatusInProgress,
+	value.TaskStatusInReview,
+	value.TaskStatusCompleted,
+	value.TaskStatusCancelled,
+}
+
+// wipState compares a column's task count to its WIP limit
+func wipState(count, limit int) string {
+	switch {
+	case limit <= 0:
+		return dto.WIPStateNoLimit
+	case count < limit:
+		return dto.WIPStateUnderLimit
+	case count == limit:
+		return dto.WIPStateAtLimit
+	default:
+		return dto.WIPStateOverLimit
+	}
+}
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetProjectBudgetQuery represents a query for a project's spend against its budget
+type GetProjectBudgetQuery struct {
+	ProjectID string
+	ViewerID  string // may be empty for anonymous requests
+}
+
+// GetProjectBudgetQueryHandler handles GetProjectBudgetQuery
+type GetProjectBudgetQueryHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	budgetService     *service.BudgetService
+}
+
+// NewGetProjectBudgetQueryHandler creates a new GetProjectBudgetQueryHandler
+func NewGetProjectBudgetQueryHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	budgetService *service.BudgetService,
+) *GetProjectBudgetQueryHandler {
+	return &GetProjectBudgetQueryHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		budgetService:     budgetService,
+	}
+}
+
+// Handle handles the GetProjectBudgetQuery
+func (h *GetProjectBudgetQueryHandler) Handle(ctx context.Context, query GetProjectBudgetQuery) (*dto.BudgetSummaryDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	if err := requireViewer(project, query.ViewerID); err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	// Without a budget, spend is reported in the currency of the first recorded cost
+	budget := project.Budget()
+	currency := ""
+	if budget != nil {
+		currency = budget.Currency()
+	} else {
+		for _, task := range tasks {
+			if entries := task.CostEntries(); len(entries) > 0 {
+				currency = entries[0].Amount().Currency()
+				break
+			}
+		}
+	}
+
+	spent, err := h.budgetService.Spend(currency, tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum spend: %w", err)
+	}
+
+	summary := &dto.BudgetSummaryDTO{
+		ProjectID: projectID.Value(),
+		Spent:     toMoneyDTO(spent),
+		Exceeded:  project.IsBudgetExceeded(),
+		Tasks:     make([]dto.TaskCostDTO, 0),
+	}
+
+	for _, task := range tasks {
+		if len(task.CostEntries()) == 0 {
+			continue
+		}
+
+		taskCost, err := task.TotalCost(currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sum spend: %w", err)
+		}
+
+		summary.Tasks = append(summary.Tasks, dto.TaskCostDTO{
+			TaskID: task.ID().Value(),
+			Title:  task.Title(),
+			Spent:  toMoneyDTO(taskCost),
+		})
+	}
+
+	if budget != nil {
+		remaining, err := budget.Subtract(spent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute remaining budget: %w", err)
+		}
+
+		budgetDTO := toMoneyDTO(*budget)
+		remainingDTO := toMoneyDTO(remaining)
+		summary.Budget = &budgetDTO
+		summary.Remaining = &remainingDTO
+		if budget.MinorUnits() > 0 {
+			summary.PercentUsed = float64(spent.MinorUnits()) / float64(budget.MinorUnits()) * 100
+		}
+	}
+
+	return summary, nil
+}
+
+// toMoneyDTO converts Money to its DTO
+func toMoneyDTO(money value.Money) dto.MoneyDTO {
+	return dto.MoneyDTO{
+		Amount:   money.Amount(),
+		Currency: money.Currency(),
+	}
+}
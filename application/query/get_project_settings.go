@@ -0,0 +1,82 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetProjectSettingsQuery represents a query for a project's settings
+type GetProjectSettingsQuery struct {
+	ProjectID string
+}
+
+// GetProjectSettingsQueryHandler handles GetProjectSettingsQuery
+type GetProjectSettingsQueryHandler struct {
+	projectRepository domain.ProjectRepository
+	inboxService      *service.InboxAddressService
+}
+
+// NewGetProjectSettingsQueryHandler creates a new GetProjectSettingsQueryHandler
+func NewGetProjectSettingsQueryHandler(
+	projectRepository domain.ProjectRepository,
+	inboxService *service.InboxAddressService,
+) *GetProjectSettingsQueryHandler {
+	return &GetProjectSettingsQueryHandler{
+		projectRepository: projectRepository,
+		inboxService:      inboxService,
+	}
+}
+
+// Handle handles the GetProjectSettingsQuery
+func (h *GetProjectSettingsQueryHandler) Handle(ctx context.Context, query GetProjectSettingsQuery) (*dto.ProjectSettingsDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Convert to DTO
+	settings := project.Settings()
+	settingsDTO := &dto.ProjectSettingsDTO{
+		DefaultPriority:         settings.DefaultPriority().Value(),
+		RequireDeadlineOnCreate: settings.RequireDeadlineOnCreate(),
+		AllowComments:           settings.AllowComments(),
+		PriorityScheme:          make([]dto.PriorityLevelDTO, 0),
+		InboxAddresses:          make([]dto.InboxAddressDTO, 0),
+	}
+	if settings.HasDefaultAssignee() {
+		settingsDTO.DefaultAssigneeID = settings.DefaultAssigneeID().Value()
+	}
+	for _, level := range project.PriorityScheme().Levels() {
+		settingsDTO.PriorityScheme = append(settingsDTO.PriorityScheme, dto.PriorityLevelDTO{
+			Name: level.Priority().Value(),
+			Rank: level.Rank(),
+		})
+	}
+	for _, inbox := range project.InboxAddresses() {
+		settingsDTO.InboxAddresses = append(settingsDTO.InboxAddresses, dto.InboxAddressDTO{
+			Token:     inbox.Token(),
+			Address:   h.inboxService.AddressOf(inbox),
+			Label:     inbox.Label(),
+			CreatedAt: inbox.CreatedAt(),
+		})
+	}
+
+	return settingsDTO, nil
+}
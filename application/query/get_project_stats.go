@@ -0,0 +1,101 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetProjectStatsQuery represents a query to get statistics for a project
+type GetProjectStatsQuery struct {
+	ProjectID string
+	ViewerID  string // may be empty for anonymous requests
+}
+
+// GetProjectStatsQueryHandler handles GetProjectStatsQuery
+type GetProjectStatsQueryHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	sliService        *service.SLICalculationService
+	holidayService    *service.HolidayCalendarService
+}
+
+// NewGetProjectStatsQueryHandler creates a new GetProjectStatsQueryHandler
+func NewGetProjectStatsQueryHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	sliService *service.SLICalculationService,
+	holidayService *service.HolidayCalendarService,
+) *GetProjectStatsQueryHandler {
+	return &GetProjectStatsQueryHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		sliService:        sliService,
+		holidayService:    holidayService,
+	}
+}
+
+// Handle handles the GetProjectStatsQuery
+func (h *GetProjectStatsQueryHandler) Handle(ctx context.Context, query GetProjectStatsQuery) (*dto.ProjectStatsDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	if err := requireViewer(project, query.ViewerID); err != nil {
+		return nil, err
+	}
+
+	// Get tasks for project
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	tasksByStatus := make(map[string]int)
+	slipCount, slippedTasks := 0, 0
+	for _, task := range tasks {
+		tasksByStatus[task.Status().Value()]++
+
+		if slips := task.DeadlineSlipCount(); slips > 0 {
+			slipCount += slips
+			slippedTasks++
+		}
+	}
+
+	summary := h.sliService.SummarizeProject(project, tasks, time.Now(), h.holidayService.CalendarOf(ctx, project.ID()))
+
+	return &dto.ProjectStatsDTO{
+		ProjectID:     projectID.Value(),
+		TaskCount:     len(tasks),
+		TasksByStatus: tasksByStatus,
+		SLI: dto.SLIStatsDTO{
+			FirstResponseTargetSeconds: int64(summary.Targets.FirstResponse().Seconds()),
+			ResolutionTargetSeconds:    int64(summary.Targets.Resolution().Seconds()),
+			RespondedCount:             summary.RespondedCount,
+			ResolvedCount:              summary.ResolvedCount,
+			AvgFirstResponseSeconds:    int64(summary.AverageFirstResponseTime.Seconds()),
+			AvgResolutionSeconds:       int64(summary.AverageResolutionTime.Seconds()),
+			FirstResponseBreachCount:   summary.FirstResponseBreachCount,
+			ResolutionBreachCount:      summary.ResolutionBreachCount,
+		},
+		DeadlineSlipCount: slipCount,
+		SlippedTaskCount:  slippedTasks,
+	}, nil
+}
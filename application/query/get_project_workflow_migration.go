@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetProjectWorkflowMigrationQuery represents a query for a project's latest workflow migration plan
+type GetProjectWorkflowMigrationQuery struct {
+	ProjectID string
+}
+
+// GetProjectWorkflowMigrationQueryHandler handles GetProjectWorkflowMigrationQuery
+type GetProjectWorkflowMigrationQueryHandler struct {
+	projectRepository domain.ProjectRepository
+}
+
+// NewGetProjectWorkflowMigrationQueryHandler creates a new GetProjectWorkflowMigrationQueryHandler
+func NewGetProjectWorkflowMigrationQueryHandler(projectRepository domain.ProjectRepository) *GetProjectWorkflowMigrationQueryHandler {
+	return &GetProjectWorkflowMigrationQueryHandler{
+		projectRepository: projectRepository,
+	}
+}
+
+// Handle handles the GetProjectWorkflowMigrationQuery
+func (h *GetProjectWorkflowMigrationQueryHandler) Handle(ctx context.Context, query GetProjectWorkflowMigrationQuery) (*dto.WorkflowMigrationDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	migration := project.WorkflowMigration()
+	if migration == nil {
+		return nil, errs.NotFound("workflow migration not found: project %s has not planned one", projectID.Value())
+	}
+
+	// Count the tasks per status move
+	moves := make([]dto.WorkflowMigrationMoveDTO, 0)
+	index := make(map[[2]string]int)
+	for _, step := range migration.Steps() {
+		key := [2]string{step.FromStatus.Name(), step.ToStatus.Name()}
+		i, seen := index[key]
+		if !seen {
+			i = len(moves)
+			index[key] = i
+			moves = append(moves, dto.WorkflowMigrationMoveDTO{FromStatus: key[0], ToStatus: key[1]})
+		}
+		moves[i].Tasks++
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i].FromStatus != moves[j].FromStatus {
+			return moves[i].FromStatus < moves[j].FromStatus
+		}
+		return moves[i].ToStatus < moves[j].ToStatus
+	})
+
+	return &dto.WorkflowMigrationDTO{
+		ID:             migration.ID(),
+		FromWorkflowID: migration.FromWorkflowID().Value(),
+		ToWorkflowID:   migration.ToVersion().WorkflowID().Value(),
+		ToVersion:      migration.ToVersion().Number(),
+		Status:         string(migration.Status()),
+		Total:          migration.Total(),
+		Applied:        migration.Applied(),
+		Moves:          moves,
+		PlannedBy:      migration.PlannedBy().Value(),
+		CreatedAt:      migration.CreatedAt(),
+		UpdatedAt:      migration.UpdatedAt(),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+const defaultRecentViewLimit = 20
+
+// GetRecentlyViewedQuery represents a query for a user's recently viewed tasks and projects
+type GetRecentlyViewedQuery struct {
+	UserID string
+	Limit  int
+}
+
+// GetRecentlyViewedQueryHandler handles GetRecentlyViewedQuery
+type GetRecentlyViewedQueryHandler struct {
+	recentViewRepository domain.RecentViewRepository
+	taskRepository       domain.TaskRepository
+	projectRepository    domain.ProjectRepository
+}
+
+// NewGetRecentlyViewedQueryHandler creates a new GetRecentlyViewedQueryHandler
+func NewGetRecentlyViewedQueryHandler(
+	recentViewRepository domain.RecentViewRepository,
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+) *GetRecentlyViewedQueryHandler {
+	return &GetRecentlyViewedQueryHandler{
+		recentViewRepository: recentViewRepository,
+		taskRepository:       taskRepository,
+		projectRepository:    projectRepository,
+	}
+}
+
+// Handle handles the GetRecentlyViewedQuery.
+// Items that no longer exist are skipped.
+func (h *GetRecentlyViewedQueryHandler) Handle(ctx context.Context, query GetRecentlyViewedQuery) ([]*dto.RecentViewDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse user ID
+	userID, err := value.NewUserID(query.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultRecentViewLimit
+	}
+
+	// Get views
+	views, err := h.recentViewRepository.GetRecent(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent views: %w", err)
+	}
+
+	// Resolve labels
+	viewDTOs := make([]*dto.RecentViewDTO, 0, len(views))
+	for _, view := range views {
+		label, ok := h.resolveLabel(ctx, view)
+		if !ok {
+			continue
+		}
+
+		viewDTOs = append(viewDTOs, &dto.RecentViewDTO{
+			Type:     view.Kind().Value(),
+			ID:       view.ItemID(),
+			Label:    label,
+			ViewedAt: view.ViewedAt(),
+		})
+	}
+
+	return viewDTOs, nil
+}
+
+// resolveLabel looks up the display label of a viewed item
+func (h *GetRecentlyViewedQueryHandler) resolveLabel(ctx context.Context, view value.RecentView) (string, bool) {
+	switch view.Kind() {
+	case value.ViewedItemTask:
+		taskID, err := value.NewTaskID(view.ItemID())
+		if err != nil {
+			return "", false
+		}
+		task, err := h.taskRepository.GetByID(ctx, taskID)
+		if err != nil {
+			return "", false
+		}
+		return task.Title(), true
+
+	case value.ViewedItemProject:
+		projectID, err := value.NewProjectID(view.ItemID())
+		if err != nil {
+			return "", false
+		}
+		project, err := h.projectRepository.GetByID(ctx, projectID)
+		if err != nil {
+			return "", false
+		}
+		return project.Name(), true
+
+	default:
+		return "", false
+	}
+}
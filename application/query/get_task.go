@@ -1,58 +1,112 @@
 package query
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
 	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
 // GetTaskQuery represents a query to get a task by ID
 type GetTaskQuery struct {
-	TaskID string
+	TaskID    string
+	Translate bool   // translate comments to the viewer's locale
+	ViewerID  string // who asks, and whose locale comments are translated to
+	Locale    string // used when the viewer has not chosen a locale, e.g. from Accept-Language
+}
+
+// CommentTranslator translates comments on demand. A version of a comment is expected to
+// translate the same every time, so implementations may cache by it.
+type CommentTranslator interface {
+	TranslateComment(commentID string, version int, content, locale string) (string, error)
 }
 
 // GetTaskQueryHandler handles GetTaskQuery
 type GetTaskQueryHandler struct {
-	taskRepository domain.TaskRepository
-	userRepository domain.UserRepository
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	userRepository    domain.UserRepository
+	translator     CommentTranslator
 }
 
 // NewGetTaskQueryHandler creates a new GetTaskQueryHandler
 func NewGetTaskQueryHandler(
 	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
 	userRepository domain.UserRepository,
+	translator CommentTranslator,
 ) *GetTaskQueryHandler {
 	return &GetTaskQueryHandler{
-		taskRepository: taskRepository,
-		userRepository: userRepository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		translator:     translator,
 	}
 }
 
 // Handle handles the GetTaskQuery
-func (h *GetTaskQueryHandler) Handle(query GetTaskQuery) (*dto.TaskDTO, error) {
+func (h *GetTaskQueryHandler) Handle(ctx context.Context, query GetTaskQuery) (*dto.TaskDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
 	// Parse task ID
 	taskID, err := value.NewTaskID(query.TaskID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid task id: %w", err)
+		return nil, errs.Invalid("invalid task id: %w", err)
 	}
 
 	// Get task
-	task, err := h.taskRepository.GetByID(taskID)
+	task, err := h.taskRepository.GetByID(ctx, taskID)
 	if err != nil {
 		return nil, fmt.Errorf("task not found: %w", err)
 	}
 
+	// Check the viewer may see the task's project
+	if err := requireProjectViewer(ctx, h.projectRepository, task.ProjectID(), query.ViewerID); err != nil {
+		return nil, err
+	}
+
 	// Convert to DTO
-	return convertTaskToDTO(task), nil
+	taskDTO := mapper.TaskToDTO(task)
+
+	// Translate comments when asked to and a locale is known
+	if query.Translate {
+		if locale, ok := h.viewerLocale(ctx, query); ok {
+			h.translateComments(taskDTO, locale)
+		}
+	}
+
+	return taskDTO, nil
 }
 
-// Helper function to convert task aggregate to DTO
-func convertTaskToDTO(task interface{}) *dto.TaskDTO {
-	// This is a placeholder - actual implementation would convert task aggregate to DTO
-	// For now returning a basic structure
-	return &dto.TaskDTO{
-		Status: "TO_DO",
+// viewerLocale returns the locale the viewer chose, falling back to the query's
+func (h *GetTaskQueryHandler) viewerLocale(ctx context.Context, query GetTaskQuery) (value.Locale, bool) {
+	if viewerID, err := value.NewUserID(query.ViewerID); err == nil {
+		if viewer, err := h.userRepository.GetByID(ctx, viewerID); err == nil {
+			if locale, ok := viewer.Locale(); ok {
+				return locale, true
+			}
+		}
 	}
-}
\ No newline at end of file
+
+	locale, err := value.NewLocale(query.Locale)
+	return locale, err == nil
+}
+
+// translateComments fills in the translations of a task's comments. A comment the provider
+// fails on is returned untranslated rather than failing the whole task.
+func (h *GetTaskQueryHandler) translateComments(taskDTO *dto.TaskDTO, locale value.Locale) {
+	for i, comment := range taskDTO.Comments {
+		translated, err := h.translator.TranslateComment(comment.ID, comment.Version, comment.Content, locale.Value())
+		if err != nil {
+			continue
+		}
+		taskDTO.Comments[i].TranslatedContent = translated
+		taskDTO.Comments[i].TranslatedLocale = locale.Value()
+	}
+}
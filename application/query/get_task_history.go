@@ -0,0 +1,135 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetTaskHistoryQuery represents a query for the history feed of a task
+type GetTaskHistoryQuery struct {
+	TaskID       string
+	AfterVersion int    // entries after this version, 0 from the start
+	Limit        int    // 0 returns every remaining entry
+	ViewerID     string // may be empty for anonymous requests
+}
+
+// GetTaskHistoryQueryHandler handles GetTaskHistoryQuery
+type GetTaskHistoryQueryHandler struct {
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	eventStore        event.EventStore
+}
+
+// NewGetTaskHistoryQueryHandler creates a new GetTaskHistoryQueryHandler
+func NewGetTaskHistoryQueryHandler(
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+	eventStore event.EventStore,
+) *GetTaskHistoryQueryHandler {
+	return &GetTaskHistoryQueryHandler{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		eventStore:        eventStore,
+	}
+}
+
+// Handle handles the GetTaskHistoryQuery, returning the task's events oldest first.
+// Pass the version of the last entry as AfterVersion to read the next page.
+func (h *GetTaskHistoryQueryHandler) Handle(ctx context.Context, query GetTaskHistoryQuery) ([]dto.TaskHistoryEntryDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse task ID
+	taskID, err := value.NewTaskID(query.TaskID)
+	if err != nil {
+		return nil, errs.Invalid("invalid task id: %w", err)
+	}
+
+	// Make sure the task exists and the viewer may see its project
+	task, err := h.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+	if err := requireProjectViewer(ctx, h.projectRepository, task.ProjectID(), query.ViewerID); err != nil {
+		return nil, err
+	}
+
+	// Get events
+	events, err := h.eventStore.GetEventsPage(ctx, taskID.Value(), query.AfterVersion, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task history: %w", err)
+	}
+
+	// Convert to DTOs
+	entries := make([]dto.TaskHistoryEntryDTO, 0, len(events))
+	for i, evt := range events {
+		entry := historyEntryOf(evt)
+		entry.Version = query.AfterVersion + i + 1
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// historyEntryOf describes one task event for the history feed
+func historyEntryOf(evt event.DomainEvent) dto.TaskHistoryEntryDTO {
+	entry := dto.TaskHistoryEntryDTO{
+		EventType:  evt.EventType(),
+		OccurredAt: evt.OccurredAt(),
+		Summary:    evt.EventType(),
+	}
+
+	switch e := evt.(type) {
+	case event.TaskCreatedEvent:
+		entry.Summary = "created"
+	case event.TaskAssignedEvent:
+		entry.Summary = "assigned to " + e.AssigneeID
+	case event.TaskUnassignedEvent:
+		entry.Summary = "unassigned from " + e.PreviousAssigneeID
+	case event.TaskDeadlineSetEvent:
+		entry.PreviousDeadline = e.PreviousDueDate
+		entry.Deadline = e.DueDate
+		entry.ChangedBy = e.ChangedBy
+		entry.Reason = e.Reason
+
+		entry.Summary = "deadline set to " + e.DueDate
+		if e.PreviousDueDate != "" {
+			entry.Summary = "deadline moved from " + e.PreviousDueDate + " to " + e.DueDate
+		}
+		if e.Reason != "" {
+			entry.Summary += ": " + e.Reason
+		}
+	case event.TaskCompletedEvent:
+		entry.Summary = "completed"
+	case event.TaskStatusChangedEvent:
+		entry.FromStatus = e.OldStatus
+		entry.ToStatus = e.NewStatus
+		entry.Reason = e.Reason
+		entry.Metadata = e.Metadata
+
+		// Show the statuses as the workflow names them
+		if e.OldWorkflowStatus != "" {
+			entry.FromStatus = e.OldWorkflowStatus
+		}
+		if e.NewWorkflowStatus != "" {
+			entry.ToStatus = e.NewWorkflowStatus
+		}
+
+		entry.Summary = "moved to " + entry.ToStatus
+		if value.TaskStatus(e.OldStatus).IsBackwardTo(value.TaskStatus(e.NewStatus)) {
+			entry.Summary = "moved back to " + entry.ToStatus
+		}
+		if e.Reason != "" {
+			entry.Summary += ": " + e.Reason
+		}
+	}
+
+	return entry
+}
@@ -0,0 +1,67 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetTeamQuery represents a query to get a team
+type GetTeamQuery struct {
+	TeamID string
+}
+
+// GetTeamQueryHandler handles GetTeamQuery
+type GetTeamQueryHandler struct {
+	teamRepository domain.TeamRepository
+}
+
+// NewGetTeamQueryHandler creates a new GetTeamQueryHandler
+func NewGetTeamQueryHandler(teamRepository domain.TeamRepository) *GetTeamQueryHandler {
+	return &GetTeamQueryHandler{
+		teamRepository: teamRepository,
+	}
+}
+
+// Handle handles the GetTeamQuery
+func (h *GetTeamQueryHandler) Handle(ctx context.Context, query GetTeamQuery) (*dto.TeamDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse team ID
+	teamID, err := value.NewTeamID(query.TeamID)
+	if err != nil {
+		return nil, errs.Invalid("invalid team id: %w", err)
+	}
+
+	// Get team
+	team, err := h.teamRepository.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+
+	return convertTeamToDTO(team), nil
+}
+
+// convertTeamToDTO converts a Team aggregate to a TeamDTO
+func convertTeamToDTO(team *aggregate.Team) *dto.TeamDTO {
+	memberIDs := make([]string, 0, len(team.MemberIDs()))
+	for _, memberID := range team.MemberIDs() {
+		memberIDs = append(memberIDs, memberID.Value())
+	}
+
+	return &dto.TeamDTO{
+		ID:        team.ID().Value(),
+		Name:      team.Name(),
+		LeadID:    team.LeadID().Value(),
+		MemberIDs: memberIDs,
+		CreatedAt: team.CreatedAt(),
+		UpdatedAt: team.UpdatedAt(),
+	}
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetUserQuery represents a query to get a user by ID
+type GetUserQuery struct {
+	UserID string
+}
+
+// GetUserQueryHandler handles GetUserQuery
+type GetUserQueryHandler struct {
+	userRepository      domain.UserRepository
+	workingHoursService *service.WorkingHoursService
+}
+
+// NewGetUserQueryHandler creates a new GetUserQueryHandler
+func NewGetUserQueryHandler(
+	userRepository domain.UserRepository,
+	workingHoursService *service.WorkingHoursService,
+) *GetUserQueryHandler {
+	return &GetUserQueryHandler{
+		userRepository:      userRepository,
+		workingHoursService: workingHoursService,
+	}
+}
+
+// Handle handles the GetUserQuery
+func (h *GetUserQueryHandler) Handle(ctx context.Context, query GetUserQuery) (*dto.UserDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse user ID
+	userID, err := value.NewUserID(query.UserID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get user
+	user, err := h.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Resolve the working hours the user follows
+	hours, own := h.workingHoursService.WorkingHoursOf(ctx, userID)
+
+	return mapper.UserToDTO(user, hours, own), nil
+}
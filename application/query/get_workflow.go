@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// GetWorkflowQuery represents a query to get a workflow by ID
+type GetWorkflowQuery struct {
+	WorkflowID string
+	Version    int // 0 gets the current version
+}
+
+// GetWorkflowQueryHandler handles GetWorkflowQuery
+type GetWorkflowQueryHandler struct {
+	workflowRepository domain.WorkflowRepository
+}
+
+// NewGetWorkflowQueryHandler creates a new GetWorkflowQueryHandler
+func NewGetWorkflowQueryHandler(workflowRepository domain.WorkflowRepository) *GetWorkflowQueryHandler {
+	return &GetWorkflowQueryHandler{
+		workflowRepository: workflowRepository,
+	}
+}
+
+// Handle handles the GetWorkflowQuery
+func (h *GetWorkflowQueryHandler) Handle(ctx context.Context, query GetWorkflowQuery) (*dto.WorkflowDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse workflow ID
+	workflowID, err := value.NewWorkflowID(query.WorkflowID)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow id: %w", err)
+	}
+
+	// Get workflow, in the version asked for
+	var workflow *aggregate.Workflow
+	if query.Version == 0 {
+		workflow, err = h.workflowRepository.GetByID(ctx, workflowID)
+	} else {
+		version, versionErr := value.NewWorkflowVersion(workflowID, query.Version)
+		if versionErr != nil {
+			return nil, errs.Invalid("invalid workflow version: %w", versionErr)
+		}
+		workflow, err = h.workflowRepository.GetVersion(ctx, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("workflow not found: %w", err)
+	}
+
+	return mapper.WorkflowToDTO(workflow), nil
+}
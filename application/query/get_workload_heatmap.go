@@ -0,0 +1,163 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+const (
+	defaultHeatmapWeeks = 4
+	maxHeatmapWeeks     = 26
+	heatmapDateLayout   = "2006-01-02"
+)
+
+// GetWorkloadHeatmapQuery represents a query for the assignee x day workload matrix
+type GetWorkloadHeatmapQuery struct {
+	Weeks     int    // number of weeks ahead, defaults to 4
+	ProjectID string // optional filter, which also applies the project's holidays to capacity
+}
+
+// GetWorkloadHeatmapQueryHandler handles GetWorkloadHeatmapQuery
+type GetWorkloadHeatmapQueryHandler struct {
+	taskRepository      domain.TaskRepository
+	workingHoursService *service.WorkingHoursService
+	holidayService      *service.HolidayCalendarService
+}
+
+// NewGetWorkloadHeatmapQueryHandler creates a new GetWorkloadHeatmapQueryHandler
+func NewGetWorkloadHeatmapQueryHandler(
+	taskRepository domain.TaskRepository,
+	workingHoursService *service.WorkingHoursService,
+	holidayService *service.HolidayCalendarService,
+) *GetWorkloadHeatmapQueryHandler {
+	return &GetWorkloadHeatmapQueryHandler{
+		taskRepository:      taskRepository,
+		workingHoursService: workingHoursService,
+		holidayService:      holidayService,
+	}
+}
+
+// Handle handles the GetWorkloadHeatmapQuery
+func (h *GetWorkloadHeatmapQueryHandler) Handle(ctx context.Context, query GetWorkloadHeatmapQuery) (*dto.WorkloadHeatmapDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	weeks := query.Weeks
+	if weeks == 0 {
+		weeks = defaultHeatmapWeeks
+	}
+	if weeks < 0 || weeks > maxHeatmapWeeks {
+		return nil, errs.Invalid("weeks must be between 1 and %d", maxHeatmapWeeks)
+	}
+
+	// Get candidate tasks
+	var tasks []*aggregate.Task
+	var calendar *aggregate.HolidayCalendar
+	var err error
+	if query.ProjectID != "" {
+		projectID, parseErr := value.NewProjectID(query.ProjectID)
+		if parseErr != nil {
+			return nil, errs.Invalid("invalid project id: %w", parseErr)
+		}
+		tasks, err = h.taskRepository.GetByProjectID(ctx, projectID)
+		calendar = h.holidayService.CalendarOf(ctx, projectID)
+	} else {
+		tasks, err = h.taskRepository.GetAll(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	// Build the day axis starting today
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	dayCount := weeks * 7
+	end := start.AddDate(0, 0, dayCount)
+
+	days := make([]string, dayCount)
+	for i := range days {
+		days[i] = start.AddDate(0, 0, i).Format(heatmapDateLayout)
+	}
+
+	// Accumulate open, assigned tasks due within the window
+	rowsByAssignee := make(map[string]*dto.WorkloadRowDTO)
+	for _, task := range tasks {
+		if task.Assignee() == nil || task.Deadline() == nil {
+			continue
+		}
+		if task.Status() == value.TaskStatusCompleted || task.Status() == value.TaskStatusCancelled {
+			continue
+		}
+
+		dueDate := task.Deadline().Value().In(now.Location())
+		if dueDate.Before(start) || !dueDate.Before(end) {
+			continue
+		}
+
+		assigneeID := task.Assignee().AssigneeID().Value()
+		row, exists := rowsByAssignee[assigneeID]
+		if !exists {
+			// Each day offers the hours the assignee works on it, none on holidays
+			hours, _ := h.workingHoursService.WorkingHoursOf(ctx, task.Assignee().AssigneeID())
+			row = &dto.WorkloadRowDTO{
+				AssigneeID:  assigneeID,
+				HoursPerDay: hours.HoursPerDay(),
+				WorkingDays: hours.WorkingDayNames(),
+				Cells:       make([]dto.WorkloadCellDTO, dayCount),
+			}
+			for i := range row.Cells {
+				day := start.AddDate(0, 0, i)
+				row.Cells[i].Date = days[i]
+				if holiday := holidayOn(calendar, day); holiday != nil {
+					row.Cells[i].Holiday = holiday.Name()
+				} else {
+					row.Cells[i].CapacityHours = hours.HoursOn(day)
+				}
+				row.TotalCapacityHours += row.Cells[i].CapacityHours
+			}
+			rowsByAssignee[assigneeID] = row
+		}
+
+		dueDay := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, now.Location())
+		index := int(math.Round(dueDay.Sub(start).Hours() / 24))
+		row.Cells[index].TasksDue++
+		row.Cells[index].EstimatedHours += task.EstimatedHours()
+		row.Cells[index].OverCapacity = row.Cells[index].EstimatedHours > row.Cells[index].CapacityHours
+		row.TotalTasksDue++
+		row.TotalEstimatedHours += task.EstimatedHours()
+	}
+
+	rows := make([]dto.WorkloadRowDTO, 0, len(rowsByAssignee))
+	for _, row := range rowsByAssignee {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].AssigneeID < rows[j].AssigneeID
+	})
+
+	return &dto.WorkloadHeatmapDTO{
+		StartDate: days[0],
+		EndDate:   days[dayCount-1],
+		Days:      days,
+		Rows:      rows,
+	}, nil
+}
+
+// holidayOn returns the holiday of the calendar on a day, nil when there is none or no calendar
+func holidayOn(calendar *aggregate.HolidayCalendar, day time.Time) *value.Holiday {
+	if calendar == nil {
+		return nil
+	}
+	return calendar.HolidayOn(day)
+}
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// DefaultEventPageSize is how many events a page holds unless the query asks for fewer
+const DefaultEventPageSize = 100
+
+// MaxEventPageSize is the largest page of events one query returns
+const MaxEventPageSize = 1000
+
+// EventPayloads renders an event's fields as in the JSON wire format
+type EventPayloads interface {
+	Payload(evt event.DomainEvent) map[string]interface{}
+}
+
+// ListEventsQuery represents a query for a page of the event log
+type ListEventsQuery struct {
+	AggregateID   string
+	EventType     string
+	Since         string // RFC3339, events that occurred after it
+	AfterPosition int64  // events after this position, 0 from the start
+	Limit         int    // 0 for DefaultEventPageSize
+}
+
+// ListEventsQueryHandler handles ListEventsQuery
+type ListEventsQueryHandler struct {
+	eventStore event.EventStore
+	payloads   EventPayloads
+}
+
+// NewListEventsQueryHandler creates a new ListEventsQueryHandler
+func NewListEventsQueryHandler(eventStore event.EventStore, payloads EventPayloads) *ListEventsQueryHandler {
+	return &ListEventsQueryHandler{
+		eventStore: eventStore,
+		payloads:   payloads,
+	}
+}
+
+// Handle handles the ListEventsQuery, returning matching events oldest first.
+// Pass the page's NextAfter as AfterPosition to read the next one.
+func (h *ListEventsQueryHandler) Handle(ctx context.Context, query ListEventsQuery) (*dto.EventPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	if query.Limit < 0 || query.Limit > MaxEventPageSize {
+		return nil, errs.Invalid("invalid limit: must be between 1 and %d", MaxEventPageSize)
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultEventPageSize
+	}
+	if query.Since != "" {
+		if _, err := time.Parse(time.RFC3339, query.Since); err != nil {
+			return nil, errs.Invalid("invalid since: must be an RFC 3339 time such as 2024-01-31T09:00:00Z")
+		}
+	}
+
+	stored, err := h.eventStore.QueryEvents(ctx, event.EventFilter{
+		AggregateID:   query.AggregateID,
+		EventType:     query.EventType,
+		Since:         query.Since,
+		AfterPosition: query.AfterPosition,
+		Limit:         query.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	page := &dto.EventPageDTO{
+		Events: make([]dto.EventRecordDTO, 0, len(stored)),
+		Count:  len(stored),
+	}
+	for _, entry := range stored {
+		page.Events = append(page.Events, dto.EventRecordDTO{
+			Position:      entry.Position,
+			EventID:       entry.Event.EventID(),
+			EventType:     entry.Event.EventType(),
+			AggregateID:   entry.Event.AggregateID(),
+			AggregateType: entry.Event.AggregateType(),
+			OccurredAt:    entry.Event.OccurredAt(),
+			Payload:       h.payloads.Payload(entry.Event),
+		})
+		page.NextAfter = entry.Position
+	}
+
+	return page, nil
+}
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListHolidayCalendarsQuery represents a query for a page of an organization's holiday calendars
+type ListHolidayCalendarsQuery struct {
+	OrganizationID string
+	Page           domain.Page
+}
+
+// ListHolidayCalendarsQueryHandler handles ListHolidayCalendarsQuery
+type ListHolidayCalendarsQueryHandler struct {
+	calendarRepository domain.HolidayCalendarRepository
+}
+
+// NewListHolidayCalendarsQueryHandler creates a new ListHolidayCalendarsQueryHandler
+func NewListHolidayCalendarsQueryHandler(
+	calendarRepository domain.HolidayCalendarRepository,
+) *ListHolidayCalendarsQueryHandler {
+	return &ListHolidayCalendarsQueryHandler{
+		calendarRepository: calendarRepository,
+	}
+}
+
+// Handle handles the ListHolidayCalendarsQuery, listing calendars by region unless the page sorts otherwise
+func (h *ListHolidayCalendarsQueryHandler) Handle(
+	ctx context.Context,
+	query ListHolidayCalendarsQuery,
+) (*dto.HolidayCalendarPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse organization ID
+	organizationID, err := value.NewOrganizationID(query.OrganizationID)
+	if err != nil {
+		return nil, errs.Invalid("invalid organization id: %w", err)
+	}
+
+	// Get holiday calendars
+	calendars, result, err := h.calendarRepository.List(ctx, domain.HolidayCalendarFilter{
+		OrganizationID: organizationID.Value(),
+	}, query.Page)
+	if err != nil {
+		return nil, pageError("failed to get holiday calendars", err)
+	}
+
+	// Convert to DTOs
+	calendarDTOs := make([]*dto.HolidayCalendarDTO, 0, len(calendars))
+	for _, calendar := range calendars {
+		calendarDTOs = append(calendarDTOs, convertHolidayCalendarToDTO(calendar))
+	}
+
+	return &dto.HolidayCalendarPageDTO{Calendars: calendarDTOs, PageDTO: mapper.PageToDTO(len(calendarDTOs), result)}, nil
+}
+
+// Helper function to convert holiday calendar aggregate to DTO
+func convertHolidayCalendarToDTO(calendar *aggregate.HolidayCalendar) *dto.HolidayCalendarDTO {
+	holidays := make([]dto.HolidayDTO, 0, len(calendar.Holidays()))
+	for _, holiday := range calendar.Holidays() {
+		holidayDTO := dto.HolidayDTO{Name: holiday.Name(), Rule: string(holiday.Rule())}
+		switch holiday.Rule() {
+		case value.HolidayRuleDate:
+			holidayDTO.Date = holiday.Date().Format("2006-01-02")
+		case value.HolidayRuleAnnual:
+			holidayDTO.Month = int(holiday.Month())
+			holidayDTO.Day = holiday.Day()
+		case value.HolidayRuleNthWeekday:
+			holidayDTO.Month = int(holiday.Month())
+			holidayDTO.Weekday = strings.ToUpper(holiday.Weekday().String())
+			holidayDTO.Nth = holiday.Nth()
+		}
+		holidays = append(holidays, holidayDTO)
+	}
+
+	return &dto.HolidayCalendarDTO{
+		ID:             calendar.ID().Value(),
+		OrganizationID: calendar.OrganizationID().Value(),
+		Region:         calendar.Region(),
+		Name:           calendar.Name(),
+		Holidays:       holidays,
+		CreatedAt:      calendar.CreatedAt(),
+		UpdatedAt:      calendar.UpdatedAt(),
+	}
+}
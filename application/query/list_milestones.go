@@ -0,0 +1,112 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListMilestonesQuery represents a query to list a project's milestones with their progress
+type ListMilestonesQuery struct {
+	ProjectID string
+	ViewerID  string // may be empty for anonymous requests
+}
+
+// ListMilestonesQueryHandler handles ListMilestonesQuery
+type ListMilestonesQueryHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	progressService   *service.MilestoneProgressService
+}
+
+// NewListMilestonesQueryHandler creates a new ListMilestonesQueryHandler
+func NewListMilestonesQueryHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	progressService *service.MilestoneProgressService,
+) *ListMilestonesQueryHandler {
+	return &ListMilestonesQueryHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		progressService:   progressService,
+	}
+}
+
+// Handle handles the ListMilestonesQuery
+func (h *ListMilestonesQueryHandler) Handle(ctx context.Context, query ListMilestonesQuery) ([]*dto.MilestoneDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project and its tasks
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	if err := requireViewer(project, query.ViewerID); err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	tasksByID := make(map[string]*aggregate.Task, len(tasks))
+	for _, task := range tasks {
+		tasksByID[task.ID().Value()] = task
+	}
+
+	// Convert to DTOs
+	now := time.Now()
+	milestoneDTOs := make([]*dto.MilestoneDTO, 0, len(project.Milestones()))
+	for _, milestone := range project.Milestones() {
+		progress := h.progressService.Progress(milestone, tasksByID)
+		milestoneDTOs = append(milestoneDTOs, convertMilestoneToDTO(milestone, progress, now))
+	}
+
+	return milestoneDTOs, nil
+}
+
+// Helper function to convert milestone entity to DTO
+func convertMilestoneToDTO(
+	milestone *entity.Milestone,
+	progress service.MilestoneProgress,
+	now time.Time,
+) *dto.MilestoneDTO {
+	taskIDs := make([]string, 0, len(milestone.TaskIDs()))
+	for _, taskID := range milestone.TaskIDs() {
+		taskIDs = append(taskIDs, taskID.Value())
+	}
+
+	return &dto.MilestoneDTO{
+		ID:          milestone.ID(),
+		Name:        milestone.Name(),
+		Description: milestone.Description(),
+		DueDate:     milestone.DueDate(),
+		TaskIDs:     taskIDs,
+		Progress: dto.MilestoneProgressDTO{
+			Total:     progress.Total,
+			Completed: progress.Completed,
+			Percent:   progress.Percent,
+		},
+		Overdue:   !milestone.IsReached() && now.After(milestone.DueDate()),
+		ReachedAt: milestone.ReachedAt(),
+		CreatedAt: milestone.CreatedAt(),
+		UpdatedAt: milestone.UpdatedAt(),
+	}
+}
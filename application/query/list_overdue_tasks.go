@@ -0,0 +1,123 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListOverdueTasksQuery represents a query to list the open tasks past their deadline
+type ListOverdueTasksQuery struct {
+	ProjectID string // empty lists the overdue tasks of every project the viewer may see
+	ViewerID  string // may be empty for anonymous requests
+}
+
+// ListOverdueTasksQueryHandler handles ListOverdueTasksQuery
+type ListOverdueTasksQueryHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	deadlineService   *service.DeadlineEnforcementService
+}
+
+// NewListOverdueTasksQueryHandler creates a new ListOverdueTasksQueryHandler
+func NewListOverdueTasksQueryHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	deadlineService *service.DeadlineEnforcementService,
+) *ListOverdueTasksQueryHandler {
+	return &ListOverdueTasksQueryHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		deadlineService:   deadlineService,
+	}
+}
+
+// Handle handles the ListOverdueTasksQuery, listing the most overdue tasks first
+func (h *ListOverdueTasksQueryHandler) Handle(ctx context.Context, query ListOverdueTasksQuery) ([]*dto.TaskDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	tasks, err := deadlineCandidates(ctx, h.projectRepository, h.taskRepository, query.ProjectID, query.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasksByDeadline(h.deadlineService.GetOverdueTasks(tasks)), nil
+}
+
+// deadlineCandidates returns the tasks of a project, or of every project the viewer may see,
+// leaving out archived projects whose deadlines no longer matter
+func deadlineCandidates(
+	ctx context.Context,
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	rawProjectID, rawViewerID string,
+) ([]*aggregate.Task, error) {
+	if rawProjectID != "" {
+		projectID, err := value.NewProjectID(rawProjectID)
+		if err != nil {
+			return nil, errs.Invalid("invalid project id: %w", err)
+		}
+
+		project, err := projectRepository.GetByID(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("project not found: %w", err)
+		}
+		if project.IsArchived() {
+			return nil, nil
+		}
+
+		tasks, err := taskRepository.GetByProjectID(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tasks: %w", err)
+		}
+		return tasks, nil
+	}
+
+	var viewerID *value.UserID
+	if id, err := value.NewUserID(rawViewerID); err == nil {
+		viewerID = &id
+	}
+
+	projects, err := projectRepository.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	tasks := make([]*aggregate.Task, 0)
+	for _, project := range projects {
+		if !project.CanView(viewerID) {
+			continue
+		}
+
+		projectTasks, err := taskRepository.GetByProjectID(ctx, project.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tasks: %w", err)
+		}
+		tasks = append(tasks, projectTasks...)
+	}
+
+	return tasks, nil
+}
+
+// tasksByDeadline converts tasks to DTOs ordered by their deadline, earliest first
+func tasksByDeadline(tasks []*aggregate.Task) []*dto.TaskDTO {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Deadline().Value().Before(tasks[j].Deadline().Value())
+	})
+
+	taskDTOs := make([]*dto.TaskDTO, 0, len(tasks))
+	for _, task := range tasks {
+		taskDTOs = append(taskDTOs, mapper.TaskToDTO(task))
+	}
+	return taskDTOs
+}
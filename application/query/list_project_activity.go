@@ -0,0 +1,115 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+const (
+	defaultActivityLimit = 20
+	maxActivityLimit     = 100
+)
+
+// ActivityEntry is one line of a project's activity stream
+type ActivityEntry struct {
+	Sequence   int // position in the project's stream from 1
+	EventType  string
+	TaskID     string
+	ActorID    string // empty when the event does not say who acted
+	Summary    string // e.g. `Ada Lovelace commented on "Fix login"`
+	OccurredAt time.Time
+}
+
+// ProjectActivityFeed is the read model backing project activity.
+// Page returns up to limit entries before the sequence given, newest first;
+// before 0 starts from the newest entry.
+type ProjectActivityFeed interface {
+	Page(projectID string, before, limit int) []ActivityEntry
+}
+
+// ListProjectActivityQuery represents a query for a page of a project's activity stream
+type ListProjectActivityQuery struct {
+	ProjectID string
+	Before    int    // entries before this sequence, 0 from the newest
+	Limit     int    // 0 uses the default page size
+	ViewerID  string // may be empty for anonymous requests
+}
+
+// ListProjectActivityQueryHandler handles ListProjectActivityQuery
+type ListProjectActivityQueryHandler struct {
+	feed              ProjectActivityFeed
+	projectRepository domain.ProjectRepository
+}
+
+// NewListProjectActivityQueryHandler creates a new ListProjectActivityQueryHandler
+func NewListProjectActivityQueryHandler(
+	feed ProjectActivityFeed,
+	projectRepository domain.ProjectRepository,
+) *ListProjectActivityQueryHandler {
+	return &ListProjectActivityQueryHandler{
+		feed:              feed,
+		projectRepository: projectRepository,
+	}
+}
+
+// Handle handles the ListProjectActivityQuery, returning entries newest first.
+// Pass the sequence of the last entry as Before to read the next page.
+func (h *ListProjectActivityQueryHandler) Handle(ctx context.Context, query ListProjectActivityQuery) ([]dto.ActivityEntryDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	// Get project
+	project, err := h.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	var viewerID *value.UserID
+	if id, err := value.NewUserID(query.ViewerID); err == nil {
+		viewerID = &id
+	}
+	if !project.CanView(viewerID) {
+		return nil, errs.PermissionDenied("permission denied: project activity is only visible to its members")
+	}
+
+	if query.Before < 0 {
+		return nil, errs.Invalid("invalid cursor: before cannot be negative")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultActivityLimit
+	}
+	if limit > maxActivityLimit {
+		limit = maxActivityLimit
+	}
+
+	// Convert to DTOs
+	page := h.feed.Page(projectID.Value(), query.Before, limit)
+	entries := make([]dto.ActivityEntryDTO, 0, len(page))
+	for _, entry := range page {
+		entries = append(entries, dto.ActivityEntryDTO{
+			Sequence:   entry.Sequence,
+			EventType:  entry.EventType,
+			TaskID:     entry.TaskID,
+			ActorID:    entry.ActorID,
+			Summary:    entry.Summary,
+			OccurredAt: entry.OccurredAt,
+		})
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListProjectsQuery represents a query for a page of the projects a user may see
+type ListProjectsQuery struct {
+	OwnerID  string // optional filter
+	Archived *bool  // nil lists archived and active projects
+	ViewerID string // may be empty for anonymous requests
+	Page     domain.Page
+}
+
+// ListProjectsQueryHandler handles ListProjectsQuery
+type ListProjectsQueryHandler struct {
+	projectRepository domain.ProjectRepository
+}
+
+// NewListProjectsQueryHandler creates a new ListProjectsQueryHandler
+func NewListProjectsQueryHandler(projectRepository domain.ProjectRepository) *ListProjectsQueryHandler {
+	return &ListProjectsQueryHandler{
+		projectRepository: projectRepository,
+	}
+}
+
+// Handle handles the ListProjectsQuery, listing projects by name unless the page sorts otherwise
+func (h *ListProjectsQueryHandler) Handle(ctx context.Context, query ListProjectsQuery) (*dto.ProjectPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	if query.OwnerID != "" {
+		if _, err := value.NewUserID(query.OwnerID); err != nil {
+			return nil, errs.Invalid("invalid owner id: %w", err)
+		}
+	}
+
+	// Restricted projects are left out for viewers who cannot see them
+	projects, result, err := h.projectRepository.List(ctx, domain.ProjectFilter{
+		OwnerID:     query.OwnerID,
+		Archived:    query.Archived,
+		VisibleOnly: true,
+		ViewerID:    query.ViewerID,
+	}, query.Page)
+	if err != nil {
+		return nil, pageError("failed to get projects", err)
+	}
+
+	projectDTOs := make([]*dto.ProjectDTO, 0, len(projects))
+	for _, project := range projects {
+		projectDTOs = append(projectDTOs, mapper.ProjectToDTO(project))
+	}
+
+	return &dto.ProjectPageDTO{Projects: projectDTOs, PageDTO: mapper.PageToDTO(len(projectDTOs), result)}, nil
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListSprintTasksQuery represents a query for the tasks planned into a sprint
+type ListSprintTasksQuery struct {
+	SprintID string
+	Status   string // optional filter
+	ViewerID string // may be empty for anonymous requests
+}
+
+// ListSprintTasksQueryHandler handles ListSprintTasksQuery
+type ListSprintTasksQueryHandler struct {
+	sprintRepository  domain.SprintRepository
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+}
+
+// NewListSprintTasksQueryHandler creates a new ListSprintTasksQueryHandler
+func NewListSprintTasksQueryHandler(
+	sprintRepository domain.SprintRepository,
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+) *ListSprintTasksQueryHandler {
+	return &ListSprintTasksQueryHandler{
+		sprintRepository:  sprintRepository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+	}
+}
+
+// Handle handles the ListSprintTasksQuery.
+// Tasks deleted since they were planned are skipped.
+func (h *ListSprintTasksQueryHandler) Handle(ctx context.Context, query ListSprintTasksQuery) ([]*dto.TaskDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse sprint ID
+	sprintID, err := value.NewSprintID(query.SprintID)
+	if err != nil {
+		return nil, errs.Invalid("invalid sprint id: %w", err)
+	}
+
+	var status value.TaskStatus
+	if query.Status != "" {
+		status, err = value.NewTaskStatus(query.Status)
+		if err != nil {
+			return nil, errs.Invalid("invalid status: %w", err)
+		}
+	}
+
+	// Get sprint
+	sprint, err := h.sprintRepository.GetByID(ctx, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("sprint not found: %w", err)
+	}
+	if err := requireProjectViewer(ctx, h.projectRepository, sprint.ProjectID(), query.ViewerID); err != nil {
+		return nil, err
+	}
+
+	// Get tasks
+	taskDTOs := make([]*dto.TaskDTO, 0, len(sprint.TaskIDs()))
+	for _, taskID := range sprint.TaskIDs() {
+		task, err := h.taskRepository.GetByID(ctx, taskID)
+		if err != nil {
+			continue
+		}
+
+		if query.Status != "" && task.Status() != status {
+			continue
+		}
+
+		taskDTOs = append(taskDTOs, mapper.TaskToDTO(task))
+	}
+
+	return taskDTOs, nil
+}
@@ -0,0 +1,92 @@
+package query
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListSprintsQuery represents a query for a page of a project's sprints
+type ListSprintsQuery struct {
+	ProjectID string
+	Status    string // optional filter
+	Page      domain.Page
+}
+
+// ListSprintsQueryHandler handles ListSprintsQuery
+type ListSprintsQueryHandler struct {
+	sprintRepository domain.SprintRepository
+}
+
+// NewListSprintsQueryHandler creates a new ListSprintsQueryHandler
+func NewListSprintsQueryHandler(
+	sprintRepository domain.SprintRepository,
+) *ListSprintsQueryHandler {
+	return &ListSprintsQueryHandler{
+		sprintRepository: sprintRepository,
+	}
+}
+
+// Handle handles the ListSprintsQuery, listing sprints by start date unless the page sorts otherwise
+func (h *ListSprintsQueryHandler) Handle(ctx context.Context, query ListSprintsQuery) (*dto.SprintPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	if query.Status != "" {
+		if _, err := value.NewSprintStatus(query.Status); err != nil {
+			return nil, errs.Invalid("invalid status: %w", err)
+		}
+	}
+
+	// Get sprints
+	sprints, result, err := h.sprintRepository.List(ctx, domain.SprintFilter{
+		ProjectID: projectID.Value(),
+		Status:    query.Status,
+	}, query.Page)
+	if err != nil {
+		return nil, pageError("failed to get sprints", err)
+	}
+
+	// Convert to DTOs
+	sprintDTOs := make([]*dto.SprintDTO, 0, len(sprints))
+	for _, sprint := range sprints {
+		sprintDTOs = append(sprintDTOs, convertSprintToDTO(sprint))
+	}
+
+	return &dto.SprintPageDTO{Sprints: sprintDTOs, PageDTO: mapper.PageToDTO(len(sprintDTOs), result)}, nil
+}
+
+// Helper function to convert sprint aggregate to DTO
+func convertSprintToDTO(sprint *aggregate.Sprint) *dto.SprintDTO {
+	taskIDs := make([]string, 0, len(sprint.TaskIDs()))
+	for _, taskID := range sprint.TaskIDs() {
+		taskIDs = append(taskIDs, taskID.Value())
+	}
+
+	return &dto.SprintDTO{
+		ID:          sprint.ID().Value(),
+		ProjectID:   sprint.ProjectID().Value(),
+		Name:        sprint.Name(),
+		Goal:        sprint.Goal(),
+		StartDate:   sprint.StartDate(),
+		EndDate:     sprint.EndDate(),
+		Status:      sprint.Status().Value(),
+		TaskIDs:     taskIDs,
+		StartedAt:   sprint.StartedAt(),
+		CompletedAt: sprint.CompletedAt(),
+		CreatedAt:   sprint.CreatedAt(),
+		UpdatedAt:   sprint.UpdatedAt(),
+	}
+}
@@ -1,64 +1,83 @@
 package query
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
 	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
-// ListTasksByProjectQuery represents a query to list tasks by project
+// ListTasksByProjectQuery represents a query for a page of a project's tasks
 type ListTasksByProjectQuery struct {
 	ProjectID string
 	Status    string // optional filter
+	Page      domain.Page
+	ViewerID  string // may be empty for anonymous requests
 }
 
 // ListTasksByProjectQueryHandler handles ListTasksByProjectQuery
 type ListTasksByProjectQueryHandler struct {
-	taskRepository domain.TaskRepository
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
 }
 
 // NewListTasksByProjectQueryHandler creates a new ListTasksByProjectQueryHandler
 func NewListTasksByProjectQueryHandler(
 	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
 ) *ListTasksByProjectQueryHandler {
 	return &ListTasksByProjectQueryHandler{
-		taskRepository: taskRepository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
 	}
 }
 
-// Handle handles the ListTasksByProjectQuery
-func (h *ListTasksByProjectQueryHandler) Handle(query ListTasksByProjectQuery) ([]*dto.TaskDTO, error) {
+// Handle handles the ListTasksByProjectQuery, oldest first unless the page sorts otherwise.
+// Sorted by votes, the most voted tasks come first unless the page sets a direction.
+func (h *ListTasksByProjectQueryHandler) Handle(ctx context.Context, query ListTasksByProjectQuery) (*dto.TaskPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
 	// Parse project ID
 	projectID, err := value.NewProjectID(query.ProjectID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid project id: %w", err)
+		return nil, errs.Invalid("invalid project id: %w", err)
 	}
 
-	// Get tasks for project
-	var tasks interface{}
-	var err2 error
+	// Check the viewer may see the project
+	if err := requireProjectViewer(ctx, h.projectRepository, projectID, query.ViewerID); err != nil {
+		return nil, err
+	}
 
 	if query.Status != "" {
-		// Get tasks for project with specific status
-		status, err := value.NewTaskStatus(query.Status)
-		if err != nil {
-			return nil, fmt.Errorf("invalid status: %w", err)
+		if _, err := value.NewTaskStatus(query.Status); err != nil {
+			return nil, errs.Invalid("invalid status: %w", err)
 		}
-		tasks, err2 = h.taskRepository.FindByProjectIDAndStatus(projectID, status)
-	} else {
-		// Get all tasks for project
-		tasks, err2 = h.taskRepository.GetByProjectID(projectID)
 	}
 
-	if err2 != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err2)
+	page := query.Page
+	if page.SortBy == "votes" && page.SortDir == "" {
+		page.SortDir = domain.SortDescending
+	}
+
+	// Get tasks for project
+	tasks, result, err := h.taskRepository.List(ctx, domain.TaskFilter{
+		ProjectID: projectID.Value(),
+		Status:    query.Status,
+	}, page)
+	if err != nil {
+		return nil, pageError("failed to get tasks", err)
 	}
 
 	// Convert to DTOs
-	taskDTOs := make([]*dto.TaskDTO, 0)
-	_ = tasks // placeholder - actual implementation would convert
+	taskDTOs := make([]*dto.TaskDTO, 0, len(tasks))
+	for _, task := range tasks {
+		taskDTOs = append(taskDTOs, mapper.TaskToDTO(task))
+	}
 
-	return taskDTOs, nil
-}
\ No newline at end of file
+	return &dto.TaskPageDTO{Tasks: taskDTOs, PageDTO: mapper.PageToDTO(len(taskDTOs), result)}, nil
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+)
+
+// ListTasksDueWithinQuery represents a query to list the open tasks due soon
+type ListTasksDueWithinQuery struct {
+	ProjectID string // empty lists the tasks of every project the viewer may see
+	ViewerID  string // may be empty for anonymous requests
+	Within    time.Duration
+}
+
+// ListTasksDueWithinQueryHandler handles ListTasksDueWithinQuery
+type ListTasksDueWithinQueryHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	deadlineService   *service.DeadlineEnforcementService
+}
+
+// NewListTasksDueWithinQueryHandler creates a new ListTasksDueWithinQueryHandler
+func NewListTasksDueWithinQueryHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	deadlineService *service.DeadlineEnforcementService,
+) *ListTasksDueWithinQueryHandler {
+	return &ListTasksDueWithinQueryHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		deadlineService:   deadlineService,
+	}
+}
+
+// Handle handles the ListTasksDueWithinQuery, listing the soonest due first.
+// Tasks already overdue are not due soon, ListOverdueTasksQuery lists them.
+func (h *ListTasksDueWithinQueryHandler) Handle(ctx context.Context, query ListTasksDueWithinQuery) ([]*dto.TaskDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	if query.Within <= 0 {
+		return nil, errs.Invalid("invalid duration: within must be positive")
+	}
+
+	tasks, err := deadlineCandidates(ctx, h.projectRepository, h.taskRepository, query.ProjectID, query.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasksByDeadline(h.deadlineService.GetTasksDueWithin(tasks, query.Within)), nil
+}
@@ -0,0 +1,92 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListTeamTasksQuery represents a query for the tasks a team is responsible for
+type ListTeamTasksQuery struct {
+	TeamID         string
+	Status         string // optional filter
+	IncludeMembers bool   // also list tasks assigned to the team's members individually
+	Page           domain.Page
+}
+
+// ListTeamTasksQueryHandler handles ListTeamTasksQuery
+type ListTeamTasksQueryHandler struct {
+	teamRepository domain.TeamRepository
+	taskRepository domain.TaskRepository
+}
+
+// NewListTeamTasksQueryHandler creates a new ListTeamTasksQueryHandler
+func NewListTeamTasksQueryHandler(
+	teamRepository domain.TeamRepository,
+	taskRepository domain.TaskRepository,
+) *ListTeamTasksQueryHandler {
+	return &ListTeamTasksQueryHandler{
+		teamRepository: teamRepository,
+		taskRepository: taskRepository,
+	}
+}
+
+// Handle handles the ListTeamTasksQuery.
+// Tasks are listed once even when both the team and a member hold them, newest first
+// unless the page sorts otherwise.
+func (h *ListTeamTasksQueryHandler) Handle(ctx context.Context, query ListTeamTasksQuery) (*dto.TaskPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse team ID
+	teamID, err := value.NewTeamID(query.TeamID)
+	if err != nil {
+		return nil, errs.Invalid("invalid team id: %w", err)
+	}
+
+	if query.Status != "" {
+		if _, err := value.NewTaskStatus(query.Status); err != nil {
+			return nil, errs.Invalid("invalid status: %w", err)
+		}
+	}
+
+	// Get team
+	team, err := h.teamRepository.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("team not found: %w", err)
+	}
+
+	filter := domain.TaskFilter{TeamID: team.ID().Value(), Status: query.Status}
+	if query.IncludeMembers {
+		for _, memberID := range team.MemberIDs() {
+			filter.TeamMemberIDs = append(filter.TeamMemberIDs, memberID.Value())
+		}
+	}
+
+	page := query.Page
+	if page.SortBy == "" {
+		page.SortBy = "created_at"
+		if page.SortDir == "" {
+			page.SortDir = domain.SortDescending
+		}
+	}
+
+	// Get tasks
+	tasks, result, err := h.taskRepository.List(ctx, filter, page)
+	if err != nil {
+		return nil, pageError("failed to get team tasks", err)
+	}
+
+	taskDTOs := make([]*dto.TaskDTO, 0, len(tasks))
+	for _, task := range tasks {
+		taskDTOs = append(taskDTOs, mapper.TaskToDTO(task))
+	}
+
+	return &dto.TaskPageDTO{Tasks: taskDTOs, PageDTO: mapper.PageToDTO(len(taskDTOs), result)}, nil
+}
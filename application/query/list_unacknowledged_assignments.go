@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListUnacknowledgedAssignmentsQuery represents a query for the open tasks a manager assigned
+// that their assignees have not acknowledged yet
+type ListUnacknowledgedAssignmentsQuery struct {
+	AssignedBy string
+	ProjectID  string // optional filter
+}
+
+// ListUnacknowledgedAssignmentsQueryHandler handles ListUnacknowledgedAssignmentsQuery
+type ListUnacknowledgedAssignmentsQueryHandler struct {
+	taskRepository domain.TaskRepository
+}
+
+// NewListUnacknowledgedAssignmentsQueryHandler creates a new ListUnacknowledgedAssignmentsQueryHandler
+func NewListUnacknowledgedAssignmentsQueryHandler(
+	taskRepository domain.TaskRepository,
+) *ListUnacknowledgedAssignmentsQueryHandler {
+	return &ListUnacknowledgedAssignmentsQueryHandler{
+		taskRepository: taskRepository,
+	}
+}
+
+// Handle handles the ListUnacknowledgedAssignmentsQuery.
+// Tasks are listed longest waiting first.
+func (h *ListUnacknowledgedAssignmentsQueryHandler) Handle(ctx context.Context, query ListUnacknowledgedAssignmentsQuery) ([]*dto.TaskDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse IDs
+	assignedBy, err := value.NewUserID(query.AssignedBy)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get tasks
+	var tasks []*aggregate.Task
+	if query.ProjectID != "" {
+		projectID, err := value.NewProjectID(query.ProjectID)
+		if err != nil {
+			return nil, errs.Invalid("invalid project id: %w", err)
+		}
+		tasks, err = h.taskRepository.GetByProjectID(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project tasks: %w", err)
+		}
+	} else {
+		tasks, err = h.taskRepository.GetAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tasks: %w", err)
+		}
+	}
+
+	selected := make([]*aggregate.Task, 0)
+	for _, task := range tasks {
+		assignee := task.Assignee()
+		if assignee == nil || !task.IsOpen() || assignee.IsAcknowledged() || !assignee.AssignedBy().Equals(assignedBy) {
+			continue
+		}
+		selected = append(selected, task)
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Assignee().AssignedAt().Before(selected[j].Assignee().AssignedAt())
+	})
+
+	taskDTOs := make([]*dto.TaskDTO, 0, len(selected))
+	for _, task := range selected {
+		taskDTOs = append(taskDTOs, mapper.TaskToDTO(task))
+	}
+
+	return taskDTOs, nil
+}
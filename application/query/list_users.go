@@ -0,0 +1,54 @@
+package query
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/service"
+)
+
+// ListUsersQuery represents a query for a page of users
+type ListUsersQuery struct {
+	Active *bool // nil lists active and deactivated users
+	Page   domain.Page
+}
+
+// ListUsersQueryHandler handles ListUsersQuery
+type ListUsersQueryHandler struct {
+	userRepository      domain.UserRepository
+	workingHoursService *service.WorkingHoursService
+}
+
+// NewListUsersQueryHandler creates a new ListUsersQueryHandler
+func NewListUsersQueryHandler(
+	userRepository domain.UserRepository,
+	workingHoursService *service.WorkingHoursService,
+) *ListUsersQueryHandler {
+	return &ListUsersQueryHandler{
+		userRepository:      userRepository,
+		workingHoursService: workingHoursService,
+	}
+}
+
+// Handle handles the ListUsersQuery, listing users by email address unless the page sorts otherwise
+func (h *ListUsersQueryHandler) Handle(ctx context.Context, query ListUsersQuery) (*dto.UserPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Get users
+	users, result, err := h.userRepository.List(ctx, domain.UserFilter{Active: query.Active}, query.Page)
+	if err != nil {
+		return nil, pageError("failed to get users", err)
+	}
+
+	userDTOs := make([]*dto.UserDTO, 0, len(users))
+	for _, user := range users {
+		hours, own := h.workingHoursService.WorkingHoursOf(ctx, user.ID())
+		userDTOs = append(userDTOs, mapper.UserToDTO(user, hours, own))
+	}
+
+	return &dto.UserPageDTO{Users: userDTOs, PageDTO: mapper.PageToDTO(len(userDTOs), result)}, nil
+}
@@ -0,0 +1,79 @@
+package query
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ListWidgetsQuery represents a query for a page of a user's dashboard widgets
+type ListWidgetsQuery struct {
+	OwnerID string
+	Page    domain.Page
+}
+
+// ListWidgetsQueryHandler handles ListWidgetsQuery
+type ListWidgetsQueryHandler struct {
+	widgetRepository domain.WidgetRepository
+}
+
+// NewListWidgetsQueryHandler creates a new ListWidgetsQueryHandler
+func NewListWidgetsQueryHandler(
+	widgetRepository domain.WidgetRepository,
+) *ListWidgetsQueryHandler {
+	return &ListWidgetsQueryHandler{
+		widgetRepository: widgetRepository,
+	}
+}
+
+// Handle handles the ListWidgetsQuery, listing widgets by their place on the grid unless the page sorts otherwise
+func (h *ListWidgetsQueryHandler) Handle(ctx context.Context, query ListWidgetsQuery) (*dto.WidgetPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse owner ID
+	ownerID, err := value.NewUserID(query.OwnerID)
+	if err != nil {
+		return nil, errs.Invalid("invalid user id: %w", err)
+	}
+
+	// Get widgets
+	widgets, result, err := h.widgetRepository.List(ctx, domain.WidgetFilter{OwnerID: ownerID.Value()}, query.Page)
+	if err != nil {
+		return nil, pageError("failed to get widgets", err)
+	}
+
+	// Convert to DTOs
+	widgetDTOs := make([]*dto.WidgetDTO, 0, len(widgets))
+	for _, widget := range widgets {
+		widgetDTOs = append(widgetDTOs, convertWidgetToDTO(widget))
+	}
+
+	return &dto.WidgetPageDTO{Widgets: widgetDTOs, PageDTO: mapper.PageToDTO(len(widgetDTOs), result)}, nil
+}
+
+// Helper function to convert widget aggregate to DTO
+func convertWidgetToDTO(widget *aggregate.Widget) *dto.WidgetDTO {
+	layout := widget.Layout()
+	return &dto.WidgetDTO{
+		ID:         widget.ID().Value(),
+		OwnerID:    widget.OwnerID().Value(),
+		Title:      widget.Title(),
+		Type:       widget.Type().Value(),
+		Parameters: widget.Parameters(),
+		Layout: dto.WidgetLayoutDTO{
+			Column: layout.Column(),
+			Row:    layout.Row(),
+			Width:  layout.Width(),
+			Height: layout.Height(),
+		},
+		CreatedAt: widget.CreatedAt(),
+		UpdatedAt: widget.UpdatedAt(),
+	}
+}
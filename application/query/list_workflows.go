@@ -0,0 +1,47 @@
+package query
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+)
+
+// ListWorkflowsQuery represents a query for a page of the current versions of the workflows
+type ListWorkflowsQuery struct {
+	Active *bool // nil lists active and inactive workflows
+	Page   domain.Page
+}
+
+// ListWorkflowsQueryHandler handles ListWorkflowsQuery
+type ListWorkflowsQueryHandler struct {
+	workflowRepository domain.WorkflowRepository
+}
+
+// NewListWorkflowsQueryHandler creates a new ListWorkflowsQueryHandler
+func NewListWorkflowsQueryHandler(workflowRepository domain.WorkflowRepository) *ListWorkflowsQueryHandler {
+	return &ListWorkflowsQueryHandler{
+		workflowRepository: workflowRepository,
+	}
+}
+
+// Handle handles the ListWorkflowsQuery, listing workflows by name unless the page sorts otherwise
+func (h *ListWorkflowsQueryHandler) Handle(ctx context.Context, query ListWorkflowsQuery) (*dto.WorkflowPageDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Get workflows
+	workflows, result, err := h.workflowRepository.List(ctx, domain.WorkflowFilter{Active: query.Active}, query.Page)
+	if err != nil {
+		return nil, pageError("failed to get workflows", err)
+	}
+
+	workflowDTOs := make([]*dto.WorkflowDTO, 0, len(workflows))
+	for _, workflow := range workflows {
+		workflowDTOs = append(workflowDTOs, mapper.WorkflowToDTO(workflow))
+	}
+
+	return &dto.WorkflowPageDTO{Workflows: workflowDTOs, PageDTO: mapper.PageToDTO(len(workflowDTOs), result)}, nil
+}
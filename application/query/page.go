@@ -0,0 +1,16 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+)
+
+// pageError reports a list that failed, passing on a page the list cannot serve as it is
+func pageError(action string, err error) error {
+	if errors.Is(err, domain.ErrInvalidPage) {
+		return err
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
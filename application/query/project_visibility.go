@@ -0,0 +1,33 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// requireViewer checks that a viewer may see a project. Restricted projects are only shown
+// to their members; an empty or invalid viewer ID is an anonymous caller.
+func requireViewer(project *aggregate.Project, rawViewerID string) error {
+	var viewerID *value.UserID
+	if id, err := value.NewUserID(rawViewerID); err == nil {
+		viewerID = &id
+	}
+	if !project.CanView(viewerID) {
+		return errs.PermissionDenied("permission denied: the project is only visible to its members")
+	}
+	return nil
+}
+
+// requireProjectViewer checks that a viewer may see the project with an ID
+func requireProjectViewer(ctx context.Context, projectRepository domain.ProjectRepository, projectID value.ProjectID, rawViewerID string) error {
+	project, err := projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+	return requireViewer(project, rawViewerID)
+}
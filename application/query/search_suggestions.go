@@ -0,0 +1,179 @@
+package query
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+const (
+	defaultSuggestionLimit = 10
+	maxSuggestionLimit     = 50
+
+	// recentViewBoost is added to items the user opened most recently, decaying with list position
+	recentViewBoost = 1.5
+	recentViewDepth = 20
+)
+
+// Suggestion is a single typeahead match returned by a SuggestionIndex
+type Suggestion struct {
+	Kind      string // "task", "project" or "user"
+	ID        string
+	Label     string
+	Score     float64
+	TouchedAt time.Time
+}
+
+// SuggestionIndex is the read model backing search suggestions
+type SuggestionIndex interface {
+	Suggest(text string, limit int) []Suggestion
+}
+
+// SearchSuggestionsQuery represents a typeahead query across tasks, projects and users
+type SearchSuggestionsQuery struct {
+	Text   string
+	Limit  int
+	UserID string // optional, boosts items the user viewed recently and shows their restricted projects
+}
+
+// SearchSuggestionsQueryHandler handles SearchSuggestionsQuery
+type SearchSuggestionsQueryHandler struct {
+	index                SuggestionIndex
+	recentViewRepository domain.RecentViewRepository
+	taskRepository       domain.TaskRepository
+	projectRepository    domain.ProjectRepository
+}
+
+// NewSearchSuggestionsQueryHandler creates a new SearchSuggestionsQueryHandler
+func NewSearchSuggestionsQueryHandler(
+	index SuggestionIndex,
+	recentViewRepository domain.RecentViewRepository,
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+) *SearchSuggestionsQueryHandler {
+	return &SearchSuggestionsQueryHandler{
+		index:                index,
+		recentViewRepository: recentViewRepository,
+		taskRepository:       taskRepository,
+		projectRepository:    projectRepository,
+	}
+}
+
+// Handle handles the SearchSuggestionsQuery
+func (h *SearchSuggestionsQueryHandler) Handle(ctx context.Context, query SearchSuggestionsQuery) ([]*dto.SuggestionDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(query.Text)
+	if text == "" {
+		return nil, errs.Invalid("search text cannot be empty")
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSuggestionLimit
+	}
+	if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+
+	// Over-fetch so personal boosts can promote items beyond the first page
+	suggestions := h.index.Suggest(text, limit*3)
+	suggestions = h.visibleSuggestions(ctx, query.UserID, suggestions)
+	suggestions = h.applyRecentViewBoost(ctx, query.UserID, suggestions)
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	suggestionDTOs := make([]*dto.SuggestionDTO, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		suggestionDTOs = append(suggestionDTOs, &dto.SuggestionDTO{
+			Type:  suggestion.Kind,
+			ID:    suggestion.ID,
+			Label: suggestion.Label,
+			Score: suggestion.Score,
+		})
+	}
+
+	return suggestionDTOs, nil
+}
+
+// visibleSuggestions drops tasks and projects of projects the user may not see, failing
+// closed for tasks and projects that are gone. Users are always suggested.
+func (h *SearchSuggestionsQueryHandler) visibleSuggestions(ctx context.Context, rawUserID string, suggestions []Suggestion) []Suggestion {
+	var userID *value.UserID
+	if parsed, err := value.NewUserID(rawUserID); err == nil {
+		userID = &parsed
+	}
+
+	visible := make(map[string]bool) // project ID -> whether the user may see it
+	canView := func(projectID string) bool {
+		if _, checked := visible[projectID]; !checked {
+			visible[projectID] = canViewProject(ctx, h.projectRepository, projectID, userID)
+		}
+		return visible[projectID]
+	}
+
+	kept := make([]Suggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		switch suggestion.Kind {
+		case "project":
+			if !canView(suggestion.ID) {
+				continue
+			}
+		case "task":
+			taskID, err := value.NewTaskID(suggestion.ID)
+			if err != nil {
+				continue
+			}
+			task, err := h.taskRepository.GetByID(ctx, taskID)
+			if err != nil || !canView(task.ProjectID().Value()) {
+				continue
+			}
+		}
+		kept = append(kept, suggestion)
+	}
+
+	return kept
+}
+
+// applyRecentViewBoost promotes suggestions the user has opened recently
+func (h *SearchSuggestionsQueryHandler) applyRecentViewBoost(ctx context.Context, rawUserID string, suggestions []Suggestion) []Suggestion {
+	if rawUserID == "" {
+		return suggestions
+	}
+
+	userID, err := value.NewUserID(rawUserID)
+	if err != nil {
+		return suggestions
+	}
+
+	views, err := h.recentViewRepository.GetRecent(ctx, userID, recentViewDepth)
+	if err != nil || len(views) == 0 {
+		return suggestions
+	}
+
+	positions := make(map[string]int, len(views))
+	for i, view := range views {
+		positions[strings.ToLower(view.Kind().Value())+":"+view.ItemID()] = i
+	}
+
+	for i := range suggestions {
+		if position, viewed := positions[suggestions[i].Kind+":"+suggestions[i].ID]; viewed {
+			suggestions[i].Score += recentViewBoost * float64(recentViewDepth-position) / recentViewDepth
+		}
+	}
+
+	sort.SliceStable(suggestions, func(a, b int) bool {
+		return suggestions[a].Score > suggestions[b].Score
+	})
+
+	return suggestions
+}
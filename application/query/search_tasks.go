@@ -0,0 +1,233 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SearchTasksQuery represents a search for tasks by the text of their title, description and
+// comments, narrowed by structured filters. Every filter is optional.
+type SearchTasksQuery struct {
+	Text       string // empty lists the tasks matching the filters, most recently updated first
+	ProjectID  string
+	Status     string
+	Priority   string
+	AssigneeID string
+	UserID     string // empty searches as an anonymous user
+	Limit      int
+}
+
+// SearchTasksQueryHandler handles SearchTasksQuery. Text is matched by the workspace search
+// index, so another WorkspaceSearchIndex backend changes how tasks are found.
+type SearchTasksQueryHandler struct {
+	index             WorkspaceSearchIndex
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+}
+
+// NewSearchTasksQueryHandler creates a new SearchTasksQueryHandler
+func NewSearchTasksQueryHandler(
+	index WorkspaceSearchIndex,
+	taskRepository domain.TaskRepository,
+	projectRepository domain.ProjectRepository,
+) *SearchTasksQueryHandler {
+	return &SearchTasksQueryHandler{
+		index:             index,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+	}
+}
+
+// taskFilter is the parsed structured filters of a SearchTasksQuery
+type taskFilter struct {
+	projectID  *value.ProjectID
+	status     *value.TaskStatus
+	priority   *value.Priority
+	assigneeID *value.UserID
+}
+
+// matches checks if a task passes every filter
+func (f taskFilter) matches(task *aggregate.Task) bool {
+	if f.projectID != nil && task.ProjectID() != *f.projectID {
+		return false
+	}
+	if f.status != nil && task.Status() != *f.status {
+		return false
+	}
+	if f.priority != nil && task.Priority() != *f.priority {
+		return false
+	}
+	if f.assigneeID != nil && (task.Assignee() == nil || task.Assignee().AssigneeID() != *f.assigneeID) {
+		return false
+	}
+	return true
+}
+
+// Handle handles the SearchTasksQuery, returning the best matches first
+func (h *SearchTasksQueryHandler) Handle(ctx context.Context, query SearchTasksQuery) ([]*dto.TaskSearchResultDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	filter, err := parseTaskFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	var userID *value.UserID
+	if query.UserID != "" {
+		parsed, err := value.NewUserID(query.UserID)
+		if err != nil {
+			return nil, errs.Invalid("invalid user id: %w", err)
+		}
+		userID = &parsed
+	}
+
+	var candidates []*dto.TaskSearchResultDTO
+	if text := strings.TrimSpace(query.Text); text != "" {
+		candidates = h.textMatches(ctx, text, filter)
+	} else {
+		candidates, err = h.filterMatches(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Trim hidden tasks before applying the limit so they never shorten or leak into a page
+	visible := make(map[string]bool)
+	results := make([]*dto.TaskSearchResultDTO, 0, limit)
+	for _, candidate := range candidates {
+		canView, checked := visible[candidate.Task.ProjectID]
+		if !checked {
+			canView = canViewProject(ctx, h.projectRepository, candidate.Task.ProjectID, userID)
+			visible[candidate.Task.ProjectID] = canView
+		}
+		if !canView {
+			continue
+		}
+
+		results = append(results, candidate)
+		if len(results) == limit {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// textMatches searches the index for tasks whose own text or comments match, each task
+// scored by its best matching document
+func (h *SearchTasksQueryHandler) textMatches(ctx context.Context, text string, filter taskFilter) []*dto.TaskSearchResultDTO {
+	seen := make(map[string]bool)
+	matches := make([]*dto.TaskSearchResultDTO, 0)
+	for _, hit := range h.index.Search(text, []string{SearchTypeTask, SearchTypeComment}) {
+		if seen[hit.TaskID] {
+			continue
+		}
+		seen[hit.TaskID] = true
+
+		taskID, err := value.NewTaskID(hit.TaskID)
+		if err != nil {
+			continue
+		}
+		task, err := h.taskRepository.GetByID(ctx, taskID)
+		if err != nil || !filter.matches(task) {
+			continue
+		}
+
+		matches = append(matches, &dto.TaskSearchResultDTO{
+			Task:    mapper.TaskToDTO(task),
+			Snippet: hit.Snippet,
+			Score:   hit.Score,
+		})
+	}
+	return matches
+}
+
+// filterMatches lists the tasks passing the filters, most recently updated first
+func (h *SearchTasksQueryHandler) filterMatches(ctx context.Context, filter taskFilter) ([]*dto.TaskSearchResultDTO, error) {
+	// Start from the narrowest lookup the repository offers
+	var tasks []*aggregate.Task
+	var err error
+	switch {
+	case filter.projectID != nil:
+		tasks, err = h.taskRepository.GetByProjectID(ctx, *filter.projectID)
+	case filter.assigneeID != nil:
+		tasks, err = h.taskRepository.GetByAssigneeID(ctx, *filter.assigneeID)
+	case filter.status != nil:
+		tasks, err = h.taskRepository.GetByStatus(ctx, *filter.status)
+	default:
+		tasks, err = h.taskRepository.GetAll(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].UpdatedAt().After(tasks[j].UpdatedAt())
+	})
+
+	matches := make([]*dto.TaskSearchResultDTO, 0, len(tasks))
+	for _, task := range tasks {
+		if filter.matches(task) {
+			matches = append(matches, &dto.TaskSearchResultDTO{Task: mapper.TaskToDTO(task)})
+		}
+	}
+	return matches, nil
+}
+
+// parseTaskFilter validates the structured filters of a query
+func parseTaskFilter(query SearchTasksQuery) (taskFilter, error) {
+	var filter taskFilter
+
+	if query.ProjectID != "" {
+		projectID, err := value.NewProjectID(query.ProjectID)
+		if err != nil {
+			return filter, errs.Invalid("invalid project id: %w", err)
+		}
+		filter.projectID = &projectID
+	}
+
+	if query.Status != "" {
+		status, err := value.NewTaskStatus(strings.ToUpper(query.Status))
+		if err != nil {
+			return filter, errs.Invalid("invalid status: %w", err)
+		}
+		filter.status = &status
+	}
+
+	if query.Priority != "" {
+		priority, err := value.ParsePriority(query.Priority)
+		if err != nil {
+			return filter, errs.Invalid("invalid priority: %w", err)
+		}
+		filter.priority = &priority
+	}
+
+	if query.AssigneeID != "" {
+		assigneeID, err := value.NewUserID(query.AssigneeID)
+		if err != nil {
+			return filter, errs.Invalid("invalid assignee id: %w", err)
+		}
+		filter.assigneeID = &assigneeID
+	}
+
+	return filter, nil
+}
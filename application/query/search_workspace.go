@@ -0,0 +1,146 @@
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Workspace search result types
+const (
+	SearchTypeTask       = "task"
+	SearchTypeComment    = "comment"
+	SearchTypeAttachment = "attachment"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchHit is a single match returned by a WorkspaceSearchIndex
+type SearchHit struct {
+	Type      string
+	ID        string
+	TaskID    string
+	ProjectID string
+	Title     string // title of the task the match belongs to
+	Snippet   string // HTML-escaped excerpt with matched words wrapped in <mark>
+	Score     float64
+}
+
+// WorkspaceSearchIndex is the read model backing workspace search.
+// Search returns every match of the given types, best first.
+type WorkspaceSearchIndex interface {
+	Search(text string, types []string) []SearchHit
+}
+
+// SearchWorkspaceQuery represents a full text query across tasks, comments and attachments
+type SearchWorkspaceQuery struct {
+	Text   string
+	Types  []string // empty searches every type
+	UserID string   // empty searches as an anonymous user
+	Limit  int
+}
+
+// SearchWorkspaceQueryHandler handles SearchWorkspaceQuery
+type SearchWorkspaceQueryHandler struct {
+	index             WorkspaceSearchIndex
+	projectRepository domain.ProjectRepository
+}
+
+// NewSearchWorkspaceQueryHandler creates a new SearchWorkspaceQueryHandler
+func NewSearchWorkspaceQueryHandler(
+	index WorkspaceSearchIndex,
+	projectRepository domain.ProjectRepository,
+) *SearchWorkspaceQueryHandler {
+	return &SearchWorkspaceQueryHandler{
+		index:             index,
+		projectRepository: projectRepository,
+	}
+}
+
+// Handle handles the SearchWorkspaceQuery
+func (h *SearchWorkspaceQueryHandler) Handle(ctx context.Context, query SearchWorkspaceQuery) ([]*dto.SearchResultDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(query.Text)
+	if text == "" {
+		return nil, errs.Invalid("search text cannot be empty")
+	}
+
+	for _, searchType := range query.Types {
+		switch searchType {
+		case SearchTypeTask, SearchTypeComment, SearchTypeAttachment:
+		default:
+			return nil, errs.Invalid("invalid search type: %s", searchType)
+		}
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	var userID *value.UserID
+	if query.UserID != "" {
+		parsed, err := value.NewUserID(query.UserID)
+		if err != nil {
+			return nil, errs.Invalid("invalid user id: %w", err)
+		}
+		userID = &parsed
+	}
+
+	// Trim hits before applying the limit so hidden projects never shorten or leak into a page
+	visible := make(map[string]bool)
+	results := make([]*dto.SearchResultDTO, 0, limit)
+	for _, hit := range h.index.Search(text, query.Types) {
+		canView, checked := visible[hit.ProjectID]
+		if !checked {
+			canView = canViewProject(ctx, h.projectRepository, hit.ProjectID, userID)
+			visible[hit.ProjectID] = canView
+		}
+		if !canView {
+			continue
+		}
+
+		results = append(results, &dto.SearchResultDTO{
+			Type:      hit.Type,
+			ID:        hit.ID,
+			TaskID:    hit.TaskID,
+			ProjectID: hit.ProjectID,
+			Title:     hit.Title,
+			Snippet:   hit.Snippet,
+			Score:     hit.Score,
+		})
+		if len(results) == limit {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+// canViewProject checks if a user may see a project, failing closed for unknown projects
+func canViewProject(ctx context.Context, projectRepository domain.ProjectRepository, rawProjectID string, userID *value.UserID) bool {
+	projectID, err := value.NewProjectID(rawProjectID)
+	if err != nil {
+		return false
+	}
+
+	project, err := projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return false
+	}
+
+	return project.CanView(userID)
+}
@@ -0,0 +1,186 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DefaultWorkflowSimulationSample is how many tasks are checked when no sample is given
+const DefaultWorkflowSimulationSample = 100
+
+// MaxWorkflowSimulationSample caps the sample size of a workflow simulation
+const MaxWorkflowSimulationSample = 1000
+
+// WorkflowStatusInput is one status of the workflow proposed in SimulateWorkflowQuery
+type WorkflowStatusInput struct {
+	Name        string
+	Description string
+	Order       int
+	IsFinal     bool
+	WIPLimit    int
+}
+
+// WorkflowTransitionInput is one allowed move of the workflow proposed in SimulateWorkflowQuery
+type WorkflowTransitionInput struct {
+	From string
+	To   string
+}
+
+// SimulateWorkflowQuery represents a query that checks a proposed workflow against a project's tasks
+type SimulateWorkflowQuery struct {
+	ProjectID   string
+	Statuses    []WorkflowStatusInput
+	Transitions []WorkflowTransitionInput // nil uses the regular task status rules
+	TaskIDs     []string                  // empty samples the most recently updated tasks
+	SampleSize  int                       // 0 uses DefaultWorkflowSimulationSample
+}
+
+// SimulateWorkflowQueryHandler handles SimulateWorkflowQuery
+type SimulateWorkflowQueryHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	simulationService *service.WorkflowSimulationService
+}
+
+// NewSimulateWorkflowQueryHandler creates a new SimulateWorkflowQueryHandler
+func NewSimulateWorkflowQueryHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	simulationService *service.WorkflowSimulationService,
+) *SimulateWorkflowQueryHandler {
+	return &SimulateWorkflowQueryHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		simulationService: simulationService,
+	}
+}
+
+// Handle handles the SimulateWorkflowQuery. Nothing is saved, the report only
+// shows what applying the workflow would break.
+func (h *SimulateWorkflowQueryHandler) Handle(ctx context.Context, query SimulateWorkflowQuery) (*dto.WorkflowSimulationDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	// Parse project ID
+	projectID, err := value.NewProjectID(query.ProjectID)
+	if err != nil {
+		return nil, errs.Invalid("invalid project id: %w", err)
+	}
+
+	if query.SampleSize < 0 || query.SampleSize > MaxWorkflowSimulationSample {
+		return nil, errs.Invalid("invalid sample size: must be between 1 and %d", MaxWorkflowSimulationSample)
+	}
+	sampleSize := query.SampleSize
+	if sampleSize == 0 {
+		sampleSize = DefaultWorkflowSimulationSample
+	}
+
+	// Build the proposed workflow, it is never saved
+	statuses := make([]aggregate.WorkflowStatus, 0, len(query.Statuses))
+	for _, s := range query.Statuses {
+		statuses = append(statuses, aggregate.NewWorkflowStatus(s.Name, s.Description, s.Order, s.IsFinal).WithWIPLimit(s.WIPLimit))
+	}
+
+	workflow, err := aggregate.NewWorkflow(value.GenerateWorkflowID(), "proposed workflow", "", statuses)
+	if err != nil {
+		return nil, errs.Invalid("invalid workflow: %w", err)
+	}
+
+	var transitions []service.WorkflowTransition
+	if query.Transitions != nil {
+		transitions = make([]service.WorkflowTransition, 0, len(query.Transitions))
+		for _, t := range query.Transitions {
+			transitions = append(transitions, service.WorkflowTransition{From: t.From, To: t.To})
+		}
+	}
+
+	// Get project
+	if _, err := h.projectRepository.GetByID(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	// Get tasks
+	tasks, err := h.taskRepository.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	sample, err := sampleTasks(tasks, query.TaskIDs, sampleSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Simulate the workflow
+	simulation := h.simulationService.Simulate(workflow, transitions, sample)
+
+	result := &dto.WorkflowSimulationDTO{
+		ProjectID:    projectID.Value(),
+		Valid:        simulation.Valid(),
+		TasksChecked: simulation.TasksChecked,
+		TotalTasks:   len(tasks),
+		Issues:       make([]dto.WorkflowIssueDTO, 0, len(simulation.Issues)),
+		InvalidTasks: make([]dto.InvalidWorkflowTaskDTO, 0, len(simulation.InvalidTasks)),
+	}
+
+	for _, issue := range simulation.Issues {
+		result.Issues = append(result.Issues, dto.WorkflowIssueDTO{
+			Code:     issue.Code,
+			Message:  issue.Message,
+			Statuses: issue.Statuses,
+		})
+	}
+
+	for _, impact := range simulation.InvalidTasks {
+		result.InvalidTasks = append(result.InvalidTasks, dto.InvalidWorkflowTaskDTO{
+			TaskID:  impact.Task.ID().Value(),
+			Title:   impact.Task.Title(),
+			Status:  impact.Task.Status().Value(),
+			Problem: impact.Problem,
+		})
+	}
+
+	return result, nil
+}
+
+// sampleTasks picks the requested tasks, or the most recently updated ones up to size
+func sampleTasks(tasks []*aggregate.Task, taskIDs []string, size int) ([]*aggregate.Task, error) {
+	if len(taskIDs) > 0 {
+		byID := make(map[string]*aggregate.Task, len(tasks))
+		for _, task := range tasks {
+			byID[task.ID().Value()] = task
+		}
+
+		sample := make([]*aggregate.Task, 0, len(taskIDs))
+		seen := make(map[string]bool, len(taskIDs))
+		for _, id := range taskIDs {
+			task, exists := byID[id]
+			if !exists {
+				return nil, errs.NotFound("task %s not found in project", id)
+			}
+			if !seen[id] {
+				seen[id] = true
+				sample = append(sample, task)
+			}
+		}
+		return sample, nil
+	}
+
+	sample := append([]*aggregate.Task{}, tasks...)
+	sort.SliceStable(sample, func(i, j int) bool {
+		return sample[i].UpdatedAt().After(sample[j].UpdatedAt())
+	})
+	if len(sample) > size {
+		sample = sample[:size]
+	}
+
+	return sample, nil
+}
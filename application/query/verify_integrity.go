@@ -0,0 +1,120 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain"
+)
+
+// The kinds of integrity issues VerifyIntegrity reports
+const (
+	IntegrityMissingTask  = "MISSING_TASK"  // a project lists a task that does not exist
+	IntegrityOrphanedTask = "ORPHANED_TASK" // a task belongs to a project that does not exist
+	IntegrityUnlistedTask = "UNLISTED_TASK" // a task is missing from its project's task list
+	IntegrityWrongProject = "WRONG_PROJECT" // a project lists a task that belongs to another project
+)
+
+// VerifyIntegrityQuery represents a query reconciling every project's task list with the tasks
+type VerifyIntegrityQuery struct{}
+
+// VerifyIntegrityQueryHandler handles VerifyIntegrityQuery
+type VerifyIntegrityQueryHandler struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+}
+
+// NewVerifyIntegrityQueryHandler creates a new VerifyIntegrityQueryHandler
+func NewVerifyIntegrityQueryHandler(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+) *VerifyIntegrityQueryHandler {
+	return &VerifyIntegrityQueryHandler{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+	}
+}
+
+// Handle handles the VerifyIntegrityQuery. Nothing is repaired, issues are only reported,
+// ordered by project and task.
+func (h *VerifyIntegrityQueryHandler) Handle(ctx context.Context, query VerifyIntegrityQuery) (*dto.IntegrityReportDTO, error) {
+	if err := abortIfDone(ctx); err != nil {
+		return nil, err
+	}
+
+	projects, err := h.projectRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	tasks, err := h.taskRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	// Index tasks by ID and the task lists of projects
+	taskProjects := make(map[string]string, len(tasks))
+	for _, task := range tasks {
+		taskProjects[task.ID().Value()] = task.ProjectID().Value()
+	}
+
+	listed := make(map[string]map[string]bool, len(projects))
+	issues := make([]dto.IntegrityIssueDTO, 0)
+	for _, project := range projects {
+		if err := abortIfDone(ctx); err != nil {
+			return nil, err
+		}
+
+		projectID := project.ID().Value()
+		listed[projectID] = make(map[string]bool)
+
+		for _, taskID := range project.TaskIDs() {
+			listed[projectID][taskID.Value()] = true
+
+			owner, exists := taskProjects[taskID.Value()]
+			switch {
+			case !exists:
+				issues = append(issues, dto.IntegrityIssueDTO{
+					Kind: IntegrityMissingTask, ProjectID: projectID, TaskID: taskID.Value(),
+					Detail: "project lists a task that does not exist",
+				})
+			case owner != projectID:
+				issues = append(issues, dto.IntegrityIssueDTO{
+					Kind: IntegrityWrongProject, ProjectID: projectID, TaskID: taskID.Value(),
+					Detail: fmt.Sprintf("task belongs to project %s", owner),
+				})
+			}
+		}
+	}
+
+	for taskID, projectID := range taskProjects {
+		tasksOfProject, exists := listed[projectID]
+		switch {
+		case !exists:
+			issues = append(issues, dto.IntegrityIssueDTO{
+				Kind: IntegrityOrphanedTask, ProjectID: projectID, TaskID: taskID,
+				Detail: "task belongs to a project that does not exist",
+			})
+		case !tasksOfProject[taskID]:
+			issues = append(issues, dto.IntegrityIssueDTO{
+				Kind: IntegrityUnlistedTask, ProjectID: projectID, TaskID: taskID,
+				Detail: "task is missing from its project's task list",
+			})
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].ProjectID != issues[j].ProjectID {
+			return issues[i].ProjectID < issues[j].ProjectID
+		}
+		return issues[i].TaskID < issues[j].TaskID
+	})
+
+	return &dto.IntegrityReportDTO{
+		ProjectsChecked: len(projects),
+		TasksChecked:    len(tasks),
+		Issues:          issues,
+	}, nil
+}
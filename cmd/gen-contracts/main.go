@@ -0,0 +1,40 @@
+// Command gen-contracts writes the OpenAPI, AsyncAPI and protobuf contracts
+// to the api directory. With -check it only reports whether they are current.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+	"github.com/miladev95/ddd-task/interface/contract"
+)
+
+func main() {
+	out := flag.String("out", "api", "output directory for contract files")
+	check := flag.Bool("check", false, "fail if the contracts are out of date instead of writing them")
+	flag.Parse()
+
+	serializer := infraEvent.NewEventSerializer()
+
+	if *check {
+		stale, err := contract.Check(*out, serializer)
+		if err != nil {
+			log.Fatalf("Contract check failed: %v", err)
+		}
+		if len(stale) > 0 {
+			fmt.Fprintf(os.Stderr, "Contracts out of date: %s (run make contracts)\n", strings.Join(stale, ", "))
+			os.Exit(1)
+		}
+		fmt.Println("Contracts are up to date")
+		return
+	}
+
+	if err := contract.Write(*out, serializer); err != nil {
+		log.Fatalf("Contract generation failed: %v", err)
+	}
+	fmt.Printf("Contracts written to %s\n", *out)
+}
@@ -0,0 +1,40 @@
+// Command rebuild-projections truncates every registered read model and
+// replays the full event store through it. Run it whenever a projection's
+// schema changes.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+func main() {
+	ctx := context.Background()
+	every := flag.Int("progress-every", 500, "report progress every N events")
+	flag.Parse()
+
+	if *every <= 0 {
+		*every = 1
+	}
+
+	// Initialize DI container
+	container := di.NewContainer()
+
+	report, err := container.ProjectionRebuilder.Rebuild(ctx, func(p command.RebuildProgress) {
+		if p.Processed%*every == 0 || p.Processed == p.Total {
+			fmt.Fprintf(os.Stderr, "\rreplayed %d/%d events", p.Processed, p.Total)
+		}
+	})
+	if err != nil {
+		log.Fatalf("Rebuild failed: %v", err)
+	}
+
+	fmt.Fprintln(os.Stderr)
+	fmt.Printf("Rebuilt %v from %d events in %s\n", report.Projections, report.EventsReplayed, report.Duration)
+}
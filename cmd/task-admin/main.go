@@ -0,0 +1,194 @@
+// Command task-admin runs operational tasks against a running instance through
+// its admin API. Calls are made as the admin whose access token is given with
+// -token or TASK_API_TOKEN, on the server at -url or TASK_API_URL.
+//
+//	task-admin verify-integrity
+//	task-admin reindex-search
+//	task-admin prune-events -older-than 2160h
+//	task-admin rebuild-projections
+//	task-admin export-backup -out events.ndjson
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// defaultURL is the server called when neither -url nor TASK_API_URL is set
+const defaultURL = "http://localhost:8080"
+
+// client calls the admin API as one admin
+type client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	baseURL := flags.String("url", envOr("TASK_API_URL", defaultURL), "server to call")
+	token := flags.String("token", os.Getenv("TASK_API_TOKEN"), "admin access token")
+
+	switch os.Args[1] {
+	case "verify-integrity":
+		flags.Parse(os.Args[2:])
+		var report struct {
+			ProjectsChecked int `json:"projects_checked"`
+			TasksChecked    int `json:"tasks_checked"`
+			Issues          []struct {
+				Kind      string `json:"kind"`
+				ProjectID string `json:"project_id"`
+				TaskID    string `json:"task_id"`
+				Detail    string `json:"detail"`
+			} `json:"issues"`
+		}
+		newClient(*baseURL, *token).callJSON(http.MethodGet, "/api/admin/integrity", &report)
+
+		for _, issue := range report.Issues {
+			fmt.Printf("%-14s project %s task %s: %s\n", issue.Kind, issue.ProjectID, issue.TaskID, issue.Detail)
+		}
+		fmt.Fprintf(os.Stderr, "Checked %d projects and %d tasks, %d issues\n",
+			report.ProjectsChecked, report.TasksChecked, len(report.Issues))
+		if len(report.Issues) > 0 {
+			os.Exit(1)
+		}
+
+	case "reindex-search", "rebuild-projections":
+		flags.Parse(os.Args[2:])
+		path := "/api/admin/projections/rebuild"
+		if os.Args[1] == "reindex-search" {
+			path = "/api/admin/search/reindex"
+		}
+
+		var report struct {
+			Projections    []string `json:"projections"`
+			EventsReplayed int      `json:"events_replayed"`
+			DurationMS     int64    `json:"duration_ms"`
+		}
+		newClient(*baseURL, *token).callJSON(http.MethodPost, path, &report)
+		fmt.Printf("Rebuilt %v from %d events in %dms\n", report.Projections, report.EventsReplayed, report.DurationMS)
+
+	case "prune-events":
+		olderThan := flags.Duration("older-than", 0, "prune streams untouched for this long, e.g. 2160h")
+		before := flags.String("before", "", "prune streams untouched since this RFC 3339 time, instead of -older-than")
+		flags.Parse(os.Args[2:])
+
+		cutoff := *before
+		if cutoff == "" {
+			if *olderThan <= 0 {
+				log.Fatal("prune-events needs -older-than or -before")
+			}
+			cutoff = time.Now().Add(-*olderThan).UTC().Format(time.RFC3339)
+		}
+
+		var result struct {
+			EventsPruned int `json:"events_pruned"`
+		}
+		newClient(*baseURL, *token).callJSON(http.MethodPost, "/api/admin/events/prune?before="+url.QueryEscape(cutoff), &result)
+		fmt.Printf("Pruned %d events of streams untouched since %s\n", result.EventsPruned, cutoff)
+
+	case "export-backup":
+		out := flags.String("out", "-", "file to write, - for stdout")
+		flags.Parse(os.Args[2:])
+
+		w, closeFn := openOutput(*out)
+		defer closeFn()
+
+		body := newClient(*baseURL, *token).call(http.MethodGet, "/api/admin/backup")
+		defer body.Close()
+
+		written, err := io.Copy(w, body)
+		if err != nil {
+			log.Fatalf("Backup failed after %d bytes: %v", written, err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d bytes\n", written)
+
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: task-admin [verify-integrity | reindex-search | rebuild-projections | "+
+		"prune-events -older-than duration | export-backup [-out file]] [-url url] [-token token]")
+	os.Exit(2)
+}
+
+func newClient(baseURL, token string) *client {
+	if token == "" {
+		log.Fatal("An admin access token is required, pass -token or set TASK_API_TOKEN")
+	}
+
+	return &client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+// call sends a request and returns the body of a successful response, exiting on problems
+func (c *client) call(method, path string) io.ReadCloser {
+	request, err := http.NewRequest(method, c.baseURL+path, nil)
+	if err != nil {
+		log.Fatalf("Invalid request: %v", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		log.Fatalf("Request failed: %v", err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+		var problem struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		}
+		json.NewDecoder(response.Body).Decode(&problem)
+		log.Fatalf("%s %s: %d %s: %s", method, path, response.StatusCode, problem.Title, problem.Detail)
+	}
+
+	return response.Body
+}
+
+// callJSON sends a request and decodes the JSON response into result
+func (c *client) callJSON(method, path string, result interface{}) {
+	body := c.call(method, path)
+	defer body.Close()
+
+	if err := json.NewDecoder(body).Decode(result); err != nil {
+		log.Fatalf("Invalid response from %s: %v", path, err)
+	}
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func openOutput(path string) (io.Writer, func()) {
+	if path == "-" {
+		return os.Stdout, func() {}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("Cannot create %s: %v", path, err)
+	}
+	return f, func() { f.Close() }
+}
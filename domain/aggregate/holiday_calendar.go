@@ -0,0 +1,175 @@
+package aggregate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// HolidayCalendar is the aggregate root for the holidays an organization observes in one region.
+// Projects following the calendar skip its holidays in business-day deadlines, SLA timers and
+// capacity.
+type HolidayCalendar struct {
+	id             value.HolidayCalendarID
+	organizationID value.OrganizationID
+	region         string
+	name           string
+	holidays       []value.Holiday
+	createdAt      time.Time
+	updatedAt      time.Time
+	domainEvents   []event.DomainEvent
+}
+
+// NewHolidayCalendar creates a new HolidayCalendar for a region of an organization
+func NewHolidayCalendar(
+	id value.HolidayCalendarID,
+	organizationID value.OrganizationID,
+	region, name string,
+	holidays []value.Holiday,
+) (*HolidayCalendar, error) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if region == "" {
+		return nil, errs.Invalid("holiday calendar region cannot be empty")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errs.Invalid("holiday calendar name cannot be empty")
+	}
+
+	calendar := &HolidayCalendar{
+		id:             id,
+		organizationID: organizationID,
+		region:         region,
+		name:           name,
+		holidays:       append([]value.Holiday{}, holidays...),
+		createdAt:      time.Now(),
+		updatedAt:      time.Now(),
+		domainEvents:   make([]event.DomainEvent, 0),
+	}
+
+	createdEvent := event.NewHolidayCalendarCreatedEvent(id.Value(), organizationID.Value(), region, name, calendar.HolidayNames())
+	calendar.domainEvents = append(calendar.domainEvents, createdEvent)
+
+	return calendar, nil
+}
+
+// ID returns the calendar ID
+func (c *HolidayCalendar) ID() value.HolidayCalendarID {
+	return c.id
+}
+
+// OrganizationID returns the organization observing the calendar
+func (c *HolidayCalendar) OrganizationID() value.OrganizationID {
+	return c.organizationID
+}
+
+// Region returns the upper-case code of the region the calendar is for, such as "DE-BY"
+func (c *HolidayCalendar) Region() string {
+	return c.region
+}
+
+// Name returns the calendar name
+func (c *HolidayCalendar) Name() string {
+	return c.name
+}
+
+// Holidays returns the calendar's holidays
+func (c *HolidayCalendar) Holidays() []value.Holiday {
+	return append([]value.Holiday{}, c.holidays...)
+}
+
+// HolidayNames returns the names of the calendar's holidays
+func (c *HolidayCalendar) HolidayNames() []string {
+	names := make([]string, len(c.holidays))
+	for i, holiday := range c.holidays {
+		names[i] = holiday.Name()
+	}
+	return names
+}
+
+// CreatedAt returns when the calendar was created
+func (c *HolidayCalendar) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UpdatedAt returns when the calendar was last updated
+func (c *HolidayCalendar) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// DomainEvents returns all uncommitted domain events
+func (c *HolidayCalendar) DomainEvents() []event.DomainEvent {
+	return append([]event.DomainEvent{}, c.domainEvents...)
+}
+
+// ClearDomainEvents clears all domain events after they have been published
+func (c *HolidayCalendar) ClearDomainEvents() {
+	c.domainEvents = make([]event.DomainEvent, 0)
+}
+
+// Update renames the calendar and replaces its holidays
+func (c *HolidayCalendar) Update(name string, holidays []value.Holiday) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errs.Invalid("holiday calendar name cannot be empty")
+	}
+
+	c.name = name
+	c.holidays = append([]value.Holiday{}, holidays...)
+	c.updatedAt = time.Now()
+
+	changedEvent := event.NewHolidayCalendarChangedEvent(c.id.Value(), name, c.HolidayNames())
+	c.domainEvents = append(c.domainEvents, changedEvent)
+
+	return nil
+}
+
+// HolidayOn returns the holiday falling on the calendar day of the given time, nil when none does
+func (c *HolidayCalendar) HolidayOn(date time.Time) *value.Holiday {
+	for _, holiday := range c.holidays {
+		if holiday.OccursOn(date) {
+			return &holiday
+		}
+	}
+	return nil
+}
+
+// IsHoliday checks if a holiday falls on the calendar day of the given time
+func (c *HolidayCalendar) IsHoliday(date time.Time) bool {
+	return c.HolidayOn(date) != nil
+}
+
+// IsBusinessDay checks if the calendar day of the given time is a weekday without a holiday
+func (c *HolidayCalendar) IsBusinessDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.IsHoliday(date)
+}
+
+// HolidayTimeBetween returns how much of the time from one instant to another falls on holidays,
+// with days starting at midnight in the location of from
+func (c *HolidayCalendar) HolidayTimeBetween(from, to time.Time) time.Duration {
+	var total time.Duration
+	to = to.In(from.Location())
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for day.Before(to) {
+		next := day.AddDate(0, 0, 1)
+		if c.IsHoliday(day) {
+			start, end := day, next
+			if from.After(start) {
+				start = from
+			}
+			if to.Before(end) {
+				end = to
+			}
+			total += end.Sub(start)
+		}
+		day = next
+	}
+	return total
+}
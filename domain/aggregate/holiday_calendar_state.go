@@ -0,0 +1,101 @@
+package aggregate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// HolidayCalendarState is the memento of a HolidayCalendar: its full state in plain fields, for
+// storage outside memory. Uncommitted domain events are not part of the state.
+type HolidayCalendarState struct {
+	ID             string         `json:"id"`
+	OrganizationID string         `json:"organization_id"`
+	Region         string         `json:"region"`
+	Name           string         `json:"name"`
+	Holidays       []HolidayState `json:"holidays"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// HolidayState is the stored form of a holiday, with the fields its rule uses
+type HolidayState struct {
+	Name    string `json:"name"`
+	Rule    string `json:"rule"`
+	Date    string `json:"date,omitempty"` // DATE only
+	Month   int    `json:"month,omitempty"`
+	Day     int    `json:"day,omitempty"`     // ANNUAL only
+	Weekday string `json:"weekday,omitempty"` // NTH_WEEKDAY only
+	Nth     int    `json:"nth,omitempty"`     // NTH_WEEKDAY only
+}
+
+// ToState captures the calendar's state
+func (c *HolidayCalendar) ToState() HolidayCalendarState {
+	holidays := make([]HolidayState, 0, len(c.holidays))
+	for _, holiday := range c.holidays {
+		holidays = append(holidays, holidayState(holiday))
+	}
+
+	return HolidayCalendarState{
+		ID:             c.id.Value(),
+		OrganizationID: c.organizationID.Value(),
+		Region:         c.region,
+		Name:           c.name,
+		Holidays:       holidays,
+		CreatedAt:      c.createdAt,
+		UpdatedAt:      c.updatedAt,
+	}
+}
+
+// HolidayCalendarFromState rebuilds a HolidayCalendar from its state without raising domain events
+func HolidayCalendarFromState(state HolidayCalendarState) (*HolidayCalendar, error) {
+	id, err := value.NewHolidayCalendarID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid holiday calendar id: %w", err)
+	}
+
+	organizationID, err := value.NewOrganizationID(state.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid organization id: %w", err)
+	}
+
+	holidays := make([]value.Holiday, 0, len(state.Holidays))
+	for _, h := range state.Holidays {
+		holiday, err := value.ParseHoliday(h.Name, h.Rule, h.Date, h.Month, h.Day, h.Weekday, h.Nth)
+		if err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	return &HolidayCalendar{
+		id:             id,
+		organizationID: organizationID,
+		region:         state.Region,
+		name:           state.Name,
+		holidays:       holidays,
+		createdAt:      state.CreatedAt,
+		updatedAt:      state.UpdatedAt,
+		domainEvents:   make([]event.DomainEvent, 0),
+	}, nil
+}
+
+// holidayState converts a holiday to its stored form
+func holidayState(holiday value.Holiday) HolidayState {
+	state := HolidayState{Name: holiday.Name(), Rule: string(holiday.Rule())}
+	switch holiday.Rule() {
+	case value.HolidayRuleDate:
+		state.Date = holiday.Date().Format("2006-01-02")
+	case value.HolidayRuleAnnual:
+		state.Month = int(holiday.Month())
+		state.Day = holiday.Day()
+	case value.HolidayRuleNthWeekday:
+		state.Month = int(holiday.Month())
+		state.Weekday = strings.ToUpper(holiday.Weekday().String())
+		state.Nth = holiday.Nth()
+	}
+	return state
+}
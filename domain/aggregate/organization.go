@@ -0,0 +1,158 @@
+package aggregate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Organization is the aggregate root for a group of users sharing one quota.
+// The projects its members own, with their tasks and attachments, count against the quota.
+type Organization struct {
+	id           value.OrganizationID
+	name         string
+	memberIDs    []value.UserID
+	quota        value.Quota
+	workingHours *value.WorkingHours // nil leaves members on value.DefaultWorkingHours
+	createdAt    time.Time
+	updatedAt    time.Time
+	domainEvents []event.DomainEvent
+}
+
+// NewOrganization creates a new Organization with its first members and no limits
+func NewOrganization(
+	id value.OrganizationID,
+	name string,
+	memberIDs []value.UserID,
+) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errs.Invalid("organization name cannot be empty")
+	}
+
+	organization := &Organization{
+		id:           id,
+		name:         name,
+		memberIDs:    make([]value.UserID, 0, len(memberIDs)),
+		createdAt:    time.Now(),
+		updatedAt:    time.Now(),
+		domainEvents: make([]event.DomainEvent, 0),
+	}
+
+	for _, memberID := range memberIDs {
+		if !organization.HasMember(memberID) {
+			organization.memberIDs = append(organization.memberIDs, memberID)
+		}
+	}
+
+	createdEvent := event.NewOrganizationCreatedEvent(id.Value(), name, userIDValues(organization.memberIDs))
+	organization.domainEvents = append(organization.domainEvents, createdEvent)
+
+	return organization, nil
+}
+
+// ID returns the organization ID
+func (o *Organization) ID() value.OrganizationID {
+	return o.id
+}
+
+// Name returns the organization name
+func (o *Organization) Name() string {
+	return o.name
+}
+
+// MemberIDs returns the organization members
+func (o *Organization) MemberIDs() []value.UserID {
+	return append([]value.UserID{}, o.memberIDs...)
+}
+
+// Quota returns the organization's limits
+func (o *Organization) Quota() value.Quota {
+	return o.quota
+}
+
+// CreatedAt returns when the organization was created
+func (o *Organization) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// UpdatedAt returns when the organization was last updated
+func (o *Organization) UpdatedAt() time.Time {
+	return o.updatedAt
+}
+
+// DomainEvents returns all uncommitted domain events
+func (o *Organization) DomainEvents() []event.DomainEvent {
+	return append([]event.DomainEvent{}, o.domainEvents...)
+}
+
+// ClearDomainEvents clears all domain events after they have been published
+func (o *Organization) ClearDomainEvents() {
+	o.domainEvents = make([]event.DomainEvent, 0)
+}
+
+// HasMember checks if a user belongs to the organization
+func (o *Organization) HasMember(userID value.UserID) bool {
+	for _, memberID := range o.memberIDs {
+		if memberID.Equals(userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddMember adds a user to the organization
+func (o *Organization) AddMember(userID value.UserID) error {
+	if o.HasMember(userID) {
+		return errs.Conflict("user is already an organization member")
+	}
+
+	o.memberIDs = append(o.memberIDs, userID)
+	o.updatedAt = time.Now()
+
+	addedEvent := event.NewOrganizationMemberAddedEvent(o.id.Value(), userID.Value())
+	o.domainEvents = append(o.domainEvents, addedEvent)
+
+	return nil
+}
+
+// SetQuota replaces the organization's limits. Usage already over a lowered limit
+// stays, but nothing more can be added until it drops below.
+func (o *Organization) SetQuota(quota value.Quota) {
+	o.quota = quota
+	o.updatedAt = time.Now()
+
+	changedEvent := event.NewOrganizationQuotaChangedEvent(
+		o.id.Value(),
+		quota.MaxProjects(),
+		quota.MaxTasks(),
+		quota.MaxAttachmentBytes(),
+		quota.APICallsPerMinute(),
+	)
+	o.domainEvents = append(o.domainEvents, changedEvent)
+}
+
+// DefaultWorkingHours returns the working hours members follow unless they set their own,
+// or nil when the organization leaves them on the built-in default
+func (o *Organization) DefaultWorkingHours() *value.WorkingHours {
+	return o.workingHours
+}
+
+// SetDefaultWorkingHours replaces the working hours members follow unless they set their own;
+// nil returns them to the built-in default
+func (o *Organization) SetDefaultWorkingHours(hours *value.WorkingHours) {
+	o.workingHours = hours
+	o.updatedAt = time.Now()
+
+	var hoursPerDay float64
+	workingDays := make([]string, 0)
+	if hours != nil {
+		hoursPerDay = hours.HoursPerDay()
+		workingDays = hours.WorkingDayNames()
+	}
+	changedEvent := event.NewOrganizationWorkingHoursChangedEvent(o.id.Value(), hoursPerDay, workingDays)
+	o.domainEvents = append(o.domainEvents, changedEvent)
+}
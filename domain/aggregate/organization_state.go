@@ -0,0 +1,81 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// OrganizationState is the memento of an Organization: its full state in plain fields, for
+// storage outside memory. Uncommitted domain events are not part of the state.
+type OrganizationState struct {
+	ID           string             `json:"id"`
+	Name         string             `json:"name"`
+	MemberIDs    []string           `json:"member_ids"`
+	Quota        QuotaState         `json:"quota"`
+	WorkingHours *WorkingHoursState `json:"working_hours,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+// QuotaState is the stored form of an organization's quota, 0 meaning unlimited
+type QuotaState struct {
+	MaxProjects        int   `json:"max_projects"`
+	MaxTasks           int   `json:"max_tasks"`
+	MaxAttachmentBytes int64 `json:"max_attachment_bytes"`
+	APICallsPerMinute  int   `json:"api_calls_per_minute"`
+}
+
+// ToState captures the organization's state
+func (o *Organization) ToState() OrganizationState {
+	return OrganizationState{
+		ID:        o.id.Value(),
+		Name:      o.name,
+		MemberIDs: userIDValues(o.memberIDs),
+		Quota: QuotaState{
+			MaxProjects:        o.quota.MaxProjects(),
+			MaxTasks:           o.quota.MaxTasks(),
+			MaxAttachmentBytes: o.quota.MaxAttachmentBytes(),
+			APICallsPerMinute:  o.quota.APICallsPerMinute(),
+		},
+		WorkingHours: workingHoursState(o.workingHours),
+		CreatedAt:    o.createdAt,
+		UpdatedAt:    o.updatedAt,
+	}
+}
+
+// OrganizationFromState rebuilds an Organization from its state without raising domain events
+func OrganizationFromState(state OrganizationState) (*Organization, error) {
+	id, err := value.NewOrganizationID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid organization id: %w", err)
+	}
+
+	memberIDs, err := parseUserIDs(state.MemberIDs, "member")
+	if err != nil {
+		return nil, err
+	}
+
+	quota, err := value.NewQuota(state.Quota.MaxProjects, state.Quota.MaxTasks, state.Quota.MaxAttachmentBytes, state.Quota.APICallsPerMinute)
+	if err != nil {
+		return nil, err
+	}
+
+	workingHours, err := workingHoursFromState(state.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Organization{
+		id:           id,
+		name:         state.Name,
+		memberIDs:    memberIDs,
+		quota:        quota,
+		workingHours: workingHours,
+		createdAt:    state.CreatedAt,
+		updatedAt:    state.UpdatedAt,
+		domainEvents: make([]event.DomainEvent, 0),
+	}, nil
+}
@@ -1,9 +1,10 @@
 package aggregate
 
 import (
-	"fmt"
 	"time"
 
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/value"
 )
@@ -15,10 +16,24 @@ type Project struct {
 	description string
 	ownerID     value.UserID
 	taskIDs     []value.TaskID
+	completedTaskCount int // as last recorded by RecordProgress
 	workflowID  value.WorkflowID
 	createdAt   time.Time
 	updatedAt   time.Time
 	archived    bool
+	sloTargets  value.SLOTargets
+	settings    value.ProjectSettings
+	priorityScheme value.PriorityScheme
+	budget      *value.Money
+	budgetExceeded bool
+	visibility  value.ProjectVisibility
+	memberIDs   []value.UserID
+	roles       map[string]value.ProjectRole
+	milestones  []*entity.Milestone
+	notificationRoutes []value.NotificationRoute
+	holidayCalendarID *value.HolidayCalendarID // nil observes no holidays
+	inboxAddresses []value.InboxAddress
+	workflowMigration *entity.WorkflowMigration // the latest planned migration, nil when none was
 	domainEvents []event.DomainEvent
 }
 
@@ -30,21 +45,40 @@ func NewProject(
 	workflowID value.WorkflowID,
 ) (*Project, error) {
 	if name == "" {
-		return nil, fmt.Errorf("project name cannot be empty")
+		return nil, errs.Invalid("project name cannot be empty")
 	}
 
-	return &Project{
+	project := &Project{
 		id:           id,
 		name:         name,
 		description:  description,
 		ownerID:      ownerID,
 		workflowID:   workflowID,
 		taskIDs:      make([]value.TaskID, 0),
+		milestones:   make([]*entity.Milestone, 0),
+		notificationRoutes: make([]value.NotificationRoute, 0),
+		inboxAddresses: make([]value.InboxAddress, 0),
+		settings:     value.DefaultProjectSettings(),
+		priorityScheme: value.DefaultPriorityScheme(),
+		visibility:   value.VisibilityWorkspace,
+		memberIDs:    make([]value.UserID, 0),
+		roles:        make(map[string]value.ProjectRole),
 		createdAt:    time.Now(),
 		updatedAt:    time.Now(),
 		archived:     false,
 		domainEvents: make([]event.DomainEvent, 0),
-	}, nil
+	}
+
+	// Raise domain event
+	createdEvent := event.NewProjectCreatedEvent(
+		id.Value(),
+		name,
+		ownerID.Value(),
+		workflowID.Value(),
+	)
+	project.domainEvents = append(project.domainEvents, createdEvent)
+
+	return project, nil
 }
 
 // ID returns the project ID
@@ -77,6 +111,16 @@ func (p *Project) TaskIDs() []value.TaskID {
 	return append([]value.TaskID{}, p.taskIDs...)
 }
 
+// HasTask checks if a task belongs to the project
+func (p *Project) HasTask(taskID value.TaskID) bool {
+	for _, id := range p.taskIDs {
+		if id.Equals(taskID) {
+			return true
+		}
+	}
+	return false
+}
+
 // CreatedAt returns when the project was created
 func (p *Project) CreatedAt() time.Time {
 	return p.createdAt
@@ -92,6 +136,147 @@ func (p *Project) IsArchived() bool {
 	return p.archived
 }
 
+// SLOTargets returns the service level objective targets
+func (p *Project) SLOTargets() value.SLOTargets {
+	return p.sloTargets
+}
+
+// Settings returns the project's settings
+func (p *Project) Settings() value.ProjectSettings {
+	return p.settings
+}
+
+// PriorityScheme returns the ladder of priorities the project's tasks may have
+func (p *Project) PriorityScheme() value.PriorityScheme {
+	return p.priorityScheme
+}
+
+// Budget returns the project's budget, nil when it has none
+func (p *Project) Budget() *value.Money {
+	return p.budget
+}
+
+// HolidayCalendarID returns the holiday calendar the project follows, nil when it observes no holidays
+func (p *Project) HolidayCalendarID() *value.HolidayCalendarID {
+	return p.holidayCalendarID
+}
+
+// InboxAddresses returns the inbound email addresses feeding the project
+func (p *Project) InboxAddresses() []value.InboxAddress {
+	return append([]value.InboxAddress{}, p.inboxAddresses...)
+}
+
+// HasInboxToken checks if one of the project's inbox addresses has the given token
+func (p *Project) HasInboxToken(token string) bool {
+	for _, inbox := range p.inboxAddresses {
+		if inbox.Token() == token {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBudgetExceeded returns whether the last recorded spend was over budget
+func (p *Project) IsBudgetExceeded() bool {
+	return p.budgetExceeded
+}
+
+// Visibility returns who can see the project
+func (p *Project) Visibility() value.ProjectVisibility {
+	return p.visibility
+}
+
+// MemberIDs returns the members of the project besides its owner
+func (p *Project) MemberIDs() []value.UserID {
+	return append([]value.UserID{}, p.memberIDs...)
+}
+
+// IsMember checks if a user is the owner, a member or holds a role in the project
+func (p *Project) IsMember(userID value.UserID) bool {
+	if p.ownerID.Equals(userID) {
+		return true
+	}
+
+	if _, ok := p.roles[userID.Value()]; ok {
+		return true
+	}
+
+	for _, memberID := range p.memberIDs {
+		if memberID.Equals(userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanView checks if a user may see the project and its tasks.
+// A nil user is anonymous and only sees workspace projects.
+func (p *Project) CanView(userID *value.UserID) bool {
+	if !p.visibility.IsRestricted() {
+		return true
+	}
+	return userID != nil && p.IsMember(*userID)
+}
+
+// RoleOf returns the role a user holds in the project.
+// The owner is always an admin, an assigned role comes next, and members and,
+// in workspace projects, everyone else work as members.
+func (p *Project) RoleOf(userID value.UserID) (value.ProjectRole, bool) {
+	if p.ownerID.Equals(userID) {
+		return value.ProjectRoleAdmin, true
+	}
+
+	if role, ok := p.roles[userID.Value()]; ok {
+		return role, true
+	}
+
+	if p.IsMember(userID) || !p.visibility.IsRestricted() {
+		return value.ProjectRoleMember, true
+	}
+
+	return "", false
+}
+
+// Roles returns the explicitly assigned roles by user ID
+func (p *Project) Roles() map[string]value.ProjectRole {
+	roles := make(map[string]value.ProjectRole, len(p.roles))
+	for userID, role := range p.roles {
+		roles[userID] = role
+	}
+	return roles
+}
+
+// NotificationRoutes returns the project's notification routing rules
+func (p *Project) NotificationRoutes() []value.NotificationRoute {
+	return append([]value.NotificationRoute{}, p.notificationRoutes...)
+}
+
+// RoutesFor returns the notification routes that apply to an event type
+func (p *Project) RoutesFor(eventType string) []value.NotificationRoute {
+	routes := make([]value.NotificationRoute, 0)
+	for _, route := range p.notificationRoutes {
+		if route.Matches(eventType) {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// Milestones returns the project milestones
+func (p *Project) Milestones() []*entity.Milestone {
+	return append([]*entity.Milestone{}, p.milestones...)
+}
+
+// Milestone returns a milestone by ID
+func (p *Project) Milestone(milestoneID string) (*entity.Milestone, error) {
+	for _, milestone := range p.milestones {
+		if milestone.ID() == milestoneID {
+			return milestone, nil
+		}
+	}
+	return nil, errs.NotFound("milestone not found")
+}
+
 // DomainEvents returns all uncommitted domain events
 func (p *Project) DomainEvents() []event.DomainEvent {
 	return append([]event.DomainEvent{}, p.domainEvents...)
@@ -105,19 +290,23 @@ func (p *Project) ClearDomainEvents() {
 // AddTask adds a task to the project
 func (p *Project) AddTask(taskID value.TaskID) error {
 	if taskID.Equals(value.TaskID{}) {
-		return fmt.Errorf("task id cannot be empty")
+		return errs.Invalid("task id cannot be empty")
 	}
 
 	// Check if task already exists
 	for _, existingID := range p.taskIDs {
 		if existingID.Equals(taskID) {
-			return fmt.Errorf("task already exists in project")
+			return errs.Conflict("task already exists in project")
 		}
 	}
 
 	p.taskIDs = append(p.taskIDs, taskID)
 	p.updatedAt = time.Now()
 
+	// Raise domain event
+	addedEvent := event.NewTaskAddedToProjectEvent(p.id.Value(), taskID.Value())
+	p.domainEvents = append(p.domainEvents, addedEvent)
+
 	return nil
 }
 
@@ -127,50 +316,643 @@ func (p *Project) RemoveTask(taskID value.TaskID) error {
 		if id.Equals(taskID) {
 			p.taskIDs = append(p.taskIDs[:i], p.taskIDs[i+1:]...)
 			p.updatedAt = time.Now()
+
+			removedEvent := event.NewTaskRemovedFromProjectEvent(p.id.Value(), taskID.Value())
+			p.domainEvents = append(p.domainEvents, removedEvent)
+
 			return nil
 		}
 	}
 
-	return fmt.Errorf("task not found in project")
+	return errs.NotFound("task not found in project")
 }
 
 // UpdateName updates the project name
 func (p *Project) UpdateName(newName string) error {
 	if newName == "" {
-		return fmt.Errorf("project name cannot be empty")
+		return errs.Invalid("project name cannot be empty")
 	}
 
+	if newName == p.name {
+		return nil
+	}
+
+	oldName := p.name
 	p.name = newName
 	p.updatedAt = time.Now()
 
+	// Raise domain event
+	renamedEvent := event.NewProjectRenamedEvent(p.id.Value(), oldName, newName)
+	p.domainEvents = append(p.domainEvents, renamedEvent)
+
 	return nil
 }
 
 // UpdateDescription updates the project description
 func (p *Project) UpdateDescription(newDescription string) error {
+	if newDescription == p.description {
+		return nil
+	}
+
 	p.description = newDescription
 	p.updatedAt = time.Now()
 
+	// Raise domain event
+	updatedEvent := event.NewProjectDescriptionUpdatedEvent(p.id.Value(), newDescription)
+	p.domainEvents = append(p.domainEvents, updatedEvent)
+
 	return nil
 }
 
-// Archive archives the project
-func (p *Project) Archive() error {
+// Archive archives the project. The open tasks that were frozen or cancelled
+// under the policy are recorded on the raised event.
+func (p *Project) Archive(policy value.ArchivePolicy, affectedTaskIDs []value.TaskID) error {
+	if p.archived {
+		return errs.Conflict("project is already archived")
+	}
+
+	if !policy.IsValid() {
+		return errs.Invalid("invalid archive policy: %s", policy.Value())
+	}
+
 	p.archived = true
 	p.updatedAt = time.Now()
 
+	// Raise domain event
+	affectedIDs := make([]string, 0, len(affectedTaskIDs))
+	for _, taskID := range affectedTaskIDs {
+		affectedIDs = append(affectedIDs, taskID.Value())
+	}
+	archivedEvent := event.NewProjectArchivedEvent(p.id.Value(), policy.Value(), affectedIDs)
+	p.domainEvents = append(p.domainEvents, archivedEvent)
+
 	return nil
 }
 
+// MarkDeleted records that the project is being deleted along with the given tasks
+func (p *Project) MarkDeleted(deletedTaskIDs []value.TaskID) {
+	deletedIDs := make([]string, 0, len(deletedTaskIDs))
+	for _, taskID := range deletedTaskIDs {
+		deletedIDs = append(deletedIDs, taskID.Value())
+	}
+
+	// Raise domain event
+	deletedEvent := event.NewProjectDeletedEvent(p.id.Value(), p.name, deletedIDs)
+	p.domainEvents = append(p.domainEvents, deletedEvent)
+}
+
 // Unarchive unarchives the project
 func (p *Project) Unarchive() error {
+	if !p.archived {
+		return errs.Conflict("project is not archived")
+	}
+
 	p.archived = false
 	p.updatedAt = time.Now()
 
+	// Raise domain event
+	unarchivedEvent := event.NewProjectUnarchivedEvent(p.id.Value())
+	p.domainEvents = append(p.domainEvents, unarchivedEvent)
+
+	return nil
+}
+
+// SetSLOTargets sets the service level objective targets for the project
+func (p *Project) SetSLOTargets(targets value.SLOTargets) error {
+	if p.archived {
+		return errs.Conflict("cannot change SLO targets of an archived project")
+	}
+
+	p.sloTargets = targets
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	sloEvent := event.NewProjectSLOTargetsChangedEvent(
+		p.id.Value(),
+		int64(targets.FirstResponse().Seconds()),
+		int64(targets.Resolution().Seconds()),
+	)
+	p.domainEvents = append(p.domainEvents, sloEvent)
+
+	return nil
+}
+
+// UpdateSettings replaces the project's settings
+func (p *Project) UpdateSettings(settings value.ProjectSettings) error {
+	if p.archived {
+		return errs.Conflict("cannot change settings of an archived project")
+	}
+	if !p.priorityScheme.Contains(settings.DefaultPriority()) {
+		return errs.Invalid("invalid default priority: %s is not in the project's priority scheme", settings.DefaultPriority())
+	}
+
+	p.settings = settings
+	p.updatedAt = time.Now()
+
+	defaultAssigneeID := ""
+	if settings.HasDefaultAssignee() {
+		defaultAssigneeID = settings.DefaultAssigneeID().Value()
+	}
+
+	// Raise domain event
+	settingsEvent := event.NewProjectSettingsChangedEvent(
+		p.id.Value(),
+		settings.DefaultPriority().Value(),
+		defaultAssigneeID,
+		settings.RequireDeadlineOnCreate(),
+		settings.AllowComments(),
+	)
+	p.domainEvents = append(p.domainEvents, settingsEvent)
+
+	return nil
+}
+
+// SetAccess replaces the project's visibility and members
+func (p *Project) SetAccess(visibility value.ProjectVisibility, memberIDs []value.UserID) error {
+	if p.archived {
+		return errs.Conflict("cannot change access of an archived project")
+	}
+
+	// Deduplicate members, the owner is always a member
+	seen := make(map[string]bool)
+	members := make([]value.UserID, 0, len(memberIDs))
+	rawMemberIDs := make([]string, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if memberID.Equals(p.ownerID) || seen[memberID.Value()] {
+			continue
+		}
+		seen[memberID.Value()] = true
+		members = append(members, memberID)
+		rawMemberIDs = append(rawMemberIDs, memberID.Value())
+	}
+
+	p.visibility = visibility
+	p.memberIDs = members
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	accessEvent := event.NewProjectAccessChangedEvent(
+		p.id.Value(),
+		visibility.Value(),
+		rawMemberIDs,
+	)
+	p.domainEvents = append(p.domainEvents, accessEvent)
+
+	return nil
+}
+
+// SetBudget sets the project's budget, nil removes it
+func (p *Project) SetBudget(budget *value.Money) error {
+	if p.archived {
+		return errs.Conflict("cannot change budget of an archived project")
+	}
+
+	if budget != nil && budget.IsNegative() {
+		return errs.Invalid("budget cannot be negative")
+	}
+
+	p.budget = budget
+	p.budgetExceeded = false
+	p.updatedAt = time.Now()
+
+	amount, currency := "", ""
+	if budget != nil {
+		amount, currency = budget.Amount(), budget.Currency()
+	}
+
+	// Raise domain event
+	budgetEvent := event.NewProjectBudgetChangedEvent(p.id.Value(), amount, currency)
+	p.domainEvents = append(p.domainEvents, budgetEvent)
+
+	return nil
+}
+
+// SetHolidayCalendar makes the project follow a holiday calendar, nil stops it observing holidays
+func (p *Project) SetHolidayCalendar(calendarID *value.HolidayCalendarID) error {
+	if p.archived {
+		return errs.Conflict("cannot change holiday calendar of an archived project")
+	}
+
+	p.holidayCalendarID = calendarID
+	p.updatedAt = time.Now()
+
+	id := ""
+	if calendarID != nil {
+		id = calendarID.Value()
+	}
+
+	// Raise domain event
+	calendarEvent := event.NewProjectHolidayCalendarChangedEvent(p.id.Value(), id)
+	p.domainEvents = append(p.domainEvents, calendarEvent)
+
+	return nil
+}
+
+// SetPriorityScheme replaces the project's priority ladder. The default priority of new
+// tasks must be one of its levels.
+func (p *Project) SetPriorityScheme(scheme value.PriorityScheme, defaultPriority value.Priority) error {
+	if p.archived {
+		return errs.Conflict("cannot change priority scheme of an archived project")
+	}
+	if !scheme.Contains(defaultPriority) {
+		return errs.Invalid("invalid default priority: %s is not in the priority scheme", defaultPriority)
+	}
+
+	p.priorityScheme = scheme
+	p.settings = p.settings.WithDefaultPriority(defaultPriority)
+	p.updatedAt = time.Now()
+
+	priorities := make([]string, 0, len(scheme.Levels()))
+	ranks := make([]int, 0, len(scheme.Levels()))
+	for _, level := range scheme.Levels() {
+		priorities = append(priorities, level.Priority().Value())
+		ranks = append(ranks, level.Rank())
+	}
+
+	// Raise domain event
+	schemeEvent := event.NewProjectPrioritySchemeChangedEvent(p.id.Value(), priorities, ranks, defaultPriority.Value())
+	p.domainEvents = append(p.domainEvents, schemeEvent)
+
+	return nil
+}
+
+// AddInboxAddress adds an inbound email address to the project
+func (p *Project) AddInboxAddress(inbox value.InboxAddress) error {
+	if p.archived {
+		return errs.Conflict("cannot add inbox address to an archived project")
+	}
+	if p.HasInboxToken(inbox.Token()) {
+		return errs.Conflict("inbox address already exists")
+	}
+
+	p.inboxAddresses = append(p.inboxAddresses, inbox)
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	addedEvent := event.NewProjectInboxAddressAddedEvent(p.id.Value(), inbox.Label())
+	p.domainEvents = append(p.domainEvents, addedEvent)
+
+	return nil
+}
+
+// RevokeInboxAddress stops an inbound email address feeding the project. Archived projects
+// may still revoke theirs.
+func (p *Project) RevokeInboxAddress(token string) error {
+	for i, inbox := range p.inboxAddresses {
+		if inbox.Token() != token {
+			continue
+		}
+
+		p.inboxAddresses = append(p.inboxAddresses[:i:i], p.inboxAddresses[i+1:]...)
+		p.updatedAt = time.Now()
+
+		// Raise domain event
+		revokedEvent := event.NewProjectInboxAddressRevokedEvent(p.id.Value(), inbox.Label())
+		p.domainEvents = append(p.domainEvents, revokedEvent)
+
+		return nil
+	}
+	return errs.NotFound("inbox address not found")
+}
+
+// RecordSpend compares the project's spend to its budget. Crossing the budget raises
+// BudgetExceededEvent once; it is raised again only after spend drops back under
+// the budget or the budget changes. It returns true if the project changed.
+func (p *Project) RecordSpend(spent value.Money) (bool, error) {
+	if p.budget == nil {
+		return false, nil
+	}
+
+	over, err := spent.GreaterThan(*p.budget)
+	if err != nil {
+		return false, err
+	}
+
+	if over == p.budgetExceeded {
+		return false, nil
+	}
+
+	p.budgetExceeded = over
+	p.updatedAt = time.Now()
+
+	if over {
+		// Raise domain event
+		exceededEvent := event.NewBudgetExceededEvent(
+			p.id.Value(),
+			p.budget.Amount(),
+			spent.Amount(),
+			spent.Currency(),
+		)
+		p.domainEvents = append(p.domainEvents, exceededEvent)
+	}
+
+	return true, nil
+}
+
+// SetNotificationRoutes replaces the project's notification routing rules
+func (p *Project) SetNotificationRoutes(routes []value.NotificationRoute) error {
+	if p.archived {
+		return errs.Conflict("cannot change notification routes of an archived project")
+	}
+
+	for i, route := range routes {
+		for _, other := range routes[:i] {
+			if route.Equals(other) {
+				return errs.Invalid("duplicate notification route for %s to %s", route.EventType(), route.Target())
+			}
+		}
+	}
+
+	p.notificationRoutes = append([]value.NotificationRoute{}, routes...)
+	p.updatedAt = time.Now()
+
 	return nil
 }
 
 // TaskCount returns the number of tasks in the project
 func (p *Project) TaskCount() int {
 	return len(p.taskIDs)
-}
\ No newline at end of file
+}
+
+// CompletedTaskCount returns the number of completed tasks in the project, as last recorded
+func (p *Project) CompletedTaskCount() int {
+	return p.completedTaskCount
+}
+
+// RecordProgress records how many of the project's tasks are completed
+func (p *Project) RecordProgress(completed int) error {
+	if completed < 0 || completed > len(p.taskIDs) {
+		return errs.Invalid("invalid completed task count %d: the project has %d tasks", completed, len(p.taskIDs))
+	}
+
+	if completed == p.completedTaskCount {
+		return nil
+	}
+
+	p.completedTaskCount = completed
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	progressEvent := event.NewProjectProgressUpdatedEvent(p.id.Value(), completed, len(p.taskIDs))
+	p.domainEvents = append(p.domainEvents, progressEvent)
+
+	return nil
+}
+
+// AddMilestone adds a milestone to the project
+func (p *Project) AddMilestone(name, description string, dueDate time.Time, taskIDs []value.TaskID) (*entity.Milestone, error) {
+	if p.archived {
+		return nil, errs.Conflict("cannot add milestone to an archived project")
+	}
+
+	milestone, err := entity.NewMilestone(name, description, dueDate, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	p.milestones = append(p.milestones, milestone)
+	p.updatedAt = time.Now()
+
+	return milestone, nil
+}
+
+// UpdateMilestone replaces the details of a milestone
+func (p *Project) UpdateMilestone(milestoneID, name, description string, dueDate time.Time, taskIDs []value.TaskID) error {
+	if p.archived {
+		return errs.Conflict("cannot change milestones of an archived project")
+	}
+
+	milestone, err := p.Milestone(milestoneID)
+	if err != nil {
+		return err
+	}
+
+	if err := milestone.Update(name, description, dueDate, taskIDs); err != nil {
+		return err
+	}
+
+	p.updatedAt = time.Now()
+
+	return nil
+}
+
+// RemoveMilestone removes a milestone from the project
+func (p *Project) RemoveMilestone(milestoneID string) error {
+	if p.archived {
+		return errs.Conflict("cannot change milestones of an archived project")
+	}
+
+	for i, milestone := range p.milestones {
+		if milestone.ID() == milestoneID {
+			p.milestones = append(p.milestones[:i], p.milestones[i+1:]...)
+			p.updatedAt = time.Now()
+			return nil
+		}
+	}
+
+	return errs.NotFound("milestone not found")
+}
+
+// MarkMilestoneReached records that every task of a milestone is done
+func (p *Project) MarkMilestoneReached(milestoneID string, at time.Time) error {
+	milestone, err := p.Milestone(milestoneID)
+	if err != nil {
+		return err
+	}
+
+	if err := milestone.MarkReached(at); err != nil {
+		return err
+	}
+
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	reachedEvent := event.NewMilestoneReachedEvent(
+		p.id.Value(),
+		milestone.ID(),
+		milestone.Name(),
+		milestone.DueDate().Format(time.RFC3339),
+		at.Format(time.RFC3339),
+		at.After(milestone.DueDate()),
+	)
+	p.domainEvents = append(p.domainEvents, reachedEvent)
+
+	return nil
+}
+
+// AssignRole gives a user a role in the project
+func (p *Project) AssignRole(userID value.UserID, role value.ProjectRole) error {
+	if p.archived {
+		return errs.Conflict("cannot change roles of an archived project")
+	}
+
+	if p.ownerID.Equals(userID) {
+		return errs.Conflict("the project owner is always an admin")
+	}
+
+	if _, err := value.NewProjectRole(role.Value()); err != nil {
+		return err
+	}
+
+	p.roles[userID.Value()] = role
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	assignedEvent := event.NewProjectRoleAssignedEvent(p.id.Value(), userID.Value(), role.Value())
+	p.domainEvents = append(p.domainEvents, assignedEvent)
+
+	return nil
+}
+
+// RevokeRole removes a user's assigned role in the project
+func (p *Project) RevokeRole(userID value.UserID) error {
+	if p.archived {
+		return errs.Conflict("cannot change roles of an archived project")
+	}
+
+	if _, ok := p.roles[userID.Value()]; !ok {
+		return errs.Conflict("user has no role in the project")
+	}
+
+	delete(p.roles, userID.Value())
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	revokedEvent := event.NewProjectRoleRevokedEvent(p.id.Value(), userID.Value())
+	p.domainEvents = append(p.domainEvents, revokedEvent)
+
+	return nil
+}
+
+// ChangeWorkflow moves the project onto another workflow
+func (p *Project) ChangeWorkflow(workflowID value.WorkflowID) error {
+	if p.archived {
+		return errs.Conflict("cannot change the workflow of an archived project")
+	}
+
+	if p.workflowID.Equals(workflowID) {
+		return errs.Conflict("project already uses this workflow")
+	}
+
+	if p.workflowMigration != nil && p.workflowMigration.IsInProgress() {
+		return errs.Conflict("a workflow migration is in progress: apply or roll it back first")
+	}
+
+	return p.switchWorkflow(workflowID)
+}
+
+// switchWorkflow moves the project onto another workflow without checking migrations
+func (p *Project) switchWorkflow(workflowID value.WorkflowID) error {
+	oldWorkflowID := p.workflowID
+	p.workflowID = workflowID
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	changedEvent := event.NewProjectWorkflowChangedEvent(p.id.Value(), oldWorkflowID.Value(), workflowID.Value())
+	p.domainEvents = append(p.domainEvents, changedEvent)
+
+	return nil
+}
+
+// WorkflowMigration returns the latest planned workflow migration, nil when none was
+func (p *Project) WorkflowMigration() *entity.WorkflowMigration {
+	return p.workflowMigration
+}
+
+// PlanWorkflowMigration records a migration plan, replacing a previous one that is not in progress
+func (p *Project) PlanWorkflowMigration(migration *entity.WorkflowMigration) error {
+	if p.archived {
+		return errs.Conflict("cannot change the workflow of an archived project")
+	}
+
+	if p.workflowMigration != nil && p.workflowMigration.IsInProgress() {
+		return errs.Conflict("a workflow migration is in progress: apply or roll it back first")
+	}
+
+	if !migration.FromWorkflowID().Equals(p.workflowID) {
+		return errs.Invalid("invalid workflow migration: the project no longer uses workflow %s", migration.FromWorkflowID().Value())
+	}
+
+	p.workflowMigration = migration
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	plannedEvent := event.NewProjectWorkflowMigrationPlannedEvent(
+		p.id.Value(),
+		migration.ID(),
+		migration.FromWorkflowID().Value(),
+		migration.ToVersion().String(),
+		migration.Total(),
+	)
+	p.domainEvents = append(p.domainEvents, plannedEvent)
+
+	return nil
+}
+
+// RecordWorkflowMigrationProgress marks the next count tasks of the migration as moved
+func (p *Project) RecordWorkflowMigrationProgress(count int) error {
+	if p.workflowMigration == nil {
+		return errs.NotFound("workflow migration not found: plan one first")
+	}
+
+	if err := p.workflowMigration.RecordProgress(count); err != nil {
+		return err
+	}
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	progressedEvent := event.NewProjectWorkflowMigrationProgressedEvent(
+		p.id.Value(),
+		p.workflowMigration.ID(),
+		p.workflowMigration.Applied(),
+		p.workflowMigration.Total(),
+	)
+	p.domainEvents = append(p.domainEvents, progressedEvent)
+
+	return nil
+}
+
+// CompleteWorkflowMigration switches the project onto the migration's workflow once every task has moved
+func (p *Project) CompleteWorkflowMigration() error {
+	migration := p.workflowMigration
+	if migration == nil {
+		return errs.NotFound("workflow migration not found: plan one first")
+	}
+
+	if migration.Applied() != migration.Total() {
+		return errs.Conflict("workflow migration has %d steps left to apply", migration.Total()-migration.Applied())
+	}
+
+	if err := p.switchWorkflow(migration.ToVersion().WorkflowID()); err != nil {
+		return err
+	}
+
+	return migration.Complete()
+}
+
+// RollBackWorkflowMigration marks the migration's moved tasks as restored, switching the
+// project back to the workflow it left when the migration had completed
+func (p *Project) RollBackWorkflowMigration() error {
+	migration := p.workflowMigration
+	if migration == nil {
+		return errs.NotFound("workflow migration not found: plan one first")
+	}
+
+	restored := migration.Applied()
+	if migration.Status() == entity.WorkflowMigrationApplied {
+		if err := p.switchWorkflow(migration.FromWorkflowID()); err != nil {
+			return err
+		}
+	}
+
+	if err := migration.RollBack(); err != nil {
+		return err
+	}
+	p.updatedAt = time.Now()
+
+	// Raise domain event
+	rolledBackEvent := event.NewProjectWorkflowMigrationRolledBackEvent(p.id.Value(), migration.ID(), restored)
+	p.domainEvents = append(p.domainEvents, rolledBackEvent)
+
+	return nil
+}
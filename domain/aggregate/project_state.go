@@ -0,0 +1,487 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ProjectState is the memento of a Project: its full state in plain fields, for storage outside memory.
+// Uncommitted domain events are not part of the state.
+type ProjectState struct {
+	ID                 string                   `json:"id"`
+	Name               string                   `json:"name"`
+	Description        string                   `json:"description"`
+	OwnerID            string                   `json:"owner_id"`
+	TaskIDs            []string                 `json:"task_ids"`
+	CompletedTaskCount int                      `json:"completed_task_count"`
+	WorkflowID         string                   `json:"workflow_id"`
+	Archived           bool                     `json:"archived"`
+	SLOTargets         SLOTargetsState          `json:"slo_targets"`
+	Settings           ProjectSettingsState     `json:"settings"`
+	PriorityScheme     []PriorityLevelState     `json:"priority_scheme,omitempty"` // empty for the default scheme
+	Budget             *MoneyState              `json:"budget,omitempty"`
+	BudgetExceeded     bool                     `json:"budget_exceeded"`
+	Visibility         string                   `json:"visibility"`
+	MemberIDs          []string                 `json:"member_ids"`
+	Roles              map[string]string        `json:"roles"` // user ID -> project role
+	Milestones         []MilestoneState         `json:"milestones"`
+	NotificationRoutes []NotificationRouteState `json:"notification_routes"`
+	HolidayCalendarID  string                   `json:"holiday_calendar_id,omitempty"`
+	InboxAddresses     []InboxAddressState      `json:"inbox_addresses"`
+	WorkflowMigration  *WorkflowMigrationState  `json:"workflow_migration,omitempty"`
+	CreatedAt          time.Time                `json:"created_at"`
+	UpdatedAt          time.Time                `json:"updated_at"`
+}
+
+// SLOTargetsState is the stored form of a project's service level objectives
+type SLOTargetsState struct {
+	FirstResponse time.Duration `json:"first_response"`
+	Resolution    time.Duration `json:"resolution"`
+}
+
+// ProjectSettingsState is the stored form of a project's settings
+type ProjectSettingsState struct {
+	DefaultPriority         string `json:"default_priority"`
+	DefaultAssigneeID       string `json:"default_assignee_id,omitempty"`
+	RequireDeadlineOnCreate bool   `json:"require_deadline_on_create"`
+	AllowComments           bool   `json:"allow_comments"`
+}
+
+// PriorityLevelState is the stored form of a level of a priority scheme
+type PriorityLevelState struct {
+	Priority string `json:"priority"`
+	Rank     int    `json:"rank"`
+}
+
+// MoneyState is the stored form of an amount of money
+type MoneyState struct {
+	AmountMinor int64  `json:"amount_minor"`
+	Currency    string `json:"currency"`
+}
+
+// MilestoneState is the stored form of a milestone
+type MilestoneState struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	DueDate     time.Time  `json:"due_date"`
+	TaskIDs     []string   `json:"task_ids"`
+	ReachedAt   *time.Time `json:"reached_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// NotificationRouteState is the stored form of a notification routing rule
+type NotificationRouteState struct {
+	EventType string `json:"event_type"`
+	Channel   string `json:"channel"`
+	Target    string `json:"target"`
+	Delivery  string `json:"delivery"`
+}
+
+// InboxAddressState is the stored form of a project's inbound email address
+type InboxAddressState struct {
+	Token     string    `json:"token"`
+	Label     string    `json:"label,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkflowMigrationState is the stored form of a workflow migration plan
+type WorkflowMigrationState struct {
+	ID             string                       `json:"id"`
+	FromWorkflowID string                       `json:"from_workflow_id"`
+	ToWorkflowID   string                       `json:"to_workflow_id"`
+	ToVersion      int                          `json:"to_version"`
+	Steps          []WorkflowMigrationStepState `json:"steps"`
+	Applied        int                          `json:"applied"`
+	Status         string                       `json:"status"`
+	PlannedBy      string                       `json:"planned_by"`
+	CreatedAt      time.Time                    `json:"created_at"`
+	UpdatedAt      time.Time                    `json:"updated_at"`
+}
+
+// WorkflowMigrationStepState is the stored form of one task's planned move
+type WorkflowMigrationStepState struct {
+	TaskID      string `json:"task_id"`
+	FromVersion int    `json:"from_version"` // of the workflow the project leaves
+	FromStatus  string `json:"from_status"`  // lifecycle stages, as in TaskState
+	ToStatus    string `json:"to_status"`
+	// The statuses as their workflows name them, empty for statuses of the default workflow
+	FromStatusWorkflowID string `json:"from_status_workflow_id,omitempty"`
+	FromStatusName       string `json:"from_status_name,omitempty"`
+	ToStatusWorkflowID   string `json:"to_status_workflow_id,omitempty"`
+	ToStatusName         string `json:"to_status_name,omitempty"`
+}
+
+// ToState captures the project's state
+func (p *Project) ToState() ProjectState {
+	state := ProjectState{
+		ID:                 p.id.Value(),
+		Name:               p.name,
+		Description:        p.description,
+		OwnerID:            p.ownerID.Value(),
+		TaskIDs:            taskIDValues(p.taskIDs),
+		CompletedTaskCount: p.completedTaskCount,
+		WorkflowID:         p.workflowID.Value(),
+		Archived:           p.archived,
+		SLOTargets: SLOTargetsState{
+			FirstResponse: p.sloTargets.FirstResponse(),
+			Resolution:    p.sloTargets.Resolution(),
+		},
+		Settings: ProjectSettingsState{
+			DefaultPriority:         p.settings.DefaultPriority().Value(),
+			RequireDeadlineOnCreate: p.settings.RequireDeadlineOnCreate(),
+			AllowComments:           p.settings.AllowComments(),
+		},
+		BudgetExceeded:     p.budgetExceeded,
+		Visibility:         p.visibility.Value(),
+		MemberIDs:          userIDValues(p.memberIDs),
+		Roles:              make(map[string]string, len(p.roles)),
+		Milestones:         make([]MilestoneState, 0, len(p.milestones)),
+		NotificationRoutes: make([]NotificationRouteState, 0, len(p.notificationRoutes)),
+		InboxAddresses:     make([]InboxAddressState, 0, len(p.inboxAddresses)),
+		CreatedAt:          p.createdAt,
+		UpdatedAt:          p.updatedAt,
+	}
+
+	if assigneeID := p.settings.DefaultAssigneeID(); assigneeID != nil {
+		state.Settings.DefaultAssigneeID = assigneeID.Value()
+	}
+
+	if p.budget != nil {
+		state.Budget = &MoneyState{AmountMinor: p.budget.MinorUnits(), Currency: p.budget.Currency()}
+	}
+
+	if p.holidayCalendarID != nil {
+		state.HolidayCalendarID = p.holidayCalendarID.Value()
+	}
+
+	if !p.priorityScheme.IsDefault() {
+		for _, level := range p.priorityScheme.Levels() {
+			state.PriorityScheme = append(state.PriorityScheme, PriorityLevelState{
+				Priority: level.Priority().Value(),
+				Rank:     level.Rank(),
+			})
+		}
+	}
+
+	for userID, role := range p.roles {
+		state.Roles[userID] = role.Value()
+	}
+
+	for _, milestone := range p.milestones {
+		state.Milestones = append(state.Milestones, MilestoneState{
+			ID:          milestone.ID(),
+			Name:        milestone.Name(),
+			Description: milestone.Description(),
+			DueDate:     milestone.DueDate(),
+			TaskIDs:     taskIDValues(milestone.TaskIDs()),
+			ReachedAt:   milestone.ReachedAt(),
+			CreatedAt:   milestone.CreatedAt(),
+			UpdatedAt:   milestone.UpdatedAt(),
+		})
+	}
+
+	for _, route := range p.notificationRoutes {
+		state.NotificationRoutes = append(state.NotificationRoutes, NotificationRouteState{
+			EventType: route.EventType(),
+			Channel:   route.Channel().Value(),
+			Target:    route.Target(),
+			Delivery:  route.Delivery().Value(),
+		})
+	}
+
+	for _, inbox := range p.inboxAddresses {
+		state.InboxAddresses = append(state.InboxAddresses, InboxAddressState{
+			Token:     inbox.Token(),
+			Label:     inbox.Label(),
+			CreatedAt: inbox.CreatedAt(),
+		})
+	}
+
+	if migration := p.workflowMigration; migration != nil {
+		migrationState := &WorkflowMigrationState{
+			ID:             migration.ID(),
+			FromWorkflowID: migration.FromWorkflowID().Value(),
+			ToWorkflowID:   migration.ToVersion().WorkflowID().Value(),
+			ToVersion:      migration.ToVersion().Number(),
+			Steps:          make([]WorkflowMigrationStepState, 0, migration.Total()),
+			Applied:        migration.Applied(),
+			Status:         string(migration.Status()),
+			PlannedBy:      migration.PlannedBy().Value(),
+			CreatedAt:      migration.CreatedAt(),
+			UpdatedAt:      migration.UpdatedAt(),
+		}
+		for _, step := range migration.Steps() {
+			stepState := WorkflowMigrationStepState{
+				TaskID:      step.TaskID.Value(),
+				FromVersion: step.FromVersion.Number(),
+				FromStatus:  step.FromStatus.Stage().Value(),
+				ToStatus:    step.ToStatus.Stage().Value(),
+			}
+			stepState.FromStatusWorkflowID, stepState.FromStatusName = scopedStatusState(step.FromStatus)
+			stepState.ToStatusWorkflowID, stepState.ToStatusName = scopedStatusState(step.ToStatus)
+			migrationState.Steps = append(migrationState.Steps, stepState)
+		}
+		state.WorkflowMigration = migrationState
+	}
+
+	return state
+}
+
+// ProjectFromState rebuilds a Project from its state without raising domain events
+func ProjectFromState(state ProjectState) (*Project, error) {
+	id, err := value.NewProjectID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project id: %w", err)
+	}
+
+	ownerID, err := value.NewUserID(state.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner id: %w", err)
+	}
+
+	workflowID, err := value.NewWorkflowID(state.WorkflowID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow id: %w", err)
+	}
+
+	taskIDs, err := parseTaskIDs(state.TaskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := parseUserIDs(state.MemberIDs, "member")
+	if err != nil {
+		return nil, err
+	}
+
+	sloTargets, err := value.NewSLOTargets(state.SLOTargets.FirstResponse, state.SLOTargets.Resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	visibility, err := value.NewProjectVisibility(state.Visibility)
+	if err != nil {
+		return nil, err
+	}
+
+	priorityScheme := value.DefaultPriorityScheme()
+	if len(state.PriorityScheme) > 0 {
+		levels := make([]value.PriorityLevel, 0, len(state.PriorityScheme))
+		for _, l := range state.PriorityScheme {
+			level, err := value.NewPriorityLevel(l.Priority, l.Rank)
+			if err != nil {
+				return nil, err
+			}
+			levels = append(levels, level)
+		}
+		if priorityScheme, err = value.NewPriorityScheme(levels); err != nil {
+			return nil, err
+		}
+	}
+
+	priority, err := value.ParsePriority(state.Settings.DefaultPriority)
+	if err != nil {
+		return nil, err
+	}
+	var defaultAssigneeID *value.UserID
+	if state.Settings.DefaultAssigneeID != "" {
+		assigneeID, err := value.NewUserID(state.Settings.DefaultAssigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default assignee id: %w", err)
+		}
+		defaultAssigneeID = &assigneeID
+	}
+	settings, err := value.NewProjectSettings(priority, defaultAssigneeID, state.Settings.RequireDeadlineOnCreate, state.Settings.AllowComments)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &Project{
+		id:                 id,
+		name:               state.Name,
+		description:        state.Description,
+		ownerID:            ownerID,
+		taskIDs:            taskIDs,
+		completedTaskCount: state.CompletedTaskCount,
+		workflowID:         workflowID,
+		createdAt:          state.CreatedAt,
+		updatedAt:          state.UpdatedAt,
+		archived:           state.Archived,
+		sloTargets:         sloTargets,
+		settings:           settings,
+		priorityScheme:     priorityScheme,
+		budgetExceeded:     state.BudgetExceeded,
+		visibility:         visibility,
+		memberIDs:          memberIDs,
+		roles:              make(map[string]value.ProjectRole, len(state.Roles)),
+		milestones:         make([]*entity.Milestone, 0, len(state.Milestones)),
+		notificationRoutes: make([]value.NotificationRoute, 0, len(state.NotificationRoutes)),
+		inboxAddresses:     make([]value.InboxAddress, 0, len(state.InboxAddresses)),
+		domainEvents:       make([]event.DomainEvent, 0),
+	}
+
+	if state.Budget != nil {
+		budget, err := value.NewMoney(state.Budget.AmountMinor, state.Budget.Currency)
+		if err != nil {
+			return nil, err
+		}
+		project.budget = &budget
+	}
+
+	if state.HolidayCalendarID != "" {
+		calendarID, err := value.NewHolidayCalendarID(state.HolidayCalendarID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday calendar id: %w", err)
+		}
+		project.holidayCalendarID = &calendarID
+	}
+
+	for userID, r := range state.Roles {
+		if _, err := value.NewUserID(userID); err != nil {
+			return nil, fmt.Errorf("invalid role holder id: %w", err)
+		}
+		role, err := value.NewProjectRole(r)
+		if err != nil {
+			return nil, err
+		}
+		project.roles[userID] = role
+	}
+
+	for _, m := range state.Milestones {
+		milestoneTaskIDs, err := parseTaskIDs(m.TaskIDs)
+		if err != nil {
+			return nil, err
+		}
+		project.milestones = append(project.milestones, entity.RestoreMilestone(m.ID, m.Name, m.Description, m.DueDate, milestoneTaskIDs, m.ReachedAt, m.CreatedAt, m.UpdatedAt))
+	}
+
+	for _, r := range state.NotificationRoutes {
+		channel, err := value.NewNotificationChannel(r.Channel)
+		if err != nil {
+			return nil, err
+		}
+		delivery, err := value.NewNotificationDelivery(r.Delivery)
+		if err != nil {
+			return nil, err
+		}
+		route, err := value.NewNotificationRoute(r.EventType, channel, r.Target, delivery)
+		if err != nil {
+			return nil, err
+		}
+		project.notificationRoutes = append(project.notificationRoutes, route)
+	}
+
+	for _, i := range state.InboxAddresses {
+		inbox, err := value.NewInboxAddress(i.Token, i.Label, i.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		project.inboxAddresses = append(project.inboxAddresses, inbox)
+	}
+
+	if state.WorkflowMigration != nil {
+		if project.workflowMigration, err = workflowMigrationFromState(*state.WorkflowMigration); err != nil {
+			return nil, err
+		}
+	}
+
+	return project, nil
+}
+
+// workflowMigrationFromState rebuilds a workflow migration plan
+func workflowMigrationFromState(state WorkflowMigrationState) (*entity.WorkflowMigration, error) {
+	fromWorkflowID, err := value.NewWorkflowID(state.FromWorkflowID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow id: %w", err)
+	}
+	toWorkflowID, err := value.NewWorkflowID(state.ToWorkflowID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow id: %w", err)
+	}
+	toVersion, err := value.NewWorkflowVersion(toWorkflowID, state.ToVersion)
+	if err != nil {
+		return nil, err
+	}
+	status, err := entity.NewWorkflowMigrationStatus(state.Status)
+	if err != nil {
+		return nil, err
+	}
+	plannedBy, err := value.NewUserID(state.PlannedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid planner id: %w", err)
+	}
+
+	steps := make([]entity.WorkflowMigrationStep, 0, len(state.Steps))
+	for _, s := range state.Steps {
+		taskID, err := value.NewTaskID(s.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task id: %w", err)
+		}
+		fromVersion, err := value.NewWorkflowVersion(fromWorkflowID, s.FromVersion)
+		if err != nil {
+			return nil, err
+		}
+		fromStatus, err := scopedStatusFromState(s.FromStatus, s.FromStatusWorkflowID, s.FromStatusName)
+		if err != nil {
+			return nil, err
+		}
+		toStatus, err := scopedStatusFromState(s.ToStatus, s.ToStatusWorkflowID, s.ToStatusName)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, entity.WorkflowMigrationStep{
+			TaskID:      taskID,
+			FromVersion: fromVersion,
+			FromStatus:  fromStatus,
+			ToStatus:    toStatus,
+		})
+	}
+
+	if state.Applied < 0 || state.Applied > len(steps) {
+		return nil, fmt.Errorf("invalid workflow migration progress: %d of %d steps", state.Applied, len(steps))
+	}
+
+	return entity.RestoreWorkflowMigration(state.ID, fromWorkflowID, toVersion, steps, state.Applied, status,
+		plannedBy, state.CreatedAt, state.UpdatedAt), nil
+}
+
+// taskIDValues returns the string form of task IDs
+func taskIDValues(ids []value.TaskID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.Value())
+	}
+	return values
+}
+
+// parseTaskIDs parses stored task IDs
+func parseTaskIDs(values []string) ([]value.TaskID, error) {
+	ids := make([]value.TaskID, 0, len(values))
+	for _, v := range values {
+		id, err := value.NewTaskID(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseUserIDs parses stored user IDs, naming what the users are in errors
+func parseUserIDs(values []string, what string) ([]value.UserID, error) {
+	ids := make([]value.UserID, 0, len(values))
+	for _, v := range values {
+		id, err := value.NewUserID(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s id: %w", what, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,247 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Sprint is the aggregate root for a time-boxed iteration of a project
+type Sprint struct {
+	id           value.SprintID
+	projectID    value.ProjectID
+	name         string
+	goal         string
+	startDate    time.Time
+	endDate      time.Time
+	status       value.SprintStatus
+	taskIDs      []value.TaskID
+	startedAt    *time.Time
+	completedAt  *time.Time
+	createdAt    time.Time
+	updatedAt    time.Time
+	domainEvents []event.DomainEvent
+}
+
+// NewSprint creates a new planned Sprint
+func NewSprint(
+	id value.SprintID,
+	projectID value.ProjectID,
+	name string,
+	goal string,
+	startDate time.Time,
+	endDate time.Time,
+) (*Sprint, error) {
+	if name == "" {
+		return nil, errs.Invalid("sprint name cannot be empty")
+	}
+
+	if !endDate.After(startDate) {
+		return nil, errs.Invalid("sprint end date must be after start date")
+	}
+
+	sprint := &Sprint{
+		id:           id,
+		projectID:    projectID,
+		name:         name,
+		goal:         goal,
+		startDate:    startDate,
+		endDate:      endDate,
+		status:       value.SprintStatusPlanned,
+		taskIDs:      make([]value.TaskID, 0),
+		createdAt:    time.Now(),
+		updatedAt:    time.Now(),
+		domainEvents: make([]event.DomainEvent, 0),
+	}
+
+	createdEvent := event.NewSprintCreatedEvent(
+		id.Value(),
+		projectID.Value(),
+		name,
+		startDate.Format(time.RFC3339),
+		endDate.Format(time.RFC3339),
+	)
+	sprint.domainEvents = append(sprint.domainEvents, createdEvent)
+
+	return sprint, nil
+}
+
+// ID returns the sprint ID
+func (s *Sprint) ID() value.SprintID {
+	return s.id
+}
+
+// ProjectID returns the ID of the project the sprint belongs to
+func (s *Sprint) ProjectID() value.ProjectID {
+	return s.projectID
+}
+
+// Name returns the sprint name
+func (s *Sprint) Name() string {
+	return s.name
+}
+
+// Goal returns the sprint goal
+func (s *Sprint) Goal() string {
+	return s.goal
+}
+
+// StartDate returns the planned start date
+func (s *Sprint) StartDate() time.Time {
+	return s.startDate
+}
+
+// EndDate returns the planned end date
+func (s *Sprint) EndDate() time.Time {
+	return s.endDate
+}
+
+// Status returns the sprint status
+func (s *Sprint) Status() value.SprintStatus {
+	return s.status
+}
+
+// TaskIDs returns the tasks planned into the sprint
+func (s *Sprint) TaskIDs() []value.TaskID {
+	return append([]value.TaskID{}, s.taskIDs...)
+}
+
+// StartedAt returns when the sprint was started, if it has been
+func (s *Sprint) StartedAt() *time.Time {
+	return s.startedAt
+}
+
+// CompletedAt returns when the sprint was completed, if it has been
+func (s *Sprint) CompletedAt() *time.Time {
+	return s.completedAt
+}
+
+// CreatedAt returns when the sprint was created
+func (s *Sprint) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// UpdatedAt returns when the sprint was last updated
+func (s *Sprint) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// DomainEvents returns all uncommitted domain events
+func (s *Sprint) DomainEvents() []event.DomainEvent {
+	return append([]event.DomainEvent{}, s.domainEvents...)
+}
+
+// ClearDomainEvents clears all domain events after they have been published
+func (s *Sprint) ClearDomainEvents() {
+	s.domainEvents = make([]event.DomainEvent, 0)
+}
+
+// IsOpen checks if the sprint still accepts changes
+func (s *Sprint) IsOpen() bool {
+	return s.status != value.SprintStatusCompleted
+}
+
+// HasTask checks if a task is planned into the sprint
+func (s *Sprint) HasTask(taskID value.TaskID) bool {
+	for _, id := range s.taskIDs {
+		if id.Equals(taskID) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTask plans a task into the sprint
+func (s *Sprint) AddTask(taskID value.TaskID) error {
+	if !s.IsOpen() {
+		return errs.Conflict("cannot add task to completed sprint")
+	}
+
+	if s.HasTask(taskID) {
+		return errs.Conflict("task already in sprint")
+	}
+
+	s.taskIDs = append(s.taskIDs, taskID)
+	s.updatedAt = time.Now()
+
+	addedEvent := event.NewTaskAddedToSprintEvent(s.id.Value(), taskID.Value())
+	s.domainEvents = append(s.domainEvents, addedEvent)
+
+	return nil
+}
+
+// RemoveTask takes a task out of the sprint
+func (s *Sprint) RemoveTask(taskID value.TaskID) error {
+	if !s.IsOpen() {
+		return errs.Conflict("cannot remove task from completed sprint")
+	}
+
+	for i, id := range s.taskIDs {
+		if id.Equals(taskID) {
+			s.taskIDs = append(s.taskIDs[:i], s.taskIDs[i+1:]...)
+			s.updatedAt = time.Now()
+
+			removedEvent := event.NewTaskRemovedFromSprintEvent(s.id.Value(), taskID.Value())
+			s.domainEvents = append(s.domainEvents, removedEvent)
+
+			return nil
+		}
+	}
+
+	return errs.Conflict("task not in sprint")
+}
+
+// Start activates a planned sprint
+func (s *Sprint) Start() error {
+	if s.status != value.SprintStatusPlanned {
+		return errs.Conflict("only planned sprints can be started")
+	}
+
+	now := time.Now()
+	s.status = value.SprintStatusActive
+	s.startedAt = &now
+	s.updatedAt = now
+
+	startedEvent := event.NewSprintStartedEvent(s.id.Value(), s.projectID.Value())
+	s.domainEvents = append(s.domainEvents, startedEvent)
+
+	return nil
+}
+
+// Complete closes an active sprint. Tasks listed as unfinished are
+// removed from the sprint and returned to the project backlog.
+func (s *Sprint) Complete(unfinished []value.TaskID) error {
+	if s.status != value.SprintStatusActive {
+		return errs.Conflict("only active sprints can be completed")
+	}
+
+	isUnfinished := make(map[string]bool, len(unfinished))
+	for _, id := range unfinished {
+		isUnfinished[id.Value()] = true
+	}
+
+	kept := make([]value.TaskID, 0, len(s.taskIDs))
+	completedIDs := make([]string, 0, len(s.taskIDs))
+	carriedOverIDs := make([]string, 0, len(unfinished))
+	for _, id := range s.taskIDs {
+		if isUnfinished[id.Value()] {
+			carriedOverIDs = append(carriedOverIDs, id.Value())
+			continue
+		}
+		kept = append(kept, id)
+		completedIDs = append(completedIDs, id.Value())
+	}
+
+	now := time.Now()
+	s.taskIDs = kept
+	s.status = value.SprintStatusCompleted
+	s.completedAt = &now
+	s.updatedAt = now
+
+	completedEvent := event.NewSprintCompletedEvent(s.id.Value(), s.projectID.Value(), completedIDs, carriedOverIDs)
+	s.domainEvents = append(s.domainEvents, completedEvent)
+
+	return nil
+}
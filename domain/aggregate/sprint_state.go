@@ -0,0 +1,83 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SprintState is the memento of a Sprint: its full state in plain fields, for storage outside memory.
+// Uncommitted domain events are not part of the state.
+type SprintState struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"project_id"`
+	Name        string     `json:"name"`
+	Goal        string     `json:"goal"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	Status      string     `json:"status"`
+	TaskIDs     []string   `json:"task_ids"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ToState captures the sprint's state
+func (s *Sprint) ToState() SprintState {
+	return SprintState{
+		ID:          s.id.Value(),
+		ProjectID:   s.projectID.Value(),
+		Name:        s.name,
+		Goal:        s.goal,
+		StartDate:   s.startDate,
+		EndDate:     s.endDate,
+		Status:      s.status.Value(),
+		TaskIDs:     taskIDValues(s.taskIDs),
+		StartedAt:   s.startedAt,
+		CompletedAt: s.completedAt,
+		CreatedAt:   s.createdAt,
+		UpdatedAt:   s.updatedAt,
+	}
+}
+
+// SprintFromState rebuilds a Sprint from its state without raising domain events
+func SprintFromState(state SprintState) (*Sprint, error) {
+	id, err := value.NewSprintID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sprint id: %w", err)
+	}
+
+	projectID, err := value.NewProjectID(state.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project id: %w", err)
+	}
+
+	status, err := value.NewSprintStatus(state.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	taskIDs, err := parseTaskIDs(state.TaskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sprint{
+		id:           id,
+		projectID:    projectID,
+		name:         state.Name,
+		goal:         state.Goal,
+		startDate:    state.StartDate,
+		endDate:      state.EndDate,
+		status:       status,
+		taskIDs:      taskIDs,
+		startedAt:    state.StartedAt,
+		completedAt:  state.CompletedAt,
+		createdAt:    state.CreatedAt,
+		updatedAt:    state.UpdatedAt,
+		domainEvents: make([]event.DomainEvent, 0),
+	}, nil
+}
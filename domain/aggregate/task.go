@@ -2,27 +2,50 @@ package aggregate
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
+// How an assignee acknowledged a task assigned to them
+const (
+	AcknowledgementExplicit = "EXPLICIT" // through the acknowledge command
+	AcknowledgementOnView   = "VIEW"     // by opening the task for the first time
+)
+
 // Task is the aggregate root for the Task aggregate
 type Task struct {
 	id          value.TaskID
 	projectID   value.ProjectID
 	title       string
 	description string
-	status      value.TaskStatus
+	status      value.ScopedStatus // the status in the task's workflow, or in the default workflow when moved by the regular rules
 	priority    value.Priority
 	assignee    *entity.Assignment
+	teamID      *value.TeamID
 	deadline    *value.Deadline
+	deadlineChanges []value.DeadlineChange
+	overdueRemindedAt *time.Time // when the task was last reported overdue for its current deadline
+	estimatedHours float64
 	comments    []*entity.Comment
+	attachments []*entity.Attachment
+	links       []*entity.TaskLink
+	voterIDs    []value.UserID
+	approvals   []value.Approval // reviewer decisions in the current status, one per reviewer
+	workflowVersion *value.WorkflowVersion
+	frozen      bool
+	editLock    *value.EditLock
+	costEntries []*entity.CostEntry
 	createdAt   time.Time
 	updatedAt   time.Time
+	completedAt *time.Time
+	deletedAt   *time.Time
 	createdBy   value.UserID
+	version     int // revision the task was last stored as, checked by repository updates
 	domainEvents []event.DomainEvent
 }
 
@@ -35,11 +58,11 @@ func NewTask(
 	createdBy value.UserID,
 ) (*Task, error) {
 	if title == "" {
-		return nil, fmt.Errorf("task title cannot be empty")
+		return nil, errs.Invalid("task title cannot be empty")
 	}
 
 	if !priority.IsValid() {
-		return nil, fmt.Errorf("invalid priority")
+		return nil, errs.Invalid("invalid priority")
 	}
 
 	task := &Task{
@@ -47,9 +70,11 @@ func NewTask(
 		projectID:    projectID,
 		title:        title,
 		description:  description,
-		status:       value.TaskStatusToDo,
+		status:       value.DefaultScopedStatus(value.TaskStatusToDo),
 		priority:     priority,
 		comments:     make([]*entity.Comment, 0),
+		links:        make([]*entity.TaskLink, 0),
+		voterIDs:     make([]value.UserID, 0),
 		createdAt:    time.Now(),
 		updatedAt:    time.Now(),
 		createdBy:    createdBy,
@@ -90,11 +115,33 @@ func (t *Task) Description() string {
 	return t.description
 }
 
-// Status returns the task status
+// Status returns the lifecycle stage of the task's status
 func (t *Task) Status() value.TaskStatus {
+	return t.status.Stage()
+}
+
+// WorkflowStatus returns the task's status as its workflow names it, the same status of
+// the default workflow when the task was moved by the regular rules
+func (t *Task) WorkflowStatus() value.ScopedStatus {
 	return t.status
 }
 
+// StatusIn returns the name of the task's status in a workflow: the workflow status the task
+// is in, or the lifecycle stage of its status when the status belongs to another workflow or
+// the workflow no longer has it
+func (t *Task) StatusIn(workflow *Workflow) string {
+	if t.status.WorkflowID().Equals(workflow.ID()) && workflow.Covers(t.status.Name()) {
+		return t.status.Name()
+	}
+	return t.status.Stage().Value()
+}
+
+// InStatus reports whether the task's status has the given name. Names match regardless of
+// case, spaces or hyphens, so "In Progress" matches a task in IN_PROGRESS.
+func (t *Task) InStatus(statusName string) bool {
+	return canonicalStatusName(t.status.Name()) == canonicalStatusName(statusName)
+}
+
 // Priority returns the task priority
 func (t *Task) Priority() value.Priority {
 	return t.priority
@@ -105,16 +152,104 @@ func (t *Task) Assignee() *entity.Assignment {
 	return t.assignee
 }
 
+// TeamID returns the team the task is assigned to, nil when it has none
+func (t *Task) TeamID() *value.TeamID {
+	return t.teamID
+}
+
 // Deadline returns the deadline if any
 func (t *Task) Deadline() *value.Deadline {
 	return t.deadline
 }
 
+// OverdueRemindedAt returns when the task was last reported overdue for its current deadline
+func (t *Task) OverdueRemindedAt() *time.Time {
+	return t.overdueRemindedAt
+}
+
+// EstimatedHours returns the estimated effort in hours (zero when not estimated)
+func (t *Task) EstimatedHours() float64 {
+	return t.estimatedHours
+}
+
 // Comments returns all comments
 func (t *Task) Comments() []*entity.Comment {
 	return append([]*entity.Comment{}, t.comments...)
 }
 
+// Attachments returns the metadata of all attached files
+func (t *Task) Attachments() []*entity.Attachment {
+	return append([]*entity.Attachment{}, t.attachments...)
+}
+
+// Links returns all links to other tasks
+func (t *Task) Links() []*entity.TaskLink {
+	return append([]*entity.TaskLink{}, t.links...)
+}
+
+// VoterIDs returns the IDs of users who voted for the task
+func (t *Task) VoterIDs() []value.UserID {
+	return append([]value.UserID{}, t.voterIDs...)
+}
+
+// VoteCount returns the number of votes
+func (t *Task) VoteCount() int {
+	return len(t.voterIDs)
+}
+
+// HasVoted checks if a user has voted for the task
+func (t *Task) HasVoted(userID value.UserID) bool {
+	for _, voterID := range t.voterIDs {
+		if voterID.Equals(userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Approvals returns the reviewer decisions on the task in its current status
+func (t *Task) Approvals() []value.Approval {
+	return append([]value.Approval{}, t.approvals...)
+}
+
+// ApproverIDs returns the users who approved the task in its current status
+func (t *Task) ApproverIDs() []value.UserID {
+	approverIDs := make([]value.UserID, 0, len(t.approvals))
+	for _, approval := range t.approvals {
+		if approval.IsApproved() {
+			approverIDs = append(approverIDs, approval.ReviewerID())
+		}
+	}
+	return approverIDs
+}
+
+// ApprovalCount returns the number of approvals in the task's current status
+func (t *Task) ApprovalCount() int {
+	return len(t.ApproverIDs())
+}
+
+// Rejection returns the latest standing rejection of the task in its current status, nil when there is none
+func (t *Task) Rejection() *value.Approval {
+	var rejection *value.Approval
+	for i := range t.approvals {
+		if !t.approvals[i].IsApproved() && (rejection == nil || t.approvals[i].DecidedAt().After(rejection.DecidedAt())) {
+			rejection = &t.approvals[i]
+		}
+	}
+	return rejection
+}
+
+// WorkflowVersion returns the workflow version the task is pinned to, nil when it
+// follows the latest version of its project's workflow
+func (t *Task) WorkflowVersion() *value.WorkflowVersion {
+	return t.workflowVersion
+}
+
+// PinWorkflowVersion pins the task to the workflow version it starts under
+func (t *Task) PinWorkflowVersion(version value.WorkflowVersion) {
+	t.workflowVersion = &version
+}
+
 // CreatedAt returns when the task was created
 func (t *Task) CreatedAt() time.Time {
 	return t.createdAt
@@ -125,11 +260,116 @@ func (t *Task) UpdatedAt() time.Time {
 	return t.updatedAt
 }
 
+// CompletedAt returns when the task was completed, if it has been
+func (t *Task) CompletedAt() *time.Time {
+	return t.completedAt
+}
+
+// FirstResponseAt returns when the first comment by someone other than the creator was made
+func (t *Task) FirstResponseAt() *time.Time {
+	var first *time.Time
+	for _, comment := range t.comments {
+		if comment.AuthorID().Equals(t.createdBy) {
+			continue
+		}
+		createdAt := comment.CreatedAt()
+		if first == nil || createdAt.Before(*first) {
+			first = &createdAt
+		}
+	}
+	return first
+}
+
+// EditLock returns the edit lock on the description, which may have expired
+func (t *Task) EditLock() *value.EditLock {
+	return t.editLock
+}
+
+// ActiveEditLock returns the edit lock on the description if it has not expired
+func (t *Task) ActiveEditLock(now time.Time) *value.EditLock {
+	if t.editLock == nil || t.editLock.IsExpired(now) {
+		return nil
+	}
+	return t.editLock
+}
+
+// CostEntries returns the money recorded as spent on the task
+func (t *Task) CostEntries() []*entity.CostEntry {
+	return append([]*entity.CostEntry{}, t.costEntries...)
+}
+
+// TotalCost sums the task's cost entries in a currency
+func (t *Task) TotalCost(currency string) (value.Money, error) {
+	total := value.ZeroMoney(currency)
+	for _, entry := range t.costEntries {
+		var err error
+		total, err = total.Add(entry.Amount())
+		if err != nil {
+			return value.Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// AddCostEntry records money spent on the task
+func (t *Task) AddCostEntry(entry *entity.CostEntry) error {
+	if !entry.TaskID().Equals(t.id) {
+		return errs.Conflict("cost entry belongs to another task")
+	}
+
+	t.costEntries = append(t.costEntries, entry)
+	t.updatedAt = time.Now()
+
+	// Raise domain event
+	costEvent := event.NewTaskCostRecordedEvent(
+		t.id.Value(),
+		t.projectID.Value(),
+		entry.ID(),
+		entry.Amount().Amount(),
+		entry.Amount().Currency(),
+		entry.Description(),
+		entry.RecordedBy().Value(),
+	)
+	t.domainEvents = append(t.domainEvents, costEvent)
+
+	return nil
+}
+
 // CreatedBy returns who created the task
 func (t *Task) CreatedBy() value.UserID {
 	return t.createdBy
 }
 
+// DeletedAt returns when the task was deleted, nil while it is not
+func (t *Task) DeletedAt() *time.Time {
+	return t.deletedAt
+}
+
+// IsDeleted returns whether the task has been deleted
+func (t *Task) IsDeleted() bool {
+	return t.deletedAt != nil
+}
+
+// IsFrozen returns whether the task is frozen by an archived project
+func (t *Task) IsFrozen() bool {
+	return t.frozen
+}
+
+// IsOpen checks if the task is neither completed nor cancelled
+func (t *Task) IsOpen() bool {
+	return t.Status() != value.TaskStatusCompleted && t.Status() != value.TaskStatusCancelled
+}
+
+// Version returns the revision the task was last stored as, 0 before it is first stored
+func (t *Task) Version() int {
+	return t.version
+}
+
+// SetVersion records the revision a repository stored the task as
+func (t *Task) SetVersion(version int) {
+	t.version = version
+}
+
 // DomainEvents returns all uncommitted domain events
 func (t *Task) DomainEvents() []event.DomainEvent {
 	return append([]event.DomainEvent{}, t.domainEvents...)
@@ -142,6 +382,10 @@ func (t *Task) ClearDomainEvents() {
 
 // Assign assigns the task to a user
 func (t *Task) Assign(assigneeID value.UserID, assignedBy value.UserID) error {
+	if t.frozen {
+		return errs.Conflict("cannot assign a frozen task")
+	}
+
 	previousAssigneeID := ""
 	if t.assignee != nil {
 		previousAssigneeID = t.assignee.AssigneeID().Value()
@@ -166,33 +410,189 @@ func (t *Task) Assign(assigneeID value.UserID, assignedBy value.UserID) error {
 	return nil
 }
 
+// Unassign takes the task away from its assignee, leaving it for someone to pick up
+func (t *Task) Unassign(unassignedBy value.UserID) error {
+	if t.frozen {
+		return errs.Conflict("cannot unassign a frozen task")
+	}
+
+	if t.assignee == nil {
+		return errs.Conflict("task is not assigned")
+	}
+
+	previousAssigneeID := t.assignee.AssigneeID().Value()
+	t.assignee = nil
+	t.updatedAt = time.Now()
+
+	// Raise domain event
+	unassignedEvent := event.NewTaskUnassignedEvent(
+		t.id.Value(),
+		previousAssigneeID,
+		unassignedBy.Value(),
+	)
+	t.domainEvents = append(t.domainEvents, unassignedEvent)
+
+	return nil
+}
+
+// AcknowledgeAssignment records that the assignee has seen the task assigned to them.
+// The source is AcknowledgementExplicit or AcknowledgementOnView.
+func (t *Task) AcknowledgeAssignment(userID value.UserID, source string) error {
+	if t.assignee == nil {
+		return errs.Conflict("task is not assigned")
+	}
+
+	if !t.assignee.IsAssignedTo(userID) {
+		return errs.PermissionDenied("permission denied: only the assignee can acknowledge the task")
+	}
+
+	if err := t.assignee.Acknowledge(time.Now()); err != nil {
+		return err
+	}
+
+	// Raise domain event
+	acknowledgedEvent := event.NewTaskAcknowledgedEvent(
+		t.id.Value(),
+		userID.Value(),
+		source,
+	)
+	t.domainEvents = append(t.domainEvents, acknowledgedEvent)
+
+	return nil
+}
+
+// EscalateUnacknowledgedAssignment escalates the assignment once it has gone unacknowledged
+// for longer than the window, reporting whether it did. Each assignment is escalated once.
+func (t *Task) EscalateUnacknowledgedAssignment(now time.Time, window time.Duration) bool {
+	if t.assignee == nil || !t.IsOpen() || t.assignee.IsAcknowledged() || t.assignee.EscalatedAt() != nil {
+		return false
+	}
+
+	waiting := now.Sub(t.assignee.AssignedAt())
+	if waiting < window {
+		return false
+	}
+
+	t.assignee.MarkEscalated(now)
+
+	// Raise domain event
+	escalatedEvent := event.NewTaskAssignmentEscalatedEvent(
+		t.id.Value(),
+		t.assignee.AssigneeID().Value(),
+		t.assignee.AssignedBy().Value(),
+		int(waiting.Hours()),
+	)
+	t.domainEvents = append(t.domainEvents, escalatedEvent)
+
+	return true
+}
+
+// AssignToTeam hands the task to a team. An individual assignee, if any, keeps working on it.
+func (t *Task) AssignToTeam(teamID value.TeamID, assignedBy value.UserID) error {
+	if t.frozen {
+		return errs.Conflict("cannot assign a frozen task")
+	}
+
+	previousTeamID := ""
+	if t.teamID != nil {
+		if t.teamID.Equals(teamID) {
+			return errs.Conflict("task is already assigned to the team")
+		}
+		previousTeamID = t.teamID.Value()
+	}
+
+	t.teamID = &teamID
+	t.updatedAt = time.Now()
+
+	// Raise domain event
+	assignedEvent := event.NewTaskAssignedToTeamEvent(
+		t.id.Value(),
+		teamID.Value(),
+		previousTeamID,
+		assignedBy.Value(),
+	)
+	t.domainEvents = append(t.domainEvents, assignedEvent)
+
+	return nil
+}
+
 // ChangeStatus changes the task status with validation
 func (t *Task) ChangeStatus(newStatus value.TaskStatus) error {
+	return t.ChangeStatusWithReason(newStatus, "", nil)
+}
+
+// ChangeStatusWithReason changes the task status with validation,
+// recording why and any metadata on the status change event
+func (t *Task) ChangeStatusWithReason(newStatus value.TaskStatus, reason string, metadata map[string]string) error {
+	if t.frozen {
+		return errs.Conflict("cannot change status of a frozen task")
+	}
+
 	if !newStatus.IsValid() {
-		return fmt.Errorf("invalid status: %s", newStatus.Value())
+		return errs.Invalid("invalid status: %s", newStatus.Value())
+	}
+
+	if !t.Status().CanTransitionTo(newStatus) {
+		return errs.InvalidTransition("cannot transition from %s to %s", t.Status().Value(), newStatus.Value())
 	}
 
-	if !t.status.CanTransitionTo(newStatus) {
-		return fmt.Errorf("cannot transition from %s to %s", t.status.Value(), newStatus.Value())
+	return t.changeStatus(value.DefaultScopedStatus(newStatus), reason, metadata)
+}
+
+// ChangeStatusInWorkflow moves the task to a status of the project's workflow, by the moves
+// the workflow allows in place of the regular task status rules. The task's lifecycle stage
+// becomes the one the workflow status stands for.
+func (t *Task) ChangeStatusInWorkflow(workflow *Workflow, newStatus value.ScopedStatus, reason string, metadata map[string]string) error {
+	if t.frozen {
+		return errs.Conflict("cannot change status of a frozen task")
 	}
 
+	current := t.StatusIn(workflow)
+	if !newStatus.WorkflowID().Equals(workflow.ID()) || !workflow.Covers(newStatus.Name()) {
+		return errs.InvalidTransition("cannot transition from %s to %s: workflow %s has no status %s",
+			current, newStatus.Name(), workflow.Name(), newStatus.Name())
+	}
+	if !workflow.AllowsTransition(current, newStatus.Name()) {
+		return errs.InvalidTransition("cannot transition from %s to %s in workflow %s",
+			current, newStatus.Name(), workflow.Name())
+	}
+
+	return t.changeStatus(newStatus, reason, metadata)
+}
+
+// changeStatus moves the task to a validated status
+func (t *Task) changeStatus(newStatus value.ScopedStatus, reason string, metadata map[string]string) error {
 	oldStatus := t.status
 	t.status = newStatus
+	t.approvals = nil // approvals count towards leaving a status, not the next one
 	t.updatedAt = time.Now()
 
 	// Raise domain event
-	statusChangedEvent := event.NewTaskStatusChangedEvent(
-		t.id.Value(),
-		oldStatus.Value(),
-		newStatus.Value(),
-	)
+	var recorded map[string]string
+	if len(metadata) > 0 {
+		recorded = make(map[string]string, len(metadata))
+		for key, val := range metadata {
+			recorded[key] = val
+		}
+	}
+
+	statusChangedEvent := newStatusChangedEvent(t.id, oldStatus, newStatus, strings.TrimSpace(reason), recorded)
 	t.domainEvents = append(t.domainEvents, statusChangedEvent)
 
-	// If completed, raise completion event
-	if newStatus == value.TaskStatusCompleted {
+	// If completed, record completion time and raise completion event
+	if newStatus.Stage() == value.TaskStatusCompleted && oldStatus.Stage() != value.TaskStatusCompleted {
+		completedAt := t.updatedAt
+		t.completedAt = &completedAt
+
+		// Workflows may allow completing a task that was never assigned
+		completedBy := ""
+		if t.assignee != nil {
+			completedBy = t.assignee.AssigneeID().Value()
+		}
+
 		completedEvent := event.NewTaskCompletedEvent(
 			t.id.Value(),
-			t.assignee.AssigneeID().Value(),
+			completedBy,
 			time.Now().Format(time.RFC3339),
 		)
 		t.domainEvents = append(t.domainEvents, completedEvent)
@@ -201,37 +601,370 @@ func (t *Task) ChangeStatus(newStatus value.TaskStatus) error {
 	return nil
 }
 
-// SetDeadline sets the deadline for the task
-func (t *Task) SetDeadline(deadline value.Deadline) error {
+// SetDeadline sets or changes the deadline for the task, recording who changed it and why
+func (t *Task) SetDeadline(deadline value.Deadline, changedBy value.UserID, reason string) error {
+	var previous *time.Time
+	if t.deadline != nil {
+		dueDate := t.deadline.Value()
+		previous = &dueDate
+	}
+
+	change, err := value.NewDeadlineChange(previous, deadline.Value(), changedBy, reason, time.Now())
+	if err != nil {
+		return err
+	}
+
 	t.deadline = &deadline
-	t.updatedAt = time.Now()
+	t.deadlineChanges = append(t.deadlineChanges, change)
+	t.overdueRemindedAt = nil // a new deadline is reminded of afresh
+	t.updatedAt = change.ChangedAt()
 
 	// Raise domain event
+	previousDueDate := ""
+	if previous != nil {
+		previousDueDate = previous.Format(time.RFC3339)
+	}
 	deadlineEvent := event.NewTaskDeadlineSetEvent(
 		t.id.Value(),
 		deadline.Value().Format(time.RFC3339),
+		previousDueDate,
+		changedBy.Value(),
+		change.Reason(),
+		change.IsSlip(),
 	)
 	t.domainEvents = append(t.domainEvents, deadlineEvent)
 
 	return nil
 }
 
+// DeadlineChanges returns every change of the task's deadline, oldest first
+func (t *Task) DeadlineChanges() []value.DeadlineChange {
+	return append([]value.DeadlineChange{}, t.deadlineChanges...)
+}
+
+// DeadlineSlipCount returns how many times the task's deadline was pushed later
+func (t *Task) DeadlineSlipCount() int {
+	slips := 0
+	for _, change := range t.deadlineChanges {
+		if change.IsSlip() {
+			slips++
+		}
+	}
+	return slips
+}
+
 // AddComment adds a comment to the task
 func (t *Task) AddComment(comment *entity.Comment) error {
 	if comment == nil {
-		return fmt.Errorf("comment cannot be nil")
+		return errs.Invalid("comment cannot be nil")
 	}
 
 	t.comments = append(t.comments, comment)
 	t.updatedAt = time.Now()
 
+	// Raise domain event
+	commentEvent := event.NewTaskCommentAddedEvent(
+		t.id.Value(),
+		comment.ID(),
+		comment.AuthorID().Value(),
+	)
+	t.domainEvents = append(t.domainEvents, commentEvent)
+
 	return nil
 }
 
+// AddAttachment attaches a file to the task
+func (t *Task) AddAttachment(attachment *entity.Attachment) error {
+	if attachment == nil {
+		return errs.Invalid("attachment cannot be nil")
+	}
+
+	if !attachment.TaskID().Equals(t.id) {
+		return errs.Conflict("attachment belongs to another task")
+	}
+
+	t.attachments = append(t.attachments, attachment)
+	t.updatedAt = time.Now()
+
+	// Raise domain event
+	attachmentEvent := event.NewTaskAttachmentAddedEvent(
+		t.id.Value(),
+		attachment.ID(),
+		attachment.FileName(),
+		attachment.ContentType(),
+		attachment.SizeBytes(),
+		attachment.UploadedBy().Value(),
+	)
+	t.domainEvents = append(t.domainEvents, attachmentEvent)
+
+	return nil
+}
+
+// MarkDeleted records that the task is being deleted
+func (t *Task) MarkDeleted() {
+	now := time.Now()
+	t.deletedAt = &now
+	t.updatedAt = now
+
+	// Raise domain event
+	deletedEvent := event.NewTaskDeletedEvent(t.id.Value(), t.projectID.Value())
+	t.domainEvents = append(t.domainEvents, deletedEvent)
+}
+
+// HasLink checks if the task is linked to another task with a specific type
+func (t *Task) HasLink(targetTaskID value.TaskID, linkType value.LinkType) bool {
+	for _, existing := range t.links {
+		if existing.Matches(targetTaskID, linkType) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddLink links the task to another task
+func (t *Task) AddLink(targetTaskID value.TaskID, linkType value.LinkType, createdBy value.UserID) error {
+	if targetTaskID.Equals(t.id) {
+		return errs.Invalid("task cannot be linked to itself")
+	}
+
+	if t.HasLink(targetTaskID, linkType) {
+		return errs.Conflict("task link already exists")
+	}
+
+	link, err := entity.NewTaskLink(targetTaskID, linkType, createdBy)
+	if err != nil {
+		return err
+	}
+
+	t.links = append(t.links, link)
+	t.updatedAt = time.Now()
+
+	// Raise domain event
+	linkedEvent := event.NewTaskLinkedEvent(
+		t.id.Value(),
+		targetTaskID.Value(),
+		linkType.Value(),
+	)
+	t.domainEvents = append(t.domainEvents, linkedEvent)
+
+	return nil
+}
+
+// RemoveLink removes a link to another task
+func (t *Task) RemoveLink(targetTaskID value.TaskID, linkType value.LinkType) error {
+	for i, existing := range t.links {
+		if existing.Matches(targetTaskID, linkType) {
+			t.links = append(t.links[:i], t.links[i+1:]...)
+			t.updatedAt = time.Now()
+
+			// Raise domain event
+			unlinkedEvent := event.NewTaskUnlinkedEvent(
+				t.id.Value(),
+				targetTaskID.Value(),
+				linkType.Value(),
+			)
+			t.domainEvents = append(t.domainEvents, unlinkedEvent)
+
+			return nil
+		}
+	}
+
+	return errs.NotFound("task link not found")
+}
+
+// SetEstimate sets the estimated effort in hours
+func (t *Task) SetEstimate(hours float64) error {
+	if hours < 0 {
+		return errs.Invalid("estimate cannot be negative")
+	}
+
+	t.estimatedHours = hours
+	t.updatedAt = time.Now()
+
+	return nil
+}
+
+// Vote records a user's vote for the task (one vote per user)
+func (t *Task) Vote(voterID value.UserID) error {
+	if voterID.Equals(value.UserID{}) {
+		return errs.Invalid("voter cannot be empty")
+	}
+
+	if t.Status() == value.TaskStatusCompleted || t.Status() == value.TaskStatusCancelled {
+		return errs.Conflict("cannot vote for completed or cancelled tasks")
+	}
+
+	if t.HasVoted(voterID) {
+		return errs.Conflict("user has already voted for this task")
+	}
+
+	t.voterIDs = append(t.voterIDs, voterID)
+	t.updatedAt = time.Now()
+
+	// Raise domain event
+	votedEvent := event.NewTaskVotedEvent(t.id.Value(), voterID.Value(), len(t.voterIDs))
+	t.domainEvents = append(t.domainEvents, votedEvent)
+
+	return nil
+}
+
+// Approve records a user's approval of the task in its current status,
+// replacing an earlier rejection by the same user
+func (t *Task) Approve(approverID value.UserID) error {
+	if approverID.Equals(value.UserID{}) {
+		return errs.Invalid("approver cannot be empty")
+	}
+
+	if err := t.recordDecision(approverID, value.ApprovalDecisionApproved, ""); err != nil {
+		return err
+	}
+
+	// Raise domain event
+	approvedEvent := event.NewTaskApprovedEvent(t.id.Value(), approverID.Value(), t.Status().Value(), t.ApprovalCount())
+	t.domainEvents = append(t.domainEvents, approvedEvent)
+
+	return nil
+}
+
+// Reject records a user's rejection of the task in its current status, replacing an
+// earlier approval by the same user. A standing rejection keeps the task from moving on
+// past an approval step.
+func (t *Task) Reject(reviewerID value.UserID, reason string) error {
+	if reviewerID.Equals(value.UserID{}) {
+		return errs.Invalid("reviewer cannot be empty")
+	}
+
+	if err := t.recordDecision(reviewerID, value.ApprovalDecisionRejected, reason); err != nil {
+		return err
+	}
+
+	// Raise domain event
+	rejection := t.Rejection()
+	rejectedEvent := event.NewTaskRejectedEvent(t.id.Value(), reviewerID.Value(), t.Status().Value(), rejection.Reason())
+	t.domainEvents = append(t.domainEvents, rejectedEvent)
+
+	return nil
+}
+
+// recordDecision records a reviewer's decision, one per reviewer in the current status
+func (t *Task) recordDecision(reviewerID value.UserID, decision value.ApprovalDecision, reason string) error {
+	if t.Status() == value.TaskStatusCompleted || t.Status() == value.TaskStatusCancelled {
+		return errs.Conflict("cannot review completed or cancelled tasks")
+	}
+
+	if t.assignee != nil && t.assignee.AssigneeID().Equals(reviewerID) {
+		return errs.Conflict("the assignee cannot review their own task")
+	}
+
+	approval, err := value.NewApproval(reviewerID, decision, reason, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i, existing := range t.approvals {
+		if !existing.ReviewerID().Equals(reviewerID) {
+			continue
+		}
+		if existing.Decision() == decision {
+			return errs.Conflict("user has already %s this task", strings.ToLower(string(decision)))
+		}
+		t.approvals = append(t.approvals[:i], t.approvals[i+1:]...)
+		break
+	}
+
+	t.approvals = append(t.approvals, approval)
+	t.updatedAt = time.Now()
+
+	return nil
+}
+
+// MigrateWorkflow moves the task onto another workflow version, into the status the
+// migration maps its current status to. Status rules do not apply, the old status may
+// not exist in the new version.
+func (t *Task) MigrateWorkflow(version value.WorkflowVersion, newStatus value.ScopedStatus) error {
+	if !newStatus.Stage().IsValid() {
+		return errs.Invalid("invalid status: %s", newStatus)
+	}
+
+	renamed := !t.InStatus(newStatus.Name())
+	if t.frozen && renamed {
+		return errs.Conflict("cannot change status of a frozen task")
+	}
+
+	from := ""
+	if t.workflowVersion != nil {
+		from = t.workflowVersion.String()
+	}
+	oldStatus := t.status
+
+	t.workflowVersion = &version
+	t.status = newStatus
+	t.updatedAt = time.Now()
+
+	// Raise domain event
+	migratedEvent := event.NewTaskWorkflowMigratedEvent(t.id.Value(), t.projectID.Value(), from, version.String(), oldStatus.Stage().Value(), newStatus.Stage().Value())
+	t.domainEvents = append(t.domainEvents, migratedEvent)
+
+	// A status of the same name in the new workflow is the same status
+	if !renamed {
+		return nil
+	}
+
+	t.approvals = nil
+
+	statusChangedEvent := newStatusChangedEvent(t.id, oldStatus, newStatus, fmt.Sprintf("Migrated to workflow version %d", version.Number()), nil)
+	t.domainEvents = append(t.domainEvents, statusChangedEvent)
+
+	if newStatus.Stage() == value.TaskStatusCompleted && t.completedAt == nil {
+		completedAt := t.updatedAt
+		t.completedAt = &completedAt
+	}
+
+	return nil
+}
+
+// newStatusChangedEvent records a move between two statuses, by their lifecycle stages and,
+// for statuses of a workflow other than the default one, their names
+func newStatusChangedEvent(taskID value.TaskID, oldStatus, newStatus value.ScopedStatus, reason string, metadata map[string]string) event.TaskStatusChangedEvent {
+	statusChangedEvent := event.NewTaskStatusChangedEvent(taskID.Value(), oldStatus.Stage().Value(), newStatus.Stage().Value(), reason, metadata)
+	if !oldStatus.IsDefault() {
+		statusChangedEvent.OldWorkflowStatus = oldStatus.Name()
+	}
+	if !newStatus.IsDefault() {
+		statusChangedEvent.NewWorkflowStatus = newStatus.Name()
+	}
+	return statusChangedEvent
+}
+
+// RequestNotification asks for a message about the task to be sent to a channel target
+func (t *Task) RequestNotification(channel value.NotificationChannel, target, message string) {
+	// Raise domain event
+	notificationEvent := event.NewTaskNotificationRequestedEvent(t.id.Value(), t.projectID.Value(), channel.Value(), target, message)
+	t.domainEvents = append(t.domainEvents, notificationEvent)
+}
+
+// RemoveVote withdraws a user's vote for the task
+func (t *Task) RemoveVote(voterID value.UserID) error {
+	for i, existing := range t.voterIDs {
+		if existing.Equals(voterID) {
+			t.voterIDs = append(t.voterIDs[:i], t.voterIDs[i+1:]...)
+			t.updatedAt = time.Now()
+
+			// Raise domain event
+			removedEvent := event.NewTaskVoteRemovedEvent(t.id.Value(), voterID.Value(), len(t.voterIDs))
+			t.domainEvents = append(t.domainEvents, removedEvent)
+
+			return nil
+		}
+	}
+
+	return errs.Conflict("user has not voted for this task")
+}
+
 // UpdateTitle updates the task title
 func (t *Task) UpdateTitle(newTitle string) error {
 	if newTitle == "" {
-		return fmt.Errorf("title cannot be empty")
+		return errs.Invalid("title cannot be empty")
 	}
 
 	t.title = newTitle
@@ -248,10 +981,100 @@ func (t *Task) UpdateDescription(newDescription string) error {
 	return nil
 }
 
+// EditDescription updates the description on behalf of an editor, refusing while
+// another user holds an unexpired edit lock so concurrent edits are not overwritten
+func (t *Task) EditDescription(editorID value.UserID, newDescription string, now time.Time) error {
+	if lock := t.ActiveEditLock(now); lock != nil && !lock.IsHeldBy(editorID) {
+		return errs.ConcurrencyConflict("description is locked by %s until %s", lock.HolderID().Value(), lock.ExpiresAt().Format(time.RFC3339))
+	}
+
+	return t.UpdateDescription(newDescription)
+}
+
+// AcquireEditLock takes the edit lock on the description, or extends it if the user already holds it
+func (t *Task) AcquireEditLock(userID value.UserID, now time.Time, ttl time.Duration) error {
+	lock := t.ActiveEditLock(now)
+	if lock != nil && !lock.IsHeldBy(userID) {
+		return errs.ConcurrencyConflict("description is locked by %s until %s", lock.HolderID().Value(), lock.ExpiresAt().Format(time.RFC3339))
+	}
+
+	var acquired value.EditLock
+	var err error
+	if lock != nil {
+		acquired, err = lock.Renew(now, ttl)
+	} else {
+		acquired, err = value.NewEditLock(userID, now, ttl)
+	}
+	if err != nil {
+		return err
+	}
+
+	t.editLock = &acquired
+
+	// Raise domain event
+	lockEvent := event.NewTaskEditLockAcquiredEvent(
+		t.id.Value(),
+		userID.Value(),
+		acquired.AcquiredAt().Format(time.RFC3339),
+		acquired.ExpiresAt().Format(time.RFC3339),
+		lock != nil,
+	)
+	t.domainEvents = append(t.domainEvents, lockEvent)
+
+	return nil
+}
+
+// RenewEditLock extends an edit lock the user still holds
+func (t *Task) RenewEditLock(userID value.UserID, now time.Time, ttl time.Duration) error {
+	lock := t.ActiveEditLock(now)
+	if lock == nil || !lock.IsHeldBy(userID) {
+		return errs.ConcurrencyConflict("edit lock is not held by user")
+	}
+
+	renewed, err := lock.Renew(now, ttl)
+	if err != nil {
+		return err
+	}
+
+	t.editLock = &renewed
+
+	// Raise domain event
+	lockEvent := event.NewTaskEditLockAcquiredEvent(
+		t.id.Value(),
+		userID.Value(),
+		renewed.AcquiredAt().Format(time.RFC3339),
+		renewed.ExpiresAt().Format(time.RFC3339),
+		true,
+	)
+	t.domainEvents = append(t.domainEvents, lockEvent)
+
+	return nil
+}
+
+// ReleaseEditLock gives up the user's edit lock, an expired lock of the user is cleared silently
+func (t *Task) ReleaseEditLock(userID value.UserID, now time.Time) error {
+	if t.editLock == nil || !t.editLock.IsHeldBy(userID) {
+		return errs.ConcurrencyConflict("edit lock is not held by user")
+	}
+
+	expired := t.editLock.IsExpired(now)
+	t.editLock = nil
+
+	if expired {
+		return nil
+	}
+
+	// Raise domain event
+	releasedEvent := event.NewTaskEditLockReleasedEvent(t.id.Value(), userID.Value())
+	t.domainEvents = append(t.domainEvents, releasedEvent)
+
+	return nil
+}
+
 // UpdatePriority updates the task priority
 func (t *Task) UpdatePriority(newPriority value.Priority) error {
 	if !newPriority.IsValid() {
-		return fmt.Errorf("invalid priority")
+		return errs.Invalid("invalid priority")
 	}
 
 	t.priority = newPriority
@@ -260,21 +1083,44 @@ func (t *Task) UpdatePriority(newPriority value.Priority) error {
 	return nil
 }
 
-// CheckDeadlineStatus checks and updates the deadline status
-func (t *Task) CheckDeadlineStatus() {
+// CheckDeadlineStatus reports an open task past its deadline as overdue, unless it was
+// reported less than reminderInterval ago. It returns whether the task was reported.
+func (t *Task) CheckDeadlineStatus(reminderInterval time.Duration) bool {
 	if t.deadline == nil {
-		return
+		return false
+	}
+
+	if !t.deadline.IsOverdue() || t.Status() == value.TaskStatusCompleted || t.Status() == value.TaskStatusCancelled {
+		return false
 	}
 
-	if t.deadline.IsOverdue() && t.status != value.TaskStatusCompleted && t.status != value.TaskStatusCancelled {
-		daysOverdue := -t.deadline.DaysUntilDue()
-		overdueEvent := event.NewTaskOverdueEvent(t.id.Value(), daysOverdue)
-		t.domainEvents = append(t.domainEvents, overdueEvent)
+	now := value.Now()
+	if t.overdueRemindedAt != nil && now.Before(t.overdueRemindedAt.Add(reminderInterval)) {
+		return false
 	}
+	t.overdueRemindedAt = &now
+
+	daysOverdue := -t.deadline.DaysUntilDue()
+	overdueEvent := event.NewTaskOverdueEvent(t.id.Value(), daysOverdue)
+	t.domainEvents = append(t.domainEvents, overdueEvent)
+
+	return true
 }
 
 // UpdateStatus is a convenience method for status update (without validation)
 func (t *Task) UpdateStatus(newStatus value.TaskStatus) {
-	t.status = newStatus
+	t.status = value.DefaultScopedStatus(newStatus)
 	t.updatedAt = time.Now()
+}
+
+// Freeze blocks status and assignment changes, used when the project is archived
+func (t *Task) Freeze() error {
+	if t.frozen {
+		return errs.Conflict("task is already frozen")
+	}
+
+	t.frozen = true
+	t.updatedAt = time.Now()
+
+	return nil
 }
\ No newline at end of file
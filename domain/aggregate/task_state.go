@@ -0,0 +1,445 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// TaskState is the memento of a Task: its full state in plain fields, for storage outside memory.
+// Uncommitted domain events are not part of the state.
+type TaskState struct {
+	ID                string                `json:"id"`
+	ProjectID         string                `json:"project_id"`
+	Title             string                `json:"title"`
+	Description       string                `json:"description"`
+	Status            string                `json:"status"`
+	StatusWorkflowID  string                `json:"status_workflow_id,omitempty"` // workflow naming the status, empty for the default workflow
+	StatusName        string                `json:"status_name,omitempty"`        // the status as that workflow names it
+	Priority          string                `json:"priority"`
+	Assignee          *AssignmentState      `json:"assignee,omitempty"`
+	TeamID            string                `json:"team_id,omitempty"`
+	Deadline          *time.Time            `json:"deadline,omitempty"`
+	DeadlineChanges   []DeadlineChangeState `json:"deadline_changes,omitempty"`
+	OverdueRemindedAt *time.Time            `json:"overdue_reminded_at,omitempty"`
+	EstimatedHours    float64               `json:"estimated_hours"`
+	Comments          []CommentState        `json:"comments"`
+	Attachments       []AttachmentState     `json:"attachments"`
+	Links             []TaskLinkState       `json:"links"`
+	VoterIDs          []string              `json:"voter_ids"`
+	Approvals         []ApprovalState       `json:"approvals,omitempty"`
+	WorkflowID        string                `json:"workflow_id,omitempty"`      // workflow the task is pinned to
+	WorkflowVersion   int                   `json:"workflow_version,omitempty"` // version the task is pinned to
+	Frozen            bool                  `json:"frozen"`
+	EditLock          *EditLockState        `json:"edit_lock,omitempty"`
+	CostEntries       []CostEntryState      `json:"cost_entries"`
+	CreatedAt         time.Time             `json:"created_at"`
+	UpdatedAt         time.Time             `json:"updated_at"`
+	CompletedAt       *time.Time            `json:"completed_at,omitempty"`
+	DeletedAt         *time.Time            `json:"deleted_at,omitempty"`
+	CreatedBy         string                `json:"created_by"`
+	Version           int                   `json:"version"` // revision of the stored task
+}
+
+// AssignmentState is the stored form of a task's assignment
+type AssignmentState struct {
+	AssigneeID     string     `json:"assignee_id"`
+	AssignedBy     string     `json:"assigned_by"`
+	AssignedAt     time.Time  `json:"assigned_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	EscalatedAt    *time.Time `json:"escalated_at,omitempty"`
+}
+
+// DeadlineChangeState is the stored form of a deadline change
+type DeadlineChangeState struct {
+	Previous  *time.Time `json:"previous,omitempty"`
+	DueDate   time.Time  `json:"due_date"`
+	ChangedBy string     `json:"changed_by"`
+	Reason    string     `json:"reason,omitempty"`
+	ChangedAt time.Time  `json:"changed_at"`
+}
+
+// ApprovalState is the stored form of a reviewer's decision on a task
+type ApprovalState struct {
+	ReviewerID string    `json:"reviewer_id"`
+	Decision   string    `json:"decision"`
+	Reason     string    `json:"reason,omitempty"`
+	DecidedAt  time.Time `json:"decided_at"`
+}
+
+// CommentState is the stored form of a comment
+type CommentState struct {
+	ID        string    `json:"id"`
+	AuthorID  string    `json:"author_id"`
+	Content   string    `json:"content"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AttachmentState is the stored form of an attachment
+type AttachmentState struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	UploadedBy  string    `json:"uploaded_by"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// TaskLinkState is the stored form of a link to another task
+type TaskLinkState struct {
+	TargetTaskID string    `json:"target_task_id"`
+	LinkType     string    `json:"link_type"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// EditLockState is the stored form of an edit lock
+type EditLockState struct {
+	HolderID   string    `json:"holder_id"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// CostEntryState is the stored form of a cost entry
+type CostEntryState struct {
+	ID          string    `json:"id"`
+	AmountMinor int64     `json:"amount_minor"`
+	Currency    string    `json:"currency"`
+	Description string    `json:"description"`
+	RecordedBy  string    `json:"recorded_by"`
+	IncurredAt  time.Time `json:"incurred_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ToState captures the task's state
+func (t *Task) ToState() TaskState {
+	state := TaskState{
+		ID:                t.id.Value(),
+		ProjectID:         t.projectID.Value(),
+		Title:             t.title,
+		Description:       t.description,
+		Status:            t.status.Stage().Value(),
+		Priority:          t.priority.Value(),
+		OverdueRemindedAt: t.overdueRemindedAt,
+		EstimatedHours:    t.estimatedHours,
+		Comments:          make([]CommentState, 0, len(t.comments)),
+		Attachments:       make([]AttachmentState, 0, len(t.attachments)),
+		Links:             make([]TaskLinkState, 0, len(t.links)),
+		VoterIDs:          userIDValues(t.voterIDs),
+		Frozen:            t.frozen,
+		CostEntries:       make([]CostEntryState, 0, len(t.costEntries)),
+		CreatedAt:         t.createdAt,
+		UpdatedAt:         t.updatedAt,
+		CompletedAt:       t.completedAt,
+		DeletedAt:         t.deletedAt,
+		CreatedBy:         t.createdBy.Value(),
+		Version:           t.version,
+	}
+
+	if t.assignee != nil {
+		state.Assignee = &AssignmentState{
+			AssigneeID:     t.assignee.AssigneeID().Value(),
+			AssignedBy:     t.assignee.AssignedBy().Value(),
+			AssignedAt:     t.assignee.AssignedAt(),
+			AcknowledgedAt: t.assignee.AcknowledgedAt(),
+			EscalatedAt:    t.assignee.EscalatedAt(),
+		}
+	}
+
+	if t.teamID != nil {
+		state.TeamID = t.teamID.Value()
+	}
+
+	state.StatusWorkflowID, state.StatusName = scopedStatusState(t.status)
+
+	if t.workflowVersion != nil {
+		state.WorkflowID = t.workflowVersion.WorkflowID().Value()
+		state.WorkflowVersion = t.workflowVersion.Number()
+	}
+
+	if t.deadline != nil {
+		dueDate := t.deadline.Value()
+		state.Deadline = &dueDate
+	}
+
+	for _, change := range t.deadlineChanges {
+		state.DeadlineChanges = append(state.DeadlineChanges, DeadlineChangeState{
+			Previous:  change.Previous(),
+			DueDate:   change.DueDate(),
+			ChangedBy: change.ChangedBy().Value(),
+			Reason:    change.Reason(),
+			ChangedAt: change.ChangedAt(),
+		})
+	}
+
+	for _, approval := range t.approvals {
+		state.Approvals = append(state.Approvals, ApprovalState{
+			ReviewerID: approval.ReviewerID().Value(),
+			Decision:   string(approval.Decision()),
+			Reason:     approval.Reason(),
+			DecidedAt:  approval.DecidedAt(),
+		})
+	}
+
+	for _, comment := range t.comments {
+		state.Comments = append(state.Comments, CommentState{
+			ID:        comment.ID(),
+			AuthorID:  comment.AuthorID().Value(),
+			Content:   comment.Content(),
+			Version:   comment.Version(),
+			CreatedAt: comment.CreatedAt(),
+			UpdatedAt: comment.UpdatedAt(),
+		})
+	}
+
+	for _, attachment := range t.attachments {
+		state.Attachments = append(state.Attachments, AttachmentState{
+			ID:          attachment.ID(),
+			FileName:    attachment.FileName(),
+			ContentType: attachment.ContentType(),
+			SizeBytes:   attachment.SizeBytes(),
+			UploadedBy:  attachment.UploadedBy().Value(),
+			UploadedAt:  attachment.UploadedAt(),
+		})
+	}
+
+	for _, link := range t.links {
+		state.Links = append(state.Links, TaskLinkState{
+			TargetTaskID: link.TargetTaskID().Value(),
+			LinkType:     link.LinkType().Value(),
+			CreatedBy:    link.CreatedBy().Value(),
+			CreatedAt:    link.CreatedAt(),
+		})
+	}
+
+	if t.editLock != nil {
+		state.EditLock = &EditLockState{
+			HolderID:   t.editLock.HolderID().Value(),
+			AcquiredAt: t.editLock.AcquiredAt(),
+			ExpiresAt:  t.editLock.ExpiresAt(),
+		}
+	}
+
+	for _, entry := range t.costEntries {
+		state.CostEntries = append(state.CostEntries, CostEntryState{
+			ID:          entry.ID(),
+			AmountMinor: entry.Amount().MinorUnits(),
+			Currency:    entry.Amount().Currency(),
+			Description: entry.Description(),
+			RecordedBy:  entry.RecordedBy().Value(),
+			IncurredAt:  entry.IncurredAt(),
+			CreatedAt:   entry.CreatedAt(),
+		})
+	}
+
+	return state
+}
+
+// TaskFromState rebuilds a Task from its state without raising domain events
+func TaskFromState(state TaskState) (*Task, error) {
+	id, err := value.NewTaskID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task id: %w", err)
+	}
+
+	projectID, err := value.NewProjectID(state.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project id: %w", err)
+	}
+
+	status, err := scopedStatusFromState(state.Status, state.StatusWorkflowID, state.StatusName)
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := value.ParsePriority(state.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := value.NewUserID(state.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid creator id: %w", err)
+	}
+
+	task := &Task{
+		id:                id,
+		projectID:         projectID,
+		title:             state.Title,
+		description:       state.Description,
+		status:            status,
+		priority:          priority,
+		overdueRemindedAt: state.OverdueRemindedAt,
+		estimatedHours:    state.EstimatedHours,
+		comments:          make([]*entity.Comment, 0, len(state.Comments)),
+		links:             make([]*entity.TaskLink, 0, len(state.Links)),
+		voterIDs:          make([]value.UserID, 0, len(state.VoterIDs)),
+		frozen:            state.Frozen,
+		createdAt:         state.CreatedAt,
+		updatedAt:         state.UpdatedAt,
+		completedAt:       state.CompletedAt,
+		deletedAt:         state.DeletedAt,
+		createdBy:         createdBy,
+		version:           state.Version,
+		domainEvents:      make([]event.DomainEvent, 0),
+	}
+
+	if state.Assignee != nil {
+		assigneeID, err := value.NewUserID(state.Assignee.AssigneeID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assignee id: %w", err)
+		}
+		assignedBy, err := value.NewUserID(state.Assignee.AssignedBy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assigner id: %w", err)
+		}
+		task.assignee = entity.RestoreAssignment(id, assigneeID, state.Assignee.AssignedAt, assignedBy,
+			state.Assignee.AcknowledgedAt, state.Assignee.EscalatedAt)
+	}
+
+	if state.TeamID != "" {
+		teamID, err := value.NewTeamID(state.TeamID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid team id: %w", err)
+		}
+		task.teamID = &teamID
+	}
+
+	if state.Deadline != nil {
+		deadline := value.RestoreDeadline(*state.Deadline)
+		task.deadline = &deadline
+	}
+
+	for _, c := range state.DeadlineChanges {
+		changedBy, err := value.NewUserID(c.ChangedBy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deadline changer id: %w", err)
+		}
+		change, err := value.NewDeadlineChange(c.Previous, c.DueDate, changedBy, c.Reason, c.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		task.deadlineChanges = append(task.deadlineChanges, change)
+	}
+
+	for _, c := range state.Comments {
+		authorID, err := value.NewUserID(c.AuthorID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid comment author id: %w", err)
+		}
+		task.comments = append(task.comments, entity.RestoreComment(c.ID, id, authorID, c.Content, c.Version, c.CreatedAt, c.UpdatedAt))
+	}
+
+	for _, a := range state.Attachments {
+		uploadedBy, err := value.NewUserID(a.UploadedBy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attachment uploader id: %w", err)
+		}
+		task.attachments = append(task.attachments, entity.RestoreAttachment(a.ID, id, a.FileName, a.ContentType, a.SizeBytes, uploadedBy, a.UploadedAt))
+	}
+
+	for _, l := range state.Links {
+		targetID, err := value.NewTaskID(l.TargetTaskID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid linked task id: %w", err)
+		}
+		linkType, err := value.NewLinkType(l.LinkType)
+		if err != nil {
+			return nil, err
+		}
+		createdBy, err := value.NewUserID(l.CreatedBy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid link creator id: %w", err)
+		}
+		task.links = append(task.links, entity.RestoreTaskLink(targetID, linkType, l.CreatedAt, createdBy))
+	}
+
+	for _, voter := range state.VoterIDs {
+		voterID, err := value.NewUserID(voter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid voter id: %w", err)
+		}
+		task.voterIDs = append(task.voterIDs, voterID)
+	}
+
+	for _, a := range state.Approvals {
+		reviewerID, err := value.NewUserID(a.ReviewerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reviewer id: %w", err)
+		}
+		approval, err := value.NewApproval(reviewerID, value.ApprovalDecision(a.Decision), a.Reason, a.DecidedAt)
+		if err != nil {
+			return nil, err
+		}
+		task.approvals = append(task.approvals, approval)
+	}
+
+	if state.WorkflowID != "" {
+		workflowID, err := value.NewWorkflowID(state.WorkflowID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workflow id: %w", err)
+		}
+		version, err := value.NewWorkflowVersion(workflowID, state.WorkflowVersion)
+		if err != nil {
+			return nil, err
+		}
+		task.workflowVersion = &version
+	}
+
+	if state.EditLock != nil {
+		holderID, err := value.NewUserID(state.EditLock.HolderID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid edit lock holder id: %w", err)
+		}
+		lock, err := value.NewEditLock(holderID, state.EditLock.AcquiredAt, state.EditLock.ExpiresAt.Sub(state.EditLock.AcquiredAt))
+		if err != nil {
+			return nil, err
+		}
+		task.editLock = &lock
+	}
+
+	for _, e := range state.CostEntries {
+		amount, err := value.NewMoney(e.AmountMinor, e.Currency)
+		if err != nil {
+			return nil, err
+		}
+		recordedBy, err := value.NewUserID(e.RecordedBy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cost recorder id: %w", err)
+		}
+		task.costEntries = append(task.costEntries, entity.RestoreCostEntry(e.ID, id, amount, e.Description, recordedBy, e.IncurredAt, e.CreatedAt))
+	}
+
+	return task, nil
+}
+
+// scopedStatusState returns the stored form of a status beside its stage: the workflow
+// naming it and its name, both empty for a status of the default workflow
+func scopedStatusState(status value.ScopedStatus) (workflowID, name string) {
+	if status.IsDefault() {
+		return "", ""
+	}
+	return status.WorkflowID().Value(), status.Name()
+}
+
+// scopedStatusFromState rebuilds a status from its stored stage, workflow and name
+func scopedStatusFromState(stage, workflowID, name string) (value.ScopedStatus, error) {
+	taskStatus, err := value.NewTaskStatus(stage)
+	if err != nil {
+		return value.ScopedStatus{}, err
+	}
+	if workflowID == "" {
+		return value.DefaultScopedStatus(taskStatus), nil
+	}
+
+	id, err := value.NewWorkflowID(workflowID)
+	if err != nil {
+		return value.ScopedStatus{}, fmt.Errorf("invalid status workflow id: %w", err)
+	}
+	return value.NewScopedStatus(id, name, taskStatus)
+}
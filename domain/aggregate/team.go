@@ -0,0 +1,176 @@
+package aggregate
+
+import (
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Team is the aggregate root for a group of users that can be assigned tasks together.
+// The lead is always a member of the team.
+type Team struct {
+	id           value.TeamID
+	name         string
+	leadID       value.UserID
+	memberIDs    []value.UserID
+	createdAt    time.Time
+	updatedAt    time.Time
+	domainEvents []event.DomainEvent
+}
+
+// NewTeam creates a new Team led by the given user, with any further members
+func NewTeam(
+	id value.TeamID,
+	name string,
+	leadID value.UserID,
+	memberIDs []value.UserID,
+) (*Team, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errs.Invalid("team name cannot be empty")
+	}
+
+	team := &Team{
+		id:           id,
+		name:         name,
+		leadID:       leadID,
+		memberIDs:    []value.UserID{leadID},
+		createdAt:    time.Now(),
+		updatedAt:    time.Now(),
+		domainEvents: make([]event.DomainEvent, 0),
+	}
+
+	for _, memberID := range memberIDs {
+		if !team.HasMember(memberID) {
+			team.memberIDs = append(team.memberIDs, memberID)
+		}
+	}
+
+	createdEvent := event.NewTeamCreatedEvent(id.Value(), name, leadID.Value(), userIDValues(team.memberIDs))
+	team.domainEvents = append(team.domainEvents, createdEvent)
+
+	return team, nil
+}
+
+// ID returns the team ID
+func (t *Team) ID() value.TeamID {
+	return t.id
+}
+
+// Name returns the team name
+func (t *Team) Name() string {
+	return t.name
+}
+
+// LeadID returns the ID of the team lead
+func (t *Team) LeadID() value.UserID {
+	return t.leadID
+}
+
+// MemberIDs returns the team members, the lead first
+func (t *Team) MemberIDs() []value.UserID {
+	return append([]value.UserID{}, t.memberIDs...)
+}
+
+// CreatedAt returns when the team was created
+func (t *Team) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// UpdatedAt returns when the team was last updated
+func (t *Team) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+// DomainEvents returns all uncommitted domain events
+func (t *Team) DomainEvents() []event.DomainEvent {
+	return append([]event.DomainEvent{}, t.domainEvents...)
+}
+
+// ClearDomainEvents clears all domain events after they have been published
+func (t *Team) ClearDomainEvents() {
+	t.domainEvents = make([]event.DomainEvent, 0)
+}
+
+// HasMember checks if a user belongs to the team
+func (t *Team) HasMember(userID value.UserID) bool {
+	for _, memberID := range t.memberIDs {
+		if memberID.Equals(userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLead checks if a user leads the team
+func (t *Team) IsLead(userID value.UserID) bool {
+	return t.leadID.Equals(userID)
+}
+
+// AddMember adds a user to the team
+func (t *Team) AddMember(userID value.UserID) error {
+	if t.HasMember(userID) {
+		return errs.Conflict("user is already a team member")
+	}
+
+	t.memberIDs = append(t.memberIDs, userID)
+	t.updatedAt = time.Now()
+
+	addedEvent := event.NewTeamMemberAddedEvent(t.id.Value(), userID.Value())
+	t.domainEvents = append(t.domainEvents, addedEvent)
+
+	return nil
+}
+
+// RemoveMember removes a user from the team. The lead has to hand over the team first.
+func (t *Team) RemoveMember(userID value.UserID) error {
+	if t.IsLead(userID) {
+		return errs.Conflict("cannot remove the team lead, choose a new lead first")
+	}
+
+	for i, memberID := range t.memberIDs {
+		if memberID.Equals(userID) {
+			t.memberIDs = append(t.memberIDs[:i], t.memberIDs[i+1:]...)
+			t.updatedAt = time.Now()
+
+			removedEvent := event.NewTeamMemberRemovedEvent(t.id.Value(), userID.Value())
+			t.domainEvents = append(t.domainEvents, removedEvent)
+
+			return nil
+		}
+	}
+
+	return errs.Conflict("user is not a team member")
+}
+
+// ChangeLead makes another member the team lead
+func (t *Team) ChangeLead(userID value.UserID) error {
+	if !t.HasMember(userID) {
+		return errs.Conflict("user is not a team member")
+	}
+
+	if t.IsLead(userID) {
+		return nil
+	}
+
+	previousLeadID := t.leadID
+	t.leadID = userID
+	t.updatedAt = time.Now()
+
+	changedEvent := event.NewTeamLeadChangedEvent(t.id.Value(), userID.Value(), previousLeadID.Value())
+	t.domainEvents = append(t.domainEvents, changedEvent)
+
+	return nil
+}
+
+// userIDValues returns the string values of user IDs
+func userIDValues(ids []value.UserID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.Value())
+	}
+	return values
+}
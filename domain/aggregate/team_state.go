@@ -0,0 +1,60 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// TeamState is the memento of a Team: its full state in plain fields, for storage outside memory.
+// Uncommitted domain events are not part of the state.
+type TeamState struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	LeadID    string    `json:"lead_id"`
+	MemberIDs []string  `json:"member_ids"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ToState captures the team's state
+func (t *Team) ToState() TeamState {
+	return TeamState{
+		ID:        t.id.Value(),
+		Name:      t.name,
+		LeadID:    t.leadID.Value(),
+		MemberIDs: userIDValues(t.memberIDs),
+		CreatedAt: t.createdAt,
+		UpdatedAt: t.updatedAt,
+	}
+}
+
+// TeamFromState rebuilds a Team from its state without raising domain events
+func TeamFromState(state TeamState) (*Team, error) {
+	id, err := value.NewTeamID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid team id: %w", err)
+	}
+
+	leadID, err := value.NewUserID(state.LeadID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lead id: %w", err)
+	}
+
+	memberIDs, err := parseUserIDs(state.MemberIDs, "member")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Team{
+		id:           id,
+		name:         state.Name,
+		leadID:       leadID,
+		memberIDs:    memberIDs,
+		createdAt:    state.CreatedAt,
+		updatedAt:    state.UpdatedAt,
+		domainEvents: make([]event.DomainEvent, 0),
+	}, nil
+}
@@ -1,9 +1,10 @@
 package aggregate
 
 import (
-	"fmt"
+	"sort"
 	"time"
 
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/value"
 )
@@ -18,7 +19,13 @@ type User struct {
 	createdAt    time.Time
 	updatedAt    time.Time
 	lastLogin    *time.Time
+	verifiedAt   *time.Time
+	passwordHash *value.PasswordHash
+	roles        []value.GlobalRole
 	preferences  map[string]string
+	workingHours *value.WorkingHours // nil follows the organization's default
+	outOfOffice  []value.OutOfOffice // ordered by start, never overlapping
+	managerID    *value.UserID       // who the user reports to, nil at the top of the hierarchy
 	domainEvents []event.DomainEvent
 }
 
@@ -28,14 +35,14 @@ func NewUser(
 	email, firstName, lastName string,
 ) (*User, error) {
 	if email == "" {
-		return nil, fmt.Errorf("email cannot be empty")
+		return nil, errs.Invalid("email cannot be empty")
 	}
 
 	if firstName == "" || lastName == "" {
-		return nil, fmt.Errorf("first and last name cannot be empty")
+		return nil, errs.Invalid("first and last name cannot be empty")
 	}
 
-	return &User{
+	user := &User{
 		id:           id,
 		email:        email,
 		firstName:    firstName,
@@ -45,7 +52,18 @@ func NewUser(
 		updatedAt:    time.Now(),
 		preferences:  make(map[string]string),
 		domainEvents: make([]event.DomainEvent, 0),
-	}, nil
+	}
+
+	// Raise domain event
+	registeredEvent := event.NewUserRegisteredEvent(
+		id.Value(),
+		email,
+		firstName,
+		lastName,
+	)
+	user.domainEvents = append(user.domainEvents, registeredEvent)
+
+	return user, nil
 }
 
 // ID returns the user ID
@@ -93,6 +111,41 @@ func (u *User) LastLogin() *time.Time {
 	return u.lastLogin
 }
 
+// IsEmailVerified returns whether the user has confirmed they own their email address
+func (u *User) IsEmailVerified() bool {
+	return u.verifiedAt != nil
+}
+
+// EmailVerifiedAt returns when the email address was verified, nil while it is unverified
+func (u *User) EmailVerifiedAt() *time.Time {
+	return u.verifiedAt
+}
+
+// HasPassword checks if the user can sign in with a password
+func (u *User) HasPassword() bool {
+	return u.passwordHash != nil
+}
+
+// PasswordHash returns the user's password hash, if any
+func (u *User) PasswordHash() *value.PasswordHash {
+	return u.passwordHash
+}
+
+// Roles returns the global roles granted to the user
+func (u *User) Roles() []value.GlobalRole {
+	return append([]value.GlobalRole{}, u.roles...)
+}
+
+// HasRole checks if the user holds a global role
+func (u *User) HasRole(role value.GlobalRole) bool {
+	for _, held := range u.roles {
+		if held == role {
+			return true
+		}
+	}
+	return false
+}
+
 // DomainEvents returns all uncommitted domain events
 func (u *User) DomainEvents() []event.DomainEvent {
 	return append([]event.DomainEvent{}, u.domainEvents...)
@@ -103,27 +156,43 @@ func (u *User) ClearDomainEvents() {
 	u.domainEvents = make([]event.DomainEvent, 0)
 }
 
-// Activate activates the user
-func (u *User) Activate() error {
+// Activate activates the user again
+func (u *User) Activate(activatedBy value.UserID) error {
 	if u.active {
-		return fmt.Errorf("user is already active")
+		return errs.Conflict("user is already active")
 	}
 
 	u.active = true
 	u.updatedAt = time.Now()
 
+	// Raise domain event
+	activatedEvent := event.NewUserActivatedEvent(u.id.Value(), activatedBy.Value())
+	u.domainEvents = append(u.domainEvents, activatedEvent)
+
 	return nil
 }
 
-// Deactivate deactivates the user
-func (u *User) Deactivate() error {
+// Deactivate deactivates the user. The fallback assignee, if any, takes over their open tasks.
+func (u *User) Deactivate(deactivatedBy value.UserID, fallbackAssigneeID *value.UserID) error {
 	if !u.active {
-		return fmt.Errorf("user is already inactive")
+		return errs.Conflict("user is already inactive")
+	}
+
+	fallback := ""
+	if fallbackAssigneeID != nil {
+		if fallbackAssigneeID.Equals(u.id) {
+			return errs.Invalid("invalid fallback assignee: a user cannot take over their own tasks")
+		}
+		fallback = fallbackAssigneeID.Value()
 	}
 
 	u.active = false
 	u.updatedAt = time.Now()
 
+	// Raise domain event
+	deactivatedEvent := event.NewUserDeactivatedEvent(u.id.Value(), deactivatedBy.Value(), fallback)
+	u.domainEvents = append(u.domainEvents, deactivatedEvent)
+
 	return nil
 }
 
@@ -137,28 +206,196 @@ func (u *User) UpdateLastLogin() {
 // UpdateEmail updates the user email
 func (u *User) UpdateEmail(newEmail string) error {
 	if newEmail == "" {
-		return fmt.Errorf("email cannot be empty")
+		return errs.Invalid("email cannot be empty")
 	}
 
+	if newEmail == u.email {
+		return nil
+	}
+
+	// A new address has to be verified again
+	previousEmail := u.email
+	u.verifiedAt = nil
 	u.email = newEmail
 	u.updatedAt = time.Now()
 
+	// Raise domain event
+	changedEvent := event.NewUserEmailChangedEvent(u.id.Value(), previousEmail, newEmail)
+	u.domainEvents = append(u.domainEvents, changedEvent)
+
+	return nil
+}
+
+// VerifyEmail marks the user's email address as verified
+func (u *User) VerifyEmail() error {
+	if u.verifiedAt != nil {
+		return errs.Conflict("email is already verified")
+	}
+
+	now := time.Now()
+	u.verifiedAt = &now
+	u.updatedAt = now
+
+	// Raise domain event
+	verifiedEvent := event.NewUserEmailVerifiedEvent(u.id.Value(), u.email)
+	u.domainEvents = append(u.domainEvents, verifiedEvent)
+
 	return nil
 }
 
 // UpdateName updates the user name
 func (u *User) UpdateName(firstName, lastName string) error {
 	if firstName == "" || lastName == "" {
-		return fmt.Errorf("first and last name cannot be empty")
+		return errs.Invalid("first and last name cannot be empty")
+	}
+
+	if firstName == u.firstName && lastName == u.lastName {
+		return nil
 	}
 
 	u.firstName = firstName
 	u.lastName = lastName
 	u.updatedAt = time.Now()
 
+	// Raise domain event
+	renamedEvent := event.NewUserRenamedEvent(u.id.Value(), firstName, lastName)
+	u.domainEvents = append(u.domainEvents, renamedEvent)
+
 	return nil
 }
 
+// WorkingHours returns the user's own working hours, or nil when they follow their organization's default
+func (u *User) WorkingHours() *value.WorkingHours {
+	return u.workingHours
+}
+
+// SetWorkingHours replaces the user's working hours; nil returns them to their organization's default
+func (u *User) SetWorkingHours(hours *value.WorkingHours) {
+	u.workingHours = hours
+	u.updatedAt = time.Now()
+
+	// Raise domain event
+	var hoursPerDay float64
+	workingDays := make([]string, 0)
+	if hours != nil {
+		hoursPerDay = hours.HoursPerDay()
+		workingDays = hours.WorkingDayNames()
+	}
+	changedEvent := event.NewUserWorkingHoursChangedEvent(u.id.Value(), hoursPerDay, workingDays)
+	u.domainEvents = append(u.domainEvents, changedEvent)
+}
+
+// OutOfOffice returns the periods the user is away, ordered by start
+func (u *User) OutOfOffice() []value.OutOfOffice {
+	periods := make([]value.OutOfOffice, len(u.outOfOffice))
+	copy(periods, u.outOfOffice)
+	return periods
+}
+
+// OutOfOfficeAt returns the period the user is away at the given time, false when they are not away
+func (u *User) OutOfOfficeAt(at time.Time) (value.OutOfOffice, bool) {
+	for _, period := range u.outOfOffice {
+		if period.Contains(at) {
+			return period, true
+		}
+	}
+
+	return value.OutOfOffice{}, false
+}
+
+// SetOutOfOffice replaces the periods the user is away; none clears them
+func (u *User) SetOutOfOffice(periods []value.OutOfOffice) error {
+	sorted := make([]value.OutOfOffice, len(periods))
+	copy(sorted, periods)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start().Before(sorted[j].Start())
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].Overlaps(sorted[i]) {
+			return errs.Invalid("invalid out-of-office period: periods starting %s and %s overlap",
+				sorted[i-1].Start().Format(time.RFC3339), sorted[i].Start().Format(time.RFC3339))
+		}
+	}
+
+	u.outOfOffice = sorted
+	u.updatedAt = time.Now()
+
+	// Raise domain event
+	intervals := make([]string, 0, len(sorted))
+	for _, period := range sorted {
+		intervals = append(intervals, period.Start().Format(time.RFC3339)+"/"+period.End().Format(time.RFC3339))
+	}
+	changedEvent := event.NewUserOutOfOfficeChangedEvent(u.id.Value(), intervals)
+	u.domainEvents = append(u.domainEvents, changedEvent)
+
+	return nil
+}
+
+// ManagerID returns who the user reports to, nil when they report to nobody
+func (u *User) ManagerID() *value.UserID {
+	return u.managerID
+}
+
+// SetManager sets who the user reports to; nil clears it. Whether the manager exists and
+// whether the change closes a reporting cycle is checked by the caller, which sees all users.
+func (u *User) SetManager(managerID *value.UserID) error {
+	if managerID != nil && managerID.Equals(u.id) {
+		return errs.Invalid("invalid manager: a user cannot report to themselves")
+	}
+
+	u.managerID = managerID
+	u.updatedAt = time.Now()
+
+	// Raise domain event
+	manager := ""
+	if managerID != nil {
+		manager = managerID.Value()
+	}
+	changedEvent := event.NewUserManagerChangedEvent(u.id.Value(), manager)
+	u.domainEvents = append(u.domainEvents, changedEvent)
+
+	return nil
+}
+
+// PreferenceLocale is the preference holding the locale a user reads in
+const PreferenceLocale = "locale"
+
+// Locale returns the locale the user reads in, false when they have not chosen one
+func (u *User) Locale() (value.Locale, bool) {
+	locale, ok := u.preferences[PreferenceLocale]
+	return value.Locale(locale), ok && locale != ""
+}
+
+// SetLocale sets the locale the user reads in; nil clears it
+func (u *User) SetLocale(locale *value.Locale) {
+	if locale == nil {
+		delete(u.preferences, PreferenceLocale)
+	} else {
+		u.preferences[PreferenceLocale] = locale.Value()
+	}
+	u.updatedAt = time.Now()
+}
+
+// preferenceBoardSort is the prefix of the preferences holding how a user sorts a project's board
+const preferenceBoardSort = "board_sort:"
+
+// BoardSort returns how the user sorts the project's board, false when they have not chosen
+func (u *User) BoardSort(projectID value.ProjectID) (value.BoardSort, bool) {
+	sort, err := value.NewBoardSort(u.preferences[preferenceBoardSort+projectID.Value()])
+	return sort, err == nil
+}
+
+// SetBoardSort sets how the user sorts the project's board; nil clears it
+func (u *User) SetBoardSort(projectID value.ProjectID, sort *value.BoardSort) {
+	if sort == nil {
+		delete(u.preferences, preferenceBoardSort+projectID.Value())
+	} else {
+		u.preferences[preferenceBoardSort+projectID.Value()] = sort.Value()
+	}
+	u.updatedAt = time.Now()
+}
+
 // SetPreference sets a user preference
 func (u *User) SetPreference(key, value string) {
 	u.preferences[key] = value
@@ -179,4 +416,49 @@ func (u *User) GetPreferences() map[string]string {
 		prefs[k] = v
 	}
 	return prefs
-}
\ No newline at end of file
+}
+
+// SetPassword sets the password of a user who has none yet
+func (u *User) SetPassword(hash value.PasswordHash) error {
+	if u.passwordHash != nil {
+		return errs.Conflict("user already has a password")
+	}
+
+	u.passwordHash = &hash
+	u.updatedAt = time.Now()
+
+	return nil
+}
+
+// ChangePassword replaces the password of a user who has one; the caller has checked
+// the current password against PasswordHash
+func (u *User) ChangePassword(newHash value.PasswordHash) error {
+	if u.passwordHash == nil {
+		return errs.Conflict("user has no password to change")
+	}
+
+	u.passwordHash = &newHash
+	u.updatedAt = time.Now()
+
+	// Raise domain event
+	changedEvent := event.NewUserPasswordChangedEvent(u.id.Value())
+	u.domainEvents = append(u.domainEvents, changedEvent)
+
+	return nil
+}
+
+// GrantRole grants a global role to the user
+func (u *User) GrantRole(role value.GlobalRole) error {
+	if _, err := value.NewGlobalRole(role.Value()); err != nil {
+		return err
+	}
+
+	if u.HasRole(role) {
+		return nil
+	}
+
+	u.roles = append(u.roles, role)
+	u.updatedAt = time.Now()
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UserState is the memento of a User: its full state in plain fields, for storage outside memory.
+// Uncommitted domain events are not part of the state.
+type UserState struct {
+	ID           string             `json:"id"`
+	Email        string             `json:"email"`
+	FirstName    string             `json:"first_name"`
+	LastName     string             `json:"last_name"`
+	Active       bool               `json:"active"`
+	LastLogin    *time.Time         `json:"last_login,omitempty"`
+	VerifiedAt   *time.Time         `json:"verified_at,omitempty"`
+	PasswordHash string             `json:"password_hash,omitempty"` // encoded, see value.ParsePasswordHash
+	Roles        []string           `json:"roles"`
+	Preferences  map[string]string  `json:"preferences"`
+	WorkingHours *WorkingHoursState `json:"working_hours,omitempty"`
+	OutOfOffice  []OutOfOfficeState `json:"out_of_office,omitempty"`
+	ManagerID    string             `json:"manager_id,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
+// WorkingHoursState is the stored form of working hours, days named as in value.ParseWorkingHours
+type WorkingHoursState struct {
+	HoursPerDay float64  `json:"hours_per_day"`
+	WorkingDays []string `json:"working_days"`
+}
+
+// OutOfOfficeState is the stored form of an out-of-office period
+type OutOfOfficeState struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Note  string    `json:"note,omitempty"`
+}
+
+// ToState captures the user's state
+func (u *User) ToState() UserState {
+	state := UserState{
+		ID:           u.id.Value(),
+		Email:        u.email,
+		FirstName:    u.firstName,
+		LastName:     u.lastName,
+		Active:       u.active,
+		LastLogin:    u.lastLogin,
+		VerifiedAt:   u.verifiedAt,
+		Roles:        make([]string, 0, len(u.roles)),
+		Preferences:  make(map[string]string, len(u.preferences)),
+		WorkingHours: workingHoursState(u.workingHours),
+		CreatedAt:    u.createdAt,
+		UpdatedAt:    u.updatedAt,
+	}
+
+	if u.passwordHash != nil {
+		state.PasswordHash = u.passwordHash.String()
+	}
+
+	if u.managerID != nil {
+		state.ManagerID = u.managerID.Value()
+	}
+
+	for _, role := range u.roles {
+		state.Roles = append(state.Roles, role.Value())
+	}
+
+	for key, val := range u.preferences {
+		state.Preferences[key] = val
+	}
+
+	for _, period := range u.outOfOffice {
+		state.OutOfOffice = append(state.OutOfOffice, OutOfOfficeState{
+			Start: period.Start(),
+			End:   period.End(),
+			Note:  period.Note(),
+		})
+	}
+
+	return state
+}
+
+// UserFromState rebuilds a User from its state without raising domain events
+func UserFromState(state UserState) (*User, error) {
+	id, err := value.NewUserID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id: %w", err)
+	}
+
+	user := &User{
+		id:           id,
+		email:        state.Email,
+		firstName:    state.FirstName,
+		lastName:     state.LastName,
+		active:       state.Active,
+		createdAt:    state.CreatedAt,
+		updatedAt:    state.UpdatedAt,
+		lastLogin:    state.LastLogin,
+		verifiedAt:   state.VerifiedAt,
+		preferences:  make(map[string]string, len(state.Preferences)),
+		domainEvents: make([]event.DomainEvent, 0),
+	}
+
+	if state.PasswordHash != "" {
+		hash, err := value.ParsePasswordHash(state.PasswordHash)
+		if err != nil {
+			return nil, err
+		}
+		user.passwordHash = &hash
+	}
+
+	for _, r := range state.Roles {
+		role, err := value.NewGlobalRole(r)
+		if err != nil {
+			return nil, err
+		}
+		user.roles = append(user.roles, role)
+	}
+
+	for key, val := range state.Preferences {
+		user.preferences[key] = val
+	}
+
+	if user.workingHours, err = workingHoursFromState(state.WorkingHours); err != nil {
+		return nil, err
+	}
+
+	for _, p := range state.OutOfOffice {
+		period, err := value.NewOutOfOffice(p.Start, p.End, p.Note)
+		if err != nil {
+			return nil, err
+		}
+		user.outOfOffice = append(user.outOfOffice, period)
+	}
+
+	if state.ManagerID != "" {
+		managerID, err := value.NewUserID(state.ManagerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manager id: %w", err)
+		}
+		user.managerID = &managerID
+	}
+
+	return user, nil
+}
+
+// workingHoursState captures working hours, nil when they are not set
+func workingHoursState(hours *value.WorkingHours) *WorkingHoursState {
+	if hours == nil {
+		return nil
+	}
+
+	return &WorkingHoursState{
+		HoursPerDay: hours.HoursPerDay(),
+		WorkingDays: hours.WorkingDayNames(),
+	}
+}
+
+// workingHoursFromState rebuilds working hours, nil when they were not set
+func workingHoursFromState(state *WorkingHoursState) (*value.WorkingHours, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	hours, err := value.ParseWorkingHours(state.HoursPerDay, state.WorkingDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hours, nil
+}
@@ -0,0 +1,166 @@
+package aggregate
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Widget is the aggregate root for a user's dashboard widget configuration
+type Widget struct {
+	id           value.WidgetID
+	ownerID      value.UserID
+	title        string
+	widgetType   value.WidgetType
+	parameters   map[string]string
+	layout       value.WidgetLayout
+	createdAt    time.Time
+	updatedAt    time.Time
+	domainEvents []event.DomainEvent
+}
+
+// NewWidget creates a new Widget
+func NewWidget(
+	id value.WidgetID,
+	ownerID value.UserID,
+	title string,
+	widgetType value.WidgetType,
+	parameters map[string]string,
+	layout value.WidgetLayout,
+) (*Widget, error) {
+	if title == "" {
+		return nil, errs.Invalid("widget title cannot be empty")
+	}
+
+	if err := validateWidgetParameters(widgetType, parameters); err != nil {
+		return nil, err
+	}
+
+	return &Widget{
+		id:           id,
+		ownerID:      ownerID,
+		title:        title,
+		widgetType:   widgetType,
+		parameters:   copyParameters(parameters),
+		layout:       layout,
+		createdAt:    time.Now(),
+		updatedAt:    time.Now(),
+		domainEvents: make([]event.DomainEvent, 0),
+	}, nil
+}
+
+// ID returns the widget ID
+func (w *Widget) ID() value.WidgetID {
+	return w.id
+}
+
+// OwnerID returns the ID of the user owning the widget
+func (w *Widget) OwnerID() value.UserID {
+	return w.ownerID
+}
+
+// Title returns the widget title
+func (w *Widget) Title() string {
+	return w.title
+}
+
+// Type returns the widget type
+func (w *Widget) Type() value.WidgetType {
+	return w.widgetType
+}
+
+// Parameters returns the widget query parameters
+func (w *Widget) Parameters() map[string]string {
+	return copyParameters(w.parameters)
+}
+
+// Parameter returns a single query parameter
+func (w *Widget) Parameter(key string) string {
+	return w.parameters[key]
+}
+
+// Layout returns the widget layout
+func (w *Widget) Layout() value.WidgetLayout {
+	return w.layout
+}
+
+// CreatedAt returns when the widget was created
+func (w *Widget) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// UpdatedAt returns when the widget was last updated
+func (w *Widget) UpdatedAt() time.Time {
+	return w.updatedAt
+}
+
+// DomainEvents returns all uncommitted domain events
+func (w *Widget) DomainEvents() []event.DomainEvent {
+	return append([]event.DomainEvent{}, w.domainEvents...)
+}
+
+// ClearDomainEvents clears all domain events after they have been published
+func (w *Widget) ClearDomainEvents() {
+	w.domainEvents = make([]event.DomainEvent, 0)
+}
+
+// IsOwnedBy checks if the widget belongs to a user
+func (w *Widget) IsOwnedBy(userID value.UserID) bool {
+	return w.ownerID.Equals(userID)
+}
+
+// UpdateTitle updates the widget title
+func (w *Widget) UpdateTitle(newTitle string) error {
+	if newTitle == "" {
+		return errs.Invalid("widget title cannot be empty")
+	}
+
+	w.title = newTitle
+	w.updatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateParameters replaces the widget query parameters
+func (w *Widget) UpdateParameters(parameters map[string]string) error {
+	if err := validateWidgetParameters(w.widgetType, parameters); err != nil {
+		return err
+	}
+
+	w.parameters = copyParameters(parameters)
+	w.updatedAt = time.Now()
+
+	return nil
+}
+
+// MoveTo updates the widget layout
+func (w *Widget) MoveTo(layout value.WidgetLayout) {
+	w.layout = layout
+	w.updatedAt = time.Now()
+}
+
+// validateWidgetParameters checks the widget type and its required parameters
+func validateWidgetParameters(widgetType value.WidgetType, parameters map[string]string) error {
+	if !widgetType.IsValid() {
+		return errs.Invalid("invalid widget type: %s", widgetType.Value())
+	}
+
+	for _, key := range widgetType.RequiredParameters() {
+		if parameters[key] == "" {
+			return errs.Invalid("widget parameter %s is required", key)
+		}
+	}
+
+	return nil
+}
+
+// copyParameters returns a copy of a parameter map
+func copyParameters(parameters map[string]string) map[string]string {
+	copied := make(map[string]string, len(parameters))
+	for k, v := range parameters {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,84 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// WidgetState is the memento of a Widget: its full state in plain fields, for storage outside memory.
+// Uncommitted domain events are not part of the state.
+type WidgetState struct {
+	ID         string            `json:"id"`
+	OwnerID    string            `json:"owner_id"`
+	Title      string            `json:"title"`
+	Type       string            `json:"type"`
+	Parameters map[string]string `json:"parameters"`
+	Layout     WidgetLayoutState `json:"layout"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+}
+
+// WidgetLayoutState is the stored form of a widget's place on the dashboard grid
+type WidgetLayoutState struct {
+	Column int `json:"column"`
+	Row    int `json:"row"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// ToState captures the widget's state
+func (w *Widget) ToState() WidgetState {
+	return WidgetState{
+		ID:         w.id.Value(),
+		OwnerID:    w.ownerID.Value(),
+		Title:      w.title,
+		Type:       w.widgetType.Value(),
+		Parameters: copyParameters(w.parameters),
+		Layout: WidgetLayoutState{
+			Column: w.layout.Column(),
+			Row:    w.layout.Row(),
+			Width:  w.layout.Width(),
+			Height: w.layout.Height(),
+		},
+		CreatedAt: w.createdAt,
+		UpdatedAt: w.updatedAt,
+	}
+}
+
+// WidgetFromState rebuilds a Widget from its state without raising domain events
+func WidgetFromState(state WidgetState) (*Widget, error) {
+	id, err := value.NewWidgetID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid widget id: %w", err)
+	}
+
+	ownerID, err := value.NewUserID(state.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner id: %w", err)
+	}
+
+	widgetType, err := value.NewWidgetType(state.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := value.NewWidgetLayout(state.Layout.Column, state.Layout.Row, state.Layout.Width, state.Layout.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Widget{
+		id:           id,
+		ownerID:      ownerID,
+		title:        state.Title,
+		widgetType:   widgetType,
+		parameters:   copyParameters(state.Parameters),
+		layout:       layout,
+		createdAt:    state.CreatedAt,
+		updatedAt:    state.UpdatedAt,
+		domainEvents: make([]event.DomainEvent, 0),
+	}, nil
+}
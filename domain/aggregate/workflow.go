@@ -1,19 +1,40 @@
 package aggregate
 
 import (
-	"fmt"
+	"strings"
 	"time"
 
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
 // WorkflowStatus represents a status in a workflow
 type WorkflowStatus struct {
-	name        string
-	description string
-	order       int
-	isFinal     bool
+	name         string
+	description  string
+	order        int
+	isFinal      bool
+	wipLimit     int
+	approvalStep *value.ApprovalStep // sign-off needed before tasks move on, nil for none
+}
+
+// WorkflowTransition is an allowed move between two statuses of a workflow
+type WorkflowTransition struct {
+	From string
+	To   string
+}
+
+// TransitionRules are the guards a task must pass to take a workflow transition
+// and the actions run once it has
+type TransitionRules struct {
+	Guards  []value.TransitionGuard
+	Actions []value.TransitionAction
+}
+
+// IsEmpty reports whether there are neither guards nor actions
+func (r TransitionRules) IsEmpty() bool {
+	return len(r.Guards) == 0 && len(r.Actions) == 0
 }
 
 // Workflow is the aggregate root for the Workflow aggregate
@@ -22,6 +43,9 @@ type Workflow struct {
 	name         string
 	description  string
 	statuses     []WorkflowStatus
+	transitions  []WorkflowTransition // nil follows the regular task status rules
+	rules        map[WorkflowTransition]TransitionRules
+	version      int
 	createdAt    time.Time
 	updatedAt    time.Time
 	active       bool
@@ -35,35 +59,60 @@ func NewWorkflow(
 	statuses []WorkflowStatus,
 ) (*Workflow, error) {
 	if name == "" {
-		return nil, fmt.Errorf("workflow name cannot be empty")
+		return nil, errs.Invalid("workflow name cannot be empty")
 	}
 
 	if len(statuses) == 0 {
-		return nil, fmt.Errorf("workflow must have at least one status")
+		return nil, errs.Invalid("workflow must have at least one status")
 	}
 
 	// Validate statuses
 	seenNames := make(map[string]bool)
 	for _, status := range statuses {
 		if status.name == "" {
-			return nil, fmt.Errorf("status name cannot be empty")
+			return nil, errs.Invalid("status name cannot be empty")
 		}
 		if seenNames[status.name] {
-			return nil, fmt.Errorf("duplicate status name: %s", status.name)
+			return nil, errs.Conflict("duplicate status name: %s", status.name)
 		}
 		seenNames[status.name] = true
 	}
 
-	return &Workflow{
+	workflow := &Workflow{
 		id:           id,
 		name:         name,
 		description:  description,
 		statuses:     statuses,
+		version:      1,
 		createdAt:    time.Now(),
 		updatedAt:    time.Now(),
 		active:       true,
 		domainEvents: make([]event.DomainEvent, 0),
-	}, nil
+	}
+
+	// Raise domain event
+	names := make([]string, len(statuses))
+	for i := range statuses {
+		names[i] = statuses[i].name
+	}
+	createdEvent := event.NewWorkflowCreatedEvent(id.Value(), name, names)
+	workflow.domainEvents = append(workflow.domainEvents, createdEvent)
+
+	return workflow, nil
+}
+
+// NewDefaultWorkflow creates the built-in workflow holding the regular task statuses,
+// moved between by the regular task status rules
+func NewDefaultWorkflow() *Workflow {
+	workflow, _ := NewWorkflow(value.DefaultWorkflowID, "Default", "Regular task statuses", []WorkflowStatus{
+		NewWorkflowStatus(value.TaskStatusBacklog.Value(), "Backlog", 1, false),
+		NewWorkflowStatus(value.TaskStatusToDo.Value(), "To Do", 2, false),
+		NewWorkflowStatus(value.TaskStatusInProgress.Value(), "In Progress", 3, false),
+		NewWorkflowStatus(value.TaskStatusInReview.Value(), "In Review", 4, false),
+		NewWorkflowStatus(value.TaskStatusCompleted.Value(), "Completed", 5, true),
+		NewWorkflowStatus(value.TaskStatusCancelled.Value(), "Cancelled", 6, true),
+	})
+	return workflow
 }
 
 // ID returns the workflow ID
@@ -71,6 +120,29 @@ func (w *Workflow) ID() value.WorkflowID {
 	return w.id
 }
 
+// Version returns the workflow version number, starting at 1
+func (w *Workflow) Version() int {
+	return w.version
+}
+
+// WorkflowVersion returns the workflow ID with its version number
+func (w *Workflow) WorkflowVersion() value.WorkflowVersion {
+	version, _ := value.NewWorkflowVersion(w.id, w.version)
+	return version
+}
+
+// NextVersion returns a copy of the workflow as its next version, to be edited and saved
+// while tasks on this version keep it unchanged
+func (w *Workflow) NextVersion() *Workflow {
+	state := w.ToState()
+	state.Version++
+
+	// The state of a valid workflow always rebuilds
+	next, _ := WorkflowFromState(state)
+	next.updatedAt = time.Now()
+	return next
+}
+
 // Name returns the workflow name
 func (w *Workflow) Name() string {
 	return w.name
@@ -118,7 +190,7 @@ func (w *Workflow) GetStatusByName(name string) (*WorkflowStatus, error) {
 			return &w.statuses[i], nil
 		}
 	}
-	return nil, fmt.Errorf("status not found: %s", name)
+	return nil, errs.NotFound("status not found: %s", name)
 }
 
 // IsValidStatus checks if a status exists in the workflow
@@ -131,10 +203,439 @@ func (w *Workflow) IsValidStatus(statusName string) bool {
 	return false
 }
 
+// Covers reports whether the workflow has a status for a task status. Names match
+// regardless of case, spaces or hyphens, so "In Progress" covers IN_PROGRESS.
+func (w *Workflow) Covers(statusName string) bool {
+	canonical := canonicalStatusName(statusName)
+	for _, status := range w.statuses {
+		if canonicalStatusName(status.name) == canonical {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusNameFor returns the name of the workflow status covering a task status, empty when none does
+func (w *Workflow) StatusNameFor(statusName string) string {
+	if index := w.statusIndex(statusName); index >= 0 {
+		return w.statuses[index].name
+	}
+	return ""
+}
+
+// ScopedStatus returns the workflow's status of the given name, spelt as the workflow does
+func (w *Workflow) ScopedStatus(statusName string) (value.ScopedStatus, error) {
+	index := w.statusIndex(statusName)
+	if index < 0 {
+		return value.ScopedStatus{}, errs.Invalid("invalid task status: workflow %s has no status %s", w.name, statusName)
+	}
+	name := w.statuses[index].name
+	return value.NewScopedStatus(w.id, name, w.StageOf(name))
+}
+
+// StageOf returns the task lifecycle stage a workflow status stands for. A regular status
+// name stands for itself. A custom final status stands for COMPLETED, any other custom
+// status for the closest regular status before it, or BACKLOG when none comes before it.
+func (w *Workflow) StageOf(statusName string) value.TaskStatus {
+	canonical := canonicalStatusName(statusName)
+	if stage := value.TaskStatus(canonical); stage.IsValid() {
+		return stage
+	}
+
+	index := w.statusIndex(statusName)
+	if index < 0 {
+		return value.TaskStatus(canonical)
+	}
+	if w.statuses[index].isFinal {
+		return value.TaskStatusCompleted
+	}
+	for i := index - 1; i >= 0; i-- {
+		if stage := value.TaskStatus(canonicalStatusName(w.statuses[i].name)); stage.IsValid() && stage != value.TaskStatusCancelled {
+			return stage
+		}
+	}
+	return value.TaskStatusBacklog
+}
+
+// followsRegularRules reports whether the regular task status rules allow a move between
+// the stages of two statuses. Custom statuses of the same stage may move between each
+// other until the work is done.
+func (w *Workflow) followsRegularRules(from, to string) bool {
+	fromStage, toStage := w.StageOf(from), w.StageOf(to)
+	if fromStage == toStage {
+		return canonicalStatusName(from) != canonicalStatusName(to) &&
+			fromStage != value.TaskStatusCompleted && fromStage != value.TaskStatusCancelled
+	}
+	return fromStage.CanTransitionTo(toStage)
+}
+
+// canonicalStatusName folds a status name to the task status spelling
+func canonicalStatusName(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+// Transitions returns the workflow's transition matrix, nil when it follows the regular task status rules
+func (w *Workflow) Transitions() []WorkflowTransition {
+	if w.transitions == nil {
+		return nil
+	}
+	return append([]WorkflowTransition{}, w.transitions...)
+}
+
+// SetTransitions replaces the workflow's transition matrix.
+// Nil or empty restores the regular task status rules between the workflow's statuses.
+func (w *Workflow) SetTransitions(transitions []WorkflowTransition) error {
+	if len(transitions) == 0 {
+		w.transitions = nil
+		w.dropUnreachableRules()
+		w.updatedAt = time.Now()
+		return nil
+	}
+
+	seen := make(map[WorkflowTransition]bool, len(transitions))
+	matrix := make([]WorkflowTransition, 0, len(transitions))
+	for _, transition := range transitions {
+		if !w.Covers(transition.From) || !w.Covers(transition.To) {
+			return errs.Invalid("invalid transition from %s to %s: both statuses must be in the workflow", transition.From, transition.To)
+		}
+		transition = WorkflowTransition{
+			From: canonicalStatusName(transition.From),
+			To:   canonicalStatusName(transition.To),
+		}
+		if transition.From == transition.To {
+			return errs.Invalid("invalid transition: %s cannot move to itself", transition.From)
+		}
+		if seen[transition] {
+			continue
+		}
+		seen[transition] = true
+		matrix = append(matrix, transition)
+	}
+
+	w.transitions = matrix
+	w.dropUnreachableRules()
+	w.updatedAt = time.Now()
+
+	return nil
+}
+
+// AllowsTransition reports whether a task may move between two statuses of the workflow.
+// The target must be a workflow status. A task left in a status the workflow lacks, for
+// instance after the project changed workflow, moves back in by the regular rules.
+func (w *Workflow) AllowsTransition(from, to string) bool {
+	if !w.Covers(to) {
+		return false
+	}
+
+	if w.transitions == nil || !w.Covers(from) {
+		return w.followsRegularRules(from, to)
+	}
+
+	from, to = canonicalStatusName(from), canonicalStatusName(to)
+
+	for _, transition := range w.transitions {
+		if transition.From == from && transition.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// AddStatus adds a status after the existing ones. With a transition matrix the
+// new status is unreachable until transitions into it are allowed.
+func (w *Workflow) AddStatus(status WorkflowStatus) error {
+	if status.name == "" {
+		return errs.Invalid("status name cannot be empty")
+	}
+	if w.Covers(status.name) {
+		return errs.Conflict("duplicate status name: %s", status.name)
+	}
+
+	status.order = 1
+	for _, existing := range w.statuses {
+		if existing.order >= status.order {
+			status.order = existing.order + 1
+		}
+	}
+
+	w.statuses = append(w.statuses, status)
+	w.updatedAt = time.Now()
+
+	w.domainEvents = append(w.domainEvents, event.NewWorkflowStatusAddedEvent(w.id.Value(), status.name, status.order, status.isFinal))
+
+	return nil
+}
+
+// RemoveStatus removes a status and every transition into or out of it
+func (w *Workflow) RemoveStatus(name string) error {
+	index := w.statusIndex(name)
+	if index < 0 {
+		return errs.NotFound("status not found: %s", name)
+	}
+	if len(w.statuses) == 1 {
+		return errs.Invalid("workflow must have at least one status")
+	}
+
+	removed := w.statuses[index]
+	w.statuses = append(w.statuses[:index:index], w.statuses[index+1:]...)
+
+	if w.transitions != nil {
+		canonical := canonicalStatusName(removed.name)
+		matrix := make([]WorkflowTransition, 0, len(w.transitions))
+		for _, transition := range w.transitions {
+			if transition.From != canonical && transition.To != canonical {
+				matrix = append(matrix, transition)
+			}
+		}
+		w.transitions = matrix
+	}
+
+	canonical := canonicalStatusName(removed.name)
+	for transition := range w.rules {
+		if transition.From == canonical || transition.To == canonical {
+			delete(w.rules, transition)
+		}
+	}
+
+	w.updatedAt = time.Now()
+
+	w.domainEvents = append(w.domainEvents, event.NewWorkflowStatusRemovedEvent(w.id.Value(), removed.name))
+
+	return nil
+}
+
+// ReorderStatuses puts the statuses in the given order, which must name each status once
+func (w *Workflow) ReorderStatuses(names []string) error {
+	if len(names) != len(w.statuses) {
+		return errs.Invalid("invalid status order: expected %d statuses, got %d", len(w.statuses), len(names))
+	}
+
+	reordered := make([]WorkflowStatus, 0, len(names))
+	seen := make(map[int]bool, len(names))
+	for i, name := range names {
+		index := w.statusIndex(name)
+		if index < 0 {
+			return errs.Invalid("invalid status order: workflow has no status %s", name)
+		}
+		if seen[index] {
+			return errs.Invalid("invalid status order: %s is listed more than once", name)
+		}
+		seen[index] = true
+
+		status := w.statuses[index]
+		status.order = i + 1
+		reordered = append(reordered, status)
+	}
+
+	w.statuses = reordered
+	w.updatedAt = time.Now()
+
+	ordered := make([]string, len(reordered))
+	for i, status := range reordered {
+		ordered[i] = status.name
+	}
+	w.domainEvents = append(w.domainEvents, event.NewWorkflowStatusesReorderedEvent(w.id.Value(), ordered))
+
+	return nil
+}
+
+// AllowTransition allows tasks to move between two statuses. A workflow following the
+// regular task status rules gets a matrix holding those rules first, so no move is lost.
+func (w *Workflow) AllowTransition(from, to string) error {
+	transition, err := w.workflowTransition(from, to)
+	if err != nil {
+		return err
+	}
+
+	matrix := w.explicitTransitions()
+	for _, existing := range matrix {
+		if existing == transition {
+			return errs.Conflict("transition from %s to %s is already allowed", transition.From, transition.To)
+		}
+	}
+
+	w.transitions = append(matrix, transition)
+	w.updatedAt = time.Now()
+
+	w.domainEvents = append(w.domainEvents, event.NewWorkflowTransitionAllowedEvent(w.id.Value(), transition.From, transition.To))
+
+	return nil
+}
+
+// DisallowTransition stops tasks from moving between two statuses. A workflow following the
+// regular task status rules gets a matrix holding the other rules.
+func (w *Workflow) DisallowTransition(from, to string) error {
+	transition, err := w.workflowTransition(from, to)
+	if err != nil {
+		return err
+	}
+
+	matrix := w.explicitTransitions()
+	remaining := make([]WorkflowTransition, 0, len(matrix))
+	for _, existing := range matrix {
+		if existing != transition {
+			remaining = append(remaining, existing)
+		}
+	}
+	if len(remaining) == len(matrix) {
+		return errs.Conflict("transition from %s to %s is not allowed", transition.From, transition.To)
+	}
+
+	w.transitions = remaining
+	delete(w.rules, transition)
+	w.updatedAt = time.Now()
+
+	w.domainEvents = append(w.domainEvents, event.NewWorkflowTransitionDisallowedEvent(w.id.Value(), transition.From, transition.To))
+
+	return nil
+}
+
+// ApprovalStepFor returns the sign-off tasks need before moving on from a status, nil when it needs none
+func (w *Workflow) ApprovalStepFor(statusName string) *value.ApprovalStep {
+	index := w.statusIndex(statusName)
+	if index < 0 {
+		return nil
+	}
+	return w.statuses[index].approvalStep
+}
+
+// SetApprovalStep makes tasks need sign-off from designated reviewers before they move
+// on from a status. A nil step removes it.
+func (w *Workflow) SetApprovalStep(statusName string, step *value.ApprovalStep) error {
+	index := w.statusIndex(statusName)
+	if index < 0 {
+		return errs.NotFound("status not found: %s", statusName)
+	}
+
+	w.statuses[index].approvalStep = step
+	w.updatedAt = time.Now()
+
+	requiredApprovals := 0
+	reviewerIDs := make([]string, 0)
+	if step != nil {
+		requiredApprovals = step.RequiredApprovals()
+		reviewerIDs = userIDValues(step.ReviewerIDs())
+	}
+	w.domainEvents = append(w.domainEvents, event.NewWorkflowApprovalStepChangedEvent(w.id.Value(), w.statuses[index].name, requiredApprovals, reviewerIDs))
+
+	return nil
+}
+
+// TransitionRules returns the guards and actions of a transition, empty when it has none
+func (w *Workflow) TransitionRules(from, to string) TransitionRules {
+	rules := w.rules[WorkflowTransition{From: canonicalStatusName(from), To: canonicalStatusName(to)}]
+	return TransitionRules{
+		Guards:  append([]value.TransitionGuard{}, rules.Guards...),
+		Actions: append([]value.TransitionAction{}, rules.Actions...),
+	}
+}
+
+// RuledTransitions returns the transitions that have guards or actions
+func (w *Workflow) RuledTransitions() []WorkflowTransition {
+	ruled := make([]WorkflowTransition, 0, len(w.rules))
+	for _, transition := range w.explicitTransitions() {
+		if _, ok := w.rules[transition]; ok {
+			ruled = append(ruled, transition)
+		}
+	}
+	return ruled
+}
+
+// SetTransitionRules replaces the guards and actions of an allowed transition.
+// Empty rules remove them.
+func (w *Workflow) SetTransitionRules(from, to string, rules TransitionRules) error {
+	transition, err := w.workflowTransition(from, to)
+	if err != nil {
+		return err
+	}
+
+	if !w.AllowsTransition(transition.From, transition.To) {
+		return errs.Conflict("transition from %s to %s is not allowed", transition.From, transition.To)
+	}
+
+	if rules.IsEmpty() {
+		delete(w.rules, transition)
+	} else {
+		if w.rules == nil {
+			w.rules = make(map[WorkflowTransition]TransitionRules)
+		}
+		w.rules[transition] = TransitionRules{
+			Guards:  append([]value.TransitionGuard{}, rules.Guards...),
+			Actions: append([]value.TransitionAction{}, rules.Actions...),
+		}
+	}
+	w.updatedAt = time.Now()
+
+	guards := make([]string, len(rules.Guards))
+	for i, guard := range rules.Guards {
+		guards[i] = guard.String()
+	}
+	actions := make([]string, len(rules.Actions))
+	for i, action := range rules.Actions {
+		actions[i] = action.String()
+	}
+	w.domainEvents = append(w.domainEvents, event.NewWorkflowTransitionRulesChangedEvent(w.id.Value(), transition.From, transition.To, guards, actions))
+
+	return nil
+}
+
+// dropUnreachableRules removes the rules of transitions the workflow no longer allows
+func (w *Workflow) dropUnreachableRules() {
+	for transition := range w.rules {
+		if !w.AllowsTransition(transition.From, transition.To) {
+			delete(w.rules, transition)
+		}
+	}
+}
+
+// statusIndex returns the position of a status by name, -1 when the workflow lacks it
+func (w *Workflow) statusIndex(name string) int {
+	canonical := canonicalStatusName(name)
+	for i, status := range w.statuses {
+		if canonicalStatusName(status.name) == canonical {
+			return i
+		}
+	}
+	return -1
+}
+
+// workflowTransition validates a move between two workflow statuses and returns it in canonical names
+func (w *Workflow) workflowTransition(from, to string) (WorkflowTransition, error) {
+	if !w.Covers(from) || !w.Covers(to) {
+		return WorkflowTransition{}, errs.Invalid("invalid transition from %s to %s: both statuses must be in the workflow", from, to)
+	}
+
+	transition := WorkflowTransition{From: canonicalStatusName(from), To: canonicalStatusName(to)}
+	if transition.From == transition.To {
+		return WorkflowTransition{}, errs.Invalid("invalid transition: %s cannot move to itself", transition.From)
+	}
+	return transition, nil
+}
+
+// explicitTransitions returns a copy of the matrix, or the regular task status rules
+// between the workflow's statuses when there is none
+func (w *Workflow) explicitTransitions() []WorkflowTransition {
+	if w.transitions != nil {
+		return append([]WorkflowTransition{}, w.transitions...)
+	}
+
+	matrix := make([]WorkflowTransition, 0)
+	for _, from := range w.statuses {
+		for _, to := range w.statuses {
+			transition := WorkflowTransition{From: canonicalStatusName(from.name), To: canonicalStatusName(to.name)}
+			if w.followsRegularRules(from.name, to.name) {
+				matrix = append(matrix, transition)
+			}
+		}
+	}
+	return matrix
+}
+
 // Activate activates the workflow
 func (w *Workflow) Activate() error {
 	if w.active {
-		return fmt.Errorf("workflow is already active")
+		return errs.Conflict("workflow is already active")
 	}
 
 	w.active = true
@@ -146,7 +647,7 @@ func (w *Workflow) Activate() error {
 // Deactivate deactivates the workflow
 func (w *Workflow) Deactivate() error {
 	if !w.active {
-		return fmt.Errorf("workflow is already inactive")
+		return errs.Conflict("workflow is already inactive")
 	}
 
 	w.active = false
@@ -158,7 +659,7 @@ func (w *Workflow) Deactivate() error {
 // UpdateName updates the workflow name
 func (w *Workflow) UpdateName(newName string) error {
 	if newName == "" {
-		return fmt.Errorf("workflow name cannot be empty")
+		return errs.Invalid("workflow name cannot be empty")
 	}
 
 	w.name = newName
@@ -167,6 +668,27 @@ func (w *Workflow) UpdateName(newName string) error {
 	return nil
 }
 
+// UpdateDetails changes the workflow's name and description
+func (w *Workflow) UpdateDetails(name, description string) error {
+	if name == "" {
+		return errs.Invalid("workflow name cannot be empty")
+	}
+
+	if name == w.name && description == w.description {
+		return nil
+	}
+
+	w.name = name
+	w.description = description
+	w.updatedAt = time.Now()
+
+	// Raise domain event
+	changedEvent := event.NewWorkflowDetailsChangedEvent(w.id.Value(), name, description)
+	w.domainEvents = append(w.domainEvents, changedEvent)
+
+	return nil
+}
+
 // NewWorkflowStatus creates a new workflow status
 func NewWorkflowStatus(name, description string, order int, isFinal bool) WorkflowStatus {
 	return WorkflowStatus{
@@ -177,6 +699,21 @@ func NewWorkflowStatus(name, description string, order int, isFinal bool) Workfl
 	}
 }
 
+// WithWIPLimit returns a copy of the status that allows at most limit tasks (0 means no limit)
+func (ws WorkflowStatus) WithWIPLimit(limit int) WorkflowStatus {
+	if limit < 0 {
+		limit = 0
+	}
+	ws.wipLimit = limit
+	return ws
+}
+
+// WithApprovalStep returns a copy of the status that needs the step's sign-off before tasks move on
+func (ws WorkflowStatus) WithApprovalStep(step *value.ApprovalStep) WorkflowStatus {
+	ws.approvalStep = step
+	return ws
+}
+
 // GetName returns the status name
 func (ws *WorkflowStatus) GetName() string {
 	return ws.name
@@ -195,4 +732,19 @@ func (ws *WorkflowStatus) GetOrder() int {
 // IsFinal returns whether this is a final status
 func (ws *WorkflowStatus) IsFinal() bool {
 	return ws.isFinal
-}
\ No newline at end of file
+}
+
+// GetWIPLimit returns the work-in-progress limit, 0 when unlimited
+func (ws *WorkflowStatus) GetWIPLimit() int {
+	return ws.wipLimit
+}
+
+// HasWIPLimit returns whether the status limits work in progress
+func (ws *WorkflowStatus) HasWIPLimit() bool {
+	return ws.wipLimit > 0
+}
+
+// GetApprovalStep returns the sign-off tasks need before moving on, nil when none is needed
+func (ws *WorkflowStatus) GetApprovalStep() *value.ApprovalStep {
+	return ws.approvalStep
+}
@@ -0,0 +1,216 @@
+package aggregate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// WorkflowState is the memento of a Workflow: its full state in plain fields, for storage outside memory.
+// Uncommitted domain events are not part of the state.
+type WorkflowState struct {
+	ID          string                        `json:"id"`
+	Name        string                        `json:"name"`
+	Description string                        `json:"description"`
+	Statuses    []WorkflowStatusState         `json:"statuses"`
+	Transitions []WorkflowTransition          `json:"transitions"` // null follows the regular task status rules
+	Rules       []WorkflowTransitionRuleState `json:"rules"`
+	Version     int                           `json:"version"`
+	Active      bool                          `json:"active"`
+	CreatedAt   time.Time                     `json:"created_at"`
+	UpdatedAt   time.Time                     `json:"updated_at"`
+}
+
+// WorkflowStatusState is the stored form of a workflow status
+type WorkflowStatusState struct {
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Order        int                `json:"order"`
+	IsFinal      bool               `json:"is_final"`
+	WIPLimit     int                `json:"wip_limit"`
+	ApprovalStep *ApprovalStepState `json:"approval_step,omitempty"`
+}
+
+// ApprovalStepState is the stored form of the sign-off a workflow status needs
+type ApprovalStepState struct {
+	RequiredApprovals int      `json:"required_approvals"`
+	ReviewerIDs       []string `json:"reviewer_ids"`
+}
+
+// WorkflowTransitionRuleState is the stored form of the guards and actions of one transition
+type WorkflowTransitionRuleState struct {
+	From    string                  `json:"from"`
+	To      string                  `json:"to"`
+	Guards  []TransitionGuardState  `json:"guards"`
+	Actions []TransitionActionState `json:"actions"`
+}
+
+// TransitionGuardState is the stored form of a transition guard
+type TransitionGuardState struct {
+	Kind      string `json:"kind"`
+	Approvals int    `json:"approvals,omitempty"`
+}
+
+// TransitionActionState is the stored form of a transition action
+type TransitionActionState struct {
+	Kind       string `json:"kind"`
+	Channel    string `json:"channel,omitempty"`
+	Target     string `json:"target,omitempty"`
+	AssigneeID string `json:"assignee_id,omitempty"`
+}
+
+// ToState captures the workflow's state
+func (w *Workflow) ToState() WorkflowState {
+	state := WorkflowState{
+		ID:          w.id.Value(),
+		Name:        w.name,
+		Description: w.description,
+		Statuses:    make([]WorkflowStatusState, 0, len(w.statuses)),
+		Rules:       make([]WorkflowTransitionRuleState, 0, len(w.rules)),
+		Version:     w.version,
+		Active:      w.active,
+		CreatedAt:   w.createdAt,
+		UpdatedAt:   w.updatedAt,
+	}
+
+	for _, status := range w.statuses {
+		statusState := WorkflowStatusState{
+			Name:        status.name,
+			Description: status.description,
+			Order:       status.order,
+			IsFinal:     status.isFinal,
+			WIPLimit:    status.wipLimit,
+		}
+		if status.approvalStep != nil {
+			statusState.ApprovalStep = &ApprovalStepState{
+				RequiredApprovals: status.approvalStep.RequiredApprovals(),
+				ReviewerIDs:       userIDValues(status.approvalStep.ReviewerIDs()),
+			}
+		}
+		state.Statuses = append(state.Statuses, statusState)
+	}
+
+	if w.transitions != nil {
+		state.Transitions = append([]WorkflowTransition{}, w.transitions...)
+	}
+
+	for _, transition := range w.RuledTransitions() {
+		rules := w.rules[transition]
+		rule := WorkflowTransitionRuleState{
+			From:    transition.From,
+			To:      transition.To,
+			Guards:  make([]TransitionGuardState, 0, len(rules.Guards)),
+			Actions: make([]TransitionActionState, 0, len(rules.Actions)),
+		}
+		for _, guard := range rules.Guards {
+			rule.Guards = append(rule.Guards, TransitionGuardState{Kind: string(guard.Kind()), Approvals: guard.Approvals()})
+		}
+		for _, action := range rules.Actions {
+			actionState := TransitionActionState{Kind: string(action.Kind())}
+			switch action.Kind() {
+			case value.TransitionActionNotify:
+				actionState.Channel = action.Channel().Value()
+				actionState.Target = action.Target()
+			case value.TransitionActionAutoAssign:
+				actionState.AssigneeID = action.AssigneeID().Value()
+			}
+			rule.Actions = append(rule.Actions, actionState)
+		}
+		state.Rules = append(state.Rules, rule)
+	}
+
+	return state
+}
+
+// WorkflowFromState rebuilds a Workflow from its state without raising domain events
+func WorkflowFromState(state WorkflowState) (*Workflow, error) {
+	id, err := value.NewWorkflowID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow id: %w", err)
+	}
+
+	if state.Version < 1 {
+		return nil, fmt.Errorf("invalid workflow version: %d", state.Version)
+	}
+
+	workflow := &Workflow{
+		id:           id,
+		name:         state.Name,
+		description:  state.Description,
+		statuses:     make([]WorkflowStatus, 0, len(state.Statuses)),
+		version:      state.Version,
+		createdAt:    state.CreatedAt,
+		updatedAt:    state.UpdatedAt,
+		active:       state.Active,
+		domainEvents: make([]event.DomainEvent, 0),
+	}
+
+	for _, s := range state.Statuses {
+		status := NewWorkflowStatus(s.Name, s.Description, s.Order, s.IsFinal).WithWIPLimit(s.WIPLimit)
+		if s.ApprovalStep != nil {
+			reviewerIDs, err := parseUserIDs(s.ApprovalStep.ReviewerIDs, "reviewer")
+			if err != nil {
+				return nil, err
+			}
+			step, err := value.NewApprovalStep(s.ApprovalStep.RequiredApprovals, reviewerIDs)
+			if err != nil {
+				return nil, err
+			}
+			status = status.WithApprovalStep(&step)
+		}
+		workflow.statuses = append(workflow.statuses, status)
+	}
+
+	if state.Transitions != nil {
+		workflow.transitions = append([]WorkflowTransition{}, state.Transitions...)
+	}
+
+	for _, r := range state.Rules {
+		rules := TransitionRules{
+			Guards:  make([]value.TransitionGuard, 0, len(r.Guards)),
+			Actions: make([]value.TransitionAction, 0, len(r.Actions)),
+		}
+		for _, g := range r.Guards {
+			guard, err := value.NewTransitionGuard(g.Kind, g.Approvals)
+			if err != nil {
+				return nil, err
+			}
+			rules.Guards = append(rules.Guards, guard)
+		}
+		for _, a := range r.Actions {
+			action, err := transitionActionFromState(a)
+			if err != nil {
+				return nil, err
+			}
+			rules.Actions = append(rules.Actions, action)
+		}
+		if workflow.rules == nil {
+			workflow.rules = make(map[WorkflowTransition]TransitionRules)
+		}
+		workflow.rules[WorkflowTransition{From: r.From, To: r.To}] = rules
+	}
+
+	return workflow, nil
+}
+
+// transitionActionFromState rebuilds a stored transition action
+func transitionActionFromState(state TransitionActionState) (value.TransitionAction, error) {
+	switch value.TransitionActionKind(state.Kind) {
+	case value.TransitionActionNotify:
+		channel, err := value.NewNotificationChannel(state.Channel)
+		if err != nil {
+			return value.TransitionAction{}, err
+		}
+		return value.NewNotifyAction(channel, state.Target)
+	case value.TransitionActionAutoAssign:
+		assigneeID, err := value.NewUserID(state.AssigneeID)
+		if err != nil {
+			return value.TransitionAction{}, fmt.Errorf("invalid assignee id: %w", err)
+		}
+		return value.NewAutoAssignAction(assigneeID)
+	default:
+		return value.TransitionAction{}, fmt.Errorf("invalid transition action: %s", state.Kind)
+	}
+}
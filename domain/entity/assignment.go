@@ -1,9 +1,9 @@
 package entity
 
 import (
-	"fmt"
 	"time"
 
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
@@ -13,12 +13,14 @@ type Assignment struct {
 	assigneeID value.UserID
 	assignedAt time.Time
 	assignedBy value.UserID
+	acknowledgedAt *time.Time // when the assignee confirmed they saw the assignment
+	escalatedAt    *time.Time // when the assigner was told it went unacknowledged
 }
 
 // NewAssignment creates a new Assignment
 func NewAssignment(taskID value.TaskID, assigneeID value.UserID, assignedBy value.UserID) (*Assignment, error) {
 	if assigneeID.Equals(value.UserID{}) {
-		return nil, fmt.Errorf("assignee cannot be empty")
+		return nil, errs.Invalid("assignee cannot be empty")
 	}
 
 	return &Assignment{
@@ -29,6 +31,24 @@ func NewAssignment(taskID value.TaskID, assigneeID value.UserID, assignedBy valu
 	}, nil
 }
 
+// RestoreAssignment rebuilds a previously stored Assignment without re-validating it
+func RestoreAssignment(
+	taskID value.TaskID,
+	assigneeID value.UserID,
+	assignedAt time.Time,
+	assignedBy value.UserID,
+	acknowledgedAt, escalatedAt *time.Time,
+) *Assignment {
+	return &Assignment{
+		taskID:         taskID,
+		assigneeID:     assigneeID,
+		assignedAt:     assignedAt,
+		assignedBy:     assignedBy,
+		acknowledgedAt: acknowledgedAt,
+		escalatedAt:    escalatedAt,
+	}
+}
+
 // TaskID returns the task ID
 func (a *Assignment) TaskID() value.TaskID {
 	return a.taskID
@@ -52,4 +72,33 @@ func (a *Assignment) AssignedBy() value.UserID {
 // IsAssignedTo checks if the assignment is for a specific user
 func (a *Assignment) IsAssignedTo(userID value.UserID) bool {
 	return a.assigneeID.Equals(userID)
-}
\ No newline at end of file
+}
+// AcknowledgedAt returns when the assignee acknowledged the assignment, nil until they do
+func (a *Assignment) AcknowledgedAt() *time.Time {
+	return a.acknowledgedAt
+}
+
+// IsAcknowledged checks if the assignee has acknowledged the assignment
+func (a *Assignment) IsAcknowledged() bool {
+	return a.acknowledgedAt != nil
+}
+
+// Acknowledge records that the assignee has seen the assignment
+func (a *Assignment) Acknowledge(at time.Time) error {
+	if a.acknowledgedAt != nil {
+		return errs.Conflict("assignment is already acknowledged")
+	}
+
+	a.acknowledgedAt = &at
+	return nil
+}
+
+// EscalatedAt returns when the unacknowledged assignment was escalated, nil if it was not
+func (a *Assignment) EscalatedAt() *time.Time {
+	return a.escalatedAt
+}
+
+// MarkEscalated records that the assignment was escalated for going unacknowledged
+func (a *Assignment) MarkEscalated(at time.Time) {
+	a.escalatedAt = &at
+}
@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// maxAttachmentFileNameLength bounds attachment file names
+const maxAttachmentFileNameLength = 255
+
+// Attachment is the metadata of a file attached to a task; the content lives in file storage
+type Attachment struct {
+	id          string
+	taskID      value.TaskID
+	fileName    string
+	contentType string
+	sizeBytes   int64
+	uploadedBy  value.UserID
+	uploadedAt  time.Time
+}
+
+// NewAttachment creates a new Attachment
+func NewAttachment(
+	taskID value.TaskID,
+	fileName, contentType string,
+	sizeBytes int64,
+	uploadedBy value.UserID,
+) (*Attachment, error) {
+	fileName = strings.TrimSpace(fileName)
+	if fileName == "" {
+		return nil, errs.Invalid("attachment file name cannot be empty")
+	}
+
+	if len(fileName) > maxAttachmentFileNameLength {
+		return nil, errs.Invalid("attachment file name cannot exceed %d characters", maxAttachmentFileNameLength)
+	}
+
+	if strings.ContainsAny(fileName, "/\\") {
+		return nil, errs.Invalid("attachment file name cannot contain path separators")
+	}
+
+	if sizeBytes < 0 {
+		return nil, errs.Invalid("attachment size cannot be negative")
+	}
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &Attachment{
+		id:          uuid.New().String(),
+		taskID:      taskID,
+		fileName:    fileName,
+		contentType: contentType,
+		sizeBytes:   sizeBytes,
+		uploadedBy:  uploadedBy,
+		uploadedAt:  time.Now(),
+	}, nil
+}
+
+// RestoreAttachment rebuilds a previously stored Attachment without re-validating it
+func RestoreAttachment(
+	id string,
+	taskID value.TaskID,
+	fileName, contentType string,
+	sizeBytes int64,
+	uploadedBy value.UserID,
+	uploadedAt time.Time,
+) *Attachment {
+	return &Attachment{
+		id:          id,
+		taskID:      taskID,
+		fileName:    fileName,
+		contentType: contentType,
+		sizeBytes:   sizeBytes,
+		uploadedBy:  uploadedBy,
+		uploadedAt:  uploadedAt,
+	}
+}
+
+// ID returns the attachment ID
+func (a *Attachment) ID() string {
+	return a.id
+}
+
+// TaskID returns the task the file is attached to
+func (a *Attachment) TaskID() value.TaskID {
+	return a.taskID
+}
+
+// FileName returns the file name
+func (a *Attachment) FileName() string {
+	return a.fileName
+}
+
+// ContentType returns the MIME type of the file
+func (a *Attachment) ContentType() string {
+	return a.contentType
+}
+
+// SizeBytes returns the file size in bytes
+func (a *Attachment) SizeBytes() int64 {
+	return a.sizeBytes
+}
+
+// UploadedBy returns the user who attached the file
+func (a *Attachment) UploadedBy() value.UserID {
+	return a.uploadedBy
+}
+
+// UploadedAt returns when the file was attached
+func (a *Attachment) UploadedAt() time.Time {
+	return a.uploadedAt
+}
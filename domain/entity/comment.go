@@ -1,11 +1,11 @@
 package entity
 
 import (
-	"fmt"
 	"time"
 
-	"github.com/miladev95/ddd-task/domain/value"
 	"github.com/google/uuid"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
 )
 
 // Comment represents a comment on a task
@@ -14,6 +14,7 @@ type Comment struct {
 	taskID    value.TaskID
 	authorID  value.UserID
 	content   string
+	version   int // starts at 1 and goes up with every edit
 	createdAt time.Time
 	updatedAt time.Time
 }
@@ -21,7 +22,7 @@ type Comment struct {
 // NewComment creates a new Comment
 func NewComment(taskID value.TaskID, authorID value.UserID, content string) (*Comment, error) {
 	if content == "" {
-		return nil, fmt.Errorf("comment content cannot be empty")
+		return nil, errs.Invalid("comment content cannot be empty")
 	}
 
 	return &Comment{
@@ -29,11 +30,29 @@ func NewComment(taskID value.TaskID, authorID value.UserID, content string) (*Co
 		taskID:    taskID,
 		authorID:  authorID,
 		content:   content,
+		version:   1,
 		createdAt: time.Now(),
 		updatedAt: time.Now(),
 	}, nil
 }
 
+// RestoreComment rebuilds a previously stored Comment without re-validating it
+func RestoreComment(id string, taskID value.TaskID, authorID value.UserID, content string, version int, createdAt, updatedAt time.Time) *Comment {
+	if version < 1 {
+		version = 1
+	}
+
+	return &Comment{
+		id:        id,
+		taskID:    taskID,
+		authorID:  authorID,
+		content:   content,
+		version:   version,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
 // ID returns the comment ID
 func (c *Comment) ID() string {
 	return c.id
@@ -54,6 +73,11 @@ func (c *Comment) Content() string {
 	return c.content
 }
 
+// Version returns the edit version of the content, 1 until the comment is first edited
+func (c *Comment) Version() int {
+	return c.version
+}
+
 // CreatedAt returns the creation timestamp
 func (c *Comment) CreatedAt() time.Time {
 	return c.createdAt
@@ -67,9 +91,10 @@ func (c *Comment) UpdatedAt() time.Time {
 // Update updates the comment content
 func (c *Comment) Update(newContent string) error {
 	if newContent == "" {
-		return fmt.Errorf("comment content cannot be empty")
+		return errs.Invalid("comment content cannot be empty")
 	}
 	c.content = newContent
+	c.version++
 	c.updatedAt = time.Now()
 	return nil
 }
\ No newline at end of file
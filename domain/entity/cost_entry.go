@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// CostEntry records money spent on a task
+type CostEntry struct {
+	id          string
+	taskID      value.TaskID
+	amount      value.Money
+	description string
+	recordedBy  value.UserID
+	incurredAt  time.Time
+	createdAt   time.Time
+}
+
+// NewCostEntry creates a new CostEntry
+func NewCostEntry(
+	taskID value.TaskID,
+	amount value.Money,
+	description string,
+	recordedBy value.UserID,
+	incurredAt time.Time,
+) (*CostEntry, error) {
+	if amount.IsNegative() || amount.MinorUnits() == 0 {
+		return nil, errs.Invalid("cost amount must be positive")
+	}
+
+	if incurredAt.IsZero() {
+		incurredAt = time.Now()
+	}
+
+	return &CostEntry{
+		id:          uuid.New().String(),
+		taskID:      taskID,
+		amount:      amount,
+		description: description,
+		recordedBy:  recordedBy,
+		incurredAt:  incurredAt,
+		createdAt:   time.Now(),
+	}, nil
+}
+
+// RestoreCostEntry rebuilds a previously stored CostEntry without re-validating it
+func RestoreCostEntry(
+	id string,
+	taskID value.TaskID,
+	amount value.Money,
+	description string,
+	recordedBy value.UserID,
+	incurredAt, createdAt time.Time,
+) *CostEntry {
+	return &CostEntry{
+		id:          id,
+		taskID:      taskID,
+		amount:      amount,
+		description: description,
+		recordedBy:  recordedBy,
+		incurredAt:  incurredAt,
+		createdAt:   createdAt,
+	}
+}
+
+// ID returns the cost entry ID
+func (c *CostEntry) ID() string {
+	return c.id
+}
+
+// TaskID returns the task the cost was spent on
+func (c *CostEntry) TaskID() value.TaskID {
+	return c.taskID
+}
+
+// Amount returns the amount spent
+func (c *CostEntry) Amount() value.Money {
+	return c.amount
+}
+
+// Description returns what the money was spent on
+func (c *CostEntry) Description() string {
+	return c.description
+}
+
+// RecordedBy returns the user who recorded the cost
+func (c *CostEntry) RecordedBy() value.UserID {
+	return c.recordedBy
+}
+
+// IncurredAt returns when the cost was incurred
+func (c *CostEntry) IncurredAt() time.Time {
+	return c.incurredAt
+}
+
+// CreatedAt returns when the cost was recorded
+func (c *CostEntry) CreatedAt() time.Time {
+	return c.createdAt
+}
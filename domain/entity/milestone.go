@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Milestone represents a dated checkpoint within a project, tracked through its tasks
+type Milestone struct {
+	id          string
+	name        string
+	description string
+	dueDate     time.Time
+	taskIDs     []value.TaskID
+	reachedAt   *time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewMilestone creates a new Milestone
+func NewMilestone(name, description string, dueDate time.Time, taskIDs []value.TaskID) (*Milestone, error) {
+	if name == "" {
+		return nil, errs.Invalid("milestone name cannot be empty")
+	}
+
+	if dueDate.IsZero() {
+		return nil, errs.Invalid("milestone due date is required")
+	}
+
+	return &Milestone{
+		id:          uuid.New().String(),
+		name:        name,
+		description: description,
+		dueDate:     dueDate,
+		taskIDs:     uniqueTaskIDs(taskIDs),
+		createdAt:   time.Now(),
+		updatedAt:   time.Now(),
+	}, nil
+}
+
+// RestoreMilestone rebuilds a previously stored Milestone without re-validating it
+func RestoreMilestone(
+	id, name, description string,
+	dueDate time.Time,
+	taskIDs []value.TaskID,
+	reachedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) *Milestone {
+	return &Milestone{
+		id:          id,
+		name:        name,
+		description: description,
+		dueDate:     dueDate,
+		taskIDs:     append([]value.TaskID{}, taskIDs...),
+		reachedAt:   reachedAt,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// ID returns the milestone ID
+func (m *Milestone) ID() string {
+	return m.id
+}
+
+// Name returns the milestone name
+func (m *Milestone) Name() string {
+	return m.name
+}
+
+// Description returns the milestone description
+func (m *Milestone) Description() string {
+	return m.description
+}
+
+// DueDate returns the milestone due date
+func (m *Milestone) DueDate() time.Time {
+	return m.dueDate
+}
+
+// TaskIDs returns the tasks associated with the milestone
+func (m *Milestone) TaskIDs() []value.TaskID {
+	return append([]value.TaskID{}, m.taskIDs...)
+}
+
+// ReachedAt returns when the milestone was reached, if it has been
+func (m *Milestone) ReachedAt() *time.Time {
+	return m.reachedAt
+}
+
+// IsReached checks if the milestone has been reached
+func (m *Milestone) IsReached() bool {
+	return m.reachedAt != nil
+}
+
+// CreatedAt returns the creation timestamp
+func (m *Milestone) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// UpdatedAt returns the last update timestamp
+func (m *Milestone) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// Update replaces the milestone details. Changing the task set reopens a reached milestone
+// so it is evaluated again.
+func (m *Milestone) Update(name, description string, dueDate time.Time, taskIDs []value.TaskID) error {
+	if name == "" {
+		return errs.Invalid("milestone name cannot be empty")
+	}
+
+	if dueDate.IsZero() {
+		return errs.Invalid("milestone due date is required")
+	}
+
+	newTaskIDs := uniqueTaskIDs(taskIDs)
+	if !sameTaskIDs(m.taskIDs, newTaskIDs) {
+		m.reachedAt = nil
+	}
+
+	m.name = name
+	m.description = description
+	m.dueDate = dueDate
+	m.taskIDs = newTaskIDs
+	m.updatedAt = time.Now()
+
+	return nil
+}
+
+// MarkReached records when the milestone was reached
+func (m *Milestone) MarkReached(at time.Time) error {
+	if m.reachedAt != nil {
+		return errs.Conflict("milestone already reached")
+	}
+
+	m.reachedAt = &at
+	m.updatedAt = time.Now()
+
+	return nil
+}
+
+func uniqueTaskIDs(taskIDs []value.TaskID) []value.TaskID {
+	seen := make(map[string]bool, len(taskIDs))
+	unique := make([]value.TaskID, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		if seen[id.Value()] {
+			continue
+		}
+		seen[id.Value()] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func sameTaskIDs(a, b []value.TaskID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, id := range a {
+		set[id.Value()] = true
+	}
+	for _, id := range b {
+		if !set[id.Value()] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// TaskLink represents a typed relationship from one task to another
+type TaskLink struct {
+	targetTaskID value.TaskID
+	linkType     value.LinkType
+	createdAt    time.Time
+	createdBy    value.UserID
+}
+
+// NewTaskLink creates a new TaskLink
+func NewTaskLink(targetTaskID value.TaskID, linkType value.LinkType, createdBy value.UserID) (*TaskLink, error) {
+	if targetTaskID.Equals(value.TaskID{}) {
+		return nil, errs.Invalid("link target cannot be empty")
+	}
+
+	if !linkType.IsValid() {
+		return nil, errs.Invalid("invalid link type: %s", linkType.Value())
+	}
+
+	return &TaskLink{
+		targetTaskID: targetTaskID,
+		linkType:     linkType,
+		createdAt:    time.Now(),
+		createdBy:    createdBy,
+	}, nil
+}
+
+// RestoreTaskLink rebuilds a previously stored TaskLink without re-validating it
+func RestoreTaskLink(targetTaskID value.TaskID, linkType value.LinkType, createdAt time.Time, createdBy value.UserID) *TaskLink {
+	return &TaskLink{
+		targetTaskID: targetTaskID,
+		linkType:     linkType,
+		createdAt:    createdAt,
+		createdBy:    createdBy,
+	}
+}
+
+// TargetTaskID returns the linked task ID
+func (l *TaskLink) TargetTaskID() value.TaskID {
+	return l.targetTaskID
+}
+
+// LinkType returns the link type
+func (l *TaskLink) LinkType() value.LinkType {
+	return l.linkType
+}
+
+// CreatedAt returns when the link was created
+func (l *TaskLink) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// CreatedBy returns who created the link
+func (l *TaskLink) CreatedBy() value.UserID {
+	return l.createdBy
+}
+
+// Matches checks if the link points to a task with a specific type
+func (l *TaskLink) Matches(targetTaskID value.TaskID, linkType value.LinkType) bool {
+	return l.targetTaskID.Equals(targetTaskID) && l.linkType == linkType
+}
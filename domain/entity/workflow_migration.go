@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// WorkflowMigrationStatus is where a workflow migration stands
+type WorkflowMigrationStatus string
+
+const (
+	WorkflowMigrationPlanned    WorkflowMigrationStatus = "PLANNED"     // validated and recorded, no task moved yet
+	WorkflowMigrationApplying   WorkflowMigrationStatus = "APPLYING"    // some batches applied, resumable
+	WorkflowMigrationApplied    WorkflowMigrationStatus = "APPLIED"     // every task moved and the project switched
+	WorkflowMigrationRolledBack WorkflowMigrationStatus = "ROLLED_BACK" // moved tasks restored from the plan
+)
+
+// NewWorkflowMigrationStatus creates a WorkflowMigrationStatus from string
+func NewWorkflowMigrationStatus(status string) (WorkflowMigrationStatus, error) {
+	switch WorkflowMigrationStatus(status) {
+	case WorkflowMigrationPlanned, WorkflowMigrationApplying, WorkflowMigrationApplied, WorkflowMigrationRolledBack:
+		return WorkflowMigrationStatus(status), nil
+	default:
+		return "", errs.Invalid("invalid workflow migration status: %s", status)
+	}
+}
+
+// WorkflowMigrationStep is the recorded move of one task, with what it is restored to on rollback
+type WorkflowMigrationStep struct {
+	TaskID      value.TaskID
+	FromVersion value.WorkflowVersion
+	FromStatus  value.ScopedStatus
+	ToStatus    value.ScopedStatus
+}
+
+// WorkflowMigration is the recorded plan for moving a project's tasks onto another workflow.
+// Steps are applied in order, so the applied count marks how far the migration got.
+type WorkflowMigration struct {
+	id             string
+	fromWorkflowID value.WorkflowID
+	toVersion      value.WorkflowVersion
+	steps          []WorkflowMigrationStep
+	applied        int
+	status         WorkflowMigrationStatus
+	plannedBy      value.UserID
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewWorkflowMigration creates a new WorkflowMigration plan
+func NewWorkflowMigration(
+	fromWorkflowID value.WorkflowID,
+	toVersion value.WorkflowVersion,
+	steps []WorkflowMigrationStep,
+	plannedBy value.UserID,
+) (*WorkflowMigration, error) {
+	if fromWorkflowID.Equals(toVersion.WorkflowID()) {
+		return nil, errs.Conflict("project already uses this workflow")
+	}
+
+	return &WorkflowMigration{
+		id:             uuid.New().String(),
+		fromWorkflowID: fromWorkflowID,
+		toVersion:      toVersion,
+		steps:          append([]WorkflowMigrationStep{}, steps...),
+		status:         WorkflowMigrationPlanned,
+		plannedBy:      plannedBy,
+		createdAt:      time.Now(),
+		updatedAt:      time.Now(),
+	}, nil
+}
+
+// RestoreWorkflowMigration rebuilds a previously stored WorkflowMigration without re-validating it
+func RestoreWorkflowMigration(
+	id string,
+	fromWorkflowID value.WorkflowID,
+	toVersion value.WorkflowVersion,
+	steps []WorkflowMigrationStep,
+	applied int,
+	status WorkflowMigrationStatus,
+	plannedBy value.UserID,
+	createdAt, updatedAt time.Time,
+) *WorkflowMigration {
+	return &WorkflowMigration{
+		id:             id,
+		fromWorkflowID: fromWorkflowID,
+		toVersion:      toVersion,
+		steps:          append([]WorkflowMigrationStep{}, steps...),
+		applied:        applied,
+		status:         status,
+		plannedBy:      plannedBy,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}
+}
+
+// ID returns the migration ID
+func (m *WorkflowMigration) ID() string {
+	return m.id
+}
+
+// FromWorkflowID returns the workflow the project leaves
+func (m *WorkflowMigration) FromWorkflowID() value.WorkflowID {
+	return m.fromWorkflowID
+}
+
+// ToVersion returns the workflow version the tasks move to
+func (m *WorkflowMigration) ToVersion() value.WorkflowVersion {
+	return m.toVersion
+}
+
+// Steps returns the planned move of every task
+func (m *WorkflowMigration) Steps() []WorkflowMigrationStep {
+	return append([]WorkflowMigrationStep{}, m.steps...)
+}
+
+// Total returns how many tasks the migration moves
+func (m *WorkflowMigration) Total() int {
+	return len(m.steps)
+}
+
+// Applied returns how many tasks have been moved so far
+func (m *WorkflowMigration) Applied() int {
+	return m.applied
+}
+
+// Status returns where the migration stands
+func (m *WorkflowMigration) Status() WorkflowMigrationStatus {
+	return m.status
+}
+
+// PlannedBy returns the user who planned the migration
+func (m *WorkflowMigration) PlannedBy() value.UserID {
+	return m.plannedBy
+}
+
+// CreatedAt returns when the migration was planned
+func (m *WorkflowMigration) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// UpdatedAt returns when the migration last progressed
+func (m *WorkflowMigration) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// IsInProgress checks if tasks have been moved without the migration being finished or rolled back
+func (m *WorkflowMigration) IsInProgress() bool {
+	return m.status == WorkflowMigrationApplying
+}
+
+// Pending returns the steps not applied yet, in order
+func (m *WorkflowMigration) Pending() []WorkflowMigrationStep {
+	return append([]WorkflowMigrationStep{}, m.steps[m.applied:]...)
+}
+
+// RecordProgress marks the next count steps as applied
+func (m *WorkflowMigration) RecordProgress(count int) error {
+	if m.status != WorkflowMigrationPlanned && m.status != WorkflowMigrationApplying {
+		return errs.Conflict("workflow migration is %s: cannot apply it", m.status)
+	}
+	if count < 0 || m.applied+count > len(m.steps) {
+		return errs.Invalid("invalid workflow migration progress: %d of %d steps left", len(m.steps)-m.applied, len(m.steps))
+	}
+
+	m.applied += count
+	m.status = WorkflowMigrationApplying
+	m.updatedAt = time.Now()
+
+	return nil
+}
+
+// Complete marks the migration as applied once every step is
+func (m *WorkflowMigration) Complete() error {
+	if m.status != WorkflowMigrationPlanned && m.status != WorkflowMigrationApplying {
+		return errs.Conflict("workflow migration is %s: cannot apply it", m.status)
+	}
+	if m.applied != len(m.steps) {
+		return errs.Conflict("workflow migration has %d steps left to apply", len(m.steps)-m.applied)
+	}
+
+	m.status = WorkflowMigrationApplied
+	m.updatedAt = time.Now()
+
+	return nil
+}
+
+// RollBack marks the applied steps as restored
+func (m *WorkflowMigration) RollBack() error {
+	if m.status != WorkflowMigrationApplying && m.status != WorkflowMigrationApplied {
+		return errs.Conflict("workflow migration is %s: only applied migrations can be rolled back", m.status)
+	}
+
+	m.status = WorkflowMigrationRolledBack
+	m.updatedAt = time.Now()
+
+	return nil
+}
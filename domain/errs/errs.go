@@ -0,0 +1,81 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of error the domain and application layers report. Match them with errors.Is;
+// an error of no kind is a failure of the system rather than of the request.
+var (
+	ErrValidation          = errors.New("validation failed")
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict with current state")
+	ErrInvalidTransition   = errors.New("invalid state transition")
+	ErrConcurrencyConflict = errors.New("concurrent modification")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrUnauthenticated     = errors.New("unauthenticated")
+	ErrQuotaExceeded       = errors.New("quota exceeded")
+)
+
+// Error is an error of a kind. It reads as the error it was made from, so giving an error
+// a kind never changes its message, and it unwraps to both.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+// Error returns the message of the underlying error
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error and the kind, so errors.Is and errors.As match either
+func (e *Error) Unwrap() []error {
+	return []error{e.Err, e.Kind}
+}
+
+// Invalid reports input that breaks a rule
+func Invalid(format string, args ...interface{}) error {
+	return newError(ErrValidation, format, args...)
+}
+
+// NotFound reports a resource that does not exist
+func NotFound(format string, args ...interface{}) error {
+	return newError(ErrNotFound, format, args...)
+}
+
+// Conflict reports a request the current state does not allow
+func Conflict(format string, args ...interface{}) error {
+	return newError(ErrConflict, format, args...)
+}
+
+// InvalidTransition reports a status change the workflow does not allow
+func InvalidTransition(format string, args ...interface{}) error {
+	return newError(ErrInvalidTransition, format, args...)
+}
+
+// ConcurrencyConflict reports a change that collides with another one in progress
+func ConcurrencyConflict(format string, args ...interface{}) error {
+	return newError(ErrConcurrencyConflict, format, args...)
+}
+
+// PermissionDenied reports a caller who may not do what they asked
+func PermissionDenied(format string, args ...interface{}) error {
+	return newError(ErrPermissionDenied, format, args...)
+}
+
+// Unauthenticated reports credentials or tokens that do not identify a caller
+func Unauthenticated(format string, args ...interface{}) error {
+	return newError(ErrUnauthenticated, format, args...)
+}
+
+// QuotaExceeded reports a request beyond an organization's quota
+func QuotaExceeded(format string, args ...interface{}) error {
+	return newError(ErrQuotaExceeded, format, args...)
+}
+
+// newError formats an error of a kind; like fmt.Errorf, %w wraps an argument
+func newError(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
@@ -1,9 +1,14 @@
 package event
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // DomainEvent is the interface that all domain events must implement
 type DomainEvent interface {
+	EventID() string
 	EventType() string
 	OccurredAt() time.Time
 	AggregateID() string
@@ -12,6 +17,7 @@ type DomainEvent interface {
 
 // BaseDomainEvent provides common functionality for domain events
 type BaseDomainEvent struct {
+	eventID       string
 	eventType     string
 	occurredAt    time.Time
 	aggregateID   string
@@ -21,6 +27,7 @@ type BaseDomainEvent struct {
 // NewBaseDomainEvent creates a new base domain event
 func NewBaseDomainEvent(eventType, aggregateID, aggregateType string) BaseDomainEvent {
 	return BaseDomainEvent{
+		eventID:       uuid.NewString(),
 		eventType:     eventType,
 		occurredAt:    time.Now(),
 		aggregateID:   aggregateID,
@@ -28,6 +35,12 @@ func NewBaseDomainEvent(eventType, aggregateID, aggregateType string) BaseDomain
 	}
 }
 
+// EventID returns the identifier that tells this event apart from every other,
+// empty for events restored from storage written before events had one
+func (b BaseDomainEvent) EventID() string {
+	return b.eventID
+}
+
 // EventType returns the event type
 func (b BaseDomainEvent) EventType() string {
 	return b.eventType
@@ -46,4 +59,21 @@ func (b BaseDomainEvent) AggregateID() string {
 // AggregateType returns the aggregate type
 func (b BaseDomainEvent) AggregateType() string {
 	return b.aggregateType
-}
\ No newline at end of file
+}
+
+// RestoreBaseDomainEvent rebuilds the base of an event read back from storage,
+// keeping its original occurrence time
+func RestoreBaseDomainEvent(eventType, aggregateID, aggregateType string, occurredAt time.Time) BaseDomainEvent {
+	return BaseDomainEvent{
+		eventType:     eventType,
+		occurredAt:    occurredAt,
+		aggregateID:   aggregateID,
+		aggregateType: aggregateType,
+	}
+}
+
+// WithEventID returns the base carrying the given event identifier, for events read back from storage
+func (b BaseDomainEvent) WithEventID(eventID string) BaseDomainEvent {
+	b.eventID = eventID
+	return b
+}
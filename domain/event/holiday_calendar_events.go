@@ -0,0 +1,37 @@
+package event
+
+// HolidayCalendarCreatedEvent is fired when an organization sets up the holidays of a region
+type HolidayCalendarCreatedEvent struct {
+	BaseDomainEvent
+	OrganizationID string
+	Region         string
+	Name           string
+	Holidays       []string
+}
+
+// NewHolidayCalendarCreatedEvent creates a new HolidayCalendarCreatedEvent
+func NewHolidayCalendarCreatedEvent(calendarID, organizationID, region, name string, holidays []string) HolidayCalendarCreatedEvent {
+	return HolidayCalendarCreatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("HolidayCalendarCreated", calendarID, "HolidayCalendar"),
+		OrganizationID:  organizationID,
+		Region:          region,
+		Name:            name,
+		Holidays:        holidays,
+	}
+}
+
+// HolidayCalendarChangedEvent is fired when a holiday calendar is renamed or its holidays replaced
+type HolidayCalendarChangedEvent struct {
+	BaseDomainEvent
+	Name     string
+	Holidays []string
+}
+
+// NewHolidayCalendarChangedEvent creates a new HolidayCalendarChangedEvent
+func NewHolidayCalendarChangedEvent(calendarID, name string, holidays []string) HolidayCalendarChangedEvent {
+	return HolidayCalendarChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("HolidayCalendarChanged", calendarID, "HolidayCalendar"),
+		Name:            name,
+		Holidays:        holidays,
+	}
+}
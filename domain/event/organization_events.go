@@ -0,0 +1,68 @@
+package event
+
+// OrganizationCreatedEvent is fired when an organization is set up
+type OrganizationCreatedEvent struct {
+	BaseDomainEvent
+	Name      string
+	MemberIDs []string
+}
+
+// NewOrganizationCreatedEvent creates a new OrganizationCreatedEvent
+func NewOrganizationCreatedEvent(organizationID, name string, memberIDs []string) OrganizationCreatedEvent {
+	return OrganizationCreatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("OrganizationCreated", organizationID, "Organization"),
+		Name:            name,
+		MemberIDs:       memberIDs,
+	}
+}
+
+// OrganizationMemberAddedEvent is fired when a user joins an organization
+type OrganizationMemberAddedEvent struct {
+	BaseDomainEvent
+	UserID string
+}
+
+// NewOrganizationMemberAddedEvent creates a new OrganizationMemberAddedEvent
+func NewOrganizationMemberAddedEvent(organizationID, userID string) OrganizationMemberAddedEvent {
+	return OrganizationMemberAddedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("OrganizationMemberAdded", organizationID, "Organization"),
+		UserID:          userID,
+	}
+}
+
+// OrganizationQuotaChangedEvent is fired when an organization's limits change. Zero means unlimited.
+type OrganizationQuotaChangedEvent struct {
+	BaseDomainEvent
+	MaxProjects        int
+	MaxTasks           int
+	MaxAttachmentBytes int64
+	APICallsPerMinute  int
+}
+
+// NewOrganizationQuotaChangedEvent creates a new OrganizationQuotaChangedEvent
+func NewOrganizationQuotaChangedEvent(organizationID string, maxProjects, maxTasks int, maxAttachmentBytes int64, apiCallsPerMinute int) OrganizationQuotaChangedEvent {
+	return OrganizationQuotaChangedEvent{
+		BaseDomainEvent:    NewBaseDomainEvent("OrganizationQuotaChanged", organizationID, "Organization"),
+		MaxProjects:        maxProjects,
+		MaxTasks:           maxTasks,
+		MaxAttachmentBytes: maxAttachmentBytes,
+		APICallsPerMinute:  apiCallsPerMinute,
+	}
+}
+
+// OrganizationWorkingHoursChangedEvent is fired when the working hours an organization's
+// members default to change. No working days means the built-in default applies again.
+type OrganizationWorkingHoursChangedEvent struct {
+	BaseDomainEvent
+	HoursPerDay float64
+	WorkingDays []string
+}
+
+// NewOrganizationWorkingHoursChangedEvent creates a new OrganizationWorkingHoursChangedEvent
+func NewOrganizationWorkingHoursChangedEvent(organizationID string, hoursPerDay float64, workingDays []string) OrganizationWorkingHoursChangedEvent {
+	return OrganizationWorkingHoursChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("OrganizationWorkingHoursChanged", organizationID, "Organization"),
+		HoursPerDay:     hoursPerDay,
+		WorkingDays:     workingDays,
+	}
+}
@@ -0,0 +1,409 @@
+package event
+
+// ProjectCreatedEvent is fired when a new project is created
+type ProjectCreatedEvent struct {
+	BaseDomainEvent
+	Name       string
+	OwnerID    string
+	WorkflowID string
+}
+
+// NewProjectCreatedEvent creates a new ProjectCreatedEvent
+func NewProjectCreatedEvent(projectID, name, ownerID, workflowID string) ProjectCreatedEvent {
+	return ProjectCreatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectCreated", projectID, "Project"),
+		Name:            name,
+		OwnerID:         ownerID,
+		WorkflowID:      workflowID,
+	}
+}
+
+// MilestoneReachedEvent is fired when every task of a project milestone is completed
+type MilestoneReachedEvent struct {
+	BaseDomainEvent
+	MilestoneID string
+	Name        string
+	DueDate     string // ISO 8601 format
+	ReachedAt   string // ISO 8601 format
+	Late        bool
+}
+
+// NewMilestoneReachedEvent creates a new MilestoneReachedEvent
+func NewMilestoneReachedEvent(projectID, milestoneID, name, dueDate, reachedAt string, late bool) MilestoneReachedEvent {
+	return MilestoneReachedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("MilestoneReached", projectID, "Project"),
+		MilestoneID:     milestoneID,
+		Name:            name,
+		DueDate:         dueDate,
+		ReachedAt:       reachedAt,
+		Late:            late,
+	}
+}
+
+// ProjectArchivedEvent is fired when a project is archived along with its open tasks
+type ProjectArchivedEvent struct {
+	BaseDomainEvent
+	Policy          string
+	AffectedTaskIDs []string
+}
+
+// NewProjectArchivedEvent creates a new ProjectArchivedEvent
+func NewProjectArchivedEvent(projectID, policy string, affectedTaskIDs []string) ProjectArchivedEvent {
+	return ProjectArchivedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectArchived", projectID, "Project"),
+		Policy:          policy,
+		AffectedTaskIDs: affectedTaskIDs,
+	}
+}
+
+// ProjectDeletedEvent is fired when a project is deleted along with its tasks
+type ProjectDeletedEvent struct {
+	BaseDomainEvent
+	Name           string
+	DeletedTaskIDs []string
+}
+
+// NewProjectDeletedEvent creates a new ProjectDeletedEvent
+func NewProjectDeletedEvent(projectID, name string, deletedTaskIDs []string) ProjectDeletedEvent {
+	return ProjectDeletedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectDeleted", projectID, "Project"),
+		Name:            name,
+		DeletedTaskIDs:  deletedTaskIDs,
+	}
+}
+
+// ProjectRenamedEvent is fired when a project's name changes
+type ProjectRenamedEvent struct {
+	BaseDomainEvent
+	OldName string
+	NewName string
+}
+
+// NewProjectRenamedEvent creates a new ProjectRenamedEvent
+func NewProjectRenamedEvent(projectID, oldName, newName string) ProjectRenamedEvent {
+	return ProjectRenamedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectRenamed", projectID, "Project"),
+		OldName:         oldName,
+		NewName:         newName,
+	}
+}
+
+// ProjectDescriptionUpdatedEvent is fired when a project's description changes
+type ProjectDescriptionUpdatedEvent struct {
+	BaseDomainEvent
+	Description string
+}
+
+// NewProjectDescriptionUpdatedEvent creates a new ProjectDescriptionUpdatedEvent
+func NewProjectDescriptionUpdatedEvent(projectID, description string) ProjectDescriptionUpdatedEvent {
+	return ProjectDescriptionUpdatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectDescriptionUpdated", projectID, "Project"),
+		Description:     description,
+	}
+}
+
+// TaskAddedToProjectEvent is fired when a task joins a project
+type TaskAddedToProjectEvent struct {
+	BaseDomainEvent
+	TaskID string
+}
+
+// NewTaskAddedToProjectEvent creates a new TaskAddedToProjectEvent
+func NewTaskAddedToProjectEvent(projectID, taskID string) TaskAddedToProjectEvent {
+	return TaskAddedToProjectEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskAddedToProject", projectID, "Project"),
+		TaskID:          taskID,
+	}
+}
+
+// TaskRemovedFromProjectEvent is fired when a task leaves a project
+type TaskRemovedFromProjectEvent struct {
+	BaseDomainEvent
+	TaskID string
+}
+
+// NewTaskRemovedFromProjectEvent creates a new TaskRemovedFromProjectEvent
+func NewTaskRemovedFromProjectEvent(projectID, taskID string) TaskRemovedFromProjectEvent {
+	return TaskRemovedFromProjectEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskRemovedFromProject", projectID, "Project"),
+		TaskID:          taskID,
+	}
+}
+
+// ProjectUnarchivedEvent is fired when an archived project is reopened
+type ProjectUnarchivedEvent struct {
+	BaseDomainEvent
+}
+
+// NewProjectUnarchivedEvent creates a new ProjectUnarchivedEvent
+func NewProjectUnarchivedEvent(projectID string) ProjectUnarchivedEvent {
+	return ProjectUnarchivedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectUnarchived", projectID, "Project"),
+	}
+}
+
+// ProjectSLOTargetsChangedEvent is fired when a project's SLO targets are configured
+type ProjectSLOTargetsChangedEvent struct {
+	BaseDomainEvent
+	FirstResponseSeconds int64
+	ResolutionSeconds    int64
+}
+
+// NewProjectSLOTargetsChangedEvent creates a new ProjectSLOTargetsChangedEvent
+func NewProjectSLOTargetsChangedEvent(projectID string, firstResponseSeconds, resolutionSeconds int64) ProjectSLOTargetsChangedEvent {
+	return ProjectSLOTargetsChangedEvent{
+		BaseDomainEvent:      NewBaseDomainEvent("ProjectSLOTargetsChanged", projectID, "Project"),
+		FirstResponseSeconds: firstResponseSeconds,
+		ResolutionSeconds:    resolutionSeconds,
+	}
+}
+
+// ProjectSettingsChangedEvent is fired when a project's settings are updated
+type ProjectSettingsChangedEvent struct {
+	BaseDomainEvent
+	DefaultPriority         string
+	DefaultAssigneeID       string
+	RequireDeadlineOnCreate bool
+	AllowComments           bool
+}
+
+// NewProjectSettingsChangedEvent creates a new ProjectSettingsChangedEvent
+func NewProjectSettingsChangedEvent(
+	projectID, defaultPriority, defaultAssigneeID string,
+	requireDeadlineOnCreate, allowComments bool,
+) ProjectSettingsChangedEvent {
+	return ProjectSettingsChangedEvent{
+		BaseDomainEvent:         NewBaseDomainEvent("ProjectSettingsChanged", projectID, "Project"),
+		DefaultPriority:         defaultPriority,
+		DefaultAssigneeID:       defaultAssigneeID,
+		RequireDeadlineOnCreate: requireDeadlineOnCreate,
+		AllowComments:           allowComments,
+	}
+}
+
+// ProjectBudgetChangedEvent is fired when a project's budget is set or cleared
+type ProjectBudgetChangedEvent struct {
+	BaseDomainEvent
+	Amount   string // decimal amount, empty when the budget is cleared
+	Currency string
+}
+
+// NewProjectBudgetChangedEvent creates a new ProjectBudgetChangedEvent
+func NewProjectBudgetChangedEvent(projectID, amount, currency string) ProjectBudgetChangedEvent {
+	return ProjectBudgetChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectBudgetChanged", projectID, "Project"),
+		Amount:          amount,
+		Currency:        currency,
+	}
+}
+
+// ProjectHolidayCalendarChangedEvent is fired when a project starts or stops following a holiday calendar
+type ProjectHolidayCalendarChangedEvent struct {
+	BaseDomainEvent
+	HolidayCalendarID string // empty when the project stops observing holidays
+}
+
+// NewProjectHolidayCalendarChangedEvent creates a new ProjectHolidayCalendarChangedEvent
+func NewProjectHolidayCalendarChangedEvent(projectID, holidayCalendarID string) ProjectHolidayCalendarChangedEvent {
+	return ProjectHolidayCalendarChangedEvent{
+		BaseDomainEvent:   NewBaseDomainEvent("ProjectHolidayCalendarChanged", projectID, "Project"),
+		HolidayCalendarID: holidayCalendarID,
+	}
+}
+
+// ProjectPrioritySchemeChangedEvent is fired when a project's priority ladder is replaced
+type ProjectPrioritySchemeChangedEvent struct {
+	BaseDomainEvent
+	Priorities      []string // least urgent first
+	Ranks           []int    // rank of each priority
+	DefaultPriority string
+}
+
+// NewProjectPrioritySchemeChangedEvent creates a new ProjectPrioritySchemeChangedEvent
+func NewProjectPrioritySchemeChangedEvent(projectID string, priorities []string, ranks []int, defaultPriority string) ProjectPrioritySchemeChangedEvent {
+	return ProjectPrioritySchemeChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectPrioritySchemeChanged", projectID, "Project"),
+		Priorities:      priorities,
+		Ranks:           ranks,
+		DefaultPriority: defaultPriority,
+	}
+}
+
+// ProjectInboxAddressAddedEvent is fired when a project gets a new inbound email address.
+// The token is left out, anyone reading events could otherwise mail tasks into the project.
+type ProjectInboxAddressAddedEvent struct {
+	BaseDomainEvent
+	Label string
+}
+
+// NewProjectInboxAddressAddedEvent creates a new ProjectInboxAddressAddedEvent
+func NewProjectInboxAddressAddedEvent(projectID, label string) ProjectInboxAddressAddedEvent {
+	return ProjectInboxAddressAddedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectInboxAddressAdded", projectID, "Project"),
+		Label:           label,
+	}
+}
+
+// ProjectInboxAddressRevokedEvent is fired when an inbound email address stops feeding a project
+type ProjectInboxAddressRevokedEvent struct {
+	BaseDomainEvent
+	Label string
+}
+
+// NewProjectInboxAddressRevokedEvent creates a new ProjectInboxAddressRevokedEvent
+func NewProjectInboxAddressRevokedEvent(projectID, label string) ProjectInboxAddressRevokedEvent {
+	return ProjectInboxAddressRevokedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectInboxAddressRevoked", projectID, "Project"),
+		Label:           label,
+	}
+}
+
+// BudgetExceededEvent is fired when a project's spend crosses its budget
+type BudgetExceededEvent struct {
+	BaseDomainEvent
+	Budget   string // decimal amount
+	Spent    string // decimal amount
+	Currency string
+}
+
+// NewBudgetExceededEvent creates a new BudgetExceededEvent
+func NewBudgetExceededEvent(projectID, budget, spent, currency string) BudgetExceededEvent {
+	return BudgetExceededEvent{
+		BaseDomainEvent: NewBaseDomainEvent("BudgetExceeded", projectID, "Project"),
+		Budget:          budget,
+		Spent:           spent,
+		Currency:        currency,
+	}
+}
+
+// ProjectAccessChangedEvent is fired when a project's visibility or members change
+type ProjectAccessChangedEvent struct {
+	BaseDomainEvent
+	Visibility string
+	MemberIDs  []string
+}
+
+// NewProjectAccessChangedEvent creates a new ProjectAccessChangedEvent
+func NewProjectAccessChangedEvent(projectID, visibility string, memberIDs []string) ProjectAccessChangedEvent {
+	return ProjectAccessChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectAccessChanged", projectID, "Project"),
+		Visibility:      visibility,
+		MemberIDs:       memberIDs,
+	}
+}
+
+// ProjectRoleAssignedEvent is fired when a user is given a role in a project
+type ProjectRoleAssignedEvent struct {
+	BaseDomainEvent
+	UserID string
+	Role   string
+}
+
+// NewProjectRoleAssignedEvent creates a new ProjectRoleAssignedEvent
+func NewProjectRoleAssignedEvent(projectID, userID, role string) ProjectRoleAssignedEvent {
+	return ProjectRoleAssignedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectRoleAssigned", projectID, "Project"),
+		UserID:          userID,
+		Role:            role,
+	}
+}
+
+// ProjectRoleRevokedEvent is fired when a user's role in a project is taken away
+type ProjectRoleRevokedEvent struct {
+	BaseDomainEvent
+	UserID string
+}
+
+// NewProjectRoleRevokedEvent creates a new ProjectRoleRevokedEvent
+func NewProjectRoleRevokedEvent(projectID, userID string) ProjectRoleRevokedEvent {
+	return ProjectRoleRevokedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectRoleRevoked", projectID, "Project"),
+		UserID:          userID,
+	}
+}
+
+// ProjectWorkflowChangedEvent is fired when a project switches to another workflow
+type ProjectWorkflowChangedEvent struct {
+	BaseDomainEvent
+	OldWorkflowID string
+	NewWorkflowID string
+}
+
+// NewProjectWorkflowChangedEvent creates a new ProjectWorkflowChangedEvent
+func NewProjectWorkflowChangedEvent(projectID, oldWorkflowID, newWorkflowID string) ProjectWorkflowChangedEvent {
+	return ProjectWorkflowChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectWorkflowChanged", projectID, "Project"),
+		OldWorkflowID:   oldWorkflowID,
+		NewWorkflowID:   newWorkflowID,
+	}
+}
+
+// ProjectWorkflowMigrationPlannedEvent is fired when a migration onto another workflow is validated and recorded
+type ProjectWorkflowMigrationPlannedEvent struct {
+	BaseDomainEvent
+	MigrationID       string
+	FromWorkflowID    string
+	ToWorkflowVersion string
+	Tasks             int
+}
+
+// NewProjectWorkflowMigrationPlannedEvent creates a new ProjectWorkflowMigrationPlannedEvent
+func NewProjectWorkflowMigrationPlannedEvent(projectID, migrationID, fromWorkflowID, toWorkflowVersion string, tasks int) ProjectWorkflowMigrationPlannedEvent {
+	return ProjectWorkflowMigrationPlannedEvent{
+		BaseDomainEvent:   NewBaseDomainEvent("ProjectWorkflowMigrationPlanned", projectID, "Project"),
+		MigrationID:       migrationID,
+		FromWorkflowID:    fromWorkflowID,
+		ToWorkflowVersion: toWorkflowVersion,
+		Tasks:             tasks,
+	}
+}
+
+// ProjectWorkflowMigrationProgressedEvent is fired after each batch of tasks a workflow migration moves
+type ProjectWorkflowMigrationProgressedEvent struct {
+	BaseDomainEvent
+	MigrationID string
+	Applied     int
+	Total       int
+}
+
+// NewProjectWorkflowMigrationProgressedEvent creates a new ProjectWorkflowMigrationProgressedEvent
+func NewProjectWorkflowMigrationProgressedEvent(projectID, migrationID string, applied, total int) ProjectWorkflowMigrationProgressedEvent {
+	return ProjectWorkflowMigrationProgressedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectWorkflowMigrationProgressed", projectID, "Project"),
+		MigrationID:     migrationID,
+		Applied:         applied,
+		Total:           total,
+	}
+}
+
+// ProjectProgressUpdatedEvent is fired when the number of completed tasks in a project changes
+type ProjectProgressUpdatedEvent struct {
+	BaseDomainEvent
+	Completed int
+	Total     int
+}
+
+// NewProjectProgressUpdatedEvent creates a new ProjectProgressUpdatedEvent
+func NewProjectProgressUpdatedEvent(projectID string, completed, total int) ProjectProgressUpdatedEvent {
+	return ProjectProgressUpdatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectProgressUpdated", projectID, "Project"),
+		Completed:       completed,
+		Total:           total,
+	}
+}
+
+// ProjectWorkflowMigrationRolledBackEvent is fired when the tasks a workflow migration moved are restored
+type ProjectWorkflowMigrationRolledBackEvent struct {
+	BaseDomainEvent
+	MigrationID string
+	Restored    int
+}
+
+// NewProjectWorkflowMigrationRolledBackEvent creates a new ProjectWorkflowMigrationRolledBackEvent
+func NewProjectWorkflowMigrationRolledBackEvent(projectID, migrationID string, restored int) ProjectWorkflowMigrationRolledBackEvent {
+	return ProjectWorkflowMigrationRolledBackEvent{
+		BaseDomainEvent: NewBaseDomainEvent("ProjectWorkflowMigrationRolledBack", projectID, "Project"),
+		MigrationID:     migrationID,
+		Restored:        restored,
+	}
+}
@@ -0,0 +1,82 @@
+package event
+
+// SprintCreatedEvent is fired when a sprint is planned for a project
+type SprintCreatedEvent struct {
+	BaseDomainEvent
+	ProjectID string
+	Name      string
+	StartDate string // ISO 8601 format
+	EndDate   string // ISO 8601 format
+}
+
+// NewSprintCreatedEvent creates a new SprintCreatedEvent
+func NewSprintCreatedEvent(sprintID, projectID, name, startDate, endDate string) SprintCreatedEvent {
+	return SprintCreatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("SprintCreated", sprintID, "Sprint"),
+		ProjectID:       projectID,
+		Name:            name,
+		StartDate:       startDate,
+		EndDate:         endDate,
+	}
+}
+
+// SprintStartedEvent is fired when a sprint becomes active
+type SprintStartedEvent struct {
+	BaseDomainEvent
+	ProjectID string
+}
+
+// NewSprintStartedEvent creates a new SprintStartedEvent
+func NewSprintStartedEvent(sprintID, projectID string) SprintStartedEvent {
+	return SprintStartedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("SprintStarted", sprintID, "Sprint"),
+		ProjectID:       projectID,
+	}
+}
+
+// SprintCompletedEvent is fired when a sprint is closed.
+// CarriedOverTaskIDs lists unfinished tasks returned to the backlog.
+type SprintCompletedEvent struct {
+	BaseDomainEvent
+	ProjectID          string
+	CompletedTaskIDs   []string
+	CarriedOverTaskIDs []string
+}
+
+// NewSprintCompletedEvent creates a new SprintCompletedEvent
+func NewSprintCompletedEvent(sprintID, projectID string, completedTaskIDs, carriedOverTaskIDs []string) SprintCompletedEvent {
+	return SprintCompletedEvent{
+		BaseDomainEvent:    NewBaseDomainEvent("SprintCompleted", sprintID, "Sprint"),
+		ProjectID:          projectID,
+		CompletedTaskIDs:   completedTaskIDs,
+		CarriedOverTaskIDs: carriedOverTaskIDs,
+	}
+}
+
+// TaskAddedToSprintEvent is fired when a task is planned into a sprint
+type TaskAddedToSprintEvent struct {
+	BaseDomainEvent
+	TaskID string
+}
+
+// NewTaskAddedToSprintEvent creates a new TaskAddedToSprintEvent
+func NewTaskAddedToSprintEvent(sprintID, taskID string) TaskAddedToSprintEvent {
+	return TaskAddedToSprintEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskAddedToSprint", sprintID, "Sprint"),
+		TaskID:          taskID,
+	}
+}
+
+// TaskRemovedFromSprintEvent is fired when a task is taken out of a sprint
+type TaskRemovedFromSprintEvent struct {
+	BaseDomainEvent
+	TaskID string
+}
+
+// NewTaskRemovedFromSprintEvent creates a new TaskRemovedFromSprintEvent
+func NewTaskRemovedFromSprintEvent(sprintID, taskID string) TaskRemovedFromSprintEvent {
+	return TaskRemovedFromSprintEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskRemovedFromSprint", sprintID, "Sprint"),
+		TaskID:          taskID,
+	}
+}
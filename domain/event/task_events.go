@@ -1,5 +1,7 @@
 package event
 
+import "context"
+
 // TaskCreatedEvent is fired when a new task is created
 type TaskCreatedEvent struct {
 	BaseDomainEvent
@@ -40,33 +42,119 @@ func NewTaskAssignedEvent(taskID, assigneeID, previousAssigneeID string) TaskAss
 	}
 }
 
+// TaskUnassignedEvent is fired when a task is taken away from its assignee
+type TaskUnassignedEvent struct {
+	BaseDomainEvent
+	PreviousAssigneeID string
+	UnassignedBy       string
+}
+
+// NewTaskUnassignedEvent creates a new TaskUnassignedEvent
+func NewTaskUnassignedEvent(taskID, previousAssigneeID, unassignedBy string) TaskUnassignedEvent {
+	return TaskUnassignedEvent{
+		BaseDomainEvent:    NewBaseDomainEvent("TaskUnassigned", taskID, "Task"),
+		PreviousAssigneeID: previousAssigneeID,
+		UnassignedBy:       unassignedBy,
+	}
+}
+
+// TaskAcknowledgedEvent is fired when an assignee acknowledges a task assigned to them,
+// explicitly or by opening it for the first time
+type TaskAcknowledgedEvent struct {
+	BaseDomainEvent
+	AssigneeID string
+	Source     string // EXPLICIT or VIEW
+}
+
+// NewTaskAcknowledgedEvent creates a new TaskAcknowledgedEvent
+func NewTaskAcknowledgedEvent(taskID, assigneeID, source string) TaskAcknowledgedEvent {
+	return TaskAcknowledgedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskAcknowledged", taskID, "Task"),
+		AssigneeID:      assigneeID,
+		Source:          source,
+	}
+}
+
+// TaskAssignmentEscalatedEvent is fired when an assignment goes unacknowledged for longer
+// than the acknowledgement window, so that whoever made it can follow up
+type TaskAssignmentEscalatedEvent struct {
+	BaseDomainEvent
+	AssigneeID          string
+	AssignedBy          string
+	UnacknowledgedHours int
+}
+
+// NewTaskAssignmentEscalatedEvent creates a new TaskAssignmentEscalatedEvent
+func NewTaskAssignmentEscalatedEvent(taskID, assigneeID, assignedBy string, unacknowledgedHours int) TaskAssignmentEscalatedEvent {
+	return TaskAssignmentEscalatedEvent{
+		BaseDomainEvent:     NewBaseDomainEvent("TaskAssignmentEscalated", taskID, "Task"),
+		AssigneeID:          assigneeID,
+		AssignedBy:          assignedBy,
+		UnacknowledgedHours: unacknowledgedHours,
+	}
+}
+
+// TaskAssignedToTeamEvent is fired when a task is handed to a team
+type TaskAssignedToTeamEvent struct {
+	BaseDomainEvent
+	TeamID         string
+	PreviousTeamID string
+	AssignedBy     string
+}
+
+// NewTaskAssignedToTeamEvent creates a new TaskAssignedToTeamEvent
+func NewTaskAssignedToTeamEvent(taskID, teamID, previousTeamID, assignedBy string) TaskAssignedToTeamEvent {
+	return TaskAssignedToTeamEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskAssignedToTeam", taskID, "Task"),
+		TeamID:          teamID,
+		PreviousTeamID:  previousTeamID,
+		AssignedBy:      assignedBy,
+	}
+}
+
 // TaskStatusChangedEvent is fired when a task status changes
 type TaskStatusChangedEvent struct {
 	BaseDomainEvent
 	OldStatus string
 	NewStatus string
+	Reason    string            // why the status changed, empty when none was given
+	Metadata  map[string]string // free-form context such as a QA run or build number
+	// The statuses as the task's workflow names them, empty for statuses of the default
+	// workflow. OldStatus and NewStatus hold the lifecycle stages they stand for.
+	OldWorkflowStatus string
+	NewWorkflowStatus string
 }
 
 // NewTaskStatusChangedEvent creates a new TaskStatusChangedEvent
-func NewTaskStatusChangedEvent(taskID, oldStatus, newStatus string) TaskStatusChangedEvent {
+func NewTaskStatusChangedEvent(taskID, oldStatus, newStatus, reason string, metadata map[string]string) TaskStatusChangedEvent {
 	return TaskStatusChangedEvent{
 		BaseDomainEvent: NewBaseDomainEvent("TaskStatusChanged", taskID, "Task"),
 		OldStatus:       oldStatus,
 		NewStatus:       newStatus,
+		Reason:          reason,
+		Metadata:        metadata,
 	}
 }
 
-// TaskDeadlineSetEvent is fired when a deadline is set on a task
+// TaskDeadlineSetEvent is fired when a deadline is set on a task or changed
 type TaskDeadlineSetEvent struct {
 	BaseDomainEvent
-	DueDate string // ISO 8601 format
+	DueDate         string // ISO 8601 format
+	PreviousDueDate string // ISO 8601 format, empty when the task had no deadline
+	ChangedBy       string
+	Reason          string
+	Slipped         bool // whether an existing deadline was pushed later
 }
 
 // NewTaskDeadlineSetEvent creates a new TaskDeadlineSetEvent
-func NewTaskDeadlineSetEvent(taskID, dueDate string) TaskDeadlineSetEvent {
+func NewTaskDeadlineSetEvent(taskID, dueDate, previousDueDate, changedBy, reason string, slipped bool) TaskDeadlineSetEvent {
 	return TaskDeadlineSetEvent{
 		BaseDomainEvent: NewBaseDomainEvent("TaskDeadlineSet", taskID, "Task"),
 		DueDate:         dueDate,
+		PreviousDueDate: previousDueDate,
+		ChangedBy:       changedBy,
+		Reason:          reason,
+		Slipped:         slipped,
 	}
 }
 
@@ -100,6 +188,164 @@ func NewTaskCompletedEvent(taskID, completedBy, completionTime string) TaskCompl
 	}
 }
 
+// TaskCommentAddedEvent is fired when a comment is added to a task
+type TaskCommentAddedEvent struct {
+	BaseDomainEvent
+	CommentID string
+	AuthorID  string
+}
+
+// NewTaskCommentAddedEvent creates a new TaskCommentAddedEvent
+func NewTaskCommentAddedEvent(taskID, commentID, authorID string) TaskCommentAddedEvent {
+	return TaskCommentAddedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskCommentAdded", taskID, "Task"),
+		CommentID:       commentID,
+		AuthorID:        authorID,
+	}
+}
+
+// TaskLinkedEvent is fired when a task is linked to another task
+type TaskLinkedEvent struct {
+	BaseDomainEvent
+	TargetTaskID string
+	LinkType     string
+}
+
+// NewTaskLinkedEvent creates a new TaskLinkedEvent
+func NewTaskLinkedEvent(taskID, targetTaskID, linkType string) TaskLinkedEvent {
+	return TaskLinkedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskLinked", taskID, "Task"),
+		TargetTaskID:    targetTaskID,
+		LinkType:        linkType,
+	}
+}
+
+// TaskUnlinkedEvent is fired when a link between tasks is removed
+type TaskUnlinkedEvent struct {
+	BaseDomainEvent
+	TargetTaskID string
+	LinkType     string
+}
+
+// NewTaskUnlinkedEvent creates a new TaskUnlinkedEvent
+func NewTaskUnlinkedEvent(taskID, targetTaskID, linkType string) TaskUnlinkedEvent {
+	return TaskUnlinkedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskUnlinked", taskID, "Task"),
+		TargetTaskID:    targetTaskID,
+		LinkType:        linkType,
+	}
+}
+
+// TaskVotedEvent is fired when a user votes for a task
+type TaskVotedEvent struct {
+	BaseDomainEvent
+	VoterID   string
+	VoteCount int
+}
+
+// NewTaskVotedEvent creates a new TaskVotedEvent
+func NewTaskVotedEvent(taskID, voterID string, voteCount int) TaskVotedEvent {
+	return TaskVotedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskVoted", taskID, "Task"),
+		VoterID:         voterID,
+		VoteCount:       voteCount,
+	}
+}
+
+// TaskVoteRemovedEvent is fired when a user withdraws a vote for a task
+type TaskVoteRemovedEvent struct {
+	BaseDomainEvent
+	VoterID   string
+	VoteCount int
+}
+
+// NewTaskVoteRemovedEvent creates a new TaskVoteRemovedEvent
+func NewTaskVoteRemovedEvent(taskID, voterID string, voteCount int) TaskVoteRemovedEvent {
+	return TaskVoteRemovedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskVoteRemoved", taskID, "Task"),
+		VoterID:         voterID,
+		VoteCount:       voteCount,
+	}
+}
+
+// TaskApprovedEvent is fired when a user approves a task in its current status
+type TaskApprovedEvent struct {
+	BaseDomainEvent
+	ApproverID    string
+	Status        string
+	ApprovalCount int
+}
+
+// NewTaskApprovedEvent creates a new TaskApprovedEvent
+func NewTaskApprovedEvent(taskID, approverID, status string, approvalCount int) TaskApprovedEvent {
+	return TaskApprovedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskApproved", taskID, "Task"),
+		ApproverID:      approverID,
+		Status:          status,
+		ApprovalCount:   approvalCount,
+	}
+}
+
+// TaskRejectedEvent is fired when a reviewer rejects a task in its current status
+type TaskRejectedEvent struct {
+	BaseDomainEvent
+	ReviewerID string
+	Status     string
+	Reason     string
+}
+
+// NewTaskRejectedEvent creates a new TaskRejectedEvent
+func NewTaskRejectedEvent(taskID, reviewerID, status, reason string) TaskRejectedEvent {
+	return TaskRejectedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskRejected", taskID, "Task"),
+		ReviewerID:      reviewerID,
+		Status:          status,
+		Reason:          reason,
+	}
+}
+
+// TaskNotificationRequestedEvent is fired when a workflow transition asks for a notification about a task
+type TaskNotificationRequestedEvent struct {
+	BaseDomainEvent
+	ProjectID string
+	Channel   string
+	Target    string
+	Message   string
+}
+
+// NewTaskNotificationRequestedEvent creates a new TaskNotificationRequestedEvent
+func NewTaskNotificationRequestedEvent(taskID, projectID, channel, target, message string) TaskNotificationRequestedEvent {
+	return TaskNotificationRequestedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskNotificationRequested", taskID, "Task"),
+		ProjectID:       projectID,
+		Channel:         channel,
+		Target:          target,
+		Message:         message,
+	}
+}
+
+// TaskWorkflowMigratedEvent is fired when a task moves onto another workflow version
+type TaskWorkflowMigratedEvent struct {
+	BaseDomainEvent
+	ProjectID   string
+	FromVersion string // empty when the task was not pinned to a version
+	ToVersion   string
+	OldStatus   string
+	NewStatus   string
+}
+
+// NewTaskWorkflowMigratedEvent creates a new TaskWorkflowMigratedEvent
+func NewTaskWorkflowMigratedEvent(taskID, projectID, fromVersion, toVersion, oldStatus, newStatus string) TaskWorkflowMigratedEvent {
+	return TaskWorkflowMigratedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskWorkflowMigrated", taskID, "Task"),
+		ProjectID:       projectID,
+		FromVersion:     fromVersion,
+		ToVersion:       toVersion,
+		OldStatus:       oldStatus,
+		NewStatus:       newStatus,
+	}
+}
+
 // TaskDeletedEvent is fired when a task is deleted
 type TaskDeletedEvent struct {
 	BaseDomainEvent
@@ -114,17 +360,149 @@ func NewTaskDeletedEvent(taskID, projectID string) TaskDeletedEvent {
 	}
 }
 
+// TaskCreationFailedEvent is fired when a saved task could not be added to its project.
+// Compensated reports whether the orphaned task was deleted again.
+type TaskCreationFailedEvent struct {
+	BaseDomainEvent
+	ProjectID   string
+	Title       string
+	CreatedBy   string
+	Reason      string
+	Compensated bool
+}
+
+// NewTaskCreationFailedEvent creates a new TaskCreationFailedEvent
+func NewTaskCreationFailedEvent(taskID, projectID, title, createdBy, reason string, compensated bool) TaskCreationFailedEvent {
+	return TaskCreationFailedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskCreationFailed", taskID, "Task"),
+		ProjectID:       projectID,
+		Title:           title,
+		CreatedBy:       createdBy,
+		Reason:          reason,
+		Compensated:     compensated,
+	}
+}
+
+// TaskEditLockAcquiredEvent is fired when a user takes or renews the edit lock on a task description
+type TaskEditLockAcquiredEvent struct {
+	BaseDomainEvent
+	HolderID   string
+	AcquiredAt string // ISO 8601 format
+	ExpiresAt  string // ISO 8601 format
+	Renewed    bool
+}
+
+// NewTaskEditLockAcquiredEvent creates a new TaskEditLockAcquiredEvent
+func NewTaskEditLockAcquiredEvent(taskID, holderID, acquiredAt, expiresAt string, renewed bool) TaskEditLockAcquiredEvent {
+	return TaskEditLockAcquiredEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskEditLockAcquired", taskID, "Task"),
+		HolderID:        holderID,
+		AcquiredAt:      acquiredAt,
+		ExpiresAt:       expiresAt,
+		Renewed:         renewed,
+	}
+}
+
+// TaskEditLockReleasedEvent is fired when the edit lock on a task description is given up
+type TaskEditLockReleasedEvent struct {
+	BaseDomainEvent
+	HolderID string
+}
+
+// NewTaskEditLockReleasedEvent creates a new TaskEditLockReleasedEvent
+func NewTaskEditLockReleasedEvent(taskID, holderID string) TaskEditLockReleasedEvent {
+	return TaskEditLockReleasedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskEditLockReleased", taskID, "Task"),
+		HolderID:        holderID,
+	}
+}
+
+// TaskCostRecordedEvent is fired when money spent on a task is recorded
+type TaskCostRecordedEvent struct {
+	BaseDomainEvent
+	ProjectID    string
+	EntryID      string
+	Amount       string // decimal amount, e.g. "99.95"
+	Currency     string
+	Description  string
+	RecordedByID string
+}
+
+// NewTaskCostRecordedEvent creates a new TaskCostRecordedEvent
+func NewTaskCostRecordedEvent(taskID, projectID, entryID, amount, currency, description, recordedByID string) TaskCostRecordedEvent {
+	return TaskCostRecordedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskCostRecorded", taskID, "Task"),
+		ProjectID:       projectID,
+		EntryID:         entryID,
+		Amount:          amount,
+		Currency:        currency,
+		Description:     description,
+		RecordedByID:    recordedByID,
+	}
+}
+
+// TaskAttachmentAddedEvent is fired when a file is attached to a task
+type TaskAttachmentAddedEvent struct {
+	BaseDomainEvent
+	AttachmentID string
+	FileName     string
+	ContentType  string
+	SizeBytes    int64
+	UploadedByID string
+}
+
+// NewTaskAttachmentAddedEvent creates a new TaskAttachmentAddedEvent
+func NewTaskAttachmentAddedEvent(taskID, attachmentID, fileName, contentType string, sizeBytes int64, uploadedByID string) TaskAttachmentAddedEvent {
+	return TaskAttachmentAddedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TaskAttachmentAdded", taskID, "Task"),
+		AttachmentID:    attachmentID,
+		FileName:        fileName,
+		ContentType:     contentType,
+		SizeBytes:       sizeBytes,
+		UploadedByID:    uploadedByID,
+	}
+}
+
 // EventPublisher defines the interface for publishing domain events
 type EventPublisher interface {
-	Publish(event DomainEvent) error
-	PublishAll(events []DomainEvent) error
+	Publish(ctx context.Context, event DomainEvent) error
+	PublishAll(ctx context.Context, events []DomainEvent) error
 }
 
 // EventStore defines the interface for storing domain events
 type EventStore interface {
-	Store(event DomainEvent) error
-	GetEvents(aggregateID string) ([]DomainEvent, error)
-	GetEventsSince(aggregateID string, since string) ([]DomainEvent, error)
+	Store(ctx context.Context, event DomainEvent) error
+	AppendBatch(ctx context.Context, events []DomainEvent) error // appends all events in one write, or none of them
+	GetEvents(ctx context.Context, aggregateID string) ([]DomainEvent, error)
+	// GetEventsPage returns up to limit events of an aggregate (all when limit is 0) following
+	// afterVersion. Versions number an aggregate's events from 1 in append order, so the i-th
+	// event returned has version afterVersion+i+1.
+	GetEventsPage(ctx context.Context, aggregateID string, afterVersion, limit int) ([]DomainEvent, error)
+	GetEventsSince(ctx context.Context, aggregateID string, since string) ([]DomainEvent, error)
+	GetAllEvents(ctx context.Context) ([]DomainEvent, error)
+	// PruneStreams removes the whole stream of every aggregate whose last event occurred before
+	// an RFC3339 timestamp, returning how many events were removed. Streams go as a whole so the
+	// versions of the remaining ones never shift.
+	PruneStreams(ctx context.Context, before string) (int, error)
+	// QueryEvents returns the events of the whole log matching a filter in append order
+	QueryEvents(ctx context.Context, filter EventFilter) ([]StoredEvent, error)
+}
+
+// EventFilter selects events from the whole log. Empty fields match every event.
+type EventFilter struct {
+	AggregateID   string
+	EventType     string
+	Since         string // RFC3339, events that occurred after it
+	AfterPosition int64  // events stored after this position, 0 from the start
+	Limit         int    // 0 returns every matching event
+}
+
+// StoredEvent is an event with its position in the log. Positions number every stored
+// event from 1 in append order and never change, so the last one read is the cursor of
+// the next page.
+type StoredEvent struct {
+	Position int64
+	Event    DomainEvent
 }
 
 // EventSubscriber defines the interface for subscribing to domain events
@@ -0,0 +1,63 @@
+package event
+
+// TeamCreatedEvent is fired when a team is formed
+type TeamCreatedEvent struct {
+	BaseDomainEvent
+	Name      string
+	LeadID    string
+	MemberIDs []string
+}
+
+// NewTeamCreatedEvent creates a new TeamCreatedEvent
+func NewTeamCreatedEvent(teamID, name, leadID string, memberIDs []string) TeamCreatedEvent {
+	return TeamCreatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TeamCreated", teamID, "Team"),
+		Name:            name,
+		LeadID:          leadID,
+		MemberIDs:       memberIDs,
+	}
+}
+
+// TeamMemberAddedEvent is fired when a user joins a team
+type TeamMemberAddedEvent struct {
+	BaseDomainEvent
+	UserID string
+}
+
+// NewTeamMemberAddedEvent creates a new TeamMemberAddedEvent
+func NewTeamMemberAddedEvent(teamID, userID string) TeamMemberAddedEvent {
+	return TeamMemberAddedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TeamMemberAdded", teamID, "Team"),
+		UserID:          userID,
+	}
+}
+
+// TeamMemberRemovedEvent is fired when a user leaves a team
+type TeamMemberRemovedEvent struct {
+	BaseDomainEvent
+	UserID string
+}
+
+// NewTeamMemberRemovedEvent creates a new TeamMemberRemovedEvent
+func NewTeamMemberRemovedEvent(teamID, userID string) TeamMemberRemovedEvent {
+	return TeamMemberRemovedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TeamMemberRemoved", teamID, "Team"),
+		UserID:          userID,
+	}
+}
+
+// TeamLeadChangedEvent is fired when another member becomes the team lead
+type TeamLeadChangedEvent struct {
+	BaseDomainEvent
+	LeadID         string
+	PreviousLeadID string
+}
+
+// NewTeamLeadChangedEvent creates a new TeamLeadChangedEvent
+func NewTeamLeadChangedEvent(teamID, leadID, previousLeadID string) TeamLeadChangedEvent {
+	return TeamLeadChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("TeamLeadChanged", teamID, "Team"),
+		LeadID:          leadID,
+		PreviousLeadID:  previousLeadID,
+	}
+}
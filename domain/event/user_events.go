@@ -0,0 +1,156 @@
+package event
+
+// UserRegisteredEvent is fired when a new user is registered
+type UserRegisteredEvent struct {
+	BaseDomainEvent
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+// NewUserRegisteredEvent creates a new UserRegisteredEvent
+func NewUserRegisteredEvent(userID, email, firstName, lastName string) UserRegisteredEvent {
+	return UserRegisteredEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserRegistered", userID, "User"),
+		Email:           email,
+		FirstName:       firstName,
+		LastName:        lastName,
+	}
+}
+
+// UserPasswordChangedEvent is fired when a user changes their password
+type UserPasswordChangedEvent struct {
+	BaseDomainEvent
+}
+
+// NewUserPasswordChangedEvent creates a new UserPasswordChangedEvent
+func NewUserPasswordChangedEvent(userID string) UserPasswordChangedEvent {
+	return UserPasswordChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserPasswordChanged", userID, "User"),
+	}
+}
+
+// UserEmailVerifiedEvent is fired when a user confirms they own their email address
+type UserEmailVerifiedEvent struct {
+	BaseDomainEvent
+	Email string
+}
+
+// NewUserEmailVerifiedEvent creates a new UserEmailVerifiedEvent
+func NewUserEmailVerifiedEvent(userID, email string) UserEmailVerifiedEvent {
+	return UserEmailVerifiedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserEmailVerified", userID, "User"),
+		Email:           email,
+	}
+}
+
+// UserDeactivatedEvent is fired when a user is deactivated. Their open tasks go to
+// the fallback assignee, or are unassigned when there is none.
+type UserDeactivatedEvent struct {
+	BaseDomainEvent
+	DeactivatedBy      string
+	FallbackAssigneeID string
+}
+
+// NewUserDeactivatedEvent creates a new UserDeactivatedEvent
+func NewUserDeactivatedEvent(userID, deactivatedBy, fallbackAssigneeID string) UserDeactivatedEvent {
+	return UserDeactivatedEvent{
+		BaseDomainEvent:    NewBaseDomainEvent("UserDeactivated", userID, "User"),
+		DeactivatedBy:      deactivatedBy,
+		FallbackAssigneeID: fallbackAssigneeID,
+	}
+}
+
+// UserActivatedEvent is fired when a deactivated user is activated again
+type UserActivatedEvent struct {
+	BaseDomainEvent
+	ActivatedBy string
+}
+
+// NewUserActivatedEvent creates a new UserActivatedEvent
+func NewUserActivatedEvent(userID, activatedBy string) UserActivatedEvent {
+	return UserActivatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserActivated", userID, "User"),
+		ActivatedBy:     activatedBy,
+	}
+}
+
+// UserEmailChangedEvent is fired when a user's email address changes.
+// The new address is unverified until the user confirms it.
+type UserEmailChangedEvent struct {
+	BaseDomainEvent
+	PreviousEmail string
+	Email         string
+}
+
+// NewUserEmailChangedEvent creates a new UserEmailChangedEvent
+func NewUserEmailChangedEvent(userID, previousEmail, email string) UserEmailChangedEvent {
+	return UserEmailChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserEmailChanged", userID, "User"),
+		PreviousEmail:   previousEmail,
+		Email:           email,
+	}
+}
+
+// UserRenamedEvent is fired when a user's name changes
+type UserRenamedEvent struct {
+	BaseDomainEvent
+	FirstName string
+	LastName  string
+}
+
+// NewUserRenamedEvent creates a new UserRenamedEvent
+func NewUserRenamedEvent(userID, firstName, lastName string) UserRenamedEvent {
+	return UserRenamedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserRenamed", userID, "User"),
+		FirstName:       firstName,
+		LastName:        lastName,
+	}
+}
+
+// UserWorkingHoursChangedEvent is fired when a user's working hours change.
+// No working days means the user follows their organization's default again.
+type UserWorkingHoursChangedEvent struct {
+	BaseDomainEvent
+	HoursPerDay float64
+	WorkingDays []string
+}
+
+// NewUserWorkingHoursChangedEvent creates a new UserWorkingHoursChangedEvent
+func NewUserWorkingHoursChangedEvent(userID string, hoursPerDay float64, workingDays []string) UserWorkingHoursChangedEvent {
+	return UserWorkingHoursChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserWorkingHoursChanged", userID, "User"),
+		HoursPerDay:     hoursPerDay,
+		WorkingDays:     workingDays,
+	}
+}
+
+// UserOutOfOfficeChangedEvent is fired when a user's out-of-office periods change.
+// Periods are ISO 8601 intervals such as "2024-08-01T00:00:00Z/2024-08-15T00:00:00Z"; none means the user is not away.
+type UserOutOfOfficeChangedEvent struct {
+	BaseDomainEvent
+	Periods []string
+}
+
+// NewUserOutOfOfficeChangedEvent creates a new UserOutOfOfficeChangedEvent
+func NewUserOutOfOfficeChangedEvent(userID string, periods []string) UserOutOfOfficeChangedEvent {
+	return UserOutOfOfficeChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserOutOfOfficeChanged", userID, "User"),
+		Periods:         periods,
+	}
+}
+
+// UserManagerChangedEvent is fired when a user starts reporting to another manager, or to
+// nobody when ManagerID is empty
+type UserManagerChangedEvent struct {
+	BaseDomainEvent
+	ManagerID string
+}
+
+// NewUserManagerChangedEvent creates a new UserManagerChangedEvent
+func NewUserManagerChangedEvent(userID, managerID string) UserManagerChangedEvent {
+	return UserManagerChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("UserManagerChanged", userID, "User"),
+		ManagerID:       managerID,
+	}
+}
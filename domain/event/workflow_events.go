@@ -0,0 +1,151 @@
+package event
+
+// WorkflowCreatedEvent is fired when a new workflow is created
+type WorkflowCreatedEvent struct {
+	BaseDomainEvent
+	Name     string
+	Statuses []string
+}
+
+// NewWorkflowCreatedEvent creates a new WorkflowCreatedEvent
+func NewWorkflowCreatedEvent(workflowID, name string, statuses []string) WorkflowCreatedEvent {
+	return WorkflowCreatedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("WorkflowCreated", workflowID, "Workflow"),
+		Name:            name,
+		Statuses:        statuses,
+	}
+}
+
+// WorkflowDetailsChangedEvent is fired when a workflow's name or description changes
+type WorkflowDetailsChangedEvent struct {
+	BaseDomainEvent
+	Name        string
+	Description string
+}
+
+// NewWorkflowDetailsChangedEvent creates a new WorkflowDetailsChangedEvent
+func NewWorkflowDetailsChangedEvent(workflowID, name, description string) WorkflowDetailsChangedEvent {
+	return WorkflowDetailsChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("WorkflowDetailsChanged", workflowID, "Workflow"),
+		Name:            name,
+		Description:     description,
+	}
+}
+
+// WorkflowStatusAddedEvent is fired when a status is added to a workflow
+type WorkflowStatusAddedEvent struct {
+	BaseDomainEvent
+	Status  string
+	Order   int
+	IsFinal bool
+}
+
+// NewWorkflowStatusAddedEvent creates a new WorkflowStatusAddedEvent
+func NewWorkflowStatusAddedEvent(workflowID, status string, order int, isFinal bool) WorkflowStatusAddedEvent {
+	return WorkflowStatusAddedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("WorkflowStatusAdded", workflowID, "Workflow"),
+		Status:          status,
+		Order:           order,
+		IsFinal:         isFinal,
+	}
+}
+
+// WorkflowStatusRemovedEvent is fired when a status is removed from a workflow,
+// along with every transition into or out of it
+type WorkflowStatusRemovedEvent struct {
+	BaseDomainEvent
+	Status string
+}
+
+// NewWorkflowStatusRemovedEvent creates a new WorkflowStatusRemovedEvent
+func NewWorkflowStatusRemovedEvent(workflowID, status string) WorkflowStatusRemovedEvent {
+	return WorkflowStatusRemovedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("WorkflowStatusRemoved", workflowID, "Workflow"),
+		Status:          status,
+	}
+}
+
+// WorkflowStatusesReorderedEvent is fired when the statuses of a workflow are put in a new order
+type WorkflowStatusesReorderedEvent struct {
+	BaseDomainEvent
+	Statuses []string
+}
+
+// NewWorkflowStatusesReorderedEvent creates a new WorkflowStatusesReorderedEvent
+func NewWorkflowStatusesReorderedEvent(workflowID string, statuses []string) WorkflowStatusesReorderedEvent {
+	return WorkflowStatusesReorderedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("WorkflowStatusesReordered", workflowID, "Workflow"),
+		Statuses:        statuses,
+	}
+}
+
+// WorkflowTransitionAllowedEvent is fired when a workflow starts allowing a move between two statuses
+type WorkflowTransitionAllowedEvent struct {
+	BaseDomainEvent
+	From string
+	To   string
+}
+
+// NewWorkflowTransitionAllowedEvent creates a new WorkflowTransitionAllowedEvent
+func NewWorkflowTransitionAllowedEvent(workflowID, from, to string) WorkflowTransitionAllowedEvent {
+	return WorkflowTransitionAllowedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("WorkflowTransitionAllowed", workflowID, "Workflow"),
+		From:            from,
+		To:              to,
+	}
+}
+
+// WorkflowTransitionDisallowedEvent is fired when a workflow stops allowing a move between two statuses
+type WorkflowTransitionDisallowedEvent struct {
+	BaseDomainEvent
+	From string
+	To   string
+}
+
+// NewWorkflowTransitionDisallowedEvent creates a new WorkflowTransitionDisallowedEvent
+func NewWorkflowTransitionDisallowedEvent(workflowID, from, to string) WorkflowTransitionDisallowedEvent {
+	return WorkflowTransitionDisallowedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("WorkflowTransitionDisallowed", workflowID, "Workflow"),
+		From:            from,
+		To:              to,
+	}
+}
+
+// WorkflowTransitionRulesChangedEvent is fired when the guards or actions of a workflow transition change
+type WorkflowTransitionRulesChangedEvent struct {
+	BaseDomainEvent
+	From    string
+	To      string
+	Guards  []string
+	Actions []string
+}
+
+// NewWorkflowTransitionRulesChangedEvent creates a new WorkflowTransitionRulesChangedEvent
+func NewWorkflowTransitionRulesChangedEvent(workflowID, from, to string, guards, actions []string) WorkflowTransitionRulesChangedEvent {
+	return WorkflowTransitionRulesChangedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("WorkflowTransitionRulesChanged", workflowID, "Workflow"),
+		From:            from,
+		To:              to,
+		Guards:          guards,
+		Actions:         actions,
+	}
+}
+
+// WorkflowApprovalStepChangedEvent is fired when the sign-off a workflow status needs changes.
+// No reviewers means the status no longer needs sign-off.
+type WorkflowApprovalStepChangedEvent struct {
+	BaseDomainEvent
+	Status            string
+	RequiredApprovals int
+	ReviewerIDs       []string
+}
+
+// NewWorkflowApprovalStepChangedEvent creates a new WorkflowApprovalStepChangedEvent
+func NewWorkflowApprovalStepChangedEvent(workflowID, status string, requiredApprovals int, reviewerIDs []string) WorkflowApprovalStepChangedEvent {
+	return WorkflowApprovalStepChangedEvent{
+		BaseDomainEvent:   NewBaseDomainEvent("WorkflowApprovalStepChanged", workflowID, "Workflow"),
+		Status:            status,
+		RequiredApprovals: requiredApprovals,
+		ReviewerIDs:       reviewerIDs,
+	}
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// DefaultPageLimit is the number of items in a page that sets no limit
+const DefaultPageLimit = 50
+
+// MaxPageLimit is the largest number of items a page can hold
+const MaxPageLimit = 500
+
+// ErrInvalidPage is wrapped by the errors of a page that a list cannot serve
+var ErrInvalidPage = errs.Invalid("invalid page")
+
+// SortDirection orders a page ascending or descending
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "ASC"
+	SortDescending SortDirection = "DESC"
+)
+
+// The fields each paged list sorts by; the first is the default
+var (
+	TaskSortFields            = []string{"created_at", "updated_at", "title", "deadline", "votes"}
+	ProjectSortFields         = []string{"name", "created_at", "updated_at"}
+	UserSortFields            = []string{"email", "created_at"}
+	WorkflowSortFields        = []string{"name", "created_at"}
+	TeamSortFields            = []string{"created_at", "name"}
+	SprintSortFields          = []string{"start_date", "created_at", "name"}
+	WidgetSortFields          = []string{"position", "created_at", "title"} // position is the grid row, then column
+	HolidayCalendarSortFields = []string{"region", "created_at", "name"}
+)
+
+// Page selects a window of a list and its order. Zero fields take their defaults: the
+// first DefaultPageLimit items, sorted ascending by the list's default field. Items that
+// sort alike are ordered by ID, so every page of a list is stable.
+type Page struct {
+	Limit   int
+	Offset  int
+	Cursor  string // NextCursor of the previous page, instead of an offset
+	SortBy  string
+	SortDir SortDirection
+}
+
+// PagedResult describes the page a list method returned
+type PagedResult struct {
+	Total      int    // items matching the filter, across all pages
+	Offset     int    // position of the page's first item
+	NextCursor string // continues after the page, empty on the last page
+}
+
+// Resolve checks a page against the fields a list sorts by and fills in its defaults,
+// turning its cursor into an offset
+func (p Page) Resolve(sortFields []string) (Page, error) {
+	if p.Limit < 0 || p.Offset < 0 {
+		return Page{}, fmt.Errorf("%w: limit and offset cannot be negative", ErrInvalidPage)
+	}
+	if p.Limit > MaxPageLimit {
+		return Page{}, fmt.Errorf("%w: limit cannot be above %d", ErrInvalidPage, MaxPageLimit)
+	}
+	if p.Limit == 0 {
+		p.Limit = DefaultPageLimit
+	}
+
+	if p.Cursor != "" {
+		if p.Offset != 0 {
+			return Page{}, fmt.Errorf("%w: use either an offset or a cursor", ErrInvalidPage)
+		}
+		offset, err := decodeCursor(p.Cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		p.Offset = offset
+		p.Cursor = ""
+	}
+
+	if p.SortBy == "" {
+		p.SortBy = sortFields[0]
+	} else if !containsField(sortFields, p.SortBy) {
+		return Page{}, fmt.Errorf("%w: unknown sort field %q, expected one of %s", ErrInvalidPage, p.SortBy, strings.Join(sortFields, ", "))
+	}
+
+	switch SortDirection(strings.ToUpper(string(p.SortDir))) {
+	case "", SortAscending:
+		p.SortDir = SortAscending
+	case SortDescending:
+		p.SortDir = SortDescending
+	default:
+		return Page{}, fmt.Errorf("%w: unknown sort direction %q, expected ASC or DESC", ErrInvalidPage, p.SortDir)
+	}
+
+	return p, nil
+}
+
+// Window returns the bounds of a resolved page within a list of total items
+func (p Page) Window(total int) (start, end int) {
+	start = p.Offset
+	if start > total {
+		start = total
+	}
+	end = start + p.Limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// Result describes the window of a resolved page within a list of total items
+func (p Page) Result(total int) PagedResult {
+	start, end := p.Window(total)
+	result := PagedResult{Total: total, Offset: start}
+	if end < total {
+		result.NextCursor = encodeCursor(end)
+	}
+	return result
+}
+
+// Before reports whether an item sorts before another on a resolved page, given how their
+// sort fields compare and their IDs
+func (p Page) Before(compared int, id, otherID string) bool {
+	if compared == 0 {
+		compared = strings.Compare(id, otherID)
+	}
+	if p.SortDir == SortDescending {
+		return compared > 0
+	}
+	return compared < 0
+}
+
+// encodeCursor returns the cursor of the page starting at an offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset a cursor starts at
+func decodeCursor(cursor string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(decoded), "offset:") {
+		return 0, fmt.Errorf("%w: unknown cursor", ErrInvalidPage)
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(decoded), "offset:"))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("%w: unknown cursor", ErrInvalidPage)
+	}
+	return offset, nil
+}
+
+// containsField reports whether a list sorts by a field
+func containsField(fields []string, field string) bool {
+	for _, candidate := range fields {
+		if candidate == field {
+			return true
+		}
+	}
+	return false
+}
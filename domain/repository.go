@@ -1,116 +1,295 @@
 package domain
 
 import (
+	"context"
+
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
 // TaskRepository defines the interface for task persistence
 type TaskRepository interface {
 	// Save persists a task to the repository
-	Save(task *aggregate.Task) error
+	Save(ctx context.Context, task *aggregate.Task) error
 
 	// GetByID retrieves a task by ID
-	GetByID(id value.TaskID) (*aggregate.Task, error)
+	GetByID(ctx context.Context, id value.TaskID) (*aggregate.Task, error)
 
 	// GetByProjectID retrieves all tasks for a project
-	GetByProjectID(projectID value.ProjectID) ([]*aggregate.Task, error)
+	GetByProjectID(ctx context.Context, projectID value.ProjectID) ([]*aggregate.Task, error)
 
 	// GetByAssigneeID retrieves all tasks assigned to a user
-	GetByAssigneeID(userID value.UserID) ([]*aggregate.Task, error)
+	GetByAssigneeID(ctx context.Context, userID value.UserID) ([]*aggregate.Task, error)
+
+	// GetByTeamID retrieves all tasks assigned to a team
+	GetByTeamID(ctx context.Context, teamID value.TeamID) ([]*aggregate.Task, error)
 
 	// GetByStatus retrieves all tasks with a specific status
-	GetByStatus(status value.TaskStatus) ([]*aggregate.Task, error)
+	GetByStatus(ctx context.Context, status value.TaskStatus) ([]*aggregate.Task, error)
 
 	// GetAll retrieves all tasks
-	GetAll() ([]*aggregate.Task, error)
+	GetAll(ctx context.Context) ([]*aggregate.Task, error)
 
 	// Delete removes a task from the repository
-	Delete(id value.TaskID) error
+	Delete(ctx context.Context, id value.TaskID) error
 
 	// Update updates an existing task
-	Update(task *aggregate.Task) error
+	Update(ctx context.Context, task *aggregate.Task) error
 
 	// FindByProjectIDAndStatus retrieves tasks for a project with specific status
-	FindByProjectIDAndStatus(projectID value.ProjectID, status value.TaskStatus) ([]*aggregate.Task, error)
+	FindByProjectIDAndStatus(ctx context.Context, projectID value.ProjectID, status value.TaskStatus) ([]*aggregate.Task, error)
+
+	// List retrieves a page of the tasks matching a filter, sorted by one of TaskSortFields
+	List(ctx context.Context, filter TaskFilter, page Page) ([]*aggregate.Task, PagedResult, error)
+}
+
+// TaskFilter selects tasks to list. Empty fields match every task.
+type TaskFilter struct {
+	ProjectID     string
+	AssigneeID    string
+	TeamID        string
+	TeamMemberIDs []string // with TeamID, also matches the tasks assigned to one of these users
+	Status        string
 }
 
 // ProjectRepository defines the interface for project persistence
 type ProjectRepository interface {
 	// Save persists a project to the repository
-	Save(project *aggregate.Project) error
+	Save(ctx context.Context, project *aggregate.Project) error
 
 	// GetByID retrieves a project by ID
-	GetByID(id value.ProjectID) (*aggregate.Project, error)
+	GetByID(ctx context.Context, id value.ProjectID) (*aggregate.Project, error)
 
 	// GetByOwnerID retrieves all projects owned by a user
-	GetByOwnerID(userID value.UserID) ([]*aggregate.Project, error)
+	GetByOwnerID(ctx context.Context, userID value.UserID) ([]*aggregate.Project, error)
 
 	// GetAll retrieves all projects
-	GetAll() ([]*aggregate.Project, error)
+	GetAll(ctx context.Context) ([]*aggregate.Project, error)
 
 	// Delete removes a project from the repository
-	Delete(id value.ProjectID) error
+	Delete(ctx context.Context, id value.ProjectID) error
 
 	// Update updates an existing project
-	Update(project *aggregate.Project) error
+	Update(ctx context.Context, project *aggregate.Project) error
 
 	// GetActive retrieves all active projects
-	GetActive() ([]*aggregate.Project, error)
+	GetActive(ctx context.Context) ([]*aggregate.Project, error)
+
+	// GetByInboxToken retrieves the project one of whose inbox addresses has the token
+	GetByInboxToken(ctx context.Context, token string) (*aggregate.Project, error)
+
+	// List retrieves a page of the projects matching a filter, sorted by one of ProjectSortFields
+	List(ctx context.Context, filter ProjectFilter, page Page) ([]*aggregate.Project, PagedResult, error)
+}
+
+// ProjectFilter selects projects to list. Empty fields match every project.
+type ProjectFilter struct {
+	OwnerID     string
+	Archived    *bool  // nil matches archived and active projects
+	VisibleOnly bool   // leaves out the restricted projects ViewerID is not a member of
+	ViewerID    string // may be empty for anonymous viewers
 }
 
 // UserRepository defines the interface for user persistence
 type UserRepository interface {
 	// Save persists a user to the repository
-	Save(user *aggregate.User) error
+	Save(ctx context.Context, user *aggregate.User) error
 
 	// GetByID retrieves a user by ID
-	GetByID(id value.UserID) (*aggregate.User, error)
+	GetByID(ctx context.Context, id value.UserID) (*aggregate.User, error)
 
 	// GetByEmail retrieves a user by email
-	GetByEmail(email string) (*aggregate.User, error)
+	GetByEmail(ctx context.Context, email string) (*aggregate.User, error)
 
 	// GetAll retrieves all users
-	GetAll() ([]*aggregate.User, error)
+	GetAll(ctx context.Context) ([]*aggregate.User, error)
 
 	// Delete removes a user from the repository
-	Delete(id value.UserID) error
+	Delete(ctx context.Context, id value.UserID) error
 
 	// Update updates an existing user
-	Update(user *aggregate.User) error
+	Update(ctx context.Context, user *aggregate.User) error
 
 	// GetActive retrieves all active users
-	GetActive() ([]*aggregate.User, error)
+	GetActive(ctx context.Context) ([]*aggregate.User, error)
+
+	// List retrieves a page of the users matching a filter, sorted by one of UserSortFields
+	List(ctx context.Context, filter UserFilter, page Page) ([]*aggregate.User, PagedResult, error)
+}
+
+// UserFilter selects users to list. Empty fields match every user.
+type UserFilter struct {
+	Active *bool // nil matches active and deactivated users
 }
 
 // WorkflowRepository defines the interface for workflow persistence
 type WorkflowRepository interface {
 	// Save persists a workflow to the repository
-	Save(workflow *aggregate.Workflow) error
+	Save(ctx context.Context, workflow *aggregate.Workflow) error
 
-	// GetByID retrieves a workflow by ID
-	GetByID(id value.WorkflowID) (*aggregate.Workflow, error)
+	// GetByID retrieves the latest version of a workflow by ID
+	GetByID(ctx context.Context, id value.WorkflowID) (*aggregate.Workflow, error)
+
+	// GetVersion retrieves one version of a workflow, including versions since superseded
+	GetVersion(ctx context.Context, version value.WorkflowVersion) (*aggregate.Workflow, error)
 
 	// GetByName retrieves a workflow by name
-	GetByName(name string) (*aggregate.Workflow, error)
+	GetByName(ctx context.Context, name string) (*aggregate.Workflow, error)
 
 	// GetAll retrieves all workflows
-	GetAll() ([]*aggregate.Workflow, error)
+	GetAll(ctx context.Context) ([]*aggregate.Workflow, error)
 
 	// Delete removes a workflow from the repository
-	Delete(id value.WorkflowID) error
+	Delete(ctx context.Context, id value.WorkflowID) error
 
-	// Update updates an existing workflow
-	Update(workflow *aggregate.Workflow) error
+	// Update updates an existing workflow. A workflow with a higher version number is kept
+	// as a new version next to the earlier ones.
+	Update(ctx context.Context, workflow *aggregate.Workflow) error
 
 	// GetActive retrieves all active workflows
-	GetActive() ([]*aggregate.Workflow, error)
+	GetActive(ctx context.Context) ([]*aggregate.Workflow, error)
+
+	// List retrieves a page of the latest versions of the workflows matching a filter,
+	// sorted by one of WorkflowSortFields
+	List(ctx context.Context, filter WorkflowFilter, page Page) ([]*aggregate.Workflow, PagedResult, error)
+}
+
+// WorkflowFilter selects workflows to list. Empty fields match every workflow.
+type WorkflowFilter struct {
+	Active *bool // nil matches active and inactive workflows
+}
+
+// WidgetRepository defines the interface for dashboard widget persistence
+type WidgetRepository interface {
+	// Save persists a widget to the repository
+	Save(ctx context.Context, widget *aggregate.Widget) error
+
+	// GetByID retrieves a widget by ID
+	GetByID(ctx context.Context, id value.WidgetID) (*aggregate.Widget, error)
+
+	// GetByOwnerID retrieves all widgets owned by a user
+	GetByOwnerID(ctx context.Context, userID value.UserID) ([]*aggregate.Widget, error)
+
+	// Delete removes a widget from the repository
+	Delete(ctx context.Context, id value.WidgetID) error
+
+	// Update updates an existing widget
+	Update(ctx context.Context, widget *aggregate.Widget) error
+
+	// List retrieves a page of the widgets matching a filter, sorted by one of WidgetSortFields
+	List(ctx context.Context, filter WidgetFilter, page Page) ([]*aggregate.Widget, PagedResult, error)
+}
+
+// WidgetFilter selects widgets to list. Empty fields match every widget.
+type WidgetFilter struct {
+	OwnerID string
+}
+
+// SprintRepository defines the interface for sprint persistence
+type SprintRepository interface {
+	// Save persists a sprint to the repository
+	Save(ctx context.Context, sprint *aggregate.Sprint) error
+
+	// GetByID retrieves a sprint by ID
+	GetByID(ctx context.Context, id value.SprintID) (*aggregate.Sprint, error)
+
+	// GetByProjectID retrieves all sprints of a project, ordered by start date
+	GetByProjectID(ctx context.Context, projectID value.ProjectID) ([]*aggregate.Sprint, error)
+
+	// Update updates an existing sprint
+	Update(ctx context.Context, sprint *aggregate.Sprint) error
+
+	// List retrieves a page of the sprints matching a filter, sorted by one of SprintSortFields
+	List(ctx context.Context, filter SprintFilter, page Page) ([]*aggregate.Sprint, PagedResult, error)
+}
+
+// SprintFilter selects sprints to list. Empty fields match every sprint.
+type SprintFilter struct {
+	ProjectID string
+	Status    string
+}
+
+// TeamRepository defines the interface for team persistence
+type TeamRepository interface {
+	// Save persists a team to the repository
+	Save(ctx context.Context, team *aggregate.Team) error
+
+	// GetByID retrieves a team by ID
+	GetByID(ctx context.Context, id value.TeamID) (*aggregate.Team, error)
+
+	// GetByMemberID retrieves all teams a user belongs to
+	GetByMemberID(ctx context.Context, userID value.UserID) ([]*aggregate.Team, error)
+
+	// GetAll retrieves all teams
+	GetAll(ctx context.Context) ([]*aggregate.Team, error)
+
+	// List retrieves a page of the teams matching a filter, sorted by one of TeamSortFields
+	List(ctx context.Context, filter TeamFilter, page Page) ([]*aggregate.Team, PagedResult, error)
+
+	// Update updates an existing team
+	Update(ctx context.Context, team *aggregate.Team) error
+}
+
+// TeamFilter selects teams to list. Empty fields match every team.
+type TeamFilter struct {
+	MemberID string
+}
+
+// OrganizationRepository defines the interface for organization persistence
+type OrganizationRepository interface {
+	// Save persists an organization to the repository
+	Save(ctx context.Context, organization *aggregate.Organization) error
+
+	// GetByID retrieves an organization by ID
+	GetByID(ctx context.Context, id value.OrganizationID) (*aggregate.Organization, error)
+
+	// GetByMemberID retrieves the organization a user belongs to
+	GetByMemberID(ctx context.Context, userID value.UserID) (*aggregate.Organization, error)
+
+	// Update updates an existing organization
+	Update(ctx context.Context, organization *aggregate.Organization) error
+}
+
+// HolidayCalendarRepository defines the interface for holiday calendar persistence
+type HolidayCalendarRepository interface {
+	// Save persists a holiday calendar to the repository
+	Save(ctx context.Context, calendar *aggregate.HolidayCalendar) error
+
+	// GetByID retrieves a holiday calendar by ID
+	GetByID(ctx context.Context, id value.HolidayCalendarID) (*aggregate.HolidayCalendar, error)
+
+	// GetByOrganizationID retrieves all holiday calendars of an organization, ordered by region
+	GetByOrganizationID(ctx context.Context, organizationID value.OrganizationID) ([]*aggregate.HolidayCalendar, error)
+
+	// Delete removes a holiday calendar from the repository
+	Delete(ctx context.Context, id value.HolidayCalendarID) error
+
+	// Update updates an existing holiday calendar
+	Update(ctx context.Context, calendar *aggregate.HolidayCalendar) error
+
+	// List retrieves a page of the holiday calendars matching a filter, sorted by one of HolidayCalendarSortFields
+	List(ctx context.Context, filter HolidayCalendarFilter, page Page) ([]*aggregate.HolidayCalendar, PagedResult, error)
+}
+
+// HolidayCalendarFilter selects holiday calendars to list. Empty fields match every calendar.
+type HolidayCalendarFilter struct {
+	OrganizationID string
+}
+
+// RecentViewRepository defines the interface for per-user recently viewed items
+type RecentViewRepository interface {
+	// Record stores a view, moving the item to the front of the user's list
+	Record(ctx context.Context, userID value.UserID, view value.RecentView) error
+
+	// GetRecent retrieves a user's most recent views, newest first
+	GetRecent(ctx context.Context, userID value.UserID, limit int) ([]value.RecentView, error)
 }
 
 // UnitOfWork defines the interface for transaction management
 type UnitOfWork interface {
 	// BeginTransaction starts a new transaction
-	BeginTransaction() error
+	BeginTransaction(ctx context.Context) error
 
 	// Commit commits the current transaction
 	Commit() error
@@ -129,4 +308,11 @@ type UnitOfWork interface {
 
 	// GetWorkflowRepository returns the workflow repository
 	GetWorkflowRepository() WorkflowRepository
-}
\ No newline at end of file
+
+	// RecordEvents queues domain events for the outbox; they are written when the
+	// transaction commits and dropped when it rolls back
+	RecordEvents(events ...event.DomainEvent)
+}
+
+// UnitOfWorkFactory starts a fresh UnitOfWork for each business transaction
+type UnitOfWorkFactory func() UnitOfWork
\ No newline at end of file
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Permission is an action that requires authorization
+type Permission string
+
+const (
+	// PermissionManageProject covers changing a project's settings, access, roles, workflow and milestones
+	PermissionManageProject Permission = "project:manage"
+
+	// PermissionDeleteProject covers deleting a project along with its tasks
+	PermissionDeleteProject Permission = "project:delete"
+
+	// PermissionDeleteTask covers deleting a task
+	PermissionDeleteTask Permission = "task:delete"
+
+	// PermissionReassignTasks covers handing all of a user's tasks in a project to someone else
+	PermissionReassignTasks Permission = "task:reassign"
+
+	// PermissionTransitionTask covers moving a task between statuses
+	PermissionTransitionTask Permission = "task:transition"
+
+	// PermissionStartTask covers moving a task into progress
+	PermissionStartTask Permission = "task:start"
+
+	// PermissionScheduleTask covers changing a task's deadline
+	PermissionScheduleTask Permission = "task:schedule"
+
+	// PermissionCreateTask covers adding a task to a project
+	PermissionCreateTask Permission = "task:create"
+
+	// PermissionAssignTask covers handing a task to a user or a team
+	PermissionAssignTask Permission = "task:assign"
+
+	// PermissionEditTask covers changing a task's description, edit lock, links, costs and attachments
+	PermissionEditTask Permission = "task:edit"
+
+	// PermissionDiscussTask covers commenting on and voting for a task
+	PermissionDiscussTask Permission = "task:discuss"
+
+	// PermissionPlanSprints covers planning, starting and completing a project's sprints
+	PermissionPlanSprints Permission = "sprint:plan"
+)
+
+// AuthorizationPolicy decides whether a user may perform an action on a project or one of its tasks
+type AuthorizationPolicy interface {
+	// Authorize returns an errs.ErrPermissionDenied error when the action is not allowed.
+	// The task is nil for project-level permissions.
+	Authorize(actor *aggregate.User, permission Permission, project *aggregate.Project, task *aggregate.Task) error
+}
+
+// RoleBasedPolicy authorizes actions by global and project roles.
+// Global admins may manage and delete anywhere, project admins within their project,
+// members may create, change and move tasks along and plan sprints, viewers may only
+// comment and vote, and only the assignee may start a task.
+type RoleBasedPolicy struct{}
+
+// NewRoleBasedPolicy creates a new RoleBasedPolicy
+func NewRoleBasedPolicy() *RoleBasedPolicy {
+	return &RoleBasedPolicy{}
+}
+
+// Authorize implements AuthorizationPolicy
+func (p *RoleBasedPolicy) Authorize(
+	actor *aggregate.User,
+	permission Permission,
+	project *aggregate.Project,
+	task *aggregate.Task,
+) error {
+	if actor == nil || !actor.IsActive() {
+		return errs.PermissionDenied("permission denied: an active user is required")
+	}
+
+	// Starting work is the assignee's call, whatever their other roles
+	if permission == PermissionStartTask {
+		if task == nil || task.Assignee() == nil || !task.Assignee().IsAssignedTo(actor.ID()) {
+			return errs.PermissionDenied("permission denied: only the assignee can start a task")
+		}
+	}
+
+	if actor.HasRole(value.GlobalRoleAdmin) {
+		return nil
+	}
+
+	role, ok := project.RoleOf(actor.ID())
+	if !ok {
+		return errs.PermissionDenied("permission denied: user has no role in the project")
+	}
+
+	switch permission {
+	case PermissionManageProject:
+		if !role.Includes(value.ProjectRoleAdmin) {
+			return errs.PermissionDenied("permission denied: only project admins can manage the project")
+		}
+	case PermissionDeleteProject:
+		if !project.OwnerID().Equals(actor.ID()) {
+			return errs.PermissionDenied("permission denied: only the project owner can delete the project")
+		}
+	case PermissionDeleteTask:
+		if !role.Includes(value.ProjectRoleAdmin) {
+			return errs.PermissionDenied("permission denied: only project admins can delete tasks")
+		}
+	case PermissionReassignTasks:
+		if !role.Includes(value.ProjectRoleAdmin) {
+			return errs.PermissionDenied("permission denied: only project admins can reassign a user's tasks")
+		}
+	case PermissionTransitionTask, PermissionStartTask, PermissionScheduleTask,
+		PermissionCreateTask, PermissionAssignTask, PermissionEditTask:
+		if !role.Includes(value.ProjectRoleMember) {
+			return errs.PermissionDenied("permission denied: viewers cannot change tasks")
+		}
+	case PermissionPlanSprints:
+		if !role.Includes(value.ProjectRoleMember) {
+			return errs.PermissionDenied("permission denied: viewers cannot plan sprints")
+		}
+	case PermissionDiscussTask:
+		// Every project role may comment and vote
+	default:
+		return errs.PermissionDenied("permission denied: unknown permission %s", permission)
+	}
+
+	return nil
+}
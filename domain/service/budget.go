@@ -0,0 +1,49 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// BudgetService compares a project's spend across its tasks to its budget
+type BudgetService struct{}
+
+// NewBudgetService creates a new BudgetService
+func NewBudgetService() *BudgetService {
+	return &BudgetService{}
+}
+
+// Spend sums the cost entries of all tasks in a currency
+func (s *BudgetService) Spend(currency string, tasks []*aggregate.Task) (value.Money, error) {
+	spent := value.ZeroMoney(currency)
+	for _, task := range tasks {
+		taskCost, err := task.TotalCost(currency)
+		if err != nil {
+			return value.Money{}, fmt.Errorf("task %s: %w", task.ID().Value(), err)
+		}
+
+		spent, err = spent.Add(taskCost)
+		if err != nil {
+			return value.Money{}, err
+		}
+	}
+	return spent, nil
+}
+
+// EvaluateBudget records the project's current spend against its budget.
+// It returns true if the project changed, in which case it may have new domain events.
+func (s *BudgetService) EvaluateBudget(project *aggregate.Project, tasks []*aggregate.Task) (bool, error) {
+	budget := project.Budget()
+	if budget == nil {
+		return false, nil
+	}
+
+	spent, err := s.Spend(budget.Currency(), tasks)
+	if err != nil {
+		return false, err
+	}
+
+	return project.RecordSpend(spent)
+}
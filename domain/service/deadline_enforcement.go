@@ -1,74 +1,139 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
+// DefaultOverdueReminderInterval is how often an overdue task is reported again while it stays open
+const DefaultOverdueReminderInterval = 24 * time.Hour
+
 // DeadlineEnforcementService handles deadline validation and enforcement
 type DeadlineEnforcementService struct {
 	notificationService NotificationService
+	userRepository      UserRepository
+	reminderInterval    time.Duration
 }
 
 // NewDeadlineEnforcementService creates a new DeadlineEnforcementService
 func NewDeadlineEnforcementService(
 	notificationService NotificationService,
+	userRepository UserRepository,
 ) *DeadlineEnforcementService {
 	return &DeadlineEnforcementService{
 		notificationService: notificationService,
+		userRepository:      userRepository,
+		reminderInterval:    DefaultOverdueReminderInterval,
+	}
+}
+
+// SetOverdueReminderInterval sets how often an overdue task is reported again while it stays open
+func (s *DeadlineEnforcementService) SetOverdueReminderInterval(interval time.Duration) {
+	if interval < 0 {
+		interval = 0
 	}
+
+	s.reminderInterval = interval
+}
+
+// OutOfOfficeWarning reports a deadline that falls while the task's assignee is away
+type OutOfOfficeWarning struct {
+	AssigneeID value.UserID
+	Deadline   time.Time
+	Period     value.OutOfOffice
+}
+
+// Message describes the warning for API responses
+func (w OutOfOfficeWarning) Message() string {
+	message := fmt.Sprintf("deadline %s falls while assignee %s is out of office until %s",
+		w.Deadline.Format(time.RFC3339), w.AssigneeID.Value(), w.Period.End().Format(time.RFC3339))
+	if w.Period.Note() != "" {
+		message += " (" + w.Period.Note() + ")"
+	}
+	return message
 }
 
 // ValidateDeadline validates that a deadline is reasonable
 func (s *DeadlineEnforcementService) ValidateDeadline(deadline value.Deadline) error {
 	if deadline.IsOverdue() {
-		return fmt.Errorf("deadline cannot be in the past")
+		return errs.Invalid("deadline cannot be in the past")
 	}
 
 	// Check if deadline is more than 5 years in the future (arbitrary validation)
-	futureThreshold := time.Now().AddDate(5, 0, 0)
+	futureThreshold := value.Now().AddDate(5, 0, 0)
 	if deadline.Value().After(futureThreshold) {
-		return fmt.Errorf("deadline too far in the future")
+		return errs.Invalid("deadline too far in the future")
 	}
 
 	return nil
 }
 
-// SetDeadline sets a deadline on a task with validation
+// SetDeadline sets or changes a task's deadline with validation. Postponing a deadline
+// needs a reason, repeated slips are what project stats report.
 func (s *DeadlineEnforcementService) SetDeadline(
 	task *aggregate.Task,
 	deadline value.Deadline,
+	changedBy value.UserID,
+	reason string,
 ) error {
 	// Validate deadline
 	if err := s.ValidateDeadline(deadline); err != nil {
-		return fmt.Errorf("invalid deadline: %w", err)
+		return errs.Invalid("invalid deadline: %w", err)
 	}
 
 	// Task cannot have a deadline if already completed or cancelled
 	if task.Status() == value.TaskStatusCompleted || task.Status() == value.TaskStatusCancelled {
-		return fmt.Errorf("cannot set deadline for completed or cancelled tasks")
+		return errs.Conflict("cannot set deadline for completed or cancelled tasks")
+	}
+
+	if current := task.Deadline(); current != nil && deadline.Value().After(current.Value()) && strings.TrimSpace(reason) == "" {
+		return errs.Invalid("a reason is required to postpone the deadline from %s to %s",
+			current.Value().Format(time.RFC3339), deadline.Value().Format(time.RFC3339))
 	}
 
 	// Set the deadline
-	if err := task.SetDeadline(deadline); err != nil {
+	if err := task.SetDeadline(deadline, changedBy, reason); err != nil {
 		return fmt.Errorf("failed to set deadline: %w", err)
 	}
 
 	return nil
 }
 
-// CheckOverdueStatus checks and notifies about overdue tasks
-func (s *DeadlineEnforcementService) CheckOverdueStatus(task *aggregate.Task) error {
-	if task.Deadline() == nil {
+// CheckAssigneeAvailability warns when the task's deadline falls within an out-of-office
+// period of its assignee. Unassigned tasks, tasks without a deadline and assignees that
+// cannot be found get no warning.
+func (s *DeadlineEnforcementService) CheckAssigneeAvailability(ctx context.Context, task *aggregate.Task) *OutOfOfficeWarning {
+	if task.Deadline() == nil || task.Assignee() == nil {
 		return nil
 	}
 
-	if task.Deadline().IsOverdue() && task.Status() != value.TaskStatusCompleted && task.Status() != value.TaskStatusCancelled {
-		task.CheckDeadlineStatus()
+	assigneeID := task.Assignee().AssigneeID()
+	assignee, err := s.userRepository.GetByID(ctx, assigneeID)
+	if err != nil {
+		return nil
+	}
 
+	period, away := assignee.OutOfOfficeAt(task.Deadline().Value())
+	if !away {
+		return nil
+	}
+
+	return &OutOfOfficeWarning{
+		AssigneeID: assigneeID,
+		Deadline:   task.Deadline().Value(),
+		Period:     period,
+	}
+}
+
+// CheckOverdueStatus reports an overdue task and notifies its assignee, once per reminder interval
+func (s *DeadlineEnforcementService) CheckOverdueStatus(task *aggregate.Task) error {
+	if task.CheckDeadlineStatus(s.reminderInterval) {
 		// Notify assignee if assigned
 		if task.Assignee() != nil {
 			err := s.notificationService.NotifyTaskOverdue(task)
@@ -119,15 +184,17 @@ func (s *DeadlineEnforcementService) GetOverdueTasks(tasks []*aggregate.Task) []
 func (s *DeadlineEnforcementService) ExtendDeadline(
 	task *aggregate.Task,
 	newDeadline value.Deadline,
+	changedBy value.UserID,
+	reason string,
 ) error {
 	// Validate new deadline is later than current
 	if task.Deadline() != nil {
 		if newDeadline.Value().Before(task.Deadline().Value()) {
-			return fmt.Errorf("new deadline must be after current deadline")
+			return errs.Invalid("new deadline must be after current deadline")
 		}
 	}
 
-	return s.SetDeadline(task, newDeadline)
+	return s.SetDeadline(task, newDeadline, changedBy, reason)
 }
 
 // NotificationService interface for sending notifications
@@ -135,4 +202,5 @@ type NotificationService interface {
 	NotifyTaskOverdue(task *aggregate.Task) error
 	NotifyTaskAssigned(task *aggregate.Task, assigneeID string) error
 	NotifyTaskStatusChanged(task *aggregate.Task, oldStatus, newStatus string) error
+	SendVerificationEmail(user *aggregate.User, token string) error
 }
\ No newline at end of file
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DefaultDuplicateThreshold is the similarity score above which two titles are considered duplicates
+const DefaultDuplicateThreshold = 0.8
+
+// DuplicateCandidate is an existing task whose title resembles a new one
+type DuplicateCandidate struct {
+	Task       *aggregate.Task
+	Similarity float64
+}
+
+// DuplicateDetectionService finds tasks with similar titles within a project
+type DuplicateDetectionService struct{}
+
+// NewDuplicateDetectionService creates a new DuplicateDetectionService
+func NewDuplicateDetectionService() *DuplicateDetectionService {
+	return &DuplicateDetectionService{}
+}
+
+// FindSimilar returns open tasks whose titles score at or above threshold, most similar first.
+// Cancelled tasks are ignored since they are not real duplicates of new work.
+func (s *DuplicateDetectionService) FindSimilar(
+	title string,
+	tasks []*aggregate.Task,
+	threshold float64,
+) []DuplicateCandidate {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultDuplicateThreshold
+	}
+
+	normalized := normalizeTitle(title)
+	if normalized == "" {
+		return nil
+	}
+
+	candidates := make([]DuplicateCandidate, 0)
+	for _, task := range tasks {
+		if task.Status() == value.TaskStatusCancelled {
+			continue
+		}
+
+		similarity := TitleSimilarity(normalized, normalizeTitle(task.Title()))
+		if similarity >= threshold {
+			candidates = append(candidates, DuplicateCandidate{
+				Task:       task,
+				Similarity: similarity,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Similarity > candidates[j].Similarity
+	})
+
+	return candidates
+}
+
+// TitleSimilarity scores two titles between 0 and 1.
+// It takes the better of an edit-distance ratio (catches typos) and
+// word overlap (catches reordered words).
+func TitleSimilarity(a, b string) float64 {
+	a, b = normalizeTitle(a), normalizeTitle(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	editScore := 1 - float64(levenshtein(a, b))/float64(maxInt(len([]rune(a)), len([]rune(b))))
+	wordScore := jaccard(strings.Fields(a), strings.Fields(b))
+
+	if editScore > wordScore {
+		return editScore
+	}
+	return wordScore
+}
+
+// normalizeTitle lowercases, strips punctuation and collapses whitespace
+func normalizeTitle(title string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, title)
+
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func jaccard(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, word := range a {
+		set[word] = true
+	}
+
+	union := len(set)
+	intersection := 0
+	seen := make(map[string]bool, len(b))
+	for _, word := range b {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+
+		if set[word] {
+			intersection++
+		} else {
+			union++
+		}
+	}
+
+	if union == 0 {
+		return 0
+	}
+	return float64(intersection) / float64(union)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
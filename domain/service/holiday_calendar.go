@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// HolidayCalendarService resolves the holidays a project observes and counts business days
+// around them. Projects without a holiday calendar only skip weekends.
+type HolidayCalendarService struct {
+	calendarRepository HolidayCalendarRepository
+	projectRepository  TaskProjectRepository
+}
+
+// NewHolidayCalendarService creates a new HolidayCalendarService
+func NewHolidayCalendarService(
+	calendarRepository HolidayCalendarRepository,
+	projectRepository TaskProjectRepository,
+) *HolidayCalendarService {
+	return &HolidayCalendarService{
+		calendarRepository: calendarRepository,
+		projectRepository:  projectRepository,
+	}
+}
+
+// CalendarOf returns the holiday calendar a project follows, nil when it observes no holidays
+func (s *HolidayCalendarService) CalendarOf(ctx context.Context, projectID value.ProjectID) *aggregate.HolidayCalendar {
+	project, err := s.projectRepository.GetByID(ctx, projectID)
+	if err != nil || project.HolidayCalendarID() == nil {
+		return nil
+	}
+
+	calendar, err := s.calendarRepository.GetByID(ctx, *project.HolidayCalendarID())
+	if err != nil {
+		return nil
+	}
+	return calendar
+}
+
+// AddBusinessDays returns the time the given number of business days after from, skipping
+// weekends and the calendar's holidays. The time of day is kept.
+func (s *HolidayCalendarService) AddBusinessDays(calendar *aggregate.HolidayCalendar, from time.Time, days int) time.Time {
+	date := from
+	for added := 0; added < days; {
+		date = date.AddDate(0, 0, 1)
+		if isBusinessDay(calendar, date) {
+			added++
+		}
+	}
+	return date
+}
+
+// isBusinessDay checks if a day is a weekday the calendar has no holiday on
+func isBusinessDay(calendar *aggregate.HolidayCalendar, date time.Time) bool {
+	if calendar != nil {
+		return calendar.IsBusinessDay(date)
+	}
+	return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+}
+
+// HolidayCalendarRepository interface for getting holiday calendars
+type HolidayCalendarRepository interface {
+	GetByID(ctx context.Context, id value.HolidayCalendarID) (*aggregate.HolidayCalendar, error)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DefaultInboundEmailDomain is the mail domain of inbox addresses until one is configured
+const DefaultInboundEmailDomain = "inbound.localhost"
+
+// InboxAddressService turns project inbox tokens into the email addresses mail is sent to
+type InboxAddressService struct {
+	domain        string
+	gatewaySecret string // empty accepts mail from any relay
+}
+
+// NewInboxAddressService creates a new InboxAddressService
+func NewInboxAddressService() *InboxAddressService {
+	return &InboxAddressService{
+		domain: DefaultInboundEmailDomain,
+	}
+}
+
+// SetDomain sets the mail domain the email-to-task gateway receives mail for
+func (s *InboxAddressService) SetDomain(domain string) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		domain = DefaultInboundEmailDomain
+	}
+
+	s.domain = domain
+}
+
+// SetGatewaySecret sets the secret the inbound mail relay must present to deliver mail
+func (s *InboxAddressService) SetGatewaySecret(secret string) {
+	s.gatewaySecret = secret
+}
+
+// VerifyGatewaySecret checks the secret presented by an inbound mail relay
+func (s *InboxAddressService) VerifyGatewaySecret(secret string) bool {
+	if s.gatewaySecret == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(s.gatewaySecret)) == 1
+}
+
+// AddressOf returns the email address of a project inbox
+func (s *InboxAddressService) AddressOf(inbox value.InboxAddress) string {
+	return inbox.Address(s.domain)
+}
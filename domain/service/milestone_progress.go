@@ -0,0 +1,87 @@
+package service
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// MilestoneProgress summarizes how far a milestone's tasks have come
+type MilestoneProgress struct {
+	Total     int
+	Completed int
+	Percent   float64
+}
+
+// IsComplete checks if every counted task of the milestone is completed
+func (p MilestoneProgress) IsComplete() bool {
+	return p.Total > 0 && p.Completed == p.Total
+}
+
+// MilestoneProgressService computes milestone progress from task state
+type MilestoneProgressService struct{}
+
+// NewMilestoneProgressService creates a new MilestoneProgressService
+func NewMilestoneProgressService() *MilestoneProgressService {
+	return &MilestoneProgressService{}
+}
+
+// Progress counts the milestone's completed tasks. Cancelled and unknown tasks are
+// left out so dropping scope does not hold a milestone back.
+func (s *MilestoneProgressService) Progress(
+	milestone *entity.Milestone,
+	tasksByID map[string]*aggregate.Task,
+) MilestoneProgress {
+	progress := MilestoneProgress{}
+
+	for _, taskID := range milestone.TaskIDs() {
+		task, exists := tasksByID[taskID.Value()]
+		if !exists || task.Status() == value.TaskStatusCancelled {
+			continue
+		}
+
+		progress.Total++
+		if task.Status() == value.TaskStatusCompleted {
+			progress.Completed++
+		}
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = float64(progress.Completed) / float64(progress.Total) * 100
+	}
+
+	return progress
+}
+
+// EvaluateMilestones marks every open milestone whose tasks are all completed as reached.
+// It returns true if any milestone changed, in which case the project has new domain events.
+func (s *MilestoneProgressService) EvaluateMilestones(
+	project *aggregate.Project,
+	tasks []*aggregate.Task,
+	now time.Time,
+) (bool, error) {
+	tasksByID := make(map[string]*aggregate.Task, len(tasks))
+	for _, task := range tasks {
+		tasksByID[task.ID().Value()] = task
+	}
+
+	changed := false
+	for _, milestone := range project.Milestones() {
+		if milestone.IsReached() {
+			continue
+		}
+
+		if !s.Progress(milestone, tasksByID).IsComplete() {
+			continue
+		}
+
+		if err := project.MarkMilestoneReached(milestone.ID(), now); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
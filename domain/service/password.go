@@ -0,0 +1,14 @@
+package service
+
+import "github.com/miladev95/ddd-task/domain/value"
+
+// PasswordHasher hashes passwords and checks them against their hashes
+type PasswordHasher interface {
+	// Hash checks a password against value.ValidatePassword and hashes it with a fresh salt
+	Hash(password string) (value.PasswordHash, error)
+
+	// Matches checks a password against a hash in constant time. A zero or unreadable hash
+	// never matches but costs as much work as one that does, so refusing an unknown user
+	// takes as long as refusing a wrong password.
+	Matches(hash value.PasswordHash, password string) bool
+}
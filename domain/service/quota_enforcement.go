@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// QuotaUsage is what an organization currently uses of its quota
+type QuotaUsage struct {
+	Projects        int
+	Tasks           int
+	AttachmentBytes int64
+}
+
+// QuotaEnforcementService checks organization quotas before work is added.
+// Users outside any organization are not limited.
+type QuotaEnforcementService struct {
+	organizationRepository OrganizationRepository
+	projectRepository      ProjectRepository
+	taskRepository         ProjectTaskRepository
+}
+
+// NewQuotaEnforcementService creates a new QuotaEnforcementService
+func NewQuotaEnforcementService(
+	organizationRepository OrganizationRepository,
+	projectRepository ProjectRepository,
+	taskRepository ProjectTaskRepository,
+) *QuotaEnforcementService {
+	return &QuotaEnforcementService{
+		organizationRepository: organizationRepository,
+		projectRepository:      projectRepository,
+		taskRepository:         taskRepository,
+	}
+}
+
+// OrganizationOf returns the organization of a user, or nil when they belong to none
+func (s *QuotaEnforcementService) OrganizationOf(ctx context.Context, userID value.UserID) *aggregate.Organization {
+	organization, err := s.organizationRepository.GetByMemberID(ctx, userID)
+	if err != nil {
+		return nil
+	}
+	return organization
+}
+
+// Usage counts the projects owned by the organization's members, their tasks and attachment storage
+func (s *QuotaEnforcementService) Usage(ctx context.Context, organization *aggregate.Organization) (QuotaUsage, error) {
+	var usage QuotaUsage
+	for _, memberID := range organization.MemberIDs() {
+		projects, err := s.projectRepository.GetByOwnerID(ctx, memberID)
+		if err != nil {
+			return QuotaUsage{}, fmt.Errorf("failed to get projects: %w", err)
+		}
+
+		for _, project := range projects {
+			tasks, err := s.taskRepository.GetByProjectID(ctx, project.ID())
+			if err != nil {
+				return QuotaUsage{}, fmt.Errorf("failed to get tasks: %w", err)
+			}
+
+			usage.Projects++
+			usage.Tasks += len(tasks)
+			for _, task := range tasks {
+				for _, attachment := range task.Attachments() {
+					usage.AttachmentBytes += attachment.SizeBytes()
+				}
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+// CheckProjectQuota verifies the owner's organization may own one more project
+func (s *QuotaEnforcementService) CheckProjectQuota(ctx context.Context, ownerID value.UserID) error {
+	organization := s.OrganizationOf(ctx, ownerID)
+	if organization == nil || organization.Quota().MaxProjects() == 0 {
+		return nil
+	}
+
+	usage, err := s.Usage(ctx, organization)
+	if err != nil {
+		return err
+	}
+
+	if !organization.Quota().AllowsProjects(usage.Projects + 1) {
+		return errs.QuotaExceeded("quota exceeded: organization %s is limited to %d projects", organization.Name(), organization.Quota().MaxProjects())
+	}
+
+	return nil
+}
+
+// CheckTaskQuota verifies the organization of the project owner may hold more tasks
+func (s *QuotaEnforcementService) CheckTaskQuota(ctx context.Context, project *aggregate.Project, additional int) error {
+	organization := s.OrganizationOf(ctx, project.OwnerID())
+	if organization == nil || organization.Quota().MaxTasks() == 0 {
+		return nil
+	}
+
+	usage, err := s.Usage(ctx, organization)
+	if err != nil {
+		return err
+	}
+
+	if !organization.Quota().AllowsTasks(usage.Tasks + additional) {
+		return errs.QuotaExceeded("quota exceeded: organization %s is limited to %d tasks", organization.Name(), organization.Quota().MaxTasks())
+	}
+
+	return nil
+}
+
+// CheckAttachmentQuota verifies the organization of the project owner may store a file of the given size
+func (s *QuotaEnforcementService) CheckAttachmentQuota(ctx context.Context, project *aggregate.Project, sizeBytes int64) error {
+	organization := s.OrganizationOf(ctx, project.OwnerID())
+	if organization == nil || organization.Quota().MaxAttachmentBytes() == 0 {
+		return nil
+	}
+
+	usage, err := s.Usage(ctx, organization)
+	if err != nil {
+		return err
+	}
+
+	if !organization.Quota().AllowsAttachmentBytes(usage.AttachmentBytes + sizeBytes) {
+		return errs.QuotaExceeded("quota exceeded: organization %s is limited to %d bytes of attachments, %d in use",
+			organization.Name(), organization.Quota().MaxAttachmentBytes(), usage.AttachmentBytes)
+	}
+
+	return nil
+}
+
+// OrganizationRepository interface for finding a user's organization
+type OrganizationRepository interface {
+	GetByMemberID(ctx context.Context, userID value.UserID) (*aggregate.Organization, error)
+}
+
+// ProjectRepository interface for getting the projects a user owns
+type ProjectRepository interface {
+	GetByOwnerID(ctx context.Context, userID value.UserID) ([]*aggregate.Project, error)
+}
+
+// ProjectTaskRepository interface for getting the tasks of a project
+type ProjectTaskRepository interface {
+	GetByProjectID(ctx context.Context, projectID value.ProjectID) ([]*aggregate.Task, error)
+}
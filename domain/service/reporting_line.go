@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// ReportingLineService follows the manager relationships between users: who a user's
+// issues escalate to, and which manager changes would make someone report to themselves
+type ReportingLineService struct {
+	userRepository UserRepository
+}
+
+// NewReportingLineService creates a new ReportingLineService
+func NewReportingLineService(userRepository UserRepository) *ReportingLineService {
+	return &ReportingLineService{
+		userRepository: userRepository,
+	}
+}
+
+// EscalationChain returns the user's manager, their manager and so on up to the top of the
+// hierarchy. The chain stops at a manager that no longer exists and never repeats a user.
+func (s *ReportingLineService) EscalationChain(ctx context.Context, userID value.UserID) []value.UserID {
+	chain := make([]value.UserID, 0)
+	seen := map[string]bool{userID.Value(): true}
+
+	current := userID
+	for {
+		user, err := s.userRepository.GetByID(ctx, current)
+		if err != nil || user.ManagerID() == nil || seen[user.ManagerID().Value()] {
+			return chain
+		}
+
+		current = *user.ManagerID()
+		seen[current.Value()] = true
+		chain = append(chain, current)
+	}
+}
+
+// CheckManager checks the user may report to the manager: the manager is an active user
+// who does not already report, directly or not, to the user
+func (s *ReportingLineService) CheckManager(ctx context.Context, userID, managerID value.UserID) error {
+	if managerID.Equals(userID) {
+		return errs.Invalid("invalid manager: a user cannot report to themselves")
+	}
+
+	manager, err := s.userRepository.GetByID(ctx, managerID)
+	if err != nil {
+		return fmt.Errorf("manager not found: %w", err)
+	}
+	if !manager.IsActive() {
+		return errs.Conflict("cannot report to %s: the manager is deactivated", managerID.Value())
+	}
+
+	for _, above := range s.EscalationChain(ctx, managerID) {
+		if above.Equals(userID) {
+			return errs.Invalid("invalid manager: %s already reports to %s", managerID.Value(), userID.Value())
+		}
+	}
+
+	return nil
+}
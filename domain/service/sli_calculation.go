@@ -0,0 +1,124 @@
+package service
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// TaskSLI holds the service level indicators measured for a single task
+type TaskSLI struct {
+	TaskID                value.TaskID
+	FirstResponseTime     *time.Duration
+	ResolutionTime        *time.Duration
+	FirstResponseBreached bool
+	ResolutionBreached    bool
+}
+
+// ProjectSLISummary aggregates service level indicators across a project's tasks
+type ProjectSLISummary struct {
+	Targets                  value.SLOTargets
+	TaskCount                int
+	RespondedCount           int
+	ResolvedCount            int
+	AverageFirstResponseTime time.Duration
+	AverageResolutionTime    time.Duration
+	FirstResponseBreachCount int
+	ResolutionBreachCount    int
+}
+
+// SLICalculationService computes response and resolution time indicators
+type SLICalculationService struct{}
+
+// NewSLICalculationService creates a new SLICalculationService
+func NewSLICalculationService() *SLICalculationService {
+	return &SLICalculationService{}
+}
+
+// CalculateTaskSLI measures time-to-first-response and time-to-resolution for a task.
+// Tasks still waiting for a response or resolution count as breached once they exceed the target.
+// The timers stop on the holidays of the calendar, which may be nil.
+func (s *SLICalculationService) CalculateTaskSLI(
+	task *aggregate.Task,
+	targets value.SLOTargets,
+	now time.Time,
+	calendar *aggregate.HolidayCalendar,
+) TaskSLI {
+	sli := TaskSLI{TaskID: task.ID()}
+
+	if respondedAt := task.FirstResponseAt(); respondedAt != nil {
+		elapsed := elapsedOutsideHolidays(calendar, task.CreatedAt(), *respondedAt)
+		sli.FirstResponseTime = &elapsed
+		sli.FirstResponseBreached = targets.HasFirstResponseTarget() && elapsed > targets.FirstResponse()
+	} else if targets.HasFirstResponseTarget() && !isClosed(task) {
+		sli.FirstResponseBreached = elapsedOutsideHolidays(calendar, task.CreatedAt(), now) > targets.FirstResponse()
+	}
+
+	if completedAt := task.CompletedAt(); completedAt != nil {
+		elapsed := elapsedOutsideHolidays(calendar, task.CreatedAt(), *completedAt)
+		sli.ResolutionTime = &elapsed
+		sli.ResolutionBreached = targets.HasResolutionTarget() && elapsed > targets.Resolution()
+	} else if targets.HasResolutionTarget() && !isClosed(task) {
+		sli.ResolutionBreached = elapsedOutsideHolidays(calendar, task.CreatedAt(), now) > targets.Resolution()
+	}
+
+	return sli
+}
+
+// SummarizeProject aggregates task indicators against the project's SLO targets, stopping
+// the timers on the holidays of the project's calendar, which may be nil
+func (s *SLICalculationService) SummarizeProject(
+	project *aggregate.Project,
+	tasks []*aggregate.Task,
+	now time.Time,
+	calendar *aggregate.HolidayCalendar,
+) ProjectSLISummary {
+	summary := ProjectSLISummary{
+		Targets:   project.SLOTargets(),
+		TaskCount: len(tasks),
+	}
+
+	var totalFirstResponse, totalResolution time.Duration
+	for _, task := range tasks {
+		sli := s.CalculateTaskSLI(task, summary.Targets, now, calendar)
+
+		if sli.FirstResponseTime != nil {
+			summary.RespondedCount++
+			totalFirstResponse += *sli.FirstResponseTime
+		}
+		if sli.ResolutionTime != nil {
+			summary.ResolvedCount++
+			totalResolution += *sli.ResolutionTime
+		}
+		if sli.FirstResponseBreached {
+			summary.FirstResponseBreachCount++
+		}
+		if sli.ResolutionBreached {
+			summary.ResolutionBreachCount++
+		}
+	}
+
+	if summary.RespondedCount > 0 {
+		summary.AverageFirstResponseTime = totalFirstResponse / time.Duration(summary.RespondedCount)
+	}
+	if summary.ResolvedCount > 0 {
+		summary.AverageResolutionTime = totalResolution / time.Duration(summary.ResolvedCount)
+	}
+
+	return summary
+}
+
+// elapsedOutsideHolidays returns the time from one instant to another, less what falls on holidays
+func elapsedOutsideHolidays(calendar *aggregate.HolidayCalendar, from, to time.Time) time.Duration {
+	elapsed := to.Sub(from)
+	if calendar != nil {
+		elapsed -= calendar.HolidayTimeBetween(from, to)
+	}
+	return elapsed
+}
+
+// isClosed checks if a task has reached a terminal status
+func isClosed(task *aggregate.Task) bool {
+	return task.Status() == value.TaskStatusCompleted || task.Status() == value.TaskStatusCancelled
+}
@@ -1,112 +1,408 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
-// StatusTransitionService handles task status transitions with business rule validation
+// StatusTransitionService handles task status transitions with business rule validation.
+// Moves follow the workflow of the task's project, or the regular task status rules
+// when the project has no workflow.
 type StatusTransitionService struct {
 	workflowRepository WorkflowRepository
+	projectRepository  TaskProjectRepository
 }
 
 // NewStatusTransitionService creates a new StatusTransitionService
 func NewStatusTransitionService(
 	workflowRepository WorkflowRepository,
+	projectRepository TaskProjectRepository,
 ) *StatusTransitionService {
 	return &StatusTransitionService{
 		workflowRepository: workflowRepository,
+		projectRepository:  projectRepository,
 	}
 }
 
+// WorkflowOf returns the workflow of the task's project in the version the task is
+// pinned to, or the latest version when the task is not pinned to one of it
+func (s *StatusTransitionService) WorkflowOf(ctx context.Context, task *aggregate.Task) *aggregate.Workflow {
+	latest := s.LatestWorkflowOf(ctx, task.ProjectID())
+	if latest == nil {
+		return nil
+	}
+
+	pinned := task.WorkflowVersion()
+	if pinned == nil || !pinned.WorkflowID().Equals(latest.ID()) || pinned.Number() == latest.Version() {
+		return latest
+	}
+
+	workflow, err := s.workflowRepository.GetVersion(ctx, *pinned)
+	if err != nil {
+		return latest
+	}
+
+	return workflow
+}
+
+// LatestWorkflowOf returns the latest version of a project's workflow. Projects on a
+// workflow that does not exist follow the default workflow, unsaved when the repository
+// does not hold it; looking a workflow up never stores one.
+func (s *StatusTransitionService) LatestWorkflowOf(ctx context.Context, projectID value.ProjectID) *aggregate.Workflow {
+	project, err := s.projectRepository.GetByID(ctx, projectID)
+	if err == nil {
+		if workflow, err := s.workflowRepository.GetByID(ctx, project.WorkflowID()); err == nil {
+			return workflow
+		}
+	}
+
+	if workflow, err := s.workflowRepository.GetByID(ctx, value.DefaultWorkflowID); err == nil {
+		return workflow
+	}
+	return aggregate.NewDefaultWorkflow()
+}
+
+// GetOrCreateDefaultWorkflow returns the built-in default workflow, saving it first
+// when the repository does not hold it yet
+func (s *StatusTransitionService) GetOrCreateDefaultWorkflow(ctx context.Context) (*aggregate.Workflow, error) {
+	if workflow, err := s.workflowRepository.GetByID(ctx, value.DefaultWorkflowID); err == nil {
+		return workflow, nil
+	}
+
+	workflow := aggregate.NewDefaultWorkflow()
+	if err := s.workflowRepository.Save(ctx, workflow); err != nil {
+		return nil, fmt.Errorf("failed to save default workflow: %w", err)
+	}
+
+	return workflow, nil
+}
+
+// ResolveStatus validates a status name against the task's workflow and returns the
+// workflow's status of that name. Without a workflow only the regular task statuses exist.
+func (s *StatusTransitionService) ResolveStatus(ctx context.Context, task *aggregate.Task, statusName string) (value.ScopedStatus, error) {
+	workflow := s.WorkflowOf(ctx, task)
+	if workflow == nil {
+		status, err := value.NewTaskStatus(statusName)
+		if err != nil {
+			return value.ScopedStatus{}, err
+		}
+		return value.DefaultScopedStatus(status), nil
+	}
+
+	return workflow.ScopedStatus(statusName)
+}
+
+// StageOf returns the task lifecycle stage a status of the task's workflow stands for
+func (s *StatusTransitionService) StageOf(ctx context.Context, task *aggregate.Task, status value.ScopedStatus) value.TaskStatus {
+	if workflow := s.WorkflowOf(ctx, task); workflow != nil && workflow.ID().Equals(status.WorkflowID()) {
+		return workflow.StageOf(status.Name())
+	}
+	return value.TaskStatus(status.Name())
+}
+
+// ScopedStatusOf returns the task's status in its workflow, or in the default workflow
+// when the task's status is one its workflow lacks
+func (s *StatusTransitionService) ScopedStatusOf(ctx context.Context, task *aggregate.Task) value.ScopedStatus {
+	if workflow := s.WorkflowOf(ctx, task); workflow != nil {
+		if scoped, err := workflow.ScopedStatus(task.StatusIn(workflow)); err == nil {
+			return scoped
+		}
+	}
+	return value.DefaultScopedStatus(task.Status())
+}
+
 // CanTransition checks if a task can transition to a new status
-func (s *StatusTransitionService) CanTransition(
+func (s *StatusTransitionService) CanTransition(ctx context.Context, 
 	task *aggregate.Task,
 	newStatus value.TaskStatus,
 ) bool {
-	return task.Status().CanTransitionTo(newStatus)
+	workflow := s.WorkflowOf(ctx, task)
+	if workflow == nil {
+		return task.Status().CanTransitionTo(newStatus)
+	}
+	return workflow.AllowsTransition(task.StatusIn(workflow), newStatus.Value())
 }
 
 // TransitionTask transitions a task to a new status with validation
-func (s *StatusTransitionService) TransitionTask(
+func (s *StatusTransitionService) TransitionTask(ctx context.Context, 
 	task *aggregate.Task,
 	newStatus value.TaskStatus,
+) error {
+	return s.TransitionTaskWithReason(ctx, task, newStatus, "", nil)
+}
+
+// TransitionTaskWithReason transitions a task to a new status with validation, recording why.
+// Policy requires a reason whenever work moves back to an earlier stage.
+func (s *StatusTransitionService) TransitionTaskWithReason(ctx context.Context, 
+	task *aggregate.Task,
+	newStatus value.TaskStatus,
+	reason string,
+	metadata map[string]string,
+) error {
+	target := value.DefaultScopedStatus(newStatus)
+	if workflow := s.WorkflowOf(ctx, task); workflow != nil {
+		scoped, err := workflow.ScopedStatus(newStatus.Value())
+		if err != nil {
+			return fmt.Errorf(
+				"invalid status transition from %s to %s: workflow %s has no status %s",
+				task.StatusIn(workflow),
+				newStatus.Value(),
+				workflow.Name(),
+				newStatus.Value(),
+			)
+		}
+		target = scoped
+	}
+	return s.TransitionTaskBy(ctx, task, target, reason, metadata, value.UserID{})
+}
+
+// statusMove is a move between two statuses, by their names and the lifecycle stages they stand for
+type statusMove struct {
+	from, to           string
+	fromStage, toStage value.TaskStatus
+}
+
+// TransitionTaskBy transitions a task to a status of its workflow on behalf of a user. The
+// workflow transition's guards must pass first, and its actions run after the move, as
+// done by the user. Without a workflow the status must be one of the default workflow.
+func (s *StatusTransitionService) TransitionTaskBy(ctx context.Context, 
+	task *aggregate.Task,
+	newStatus value.ScopedStatus,
+	reason string,
+	metadata map[string]string,
+	actorID value.UserID,
 ) error {
 	// Check if transition is allowed
-	if !s.CanTransition(task, newStatus) {
-		return fmt.Errorf(
-			"invalid status transition from %s to %s",
-			task.Status().Value(),
-			newStatus.Value(),
-		)
+	workflow := s.WorkflowOf(ctx, task)
+	move := statusMove{from: task.Status().Value(), to: newStatus.Name(), fromStage: task.Status()}
+	if workflow == nil {
+		move.toStage = value.TaskStatus(newStatus.Name())
+		if !newStatus.WorkflowID().Equals(value.DefaultWorkflowID) || !task.Status().CanTransitionTo(move.toStage) {
+			return errs.InvalidTransition("invalid status transition from %s to %s", move.from, move.to)
+		}
+	} else {
+		move.from = task.StatusIn(workflow)
+		if !newStatus.WorkflowID().Equals(workflow.ID()) || !workflow.Covers(newStatus.Name()) {
+			return fmt.Errorf(
+				"invalid status transition from %s to %s: workflow %s has no status %s",
+				move.from,
+				move.to,
+				workflow.Name(),
+				move.to,
+			)
+		}
+		if !workflow.AllowsTransition(move.from, move.to) {
+			return errs.InvalidTransition("invalid status transition from %s to %s", move.from, move.to)
+		}
+		move.toStage = workflow.StageOf(move.to)
 	}
 
 	// Additional validation: task must be assigned before moving to in-progress
-	if newStatus == value.TaskStatusInProgress && task.Assignee() == nil {
-		return fmt.Errorf("task must be assigned before moving to in-progress")
+	if move.toStage == value.TaskStatusInProgress && task.Assignee() == nil {
+		return errs.InvalidTransition("task must be assigned before moving to in-progress")
 	}
 
 	// Additional validation: task must have a deadline before completing
-	if newStatus == value.TaskStatusCompleted && task.Deadline() == nil {
-		return fmt.Errorf("task must have a deadline before completion")
+	if move.toStage == value.TaskStatusCompleted && task.Deadline() == nil {
+		return errs.InvalidTransition("task must have a deadline before completion")
+	}
+
+	// Backward transitions must explain why the work is going back
+	if move.fromStage.IsBackwardTo(move.toStage) && strings.TrimSpace(reason) == "" {
+		return fmt.Errorf(
+			"a reason is required to move a task back from %s to %s",
+			move.from,
+			move.to,
+		)
 	}
 
 	// Perform the transition
-	if err := task.ChangeStatus(newStatus); err != nil {
+	if workflow != nil {
+		rules := workflow.TransitionRules(move.from, move.to)
+		if err := checkApprovalStep(task, workflow.ApprovalStepFor(move.from), move); err != nil {
+			return err
+		}
+		if err := checkGuards(task, move, rules.Guards); err != nil {
+			return err
+		}
+		if err := task.ChangeStatusInWorkflow(workflow, newStatus, reason, metadata); err != nil {
+			return fmt.Errorf("failed to change task status: %w", err)
+		}
+		return runActions(task, move, rules.Actions, actorID)
+	}
+	if err := task.ChangeStatusWithReason(move.toStage, reason, metadata); err != nil {
 		return fmt.Errorf("failed to change task status: %w", err)
 	}
 
 	return nil
 }
 
-// GetValidNextStatuses returns the valid next statuses for a task
+// ApprovalStepOf returns the sign-off the task needs before moving on from its current
+// status, nil when it needs none
+func (s *StatusTransitionService) ApprovalStepOf(ctx context.Context, task *aggregate.Task) *value.ApprovalStep {
+	workflow := s.WorkflowOf(ctx, task)
+	if workflow == nil {
+		return nil
+	}
+	return workflow.ApprovalStepFor(task.StatusIn(workflow))
+}
+
+// checkApprovalStep returns why a task may not move on from a status needing sign-off, nil when
+// it has it. Sending the task back or cancelling it needs no sign-off.
+func checkApprovalStep(task *aggregate.Task, step *value.ApprovalStep, move statusMove) error {
+	if step == nil || move.fromStage.IsBackwardTo(move.toStage) || move.toStage == value.TaskStatusCancelled {
+		return nil
+	}
+
+	approvals := 0
+	for _, approval := range task.Approvals() {
+		if !step.IsReviewer(approval.ReviewerID()) {
+			continue
+		}
+		if !approval.IsApproved() {
+			return errs.InvalidTransition("cannot transition from %s to %s: rejected by %s: %s",
+				move.from, move.to, approval.ReviewerID().Value(), approval.Reason())
+		}
+		approvals++
+	}
+
+	if approvals < step.RequiredApprovals() {
+		return errs.InvalidTransition("cannot transition from %s to %s: the task needs sign-off from %d of its reviewers, has %d",
+			move.from, move.to, step.RequiredApprovals(), approvals)
+	}
+
+	return nil
+}
+
+// checkGuards returns why a task may not take a transition, nil when every guard passes
+func checkGuards(
+	task *aggregate.Task,
+	move statusMove,
+	guards []value.TransitionGuard,
+) error {
+	for _, guard := range guards {
+		unmet := ""
+		switch guard.Kind() {
+		case value.TransitionGuardRequiresDeadline:
+			if task.Deadline() == nil {
+				unmet = "the task needs a deadline"
+			}
+		case value.TransitionGuardRequiresAssignee:
+			if task.Assignee() == nil {
+				unmet = "the task needs an assignee"
+			}
+		case value.TransitionGuardRequiresApprovals:
+			if task.ApprovalCount() < guard.Approvals() {
+				unmet = fmt.Sprintf("the task needs %d approvals, has %d", guard.Approvals(), task.ApprovalCount())
+			}
+		}
+
+		if unmet != "" {
+			return errs.InvalidTransition("cannot transition from %s to %s: %s", move.from, move.to, unmet)
+		}
+	}
+	return nil
+}
+
+// runActions runs the actions of a transition the task has just taken
+func runActions(
+	task *aggregate.Task,
+	move statusMove,
+	actions []value.TransitionAction,
+	actorID value.UserID,
+) error {
+	for _, action := range actions {
+		switch action.Kind() {
+		case value.TransitionActionAutoAssign:
+			if assignee := task.Assignee(); assignee != nil && assignee.AssigneeID().Equals(action.AssigneeID()) {
+				continue
+			}
+			if err := task.Assign(action.AssigneeID(), actorID); err != nil {
+				return fmt.Errorf("failed to auto-assign task: %w", err)
+			}
+		case value.TransitionActionNotify:
+			message := fmt.Sprintf("Task %q moved from %s to %s", task.Title(), move.from, move.to)
+			task.RequestNotification(action.Channel(), action.Target(), message)
+		}
+	}
+	return nil
+}
+
+// GetValidNextStatuses returns the valid next statuses for a task by the regular task status rules
 func (s *StatusTransitionService) GetValidNextStatuses(
 	currentStatus value.TaskStatus,
 ) []value.TaskStatus {
 	validStatuses := make([]value.TaskStatus, 0)
+	for _, status := range allTaskStatuses {
+		if currentStatus.CanTransitionTo(status) {
+			validStatuses = append(validStatuses, status)
+		}
+	}
+	return validStatuses
+}
 
-	allStatuses := []value.TaskStatus{
-		value.TaskStatusBacklog,
-		value.TaskStatusToDo,
-		value.TaskStatusInProgress,
-		value.TaskStatusInReview,
-		value.TaskStatusCompleted,
-		value.TaskStatusCancelled,
+// GetValidNextStatusesFor returns the statuses a task may move to in its project's workflow
+func (s *StatusTransitionService) GetValidNextStatusesFor(ctx context.Context, 
+	task *aggregate.Task,
+) []value.TaskStatus {
+	workflow := s.WorkflowOf(ctx, task)
+	if workflow == nil {
+		return s.GetValidNextStatuses(task.Status())
 	}
 
-	for _, status := range allStatuses {
-		if currentStatus.CanTransitionTo(status) {
+	validStatuses := make([]value.TaskStatus, 0)
+	for _, status := range allTaskStatuses {
+		if workflow.AllowsTransition(task.StatusIn(workflow), status.Value()) {
 			validStatuses = append(validStatuses, status)
 		}
 	}
-
 	return validStatuses
 }
 
+// allTaskStatuses lists the regular task statuses in task status order
+var allTaskStatuses = []value.TaskStatus{
+	value.TaskStatusBacklog,
+	value.TaskStatusToDo,
+	value.TaskStatusInProgress,
+	value.TaskStatusInReview,
+	value.TaskStatusCompleted,
+	value.TaskStatusCancelled,
+}
+
 // StartTask starts a task (transitions to in-progress)
-func (s *StatusTransitionService) StartTask(task *aggregate.Task) error {
-	return s.TransitionTask(task, value.TaskStatusInProgress)
+func (s *StatusTransitionService) StartTask(ctx context.Context, task *aggregate.Task) error {
+	return s.TransitionTask(ctx, task, value.TaskStatusInProgress)
 }
 
 // CompleteTask completes a task (transitions to completed)
-func (s *StatusTransitionService) CompleteTask(task *aggregate.Task) error {
-	return s.TransitionTask(task, value.TaskStatusCompleted)
+func (s *StatusTransitionService) CompleteTask(ctx context.Context, task *aggregate.Task) error {
+	return s.TransitionTask(ctx, task, value.TaskStatusCompleted)
 }
 
 // CancelTask cancels a task (transitions to cancelled)
-func (s *StatusTransitionService) CancelTask(task *aggregate.Task) error {
-	return s.TransitionTask(task, value.TaskStatusCancelled)
+func (s *StatusTransitionService) CancelTask(ctx context.Context, task *aggregate.Task) error {
+	return s.TransitionTask(ctx, task, value.TaskStatusCancelled)
 }
 
 // MoveToReview moves a task to review status
-func (s *StatusTransitionService) MoveToReview(task *aggregate.Task) error {
-	return s.TransitionTask(task, value.TaskStatusInReview)
+func (s *StatusTransitionService) MoveToReview(ctx context.Context, task *aggregate.Task) error {
+	return s.TransitionTask(ctx, task, value.TaskStatusInReview)
 }
 
 // WorkflowRepository interface for workflow operations
 type WorkflowRepository interface {
-	GetByID(id value.WorkflowID) (*aggregate.Workflow, error)
-	GetByName(name string) (*aggregate.Workflow, error)
+	Save(ctx context.Context, workflow *aggregate.Workflow) error
+	GetByID(ctx context.Context, id value.WorkflowID) (*aggregate.Workflow, error)
+	GetVersion(ctx context.Context, version value.WorkflowVersion) (*aggregate.Workflow, error)
+	GetByName(ctx context.Context, name string) (*aggregate.Workflow, error)
+}
+
+// TaskProjectRepository interface for finding the project a task belongs to
+type TaskProjectRepository interface {
+	GetByID(ctx context.Context, id value.ProjectID) (*aggregate.Project, error)
 }
\ No newline at end of file
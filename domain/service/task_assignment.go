@@ -1,16 +1,56 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
+// CapacityMode decides what happens when an assignment puts a user over their open task limit
+type CapacityMode string
+
+const (
+	CapacityModeWarn   CapacityMode = "WARN"   // assign anyway and report a warning
+	CapacityModeReject CapacityMode = "REJECT" // refuse the assignment
+)
+
+// NewCapacityMode creates a CapacityMode from string
+func NewCapacityMode(mode string) (CapacityMode, error) {
+	switch CapacityMode(mode) {
+	case CapacityModeWarn, CapacityModeReject:
+		return CapacityMode(mode), nil
+	default:
+		return "", errs.Invalid("invalid capacity mode: %s", mode)
+	}
+}
+
+// CapacityWarning reports an assignment that leaves a user over their open task limit
+type CapacityWarning struct {
+	AssigneeID   value.UserID
+	OpenTasks    int // including the tasks being assigned
+	MaxOpenTasks int
+}
+
+// Message describes the warning for API responses
+func (w CapacityWarning) Message() string {
+	return fmt.Sprintf("assignee %s now has %d open tasks, over the limit of %d", w.AssigneeID.Value(), w.OpenTasks, w.MaxOpenTasks)
+}
+
+// DefaultAcknowledgementWindow is how long an assignee has to acknowledge a task before
+// the assignment is escalated, until another window is configured
+const DefaultAcknowledgementWindow = 24 * time.Hour
+
 // TaskAssignmentService handles task assignment logic
 type TaskAssignmentService struct {
 	userRepository  UserRepository
 	taskRepository  TaskRepository
+	maxOpenTasks    int // 0 means unlimited
+	capacityMode    CapacityMode
+	acknowledgementWindow time.Duration // 0 means assignments are never escalated
 }
 
 // NewTaskAssignmentService creates a new TaskAssignmentService
@@ -21,105 +61,164 @@ func NewTaskAssignmentService(
 	return &TaskAssignmentService{
 		userRepository:  userRepository,
 		taskRepository:  taskRepository,
+		capacityMode:    CapacityModeWarn,
+		acknowledgementWindow: DefaultAcknowledgementWindow,
+	}
+}
+
+// SetCapacityLimit caps how many open tasks a user may hold, 0 removes the cap
+func (s *TaskAssignmentService) SetCapacityLimit(maxOpenTasks int, mode CapacityMode) {
+	if maxOpenTasks < 0 {
+		maxOpenTasks = 0
 	}
+
+	s.maxOpenTasks = maxOpenTasks
+	s.capacityMode = mode
 }
 
-// AssignTask assigns a task to a user
-func (s *TaskAssignmentService) AssignTask(
+// SetAcknowledgementWindow sets how long assignees have to acknowledge a task before the
+// assignment is escalated, 0 turns escalation off
+func (s *TaskAssignmentService) SetAcknowledgementWindow(window time.Duration) {
+	if window < 0 {
+		window = 0
+	}
+
+	s.acknowledgementWindow = window
+}
+
+// AcknowledgementWindow returns how long assignees have to acknowledge a task
+func (s *TaskAssignmentService) AcknowledgementWindow() time.Duration {
+	return s.acknowledgementWindow
+}
+
+// EscalateUnacknowledged escalates the task's assignment if its assignee has not
+// acknowledged it within the window, reporting whether it did
+func (s *TaskAssignmentService) EscalateUnacknowledged(task *aggregate.Task, now time.Time) bool {
+	if s.acknowledgementWindow == 0 {
+		return false
+	}
+
+	return task.EscalateUnacknowledgedAssignment(now, s.acknowledgementWindow)
+}
+
+// AssignTask assigns a task to a user. The warning is set when the assignment
+// leaves the user over their open task limit in warn mode.
+func (s *TaskAssignmentService) AssignTask(ctx context.Context, 
 	task *aggregate.Task,
 	assigneeID value.UserID,
 	assignedBy value.UserID,
-) error {
-	// Verify assignee exists
-	_, err := s.userRepository.GetByID(assigneeID)
+) (*CapacityWarning, error) {
+	// Verify assignee exists and can be reached
+	assignee, err := s.userRepository.GetByID(ctx, assigneeID)
 	if err != nil {
-		return fmt.Errorf("assignee not found: %w", err)
+		return nil, fmt.Errorf("assignee not found: %w", err)
+	}
+
+	if !assignee.IsActive() {
+		return nil, errs.Conflict("assignee is deactivated")
+	}
+
+	if !assignee.IsEmailVerified() {
+		return nil, errs.Conflict("assignee's email is not verified")
 	}
 
 	// Verify assigner has permission (simplified - can be enhanced with permissions service)
-	_, err = s.userRepository.GetByID(assignedBy)
+	_, err = s.userRepository.GetByID(ctx, assignedBy)
 	if err != nil {
-		return fmt.Errorf("assigner not found: %w", err)
+		return nil, fmt.Errorf("assigner not found: %w", err)
+	}
+
+	// Verify the assignee can take on the task
+	warning, err := s.checkTaskCapacity(ctx, task, assigneeID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Assign the task
 	if err := task.Assign(assigneeID, assignedBy); err != nil {
-		return fmt.Errorf("failed to assign task: %w", err)
+		return nil, fmt.Errorf("failed to assign task: %w", err)
 	}
 
-	return nil
+	return warning, nil
 }
 
-// ReassignTask reassigns a task from one user to another
-func (s *TaskAssignmentService) ReassignTask(
+// ReassignTask reassigns a task from one user to another, checking capacity like AssignTask
+func (s *TaskAssignmentService) ReassignTask(ctx context.Context, 
 	task *aggregate.Task,
 	newAssigneeID value.UserID,
 	reassignedBy value.UserID,
-) error {
+) (*CapacityWarning, error) {
 	// Verify task is assigned
 	if task.Assignee() == nil {
-		return fmt.Errorf("task is not assigned")
+		return nil, errs.Conflict("task is not assigned")
 	}
 
-	// Verify new assignee exists
-	_, err := s.userRepository.GetByID(newAssigneeID)
+	// Verify new assignee exists and can be reached
+	newAssignee, err := s.userRepository.GetByID(ctx, newAssigneeID)
 	if err != nil {
-		return fmt.Errorf("new assignee not found: %w", err)
+		return nil, fmt.Errorf("new assignee not found: %w", err)
+	}
+
+	if !newAssignee.IsActive() {
+		return nil, errs.Conflict("new assignee is deactivated")
+	}
+
+	if !newAssignee.IsEmailVerified() {
+		return nil, errs.Conflict("new assignee's email is not verified")
 	}
 
 	// Verify reassigner has permission
-	_, err = s.userRepository.GetByID(reassignedBy)
+	_, err = s.userRepository.GetByID(ctx, reassignedBy)
 	if err != nil {
-		return fmt.Errorf("reassigner not found: %w", err)
+		return nil, fmt.Errorf("reassigner not found: %w", err)
+	}
+
+	// Verify the new assignee can take on the task
+	warning, err := s.checkTaskCapacity(ctx, task, newAssigneeID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Reassign the task
 	if err := task.Assign(newAssigneeID, reassignedBy); err != nil {
-		return fmt.Errorf("failed to reassign task: %w", err)
+		return nil, fmt.Errorf("failed to reassign task: %w", err)
 	}
 
-	return nil
+	return warning, nil
 }
 
 // UnassignTask unassigns a task from its current assignee
-func (s *TaskAssignmentService) UnassignTask(task *aggregate.Task) error {
-	// Verify task is assigned
-	if task.Assignee() == nil {
-		return fmt.Errorf("task is not assigned")
+func (s *TaskAssignmentService) UnassignTask(task *aggregate.Task, unassignedBy value.UserID) error {
+	if err := task.Unassign(unassignedBy); err != nil {
+		return fmt.Errorf("failed to unassign task: %w", err)
 	}
 
-	// Get unassigned user ID (empty/zero user)
-	unassignedID := value.UserID{} // This will be a zero value
+	return nil
+}
 
-	// Create a new unassigned user ID properly
-	tempUserID, err := value.NewUserID("unassigned")
+// GetAssigneeTaskCount returns the number of open tasks assigned to a user
+func (s *TaskAssignmentService) GetAssigneeTaskCount(ctx context.Context, assigneeID value.UserID) (int, error) {
+	tasks, err := s.taskRepository.GetByAssigneeID(ctx, assigneeID)
 	if err != nil {
-		return fmt.Errorf("failed to create unassigned id: %w", err)
+		return 0, fmt.Errorf("failed to get assigned tasks: %w", err)
 	}
 
-	// Assign to unassigned user
-	if err := task.Assign(tempUserID, tempUserID); err != nil {
-		return fmt.Errorf("failed to unassign task: %w", err)
+	count := 0
+	for _, task := range tasks {
+		if task.IsOpen() {
+			count++
+		}
 	}
 
-	_ = unassignedID // Remove unused variable warning if not needed
-
-	return nil
-}
-
-// GetAssigneeTaskCount returns the number of tasks assigned to a user
-func (s *TaskAssignmentService) GetAssigneeTaskCount(assigneeID value.UserID) (int, error) {
-	// This would typically use a repository query
-	// For now, returning 0 as placeholder
-	return 0, nil
+	return count, nil
 }
 
 // ValidateAssignmentCapacity checks if a user can take on more tasks
-func (s *TaskAssignmentService) ValidateAssignmentCapacity(
+func (s *TaskAssignmentService) ValidateAssignmentCapacity(ctx context.Context, 
 	assigneeID value.UserID,
 	maxTasksPerUser int,
 ) (bool, error) {
-	count, err := s.GetAssigneeTaskCount(assigneeID)
+	count, err := s.GetAssigneeTaskCount(ctx, assigneeID)
 	if err != nil {
 		return false, err
 	}
@@ -127,12 +226,50 @@ func (s *TaskAssignmentService) ValidateAssignmentCapacity(
 	return count < maxTasksPerUser, nil
 }
 
+// CheckCapacity checks whether a user can take on additional open tasks under the configured limit.
+// It fails in reject mode and returns a warning in warn mode when the limit would be exceeded.
+func (s *TaskAssignmentService) CheckCapacity(ctx context.Context, assigneeID value.UserID, additional int) (*CapacityWarning, error) {
+	if s.maxOpenTasks == 0 || additional <= 0 {
+		return nil, nil
+	}
+
+	count, err := s.GetAssigneeTaskCount(ctx, assigneeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if count+additional <= s.maxOpenTasks {
+		return nil, nil
+	}
+
+	if s.capacityMode == CapacityModeReject {
+		return nil, errs.Conflict("assignee is at capacity: %d open tasks, limit is %d", count, s.maxOpenTasks)
+	}
+
+	return &CapacityWarning{
+		AssigneeID:   assigneeID,
+		OpenTasks:    count + additional,
+		MaxOpenTasks: s.maxOpenTasks,
+	}, nil
+}
+
+// checkTaskCapacity checks capacity for one task, which adds nothing if the user already holds it
+// or if it is already finished
+func (s *TaskAssignmentService) checkTaskCapacity(ctx context.Context, task *aggregate.Task, assigneeID value.UserID) (*CapacityWarning, error) {
+	if !task.IsOpen() || (task.Assignee() != nil && task.Assignee().IsAssignedTo(assigneeID)) {
+		return nil, nil
+	}
+
+	return s.CheckCapacity(ctx, assigneeID, 1)
+}
+
 // UserRepository interface for getting user information
 type UserRepository interface {
-	GetByID(id value.UserID) (*aggregate.User, error)
+	GetByID(ctx context.Context, id value.UserID) (*aggregate.User, error)
 }
 
 // TaskRepository interface for getting task information
 type TaskRepository interface {
-	GetByID(id value.TaskID) (*aggregate.Task, error)
+	GetByID(ctx context.Context, id value.TaskID) (*aggregate.Task, error)
+	GetByAssigneeID(ctx context.Context, userID value.UserID) ([]*aggregate.Task, error)
 }
\ No newline at end of file
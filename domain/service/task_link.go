@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// TaskLinkService keeps task-to-task links consistent on both aggregates
+type TaskLinkService struct{}
+
+// NewTaskLinkService creates a new TaskLinkService
+func NewTaskLinkService() *TaskLinkService {
+	return &TaskLinkService{}
+}
+
+// LinkTasks links source to target and records the inverse link on target
+func (s *TaskLinkService) LinkTasks(
+	source *aggregate.Task,
+	target *aggregate.Task,
+	linkType value.LinkType,
+	linkedBy value.UserID,
+) error {
+	if !linkType.IsValid() {
+		return errs.Invalid("invalid link type: %s", linkType.Value())
+	}
+
+	if source.ID().Equals(target.ID()) {
+		return errs.Invalid("task cannot be linked to itself")
+	}
+
+	// Check both sides before mutating so the aggregates never diverge
+	if source.HasLink(target.ID(), linkType) || target.HasLink(source.ID(), linkType.Inverse()) {
+		return errs.Conflict("task link already exists")
+	}
+
+	// A subtask has a single parent, so completing its siblings closes exactly one task
+	subtask := source
+	if linkType == value.LinkTypeParentOf {
+		subtask = target
+	}
+	if (linkType == value.LinkTypeSubtaskOf || linkType == value.LinkTypeParentOf) && s.ParentTaskID(subtask) != nil {
+		return errs.Conflict("task already has a parent task")
+	}
+
+	if err := source.AddLink(target.ID(), linkType, linkedBy); err != nil {
+		return fmt.Errorf("failed to link source task: %w", err)
+	}
+
+	if err := target.AddLink(source.ID(), linkType.Inverse(), linkedBy); err != nil {
+		return fmt.Errorf("failed to link target task: %w", err)
+	}
+
+	return nil
+}
+
+// ParentTaskID returns the task a subtask belongs to, or nil when the task is no subtask
+func (s *TaskLinkService) ParentTaskID(task *aggregate.Task) *value.TaskID {
+	for _, link := range task.Links() {
+		if link.LinkType() == value.LinkTypeSubtaskOf {
+			parentID := link.TargetTaskID()
+			return &parentID
+		}
+	}
+	return nil
+}
+
+// SubtaskIDs returns the subtasks of a parent task
+func (s *TaskLinkService) SubtaskIDs(task *aggregate.Task) []value.TaskID {
+	subtaskIDs := make([]value.TaskID, 0)
+	for _, link := range task.Links() {
+		if link.LinkType() == value.LinkTypeParentOf {
+			subtaskIDs = append(subtaskIDs, link.TargetTaskID())
+		}
+	}
+	return subtaskIDs
+}
+
+// UnlinkTasks removes the link between source and target on both aggregates
+func (s *TaskLinkService) UnlinkTasks(
+	source *aggregate.Task,
+	target *aggregate.Task,
+	linkType value.LinkType,
+) error {
+	if !source.HasLink(target.ID(), linkType) {
+		return errs.NotFound("task link not found")
+	}
+
+	if err := source.RemoveLink(target.ID(), linkType); err != nil {
+		return fmt.Errorf("failed to unlink source task: %w", err)
+	}
+
+	// Tolerate a missing inverse side so a half-broken link can still be cleaned up
+	if target.HasLink(source.ID(), linkType.Inverse()) {
+		if err := target.RemoveLink(source.ID(), linkType.Inverse()); err != nil {
+			return fmt.Errorf("failed to unlink target task: %w", err)
+		}
+	}
+
+	return nil
+}
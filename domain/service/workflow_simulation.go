@@ -0,0 +1,316 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Workflow simulation issue codes
+const (
+	WorkflowIssueNoFinalStatus     = "NO_FINAL_STATUS"
+	WorkflowIssueUnknownTransition = "UNKNOWN_TRANSITION_STATUS"
+	WorkflowIssueFinalUnreachable  = "FINAL_UNREACHABLE"
+	WorkflowIssueCycle             = "CYCLE"
+)
+
+// Task problems found by a workflow simulation
+const (
+	TaskProblemStatusMissing    = "STATUS_MISSING"
+	TaskProblemFinalUnreachable = "FINAL_UNREACHABLE"
+	TaskProblemTrappedInCycle   = "TRAPPED_IN_CYCLE"
+)
+
+// WorkflowTransition is a move between two statuses of a proposed workflow
+type WorkflowTransition = aggregate.WorkflowTransition
+
+// WorkflowIssue is a problem with the proposed workflow itself
+type WorkflowIssue struct {
+	Code     string
+	Message  string
+	Statuses []string
+}
+
+// TaskWorkflowImpact is a task that would be invalid under the proposed workflow
+type TaskWorkflowImpact struct {
+	Task    *aggregate.Task
+	Problem string
+}
+
+// WorkflowSimulation is the outcome of checking tasks against a proposed workflow
+type WorkflowSimulation struct {
+	Issues       []WorkflowIssue
+	InvalidTasks []TaskWorkflowImpact
+	TasksChecked int
+}
+
+// Valid reports whether the workflow has no issues and invalidates none of the checked tasks
+func (s WorkflowSimulation) Valid() bool {
+	return len(s.Issues) == 0 && len(s.InvalidTasks) == 0
+}
+
+// WorkflowSimulationService checks a proposed workflow against existing tasks before it is applied
+type WorkflowSimulationService struct{}
+
+// NewWorkflowSimulationService creates a new WorkflowSimulationService
+func NewWorkflowSimulationService() *WorkflowSimulationService {
+	return &WorkflowSimulationService{}
+}
+
+// Simulate reports the workflow's structural issues and the tasks it would strand.
+// Without explicit transitions, tasks move between the workflow's statuses by the
+// regular task status rules, the same rules ChangeTaskStatus enforces today.
+func (s *WorkflowSimulationService) Simulate(
+	workflow *aggregate.Workflow,
+	transitions []WorkflowTransition,
+	tasks []*aggregate.Task,
+) WorkflowSimulation {
+	simulation := WorkflowSimulation{
+		Issues:       make([]WorkflowIssue, 0),
+		InvalidTasks: make([]TaskWorkflowImpact, 0),
+		TasksChecked: len(tasks),
+	}
+
+	statuses := workflow.Statuses()
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].GetOrder() < statuses[j].GetOrder()
+	})
+
+	names := make([]string, 0, len(statuses))
+	final := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		names = append(names, status.GetName())
+		final[status.GetName()] = status.IsFinal()
+	}
+
+	if transitions == nil {
+		transitions = defaultTransitions(names)
+	}
+
+	// Build the transition graph, skipping moves to or from statuses the workflow lacks
+	next := make(map[string][]string, len(names))
+	unknown := make([]string, 0)
+	for _, transition := range transitions {
+		_, fromKnown := final[transition.From]
+		_, toKnown := final[transition.To]
+		if !fromKnown {
+			unknown = appendUnique(unknown, transition.From)
+		}
+		if !toKnown {
+			unknown = appendUnique(unknown, transition.To)
+		}
+		if fromKnown && toKnown {
+			next[transition.From] = appendUnique(next[transition.From], transition.To)
+		}
+	}
+
+	if len(unknown) > 0 {
+		simulation.Issues = append(simulation.Issues, WorkflowIssue{
+			Code:     WorkflowIssueUnknownTransition,
+			Message:  fmt.Sprintf("transitions refer to statuses the workflow does not define: %s", strings.Join(unknown, ", ")),
+			Statuses: unknown,
+		})
+	}
+
+	finals := make([]string, 0)
+	for _, name := range names {
+		if final[name] {
+			finals = append(finals, name)
+		}
+	}
+	if len(finals) == 0 {
+		simulation.Issues = append(simulation.Issues, WorkflowIssue{
+			Code:    WorkflowIssueNoFinalStatus,
+			Message: "workflow has no final status, so no task can ever be finished",
+		})
+	}
+
+	canFinish := reachesAny(names, next, finals)
+
+	// Loops of statuses with no way out to a final status trap their tasks for good
+	trapped := make(map[string]bool)
+	for _, component := range stronglyConnected(names, next) {
+		if canFinish[component[0]] {
+			continue
+		}
+		if len(component) == 1 && !containsString(next[component[0]], component[0]) {
+			continue
+		}
+		for _, name := range component {
+			trapped[name] = true
+		}
+		simulation.Issues = append(simulation.Issues, WorkflowIssue{
+			Code:     WorkflowIssueCycle,
+			Message:  fmt.Sprintf("statuses %s loop without reaching a final status", strings.Join(component, ", ")),
+			Statuses: component,
+		})
+	}
+
+	deadEnds := make([]string, 0)
+	for _, name := range names {
+		if !final[name] && !canFinish[name] && !trapped[name] {
+			deadEnds = append(deadEnds, name)
+		}
+	}
+	if len(deadEnds) > 0 && len(finals) > 0 {
+		simulation.Issues = append(simulation.Issues, WorkflowIssue{
+			Code:     WorkflowIssueFinalUnreachable,
+			Message:  fmt.Sprintf("no final status can be reached from %s", strings.Join(deadEnds, ", ")),
+			Statuses: deadEnds,
+		})
+	}
+
+	// Check each task's current status against the proposed workflow
+	for _, task := range tasks {
+		status := task.Status().Value()
+		isFinal, exists := final[status]
+
+		problem := ""
+		switch {
+		case !exists:
+			problem = TaskProblemStatusMissing
+		case isFinal:
+		case trapped[status]:
+			problem = TaskProblemTrappedInCycle
+		case !canFinish[status]:
+			problem = TaskProblemFinalUnreachable
+		}
+
+		if problem != "" {
+			simulation.InvalidTasks = append(simulation.InvalidTasks, TaskWorkflowImpact{
+				Task:    task,
+				Problem: problem,
+			})
+		}
+	}
+
+	return simulation
+}
+
+// defaultTransitions returns the task status rules between the given statuses
+func defaultTransitions(names []string) []WorkflowTransition {
+	transitions := make([]WorkflowTransition, 0)
+	for _, from := range names {
+		for _, to := range names {
+			if value.TaskStatus(from).CanTransitionTo(value.TaskStatus(to)) {
+				transitions = append(transitions, WorkflowTransition{From: from, To: to})
+			}
+		}
+	}
+	return transitions
+}
+
+// reachesAny marks the statuses from which one of the targets can be reached
+func reachesAny(names []string, next map[string][]string, targets []string) map[string]bool {
+	previous := make(map[string][]string, len(names))
+	for _, from := range names {
+		for _, to := range next[from] {
+			previous[to] = append(previous[to], from)
+		}
+	}
+
+	reached := make(map[string]bool, len(names))
+	queue := append([]string{}, targets...)
+	for _, target := range targets {
+		reached[target] = true
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, from := range previous[current] {
+			if !reached[from] {
+				reached[from] = true
+				queue = append(queue, from)
+			}
+		}
+	}
+
+	return reached
+}
+
+// stronglyConnected splits the status graph into strongly connected components (Tarjan),
+// each listed in workflow order
+func stronglyConnected(names []string, next map[string][]string) [][]string {
+	position := make(map[string]int, len(names))
+	for i, name := range names {
+		position[name] = i
+	}
+
+	index := make(map[string]int, len(names))
+	lowLink := make(map[string]int, len(names))
+	onStack := make(map[string]bool, len(names))
+	stack := make([]string, 0)
+	components := make([][]string, 0)
+	counter := 0
+
+	var visit func(name string)
+	visit = func(name string) {
+		index[name] = counter
+		lowLink[name] = counter
+		counter++
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, to := range next[name] {
+			if _, visited := index[to]; !visited {
+				visit(to)
+				if lowLink[to] < lowLink[name] {
+					lowLink[name] = lowLink[to]
+				}
+			} else if onStack[to] && index[to] < lowLink[name] {
+				lowLink[name] = index[to]
+			}
+		}
+
+		if lowLink[name] == index[name] {
+			component := make([]string, 0)
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == name {
+					break
+				}
+			}
+			sort.SliceStable(component, func(i, j int) bool {
+				return position[component[i]] < position[component[j]]
+			})
+			components = append(components, component)
+		}
+	}
+
+	for _, name := range names {
+		if _, visited := index[name]; !visited {
+			visit(name)
+		}
+	}
+
+	sort.SliceStable(components, func(i, j int) bool {
+		return position[components[i][0]] < position[components[j][0]]
+	})
+
+	return components
+}
+
+// appendUnique appends value unless the slice already holds it
+func appendUnique(values []string, v string) []string {
+	if containsString(values, v) {
+		return values
+	}
+	return append(values, v)
+}
+
+// containsString checks if the slice holds the value
+func containsString(values []string, v string) bool {
+	for _, existing := range values {
+		if existing == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// WorkingHoursService resolves how many hours users have to work on tasks.
+// A user's own working hours win over their organization's default, which wins
+// over value.DefaultWorkingHours.
+type WorkingHoursService struct {
+	userRepository         UserRepository
+	organizationRepository OrganizationRepository
+}
+
+// NewWorkingHoursService creates a new WorkingHoursService
+func NewWorkingHoursService(
+	userRepository UserRepository,
+	organizationRepository OrganizationRepository,
+) *WorkingHoursService {
+	return &WorkingHoursService{
+		userRepository:         userRepository,
+		organizationRepository: organizationRepository,
+	}
+}
+
+// WorkingHoursOf returns the working hours a user follows, and whether they set them themselves
+func (s *WorkingHoursService) WorkingHoursOf(ctx context.Context, userID value.UserID) (value.WorkingHours, bool) {
+	if user, err := s.userRepository.GetByID(ctx, userID); err == nil && user.WorkingHours() != nil {
+		return *user.WorkingHours(), true
+	}
+
+	return s.OrganizationDefaultOf(ctx, userID), false
+}
+
+// OrganizationDefaultOf returns the working hours a user follows unless they set their own
+func (s *WorkingHoursService) OrganizationDefaultOf(ctx context.Context, userID value.UserID) value.WorkingHours {
+	organization, err := s.organizationRepository.GetByMemberID(ctx, userID)
+	if err == nil && organization.DefaultWorkingHours() != nil {
+		return *organization.DefaultWorkingHours()
+	}
+
+	return value.DefaultWorkingHours()
+}
@@ -0,0 +1,134 @@
+package value
+
+import (
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// ApprovalDecision is a reviewer's verdict on a task
+type ApprovalDecision string
+
+const (
+	ApprovalDecisionApproved ApprovalDecision = "APPROVED"
+	ApprovalDecisionRejected ApprovalDecision = "REJECTED"
+)
+
+// Approval records a reviewer's sign-off or rejection of a task in one status
+type Approval struct {
+	reviewerID UserID
+	decision   ApprovalDecision
+	reason     string
+	decidedAt  time.Time
+}
+
+// NewApproval creates a new Approval. Rejections must give a reason.
+func NewApproval(reviewerID UserID, decision ApprovalDecision, reason string, decidedAt time.Time) (Approval, error) {
+	if reviewerID.Equals(UserID{}) {
+		return Approval{}, errs.Invalid("reviewer cannot be empty")
+	}
+
+	reason = strings.TrimSpace(reason)
+	switch decision {
+	case ApprovalDecisionApproved:
+	case ApprovalDecisionRejected:
+		if reason == "" {
+			return Approval{}, errs.Invalid("a reason is required to reject a task")
+		}
+	default:
+		return Approval{}, errs.Invalid("invalid approval decision: %s", decision)
+	}
+
+	return Approval{
+		reviewerID: reviewerID,
+		decision:   decision,
+		reason:     reason,
+		decidedAt:  decidedAt,
+	}, nil
+}
+
+// ReviewerID returns the user who decided
+func (a Approval) ReviewerID() UserID {
+	return a.reviewerID
+}
+
+// Decision returns the verdict
+func (a Approval) Decision() ApprovalDecision {
+	return a.decision
+}
+
+// IsApproved checks if the reviewer signed off
+func (a Approval) IsApproved() bool {
+	return a.decision == ApprovalDecisionApproved
+}
+
+// Reason returns why the reviewer decided so, always set for rejections
+func (a Approval) Reason() string {
+	return a.reason
+}
+
+// DecidedAt returns when the reviewer decided
+func (a Approval) DecidedAt() time.Time {
+	return a.decidedAt
+}
+
+// ApprovalStep asks for sign-off from a number of designated reviewers before a
+// task may move on from a workflow status
+type ApprovalStep struct {
+	requiredApprovals int
+	reviewerIDs       []UserID
+}
+
+// NewApprovalStep creates a new ApprovalStep
+func NewApprovalStep(requiredApprovals int, reviewerIDs []UserID) (ApprovalStep, error) {
+	reviewers := make([]UserID, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		if reviewerID.Equals(UserID{}) {
+			return ApprovalStep{}, errs.Invalid("invalid approval step: reviewer cannot be empty")
+		}
+		duplicate := false
+		for _, existing := range reviewers {
+			if existing.Equals(reviewerID) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			reviewers = append(reviewers, reviewerID)
+		}
+	}
+
+	if len(reviewers) == 0 {
+		return ApprovalStep{}, errs.Invalid("invalid approval step: at least one reviewer is required")
+	}
+	if requiredApprovals < 1 || requiredApprovals > len(reviewers) {
+		return ApprovalStep{}, errs.Invalid("invalid approval step: between 1 and %d approvals can be required from %d reviewers",
+			len(reviewers), len(reviewers))
+	}
+
+	return ApprovalStep{
+		requiredApprovals: requiredApprovals,
+		reviewerIDs:       reviewers,
+	}, nil
+}
+
+// RequiredApprovals returns how many reviewers must sign off
+func (s ApprovalStep) RequiredApprovals() int {
+	return s.requiredApprovals
+}
+
+// ReviewerIDs returns the designated reviewers
+func (s ApprovalStep) ReviewerIDs() []UserID {
+	return append([]UserID{}, s.reviewerIDs...)
+}
+
+// IsReviewer checks if a user is one of the designated reviewers
+func (s ApprovalStep) IsReviewer(userID UserID) bool {
+	for _, reviewerID := range s.reviewerIDs {
+		if reviewerID.Equals(userID) {
+			return true
+		}
+	}
+	return false
+}
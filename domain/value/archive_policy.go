@@ -0,0 +1,37 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// ArchivePolicy decides what happens to a project's open tasks when it is archived
+type ArchivePolicy string
+
+const (
+	// ArchivePolicyFreeze keeps open tasks as they are but blocks further changes
+	ArchivePolicyFreeze ArchivePolicy = "FREEZE"
+	// ArchivePolicyCancel cancels every open task
+	ArchivePolicyCancel ArchivePolicy = "CANCEL"
+)
+
+// NewArchivePolicy creates a new ArchivePolicy from string
+func NewArchivePolicy(policy string) (ArchivePolicy, error) {
+	p := ArchivePolicy(policy)
+	if !p.IsValid() {
+		return "", errs.Invalid("invalid archive policy: %s", policy)
+	}
+	return p, nil
+}
+
+// Value returns the string representation
+func (p ArchivePolicy) Value() string {
+	return string(p)
+}
+
+// IsValid checks if the archive policy is valid
+func (p ArchivePolicy) IsValid() bool {
+	switch p {
+	case ArchivePolicyFreeze, ArchivePolicyCancel:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,31 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// BoardSort orders the tasks within each column of a project board
+type BoardSort string
+
+const (
+	// BoardSortCreated keeps tasks in the order they were created, the default
+	BoardSortCreated BoardSort = "CREATED"
+
+	// BoardSortPriority puts the most urgent tasks first and, among equal priorities,
+	// the earliest deadline; tasks without a deadline come last
+	BoardSortPriority BoardSort = "PRIORITY"
+)
+
+// NewBoardSort creates a new BoardSort from string
+func NewBoardSort(sort string) (BoardSort, error) {
+	s := BoardSort(sort)
+	switch s {
+	case BoardSortCreated, BoardSortPriority:
+		return s, nil
+	default:
+		return "", errs.Invalid("invalid board sort: %s", sort)
+	}
+}
+
+// Value returns the string representation
+func (s BoardSort) Value() string {
+	return string(s)
+}
@@ -1,8 +1,9 @@
 package value
 
 import (
-	"fmt"
 	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
 )
 
 // Deadline represents a task deadline
@@ -10,14 +11,20 @@ type Deadline struct {
 	dueDate time.Time
 }
 
-// NewDeadline creates a new Deadline
+// NewDeadline creates a new Deadline, which cannot be in the past. Repositories restore
+// stored deadlines with RestoreDeadline instead.
 func NewDeadline(dueDate time.Time) (Deadline, error) {
-	if dueDate.Before(time.Now()) {
-		return Deadline{}, fmt.Errorf("deadline cannot be in the past")
+	if dueDate.Before(Now()) {
+		return Deadline{}, errs.Invalid("deadline cannot be in the past")
 	}
 	return Deadline{dueDate: dueDate}, nil
 }
 
+// RestoreDeadline rebuilds a stored Deadline, which may since have passed
+func RestoreDeadline(dueDate time.Time) Deadline {
+	return Deadline{dueDate: dueDate}
+}
+
 // Value returns the time.Time representation
 func (d Deadline) Value() time.Time {
 	return d.dueDate
@@ -25,18 +32,18 @@ func (d Deadline) Value() time.Time {
 
 // IsOverdue checks if the deadline is overdue
 func (d Deadline) IsOverdue() bool {
-	return d.dueDate.Before(time.Now())
+	return d.dueDate.Before(Now())
 }
 
 // IsDueSoon checks if the deadline is due within the specified duration
 func (d Deadline) IsDueSoon(duration time.Duration) bool {
-	now := time.Now()
+	now := Now()
 	return d.dueDate.After(now) && d.dueDate.Before(now.Add(duration))
 }
 
 // DaysUntilDue returns the number of days until the deadline
 func (d Deadline) DaysUntilDue() int {
-	now := time.Now()
+	now := Now()
 	return int(d.dueDate.Sub(now).Hours() / 24)
 }
 
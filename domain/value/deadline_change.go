@@ -0,0 +1,68 @@
+package value
+
+import (
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// DeadlineChange records one change of a task's deadline: when it was due before, when it
+// is due now, who changed it and why
+type DeadlineChange struct {
+	previous  *time.Time
+	dueDate   time.Time
+	changedBy UserID
+	reason    string
+	changedAt time.Time
+}
+
+// NewDeadlineChange creates a new DeadlineChange. Previous is nil when the task had no deadline.
+func NewDeadlineChange(previous *time.Time, dueDate time.Time, changedBy UserID, reason string, changedAt time.Time) (DeadlineChange, error) {
+	if changedBy.Equals(UserID{}) {
+		return DeadlineChange{}, errs.Invalid("deadline changer cannot be empty")
+	}
+
+	if previous != nil {
+		p := *previous
+		previous = &p
+	}
+
+	return DeadlineChange{
+		previous:  previous,
+		dueDate:   dueDate,
+		changedBy: changedBy,
+		reason:    strings.TrimSpace(reason),
+		changedAt: changedAt,
+	}, nil
+}
+
+// Previous returns when the task was due before the change, nil when it had no deadline
+func (c DeadlineChange) Previous() *time.Time {
+	return c.previous
+}
+
+// DueDate returns when the task is due after the change
+func (c DeadlineChange) DueDate() time.Time {
+	return c.dueDate
+}
+
+// ChangedBy returns the user who changed the deadline
+func (c DeadlineChange) ChangedBy() UserID {
+	return c.changedBy
+}
+
+// Reason returns why the deadline changed, empty when none was given
+func (c DeadlineChange) Reason() string {
+	return c.reason
+}
+
+// ChangedAt returns when the deadline changed
+func (c DeadlineChange) ChangedAt() time.Time {
+	return c.changedAt
+}
+
+// IsSlip returns whether the change pushed an existing deadline later
+func (c DeadlineChange) IsSlip() bool {
+	return c.previous != nil && c.dueDate.After(*c.previous)
+}
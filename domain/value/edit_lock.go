@@ -0,0 +1,65 @@
+package value
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// DefaultEditLockTTL is how long an edit lock lasts unless it is renewed
+const DefaultEditLockTTL = 2 * time.Minute
+
+// EditLock is an advisory lock a user holds while editing a task description
+type EditLock struct {
+	holderID   UserID
+	acquiredAt time.Time
+	expiresAt  time.Time
+}
+
+// NewEditLock creates a new EditLock held from acquiredAt for ttl
+func NewEditLock(holderID UserID, acquiredAt time.Time, ttl time.Duration) (EditLock, error) {
+	if ttl <= 0 {
+		return EditLock{}, errs.Invalid("edit lock ttl must be positive")
+	}
+
+	return EditLock{
+		holderID:   holderID,
+		acquiredAt: acquiredAt,
+		expiresAt:  acquiredAt.Add(ttl),
+	}, nil
+}
+
+// HolderID returns the user holding the lock
+func (l EditLock) HolderID() UserID {
+	return l.holderID
+}
+
+// AcquiredAt returns when the lock was first acquired
+func (l EditLock) AcquiredAt() time.Time {
+	return l.acquiredAt
+}
+
+// ExpiresAt returns when the lock lapses unless renewed
+func (l EditLock) ExpiresAt() time.Time {
+	return l.expiresAt
+}
+
+// IsHeldBy checks if the user holds the lock
+func (l EditLock) IsHeldBy(userID UserID) bool {
+	return l.holderID.Equals(userID)
+}
+
+// IsExpired checks if the lock has lapsed at the given time
+func (l EditLock) IsExpired(now time.Time) bool {
+	return !now.Before(l.expiresAt)
+}
+
+// Renew returns the lock extended to ttl from now, keeping its acquisition time
+func (l EditLock) Renew(now time.Time, ttl time.Duration) (EditLock, error) {
+	if ttl <= 0 {
+		return EditLock{}, errs.Invalid("edit lock ttl must be positive")
+	}
+
+	l.expiresAt = now.Add(ttl)
+	return l, nil
+}
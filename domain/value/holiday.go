@@ -0,0 +1,181 @@
+package value
+
+import (
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// HolidayRule is how a holiday falls on the calendar
+type HolidayRule string
+
+const (
+	// HolidayRuleDate is a holiday on one date only
+	HolidayRuleDate HolidayRule = "DATE"
+	// HolidayRuleAnnual is a holiday on the same day of the same month every year
+	HolidayRuleAnnual HolidayRule = "ANNUAL"
+	// HolidayRuleNthWeekday is a holiday on the nth weekday of a month every year, such as
+	// the fourth Thursday of November. The last one of the month is nth -1.
+	HolidayRuleNthWeekday HolidayRule = "NTH_WEEKDAY"
+)
+
+// Holiday is a named day off, either on one date or recurring every year
+type Holiday struct {
+	name    string
+	rule    HolidayRule
+	date    time.Time // HolidayRuleDate only
+	month   time.Month
+	day     int // HolidayRuleAnnual only
+	weekday time.Weekday
+	nth     int // HolidayRuleNthWeekday only
+}
+
+// NewDateHoliday creates a holiday on one date only
+func NewDateHoliday(name string, date time.Time) (Holiday, error) {
+	name, err := holidayName(name)
+	if err != nil {
+		return Holiday{}, err
+	}
+
+	year, month, day := date.Date()
+	return Holiday{
+		name:  name,
+		rule:  HolidayRuleDate,
+		date:  time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		month: month,
+	}, nil
+}
+
+// NewAnnualHoliday creates a holiday on the same day of the same month every year
+func NewAnnualHoliday(name string, month time.Month, day int) (Holiday, error) {
+	name, err := holidayName(name)
+	if err != nil {
+		return Holiday{}, err
+	}
+	if month < time.January || month > time.December {
+		return Holiday{}, errs.Invalid("invalid holiday: unknown month %d", month)
+	}
+	// Checked against a leap year so that February 29 is allowed
+	if day < 1 || day > daysIn(month, 2024) {
+		return Holiday{}, errs.Invalid("invalid holiday: %s has no day %d", month, day)
+	}
+
+	return Holiday{name: name, rule: HolidayRuleAnnual, month: month, day: day}, nil
+}
+
+// NewNthWeekdayHoliday creates a holiday on the nth weekday of a month every year,
+// nth 1 to 4 or -1 for the last one
+func NewNthWeekdayHoliday(name string, month time.Month, weekday time.Weekday, nth int) (Holiday, error) {
+	name, err := holidayName(name)
+	if err != nil {
+		return Holiday{}, err
+	}
+	if month < time.January || month > time.December {
+		return Holiday{}, errs.Invalid("invalid holiday: unknown month %d", month)
+	}
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return Holiday{}, errs.Invalid("invalid holiday: unknown weekday %d", weekday)
+	}
+	if nth != -1 && (nth < 1 || nth > 4) {
+		return Holiday{}, errs.Invalid("invalid holiday: nth must be 1 to 4, or -1 for the last %s of the month", weekday)
+	}
+
+	return Holiday{name: name, rule: HolidayRuleNthWeekday, month: month, weekday: weekday, nth: nth}, nil
+}
+
+// ParseHoliday creates a holiday from its rule and the fields the rule uses: an ISO 8601
+// date for DATE, month and day for ANNUAL, month, weekday name and nth for NTH_WEEKDAY
+func ParseHoliday(name, rule, date string, month, day int, weekday string, nth int) (Holiday, error) {
+	switch HolidayRule(strings.ToUpper(strings.TrimSpace(rule))) {
+	case HolidayRuleDate:
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return Holiday{}, errs.Invalid("invalid holiday date: %w", err)
+		}
+		return NewDateHoliday(name, parsed)
+	case HolidayRuleAnnual:
+		return NewAnnualHoliday(name, time.Month(month), day)
+	case HolidayRuleNthWeekday:
+		parsed, err := parseWeekday(weekday)
+		if err != nil {
+			return Holiday{}, errs.Invalid("invalid holiday: unknown weekday %q", weekday)
+		}
+		return NewNthWeekdayHoliday(name, time.Month(month), parsed, nth)
+	default:
+		return Holiday{}, errs.Invalid("invalid holiday rule: %s", rule)
+	}
+}
+
+// holidayName trims a holiday name and checks it is set
+func holidayName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errs.Invalid("invalid holiday: name cannot be empty")
+	}
+	return name, nil
+}
+
+// daysIn returns the number of days of a month in a year
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Name returns the holiday name
+func (h Holiday) Name() string {
+	return h.name
+}
+
+// Rule returns how the holiday falls on the calendar
+func (h Holiday) Rule() HolidayRule {
+	return h.rule
+}
+
+// Date returns the date of a one-off holiday, zero for recurring ones
+func (h Holiday) Date() time.Time {
+	return h.date
+}
+
+// Month returns the month the holiday is in
+func (h Holiday) Month() time.Month {
+	return h.month
+}
+
+// Day returns the day of the month of an annual holiday, zero otherwise
+func (h Holiday) Day() int {
+	return h.day
+}
+
+// Weekday returns the weekday of an nth weekday holiday
+func (h Holiday) Weekday() time.Weekday {
+	return h.weekday
+}
+
+// Nth returns which weekday of the month an nth weekday holiday is on, -1 for the last,
+// zero otherwise
+func (h Holiday) Nth() int {
+	return h.nth
+}
+
+// OccursOn checks if the holiday falls on the calendar day of the given time
+func (h Holiday) OccursOn(date time.Time) bool {
+	year, month, day := date.Date()
+
+	switch h.rule {
+	case HolidayRuleDate:
+		holidayYear, holidayMonth, holidayDay := h.date.Date()
+		return year == holidayYear && month == holidayMonth && day == holidayDay
+	case HolidayRuleAnnual:
+		return month == h.month && day == h.day
+	case HolidayRuleNthWeekday:
+		if month != h.month || date.Weekday() != h.weekday {
+			return false
+		}
+		if h.nth == -1 {
+			return day+7 > daysIn(month, year)
+		}
+		return (day-1)/7+1 == h.nth
+	default:
+		return false
+	}
+}
@@ -0,0 +1,88 @@
+package value
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// IDFormat is how identifiers must be written
+type IDFormat string
+
+const (
+	IDFormatAny      IDFormat = "ANY"      // any non-empty string, the default
+	IDFormatUUID     IDFormat = "UUID"     // a version 4 UUID
+	IDFormatPrefixed IDFormat = "PREFIXED" // a version 4 UUID behind a per-type prefix, e.g. tsk_<uuid>
+)
+
+// NewIDFormat creates an IDFormat from string
+func NewIDFormat(format string) (IDFormat, error) {
+	switch IDFormat(format) {
+	case IDFormatAny, IDFormatUUID, IDFormatPrefixed:
+		return IDFormat(format), nil
+	default:
+		return "", errs.Invalid("invalid id format: %s", format)
+	}
+}
+
+var (
+	idFormatMu sync.RWMutex
+	idFormat   = IDFormatAny
+)
+
+// CurrentIDFormat returns the format identifiers are validated and generated in
+func CurrentIDFormat() IDFormat {
+	idFormatMu.RLock()
+	defer idFormatMu.RUnlock()
+	return idFormat
+}
+
+// SetIDFormat changes the format identifiers are validated and generated in. Identifiers
+// already stored in another format are rejected once it is stricter.
+func SetIDFormat(format IDFormat) {
+	idFormatMu.Lock()
+	defer idFormatMu.Unlock()
+	idFormat = format
+}
+
+// parseID checks id is written in the configured format; kind names the identifier in errors
+func parseID(kind, prefix, id string) error {
+	if id == "" {
+		return errs.Invalid("%s id cannot be empty", kind)
+	}
+
+	switch CurrentIDFormat() {
+	case IDFormatUUID:
+		if !isUUIDv4(id) {
+			return errs.Invalid("invalid %s id %q: must be a version 4 UUID", kind, id)
+		}
+	case IDFormatPrefixed:
+		rest, ok := strings.CutPrefix(id, prefix+"_")
+		if !ok || !isUUIDv4(rest) {
+			return errs.Invalid("invalid %s id %q: must be %s_ followed by a version 4 UUID", kind, id, prefix)
+		}
+	}
+
+	return nil
+}
+
+// generateID returns a new random identifier in the configured format
+func generateID(prefix string) string {
+	id := uuid.New().String()
+	if CurrentIDFormat() == IDFormatPrefixed {
+		return prefix + "_" + id
+	}
+	return id
+}
+
+// isUUIDv4 checks s is a version 4 UUID in its canonical hyphenated form
+func isUUIDv4(s string) bool {
+	parsed, err := uuid.Parse(s)
+	if err != nil || len(s) != 36 {
+		return false
+	}
+	return parsed.Version() == 4 && parsed.Variant() == uuid.RFC4122
+}
@@ -1,9 +1,16 @@
 package value
 
-import (
-	"fmt"
-
-	"github.com/google/uuid"
+// Prefixes identifiers carry in the PREFIXED id format
+const (
+	taskIDPrefix            = "tsk"
+	projectIDPrefix         = "prj"
+	userIDPrefix            = "usr"
+	workflowIDPrefix        = "wfl"
+	widgetIDPrefix          = "wgt"
+	sprintIDPrefix          = "spr"
+	teamIDPrefix            = "team"
+	organizationIDPrefix    = "org"
+	holidayCalendarIDPrefix = "hcal"
 )
 
 // TaskID represents a unique identifier for a Task
@@ -13,15 +20,15 @@ type TaskID struct {
 
 // NewTaskID creates a new TaskID
 func NewTaskID(id string) (TaskID, error) {
-	if id == "" {
-		return TaskID{}, fmt.Errorf("task id cannot be empty")
+	if err := parseID("task", taskIDPrefix, id); err != nil {
+		return TaskID{}, err
 	}
 	return TaskID{value: id}, nil
 }
 
 // GenerateTaskID generates a new random TaskID
 func GenerateTaskID() TaskID {
-	return TaskID{value: uuid.New().String()}
+	return TaskID{value: generateID(taskIDPrefix)}
 }
 
 // Value returns the string representation of TaskID
@@ -41,15 +48,15 @@ type ProjectID struct {
 
 // NewProjectID creates a new ProjectID
 func NewProjectID(id string) (ProjectID, error) {
-	if id == "" {
-		return ProjectID{}, fmt.Errorf("project id cannot be empty")
+	if err := parseID("project", projectIDPrefix, id); err != nil {
+		return ProjectID{}, err
 	}
 	return ProjectID{value: id}, nil
 }
 
 // GenerateProjectID generates a new random ProjectID
 func GenerateProjectID() ProjectID {
-	return ProjectID{value: uuid.New().String()}
+	return ProjectID{value: generateID(projectIDPrefix)}
 }
 
 // Value returns the string representation of ProjectID
@@ -69,15 +76,15 @@ type UserID struct {
 
 // NewUserID creates a new UserID
 func NewUserID(id string) (UserID, error) {
-	if id == "" {
-		return UserID{}, fmt.Errorf("user id cannot be empty")
+	if err := parseID("user", userIDPrefix, id); err != nil {
+		return UserID{}, err
 	}
 	return UserID{value: id}, nil
 }
 
 // GenerateUserID generates a new random UserID
 func GenerateUserID() UserID {
-	return UserID{value: uuid.New().String()}
+	return UserID{value: generateID(userIDPrefix)}
 }
 
 // Value returns the string representation of UserID
@@ -95,17 +102,20 @@ type WorkflowID struct {
 	value string
 }
 
-// NewWorkflowID creates a new WorkflowID
+// NewWorkflowID creates a new WorkflowID. The built-in default workflow keeps its id in every format.
 func NewWorkflowID(id string) (WorkflowID, error) {
-	if id == "" {
-		return WorkflowID{}, fmt.Errorf("workflow id cannot be empty")
+	if id == DefaultWorkflowID.value {
+		return DefaultWorkflowID, nil
+	}
+	if err := parseID("workflow", workflowIDPrefix, id); err != nil {
+		return WorkflowID{}, err
 	}
 	return WorkflowID{value: id}, nil
 }
 
 // GenerateWorkflowID generates a new random WorkflowID
 func GenerateWorkflowID() WorkflowID {
-	return WorkflowID{value: uuid.New().String()}
+	return WorkflowID{value: generateID(workflowIDPrefix)}
 }
 
 // Value returns the string representation of WorkflowID
@@ -116,4 +126,144 @@ func (w WorkflowID) Value() string {
 // Equals compares two WorkflowIDs for equality
 func (w WorkflowID) Equals(other WorkflowID) bool {
 	return w.value == other.value
-}
\ No newline at end of file
+}
+
+// WidgetID represents a unique identifier for a dashboard Widget
+type WidgetID struct {
+	value string
+}
+
+// NewWidgetID creates a new WidgetID
+func NewWidgetID(id string) (WidgetID, error) {
+	if err := parseID("widget", widgetIDPrefix, id); err != nil {
+		return WidgetID{}, err
+	}
+	return WidgetID{value: id}, nil
+}
+
+// GenerateWidgetID generates a new random WidgetID
+func GenerateWidgetID() WidgetID {
+	return WidgetID{value: generateID(widgetIDPrefix)}
+}
+
+// Value returns the string representation of WidgetID
+func (w WidgetID) Value() string {
+	return w.value
+}
+
+// Equals compares two WidgetIDs for equality
+func (w WidgetID) Equals(other WidgetID) bool {
+	return w.value == other.value
+}
+
+// SprintID represents a unique identifier for a Sprint
+type SprintID struct {
+	value string
+}
+
+// NewSprintID creates a new SprintID
+func NewSprintID(id string) (SprintID, error) {
+	if err := parseID("sprint", sprintIDPrefix, id); err != nil {
+		return SprintID{}, err
+	}
+	return SprintID{value: id}, nil
+}
+
+// GenerateSprintID generates a new random SprintID
+func GenerateSprintID() SprintID {
+	return SprintID{value: generateID(sprintIDPrefix)}
+}
+
+// Value returns the string representation of SprintID
+func (s SprintID) Value() string {
+	return s.value
+}
+
+// Equals compares two SprintIDs for equality
+func (s SprintID) Equals(other SprintID) bool {
+	return s.value == other.value
+}
+
+// TeamID represents a unique identifier for a Team
+type TeamID struct {
+	value string
+}
+
+// NewTeamID creates a new TeamID
+func NewTeamID(id string) (TeamID, error) {
+	if err := parseID("team", teamIDPrefix, id); err != nil {
+		return TeamID{}, err
+	}
+	return TeamID{value: id}, nil
+}
+
+// GenerateTeamID generates a new random TeamID
+func GenerateTeamID() TeamID {
+	return TeamID{value: generateID(teamIDPrefix)}
+}
+
+// Value returns the string representation of TeamID
+func (t TeamID) Value() string {
+	return t.value
+}
+
+// Equals compares two TeamIDs for equality
+func (t TeamID) Equals(other TeamID) bool {
+	return t.value == other.value
+}
+
+// OrganizationID represents a unique identifier for an Organization
+type OrganizationID struct {
+	value string
+}
+
+// NewOrganizationID creates a new OrganizationID
+func NewOrganizationID(id string) (OrganizationID, error) {
+	if err := parseID("organization", organizationIDPrefix, id); err != nil {
+		return OrganizationID{}, err
+	}
+	return OrganizationID{value: id}, nil
+}
+
+// GenerateOrganizationID generates a new random OrganizationID
+func GenerateOrganizationID() OrganizationID {
+	return OrganizationID{value: generateID(organizationIDPrefix)}
+}
+
+// Value returns the string representation of OrganizationID
+func (o OrganizationID) Value() string {
+	return o.value
+}
+
+// Equals compares two OrganizationIDs for equality
+func (o OrganizationID) Equals(other OrganizationID) bool {
+	return o.value == other.value
+}
+
+// HolidayCalendarID represents a unique identifier for a HolidayCalendar
+type HolidayCalendarID struct {
+	value string
+}
+
+// NewHolidayCalendarID creates a new HolidayCalendarID
+func NewHolidayCalendarID(id string) (HolidayCalendarID, error) {
+	if err := parseID("holiday calendar", holidayCalendarIDPrefix, id); err != nil {
+		return HolidayCalendarID{}, err
+	}
+	return HolidayCalendarID{value: id}, nil
+}
+
+// GenerateHolidayCalendarID generates a new random HolidayCalendarID
+func GenerateHolidayCalendarID() HolidayCalendarID {
+	return HolidayCalendarID{value: generateID(holidayCalendarIDPrefix)}
+}
+
+// Value returns the string representation of HolidayCalendarID
+func (h HolidayCalendarID) Value() string {
+	return h.value
+}
+
+// Equals compares two HolidayCalendarIDs for equality
+func (h HolidayCalendarID) Equals(other HolidayCalendarID) bool {
+	return h.value == other.value
+}
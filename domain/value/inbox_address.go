@@ -0,0 +1,97 @@
+package value
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// inboxTokenBytes is the entropy of an inbox token, hex encoded to twice as many characters
+const inboxTokenBytes = 12
+
+// InboxAddress is an inbound email address feeding a project, identified by an unguessable
+// token in its local part so that each mailing list can get its own
+type InboxAddress struct {
+	token     string
+	label     string
+	createdAt time.Time
+}
+
+// GenerateInboxAddress creates an inbox address with a new random token
+func GenerateInboxAddress(label string) (InboxAddress, error) {
+	raw := make([]byte, inboxTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return InboxAddress{}, fmt.Errorf("failed to generate inbox token: %w", err)
+	}
+	return NewInboxAddress(hex.EncodeToString(raw), label, time.Now())
+}
+
+// NewInboxAddress creates an inbox address from an existing token
+func NewInboxAddress(token, label string, createdAt time.Time) (InboxAddress, error) {
+	if !isInboxToken(token) {
+		return InboxAddress{}, errs.Invalid("invalid inbox token: %q", token)
+	}
+	label = strings.TrimSpace(label)
+	if len(label) > 100 {
+		return InboxAddress{}, errs.Invalid("invalid inbox label: longer than 100 characters")
+	}
+
+	return InboxAddress{token: token, label: label, createdAt: createdAt}, nil
+}
+
+// InboxTokenOf returns the inbox token of an email address, the part after the last "+"
+// of its local part (tasks+token@domain) or the whole local part (token@domain)
+func InboxTokenOf(address string) (string, bool) {
+	address = strings.ToLower(strings.TrimSpace(address))
+	// Display names wrap the address in angle brackets
+	if start := strings.LastIndex(address, "<"); start >= 0 {
+		address = strings.TrimSuffix(address[start+1:], ">")
+	}
+
+	at := strings.LastIndex(address, "@")
+	if at <= 0 {
+		return "", false
+	}
+	local := address[:at]
+	if plus := strings.LastIndex(local, "+"); plus >= 0 {
+		local = local[plus+1:]
+	}
+
+	if !isInboxToken(local) {
+		return "", false
+	}
+	return local, true
+}
+
+// isInboxToken checks if a string is shaped like a generated inbox token
+func isInboxToken(token string) bool {
+	if len(token) != 2*inboxTokenBytes {
+		return false
+	}
+	_, err := hex.DecodeString(token)
+	return err == nil && token == strings.ToLower(token)
+}
+
+// Token returns the token identifying the address
+func (a InboxAddress) Token() string {
+	return a.token
+}
+
+// Label returns what the address is for, such as the mailing list feeding it
+func (a InboxAddress) Label() string {
+	return a.label
+}
+
+// CreatedAt returns when the address was generated
+func (a InboxAddress) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// Address returns the email address at the given inbound mail domain
+func (a InboxAddress) Address(domain string) string {
+	return "tasks+" + a.token + "@" + domain
+}
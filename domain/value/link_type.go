@@ -0,0 +1,61 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// LinkType represents the kind of relationship between two tasks
+type LinkType string
+
+const (
+	LinkTypeRelatesTo    LinkType = "RELATES_TO"
+	LinkTypeDuplicates   LinkType = "DUPLICATES"
+	LinkTypeDuplicatedBy LinkType = "DUPLICATED_BY"
+	LinkTypeCausedBy     LinkType = "CAUSED_BY"
+	LinkTypeCauses       LinkType = "CAUSES"
+	LinkTypeSubtaskOf    LinkType = "SUBTASK_OF" // the task is a subtask of the linked parent task
+	LinkTypeParentOf     LinkType = "PARENT_OF"
+)
+
+// NewLinkType creates a new LinkType from string
+func NewLinkType(linkType string) (LinkType, error) {
+	lt := LinkType(linkType)
+	if !lt.IsValid() {
+		return "", errs.Invalid("invalid link type: %s", linkType)
+	}
+	return lt, nil
+}
+
+// Value returns the string representation
+func (l LinkType) Value() string {
+	return string(l)
+}
+
+// IsValid checks if the link type is valid
+func (l LinkType) IsValid() bool {
+	switch l {
+	case LinkTypeRelatesTo, LinkTypeDuplicates, LinkTypeDuplicatedBy, LinkTypeCausedBy, LinkTypeCauses,
+		LinkTypeSubtaskOf, LinkTypeParentOf:
+		return true
+	default:
+		return false
+	}
+}
+
+// Inverse returns the link type as seen from the other task
+func (l LinkType) Inverse() LinkType {
+	switch l {
+	case LinkTypeDuplicates:
+		return LinkTypeDuplicatedBy
+	case LinkTypeDuplicatedBy:
+		return LinkTypeDuplicates
+	case LinkTypeCausedBy:
+		return LinkTypeCauses
+	case LinkTypeCauses:
+		return LinkTypeCausedBy
+	case LinkTypeSubtaskOf:
+		return LinkTypeParentOf
+	case LinkTypeParentOf:
+		return LinkTypeSubtaskOf
+	default:
+		return l
+	}
+}
@@ -0,0 +1,64 @@
+package value
+
+import (
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// Locale is a BCP 47 language tag such as "de" or "pt-BR"
+type Locale string
+
+// NewLocale creates a Locale, normalizing the language to lower case and the region to
+// upper case
+func NewLocale(tag string) (Locale, error) {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	if len(parts[0]) < 2 || len(parts[0]) > 3 || !isLetters(parts[0]) {
+		return "", errs.Invalid("invalid locale: %q", tag)
+	}
+	parts[0] = strings.ToLower(parts[0])
+
+	for i, part := range parts[1:] {
+		switch {
+		case len(part) == 2 && isLetters(part):
+			parts[i+1] = strings.ToUpper(part)
+		case len(part) >= 2 && len(part) <= 8 && isAlphanumeric(part):
+			// Scripts and variants keep their case
+		default:
+			return "", errs.Invalid("invalid locale: %q", tag)
+		}
+	}
+
+	return Locale(strings.Join(parts, "-")), nil
+}
+
+// isLetters checks if a string is made of ASCII letters only
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumeric checks if a string is made of ASCII letters and digits only
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Value returns the string representation
+func (l Locale) Value() string {
+	return string(l)
+}
+
+// Language returns the language subtag, "pt" for "pt-BR"
+func (l Locale) Language() string {
+	language, _, _ := strings.Cut(string(l), "-")
+	return language
+}
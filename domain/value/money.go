@@ -0,0 +1,133 @@
+package value
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// Money is an amount in minor units (cents) of an ISO 4217 currency with two decimals
+type Money struct {
+	minor    int64
+	currency string
+}
+
+// NewMoney creates Money from an amount in minor units
+func NewMoney(minor int64, currency string) (Money, error) {
+	if !isCurrencyCode(currency) {
+		return Money{}, errs.Invalid("invalid currency: %s", currency)
+	}
+
+	return Money{minor: minor, currency: currency}, nil
+}
+
+// ParseMoney creates Money from a decimal amount such as "1250" or "99.95"
+func ParseMoney(amount, currency string) (Money, error) {
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	digits := strings.TrimPrefix(amount, "-")
+
+	whole, fraction, hasFraction := strings.Cut(digits, ".")
+	if whole == "" || (hasFraction && (fraction == "" || len(fraction) > 2)) {
+		return Money{}, errs.Invalid("invalid amount: %s", amount)
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units < 0 {
+		return Money{}, errs.Invalid("invalid amount: %s", amount)
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil || cents < 0 {
+		return Money{}, errs.Invalid("invalid amount: %s", amount)
+	}
+
+	minor := units*100 + cents
+	if negative {
+		minor = -minor
+	}
+
+	return NewMoney(minor, currency)
+}
+
+// ZeroMoney returns no money of a currency
+func ZeroMoney(currency string) Money {
+	return Money{currency: currency}
+}
+
+// MinorUnits returns the amount in minor units
+func (m Money) MinorUnits() int64 {
+	return m.minor
+}
+
+// Currency returns the ISO 4217 currency code
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Amount returns the amount as a decimal string with two places
+func (m Money) Amount() string {
+	minor := m.minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/100, minor%100)
+}
+
+// String returns the amount followed by the currency
+func (m Money) String() string {
+	return m.Amount() + " " + m.currency
+}
+
+// IsNegative checks if the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+// Add returns the sum of two amounts of the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, errs.Invalid("currency mismatch: %s and %s", m.currency, other.currency)
+	}
+	return Money{minor: m.minor + other.minor, currency: m.currency}, nil
+}
+
+// Subtract returns the difference of two amounts of the same currency
+func (m Money) Subtract(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, errs.Invalid("currency mismatch: %s and %s", m.currency, other.currency)
+	}
+	return Money{minor: m.minor - other.minor, currency: m.currency}, nil
+}
+
+// GreaterThan checks if the amount exceeds another amount of the same currency
+func (m Money) GreaterThan(other Money) (bool, error) {
+	if m.currency != other.currency {
+		return false, errs.Invalid("currency mismatch: %s and %s", m.currency, other.currency)
+	}
+	return m.minor > other.minor, nil
+}
+
+// Equals checks if two amounts are the same
+func (m Money) Equals(other Money) bool {
+	return m.minor == other.minor && m.currency == other.currency
+}
+
+// isCurrencyCode checks for three upper case letters
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package value
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// AllEvents is the event type wildcard of a notification route
+const AllEvents = "*"
+
+// NotificationChannel represents where a notification is delivered
+type NotificationChannel string
+
+const (
+	NotificationChannelSlack   NotificationChannel = "SLACK"
+	NotificationChannelEmail   NotificationChannel = "EMAIL"
+	NotificationChannelWebhook NotificationChannel = "WEBHOOK"
+)
+
+// NewNotificationChannel creates a new NotificationChannel from string
+func NewNotificationChannel(channel string) (NotificationChannel, error) {
+	c := NotificationChannel(channel)
+	if !c.IsValid() {
+		return "", errs.Invalid("invalid notification channel: %s", channel)
+	}
+	return c, nil
+}
+
+// Value returns the string representation
+func (c NotificationChannel) Value() string {
+	return string(c)
+}
+
+// IsValid checks if the notification channel is valid
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case NotificationChannelSlack, NotificationChannelEmail, NotificationChannelWebhook:
+		return true
+	default:
+		return false
+	}
+}
+
+// NotificationDelivery represents when a notification is delivered
+type NotificationDelivery string
+
+const (
+	NotificationDeliveryImmediate    NotificationDelivery = "IMMEDIATE"
+	NotificationDeliveryDailyDigest  NotificationDelivery = "DAILY_DIGEST"
+	NotificationDeliveryWeeklyDigest NotificationDelivery = "WEEKLY_DIGEST"
+)
+
+// NewNotificationDelivery creates a new NotificationDelivery from string
+func NewNotificationDelivery(delivery string) (NotificationDelivery, error) {
+	d := NotificationDelivery(delivery)
+	if !d.IsValid() {
+		return "", errs.Invalid("invalid notification delivery: %s", delivery)
+	}
+	return d, nil
+}
+
+// Value returns the string representation
+func (d NotificationDelivery) Value() string {
+	return string(d)
+}
+
+// IsValid checks if the notification delivery is valid
+func (d NotificationDelivery) IsValid() bool {
+	switch d {
+	case NotificationDeliveryImmediate, NotificationDeliveryDailyDigest, NotificationDeliveryWeeklyDigest:
+		return true
+	default:
+		return false
+	}
+}
+
+// DigestPeriod returns how often a digest is sent, zero for immediate delivery
+func (d NotificationDelivery) DigestPeriod() time.Duration {
+	switch d {
+	case NotificationDeliveryDailyDigest:
+		return 24 * time.Hour
+	case NotificationDeliveryWeeklyDigest:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// NotificationRoute sends events of one type to a channel target
+type NotificationRoute struct {
+	eventType string
+	channel   NotificationChannel
+	target    string
+	delivery  NotificationDelivery
+}
+
+// NewNotificationRoute creates a new NotificationRoute.
+// The target is channel specific, e.g. a Slack channel, an email address or a URL.
+func NewNotificationRoute(
+	eventType string,
+	channel NotificationChannel,
+	target string,
+	delivery NotificationDelivery,
+) (NotificationRoute, error) {
+	if eventType == "" {
+		return NotificationRoute{}, errs.Invalid("route event type cannot be empty")
+	}
+
+	if !channel.IsValid() {
+		return NotificationRoute{}, errs.Invalid("invalid notification channel: %s", channel.Value())
+	}
+
+	if target == "" {
+		return NotificationRoute{}, errs.Invalid("route target cannot be empty")
+	}
+
+	if !delivery.IsValid() {
+		return NotificationRoute{}, errs.Invalid("invalid notification delivery: %s", delivery.Value())
+	}
+
+	return NotificationRoute{
+		eventType: eventType,
+		channel:   channel,
+		target:    target,
+		delivery:  delivery,
+	}, nil
+}
+
+// EventType returns the routed event type, or AllEvents
+func (r NotificationRoute) EventType() string {
+	return r.eventType
+}
+
+// Channel returns the delivery channel
+func (r NotificationRoute) Channel() NotificationChannel {
+	return r.channel
+}
+
+// Target returns the channel specific destination
+func (r NotificationRoute) Target() string {
+	return r.target
+}
+
+// Delivery returns when notifications are delivered
+func (r NotificationRoute) Delivery() NotificationDelivery {
+	return r.delivery
+}
+
+// Matches checks if the route applies to an event type
+func (r NotificationRoute) Matches(eventType string) bool {
+	return r.eventType == AllEvents || r.eventType == eventType
+}
+
+// Equals compares two NotificationRoutes for equality
+func (r NotificationRoute) Equals(other NotificationRoute) bool {
+	return r == other
+}
@@ -0,0 +1,60 @@
+package value
+
+import (
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// MaxOutOfOfficeNoteLength bounds the note shown with an out-of-office period
+const MaxOutOfOfficeNoteLength = 200
+
+// OutOfOffice is a period a user is away, from start up to but not including end
+type OutOfOffice struct {
+	start time.Time
+	end   time.Time
+	note  string
+}
+
+// NewOutOfOffice creates a new OutOfOffice
+func NewOutOfOffice(start, end time.Time, note string) (OutOfOffice, error) {
+	if start.IsZero() || end.IsZero() {
+		return OutOfOffice{}, errs.Invalid("invalid out-of-office period: start and end are required")
+	}
+	if !end.After(start) {
+		return OutOfOffice{}, errs.Invalid("invalid out-of-office period: end must be after start")
+	}
+
+	note = strings.TrimSpace(note)
+	if len(note) > MaxOutOfOfficeNoteLength {
+		return OutOfOffice{}, errs.Invalid("invalid out-of-office period: note must be at most %d characters", MaxOutOfOfficeNoteLength)
+	}
+
+	return OutOfOffice{start: start, end: end, note: note}, nil
+}
+
+// Start returns when the user leaves
+func (o OutOfOffice) Start() time.Time {
+	return o.start
+}
+
+// End returns when the user is back
+func (o OutOfOffice) End() time.Time {
+	return o.end
+}
+
+// Note returns the note shown with the period, such as a stand-in to contact
+func (o OutOfOffice) Note() string {
+	return o.note
+}
+
+// Contains checks if the user is away at the given time
+func (o OutOfOffice) Contains(at time.Time) bool {
+	return !at.Before(o.start) && at.Before(o.end)
+}
+
+// Overlaps checks if two periods share any time
+func (o OutOfOffice) Overlaps(other OutOfOffice) bool {
+	return o.start.Before(other.end) && other.start.Before(o.end)
+}
@@ -0,0 +1,53 @@
+package value
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+const (
+	// MinPasswordLength is the shortest password a user may choose
+	MinPasswordLength = 8
+
+	// MaxPasswordLength bounds the work a single login attempt can cause
+	MaxPasswordLength = 128
+)
+
+// PasswordHash is an encoded password hash. The domain only stores it; a
+// service.PasswordHasher makes and checks it. The encoding names its scheme, e.g.
+// "$argon2id$v=19$...", so the scheme can be replaced without invalidating stored hashes.
+type PasswordHash struct {
+	encoded string
+}
+
+// ValidatePassword checks a plain text password against the password policy
+func ValidatePassword(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < MinPasswordLength {
+		return errs.Invalid("password must be at least %d characters", MinPasswordLength)
+	}
+	if length > MaxPasswordLength {
+		return errs.Invalid("password must be at most %d characters", MaxPasswordLength)
+	}
+	return nil
+}
+
+// ParsePasswordHash restores a PasswordHash from its encoded form
+func ParsePasswordHash(encoded string) (PasswordHash, error) {
+	if !strings.Contains(strings.TrimPrefix(encoded, "$"), "$") {
+		return PasswordHash{}, errs.Invalid("invalid password hash")
+	}
+	return PasswordHash{encoded: encoded}, nil
+}
+
+// IsZero reports whether the hash is empty
+func (h PasswordHash) IsZero() bool {
+	return h.encoded == ""
+}
+
+// String returns the encoded hash
+func (h PasswordHash) String() string {
+	return h.encoded
+}
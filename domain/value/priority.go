@@ -1,26 +1,44 @@
 package value
 
-import "fmt"
+import (
+	"strings"
 
-// Priority represents the priority level of a task
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// Priority represents the priority level of a task, one of the levels of its project's
+// priority scheme
 type Priority string
 
 const (
-	PriorityLow    Priority = "LOW"
-	PriorityMedium Priority = "MEDIUM"
-	PriorityHigh   Priority = "HIGH"
+	PriorityLow      Priority = "LOW"
+	PriorityMedium   Priority = "MEDIUM"
+	PriorityHigh     Priority = "HIGH"
 	PriorityCritical Priority = "CRITICAL"
 )
 
-// NewPriority creates a new Priority from string
+// maxPriorityNameLength bounds the name of a priority level
+const maxPriorityNameLength = 32
+
+// NewPriority creates a new Priority of the default scheme from string
 func NewPriority(priority string) (Priority, error) {
 	p := Priority(priority)
 	switch p {
 	case PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical:
 		return p, nil
 	default:
-		return "", fmt.Errorf("invalid priority: %s", priority)
+		return "", errs.Invalid("invalid priority: %s", priority)
+	}
+}
+
+// ParsePriority creates a Priority of any scheme, upper casing the name. Whether the
+// project's scheme has the level is for the scheme to say.
+func ParsePriority(priority string) (Priority, error) {
+	p := Priority(strings.ToUpper(strings.TrimSpace(priority)))
+	if !p.IsValid() {
+		return "", errs.Invalid("invalid priority: %q", priority)
 	}
+	return p, nil
 }
 
 // Value returns the string representation
@@ -28,17 +46,22 @@ func (p Priority) Value() string {
 	return string(p)
 }
 
-// IsValid checks if the priority is valid
+// IsValid checks if the priority is a well-formed level name: upper case letters, digits,
+// "_" and "-", such as HIGH or P1
 func (p Priority) IsValid() bool {
-	switch p {
-	case PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical:
-		return true
-	default:
+	if len(p) == 0 || len(p) > maxPriorityNameLength || p[0] == '-' || p[0] == '_' {
 		return false
 	}
+	for _, r := range p {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
 }
 
-// Numeric returns a numeric representation for comparison
+// Numeric returns the rank of the priority in the default scheme, 0 for other priorities.
+// Use PriorityScheme.Rank for the rank in a project's scheme.
 func (p Priority) Numeric() int {
 	switch p {
 	case PriorityLow:
@@ -52,4 +75,4 @@ func (p Priority) Numeric() int {
 	default:
 		return 0
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,135 @@
+package value
+
+import (
+	"sort"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// Bounds on the number of levels of a priority scheme
+const (
+	minPriorityLevels = 2
+	maxPriorityLevels = 20
+)
+
+// PriorityLevel is one rung of a priority ladder. Higher ranks are more urgent.
+type PriorityLevel struct {
+	priority Priority
+	rank     int
+}
+
+// NewPriorityLevel creates a new PriorityLevel
+func NewPriorityLevel(name string, rank int) (PriorityLevel, error) {
+	priority, err := ParsePriority(name)
+	if err != nil {
+		return PriorityLevel{}, err
+	}
+	return PriorityLevel{priority: priority, rank: rank}, nil
+}
+
+// Priority returns the priority of the level
+func (l PriorityLevel) Priority() Priority {
+	return l.priority
+}
+
+// Rank returns the numeric order of the level, higher is more urgent
+func (l PriorityLevel) Rank() int {
+	return l.rank
+}
+
+// PriorityScheme is the ladder of priorities the tasks of a project may have
+type PriorityScheme struct {
+	levels []PriorityLevel // least urgent first
+}
+
+// DefaultPriorityScheme returns the LOW, MEDIUM, HIGH, CRITICAL ladder projects start with
+func DefaultPriorityScheme() PriorityScheme {
+	return PriorityScheme{levels: []PriorityLevel{
+		{priority: PriorityLow, rank: PriorityLow.Numeric()},
+		{priority: PriorityMedium, rank: PriorityMedium.Numeric()},
+		{priority: PriorityHigh, rank: PriorityHigh.Numeric()},
+		{priority: PriorityCritical, rank: PriorityCritical.Numeric()},
+	}}
+}
+
+// NewPriorityScheme creates a new PriorityScheme. Names and ranks must be unique.
+func NewPriorityScheme(levels []PriorityLevel) (PriorityScheme, error) {
+	if len(levels) < minPriorityLevels || len(levels) > maxPriorityLevels {
+		return PriorityScheme{}, errs.Invalid("invalid priority scheme: needs %d to %d levels, got %d",
+			minPriorityLevels, maxPriorityLevels, len(levels))
+	}
+
+	names := make(map[Priority]bool, len(levels))
+	ranks := make(map[int]bool, len(levels))
+	for _, level := range levels {
+		if names[level.priority] {
+			return PriorityScheme{}, errs.Invalid("invalid priority scheme: duplicate priority %s", level.priority)
+		}
+		if ranks[level.rank] {
+			return PriorityScheme{}, errs.Invalid("invalid priority scheme: duplicate rank %d", level.rank)
+		}
+		names[level.priority] = true
+		ranks[level.rank] = true
+	}
+
+	sorted := make([]PriorityLevel, len(levels))
+	copy(sorted, levels)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].rank < sorted[j].rank
+	})
+
+	return PriorityScheme{levels: sorted}, nil
+}
+
+// Levels returns the levels of the scheme, least urgent first
+func (s PriorityScheme) Levels() []PriorityLevel {
+	return s.levels
+}
+
+// Contains checks if the scheme has the priority
+func (s PriorityScheme) Contains(priority Priority) bool {
+	_, ok := s.level(priority)
+	return ok
+}
+
+// Rank returns the rank of the priority in the scheme, 0 when it is not in it
+func (s PriorityScheme) Rank(priority Priority) int {
+	level, _ := s.level(priority)
+	return level.rank
+}
+
+// Parse returns the priority of the scheme with the name, which is case insensitive
+func (s PriorityScheme) Parse(name string) (Priority, error) {
+	priority, err := ParsePriority(name)
+	if err != nil {
+		return "", err
+	}
+	if !s.Contains(priority) {
+		return "", errs.Invalid("invalid priority: %s is not in the project's priority scheme", priority)
+	}
+	return priority, nil
+}
+
+// IsDefault checks if the scheme is the default ladder
+func (s PriorityScheme) IsDefault() bool {
+	defaults := DefaultPriorityScheme().levels
+	if len(s.levels) != len(defaults) {
+		return false
+	}
+	for i, level := range s.levels {
+		if level != defaults[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// level finds the level of a priority
+func (s PriorityScheme) level(priority Priority) (PriorityLevel, bool) {
+	for _, level := range s.levels {
+		if level.priority == priority {
+			return level, true
+		}
+	}
+	return PriorityLevel{}, false
+}
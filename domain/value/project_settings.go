@@ -0,0 +1,69 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// ProjectSettings holds the defaults and rules task commands apply within a project
+type ProjectSettings struct {
+	defaultPriority         Priority
+	defaultAssigneeID       *UserID
+	requireDeadlineOnCreate bool
+	allowComments           bool
+}
+
+// DefaultProjectSettings returns the settings of a newly created project
+func DefaultProjectSettings() ProjectSettings {
+	return ProjectSettings{
+		defaultPriority: PriorityMedium,
+		allowComments:   true,
+	}
+}
+
+// NewProjectSettings creates new ProjectSettings (a nil assignee means no default assignee)
+func NewProjectSettings(
+	defaultPriority Priority,
+	defaultAssigneeID *UserID,
+	requireDeadlineOnCreate bool,
+	allowComments bool,
+) (ProjectSettings, error) {
+	if !defaultPriority.IsValid() {
+		return ProjectSettings{}, errs.Invalid("invalid default priority: %s", defaultPriority)
+	}
+
+	return ProjectSettings{
+		defaultPriority:         defaultPriority,
+		defaultAssigneeID:       defaultAssigneeID,
+		requireDeadlineOnCreate: requireDeadlineOnCreate,
+		allowComments:           allowComments,
+	}, nil
+}
+
+// DefaultPriority returns the priority given to tasks created without one
+func (s ProjectSettings) DefaultPriority() Priority {
+	return s.defaultPriority
+}
+
+// WithDefaultPriority returns a copy of the settings giving new tasks another default priority
+func (s ProjectSettings) WithDefaultPriority(priority Priority) ProjectSettings {
+	s.defaultPriority = priority
+	return s
+}
+
+// DefaultAssigneeID returns the user new tasks are assigned to, if any
+func (s ProjectSettings) DefaultAssigneeID() *UserID {
+	return s.defaultAssigneeID
+}
+
+// HasDefaultAssignee checks if a default assignee is configured
+func (s ProjectSettings) HasDefaultAssignee() bool {
+	return s.defaultAssigneeID != nil
+}
+
+// RequireDeadlineOnCreate checks if new tasks must be created with a deadline
+func (s ProjectSettings) RequireDeadlineOnCreate() bool {
+	return s.requireDeadlineOnCreate
+}
+
+// AllowComments checks if tasks of the project accept comments
+func (s ProjectSettings) AllowComments() bool {
+	return s.allowComments
+}
@@ -0,0 +1,35 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// ProjectVisibility controls who can see a project and its tasks
+type ProjectVisibility string
+
+const (
+	// VisibilityWorkspace makes a project visible to every user of the workspace
+	VisibilityWorkspace ProjectVisibility = "WORKSPACE"
+
+	// VisibilityRestricted limits a project to its owner and members
+	VisibilityRestricted ProjectVisibility = "RESTRICTED"
+)
+
+// NewProjectVisibility creates a new ProjectVisibility from string
+func NewProjectVisibility(visibility string) (ProjectVisibility, error) {
+	v := ProjectVisibility(visibility)
+	switch v {
+	case VisibilityWorkspace, VisibilityRestricted:
+		return v, nil
+	default:
+		return "", errs.Invalid("invalid project visibility: %s", visibility)
+	}
+}
+
+// Value returns the string representation
+func (v ProjectVisibility) Value() string {
+	return string(v)
+}
+
+// IsRestricted checks if only members may see the project
+func (v ProjectVisibility) IsRestricted() bool {
+	return v == VisibilityRestricted
+}
@@ -0,0 +1,65 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// Quota caps what an organization may use. A zero limit means unlimited.
+type Quota struct {
+	maxProjects        int
+	maxTasks           int
+	maxAttachmentBytes int64
+	apiCallsPerMinute  int
+}
+
+// NewQuota creates a new Quota
+func NewQuota(maxProjects, maxTasks int, maxAttachmentBytes int64, apiCallsPerMinute int) (Quota, error) {
+	if maxProjects < 0 || maxTasks < 0 || maxAttachmentBytes < 0 || apiCallsPerMinute < 0 {
+		return Quota{}, errs.Invalid("invalid quota: limits cannot be negative")
+	}
+
+	return Quota{
+		maxProjects:        maxProjects,
+		maxTasks:           maxTasks,
+		maxAttachmentBytes: maxAttachmentBytes,
+		apiCallsPerMinute:  apiCallsPerMinute,
+	}, nil
+}
+
+// MaxProjects returns how many projects the organization's members may own
+func (q Quota) MaxProjects() int {
+	return q.maxProjects
+}
+
+// MaxTasks returns how many tasks the organization's projects may hold
+func (q Quota) MaxTasks() int {
+	return q.maxTasks
+}
+
+// MaxAttachmentBytes returns the attachment storage of the organization's projects
+func (q Quota) MaxAttachmentBytes() int64 {
+	return q.maxAttachmentBytes
+}
+
+// APICallsPerMinute returns how many API calls the organization's members may make each minute
+func (q Quota) APICallsPerMinute() int {
+	return q.apiCallsPerMinute
+}
+
+// AllowsProjects checks if the organization may own the given number of projects
+func (q Quota) AllowsProjects(count int) bool {
+	return q.maxProjects == 0 || count <= q.maxProjects
+}
+
+// AllowsTasks checks if the organization's projects may hold the given number of tasks
+func (q Quota) AllowsTasks(count int) bool {
+	return q.maxTasks == 0 || count <= q.maxTasks
+}
+
+// AllowsAttachmentBytes checks if the organization may store the given attachment bytes
+func (q Quota) AllowsAttachmentBytes(bytes int64) bool {
+	return q.maxAttachmentBytes == 0 || bytes <= q.maxAttachmentBytes
+}
+
+// AllowsAPICalls checks if the organization may make the given number of API calls in a minute
+func (q Quota) AllowsAPICalls(count int) bool {
+	return q.apiCallsPerMinute == 0 || count <= q.apiCallsPerMinute
+}
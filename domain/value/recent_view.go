@@ -0,0 +1,66 @@
+package value
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// ViewedItemKind represents the kind of item a user opened
+type ViewedItemKind string
+
+const (
+	ViewedItemTask    ViewedItemKind = "TASK"
+	ViewedItemProject ViewedItemKind = "PROJECT"
+)
+
+// NewViewedItemKind creates a new ViewedItemKind from string
+func NewViewedItemKind(kind string) (ViewedItemKind, error) {
+	k := ViewedItemKind(kind)
+	switch k {
+	case ViewedItemTask, ViewedItemProject:
+		return k, nil
+	default:
+		return "", errs.Invalid("invalid viewed item kind: %s", kind)
+	}
+}
+
+// Value returns the string representation
+func (k ViewedItemKind) Value() string {
+	return string(k)
+}
+
+// RecentView records that a user opened a task or project
+type RecentView struct {
+	kind     ViewedItemKind
+	itemID   string
+	viewedAt time.Time
+}
+
+// NewRecentView creates a new RecentView
+func NewRecentView(kind ViewedItemKind, itemID string, viewedAt time.Time) (RecentView, error) {
+	if itemID == "" {
+		return RecentView{}, errs.Invalid("viewed item id cannot be empty")
+	}
+	return RecentView{kind: kind, itemID: itemID, viewedAt: viewedAt}, nil
+}
+
+// Kind returns the kind of item viewed
+func (v RecentView) Kind() ViewedItemKind {
+	return v.kind
+}
+
+// ItemID returns the ID of the item viewed
+func (v RecentView) ItemID() string {
+	return v.itemID
+}
+
+// ViewedAt returns when the item was viewed
+func (v RecentView) ViewedAt() time.Time {
+	return v.viewedAt
+}
+
+// SameItem checks if two views refer to the same item
+func (v RecentView) SameItem(other RecentView) bool {
+	return v.kind == other.kind && v.itemID == other.itemID
+}
@@ -0,0 +1,67 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// GlobalRole is a role a user holds across the whole workspace
+type GlobalRole string
+
+const (
+	// GlobalRoleAdmin may perform every action in every project
+	GlobalRoleAdmin GlobalRole = "ADMIN"
+)
+
+// NewGlobalRole creates a new GlobalRole from string
+func NewGlobalRole(role string) (GlobalRole, error) {
+	r := GlobalRole(role)
+	switch r {
+	case GlobalRoleAdmin:
+		return r, nil
+	default:
+		return "", errs.Invalid("invalid global role: %s", role)
+	}
+}
+
+// Value returns the string representation
+func (r GlobalRole) Value() string {
+	return string(r)
+}
+
+// ProjectRole is a role a user holds within one project
+type ProjectRole string
+
+const (
+	// ProjectRoleViewer may read the project and its tasks
+	ProjectRoleViewer ProjectRole = "VIEWER"
+
+	// ProjectRoleMember may also work on tasks
+	ProjectRoleMember ProjectRole = "MEMBER"
+
+	// ProjectRoleAdmin may also configure the project and delete tasks
+	ProjectRoleAdmin ProjectRole = "ADMIN"
+)
+
+// projectRoleRanks orders project roles from least to most privileged
+var projectRoleRanks = map[ProjectRole]int{
+	ProjectRoleViewer: 1,
+	ProjectRoleMember: 2,
+	ProjectRoleAdmin:  3,
+}
+
+// NewProjectRole creates a new ProjectRole from string
+func NewProjectRole(role string) (ProjectRole, error) {
+	r := ProjectRole(role)
+	if _, ok := projectRoleRanks[r]; !ok {
+		return "", errs.Invalid("invalid project role: %s", role)
+	}
+	return r, nil
+}
+
+// Value returns the string representation
+func (r ProjectRole) Value() string {
+	return string(r)
+}
+
+// Includes checks if the role grants at least the privileges of another
+func (r ProjectRole) Includes(other ProjectRole) bool {
+	return projectRoleRanks[r] >= projectRoleRanks[other]
+}
@@ -0,0 +1,73 @@
+package value
+
+import (
+	"strings"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// DefaultWorkflowID identifies the built-in workflow holding the regular task statuses.
+// Projects whose workflow does not exist follow it.
+var DefaultWorkflowID = WorkflowID{value: "default"}
+
+// ScopedStatus is a task status as defined by a workflow: the status name, the workflow
+// owning it and the lifecycle stage it stands for
+type ScopedStatus struct {
+	workflowID WorkflowID
+	name       string
+	stage      TaskStatus
+}
+
+// NewScopedStatus creates a new ScopedStatus. Whether the workflow has the status, and
+// which stage it stands for, is decided by the workflow, see Workflow.ScopedStatus.
+func NewScopedStatus(workflowID WorkflowID, name string, stage TaskStatus) (ScopedStatus, error) {
+	if workflowID.Equals(WorkflowID{}) {
+		return ScopedStatus{}, errs.Invalid("workflow id cannot be empty")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ScopedStatus{}, errs.Invalid("status name cannot be empty")
+	}
+
+	if !stage.IsValid() {
+		return ScopedStatus{}, errs.Invalid("invalid status stage: %s", stage.Value())
+	}
+
+	return ScopedStatus{workflowID: workflowID, name: name, stage: stage}, nil
+}
+
+// DefaultScopedStatus maps a regular task status to the same status of the default workflow
+func DefaultScopedStatus(status TaskStatus) ScopedStatus {
+	return ScopedStatus{workflowID: DefaultWorkflowID, name: status.Value(), stage: status}
+}
+
+// WorkflowID returns the workflow owning the status
+func (s ScopedStatus) WorkflowID() WorkflowID {
+	return s.workflowID
+}
+
+// Name returns the status name as the workflow spells it
+func (s ScopedStatus) Name() string {
+	return s.name
+}
+
+// Stage returns the task lifecycle stage the status stands for
+func (s ScopedStatus) Stage() TaskStatus {
+	return s.stage
+}
+
+// IsDefault reports whether the status is one of the default workflow
+func (s ScopedStatus) IsDefault() bool {
+	return s.workflowID.Equals(DefaultWorkflowID)
+}
+
+// Equals compares two ScopedStatuses for equality
+func (s ScopedStatus) Equals(other ScopedStatus) bool {
+	return s.workflowID.Equals(other.workflowID) && s.name == other.name
+}
+
+// String returns the status name qualified by its workflow, such as IN_REVIEW@default
+func (s ScopedStatus) String() string {
+	return s.name + "@" + s.workflowID.Value()
+}
@@ -0,0 +1,54 @@
+package value
+
+import (
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// SLOTargets represents the service level objective targets for a project
+type SLOTargets struct {
+	firstResponse time.Duration
+	resolution    time.Duration
+}
+
+// NewSLOTargets creates new SLOTargets (a zero duration means no target)
+func NewSLOTargets(firstResponse, resolution time.Duration) (SLOTargets, error) {
+	if firstResponse < 0 {
+		return SLOTargets{}, errs.Invalid("first response target cannot be negative")
+	}
+
+	if resolution < 0 {
+		return SLOTargets{}, errs.Invalid("resolution target cannot be negative")
+	}
+
+	return SLOTargets{
+		firstResponse: firstResponse,
+		resolution:    resolution,
+	}, nil
+}
+
+// FirstResponse returns the time-to-first-response target
+func (s SLOTargets) FirstResponse() time.Duration {
+	return s.firstResponse
+}
+
+// Resolution returns the time-to-resolution target
+func (s SLOTargets) Resolution() time.Duration {
+	return s.resolution
+}
+
+// HasFirstResponseTarget checks if a first response target is configured
+func (s SLOTargets) HasFirstResponseTarget() bool {
+	return s.firstResponse > 0
+}
+
+// HasResolutionTarget checks if a resolution target is configured
+func (s SLOTargets) HasResolutionTarget() bool {
+	return s.resolution > 0
+}
+
+// IsZero checks if no targets are configured
+func (s SLOTargets) IsZero() bool {
+	return !s.HasFirstResponseTarget() && !s.HasResolutionTarget()
+}
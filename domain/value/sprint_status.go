@@ -0,0 +1,36 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// SprintStatus represents the lifecycle state of a sprint
+type SprintStatus string
+
+const (
+	SprintStatusPlanned   SprintStatus = "PLANNED"
+	SprintStatusActive    SprintStatus = "ACTIVE"
+	SprintStatusCompleted SprintStatus = "COMPLETED"
+)
+
+// NewSprintStatus creates a new SprintStatus from string
+func NewSprintStatus(status string) (SprintStatus, error) {
+	s := SprintStatus(status)
+	if !s.IsValid() {
+		return "", errs.Invalid("invalid sprint status: %s", status)
+	}
+	return s, nil
+}
+
+// Value returns the string representation
+func (s SprintStatus) Value() string {
+	return string(s)
+}
+
+// IsValid checks if the sprint status is valid
+func (s SprintStatus) IsValid() bool {
+	switch s {
+	case SprintStatusPlanned, SprintStatusActive, SprintStatusCompleted:
+		return true
+	default:
+		return false
+	}
+}
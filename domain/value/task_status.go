@@ -1,6 +1,6 @@
 package value
 
-import "fmt"
+import "github.com/miladev95/ddd-task/domain/errs"
 
 // TaskStatus represents the status of a task
 type TaskStatus string
@@ -21,7 +21,7 @@ func NewTaskStatus(status string) (TaskStatus, error) {
 	case TaskStatusBacklog, TaskStatusToDo, TaskStatusInProgress, TaskStatusInReview, TaskStatusCompleted, TaskStatusCancelled:
 		return ts, nil
 	default:
-		return "", fmt.Errorf("invalid task status: %s", status)
+		return "", errs.Invalid("invalid task status: %s", status)
 	}
 }
 
@@ -62,4 +62,21 @@ func (t TaskStatus) CanTransitionTo(target TaskStatus) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// progressRank orders the statuses along the normal flow of work; CANCELLED leaves the flow
+var progressRank = map[TaskStatus]int{
+	TaskStatusBacklog:    0,
+	TaskStatusToDo:       1,
+	TaskStatusInProgress: 2,
+	TaskStatusInReview:   3,
+	TaskStatusCompleted:  4,
+}
+
+// IsBackwardTo reports whether moving to target sends work back to an earlier stage,
+// such as IN_REVIEW back to IN_PROGRESS
+func (t TaskStatus) IsBackwardTo(target TaskStatus) bool {
+	from, fromRanked := progressRank[t]
+	to, toRanked := progressRank[target]
+	return fromRanked && toRanked && to < from
+}
@@ -0,0 +1,132 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// TransitionGuardKind names a condition a task must meet to take a workflow transition
+type TransitionGuardKind string
+
+const (
+	TransitionGuardRequiresDeadline  TransitionGuardKind = "REQUIRES_DEADLINE"
+	TransitionGuardRequiresAssignee  TransitionGuardKind = "REQUIRES_ASSIGNEE"
+	TransitionGuardRequiresApprovals TransitionGuardKind = "REQUIRES_APPROVALS"
+)
+
+// TransitionGuard is a condition attached to a workflow transition
+type TransitionGuard struct {
+	kind      TransitionGuardKind
+	approvals int
+}
+
+// NewTransitionGuard creates a new TransitionGuard. Approvals is the number of
+// approvals REQUIRES_APPROVALS asks for, and is ignored by the other kinds.
+func NewTransitionGuard(kind string, approvals int) (TransitionGuard, error) {
+	guard := TransitionGuard{kind: TransitionGuardKind(kind)}
+
+	switch guard.kind {
+	case TransitionGuardRequiresDeadline, TransitionGuardRequiresAssignee:
+		return guard, nil
+	case TransitionGuardRequiresApprovals:
+		if approvals < 1 {
+			return TransitionGuard{}, errs.Invalid("invalid transition guard: %s needs at least one approval", kind)
+		}
+		guard.approvals = approvals
+		return guard, nil
+	default:
+		return TransitionGuard{}, errs.Invalid("invalid transition guard: %s", kind)
+	}
+}
+
+// Kind returns the guard kind
+func (g TransitionGuard) Kind() TransitionGuardKind {
+	return g.kind
+}
+
+// Approvals returns the number of approvals required, 0 for other kinds
+func (g TransitionGuard) Approvals() int {
+	return g.approvals
+}
+
+// String describes the guard, such as REQUIRES_APPROVALS:2
+func (g TransitionGuard) String() string {
+	if g.kind == TransitionGuardRequiresApprovals {
+		return fmt.Sprintf("%s:%d", g.kind, g.approvals)
+	}
+	return string(g.kind)
+}
+
+// TransitionActionKind names what happens after a task takes a workflow transition
+type TransitionActionKind string
+
+const (
+	TransitionActionNotify     TransitionActionKind = "NOTIFY"
+	TransitionActionAutoAssign TransitionActionKind = "AUTO_ASSIGN"
+)
+
+// TransitionAction is run after a task takes a workflow transition
+type TransitionAction struct {
+	kind       TransitionActionKind
+	channel    NotificationChannel
+	target     string
+	assigneeID UserID
+}
+
+// NewNotifyAction creates an action that notifies a channel target of the move
+func NewNotifyAction(channel NotificationChannel, target string) (TransitionAction, error) {
+	if !channel.IsValid() {
+		return TransitionAction{}, errs.Invalid("invalid notification channel: %s", channel.Value())
+	}
+
+	if target == "" {
+		return TransitionAction{}, errs.Invalid("notification target cannot be empty")
+	}
+
+	return TransitionAction{
+		kind:    TransitionActionNotify,
+		channel: channel,
+		target:  target,
+	}, nil
+}
+
+// NewAutoAssignAction creates an action that assigns the task to a user after the move
+func NewAutoAssignAction(assigneeID UserID) (TransitionAction, error) {
+	if assigneeID.Equals(UserID{}) {
+		return TransitionAction{}, errs.Invalid("assignee cannot be empty")
+	}
+
+	return TransitionAction{
+		kind:       TransitionActionAutoAssign,
+		assigneeID: assigneeID,
+	}, nil
+}
+
+// Kind returns the action kind
+func (a TransitionAction) Kind() TransitionActionKind {
+	return a.kind
+}
+
+// Channel returns the channel a NOTIFY action sends to
+func (a TransitionAction) Channel() NotificationChannel {
+	return a.channel
+}
+
+// Target returns the channel target a NOTIFY action sends to
+func (a TransitionAction) Target() string {
+	return a.target
+}
+
+// AssigneeID returns the user an AUTO_ASSIGN action assigns the task to
+func (a TransitionAction) AssigneeID() UserID {
+	return a.assigneeID
+}
+
+// String describes the action, such as NOTIFY:SLACK:#qa
+func (a TransitionAction) String() string {
+	if a.kind == TransitionActionAutoAssign {
+		return fmt.Sprintf("%s:%s", a.kind, a.assigneeID.Value())
+	}
+	return fmt.Sprintf("%s:%s:%s", a.kind, a.channel, a.target)
+}
@@ -0,0 +1,93 @@
+package value
+
+import "github.com/miladev95/ddd-task/domain/errs"
+
+// WidgetType represents the kind of query a dashboard widget runs
+type WidgetType string
+
+const (
+	WidgetTypeTaskList                  WidgetType = "TASK_LIST"
+	WidgetTypeProjectStats              WidgetType = "PROJECT_STATS"
+	WidgetTypeWorkloadHeatmap           WidgetType = "WORKLOAD_HEATMAP"
+	WidgetTypeUnacknowledgedAssignments WidgetType = "UNACKNOWLEDGED_ASSIGNMENTS"
+)
+
+// NewWidgetType creates a new WidgetType from string
+func NewWidgetType(widgetType string) (WidgetType, error) {
+	wt := WidgetType(widgetType)
+	if !wt.IsValid() {
+		return "", errs.Invalid("invalid widget type: %s", widgetType)
+	}
+	return wt, nil
+}
+
+// Value returns the string representation
+func (w WidgetType) Value() string {
+	return string(w)
+}
+
+// IsValid checks if the widget type is valid
+func (w WidgetType) IsValid() bool {
+	switch w {
+	case WidgetTypeTaskList, WidgetTypeProjectStats, WidgetTypeWorkloadHeatmap, WidgetTypeUnacknowledgedAssignments:
+		return true
+	default:
+		return false
+	}
+}
+
+// RequiredParameters returns the query parameters the widget type cannot run without
+func (w WidgetType) RequiredParameters() []string {
+	switch w {
+	case WidgetTypeTaskList, WidgetTypeProjectStats:
+		return []string{"project_id"}
+	default:
+		return nil
+	}
+}
+
+// WidgetLayout represents the position and size of a widget on a dashboard grid
+type WidgetLayout struct {
+	column int
+	row    int
+	width  int
+	height int
+}
+
+// NewWidgetLayout creates a new WidgetLayout
+func NewWidgetLayout(column, row, width, height int) (WidgetLayout, error) {
+	if column < 0 || row < 0 {
+		return WidgetLayout{}, errs.Invalid("widget position cannot be negative")
+	}
+
+	if width <= 0 || height <= 0 {
+		return WidgetLayout{}, errs.Invalid("widget size must be positive")
+	}
+
+	return WidgetLayout{
+		column: column,
+		row:    row,
+		width:  width,
+		height: height,
+	}, nil
+}
+
+// Column returns the grid column
+func (l WidgetLayout) Column() int {
+	return l.column
+}
+
+// Row returns the grid row
+func (l WidgetLayout) Row() int {
+	return l.row
+}
+
+// Width returns the width in grid cells
+func (l WidgetLayout) Width() int {
+	return l.width
+}
+
+// Height returns the height in grid cells
+func (l WidgetLayout) Height() int {
+	return l.height
+}
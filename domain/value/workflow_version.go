@@ -0,0 +1,47 @@
+package value
+
+import (
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// WorkflowVersion identifies one version of a workflow. Editing a workflow creates a new
+// version, and tasks stay on the version they were created under until migrated.
+type WorkflowVersion struct {
+	workflowID WorkflowID
+	number     int
+}
+
+// NewWorkflowVersion creates a new WorkflowVersion
+func NewWorkflowVersion(workflowID WorkflowID, number int) (WorkflowVersion, error) {
+	if workflowID.Equals(WorkflowID{}) {
+		return WorkflowVersion{}, errs.Invalid("workflow id cannot be empty")
+	}
+
+	if number < 1 {
+		return WorkflowVersion{}, errs.Invalid("invalid workflow version: %d", number)
+	}
+
+	return WorkflowVersion{workflowID: workflowID, number: number}, nil
+}
+
+// WorkflowID returns the versioned workflow
+func (v WorkflowVersion) WorkflowID() WorkflowID {
+	return v.workflowID
+}
+
+// Number returns the version number, starting at 1
+func (v WorkflowVersion) Number() int {
+	return v.number
+}
+
+// Equals compares two WorkflowVersions for equality
+func (v WorkflowVersion) Equals(other WorkflowVersion) bool {
+	return v.workflowID.Equals(other.workflowID) && v.number == other.number
+}
+
+// String returns the workflow ID with the version number, such as default/v2
+func (v WorkflowVersion) String() string {
+	return fmt.Sprintf("%s/v%d", v.workflowID.Value(), v.number)
+}
@@ -0,0 +1,136 @@
+package value
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// WorkingHours is how many hours a user works on each of their working days
+type WorkingHours struct {
+	hoursPerDay float64
+	workingDays []time.Weekday
+}
+
+// NewWorkingHours creates a new WorkingHours
+func NewWorkingHours(hoursPerDay float64, workingDays []time.Weekday) (WorkingHours, error) {
+	if hoursPerDay <= 0 || hoursPerDay > 24 {
+		return WorkingHours{}, errs.Invalid("invalid working hours: hours per day must be more than 0 and at most 24")
+	}
+	if len(workingDays) == 0 {
+		return WorkingHours{}, errs.Invalid("invalid working hours: at least one working day is required")
+	}
+
+	days := make([]time.Weekday, 0, len(workingDays))
+	seen := make(map[time.Weekday]bool, len(workingDays))
+	for _, day := range workingDays {
+		if day < time.Sunday || day > time.Saturday {
+			return WorkingHours{}, errs.Invalid("invalid working hours: unknown weekday %d", day)
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	// Keep the days in calendar order, Monday first
+	sort.Slice(days, func(i, j int) bool {
+		return (days[i]+6)%7 < (days[j]+6)%7
+	})
+
+	return WorkingHours{hoursPerDay: hoursPerDay, workingDays: days}, nil
+}
+
+// ParseWorkingHours creates WorkingHours from weekday names such as "MONDAY" or "Mon"
+func ParseWorkingHours(hoursPerDay float64, workingDays []string) (WorkingHours, error) {
+	days := make([]time.Weekday, 0, len(workingDays))
+	for _, name := range workingDays {
+		day, err := parseWeekday(name)
+		if err != nil {
+			return WorkingHours{}, err
+		}
+		days = append(days, day)
+	}
+
+	return NewWorkingHours(hoursPerDay, days)
+}
+
+// DefaultWorkingHours returns eight hours a day, Monday to Friday
+func DefaultWorkingHours() WorkingHours {
+	hours, _ := NewWorkingHours(8, []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+	})
+	return hours
+}
+
+// parseWeekday parses a full or three-letter weekday name, ignoring case
+func parseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || (len(name) == 3 && name == full[:3]) {
+			return day, nil
+		}
+	}
+
+	return 0, errs.Invalid("invalid working hours: unknown weekday %q", name)
+}
+
+// HoursPerDay returns the hours worked on each working day
+func (w WorkingHours) HoursPerDay() float64 {
+	return w.hoursPerDay
+}
+
+// WorkingDays returns the working days, Monday first
+func (w WorkingHours) WorkingDays() []time.Weekday {
+	days := make([]time.Weekday, len(w.workingDays))
+	copy(days, w.workingDays)
+	return days
+}
+
+// WorkingDayNames returns the working days as upper-case names, Monday first
+func (w WorkingHours) WorkingDayNames() []string {
+	names := make([]string, len(w.workingDays))
+	for i, day := range w.workingDays {
+		names[i] = strings.ToUpper(day.String())
+	}
+	return names
+}
+
+// IsWorkingDay checks if the weekday is a working day
+func (w WorkingHours) IsWorkingDay(day time.Weekday) bool {
+	for _, workingDay := range w.workingDays {
+		if workingDay == day {
+			return true
+		}
+	}
+	return false
+}
+
+// HoursOn returns the hours available on the day of the given date
+func (w WorkingHours) HoursOn(date time.Time) float64 {
+	if !w.IsWorkingDay(date.Weekday()) {
+		return 0
+	}
+	return w.hoursPerDay
+}
+
+// WeeklyHours returns the hours available in a full week
+func (w WorkingHours) WeeklyHours() float64 {
+	return w.hoursPerDay * float64(len(w.workingDays))
+}
+
+// Equals checks if two WorkingHours are equal
+func (w WorkingHours) Equals(other WorkingHours) bool {
+	if w.hoursPerDay != other.hoursPerDay || len(w.workingDays) != len(other.workingDays) {
+		return false
+	}
+	for i := range w.workingDays {
+		if w.workingDays[i] != other.workingDays[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 // This file demonstrates how to use the DDD architecture
 
 func main() {
+	ctx := context.Background()
 	// Initialize DI container
 	container := di.NewContainer()
 
@@ -24,7 +26,7 @@ func main() {
 		fmt.Printf("Error creating user: %v\n", err)
 		return
 	}
-	container.UserRepository.Save(user1)
+	container.UserRepository.Save(ctx, user1)
 	fmt.Printf("Created user: %s (%s)\n", user1.FullName(), user1ID.Value())
 
 	user2ID := value.GenerateUserID()
@@ -33,7 +35,8 @@ func main() {
 		fmt.Printf("Error creating user: %v\n", err)
 		return
 	}
-	container.UserRepository.Save(user2)
+	user2.VerifyEmail() // only users with a verified email can be assigned tasks
+	container.UserRepository.Save(ctx, user2)
 	fmt.Printf("Created user: %s (%s)\n", user2.FullName(), user2ID.Value())
 
 	// Example 2: Create a workflow
@@ -51,7 +54,7 @@ func main() {
 		fmt.Printf("Error creating workflow: %v\n", err)
 		return
 	}
-	container.WorkflowRepository.Save(workflow)
+	container.WorkflowRepository.Save(ctx, workflow)
 	fmt.Printf("Created workflow: %s\n", workflow.Name())
 
 	// Example 3: Create a project
@@ -68,7 +71,7 @@ func main() {
 		fmt.Printf("Error creating project: %v\n", err)
 		return
 	}
-	container.ProjectRepository.Save(project)
+	container.ProjectRepository.Save(ctx, project)
 	fmt.Printf("Created project: %s (Owner: %s)\n", project.Name(), user1.FullName())
 
 	// Example 4: Create a task using command handler
@@ -83,7 +86,7 @@ func main() {
 		CreatedBy:   user1ID.Value(),
 	}
 
-	result, err := container.CreateTaskCommandHandler.Handle(createTaskCmd)
+	result, err := container.CreateTaskCommandHandler.Handle(context.Background(), createTaskCmd)
 	if err != nil {
 		fmt.Printf("Error creating task: %v\n", err)
 		return
@@ -93,7 +96,7 @@ func main() {
 	// Example 5: Retrieve and display the task
 	fmt.Println("\n=== Retrieving Task ===")
 	taskID, _ := value.NewTaskID(result.TaskID)
-	task, err := container.TaskRepository.GetByID(taskID)
+	task, err := container.TaskRepository.GetByID(ctx, taskID)
 	if err != nil {
 		fmt.Printf("Error retrieving task: %v\n", err)
 		return
@@ -113,11 +116,12 @@ func main() {
 	// Example 6: Update task status
 	fmt.Println("\n=== Updating Task Status ===")
 	statusCmd := command.UpdateTaskStatusCommand{
-		TaskID:    result.TaskID,
-		NewStatus: "IN_PROGRESS",
+		TaskID:      result.TaskID,
+		NewStatus:   "IN_PROGRESS",
+		RequestedBy: user2ID.Value(), // only the assignee may start a task
 	}
 
-	_, err = container.UpdateTaskStatusCommandHandler.Handle(statusCmd)
+	_, err = container.UpdateTaskStatusCommandHandler.Handle(context.Background(), statusCmd)
 	if err != nil {
 		fmt.Printf("Error updating task status: %v\n", err)
 		return
@@ -126,7 +130,7 @@ func main() {
 
 	// Example 7: List tasks by project
 	fmt.Println("\n=== Listing Tasks by Project ===")
-	tasks, err := container.TaskRepository.GetByProjectID(projectID)
+	tasks, err := container.TaskRepository.GetByProjectID(ctx, projectID)
 	if err != nil {
 		fmt.Printf("Error listing tasks: %v\n", err)
 		return
@@ -139,7 +143,7 @@ func main() {
 
 	// Example 8: Get tasks assigned to user
 	fmt.Println("\n=== Getting Tasks Assigned to User ===")
-	assignedTasks, err := container.TaskRepository.GetByAssigneeID(user2ID)
+	assignedTasks, err := container.TaskRepository.GetByAssigneeID(ctx, user2ID)
 	if err != nil {
 		fmt.Printf("Error getting assigned tasks: %v\n", err)
 		return
@@ -161,11 +165,12 @@ func main() {
 	// Example 10: Verify business rules - invalid status transition
 	fmt.Println("\n=== Testing Business Rules ===")
 	invalidStatusCmd := command.UpdateTaskStatusCommand{
-		TaskID:    result.TaskID,
-		NewStatus: "BACKLOG", // Invalid transition from IN_PROGRESS
+		TaskID:      result.TaskID,
+		NewStatus:   "BACKLOG", // Invalid transition from IN_PROGRESS
+		RequestedBy: user1ID.Value(),
 	}
 
-	_, err = container.UpdateTaskStatusCommandHandler.Handle(invalidStatusCmd)
+	_, err = container.UpdateTaskStatusCommandHandler.Handle(context.Background(), invalidStatusCmd)
 	if err != nil {
 		fmt.Printf("Expected error (invalid transition): %v\n", err)
 	}
@@ -0,0 +1,66 @@
+package activity
+
+import (
+	"sync"
+
+	"github.com/miladev95/ddd-task/application/query"
+)
+
+// Feed is an in-memory activity stream per project, kept oldest first
+type Feed struct {
+	entries map[string][]query.ActivityEntry
+	mu      sync.RWMutex
+}
+
+// NewFeed creates a new Feed
+func NewFeed() *Feed {
+	return &Feed{
+		entries: make(map[string][]query.ActivityEntry),
+	}
+}
+
+// Clear removes every entry of every project
+func (f *Feed) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.entries = make(map[string][]query.ActivityEntry)
+}
+
+// Append adds an entry to a project's stream, numbering it after the last one
+func (f *Feed) Append(projectID string, entry query.ActivityEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry.Sequence = len(f.entries[projectID]) + 1
+	f.entries[projectID] = append(f.entries[projectID], entry)
+}
+
+// Remove drops a project's stream
+func (f *Feed) Remove(projectID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.entries, projectID)
+}
+
+// Page returns up to limit entries before the sequence given, newest first
+func (f *Feed) Page(projectID string, before, limit int) []query.ActivityEntry {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	entries := f.entries[projectID]
+	end := len(entries)
+	if before > 0 && before-1 < end {
+		end = before - 1
+	}
+
+	page := make([]query.ActivityEntry, 0, limit)
+	for i := end - 1; i >= 0 && len(page) < limit; i-- {
+		page = append(page, entries[i])
+	}
+	return page
+}
+
+// Ensure Feed implements query.ProjectActivityFeed
+var _ query.ProjectActivityFeed = (*Feed)(nil)
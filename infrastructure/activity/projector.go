@@ -0,0 +1,143 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// feedTask is what the projector remembers of a task to describe its activity
+type feedTask struct {
+	projectID string
+	title     string
+}
+
+// Projector turns task events into human-readable entries of their project's Feed.
+// It remembers the project and title of each task it saw created, so a replay describes
+// tasks that have since been deleted; other tasks are looked up in the repository.
+type Projector struct {
+	feed           *Feed
+	taskRepository domain.TaskRepository
+	userRepository domain.UserRepository
+	tasks          map[string]feedTask
+	mu             sync.Mutex
+}
+
+// NewProjector creates a new Projector
+func NewProjector(feed *Feed, taskRepository domain.TaskRepository, userRepository domain.UserRepository) *Projector {
+	return &Projector{
+		feed:           feed,
+		taskRepository: taskRepository,
+		userRepository: userRepository,
+		tasks:          make(map[string]feedTask),
+	}
+}
+
+// Name identifies the projection when rebuilding read models
+func (p *Projector) Name() string {
+	return "project_activity"
+}
+
+// Reset truncates the feed before a replay
+func (p *Projector) Reset() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.feed.Clear()
+	p.tasks = make(map[string]feedTask)
+	return nil
+}
+
+// Register subscribes the projector to the events it consumes
+func (p *Projector) Register(subscriber event.EventSubscriber) error {
+	for _, eventType := range []string{"TaskCreated", "TaskAssigned", "TaskCompleted", "TaskCommentAdded", "ProjectDeleted"} {
+		if err := subscriber.Subscribe(eventType, p.Handle); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// Handle applies a single domain event to the feed
+func (p *Projector) Handle(evt event.DomainEvent) error {
+	ctx := context.Background()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e, ok := evt.(event.ProjectDeletedEvent); ok {
+		p.feed.Remove(e.AggregateID())
+		return nil
+	}
+
+	if e, ok := evt.(event.TaskCreatedEvent); ok {
+		p.tasks[e.AggregateID()] = feedTask{projectID: e.ProjectID, title: e.Title}
+	}
+
+	task, known := p.task(ctx, evt.AggregateID())
+	if !known {
+		// The task is gone and was created before the feed existed, nothing to attach it to
+		return nil
+	}
+
+	entry := query.ActivityEntry{
+		EventType:  evt.EventType(),
+		TaskID:     evt.AggregateID(),
+		OccurredAt: evt.OccurredAt(),
+	}
+	switch e := evt.(type) {
+	case event.TaskCreatedEvent:
+		entry.Summary = fmt.Sprintf("Task %q was created", task.title)
+	case event.TaskAssignedEvent:
+		entry.Summary = fmt.Sprintf("%q was assigned to %s", task.title, p.userName(ctx, e.AssigneeID))
+	case event.TaskCompletedEvent:
+		entry.ActorID = e.CompletedBy
+		entry.Summary = fmt.Sprintf("%s completed %q", p.userName(ctx, e.CompletedBy), task.title)
+	case event.TaskCommentAddedEvent:
+		entry.ActorID = e.AuthorID
+		entry.Summary = fmt.Sprintf("%s commented on %q", p.userName(ctx, e.AuthorID), task.title)
+	default:
+		return nil
+	}
+
+	p.feed.Append(task.projectID, entry)
+	return nil
+}
+
+// task returns the project and title of a task, remembering tasks found in the repository
+func (p *Projector) task(ctx context.Context, rawTaskID string) (feedTask, bool) {
+	if task, ok := p.tasks[rawTaskID]; ok {
+		return task, true
+	}
+
+	taskID, err := value.NewTaskID(rawTaskID)
+	if err != nil {
+		return feedTask{}, false
+	}
+	found, err := p.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return feedTask{}, false
+	}
+
+	task := feedTask{projectID: found.ProjectID().Value(), title: found.Title()}
+	p.tasks[rawTaskID] = task
+	return task, true
+}
+
+// userName returns the full name of a user, their ID when they cannot be found
+func (p *Projector) userName(ctx context.Context, rawUserID string) string {
+	userID, err := value.NewUserID(rawUserID)
+	if err != nil {
+		return rawUserID
+	}
+	user, err := p.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return rawUserID
+	}
+	return user.FullName()
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Callers looks up the current state of the users access tokens were issued to, so a
+// deactivation or a role change takes effect on the next request rather than when the
+// caller's tokens expire
+type Callers struct {
+	userRepository domain.UserRepository
+}
+
+// NewCallers creates a new Callers
+func NewCallers(userRepository domain.UserRepository) *Callers {
+	return &Callers{userRepository: userRepository}
+}
+
+// CurrentRoles returns the global roles a user holds now, false when the user is unknown or inactive
+func (c *Callers) CurrentRoles(ctx context.Context, userID string) ([]string, bool) {
+	id, err := value.NewUserID(userID)
+	if err != nil {
+		return nil, false
+	}
+
+	user, err := c.userRepository.GetByID(ctx, id)
+	if err != nil || !user.IsActive() {
+		return nil, false
+	}
+
+	roles := make([]string, 0, len(user.Roles()))
+	for _, role := range user.Roles() {
+		roles = append(roles, role.Value())
+	}
+	return roles, true
+}
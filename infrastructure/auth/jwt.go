@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// DefaultAccessTokenTTL is how long an access token authenticates requests
+const DefaultAccessTokenTTL = 15 * time.Minute
+
+// DefaultRefreshTokenTTL is how long a refresh token can be exchanged for new tokens
+const DefaultRefreshTokenTTL = 7 * 24 * time.Hour
+
+// Token types carried in the "typ" claim so one kind cannot stand in for the other
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+// jwtHeader is the fixed header of every token, only HS256 is issued or accepted
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims are the claims of a token issued by TokenIssuer
+type Claims struct {
+	Subject    string   `json:"sub"`
+	Roles      []string `json:"roles,omitempty"`
+	TokenType  string   `json:"typ"`
+	ID         string   `json:"jti"`
+	IssuedAt   int64    `json:"iat"`
+	ExpiresAt  int64    `json:"exp"`
+	Generation int64    `json:"gen"` // the user's token generation when issued
+}
+
+// TokenIssuer issues and verifies HS256 JSON Web Tokens.
+// Refresh tokens are single use, and all tokens of a user issued before a revocation are rejected.
+// A revocation starts a new token generation of the user rather than recording a time, so
+// tokens issued right after it are valid however fine the clock.
+type TokenIssuer struct {
+	secret      []byte
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	redeemed    map[string]time.Time // refresh token ID -> expiry, kept until it would have expired
+	generations map[string]int64     // user ID -> generation of the tokens that are valid
+	now         func() time.Time
+	mu          sync.Mutex
+}
+
+// NewTokenIssuer creates a new TokenIssuer signing with the given secret
+func NewTokenIssuer(secret []byte, accessTTL, refreshTTL time.Duration) *TokenIssuer {
+	if accessTTL <= 0 {
+		accessTTL = DefaultAccessTokenTTL
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = DefaultRefreshTokenTTL
+	}
+
+	return &TokenIssuer{
+		secret:      secret,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
+		redeemed:    make(map[string]time.Time),
+		generations: make(map[string]int64),
+		now:         time.Now,
+	}
+}
+
+// GenerateSecret returns a random signing secret, for when none is configured
+func GenerateSecret() ([]byte, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate token secret: %w", err)
+	}
+	return secret, nil
+}
+
+// SetClock replaces the issuer's clock, for tests
+func (i *TokenIssuer) SetClock(now func() time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.now = now
+}
+
+// Issue creates an access and a refresh token for a user
+func (i *TokenIssuer) Issue(userID string, roles []string) (command.TokenPair, error) {
+	i.mu.Lock()
+	now := i.now()
+	generation := i.generations[userID]
+	i.mu.Unlock()
+
+	access, err := i.sign(userID, roles, AccessToken, generation, now, now.Add(i.accessTTL))
+	if err != nil {
+		return command.TokenPair{}, err
+	}
+
+	refresh, err := i.sign(userID, nil, RefreshToken, generation, now, now.Add(i.refreshTTL))
+	if err != nil {
+		return command.TokenPair{}, err
+	}
+
+	return command.TokenPair{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		IssuedAt:         now,
+		AccessExpiresAt:  now.Add(i.accessTTL),
+		RefreshExpiresAt: now.Add(i.refreshTTL),
+	}, nil
+}
+
+// Verify returns the claims of a valid access token
+func (i *TokenIssuer) Verify(token string) (command.AccessClaims, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	claims, err := i.verify(token, AccessToken)
+	if err != nil {
+		return command.AccessClaims{}, err
+	}
+	return command.AccessClaims{UserID: claims.Subject, Roles: claims.Roles}, nil
+}
+
+// Redeem marks a valid refresh token used and returns the ID of the user it was issued to
+func (i *TokenIssuer) Redeem(token string) (string, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	claims, err := i.verify(token, RefreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	if _, used := i.redeemed[claims.ID]; used {
+		return "", errs.Unauthenticated("invalid refresh token")
+	}
+
+	// Forget tokens that have expired anyway
+	now := i.now()
+	for id, expiresAt := range i.redeemed {
+		if !now.Before(expiresAt) {
+			delete(i.redeemed, id)
+		}
+	}
+	i.redeemed[claims.ID] = time.Unix(claims.ExpiresAt, 0)
+
+	return claims.Subject, nil
+}
+
+// RevokeUser invalidates every token issued to a user up to now
+func (i *TokenIssuer) RevokeUser(userID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.generations[userID]++
+}
+
+// Register subscribes the issuer to revoke the tokens of users who change their password
+// or are deactivated
+func (i *TokenIssuer) Register(subscriber event.EventSubscriber) {
+	revoke := func(evt event.DomainEvent) error {
+		i.RevokeUser(evt.AggregateID())
+		return nil
+	}
+	subscriber.Subscribe("UserPasswordChanged", revoke)
+	subscriber.Subscribe("UserDeactivated", revoke)
+}
+
+// sign encodes and signs a token
+func (i *TokenIssuer) sign(userID string, roles []string, tokenType string, generation int64, issuedAt, expiresAt time.Time) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+
+	payload, err := json.Marshal(Claims{
+		Subject:    userID,
+		Roles:      roles,
+		TokenType:  tokenType,
+		ID:         base64.RawURLEncoding.EncodeToString(id),
+		IssuedAt:   issuedAt.Unix(),
+		ExpiresAt:  expiresAt.Unix(),
+		Generation: generation,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	signingInput := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + i.signature(signingInput), nil
+}
+
+// verify checks the signature, type, expiry and revocation of a token. Callers hold the lock.
+func (i *TokenIssuer) verify(token string, tokenType string) (Claims, error) {
+	invalid := errs.Unauthenticated("invalid %s token", tokenType)
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return Claims{}, invalid
+	}
+
+	expected := i.signature(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return Claims{}, invalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, invalid
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, invalid
+	}
+
+	if claims.TokenType != tokenType || claims.Subject == "" {
+		return Claims{}, invalid
+	}
+
+	if !i.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return Claims{}, invalid
+	}
+
+	if claims.Generation != i.generations[claims.Subject] {
+		return Claims{}, invalid
+	}
+
+	return claims, nil
+}
+
+// signature computes the base64url HMAC-SHA256 of a signing input
+func (i *TokenIssuer) signature(signingInput string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Ensure TokenIssuer implements command.TokenIssuer and command.TokenVerifier
+var (
+	_ command.TokenIssuer   = (*TokenIssuer)(nil)
+	_ command.TokenVerifier = (*TokenIssuer)(nil)
+)
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Argon2id parameters of new hashes, the OWASP recommendation for a 19 MiB memory budget
+const (
+	argon2Memory     = 19 * 1024 // KiB
+	argon2Time       = 2
+	argon2Threads    = 1
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// argon2Params are the parameters an Argon2id hash was made with
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+// PasswordHasher hashes passwords with Argon2id (RFC 9106) in the PHC string format,
+// "$argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>".
+type PasswordHasher struct {
+	params argon2Params
+	dummy  value.PasswordHash // checked instead of a hash that cannot be read
+}
+
+// NewPasswordHasher creates a PasswordHasher
+func NewPasswordHasher() *PasswordHasher {
+	h := &PasswordHasher{params: argon2Params{memory: argon2Memory, time: argon2Time, threads: argon2Threads}}
+	h.dummy = h.encode(h.params, make([]byte, argon2SaltLength), make([]byte, argon2KeyLength))
+	return h
+}
+
+// Hash checks a password against the password policy and hashes it with a fresh salt
+func (h *PasswordHasher) Hash(password string) (value.PasswordHash, error) {
+	if err := value.ValidatePassword(password); err != nil {
+		return value.PasswordHash{}, err
+	}
+
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return value.PasswordHash{}, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.time, h.params.memory, h.params.threads, argon2KeyLength)
+	return h.encode(h.params, salt, key), nil
+}
+
+// Matches checks a password against a hash in constant time. A hash that cannot be read
+// is swapped for a dummy one, so refusing it costs as much as checking a real hash.
+func (h *PasswordHasher) Matches(hash value.PasswordHash, password string) bool {
+	if utf8.RuneCountInString(password) > value.MaxPasswordLength {
+		return false
+	}
+
+	params, salt, key, err := decodeArgon2(hash.String())
+	readable := err == nil
+	if !readable {
+		params, salt, key, _ = decodeArgon2(h.dummy.String())
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(candidate, key) == 1 && readable
+}
+
+// encode writes an Argon2id hash in the PHC string format
+func (h *PasswordHasher) encode(params argon2Params, salt, key []byte) value.PasswordHash {
+	hash, _ := value.ParsePasswordHash(fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.memory, params.time, params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	))
+	return hash
+}
+
+// decodeArgon2 reads an Argon2id hash in the PHC string format
+func decodeArgon2(encoded string) (argon2Params, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" || parts[2] != fmt.Sprintf("v=%d", argon2.Version) {
+		return argon2Params{}, nil, nil, fmt.Errorf("invalid password hash")
+	}
+
+	var params argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil ||
+		params.memory == 0 || params.time == 0 || params.threads == 0 {
+		return argon2Params{}, nil, nil, fmt.Errorf("invalid password hash parameters")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return argon2Params{}, nil, nil, fmt.Errorf("invalid password hash salt")
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2Params{}, nil, nil, fmt.Errorf("invalid password hash key")
+	}
+
+	return params, salt, key, nil
+}
+
+// Ensure PasswordHasher implements service.PasswordHasher
+var _ service.PasswordHasher = (*PasswordHasher)(nil)
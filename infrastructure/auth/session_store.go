@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// DefaultSessionTTL is how long a session token stays valid after login
+const DefaultSessionTTL = 24 * time.Hour
+
+// tokenLength is the number of random bytes in a session token
+const tokenLength = 32
+
+// SessionStore issues and resolves opaque session tokens in memory.
+// Only a SHA-256 digest of each token is kept, so the store never holds usable tokens.
+type SessionStore struct {
+	ttl      time.Duration
+	sessions map[string]command.Session
+	now      func() time.Time
+	mu       sync.Mutex
+}
+
+// NewSessionStore creates a new SessionStore
+func NewSessionStore(ttl time.Duration) *SessionStore {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+
+	return &SessionStore{
+		ttl:      ttl,
+		sessions: make(map[string]command.Session),
+		now:      time.Now,
+	}
+}
+
+// SetClock replaces the store's clock, for tests
+func (s *SessionStore) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = now
+}
+
+// Issue creates a session for a user and returns its token
+func (s *SessionStore) Issue(userID string) (string, command.Session, error) {
+	raw := make([]byte, tokenLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", command.Session{}, fmt.Errorf("failed to generate session token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	session := command.Session{
+		UserID:    userID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.ttl),
+	}
+	s.sessions[digest(token)] = session
+
+	return token, session, nil
+}
+
+// Resolve returns the live session of a token
+func (s *SessionStore) Resolve(token string) (command.Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := digest(token)
+	session, exists := s.sessions[key]
+	if !exists {
+		return command.Session{}, false
+	}
+
+	if !s.now().Before(session.ExpiresAt) {
+		delete(s.sessions, key)
+		return command.Session{}, false
+	}
+
+	return session, true
+}
+
+// Revoke ends the session of a token, returning false if it was unknown
+func (s *SessionStore) Revoke(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := digest(token)
+	_, exists := s.sessions[key]
+	delete(s.sessions, key)
+
+	return exists
+}
+
+// RevokeUser ends every session of a user and returns how many were ended
+func (s *SessionStore) RevokeUser(userID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revoked := 0
+	for key, session := range s.sessions {
+		if session.UserID == userID {
+			delete(s.sessions, key)
+			revoked++
+		}
+	}
+
+	return revoked
+}
+
+// Register subscribes the store to end the sessions of users who are deactivated
+func (s *SessionStore) Register(subscriber event.EventSubscriber) {
+	subscriber.Subscribe("UserDeactivated", func(evt event.DomainEvent) error {
+		s.RevokeUser(evt.AggregateID())
+		return nil
+	})
+}
+
+// digest returns the key a token is stored under
+func digest(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Ensure SessionStore implements command.SessionStore
+var _ command.SessionStore = (*SessionStore)(nil)
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// DefaultVerificationTTL is how long an email verification token can be used
+const DefaultVerificationTTL = 48 * time.Hour
+
+// verification is a pending email verification
+type verification struct {
+	userID    string
+	email     string
+	expiresAt time.Time
+}
+
+// VerificationTokens generates and redeems single use email verification tokens in memory.
+// Like sessions, only a SHA-256 digest of each token is kept, and a new token replaces the user's previous one.
+type VerificationTokens struct {
+	ttl     time.Duration
+	pending map[string]verification // token digest -> verification
+	byUser  map[string]string       // user ID -> digest of their live token
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewVerificationTokens creates a new VerificationTokens
+func NewVerificationTokens(ttl time.Duration) *VerificationTokens {
+	if ttl <= 0 {
+		ttl = DefaultVerificationTTL
+	}
+
+	return &VerificationTokens{
+		ttl:     ttl,
+		pending: make(map[string]verification),
+		byUser:  make(map[string]string),
+		now:     time.Now,
+	}
+}
+
+// SetClock replaces the generator's clock, for tests
+func (v *VerificationTokens) SetClock(now func() time.Time) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.now = now
+}
+
+// Generate creates a token that verifies the given email address of a user
+func (v *VerificationTokens) Generate(userID, email string) (string, time.Time, error) {
+	raw := make([]byte, tokenLength)
+	if _, err := rand.Read(raw); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if previous, exists := v.byUser[userID]; exists {
+		delete(v.pending, previous)
+	}
+
+	expiresAt := v.now().Add(v.ttl)
+	key := digest(token)
+	v.pending[key] = verification{userID: userID, email: email, expiresAt: expiresAt}
+	v.byUser[userID] = key
+
+	return token, expiresAt, nil
+}
+
+// Redeem uses up a token and returns the user and email address it verifies
+func (v *VerificationTokens) Redeem(token string) (string, string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key := digest(token)
+	pending, exists := v.pending[key]
+	if !exists {
+		return "", "", errs.Invalid("invalid verification token")
+	}
+
+	delete(v.pending, key)
+	delete(v.byUser, pending.userID)
+
+	if !v.now().Before(pending.expiresAt) {
+		return "", "", errs.Invalid("invalid verification token")
+	}
+
+	return pending.userID, pending.email, nil
+}
+
+// Ensure VerificationTokens implements command.VerificationTokens
+var _ command.VerificationTokens = (*VerificationTokens)(nil)
@@ -0,0 +1,177 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UsageEmitter turns domain events and user activity into usage events on the UsageTopic.
+// Usage is billed to the organization of the project owner, or of the active user;
+// usage outside any organization is not metered.
+type UsageEmitter struct {
+	topic         *UsageTopic
+	organizations domain.OrganizationRepository
+	projects      domain.ProjectRepository
+	tasks         domain.TaskRepository
+	activePeriod  string
+	active        map[string]bool // users already reported active in activePeriod
+	now           func() time.Time
+	mu            sync.Mutex
+}
+
+// NewUsageEmitter creates a new UsageEmitter
+func NewUsageEmitter(
+	topic *UsageTopic,
+	organizations domain.OrganizationRepository,
+	projects domain.ProjectRepository,
+	tasks domain.TaskRepository,
+) *UsageEmitter {
+	return &UsageEmitter{
+		topic:         topic,
+		organizations: organizations,
+		projects:      projects,
+		tasks:         tasks,
+		active:        make(map[string]bool),
+		now:           time.Now,
+	}
+}
+
+// SetClock replaces the emitter's clock, for tests
+func (e *UsageEmitter) SetClock(now func() time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.now = now
+}
+
+// Register subscribes the emitter to the domain events it meters
+func (e *UsageEmitter) Register(subscriber event.EventSubscriber) error {
+	if err := subscriber.Subscribe("TaskCreated", e.OnTaskCreated); err != nil {
+		return fmt.Errorf("failed to subscribe to TaskCreated: %w", err)
+	}
+	if err := subscriber.Subscribe("TaskAttachmentAdded", e.OnTaskAttachmentAdded); err != nil {
+		return fmt.Errorf("failed to subscribe to TaskAttachmentAdded: %w", err)
+	}
+	return nil
+}
+
+// OnTaskCreated meters one task
+func (e *UsageEmitter) OnTaskCreated(evt event.DomainEvent) error {
+	created, ok := evt.(event.TaskCreatedEvent)
+	if !ok {
+		return nil
+	}
+
+	projectID, err := value.NewProjectID(created.ProjectID)
+	if err != nil {
+		return nil
+	}
+
+	organizationID := e.projectOrganization(context.Background(), projectID)
+	if organizationID == "" {
+		return nil
+	}
+
+	e.topic.Publish(newUsageEvent(
+		UsageTaskCreated+":"+created.AggregateID(),
+		UsageTaskCreated,
+		organizationID,
+		created.AggregateID(),
+		1,
+		UnitTask,
+		created.OccurredAt(),
+	))
+	return nil
+}
+
+// OnTaskAttachmentAdded meters the bytes of a stored attachment
+func (e *UsageEmitter) OnTaskAttachmentAdded(evt event.DomainEvent) error {
+	added, ok := evt.(event.TaskAttachmentAddedEvent)
+	if !ok {
+		return nil
+	}
+
+	taskID, err := value.NewTaskID(added.AggregateID())
+	if err != nil {
+		return nil
+	}
+
+	task, err := e.tasks.GetByID(context.Background(), taskID)
+	if err != nil {
+		return nil
+	}
+
+	organizationID := e.projectOrganization(context.Background(), task.ProjectID())
+	if organizationID == "" {
+		return nil
+	}
+
+	e.topic.Publish(newUsageEvent(
+		UsageAttachmentStored+":"+added.AttachmentID,
+		UsageAttachmentStored,
+		organizationID,
+		added.AttachmentID,
+		added.SizeBytes,
+		UnitByte,
+		added.OccurredAt(),
+	))
+	return nil
+}
+
+// RecordActivity meters a user as active the first time they are seen in a billing period
+func (e *UsageEmitter) RecordActivity(ctx context.Context, userID string) {
+	id, err := value.NewUserID(userID)
+	if err != nil {
+		return
+	}
+
+	e.mu.Lock()
+	now := e.now()
+	period := Period(now)
+	if period != e.activePeriod {
+		e.activePeriod = period
+		e.active = make(map[string]bool)
+	}
+	if e.active[userID] {
+		e.mu.Unlock()
+		return
+	}
+	e.active[userID] = true
+	e.mu.Unlock()
+
+	organization, err := e.organizations.GetByMemberID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	e.topic.Publish(newUsageEvent(
+		UsageUserActive+":"+userID+":"+period,
+		UsageUserActive,
+		organization.ID().Value(),
+		userID,
+		1,
+		UnitUser,
+		now,
+	))
+}
+
+// projectOrganization returns the organization of a project's owner, empty when there is none
+func (e *UsageEmitter) projectOrganization(ctx context.Context, projectID value.ProjectID) string {
+	project, err := e.projects.GetByID(ctx, projectID)
+	if err != nil {
+		return ""
+	}
+
+	organization, err := e.organizations.GetByMemberID(ctx, project.OwnerID())
+	if err != nil {
+		return ""
+	}
+
+	return organization.ID().Value()
+}
@@ -0,0 +1,52 @@
+package billing
+
+import "time"
+
+// Usage event types, one per metered resource
+const (
+	UsageTaskCreated      = "task.created"
+	UsageAttachmentStored = "attachment.stored"
+	UsageUserActive       = "user.active"
+)
+
+// Units the quantity of a usage event is counted in
+const (
+	UnitTask = "task"
+	UnitByte = "byte"
+	UnitUser = "user"
+)
+
+// periodLayout formats the calendar month usage is billed in
+const periodLayout = "2006-01"
+
+// UsageEvent is one metered unit of consumption by an organization, in the same shape
+// whatever produced it. IDs are stable per usage, so billing can drop redelivered events.
+type UsageEvent struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	OrganizationID string    `json:"organization_id"`
+	SubjectID      string    `json:"subject_id"` // the task, attachment or user metered
+	Quantity       int64     `json:"quantity"`
+	Unit           string    `json:"unit"`
+	Period         string    `json:"period"` // UTC month, as 2006-01
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// Period returns the billing period of a point in time
+func Period(t time.Time) string {
+	return t.UTC().Format(periodLayout)
+}
+
+// newUsageEvent creates a UsageEvent billed in the month it occurred
+func newUsageEvent(id, usageType, organizationID, subjectID string, quantity int64, unit string, occurredAt time.Time) UsageEvent {
+	return UsageEvent{
+		ID:             id,
+		Type:           usageType,
+		OrganizationID: organizationID,
+		SubjectID:      subjectID,
+		Quantity:       quantity,
+		Unit:           unit,
+		Period:         Period(occurredAt),
+		OccurredAt:     occurredAt.UTC(),
+	}
+}
@@ -0,0 +1,123 @@
+package billing
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+)
+
+// UsageTopic is the channel usage events are published on, kept apart from the domain event bus.
+// Subscribers receive each event as it is published, and the topic keeps the log for previews.
+type UsageTopic struct {
+	events       []UsageEvent
+	listeners    map[int]func(UsageEvent)
+	nextListener int
+	mu           sync.RWMutex
+}
+
+// NewUsageTopic creates a new UsageTopic
+func NewUsageTopic() *UsageTopic {
+	return &UsageTopic{
+		events:    make([]UsageEvent, 0),
+		listeners: make(map[int]func(UsageEvent)),
+	}
+}
+
+// Publish appends a usage event to the topic and hands it to every subscriber
+func (t *UsageTopic) Publish(usage UsageEvent) {
+	t.mu.Lock()
+	t.events = append(t.events, usage)
+	listeners := make([]func(UsageEvent), 0, len(t.listeners))
+	for _, listener := range t.listeners {
+		listeners = append(listeners, listener)
+	}
+	t.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(usage)
+	}
+}
+
+// Subscribe registers a listener and returns a function that removes it
+func (t *UsageTopic) Subscribe(listener func(UsageEvent)) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.nextListener
+	t.nextListener++
+	t.listeners[id] = listener
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		delete(t.listeners, id)
+	}
+}
+
+// Events returns the usage events of an organization in a billing period, in publish order
+func (t *UsageTopic) Events(organizationID, period string) []UsageEvent {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	events := make([]UsageEvent, 0)
+	for _, usage := range t.events {
+		if usage.OrganizationID == organizationID && usage.Period == period {
+			events = append(events, usage)
+		}
+	}
+	return events
+}
+
+// MonthlyUsage previews what billing would meter for an organization in the month of a point
+// in time so far. Events published more than once are counted once.
+func (t *UsageTopic) MonthlyUsage(organizationID string, month time.Time) dto.MonthlyUsageDTO {
+	period := Period(month)
+	usage := dto.MonthlyUsageDTO{
+		OrganizationID: organizationID,
+		Period:         period,
+	}
+
+	seen := make(map[string]bool)
+	for _, evt := range t.Events(organizationID, period) {
+		if seen[evt.ID] {
+			continue
+		}
+		seen[evt.ID] = true
+		usage.Events++
+
+		switch evt.Type {
+		case UsageTaskCreated:
+			usage.TasksCreated += evt.Quantity
+		case UsageAttachmentStored:
+			usage.AttachmentBytes += evt.Quantity
+		case UsageUserActive:
+			usage.ActiveUsers += evt.Quantity
+		}
+	}
+
+	return usage
+}
+
+// NDJSONSink returns a subscriber that writes each usage event as one JSON line,
+// for a billing system that tails a file or pipe
+func NDJSONSink(w io.Writer) func(UsageEvent) {
+	var mu sync.Mutex
+	return func(usage UsageEvent) {
+		line, err := json.Marshal(usage)
+		if err != nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			log.Printf("billing: failed to write usage event %s: %v", usage.ID, err)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package event
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// subscriberStats are the running counters of one subscriber
+type subscriberStats struct {
+	processed       int64
+	errors          int64
+	lastEventAt     time.Time
+	lastProcessedAt time.Time
+	totalLatency    time.Duration
+	maxLatency      time.Duration
+}
+
+// projectStats are the running counters of one project
+type projectStats struct {
+	events    int64
+	producers map[string]*dto.EventProducerDTO // aggregate type and ID -> producer
+}
+
+// EventMetrics counts stored and delivered events and tracks every named subscriber.
+// An event counts as delivered once all subscribers handled it without error, so the
+// outbox backlog is the number of stored events whose delivery failed or is in flight.
+type EventMetrics struct {
+	stored            int64
+	delivered         int64
+	lastStoredEventAt time.Time
+	subscribers       map[string]*subscriberStats
+	byType            map[string]int64
+	byAggregateType   map[string]int64
+	byProject         map[string]*projectStats
+	projectOf         func(evt event.DomainEvent) string
+	mu                sync.Mutex
+}
+
+// NewEventMetrics creates a new EventMetrics
+func NewEventMetrics() *EventMetrics {
+	return &EventMetrics{
+		subscribers:     make(map[string]*subscriberStats),
+		byType:          make(map[string]int64),
+		byAggregateType: make(map[string]int64),
+		byProject:       make(map[string]*projectStats),
+	}
+}
+
+// SetProjectResolver sets how an event is traced to its project, for the per-project counts.
+// The resolver returns an empty string for events outside any project.
+func (m *EventMetrics) SetProjectResolver(projectOf func(evt event.DomainEvent) string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.projectOf = projectOf
+}
+
+// RecordStored counts an event appended to the event store
+func (m *EventMetrics) RecordStored(evt event.DomainEvent) {
+	m.mu.Lock()
+	projectOf := m.projectOf
+	m.mu.Unlock()
+
+	// Resolve the project outside the lock, it may need a repository lookup
+	projectID := ""
+	if projectOf != nil {
+		projectID = projectOf(evt)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.stored++
+	if evt.OccurredAt().After(m.lastStoredEventAt) {
+		m.lastStoredEventAt = evt.OccurredAt()
+	}
+
+	m.byType[evt.EventType()]++
+	m.byAggregateType[evt.AggregateType()]++
+
+	if projectID == "" {
+		return
+	}
+
+	project, exists := m.byProject[projectID]
+	if !exists {
+		project = &projectStats{producers: make(map[string]*dto.EventProducerDTO)}
+		m.byProject[projectID] = project
+	}
+	project.events++
+
+	key := evt.AggregateType() + "/" + evt.AggregateID()
+	producer, exists := project.producers[key]
+	if !exists {
+		producer = &dto.EventProducerDTO{AggregateType: evt.AggregateType(), AggregateID: evt.AggregateID()}
+		project.producers[key] = producer
+	}
+	producer.Events++
+	if evt.OccurredAt().After(producer.LastEventAt) {
+		producer.LastEventAt = evt.OccurredAt()
+	}
+}
+
+// RecordDelivered counts an event handed to all subscribers
+func (m *EventMetrics) RecordDelivered(evt event.DomainEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.delivered++
+}
+
+// RecordHandled records the outcome of one subscriber handling an event and how long it took
+func (m *EventMetrics) RecordHandled(subscriber string, evt event.DomainEvent, elapsed time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, exists := m.subscribers[subscriber]
+	if !exists {
+		stats = &subscriberStats{}
+		m.subscribers[subscriber] = stats
+	}
+
+	stats.processed++
+	stats.totalLatency += elapsed
+	if elapsed > stats.maxLatency {
+		stats.maxLatency = elapsed
+	}
+	if err != nil {
+		stats.errors++
+		return
+	}
+
+	if evt.OccurredAt().After(stats.lastEventAt) {
+		stats.lastEventAt = evt.OccurredAt()
+	}
+	stats.lastProcessedAt = time.Now()
+}
+
+// Track registers a subscriber so it is reported before it handles its first event
+func (m *EventMetrics) Track(subscriber string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.subscribers[subscriber]; !exists {
+		m.subscribers[subscriber] = &subscriberStats{}
+	}
+}
+
+// Snapshot returns the current metrics, subscribers sorted by name.
+// A subscriber's lag is how far its last processed event trails the newest stored event.
+func (m *EventMetrics) Snapshot() dto.EventMetricsSnapshotDTO {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := dto.EventMetricsSnapshotDTO{
+		EventsStored:    m.stored,
+		EventsDelivered: m.delivered,
+		OutboxBacklog:   m.stored - m.delivered,
+		Subscribers:     make([]dto.SubscriberMetricsDTO, 0, len(m.subscribers)),
+	}
+	if !m.lastStoredEventAt.IsZero() {
+		lastStored := m.lastStoredEventAt
+		snapshot.LastStoredEventAt = &lastStored
+	}
+
+	for name, stats := range m.subscribers {
+		metrics := dto.SubscriberMetricsDTO{
+			Name:      name,
+			Processed: stats.processed,
+			Errors:    stats.errors,
+		}
+
+		if stats.processed > 0 {
+			metrics.ErrorRate = float64(stats.errors) / float64(stats.processed)
+			metrics.AvgLatencyMs = durationMs(stats.totalLatency) / float64(stats.processed)
+			metrics.MaxLatencyMs = durationMs(stats.maxLatency)
+		}
+
+		if !stats.lastEventAt.IsZero() {
+			lastEvent := stats.lastEventAt
+			lastProcessed := stats.lastProcessedAt
+			metrics.LastEventAt = &lastEvent
+			metrics.LastProcessedAt = &lastProcessed
+			if m.lastStoredEventAt.After(lastEvent) {
+				metrics.LagSeconds = m.lastStoredEventAt.Sub(lastEvent).Seconds()
+			}
+		}
+
+		snapshot.Subscribers = append(snapshot.Subscribers, metrics)
+	}
+
+	sort.Slice(snapshot.Subscribers, func(i, j int) bool {
+		return snapshot.Subscribers[i].Name < snapshot.Subscribers[j].Name
+	})
+
+	return snapshot
+}
+
+// Stats returns event counts by type and aggregate type, most frequent first, and each
+// project's busiest aggregates, limited to top per project. projectID, when set, limits
+// the projects to that one.
+func (m *EventMetrics) Stats(projectID string, top int) dto.EventStatsDTO {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := dto.EventStatsDTO{
+		EventTypes:     make([]dto.EventTypeCountDTO, 0, len(m.byType)),
+		AggregateTypes: make([]dto.AggregateTypeCountDTO, 0, len(m.byAggregateType)),
+		Projects:       make([]dto.ProjectEventStatsDTO, 0),
+	}
+
+	for eventType, count := range m.byType {
+		stats.EventTypes = append(stats.EventTypes, dto.EventTypeCountDTO{EventType: eventType, Count: count})
+	}
+	sort.Slice(stats.EventTypes, func(i, j int) bool {
+		if stats.EventTypes[i].Count != stats.EventTypes[j].Count {
+			return stats.EventTypes[i].Count > stats.EventTypes[j].Count
+		}
+		return stats.EventTypes[i].EventType < stats.EventTypes[j].EventType
+	})
+
+	for aggregateType, count := range m.byAggregateType {
+		stats.AggregateTypes = append(stats.AggregateTypes, dto.AggregateTypeCountDTO{AggregateType: aggregateType, Count: count})
+	}
+	sort.Slice(stats.AggregateTypes, func(i, j int) bool {
+		if stats.AggregateTypes[i].Count != stats.AggregateTypes[j].Count {
+			return stats.AggregateTypes[i].Count > stats.AggregateTypes[j].Count
+		}
+		return stats.AggregateTypes[i].AggregateType < stats.AggregateTypes[j].AggregateType
+	})
+
+	for id, project := range m.byProject {
+		if projectID != "" && id != projectID {
+			continue
+		}
+
+		producers := make([]dto.EventProducerDTO, 0, len(project.producers))
+		for _, producer := range project.producers {
+			producers = append(producers, *producer)
+		}
+		sort.Slice(producers, func(i, j int) bool {
+			if producers[i].Events != producers[j].Events {
+				return producers[i].Events > producers[j].Events
+			}
+			return producers[i].AggregateID < producers[j].AggregateID
+		})
+		if top > 0 && len(producers) > top {
+			producers = producers[:top]
+		}
+
+		stats.Projects = append(stats.Projects, dto.ProjectEventStatsDTO{
+			ProjectID:    id,
+			Events:       project.events,
+			TopProducers: producers,
+		})
+	}
+	sort.Slice(stats.Projects, func(i, j int) bool {
+		if stats.Projects[i].Events != stats.Projects[j].Events {
+			return stats.Projects[i].Events > stats.Projects[j].Events
+		}
+		return stats.Projects[i].ProjectID < stats.Projects[j].ProjectID
+	})
+
+	return stats
+}
+
+// durationMs converts a duration to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// MeasuredSubscriber wraps an EventSubscriber so every handler it registers is
+// reported under one subscriber name
+type MeasuredSubscriber struct {
+	subscriber event.EventSubscriber
+	metrics    *EventMetrics
+	name       string
+}
+
+// NewMeasuredSubscriber creates a new MeasuredSubscriber
+func NewMeasuredSubscriber(subscriber event.EventSubscriber, metrics *EventMetrics, name string) *MeasuredSubscriber {
+	metrics.Track(name)
+
+	return &MeasuredSubscriber{
+		subscriber: subscriber,
+		metrics:    metrics,
+		name:       name,
+	}
+}
+
+// Subscribe registers a handler whose outcomes are recorded
+func (s *MeasuredSubscriber) Subscribe(eventType string, handler func(event.DomainEvent) error) error {
+	return s.subscriber.Subscribe(eventType, func(evt event.DomainEvent) error {
+		started := time.Now()
+		err := handler(evt)
+		s.metrics.RecordHandled(s.name, evt, time.Since(started), err)
+		return err
+	})
+}
+
+// Unsubscribe removes all handlers for an event type
+func (s *MeasuredSubscriber) Unsubscribe(eventType string) error {
+	return s.subscriber.Unsubscribe(eventType)
+}
+
+// Ensure MeasuredSubscriber implements event.EventSubscriber
+var _ event.EventSubscriber = (*MeasuredSubscriber)(nil)
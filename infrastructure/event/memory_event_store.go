@@ -0,0 +1,199 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// InMemoryEventStore is an append-only in-memory event store
+type InMemoryEventStore struct {
+	events    []event.DomainEvent
+	positions []int64 // position of each event in the log, kept across pruning
+	last      int64
+	mu        sync.RWMutex
+}
+
+// NewInMemoryEventStore creates a new InMemoryEventStore
+func NewInMemoryEventStore() *InMemoryEventStore {
+	return &InMemoryEventStore{
+		events: make([]event.DomainEvent, 0),
+	}
+}
+
+// Store appends an event to the store
+func (s *InMemoryEventStore) Store(ctx context.Context, evt event.DomainEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, evt)
+	s.last++
+	s.positions = append(s.positions, s.last)
+	return nil
+}
+
+// AppendBatch appends events to the store under a single lock, growing the log once
+func (s *InMemoryEventStore) AppendBatch(ctx context.Context, events []event.DomainEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if free := cap(s.events) - len(s.events); free < len(events) {
+		grown := make([]event.DomainEvent, len(s.events), len(s.events)+len(events))
+		copy(grown, s.events)
+		s.events = grown
+	}
+
+	s.events = append(s.events, events...)
+	for range events {
+		s.last++
+		s.positions = append(s.positions, s.last)
+	}
+	return nil
+}
+
+// GetEvents retrieves all events for an aggregate in append order
+func (s *InMemoryEventStore) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]event.DomainEvent, 0)
+	for _, evt := range s.events {
+		if evt.AggregateID() == aggregateID {
+			events = append(events, evt)
+		}
+	}
+
+	return events, nil
+}
+
+// GetEventsPage retrieves up to limit events for an aggregate following afterVersion, in append order
+func (s *InMemoryEventStore) GetEventsPage(ctx context.Context, aggregateID string, afterVersion, limit int) ([]event.DomainEvent, error) {
+	if afterVersion < 0 || limit < 0 {
+		return nil, errs.Invalid("invalid page: after version and limit cannot be negative")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]event.DomainEvent, 0)
+	version := 0
+	for _, evt := range s.events {
+		if evt.AggregateID() != aggregateID {
+			continue
+		}
+		version++
+		if version <= afterVersion {
+			continue
+		}
+		events = append(events, evt)
+		if limit > 0 && len(events) == limit {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// GetEventsSince retrieves events for an aggregate that occurred after an RFC3339 timestamp
+func (s *InMemoryEventStore) GetEventsSince(ctx context.Context, aggregateID string, since string) ([]event.DomainEvent, error) {
+	sinceTime, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return nil, errs.Invalid("invalid since timestamp: %w", err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]event.DomainEvent, 0)
+	for _, evt := range s.events {
+		if evt.AggregateID() == aggregateID && evt.OccurredAt().After(sinceTime) {
+			events = append(events, evt)
+		}
+	}
+
+	return events, nil
+}
+
+// GetAllEvents retrieves every stored event in append order
+func (s *InMemoryEventStore) GetAllEvents(ctx context.Context) ([]event.DomainEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]event.DomainEvent, len(s.events))
+	copy(events, s.events)
+	return events, nil
+}
+
+// PruneStreams removes the streams of aggregates whose last event occurred before an RFC3339 timestamp
+func (s *InMemoryEventStore) PruneStreams(ctx context.Context, before string) (int, error) {
+	beforeTime, err := time.Parse(time.RFC3339, before)
+	if err != nil {
+		return 0, errs.Invalid("invalid before timestamp: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lastEvent := make(map[string]time.Time)
+	for _, evt := range s.events {
+		if evt.OccurredAt().After(lastEvent[evt.AggregateID()]) {
+			lastEvent[evt.AggregateID()] = evt.OccurredAt()
+		}
+	}
+
+	kept := make([]event.DomainEvent, 0, len(s.events))
+	keptPositions := make([]int64, 0, len(s.positions))
+	for i, evt := range s.events {
+		if !lastEvent[evt.AggregateID()].Before(beforeTime) {
+			kept = append(kept, evt)
+			keptPositions = append(keptPositions, s.positions[i])
+		}
+	}
+
+	pruned := len(s.events) - len(kept)
+	s.events = kept
+	s.positions = keptPositions
+	return pruned, nil
+}
+
+// QueryEvents retrieves the events of the whole log matching a filter, in append order
+func (s *InMemoryEventStore) QueryEvents(ctx context.Context, filter event.EventFilter) ([]event.StoredEvent, error) {
+	if filter.AfterPosition < 0 || filter.Limit < 0 {
+		return nil, errs.Invalid("invalid page: after position and limit cannot be negative")
+	}
+
+	var since time.Time
+	if filter.Since != "" {
+		parsed, err := time.Parse(time.RFC3339, filter.Since)
+		if err != nil {
+			return nil, errs.Invalid("invalid since timestamp: %w", err)
+		}
+		since = parsed
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]event.StoredEvent, 0)
+	for i, evt := range s.events {
+		if s.positions[i] <= filter.AfterPosition ||
+			(filter.AggregateID != "" && evt.AggregateID() != filter.AggregateID) ||
+			(filter.EventType != "" && evt.EventType() != filter.EventType) ||
+			(!since.IsZero() && !evt.OccurredAt().After(since)) {
+			continue
+		}
+
+		events = append(events, event.StoredEvent{Position: s.positions[i], Event: evt})
+		if filter.Limit > 0 && len(events) == filter.Limit {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// Ensure InMemoryEventStore implements event.EventStore
+var _ event.EventStore = (*InMemoryEventStore)(nil)
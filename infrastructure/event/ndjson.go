@@ -0,0 +1,217 @@
+package event
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// maxNDJSONLine bounds a single exported event line
+const maxNDJSONLine = 4 * 1024 * 1024
+
+// importBatchSize is how many imported events are appended to the store per write
+const importBatchSize = 500
+
+// IDMapper rewrites identifiers while importing an event stream
+type IDMapper interface {
+	Map(id string) string
+}
+
+// KeepIDs imports identifiers unchanged
+type KeepIDs struct{}
+
+// Map returns the identifier as is
+func (KeepIDs) Map(id string) string {
+	return id
+}
+
+// SeededIDMapper replaces identifiers with name-based UUIDs derived from a seed.
+// The same seed always maps an ID to the same replacement, so references across
+// events stay consistent and repeated imports are reproducible.
+type SeededIDMapper struct {
+	namespace uuid.UUID
+}
+
+// NewSeededIDMapper creates a SeededIDMapper
+func NewSeededIDMapper(seed string) *SeededIDMapper {
+	return &SeededIDMapper{
+		namespace: uuid.NewSHA1(uuid.NameSpaceOID, []byte(seed)),
+	}
+}
+
+// Map returns the replacement for an identifier, empty IDs stay empty
+func (m *SeededIDMapper) Map(id string) string {
+	if id == "" {
+		return ""
+	}
+	return uuid.NewSHA1(m.namespace, []byte(id)).String()
+}
+
+// ExportNDJSON writes every stored event as one JSON envelope per line, in append order.
+// The same store always produces byte-identical output.
+func ExportNDJSON(ctx context.Context, w io.Writer, store event.EventStore, serializer *EventSerializer) (int, error) {
+	events, err := store.GetAllEvents(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read event store: %w", err)
+	}
+
+	buffered := bufio.NewWriter(w)
+	for i, evt := range events {
+		if err := ctx.Err(); err != nil {
+			return i, fmt.Errorf("export stopped after %d events: %w", i, err)
+		}
+
+		line, err := serializer.Serialize(evt)
+		if err != nil {
+			return i, fmt.Errorf("failed to serialize event %d: %w", i+1, err)
+		}
+
+		buffered.Write(line)
+		if err := buffered.WriteByte('\n'); err != nil {
+			return i, fmt.Errorf("failed to write event %d: %w", i+1, err)
+		}
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return len(events), fmt.Errorf("failed to write events: %w", err)
+	}
+
+	return len(events), nil
+}
+
+// NDJSONExporter exports an event store in the format ExportNDJSON writes
+type NDJSONExporter struct {
+	store      event.EventStore
+	serializer *EventSerializer
+}
+
+// NewNDJSONExporter creates a new NDJSONExporter
+func NewNDJSONExporter(store event.EventStore, serializer *EventSerializer) *NDJSONExporter {
+	return &NDJSONExporter{
+		store:      store,
+		serializer: serializer,
+	}
+}
+
+// Export writes every stored event as one JSON envelope per line
+func (e *NDJSONExporter) Export(ctx context.Context, w io.Writer) (int, error) {
+	return ExportNDJSON(ctx, w, e.store, e.serializer)
+}
+
+// ImportNDJSON reads an exported event stream into a store, rewriting the aggregate ID
+// and every identifier field of the payload through the mapper. Blank lines are skipped.
+// Events are appended in batches, the returned count only includes fully stored batches.
+func ImportNDJSON(ctx context.Context, r io.Reader, store event.EventStore, serializer *EventSerializer, mapper IDMapper) (int, error) {
+	if mapper == nil {
+		mapper = KeepIDs{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+
+	imported := 0
+	lineNumber := 0
+	batch := make([]event.DomainEvent, 0, importBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := store.AppendBatch(ctx, batch); err != nil {
+			return fmt.Errorf("line %d: failed to store events: %w", lineNumber, err)
+		}
+		imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var envelope rawEnvelope
+		if err := json.Unmarshal([]byte(line), &envelope); err != nil {
+			return imported, fmt.Errorf("line %d: invalid event envelope: %w", lineNumber, err)
+		}
+
+		if err := remapEnvelope(&envelope, mapper); err != nil {
+			return imported, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		evt, err := serializer.fromEnvelope(envelope)
+		if err != nil {
+			return imported, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		batch = append(batch, evt)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return imported, err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return imported, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return imported, err
+	}
+
+	return imported, nil
+}
+
+// remapEnvelope rewrites the identifiers of one envelope in place
+func remapEnvelope(envelope *rawEnvelope, mapper IDMapper) error {
+	envelope.AggregateID = mapper.Map(envelope.AggregateID)
+
+	for name, raw := range envelope.Payload {
+		if !isIDField(name) {
+			continue
+		}
+
+		var remapped interface{}
+		if strings.HasSuffix(name, "_ids") {
+			var ids []string
+			if err := json.Unmarshal(raw, &ids); err != nil {
+				return fmt.Errorf("invalid id list %s: %w", name, err)
+			}
+			for i, id := range ids {
+				ids[i] = mapper.Map(id)
+			}
+			remapped = ids
+		} else {
+			var id string
+			if err := json.Unmarshal(raw, &id); err != nil {
+				return fmt.Errorf("invalid id %s: %w", name, err)
+			}
+			remapped = mapper.Map(id)
+		}
+
+		encoded, err := json.Marshal(remapped)
+		if err != nil {
+			return err
+		}
+		envelope.Payload[name] = encoded
+	}
+
+	return nil
+}
+
+// isIDField reports whether a payload field holds identifiers
+func isIDField(name string) bool {
+	return strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "_ids") || name == "completed_by"
+}
+
+// Ensure NDJSONExporter implements query.EventExporter
+var _ query.EventExporter = (*NDJSONExporter)(nil)
@@ -0,0 +1,295 @@
+package event
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// PostgresEventSchema creates the table PostgresEventStore appends to. Position orders
+// the whole log; sequence numbers each aggregate's events from 1 without gaps.
+const PostgresEventSchema = `
+CREATE TABLE IF NOT EXISTS domain_events (
+    position       BIGSERIAL PRIMARY KEY,
+    aggregate_id   TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    event_type     TEXT NOT NULL,
+    schema_version INTEGER NOT NULL,
+    sequence       BIGINT NOT NULL,
+    payload        JSONB NOT NULL,
+    occurred_at    TIMESTAMPTZ NOT NULL,
+    UNIQUE (aggregate_id, sequence)
+);
+CREATE INDEX IF NOT EXISTS domain_events_occurred_at ON domain_events (aggregate_id, occurred_at);
+ALTER TABLE domain_events ADD COLUMN IF NOT EXISTS event_id TEXT;
+CREATE INDEX IF NOT EXISTS domain_events_event_type ON domain_events (event_type, position);
+`
+
+// PostgresEventStore is an append-only event store kept in a PostgreSQL table.
+// Events are written with the serializer's wire payloads, so every stored event type
+// must be registered with it.
+type PostgresEventStore struct {
+	db         *sql.DB
+	serializer *EventSerializer
+}
+
+// NewPostgresEventStore creates a new PostgresEventStore on an open database.
+// Call EnsureSchema once before use unless the table is migrated separately.
+func NewPostgresEventStore(db *sql.DB, serializer *EventSerializer) *PostgresEventStore {
+	return &PostgresEventStore{
+		db:         db,
+		serializer: serializer,
+	}
+}
+
+// EnsureSchema creates the events table and its index when they do not exist yet
+func (s *PostgresEventStore) EnsureSchema() error {
+	if _, err := s.db.Exec(PostgresEventSchema); err != nil {
+		return fmt.Errorf("failed to create event store schema: %w", err)
+	}
+	return nil
+}
+
+// Store appends an event to the store
+func (s *PostgresEventStore) Store(ctx context.Context, evt event.DomainEvent) error {
+	return s.AppendBatch(ctx, []event.DomainEvent{evt})
+}
+
+// AppendBatch appends events in a single transaction, numbering each after the last
+// event of its aggregate. Concurrent writers to the same aggregate are serialized.
+func (s *PostgresEventStore) AppendBatch(ctx context.Context, events []event.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	envelopes := make([]dto.EventEnvelopeDTO, len(events))
+	payloads := make([][]byte, len(events))
+	for i, evt := range events {
+		envelope, err := s.serializer.envelopeOf(evt)
+		if err != nil {
+			return err
+		}
+		payload, err := json.Marshal(envelope.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s payload: %w", envelope.EventType, err)
+		}
+		envelopes[i] = envelope
+		payloads[i] = payload
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin event append: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock aggregates in a fixed order so batches touching the same ones cannot deadlock
+	aggregateIDs := make([]string, 0, len(envelopes))
+	seen := make(map[string]bool, len(envelopes))
+	for _, envelope := range envelopes {
+		if !seen[envelope.AggregateID] {
+			seen[envelope.AggregateID] = true
+			aggregateIDs = append(aggregateIDs, envelope.AggregateID)
+		}
+	}
+	sort.Strings(aggregateIDs)
+	for _, aggregateID := range aggregateIDs {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, aggregateID); err != nil {
+			return fmt.Errorf("failed to lock aggregate %s: %w", aggregateID, err)
+		}
+	}
+
+	for i, envelope := range envelopes {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO domain_events
+				(aggregate_id, aggregate_type, event_type, schema_version, sequence, payload, occurred_at, event_id)
+			SELECT $1, $2, $3, $4, COALESCE(MAX(sequence), 0) + 1, $5, $6, $7
+			FROM domain_events WHERE aggregate_id = $1`,
+			envelope.AggregateID,
+			envelope.AggregateType,
+			envelope.EventType,
+			envelope.SchemaVersion,
+			payloads[i],
+			envelope.OccurredAt.UTC(),
+			envelope.EventID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to append %s: %w", envelope.EventType, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit event append: %w", err)
+	}
+	return nil
+}
+
+// GetEvents retrieves all events for an aggregate in append order
+func (s *PostgresEventStore) GetEvents(ctx context.Context, aggregateID string) ([]event.DomainEvent, error) {
+	return s.query(ctx, `
+		SELECT aggregate_id, aggregate_type, event_type, schema_version, payload, occurred_at, COALESCE(event_id, ''), position
+		FROM domain_events WHERE aggregate_id = $1 ORDER BY sequence`, aggregateID)
+}
+
+// GetEventsPage retrieves up to limit events for an aggregate following afterVersion, in append order
+func (s *PostgresEventStore) GetEventsPage(ctx context.Context, aggregateID string, afterVersion, limit int) ([]event.DomainEvent, error) {
+	if afterVersion < 0 || limit < 0 {
+		return nil, errs.Invalid("invalid page: after version and limit cannot be negative")
+	}
+
+	// LIMIT NULL returns every row
+	var rowLimit interface{}
+	if limit > 0 {
+		rowLimit = limit
+	}
+
+	return s.query(ctx, `
+		SELECT aggregate_id, aggregate_type, event_type, schema_version, payload, occurred_at, COALESCE(event_id, ''), position
+		FROM domain_events WHERE aggregate_id = $1 AND sequence > $2 ORDER BY sequence LIMIT $3`,
+		aggregateID, afterVersion, rowLimit)
+}
+
+// GetEventsSince retrieves events for an aggregate that occurred after an RFC3339 timestamp
+func (s *PostgresEventStore) GetEventsSince(ctx context.Context, aggregateID string, since string) ([]event.DomainEvent, error) {
+	sinceTime, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return nil, errs.Invalid("invalid since timestamp: %w", err)
+	}
+
+	return s.query(ctx, `
+		SELECT aggregate_id, aggregate_type, event_type, schema_version, payload, occurred_at, COALESCE(event_id, ''), position
+		FROM domain_events WHERE aggregate_id = $1 AND occurred_at > $2 ORDER BY sequence`,
+		aggregateID, sinceTime.UTC())
+}
+
+// GetAllEvents retrieves every stored event in append order
+func (s *PostgresEventStore) GetAllEvents(ctx context.Context) ([]event.DomainEvent, error) {
+	return s.query(ctx, `
+		SELECT aggregate_id, aggregate_type, event_type, schema_version, payload, occurred_at, COALESCE(event_id, ''), position
+		FROM domain_events ORDER BY position`)
+}
+
+// PruneStreams removes the streams of aggregates whose last event occurred before an RFC3339 timestamp
+func (s *PostgresEventStore) PruneStreams(ctx context.Context, before string) (int, error) {
+	beforeTime, err := time.Parse(time.RFC3339, before)
+	if err != nil {
+		return 0, errs.Invalid("invalid before timestamp: %w", err)
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM domain_events WHERE aggregate_id IN (
+			SELECT aggregate_id FROM domain_events
+			GROUP BY aggregate_id HAVING MAX(occurred_at) < $1)`,
+		beforeTime.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune events: %w", err)
+	}
+
+	pruned, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pruned events: %w", err)
+	}
+	return int(pruned), nil
+}
+
+// QueryEvents retrieves the events of the whole log matching a filter, in append order
+func (s *PostgresEventStore) QueryEvents(ctx context.Context, filter event.EventFilter) ([]event.StoredEvent, error) {
+	if filter.AfterPosition < 0 || filter.Limit < 0 {
+		return nil, errs.Invalid("invalid page: after position and limit cannot be negative")
+	}
+
+	// NULL parameters match every event and LIMIT NULL returns every row
+	var aggregateID, eventType, since, rowLimit interface{}
+	if filter.AggregateID != "" {
+		aggregateID = filter.AggregateID
+	}
+	if filter.EventType != "" {
+		eventType = filter.EventType
+	}
+	if filter.Since != "" {
+		sinceTime, err := time.Parse(time.RFC3339, filter.Since)
+		if err != nil {
+			return nil, errs.Invalid("invalid since timestamp: %w", err)
+		}
+		since = sinceTime.UTC()
+	}
+	if filter.Limit > 0 {
+		rowLimit = filter.Limit
+	}
+
+	return s.queryStored(ctx, `
+		SELECT aggregate_id, aggregate_type, event_type, schema_version, payload, occurred_at, COALESCE(event_id, ''), position
+		FROM domain_events
+		WHERE position > $1
+			AND ($2::TEXT IS NULL OR aggregate_id = $2)
+			AND ($3::TEXT IS NULL OR event_type = $3)
+			AND ($4::TIMESTAMPTZ IS NULL OR occurred_at > $4)
+		ORDER BY position LIMIT $5`,
+		filter.AfterPosition, aggregateID, eventType, since, rowLimit)
+}
+
+// query decodes the event rows a query selects, in the order it returns them
+func (s *PostgresEventStore) query(ctx context.Context, statement string, args ...interface{}) ([]event.DomainEvent, error) {
+	stored, err := s.queryStored(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]event.DomainEvent, len(stored))
+	for i, entry := range stored {
+		events[i] = entry.Event
+	}
+	return events, nil
+}
+
+// queryStored decodes the event rows a query selects with their positions
+func (s *PostgresEventStore) queryStored(ctx context.Context, statement string, args ...interface{}) ([]event.StoredEvent, error) {
+	rows, err := s.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]event.StoredEvent, 0)
+	for rows.Next() {
+		var envelope rawEnvelope
+		var payload []byte
+		var position int64
+		if err := rows.Scan(
+			&envelope.AggregateID,
+			&envelope.AggregateType,
+			&envelope.EventType,
+			&envelope.SchemaVersion,
+			&payload,
+			&envelope.OccurredAt,
+			&envelope.EventID,
+			&position,
+		); err != nil {
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+		if err := json.Unmarshal(payload, &envelope.Payload); err != nil {
+			return nil, errs.Invalid("invalid %s payload: %w", envelope.EventType, err)
+		}
+
+		evt, err := s.serializer.fromEnvelope(envelope)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event.StoredEvent{Position: position, Event: evt})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Ensure PostgresEventStore implements event.EventStore
+var _ event.EventStore = (*PostgresEventStore)(nil)
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// NewProjectResolver returns a function that traces an event to the project it happened in,
+// looking up tasks and sprints when the event does not carry the project itself.
+// Events outside any project, such as user events, resolve to an empty string.
+func NewProjectResolver(tasks domain.TaskRepository, sprints domain.SprintRepository) func(evt event.DomainEvent) string {
+	return func(evt event.DomainEvent) string {
+		switch e := evt.(type) {
+		case event.TaskCreatedEvent:
+			return e.ProjectID
+		case event.TaskDeletedEvent:
+			return e.ProjectID
+		case event.SprintCreatedEvent:
+			return e.ProjectID
+		}
+
+		switch evt.AggregateType() {
+		case "Project":
+			return evt.AggregateID()
+		case "Task":
+			taskID, err := value.NewTaskID(evt.AggregateID())
+			if err != nil {
+				return ""
+			}
+			task, err := tasks.GetByID(context.Background(), taskID)
+			if err != nil {
+				return ""
+			}
+			return task.ProjectID().Value()
+		case "Sprint":
+			sprintID, err := value.NewSprintID(evt.AggregateID())
+			if err != nil {
+				return ""
+			}
+			sprint, err := sprints.GetByID(context.Background(), sprintID)
+			if err != nil {
+				return ""
+			}
+			return sprint.ProjectID().Value()
+		}
+
+		return ""
+	}
+}
@@ -0,0 +1,118 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// Projection is a read model built from domain events
+type Projection interface {
+	Name() string
+	Reset() error
+	Handle(evt event.DomainEvent) error
+}
+
+// ProjectionRebuilder truncates registered projections and replays the event store through them
+type ProjectionRebuilder struct {
+	store       event.EventStore
+	projections []Projection
+	mu          sync.Mutex
+}
+
+// NewProjectionRebuilder creates a new ProjectionRebuilder
+func NewProjectionRebuilder(store event.EventStore) *ProjectionRebuilder {
+	return &ProjectionRebuilder{
+		store:       store,
+		projections: make([]Projection, 0),
+	}
+}
+
+// Register adds a projection to be rebuilt
+func (r *ProjectionRebuilder) Register(projection Projection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projections = append(r.projections, projection)
+}
+
+// Rebuild resets every projection and replays all stored events in order.
+// progress, when non-nil, is called after each event.
+func (r *ProjectionRebuilder) Rebuild(ctx context.Context, progress func(command.RebuildProgress)) (*command.RebuildReport, error) {
+	return r.RebuildOnly(ctx, nil, progress)
+}
+
+// RebuildOnly resets and replays the named projections, every projection when names is empty
+func (r *ProjectionRebuilder) RebuildOnly(ctx context.Context, names []string, progress func(command.RebuildProgress)) (*command.RebuildReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	started := time.Now()
+
+	projections, err := r.selectProjections(names)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := r.store.GetAllEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	names = make([]string, 0, len(projections))
+	for _, projection := range projections {
+		if err := projection.Reset(); err != nil {
+			return nil, fmt.Errorf("failed to reset projection %s: %w", projection.Name(), err)
+		}
+		names = append(names, projection.Name())
+	}
+
+	for i, evt := range events {
+		for _, projection := range projections {
+			if err := projection.Handle(evt); err != nil {
+				return nil, fmt.Errorf("projection %s failed on event %d (%s): %w", projection.Name(), i, evt.EventType(), err)
+			}
+		}
+
+		if progress != nil {
+			progress(command.RebuildProgress{Processed: i + 1, Total: len(events)})
+		}
+	}
+
+	return &command.RebuildReport{
+		Projections:    names,
+		EventsReplayed: len(events),
+		Duration:       time.Since(started),
+	}, nil
+}
+
+// selectProjections returns the registered projections with the given names, all of them for none
+func (r *ProjectionRebuilder) selectProjections(names []string) ([]Projection, error) {
+	if len(names) == 0 {
+		return r.projections, nil
+	}
+
+	selected := make([]Projection, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, projection := range r.projections {
+			if projection.Name() == name {
+				selected = append(selected, projection)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errs.NotFound("projection not found: %s", name)
+		}
+	}
+	return selected, nil
+}
+
+// Ensure ProjectionRebuilder implements command.ProjectionRebuilder
+var _ command.ProjectionRebuilder = (*ProjectionRebuilder)(nil)
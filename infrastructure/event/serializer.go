@@ -0,0 +1,383 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// jsonSchemaDialect is the JSON Schema draft used for generated schemas
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// registeredEvent is a serializer registry entry
+type registeredEvent struct {
+	version     int
+	payloadType reflect.Type
+}
+
+// EventSerializer converts domain events to versioned JSON envelopes.
+// Every event type must be registered with its current schema version.
+type EventSerializer struct {
+	registry map[string]registeredEvent
+	mu       sync.RWMutex
+}
+
+// NewEventSerializer creates an EventSerializer with all domain events registered
+func NewEventSerializer() *EventSerializer {
+	s := &EventSerializer{
+		registry: make(map[string]registeredEvent),
+	}
+
+	s.Register("TaskCreated", 1, event.TaskCreatedEvent{})
+	s.Register("TaskAssigned", 1, event.TaskAssignedEvent{})
+	s.Register("TaskAcknowledged", 1, event.TaskAcknowledgedEvent{})
+	s.Register("TaskAssignmentEscalated", 1, event.TaskAssignmentEscalatedEvent{})
+	s.Register("TaskUnassigned", 1, event.TaskUnassignedEvent{})
+	s.Register("TaskAssignedToTeam", 1, event.TaskAssignedToTeamEvent{})
+	s.Register("TaskStatusChanged", 2, event.TaskStatusChangedEvent{})
+	s.Register("TaskDeadlineSet", 1, event.TaskDeadlineSetEvent{})
+	s.Register("TaskOverdue", 1, event.TaskOverdueEvent{})
+	s.Register("TaskCompleted", 1, event.TaskCompletedEvent{})
+	s.Register("TaskCommentAdded", 1, event.TaskCommentAddedEvent{})
+	s.Register("TaskLinked", 1, event.TaskLinkedEvent{})
+	s.Register("TaskUnlinked", 1, event.TaskUnlinkedEvent{})
+	s.Register("TaskVoted", 1, event.TaskVotedEvent{})
+	s.Register("TaskVoteRemoved", 1, event.TaskVoteRemovedEvent{})
+	s.Register("TaskApproved", 1, event.TaskApprovedEvent{})
+	s.Register("TaskRejected", 1, event.TaskRejectedEvent{})
+	s.Register("TaskWorkflowMigrated", 1, event.TaskWorkflowMigratedEvent{})
+	s.Register("TaskNotificationRequested", 1, event.TaskNotificationRequestedEvent{})
+	s.Register("TaskDeleted", 1, event.TaskDeletedEvent{})
+	s.Register("TaskCreationFailed", 1, event.TaskCreationFailedEvent{})
+	s.Register("TaskEditLockAcquired", 1, event.TaskEditLockAcquiredEvent{})
+	s.Register("TaskEditLockReleased", 1, event.TaskEditLockReleasedEvent{})
+	s.Register("TaskCostRecorded", 1, event.TaskCostRecordedEvent{})
+	s.Register("TaskAttachmentAdded", 1, event.TaskAttachmentAddedEvent{})
+	s.Register("ProjectCreated", 1, event.ProjectCreatedEvent{})
+	s.Register("ProjectRenamed", 1, event.ProjectRenamedEvent{})
+	s.Register("ProjectDescriptionUpdated", 1, event.ProjectDescriptionUpdatedEvent{})
+	s.Register("TaskAddedToProject", 1, event.TaskAddedToProjectEvent{})
+	s.Register("TaskRemovedFromProject", 1, event.TaskRemovedFromProjectEvent{})
+	s.Register("ProjectSLOTargetsChanged", 1, event.ProjectSLOTargetsChangedEvent{})
+	s.Register("ProjectSettingsChanged", 1, event.ProjectSettingsChangedEvent{})
+	s.Register("ProjectBudgetChanged", 1, event.ProjectBudgetChangedEvent{})
+	s.Register("ProjectHolidayCalendarChanged", 1, event.ProjectHolidayCalendarChangedEvent{})
+	s.Register("ProjectPrioritySchemeChanged", 1, event.ProjectPrioritySchemeChangedEvent{})
+	s.Register("ProjectInboxAddressAdded", 1, event.ProjectInboxAddressAddedEvent{})
+	s.Register("ProjectInboxAddressRevoked", 1, event.ProjectInboxAddressRevokedEvent{})
+	s.Register("BudgetExceeded", 1, event.BudgetExceededEvent{})
+	s.Register("ProjectAccessChanged", 1, event.ProjectAccessChangedEvent{})
+	s.Register("ProjectRoleAssigned", 1, event.ProjectRoleAssignedEvent{})
+	s.Register("ProjectRoleRevoked", 1, event.ProjectRoleRevokedEvent{})
+	s.Register("ProjectWorkflowChanged", 1, event.ProjectWorkflowChangedEvent{})
+	s.Register("ProjectWorkflowMigrationPlanned", 1, event.ProjectWorkflowMigrationPlannedEvent{})
+	s.Register("ProjectWorkflowMigrationProgressed", 1, event.ProjectWorkflowMigrationProgressedEvent{})
+	s.Register("ProjectWorkflowMigrationRolledBack", 1, event.ProjectWorkflowMigrationRolledBackEvent{})
+	s.Register("ProjectProgressUpdated", 1, event.ProjectProgressUpdatedEvent{})
+	s.Register("ProjectArchived", 1, event.ProjectArchivedEvent{})
+	s.Register("ProjectDeleted", 1, event.ProjectDeletedEvent{})
+	s.Register("ProjectUnarchived", 1, event.ProjectUnarchivedEvent{})
+	s.Register("MilestoneReached", 1, event.MilestoneReachedEvent{})
+	s.Register("UserRegistered", 1, event.UserRegisteredEvent{})
+	s.Register("UserPasswordChanged", 1, event.UserPasswordChangedEvent{})
+	s.Register("UserEmailVerified", 1, event.UserEmailVerifiedEvent{})
+	s.Register("UserDeactivated", 1, event.UserDeactivatedEvent{})
+	s.Register("UserActivated", 1, event.UserActivatedEvent{})
+	s.Register("UserEmailChanged", 1, event.UserEmailChangedEvent{})
+	s.Register("UserRenamed", 1, event.UserRenamedEvent{})
+	s.Register("UserWorkingHoursChanged", 1, event.UserWorkingHoursChangedEvent{})
+	s.Register("UserOutOfOfficeChanged", 1, event.UserOutOfOfficeChangedEvent{})
+	s.Register("UserManagerChanged", 1, event.UserManagerChangedEvent{})
+	s.Register("WorkflowCreated", 1, event.WorkflowCreatedEvent{})
+	s.Register("WorkflowDetailsChanged", 1, event.WorkflowDetailsChangedEvent{})
+	s.Register("WorkflowStatusAdded", 1, event.WorkflowStatusAddedEvent{})
+	s.Register("WorkflowStatusRemoved", 1, event.WorkflowStatusRemovedEvent{})
+	s.Register("WorkflowStatusesReordered", 1, event.WorkflowStatusesReorderedEvent{})
+	s.Register("WorkflowTransitionAllowed", 1, event.WorkflowTransitionAllowedEvent{})
+	s.Register("WorkflowTransitionDisallowed", 1, event.WorkflowTransitionDisallowedEvent{})
+	s.Register("WorkflowTransitionRulesChanged", 1, event.WorkflowTransitionRulesChangedEvent{})
+	s.Register("WorkflowApprovalStepChanged", 1, event.WorkflowApprovalStepChangedEvent{})
+	s.Register("TeamCreated", 1, event.TeamCreatedEvent{})
+	s.Register("TeamMemberAdded", 1, event.TeamMemberAddedEvent{})
+	s.Register("TeamMemberRemoved", 1, event.TeamMemberRemovedEvent{})
+	s.Register("TeamLeadChanged", 1, event.TeamLeadChangedEvent{})
+	s.Register("OrganizationCreated", 1, event.OrganizationCreatedEvent{})
+	s.Register("OrganizationMemberAdded", 1, event.OrganizationMemberAddedEvent{})
+	s.Register("OrganizationQuotaChanged", 1, event.OrganizationQuotaChangedEvent{})
+	s.Register("OrganizationWorkingHoursChanged", 1, event.OrganizationWorkingHoursChangedEvent{})
+	s.Register("HolidayCalendarCreated", 1, event.HolidayCalendarCreatedEvent{})
+	s.Register("HolidayCalendarChanged", 1, event.HolidayCalendarChangedEvent{})
+	s.Register("SprintCreated", 1, event.SprintCreatedEvent{})
+	s.Register("SprintStarted", 1, event.SprintStartedEvent{})
+	s.Register("SprintCompleted", 1, event.SprintCompletedEvent{})
+	s.Register("TaskAddedToSprint", 1, event.TaskAddedToSprintEvent{})
+	s.Register("TaskRemovedFromSprint", 1, event.TaskRemovedFromSprintEvent{})
+
+	return s
+}
+
+// Register adds or replaces an event type in the registry
+func (s *EventSerializer) Register(eventType string, version int, prototype event.DomainEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.registry[eventType] = registeredEvent{
+		version:     version,
+		payloadType: reflect.TypeOf(prototype),
+	}
+}
+
+// Serialize encodes a domain event as a JSON envelope
+func (s *EventSerializer) Serialize(evt event.DomainEvent) ([]byte, error) {
+	envelope, err := s.envelopeOf(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(envelope)
+}
+
+// envelopeOf wraps a domain event in an envelope carrying its current schema version
+func (s *EventSerializer) envelopeOf(evt event.DomainEvent) (dto.EventEnvelopeDTO, error) {
+	s.mu.RLock()
+	entry, exists := s.registry[evt.EventType()]
+	s.mu.RUnlock()
+
+	if !exists {
+		return dto.EventEnvelopeDTO{}, fmt.Errorf("unregistered event type: %s", evt.EventType())
+	}
+
+	return dto.EventEnvelopeDTO{
+		EventID:       evt.EventID(),
+		EventType:     evt.EventType(),
+		SchemaVersion: entry.version,
+		AggregateID:   evt.AggregateID(),
+		AggregateType: evt.AggregateType(),
+		OccurredAt:    evt.OccurredAt(),
+		Payload:       payloadOf(evt),
+	}, nil
+}
+
+// Payload returns an event's fields as they appear in the payload of its envelope
+func (s *EventSerializer) Payload(evt event.DomainEvent) map[string]interface{} {
+	return payloadOf(evt)
+}
+
+// Deserialize decodes a JSON envelope back into its registered domain event type
+func (s *EventSerializer) Deserialize(data []byte) (event.DomainEvent, error) {
+	var envelope rawEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid event envelope: %w", err)
+	}
+
+	return s.fromEnvelope(envelope)
+}
+
+// rawEnvelope is an envelope whose payload fields are still undecoded
+type rawEnvelope struct {
+	EventID       string                     `json:"event_id,omitempty"`
+	EventType     string                     `json:"event_type"`
+	SchemaVersion int                        `json:"schema_version"`
+	AggregateID   string                     `json:"aggregate_id"`
+	AggregateType string                     `json:"aggregate_type"`
+	OccurredAt    time.Time                  `json:"occurred_at"`
+	Payload       map[string]json.RawMessage `json:"payload"`
+}
+
+// fromEnvelope builds the registered event struct for an envelope
+func (s *EventSerializer) fromEnvelope(envelope rawEnvelope) (event.DomainEvent, error) {
+	s.mu.RLock()
+	entry, exists := s.registry[envelope.EventType]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unregistered event type: %s", envelope.EventType)
+	}
+
+	if envelope.SchemaVersion != entry.version {
+		return nil, fmt.Errorf(
+			"unsupported schema version %d for %s, current is %d",
+			envelope.SchemaVersion,
+			envelope.EventType,
+			entry.version,
+		)
+	}
+
+	v := reflect.New(entry.payloadType).Elem()
+	base := event.RestoreBaseDomainEvent(envelope.EventType, envelope.AggregateID, envelope.AggregateType, envelope.OccurredAt).
+		WithEventID(envelope.EventID)
+	for i := 0; i < v.NumField(); i++ {
+		if entry.payloadType.Field(i).Type == reflect.TypeOf(base) {
+			v.Field(i).Set(reflect.ValueOf(base))
+		}
+	}
+
+	for _, field := range payloadFields(entry.payloadType) {
+		raw, exists := envelope.Payload[snakeCase(field.Name)]
+		if !exists {
+			continue
+		}
+		if err := json.Unmarshal(raw, v.FieldByIndex(field.Index).Addr().Interface()); err != nil {
+			return nil, fmt.Errorf("invalid %s field %s: %w", envelope.EventType, snakeCase(field.Name), err)
+		}
+	}
+
+	evt, ok := v.Interface().(event.DomainEvent)
+	if !ok {
+		return nil, fmt.Errorf("registered type for %s is not a domain event", envelope.EventType)
+	}
+
+	return evt, nil
+}
+
+// EventTypes returns every registered event type, sorted
+func (s *EventSerializer) EventTypes() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	eventTypes := make([]string, 0, len(s.registry))
+	for eventType := range s.registry {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	return eventTypes
+}
+
+// Schemas returns the JSON Schema of every registered event type, sorted by type
+func (s *EventSerializer) Schemas() []dto.EventSchemaDTO {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	schemas := make([]dto.EventSchemaDTO, 0, len(s.registry))
+	for eventType, entry := range s.registry {
+		schemas = append(schemas, dto.EventSchemaDTO{
+			EventType: eventType,
+			Version:   entry.version,
+			Schema:    envelopeSchema(eventType, entry),
+		})
+	}
+
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].EventType < schemas[j].EventType
+	})
+
+	return schemas
+}
+
+// payloadOf collects the exported fields of an event under snake_case keys
+func payloadOf(evt event.DomainEvent) map[string]interface{} {
+	payload := make(map[string]interface{})
+
+	v := reflect.ValueOf(evt)
+	for _, field := range payloadFields(v.Type()) {
+		payload[snakeCase(field.Name)] = v.FieldByIndex(field.Index).Interface()
+	}
+
+	return payload
+}
+
+// payloadFields lists exported, non-embedded fields of an event struct
+func payloadFields(t reflect.Type) []reflect.StructField {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// envelopeSchema builds the JSON Schema for one event type
+func envelopeSchema(eventType string, entry registeredEvent) map[string]interface{} {
+	payloadProperties := make(map[string]interface{})
+	payloadRequired := make([]string, 0)
+	for _, field := range payloadFields(entry.payloadType) {
+		name := snakeCase(field.Name)
+		payloadProperties[name] = schemaForType(field.Type)
+		payloadRequired = append(payloadRequired, name)
+	}
+
+	return map[string]interface{}{
+		"$schema": jsonSchemaDialect,
+		"title":   eventType,
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"event_id":       map[string]interface{}{"type": "string"},
+			"event_type":     map[string]interface{}{"const": eventType},
+			"schema_version": map[string]interface{}{"const": entry.version},
+			"aggregate_id":   map[string]interface{}{"type": "string"},
+			"aggregate_type": map[string]interface{}{"type": "string"},
+			"occurred_at":    map[string]interface{}{"type": "string", "format": "date-time"},
+			"payload": map[string]interface{}{
+				"type":       "object",
+				"properties": payloadProperties,
+				"required":   payloadRequired,
+			},
+		},
+		"required": []string{"event_type", "schema_version", "aggregate_id", "aggregate_type", "occurred_at", "payload"},
+	}
+}
+
+// schemaForType maps a Go type to a JSON Schema fragment
+func schemaForType(t reflect.Type) map[string]interface{} {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaForType(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaForType(t.Elem())}
+	case reflect.Ptr:
+		return schemaForType(t.Elem())
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// snakeCase converts a Go field name such as PreviousAssigneeID to previous_assignee_id.
+// A plural acronym like TaskIDs becomes task_ids.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			pluralAcronym := nextLower && runes[i+1] == 's' && (i+2 == len(runes) || unicode.IsUpper(runes[i+2]))
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower && !pluralAcronym) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// Ensure EventSerializer implements query.EventSchemas and query.EventPayloads
+var (
+	_ query.EventSchemas  = (*EventSerializer)(nil)
+	_ query.EventPayloads = (*EventSerializer)(nil)
+)
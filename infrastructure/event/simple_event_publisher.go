@@ -1,16 +1,58 @@
 package event
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/miladev95/ddd-task/domain/event"
 )
 
-// SimpleEventPublisher is a basic in-memory event publisher implementation
+// AsyncOptions configures asynchronous dispatch
+type AsyncOptions struct {
+	// Workers is how many handlers run at the same time
+	Workers int
+	// QueueSize bounds how many deliveries wait for a worker; while it is full, Publish runs
+	// the handlers itself instead, so handlers publishing from a worker cannot deadlock the pool
+	QueueSize int
+	// MaxAttempts is how often a failing handler is tried before the delivery is given up
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every further one up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// OnFailure, when set, is told about deliveries given up after the last attempt
+	OnFailure func(evt event.DomainEvent, err error)
+}
+
+// DefaultAsyncOptions returns the options used unless configured otherwise
+func DefaultAsyncOptions() AsyncOptions {
+	return AsyncOptions{
+		Workers:        4,
+		QueueSize:      1024,
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	}
+}
+
+// delivery is one event on its way to one handler
+type delivery struct {
+	evt     event.DomainEvent
+	handler func(event.DomainEvent) error
+}
+
+// SimpleEventPublisher is a basic in-memory event publisher implementation.
+// Handlers run synchronously within Publish until StartAsync hands them to a worker pool.
 type SimpleEventPublisher struct {
 	subscribers map[string][]func(event.DomainEvent) error
 	mu          sync.RWMutex
+
+	async    *AsyncOptions
+	queue    chan delivery
+	workers  sync.WaitGroup
+	inFlight sync.WaitGroup
+	draining bool
 }
 
 // NewSimpleEventPublisher creates a new SimpleEventPublisher
@@ -20,16 +62,89 @@ func NewSimpleEventPublisher() *SimpleEventPublisher {
 	}
 }
 
+// StartAsync makes Publish queue deliveries for a pool of workers instead of running
+// handlers itself, so publishing no longer waits for them. Each handler receives the event
+// on its own and is retried with backoff when it fails. Handlers of different events may
+// then run concurrently and out of order. Call Drain before exiting.
+func (p *SimpleEventPublisher) StartAsync(options AsyncOptions) {
+	defaults := DefaultAsyncOptions()
+	if options.Workers <= 0 {
+		options.Workers = defaults.Workers
+	}
+	if options.QueueSize < 0 {
+		options.QueueSize = defaults.QueueSize
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = 1
+	}
+	if options.MaxBackoff < options.InitialBackoff {
+		options.MaxBackoff = options.InitialBackoff
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.async != nil {
+		return
+	}
+
+	p.async = &options
+	p.queue = make(chan delivery, options.QueueSize)
+	for i := 0; i < options.Workers; i++ {
+		p.workers.Add(1)
+		go p.work()
+	}
+}
+
+// Drain stops accepting deliveries for the worker pool and waits until every queued one
+// was handled or given up, or until the context is done. Events published afterwards are
+// handled synchronously again, so none are lost during shutdown.
+func (p *SimpleEventPublisher) Drain(ctx context.Context) error {
+	p.mu.Lock()
+	if p.async == nil || p.draining {
+		p.mu.Unlock()
+		return nil
+	}
+	p.draining = true
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.inFlight.Wait()
+		close(p.queue)
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("event dispatch did not drain: %w", ctx.Err())
+	}
+}
+
 // Publish publishes a domain event to all subscribers
-func (p *SimpleEventPublisher) Publish(evt event.DomainEvent) error {
+func (p *SimpleEventPublisher) Publish(ctx context.Context, evt event.DomainEvent) error {
 	p.mu.RLock()
 	subscribers, exists := p.subscribers[evt.EventType()]
+	async := p.async != nil && !p.draining
+	if async && exists {
+		p.inFlight.Add(len(subscribers))
+	}
 	p.mu.RUnlock()
 
 	if !exists {
 		return nil // No subscribers, but not an error
 	}
 
+	if async {
+		for _, handler := range subscribers {
+			p.enqueue(delivery{evt: evt, handler: handler})
+		}
+		return nil
+	}
+
 	var errs []error
 	for _, handler := range subscribers {
 		if err := handler(evt); err != nil {
@@ -45,9 +160,9 @@ func (p *SimpleEventPublisher) Publish(evt event.DomainEvent) error {
 }
 
 // PublishAll publishes multiple domain events
-func (p *SimpleEventPublisher) PublishAll(events []event.DomainEvent) error {
+func (p *SimpleEventPublisher) PublishAll(ctx context.Context, events []event.DomainEvent) error {
 	for _, evt := range events {
-		if err := p.Publish(evt); err != nil {
+		if err := p.Publish(ctx, evt); err != nil {
 			return err
 		}
 	}
@@ -79,5 +194,50 @@ func (p *SimpleEventPublisher) Unsubscribe(eventType string) error {
 	return nil
 }
 
+// enqueue queues a delivery for the worker pool, or runs it on the publishing goroutine when
+// the queue is full. Blocking instead would deadlock once every worker is publishing.
+func (p *SimpleEventPublisher) enqueue(d delivery) {
+	select {
+	case p.queue <- d:
+	default:
+		p.deliver(d)
+		p.inFlight.Done()
+	}
+}
+
+// work runs queued deliveries until the queue is closed
+func (p *SimpleEventPublisher) work() {
+	defer p.workers.Done()
+
+	for d := range p.queue {
+		p.deliver(d)
+		p.inFlight.Done()
+	}
+}
+
+// deliver runs one handler, retrying it with exponential backoff while it fails
+func (p *SimpleEventPublisher) deliver(d delivery) {
+	backoff := p.async.InitialBackoff
+
+	var err error
+	for attempt := 1; attempt <= p.async.MaxAttempts; attempt++ {
+		if err = d.handler(d.evt); err == nil {
+			return
+		}
+
+		if attempt < p.async.MaxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > p.async.MaxBackoff {
+				backoff = p.async.MaxBackoff
+			}
+		}
+	}
+
+	if p.async.OnFailure != nil {
+		p.async.OnFailure(d.evt, fmt.Errorf("failed to deliver %s after %d attempts: %w", d.evt.EventType(), p.async.MaxAttempts, err))
+	}
+}
+
 // Ensure SimpleEventPublisher implements event.EventPublisher
 var _ event.EventPublisher = (*SimpleEventPublisher)(nil)
\ No newline at end of file
@@ -63,5 +63,20 @@ func (s *SimpleNotificationService) NotifyTaskStatusChanged(
 	return nil
 }
 
+// SendVerificationEmail sends a user the token that verifies their email address
+func (s *SimpleNotificationService) SendVerificationEmail(user *aggregate.User, token string) error {
+	if user.Email() == "" {
+		return fmt.Errorf("user has no email address")
+	}
+
+	// In real implementation, send an email linking to the verification page
+	fmt.Printf("NOTIFICATION: Verify the email address %s with token %s\n",
+		user.Email(),
+		token,
+	)
+
+	return nil
+}
+
 // Ensure SimpleNotificationService implements service.NotificationService
 var _ service.NotificationService = (*SimpleNotificationService)(nil)
\ No newline at end of file
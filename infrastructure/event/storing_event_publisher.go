@@ -0,0 +1,93 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// StoringEventPublisher appends every event to an event store before
+// handing it to the wrapped publisher, so read models can be replayed later
+type StoringEventPublisher struct {
+	store     event.EventStore
+	publisher event.EventPublisher
+	metrics   *EventMetrics
+}
+
+// NewStoringEventPublisher creates a new StoringEventPublisher.
+// metrics may be nil when delivery is not measured.
+func NewStoringEventPublisher(store event.EventStore, publisher event.EventPublisher, metrics *EventMetrics) *StoringEventPublisher {
+	return &StoringEventPublisher{
+		store:     store,
+		publisher: publisher,
+		metrics:   metrics,
+	}
+}
+
+// Publish stores and then publishes a domain event
+func (p *StoringEventPublisher) Publish(ctx context.Context, evt event.DomainEvent) error {
+	if err := p.store.Store(ctx, evt); err != nil {
+		return fmt.Errorf("failed to store event: %w", err)
+	}
+
+	if p.metrics != nil {
+		p.metrics.RecordStored(evt)
+	}
+
+	if err := p.publisher.Publish(ctx, evt); err != nil {
+		return err
+	}
+
+	if p.metrics != nil {
+		p.metrics.RecordDelivered(evt)
+	}
+
+	return nil
+}
+
+// PublishAll stores multiple domain events in one batch and then publishes them,
+// so a command's events are either all stored or none are
+func (p *StoringEventPublisher) PublishAll(ctx context.Context, events []event.DomainEvent) error {
+	if err := p.AppendAll(ctx, events); err != nil {
+		return err
+	}
+	return p.DeliverAll(ctx, events)
+}
+
+// AppendAll stores multiple domain events in one batch without publishing them
+func (p *StoringEventPublisher) AppendAll(ctx context.Context, events []event.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := p.store.AppendBatch(ctx, events); err != nil {
+		return fmt.Errorf("failed to store events: %w", err)
+	}
+
+	for _, evt := range events {
+		if p.metrics != nil {
+			p.metrics.RecordStored(evt)
+		}
+	}
+
+	return nil
+}
+
+// DeliverAll publishes stored domain events, stopping at the first subscriber error
+func (p *StoringEventPublisher) DeliverAll(ctx context.Context, events []event.DomainEvent) error {
+	for _, evt := range events {
+		if err := p.publisher.Publish(ctx, evt); err != nil {
+			return err
+		}
+
+		if p.metrics != nil {
+			p.metrics.RecordDelivered(evt)
+		}
+	}
+
+	return nil
+}
+
+// Ensure StoringEventPublisher implements event.EventPublisher
+var _ event.EventPublisher = (*StoringEventPublisher)(nil)
@@ -0,0 +1,107 @@
+package idempotency
+
+import (
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// DefaultTTL is how long the result of a request is kept for replays under its idempotency key
+const DefaultTTL = 24 * time.Hour
+
+// entry is a request made under an idempotency key, with its result once it completed
+type entry struct {
+	fingerprint string
+	response    []byte // nil while the request is still running
+	createdAt   time.Time
+}
+
+// Store keeps the results of requests by idempotency key in memory, so a retried request
+// can be answered with the original result instead of running again. Keys are scoped,
+// for example to the caller, so different callers may use the same key.
+type Store struct {
+	ttl     time.Duration
+	entries map[string]*entry // scope + key -> entry
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+// NewStore creates a new Store
+func NewStore(ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Store{
+		ttl:     ttl,
+		entries: make(map[string]*entry),
+		now:     time.Now,
+	}
+}
+
+// SetClock replaces the store's clock, for tests
+func (s *Store) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = now
+}
+
+// Begin claims a key for a request with the given fingerprint. It returns the stored result
+// when the same request already completed under the key, and nil when the request should run
+// now and be completed or abandoned afterwards. A key still held by a running request, or
+// used before for a different request, is refused.
+func (s *Store) Begin(scope, key, fingerprint string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire()
+
+	id := scope + "\x00" + key
+	existing, exists := s.entries[id]
+	if !exists {
+		s.entries[id] = &entry{fingerprint: fingerprint, createdAt: s.now()}
+		return nil, nil
+	}
+
+	if existing.fingerprint != fingerprint {
+		return nil, errs.Invalid("invalid idempotency key: it was already used for a different request")
+	}
+	if existing.response == nil {
+		return nil, errs.Conflict("idempotency key is in use by a request that has not finished yet")
+	}
+
+	return existing.response, nil
+}
+
+// Complete stores the result of the request holding a key
+func (s *Store) Complete(scope, key string, response []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.entries[scope+"\x00"+key]; exists {
+		existing.response = response
+	}
+}
+
+// Abandon releases a key whose request failed in a way worth retrying, so it can run again
+func (s *Store) Abandon(scope, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := scope + "\x00" + key
+	if existing, exists := s.entries[id]; exists && existing.response == nil {
+		delete(s.entries, id)
+	}
+}
+
+// expire forgets the keys older than the store's TTL, the caller holds the lock
+func (s *Store) expire() {
+	cutoff := s.now().Add(-s.ttl)
+	for id, existing := range s.entries {
+		if existing.createdAt.Before(cutoff) {
+			delete(s.entries, id)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package integration
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// CircuitState is whether calls to an integration go through
+type CircuitState string
+
+const (
+	// CircuitClosed lets every call through
+	CircuitClosed CircuitState = "CLOSED"
+	// CircuitOpen refuses calls until the cooldown has passed
+	CircuitOpen CircuitState = "OPEN"
+	// CircuitHalfOpen lets one trial call through after the cooldown
+	CircuitHalfOpen CircuitState = "HALF_OPEN"
+)
+
+// Integration kinds reported on the health dashboard
+const (
+	KindWebhook       = "WEBHOOK"
+	KindEmail         = "EMAIL"
+	KindChat          = "CHAT"
+	KindMessageBroker = "MESSAGE_BROKER"
+)
+
+// Defaults for NewMonitor
+const (
+	DefaultFailureThreshold = 5
+	DefaultCooldown         = 30 * time.Second
+	DefaultFailureWindow    = time.Hour
+)
+
+// Breaker guards the calls to one integration. After a number of consecutive failures it
+// opens and refuses calls for a cooldown, then lets one trial call through: success closes
+// it again, failure reopens it.
+type Breaker struct {
+	name    string
+	kind    string
+	monitor *Monitor
+
+	state               CircuitState
+	consecutiveFailures int
+	failures            []time.Time
+	lastSuccessAt       *time.Time
+	lastFailureAt       *time.Time
+	lastError           string
+	openedAt            time.Time
+	calls               int64
+	rejected            int64
+	mu                  sync.Mutex
+}
+
+// Monitor keeps a breaker per integration and reports their health
+type Monitor struct {
+	failureThreshold int
+	cooldown         time.Duration
+	window           time.Duration
+	now              func() time.Time
+	breakers         map[string]*Breaker
+	mu               sync.Mutex
+}
+
+// NewMonitor creates a Monitor opening breakers after failureThreshold consecutive failures
+// for cooldown, and counting the failures of the last window as recent
+func NewMonitor(failureThreshold int, cooldown, window time.Duration) *Monitor {
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultFailureThreshold
+	}
+
+	return &Monitor{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		window:           window,
+		now:              time.Now,
+		breakers:         make(map[string]*Breaker),
+	}
+}
+
+// SetClock replaces the clock, for tests
+func (m *Monitor) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = now
+}
+
+// Breaker returns the breaker of an integration, registering it on first use
+func (m *Monitor) Breaker(name, kind string) *Breaker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if breaker, exists := m.breakers[name]; exists {
+		return breaker
+	}
+
+	breaker := &Breaker{name: name, kind: kind, monitor: m, state: CircuitClosed}
+	m.breakers[name] = breaker
+	return breaker
+}
+
+// Report returns the health of every registered integration ordered by name
+func (m *Monitor) Report() dto.IntegrationHealthReportDTO {
+	m.mu.Lock()
+	breakers := make([]*Breaker, 0, len(m.breakers))
+	for _, breaker := range m.breakers {
+		breakers = append(breakers, breaker)
+	}
+	m.mu.Unlock()
+
+	now := m.clock()
+	report := dto.IntegrationHealthReportDTO{
+		CheckedAt:     now,
+		WindowSeconds: int64(m.window / time.Second),
+		Integrations:  make([]dto.IntegrationHealthDTO, 0, len(breakers)),
+	}
+	for _, breaker := range breakers {
+		report.Integrations = append(report.Integrations, breaker.Health(now))
+	}
+
+	sort.Slice(report.Integrations, func(i, j int) bool {
+		return report.Integrations[i].Name < report.Integrations[j].Name
+	})
+	return report
+}
+
+// clock reads the monitor's current time
+func (m *Monitor) clock() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now()
+}
+
+// Call runs fn unless the breaker is open, recording its outcome
+func (b *Breaker) Call(fn func() error) error {
+	now := b.monitor.clock()
+
+	b.mu.Lock()
+	b.calls++
+	if b.state == CircuitOpen {
+		if now.Before(b.openedAt.Add(b.monitor.cooldown)) {
+			b.rejected++
+			b.mu.Unlock()
+			return fmt.Errorf("%s is unavailable: circuit open until %s", b.name, b.openedAt.Add(b.monitor.cooldown).Format(time.RFC3339))
+		}
+		b.state = CircuitHalfOpen
+	}
+	b.mu.Unlock()
+
+	err := fn()
+	b.record(now, err)
+	return err
+}
+
+// Guard wraps an event handler so it runs through the breaker
+func (b *Breaker) Guard(handler func(event.DomainEvent) error) func(event.DomainEvent) error {
+	return func(evt event.DomainEvent) error {
+		return b.Call(func() error { return handler(evt) })
+	}
+}
+
+// Health reports the breaker's state as of now
+func (b *Breaker) Health(now time.Time) dto.IntegrationHealthDTO {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trimFailures(now)
+	health := dto.IntegrationHealthDTO{
+		Name:                b.name,
+		Kind:                b.kind,
+		State:               string(b.state),
+		Healthy:             b.state == CircuitClosed && b.consecutiveFailures == 0,
+		LastSuccessAt:       b.lastSuccessAt,
+		LastFailureAt:       b.lastFailureAt,
+		LastError:           b.lastError,
+		ConsecutiveFailures: b.consecutiveFailures,
+		RecentFailures:      len(b.failures),
+		Calls:               b.calls,
+		Rejected:            b.rejected,
+	}
+
+	if b.state == CircuitOpen {
+		retryAt := b.openedAt.Add(b.monitor.cooldown)
+		health.NextRetryAt = &retryAt
+	}
+
+	return health
+}
+
+// record updates the breaker after a call
+func (b *Breaker) record(at time.Time, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state = CircuitClosed
+		b.consecutiveFailures = 0
+		b.lastSuccessAt = &at
+		return
+	}
+
+	b.consecutiveFailures++
+	b.failures = append(b.failures, at)
+	b.trimFailures(at)
+	b.lastFailureAt = &at
+	b.lastError = err.Error()
+
+	if b.state == CircuitHalfOpen || b.consecutiveFailures >= b.monitor.failureThreshold {
+		b.state = CircuitOpen
+		b.openedAt = at
+	}
+}
+
+// trimFailures forgets failures older than the window, callers hold the lock
+func (b *Breaker) trimFailures(now time.Time) {
+	cutoff := now.Add(-b.monitor.window)
+	kept := b.failures[:0]
+	for _, at := range b.failures {
+		if at.After(cutoff) {
+			kept = append(kept, at)
+		}
+	}
+	b.failures = kept
+}
@@ -0,0 +1,89 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AMQPChannel is the part of an *amqp.Channel the publisher and consumer use
+type AMQPChannel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Confirm(noWait bool) error
+	NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation
+	PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+}
+
+// AMQPTopology names the exchanges and queues events travel through. Events are published
+// to a topic exchange; messages a consumer rejects go to the dead-letter exchange, which
+// files them in the dead-letter queue for inspection and replay.
+type AMQPTopology struct {
+	Exchange           string
+	DeadLetterExchange string
+	DeadLetterQueue    string
+}
+
+// DefaultAMQPTopology returns the topology used unless configured otherwise
+func DefaultAMQPTopology() AMQPTopology {
+	return AMQPTopology{
+		Exchange:           "task.events",
+		DeadLetterExchange: "task.events.dlx",
+		DeadLetterQueue:    "task.events.dead",
+	}
+}
+
+// AMQPRoute is where one event type is published
+type AMQPRoute struct {
+	Exchange   string
+	RoutingKey string
+}
+
+// DefaultRoutingKey is the routing key of an event type unless overridden, the aggregate
+// type and event type joined by a dot such as "task.TaskCompleted", so consumers can bind
+// to "task.*" for everything about tasks or "*.TaskCompleted" for one event
+func DefaultRoutingKey(aggregateType, eventType string) string {
+	return strings.ToLower(aggregateType) + "." + eventType
+}
+
+// Declare creates the exchanges and the dead-letter queue when they do not exist yet
+func (t AMQPTopology) Declare(channel AMQPChannel) error {
+	if err := channel.ExchangeDeclare(t.Exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", t.Exchange, err)
+	}
+
+	if err := channel.ExchangeDeclare(t.DeadLetterExchange, amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange %s: %w", t.DeadLetterExchange, err)
+	}
+
+	if _, err := channel.QueueDeclare(t.DeadLetterQueue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue %s: %w", t.DeadLetterQueue, err)
+	}
+
+	if err := channel.QueueBind(t.DeadLetterQueue, "", t.DeadLetterExchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue %s: %w", t.DeadLetterQueue, err)
+	}
+
+	return nil
+}
+
+// DeclareQueue creates a consumer queue bound to the event exchange by the given routing
+// key patterns, dead-lettering every message its consumer rejects
+func (t AMQPTopology) DeclareQueue(channel AMQPChannel, queue string, bindingKeys []string) error {
+	args := amqp.Table{"x-dead-letter-exchange": t.DeadLetterExchange}
+	if _, err := channel.QueueDeclare(queue, true, false, false, false, args); err != nil {
+		return fmt.Errorf("failed to declare queue %s: %w", queue, err)
+	}
+
+	for _, key := range bindingKeys {
+		if err := channel.QueueBind(queue, key, t.Exchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind queue %s to %s: %w", queue, key, err)
+		}
+	}
+
+	return nil
+}
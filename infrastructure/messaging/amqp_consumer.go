@@ -0,0 +1,71 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+)
+
+// AMQPConsumer hands the events of a queue to a handler. Events the handler processes are
+// acknowledged; events it fails on, or that cannot be decoded, are rejected without requeueing,
+// so a queue declared with AMQPTopology.DeclareQueue dead-letters them.
+type AMQPConsumer struct {
+	channel    AMQPChannel
+	serializer *infraEvent.EventSerializer
+}
+
+// NewAMQPConsumer creates a new AMQPConsumer
+func NewAMQPConsumer(channel AMQPChannel, serializer *infraEvent.EventSerializer) *AMQPConsumer {
+	return &AMQPConsumer{
+		channel:    channel,
+		serializer: serializer,
+	}
+}
+
+// Consume processes the queue's messages one at a time until the context is done
+// or the channel closes
+func (c *AMQPConsumer) Consume(ctx context.Context, queue, name string, handler func(event.DomainEvent) error) error {
+	deliveries, err := c.channel.Consume(queue, name, false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume %s: %w", queue, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("consumer %s stopped: channel closed", name)
+			}
+			if err := c.handle(delivery, handler); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle acknowledges a processed delivery and dead-letters a failed one
+func (c *AMQPConsumer) handle(delivery amqp.Delivery, handler func(event.DomainEvent) error) error {
+	evt, err := c.serializer.Deserialize(delivery.Body)
+	if err == nil {
+		err = handler(evt)
+	}
+
+	if err != nil {
+		if nackErr := delivery.Nack(false, false); nackErr != nil {
+			return fmt.Errorf("failed to reject message: %w", nackErr)
+		}
+		return nil
+	}
+
+	if ackErr := delivery.Ack(false); ackErr != nil {
+		return fmt.Errorf("failed to acknowledge message: %w", ackErr)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+)
+
+// DefaultConfirmTimeout is how long the publisher waits for the broker to confirm a message
+const DefaultConfirmTimeout = 5 * time.Second
+
+// AMQPPublisher publishes domain events to a RabbitMQ exchange as persistent JSON envelopes.
+// The channel is in confirm mode, so an event only counts as published once the broker
+// has taken responsibility for it.
+type AMQPPublisher struct {
+	channel        AMQPChannel
+	serializer     *infraEvent.EventSerializer
+	topology       AMQPTopology
+	routes         map[string]AMQPRoute
+	confirms       chan amqp.Confirmation
+	confirmTimeout time.Duration
+	mu             sync.Mutex
+}
+
+// NewAMQPPublisher creates a new AMQPPublisher, putting the channel into confirm mode.
+// Declare the topology before publishing.
+func NewAMQPPublisher(channel AMQPChannel, serializer *infraEvent.EventSerializer, topology AMQPTopology) (*AMQPPublisher, error) {
+	if err := channel.Confirm(false); err != nil {
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	return &AMQPPublisher{
+		channel:        channel,
+		serializer:     serializer,
+		topology:       topology,
+		routes:         make(map[string]AMQPRoute),
+		confirms:       channel.NotifyPublish(make(chan amqp.Confirmation, 1)),
+		confirmTimeout: DefaultConfirmTimeout,
+	}, nil
+}
+
+// SetRoute publishes an event type to another exchange or under another routing key.
+// An empty exchange keeps the topology's.
+func (p *AMQPPublisher) SetRoute(eventType string, route AMQPRoute) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.routes[eventType] = route
+}
+
+// SetConfirmTimeout sets how long to wait for each broker confirmation
+func (p *AMQPPublisher) SetConfirmTimeout(timeout time.Duration) {
+	p.confirmTimeout = timeout
+}
+
+// RouteOf returns where an event is published
+func (p *AMQPPublisher) RouteOf(evt event.DomainEvent) AMQPRoute {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.routeOf(evt)
+}
+
+// Publish publishes one event and waits for the broker to confirm it
+func (p *AMQPPublisher) Publish(ctx context.Context, evt event.DomainEvent) error {
+	return p.PublishAll(ctx, []event.DomainEvent{evt})
+}
+
+// PublishAll publishes events in order and waits until the broker confirmed every one.
+// A rejected or unconfirmed event fails the call; events before it may have been delivered.
+func (p *AMQPPublisher) PublishAll(ctx context.Context, events []event.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, evt := range events {
+		body, err := p.serializer.Serialize(evt)
+		if err != nil {
+			return err
+		}
+
+		route := p.routeOf(evt)
+		ctx, cancel := context.WithTimeout(context.Background(), p.confirmTimeout)
+		err = p.channel.PublishWithContext(ctx, route.Exchange, route.RoutingKey, false, false, amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Type:         evt.EventType(),
+			Timestamp:    evt.OccurredAt(),
+			Body:         body,
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to publish %s: %w", evt.EventType(), err)
+		}
+
+		if err := p.awaitConfirm(evt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Forward subscribes the publisher to every given event type, relaying each event
+// delivered in-process to the broker
+func (p *AMQPPublisher) Forward(subscriber event.EventSubscriber, eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		relay := func(evt event.DomainEvent) error { return p.Publish(context.Background(), evt) }
+		if err := subscriber.Subscribe(eventType, relay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// routeOf returns where an event is published, callers hold the lock
+func (p *AMQPPublisher) routeOf(evt event.DomainEvent) AMQPRoute {
+	route := AMQPRoute{
+		Exchange:   p.topology.Exchange,
+		RoutingKey: DefaultRoutingKey(evt.AggregateType(), evt.EventType()),
+	}
+
+	if override, ok := p.routes[evt.EventType()]; ok {
+		if override.Exchange != "" {
+			route.Exchange = override.Exchange
+		}
+		if override.RoutingKey != "" {
+			route.RoutingKey = override.RoutingKey
+		}
+	}
+
+	return route
+}
+
+// awaitConfirm waits for the broker's confirmation of the last published message
+func (p *AMQPPublisher) awaitConfirm(evt event.DomainEvent) error {
+	select {
+	case confirmation, ok := <-p.confirms:
+		if !ok {
+			return fmt.Errorf("failed to publish %s: channel closed before the broker confirmed it", evt.EventType())
+		}
+		if !confirmation.Ack {
+			return fmt.Errorf("failed to publish %s: broker rejected it", evt.EventType())
+		}
+		return nil
+
+	case <-time.After(p.confirmTimeout):
+		return fmt.Errorf("failed to publish %s: broker did not confirm it within %s", evt.EventType(), p.confirmTimeout)
+	}
+}
+
+// Ensure AMQPPublisher implements event.EventPublisher
+var _ event.EventPublisher = (*AMQPPublisher)(nil)
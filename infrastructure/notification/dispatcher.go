@@ -0,0 +1,310 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/integration"
+)
+
+// digestKey groups digest entries that are sent together
+type digestKey struct {
+	projectID string
+	channel   value.NotificationChannel
+	target    string
+	delivery  value.NotificationDelivery
+}
+
+// digest collects lines until its period has elapsed
+type digest struct {
+	projectName string
+	since       time.Time
+	lines       []string
+}
+
+// Dispatcher routes domain events to channels following each project's routing rules.
+// Immediate routes are sent as events arrive; digest routes are collected and sent by FlushDue.
+type Dispatcher struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	sprintRepository  domain.SprintRepository
+	senders           map[value.NotificationChannel]Sender
+	breakers          map[value.NotificationChannel]*integration.Breaker
+	digests           map[digestKey]*digest
+	mu                sync.Mutex
+}
+
+// NewDispatcher creates a Dispatcher that logs on every channel until real senders are set
+func NewDispatcher(
+	projectRepository domain.ProjectRepository,
+	taskRepository domain.TaskRepository,
+	sprintRepository domain.SprintRepository,
+) *Dispatcher {
+	logSender := NewLogSender()
+
+	return &Dispatcher{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		sprintRepository:  sprintRepository,
+		senders: map[value.NotificationChannel]Sender{
+			value.NotificationChannelSlack:   logSender,
+			value.NotificationChannelEmail:   logSender,
+			value.NotificationChannelWebhook: logSender,
+		},
+		breakers: make(map[value.NotificationChannel]*integration.Breaker),
+		digests:  make(map[digestKey]*digest),
+	}
+}
+
+// channelKinds is how each channel is reported on the integration health dashboard
+var channelKinds = map[value.NotificationChannel]string{
+	value.NotificationChannelSlack:   integration.KindChat,
+	value.NotificationChannelEmail:   integration.KindEmail,
+	value.NotificationChannelWebhook: integration.KindWebhook,
+}
+
+// TrackHealth sends every channel through a breaker of the monitor, so a failing channel
+// shows on the health dashboard and is given a rest instead of being called on every event
+func (d *Dispatcher) TrackHealth(monitor *integration.Monitor) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for channel := range d.senders {
+		d.breakers[channel] = monitor.Breaker("notifications."+strings.ToLower(channel.Value()), channelKinds[channel])
+	}
+}
+
+// SetSender replaces the sender of a channel
+func (d *Dispatcher) SetSender(channel value.NotificationChannel, sender Sender) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.senders[channel] = sender
+}
+
+// Register subscribes the dispatcher to the given event types
+func (d *Dispatcher) Register(subscriber event.EventSubscriber, eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if err := subscriber.Subscribe(eventType, d.Handle); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// Handle routes one event. Events that cannot be traced to a project are ignored.
+func (d *Dispatcher) Handle(evt event.DomainEvent) error {
+	project := d.projectOf(context.Background(), evt)
+	if project == nil {
+		return nil
+	}
+
+	// Workflow transitions name their own recipient, on top of the project's routes
+	if requested, ok := evt.(event.TaskNotificationRequestedEvent); ok {
+		err := d.send(Notification{
+			ProjectID:  project.ID().Value(),
+			Channel:    value.NotificationChannel(requested.Channel),
+			Target:     requested.Target,
+			Subject:    fmt.Sprintf("[%s] %s", project.Name(), requested.Message),
+			Lines:      []string{requested.Message},
+			OccurredAt: evt.OccurredAt(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	routes := project.RoutesFor(evt.EventType())
+	if len(routes) == 0 {
+		return nil
+	}
+
+	line := describe(evt)
+	for _, route := range routes {
+		if route.Delivery() == value.NotificationDeliveryImmediate {
+			err := d.send(Notification{
+				ProjectID:  project.ID().Value(),
+				Channel:    route.Channel(),
+				Target:     route.Target(),
+				Subject:    fmt.Sprintf("[%s] %s", project.Name(), evt.EventType()),
+				Lines:      []string{line},
+				OccurredAt: evt.OccurredAt(),
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		d.enqueue(project, route, line, evt.OccurredAt())
+	}
+
+	return nil
+}
+
+// FlushDue sends every digest whose period has elapsed
+func (d *Dispatcher) FlushDue(now time.Time) error {
+	d.mu.Lock()
+	due := make(map[digestKey]*digest)
+	for key, pending := range d.digests {
+		if now.Sub(pending.since) >= key.delivery.DigestPeriod() {
+			due[key] = pending
+			delete(d.digests, key)
+		}
+	}
+	d.mu.Unlock()
+
+	keys := make([]digestKey, 0, len(due))
+	for key := range due {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return due[keys[i]].since.Before(due[keys[j]].since)
+	})
+
+	for _, key := range keys {
+		pending := due[key]
+		err := d.send(Notification{
+			ProjectID:  key.projectID,
+			Channel:    key.channel,
+			Target:     key.target,
+			Subject:    fmt.Sprintf("[%s] %d updates", pending.projectName, len(pending.lines)),
+			Lines:      pending.lines,
+			OccurredAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PendingDigestLines returns how many lines wait in digests for a project
+func (d *Dispatcher) PendingDigestLines(projectID string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	count := 0
+	for key, pending := range d.digests {
+		if key.projectID == projectID {
+			count += len(pending.lines)
+		}
+	}
+	return count
+}
+
+// enqueue adds a line to the digest of a route
+func (d *Dispatcher) enqueue(project *aggregate.Project, route value.NotificationRoute, line string, at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := digestKey{
+		projectID: project.ID().Value(),
+		channel:   route.Channel(),
+		target:    route.Target(),
+		delivery:  route.Delivery(),
+	}
+
+	pending, exists := d.digests[key]
+	if !exists {
+		pending = &digest{since: at}
+		d.digests[key] = pending
+	}
+	pending.projectName = project.Name()
+	pending.lines = append(pending.lines, line)
+}
+
+// send delivers a notification through the sender of its channel
+func (d *Dispatcher) send(notification Notification) error {
+	d.mu.Lock()
+	sender, exists := d.senders[notification.Channel]
+	breaker := d.breakers[notification.Channel]
+	d.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("no sender for channel %s", notification.Channel.Value())
+	}
+
+	send := func() error { return sender.Send(notification) }
+	if breaker != nil {
+		send = func() error { return breaker.Call(func() error { return sender.Send(notification) }) }
+	}
+
+	if err := send(); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", notification.Channel.Value(), err)
+	}
+
+	return nil
+}
+
+// projectOf finds the project an event belongs to
+func (d *Dispatcher) projectOf(ctx context.Context, evt event.DomainEvent) *aggregate.Project {
+	rawProjectID := ""
+
+	switch e := evt.(type) {
+	case event.TaskCreatedEvent:
+		rawProjectID = e.ProjectID
+	case event.TaskDeletedEvent:
+		rawProjectID = e.ProjectID
+	case event.TaskNotificationRequestedEvent:
+		rawProjectID = e.ProjectID
+	case event.SprintCreatedEvent:
+		rawProjectID = e.ProjectID
+	default:
+		switch evt.AggregateType() {
+		case "Project":
+			rawProjectID = evt.AggregateID()
+		case "Task":
+			taskID, err := value.NewTaskID(evt.AggregateID())
+			if err != nil {
+				return nil
+			}
+			task, err := d.taskRepository.GetByID(ctx, taskID)
+			if err != nil {
+				return nil
+			}
+			rawProjectID = task.ProjectID().Value()
+		case "Sprint":
+			sprintID, err := value.NewSprintID(evt.AggregateID())
+			if err != nil {
+				return nil
+			}
+			sprint, err := d.sprintRepository.GetByID(ctx, sprintID)
+			if err != nil {
+				return nil
+			}
+			rawProjectID = sprint.ProjectID().Value()
+		}
+	}
+
+	projectID, err := value.NewProjectID(rawProjectID)
+	if err != nil {
+		return nil
+	}
+
+	project, err := d.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return nil
+	}
+
+	return project
+}
+
+// describe renders an event as one human readable line
+func describe(evt event.DomainEvent) string {
+	return fmt.Sprintf("%s %s on %s %s",
+		evt.OccurredAt().Format(time.RFC3339),
+		evt.EventType(),
+		evt.AggregateType(),
+		evt.AggregateID(),
+	)
+}
@@ -0,0 +1,45 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// Notification is a message bound for one channel target
+type Notification struct {
+	ProjectID  string
+	Channel    value.NotificationChannel
+	Target     string
+	Subject    string
+	Lines      []string
+	OccurredAt time.Time
+}
+
+// Sender delivers notifications over one channel
+type Sender interface {
+	Send(notification Notification) error
+}
+
+// LogSender prints notifications instead of delivering them
+type LogSender struct{}
+
+// NewLogSender creates a new LogSender
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+// Send prints the notification
+func (s *LogSender) Send(notification Notification) error {
+	// In real implementation, post to Slack, send an email or call the webhook
+	fmt.Printf("NOTIFICATION [%s %s]: %s\n  %s\n",
+		notification.Channel.Value(),
+		notification.Target,
+		notification.Subject,
+		strings.Join(notification.Lines, "\n  "),
+	)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package quota
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// callWindow counts an organization's API calls in the current minute
+type callWindow struct {
+	start time.Time
+	count int
+}
+
+// APICallLimiter enforces the API calls per minute quota of organizations, in fixed one-minute windows.
+// Calls by users outside any organization are not limited.
+type APICallLimiter struct {
+	organizations domain.OrganizationRepository
+	windows       map[string]*callWindow // organization ID -> current window
+	now           func() time.Time
+	mu            sync.Mutex
+}
+
+// NewAPICallLimiter creates a new APICallLimiter
+func NewAPICallLimiter(organizations domain.OrganizationRepository) *APICallLimiter {
+	return &APICallLimiter{
+		organizations: organizations,
+		windows:       make(map[string]*callWindow),
+		now:           time.Now,
+	}
+}
+
+// SetClock replaces the limiter's clock, for tests
+func (l *APICallLimiter) SetClock(now func() time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.now = now
+}
+
+// Allow records an API call by a user. When their organization is over its limit
+// the call is refused with how long to wait until the next window.
+func (l *APICallLimiter) Allow(ctx context.Context, userID string) (time.Duration, error) {
+	id, err := value.NewUserID(userID)
+	if err != nil {
+		return 0, nil
+	}
+
+	organization, err := l.organizations.GetByMemberID(ctx, id)
+	if err != nil {
+		return 0, nil
+	}
+
+	limit := organization.Quota().APICallsPerMinute()
+	if limit == 0 {
+		return 0, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	window := l.currentWindow(organization.ID().Value())
+	if !organization.Quota().AllowsAPICalls(window.count + 1) {
+		retryAfter := window.start.Add(time.Minute).Sub(l.now())
+		return retryAfter, errs.QuotaExceeded("quota exceeded: organization %s is limited to %d API calls per minute", organization.Name(), limit)
+	}
+
+	window.count++
+	return 0, nil
+}
+
+// CallsThisMinute returns how many API calls an organization has made in the current window
+func (l *APICallLimiter) CallsThisMinute(organizationID value.OrganizationID) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.currentWindow(organizationID.Value()).count
+}
+
+// currentWindow returns the organization's window, starting a new one once a minute has passed
+func (l *APICallLimiter) currentWindow(organizationID string) *callWindow {
+	now := l.now()
+	window, exists := l.windows[organizationID]
+	if !exists || now.Sub(window.start) >= time.Minute {
+		window = &callWindow{start: now.Truncate(time.Minute)}
+		l.windows[organizationID] = window
+	}
+	return window
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// InMemoryHolidayCalendarRepository is an in-memory implementation of HolidayCalendarRepository
+type InMemoryHolidayCalendarRepository struct {
+	calendars map[string]*aggregate.HolidayCalendar
+	mu        sync.RWMutex
+}
+
+// NewInMemoryHolidayCalendarRepository creates a new InMemoryHolidayCalendarRepository
+func NewInMemoryHolidayCalendarRepository() *InMemoryHolidayCalendarRepository {
+	return &InMemoryHolidayCalendarRepository{
+		calendars: make(map[string]*aggregate.HolidayCalendar),
+	}
+}
+
+// Save persists a holiday calendar to the repository. Each region of an organization has
+// one calendar.
+func (r *InMemoryHolidayCalendarRepository) Save(ctx context.Context, calendar *aggregate.HolidayCalendar) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if calendar == nil {
+		return fmt.Errorf("holiday calendar cannot be nil")
+	}
+
+	for _, existing := range r.calendars {
+		if existing.OrganizationID().Equals(calendar.OrganizationID()) && existing.Region() == calendar.Region() &&
+			!existing.ID().Equals(calendar.ID()) {
+			return errs.Conflict("holiday calendar for region %s already exists", calendar.Region())
+		}
+	}
+
+	r.calendars[calendar.ID().Value()] = calendar
+	return nil
+}
+
+// GetByID retrieves a holiday calendar by ID
+func (r *InMemoryHolidayCalendarRepository) GetByID(ctx context.Context, id value.HolidayCalendarID) (*aggregate.HolidayCalendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	calendar, exists := r.calendars[id.Value()]
+	if !exists {
+		return nil, errs.NotFound("holiday calendar not found")
+	}
+
+	return calendar, nil
+}
+
+// GetByOrganizationID retrieves all holiday calendars of an organization, ordered by region
+func (r *InMemoryHolidayCalendarRepository) GetByOrganizationID(ctx context.Context, organizationID value.OrganizationID) ([]*aggregate.HolidayCalendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	calendars := make([]*aggregate.HolidayCalendar, 0)
+	for _, calendar := range r.calendars {
+		if calendar.OrganizationID().Equals(organizationID) {
+			calendars = append(calendars, calendar)
+		}
+	}
+
+	sort.Slice(calendars, func(i, j int) bool {
+		return calendars[i].Region() < calendars[j].Region()
+	})
+
+	return calendars, nil
+}
+
+// Delete removes a holiday calendar from the repository
+func (r *InMemoryHolidayCalendarRepository) Delete(ctx context.Context, id value.HolidayCalendarID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.calendars[id.Value()]; !exists {
+		return errs.NotFound("holiday calendar not found")
+	}
+
+	delete(r.calendars, id.Value())
+	return nil
+}
+
+// Update updates an existing holiday calendar
+func (r *InMemoryHolidayCalendarRepository) Update(ctx context.Context, calendar *aggregate.HolidayCalendar) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if calendar == nil {
+		return fmt.Errorf("holiday calendar cannot be nil")
+	}
+
+	if _, exists := r.calendars[calendar.ID().Value()]; !exists {
+		return errs.NotFound("holiday calendar not found")
+	}
+
+	r.calendars[calendar.ID().Value()] = calendar
+	return nil
+}
+
+// List retrieves a page of the holiday calendars matching a filter
+func (r *InMemoryHolidayCalendarRepository) List(
+	ctx context.Context,
+	filter domain.HolidayCalendarFilter,
+	page domain.Page,
+) ([]*aggregate.HolidayCalendar, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.HolidayCalendarSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	r.mu.RLock()
+	calendars := make([]*aggregate.HolidayCalendar, 0)
+	for _, calendar := range r.calendars {
+		if filter.OrganizationID == "" || calendar.OrganizationID().Value() == filter.OrganizationID {
+			calendars = append(calendars, calendar)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(calendars, func(i, j int) bool {
+		a, b := calendars[i], calendars[j]
+		var compared int
+		switch page.SortBy {
+		case "created_at":
+			compared = a.CreatedAt().Compare(b.CreatedAt())
+		case "name":
+			compared = strings.Compare(a.Name(), b.Name())
+		default:
+			compared = strings.Compare(a.Region(), b.Region())
+		}
+		return page.Before(compared, a.ID().Value(), b.ID().Value())
+	})
+
+	start, end := page.Window(len(calendars))
+	return calendars[start:end], page.Result(len(calendars)), nil
+}
+
+// Ensure InMemoryHolidayCalendarRepository implements domain.HolidayCalendarRepository
+var _ domain.HolidayCalendarRepository = (*InMemoryHolidayCalendarRepository)(nil)
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// InMemoryOrganizationRepository is an in-memory implementation of OrganizationRepository
+type InMemoryOrganizationRepository struct {
+	organizations map[string]*aggregate.Organization
+	mu            sync.RWMutex
+}
+
+// NewInMemoryOrganizationRepository creates a new InMemoryOrganizationRepository
+func NewInMemoryOrganizationRepository() *InMemoryOrganizationRepository {
+	return &InMemoryOrganizationRepository{
+		organizations: make(map[string]*aggregate.Organization),
+	}
+}
+
+// Save persists an organization to the repository
+func (r *InMemoryOrganizationRepository) Save(ctx context.Context, organization *aggregate.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if organization == nil {
+		return fmt.Errorf("organization cannot be nil")
+	}
+
+	r.organizations[organization.ID().Value()] = organization
+	return nil
+}
+
+// GetByID retrieves an organization by ID
+func (r *InMemoryOrganizationRepository) GetByID(ctx context.Context, id value.OrganizationID) (*aggregate.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	organization, exists := r.organizations[id.Value()]
+	if !exists {
+		return nil, errs.NotFound("organization not found")
+	}
+
+	return organization, nil
+}
+
+// GetByMemberID retrieves the organization a user belongs to
+func (r *InMemoryOrganizationRepository) GetByMemberID(ctx context.Context, userID value.UserID) (*aggregate.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, organization := range r.organizations {
+		if organization.HasMember(userID) {
+			return organization, nil
+		}
+	}
+
+	return nil, errs.NotFound("organization not found")
+}
+
+// Update updates an existing organization
+func (r *InMemoryOrganizationRepository) Update(ctx context.Context, organization *aggregate.Organization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if organization == nil {
+		return fmt.Errorf("organization cannot be nil")
+	}
+
+	if _, exists := r.organizations[organization.ID().Value()]; !exists {
+		return errs.NotFound("organization not found")
+	}
+
+	r.organizations[organization.ID().Value()] = organization
+	return nil
+}
+
+// Ensure InMemoryOrganizationRepository implements domain.OrganizationRepository
+var _ domain.OrganizationRepository = (*InMemoryOrganizationRepository)(nil)
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/process"
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// InMemoryProcessStateRepository is an in-memory implementation of process.StateStore
+type InMemoryProcessStateRepository struct {
+	states map[string]process.State
+	mu     sync.RWMutex
+}
+
+// NewInMemoryProcessStateRepository creates a new InMemoryProcessStateRepository
+func NewInMemoryProcessStateRepository() *InMemoryProcessStateRepository {
+	return &InMemoryProcessStateRepository{
+		states: make(map[string]process.State),
+	}
+}
+
+// Save persists the state of a reaction, replacing the one stored before
+func (r *InMemoryProcessStateRepository) Save(ctx context.Context, state process.State) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if state.ID == "" {
+		return errs.Invalid("process state id cannot be empty")
+	}
+
+	r.states[state.ID] = state
+	return nil
+}
+
+// GetByID retrieves the state of a reaction by ID
+func (r *InMemoryProcessStateRepository) GetByID(ctx context.Context, id string) (process.State, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state, exists := r.states[id]
+	if !exists {
+		return process.State{}, errs.NotFound("process state not found")
+	}
+
+	return state, nil
+}
+
+// GetDue retrieves the pending states whose next attempt is due, the longest waiting first
+func (r *InMemoryProcessStateRepository) GetDue(ctx context.Context, now time.Time) ([]process.State, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	states := make([]process.State, 0)
+	for _, state := range r.states {
+		if state.Status == process.StatusPending && !state.NextAttemptAt.After(now) {
+			states = append(states, state)
+		}
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].NextAttemptAt.Before(states[j].NextAttemptAt)
+	})
+
+	return states, nil
+}
+
+// GetByStatus retrieves the states with a status, the most recently updated first
+func (r *InMemoryProcessStateRepository) GetByStatus(ctx context.Context, status process.Status) ([]process.State, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	states := make([]process.State, 0)
+	for _, state := range r.states {
+		if state.Status == status {
+			states = append(states, state)
+		}
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].UpdatedAt.After(states[j].UpdatedAt)
+	})
+
+	return states, nil
+}
+
+// Ensure InMemoryProcessStateRepository implements process.StateStore
+var _ process.StateStore = (*InMemoryProcessStateRepository)(nil)
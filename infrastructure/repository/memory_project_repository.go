@@ -1,11 +1,15 @@
 package repository
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/miladev95/ddd-task/domain"
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
@@ -23,7 +27,7 @@ func NewInMemoryProjectRepository() *InMemoryProjectRepository {
 }
 
 // Save persists a project to the repository
-func (r *InMemoryProjectRepository) Save(project *aggregate.Project) error {
+func (r *InMemoryProjectRepository) Save(ctx context.Context, project *aggregate.Project) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -36,20 +40,20 @@ func (r *InMemoryProjectRepository) Save(project *aggregate.Project) error {
 }
 
 // GetByID retrieves a project by ID
-func (r *InMemoryProjectRepository) GetByID(id value.ProjectID) (*aggregate.Project, error) {
+func (r *InMemoryProjectRepository) GetByID(ctx context.Context, id value.ProjectID) (*aggregate.Project, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	project, exists := r.projects[id.Value()]
 	if !exists {
-		return nil, fmt.Errorf("project not found")
+		return nil, errs.NotFound("project not found")
 	}
 
 	return project, nil
 }
 
 // GetByOwnerID retrieves all projects owned by a user
-func (r *InMemoryProjectRepository) GetByOwnerID(userID value.UserID) ([]*aggregate.Project, error) {
+func (r *InMemoryProjectRepository) GetByOwnerID(ctx context.Context, userID value.UserID) ([]*aggregate.Project, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -64,7 +68,7 @@ func (r *InMemoryProjectRepository) GetByOwnerID(userID value.UserID) ([]*aggreg
 }
 
 // GetAll retrieves all projects
-func (r *InMemoryProjectRepository) GetAll() ([]*aggregate.Project, error) {
+func (r *InMemoryProjectRepository) GetAll(ctx context.Context) ([]*aggregate.Project, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -77,12 +81,12 @@ func (r *InMemoryProjectRepository) GetAll() ([]*aggregate.Project, error) {
 }
 
 // Delete removes a project from the repository
-func (r *InMemoryProjectRepository) Delete(id value.ProjectID) error {
+func (r *InMemoryProjectRepository) Delete(ctx context.Context, id value.ProjectID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, exists := r.projects[id.Value()]; !exists {
-		return fmt.Errorf("project not found")
+		return errs.NotFound("project not found")
 	}
 
 	delete(r.projects, id.Value())
@@ -90,7 +94,7 @@ func (r *InMemoryProjectRepository) Delete(id value.ProjectID) error {
 }
 
 // Update updates an existing project
-func (r *InMemoryProjectRepository) Update(project *aggregate.Project) error {
+func (r *InMemoryProjectRepository) Update(ctx context.Context, project *aggregate.Project) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -99,7 +103,7 @@ func (r *InMemoryProjectRepository) Update(project *aggregate.Project) error {
 	}
 
 	if _, exists := r.projects[project.ID().Value()]; !exists {
-		return fmt.Errorf("project not found")
+		return errs.NotFound("project not found")
 	}
 
 	r.projects[project.ID().Value()] = project
@@ -107,7 +111,7 @@ func (r *InMemoryProjectRepository) Update(project *aggregate.Project) error {
 }
 
 // GetActive retrieves all active projects
-func (r *InMemoryProjectRepository) GetActive() ([]*aggregate.Project, error) {
+func (r *InMemoryProjectRepository) GetActive(ctx context.Context) ([]*aggregate.Project, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -121,5 +125,60 @@ func (r *InMemoryProjectRepository) GetActive() ([]*aggregate.Project, error) {
 	return projects, nil
 }
 
+// GetByInboxToken retrieves the project one of whose inbox addresses has the token
+func (r *InMemoryProjectRepository) GetByInboxToken(ctx context.Context, token string) (*aggregate.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, project := range r.projects {
+		if project.HasInboxToken(token) {
+			return project, nil
+		}
+	}
+
+	return nil, errs.NotFound("project not found")
+}
+
+// List retrieves a page of the projects matching a filter
+func (r *InMemoryProjectRepository) List(ctx context.Context, filter domain.ProjectFilter, page domain.Page) ([]*aggregate.Project, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.ProjectSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	var viewerID *value.UserID
+	if id, err := value.NewUserID(filter.ViewerID); err == nil {
+		viewerID = &id
+	}
+
+	r.mu.RLock()
+	projects := make([]*aggregate.Project, 0)
+	for _, project := range r.projects {
+		if (filter.OwnerID == "" || project.OwnerID().Value() == filter.OwnerID) &&
+			(filter.Archived == nil || project.IsArchived() == *filter.Archived) &&
+			(!filter.VisibleOnly || project.CanView(viewerID)) {
+			projects = append(projects, project)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(projects, func(i, j int) bool {
+		a, b := projects[i], projects[j]
+		var compared int
+		switch page.SortBy {
+		case "updated_at":
+			compared = a.UpdatedAt().Compare(b.UpdatedAt())
+		case "name":
+			compared = strings.Compare(a.Name(), b.Name())
+		default:
+			compared = a.CreatedAt().Compare(b.CreatedAt())
+		}
+		return page.Before(compared, a.ID().Value(), b.ID().Value())
+	})
+
+	start, end := page.Window(len(projects))
+	return projects[start:end], page.Result(len(projects)), nil
+}
+
 // Ensure InMemoryProjectRepository implements domain.ProjectRepository
 var _ domain.ProjectRepository = (*InMemoryProjectRepository)(nil)
\ No newline at end of file
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// DefaultRecentViewCapacity is the number of views kept per user
+const DefaultRecentViewCapacity = 50
+
+// InMemoryRecentViewRepository is an in-memory implementation of RecentViewRepository
+// keeping a bounded, de-duplicated list per user
+type InMemoryRecentViewRepository struct {
+	views    map[string][]value.RecentView
+	capacity int
+	mu       sync.RWMutex
+}
+
+// NewInMemoryRecentViewRepository creates a new InMemoryRecentViewRepository
+func NewInMemoryRecentViewRepository(capacity int) *InMemoryRecentViewRepository {
+	if capacity <= 0 {
+		capacity = DefaultRecentViewCapacity
+	}
+
+	return &InMemoryRecentViewRepository{
+		views:    make(map[string][]value.RecentView),
+		capacity: capacity,
+	}
+}
+
+// Record stores a view, moving the item to the front of the user's list
+func (r *InMemoryRecentViewRepository) Record(ctx context.Context, userID value.UserID, view value.RecentView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := r.views[userID.Value()]
+	updated := make([]value.RecentView, 0, len(existing)+1)
+	updated = append(updated, view)
+	for _, v := range existing {
+		if !v.SameItem(view) {
+			updated = append(updated, v)
+		}
+	}
+
+	if len(updated) > r.capacity {
+		updated = updated[:r.capacity]
+	}
+
+	r.views[userID.Value()] = updated
+	return nil
+}
+
+// GetRecent retrieves a user's most recent views, newest first
+func (r *InMemoryRecentViewRepository) GetRecent(ctx context.Context, userID value.UserID, limit int) ([]value.RecentView, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	views := r.views[userID.Value()]
+	if limit > 0 && len(views) > limit {
+		views = views[:limit]
+	}
+
+	return append([]value.RecentView{}, views...), nil
+}
+
+// Ensure InMemoryRecentViewRepository implements domain.RecentViewRepository
+var _ domain.RecentViewRepository = (*InMemoryRecentViewRepository)(nil)
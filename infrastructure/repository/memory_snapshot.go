@@ -0,0 +1,532 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// taskSnapshot is the snapshot file of InMemoryTaskRepository. Archived tasks are written
+// among the live ones and come back resident.
+type taskSnapshot struct {
+	Tasks   []aggregate.TaskState `json:"tasks"`
+	Deleted []aggregate.TaskState `json:"deleted"`
+}
+
+// recentViewSnapshot is one entry of the snapshot file of InMemoryRecentViewRepository
+type recentViewSnapshot struct {
+	Kind     string    `json:"kind"`
+	ItemID   string    `json:"item_id"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+// writeSnapshot encodes a snapshot as indented JSON, so the files stay readable
+func writeSnapshot(w io.Writer, snapshot interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(snapshot); err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return nil
+}
+
+// readSnapshot decodes a snapshot written by writeSnapshot
+func readSnapshot(r io.Reader, snapshot interface{}) error {
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return nil
+}
+
+// SnapshotName is the name of the task snapshot file
+func (r *InMemoryTaskRepository) SnapshotName() string {
+	return "tasks.json"
+}
+
+// WriteSnapshot writes every task, reading archived ones back from the spill file
+func (r *InMemoryTaskRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	snapshot := taskSnapshot{
+		Tasks:   make([]aggregate.TaskState, 0, len(r.tasks)+len(r.archived)),
+		Deleted: make([]aggregate.TaskState, 0, len(r.deleted)),
+	}
+	for _, task := range r.tasks {
+		snapshot.Tasks = append(snapshot.Tasks, task.ToState())
+	}
+	for id := range r.archived {
+		state, err := r.spill.Read(id)
+		if err != nil {
+			r.mu.RUnlock()
+			return err
+		}
+		snapshot.Tasks = append(snapshot.Tasks, state)
+	}
+	for _, task := range r.deleted {
+		snapshot.Deleted = append(snapshot.Deleted, task.ToState())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(snapshot.Tasks, func(i, j int) bool { return snapshot.Tasks[i].ID < snapshot.Tasks[j].ID })
+	sort.Slice(snapshot.Deleted, func(i, j int) bool { return snapshot.Deleted[i].ID < snapshot.Deleted[j].ID })
+	return writeSnapshot(w, snapshot)
+}
+
+// ReadSnapshot replaces every task with a snapshot, emptying the spill file
+func (r *InMemoryTaskRepository) ReadSnapshot(reader io.Reader) error {
+	var snapshot taskSnapshot
+	if err := readSnapshot(reader, &snapshot); err != nil {
+		return err
+	}
+
+	tasks := make(map[string]*aggregate.Task, len(snapshot.Tasks))
+	for _, state := range snapshot.Tasks {
+		task, err := aggregate.TaskFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore task %s: %w", state.ID, err)
+		}
+		tasks[state.ID] = task
+	}
+	deleted := make(map[string]*aggregate.Task, len(snapshot.Deleted))
+	for _, state := range snapshot.Deleted {
+		task, err := aggregate.TaskFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore task %s: %w", state.ID, err)
+		}
+		deleted[state.ID] = task
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := range r.archived {
+		if err := r.forgetArchived(id); err != nil {
+			return err
+		}
+	}
+	r.tasks = tasks
+	r.deleted = deleted
+
+	r.usageMu.Lock()
+	r.lastUsed = make(map[string]uint64)
+	r.usageMu.Unlock()
+	return nil
+}
+
+// SnapshotName is the name of the project snapshot file
+func (r *InMemoryProjectRepository) SnapshotName() string {
+	return "projects.json"
+}
+
+// WriteSnapshot writes every project
+func (r *InMemoryProjectRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	states := make([]aggregate.ProjectState, 0, len(r.projects))
+	for _, project := range r.projects {
+		states = append(states, project.ToState())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return writeSnapshot(w, states)
+}
+
+// ReadSnapshot replaces every project with a snapshot
+func (r *InMemoryProjectRepository) ReadSnapshot(reader io.Reader) error {
+	var states []aggregate.ProjectState
+	if err := readSnapshot(reader, &states); err != nil {
+		return err
+	}
+
+	projects := make(map[string]*aggregate.Project, len(states))
+	for _, state := range states {
+		project, err := aggregate.ProjectFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore project %s: %w", state.ID, err)
+		}
+		projects[state.ID] = project
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.projects = projects
+	return nil
+}
+
+// SnapshotName is the name of the user snapshot file
+func (r *InMemoryUserRepository) SnapshotName() string {
+	return "users.json"
+}
+
+// WriteSnapshot writes every user
+func (r *InMemoryUserRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	states := make([]aggregate.UserState, 0, len(r.users))
+	for _, user := range r.users {
+		states = append(states, user.ToState())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return writeSnapshot(w, states)
+}
+
+// ReadSnapshot replaces every user with a snapshot
+func (r *InMemoryUserRepository) ReadSnapshot(reader io.Reader) error {
+	var states []aggregate.UserState
+	if err := readSnapshot(reader, &states); err != nil {
+		return err
+	}
+
+	users := make(map[string]*aggregate.User, len(states))
+	for _, state := range states {
+		user, err := aggregate.UserFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore user %s: %w", state.ID, err)
+		}
+		users[state.ID] = user
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users = users
+	return nil
+}
+
+// SnapshotName is the name of the workflow snapshot file
+func (r *InMemoryWorkflowRepository) SnapshotName() string {
+	return "workflows.json"
+}
+
+// WriteSnapshot writes every version of every workflow
+func (r *InMemoryWorkflowRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	states := make([]aggregate.WorkflowState, 0, len(r.versions))
+	for _, versions := range r.versions {
+		for _, workflow := range versions {
+			states = append(states, workflow.ToState())
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].ID != states[j].ID {
+			return states[i].ID < states[j].ID
+		}
+		return states[i].Version < states[j].Version
+	})
+	return writeSnapshot(w, states)
+}
+
+// ReadSnapshot replaces every workflow with a snapshot, the latest version of each becoming current
+func (r *InMemoryWorkflowRepository) ReadSnapshot(reader io.Reader) error {
+	var states []aggregate.WorkflowState
+	if err := readSnapshot(reader, &states); err != nil {
+		return err
+	}
+
+	workflows := make(map[string]*aggregate.Workflow)
+	versions := make(map[string]map[int]*aggregate.Workflow)
+	for _, state := range states {
+		workflow, err := aggregate.WorkflowFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore workflow %s: %w", state.ID, err)
+		}
+
+		if versions[state.ID] == nil {
+			versions[state.ID] = make(map[int]*aggregate.Workflow)
+		}
+		versions[state.ID][state.Version] = workflow
+		if latest, exists := workflows[state.ID]; !exists || latest.Version() < state.Version {
+			workflows[state.ID] = workflow
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.workflows = workflows
+	r.versions = versions
+	return nil
+}
+
+// SnapshotName is the name of the widget snapshot file
+func (r *InMemoryWidgetRepository) SnapshotName() string {
+	return "widgets.json"
+}
+
+// WriteSnapshot writes every widget
+func (r *InMemoryWidgetRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	states := make([]aggregate.WidgetState, 0, len(r.widgets))
+	for _, widget := range r.widgets {
+		states = append(states, widget.ToState())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return writeSnapshot(w, states)
+}
+
+// ReadSnapshot replaces every widget with a snapshot
+func (r *InMemoryWidgetRepository) ReadSnapshot(reader io.Reader) error {
+	var states []aggregate.WidgetState
+	if err := readSnapshot(reader, &states); err != nil {
+		return err
+	}
+
+	widgets := make(map[string]*aggregate.Widget, len(states))
+	for _, state := range states {
+		widget, err := aggregate.WidgetFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore widget %s: %w", state.ID, err)
+		}
+		widgets[state.ID] = widget
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.widgets = widgets
+	return nil
+}
+
+// SnapshotName is the name of the sprint snapshot file
+func (r *InMemorySprintRepository) SnapshotName() string {
+	return "sprints.json"
+}
+
+// WriteSnapshot writes every sprint
+func (r *InMemorySprintRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	states := make([]aggregate.SprintState, 0, len(r.sprints))
+	for _, sprint := range r.sprints {
+		states = append(states, sprint.ToState())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return writeSnapshot(w, states)
+}
+
+// ReadSnapshot replaces every sprint with a snapshot
+func (r *InMemorySprintRepository) ReadSnapshot(reader io.Reader) error {
+	var states []aggregate.SprintState
+	if err := readSnapshot(reader, &states); err != nil {
+		return err
+	}
+
+	sprints := make(map[string]*aggregate.Sprint, len(states))
+	for _, state := range states {
+		sprint, err := aggregate.SprintFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore sprint %s: %w", state.ID, err)
+		}
+		sprints[state.ID] = sprint
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sprints = sprints
+	return nil
+}
+
+// SnapshotName is the name of the team snapshot file
+func (r *InMemoryTeamRepository) SnapshotName() string {
+	return "teams.json"
+}
+
+// WriteSnapshot writes every team
+func (r *InMemoryTeamRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	states := make([]aggregate.TeamState, 0, len(r.teams))
+	for _, team := range r.teams {
+		states = append(states, team.ToState())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return writeSnapshot(w, states)
+}
+
+// ReadSnapshot replaces every team with a snapshot
+func (r *InMemoryTeamRepository) ReadSnapshot(reader io.Reader) error {
+	var states []aggregate.TeamState
+	if err := readSnapshot(reader, &states); err != nil {
+		return err
+	}
+
+	teams := make(map[string]*aggregate.Team, len(states))
+	for _, state := range states {
+		team, err := aggregate.TeamFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore team %s: %w", state.ID, err)
+		}
+		teams[state.ID] = team
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.teams = teams
+	return nil
+}
+
+// SnapshotName is the name of the organization snapshot file
+func (r *InMemoryOrganizationRepository) SnapshotName() string {
+	return "organizations.json"
+}
+
+// WriteSnapshot writes every organization
+func (r *InMemoryOrganizationRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	states := make([]aggregate.OrganizationState, 0, len(r.organizations))
+	for _, organization := range r.organizations {
+		states = append(states, organization.ToState())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return writeSnapshot(w, states)
+}
+
+// ReadSnapshot replaces every organization with a snapshot
+func (r *InMemoryOrganizationRepository) ReadSnapshot(reader io.Reader) error {
+	var states []aggregate.OrganizationState
+	if err := readSnapshot(reader, &states); err != nil {
+		return err
+	}
+
+	organizations := make(map[string]*aggregate.Organization, len(states))
+	for _, state := range states {
+		organization, err := aggregate.OrganizationFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore organization %s: %w", state.ID, err)
+		}
+		organizations[state.ID] = organization
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.organizations = organizations
+	return nil
+}
+
+// SnapshotName is the name of the holiday calendar snapshot file
+func (r *InMemoryHolidayCalendarRepository) SnapshotName() string {
+	return "holiday_calendars.json"
+}
+
+// WriteSnapshot writes every holiday calendar
+func (r *InMemoryHolidayCalendarRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	states := make([]aggregate.HolidayCalendarState, 0, len(r.calendars))
+	for _, calendar := range r.calendars {
+		states = append(states, calendar.ToState())
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].ID < states[j].ID })
+	return writeSnapshot(w, states)
+}
+
+// ReadSnapshot replaces every holiday calendar with a snapshot
+func (r *InMemoryHolidayCalendarRepository) ReadSnapshot(reader io.Reader) error {
+	var states []aggregate.HolidayCalendarState
+	if err := readSnapshot(reader, &states); err != nil {
+		return err
+	}
+
+	calendars := make(map[string]*aggregate.HolidayCalendar, len(states))
+	for _, state := range states {
+		calendar, err := aggregate.HolidayCalendarFromState(state)
+		if err != nil {
+			return fmt.Errorf("failed to restore holiday calendar %s: %w", state.ID, err)
+		}
+		calendars[state.ID] = calendar
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calendars = calendars
+	return nil
+}
+
+// SnapshotName is the name of the recent view snapshot file
+func (r *InMemoryRecentViewRepository) SnapshotName() string {
+	return "recent_views.json"
+}
+
+// WriteSnapshot writes every user's views, newest first
+func (r *InMemoryRecentViewRepository) WriteSnapshot(w io.Writer) error {
+	r.mu.RLock()
+	snapshot := make(map[string][]recentViewSnapshot, len(r.views))
+	for userID, views := range r.views {
+		entries := make([]recentViewSnapshot, len(views))
+		for i, view := range views {
+			entries[i] = recentViewSnapshot{
+				Kind:     view.Kind().Value(),
+				ItemID:   view.ItemID(),
+				ViewedAt: view.ViewedAt(),
+			}
+		}
+		snapshot[userID] = entries
+	}
+	r.mu.RUnlock()
+
+	return writeSnapshot(w, snapshot)
+}
+
+// ReadSnapshot replaces every user's views with a snapshot, trimmed to the repository's capacity
+func (r *InMemoryRecentViewRepository) ReadSnapshot(reader io.Reader) error {
+	var snapshot map[string][]recentViewSnapshot
+	if err := readSnapshot(reader, &snapshot); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	views := make(map[string][]value.RecentView, len(snapshot))
+	for userID, entries := range snapshot {
+		if len(entries) > r.capacity {
+			entries = entries[:r.capacity]
+		}
+
+		restored := make([]value.RecentView, 0, len(entries))
+		for _, entry := range entries {
+			view, err := value.NewRecentView(value.ViewedItemKind(entry.Kind), entry.ItemID, entry.ViewedAt)
+			if err != nil {
+				return fmt.Errorf("failed to restore recent view of %s: %w", userID, err)
+			}
+			restored = append(restored, view)
+		}
+		views[userID] = restored
+	}
+
+	r.views = views
+	return nil
+}
+
+// Ensure the in-memory repositories implement Snapshotter
+var (
+	_ Snapshotter = (*InMemoryTaskRepository)(nil)
+	_ Snapshotter = (*InMemoryProjectRepository)(nil)
+	_ Snapshotter = (*InMemoryUserRepository)(nil)
+	_ Snapshotter = (*InMemoryWorkflowRepository)(nil)
+	_ Snapshotter = (*InMemoryWidgetRepository)(nil)
+	_ Snapshotter = (*InMemorySprintRepository)(nil)
+	_ Snapshotter = (*InMemoryTeamRepository)(nil)
+	_ Snapshotter = (*InMemoryOrganizationRepository)(nil)
+	_ Snapshotter = (*InMemoryHolidayCalendarRepository)(nil)
+	_ Snapshotter = (*InMemoryRecentViewRepository)(nil)
+)
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// InMemorySprintRepository is an in-memory implementation of SprintRepository
+type InMemorySprintRepository struct {
+	sprints map[string]*aggregate.Sprint
+	mu      sync.RWMutex
+}
+
+// NewInMemorySprintRepository creates a new InMemorySprintRepository
+func NewInMemorySprintRepository() *InMemorySprintRepository {
+	return &InMemorySprintRepository{
+		sprints: make(map[string]*aggregate.Sprint),
+	}
+}
+
+// Save persists a sprint to the repository
+func (r *InMemorySprintRepository) Save(ctx context.Context, sprint *aggregate.Sprint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sprint == nil {
+		return fmt.Errorf("sprint cannot be nil")
+	}
+
+	r.sprints[sprint.ID().Value()] = sprint
+	return nil
+}
+
+// GetByID retrieves a sprint by ID
+func (r *InMemorySprintRepository) GetByID(ctx context.Context, id value.SprintID) (*aggregate.Sprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sprint, exists := r.sprints[id.Value()]
+	if !exists {
+		return nil, errs.NotFound("sprint not found")
+	}
+
+	return sprint, nil
+}
+
+// GetByProjectID retrieves all sprints of a project, ordered by start date
+func (r *InMemorySprintRepository) GetByProjectID(ctx context.Context, projectID value.ProjectID) ([]*aggregate.Sprint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sprints := make([]*aggregate.Sprint, 0)
+	for _, sprint := range r.sprints {
+		if sprint.ProjectID().Equals(projectID) {
+			sprints = append(sprints, sprint)
+		}
+	}
+
+	sort.Slice(sprints, func(i, j int) bool {
+		return sprints[i].StartDate().Before(sprints[j].StartDate())
+	})
+
+	return sprints, nil
+}
+
+// Update updates an existing sprint
+func (r *InMemorySprintRepository) Update(ctx context.Context, sprint *aggregate.Sprint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sprint == nil {
+		return fmt.Errorf("sprint cannot be nil")
+	}
+
+	if _, exists := r.sprints[sprint.ID().Value()]; !exists {
+		return errs.NotFound("sprint not found")
+	}
+
+	r.sprints[sprint.ID().Value()] = sprint
+	return nil
+}
+
+// List retrieves a page of the sprints matching a filter
+func (r *InMemorySprintRepository) List(ctx context.Context, filter domain.SprintFilter, page domain.Page) ([]*aggregate.Sprint, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.SprintSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	r.mu.RLock()
+	sprints := make([]*aggregate.Sprint, 0)
+	for _, sprint := range r.sprints {
+		if (filter.ProjectID == "" || sprint.ProjectID().Value() == filter.ProjectID) &&
+			(filter.Status == "" || sprint.Status().Value() == filter.Status) {
+			sprints = append(sprints, sprint)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(sprints, func(i, j int) bool {
+		a, b := sprints[i], sprints[j]
+		var compared int
+		switch page.SortBy {
+		case "created_at":
+			compared = a.CreatedAt().Compare(b.CreatedAt())
+		case "name":
+			compared = strings.Compare(a.Name(), b.Name())
+		default:
+			compared = a.StartDate().Compare(b.StartDate())
+		}
+		return page.Before(compared, a.ID().Value(), b.ID().Value())
+	})
+
+	start, end := page.Window(len(sprints))
+	return sprints[start:end], page.Result(len(sprints)), nil
+}
+
+// Ensure InMemorySprintRepository implements domain.SprintRepository
+var _ domain.SprintRepository = (*InMemorySprintRepository)(nil)
@@ -1,29 +1,128 @@
 package repository
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/miladev95/ddd-task/domain"
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
-// InMemoryTaskRepository is an in-memory implementation of TaskRepository for testing and demo
+// InMemoryTaskRepository is an in-memory implementation of TaskRepository for testing and demo.
+// With archival enabled, Compact moves the least recently used finished tasks to a spill file
+// once more than maxResident tasks are held, and any lookup that matches them brings them back.
+// Soft-deleted tasks are stored apart and left out of every lookup except GetDeleted.
+// Lookups hand out copies of the stored tasks, and Update refuses a task whose stored
+// version moved on since it was read.
 type InMemoryTaskRepository struct {
-	tasks map[string]*aggregate.Task
-	mu    sync.RWMutex
+	tasks   map[string]*aggregate.Task
+	deleted map[string]*aggregate.Task // task ID -> soft-deleted task
+	mu      sync.RWMutex
+
+	spill       *TaskSpillFile
+	maxResident int
+	archived    map[string]archivedTask // task ID -> the fields lookups match on
+
+	lastUsed map[string]uint64 // task ID -> tick of its last use
+	tick     uint64
+	usageMu  sync.Mutex
+}
+
+// archivedTask is what stays in memory of a task moved to the spill file
+type archivedTask struct {
+	projectID  string
+	assigneeID string
+	teamID     string
+	status     value.TaskStatus
 }
 
 // NewInMemoryTaskRepository creates a new InMemoryTaskRepository
 func NewInMemoryTaskRepository() *InMemoryTaskRepository {
 	return &InMemoryTaskRepository{
-		tasks: make(map[string]*aggregate.Task),
+		tasks:    make(map[string]*aggregate.Task),
+		deleted:  make(map[string]*aggregate.Task),
+		archived: make(map[string]archivedTask),
+		lastUsed: make(map[string]uint64),
 	}
 }
 
+// EnableArchival lets Compact keep at most maxResident tasks in memory by spilling finished ones
+func (r *InMemoryTaskRepository) EnableArchival(maxResident int, spill *TaskSpillFile) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.maxResident = maxResident
+	r.spill = spill
+}
+
+// Compact spills the least recently used completed and cancelled tasks until the memory cap
+// is met, and returns how many were archived. Open tasks always stay in memory.
+func (r *InMemoryTaskRepository) Compact() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.spill == nil || len(r.tasks) <= r.maxResident {
+		return 0, nil
+	}
+
+	candidates := make([]*aggregate.Task, 0)
+	for _, task := range r.tasks {
+		if task.Status() == value.TaskStatusCompleted || task.Status() == value.TaskStatusCancelled {
+			candidates = append(candidates, task)
+		}
+	}
+
+	r.usageMu.Lock()
+	sort.Slice(candidates, func(i, j int) bool {
+		return r.lastUsed[candidates[i].ID().Value()] < r.lastUsed[candidates[j].ID().Value()]
+	})
+	r.usageMu.Unlock()
+
+	archived := 0
+	for _, task := range candidates {
+		if len(r.tasks) <= r.maxResident {
+			break
+		}
+
+		if err := r.spill.Write(task.ToState()); err != nil {
+			return archived, err
+		}
+
+		entry := archivedTask{
+			projectID: task.ProjectID().Value(),
+			status:    task.Status(),
+		}
+		if task.Assignee() != nil {
+			entry.assigneeID = task.Assignee().AssigneeID().Value()
+		}
+		if task.TeamID() != nil {
+			entry.teamID = task.TeamID().Value()
+		}
+
+		id := task.ID().Value()
+		r.archived[id] = entry
+		delete(r.tasks, id)
+		archived++
+	}
+
+	return archived, nil
+}
+
+// ArchivedCount returns how many tasks are currently in the spill file
+func (r *InMemoryTaskRepository) ArchivedCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.archived)
+}
+
 // Save persists a task to the repository
-func (r *InMemoryTaskRepository) Save(task *aggregate.Task) error {
+func (r *InMemoryTaskRepository) Save(ctx context.Context, task *aggregate.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -31,25 +130,51 @@ func (r *InMemoryTaskRepository) Save(task *aggregate.Task) error {
 		return fmt.Errorf("task cannot be nil")
 	}
 
-	r.tasks[task.ID().Value()] = task
-	return nil
+	if err := r.forgetArchived(task.ID().Value()); err != nil {
+		return err
+	}
+
+	task.SetVersion(task.Version() + 1)
+	return r.store(task)
 }
 
-// GetByID retrieves a task by ID
-func (r *InMemoryTaskRepository) GetByID(id value.TaskID) (*aggregate.Task, error) {
+// GetByID retrieves a task by ID, restoring it from the spill file if it was archived
+func (r *InMemoryTaskRepository) GetByID(ctx context.Context, id value.TaskID) (*aggregate.Task, error) {
 	r.mu.RLock()
-	defer r.mu.RUnlock()
-
 	task, exists := r.tasks[id.Value()]
-	if !exists {
-		return nil, fmt.Errorf("task not found")
+	r.mu.RUnlock()
+
+	if exists {
+		r.touch(id.Value())
+		return copyTask(task)
 	}
 
-	return task, nil
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if task, exists := r.tasks[id.Value()]; exists {
+		return copyTask(task)
+	}
+
+	if _, archived := r.archived[id.Value()]; !archived {
+		return nil, errs.NotFound("task not found")
+	}
+
+	task, err := r.restore(id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return copyTask(task)
 }
 
 // GetByProjectID retrieves all tasks for a project
-func (r *InMemoryTaskRepository) GetByProjectID(projectID value.ProjectID) ([]*aggregate.Task, error) {
+func (r *InMemoryTaskRepository) GetByProjectID(ctx context.Context, projectID value.ProjectID) ([]*aggregate.Task, error) {
+	if err := r.restoreArchived(func(a archivedTask) bool {
+		return a.projectID == projectID.Value()
+	}); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -60,11 +185,17 @@ func (r *InMemoryTaskRepository) GetByProjectID(projectID value.ProjectID) ([]*a
 		}
 	}
 
-	return tasks, nil
+	return copyTasks(tasks)
 }
 
 // GetByAssigneeID retrieves all tasks assigned to a user
-func (r *InMemoryTaskRepository) GetByAssigneeID(userID value.UserID) ([]*aggregate.Task, error) {
+func (r *InMemoryTaskRepository) GetByAssigneeID(ctx context.Context, userID value.UserID) ([]*aggregate.Task, error) {
+	if err := r.restoreArchived(func(a archivedTask) bool {
+		return a.assigneeID == userID.Value()
+	}); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -75,11 +206,38 @@ func (r *InMemoryTaskRepository) GetByAssigneeID(userID value.UserID) ([]*aggreg
 		}
 	}
 
-	return tasks, nil
+	return copyTasks(tasks)
+}
+
+// GetByTeamID retrieves all tasks assigned to a team
+func (r *InMemoryTaskRepository) GetByTeamID(ctx context.Context, teamID value.TeamID) ([]*aggregate.Task, error) {
+	if err := r.restoreArchived(func(a archivedTask) bool {
+		return a.teamID == teamID.Value()
+	}); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := make([]*aggregate.Task, 0)
+	for _, task := range r.tasks {
+		if task.TeamID() != nil && task.TeamID().Equals(teamID) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return copyTasks(tasks)
 }
 
 // GetByStatus retrieves all tasks with a specific status
-func (r *InMemoryTaskRepository) GetByStatus(status value.TaskStatus) ([]*aggregate.Task, error) {
+func (r *InMemoryTaskRepository) GetByStatus(ctx context.Context, status value.TaskStatus) ([]*aggregate.Task, error) {
+	if err := r.restoreArchived(func(a archivedTask) bool {
+		return a.status == status
+	}); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -90,11 +248,17 @@ func (r *InMemoryTaskRepository) GetByStatus(status value.TaskStatus) ([]*aggreg
 		}
 	}
 
-	return tasks, nil
+	return copyTasks(tasks)
 }
 
 // GetAll retrieves all tasks
-func (r *InMemoryTaskRepository) GetAll() ([]*aggregate.Task, error) {
+func (r *InMemoryTaskRepository) GetAll(ctx context.Context) ([]*aggregate.Task, error) {
+	if err := r.restoreArchived(func(a archivedTask) bool {
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -103,24 +267,34 @@ func (r *InMemoryTaskRepository) GetAll() ([]*aggregate.Task, error) {
 		tasks = append(tasks, task)
 	}
 
-	return tasks, nil
+	return copyTasks(tasks)
 }
 
 // Delete removes a task from the repository
-func (r *InMemoryTaskRepository) Delete(id value.TaskID) error {
+func (r *InMemoryTaskRepository) Delete(ctx context.Context, id value.TaskID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.tasks[id.Value()]; !exists {
-		return fmt.Errorf("task not found")
+	_, archived := r.archived[id.Value()]
+	if _, exists := r.tasks[id.Value()]; !exists && !archived {
+		return errs.NotFound("task not found")
+	}
+
+	if err := r.forgetArchived(id.Value()); err != nil {
+		return err
 	}
 
 	delete(r.tasks, id.Value())
+
+	r.usageMu.Lock()
+	delete(r.lastUsed, id.Value())
+	r.usageMu.Unlock()
+
 	return nil
 }
 
-// Update updates an existing task
-func (r *InMemoryTaskRepository) Update(task *aggregate.Task) error {
+// Update updates an existing task, unless it was stored again since the task was read
+func (r *InMemoryTaskRepository) Update(ctx context.Context, task *aggregate.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -128,19 +302,55 @@ func (r *InMemoryTaskRepository) Update(task *aggregate.Task) error {
 		return fmt.Errorf("task cannot be nil")
 	}
 
-	if _, exists := r.tasks[task.ID().Value()]; !exists {
-		return fmt.Errorf("task not found")
+	id := task.ID().Value()
+	stored, exists := r.tasks[id]
+	if _, archived := r.archived[id]; archived && !exists {
+		restored, err := r.restore(id)
+		if err != nil {
+			return err
+		}
+		stored, exists = restored, true
+	}
+	if !exists {
+		return errs.NotFound("task not found")
+	}
+
+	if stored.Version() != task.Version() {
+		return errs.ConcurrencyConflict("task %s was changed since it was read", id)
 	}
 
-	r.tasks[task.ID().Value()] = task
+	task.SetVersion(task.Version() + 1)
+	if err := r.store(task); err != nil {
+		task.SetVersion(stored.Version())
+		return err
+	}
 	return nil
 }
 
+// GetDeleted retrieves a soft-deleted task by ID
+func (r *InMemoryTaskRepository) GetDeleted(ctx context.Context, id value.TaskID) (*aggregate.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, exists := r.deleted[id.Value()]
+	if !exists {
+		return nil, errs.NotFound("task not found")
+	}
+
+	return copyTask(task)
+}
+
 // FindByProjectIDAndStatus retrieves tasks for a project with specific status
-func (r *InMemoryTaskRepository) FindByProjectIDAndStatus(
+func (r *InMemoryTaskRepository) FindByProjectIDAndStatus(ctx context.Context, 
 	projectID value.ProjectID,
 	status value.TaskStatus,
 ) ([]*aggregate.Task, error) {
+	if err := r.restoreArchived(func(a archivedTask) bool {
+		return a.projectID == projectID.Value() && a.status == status
+	}); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -151,7 +361,214 @@ func (r *InMemoryTaskRepository) FindByProjectIDAndStatus(
 		}
 	}
 
-	return tasks, nil
+	return copyTasks(tasks)
+}
+
+// List retrieves a page of the tasks matching a filter, bringing back the archived ones that match
+func (r *InMemoryTaskRepository) List(ctx context.Context, filter domain.TaskFilter, page domain.Page) ([]*aggregate.Task, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.TaskSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	if err := r.restoreArchived(func(a archivedTask) bool {
+		return filterMatches(filter, a.projectID, a.assigneeID, a.teamID, a.status.Value())
+	}); err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	r.mu.RLock()
+	tasks := make([]*aggregate.Task, 0)
+	for _, task := range r.tasks {
+		if matchesTaskFilter(task, filter) {
+			tasks = append(tasks, task)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return page.Before(compareTasks(tasks[i], tasks[j], page.SortBy), tasks[i].ID().Value(), tasks[j].ID().Value())
+	})
+
+	start, end := page.Window(len(tasks))
+	copied, err := copyTasks(tasks[start:end])
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+	return copied, page.Result(len(tasks)), nil
+}
+
+// restoreArchived brings back every archived task that matches, so scans see them
+func (r *InMemoryTaskRepository) restoreArchived(match func(a archivedTask) bool) error {
+	r.mu.RLock()
+	empty := len(r.archived) == 0
+	r.mu.RUnlock()
+
+	if empty {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, entry := range r.archived {
+		if match(entry) {
+			if _, err := r.restore(id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// restore moves an archived task back into memory. The caller holds the write lock.
+func (r *InMemoryTaskRepository) restore(id string) (*aggregate.Task, error) {
+	state, err := r.spill.Read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := aggregate.TaskFromState(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore task %s: %w", id, err)
+	}
+
+	if err := r.forgetArchived(id); err != nil {
+		return nil, err
+	}
+
+	r.tasks[id] = task
+	r.touch(id)
+
+	return task, nil
+}
+
+// forgetArchived drops the spilled copy of a task. The caller holds the write lock.
+func (r *InMemoryTaskRepository) forgetArchived(id string) error {
+	if _, archived := r.archived[id]; !archived {
+		return nil
+	}
+
+	delete(r.archived, id)
+	return r.spill.Remove(id)
+}
+
+// store keeps a copy of a task among the live ones, or apart once it is soft-deleted.
+// The caller holds the write lock.
+func (r *InMemoryTaskRepository) store(task *aggregate.Task) error {
+	task, err := copyTask(task)
+	if err != nil {
+		return err
+	}
+
+	id := task.ID().Value()
+	if task.IsDeleted() {
+		delete(r.tasks, id)
+		r.deleted[id] = task
+
+		r.usageMu.Lock()
+		delete(r.lastUsed, id)
+		r.usageMu.Unlock()
+		return nil
+	}
+
+	r.tasks[id] = task
+	r.touch(id)
+	return nil
+}
+
+// copyTask returns a copy of a task that shares nothing with it, so a caller changing its
+// task changes the stored one only through Save or Update
+func copyTask(task *aggregate.Task) (*aggregate.Task, error) {
+	copied, err := aggregate.TaskFromState(task.ToState())
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy task %s: %w", task.ID().Value(), err)
+	}
+	return copied, nil
+}
+
+// copyTasks copies each of a list of tasks
+func copyTasks(tasks []*aggregate.Task) ([]*aggregate.Task, error) {
+	copied := make([]*aggregate.Task, 0, len(tasks))
+	for _, task := range tasks {
+		task, err := copyTask(task)
+		if err != nil {
+			return nil, err
+		}
+		copied = append(copied, task)
+	}
+	return copied, nil
+}
+
+// touch marks a task as just used
+func (r *InMemoryTaskRepository) touch(id string) {
+	r.usageMu.Lock()
+	defer r.usageMu.Unlock()
+
+	r.tick++
+	r.lastUsed[id] = r.tick
+}
+
+// matchesTaskFilter reports whether a task is selected by a filter
+func matchesTaskFilter(task *aggregate.Task, filter domain.TaskFilter) bool {
+	assigneeID, teamID := "", ""
+	if task.Assignee() != nil {
+		assigneeID = task.Assignee().AssigneeID().Value()
+	}
+	if task.TeamID() != nil {
+		teamID = task.TeamID().Value()
+	}
+	return filterMatches(filter, task.ProjectID().Value(), assigneeID, teamID, task.Status().Value())
+}
+
+// filterMatches reports whether a task with a project, assignee, team and status is selected by a filter
+func filterMatches(filter domain.TaskFilter, projectID, assigneeID, teamID, status string) bool {
+	if filter.ProjectID != "" && projectID != filter.ProjectID {
+		return false
+	}
+	if filter.AssigneeID != "" && assigneeID != filter.AssigneeID {
+		return false
+	}
+	if filter.TeamID != "" && teamID != filter.TeamID && !containsID(filter.TeamMemberIDs, assigneeID) {
+		return false
+	}
+	return filter.Status == "" || status == filter.Status
+}
+
+// containsID reports whether an ID is one of a list
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if id != "" && candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// compareTasks compares two tasks by one of domain.TaskSortFields. Tasks without a deadline
+// sort before those with one.
+func compareTasks(a, b *aggregate.Task, field string) int {
+	switch field {
+	case "updated_at":
+		return a.UpdatedAt().Compare(b.UpdatedAt())
+	case "title":
+		return strings.Compare(a.Title(), b.Title())
+	case "votes":
+		return a.VoteCount() - b.VoteCount()
+	case "deadline":
+		switch {
+		case a.Deadline() == nil && b.Deadline() == nil:
+			return 0
+		case a.Deadline() == nil:
+			return -1
+		case b.Deadline() == nil:
+			return 1
+		}
+		return a.Deadline().Value().Compare(b.Deadline().Value())
+	default:
+		return a.CreatedAt().Compare(b.CreatedAt())
+	}
 }
 
 // Ensure InMemoryTaskRepository implements domain.TaskRepository
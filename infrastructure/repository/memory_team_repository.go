@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// InMemoryTeamRepository is an in-memory implementation of TeamRepository
+type InMemoryTeamRepository struct {
+	teams map[string]*aggregate.Team
+	mu    sync.RWMutex
+}
+
+// NewInMemoryTeamRepository creates a new InMemoryTeamRepository
+func NewInMemoryTeamRepository() *InMemoryTeamRepository {
+	return &InMemoryTeamRepository{
+		teams: make(map[string]*aggregate.Team),
+	}
+}
+
+// Save persists a team to the repository
+func (r *InMemoryTeamRepository) Save(ctx context.Context, team *aggregate.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if team == nil {
+		return fmt.Errorf("team cannot be nil")
+	}
+
+	r.teams[team.ID().Value()] = team
+	return nil
+}
+
+// GetByID retrieves a team by ID
+func (r *InMemoryTeamRepository) GetByID(ctx context.Context, id value.TeamID) (*aggregate.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	team, exists := r.teams[id.Value()]
+	if !exists {
+		return nil, errs.NotFound("team not found")
+	}
+
+	return team, nil
+}
+
+// GetByMemberID retrieves all teams a user belongs to, ordered by name
+func (r *InMemoryTeamRepository) GetByMemberID(ctx context.Context, userID value.UserID) ([]*aggregate.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teams := make([]*aggregate.Team, 0)
+	for _, team := range r.teams {
+		if team.HasMember(userID) {
+			teams = append(teams, team)
+		}
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name() < teams[j].Name()
+	})
+
+	return teams, nil
+}
+
+// GetAll retrieves all teams, ordered by name
+func (r *InMemoryTeamRepository) GetAll(ctx context.Context) ([]*aggregate.Team, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	teams := make([]*aggregate.Team, 0, len(r.teams))
+	for _, team := range r.teams {
+		teams = append(teams, team)
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name() < teams[j].Name()
+	})
+
+	return teams, nil
+}
+
+// Update updates an existing team
+func (r *InMemoryTeamRepository) Update(ctx context.Context, team *aggregate.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if team == nil {
+		return fmt.Errorf("team cannot be nil")
+	}
+
+	if _, exists := r.teams[team.ID().Value()]; !exists {
+		return errs.NotFound("team not found")
+	}
+
+	r.teams[team.ID().Value()] = team
+	return nil
+}
+
+// List retrieves a page of the teams matching a filter
+func (r *InMemoryTeamRepository) List(ctx context.Context, filter domain.TeamFilter, page domain.Page) ([]*aggregate.Team, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.TeamSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	r.mu.RLock()
+	teams := make([]*aggregate.Team, 0)
+	for _, team := range r.teams {
+		if filter.MemberID == "" || hasMemberID(team, filter.MemberID) {
+			teams = append(teams, team)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(teams, func(i, j int) bool {
+		a, b := teams[i], teams[j]
+		compared := a.CreatedAt().Compare(b.CreatedAt())
+		if page.SortBy == "name" {
+			compared = strings.Compare(a.Name(), b.Name())
+		}
+		return page.Before(compared, a.ID().Value(), b.ID().Value())
+	})
+
+	start, end := page.Window(len(teams))
+	return teams[start:end], page.Result(len(teams)), nil
+}
+
+// hasMemberID reports whether a user ID is among a team's members
+func hasMemberID(team *aggregate.Team, userID string) bool {
+	for _, memberID := range team.MemberIDs() {
+		if memberID.Value() == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure InMemoryTeamRepository implements domain.TeamRepository
+var _ domain.TeamRepository = (*InMemoryTeamRepository)(nil)
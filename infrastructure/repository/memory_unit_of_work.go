@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// UnitOfWorkRepositories are the repositories a unit of work hands out
+type UnitOfWorkRepositories struct {
+	Tasks     domain.TaskRepository
+	Projects  domain.ProjectRepository
+	Users     domain.UserRepository
+	Workflows domain.WorkflowRepository
+}
+
+// Outbox stores the events of a transaction and delivers them to subscribers once it is kept
+type Outbox interface {
+	AppendAll(ctx context.Context, events []event.DomainEvent) error
+	DeliverAll(ctx context.Context, events []event.DomainEvent) error
+}
+
+// InMemoryUnitOfWork is an in-memory implementation of UnitOfWork. Task and project writes
+// made during a transaction go straight to the repositories and are logged, so a rollback
+// can undo them; recorded events are appended to the outbox in one batch on commit.
+// It makes a transaction atomic but not isolated: other readers see its writes before it commits.
+// Aggregates may be shared with the repositories, so the unit of work keeps a memento of each task
+// and project as first loaded through it, and a rollback stores them back as they were then.
+// The caller's own copy of a changed aggregate is for the caller to revert.
+type InMemoryUnitOfWork struct {
+	repositories UnitOfWorkRepositories
+	outbox       Outbox
+
+	ctx      context.Context // of the open transaction
+	active   bool
+	undo     []func() error
+	events   []event.DomainEvent
+	tasks    map[string]aggregate.TaskState    // tasks as loaded, since the last commit
+	projects map[string]aggregate.ProjectState // projects as loaded, since the last commit
+}
+
+// NewInMemoryUnitOfWork creates a new InMemoryUnitOfWork
+func NewInMemoryUnitOfWork(repositories UnitOfWorkRepositories, outbox Outbox) *InMemoryUnitOfWork {
+	return &InMemoryUnitOfWork{
+		repositories: repositories,
+		outbox:       outbox,
+		tasks:        make(map[string]aggregate.TaskState),
+		projects:     make(map[string]aggregate.ProjectState),
+	}
+}
+
+// NewInMemoryUnitOfWorkFactory creates a factory of InMemoryUnitOfWork over the same repositories and outbox
+func NewInMemoryUnitOfWorkFactory(repositories UnitOfWorkRepositories, outbox Outbox) domain.UnitOfWorkFactory {
+	return func() domain.UnitOfWork {
+		return NewInMemoryUnitOfWork(repositories, outbox)
+	}
+}
+
+// BeginTransaction starts a new transaction
+func (u *InMemoryUnitOfWork) BeginTransaction(ctx context.Context) error {
+	if u.active {
+		return fmt.Errorf("transaction already started")
+	}
+
+	u.ctx = ctx
+	u.active = true
+	u.undo = nil
+	u.events = nil
+	return nil
+}
+
+// Commit appends the recorded events to the outbox and keeps the writes of the transaction.
+// When the outbox refuses the events, the writes are rolled back. Appending is the last step
+// that can fail: the events are delivered to subscribers after the transaction is kept, and
+// a failing subscriber does not fail the commit; its failure is measured where it subscribed
+// and the stored events can be replayed to it. The events are appended under the context the
+// transaction began with, and delivered even if it is cancelled once the transaction is kept.
+func (u *InMemoryUnitOfWork) Commit() error {
+	if !u.active {
+		return fmt.Errorf("no transaction to commit")
+	}
+
+	if err := u.outbox.AppendAll(u.ctx, u.events); err != nil {
+		if rollbackErr := u.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("failed to write outbox events: %w (%v)", err, rollbackErr)
+		}
+		return fmt.Errorf("failed to write outbox events: %w", err)
+	}
+
+	ctx, events := u.ctx, u.events
+	u.ctx = nil
+	u.active = false
+	u.undo = nil
+	u.events = nil
+	u.tasks = make(map[string]aggregate.TaskState)
+	u.projects = make(map[string]aggregate.ProjectState)
+
+	u.outbox.DeliverAll(context.WithoutCancel(ctx), events)
+	return nil
+}
+
+// Rollback undoes the writes of the transaction, newest first, and drops its recorded events
+func (u *InMemoryUnitOfWork) Rollback() error {
+	if !u.active {
+		return nil
+	}
+
+	var failed error
+	for i := len(u.undo) - 1; i >= 0; i-- {
+		if err := u.undo[i](); err != nil && failed == nil {
+			failed = err
+		}
+	}
+
+	u.active = false
+	u.undo = nil
+	u.events = nil
+
+	if failed != nil {
+		return fmt.Errorf("failed to roll back: %w", failed)
+	}
+	return nil
+}
+
+// GetTaskRepository returns the task repository, remembering loaded tasks and logging writes
+// while a transaction is active
+func (u *InMemoryUnitOfWork) GetTaskRepository() domain.TaskRepository {
+	return &loggedTaskRepository{TaskRepository: u.repositories.Tasks, unitOfWork: u}
+}
+
+// GetProjectRepository returns the project repository, remembering loaded projects and logging
+// writes while a transaction is active
+func (u *InMemoryUnitOfWork) GetProjectRepository() domain.ProjectRepository {
+	return &loggedProjectRepository{ProjectRepository: u.repositories.Projects, unitOfWork: u}
+}
+
+// GetUserRepository returns the user repository
+func (u *InMemoryUnitOfWork) GetUserRepository() domain.UserRepository {
+	return u.repositories.Users
+}
+
+// GetWorkflowRepository returns the workflow repository
+func (u *InMemoryUnitOfWork) GetWorkflowRepository() domain.WorkflowRepository {
+	return u.repositories.Workflows
+}
+
+// RecordEvents queues domain events for the outbox
+func (u *InMemoryUnitOfWork) RecordEvents(events ...event.DomainEvent) {
+	u.events = append(u.events, events...)
+}
+
+// logUndo remembers how to undo a write of the active transaction
+func (u *InMemoryUnitOfWork) logUndo(undo func() error) {
+	if u.active {
+		u.undo = append(u.undo, undo)
+	}
+}
+
+// loggedTaskRepository writes through to the task repository and logs how to undo each write
+type loggedTaskRepository struct {
+	domain.TaskRepository
+	unitOfWork *InMemoryUnitOfWork
+}
+
+// GetByID retrieves a task, remembering its state as first loaded
+func (r *loggedTaskRepository) GetByID(ctx context.Context, id value.TaskID) (*aggregate.Task, error) {
+	task, err := r.TaskRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.remember(task)
+	return task, nil
+}
+
+// GetByAssigneeID retrieves the tasks assigned to a user, remembering their states as first loaded
+func (r *loggedTaskRepository) GetByAssigneeID(ctx context.Context, userID value.UserID) ([]*aggregate.Task, error) {
+	tasks, err := r.TaskRepository.GetByAssigneeID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		r.remember(task)
+	}
+	return tasks, nil
+}
+
+// remember keeps a task's state unless an earlier one is kept
+func (r *loggedTaskRepository) remember(task *aggregate.Task) aggregate.TaskState {
+	state, kept := r.unitOfWork.tasks[task.ID().Value()]
+	if !kept {
+		state = task.ToState()
+		r.unitOfWork.tasks[task.ID().Value()] = state
+	}
+	return state
+}
+
+// restore stores a task back as it was in a state, over whatever version the transaction left
+func (r *loggedTaskRepository) restore(ctx context.Context, state aggregate.TaskState) error {
+	task, err := aggregate.TaskFromState(state)
+	if err != nil {
+		return err
+	}
+	current, err := r.TaskRepository.GetByID(ctx, task.ID())
+	if err != nil {
+		return err
+	}
+	task.SetVersion(current.Version())
+	return r.TaskRepository.Update(ctx, task)
+}
+
+// Save persists a task, undone by deleting it
+func (r *loggedTaskRepository) Save(ctx context.Context, task *aggregate.Task) error {
+	if err := r.TaskRepository.Save(ctx, task); err != nil {
+		return err
+	}
+	r.unitOfWork.logUndo(func() error { return r.TaskRepository.Delete(ctx, task.ID()) })
+	return nil
+}
+
+// Update updates a task, undone by storing the task as it was loaded
+func (r *loggedTaskRepository) Update(ctx context.Context, task *aggregate.Task) error {
+	previous, err := r.TaskRepository.GetByID(ctx, task.ID())
+	if err != nil {
+		return err
+	}
+	state := r.remember(previous)
+	if err := r.TaskRepository.Update(ctx, task); err != nil {
+		return err
+	}
+	r.unitOfWork.logUndo(func() error { return r.restore(ctx, state) })
+	return nil
+}
+
+// Delete removes a task, undone by saving it again
+func (r *loggedTaskRepository) Delete(ctx context.Context, id value.TaskID) error {
+	previous, err := r.TaskRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.TaskRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.unitOfWork.logUndo(func() error { return r.TaskRepository.Save(ctx, previous) })
+	return nil
+}
+
+// loggedProjectRepository writes through to the project repository and logs how to undo each write
+type loggedProjectRepository struct {
+	domain.ProjectRepository
+	unitOfWork *InMemoryUnitOfWork
+}
+
+// GetByID retrieves a project, remembering its state as first loaded
+func (r *loggedProjectRepository) GetByID(ctx context.Context, id value.ProjectID) (*aggregate.Project, error) {
+	project, err := r.ProjectRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.remember(project)
+	return project, nil
+}
+
+// remember keeps a project's state unless an earlier one is kept
+func (r *loggedProjectRepository) remember(project *aggregate.Project) aggregate.ProjectState {
+	state, kept := r.unitOfWork.projects[project.ID().Value()]
+	if !kept {
+		state = project.ToState()
+		r.unitOfWork.projects[project.ID().Value()] = state
+	}
+	return state
+}
+
+// restore stores a project back as it was in a state
+func (r *loggedProjectRepository) restore(ctx context.Context, state aggregate.ProjectState) error {
+	project, err := aggregate.ProjectFromState(state)
+	if err != nil {
+		return err
+	}
+	return r.ProjectRepository.Update(ctx, project)
+}
+
+// Save persists a project, undone by deleting it
+func (r *loggedProjectRepository) Save(ctx context.Context, project *aggregate.Project) error {
+	if err := r.ProjectRepository.Save(ctx, project); err != nil {
+		return err
+	}
+	r.unitOfWork.logUndo(func() error { return r.ProjectRepository.Delete(ctx, project.ID()) })
+	return nil
+}
+
+// Update updates a project, undone by storing the project as it was loaded
+func (r *loggedProjectRepository) Update(ctx context.Context, project *aggregate.Project) error {
+	previous, err := r.ProjectRepository.GetByID(ctx, project.ID())
+	if err != nil {
+		return err
+	}
+	state := r.remember(previous)
+	if err := r.ProjectRepository.Update(ctx, project); err != nil {
+		return err
+	}
+	r.unitOfWork.logUndo(func() error { return r.restore(ctx, state) })
+	return nil
+}
+
+// Delete removes a project, undone by saving it again
+func (r *loggedProjectRepository) Delete(ctx context.Context, id value.ProjectID) error {
+	previous, err := r.ProjectRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.ProjectRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.unitOfWork.logUndo(func() error { return r.ProjectRepository.Save(ctx, previous) })
+	return nil
+}
+
+// Ensure InMemoryUnitOfWork implements domain.UnitOfWork
+var _ domain.UnitOfWork = (*InMemoryUnitOfWork)(nil)
@@ -1,11 +1,15 @@
 package repository
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/miladev95/ddd-task/domain"
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
@@ -23,7 +27,7 @@ func NewInMemoryUserRepository() *InMemoryUserRepository {
 }
 
 // Save persists a user to the repository
-func (r *InMemoryUserRepository) Save(user *aggregate.User) error {
+func (r *InMemoryUserRepository) Save(ctx context.Context, user *aggregate.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -36,20 +40,20 @@ func (r *InMemoryUserRepository) Save(user *aggregate.User) error {
 }
 
 // GetByID retrieves a user by ID
-func (r *InMemoryUserRepository) GetByID(id value.UserID) (*aggregate.User, error) {
+func (r *InMemoryUserRepository) GetByID(ctx context.Context, id value.UserID) (*aggregate.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	user, exists := r.users[id.Value()]
 	if !exists {
-		return nil, fmt.Errorf("user not found")
+		return nil, errs.NotFound("user not found")
 	}
 
 	return user, nil
 }
 
 // GetByEmail retrieves a user by email
-func (r *InMemoryUserRepository) GetByEmail(email string) (*aggregate.User, error) {
+func (r *InMemoryUserRepository) GetByEmail(ctx context.Context, email string) (*aggregate.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -59,11 +63,11 @@ func (r *InMemoryUserRepository) GetByEmail(email string) (*aggregate.User, erro
 		}
 	}
 
-	return nil, fmt.Errorf("user not found")
+	return nil, errs.NotFound("user not found")
 }
 
 // GetAll retrieves all users
-func (r *InMemoryUserRepository) GetAll() ([]*aggregate.User, error) {
+func (r *InMemoryUserRepository) GetAll(ctx context.Context) ([]*aggregate.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -76,12 +80,12 @@ func (r *InMemoryUserRepository) GetAll() ([]*aggregate.User, error) {
 }
 
 // Delete removes a user from the repository
-func (r *InMemoryUserRepository) Delete(id value.UserID) error {
+func (r *InMemoryUserRepository) Delete(ctx context.Context, id value.UserID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, exists := r.users[id.Value()]; !exists {
-		return fmt.Errorf("user not found")
+		return errs.NotFound("user not found")
 	}
 
 	delete(r.users, id.Value())
@@ -89,7 +93,7 @@ func (r *InMemoryUserRepository) Delete(id value.UserID) error {
 }
 
 // Update updates an existing user
-func (r *InMemoryUserRepository) Update(user *aggregate.User) error {
+func (r *InMemoryUserRepository) Update(ctx context.Context, user *aggregate.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -98,7 +102,7 @@ func (r *InMemoryUserRepository) Update(user *aggregate.User) error {
 	}
 
 	if _, exists := r.users[user.ID().Value()]; !exists {
-		return fmt.Errorf("user not found")
+		return errs.NotFound("user not found")
 	}
 
 	r.users[user.ID().Value()] = user
@@ -106,7 +110,7 @@ func (r *InMemoryUserRepository) Update(user *aggregate.User) error {
 }
 
 // GetActive retrieves all active users
-func (r *InMemoryUserRepository) GetActive() ([]*aggregate.User, error) {
+func (r *InMemoryUserRepository) GetActive(ctx context.Context) ([]*aggregate.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -120,5 +124,34 @@ func (r *InMemoryUserRepository) GetActive() ([]*aggregate.User, error) {
 	return users, nil
 }
 
+// List retrieves a page of the users matching a filter
+func (r *InMemoryUserRepository) List(ctx context.Context, filter domain.UserFilter, page domain.Page) ([]*aggregate.User, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.UserSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	r.mu.RLock()
+	users := make([]*aggregate.User, 0)
+	for _, user := range r.users {
+		if filter.Active == nil || user.IsActive() == *filter.Active {
+			users = append(users, user)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(users, func(i, j int) bool {
+		a, b := users[i], users[j]
+		compared := a.CreatedAt().Compare(b.CreatedAt())
+		if page.SortBy == "email" {
+			compared = strings.Compare(a.Email(), b.Email())
+		}
+		return page.Before(compared, a.ID().Value(), b.ID().Value())
+	})
+
+	start, end := page.Window(len(users))
+	return users[start:end], page.Result(len(users)), nil
+}
+
 // Ensure InMemoryUserRepository implements domain.UserRepository
 var _ domain.UserRepository = (*InMemoryUserRepository)(nil)
\ No newline at end of file
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// InMemoryWidgetRepository is an in-memory implementation of WidgetRepository
+type InMemoryWidgetRepository struct {
+	widgets map[string]*aggregate.Widget
+	mu      sync.RWMutex
+}
+
+// NewInMemoryWidgetRepository creates a new InMemoryWidgetRepository
+func NewInMemoryWidgetRepository() *InMemoryWidgetRepository {
+	return &InMemoryWidgetRepository{
+		widgets: make(map[string]*aggregate.Widget),
+	}
+}
+
+// Save persists a widget to the repository
+func (r *InMemoryWidgetRepository) Save(ctx context.Context, widget *aggregate.Widget) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if widget == nil {
+		return fmt.Errorf("widget cannot be nil")
+	}
+
+	r.widgets[widget.ID().Value()] = widget
+	return nil
+}
+
+// GetByID retrieves a widget by ID
+func (r *InMemoryWidgetRepository) GetByID(ctx context.Context, id value.WidgetID) (*aggregate.Widget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	widget, exists := r.widgets[id.Value()]
+	if !exists {
+		return nil, errs.NotFound("widget not found")
+	}
+
+	return widget, nil
+}
+
+// GetByOwnerID retrieves all widgets owned by a user, ordered by layout position
+func (r *InMemoryWidgetRepository) GetByOwnerID(ctx context.Context, userID value.UserID) ([]*aggregate.Widget, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	widgets := make([]*aggregate.Widget, 0)
+	for _, widget := range r.widgets {
+		if widget.IsOwnedBy(userID) {
+			widgets = append(widgets, widget)
+		}
+	}
+
+	sort.Slice(widgets, func(i, j int) bool {
+		a, b := widgets[i].Layout(), widgets[j].Layout()
+		if a.Row() != b.Row() {
+			return a.Row() < b.Row()
+		}
+		return a.Column() < b.Column()
+	})
+
+	return widgets, nil
+}
+
+// Delete removes a widget from the repository
+func (r *InMemoryWidgetRepository) Delete(ctx context.Context, id value.WidgetID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.widgets[id.Value()]; !exists {
+		return errs.NotFound("widget not found")
+	}
+
+	delete(r.widgets, id.Value())
+	return nil
+}
+
+// Update updates an existing widget
+func (r *InMemoryWidgetRepository) Update(ctx context.Context, widget *aggregate.Widget) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if widget == nil {
+		return fmt.Errorf("widget cannot be nil")
+	}
+
+	if _, exists := r.widgets[widget.ID().Value()]; !exists {
+		return errs.NotFound("widget not found")
+	}
+
+	r.widgets[widget.ID().Value()] = widget
+	return nil
+}
+
+// List retrieves a page of the widgets matching a filter
+func (r *InMemoryWidgetRepository) List(ctx context.Context, filter domain.WidgetFilter, page domain.Page) ([]*aggregate.Widget, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.WidgetSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	r.mu.RLock()
+	widgets := make([]*aggregate.Widget, 0)
+	for _, widget := range r.widgets {
+		if filter.OwnerID == "" || widget.OwnerID().Value() == filter.OwnerID {
+			widgets = append(widgets, widget)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(widgets, func(i, j int) bool {
+		a, b := widgets[i], widgets[j]
+		var compared int
+		switch page.SortBy {
+		case "created_at":
+			compared = a.CreatedAt().Compare(b.CreatedAt())
+		case "title":
+			compared = strings.Compare(a.Title(), b.Title())
+		default:
+			compared = a.Layout().Row() - b.Layout().Row()
+			if compared == 0 {
+				compared = a.Layout().Column() - b.Layout().Column()
+			}
+		}
+		return page.Before(compared, a.ID().Value(), b.ID().Value())
+	})
+
+	start, end := page.Window(len(widgets))
+	return widgets[start:end], page.Result(len(widgets)), nil
+}
+
+// Ensure InMemoryWidgetRepository implements domain.WidgetRepository
+var _ domain.WidgetRepository = (*InMemoryWidgetRepository)(nil)
@@ -1,17 +1,23 @@
 package repository
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/miladev95/ddd-task/domain"
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
-// InMemoryWorkflowRepository is an in-memory implementation of WorkflowRepository
+// InMemoryWorkflowRepository is an in-memory implementation of WorkflowRepository.
+// It keeps every version of a workflow, lookups by ID return the latest.
 type InMemoryWorkflowRepository struct {
 	workflows map[string]*aggregate.Workflow
+	versions  map[string]map[int]*aggregate.Workflow
 	mu        sync.RWMutex
 }
 
@@ -19,11 +25,12 @@ type InMemoryWorkflowRepository struct {
 func NewInMemoryWorkflowRepository() *InMemoryWorkflowRepository {
 	return &InMemoryWorkflowRepository{
 		workflows: make(map[string]*aggregate.Workflow),
+		versions:  make(map[string]map[int]*aggregate.Workflow),
 	}
 }
 
 // Save persists a workflow to the repository
-func (r *InMemoryWorkflowRepository) Save(workflow *aggregate.Workflow) error {
+func (r *InMemoryWorkflowRepository) Save(ctx context.Context, workflow *aggregate.Workflow) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -32,24 +39,38 @@ func (r *InMemoryWorkflowRepository) Save(workflow *aggregate.Workflow) error {
 	}
 
 	r.workflows[workflow.ID().Value()] = workflow
+	r.versions[workflow.ID().Value()] = map[int]*aggregate.Workflow{workflow.Version(): workflow}
 	return nil
 }
 
-// GetByID retrieves a workflow by ID
-func (r *InMemoryWorkflowRepository) GetByID(id value.WorkflowID) (*aggregate.Workflow, error) {
+// GetByID retrieves the latest version of a workflow by ID
+func (r *InMemoryWorkflowRepository) GetByID(ctx context.Context, id value.WorkflowID) (*aggregate.Workflow, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	workflow, exists := r.workflows[id.Value()]
 	if !exists {
-		return nil, fmt.Errorf("workflow not found")
+		return nil, errs.NotFound("workflow not found")
+	}
+
+	return workflow, nil
+}
+
+// GetVersion retrieves one version of a workflow
+func (r *InMemoryWorkflowRepository) GetVersion(ctx context.Context, version value.WorkflowVersion) (*aggregate.Workflow, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	workflow, exists := r.versions[version.WorkflowID().Value()][version.Number()]
+	if !exists {
+		return nil, errs.NotFound("workflow version not found: %s", version)
 	}
 
 	return workflow, nil
 }
 
 // GetByName retrieves a workflow by name
-func (r *InMemoryWorkflowRepository) GetByName(name string) (*aggregate.Workflow, error) {
+func (r *InMemoryWorkflowRepository) GetByName(ctx context.Context, name string) (*aggregate.Workflow, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -59,11 +80,11 @@ func (r *InMemoryWorkflowRepository) GetByName(name string) (*aggregate.Workflow
 		}
 	}
 
-	return nil, fmt.Errorf("workflow not found")
+	return nil, errs.NotFound("workflow not found")
 }
 
 // GetAll retrieves all workflows
-func (r *InMemoryWorkflowRepository) GetAll() ([]*aggregate.Workflow, error) {
+func (r *InMemoryWorkflowRepository) GetAll(ctx context.Context) ([]*aggregate.Workflow, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -76,20 +97,21 @@ func (r *InMemoryWorkflowRepository) GetAll() ([]*aggregate.Workflow, error) {
 }
 
 // Delete removes a workflow from the repository
-func (r *InMemoryWorkflowRepository) Delete(id value.WorkflowID) error {
+func (r *InMemoryWorkflowRepository) Delete(ctx context.Context, id value.WorkflowID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, exists := r.workflows[id.Value()]; !exists {
-		return fmt.Errorf("workflow not found")
+		return errs.NotFound("workflow not found")
 	}
 
 	delete(r.workflows, id.Value())
+	delete(r.versions, id.Value())
 	return nil
 }
 
 // Update updates an existing workflow
-func (r *InMemoryWorkflowRepository) Update(workflow *aggregate.Workflow) error {
+func (r *InMemoryWorkflowRepository) Update(ctx context.Context, workflow *aggregate.Workflow) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -97,16 +119,22 @@ func (r *InMemoryWorkflowRepository) Update(workflow *aggregate.Workflow) error
 		return fmt.Errorf("workflow cannot be nil")
 	}
 
-	if _, exists := r.workflows[workflow.ID().Value()]; !exists {
-		return fmt.Errorf("workflow not found")
+	latest, exists := r.workflows[workflow.ID().Value()]
+	if !exists {
+		return errs.NotFound("workflow not found")
+	}
+
+	if workflow.Version() < latest.Version() {
+		return errs.Conflict("workflow version %d is out of date, the latest is %d", workflow.Version(), latest.Version())
 	}
 
 	r.workflows[workflow.ID().Value()] = workflow
+	r.versions[workflow.ID().Value()][workflow.Version()] = workflow
 	return nil
 }
 
 // GetActive retrieves all active workflows
-func (r *InMemoryWorkflowRepository) GetActive() ([]*aggregate.Workflow, error) {
+func (r *InMemoryWorkflowRepository) GetActive(ctx context.Context) ([]*aggregate.Workflow, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -120,5 +148,34 @@ func (r *InMemoryWorkflowRepository) GetActive() ([]*aggregate.Workflow, error)
 	return workflows, nil
 }
 
+// List retrieves a page of the latest versions of the workflows matching a filter
+func (r *InMemoryWorkflowRepository) List(ctx context.Context, filter domain.WorkflowFilter, page domain.Page) ([]*aggregate.Workflow, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.WorkflowSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	r.mu.RLock()
+	workflows := make([]*aggregate.Workflow, 0)
+	for _, workflow := range r.workflows {
+		if filter.Active == nil || workflow.IsActive() == *filter.Active {
+			workflows = append(workflows, workflow)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(workflows, func(i, j int) bool {
+		a, b := workflows[i], workflows[j]
+		compared := a.CreatedAt().Compare(b.CreatedAt())
+		if page.SortBy == "name" {
+			compared = strings.Compare(a.Name(), b.Name())
+		}
+		return page.Before(compared, a.ID().Value(), b.ID().Value())
+	})
+
+	start, end := page.Window(len(workflows))
+	return workflows[start:end], page.Result(len(workflows)), nil
+}
+
 // Ensure InMemoryWorkflowRepository implements domain.WorkflowRepository
 var _ domain.WorkflowRepository = (*InMemoryWorkflowRepository)(nil)
\ No newline at end of file
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Snapshotter is an in-memory store whose whole content can be written to a file and read back
+type Snapshotter interface {
+	// SnapshotName is the name of the store's file in a snapshot directory
+	SnapshotName() string
+
+	// WriteSnapshot writes everything the store holds
+	WriteSnapshot(w io.Writer) error
+
+	// ReadSnapshot replaces what the store holds with a snapshot it wrote before
+	ReadSnapshot(r io.Reader) error
+}
+
+// SnapshotDirectory keeps snapshots of in-memory stores in a directory, one file per store,
+// so demo and development data survives restarts. Each file is replaced as a whole, never
+// left half written, but the files of one Save are not written together: a crash between
+// two of them leaves stores from different moments.
+type SnapshotDirectory struct {
+	dir    string
+	stores []Snapshotter
+	mu     sync.Mutex // held while saving, so saves do not interleave
+}
+
+// NewSnapshotDirectory creates a SnapshotDirectory, creating the directory when it does not exist
+func NewSnapshotDirectory(dir string, stores ...Snapshotter) (*SnapshotDirectory, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("snapshot directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	return &SnapshotDirectory{
+		dir:    dir,
+		stores: stores,
+	}, nil
+}
+
+// Save writes a snapshot of every store
+func (d *SnapshotDirectory) Save() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, store := range d.stores {
+		if err := d.save(store); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load reads back the snapshot of every store that has one and returns how many were loaded.
+// Stores without a snapshot file are left as they are.
+func (d *SnapshotDirectory) Load() (int, error) {
+	loaded := 0
+	for _, store := range d.stores {
+		file, err := os.Open(filepath.Join(d.dir, store.SnapshotName()))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return loaded, fmt.Errorf("failed to open snapshot %s: %w", store.SnapshotName(), err)
+		}
+
+		err = store.ReadSnapshot(file)
+		file.Close()
+		if err != nil {
+			return loaded, fmt.Errorf("failed to load snapshot %s: %w", store.SnapshotName(), err)
+		}
+		loaded++
+	}
+	return loaded, nil
+}
+
+// save writes a store to a temporary file and moves it over the previous snapshot
+func (d *SnapshotDirectory) save(store Snapshotter) error {
+	path := filepath.Join(d.dir, store.SnapshotName())
+	file, err := os.CreateTemp(d.dir, store.SnapshotName()+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot %s: %w", store.SnapshotName(), err)
+	}
+	defer os.Remove(file.Name())
+
+	if err := store.WriteSnapshot(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write snapshot %s: %w", store.SnapshotName(), err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write snapshot %s: %w", store.SnapshotName(), err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot %s: %w", store.SnapshotName(), err)
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot %s: %w", store.SnapshotName(), err)
+	}
+	return nil
+}
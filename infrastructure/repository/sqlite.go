@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// SQLiteSchema creates the tables of the SQLite repositories. Each aggregate is stored as its
+// memento in the state column, next to the few fields its lookups filter and order on.
+const SQLiteSchema = `
+CREATE TABLE IF NOT EXISTS tasks (
+    id          TEXT PRIMARY KEY,
+    project_id  TEXT NOT NULL,
+    assignee_id TEXT,
+    team_id     TEXT,
+    status      TEXT NOT NULL,
+    deleted     INTEGER NOT NULL DEFAULT 0,
+    state       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS tasks_project ON tasks (project_id, status);
+CREATE INDEX IF NOT EXISTS tasks_assignee ON tasks (assignee_id);
+CREATE INDEX IF NOT EXISTS tasks_team ON tasks (team_id);
+CREATE INDEX IF NOT EXISTS tasks_status ON tasks (status);
+
+CREATE TABLE IF NOT EXISTS projects (
+    id       TEXT PRIMARY KEY,
+    owner_id TEXT NOT NULL,
+    archived INTEGER NOT NULL,
+    state    TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS projects_owner ON projects (owner_id);
+
+CREATE TABLE IF NOT EXISTS users (
+    id     TEXT PRIMARY KEY,
+    email  TEXT NOT NULL,
+    active INTEGER NOT NULL,
+    state  TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS users_email ON users (email);
+
+CREATE TABLE IF NOT EXISTS workflows (
+    id      TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    name    TEXT NOT NULL,
+    active  INTEGER NOT NULL,
+    state   TEXT NOT NULL,
+    PRIMARY KEY (id, version)
+);
+CREATE INDEX IF NOT EXISTS workflows_name ON workflows (name);
+
+CREATE TABLE IF NOT EXISTS widgets (
+    id            TEXT PRIMARY KEY,
+    owner_id      TEXT NOT NULL,
+    layout_row    INTEGER NOT NULL,
+    layout_column INTEGER NOT NULL,
+    state         TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS widgets_owner ON widgets (owner_id);
+
+CREATE TABLE IF NOT EXISTS sprints (
+    id         TEXT PRIMARY KEY,
+    project_id TEXT NOT NULL,
+    start_date INTEGER NOT NULL,
+    state      TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS sprints_project ON sprints (project_id, start_date);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id    TEXT PRIMARY KEY,
+    name  TEXT NOT NULL,
+    state TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id    TEXT PRIMARY KEY,
+    state TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS holiday_calendars (
+    id              TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL,
+    region          TEXT NOT NULL,
+    state           TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS holiday_calendars_organization ON holiday_calendars (organization_id, region);
+
+CREATE TABLE IF NOT EXISTS recent_views (
+    user_id   TEXT NOT NULL,
+    kind      TEXT NOT NULL,
+    item_id   TEXT NOT NULL,
+    viewed_at INTEGER NOT NULL,
+    PRIMARY KEY (user_id, kind, item_id)
+);
+
+CREATE TABLE IF NOT EXISTS process_states (
+    id              TEXT PRIMARY KEY,
+    status          TEXT NOT NULL,
+    next_attempt_at INTEGER NOT NULL,
+    updated_at      INTEGER NOT NULL,
+    state           TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS process_states_status ON process_states (status, next_attempt_at);
+`
+
+// SQLExecutor runs statements on either a database or a transaction,
+// so the same SQL repository works inside and outside a unit of work
+type SQLExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// OpenSQLite opens the SQLite database file at path, creating it when it does not exist.
+// The database is journaled with a write-ahead log, so readers never wait for a writer,
+// and writers wait up to five seconds for each other instead of failing right away.
+func OpenSQLite(path string) (*sql.DB, error) {
+	if path == "" {
+		return nil, fmt.Errorf("sqlite database path cannot be empty")
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	return db, nil
+}
+
+// EnsureSQLiteSchema creates the tables and indexes of the SQLite repositories when they do not exist yet
+func EnsureSQLiteSchema(db *sql.DB) error {
+	if _, err := db.Exec(SQLiteSchema); err != nil {
+		return fmt.Errorf("failed to create sqlite schema: %w", err)
+	}
+	return nil
+}
+
+// encodeState encodes an aggregate's memento for the state column
+func encodeState(state interface{}) ([]byte, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	return data, nil
+}
+
+// queryState reads the state column of the row a query selects, failing with notFound without one
+func queryState(ctx context.Context, db SQLExecutor, notFound string, query string, args ...interface{}) ([]byte, error) {
+	var data []byte
+	err := db.QueryRowContext(ctx, query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFound("%s", notFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	return data, nil
+}
+
+// queryStates reads the state column of every row a query selects, in the order it returns them
+func queryStates(ctx context.Context, db SQLExecutor, query string, args ...interface{}) ([][]byte, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read states: %w", err)
+	}
+	defer rows.Close()
+
+	states := make([][]byte, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read state: %w", err)
+		}
+		states = append(states, data)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read states: %w", err)
+	}
+	return states, nil
+}
+
+// execExisting runs a statement that changes an existing row, failing with notFound when none was changed
+func execExisting(ctx context.Context, db SQLExecutor, notFound string, query string, args ...interface{}) error {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+
+	changed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if changed == 0 {
+		return errs.NotFound("%s", notFound)
+	}
+	return nil
+}
+
+// queryPage reads the state column of a resolved page of the rows a query selects and counts
+// all of them. from is the query after its SELECT list; rows are ordered by the expressions
+// sortColumns gives the page's sort field, then by ID.
+func queryPage(
+	ctx context.Context,
+	db SQLExecutor,
+	from string,
+	sortColumns map[string][]string,
+	page domain.Page,
+	args ...interface{},
+) ([][]byte, domain.PagedResult, error) {
+	var total int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) `+from, args...).Scan(&total); err != nil {
+		return nil, domain.PagedResult{}, fmt.Errorf("failed to count states: %w", err)
+	}
+
+	direction := string(page.SortDir)
+	order := make([]string, 0, len(sortColumns[page.SortBy])+1)
+	for _, column := range append(sortColumns[page.SortBy], `id`) {
+		order = append(order, column+` `+direction)
+	}
+	states, err := queryStates(ctx, db,
+		`SELECT state `+from+` ORDER BY `+strings.Join(order, `, `)+` LIMIT ? OFFSET ?`,
+		append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+	return states, page.Result(total), nil
+}
+
+// sqliteWhere joins the conditions of a query, selecting every row when there are none
+func sqliteWhere(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return ` WHERE ` + strings.Join(conditions, ` AND `)
+}
+
+// sqliteTime orders rows by a time in their state. SQLite reads times to the millisecond,
+// so rows closer together than that are ordered by ID.
+func sqliteTime(field string) string {
+	return `julianday(json_extract(state, '$.` + field + `'))`
+}
+
+// sqliteBool stores a flag as the 0 or 1 SQLite compares it with
+func sqliteBool(flag bool) int {
+	if flag {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteHolidayCalendarRepository is a SQLite implementation of HolidayCalendarRepository
+type SQLiteHolidayCalendarRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteHolidayCalendarRepository creates a new SQLiteHolidayCalendarRepository on a database or transaction
+func NewSQLiteHolidayCalendarRepository(db SQLExecutor) *SQLiteHolidayCalendarRepository {
+	return &SQLiteHolidayCalendarRepository{db: db}
+}
+
+// Save persists a holiday calendar to the repository. Each region of an organization has
+// one calendar.
+func (r *SQLiteHolidayCalendarRepository) Save(ctx context.Context, calendar *aggregate.HolidayCalendar) error {
+	if calendar == nil {
+		return fmt.Errorf("holiday calendar cannot be nil")
+	}
+
+	var existing string
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM holiday_calendars WHERE organization_id = ? AND region = ? AND id <> ?`,
+		calendar.OrganizationID().Value(), calendar.Region(), calendar.ID().Value()).Scan(&existing)
+	if err == nil {
+		return errs.Conflict("holiday calendar for region %s already exists", calendar.Region())
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to save holiday calendar: %w", err)
+	}
+
+	state, err := encodeState(calendar.ToState())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO holiday_calendars (id, organization_id, region, state) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET organization_id = excluded.organization_id, region = excluded.region, state = excluded.state`,
+		calendar.ID().Value(), calendar.OrganizationID().Value(), calendar.Region(), state)
+	if err != nil {
+		return fmt.Errorf("failed to save holiday calendar: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a holiday calendar by ID
+func (r *SQLiteHolidayCalendarRepository) GetByID(ctx context.Context, id value.HolidayCalendarID) (*aggregate.HolidayCalendar, error) {
+	data, err := queryState(ctx, r.db, "holiday calendar not found", `SELECT state FROM holiday_calendars WHERE id = ?`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByOrganizationID retrieves all holiday calendars of an organization, ordered by region
+func (r *SQLiteHolidayCalendarRepository) GetByOrganizationID(ctx context.Context, organizationID value.OrganizationID) ([]*aggregate.HolidayCalendar, error) {
+	states, err := queryStates(ctx, r.db, `SELECT state FROM holiday_calendars WHERE organization_id = ? ORDER BY region`,
+		organizationID.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	calendars := make([]*aggregate.HolidayCalendar, 0, len(states))
+	for _, data := range states {
+		calendar, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		calendars = append(calendars, calendar)
+	}
+	return calendars, nil
+}
+
+// Delete removes a holiday calendar from the repository
+func (r *SQLiteHolidayCalendarRepository) Delete(ctx context.Context, id value.HolidayCalendarID) error {
+	return execExisting(ctx, r.db, "holiday calendar not found", `DELETE FROM holiday_calendars WHERE id = ?`, id.Value())
+}
+
+// Update updates an existing holiday calendar
+func (r *SQLiteHolidayCalendarRepository) Update(ctx context.Context, calendar *aggregate.HolidayCalendar) error {
+	if calendar == nil {
+		return fmt.Errorf("holiday calendar cannot be nil")
+	}
+
+	state, err := encodeState(calendar.ToState())
+	if err != nil {
+		return err
+	}
+
+	return execExisting(ctx, r.db, "holiday calendar not found", `
+		UPDATE holiday_calendars SET organization_id = ?, region = ?, state = ? WHERE id = ?`,
+		calendar.OrganizationID().Value(), calendar.Region(), state, calendar.ID().Value())
+}
+
+// holidayCalendarSortColumns orders holiday calendars by each of domain.HolidayCalendarSortFields
+var holidayCalendarSortColumns = map[string][]string{
+	"region":     {`region`},
+	"created_at": {sqliteTime("created_at")},
+	"name":       {`json_extract(state, '$.name')`},
+}
+
+// List retrieves a page of the holiday calendars matching a filter
+func (r *SQLiteHolidayCalendarRepository) List(
+	ctx context.Context,
+	filter domain.HolidayCalendarFilter,
+	page domain.Page,
+) ([]*aggregate.HolidayCalendar, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.HolidayCalendarSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.OrganizationID != "" {
+		conditions = append(conditions, `organization_id = ?`)
+		args = append(args, filter.OrganizationID)
+	}
+
+	states, result, err := queryPage(ctx, r.db, `FROM holiday_calendars`+sqliteWhere(conditions), holidayCalendarSortColumns, page, args...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	calendars := make([]*aggregate.HolidayCalendar, 0, len(states))
+	for _, data := range states {
+		calendar, err := r.decode(data)
+		if err != nil {
+			return nil, domain.PagedResult{}, err
+		}
+		calendars = append(calendars, calendar)
+	}
+	return calendars, result, nil
+}
+
+// decode restores a holiday calendar from its stored state
+func (r *SQLiteHolidayCalendarRepository) decode(data []byte) (*aggregate.HolidayCalendar, error) {
+	var state aggregate.HolidayCalendarState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode holiday calendar: %w", err)
+	}
+	return aggregate.HolidayCalendarFromState(state)
+}
+
+// Ensure SQLiteHolidayCalendarRepository implements domain.HolidayCalendarRepository
+var _ domain.HolidayCalendarRepository = (*SQLiteHolidayCalendarRepository)(nil)
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteOrganizationRepository is a SQLite implementation of OrganizationRepository
+type SQLiteOrganizationRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteOrganizationRepository creates a new SQLiteOrganizationRepository on a database or transaction
+func NewSQLiteOrganizationRepository(db SQLExecutor) *SQLiteOrganizationRepository {
+	return &SQLiteOrganizationRepository{db: db}
+}
+
+// Save persists an organization to the repository
+func (r *SQLiteOrganizationRepository) Save(ctx context.Context, organization *aggregate.Organization) error {
+	if organization == nil {
+		return fmt.Errorf("organization cannot be nil")
+	}
+
+	state, err := encodeState(organization.ToState())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO organizations (id, state) VALUES (?, ?)
+		ON CONFLICT (id) DO UPDATE SET state = excluded.state`,
+		organization.ID().Value(), state)
+	if err != nil {
+		return fmt.Errorf("failed to save organization: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an organization by ID
+func (r *SQLiteOrganizationRepository) GetByID(ctx context.Context, id value.OrganizationID) (*aggregate.Organization, error) {
+	data, err := queryState(ctx, r.db, "organization not found", `SELECT state FROM organizations WHERE id = ?`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByMemberID retrieves the organization a user belongs to
+func (r *SQLiteOrganizationRepository) GetByMemberID(ctx context.Context, userID value.UserID) (*aggregate.Organization, error) {
+	states, err := queryStates(ctx, r.db, `SELECT state FROM organizations`)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range states {
+		organization, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		if organization.HasMember(userID) {
+			return organization, nil
+		}
+	}
+	return nil, errs.NotFound("organization not found")
+}
+
+// Update updates an existing organization
+func (r *SQLiteOrganizationRepository) Update(ctx context.Context, organization *aggregate.Organization) error {
+	if organization == nil {
+		return fmt.Errorf("organization cannot be nil")
+	}
+
+	state, err := encodeState(organization.ToState())
+	if err != nil {
+		return err
+	}
+
+	return execExisting(ctx, r.db, "organization not found", `UPDATE organizations SET state = ? WHERE id = ?`,
+		state, organization.ID().Value())
+}
+
+// decode restores an organization from its stored state
+func (r *SQLiteOrganizationRepository) decode(data []byte) (*aggregate.Organization, error) {
+	var state aggregate.OrganizationState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode organization: %w", err)
+	}
+	return aggregate.OrganizationFromState(state)
+}
+
+// Ensure SQLiteOrganizationRepository implements domain.OrganizationRepository
+var _ domain.OrganizationRepository = (*SQLiteOrganizationRepository)(nil)
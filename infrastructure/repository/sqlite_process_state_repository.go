@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/process"
+	"github.com/miladev95/ddd-task/domain/errs"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+)
+
+// storedProcessState is the stored form of a process state, its event in the serializer's envelope
+type storedProcessState struct {
+	ID            string          `json:"id"`
+	Reaction      string          `json:"reaction"`
+	Event         json.RawMessage `json:"event"`
+	Status        process.Status  `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// SQLiteProcessStateRepository is a SQLite implementation of process.StateStore.
+// Events are stored with the serializer's envelopes, so every event type reacted to
+// must be registered with it.
+type SQLiteProcessStateRepository struct {
+	db         SQLExecutor
+	serializer *infraEvent.EventSerializer
+}
+
+// NewSQLiteProcessStateRepository creates a new SQLiteProcessStateRepository on a database or transaction
+func NewSQLiteProcessStateRepository(db SQLExecutor, serializer *infraEvent.EventSerializer) *SQLiteProcessStateRepository {
+	return &SQLiteProcessStateRepository{
+		db:         db,
+		serializer: serializer,
+	}
+}
+
+// Save persists the state of a reaction, replacing the one stored before
+func (r *SQLiteProcessStateRepository) Save(ctx context.Context, state process.State) error {
+	if state.ID == "" {
+		return errs.Invalid("process state id cannot be empty")
+	}
+
+	envelope, err := r.serializer.Serialize(state.Event)
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeState(storedProcessState{
+		ID:            state.ID,
+		Reaction:      state.Reaction,
+		Event:         envelope,
+		Status:        state.Status,
+		Attempts:      state.Attempts,
+		LastError:     state.LastError,
+		NextAttemptAt: state.NextAttemptAt,
+		UpdatedAt:     state.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO process_states (id, status, next_attempt_at, updated_at, state) VALUES (?, ?, ?, ?, ?)`,
+		state.ID, string(state.Status), state.NextAttemptAt.UnixNano(), state.UpdatedAt.UnixNano(), data)
+	if err != nil {
+		return fmt.Errorf("failed to save process state: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves the state of a reaction by ID
+func (r *SQLiteProcessStateRepository) GetByID(ctx context.Context, id string) (process.State, error) {
+	data, err := queryState(ctx, r.db, "process state not found", `SELECT state FROM process_states WHERE id = ?`, id)
+	if err != nil {
+		return process.State{}, err
+	}
+	return r.decode(data)
+}
+
+// GetDue retrieves the pending states whose next attempt is due, the longest waiting first
+func (r *SQLiteProcessStateRepository) GetDue(ctx context.Context, now time.Time) ([]process.State, error) {
+	return r.query(ctx, `
+		SELECT state FROM process_states WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at`,
+		string(process.StatusPending), now.UnixNano())
+}
+
+// GetByStatus retrieves the states with a status, the most recently updated first
+func (r *SQLiteProcessStateRepository) GetByStatus(ctx context.Context, status process.Status) ([]process.State, error) {
+	return r.query(ctx, `SELECT state FROM process_states WHERE status = ? ORDER BY updated_at DESC`, string(status))
+}
+
+// decode restores a process state and its event
+func (r *SQLiteProcessStateRepository) decode(data []byte) (process.State, error) {
+	var stored storedProcessState
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return process.State{}, fmt.Errorf("failed to decode process state: %w", err)
+	}
+
+	evt, err := r.serializer.Deserialize(stored.Event)
+	if err != nil {
+		return process.State{}, err
+	}
+
+	return process.State{
+		ID:            stored.ID,
+		Reaction:      stored.Reaction,
+		Event:         evt,
+		Status:        stored.Status,
+		Attempts:      stored.Attempts,
+		LastError:     stored.LastError,
+		NextAttemptAt: stored.NextAttemptAt,
+		UpdatedAt:     stored.UpdatedAt,
+	}, nil
+}
+
+// query restores the process states a query selects
+func (r *SQLiteProcessStateRepository) query(ctx context.Context, query string, args ...interface{}) ([]process.State, error) {
+	rows, err := queryStates(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]process.State, 0, len(rows))
+	for _, data := range rows {
+		state, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// Ensure SQLiteProcessStateRepository implements process.StateStore
+var _ process.StateStore = (*SQLiteProcessStateRepository)(nil)
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteProjectRepository is a SQLite implementation of ProjectRepository
+type SQLiteProjectRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteProjectRepository creates a new SQLiteProjectRepository on a database or transaction
+func NewSQLiteProjectRepository(db SQLExecutor) *SQLiteProjectRepository {
+	return &SQLiteProjectRepository{db: db}
+}
+
+// Save persists a project to the repository
+func (r *SQLiteProjectRepository) Save(ctx context.Context, project *aggregate.Project) error {
+	if project == nil {
+		return fmt.Errorf("project cannot be nil")
+	}
+
+	state, err := encodeState(project.ToState())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO projects (id, owner_id, archived, state) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET owner_id = excluded.owner_id, archived = excluded.archived, state = excluded.state`,
+		project.ID().Value(), project.OwnerID().Value(), sqliteBool(project.IsArchived()), state)
+	if err != nil {
+		return fmt.Errorf("failed to save project: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a project by ID
+func (r *SQLiteProjectRepository) GetByID(ctx context.Context, id value.ProjectID) (*aggregate.Project, error) {
+	data, err := queryState(ctx, r.db, "project not found", `SELECT state FROM projects WHERE id = ?`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByOwnerID retrieves all projects owned by a user
+func (r *SQLiteProjectRepository) GetByOwnerID(ctx context.Context, userID value.UserID) ([]*aggregate.Project, error) {
+	return r.query(ctx, `SELECT state FROM projects WHERE owner_id = ?`, userID.Value())
+}
+
+// GetAll retrieves all projects
+func (r *SQLiteProjectRepository) GetAll(ctx context.Context) ([]*aggregate.Project, error) {
+	return r.query(ctx, `SELECT state FROM projects`)
+}
+
+// Delete removes a project from the repository
+func (r *SQLiteProjectRepository) Delete(ctx context.Context, id value.ProjectID) error {
+	return execExisting(ctx, r.db, "project not found", `DELETE FROM projects WHERE id = ?`, id.Value())
+}
+
+// Update updates an existing project
+func (r *SQLiteProjectRepository) Update(ctx context.Context, project *aggregate.Project) error {
+	if project == nil {
+		return fmt.Errorf("project cannot be nil")
+	}
+
+	state, err := encodeState(project.ToState())
+	if err != nil {
+		return err
+	}
+
+	return execExisting(ctx, r.db, "project not found", `UPDATE projects SET owner_id = ?, archived = ?, state = ? WHERE id = ?`,
+		project.OwnerID().Value(), sqliteBool(project.IsArchived()), state, project.ID().Value())
+}
+
+// GetActive retrieves all active projects
+func (r *SQLiteProjectRepository) GetActive(ctx context.Context) ([]*aggregate.Project, error) {
+	return r.query(ctx, `SELECT state FROM projects WHERE archived = 0`)
+}
+
+// GetByInboxToken retrieves the project one of whose inbox addresses has the token
+func (r *SQLiteProjectRepository) GetByInboxToken(ctx context.Context, token string) (*aggregate.Project, error) {
+	projects, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		if project.HasInboxToken(token) {
+			return project, nil
+		}
+	}
+	return nil, errs.NotFound("project not found")
+}
+
+// projectSortColumns orders projects by each of domain.ProjectSortFields
+var projectSortColumns = map[string][]string{
+	"created_at": {sqliteTime("created_at")},
+	"updated_at": {sqliteTime("updated_at")},
+	"name":       {`json_extract(state, '$.name')`},
+}
+
+// List retrieves a page of the projects matching a filter
+func (r *SQLiteProjectRepository) List(ctx context.Context, filter domain.ProjectFilter, page domain.Page) ([]*aggregate.Project, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.ProjectSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.OwnerID != "" {
+		conditions = append(conditions, `owner_id = ?`)
+		args = append(args, filter.OwnerID)
+	}
+	if filter.Archived != nil {
+		conditions = append(conditions, `archived = ?`)
+		args = append(args, sqliteBool(*filter.Archived))
+	}
+	if filter.VisibleOnly {
+		// Mirrors Project.CanView: the owner, users with a role and members see restricted projects
+		conditions = append(conditions, `(json_extract(state, '$.visibility') <> ?
+			OR owner_id = ?
+			OR EXISTS (SELECT 1 FROM json_each(state, '$.roles') WHERE key = ?)
+			OR EXISTS (SELECT 1 FROM json_each(state, '$.member_ids') WHERE value = ?))`)
+		args = append(args, value.VisibilityRestricted.Value(), filter.ViewerID, filter.ViewerID, filter.ViewerID)
+	}
+
+	states, result, err := queryPage(ctx, r.db, `FROM projects`+sqliteWhere(conditions), projectSortColumns, page, args...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	projects := make([]*aggregate.Project, 0, len(states))
+	for _, data := range states {
+		project, err := r.decode(data)
+		if err != nil {
+			return nil, domain.PagedResult{}, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, result, nil
+}
+
+// decode restores a project from its stored state
+func (r *SQLiteProjectRepository) decode(data []byte) (*aggregate.Project, error) {
+	var state aggregate.ProjectState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode project: %w", err)
+	}
+	return aggregate.ProjectFromState(state)
+}
+
+// query restores the projects a query selects
+func (r *SQLiteProjectRepository) query(ctx context.Context, query string, args ...interface{}) ([]*aggregate.Project, error) {
+	states, err := queryStates(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*aggregate.Project, 0, len(states))
+	for _, data := range states {
+		project, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+// Ensure SQLiteProjectRepository implements domain.ProjectRepository
+var _ domain.ProjectRepository = (*SQLiteProjectRepository)(nil)
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteRecentViewRepository is a SQLite implementation of RecentViewRepository
+// keeping a bounded, de-duplicated list per user
+type SQLiteRecentViewRepository struct {
+	db       SQLExecutor
+	capacity int
+}
+
+// NewSQLiteRecentViewRepository creates a new SQLiteRecentViewRepository on a database or transaction
+func NewSQLiteRecentViewRepository(db SQLExecutor, capacity int) *SQLiteRecentViewRepository {
+	if capacity <= 0 {
+		capacity = DefaultRecentViewCapacity
+	}
+
+	return &SQLiteRecentViewRepository{
+		db:       db,
+		capacity: capacity,
+	}
+}
+
+// Record stores a view, moving the item to the front of the user's list. Replacing a row
+// gives it the next rowid, so rowids order each user's views from the most recent.
+func (r *SQLiteRecentViewRepository) Record(ctx context.Context, userID value.UserID, view value.RecentView) error {
+	_, err := r.db.ExecContext(ctx, `INSERT OR REPLACE INTO recent_views (user_id, kind, item_id, viewed_at) VALUES (?, ?, ?, ?)`,
+		userID.Value(), string(view.Kind()), view.ItemID(), view.ViewedAt().UnixNano())
+	if err != nil {
+		return fmt.Errorf("failed to record view: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		DELETE FROM recent_views WHERE user_id = ? AND rowid NOT IN (
+			SELECT rowid FROM recent_views WHERE user_id = ? ORDER BY rowid DESC LIMIT ?)`,
+		userID.Value(), userID.Value(), r.capacity)
+	if err != nil {
+		return fmt.Errorf("failed to trim recent views: %w", err)
+	}
+	return nil
+}
+
+// GetRecent retrieves a user's most recent views, newest first
+func (r *SQLiteRecentViewRepository) GetRecent(ctx context.Context, userID value.UserID, limit int) ([]value.RecentView, error) {
+	// LIMIT -1 returns every row
+	if limit <= 0 {
+		limit = -1
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT kind, item_id, viewed_at FROM recent_views WHERE user_id = ? ORDER BY rowid DESC LIMIT ?`,
+		userID.Value(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recent views: %w", err)
+	}
+	defer rows.Close()
+
+	views := make([]value.RecentView, 0)
+	for rows.Next() {
+		var kind, itemID string
+		var viewedAt int64
+		if err := rows.Scan(&kind, &itemID, &viewedAt); err != nil {
+			return nil, fmt.Errorf("failed to read recent view: %w", err)
+		}
+
+		view, err := value.NewRecentView(value.ViewedItemKind(kind), itemID, time.Unix(0, viewedAt))
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recent views: %w", err)
+	}
+
+	return views, nil
+}
+
+// Ensure SQLiteRecentViewRepository implements domain.RecentViewRepository
+var _ domain.RecentViewRepository = (*SQLiteRecentViewRepository)(nil)
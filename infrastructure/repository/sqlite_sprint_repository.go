@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteSprintRepository is a SQLite implementation of SprintRepository
+type SQLiteSprintRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteSprintRepository creates a new SQLiteSprintRepository on a database or transaction
+func NewSQLiteSprintRepository(db SQLExecutor) *SQLiteSprintRepository {
+	return &SQLiteSprintRepository{db: db}
+}
+
+// Save persists a sprint to the repository
+func (r *SQLiteSprintRepository) Save(ctx context.Context, sprint *aggregate.Sprint) error {
+	if sprint == nil {
+		return fmt.Errorf("sprint cannot be nil")
+	}
+
+	state, err := encodeState(sprint.ToState())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO sprints (id, project_id, start_date, state) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET project_id = excluded.project_id, start_date = excluded.start_date, state = excluded.state`,
+		sprint.ID().Value(), sprint.ProjectID().Value(), sprint.StartDate().UnixNano(), state)
+	if err != nil {
+		return fmt.Errorf("failed to save sprint: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a sprint by ID
+func (r *SQLiteSprintRepository) GetByID(ctx context.Context, id value.SprintID) (*aggregate.Sprint, error) {
+	data, err := queryState(ctx, r.db, "sprint not found", `SELECT state FROM sprints WHERE id = ?`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByProjectID retrieves all sprints of a project, ordered by start date
+func (r *SQLiteSprintRepository) GetByProjectID(ctx context.Context, projectID value.ProjectID) ([]*aggregate.Sprint, error) {
+	states, err := queryStates(ctx, r.db, `SELECT state FROM sprints WHERE project_id = ? ORDER BY start_date`, projectID.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	sprints := make([]*aggregate.Sprint, 0, len(states))
+	for _, data := range states {
+		sprint, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, sprint)
+	}
+	return sprints, nil
+}
+
+// Update updates an existing sprint
+func (r *SQLiteSprintRepository) Update(ctx context.Context, sprint *aggregate.Sprint) error {
+	if sprint == nil {
+		return fmt.Errorf("sprint cannot be nil")
+	}
+
+	state, err := encodeState(sprint.ToState())
+	if err != nil {
+		return err
+	}
+
+	return execExisting(ctx, r.db, "sprint not found", `UPDATE sprints SET project_id = ?, start_date = ?, state = ? WHERE id = ?`,
+		sprint.ProjectID().Value(), sprint.StartDate().UnixNano(), state, sprint.ID().Value())
+}
+
+// sprintSortColumns orders sprints by each of domain.SprintSortFields
+var sprintSortColumns = map[string][]string{
+	"start_date": {`start_date`},
+	"created_at": {sqliteTime("created_at")},
+	"name":       {`json_extract(state, '$.name')`},
+}
+
+// List retrieves a page of the sprints matching a filter
+func (r *SQLiteSprintRepository) List(
+	ctx context.Context,
+	filter domain.SprintFilter,
+	page domain.Page,
+) ([]*aggregate.Sprint, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.SprintSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.ProjectID != "" {
+		conditions = append(conditions, `project_id = ?`)
+		args = append(args, filter.ProjectID)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, `json_extract(state, '$.status') = ?`)
+		args = append(args, filter.Status)
+	}
+
+	states, result, err := queryPage(ctx, r.db, `FROM sprints`+sqliteWhere(conditions), sprintSortColumns, page, args...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	sprints := make([]*aggregate.Sprint, 0, len(states))
+	for _, data := range states {
+		sprint, err := r.decode(data)
+		if err != nil {
+			return nil, domain.PagedResult{}, err
+		}
+		sprints = append(sprints, sprint)
+	}
+	return sprints, result, nil
+}
+
+// decode restores a sprint from its stored state
+func (r *SQLiteSprintRepository) decode(data []byte) (*aggregate.Sprint, error) {
+	var state aggregate.SprintState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode sprint: %w", err)
+	}
+	return aggregate.SprintFromState(state)
+}
+
+// Ensure SQLiteSprintRepository implements domain.SprintRepository
+var _ domain.SprintRepository = (*SQLiteSprintRepository)(nil)
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteTaskRepository is a SQLite implementation of TaskRepository. Soft-deleted tasks are
+// kept in the table, flagged, and left out of every lookup except GetDeleted.
+type SQLiteTaskRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteTaskRepository creates a new SQLiteTaskRepository on a database or transaction
+func NewSQLiteTaskRepository(db SQLExecutor) *SQLiteTaskRepository {
+	return &SQLiteTaskRepository{db: db}
+}
+
+// Save persists a task to the repository
+func (r *SQLiteTaskRepository) Save(ctx context.Context, task *aggregate.Task) error {
+	if task == nil {
+		return fmt.Errorf("task cannot be nil")
+	}
+
+	task.SetVersion(task.Version() + 1)
+	state, assigneeID, teamID, err := r.encode(task)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO tasks (id, project_id, assignee_id, team_id, status, deleted, state)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			project_id = excluded.project_id, assignee_id = excluded.assignee_id, team_id = excluded.team_id,
+			status = excluded.status, deleted = excluded.deleted, state = excluded.state`,
+		task.ID().Value(), task.ProjectID().Value(), assigneeID, teamID, task.Status().Value(),
+		sqliteBool(task.IsDeleted()), state)
+	if err != nil {
+		return fmt.Errorf("failed to save task: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a task by ID
+func (r *SQLiteTaskRepository) GetByID(ctx context.Context, id value.TaskID) (*aggregate.Task, error) {
+	data, err := queryState(ctx, r.db, "task not found", `SELECT state FROM tasks WHERE id = ? AND deleted = 0`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByProjectID retrieves all tasks for a project
+func (r *SQLiteTaskRepository) GetByProjectID(ctx context.Context, projectID value.ProjectID) ([]*aggregate.Task, error) {
+	return r.query(ctx, `SELECT state FROM tasks WHERE project_id = ? AND deleted = 0`, projectID.Value())
+}
+
+// GetByAssigneeID retrieves all tasks assigned to a user
+func (r *SQLiteTaskRepository) GetByAssigneeID(ctx context.Context, userID value.UserID) ([]*aggregate.Task, error) {
+	return r.query(ctx, `SELECT state FROM tasks WHERE assignee_id = ? AND deleted = 0`, userID.Value())
+}
+
+// GetByTeamID retrieves all tasks assigned to a team
+func (r *SQLiteTaskRepository) GetByTeamID(ctx context.Context, teamID value.TeamID) ([]*aggregate.Task, error) {
+	return r.query(ctx, `SELECT state FROM tasks WHERE team_id = ? AND deleted = 0`, teamID.Value())
+}
+
+// GetByStatus retrieves all tasks with a specific status
+func (r *SQLiteTaskRepository) GetByStatus(ctx context.Context, status value.TaskStatus) ([]*aggregate.Task, error) {
+	return r.query(ctx, `SELECT state FROM tasks WHERE status = ? AND deleted = 0`, status.Value())
+}
+
+// GetAll retrieves all tasks
+func (r *SQLiteTaskRepository) GetAll(ctx context.Context) ([]*aggregate.Task, error) {
+	return r.query(ctx, `SELECT state FROM tasks WHERE deleted = 0`)
+}
+
+// Delete removes a task from the repository
+func (r *SQLiteTaskRepository) Delete(ctx context.Context, id value.TaskID) error {
+	return execExisting(ctx, r.db, "task not found", `DELETE FROM tasks WHERE id = ? AND deleted = 0`, id.Value())
+}
+
+// Update updates an existing task, unless it was stored again since the task was read.
+// Tasks stored before versioning count as version 0.
+func (r *SQLiteTaskRepository) Update(ctx context.Context, task *aggregate.Task) error {
+	if task == nil {
+		return fmt.Errorf("task cannot be nil")
+	}
+
+	version := task.Version()
+	task.SetVersion(version + 1)
+	state, assigneeID, teamID, err := r.encode(task)
+	if err != nil {
+		task.SetVersion(version)
+		return err
+	}
+
+	err = execExisting(ctx, r.db, "task not found", `
+		UPDATE tasks SET project_id = ?, assignee_id = ?, team_id = ?, status = ?, deleted = ?, state = ?
+		WHERE id = ? AND deleted = 0 AND COALESCE(json_extract(state, '$.version'), 0) = ?`,
+		task.ProjectID().Value(), assigneeID, teamID, task.Status().Value(), sqliteBool(task.IsDeleted()), state,
+		task.ID().Value(), version)
+	if errors.Is(err, errs.ErrNotFound) {
+		if _, lookupErr := r.GetByID(ctx, task.ID()); lookupErr == nil {
+			err = errs.ConcurrencyConflict("task %s was changed since it was read", task.ID().Value())
+		}
+	}
+	if err != nil {
+		task.SetVersion(version)
+		return err
+	}
+	return nil
+}
+
+// GetDeleted retrieves a soft-deleted task by ID
+func (r *SQLiteTaskRepository) GetDeleted(ctx context.Context, id value.TaskID) (*aggregate.Task, error) {
+	data, err := queryState(ctx, r.db, "task not found", `SELECT state FROM tasks WHERE id = ? AND deleted = 1`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// FindByProjectIDAndStatus retrieves tasks for a project with specific status
+func (r *SQLiteTaskRepository) FindByProjectIDAndStatus(
+	ctx context.Context,
+	projectID value.ProjectID,
+	status value.TaskStatus,
+) ([]*aggregate.Task, error) {
+	return r.query(ctx, `SELECT state FROM tasks WHERE project_id = ? AND status = ? AND deleted = 0`,
+		projectID.Value(), status.Value())
+}
+
+// taskSortColumns orders tasks by each of domain.TaskSortFields
+var taskSortColumns = map[string][]string{
+	"created_at": {sqliteTime("created_at")},
+	"updated_at": {sqliteTime("updated_at")},
+	"title":      {`json_extract(state, '$.title')`},
+	"deadline":   {sqliteTime("deadline")},
+	"votes":      {`json_array_length(state, '$.voter_ids')`},
+}
+
+// List retrieves a page of the tasks matching a filter. Tasks without a deadline sort before those with one.
+func (r *SQLiteTaskRepository) List(ctx context.Context, filter domain.TaskFilter, page domain.Page) ([]*aggregate.Task, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.TaskSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	conditions := []string{`deleted = 0`}
+	args := make([]interface{}, 0)
+	for _, match := range []struct{ column, value string }{
+		{"project_id", filter.ProjectID},
+		{"assignee_id", filter.AssigneeID},
+		{"status", filter.Status},
+	} {
+		if match.value != "" {
+			conditions = append(conditions, match.column+` = ?`)
+			args = append(args, match.value)
+		}
+	}
+	if filter.TeamID != "" {
+		team := `team_id = ?`
+		args = append(args, filter.TeamID)
+		for _, memberID := range filter.TeamMemberIDs {
+			team += ` OR assignee_id = ?`
+			args = append(args, memberID)
+		}
+		conditions = append(conditions, `(`+team+`)`)
+	}
+
+	states, result, err := queryPage(ctx, r.db, `FROM tasks`+sqliteWhere(conditions), taskSortColumns, page, args...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	tasks := make([]*aggregate.Task, 0, len(states))
+	for _, data := range states {
+		task, err := r.decode(data)
+		if err != nil {
+			return nil, domain.PagedResult{}, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, result, nil
+}
+
+// encode returns a task's stored state and the assignee and team its lookups match on
+func (r *SQLiteTaskRepository) encode(task *aggregate.Task) (state []byte, assigneeID, teamID interface{}, err error) {
+	state, err = encodeState(task.ToState())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if task.Assignee() != nil {
+		assigneeID = task.Assignee().AssigneeID().Value()
+	}
+	if task.TeamID() != nil {
+		teamID = task.TeamID().Value()
+	}
+	return state, assigneeID, teamID, nil
+}
+
+// decode restores a task from its stored state
+func (r *SQLiteTaskRepository) decode(data []byte) (*aggregate.Task, error) {
+	var state aggregate.TaskState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode task: %w", err)
+	}
+	return aggregate.TaskFromState(state)
+}
+
+// query restores the tasks a query selects
+func (r *SQLiteTaskRepository) query(ctx context.Context, query string, args ...interface{}) ([]*aggregate.Task, error) {
+	states, err := queryStates(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*aggregate.Task, 0, len(states))
+	for _, data := range states {
+		task, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// Ensure SQLiteTaskRepository implements domain.TaskRepository
+var _ domain.TaskRepository = (*SQLiteTaskRepository)(nil)
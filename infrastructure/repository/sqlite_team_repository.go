@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteTeamRepository is a SQLite implementation of TeamRepository
+type SQLiteTeamRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteTeamRepository creates a new SQLiteTeamRepository on a database or transaction
+func NewSQLiteTeamRepository(db SQLExecutor) *SQLiteTeamRepository {
+	return &SQLiteTeamRepository{db: db}
+}
+
+// Save persists a team to the repository
+func (r *SQLiteTeamRepository) Save(ctx context.Context, team *aggregate.Team) error {
+	if team == nil {
+		return fmt.Errorf("team cannot be nil")
+	}
+
+	state, err := encodeState(team.ToState())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO teams (id, name, state) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET name = excluded.name, state = excluded.state`,
+		team.ID().Value(), team.Name(), state)
+	if err != nil {
+		return fmt.Errorf("failed to save team: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a team by ID
+func (r *SQLiteTeamRepository) GetByID(ctx context.Context, id value.TeamID) (*aggregate.Team, error) {
+	data, err := queryState(ctx, r.db, "team not found", `SELECT state FROM teams WHERE id = ?`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByMemberID retrieves all teams a user belongs to, ordered by name
+func (r *SQLiteTeamRepository) GetByMemberID(ctx context.Context, userID value.UserID) ([]*aggregate.Team, error) {
+	teams, err := r.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	memberOf := make([]*aggregate.Team, 0)
+	for _, team := range teams {
+		if team.HasMember(userID) {
+			memberOf = append(memberOf, team)
+		}
+	}
+	return memberOf, nil
+}
+
+// GetAll retrieves all teams, ordered by name
+func (r *SQLiteTeamRepository) GetAll(ctx context.Context) ([]*aggregate.Team, error) {
+	states, err := queryStates(ctx, r.db, `SELECT state FROM teams ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]*aggregate.Team, 0, len(states))
+	for _, data := range states {
+		team, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
+
+// Update updates an existing team
+func (r *SQLiteTeamRepository) Update(ctx context.Context, team *aggregate.Team) error {
+	if team == nil {
+		return fmt.Errorf("team cannot be nil")
+	}
+
+	state, err := encodeState(team.ToState())
+	if err != nil {
+		return err
+	}
+
+	return execExisting(ctx, r.db, "team not found", `UPDATE teams SET name = ?, state = ? WHERE id = ?`,
+		team.Name(), state, team.ID().Value())
+}
+
+// teamSortColumns orders teams by each of domain.TeamSortFields
+var teamSortColumns = map[string][]string{
+	"created_at": {sqliteTime("created_at")},
+	"name":       {`name`},
+}
+
+// List retrieves a page of the teams matching a filter
+func (r *SQLiteTeamRepository) List(ctx context.Context, filter domain.TeamFilter, page domain.Page) ([]*aggregate.Team, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.TeamSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.MemberID != "" {
+		conditions = append(conditions, `EXISTS (SELECT 1 FROM json_each(state, '$.member_ids') WHERE value = ?)`)
+		args = append(args, filter.MemberID)
+	}
+
+	states, result, err := queryPage(ctx, r.db, `FROM teams`+sqliteWhere(conditions), teamSortColumns, page, args...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	teams := make([]*aggregate.Team, 0, len(states))
+	for _, data := range states {
+		team, err := r.decode(data)
+		if err != nil {
+			return nil, domain.PagedResult{}, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, result, nil
+}
+
+// decode restores a team from its stored state
+func (r *SQLiteTeamRepository) decode(data []byte) (*aggregate.Team, error) {
+	var state aggregate.TeamState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode team: %w", err)
+	}
+	return aggregate.TeamFromState(state)
+}
+
+// Ensure SQLiteTeamRepository implements domain.TeamRepository
+var _ domain.TeamRepository = (*SQLiteTeamRepository)(nil)
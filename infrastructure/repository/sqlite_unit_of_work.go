@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/event"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+)
+
+// SQLiteUnitOfWork is a UnitOfWork on a SQLite transaction. The repositories it hands out are
+// bound to the transaction while one is active, and recorded events are appended to the event
+// store within it, so they are stored exactly when the writes are. Once the transaction is
+// committed the events are delivered to subscribers; a failing subscriber does not fail the commit.
+type SQLiteUnitOfWork struct {
+	db     *sql.DB
+	events *infraEvent.SQLiteEventStore
+	outbox Outbox
+
+	ctx          context.Context // of the open transaction
+	tx           *sql.Tx
+	repositories UnitOfWorkRepositories
+	recorded     []event.DomainEvent
+}
+
+// NewSQLiteUnitOfWork creates a new SQLiteUnitOfWork on an open database and its event store.
+// Committed events are delivered through the outbox.
+func NewSQLiteUnitOfWork(db *sql.DB, events *infraEvent.SQLiteEventStore, outbox Outbox) *SQLiteUnitOfWork {
+	return &SQLiteUnitOfWork{
+		db:           db,
+		events:       events,
+		outbox:       outbox,
+		repositories: sqliteUnitOfWorkRepositories(db),
+	}
+}
+
+// NewSQLiteUnitOfWorkFactory creates a factory of SQLiteUnitOfWork on the same database, event store and outbox
+func NewSQLiteUnitOfWorkFactory(db *sql.DB, events *infraEvent.SQLiteEventStore, outbox Outbox) domain.UnitOfWorkFactory {
+	return func() domain.UnitOfWork {
+		return NewSQLiteUnitOfWork(db, events, outbox)
+	}
+}
+
+// sqliteUnitOfWorkRepositories opens the repositories a unit of work hands out on the database or a transaction
+func sqliteUnitOfWorkRepositories(executor SQLExecutor) UnitOfWorkRepositories {
+	return UnitOfWorkRepositories{
+		Tasks:     NewSQLiteTaskRepository(executor),
+		Projects:  NewSQLiteProjectRepository(executor),
+		Users:     NewSQLiteUserRepository(executor),
+		Workflows: NewSQLiteWorkflowRepository(executor),
+	}
+}
+
+// BeginTransaction starts a new transaction
+func (u *SQLiteUnitOfWork) BeginTransaction(ctx context.Context) error {
+	if u.tx != nil {
+		return fmt.Errorf("transaction already started")
+	}
+
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	u.ctx = ctx
+	u.tx = tx
+	u.repositories = sqliteUnitOfWorkRepositories(tx)
+	u.recorded = nil
+	return nil
+}
+
+// Commit appends the recorded events and commits the transaction, then delivers the events.
+// Nothing of the transaction is kept when either step fails. The steps run under the context
+// the transaction began with; delivery goes on once the transaction is kept, even if it is cancelled.
+func (u *SQLiteUnitOfWork) Commit() error {
+	if u.tx == nil {
+		return fmt.Errorf("no transaction to commit")
+	}
+
+	if err := u.events.AppendBatchTx(u.ctx, u.tx, u.recorded); err != nil {
+		if rollbackErr := u.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("failed to write outbox events: %w (%v)", err, rollbackErr)
+		}
+		return fmt.Errorf("failed to write outbox events: %w", err)
+	}
+
+	ctx, events := u.ctx, u.recorded
+	err := u.tx.Commit()
+	u.end()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	u.outbox.DeliverAll(context.WithoutCancel(ctx), events)
+	return nil
+}
+
+// Rollback rolls back the transaction and drops its recorded events
+func (u *SQLiteUnitOfWork) Rollback() error {
+	if u.tx == nil {
+		return nil
+	}
+
+	err := u.tx.Rollback()
+	u.end()
+	if err != nil {
+		return fmt.Errorf("failed to roll back: %w", err)
+	}
+	return nil
+}
+
+// end forgets the finished transaction and binds the repositories to the database again
+func (u *SQLiteUnitOfWork) end() {
+	u.ctx = nil
+	u.tx = nil
+	u.repositories = sqliteUnitOfWorkRepositories(u.db)
+	u.recorded = nil
+}
+
+// GetTaskRepository returns the task repository
+func (u *SQLiteUnitOfWork) GetTaskRepository() domain.TaskRepository {
+	return u.repositories.Tasks
+}
+
+// GetProjectRepository returns the project repository
+func (u *SQLiteUnitOfWork) GetProjectRepository() domain.ProjectRepository {
+	return u.repositories.Projects
+}
+
+// GetUserRepository returns the user repository
+func (u *SQLiteUnitOfWork) GetUserRepository() domain.UserRepository {
+	return u.repositories.Users
+}
+
+// GetWorkflowRepository returns the workflow repository
+func (u *SQLiteUnitOfWork) GetWorkflowRepository() domain.WorkflowRepository {
+	return u.repositories.Workflows
+}
+
+// RecordEvents queues domain events for the event store
+func (u *SQLiteUnitOfWork) RecordEvents(events ...event.DomainEvent) {
+	u.recorded = append(u.recorded, events...)
+}
+
+// Ensure SQLiteUnitOfWork implements domain.UnitOfWork
+var _ domain.UnitOfWork = (*SQLiteUnitOfWork)(nil)
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteUserRepository is a SQLite implementation of UserRepository
+type SQLiteUserRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteUserRepository creates a new SQLiteUserRepository on a database or transaction
+func NewSQLiteUserRepository(db SQLExecutor) *SQLiteUserRepository {
+	return &SQLiteUserRepository{db: db}
+}
+
+// Save persists a user to the repository
+func (r *SQLiteUserRepository) Save(ctx context.Context, user *aggregate.User) error {
+	if user == nil {
+		return fmt.Errorf("user cannot be nil")
+	}
+
+	state, err := encodeState(user.ToState())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO users (id, email, active, state) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET email = excluded.email, active = excluded.active, state = excluded.state`,
+		user.ID().Value(), user.Email(), sqliteBool(user.IsActive()), state)
+	if err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a user by ID
+func (r *SQLiteUserRepository) GetByID(ctx context.Context, id value.UserID) (*aggregate.User, error) {
+	data, err := queryState(ctx, r.db, "user not found", `SELECT state FROM users WHERE id = ?`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByEmail retrieves a user by email
+func (r *SQLiteUserRepository) GetByEmail(ctx context.Context, email string) (*aggregate.User, error) {
+	data, err := queryState(ctx, r.db, "user not found", `SELECT state FROM users WHERE email = ? LIMIT 1`, email)
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetAll retrieves all users
+func (r *SQLiteUserRepository) GetAll(ctx context.Context) ([]*aggregate.User, error) {
+	return r.query(ctx, `SELECT state FROM users`)
+}
+
+// Delete removes a user from the repository
+func (r *SQLiteUserRepository) Delete(ctx context.Context, id value.UserID) error {
+	return execExisting(ctx, r.db, "user not found", `DELETE FROM users WHERE id = ?`, id.Value())
+}
+
+// Update updates an existing user
+func (r *SQLiteUserRepository) Update(ctx context.Context, user *aggregate.User) error {
+	if user == nil {
+		return fmt.Errorf("user cannot be nil")
+	}
+
+	state, err := encodeState(user.ToState())
+	if err != nil {
+		return err
+	}
+
+	return execExisting(ctx, r.db, "user not found", `UPDATE users SET email = ?, active = ?, state = ? WHERE id = ?`,
+		user.Email(), sqliteBool(user.IsActive()), state, user.ID().Value())
+}
+
+// GetActive retrieves all active users
+func (r *SQLiteUserRepository) GetActive(ctx context.Context) ([]*aggregate.User, error) {
+	return r.query(ctx, `SELECT state FROM users WHERE active = 1`)
+}
+
+// userSortColumns orders users by each of domain.UserSortFields
+var userSortColumns = map[string][]string{
+	"created_at": {sqliteTime("created_at")},
+	"email":      {`email`},
+}
+
+// List retrieves a page of the users matching a filter
+func (r *SQLiteUserRepository) List(ctx context.Context, filter domain.UserFilter, page domain.Page) ([]*aggregate.User, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.UserSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.Active != nil {
+		conditions = append(conditions, `active = ?`)
+		args = append(args, sqliteBool(*filter.Active))
+	}
+
+	states, result, err := queryPage(ctx, r.db, `FROM users`+sqliteWhere(conditions), userSortColumns, page, args...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	users := make([]*aggregate.User, 0, len(states))
+	for _, data := range states {
+		user, err := r.decode(data)
+		if err != nil {
+			return nil, domain.PagedResult{}, err
+		}
+		users = append(users, user)
+	}
+	return users, result, nil
+}
+
+// decode restores a user from its stored state
+func (r *SQLiteUserRepository) decode(data []byte) (*aggregate.User, error) {
+	var state aggregate.UserState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode user: %w", err)
+	}
+	return aggregate.UserFromState(state)
+}
+
+// query restores the users a query selects
+func (r *SQLiteUserRepository) query(ctx context.Context, query string, args ...interface{}) ([]*aggregate.User, error) {
+	states, err := queryStates(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*aggregate.User, 0, len(states))
+	for _, data := range states {
+		user, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// Ensure SQLiteUserRepository implements domain.UserRepository
+var _ domain.UserRepository = (*SQLiteUserRepository)(nil)
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// SQLiteWidgetRepository is a SQLite implementation of WidgetRepository
+type SQLiteWidgetRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteWidgetRepository creates a new SQLiteWidgetRepository on a database or transaction
+func NewSQLiteWidgetRepository(db SQLExecutor) *SQLiteWidgetRepository {
+	return &SQLiteWidgetRepository{db: db}
+}
+
+// Save persists a widget to the repository
+func (r *SQLiteWidgetRepository) Save(ctx context.Context, widget *aggregate.Widget) error {
+	if widget == nil {
+		return fmt.Errorf("widget cannot be nil")
+	}
+
+	state, err := encodeState(widget.ToState())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO widgets (id, owner_id, layout_row, layout_column, state) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			owner_id = excluded.owner_id, layout_row = excluded.layout_row,
+			layout_column = excluded.layout_column, state = excluded.state`,
+		widget.ID().Value(), widget.OwnerID().Value(), widget.Layout().Row(), widget.Layout().Column(), state)
+	if err != nil {
+		return fmt.Errorf("failed to save widget: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a widget by ID
+func (r *SQLiteWidgetRepository) GetByID(ctx context.Context, id value.WidgetID) (*aggregate.Widget, error) {
+	data, err := queryState(ctx, r.db, "widget not found", `SELECT state FROM widgets WHERE id = ?`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByOwnerID retrieves all widgets owned by a user, ordered by layout position
+func (r *SQLiteWidgetRepository) GetByOwnerID(ctx context.Context, userID value.UserID) ([]*aggregate.Widget, error) {
+	states, err := queryStates(ctx, r.db, `SELECT state FROM widgets WHERE owner_id = ? ORDER BY layout_row, layout_column`, userID.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	widgets := make([]*aggregate.Widget, 0, len(states))
+	for _, data := range states {
+		widget, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		widgets = append(widgets, widget)
+	}
+	return widgets, nil
+}
+
+// Delete removes a widget from the repository
+func (r *SQLiteWidgetRepository) Delete(ctx context.Context, id value.WidgetID) error {
+	return execExisting(ctx, r.db, "widget not found", `DELETE FROM widgets WHERE id = ?`, id.Value())
+}
+
+// Update updates an existing widget
+func (r *SQLiteWidgetRepository) Update(ctx context.Context, widget *aggregate.Widget) error {
+	if widget == nil {
+		return fmt.Errorf("widget cannot be nil")
+	}
+
+	state, err := encodeState(widget.ToState())
+	if err != nil {
+		return err
+	}
+
+	return execExisting(ctx, r.db, "widget not found", `
+		UPDATE widgets SET owner_id = ?, layout_row = ?, layout_column = ?, state = ? WHERE id = ?`,
+		widget.OwnerID().Value(), widget.Layout().Row(), widget.Layout().Column(), state, widget.ID().Value())
+}
+
+// widgetSortColumns orders widgets by each of domain.WidgetSortFields
+var widgetSortColumns = map[string][]string{
+	"position":   {`layout_row`, `layout_column`},
+	"created_at": {sqliteTime("created_at")},
+	"title":      {`json_extract(state, '$.title')`},
+}
+
+// List retrieves a page of the widgets matching a filter
+func (r *SQLiteWidgetRepository) List(
+	ctx context.Context,
+	filter domain.WidgetFilter,
+	page domain.Page,
+) ([]*aggregate.Widget, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.WidgetSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+	if filter.OwnerID != "" {
+		conditions = append(conditions, `owner_id = ?`)
+		args = append(args, filter.OwnerID)
+	}
+
+	states, result, err := queryPage(ctx, r.db, `FROM widgets`+sqliteWhere(conditions), widgetSortColumns, page, args...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	widgets := make([]*aggregate.Widget, 0, len(states))
+	for _, data := range states {
+		widget, err := r.decode(data)
+		if err != nil {
+			return nil, domain.PagedResult{}, err
+		}
+		widgets = append(widgets, widget)
+	}
+	return widgets, result, nil
+}
+
+// decode restores a widget from its stored state
+func (r *SQLiteWidgetRepository) decode(data []byte) (*aggregate.Widget, error) {
+	var state aggregate.WidgetState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode widget: %w", err)
+	}
+	return aggregate.WidgetFromState(state)
+}
+
+// Ensure SQLiteWidgetRepository implements domain.WidgetRepository
+var _ domain.WidgetRepository = (*SQLiteWidgetRepository)(nil)
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// latestWorkflows selects the latest version of every workflow
+const latestWorkflows = `SELECT state FROM workflows w WHERE version = (SELECT MAX(version) FROM workflows WHERE id = w.id)`
+
+// SQLiteWorkflowRepository is a SQLite implementation of WorkflowRepository.
+// It keeps every version of a workflow, lookups by ID return the latest.
+type SQLiteWorkflowRepository struct {
+	db SQLExecutor
+}
+
+// NewSQLiteWorkflowRepository creates a new SQLiteWorkflowRepository on a database or transaction
+func NewSQLiteWorkflowRepository(db SQLExecutor) *SQLiteWorkflowRepository {
+	return &SQLiteWorkflowRepository{db: db}
+}
+
+// Save persists a workflow to the repository, replacing every version stored before
+func (r *SQLiteWorkflowRepository) Save(ctx context.Context, workflow *aggregate.Workflow) error {
+	if workflow == nil {
+		return fmt.Errorf("workflow cannot be nil")
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM workflows WHERE id = ?`, workflow.ID().Value()); err != nil {
+		return fmt.Errorf("failed to save workflow: %w", err)
+	}
+	return r.store(ctx, workflow)
+}
+
+// GetByID retrieves the latest version of a workflow by ID
+func (r *SQLiteWorkflowRepository) GetByID(ctx context.Context, id value.WorkflowID) (*aggregate.Workflow, error) {
+	data, err := queryState(ctx, r.db, "workflow not found", latestWorkflows+` AND id = ?`, id.Value())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetVersion retrieves one version of a workflow
+func (r *SQLiteWorkflowRepository) GetVersion(ctx context.Context, version value.WorkflowVersion) (*aggregate.Workflow, error) {
+	data, err := queryState(ctx, r.db, fmt.Sprintf("workflow version not found: %s", version),
+		`SELECT state FROM workflows WHERE id = ? AND version = ?`, version.WorkflowID().Value(), version.Number())
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetByName retrieves a workflow by name
+func (r *SQLiteWorkflowRepository) GetByName(ctx context.Context, name string) (*aggregate.Workflow, error) {
+	data, err := queryState(ctx, r.db, "workflow not found", latestWorkflows+` AND name = ? LIMIT 1`, name)
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// GetAll retrieves all workflows
+func (r *SQLiteWorkflowRepository) GetAll(ctx context.Context) ([]*aggregate.Workflow, error) {
+	return r.query(ctx, latestWorkflows)
+}
+
+// Delete removes a workflow and all its versions from the repository
+func (r *SQLiteWorkflowRepository) Delete(ctx context.Context, id value.WorkflowID) error {
+	return execExisting(ctx, r.db, "workflow not found", `DELETE FROM workflows WHERE id = ?`, id.Value())
+}
+
+// Update updates an existing workflow
+func (r *SQLiteWorkflowRepository) Update(ctx context.Context, workflow *aggregate.Workflow) error {
+	if workflow == nil {
+		return fmt.Errorf("workflow cannot be nil")
+	}
+
+	var latest sql.NullInt64
+	if err := r.db.QueryRowContext(ctx, `SELECT MAX(version) FROM workflows WHERE id = ?`, workflow.ID().Value()).Scan(&latest); err != nil {
+		return fmt.Errorf("failed to read workflow version: %w", err)
+	}
+	if !latest.Valid {
+		return errs.NotFound("workflow not found")
+	}
+
+	if int64(workflow.Version()) < latest.Int64 {
+		return errs.Conflict("workflow version %d is out of date, the latest is %d", workflow.Version(), latest.Int64)
+	}
+	return r.store(ctx, workflow)
+}
+
+// GetActive retrieves all active workflows
+func (r *SQLiteWorkflowRepository) GetActive(ctx context.Context) ([]*aggregate.Workflow, error) {
+	return r.query(ctx, latestWorkflows+` AND active = 1`)
+}
+
+// store writes a version of a workflow, replacing the same version stored before
+func (r *SQLiteWorkflowRepository) store(ctx context.Context, workflow *aggregate.Workflow) error {
+	state, err := encodeState(workflow.ToState())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `INSERT OR REPLACE INTO workflows (id, version, name, active, state) VALUES (?, ?, ?, ?, ?)`,
+		workflow.ID().Value(), workflow.Version(), workflow.Name(), sqliteBool(workflow.IsActive()), state)
+	if err != nil {
+		return fmt.Errorf("failed to save workflow: %w", err)
+	}
+	return nil
+}
+
+// workflowSortColumns orders workflows by each of domain.WorkflowSortFields
+var workflowSortColumns = map[string][]string{
+	"created_at": {sqliteTime("created_at")},
+	"name":       {`name`},
+}
+
+// List retrieves a page of the latest versions of the workflows matching a filter
+func (r *SQLiteWorkflowRepository) List(ctx context.Context, filter domain.WorkflowFilter, page domain.Page) ([]*aggregate.Workflow, domain.PagedResult, error) {
+	page, err := page.Resolve(domain.WorkflowSortFields)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	conditions := []string{`version = (SELECT MAX(version) FROM workflows WHERE id = w.id)`}
+	args := make([]interface{}, 0)
+	if filter.Active != nil {
+		conditions = append(conditions, `active = ?`)
+		args = append(args, sqliteBool(*filter.Active))
+	}
+
+	states, result, err := queryPage(ctx, r.db, `FROM workflows w`+sqliteWhere(conditions), workflowSortColumns, page, args...)
+	if err != nil {
+		return nil, domain.PagedResult{}, err
+	}
+
+	workflows := make([]*aggregate.Workflow, 0, len(states))
+	for _, data := range states {
+		workflow, err := r.decode(data)
+		if err != nil {
+			return nil, domain.PagedResult{}, err
+		}
+		workflows = append(workflows, workflow)
+	}
+	return workflows, result, nil
+}
+
+// decode restores a workflow from its stored state
+func (r *SQLiteWorkflowRepository) decode(data []byte) (*aggregate.Workflow, error) {
+	var state aggregate.WorkflowState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow: %w", err)
+	}
+	return aggregate.WorkflowFromState(state)
+}
+
+// query restores the workflows a query selects
+func (r *SQLiteWorkflowRepository) query(ctx context.Context, query string, args ...interface{}) ([]*aggregate.Workflow, error) {
+	states, err := queryStates(ctx, r.db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	workflows := make([]*aggregate.Workflow, 0, len(states))
+	for _, data := range states {
+		workflow, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, workflow)
+	}
+	return workflows, nil
+}
+
+// Ensure SQLiteWorkflowRepository implements domain.WorkflowRepository
+var _ domain.WorkflowRepository = (*SQLiteWorkflowRepository)(nil)
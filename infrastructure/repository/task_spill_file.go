@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+)
+
+// spillRecord locates the latest stored state of a task in the spill file
+type spillRecord struct {
+	offset int64
+	length int64
+}
+
+// TaskSpillFile keeps archived task states on disk, one JSON line per task.
+// Records are only appended; once more than half the file is dead records it is rewritten.
+type TaskSpillFile struct {
+	path    string
+	file    *os.File
+	records map[string]spillRecord // task ID -> latest state
+	size    int64
+	dead    int64 // bytes held by removed or replaced states
+	mu      sync.Mutex
+}
+
+// OpenTaskSpillFile creates the spill file at path, discarding what an earlier process left there
+func OpenTaskSpillFile(path string) (*TaskSpillFile, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open spill file: %w", err)
+	}
+
+	return &TaskSpillFile{
+		path:    path,
+		file:    file,
+		records: make(map[string]spillRecord),
+	}, nil
+}
+
+// Write stores a task state, replacing any earlier one of the same task
+func (s *TaskSpillFile) Write(state aggregate.TaskState) error {
+	line, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode task %s: %w", state.ID, err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.WriteAt(line, s.size); err != nil {
+		return fmt.Errorf("failed to write spill file: %w", err)
+	}
+
+	if previous, exists := s.records[state.ID]; exists {
+		s.dead += previous.length
+	}
+	s.records[state.ID] = spillRecord{offset: s.size, length: int64(len(line))}
+	s.size += int64(len(line))
+
+	return nil
+}
+
+// Read loads the stored state of a task
+func (s *TaskSpillFile) Read(taskID string) (aggregate.TaskState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, exists := s.records[taskID]
+	if !exists {
+		return aggregate.TaskState{}, errs.NotFound("task not found")
+	}
+
+	line := make([]byte, record.length)
+	if _, err := s.file.ReadAt(line, record.offset); err != nil && err != io.EOF {
+		return aggregate.TaskState{}, fmt.Errorf("failed to read spill file: %w", err)
+	}
+
+	var state aggregate.TaskState
+	if err := json.Unmarshal(line, &state); err != nil {
+		return aggregate.TaskState{}, fmt.Errorf("failed to decode task %s: %w", taskID, err)
+	}
+
+	return state, nil
+}
+
+// Remove forgets a task's state
+func (s *TaskSpillFile) Remove(taskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, exists := s.records[taskID]
+	if !exists {
+		return nil
+	}
+
+	delete(s.records, taskID)
+	s.dead += record.length
+
+	if s.dead*2 > s.size {
+		return s.rewrite()
+	}
+	return nil
+}
+
+// Len returns how many tasks the spill file holds
+func (s *TaskSpillFile) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.records)
+}
+
+// Close closes and deletes the spill file
+func (s *TaskSpillFile) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(s.path)
+}
+
+// rewrite copies the live states to a fresh file, dropping the dead ones
+func (s *TaskSpillFile) rewrite() error {
+	tmpPath := s.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to compact spill file: %w", err)
+	}
+
+	records := make(map[string]spillRecord, len(s.records))
+	var size int64
+	for taskID, record := range s.records {
+		line := make([]byte, record.length)
+		if _, err := s.file.ReadAt(line, record.offset); err != nil && err != io.EOF {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to compact spill file: %w", err)
+		}
+		if _, err := tmp.WriteAt(line, size); err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to compact spill file: %w", err)
+		}
+		records[taskID] = spillRecord{offset: size, length: record.length}
+		size += record.length
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to compact spill file: %w", err)
+	}
+
+	s.file.Close()
+	s.file = tmp
+	s.records = records
+	s.size = size
+	s.dead = 0
+
+	return nil
+}
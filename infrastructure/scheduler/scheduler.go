@@ -0,0 +1,245 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTick is how often a started scheduler looks for jobs that are due
+const DefaultTick = time.Minute
+
+// Schedule tells when a job runs next
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// Every runs a job at a fixed interval, counted from its previous run
+func Every(interval time.Duration) Schedule {
+	if interval <= 0 {
+		interval = DefaultTick
+	}
+	return every{interval: interval}
+}
+
+// every is a schedule at a fixed interval
+type every struct {
+	interval time.Duration
+}
+
+// Next returns the time one interval after the previous run
+func (e every) Next(after time.Time) time.Time {
+	return after.Add(e.interval)
+}
+
+// DailyAt runs a job once a day at the given hour and minute of the location
+func DailyAt(hour, minute int, location *time.Location) (Schedule, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return nil, fmt.Errorf("invalid time of day %02d:%02d", hour, minute)
+	}
+	if location == nil {
+		location = time.Local
+	}
+	return daily{hour: hour, minute: minute, location: location}, nil
+}
+
+// daily is a schedule at a time of day
+type daily struct {
+	hour     int
+	minute   int
+	location *time.Location
+}
+
+// Next returns the first time of day after the previous run
+func (d daily) Next(after time.Time) time.Time {
+	local := after.In(d.location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), d.hour, d.minute, 0, 0, d.location)
+	if !next.After(local) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, d.hour, d.minute, 0, 0, d.location)
+	}
+	return next
+}
+
+// Job is the work a scheduler runs; now is the time the run was due
+type Job func(ctx context.Context, now time.Time) error
+
+// JobStatus reports how a scheduled job has been running
+type JobStatus struct {
+	Name      string     `json:"name"`
+	Runs      int        `json:"runs"`
+	Failures  int        `json:"failures"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	LastError string     `json:"last_error,omitempty"` // empty when the last run succeeded
+	NextRunAt time.Time  `json:"next_run_at"`
+}
+
+// scheduledJob is a job with its schedule and run history
+type scheduledJob struct {
+	name     string
+	schedule Schedule
+	run      Job
+	status   JobStatus
+}
+
+// Scheduler runs jobs on their schedules, cron-like, one at a time. A job's first run is due
+// one schedule step after it was added; a run that was missed, for example while the
+// process was down, is made up once rather than for every step that was missed.
+type Scheduler struct {
+	jobs      []*scheduledJob
+	now       func() time.Time
+	onFailure func(name string, err error)
+	running   sync.Mutex // held while due jobs run
+	mu        sync.Mutex
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		jobs: make([]*scheduledJob, 0),
+		now:  time.Now,
+	}
+}
+
+// SetClock replaces the scheduler's clock, for tests
+func (s *Scheduler) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = now
+}
+
+// OnFailure sets what is told about job runs that fail
+func (s *Scheduler) OnFailure(handler func(name string, err error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onFailure = handler
+}
+
+// Add schedules a job under a unique name
+func (s *Scheduler) Add(name string, schedule Schedule, run Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.jobs {
+		if existing.name == name {
+			return fmt.Errorf("job %s already exists", name)
+		}
+	}
+
+	s.jobs = append(s.jobs, &scheduledJob{
+		name:     name,
+		schedule: schedule,
+		run:      run,
+		status:   JobStatus{Name: name, NextRunAt: schedule.Next(s.now())},
+	})
+	return nil
+}
+
+// RunDue runs the jobs whose next run is due and returns how many ran
+func (s *Scheduler) RunDue(ctx context.Context) int {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	s.mu.Lock()
+	now := s.now()
+	due := make([]*scheduledJob, 0)
+	for _, job := range s.jobs {
+		if !job.status.NextRunAt.After(now) {
+			due = append(due, job)
+		}
+	}
+	s.mu.Unlock()
+
+	ran := 0
+	for _, job := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		s.runJob(ctx, job, now)
+		ran++
+	}
+	return ran
+}
+
+// Run runs a job now, whether it is due or not, and returns its error
+func (s *Scheduler) Run(ctx context.Context, name string) error {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	s.mu.Lock()
+	var found *scheduledJob
+	for _, job := range s.jobs {
+		if job.name == name {
+			found = job
+		}
+	}
+	now := s.now()
+	s.mu.Unlock()
+
+	if found == nil {
+		return fmt.Errorf("job %s not found", name)
+	}
+	return s.runJob(ctx, found, now)
+}
+
+// runJob runs a job and records the run, the caller holds the running lock
+func (s *Scheduler) runJob(ctx context.Context, job *scheduledJob, now time.Time) error {
+	err := job.run(ctx, now)
+
+	s.mu.Lock()
+	job.status.Runs++
+	job.status.LastRunAt = &now
+	job.status.LastError = ""
+	if err != nil {
+		job.status.Failures++
+		job.status.LastError = err.Error()
+	}
+	job.status.NextRunAt = job.schedule.Next(now)
+	onFailure := s.onFailure
+	s.mu.Unlock()
+
+	if err != nil && onFailure != nil {
+		onFailure(job.name, err)
+	}
+	return err
+}
+
+// Start runs due jobs every tick in the background until the context is done
+func (s *Scheduler) Start(ctx context.Context, tick time.Duration) {
+	if tick <= 0 {
+		tick = DefaultTick
+	}
+
+	go func() {
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.RunDue(ctx)
+			}
+		}
+	}()
+}
+
+// Status reports every job, by name
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		statuses = append(statuses, job.status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
@@ -0,0 +1,256 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/miladev95/ddd-task/application/query"
+)
+
+// maxPrefixLength bounds the prefixes stored per word to keep the index small
+const maxPrefixLength = 8
+
+// Relevance weights, from strongest to weakest match
+const (
+	relevanceExact      = 3.0
+	relevanceFullPrefix = 2.0
+	relevanceWordPrefix = 1.0
+)
+
+// indexEntry is a single searchable item
+type indexEntry struct {
+	kind      string
+	id        string
+	label     string
+	words     []string
+	touchedAt time.Time
+}
+
+// PrefixIndex is a lightweight in-memory prefix index for typeahead suggestions
+type PrefixIndex struct {
+	entries  map[string]*indexEntry
+	prefixes map[string]map[string]struct{}
+	now      func() time.Time
+	mu       sync.RWMutex
+}
+
+// NewPrefixIndex creates a new PrefixIndex
+func NewPrefixIndex() *PrefixIndex {
+	return &PrefixIndex{
+		entries:  make(map[string]*indexEntry),
+		prefixes: make(map[string]map[string]struct{}),
+		now:      time.Now,
+	}
+}
+
+// Clear removes every item from the index
+func (i *PrefixIndex) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.entries = make(map[string]*indexEntry)
+	i.prefixes = make(map[string]map[string]struct{})
+}
+
+// Upsert adds an item to the index or replaces its label
+func (i *PrefixIndex) Upsert(kind, id, label string, touchedAt time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	key := entryKey(kind, id)
+	i.removeLocked(key)
+
+	entry := &indexEntry{
+		kind:      kind,
+		id:        id,
+		label:     label,
+		words:     tokenize(label),
+		touchedAt: touchedAt,
+	}
+	i.entries[key] = entry
+
+	for _, word := range entry.words {
+		for _, prefix := range prefixesOf(word) {
+			if _, exists := i.prefixes[prefix]; !exists {
+				i.prefixes[prefix] = make(map[string]struct{})
+			}
+			i.prefixes[prefix][key] = struct{}{}
+		}
+	}
+}
+
+// Touch records activity on an item so it ranks higher for recency
+func (i *PrefixIndex) Touch(kind, id string, at time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if entry, exists := i.entries[entryKey(kind, id)]; exists && at.After(entry.touchedAt) {
+		entry.touchedAt = at
+	}
+}
+
+// Remove deletes an item from the index
+func (i *PrefixIndex) Remove(kind, id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.removeLocked(entryKey(kind, id))
+}
+
+// Suggest returns the best matches for text, ranked by relevance then recency
+func (i *PrefixIndex) Suggest(text string, limit int) []query.Suggestion {
+	queryWords := tokenize(text)
+	if len(queryWords) == 0 {
+		return []query.Suggestion{}
+	}
+	normalized := strings.Join(queryWords, " ")
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	now := i.now()
+	suggestions := make([]query.Suggestion, 0)
+	for key := range intersectPostings(i.prefixes, queryWords) {
+		entry := i.entries[key]
+
+		relevance, ok := matchRelevance(entry, queryWords, normalized)
+		if !ok {
+			continue
+		}
+
+		suggestions = append(suggestions, query.Suggestion{
+			Kind:      entry.kind,
+			ID:        entry.id,
+			Label:     entry.label,
+			Score:     relevance + recencyBoost(now, entry.touchedAt),
+			TouchedAt: entry.touchedAt,
+		})
+	}
+
+	sort.Slice(suggestions, func(a, b int) bool {
+		if suggestions[a].Score != suggestions[b].Score {
+			return suggestions[a].Score > suggestions[b].Score
+		}
+		return suggestions[a].Label < suggestions[b].Label
+	})
+
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions
+}
+
+// intersectPostings intersects the posting sets of every query word
+func intersectPostings(postings map[string]map[string]struct{}, queryWords []string) map[string]struct{} {
+	var candidates map[string]struct{}
+	for _, word := range queryWords {
+		posting := postings[truncate(word)]
+		next := make(map[string]struct{})
+		for key := range posting {
+			if candidates == nil {
+				next[key] = struct{}{}
+			} else if _, exists := candidates[key]; exists {
+				next[key] = struct{}{}
+			}
+		}
+		candidates = next
+		if len(candidates) == 0 {
+			break
+		}
+	}
+	return candidates
+}
+
+// removeLocked deletes an entry and its prefixes; the caller must hold the write lock
+func (i *PrefixIndex) removeLocked(key string) {
+	entry, exists := i.entries[key]
+	if !exists {
+		return
+	}
+
+	for _, word := range entry.words {
+		for _, prefix := range prefixesOf(word) {
+			delete(i.prefixes[prefix], key)
+			if len(i.prefixes[prefix]) == 0 {
+				delete(i.prefixes, prefix)
+			}
+		}
+	}
+	delete(i.entries, key)
+}
+
+// matchRelevance verifies every query word prefixes an entry word and scores the match
+func matchRelevance(entry *indexEntry, queryWords []string, normalized string) (float64, bool) {
+	for _, queryWord := range queryWords {
+		matched := false
+		for _, word := range entry.words {
+			if strings.HasPrefix(word, queryWord) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return 0, false
+		}
+	}
+
+	full := strings.Join(entry.words, " ")
+	switch {
+	case full == normalized:
+		return relevanceExact, true
+	case strings.HasPrefix(full, normalized):
+		return relevanceFullPrefix, true
+	default:
+		return relevanceWordPrefix, true
+	}
+}
+
+// recencyBoost decays from 1 towards 0 as an entry ages, halving after a day
+func recencyBoost(now, touchedAt time.Time) float64 {
+	age := now.Sub(touchedAt)
+	if age < 0 {
+		age = 0
+	}
+	return 1 / (1 + age.Hours()/24)
+}
+
+// tokenize lowercases text and splits it into words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// prefixesOf returns all indexed prefixes of a word
+func prefixesOf(word string) []string {
+	runes := []rune(word)
+	if len(runes) > maxPrefixLength {
+		runes = runes[:maxPrefixLength]
+	}
+	prefixes := make([]string, len(runes))
+	for n := 1; n <= len(runes); n++ {
+		prefixes[n-1] = string(runes[:n])
+	}
+	return prefixes
+}
+
+// truncate shortens a query word to the longest indexed prefix length
+func truncate(word string) string {
+	runes := []rune(word)
+	if len(runes) > maxPrefixLength {
+		return string(runes[:maxPrefixLength])
+	}
+	return word
+}
+
+// entryKey builds the unique key of an index entry
+func entryKey(kind, id string) string {
+	return kind + ":" + id
+}
+
+// Ensure PrefixIndex implements query.SuggestionIndex
+var _ query.SuggestionIndex = (*PrefixIndex)(nil)
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/miladev95/ddd-task/domain/event"
+)
+
+// Suggestion kinds
+const (
+	KindTask    = "task"
+	KindProject = "project"
+	KindUser    = "user"
+)
+
+// touchingTaskEvents are task events that only bump recency
+var touchingTaskEvents = []string{
+	"TaskAssigned",
+	"TaskUnassigned",
+	"TaskStatusChanged",
+	"TaskDeadlineSet",
+	"TaskCommentAdded",
+	"TaskLinked",
+	"TaskUnlinked",
+}
+
+// SuggestionProjector keeps a PrefixIndex up to date from domain events
+type SuggestionProjector struct {
+	index *PrefixIndex
+}
+
+// NewSuggestionProjector creates a new SuggestionProjector
+func NewSuggestionProjector(index *PrefixIndex) *SuggestionProjector {
+	return &SuggestionProjector{
+		index: index,
+	}
+}
+
+// Name identifies the projection when rebuilding read models
+func (p *SuggestionProjector) Name() string {
+	return "search_suggestions"
+}
+
+// Reset truncates the index before a replay
+func (p *SuggestionProjector) Reset() error {
+	p.index.Clear()
+	return nil
+}
+
+// Register subscribes the projector to the events it consumes
+func (p *SuggestionProjector) Register(subscriber event.EventSubscriber) error {
+	eventTypes := append([]string{"TaskCreated", "TaskDeleted", "ProjectCreated", "ProjectRenamed", "ProjectDeleted", "UserRegistered", "UserRenamed"}, touchingTaskEvents...)
+	for _, eventType := range eventTypes {
+		if err := subscriber.Subscribe(eventType, p.Handle); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// Handle applies a single domain event to the index
+func (p *SuggestionProjector) Handle(evt event.DomainEvent) error {
+	switch e := evt.(type) {
+	case event.TaskCreatedEvent:
+		p.index.Upsert(KindTask, e.AggregateID(), e.Title, e.OccurredAt())
+	case event.TaskDeletedEvent:
+		p.index.Remove(KindTask, e.AggregateID())
+	case event.ProjectCreatedEvent:
+		p.index.Upsert(KindProject, e.AggregateID(), e.Name, e.OccurredAt())
+	case event.ProjectRenamedEvent:
+		p.index.Upsert(KindProject, e.AggregateID(), e.NewName, e.OccurredAt())
+	case event.ProjectDeletedEvent:
+		p.index.Remove(KindProject, e.AggregateID())
+	case event.UserRegisteredEvent:
+		p.index.Upsert(KindUser, e.AggregateID(), e.FirstName+" "+e.LastName, e.OccurredAt())
+	case event.UserRenamedEvent:
+		p.index.Upsert(KindUser, e.AggregateID(), e.FirstName+" "+e.LastName, e.OccurredAt())
+	default:
+		if evt.AggregateType() == "Task" {
+			p.index.Touch(KindTask, evt.AggregateID(), evt.OccurredAt())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+package search
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/miladev95/ddd-task/application/query"
+)
+
+// Snippet sizes in runes
+const (
+	snippetLength  = 160
+	snippetContext = 60
+)
+
+// Match weights of a query word against a document word
+const (
+	matchExactWord  = 1.0
+	matchWordPrefix = 0.5
+
+	// taskTypeBoost ranks a task above its comments for the same match
+	taskTypeBoost = 0.25
+)
+
+// Document is a searchable piece of a task: its own text, a comment or an attachment
+type Document struct {
+	Type      string // query.SearchTypeTask, query.SearchTypeComment or query.SearchTypeAttachment
+	ID        string
+	Text      string
+	UpdatedAt time.Time
+}
+
+// workspaceEntry is an indexed document with the task it belongs to
+type workspaceEntry struct {
+	Document
+	taskID    string
+	projectID string
+	title     string
+	words     []string
+}
+
+// WorkspaceIndex is an in-memory full text index over tasks, comments and attachment names.
+// Documents are grouped by task so a task is always reindexed as a whole.
+type WorkspaceIndex struct {
+	entries  map[string]*workspaceEntry
+	byTask   map[string][]string
+	postings map[string]map[string]struct{}
+	mu       sync.RWMutex
+}
+
+// NewWorkspaceIndex creates a new WorkspaceIndex
+func NewWorkspaceIndex() *WorkspaceIndex {
+	return &WorkspaceIndex{
+		entries:  make(map[string]*workspaceEntry),
+		byTask:   make(map[string][]string),
+		postings: make(map[string]map[string]struct{}),
+	}
+}
+
+// Clear removes every document from the index
+func (i *WorkspaceIndex) Clear() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.entries = make(map[string]*workspaceEntry)
+	i.byTask = make(map[string][]string)
+	i.postings = make(map[string]map[string]struct{})
+}
+
+// ReplaceTask replaces every document of a task
+func (i *WorkspaceIndex) ReplaceTask(taskID, projectID, title string, documents []Document) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.removeTaskLocked(taskID)
+
+	keys := make([]string, 0, len(documents))
+	for _, document := range documents {
+		key := entryKey(document.Type, document.ID)
+		entry := &workspaceEntry{
+			Document:  document,
+			taskID:    taskID,
+			projectID: projectID,
+			title:     title,
+			words:     tokenize(document.Text),
+		}
+		i.entries[key] = entry
+		keys = append(keys, key)
+
+		for _, word := range entry.words {
+			for _, prefix := range prefixesOf(word) {
+				if _, exists := i.postings[prefix]; !exists {
+					i.postings[prefix] = make(map[string]struct{})
+				}
+				i.postings[prefix][key] = struct{}{}
+			}
+		}
+	}
+	i.byTask[taskID] = keys
+}
+
+// RemoveTask deletes a task and all of its documents
+func (i *WorkspaceIndex) RemoveTask(taskID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.removeTaskLocked(taskID)
+}
+
+// Search returns the documents matching every word of text, best first
+func (i *WorkspaceIndex) Search(text string, types []string) []query.SearchHit {
+	queryWords := tokenize(text)
+	if len(queryWords) == 0 {
+		return []query.SearchHit{}
+	}
+
+	wanted := make(map[string]bool, len(types))
+	for _, searchType := range types {
+		wanted[searchType] = true
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	type scored struct {
+		hit       query.SearchHit
+		updatedAt time.Time
+	}
+
+	matches := make([]scored, 0)
+	for key := range intersectPostings(i.postings, queryWords) {
+		entry := i.entries[key]
+		if len(wanted) > 0 && !wanted[entry.Type] {
+			continue
+		}
+
+		score, ok := documentScore(entry.words, queryWords)
+		if !ok {
+			continue
+		}
+		if entry.Type == query.SearchTypeTask {
+			score += taskTypeBoost
+		}
+
+		matches = append(matches, scored{
+			hit: query.SearchHit{
+				Type:      entry.Type,
+				ID:        entry.ID,
+				TaskID:    entry.taskID,
+				ProjectID: entry.projectID,
+				Title:     entry.title,
+				Snippet:   highlight(entry.Text, queryWords),
+				Score:     score,
+			},
+			updatedAt: entry.UpdatedAt,
+		})
+	}
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].hit.Score != matches[b].hit.Score {
+			return matches[a].hit.Score > matches[b].hit.Score
+		}
+		if !matches[a].updatedAt.Equal(matches[b].updatedAt) {
+			return matches[a].updatedAt.After(matches[b].updatedAt)
+		}
+		return matches[a].hit.ID < matches[b].hit.ID
+	})
+
+	hits := make([]query.SearchHit, len(matches))
+	for n, match := range matches {
+		hits[n] = match.hit
+	}
+	return hits
+}
+
+// removeTaskLocked deletes the documents of a task; the caller must hold the write lock
+func (i *WorkspaceIndex) removeTaskLocked(taskID string) {
+	for _, key := range i.byTask[taskID] {
+		entry, exists := i.entries[key]
+		if !exists {
+			continue
+		}
+
+		for _, word := range entry.words {
+			for _, prefix := range prefixesOf(word) {
+				delete(i.postings[prefix], key)
+				if len(i.postings[prefix]) == 0 {
+					delete(i.postings, prefix)
+				}
+			}
+		}
+		delete(i.entries, key)
+	}
+	delete(i.byTask, taskID)
+}
+
+// documentScore verifies every query word prefixes a document word, exact words scoring higher
+func documentScore(words, queryWords []string) (float64, bool) {
+	score := 0.0
+	for _, queryWord := range queryWords {
+		best := 0.0
+		for _, word := range words {
+			if word == queryWord {
+				best = matchExactWord
+				break
+			}
+			if strings.HasPrefix(word, queryWord) {
+				best = matchWordPrefix
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		score += best
+	}
+	return score, true
+}
+
+// wordSpan is the rune range of one word in a text
+type wordSpan struct {
+	start   int
+	end     int
+	matched bool
+}
+
+// highlight cuts an HTML-escaped excerpt around the first match, wrapping matched words in <mark>
+func highlight(text string, queryWords []string) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	spans := wordSpans(runes, queryWords)
+
+	start := 0
+	for _, span := range spans {
+		if span.matched {
+			start = span.start - snippetContext
+			break
+		}
+	}
+	if start <= 0 {
+		start = 0
+	} else {
+		// Never open the excerpt in the middle of a word
+		for _, span := range spans {
+			if span.start >= start {
+				start = span.start
+				break
+			}
+		}
+	}
+
+	end := start + snippetLength
+	if end >= len(runes) {
+		end = len(runes)
+	} else {
+		for n := len(spans) - 1; n >= 0; n-- {
+			if spans[n].end <= end && spans[n].end > start {
+				end = spans[n].end
+				break
+			}
+		}
+	}
+
+	var snippet strings.Builder
+	if start > 0 {
+		snippet.WriteString("…")
+	}
+
+	position := start
+	for _, span := range spans {
+		if span.start < start || span.end > end {
+			continue
+		}
+
+		snippet.WriteString(html.EscapeString(string(runes[position:span.start])))
+		word := html.EscapeString(string(runes[span.start:span.end]))
+		if span.matched {
+			snippet.WriteString("<mark>" + word + "</mark>")
+		} else {
+			snippet.WriteString(word)
+		}
+		position = span.end
+	}
+	snippet.WriteString(html.EscapeString(string(runes[position:end])))
+
+	if end < len(runes) {
+		snippet.WriteString("…")
+	}
+	return snippet.String()
+}
+
+// wordSpans splits text into words the same way tokenize does and marks query matches
+func wordSpans(runes []rune, queryWords []string) []wordSpan {
+	isWordRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+
+	spans := make([]wordSpan, 0)
+	for n := 0; n < len(runes); {
+		if !isWordRune(runes[n]) {
+			n++
+			continue
+		}
+
+		start := n
+		for n < len(runes) && isWordRune(runes[n]) {
+			n++
+		}
+
+		word := strings.ToLower(string(runes[start:n]))
+		matched := false
+		for _, queryWord := range queryWords {
+			if strings.HasPrefix(word, queryWord) {
+				matched = true
+				break
+			}
+		}
+		spans = append(spans, wordSpan{start: start, end: n, matched: matched})
+	}
+	return spans
+}
+
+// Ensure WorkspaceIndex implements query.WorkspaceSearchIndex
+var _ query.WorkspaceSearchIndex = (*WorkspaceIndex)(nil)
@@ -0,0 +1,107 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// workspaceTaskEvents are the task events that change what workspace search finds
+var workspaceTaskEvents = []string{
+	"TaskCreated",
+	"TaskCommentAdded",
+	"TaskAttachmentAdded",
+	"TaskDeleted",
+}
+
+// WorkspaceProjector keeps a WorkspaceIndex up to date from domain events.
+// Events do not carry comment bodies, so a changed task is reindexed from the repository.
+type WorkspaceProjector struct {
+	index          *WorkspaceIndex
+	taskRepository domain.TaskRepository
+}
+
+// NewWorkspaceProjector creates a new WorkspaceProjector
+func NewWorkspaceProjector(index *WorkspaceIndex, taskRepository domain.TaskRepository) *WorkspaceProjector {
+	return &WorkspaceProjector{
+		index:          index,
+		taskRepository: taskRepository,
+	}
+}
+
+// Name identifies the projection when rebuilding read models
+func (p *WorkspaceProjector) Name() string {
+	return "workspace_search"
+}
+
+// Reset truncates the index before a replay
+func (p *WorkspaceProjector) Reset() error {
+	p.index.Clear()
+	return nil
+}
+
+// Register subscribes the projector to the events it consumes
+func (p *WorkspaceProjector) Register(subscriber event.EventSubscriber) error {
+	for _, eventType := range workspaceTaskEvents {
+		if err := subscriber.Subscribe(eventType, p.Handle); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// Handle applies a single domain event to the index
+func (p *WorkspaceProjector) Handle(evt event.DomainEvent) error {
+	ctx := context.Background()
+
+	if evt.AggregateType() != "Task" {
+		return nil
+	}
+
+	if _, deleted := evt.(event.TaskDeletedEvent); deleted {
+		p.index.RemoveTask(evt.AggregateID())
+		return nil
+	}
+
+	taskID, err := value.NewTaskID(evt.AggregateID())
+	if err != nil {
+		return fmt.Errorf("invalid task id: %w", err)
+	}
+
+	task, err := p.taskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		// The task is gone, so there is nothing left to find
+		p.index.RemoveTask(taskID.Value())
+		return nil
+	}
+
+	documents := []Document{{
+		Type:      query.SearchTypeTask,
+		ID:        task.ID().Value(),
+		Text:      task.Title() + "\n" + task.Description(),
+		UpdatedAt: task.UpdatedAt(),
+	}}
+	for _, comment := range task.Comments() {
+		documents = append(documents, Document{
+			Type:      query.SearchTypeComment,
+			ID:        comment.ID(),
+			Text:      comment.Content(),
+			UpdatedAt: comment.UpdatedAt(),
+		})
+	}
+	for _, attachment := range task.Attachments() {
+		documents = append(documents, Document{
+			Type:      query.SearchTypeAttachment,
+			ID:        attachment.ID(),
+			Text:      attachment.FileName(),
+			UpdatedAt: attachment.UploadedAt(),
+		})
+	}
+
+	p.index.ReplaceTask(task.ID().Value(), task.ProjectID().Value(), task.Title(), documents)
+	return nil
+}
@@ -0,0 +1,96 @@
+package translation
+
+import (
+	"errors"
+	"sync"
+)
+
+// DefaultCacheSize is how many comment translations a CommentTranslator keeps
+const DefaultCacheSize = 10000
+
+// ErrNoProvider is returned when translations are asked for without a provider plugged in
+var ErrNoProvider = errors.New("no translation provider configured")
+
+// Provider translates text, typically by calling a machine translation service
+type Provider interface {
+	// Translate returns the text translated to the locale, a BCP 47 tag such as "de" or "pt-BR"
+	Translate(text, targetLocale string) (string, error)
+}
+
+// cacheKey identifies one translation of one version of a comment
+type cacheKey struct {
+	commentID string
+	version   int
+	locale    string
+}
+
+// CommentTranslator translates comments through a pluggable provider, caching the result
+// per comment version and locale so that a comment is only sent to the provider again once
+// it is edited. The oldest translations are dropped beyond the cache size.
+type CommentTranslator struct {
+	provider Provider
+	size     int
+	entries  map[cacheKey]string
+	order    []cacheKey // insertion order, oldest first
+	mu       sync.Mutex
+}
+
+// NewCommentTranslator creates a new CommentTranslator without a provider
+func NewCommentTranslator(size int) *CommentTranslator {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+
+	return &CommentTranslator{
+		size:    size,
+		entries: make(map[cacheKey]string),
+	}
+}
+
+// SetProvider plugs in the provider translations are asked of, dropping those cached from
+// the previous one
+func (t *CommentTranslator) SetProvider(provider Provider) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.provider = provider
+	t.entries = make(map[cacheKey]string)
+	t.order = nil
+}
+
+// TranslateComment returns the content of a comment version translated to the locale
+func (t *CommentTranslator) TranslateComment(commentID string, version int, content, locale string) (string, error) {
+	key := cacheKey{commentID: commentID, version: version, locale: locale}
+
+	t.mu.Lock()
+	provider := t.provider
+	translated, cached := t.entries[key]
+	t.mu.Unlock()
+
+	if cached {
+		return translated, nil
+	}
+	if provider == nil {
+		return "", ErrNoProvider
+	}
+
+	// Ask the provider outside the lock, it may be slow
+	translated, err := provider.Translate(content, locale)
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.entries[key]; !exists {
+		t.entries[key] = translated
+		t.order = append(t.order, key)
+		for len(t.order) > t.size {
+			delete(t.entries, t.order[0])
+			t.order = t.order[1:]
+		}
+	}
+
+	return translated, nil
+}
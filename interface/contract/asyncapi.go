@@ -0,0 +1,54 @@
+package contract
+
+import (
+	"github.com/miladev95/ddd-task/application/query"
+)
+
+// GenerateAsyncAPI renders the AsyncAPI 2.6 document for published domain events.
+// Each event type is a channel named after it; payloads are the serializer envelopes.
+func GenerateAsyncAPI(events query.EventSchemas) ([]byte, error) {
+	channels := make(map[string]interface{})
+	messages := make(map[string]interface{})
+
+	for _, schema := range events.Schemas() {
+		payload := make(map[string]interface{}, len(schema.Schema))
+		for key, value := range schema.Schema {
+			if key == "$schema" {
+				continue
+			}
+			payload[key] = value
+		}
+
+		messages[schema.EventType] = map[string]interface{}{
+			"name":             schema.EventType,
+			"contentType":      "application/json",
+			"schemaFormat":     "application/schema+json;version=draft-07",
+			"payload":          payload,
+			"x-schema-version": schema.Version,
+		}
+
+		channels["events."+schema.EventType] = map[string]interface{}{
+			"subscribe": map[string]interface{}{
+				"operationId": "on" + schema.EventType,
+				"message": map[string]interface{}{
+					"$ref": "#/components/messages/" + schema.EventType,
+				},
+			},
+		}
+	}
+
+	document := map[string]interface{}{
+		"asyncapi": "2.6.0",
+		"info": map[string]interface{}{
+			"title":   "Task Management Domain Events",
+			"version": APIVersion,
+		},
+		"defaultContentType": "application/json",
+		"channels":           channels,
+		"components": map[string]interface{}{
+			"messages": messages,
+		},
+	}
+
+	return marshal(document)
+}
@@ -0,0 +1,84 @@
+// Package contract generates the machine-readable API contracts (OpenAPI,
+// AsyncAPI and protobuf) from the route catalog and the event serializer
+// registry, so client SDKs can be generated from files that never drift.
+package contract
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/miladev95/ddd-task/application/query"
+)
+
+// Contract file names, relative to the output directory
+const (
+	OpenAPIFile  = "openapi.json"
+	AsyncAPIFile = "asyncapi.json"
+	ProtoFile    = "events.proto"
+)
+
+// Generate renders every contract file
+func Generate(events query.EventSchemas) (map[string][]byte, error) {
+	openAPI, err := GenerateOpenAPI()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI: %w", err)
+	}
+
+	asyncAPI, err := GenerateAsyncAPI(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate AsyncAPI: %w", err)
+	}
+
+	proto, err := GenerateProto(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate protobuf: %w", err)
+	}
+
+	return map[string][]byte{
+		OpenAPIFile:  openAPI,
+		AsyncAPIFile: asyncAPI,
+		ProtoFile:    proto,
+	}, nil
+}
+
+// Write generates the contracts into dir
+func Write(dir string, events query.EventSchemas) error {
+	files, err := Generate(events)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Check compares the contracts in dir with freshly generated ones and
+// returns the names of files that are missing or out of date
+func Check(dir string, events query.EventSchemas) ([]string, error) {
+	files, err := Generate(events)
+	if err != nil {
+		return nil, err
+	}
+
+	stale := make([]string, 0)
+	for name, data := range files {
+		existing, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(existing) != string(data) {
+			stale = append(stale, name)
+		}
+	}
+
+	sort.Strings(stale)
+	return stale, nil
+}
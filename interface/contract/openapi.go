@@ -0,0 +1,127 @@
+package contract
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+)
+
+// APIVersion is the version advertised by the generated contracts
+const APIVersion = "1.0.0"
+
+// GenerateOpenAPI renders the OpenAPI 3.1 document for the HTTP API
+func GenerateOpenAPI() ([]byte, error) {
+	builder := newSchemaBuilder()
+	paths := make(map[string]interface{})
+
+	for _, op := range Operations() {
+		item, exists := paths[op.Path].(map[string]interface{})
+		if !exists {
+			item = make(map[string]interface{})
+			paths[op.Path] = item
+		}
+		item[strings.ToLower(op.Method)] = operationObject(builder, op)
+	}
+
+	// Clients branch on the problem type, so publish the stable URIs
+	problemTypes := []string{}
+	for _, problemType := range middleware.ProblemTypes() {
+		problemTypes = append(problemTypes, problemType.URI)
+	}
+	if problem, ok := builder.components["Problem"].(map[string]interface{}); ok {
+		problem["properties"].(map[string]interface{})["type"] = map[string]interface{}{"type": "string", "enum": problemTypes}
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":   "Task Management API",
+			"version": APIVersion,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": builder.components,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+	}
+
+	return marshal(document)
+}
+
+// operationObject renders a single OpenAPI operation
+func operationObject(builder *schemaBuilder, op Operation) map[string]interface{} {
+	parameters := []interface{}{}
+	for _, param := range op.Params {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     param.Name,
+			"in":       "query",
+			"required": param.Required,
+			"schema":   map[string]interface{}{"type": param.Type},
+		})
+	}
+
+	operation := map[string]interface{}{
+		"operationId": operationID(op),
+		"summary":     op.Summary,
+		"tags":        []string{op.Tag},
+		"parameters":  parameters,
+		"responses": map[string]interface{}{
+			strconv.Itoa(op.Status): map[string]interface{}{
+				"description": http.StatusText(op.Status),
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": builder.schemaOf(op.Response)},
+				},
+			},
+			"default": map[string]interface{}{
+				"description": "Error",
+				"content": map[string]interface{}{
+					middleware.ProblemContentType: map[string]interface{}{"schema": builder.schemaOf(middleware.Problem{})},
+				},
+			},
+		},
+	}
+
+	// Every operation except the public ones acts as the user of the bearer access token
+	if !op.Public {
+		operation["security"] = []interface{}{map[string]interface{}{"bearerAuth": []interface{}{}}}
+	}
+
+	if op.Request != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": builder.schemaOf(op.Request)},
+			},
+		}
+	}
+
+	return operation
+}
+
+// operationID derives a stable identifier such as postApiTasksStatusCas
+func operationID(op Operation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.Method))
+	for _, segment := range strings.FieldsFunc(op.Path, func(r rune) bool { return r == '/' || r == '-' }) {
+		b.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return b.String()
+}
+
+// marshal renders a document with stable key order and a trailing newline
+func marshal(document interface{}) ([]byte, error) {
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
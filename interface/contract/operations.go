@@ -0,0 +1,527 @@
+package contract
+
+import (
+	"net/http"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/interface/http/handler"
+)
+
+// Param is a query string parameter of an operation
+type Param struct {
+	Name     string
+	Required bool
+	Type     string // JSON Schema primitive type
+}
+
+// Operation describes one HTTP endpoint in the public contract
+type Operation struct {
+	Method   string
+	Path     string
+	Tag      string
+	Summary  string
+	Params   []Param
+	Request  interface{} // request body prototype, nil when there is no body
+	Status   int
+	Response interface{} // response body prototype or description
+	Public   bool        // callable without a bearer access token
+}
+
+func required(name string) Param {
+	return Param{Name: name, Required: true, Type: "string"}
+}
+
+func optional(name, paramType string) Param {
+	return Param{Name: name, Type: paramType}
+}
+
+// paged appends the parameters every paged list endpoint takes to its own
+func paged(params ...Param) []Param {
+	return append(params, optional("limit", "integer"), optional("offset", "integer"), optional("cursor", "string"),
+		optional("sort", "string"), optional("order", "string"))
+}
+
+// message is the response shape of commands that only acknowledge success
+var message = Fields{"message": ""}
+
+// Operations is the catalog of every public HTTP endpoint.
+// Keep it in step with the router; tests fail when the two drift apart.
+func Operations() []Operation {
+	return []Operation{
+		// Auth
+		{Method: http.MethodPost, Path: "/api/auth/register", Tag: "auth", Summary: "Register a user who signs in with a password",
+			Request: dto.RegisterRequest{}, Status: http.StatusCreated,
+			Response: Fields{"user_id": "", "message": ""}, Public: true},
+		{Method: http.MethodPost, Path: "/api/auth/signup", Tag: "auth", Summary: "Sign up a new organization with its owner, a workflow and a sample project, signing the owner in",
+			Request: dto.SignUpRequest{}, Status: http.StatusCreated,
+			Response: dto.SignUpDTO{}, Public: true},
+		{Method: http.MethodPost, Path: "/api/auth/login", Tag: "auth", Summary: "Sign in with email and password, issuing a session token",
+			Request: dto.LoginRequest{}, Status: http.StatusOK,
+			Response: dto.SessionDTO{}, Public: true},
+		{Method: http.MethodPost, Path: "/api/auth/token", Tag: "auth", Summary: "Exchange email and password for access and refresh tokens",
+			Request: dto.LoginRequest{}, Status: http.StatusOK,
+			Response: dto.TokenDTO{}, Public: true},
+		{Method: http.MethodPost, Path: "/api/auth/token/refresh", Tag: "auth", Summary: "Exchange a refresh token for new access and refresh tokens",
+			Request: dto.RefreshTokenRequest{}, Status: http.StatusOK,
+			Response: dto.TokenDTO{}, Public: true},
+		{Method: http.MethodPost, Path: "/api/auth/logout", Tag: "auth", Summary: "End the session of the bearer token",
+			Status:   http.StatusOK,
+			Response: message, Public: true},
+		{Method: http.MethodPut, Path: "/api/auth/password", Tag: "auth", Summary: "Change the password, ending all sessions of the user",
+			Request: dto.ChangePasswordRequest{}, Status: http.StatusOK,
+			Response: Fields{"revoked_sessions": 0, "message": ""}},
+
+		// Users
+		{Method: http.MethodPost, Path: "/api/users", Tag: "users", Summary: "Register a user",
+			Request: handler.CreateUserRequest{}, Status: http.StatusCreated,
+			Response: Fields{"user_id": "", "email": "", "first_name": "", "last_name": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/users", Tag: "users", Summary: "List a page of users by email address, optionally only active or deactivated ones",
+			Params: paged(optional("active", "boolean")), Status: http.StatusOK,
+			Response: PageOf{Key: "users", Item: dto.UserDTO{}}},
+		{Method: http.MethodPut, Path: "/api/users", Tag: "users", Summary: "Change a user's name or email address; a new address must be verified again",
+			Params: []Param{required("id")}, Request: dto.UpdateUserRequest{}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "first_name": "", "last_name": "", "email": "", "email_verified": false, "message": ""}},
+		{Method: http.MethodGet, Path: "/api/users/get", Tag: "users", Summary: "Get a user",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: dto.UserDTO{}},
+		{Method: http.MethodGet, Path: "/api/users/recent", Tag: "users", Summary: "List a user's recently viewed items",
+			Params: []Param{required("id"), optional("limit", "integer")}, Status: http.StatusOK,
+			Response: ListOf{Key: "items", Item: dto.RecentViewDTO{}}},
+		{Method: http.MethodPost, Path: "/api/users/verify", Tag: "users", Summary: "Verify a user's email address with the emailed token",
+			Request: dto.VerifyEmailRequest{}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "email": "", "message": ""}, Public: true},
+		{Method: http.MethodPost, Path: "/api/users/deactivate", Tag: "users", Summary: "Deactivate a user, handing their open tasks to a fallback assignee or unassigning them",
+			Params: []Param{required("id")}, Request: dto.DeactivateUserRequest{}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "message": ""}},
+		{Method: http.MethodPost, Path: "/api/users/activate", Tag: "users", Summary: "Activate a deactivated user again",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "message": ""}},
+		{Method: http.MethodPut, Path: "/api/users/email", Tag: "users", Summary: "Change a user's email address, which must then be verified again",
+			Params: []Param{required("id")}, Request: dto.ChangeEmailRequest{}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "email": "", "message": ""}},
+		{Method: http.MethodPut, Path: "/api/users/working-hours", Tag: "users", Summary: "Set the hours a user works each day and their working days",
+			Params: []Param{required("id")}, Request: dto.WorkingHoursRequest{}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/users/working-hours", Tag: "users", Summary: "Return a user to their organization's default working hours",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "message": ""}},
+		{Method: http.MethodPut, Path: "/api/users/locale", Tag: "users", Summary: "Set the locale comments are translated to for a user",
+			Params: []Param{required("id")}, Request: dto.UserLocaleRequest{}, Status: http.StatusOK,
+			Response: Fields{"locale": "", "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/users/locale", Tag: "users", Summary: "Clear a user's locale, falling back to Accept-Language",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"locale": "", "message": ""}},
+		{Method: http.MethodPut, Path: "/api/users/out-of-office", Tag: "users", Summary: "Replace the periods a user is out of office, checked when deadlines are set",
+			Params: []Param{required("id")}, Request: dto.UserOutOfOfficeRequest{}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "out_of_office": []dto.OutOfOfficeDTO{}, "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/users/out-of-office", Tag: "users", Summary: "Clear a user's out-of-office periods",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "out_of_office": []dto.OutOfOfficeDTO{}, "message": ""}},
+		{Method: http.MethodPut, Path: "/api/users/manager", Tag: "users", Summary: "Set who a user reports to (admin only)",
+			Params: []Param{required("id")}, Request: dto.UserManagerRequest{}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "manager_id": "", "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/users/manager", Tag: "users", Summary: "Clear a user's manager (admin only)",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"user_id": "", "manager_id": "", "message": ""}},
+
+		// Workflows
+		{Method: http.MethodPost, Path: "/api/workflows", Tag: "workflows", Summary: "Create a workflow",
+			Request: handler.CreateWorkflowRequest{}, Status: http.StatusCreated,
+			Response: Fields{"workflow_id": "", "name": "", "description": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/workflows", Tag: "workflows", Summary: "List a page of the latest versions of the workflows by name, optionally only active or inactive ones",
+			Params: paged(optional("active", "boolean")), Status: http.StatusOK,
+			Response: PageOf{Key: "workflows", Item: dto.WorkflowDTO{}}},
+		{Method: http.MethodPut, Path: "/api/workflows", Tag: "workflows", Summary: "Rename a workflow or change its description as a new version (admin only)",
+			Params: []Param{required("id")}, Request: dto.UpdateWorkflowRequest{}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "name": "", "description": "", "version": 0, "message": ""}},
+		{Method: http.MethodGet, Path: "/api/workflows/get", Tag: "workflows", Summary: "Get a workflow",
+			Params: []Param{required("id"), optional("version", "integer")}, Status: http.StatusOK,
+			Response: dto.WorkflowDTO{}},
+		{Method: http.MethodPost, Path: "/api/workflows/statuses", Tag: "workflows", Summary: "Add a status after a workflow's existing ones",
+			Params: []Param{required("id")}, Request: dto.AddWorkflowStatusRequest{}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "statuses": []string{}, "version": 0, "message": ""}},
+		{Method: http.MethodPut, Path: "/api/workflows/statuses", Tag: "workflows", Summary: "Put a workflow's statuses in a new order",
+			Params: []Param{required("id")}, Request: dto.ReorderWorkflowStatusesRequest{}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "statuses": []string{}, "version": 0, "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/workflows/statuses", Tag: "workflows", Summary: "Remove a status no task is in, with its transitions",
+			Params: []Param{required("id"), required("name")}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "statuses": []string{}, "version": 0, "message": ""}},
+		{Method: http.MethodPost, Path: "/api/workflows/transitions", Tag: "workflows", Summary: "Allow tasks to move between two workflow statuses",
+			Params: []Param{required("id")}, Request: dto.WorkflowTransitionInput{}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "transitions": []dto.WorkflowTransitionInput{}, "version": 0, "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/workflows/transitions", Tag: "workflows", Summary: "Stop tasks from moving between two workflow statuses",
+			Params: []Param{required("id"), required("from"), required("to")}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "transitions": []dto.WorkflowTransitionInput{}, "version": 0, "message": ""}},
+		{Method: http.MethodPut, Path: "/api/workflows/transitions/rules", Tag: "workflows", Summary: "Set the guards and actions of a workflow transition",
+			Params: []Param{required("id")}, Request: dto.TransitionRulesInput{}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "rules": dto.TransitionRulesInput{}, "version": 0, "message": ""}},
+		{Method: http.MethodPut, Path: "/api/workflows/approval-steps", Tag: "workflows", Summary: "Make tasks need sign-off from designated reviewers before moving on from a status",
+			Params: []Param{required("id")}, Request: dto.WorkflowApprovalStepRequest{}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "approval_step": dto.WorkflowApprovalStepDTO{}, "version": 0, "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/workflows/approval-steps", Tag: "workflows", Summary: "Remove the approval step of a workflow status",
+			Params: []Param{required("id"), required("status")}, Status: http.StatusOK,
+			Response: Fields{"workflow_id": "", "approval_step": dto.WorkflowApprovalStepDTO{}, "version": 0, "message": ""}},
+
+		// Projects
+		{Method: http.MethodPost, Path: "/api/projects", Tag: "projects", Summary: "Create a project",
+			Request: handler.CreateProjectRequest{}, Status: http.StatusCreated,
+			Response: Fields{"project_id": "", "name": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/projects", Tag: "projects", Summary: "List a page of the projects the user may see by name, filtered by owner and archived state",
+			Params: paged(optional("owner_id", "string"), optional("archived", "boolean")), Status: http.StatusOK,
+			Response: PageOf{Key: "projects", Item: dto.ProjectDTO{}}},
+		{Method: http.MethodPut, Path: "/api/projects", Tag: "projects", Summary: "Rename a project or change its description",
+			Params: []Param{required("id")}, Request: dto.UpdateProjectRequest{}, Status: http.StatusOK,
+			Response: Fields{"project_id": "", "name": "", "description": "", "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/projects", Tag: "projects", Summary: "Delete a project along with its tasks (owner only)",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"deleted_task_ids": []string{}, "message": ""}},
+		{Method: http.MethodGet, Path: "/api/projects/get", Tag: "projects", Summary: "Get a project",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: dto.ProjectDTO{}},
+		{Method: http.MethodGet, Path: "/api/projects/activity", Tag: "projects", Summary: "List a project's activity stream newest first, a page of limit entries before the sequence given as before",
+			Params: []Param{required("id"), optional("before", "integer"), optional("limit", "integer")}, Status: http.StatusOK,
+			Response: ListOf{Key: "activity", Item: dto.ActivityEntryDTO{}}},
+		{Method: http.MethodGet, Path: "/api/projects/stats", Tag: "projects", Summary: "Get project statistics and SLIs",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: dto.ProjectStatsDTO{}},
+		{Method: http.MethodPut, Path: "/api/projects/slo", Tag: "projects", Summary: "Set project SLO targets",
+			Params: []Param{required("id")}, Request: dto.SetProjectSLORequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/projects/board", Tag: "projects", Summary: "Get a project's kanban board grouped by workflow column, sorted as asked or as the caller prefers",
+			Params: []Param{required("id"), optional("sort", "string")}, Status: http.StatusOK,
+			Response: dto.BoardDTO{}},
+		{Method: http.MethodPut, Path: "/api/projects/board/sort", Tag: "projects", Summary: "Choose how the caller's board of a project is sorted: CREATED or PRIORITY",
+			Params: []Param{required("id")}, Request: dto.SetBoardSortRequest{}, Status: http.StatusOK,
+			Response: Fields{"sort": "", "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/projects/board/sort", Tag: "projects", Summary: "Return the caller's board of a project to creation order",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"sort": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/projects/settings", Tag: "projects", Summary: "Get a project's settings",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: dto.ProjectSettingsDTO{}},
+		{Method: http.MethodPut, Path: "/api/projects/settings", Tag: "projects", Summary: "Replace a project's settings",
+			Params: []Param{required("id")}, Request: dto.SetProjectSettingsRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPost, Path: "/api/projects/settings/inbox", Tag: "projects", Summary: "Generate an inbound email address whose mail becomes tasks of the project",
+			Params: []Param{required("id")}, Request: dto.AddInboxAddressRequest{}, Status: http.StatusCreated,
+			Response: Fields{"token": "", "address": "", "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/projects/settings/inbox", Tag: "projects", Summary: "Revoke a project's inbound email address",
+			Params: []Param{required("id"), required("token")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPut, Path: "/api/projects/access", Tag: "projects", Summary: "Replace a project's visibility and members",
+			Params: []Param{required("id")}, Request: dto.SetProjectAccessRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/projects/budget", Tag: "projects", Summary: "Get a project's spend against its budget",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: dto.BudgetSummaryDTO{}},
+		{Method: http.MethodPut, Path: "/api/projects/budget", Tag: "projects", Summary: "Set or clear a project's budget",
+			Params: []Param{required("id")}, Request: dto.SetProjectBudgetRequest{}, Status: http.StatusOK,
+			Response: Fields{"exceeded": false, "message": ""}},
+		{Method: http.MethodGet, Path: "/api/projects/notification-routes", Tag: "projects", Summary: "List a project's notification routing rules",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: ListOf{Key: "routes", Item: dto.NotificationRouteDTO{}}},
+		{Method: http.MethodPut, Path: "/api/projects/notification-routes", Tag: "projects", Summary: "Replace a project's notification routing rules",
+			Params: []Param{required("id")}, Request: dto.SetNotificationRoutesRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPost, Path: "/api/projects/archive", Tag: "projects", Summary: "Archive a project, freezing or cancelling its open tasks",
+			Params: []Param{required("id")}, Request: dto.ArchiveProjectRequest{}, Status: http.StatusOK,
+			Response: Fields{"affected_task_ids": []string{}, "message": ""}},
+		{Method: http.MethodPut, Path: "/api/projects/workflow", Tag: "projects", Summary: "Move a project onto another workflow",
+			Params: []Param{required("id")}, Request: dto.ChangeProjectWorkflowRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPost, Path: "/api/projects/workflow/migrate", Tag: "projects", Summary: "Move a project's tasks to the latest version of its workflow, mapping statuses it dropped",
+			Params: []Param{required("id")}, Request: dto.MigrateProjectWorkflowRequest{}, Status: http.StatusOK,
+			Response: Fields{"workflow_version": "", "migrated": 0, "message": ""}},
+		{Method: http.MethodPost, Path: "/api/projects/workflow/migration", Tag: "projects", Summary: "Plan moving a live project onto another workflow, validating the status mapping for every task",
+			Params: []Param{required("id")}, Request: dto.PlanWorkflowMigrationRequest{}, Status: http.StatusCreated,
+			Response: Fields{"migration_id": "", "total": 0, "remapped": 0, "message": ""}},
+		{Method: http.MethodGet, Path: "/api/projects/workflow/migration", Tag: "projects", Summary: "Get a project's latest workflow migration plan and its progress",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: dto.WorkflowMigrationDTO{}},
+		{Method: http.MethodPost, Path: "/api/projects/workflow/migration/apply", Tag: "projects", Summary: "Apply a planned workflow migration in batches, resuming one that stopped, then switch the project's workflow",
+			Params: []Param{required("id")}, Request: dto.ApplyWorkflowMigrationRequest{}, Status: http.StatusOK,
+			Response: Fields{"migration_id": "", "applied": 0, "total": 0, "status": "", "message": ""}},
+		{Method: http.MethodPost, Path: "/api/projects/workflow/migration/rollback", Tag: "projects", Summary: "Restore the tasks a workflow migration moved, and the project's previous workflow",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"migration_id": "", "restored": 0, "message": ""}},
+		{Method: http.MethodPost, Path: "/api/projects/workflow/simulate", Tag: "projects", Summary: "Check which of a project's tasks a proposed workflow would invalidate",
+			Params: []Param{required("id")}, Request: dto.SimulateWorkflowRequest{}, Status: http.StatusOK,
+			Response: dto.WorkflowSimulationDTO{}},
+		{Method: http.MethodPut, Path: "/api/projects/holiday-calendar", Tag: "projects", Summary: "Make a project observe a holiday calendar of its owner's organization",
+			Params: []Param{required("id")}, Request: dto.SetProjectHolidayCalendarRequest{}, Status: http.StatusOK,
+			Response: Fields{"calendar_id": "", "region": "", "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/projects/holiday-calendar", Tag: "projects", Summary: "Stop a project observing holidays",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"calendar_id": "", "region": "", "message": ""}},
+		{Method: http.MethodPut, Path: "/api/projects/priority-scheme", Tag: "projects", Summary: "Replace the ladder of priorities a project's tasks may have, remapping tasks off dropped priorities",
+			Params: []Param{required("id")}, Request: dto.SetPrioritySchemeRequest{}, Status: http.StatusOK,
+			Response: Fields{"priorities": []string{}, "default_priority": "", "remapped": 0, "message": ""}},
+		{Method: http.MethodPut, Path: "/api/projects/roles", Tag: "projects", Summary: "Assign or revoke a user's role in a project",
+			Params: []Param{required("id")}, Request: dto.AssignProjectRoleRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPost, Path: "/api/projects/milestones", Tag: "projects", Summary: "Add a milestone to a project",
+			Params: []Param{required("id")}, Request: dto.MilestoneRequest{}, Status: http.StatusCreated,
+			Response: Fields{"milestone_id": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/projects/milestones", Tag: "projects", Summary: "List a project's milestones with progress",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: ListOf{Key: "milestones", Item: dto.MilestoneDTO{}}},
+		{Method: http.MethodPut, Path: "/api/projects/milestones", Tag: "projects", Summary: "Update a milestone",
+			Params: []Param{required("id"), required("milestone_id")}, Request: dto.MilestoneRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodDelete, Path: "/api/projects/milestones", Tag: "projects", Summary: "Delete a milestone",
+			Params: []Param{required("id"), required("milestone_id")}, Status: http.StatusOK,
+			Response: message},
+
+		// Tasks
+		{Method: http.MethodPost, Path: "/api/tasks", Tag: "tasks", Summary: "Create a task",
+			Request: dto.CreateTaskRequest{}, Status: http.StatusCreated,
+			Response: Fields{"task_id": "", "message": "", "possible_duplicates": []string{}, "warnings": []string{}}},
+		{Method: http.MethodGet, Path: "/api/tasks", Tag: "tasks", Summary: "List a page of a project's tasks, oldest first unless sorted otherwise",
+			Params: paged(required("project_id"), optional("status", "string")), Status: http.StatusOK,
+			Response: PageOf{Key: "tasks", Item: dto.TaskDTO{}}},
+		{Method: http.MethodDelete, Path: "/api/tasks", Tag: "tasks", Summary: "Delete a task",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/tasks/get", Tag: "tasks", Summary: "Get a task, with translate=true its comments translated to the caller's locale or Accept-Language",
+			Params: []Param{required("id"), optional("translate", "boolean")}, Status: http.StatusOK,
+			Response: dto.TaskDTO{}},
+		{Method: http.MethodGet, Path: "/api/tasks/overdue", Tag: "tasks", Summary: "List open tasks past their deadline in a project, or in every project the caller may see, most overdue first",
+			Params: []Param{optional("project_id", "string")}, Status: http.StatusOK,
+			Response: ListOf{Key: "tasks", Item: dto.TaskDTO{}}},
+		{Method: http.MethodGet, Path: "/api/tasks/due", Tag: "tasks", Summary: "List open tasks due within a duration such as 72h, in a project or every project the caller may see, soonest first",
+			Params: []Param{required("within"), optional("project_id", "string")}, Status: http.StatusOK,
+			Response: ListOf{Key: "tasks", Item: dto.TaskDTO{}}},
+		{Method: http.MethodGet, Path: "/api/tasks/history", Tag: "tasks", Summary: "List a task's history with status change reasons, a page of limit entries after the version given as after",
+			Params: []Param{required("id"), optional("after", "integer"), optional("limit", "integer")}, Status: http.StatusOK,
+			Response: ListOf{Key: "history", Item: dto.TaskHistoryEntryDTO{}}},
+		{Method: http.MethodPost, Path: "/api/tasks/assign", Tag: "tasks", Summary: "Assign a task",
+			Params: []Param{required("id")}, Request: dto.AssignTaskRequest{}, Status: http.StatusOK,
+			Response: Fields{"message": "", "warnings": []string{}}},
+		{Method: http.MethodPost, Path: "/api/tasks/acknowledge", Tag: "tasks", Summary: "Acknowledge a task assigned to you, which opening it also does",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPost, Path: "/api/tasks/assign-team", Tag: "tasks", Summary: "Assign a task to a team",
+			Params: []Param{required("id")}, Request: dto.AssignTaskToTeamRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPost, Path: "/api/tasks/reassign", Tag: "tasks", Summary: "Hand all of a user's open tasks to another user",
+			Request: dto.ReassignAllTasksRequest{}, Status: http.StatusOK,
+			Response: dto.ReassignmentReportDTO{}},
+		{Method: http.MethodPut, Path: "/api/tasks/status", Tag: "tasks", Summary: "Change a task's status",
+			Params: []Param{required("id")}, Request: dto.UpdateTaskStatusRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPost, Path: "/api/tasks/status/cas", Tag: "tasks", Summary: "Change a task's status if it still has the expected status",
+			Params: []Param{required("id")}, Request: dto.CompareAndSetStatusRequest{}, Status: http.StatusOK,
+			Response: Fields{"applied": false, "current_status": "", "task": dto.TaskDTO{}}},
+		{Method: http.MethodPost, Path: "/api/tasks/comments", Tag: "tasks", Summary: "Comment on a task",
+			Params: []Param{required("id")}, Request: dto.AddCommentRequest{}, Status: http.StatusCreated,
+			Response: Fields{"comment_id": "", "message": ""}},
+		{Method: http.MethodPost, Path: "/api/tasks/attachments", Tag: "tasks", Summary: "Record a file attached to a task",
+			Params: []Param{required("id")}, Request: dto.AddAttachmentRequest{}, Status: http.StatusCreated,
+			Response: Fields{"attachment_id": "", "message": ""}},
+		{Method: http.MethodPost, Path: "/api/tasks/links", Tag: "tasks", Summary: "Link two tasks",
+			Params: []Param{required("id")}, Request: dto.LinkTaskRequest{}, Status: http.StatusCreated,
+			Response: message},
+		{Method: http.MethodDelete, Path: "/api/tasks/links", Tag: "tasks", Summary: "Unlink two tasks",
+			Params: []Param{required("id"), required("target_id"), required("type")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/tasks/duplicates", Tag: "tasks", Summary: "Find tasks with titles similar to a proposed one",
+			Params: []Param{required("project_id"), required("title"), optional("threshold", "number")}, Status: http.StatusOK,
+			Response: ListOf{Key: "candidates", Item: dto.DuplicateCandidateDTO{}}},
+		{Method: http.MethodPost, Path: "/api/tasks/vote", Tag: "tasks", Summary: "Vote for a task",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"vote_count": 0, "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/tasks/vote", Tag: "tasks", Summary: "Withdraw a vote",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"vote_count": 0, "message": ""}},
+		{Method: http.MethodPost, Path: "/api/tasks/approve", Tag: "tasks", Summary: "Approve a task in its current status",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"approval_count": 0, "required_approvals": 0, "message": ""}},
+		{Method: http.MethodPost, Path: "/api/tasks/reject", Tag: "tasks", Summary: "Reject a task in its current status, keeping it from moving on",
+			Params: []Param{required("id")}, Request: dto.RejectTaskRequest{}, Status: http.StatusOK,
+			Response: Fields{"approval_count": 0, "message": ""}},
+		{Method: http.MethodPost, Path: "/api/tasks/edit-lock", Tag: "tasks", Summary: "Acquire the advisory edit lock on a task description",
+			Params: []Param{required("id")}, Request: dto.EditLockRequest{}, Status: http.StatusOK,
+			Response: Fields{"locked": false, "expires_at": ""}},
+		{Method: http.MethodPut, Path: "/api/tasks/edit-lock", Tag: "tasks", Summary: "Renew a held edit lock",
+			Params: []Param{required("id")}, Request: dto.EditLockRequest{}, Status: http.StatusOK,
+			Response: Fields{"locked": false, "expires_at": ""}},
+		{Method: http.MethodDelete, Path: "/api/tasks/edit-lock", Tag: "tasks", Summary: "Release a held edit lock",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"locked": false, "expires_at": ""}},
+		{Method: http.MethodPut, Path: "/api/tasks/description", Tag: "tasks", Summary: "Edit a task description, refused while another user holds the edit lock",
+			Params: []Param{required("id")}, Request: dto.UpdateTaskDescriptionRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPut, Path: "/api/tasks/deadline", Tag: "tasks", Summary: "Set or move a task deadline, or count business days from today skipping the project's holidays; a reason is required to postpone it; warns when the assignee is out of office on the deadline",
+			Params: []Param{required("id")}, Request: dto.ChangeTaskDeadlineRequest{}, Status: http.StatusOK,
+			Response: Fields{"deadline": "", "deadline_slip_count": 0, "message": "", "warnings": []string{}}},
+		{Method: http.MethodPost, Path: "/api/tasks/costs", Tag: "tasks", Summary: "Record money spent on a task",
+			Params: []Param{required("id")}, Request: dto.RecordCostRequest{}, Status: http.StatusCreated,
+			Response: Fields{"entry_id": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/workload/heatmap", Tag: "tasks", Summary: "Get the assignee workload heatmap",
+			Params: []Param{optional("weeks", "integer"), optional("project_id", "string")}, Status: http.StatusOK,
+			Response: dto.WorkloadHeatmapDTO{}},
+
+		// Widgets
+		{Method: http.MethodPost, Path: "/api/widgets", Tag: "widgets", Summary: "Create a dashboard widget",
+			Request: dto.CreateWidgetRequest{}, Status: http.StatusCreated,
+			Response: Fields{"widget_id": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/widgets", Tag: "widgets", Summary: "List a page of the caller's widgets by their place on the grid",
+			Params: paged(), Status: http.StatusOK, Response: PageOf{Key: "widgets", Item: dto.WidgetDTO{}}},
+		{Method: http.MethodPut, Path: "/api/widgets", Tag: "widgets", Summary: "Update a widget",
+			Params: []Param{required("id")}, Request: dto.UpdateWidgetRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodDelete, Path: "/api/widgets", Tag: "widgets", Summary: "Delete a widget",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/widgets/evaluate", Tag: "widgets", Summary: "Evaluate all of the caller's widgets",
+			Status: http.StatusOK, Response: ListOf{Key: "results", Item: dto.WidgetResultDTO{}}},
+
+		// Sprints
+		{Method: http.MethodPost, Path: "/api/sprints", Tag: "sprints", Summary: "Plan a sprint",
+			Request: dto.CreateSprintRequest{}, Status: http.StatusCreated,
+			Response: Fields{"sprint_id": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/sprints", Tag: "sprints", Summary: "List a page of a project's sprints by start date",
+			Params: paged(required("project_id"), optional("status", "string")), Status: http.StatusOK,
+			Response: PageOf{Key: "sprints", Item: dto.SprintDTO{}}},
+		{Method: http.MethodPost, Path: "/api/sprints/start", Tag: "sprints", Summary: "Start a sprint",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPost, Path: "/api/sprints/complete", Tag: "sprints", Summary: "Complete a sprint",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: Fields{"carried_over_task_ids": []string{}, "message": ""}},
+		{Method: http.MethodPost, Path: "/api/sprints/tasks", Tag: "sprints", Summary: "Add a task to a sprint",
+			Params: []Param{required("id")}, Request: dto.SprintTaskRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodDelete, Path: "/api/sprints/tasks", Tag: "sprints", Summary: "Remove a task from a sprint",
+			Params: []Param{required("id"), required("task_id")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/sprints/tasks", Tag: "sprints", Summary: "List a sprint's tasks",
+			Params: []Param{required("id"), optional("status", "string")}, Status: http.StatusOK,
+			Response: ListOf{Key: "tasks", Item: dto.TaskDTO{}}},
+
+		// Teams
+		{Method: http.MethodPost, Path: "/api/teams", Tag: "teams", Summary: "Create a team led by the caller or a named lead",
+			Request: dto.CreateTeamRequest{}, Status: http.StatusCreated,
+			Response: Fields{"team_id": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/teams/get", Tag: "teams", Summary: "Get a team",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: dto.TeamDTO{}},
+		{Method: http.MethodPost, Path: "/api/teams/members", Tag: "teams", Summary: "Add a member to a team",
+			Params: []Param{required("id")}, Request: dto.TeamMemberRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodDelete, Path: "/api/teams/members", Tag: "teams", Summary: "Remove a member from a team",
+			Params: []Param{required("id"), required("user_id")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPut, Path: "/api/teams/lead", Tag: "teams", Summary: "Make a member the team lead",
+			Params: []Param{required("id")}, Request: dto.TeamMemberRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/teams/tasks", Tag: "teams", Summary: "List a page of the tasks assigned to a team and optionally its members, newest first",
+			Params: paged(required("id"), optional("status", "string"), optional("include_members", "boolean")), Status: http.StatusOK,
+			Response: PageOf{Key: "tasks", Item: dto.TaskDTO{}}},
+
+		// Organizations
+		{Method: http.MethodPost, Path: "/api/organizations", Tag: "organizations", Summary: "Create an organization with its members and quota (admin only)",
+			Request: dto.CreateOrganizationRequest{}, Status: http.StatusCreated,
+			Response: Fields{"organization_id": "", "message": ""}},
+		{Method: http.MethodPost, Path: "/api/organizations/members", Tag: "organizations", Summary: "Add a member to an organization (admin only)",
+			Params: []Param{required("id")}, Request: dto.OrganizationMemberRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPut, Path: "/api/organizations/quota", Tag: "organizations", Summary: "Replace an organization's quota, zero meaning unlimited (admin only)",
+			Params: []Param{required("id")}, Request: dto.OrganizationQuotaRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodPut, Path: "/api/organizations/working-hours", Tag: "organizations", Summary: "Set the working hours members follow unless they set their own (admin only)",
+			Params: []Param{required("id")}, Request: dto.WorkingHoursRequest{}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodDelete, Path: "/api/organizations/working-hours", Tag: "organizations", Summary: "Return members to the built-in default of 8 hours, Monday to Friday (admin only)",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/organizations/usage", Tag: "organizations", Summary: "Show an organization's usage against its quota, the caller's own by default",
+			Params: []Param{optional("id", "string")}, Status: http.StatusOK,
+			Response: dto.OrganizationUsageDTO{}},
+		{Method: http.MethodGet, Path: "/api/org/directory", Tag: "organizations", Summary: "List the caller's organization: members with roles, teams and reporting lines",
+			Status:   http.StatusOK,
+			Response: dto.OrganizationDirectoryDTO{}},
+
+		// Holiday calendars
+		{Method: http.MethodPost, Path: "/api/holiday-calendars", Tag: "holiday-calendars", Summary: "Create an organization's holiday calendar for a region (admin only)",
+			Request: dto.CreateHolidayCalendarRequest{}, Status: http.StatusCreated,
+			Response: Fields{"calendar_id": "", "message": ""}},
+		{Method: http.MethodGet, Path: "/api/holiday-calendars", Tag: "holiday-calendars", Summary: "List a page of an organization's holiday calendars by region",
+			Params: paged(required("organization_id")), Status: http.StatusOK,
+			Response: PageOf{Key: "calendars", Item: dto.HolidayCalendarDTO{}}},
+		{Method: http.MethodGet, Path: "/api/holiday-calendars/get", Tag: "holiday-calendars", Summary: "Get a holiday calendar",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: dto.HolidayCalendarDTO{}},
+		{Method: http.MethodPut, Path: "/api/holiday-calendars", Tag: "holiday-calendars", Summary: "Rename a holiday calendar and replace its holidays (admin only)",
+			Params: []Param{required("id")}, Request: dto.UpdateHolidayCalendarRequest{}, Status: http.StatusOK,
+			Response: Fields{"holiday_count": 0, "message": ""}},
+		{Method: http.MethodDelete, Path: "/api/holiday-calendars", Tag: "holiday-calendars", Summary: "Delete a holiday calendar no project observes (admin only)",
+			Params: []Param{required("id")}, Status: http.StatusOK,
+			Response: message},
+
+		// Search
+		{Method: http.MethodGet, Path: "/api/search", Tag: "search", Summary: "Full text search across tasks, comments and attachment names the user may see",
+			Params: []Param{required("q"), optional("types", "string"), optional("limit", "integer")}, Status: http.StatusOK,
+			Response: ListOf{Key: "results", Item: dto.SearchResultDTO{}}},
+		{Method: http.MethodGet, Path: "/api/search/tasks", Tag: "search", Summary: "Search the tasks the user may see by title, description and comments, filtered by project, status, priority and assignee",
+			Params: []Param{optional("q", "string"), optional("project_id", "string"), optional("status", "string"), optional("priority", "string"), optional("assignee_id", "string"), optional("limit", "integer")}, Status: http.StatusOK,
+			Response: ListOf{Key: "results", Item: dto.TaskSearchResultDTO{}}},
+		{Method: http.MethodGet, Path: "/api/search/suggest", Tag: "search", Summary: "Typeahead suggestions across tasks, projects and users",
+			Params: []Param{required("q"), optional("limit", "integer")}, Status: http.StatusOK,
+			Response: ListOf{Key: "suggestions", Item: dto.SuggestionDTO{}}},
+
+		// Events
+		{Method: http.MethodGet, Path: "/api/events/schemas", Tag: "events", Summary: "List event types with their JSON Schemas",
+			Status: http.StatusOK, Response: Fields{"schemas": []interface{}{}, "count": 0}, Public: true},
+		{Method: http.MethodGet, Path: "/api/events", Tag: "events", Summary: "Page through the stored events, oldest first, by aggregate, type and time (admin only)",
+			Params: []Param{optional("aggregate_id", "string"), optional("type", "string"), optional("since", "string"),
+				optional("after", "integer"), optional("limit", "integer")},
+			Status: http.StatusOK, Response: dto.EventPageDTO{}},
+
+		// Inbound email
+		{Method: http.MethodPost, Path: "/api/inbound/email", Tag: "inbound", Summary: "Turn mail relayed to a project inbox address into a task, sent with the X-Inbound-Email-Secret header when one is configured",
+			Request: dto.InboundEmailRequest{}, Status: http.StatusCreated,
+			Response: Fields{"task_id": "", "project_id": "", "message": ""}, Public: true},
+
+		// Presence
+		{Method: http.MethodGet, Path: "/api/presence", Tag: "presence", Summary: "List who is currently viewing a task or board",
+			Params: []Param{required("kind"), required("id")}, Status: http.StatusOK,
+			Response: dto.PresenceDTO{}},
+		{Method: http.MethodPut, Path: "/api/presence", Tag: "presence", Summary: "Send a presence heartbeat, the fallback for clients without WebSockets",
+			Request: dto.PresenceHeartbeatRequest{}, Status: http.StatusOK,
+			Response: Fields{"session_id": "", "ttl_seconds": 0, "presence": dto.PresenceDTO{}}},
+		{Method: http.MethodDelete, Path: "/api/presence", Tag: "presence", Summary: "End a presence session",
+			Params: []Param{required("session_id")}, Status: http.StatusOK,
+			Response: message},
+		{Method: http.MethodGet, Path: "/api/presence/ws", Tag: "presence", Summary: "Open the presence WebSocket, exchanging PresenceMessage frames",
+			Params: []Param{optional("user_id", "string")}, Status: http.StatusSwitchingProtocols,
+			Response: dto.PresenceMessage{}},
+
+		// Admin
+		{Method: http.MethodPost, Path: "/api/admin/projections/rebuild", Tag: "admin", Summary: "Replay the event store into all read models",
+			Status: http.StatusOK, Response: Fields{"projections": []string{}, "events_replayed": 0, "duration_ms": 0}},
+		{Method: http.MethodPost, Path: "/api/admin/search/reindex", Tag: "admin", Summary: "Replay the event store into the search read models only",
+			Status: http.StatusOK, Response: Fields{"projections": []string{}, "events_replayed": 0, "duration_ms": 0}},
+		{Method: http.MethodGet, Path: "/api/admin/integrity", Tag: "admin", Summary: "Reconcile every project's task list with the tasks, reporting disagreements",
+			Status: http.StatusOK, Response: dto.IntegrityReportDTO{}},
+		{Method: http.MethodGet, Path: "/api/admin/backup", Tag: "admin", Summary: "Export the event store as application/x-ndjson, one EventEnvelope per line",
+			Status: http.StatusOK, Response: dto.EventEnvelopeDTO{}},
+		{Method: http.MethodPost, Path: "/api/admin/events/prune", Tag: "admin", Summary: "Remove the event streams of aggregates untouched since an RFC 3339 timestamp",
+			Params: []Param{required("before")}, Status: http.StatusOK,
+			Response: Fields{"events_pruned": 0, "message": ""}},
+		{Method: http.MethodGet, Path: "/api/admin/events/metrics", Tag: "admin", Summary: "Report outbox backlog, projection freshness and subscriber error rates",
+			Status: http.StatusOK, Response: dto.EventMetricsSnapshotDTO{}},
+		{Method: http.MethodGet, Path: "/api/admin/events/stats", Tag: "admin", Summary: "Count events by type and aggregate, with each project's top event producers",
+			Params: []Param{optional("project_id", "string"), optional("top", "integer")}, Status: http.StatusOK,
+			Response: dto.EventStatsDTO{}},
+		{Method: http.MethodGet, Path: "/api/admin/billing/usage", Tag: "admin", Summary: "Preview an organization's metered usage for a month, the current one by default",
+			Params: []Param{required("organization_id"), optional("month", "string")}, Status: http.StatusOK,
+			Response: dto.MonthlyUsageDTO{}},
+		{Method: http.MethodGet, Path: "/api/admin/integrations/health", Tag: "admin", Summary: "Report each integration's last success, recent failures, breaker state and next retry",
+			Status: http.StatusOK, Response: dto.IntegrationHealthReportDTO{}},
+
+		// Health
+		{Method: http.MethodGet, Path: "/health", Tag: "health", Summary: "Health check",
+			Status: http.StatusOK, Response: Fields{"status": ""}, Public: true},
+	}
+}
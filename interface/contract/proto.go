@@ -0,0 +1,99 @@
+package contract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miladev95/ddd-task/application/query"
+)
+
+// protoPackage is the protobuf package of the generated event messages
+const protoPackage = "taskmanagement.events.v1"
+
+// GenerateProto renders protobuf definitions for published domain events.
+// Field numbers follow payload field declaration order, so new event fields
+// must be appended to the end of their struct to keep numbers stable.
+func GenerateProto(events query.EventSchemas) ([]byte, error) {
+	var b strings.Builder
+
+	b.WriteString("// Code generated by cmd/gen-contracts. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", protoPackage)
+
+	b.WriteString("// EventEnvelope wraps every event; payload holds the message named by event_type.\n")
+	b.WriteString("message EventEnvelope {\n")
+	b.WriteString("  string event_type = 1;\n")
+	b.WriteString("  int32 schema_version = 2;\n")
+	b.WriteString("  string aggregate_id = 3;\n")
+	b.WriteString("  string aggregate_type = 4;\n")
+	b.WriteString("  string occurred_at = 5; // RFC 3339\n")
+	b.WriteString("  bytes payload = 6;\n")
+	b.WriteString("}\n")
+
+	for _, schema := range events.Schemas() {
+		payload, ok := payloadSchema(schema.Schema)
+		if !ok {
+			return nil, fmt.Errorf("event %s has no payload schema", schema.EventType)
+		}
+
+		properties, _ := payload["properties"].(map[string]interface{})
+		fieldOrder, _ := payload["required"].([]string)
+
+		fmt.Fprintf(&b, "\n// %s payload, schema version %d\n", schema.EventType, schema.Version)
+		fmt.Fprintf(&b, "message %s {\n", schema.EventType)
+		for i, name := range fieldOrder {
+			fieldSchema, _ := properties[name].(map[string]interface{})
+			protoType, err := protoTypeOf(fieldSchema)
+			if err != nil {
+				return nil, fmt.Errorf("event %s field %s: %w", schema.EventType, name, err)
+			}
+			fmt.Fprintf(&b, "  %s %s = %d;\n", protoType, name, i+1)
+		}
+		b.WriteString("}\n")
+	}
+
+	return []byte(b.String()), nil
+}
+
+// payloadSchema extracts the payload object schema from an envelope schema
+func payloadSchema(schema map[string]interface{}) (map[string]interface{}, bool) {
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	payload, ok := properties["payload"].(map[string]interface{})
+	return payload, ok
+}
+
+// protoTypeOf maps a JSON Schema fragment to a protobuf field type
+func protoTypeOf(schema map[string]interface{}) (string, error) {
+	switch schema["type"] {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "double", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		itemType, err := protoTypeOf(items)
+		if err != nil {
+			return "", err
+		}
+		if strings.HasPrefix(itemType, "repeated ") || strings.HasPrefix(itemType, "map<") {
+			return "", fmt.Errorf("nested collections are not supported")
+		}
+		return "repeated " + itemType, nil
+	case "object":
+		values, _ := schema["additionalProperties"].(map[string]interface{})
+		valueType, err := protoTypeOf(values)
+		if err != nil {
+			return "", err
+		}
+		return "map<string, " + valueType + ">", nil
+	default:
+		return "", fmt.Errorf("unsupported schema type %v", schema["type"])
+	}
+}
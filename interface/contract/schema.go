@@ -0,0 +1,202 @@
+package contract
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Fields describes an ad-hoc JSON object whose properties are prototypes or nested descriptions
+type Fields map[string]interface{}
+
+// ListOf describes the {"<key>": [...], "count": n} envelope used by list endpoints
+type ListOf struct {
+	Key  string
+	Item interface{}
+}
+
+// PageOf describes the ListOf envelope of a paged list endpoint, which also tells
+// where the page falls
+type PageOf struct {
+	Key  string
+	Item interface{}
+}
+
+// schemaBuilder turns Go prototypes into JSON Schema, collecting named structs as components
+type schemaBuilder struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		components: make(map[string]interface{}),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf builds a schema for a prototype value or description
+func (b *schemaBuilder) schemaOf(prototype interface{}) map[string]interface{} {
+	switch p := prototype.(type) {
+	case nil:
+		return map[string]interface{}{"type": "object"}
+	case Fields:
+		properties := make(map[string]interface{}, len(p))
+		for name, field := range p {
+			properties[name] = b.schemaOf(field)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	case ListOf:
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				p.Key:   map[string]interface{}{"type": "array", "items": b.schemaOf(p.Item)},
+				"count": map[string]interface{}{"type": "integer"},
+			},
+		}
+	case PageOf:
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				p.Key:         map[string]interface{}{"type": "array", "items": b.schemaOf(p.Item)},
+				"count":       map[string]interface{}{"type": "integer"},
+				"total":       map[string]interface{}{"type": "integer"},
+				"offset":      map[string]interface{}{"type": "integer"},
+				"next_cursor": map[string]interface{}{"type": "string"},
+			},
+		}
+	default:
+		return b.schemaForType(reflect.TypeOf(prototype))
+	}
+}
+
+// schemaForType maps a Go type to a JSON Schema fragment
+func (b *schemaBuilder) schemaForType(t reflect.Type) map[string]interface{} {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.schemaForType(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": b.schemaForType(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schemaForType(t.Elem())}
+	case reflect.Struct:
+		return map[string]interface{}{"$ref": "#/components/schemas/" + b.component(t)}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// component registers a struct type as a named component and returns its name
+func (b *schemaBuilder) component(t reflect.Type) string {
+	if name, exists := b.names[t]; exists {
+		return name
+	}
+
+	name := t.Name()
+	for _, other := range b.names {
+		if other == name {
+			// Same type name in two packages, qualify the later one
+			name = packageName(t) + name
+			break
+		}
+	}
+	b.names[t] = name
+
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		jsonName, omitEmpty := jsonFieldName(field)
+		if jsonName == "-" {
+			continue
+		}
+
+		binding := field.Tag.Get("binding")
+		fieldSchema := b.schemaForType(field.Type)
+		if allowed := oneOf(binding); allowed != nil {
+			fieldSchema["enum"] = allowed
+		}
+		properties[jsonName] = fieldSchema
+		if strings.Contains(binding, "required") && !omitEmpty {
+			required = append(required, jsonName)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	b.components[name] = schema
+
+	return name
+}
+
+// oneOf returns the values a oneof binding rule allows, with the empty string
+// when the field is optional, or nil when the field has no such rule
+func oneOf(binding string) []string {
+	for _, rule := range strings.Split(binding, ",") {
+		if strings.HasPrefix(rule, "oneof=") {
+			allowed := strings.Fields(strings.TrimPrefix(rule, "oneof="))
+			if !strings.Contains(binding, "required") {
+				allowed = append(allowed, "")
+			}
+			return allowed
+		}
+	}
+	return nil
+}
+
+// jsonFieldName returns the wire name of a struct field and whether it is omitempty
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+
+	omitEmpty := false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty
+}
+
+// packageName returns the last element of a type's package path, capitalized
+func packageName(t reflect.Type) string {
+	path := t.PkgPath()
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		path = path[i+1:]
+	}
+	if path == "" {
+		return ""
+	}
+	return strings.ToUpper(path[:1]) + path[1:]
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// rebuildLogInterval controls how often replay progress is logged
+const rebuildLogInterval = 1000
+
+// searchProjections are the read models the search endpoints answer from
+var searchProjections = []string{"search_suggestions", "workspace_search"}
+
+// defaultTopEventProducers is how many producers are listed per project by default
+const defaultTopEventProducers = 5
+
+// AdminHandler handles HTTP requests for operational tasks
+type AdminHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(container *di.Container) *AdminHandler {
+	return &AdminHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// RebuildProjections handles POST /api/admin/projections/rebuild
+func (h *AdminHandler) RebuildProjections(w http.ResponseWriter, r *http.Request) {
+	report, err := h.container.ProjectionRebuilder.Rebuild(r.Context(), func(p command.RebuildProgress) {
+		if p.Processed%rebuildLogInterval == 0 || p.Processed == p.Total {
+			log.Printf("projection rebuild: %d/%d events replayed", p.Processed, p.Total)
+		}
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"projections":     report.Projections,
+		"events_replayed": report.EventsReplayed,
+		"duration_ms":     report.Duration.Milliseconds(),
+	})
+}
+
+// ReindexSearch handles POST /api/admin/search/reindex, rebuilding only the search read models
+func (h *AdminHandler) ReindexSearch(w http.ResponseWriter, r *http.Request) {
+	report, err := h.container.ProjectionRebuilder.RebuildOnly(r.Context(), searchProjections, nil)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"projections":     report.Projections,
+		"events_replayed": report.EventsReplayed,
+		"duration_ms":     report.Duration.Milliseconds(),
+	})
+}
+
+// VerifyIntegrity handles GET /api/admin/integrity, reconciling projects with their tasks
+func (h *AdminHandler) VerifyIntegrity(w http.ResponseWriter, r *http.Request) {
+	report, err := h.container.VerifyIntegrityQueryHandler.Handle(r.Context(), query.VerifyIntegrityQuery{})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, report)
+}
+
+// PruneEvents handles POST /api/admin/events/prune?before={rfc3339}, removing the streams
+// of aggregates untouched since then
+func (h *AdminHandler) PruneEvents(w http.ResponseWriter, r *http.Request) {
+	before := r.URL.Query().Get("before")
+	if before == "" {
+		h.writeError(w, http.StatusBadRequest, "Before timestamp is required")
+		return
+	}
+
+	pruned, err := h.container.EventStore.PruneStreams(r.Context(), before)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"events_pruned": pruned,
+		"message":       "Events pruned successfully, rebuild projections to drop what they derived from them",
+	})
+}
+
+// ExportBackup handles GET /api/admin/backup, streaming every stored event as one NDJSON
+// line that event-stream import reads back. The export stops when the request is cancelled
+// or its route timeout passes, leaving the client a truncated file.
+func (h *AdminHandler) ExportBackup(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="events.ndjson"`)
+
+	// Send the status line now, so the events stream out instead of being held until the end
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	count, err := h.container.EventExporter.Export(r.Context(), w)
+	if err != nil {
+		// The status line is already sent, so only the log can tell
+		log.Printf("backup export failed after %d events: %v", count, err)
+	}
+}
+
+// GetEventMetrics handles GET /api/admin/events/metrics
+func (h *AdminHandler) GetEventMetrics(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, http.StatusOK, h.container.EventMetrics.Snapshot())
+}
+
+// GetIntegrationHealth handles GET /api/admin/integrations/health
+func (h *AdminHandler) GetIntegrationHealth(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, http.StatusOK, h.container.IntegrationHealth.Report())
+}
+
+// GetEventStats handles GET /api/admin/events/stats?project_id={id}&top={n}
+func (h *AdminHandler) GetEventStats(w http.ResponseWriter, r *http.Request) {
+	top := defaultTopEventProducers
+	if raw := r.URL.Query().Get("top"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			h.writeError(w, http.StatusBadRequest, "Invalid top parameter")
+			return
+		}
+		top = parsed
+	}
+
+	h.writeJSON(w, http.StatusOK, h.container.EventMetrics.Stats(r.URL.Query().Get("project_id"), top))
+}
+
+// GetMonthlyUsage handles GET /api/admin/billing/usage?organization_id={id}&month={yyyy-mm},
+// previewing what billing meters for the month, the current one by default
+func (h *AdminHandler) GetMonthlyUsage(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.URL.Query().Get("organization_id")
+	if organizationID == "" {
+		h.writeError(w, http.StatusBadRequest, "Organization ID is required")
+		return
+	}
+
+	month := time.Now()
+	if raw := r.URL.Query().Get("month"); raw != "" {
+		parsed, err := time.Parse("2006-01", raw)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid month parameter, expected YYYY-MM")
+			return
+		}
+		month = parsed
+	}
+
+	h.writeJSON(w, http.StatusOK, h.container.UsageTopic.MonthlyUsage(organizationID, month))
+}
+
+// Helper methods
+
+// writeJSON writes a JSON response
+func (h *AdminHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *AdminHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
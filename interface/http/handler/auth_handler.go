@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// AuthHandler handles HTTP requests for password authentication
+type AuthHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewAuthHandler creates a new AuthHandler
+func NewAuthHandler(container *di.Container) *AuthHandler {
+	return &AuthHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// Register handles POST /api/auth/register
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req dto.RegisterRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.RegisterUserCommand{
+		Email:     req.Email,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Password:  req.Password,
+	}
+
+	// Handle command
+	result, err := h.container.RegisterUserCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"user_id": result.UserID,
+		"message": "User registered successfully",
+	})
+}
+
+// SignUp handles POST /api/auth/signup
+func (h *AuthHandler) SignUp(w http.ResponseWriter, r *http.Request) {
+	var req dto.SignUpRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.SignUpTenantCommand{
+		OrganizationName: req.OrganizationName,
+		Email:            req.Email,
+		FirstName:        req.FirstName,
+		LastName:         req.LastName,
+		Password:         req.Password,
+	}
+
+	// Handle command
+	result, err := h.container.SignUpTenantCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, dto.SignUpDTO{
+		OrganizationID: result.OrganizationID,
+		UserID:         result.UserID,
+		WorkflowID:     result.WorkflowID,
+		ProjectID:      result.ProjectID,
+		Tokens:         toTokenDTO(result.Token),
+	})
+}
+
+// Login handles POST /api/auth/login
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req dto.LoginRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.LoginCommand{
+		Email:    req.Email,
+		Password: req.Password,
+	}
+
+	// Handle command
+	result, err := h.container.LoginCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, dto.SessionDTO{
+		Token:     result.Token,
+		TokenType: "Bearer",
+		UserID:    result.UserID,
+		ExpiresAt: result.ExpiresAt,
+	})
+}
+
+// IssueToken handles POST /api/auth/token
+func (h *AuthHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	var req dto.LoginRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.IssueTokenCommand{
+		Email:    req.Email,
+		Password: req.Password,
+	}
+
+	// Handle command
+	result, err := h.container.IssueTokenCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, toTokenDTO(result))
+}
+
+// RefreshToken handles POST /api/auth/token/refresh
+func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshTokenRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Handle command
+	result, err := h.container.RefreshTokenCommandHandler.Handle(r.Context(), command.RefreshTokenCommand{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, toTokenDTO(result))
+}
+
+// Logout handles POST /api/auth/logout with the session token as a bearer token
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	token := middleware.BearerToken(r)
+	if token == "" {
+		h.writeError(w, http.StatusUnauthorized, "Session token is required")
+		return
+	}
+
+	// Handle command
+	_, err := h.container.LogoutCommandHandler.Handle(r.Context(), command.LogoutCommand{Token: token})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Logged out successfully",
+	})
+}
+
+// ChangePassword handles PUT /api/auth/password
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r)
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.ChangePasswordCommand{
+		UserID:          userID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}
+
+	// Handle command
+	result, err := h.container.ChangePasswordCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"revoked_sessions": result.RevokedSessions,
+		"message":          "Password changed successfully",
+	})
+}
+
+// toTokenDTO converts issued tokens to their response
+func toTokenDTO(result *command.TokenResult) dto.TokenDTO {
+	roles := result.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	return dto.TokenDTO{
+		AccessToken:      result.Tokens.AccessToken,
+		RefreshToken:     result.Tokens.RefreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(result.Tokens.AccessExpiresAt.Sub(result.Tokens.IssuedAt).Seconds()),
+		UserID:           result.UserID,
+		Roles:            roles,
+		RefreshExpiresAt: result.Tokens.RefreshExpiresAt,
+	}
+}
+
+// writeJSON writes a JSON response
+func (h *AuthHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *AuthHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
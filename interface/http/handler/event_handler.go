@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// EventHandler handles HTTP requests for domain event metadata
+type EventHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewEventHandler creates a new EventHandler
+func NewEventHandler(container *di.Container) *EventHandler {
+	return &EventHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// ListSchemas handles GET /api/events/schemas
+func (h *EventHandler) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	schemas := h.container.EventSerializer.Schemas()
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas": schemas,
+		"count":   len(schemas),
+	})
+}
+
+// ListEvents handles GET /api/events?aggregate_id={id}&type={type}&since={time}&after={position}&limit={n}
+func (h *EventHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	page := query.ListEventsQuery{
+		AggregateID: params.Get("aggregate_id"),
+		EventType:   params.Get("type"),
+		Since:       params.Get("since"),
+	}
+
+	if raw := params.Get("after"); raw != "" {
+		after, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || after < 0 {
+			h.writeError(w, http.StatusBadRequest, "Invalid after parameter")
+			return
+		}
+		page.AfterPosition = after
+	}
+	if raw := params.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			h.writeError(w, http.StatusBadRequest, "Invalid limit parameter")
+			return
+		}
+		page.Limit = limit
+	}
+
+	// Handle query
+	events, err := h.container.ListEventsQueryHandler.Handle(r.Context(), page)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, events)
+}
+
+// Helper methods
+
+// writeJSON writes a JSON response
+func (h *EventHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *EventHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
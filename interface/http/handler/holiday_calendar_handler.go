@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// HolidayCalendarHandler handles HTTP requests for holiday calendars
+type HolidayCalendarHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewHolidayCalendarHandler creates a new HolidayCalendarHandler
+func NewHolidayCalendarHandler(container *di.Container) *HolidayCalendarHandler {
+	return &HolidayCalendarHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// CreateCalendar handles POST /api/holiday-calendars
+func (h *HolidayCalendarHandler) CreateCalendar(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateHolidayCalendarRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.CreateHolidayCalendarCommand{
+		OrganizationID: req.OrganizationID,
+		Region:         req.Region,
+		Name:           req.Name,
+		Holidays:       holidayInputs(req.Holidays),
+		RequestedBy:    middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.CreateHolidayCalendarCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"calendar_id": result.CalendarID,
+		"message":     "Holiday calendar created successfully",
+	})
+}
+
+// ListCalendars handles GET /api/holiday-calendars?organization_id={id} and the page parameters
+func (h *HolidayCalendarHandler) ListCalendars(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.URL.Query().Get("organization_id")
+	if organizationID == "" {
+		h.writeError(w, http.StatusBadRequest, "Organization ID is required")
+		return
+	}
+	page, err := pageParams(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Handle query
+	results, err := h.container.ListHolidayCalendarsQueryHandler.Handle(r.Context(), query.ListHolidayCalendarsQuery{
+		OrganizationID: organizationID,
+		Page:           page,
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, results)
+}
+
+// GetCalendar handles GET /api/holiday-calendars/get?id={id}
+func (h *HolidayCalendarHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	calendarID := r.URL.Query().Get("id")
+	if calendarID == "" {
+		h.writeError(w, http.StatusBadRequest, "Holiday calendar ID is required")
+		return
+	}
+
+	// Handle query
+	result, err := h.container.GetHolidayCalendarQueryHandler.Handle(r.Context(), query.GetHolidayCalendarQuery{
+		CalendarID: calendarID,
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// UpdateCalendar handles PUT /api/holiday-calendars?id={id}
+func (h *HolidayCalendarHandler) UpdateCalendar(w http.ResponseWriter, r *http.Request) {
+	calendarID := r.URL.Query().Get("id")
+	if calendarID == "" {
+		h.writeError(w, http.StatusBadRequest, "Holiday calendar ID is required")
+		return
+	}
+
+	var req dto.UpdateHolidayCalendarRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.UpdateHolidayCalendarCommand{
+		CalendarID:  calendarID,
+		Name:        req.Name,
+		Holidays:    holidayInputs(req.Holidays),
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.UpdateHolidayCalendarCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"holiday_count": result.HolidayCount,
+		"message":       "Holiday calendar updated successfully",
+	})
+}
+
+// DeleteCalendar handles DELETE /api/holiday-calendars?id={id}
+func (h *HolidayCalendarHandler) DeleteCalendar(w http.ResponseWriter, r *http.Request) {
+	calendarID := r.URL.Query().Get("id")
+	if calendarID == "" {
+		h.writeError(w, http.StatusBadRequest, "Holiday calendar ID is required")
+		return
+	}
+
+	// Create command
+	cmd := command.DeleteHolidayCalendarCommand{
+		CalendarID:  calendarID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.DeleteHolidayCalendarCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Holiday calendar deleted successfully",
+	})
+}
+
+// Helper methods
+
+// holidayInputs converts holidays from their wire format to command inputs
+func holidayInputs(holidays []dto.HolidayDTO) []command.HolidayInput {
+	inputs := make([]command.HolidayInput, 0, len(holidays))
+	for _, holiday := range holidays {
+		inputs = append(inputs, command.HolidayInput{
+			Name:    holiday.Name,
+			Rule:    holiday.Rule,
+			Date:    holiday.Date,
+			Month:   holiday.Month,
+			Day:     holiday.Day,
+			Weekday: holiday.Weekday,
+			Nth:     holiday.Nth,
+		})
+	}
+	return inputs
+}
+
+// writeJSON writes a JSON response
+func (h *HolidayCalendarHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *HolidayCalendarHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// InboundEmailSecretHeader carries the secret the inbound mail relay shares with the gateway
+const InboundEmailSecretHeader = "X-Inbound-Email-Secret"
+
+// InboundEmailHandler handles mail relayed to the email-to-task gateway
+type InboundEmailHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewInboundEmailHandler creates a new InboundEmailHandler
+func NewInboundEmailHandler(container *di.Container) *InboundEmailHandler {
+	return &InboundEmailHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// ReceiveEmail handles POST /api/inbound/email
+func (h *InboundEmailHandler) ReceiveEmail(w http.ResponseWriter, r *http.Request) {
+	if !h.container.InboxAddressService.VerifyGatewaySecret(r.Header.Get(InboundEmailSecretHeader)) {
+		h.writeError(w, http.StatusUnauthorized, "Invalid inbound email secret")
+		return
+	}
+
+	var req dto.InboundEmailRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.ReceiveInboundEmailCommand{
+		Recipients: append(append([]string{}, req.To...), req.Cc...),
+		From:       req.From,
+		Subject:    req.Subject,
+		Body:       req.Text,
+	}
+
+	// Handle command
+	result, err := h.container.ReceiveInboundEmailCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"task_id":    result.TaskID,
+		"project_id": result.ProjectID,
+		"message":    "Task created from email",
+	})
+}
+
+// Helper methods
+
+// writeJSON writes a JSON response
+func (h *InboundEmailHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *InboundEmailHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
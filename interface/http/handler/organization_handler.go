@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// OrganizationHandler handles HTTP requests for organizations and their quotas
+type OrganizationHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(container *di.Container) *OrganizationHandler {
+	return &OrganizationHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// CreateOrganization handles POST /api/organizations
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateOrganizationRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.CreateOrganizationCommand{
+		Name:        req.Name,
+		MemberIDs:   req.MemberIDs,
+		Quota:       quotaInput(req.Quota),
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.CreateOrganizationCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"organization_id": result.OrganizationID,
+		"message":         "Organization created successfully",
+	})
+}
+
+// AddMember handles POST /api/organizations/members?id={id}
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.URL.Query().Get("id")
+	if organizationID == "" {
+		h.writeError(w, http.StatusBadRequest, "Organization ID is required")
+		return
+	}
+
+	var req dto.OrganizationMemberRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.AddOrganizationMemberCommand{
+		OrganizationID: organizationID,
+		UserID:         req.UserID,
+		RequestedBy:    middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.AddOrganizationMemberCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Member added successfully",
+	})
+}
+
+// SetQuota handles PUT /api/organizations/quota?id={id}
+func (h *OrganizationHandler) SetQuota(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.URL.Query().Get("id")
+	if organizationID == "" {
+		h.writeError(w, http.StatusBadRequest, "Organization ID is required")
+		return
+	}
+
+	var req dto.OrganizationQuotaRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.SetOrganizationQuotaCommand{
+		OrganizationID: organizationID,
+		Quota:          quotaInput(req),
+		RequestedBy:    middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.SetOrganizationQuotaCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Quota updated successfully",
+	})
+}
+
+// ChangeWorkingHours handles PUT and DELETE /api/organizations/working-hours?id={id};
+// DELETE returns the members to the built-in default
+func (h *OrganizationHandler) ChangeWorkingHours(w http.ResponseWriter, r *http.Request) {
+	organizationID := r.URL.Query().Get("id")
+	if organizationID == "" {
+		h.writeError(w, http.StatusBadRequest, "Organization ID is required")
+		return
+	}
+
+	// Parse request body
+	hours, fieldErrors := workingHoursInput(r)
+	if fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.SetOrganizationWorkingHoursCommand{
+		OrganizationID: organizationID,
+		WorkingHours:   hours,
+		RequestedBy:    middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.SetOrganizationWorkingHoursCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Working hours updated successfully",
+	})
+}
+
+// GetUsage handles GET /api/organizations/usage?id={id}, defaulting to the caller's organization
+func (h *OrganizationHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	// Handle query
+	result, err := h.container.GetOrganizationUsageQueryHandler.Handle(r.Context(), query.GetOrganizationUsageQuery{
+		OrganizationID: r.URL.Query().Get("id"),
+		UserID:         middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// GetDirectory handles GET /api/org/directory, the directory of the caller's organization
+func (h *OrganizationHandler) GetDirectory(w http.ResponseWriter, r *http.Request) {
+	// Handle query
+	result, err := h.container.GetOrganizationDirectoryQueryHandler.Handle(r.Context(), query.GetOrganizationDirectoryQuery{
+		UserID: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// quotaInput converts the quota of a request to the command's form
+func quotaInput(req dto.OrganizationQuotaRequest) command.OrganizationQuotaInput {
+	return command.OrganizationQuotaInput{
+		MaxProjects:        req.MaxProjects,
+		MaxTasks:           req.MaxTasks,
+		MaxAttachmentBytes: req.MaxAttachmentBytes,
+		APICallsPerMinute:  req.APICallsPerMinute,
+	}
+}
+
+// Helper methods
+
+// writeJSON writes a JSON response
+func (h *OrganizationHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *OrganizationHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
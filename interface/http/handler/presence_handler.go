@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/presence"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/interface/http/websocket"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// PresenceHandler handles presence over WebSocket with a REST fallback
+type PresenceHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewPresenceHandler creates a new PresenceHandler
+func NewPresenceHandler(container *di.Container) *PresenceHandler {
+	return &PresenceHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// Connect handles GET /api/presence/ws, upgrading to the presence WebSocket protocol
+func (h *PresenceHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r)
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	if !websocket.IsUpgrade(r) {
+		h.writeError(w, http.StatusBadRequest, "WebSocket upgrade required")
+		return
+	}
+
+	conn, err := websocket.Upgrade(w, r)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+	defer conn.Close()
+
+	tracker := h.container.PresenceTracker
+	sessionID := uuid.New().String()
+	conn.OnPong(func() { tracker.Touch(sessionID) })
+
+	// Forward updates for the resource this connection is viewing
+	var current presence.Resource
+	var currentMu sync.Mutex
+	unsubscribe := tracker.Subscribe(func(resource presence.Resource, viewers []presence.Viewer) {
+		currentMu.Lock()
+		watching := current == resource
+		currentMu.Unlock()
+
+		if watching {
+			h.send(conn, h.toPresenceMessage(r.Context(), resource, viewers))
+		}
+	})
+	defer unsubscribe()
+	defer tracker.Leave(sessionID)
+
+	unsubscribeNotices := h.container.PresenceBroadcaster.Subscribe(func(resource presence.Resource, notice interface{}) {
+		currentMu.Lock()
+		watching := current == resource
+		currentMu.Unlock()
+
+		if lockNotice, ok := notice.(presence.EditLockNotice); ok && watching {
+			h.send(conn, h.toEditLockMessage(resource, lockNotice))
+		}
+	})
+	defer unsubscribeNotices()
+
+	// Keep the session alive while the client answers pings
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(tracker.TTL() / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if conn.Ping() != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var message dto.PresenceMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			h.send(conn, dto.PresenceMessage{Type: "error", Message: "Invalid message"})
+			continue
+		}
+
+		switch message.Type {
+		case "view":
+			resource, err := h.parseResource(message.Kind, message.ItemID)
+			if err != nil {
+				h.send(conn, dto.PresenceMessage{Type: "error", Message: err.Error()})
+				continue
+			}
+
+			currentMu.Lock()
+			current = resource
+			currentMu.Unlock()
+			tracker.View(sessionID, userID, resource)
+
+			// Tell a new viewer of a task who is editing its description
+			if notice, locked := h.currentEditLock(r.Context(), resource); locked {
+				h.send(conn, h.toEditLockMessage(resource, notice))
+			}
+		case "leave":
+			currentMu.Lock()
+			current = presence.Resource{}
+			currentMu.Unlock()
+			tracker.Leave(sessionID)
+		default:
+			h.send(conn, dto.PresenceMessage{Type: "error", Message: "Unknown message type"})
+		}
+	}
+}
+
+// GetPresence handles GET /api/presence?kind={kind}&id={id}
+func (h *PresenceHandler) GetPresence(w http.ResponseWriter, r *http.Request) {
+	resource, err := h.parseResource(r.URL.Query().Get("kind"), r.URL.Query().Get("id"))
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	viewers := h.container.PresenceTracker.Viewers(resource)
+	h.writeJSON(w, http.StatusOK, h.toPresenceDTO(r.Context(), resource, viewers))
+}
+
+// Heartbeat handles PUT /api/presence, the polling fallback for clients without WebSockets
+func (h *PresenceHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r)
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.PresenceHeartbeatRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	resource, err := h.parseResource(req.Kind, req.ItemID)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	sessionID := req.SessionID
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+	}
+
+	tracker := h.container.PresenceTracker
+	tracker.View(sessionID, userID, resource)
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"session_id":  sessionID,
+		"ttl_seconds": int64(tracker.TTL().Seconds()),
+		"presence":    h.toPresenceDTO(r.Context(), resource, tracker.Viewers(resource)),
+	})
+}
+
+// Leave handles DELETE /api/presence?session_id={id}
+func (h *PresenceHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.URL.Query().Get("session_id")
+	if sessionID == "" {
+		h.writeError(w, http.StatusBadRequest, "Session ID is required")
+		return
+	}
+
+	h.container.PresenceTracker.Leave(sessionID)
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Presence cleared",
+	})
+}
+
+// Helper methods
+
+// parseResource validates the kind and ID of a viewed resource
+func (h *PresenceHandler) parseResource(kind, itemID string) (presence.Resource, error) {
+	presenceKind, err := presence.NewKind(kind)
+	if err != nil {
+		return presence.Resource{}, err
+	}
+
+	if presenceKind == presence.KindTask {
+		if _, err := value.NewTaskID(itemID); err != nil {
+			return presence.Resource{}, err
+		}
+	} else {
+		if _, err := value.NewProjectID(itemID); err != nil {
+			return presence.Resource{}, err
+		}
+	}
+
+	return presence.Resource{Kind: presenceKind, ID: itemID}, nil
+}
+
+// toPresenceDTO resolves viewer names so clients can show who is viewing
+func (h *PresenceHandler) toPresenceDTO(ctx context.Context, resource presence.Resource, viewers []presence.Viewer) dto.PresenceDTO {
+	viewerDTOs := make([]dto.PresenceViewerDTO, 0, len(viewers))
+	for _, viewer := range viewers {
+		viewerDTO := dto.PresenceViewerDTO{
+			UserID: viewer.UserID,
+			Name:   viewer.UserID,
+			Since:  viewer.Since,
+		}
+
+		if userID, err := value.NewUserID(viewer.UserID); err == nil {
+			if user, err := h.container.UserRepository.GetByID(ctx, userID); err == nil {
+				viewerDTO.Name = user.FullName()
+			}
+		}
+
+		viewerDTOs = append(viewerDTOs, viewerDTO)
+	}
+
+	return dto.PresenceDTO{
+		Kind:    string(resource.Kind),
+		ItemID:  resource.ID,
+		Viewers: viewerDTOs,
+	}
+}
+
+// toPresenceMessage wraps the viewers of a resource in a WebSocket update
+func (h *PresenceHandler) toPresenceMessage(ctx context.Context, resource presence.Resource, viewers []presence.Viewer) dto.PresenceMessage {
+	presenceDTO := h.toPresenceDTO(ctx, resource, viewers)
+
+	return dto.PresenceMessage{
+		Type:    "presence",
+		Kind:    presenceDTO.Kind,
+		ItemID:  presenceDTO.ItemID,
+		Viewers: presenceDTO.Viewers,
+	}
+}
+
+// currentEditLock looks up the active edit lock of a viewed task
+func (h *PresenceHandler) currentEditLock(ctx context.Context, resource presence.Resource) (presence.EditLockNotice, bool) {
+	if resource.Kind != presence.KindTask {
+		return presence.EditLockNotice{}, false
+	}
+
+	taskID, err := value.NewTaskID(resource.ID)
+	if err != nil {
+		return presence.EditLockNotice{}, false
+	}
+
+	task, err := h.container.TaskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		return presence.EditLockNotice{}, false
+	}
+
+	lock := task.ActiveEditLock(time.Now())
+	if lock == nil {
+		return presence.EditLockNotice{}, false
+	}
+
+	expiresAt := lock.ExpiresAt()
+	return presence.EditLockNotice{
+		TaskID:     resource.ID,
+		HolderID:   lock.HolderID().Value(),
+		AcquiredAt: lock.AcquiredAt(),
+		ExpiresAt:  &expiresAt,
+	}, true
+}
+
+// toEditLockMessage wraps an edit lock change in a WebSocket update
+func (h *PresenceHandler) toEditLockMessage(resource presence.Resource, notice presence.EditLockNotice) dto.PresenceMessage {
+	message := dto.PresenceMessage{
+		Type:   "edit_lock",
+		Kind:   string(resource.Kind),
+		ItemID: resource.ID,
+	}
+
+	if notice.ExpiresAt != nil {
+		message.EditLock = &dto.EditLockDTO{
+			HolderID:   notice.HolderID,
+			AcquiredAt: notice.AcquiredAt,
+			ExpiresAt:  *notice.ExpiresAt,
+		}
+	}
+
+	return message
+}
+
+// send writes a WebSocket message, dropped connections are noticed by the read loop
+func (h *PresenceHandler) send(conn *websocket.Conn, message dto.PresenceMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	conn.WriteText(data)
+}
+
+// writeJSON writes a JSON response
+func (h *PresenceHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *PresenceHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
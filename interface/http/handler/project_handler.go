@@ -3,8 +3,15 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain"
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
 	"github.com/miladev95/ddd-task/domain/value"
 	"github.com/miladev95/ddd-task/interface/http/middleware"
 	"github.com/miladev95/ddd-task/shared/di"
@@ -29,7 +36,7 @@ type CreateProjectRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
 	OwnerID     string `json:"owner_id" binding:"required"`
-	WorkflowID  string `json:"workflow_id" binding:"required"`
+	WorkflowID  string `json:"workflow_id"` // empty uses the default workflow
 }
 
 // CreateProject handles POST /api/projects
@@ -37,8 +44,8 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	var req CreateProjectRequest
 
 	// Parse request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
@@ -49,22 +56,38 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = h.container.UserRepository.GetByID(ownerID)
+	_, err = h.container.UserRepository.GetByID(r.Context(), ownerID)
 	if err != nil {
 		h.writeError(w, http.StatusBadRequest, "Owner user not found")
 		return
 	}
 
-	// Validate workflow exists
-	workflowID, err := value.NewWorkflowID(req.WorkflowID)
-	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid workflow ID")
-		return
+	// Validate workflow exists, or fall back to the default workflow
+	var workflowID value.WorkflowID
+	if req.WorkflowID == "" {
+		workflow, err := h.container.StatusTransitionService.GetOrCreateDefaultWorkflow(r.Context())
+		if err != nil {
+			h.writeError(w, http.StatusInternalServerError, "Failed to load default workflow")
+			return
+		}
+		workflowID = workflow.ID()
+	} else {
+		workflowID, err = value.NewWorkflowID(req.WorkflowID)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid workflow ID")
+			return
+		}
+
+		_, err = h.container.WorkflowRepository.GetByID(r.Context(), workflowID)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Workflow not found")
+			return
+		}
 	}
 
-	_, err = h.container.WorkflowRepository.GetByID(workflowID)
-	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Workflow not found")
+	// Verify the owner's organization may own another project
+	if err := h.container.QuotaEnforcementService.CheckProjectQuota(r.Context(), ownerID); err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
@@ -74,17 +97,24 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	// Create project aggregate
 	project, err := aggregate.NewProject(projectID, req.Name, req.Description, ownerID, workflowID)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, err.Error())
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
 	// Save project
-	err = h.container.ProjectRepository.Save(project)
+	err = h.container.ProjectRepository.Save(r.Context(), project)
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "Failed to save project")
 		return
 	}
 
+	// Publish domain events
+	if err := h.container.EventPublisher.PublishAll(r.Context(), project.DomainEvents()); err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to publish events")
+		return
+	}
+	project.ClearDomainEvents()
+
 	// Return response
 	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
 		"project_id": projectID.Value(),
@@ -93,6 +123,38 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ListProjects handles GET /api/projects?owner_id={id}&archived={true|false} and the page parameters
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	archived, err := optionalBool(r, "archived")
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid archived parameter")
+		return
+	}
+	page, err := pageParams(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Create query
+	q := query.ListProjectsQuery{
+		OwnerID:  r.URL.Query().Get("owner_id"),
+		Archived: archived,
+		ViewerID: middleware.UserID(r),
+		Page:     page,
+	}
+
+	// Handle query
+	results, err := h.container.ListProjectsQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, results)
+}
+
 // GetProject handles GET /api/projects/{id}
 func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
 	projectID := r.URL.Query().Get("id")
@@ -101,47 +163,1159 @@ func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create project ID
-	id, err := value.NewProjectID(projectID)
+	// Handle query
+	result, err := h.container.GetProjectQueryHandler.Handle(r.Context(), query.GetProjectQuery{ProjectID: projectID, ViewerID: middleware.UserID(r)})
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid project ID")
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Track the view for the requesting user (best effort)
+	if viewerID := middleware.UserID(r); viewerID != "" {
+		h.container.RecordViewCommandHandler.Handle(r.Context(), command.RecordViewCommand{
+			UserID: viewerID,
+			Kind:   "PROJECT",
+			ItemID: result.ID,
+		})
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// GetProjectActivity handles GET /api/projects/activity?id={id}&before={sequence}&limit={n}
+func (h *ProjectHandler) GetProjectActivity(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
 		return
 	}
 
-	// Get project
-	project, err := h.container.ProjectRepository.GetByID(id)
+	// Read the page, the newest entries by default
+	page := query.ListProjectActivityQuery{ProjectID: projectID, ViewerID: middleware.UserID(r)}
+	for name, target := range map[string]*int{"before": &page.Before, "limit": &page.Limit} {
+		raw := r.URL.Query().Get(name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			h.writeError(w, http.StatusBadRequest, "Invalid "+name+" parameter")
+			return
+		}
+		*target = parsed
+	}
+
+	// Handle query
+	results, err := h.container.ListProjectActivityQueryHandler.Handle(r.Context(), page)
 	if err != nil {
-		h.writeError(w, http.StatusNotFound, "Project not found")
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
 	// Return response
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
-		"id":          project.ID().Value(),
-		"name":        project.Name(),
-		"description": project.Description(),
-		"owner_id":    project.OwnerID().Value(),
-		"workflow_id": project.WorkflowID().Value(),
-		"task_count":  project.TaskCount(),
-		"created_at":  project.CreatedAt(),
-		"updated_at":  project.UpdatedAt(),
-		"archived":    project.IsArchived(),
+		"activity": results,
+		"count":    len(results),
 	})
 }
 
-// Helper methods
+// GetProjectStats handles GET /api/projects/stats
+func (h *ProjectHandler) GetProjectStats(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
 
-// writeJSON writes a JSON response
-func (h *ProjectHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(data)
+	// Create query
+	q := query.GetProjectStatsQuery{
+		ProjectID: projectID,
+		ViewerID:  middleware.UserID(r),
+	}
+
+	// Handle query
+	result, err := h.container.GetProjectStatsQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
 }
 
-// writeError writes an error response
-func (h *ProjectHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
-	h.writeJSON(w, statusCode, map[string]interface{}{
-		"code":    statusCode,
-		"message": message,
+// SetProjectSLO handles PUT /api/projects/slo
+func (h *ProjectHandler) SetProjectSLO(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SetProjectSLORequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.SetProjectSLOCommand{
+		ProjectID:     projectID,
+		FirstResponse: req.FirstResponse,
+		Resolution:    req.Resolution,
+		RequestedBy:   middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.SetProjectSLOCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Project SLO targets updated successfully",
+	})
+}
+
+// GetProjectBoard handles GET /api/projects/board?id={id}
+func (h *ProjectHandler) GetProjectBoard(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	// Create query
+	q := query.GetProjectBoardQuery{
+		ProjectID: projectID,
+		Sort:      strings.ToUpper(r.URL.Query().Get("sort")),
+		ViewerID:  middleware.UserID(r),
+	}
+
+	// Handle query
+	result, err := h.container.GetProjectBoardQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// SetBoardSort handles PUT and DELETE /api/projects/board/sort?id={id}; the preference is the
+// caller's own and DELETE returns their board to creation order
+func (h *ProjectHandler) SetBoardSort(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SetBoardSortRequest
+
+	// Parse request body
+	if r.Method != http.MethodDelete {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	// Handle command
+	result, err := h.container.SetBoardSortCommandHandler.Handle(r.Context(), command.SetBoardSortCommand{
+		ProjectID:   projectID,
+		Sort:        strings.ToUpper(req.Sort),
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sort":    result.Sort,
+		"message": "Board sort updated successfully",
+	})
+}
+
+// GetProjectSettings handles GET /api/projects/settings?id={id}
+func (h *ProjectHandler) GetProjectSettings(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	// Create query
+	q := query.GetProjectSettingsQuery{
+		ProjectID: projectID,
+	}
+
+	// Handle query
+	result, err := h.container.GetProjectSettingsQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// SetProjectSettings handles PUT /api/projects/settings?id={id}
+func (h *ProjectHandler) SetProjectSettings(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SetProjectSettingsRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	allowComments := true
+	if req.AllowComments != nil {
+		allowComments = *req.AllowComments
+	}
+
+	// Create command
+	cmd := command.SetProjectSettingsCommand{
+		ProjectID:               projectID,
+		DefaultPriority:         req.DefaultPriority,
+		DefaultAssigneeID:       req.DefaultAssigneeID,
+		RequireDeadlineOnCreate: req.RequireDeadlineOnCreate,
+		AllowComments:           allowComments,
+		RequestedBy:             middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.SetProjectSettingsCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Project settings updated successfully",
+	})
+}
+
+// AddInboxAddress handles POST /api/projects/settings/inbox?id={id}
+func (h *ProjectHandler) AddInboxAddress(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.AddInboxAddressRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.AddProjectInboxAddressCommand{
+		ProjectID:   projectID,
+		Label:       req.Label,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.AddProjectInboxAddressCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"token":   result.Token,
+		"address": result.Address,
+		"message": "Inbox address added successfully",
+	})
+}
+
+// RevokeInboxAddress handles DELETE /api/projects/settings/inbox?id={id}&token={token}
+func (h *ProjectHandler) RevokeInboxAddress(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	token := r.URL.Query().Get("token")
+	if projectID == "" || token == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID and token are required")
+		return
+	}
+
+	// Create command
+	cmd := command.RevokeProjectInboxAddressCommand{
+		ProjectID:   projectID,
+		Token:       token,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.RevokeProjectInboxAddressCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Inbox address revoked successfully",
+	})
+}
+
+// SetProjectAccess handles PUT /api/projects/access?id={id}
+func (h *ProjectHandler) SetProjectAccess(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SetProjectAccessRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.SetProjectAccessCommand{
+		ProjectID:   projectID,
+		Visibility:  req.Visibility,
+		MemberIDs:   req.MemberIDs,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.SetProjectAccessCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Project access updated successfully",
+	})
+}
+
+// GetProjectBudget handles GET /api/projects/budget?id={id}
+func (h *ProjectHandler) GetProjectBudget(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	// Create query
+	q := query.GetProjectBudgetQuery{
+		ProjectID: projectID,
+		ViewerID:  middleware.UserID(r),
+	}
+
+	// Handle query
+	result, err := h.container.GetProjectBudgetQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// SetProjectBudget handles PUT /api/projects/budget?id={id}
+func (h *ProjectHandler) SetProjectBudget(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SetProjectBudgetRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.SetProjectBudgetCommand{
+		ProjectID:   projectID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.SetProjectBudgetCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"exceeded": result.Exceeded,
+		"message":  "Project budget updated successfully",
 	})
+}
+
+// ArchiveProject handles POST /api/projects/archive?id={id}
+func (h *ProjectHandler) ArchiveProject(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.ArchiveProjectRequest
+
+	// Parse request body, an empty body archives with the default policy
+	if r.ContentLength != 0 {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	// Create command
+	cmd := command.ArchiveProjectCommand{
+		ProjectID:   projectID,
+		Policy:      req.Policy,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.ArchiveProjectCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"affected_task_ids": result.AffectedTaskIDs,
+		"message":           "Project archived successfully",
+	})
+}
+
+// UpdateProject handles PUT /api/projects?id={id}
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.UpdateProjectRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.UpdateProjectCommand{
+		ProjectID:   projectID,
+		Name:        req.Name,
+		Description: req.Description,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.UpdateProjectCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"project_id":  result.ProjectID,
+		"name":        result.Name,
+		"description": result.Description,
+		"message":     "Project updated successfully",
+	})
+}
+
+// DeleteProject handles DELETE /api/projects?id={id}
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	// Create command
+	cmd := command.DeleteProjectCommand{
+		ProjectID:   projectID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.DeleteProjectCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deleted_task_ids": result.DeletedTaskIDs,
+		"message":          "Project deleted successfully",
+	})
+}
+
+// GetNotificationRoutes handles GET /api/projects/notification-routes?id={id}
+func (h *ProjectHandler) GetNotificationRoutes(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	// Create query
+	q := query.GetNotificationRoutesQuery{
+		ProjectID: projectID,
+	}
+
+	// Handle query
+	results, err := h.container.GetNotificationRoutesQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"routes": results,
+		"count":  len(results),
+	})
+}
+
+// SetNotificationRoutes handles PUT /api/projects/notification-routes?id={id}
+func (h *ProjectHandler) SetNotificationRoutes(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SetNotificationRoutesRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	routes := make([]command.NotificationRouteInput, 0, len(req.Routes))
+	for _, route := range req.Routes {
+		routes = append(routes, command.NotificationRouteInput{
+			EventType: route.EventType,
+			Channel:   route.Channel,
+			Target:    route.Target,
+			Delivery:  route.Delivery,
+		})
+	}
+
+	cmd := command.SetNotificationRoutesCommand{
+		ProjectID:   projectID,
+		Routes:      routes,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.SetNotificationRoutesCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Notification routes updated successfully",
+	})
+}
+
+// CreateMilestone handles POST /api/projects/milestones?id={id}
+func (h *ProjectHandler) CreateMilestone(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.MilestoneRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.CreateMilestoneCommand{
+		ProjectID:   projectID,
+		Name:        req.Name,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+		TaskIDs:     req.TaskIDs,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.CreateMilestoneCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"milestone_id": result.MilestoneID,
+		"message":      "Milestone created successfully",
+	})
+}
+
+// ListMilestones handles GET /api/projects/milestones?id={id}
+func (h *ProjectHandler) ListMilestones(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	// Create query
+	q := query.ListMilestonesQuery{
+		ProjectID: projectID,
+		ViewerID:  middleware.UserID(r),
+	}
+
+	// Handle query
+	results, err := h.container.ListMilestonesQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"milestones": results,
+		"count":      len(results),
+	})
+}
+
+// UpdateMilestone handles PUT /api/projects/milestones?id={id}&milestone_id={milestone_id}
+func (h *ProjectHandler) UpdateMilestone(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	milestoneID := r.URL.Query().Get("milestone_id")
+	if projectID == "" || milestoneID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID and milestone ID are required")
+		return
+	}
+
+	var req dto.MilestoneRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.UpdateMilestoneCommand{
+		ProjectID:   projectID,
+		MilestoneID: milestoneID,
+		Name:        req.Name,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+		TaskIDs:     req.TaskIDs,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.UpdateMilestoneCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Milestone updated successfully",
+	})
+}
+
+// DeleteMilestone handles DELETE /api/projects/milestones?id={id}&milestone_id={milestone_id}
+func (h *ProjectHandler) DeleteMilestone(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	milestoneID := r.URL.Query().Get("milestone_id")
+	if projectID == "" || milestoneID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID and milestone ID are required")
+		return
+	}
+
+	// Create command
+	cmd := command.DeleteMilestoneCommand{
+		ProjectID:   projectID,
+		MilestoneID: milestoneID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.DeleteMilestoneCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Milestone deleted successfully",
+	})
+}
+
+// ChangeProjectWorkflow handles PUT /api/projects/workflow?id={id}
+func (h *ProjectHandler) ChangeProjectWorkflow(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.ChangeProjectWorkflowRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.ChangeProjectWorkflowCommand{
+		ProjectID:   projectID,
+		WorkflowID:  req.WorkflowID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.ChangeProjectWorkflowCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Project workflow changed successfully",
+	})
+}
+
+// SetHolidayCalendar handles PUT /api/projects/holiday-calendar?id={id} (DELETE stops observing holidays)
+func (h *ProjectHandler) SetHolidayCalendar(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SetProjectHolidayCalendarRequest
+
+	// Parse request body
+	if r.Method != http.MethodDelete {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	// Create command
+	cmd := command.SetProjectHolidayCalendarCommand{
+		ProjectID:   projectID,
+		CalendarID:  req.CalendarID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.SetProjectHolidayCalendarCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"calendar_id": req.CalendarID,
+		"region":      result.Region,
+		"message":     "Project holiday calendar changed successfully",
+	})
+}
+
+// SetPriorityScheme handles PUT /api/projects/priority-scheme?id={id}
+func (h *ProjectHandler) SetPriorityScheme(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SetPrioritySchemeRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.SetProjectPrioritySchemeCommand{
+		ProjectID:       projectID,
+		Levels:          make([]command.PriorityLevelInput, 0, len(req.Levels)),
+		DefaultPriority: req.DefaultPriority,
+		Remap:           req.Remap,
+		RequestedBy:     middleware.UserID(r),
+	}
+	for _, level := range req.Levels {
+		cmd.Levels = append(cmd.Levels, command.PriorityLevelInput{Name: level.Name, Rank: level.Rank})
+	}
+
+	// Handle command
+	result, err := h.container.SetProjectPrioritySchemeCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"priorities":       result.Priorities,
+		"default_priority": result.DefaultPriority,
+		"remapped":         result.Remapped,
+		"message":          "Project priority scheme changed successfully",
+	})
+}
+
+// MigrateProjectWorkflow handles POST /api/projects/workflow/migrate?id={id}
+func (h *ProjectHandler) MigrateProjectWorkflow(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.MigrateProjectWorkflowRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.MigrateProjectWorkflowCommand{
+		ProjectID:     projectID,
+		StatusMapping: req.StatusMapping,
+		RequestedBy:   middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.MigrateProjectWorkflowCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"workflow_version": result.WorkflowVersion,
+		"migrated":         result.Migrated,
+		"message":          "Project tasks migrated successfully",
+	})
+}
+
+// GetWorkflowMigration handles GET /api/projects/workflow/migration?id={id}
+func (h *ProjectHandler) GetWorkflowMigration(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	// Handle query
+	result, err := h.container.GetProjectWorkflowMigrationQueryHandler.Handle(r.Context(), query.GetProjectWorkflowMigrationQuery{
+		ProjectID: projectID,
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// PlanWorkflowMigration handles POST /api/projects/workflow/migration?id={id}
+func (h *ProjectHandler) PlanWorkflowMigration(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.PlanWorkflowMigrationRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Handle command
+	result, err := h.container.PlanProjectWorkflowMigrationCommandHandler.Handle(r.Context(), command.PlanProjectWorkflowMigrationCommand{
+		ProjectID:     projectID,
+		WorkflowID:    req.WorkflowID,
+		StatusMapping: req.StatusMapping,
+		RequestedBy:   middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"migration_id": result.MigrationID,
+		"total":        result.Total,
+		"remapped":     result.Remapped,
+		"message":      "Workflow migration planned successfully",
+	})
+}
+
+// ApplyWorkflowMigration handles POST /api/projects/workflow/migration/apply?id={id}
+func (h *ProjectHandler) ApplyWorkflowMigration(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.ApplyWorkflowMigrationRequest
+
+	// Parse request body, an empty body uses the default batch size
+	if r.ContentLength != 0 {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	// Handle command
+	result, err := h.container.ApplyProjectWorkflowMigrationCommandHandler.Handle(r.Context(), command.ApplyProjectWorkflowMigrationCommand{
+		ProjectID:   projectID,
+		BatchSize:   req.BatchSize,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"migration_id": result.MigrationID,
+		"applied":      result.Applied,
+		"total":        result.Total,
+		"status":       result.Status,
+		"message":      "Workflow migration applied successfully",
+	})
+}
+
+// RollBackWorkflowMigration handles POST /api/projects/workflow/migration/rollback?id={id}
+func (h *ProjectHandler) RollBackWorkflowMigration(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	// Handle command
+	result, err := h.container.RollBackProjectWorkflowMigrationCommandHandler.Handle(r.Context(), command.RollBackProjectWorkflowMigrationCommand{
+		ProjectID:   projectID,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"migration_id": result.MigrationID,
+		"restored":     result.Restored,
+		"message":      "Workflow migration rolled back successfully",
+	})
+}
+
+// SimulateProjectWorkflow handles POST /api/projects/workflow/simulate?id={id}
+func (h *ProjectHandler) SimulateProjectWorkflow(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.SimulateWorkflowRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create query
+	statuses := make([]query.WorkflowStatusInput, 0, len(req.Statuses))
+	for _, s := range req.Statuses {
+		statuses = append(statuses, query.WorkflowStatusInput{
+			Name:        s.Name,
+			Description: s.Description,
+			Order:       s.Order,
+			IsFinal:     s.IsFinal,
+			WIPLimit:    s.WIPLimit,
+		})
+	}
+
+	var transitions []query.WorkflowTransitionInput
+	if req.Transitions != nil {
+		transitions = make([]query.WorkflowTransitionInput, 0, len(req.Transitions))
+		for _, t := range req.Transitions {
+			transitions = append(transitions, query.WorkflowTransitionInput{From: t.From, To: t.To})
+		}
+	}
+
+	q := query.SimulateWorkflowQuery{
+		ProjectID:   projectID,
+		Statuses:    statuses,
+		Transitions: transitions,
+		TaskIDs:     req.TaskIDs,
+		SampleSize:  req.SampleSize,
+	}
+
+	// Handle query
+	result, err := h.container.SimulateWorkflowQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// AssignProjectRole handles PUT /api/projects/roles?id={id}
+func (h *ProjectHandler) AssignProjectRole(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	var req dto.AssignProjectRoleRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.AssignProjectRoleCommand{
+		ProjectID:   projectID,
+		UserID:      req.UserID,
+		Role:        req.Role,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.AssignProjectRoleCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Project role updated successfully",
+	})
+}
+
+// Helper methods
+
+// optionalBool reads a true/false query parameter, nil when it is absent
+func optionalBool(r *http.Request, name string) (*bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// pageParams reads the page a list request asks for from its limit, offset, cursor, sort and order parameters
+func pageParams(r *http.Request) (domain.Page, error) {
+	params := r.URL.Query()
+	page := domain.Page{
+		Cursor:  params.Get("cursor"),
+		SortBy:  params.Get("sort"),
+		SortDir: domain.SortDirection(params.Get("order")),
+	}
+
+	for name, target := range map[string]*int{"limit": &page.Limit, "offset": &page.Offset} {
+		if raw := params.Get(name); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil {
+				return domain.Page{}, errs.Invalid("Invalid %s parameter", name)
+			}
+			*target = parsed
+		}
+	}
+	return page, nil
+}
+
+// writeJSON writes a JSON response
+func (h *ProjectHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *ProjectHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
 }
\ No newline at end of file
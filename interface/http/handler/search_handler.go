@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// SearchHandler handles HTTP requests for search
+type SearchHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(container *di.Container) *SearchHandler {
+	return &SearchHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// Suggest handles GET /api/search/suggest?q={text}
+func (h *SearchHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	text := r.URL.Query().Get("q")
+	if text == "" {
+		h.writeError(w, http.StatusBadRequest, "Search text is required")
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid limit parameter")
+			return
+		}
+		limit = parsed
+	}
+
+	// Create query
+	q := query.SearchSuggestionsQuery{
+		Text:   text,
+		Limit:  limit,
+		UserID: middleware.UserID(r),
+	}
+
+	// Handle query
+	results, err := h.container.SearchSuggestionsQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"suggestions": results,
+		"count":       len(results),
+	})
+}
+
+// Search handles GET /api/search?q={text}&types={task,comment,attachment}
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	text := r.URL.Query().Get("q")
+	if text == "" {
+		h.writeError(w, http.StatusBadRequest, "Search text is required")
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid limit parameter")
+			return
+		}
+		limit = parsed
+	}
+
+	var types []string
+	if raw := r.URL.Query().Get("types"); raw != "" {
+		for _, searchType := range strings.Split(raw, ",") {
+			if searchType = strings.TrimSpace(searchType); searchType != "" {
+				types = append(types, strings.ToLower(searchType))
+			}
+		}
+	}
+
+	// Create query
+	q := query.SearchWorkspaceQuery{
+		Text:   text,
+		Types:  types,
+		UserID: middleware.UserID(r),
+		Limit:  limit,
+	}
+
+	// Handle query
+	results, err := h.container.SearchWorkspaceQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results": results,
+		"count":   len(results),
+	})
+}
+
+// SearchTasks handles GET /api/search/tasks?q={text}&project_id=&status=&priority=&assignee_id=
+func (h *SearchHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid limit parameter")
+			return
+		}
+		limit = parsed
+	}
+
+	// Create query
+	q := query.SearchTasksQuery{
+		Text:       r.URL.Query().Get("q"),
+		ProjectID:  r.URL.Query().Get("project_id"),
+		Status:     r.URL.Query().Get("status"),
+		Priority:   r.URL.Query().Get("priority"),
+		AssigneeID: r.URL.Query().Get("assignee_id"),
+		UserID:     middleware.UserID(r),
+		Limit:      limit,
+	}
+
+	// Handle query
+	results, err := h.container.SearchTasksQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results": results,
+		"count":   len(results),
+	})
+}
+
+// Helper methods
+
+// writeJSON writes a JSON response
+func (h *SearchHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *SearchHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
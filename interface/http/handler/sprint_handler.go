@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// SprintHandler handles HTTP requests for sprints
+type SprintHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewSprintHandler creates a new SprintHandler
+func NewSprintHandler(container *di.Container) *SprintHandler {
+	return &SprintHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// CreateSprint handles POST /api/sprints
+func (h *SprintHandler) CreateSprint(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSprintRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.CreateSprintCommand{
+		ProjectID:   req.ProjectID,
+		Name:        req.Name,
+		Goal:        req.Goal,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.CreateSprintCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"sprint_id": result.SprintID,
+		"message":   "Sprint created successfully",
+	})
+}
+
+// ListSprints handles GET /api/sprints?project_id={id}&status={status} and the page parameters
+func (h *SprintHandler) ListSprints(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project_id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+	page, err := pageParams(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Create query
+	q := query.ListSprintsQuery{
+		ProjectID: projectID,
+		Status:    r.URL.Query().Get("status"),
+		Page:      page,
+	}
+
+	// Handle query
+	results, err := h.container.ListSprintsQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, results)
+}
+
+// StartSprint handles POST /api/sprints/start?id={id}
+func (h *SprintHandler) StartSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID := r.URL.Query().Get("id")
+	if sprintID == "" {
+		h.writeError(w, http.StatusBadRequest, "Sprint ID is required")
+		return
+	}
+
+	// Handle command
+	_, err := h.container.StartSprintCommandHandler.Handle(r.Context(), command.StartSprintCommand{
+		SprintID:    sprintID,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Sprint started successfully",
+	})
+}
+
+// CompleteSprint handles POST /api/sprints/complete?id={id}
+func (h *SprintHandler) CompleteSprint(w http.ResponseWriter, r *http.Request) {
+	sprintID := r.URL.Query().Get("id")
+	if sprintID == "" {
+		h.writeError(w, http.StatusBadRequest, "Sprint ID is required")
+		return
+	}
+
+	// Handle command
+	result, err := h.container.CompleteSprintCommandHandler.Handle(r.Context(), command.CompleteSprintCommand{
+		SprintID:    sprintID,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"carried_over_task_ids": result.CarriedOverTaskIDs,
+		"message":               "Sprint completed successfully",
+	})
+}
+
+// AddTask handles POST /api/sprints/tasks?id={id}
+func (h *SprintHandler) AddTask(w http.ResponseWriter, r *http.Request) {
+	sprintID := r.URL.Query().Get("id")
+	if sprintID == "" {
+		h.writeError(w, http.StatusBadRequest, "Sprint ID is required")
+		return
+	}
+
+	var req dto.SprintTaskRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.AddTaskToSprintCommand{
+		SprintID:    sprintID,
+		TaskID:      req.TaskID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.AddTaskToSprintCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Task added to sprint successfully",
+	})
+}
+
+// RemoveTask handles DELETE /api/sprints/tasks?id={id}&task_id={task_id}
+func (h *SprintHandler) RemoveTask(w http.ResponseWriter, r *http.Request) {
+	sprintID := r.URL.Query().Get("id")
+	taskID := r.URL.Query().Get("task_id")
+	if sprintID == "" || taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Sprint ID and task ID are required")
+		return
+	}
+
+	// Create command
+	cmd := command.RemoveTaskFromSprintCommand{
+		SprintID:    sprintID,
+		TaskID:      taskID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.RemoveTaskFromSprintCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Task removed from sprint successfully",
+	})
+}
+
+// ListTasks handles GET /api/sprints/tasks?id={id}&status={status}
+func (h *SprintHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	sprintID := r.URL.Query().Get("id")
+	if sprintID == "" {
+		h.writeError(w, http.StatusBadRequest, "Sprint ID is required")
+		return
+	}
+
+	// Create query
+	q := query.ListSprintTasksQuery{
+		SprintID: sprintID,
+		Status:   r.URL.Query().Get("status"),
+		ViewerID: middleware.UserID(r),
+	}
+
+	// Handle query
+	results, err := h.container.ListSprintTasksQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tasks": results,
+		"count": len(results),
+	})
+}
+
+// Helper methods
+
+// writeJSON writes a JSON response
+func (h *SprintHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *SprintHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
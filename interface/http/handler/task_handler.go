@@ -3,6 +3,9 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/miladev95/ddd-task/application/command"
 	"github.com/miladev95/ddd-task/application/dto"
@@ -30,8 +33,8 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateTaskRequest
 
 	// Parse request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
@@ -43,21 +46,75 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		Priority:    req.Priority,
 		AssigneeID:  req.AssigneeID,
 		Deadline:    req.Deadline,
-		CreatedBy:   r.Header.Get("X-User-ID"), // In real app, from auth context
+		EstimatedHours: req.EstimatedHours,
+		CreatedBy:   middleware.UserID(r),
+		EnforceUnique: req.EnforceUnique,
 	}
 
 	// Handle command
-	result, err := h.container.CreateTaskCommandHandler.Handle(cmd)
+	result, err := h.container.CreateTaskCommandHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		httpErr := h.errorHandler.HandleError(err)
-		h.writeJSON(w, httpErr.Code, httpErr)
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
 	// Return response
-	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+	response := map[string]interface{}{
 		"task_id": result.TaskID,
 		"message": "Task created successfully",
+	}
+	if len(result.PossibleDuplicates) > 0 {
+		response["possible_duplicates"] = result.PossibleDuplicates
+	}
+	if len(result.Warnings) > 0 {
+		response["warnings"] = result.Warnings
+	}
+
+	h.writeJSON(w, http.StatusCreated, response)
+}
+
+// FindDuplicates handles GET /api/tasks/duplicates?project_id={id}&title={title}
+func (h *TaskHandler) FindDuplicates(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project_id")
+	if projectID == "" {
+		h.writeError(w, http.StatusBadRequest, "Project ID is required")
+		return
+	}
+
+	title := r.URL.Query().Get("title")
+	if title == "" {
+		h.writeError(w, http.StatusBadRequest, "Title is required")
+		return
+	}
+
+	threshold := 0.0
+	if raw := r.URL.Query().Get("threshold"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid threshold parameter")
+			return
+		}
+		threshold = parsed
+	}
+
+	// Create query
+	q := query.FindDuplicateTasksQuery{
+		ProjectID: projectID,
+		Title:     title,
+		Threshold: threshold,
+	}
+
+	// Handle query
+	results, err := h.container.FindDuplicateTasksQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"candidates": results,
+		"count":      len(results),
 	})
 }
 
@@ -69,44 +126,153 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Opening a task assigned to you acknowledges it (best effort)
+	if viewerID := middleware.UserID(r); viewerID != "" {
+		h.container.AcknowledgeTaskCommandHandler.Handle(r.Context(), command.AcknowledgeTaskCommand{
+			TaskID: taskID,
+			UserID: viewerID,
+			OnView: true,
+		})
+	}
+
 	// Create query
 	q := query.GetTaskQuery{
-		TaskID: taskID,
+		TaskID:    taskID,
+		Translate: r.URL.Query().Get("translate") == "true",
+		ViewerID:  middleware.UserID(r),
+		Locale:    acceptedLocale(r),
 	}
 
 	// Handle query
-	result, err := h.container.GetTaskQueryHandler.Handle(q)
+	result, err := h.container.GetTaskQueryHandler.Handle(r.Context(), q)
 	if err != nil {
-		httpErr := h.errorHandler.HandleError(err)
-		h.writeJSON(w, httpErr.Code, httpErr)
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
+	// Track the view for the requesting user (best effort)
+	if viewerID := middleware.UserID(r); viewerID != "" {
+		h.container.RecordViewCommandHandler.Handle(r.Context(), command.RecordViewCommand{
+			UserID: viewerID,
+			Kind:   "TASK",
+			ItemID: result.ID,
+		})
+	}
+
 	// Return response
 	h.writeJSON(w, http.StatusOK, result)
 }
 
-// ListTasksByProject handles GET /projects/{id}/tasks
+// GetTaskHistory handles GET /api/tasks/history?id={id}
+func (h *TaskHandler) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	// Read the page, every entry by default
+	page := query.GetTaskHistoryQuery{TaskID: taskID, ViewerID: middleware.UserID(r)}
+	for name, target := range map[string]*int{"after": &page.AfterVersion, "limit": &page.Limit} {
+		raw := r.URL.Query().Get(name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			h.writeError(w, http.StatusBadRequest, "Invalid "+name+" parameter")
+			return
+		}
+		*target = parsed
+	}
+
+	// Handle query
+	entries, err := h.container.GetTaskHistoryQueryHandler.Handle(r.Context(), page)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"history": entries,
+		"count":   len(entries),
+	})
+}
+
+// ListTasksByProject handles GET /projects/{id}/tasks with the page parameters
 func (h *TaskHandler) ListTasksByProject(w http.ResponseWriter, r *http.Request) {
 	projectID := r.URL.Query().Get("project_id")
 	if projectID == "" {
 		h.writeError(w, http.StatusBadRequest, "Project ID is required")
 		return
 	}
-
-	status := r.URL.Query().Get("status")
+	page, err := pageParams(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// Create query
 	q := query.ListTasksByProjectQuery{
 		ProjectID: projectID,
-		Status:    status,
+		Status:    r.URL.Query().Get("status"),
+		Page:      page,
+		ViewerID:  middleware.UserID(r),
+	}
+
+	// Handle query
+	results, err := h.container.ListTasksByProjectQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, results)
+}
+
+// ListOverdueTasks handles GET /api/tasks/overdue?project_id={id}, every visible project without one
+func (h *TaskHandler) ListOverdueTasks(w http.ResponseWriter, r *http.Request) {
+	// Create query
+	q := query.ListOverdueTasksQuery{
+		ProjectID: r.URL.Query().Get("project_id"),
+		ViewerID:  middleware.UserID(r),
+	}
+
+	// Handle query
+	results, err := h.container.ListOverdueTasksQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tasks": results,
+		"count": len(results),
+	})
+}
+
+// ListTasksDueWithin handles GET /api/tasks/due?within=72h&project_id={id}
+func (h *TaskHandler) ListTasksDueWithin(w http.ResponseWriter, r *http.Request) {
+	within, err := time.ParseDuration(r.URL.Query().Get("within"))
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid within, expected a duration such as 72h")
+		return
+	}
+
+	// Create query
+	q := query.ListTasksDueWithinQuery{
+		ProjectID: r.URL.Query().Get("project_id"),
+		ViewerID:  middleware.UserID(r),
+		Within:    within,
 	}
 
 	// Handle query
-	results, err := h.container.ListTasksByProjectQueryHandler.Handle(q)
+	results, err := h.container.ListTasksDueWithinQueryHandler.Handle(r.Context(), q)
 	if err != nil {
-		httpErr := h.errorHandler.HandleError(err)
-		h.writeJSON(w, httpErr.Code, httpErr)
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
@@ -128,8 +294,8 @@ func (h *TaskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
 	var req dto.AssignTaskRequest
 
 	// Parse request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
@@ -137,23 +303,145 @@ func (h *TaskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
 	cmd := command.AssignTaskCommand{
 		TaskID:     taskID,
 		AssigneeID: req.AssigneeID,
-		AssignedBy: r.Header.Get("X-User-ID"),
+		AssignedBy: middleware.UserID(r),
 	}
 
 	// Handle command
-	_, err := h.container.AssignTaskCommandHandler.Handle(cmd)
+	result, err := h.container.AssignTaskCommandHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		httpErr := h.errorHandler.HandleError(err)
-		h.writeJSON(w, httpErr.Code, httpErr)
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
 	// Return response
-	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"message": "Task assigned successfully",
+	}
+	if len(result.Warnings) > 0 {
+		response["warnings"] = result.Warnings
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// AcknowledgeTask handles POST /api/tasks/acknowledge?id={id}
+func (h *TaskHandler) AcknowledgeTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	// Create command
+	cmd := command.AcknowledgeTaskCommand{
+		TaskID: taskID,
+		UserID: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.AcknowledgeTaskCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Task acknowledged successfully",
 	})
 }
 
+// AssignTaskToTeam handles POST /api/tasks/assign-team?id={id}
+func (h *TaskHandler) AssignTaskToTeam(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.AssignTaskToTeamRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.AssignTaskToTeamCommand{
+		TaskID:     taskID,
+		TeamID:     req.TeamID,
+		AssignedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.AssignTaskToTeamCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Task assigned to team successfully",
+	})
+}
+
+// ReassignAllTasks handles POST /api/tasks/reassign
+func (h *TaskHandler) ReassignAllTasks(w http.ResponseWriter, r *http.Request) {
+	var req dto.ReassignAllTasksRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.ReassignAllTasksCommand{
+		FromUserID:  req.FromUserID,
+		ToUserID:    req.ToUserID,
+		ProjectID:   req.ProjectID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.ReassignAllTasksCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Build report
+	report := dto.ReassignmentReportDTO{
+		FromUserID: req.FromUserID,
+		ToUserID:   req.ToUserID,
+		ProjectID:  req.ProjectID,
+		MovedCount: len(result.Reassigned),
+		ByProject:  result.ByProject,
+		Reassigned: make([]dto.ReassignedTaskDTO, 0, len(result.Reassigned)),
+		Skipped:    make([]dto.SkippedTaskDTO, 0, len(result.Skipped)),
+		Warnings:   result.Warnings,
+	}
+	for _, moved := range result.Reassigned {
+		report.Reassigned = append(report.Reassigned, dto.ReassignedTaskDTO{
+			TaskID:    moved.TaskID,
+			ProjectID: moved.ProjectID,
+			Title:     moved.Title,
+			Status:    moved.Status,
+		})
+	}
+	for _, skipped := range result.Skipped {
+		report.Skipped = append(report.Skipped, dto.SkippedTaskDTO{
+			TaskID: skipped.TaskID,
+			Reason: skipped.Reason,
+		})
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, report)
+}
+
 // UpdateTaskStatus handles PUT /tasks/{id}/status
 func (h *TaskHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
 	taskID := r.URL.Query().Get("id")
@@ -165,22 +453,24 @@ func (h *TaskHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
 	var req dto.UpdateTaskStatusRequest
 
 	// Parse request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
 	// Create command
 	cmd := command.UpdateTaskStatusCommand{
-		TaskID:    taskID,
-		NewStatus: req.Status,
+		TaskID:      taskID,
+		NewStatus:   req.Status,
+		Reason:      req.Reason,
+		Metadata:    req.Metadata,
+		RequestedBy: middleware.UserID(r),
 	}
 
 	// Handle command
-	_, err := h.container.UpdateTaskStatusCommandHandler.Handle(cmd)
+	_, err := h.container.UpdateTaskStatusCommandHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		httpErr := h.errorHandler.HandleError(err)
-		h.writeJSON(w, httpErr.Code, httpErr)
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
@@ -190,6 +480,524 @@ func (h *TaskHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// CompareAndSetTaskStatus handles POST /api/tasks/status/cas?id={id}.
+// The response always carries the task's current state so optimistic UIs can
+// reconcile without another GET; a failed expectation answers 409.
+func (h *TaskHandler) CompareAndSetTaskStatus(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.CompareAndSetStatusRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.CompareAndSetTaskStatusCommand{
+		TaskID:         taskID,
+		ExpectedStatus: req.ExpectedStatus,
+		NewStatus:      req.Status,
+		Reason:         req.Reason,
+		Metadata:       req.Metadata,
+		RequestedBy:    middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.CompareAndSetTaskStatusCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Load the current state for reconciliation
+	task, err := h.container.GetTaskQueryHandler.Handle(r.Context(), query.GetTaskQuery{TaskID: taskID, ViewerID: middleware.UserID(r)})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	code := http.StatusOK
+	if !result.Applied {
+		code = http.StatusConflict
+	}
+
+	// Return response
+	h.writeJSON(w, code, map[string]interface{}{
+		"applied":        result.Applied,
+		"current_status": result.CurrentStatus,
+		"task":           task,
+	})
+}
+
+// AddComment handles POST /tasks/{id}/comments
+func (h *TaskHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.AddCommentRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.AddCommentCommand{
+		TaskID:   taskID,
+		AuthorID: middleware.UserID(r),
+		Content:  req.Content,
+	}
+
+	// Handle command
+	result, err := h.container.AddCommentCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"comment_id": result.CommentID,
+		"message":    "Comment added successfully",
+	})
+}
+
+// AddAttachment handles POST /api/tasks/attachments?id={id}
+func (h *TaskHandler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.AddAttachmentRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.AddAttachmentCommand{
+		TaskID:      taskID,
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		SizeBytes:   req.SizeBytes,
+		UploadedBy:  middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.AddAttachmentCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"attachment_id": result.AttachmentID,
+		"message":       "Attachment added successfully",
+	})
+}
+
+// LinkTask handles POST /tasks/{id}/links
+func (h *TaskHandler) LinkTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.LinkTaskRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.LinkTasksCommand{
+		TaskID:       taskID,
+		TargetTaskID: req.TargetTaskID,
+		LinkType:     req.LinkType,
+		LinkedBy:     middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.LinkTasksCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"message": "Tasks linked successfully",
+	})
+}
+
+// UnlinkTask handles DELETE /tasks/{id}/links
+func (h *TaskHandler) UnlinkTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	targetTaskID := r.URL.Query().Get("target_id")
+	if taskID == "" || targetTaskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID and target task ID are required")
+		return
+	}
+
+	// Create command
+	cmd := command.UnlinkTasksCommand{
+		TaskID:       taskID,
+		TargetTaskID: targetTaskID,
+		LinkType:     r.URL.Query().Get("type"),
+		RequestedBy:  middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.UnlinkTasksCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Tasks unlinked successfully",
+	})
+}
+
+// GetWorkloadHeatmap handles GET /workload/heatmap
+func (h *TaskHandler) GetWorkloadHeatmap(w http.ResponseWriter, r *http.Request) {
+	weeks := 0
+	if raw := r.URL.Query().Get("weeks"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid weeks parameter")
+			return
+		}
+		weeks = parsed
+	}
+
+	// Create query
+	q := query.GetWorkloadHeatmapQuery{
+		Weeks:     weeks,
+		ProjectID: r.URL.Query().Get("project_id"),
+	}
+
+	// Handle query
+	result, err := h.container.GetWorkloadHeatmapQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// VoteTask handles POST /tasks/{id}/vote (DELETE withdraws the vote)
+func (h *TaskHandler) VoteTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	// Create command
+	cmd := command.VoteTaskCommand{
+		TaskID:  taskID,
+		VoterID: middleware.UserID(r),
+		Remove:  r.Method == http.MethodDelete,
+	}
+
+	// Handle command
+	result, err := h.container.VoteTaskCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"vote_count": result.VoteCount,
+		"message":    "Vote updated successfully",
+	})
+}
+
+// ApproveTask handles POST /api/tasks/approve?id={id}
+func (h *TaskHandler) ApproveTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	// Create command
+	cmd := command.ApproveTaskCommand{
+		TaskID:     taskID,
+		ApproverID: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.ApproveTaskCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"approval_count":     result.ApprovalCount,
+		"required_approvals": result.RequiredApprovals,
+		"message":            "Task approved successfully",
+	})
+}
+
+// RejectTask handles POST /api/tasks/reject?id={id}
+func (h *TaskHandler) RejectTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.RejectTaskRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.RejectTaskCommand{
+		TaskID:     taskID,
+		ReviewerID: middleware.UserID(r),
+		Reason:     req.Reason,
+	}
+
+	// Handle command
+	result, err := h.container.RejectTaskCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"approval_count": result.ApprovalCount,
+		"message":        "Task rejected successfully",
+	})
+}
+
+// ChangeEditLock handles POST (acquire), PUT (renew) and DELETE (release) /api/tasks/edit-lock?id={id}
+func (h *TaskHandler) ChangeEditLock(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.EditLockRequest
+
+	// Parse request body, an empty body uses the default lock duration
+	if r.ContentLength != 0 && r.Method != http.MethodDelete {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	action := command.EditLockAcquire
+	switch r.Method {
+	case http.MethodPut:
+		action = command.EditLockRenew
+	case http.MethodDelete:
+		action = command.EditLockRelease
+	}
+
+	// Create command
+	cmd := command.EditLockCommand{
+		TaskID:     taskID,
+		UserID:     middleware.UserID(r),
+		Action:     action,
+		TTLSeconds: req.TTLSeconds,
+	}
+
+	// Handle command
+	result, err := h.container.EditLockCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"locked":     result.ExpiresAt != nil,
+		"expires_at": result.ExpiresAt,
+	})
+}
+
+// UpdateDescription handles PUT /api/tasks/description?id={id}
+func (h *TaskHandler) UpdateDescription(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.UpdateTaskDescriptionRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.UpdateTaskDescriptionCommand{
+		TaskID:      taskID,
+		EditorID:    middleware.UserID(r),
+		Description: req.Description,
+	}
+
+	// Handle command
+	_, err := h.container.UpdateTaskDescriptionCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Task description updated successfully",
+	})
+}
+
+// ChangeDeadline handles PUT /api/tasks/deadline?id={id}
+func (h *TaskHandler) ChangeDeadline(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.ChangeTaskDeadlineRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.ChangeTaskDeadlineCommand{
+		TaskID:       taskID,
+		Deadline:     req.Deadline,
+		BusinessDays: req.BusinessDays,
+		Reason:       req.Reason,
+		RequestedBy:  middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.ChangeTaskDeadlineCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	response := map[string]interface{}{
+		"deadline":            result.Deadline,
+		"deadline_slip_count": result.SlipCount,
+		"message":             "Task deadline changed successfully",
+	}
+	if len(result.Warnings) > 0 {
+		response["warnings"] = result.Warnings
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// RecordCost handles POST /api/tasks/costs?id={id}
+func (h *TaskHandler) RecordCost(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	var req dto.RecordCostRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.RecordTaskCostCommand{
+		TaskID:      taskID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Description: req.Description,
+		IncurredAt:  req.IncurredAt,
+		RecordedBy:  middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.RecordTaskCostCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"entry_id": result.EntryID,
+		"message":  "Cost recorded successfully",
+	})
+}
+
+// DeleteTask handles DELETE /api/tasks?id={id}
+func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("id")
+	if taskID == "" {
+		h.writeError(w, http.StatusBadRequest, "Task ID is required")
+		return
+	}
+
+	// Create command
+	cmd := command.DeleteTaskCommand{
+		TaskID:      taskID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.DeleteTaskCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Task deleted successfully",
+	})
+}
+
 // Helper methods
 
 // writeJSON writes a JSON response
@@ -201,8 +1009,28 @@ func (h *TaskHandler) writeJSON(w http.ResponseWriter, statusCode int, data inte
 
 // writeError writes an error response
 func (h *TaskHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
-	h.writeJSON(w, statusCode, map[string]interface{}{
-		"code":    statusCode,
-		"message": message,
-	})
-}
\ No newline at end of file
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
+
+// acceptedLocale returns the most preferred language tag of the Accept-Language header,
+// empty when there is none
+func acceptedLocale(r *http.Request) string {
+	best, bestQuality := "", -1.0
+	for _, entry := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = tag, quality
+		}
+	}
+	return best
+}
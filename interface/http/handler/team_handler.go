@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// TeamHandler handles HTTP requests for teams
+type TeamHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewTeamHandler creates a new TeamHandler
+func NewTeamHandler(container *di.Container) *TeamHandler {
+	return &TeamHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// CreateTeam handles POST /api/teams
+func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateTeamRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.CreateTeamCommand{
+		Name:        req.Name,
+		LeadID:      req.LeadID,
+		MemberIDs:   req.MemberIDs,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	result, err := h.container.CreateTeamCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"team_id": result.TeamID,
+		"message": "Team created successfully",
+	})
+}
+
+// GetTeam handles GET /api/teams/get?id={id}
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	teamID := r.URL.Query().Get("id")
+	if teamID == "" {
+		h.writeError(w, http.StatusBadRequest, "Team ID is required")
+		return
+	}
+
+	// Handle query
+	result, err := h.container.GetTeamQueryHandler.Handle(r.Context(), query.GetTeamQuery{TeamID: teamID})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// AddMember handles POST /api/teams/members?id={id}
+func (h *TeamHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	teamID := r.URL.Query().Get("id")
+	if teamID == "" {
+		h.writeError(w, http.StatusBadRequest, "Team ID is required")
+		return
+	}
+
+	var req dto.TeamMemberRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.AddTeamMemberCommand{
+		TeamID:      teamID,
+		UserID:      req.UserID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.AddTeamMemberCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Team member added successfully",
+	})
+}
+
+// RemoveMember handles DELETE /api/teams/members?id={id}&user_id={user_id}
+func (h *TeamHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	teamID := r.URL.Query().Get("id")
+	userID := r.URL.Query().Get("user_id")
+	if teamID == "" || userID == "" {
+		h.writeError(w, http.StatusBadRequest, "Team ID and user ID are required")
+		return
+	}
+
+	// Create command
+	cmd := command.RemoveTeamMemberCommand{
+		TeamID:      teamID,
+		UserID:      userID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.RemoveTeamMemberCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Team member removed successfully",
+	})
+}
+
+// ChangeLead handles PUT /api/teams/lead?id={id}
+func (h *TeamHandler) ChangeLead(w http.ResponseWriter, r *http.Request) {
+	teamID := r.URL.Query().Get("id")
+	if teamID == "" {
+		h.writeError(w, http.StatusBadRequest, "Team ID is required")
+		return
+	}
+
+	var req dto.TeamMemberRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.ChangeTeamLeadCommand{
+		TeamID:      teamID,
+		UserID:      req.UserID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.ChangeTeamLeadCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Team lead changed successfully",
+	})
+}
+
+// ListTasks handles GET /api/teams/tasks?id={id}&status={status}&include_members={bool} and the page parameters
+func (h *TeamHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	teamID := r.URL.Query().Get("id")
+	if teamID == "" {
+		h.writeError(w, http.StatusBadRequest, "Team ID is required")
+		return
+	}
+	page, err := pageParams(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Create query
+	q := query.ListTeamTasksQuery{
+		TeamID:         teamID,
+		Status:         r.URL.Query().Get("status"),
+		IncludeMembers: r.URL.Query().Get("include_members") == "true",
+		Page:           page,
+	}
+
+	// Handle query
+	results, err := h.container.ListTeamTasksQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, results)
+}
+
+// Helper methods
+
+// writeJSON writes a JSON response
+func (h *TeamHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *TeamHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
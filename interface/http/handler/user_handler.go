@@ -3,7 +3,12 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/application/query"
 	"github.com/miladev95/ddd-task/domain/aggregate"
 	"github.com/miladev95/ddd-task/domain/value"
 	"github.com/miladev95/ddd-task/interface/http/middleware"
@@ -36,8 +41,8 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req CreateUserRequest
 
 	// Parse request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
@@ -47,17 +52,24 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	// Create user aggregate
 	user, err := aggregate.NewUser(userID, req.Email, req.FirstName, req.LastName)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, err.Error())
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
 	// Save user
-	err = h.container.UserRepository.Save(user)
+	err = h.container.UserRepository.Save(r.Context(), user)
 	if err != nil {
 		h.writeError(w, http.StatusInternalServerError, "Failed to save user")
 		return
 	}
 
+	// Publish domain events
+	if err := h.container.EventPublisher.PublishAll(r.Context(), user.DomainEvents()); err != nil {
+		h.writeError(w, http.StatusInternalServerError, "Failed to publish events")
+		return
+	}
+	user.ClearDomainEvents()
+
 	// Return response
 	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
 		"user_id":    userID.Value(),
@@ -69,6 +81,30 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// ListUsers handles GET /api/users?active={true|false} and the page parameters
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	active, err := optionalBool(r, "active")
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid active parameter")
+		return
+	}
+	page, err := pageParams(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Handle query
+	results, err := h.container.ListUsersQueryHandler.Handle(r.Context(), query.ListUsersQuery{Active: active, Page: page})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, results)
+}
+
 // GetUser handles GET /api/users/{id}
 func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("id")
@@ -77,29 +113,387 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create user ID
-	id, err := value.NewUserID(userID)
+	// Handle query
+	result, err := h.container.GetUserQueryHandler.Handle(r.Context(), query.GetUserQuery{UserID: userID})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// VerifyEmail handles POST /api/users/verify with the token from the verification email
+func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req dto.VerifyEmailRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	if req.Token == "" {
+		h.writeError(w, http.StatusBadRequest, "Verification token is required")
+		return
+	}
+
+	// Handle command
+	result, err := h.container.VerifyEmailCommandHandler.Handle(r.Context(), command.VerifyEmailCommand{
+		Token: req.Token,
+	})
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid user ID")
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id": result.UserID,
+		"email":   result.Email,
+		"message": "Email verified successfully",
+	})
+}
+
+// UpdateUser handles PUT /api/users?id={id}
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.UpdateUserRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
-	// Get user
-	user, err := h.container.UserRepository.GetByID(id)
+	// Handle command
+	result, err := h.container.UpdateUserCommandHandler.Handle(r.Context(), command.UpdateUserCommand{
+		UserID:      userID,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Email:       req.Email,
+		RequestedBy: middleware.UserID(r),
+	})
 	if err != nil {
-		h.writeError(w, http.StatusNotFound, "User not found")
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
 	// Return response
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
-		"id":         user.ID().Value(),
-		"email":      user.Email(),
-		"first_name": user.FirstName(),
-		"last_name":  user.LastName(),
-		"full_name":  user.FullName(),
-		"created_at": user.CreatedAt(),
-		"updated_at": user.UpdatedAt(),
+		"user_id":        result.UserID,
+		"first_name":     result.FirstName,
+		"last_name":      result.LastName,
+		"email":          result.Email,
+		"email_verified": result.EmailVerified,
+		"message":        "User updated successfully",
+	})
+}
+
+// DeactivateUser handles POST /api/users/deactivate?id={id}.
+// The user's open tasks go to the fallback assignee, or are unassigned without one.
+func (h *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.DeactivateUserRequest
+
+	// Parse request body, which is optional
+	if r.ContentLength != 0 {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	// Handle command
+	result, err := h.container.DeactivateUserCommandHandler.Handle(r.Context(), command.DeactivateUserCommand{
+		UserID:             userID,
+		FallbackAssigneeID: req.FallbackAssigneeID,
+		RequestedBy:        middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id": result.UserID,
+		"message": "User deactivated successfully",
+	})
+}
+
+// ActivateUser handles POST /api/users/activate?id={id}
+func (h *UserHandler) ActivateUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	// Handle command
+	result, err := h.container.ActivateUserCommandHandler.Handle(r.Context(), command.ActivateUserCommand{
+		UserID:      userID,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id": result.UserID,
+		"message": "User activated successfully",
+	})
+}
+
+// ChangeEmail handles PUT /api/users/email?id={id}
+func (h *UserHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.ChangeEmailRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Handle command
+	result, err := h.container.ChangeEmailCommandHandler.Handle(r.Context(), command.ChangeEmailCommand{
+		UserID:      userID,
+		NewEmail:    req.Email,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id": result.UserID,
+		"email":   result.Email,
+		"message": "Email changed, verify the new address",
+	})
+}
+
+// ChangeWorkingHours handles PUT and DELETE /api/users/working-hours?id={id};
+// DELETE returns the user to their organization's default
+func (h *UserHandler) ChangeWorkingHours(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	// Parse request body
+	hours, fieldErrors := workingHoursInput(r)
+	if fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Handle command
+	result, err := h.container.SetUserWorkingHoursCommandHandler.Handle(r.Context(), command.SetUserWorkingHoursCommand{
+		UserID:       userID,
+		WorkingHours: hours,
+		RequestedBy:  middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id": result.UserID,
+		"message": "Working hours updated successfully",
+	})
+}
+
+// ChangeLocale handles PUT and DELETE /api/users/locale?id={id}; DELETE clears the locale
+func (h *UserHandler) ChangeLocale(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.UserLocaleRequest
+
+	// Parse request body
+	if r.Method != http.MethodDelete {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	// Handle command
+	result, err := h.container.SetUserLocaleCommandHandler.Handle(r.Context(), command.SetUserLocaleCommand{
+		UserID:      userID,
+		Locale:      req.Locale,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"locale":  result.Locale,
+		"message": "Locale updated successfully",
+	})
+}
+
+// ChangeOutOfOffice handles PUT and DELETE /api/users/out-of-office?id={id}; DELETE clears every period
+func (h *UserHandler) ChangeOutOfOffice(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.UserOutOfOfficeRequest
+
+	// Parse request body
+	if r.Method != http.MethodDelete {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	periods := make([]command.OutOfOfficeInput, 0, len(req.Periods))
+	for _, period := range req.Periods {
+		periods = append(periods, command.OutOfOfficeInput{Start: period.Start, End: period.End, Note: period.Note})
+	}
+
+	// Handle command
+	result, err := h.container.SetUserOutOfOfficeCommandHandler.Handle(r.Context(), command.SetUserOutOfOfficeCommand{
+		UserID:      userID,
+		Periods:     periods,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":       result.UserID,
+		"out_of_office": mapper.OutOfOfficeToDTOs(result.Periods),
+		"message":       "Out-of-office periods updated successfully",
+	})
+}
+
+// ChangeManager handles PUT and DELETE /api/users/manager?id={id}; DELETE clears the manager
+func (h *UserHandler) ChangeManager(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	var req dto.UserManagerRequest
+
+	// Parse request body
+	if r.Method != http.MethodDelete {
+		if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+			middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+			return
+		}
+	}
+
+	// Handle command
+	result, err := h.container.SetUserManagerCommandHandler.Handle(r.Context(), command.SetUserManagerCommand{
+		UserID:      userID,
+		ManagerID:   req.ManagerID,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"user_id":    result.UserID,
+		"manager_id": result.ManagerID,
+		"message":    "Manager updated successfully",
+	})
+}
+
+// workingHoursInput reads the working hours of a PUT body, nil for a DELETE resetting them
+func workingHoursInput(r *http.Request) (*command.WorkingHoursInput, []middleware.FieldError) {
+	if r.Method == http.MethodDelete {
+		return nil, nil
+	}
+
+	var req dto.WorkingHoursRequest
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		return nil, fieldErrors
+	}
+
+	return &command.WorkingHoursInput{
+		HoursPerDay: req.HoursPerDay,
+		WorkingDays: req.WorkingDays,
+	}, nil
+}
+
+// GetRecentlyViewed handles GET /api/users/recent?id={id}
+func (h *UserHandler) GetRecentlyViewed(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("id")
+	if userID == "" {
+		h.writeError(w, http.StatusBadRequest, "User ID is required")
+		return
+	}
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid limit parameter")
+			return
+		}
+		limit = parsed
+	}
+
+	// Create query
+	q := query.GetRecentlyViewedQuery{
+		UserID: userID,
+		Limit:  limit,
+	}
+
+	// Handle query
+	results, err := h.container.GetRecentlyViewedQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"items": results,
+		"count": len(results),
 	})
 }
 
@@ -114,8 +508,5 @@ func (h *UserHandler) writeJSON(w http.ResponseWriter, statusCode int, data inte
 
 // writeError writes an error response
 func (h *UserHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
-	h.writeJSON(w, statusCode, map[string]interface{}{
-		"code":    statusCode,
-		"message": message,
-	})
-}
\ No newline at end of file
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
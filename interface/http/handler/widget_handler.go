@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// WidgetHandler handles HTTP requests for dashboard widgets
+type WidgetHandler struct {
+	container    *di.Container
+	errorHandler *middleware.ErrorHandler
+}
+
+// NewWidgetHandler creates a new WidgetHandler
+func NewWidgetHandler(container *di.Container) *WidgetHandler {
+	return &WidgetHandler{
+		container:    container,
+		errorHandler: middleware.NewErrorHandler(),
+	}
+}
+
+// CreateWidget handles POST /api/widgets
+func (h *WidgetHandler) CreateWidget(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWidgetRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.CreateWidgetCommand{
+		OwnerID:    middleware.UserID(r),
+		Title:      req.Title,
+		Type:       req.Type,
+		Parameters: req.Parameters,
+		Column:     req.Layout.Column,
+		Row:        req.Layout.Row,
+		Width:      req.Layout.Width,
+		Height:     req.Layout.Height,
+	}
+
+	// Handle command
+	result, err := h.container.CreateWidgetCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"widget_id": result.WidgetID,
+		"message":   "Widget created successfully",
+	})
+}
+
+// ListWidgets handles GET /api/widgets with the page parameters
+func (h *WidgetHandler) ListWidgets(w http.ResponseWriter, r *http.Request) {
+	page, err := pageParams(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Create query
+	q := query.ListWidgetsQuery{
+		OwnerID: middleware.UserID(r),
+		Page:    page,
+	}
+
+	// Handle query
+	results, err := h.container.ListWidgetsQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, results)
+}
+
+// UpdateWidget handles PUT /api/widgets?id={id}
+func (h *WidgetHandler) UpdateWidget(w http.ResponseWriter, r *http.Request) {
+	widgetID := r.URL.Query().Get("id")
+	if widgetID == "" {
+		h.writeError(w, http.StatusBadRequest, "Widget ID is required")
+		return
+	}
+
+	var req dto.UpdateWidgetRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	// Create command
+	cmd := command.UpdateWidgetCommand{
+		WidgetID:    widgetID,
+		RequestedBy: middleware.UserID(r),
+		Title:       req.Title,
+		Parameters:  req.Parameters,
+	}
+	if req.Layout != nil {
+		cmd.Layout = &command.WidgetLayoutInput{
+			Column: req.Layout.Column,
+			Row:    req.Layout.Row,
+			Width:  req.Layout.Width,
+			Height: req.Layout.Height,
+		}
+	}
+
+	// Handle command
+	_, err := h.container.UpdateWidgetCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Widget updated successfully",
+	})
+}
+
+// DeleteWidget handles DELETE /api/widgets?id={id}
+func (h *WidgetHandler) DeleteWidget(w http.ResponseWriter, r *http.Request) {
+	widgetID := r.URL.Query().Get("id")
+	if widgetID == "" {
+		h.writeError(w, http.StatusBadRequest, "Widget ID is required")
+		return
+	}
+
+	// Create command
+	cmd := command.DeleteWidgetCommand{
+		WidgetID:    widgetID,
+		RequestedBy: middleware.UserID(r),
+	}
+
+	// Handle command
+	_, err := h.container.DeleteWidgetCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Widget deleted successfully",
+	})
+}
+
+// EvaluateDashboard handles GET /api/widgets/evaluate
+func (h *WidgetHandler) EvaluateDashboard(w http.ResponseWriter, r *http.Request) {
+	// Create query
+	q := query.EvaluateDashboardQuery{
+		OwnerID: middleware.UserID(r),
+	}
+
+	// Handle query
+	results, err := h.container.EvaluateDashboardQueryHandler.Handle(r.Context(), q)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results": results,
+		"count":   len(results),
+	})
+}
+
+// Helper methods
+
+// writeJSON writes a JSON response
+func (h *WidgetHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeError writes an error response
+func (h *WidgetHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
+}
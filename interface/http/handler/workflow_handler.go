@@ -3,9 +3,12 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
-	"github.com/miladev95/ddd-task/domain/aggregate"
-	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/application/query"
 	"github.com/miladev95/ddd-task/interface/http/middleware"
 	"github.com/miladev95/ddd-task/shared/di"
 )
@@ -30,6 +33,7 @@ type WorkflowStatusRequest struct {
 	Description string `json:"description"`
 	Order       int    `json:"order" binding:"required"`
 	IsFinal     bool   `json:"is_final"`
+	WIPLimit    int    `json:"wip_limit"`
 }
 
 // CreateWorkflowRequest represents the request to create a workflow
@@ -37,6 +41,7 @@ type CreateWorkflowRequest struct {
 	Name        string                    `json:"name" binding:"required"`
 	Description string                    `json:"description"`
 	Statuses    []WorkflowStatusRequest   `json:"statuses" binding:"required"`
+	Transitions []dto.WorkflowTransitionInput `json:"transitions"` // omitted uses the regular task status rules
 }
 
 // CreateWorkflow handles POST /api/workflows
@@ -44,50 +49,111 @@ func (h *WorkflowHandler) CreateWorkflow(w http.ResponseWriter, r *http.Request)
 	var req CreateWorkflowRequest
 
 	// Parse request body
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid request body")
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
-	// Validate at least one status
-	if len(req.Statuses) == 0 {
-		h.writeError(w, http.StatusBadRequest, "At least one status is required")
+	// Create command
+	cmd := command.CreateWorkflowCommand{
+		Name:        req.Name,
+		Description: req.Description,
+		Statuses:    make([]command.WorkflowStatusInput, 0, len(req.Statuses)),
+		Transitions: make([]command.WorkflowTransitionInput, 0, len(req.Transitions)),
+		RequestedBy: middleware.UserID(r),
+	}
+	for _, s := range req.Statuses {
+		cmd.Statuses = append(cmd.Statuses, command.WorkflowStatusInput{
+			Name:        s.Name,
+			Description: s.Description,
+			Order:       s.Order,
+			IsFinal:     s.IsFinal,
+			WIPLimit:    s.WIPLimit,
+		})
+	}
+	for _, t := range req.Transitions {
+		cmd.Transitions = append(cmd.Transitions, command.WorkflowTransitionInput{From: t.From, To: t.To})
+	}
+
+	// Handle command
+	result, err := h.container.CreateWorkflowCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"workflow_id": result.WorkflowID,
+		"name":        result.Name,
+		"description": result.Description,
+		"message":     "Workflow created successfully",
+	})
+}
+
+// UpdateWorkflow handles PUT /api/workflows?id={id}
+func (h *WorkflowHandler) UpdateWorkflow(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	if workflowID == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID is required")
+		return
+	}
+
+	var req dto.UpdateWorkflowRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
-	// Convert statuses
-	statuses := make([]aggregate.WorkflowStatus, len(req.Statuses))
-	for i, s := range req.Statuses {
-		statuses[i] = aggregate.NewWorkflowStatus(s.Name, s.Description, s.Order, s.IsFinal)
+	// Handle command
+	result, err := h.container.UpdateWorkflowCommandHandler.Handle(r.Context(), command.UpdateWorkflowCommand{
+		WorkflowID:  workflowID,
+		Name:        req.Name,
+		Description: req.Description,
+		RequestedBy: middleware.UserID(r),
+	})
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
 	}
 
-	// Generate new workflow ID
-	workflowID := value.GenerateWorkflowID()
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"workflow_id": result.WorkflowID,
+		"name":        result.Name,
+		"description": result.Description,
+		"version":     result.Version,
+		"message":     "Workflow updated successfully",
+	})
+}
 
-	// Create workflow aggregate
-	workflow, err := aggregate.NewWorkflow(workflowID, req.Name, req.Description, statuses)
+// ListWorkflows handles GET /api/workflows?active={true|false} and the page parameters, the latest version of each
+func (h *WorkflowHandler) ListWorkflows(w http.ResponseWriter, r *http.Request) {
+	active, err := optionalBool(r, "active")
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "Invalid active parameter")
+		return
+	}
+	page, err := pageParams(r)
 	if err != nil {
 		h.writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	// Save workflow
-	err = h.container.WorkflowRepository.Save(workflow)
+	// Handle query
+	results, err := h.container.ListWorkflowsQueryHandler.Handle(r.Context(), query.ListWorkflowsQuery{Active: active, Page: page})
 	if err != nil {
-		h.writeError(w, http.StatusInternalServerError, "Failed to save workflow")
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
 	// Return response
-	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
-		"workflow_id": workflowID.Value(),
-		"name":        workflow.Name(),
-		"description": workflow.Description(),
-		"message":     "Workflow created successfully",
-	})
+	h.writeJSON(w, http.StatusOK, results)
 }
 
-// GetWorkflow handles GET /api/workflows/{id}
+// GetWorkflow handles GET /api/workflows/{id}, the latest version unless ?version= names another
 func (h *WorkflowHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
 	workflowID := r.URL.Query().Get("id")
 	if workflowID == "" {
@@ -95,32 +161,299 @@ func (h *WorkflowHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create workflow ID
-	id, err := value.NewWorkflowID(workflowID)
+	// Create query, in the version asked for
+	q := query.GetWorkflowQuery{WorkflowID: workflowID}
+	if number := r.URL.Query().Get("version"); number != "" {
+		n, err := strconv.Atoi(number)
+		if err != nil || n <= 0 {
+			h.writeError(w, http.StatusBadRequest, "Invalid workflow version")
+			return
+		}
+		q.Version = n
+	}
+
+	// Handle query
+	result, err := h.container.GetWorkflowQueryHandler.Handle(r.Context(), q)
 	if err != nil {
-		h.writeError(w, http.StatusBadRequest, "Invalid workflow ID")
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, result)
+}
+
+// AddStatus handles POST /api/workflows/statuses?id={id}
+func (h *WorkflowHandler) AddStatus(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	if workflowID == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID is required")
+		return
+	}
+
+	var req dto.AddWorkflowStatusRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	h.editStatuses(w, r, command.EditWorkflowStatusesCommand{
+		WorkflowID:  workflowID,
+		Action:      command.WorkflowStatusAdd,
+		Name:        req.Name,
+		Description: req.Description,
+		IsFinal:     req.IsFinal,
+		WIPLimit:    req.WIPLimit,
+	}, "Status added successfully")
+}
+
+// RemoveStatus handles DELETE /api/workflows/statuses?id={id}&name={name}
+func (h *WorkflowHandler) RemoveStatus(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	name := r.URL.Query().Get("name")
+	if workflowID == "" || name == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID and status name are required")
+		return
+	}
+
+	h.editStatuses(w, r, command.EditWorkflowStatusesCommand{
+		WorkflowID: workflowID,
+		Action:     command.WorkflowStatusRemove,
+		Name:       name,
+	}, "Status removed successfully")
+}
+
+// ReorderStatuses handles PUT /api/workflows/statuses?id={id}
+func (h *WorkflowHandler) ReorderStatuses(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	if workflowID == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID is required")
+		return
+	}
+
+	var req dto.ReorderWorkflowStatusesRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	h.editStatuses(w, r, command.EditWorkflowStatusesCommand{
+		WorkflowID: workflowID,
+		Action:     command.WorkflowStatusReorder,
+		Order:      req.Statuses,
+	}, "Statuses reordered successfully")
+}
+
+// AllowTransition handles POST /api/workflows/transitions?id={id}
+func (h *WorkflowHandler) AllowTransition(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	if workflowID == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID is required")
+		return
+	}
+
+	var req dto.WorkflowTransitionInput
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	h.editTransitions(w, r, command.EditWorkflowTransitionsCommand{
+		WorkflowID: workflowID,
+		Action:     command.WorkflowTransitionAllow,
+		From:       req.From,
+		To:         req.To,
+	}, "Transition allowed successfully")
+}
+
+// DisallowTransition handles DELETE /api/workflows/transitions?id={id}&from={from}&to={to}
+func (h *WorkflowHandler) DisallowTransition(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if workflowID == "" || from == "" || to == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID, from and to statuses are required")
+		return
+	}
+
+	h.editTransitions(w, r, command.EditWorkflowTransitionsCommand{
+		WorkflowID: workflowID,
+		Action:     command.WorkflowTransitionDisallow,
+		From:       from,
+		To:         to,
+	}, "Transition disallowed successfully")
+}
+
+// SetTransitionRules handles PUT /api/workflows/transitions/rules?id={id}
+func (h *WorkflowHandler) SetTransitionRules(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	if workflowID == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID is required")
+		return
+	}
+
+	var req dto.TransitionRulesInput
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
 		return
 	}
 
-	// Get workflow
-	workflow, err := h.container.WorkflowRepository.GetByID(id)
+	// Create command
+	cmd := command.SetTransitionRulesCommand{
+		WorkflowID:  workflowID,
+		From:        req.From,
+		To:          req.To,
+		Guards:      make([]command.TransitionGuardInput, 0, len(req.Guards)),
+		Actions:     make([]command.TransitionActionInput, 0, len(req.Actions)),
+		RequestedBy: middleware.UserID(r),
+	}
+	for _, g := range req.Guards {
+		cmd.Guards = append(cmd.Guards, command.TransitionGuardInput{Kind: g.Kind, Approvals: g.Approvals})
+	}
+	for _, a := range req.Actions {
+		cmd.Actions = append(cmd.Actions, command.TransitionActionInput{
+			Kind:       a.Kind,
+			Channel:    a.Channel,
+			Target:     a.Target,
+			AssigneeID: a.AssigneeID,
+		})
+	}
+
+	// Handle command
+	result, err := h.container.SetTransitionRulesCommandHandler.Handle(r.Context(), cmd)
 	if err != nil {
-		h.writeError(w, http.StatusNotFound, "Workflow not found")
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
 		return
 	}
 
 	// Return response
 	h.writeJSON(w, http.StatusOK, map[string]interface{}{
-		"id":          workflow.ID().Value(),
-		"name":        workflow.Name(),
-		"description": workflow.Description(),
-		"created_at":  workflow.CreatedAt(),
-		"updated_at":  workflow.UpdatedAt(),
+		"workflow_id": workflowID,
+		"rules":       mapper.TransitionRulesToDTO(req.From, req.To, result.Rules),
+		"version":     result.Version,
+		"message":     "Transition rules updated successfully",
 	})
 }
 
+// SetApprovalStep handles PUT /api/workflows/approval-steps?id={id}
+func (h *WorkflowHandler) SetApprovalStep(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	if workflowID == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID is required")
+		return
+	}
+
+	var req dto.WorkflowApprovalStepRequest
+
+	// Parse request body
+	if fieldErrors := middleware.DecodeRequest(r, &req); fieldErrors != nil {
+		middleware.WriteProblem(w, middleware.ValidationProblem(fieldErrors))
+		return
+	}
+
+	h.setApprovalStep(w, r, command.SetWorkflowApprovalStepCommand{
+		WorkflowID:        workflowID,
+		Status:            req.Status,
+		RequiredApprovals: req.RequiredApprovals,
+		ReviewerIDs:       req.ReviewerIDs,
+	}, "Approval step updated successfully")
+}
+
+// RemoveApprovalStep handles DELETE /api/workflows/approval-steps?id={id}&status={status}
+func (h *WorkflowHandler) RemoveApprovalStep(w http.ResponseWriter, r *http.Request) {
+	workflowID := r.URL.Query().Get("id")
+	status := r.URL.Query().Get("status")
+	if workflowID == "" || status == "" {
+		h.writeError(w, http.StatusBadRequest, "Workflow ID and status are required")
+		return
+	}
+
+	h.setApprovalStep(w, r, command.SetWorkflowApprovalStepCommand{
+		WorkflowID: workflowID,
+		Status:     status,
+	}, "Approval step removed successfully")
+}
+
 // Helper methods
 
+// setApprovalStep runs an approval step edit on behalf of the caller and writes the status's step
+func (h *WorkflowHandler) setApprovalStep(w http.ResponseWriter, r *http.Request, cmd command.SetWorkflowApprovalStepCommand, message string) {
+	cmd.RequestedBy = middleware.UserID(r)
+
+	// Handle command
+	result, err := h.container.SetWorkflowApprovalStepCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	step := dto.WorkflowApprovalStepDTO{Status: cmd.Status, ReviewerIDs: []string{}}
+	if result.Step != nil {
+		step = mapper.ApprovalStepToDTO(cmd.Status, *result.Step)
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"workflow_id":   cmd.WorkflowID,
+		"approval_step": step,
+		"version":       result.Version,
+		"message":       message,
+	})
+}
+
+// editStatuses runs a status edit on behalf of the caller and writes the workflow's statuses
+func (h *WorkflowHandler) editStatuses(w http.ResponseWriter, r *http.Request, cmd command.EditWorkflowStatusesCommand, message string) {
+	cmd.RequestedBy = middleware.UserID(r)
+
+	// Handle command
+	result, err := h.container.EditWorkflowStatusesCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"workflow_id": cmd.WorkflowID,
+		"statuses":    result.Statuses,
+		"version":     result.Version,
+		"message":     message,
+	})
+}
+
+// editTransitions runs a transition edit on behalf of the caller and writes the workflow's matrix
+func (h *WorkflowHandler) editTransitions(w http.ResponseWriter, r *http.Request, cmd command.EditWorkflowTransitionsCommand, message string) {
+	cmd.RequestedBy = middleware.UserID(r)
+
+	// Handle command
+	result, err := h.container.EditWorkflowTransitionsCommandHandler.Handle(r.Context(), cmd)
+	if err != nil {
+		middleware.WriteProblem(w, h.errorHandler.HandleError(err))
+		return
+	}
+
+	transitions := make([]dto.WorkflowTransitionInput, 0, len(result.Transitions))
+	for _, t := range result.Transitions {
+		transitions = append(transitions, dto.WorkflowTransitionInput{From: t.From, To: t.To})
+	}
+
+	// Return response
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"workflow_id": cmd.WorkflowID,
+		"transitions": transitions,
+		"version":     result.Version,
+		"message":     message,
+	})
+}
+
 // writeJSON writes a JSON response
 func (h *WorkflowHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -130,8 +463,5 @@ func (h *WorkflowHandler) writeJSON(w http.ResponseWriter, statusCode int, data
 
 // writeError writes an error response
 func (h *WorkflowHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
-	h.writeJSON(w, statusCode, map[string]interface{}{
-		"code":    statusCode,
-		"message": message,
-	})
+	middleware.WriteProblem(w, middleware.StatusProblem(statusCode, message))
 }
\ No newline at end of file
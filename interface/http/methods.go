@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+)
+
+// Methods dispatches a request to the handler registered for its method
+type Methods map[string]http.HandlerFunc
+
+// ServeHTTP calls the handler for the request method or answers 405 with the allowed methods
+func (m Methods) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if handlerFunc, ok := m[req.Method]; ok {
+		handlerFunc(w, req)
+		return
+	}
+
+	w.Header().Set("Allow", m.allowed())
+	middleware.WriteProblem(w, middleware.NewProblem(middleware.ProblemMethodNotAllowed, http.StatusMethodNotAllowed,
+		req.Method+" is not supported, use "+m.allowed()))
+}
+
+// allowed lists the registered methods for the Allow header
+func (m Methods) allowed() string {
+	methods := make([]string, 0, len(m))
+	for method := range m {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// ActivityRecorder notes that a user made an authenticated request
+type ActivityRecorder interface {
+	RecordActivity(ctx context.Context, userID string)
+}
+
+// RecordActivity reports the caller of each request to the recorder before handling it.
+// It runs after RequireAuth, so every request it sees has a caller.
+func RecordActivity(recorder ActivityRecorder) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder.RecordActivity(r.Context(), UserID(r))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
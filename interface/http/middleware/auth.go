@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/miladev95/ddd-task/application/command"
+)
+
+// AuthContext is the authenticated caller of a request
+type AuthContext struct {
+	UserID string
+	Roles  []string
+}
+
+// HasRole reports whether the caller holds a global role
+func (a AuthContext) HasRole(role string) bool {
+	for _, held := range a.Roles {
+		if held == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CallerDirectory looks up the current state of the user a token was issued to
+type CallerDirectory interface {
+	// CurrentRoles returns the global roles a user holds now, false when the user is unknown or inactive
+	CurrentRoles(ctx context.Context, userID string) ([]string, bool)
+}
+
+// authContextKey is the request context key of the AuthContext
+type authContextKey struct{}
+
+// Authenticate resolves a valid bearer access token into the request's AuthContext. The caller's
+// roles are looked up per request rather than taken from the token, and tokens of users who are
+// gone or deactivated do not authenticate. Requests without a valid token pass through
+// unauthenticated; RequireAuth rejects them where needed.
+func Authenticate(verifier command.TokenVerifier, callers CallerDirectory) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := BearerToken(r)
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			roles, active := callers.CurrentRoles(r.Context(), claims.UserID)
+			if !active {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), authContextKey{}, AuthContext{
+				UserID: claims.UserID,
+				Roles:  roles,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireAuth rejects requests that carry no valid access token with 401
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := AuthFromContext(r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		message := "Authentication required"
+		if BearerToken(r) != "" {
+			message = "Invalid or expired access token"
+		}
+
+		w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+		WriteProblem(w, NewProblem(ProblemUnauthorized, http.StatusUnauthorized, message))
+	})
+}
+
+// RequireRole rejects callers who do not currently hold a global role with 403.
+// Use it after RequireAuth.
+func RequireRole(role string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if authContext, ok := AuthFromContext(r.Context()); ok && authContext.HasRole(role) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			WriteProblem(w, NewProblem(ProblemForbidden, http.StatusForbidden, "permission denied: the "+role+" role is required"))
+		})
+	}
+}
+
+// AuthFromContext returns the authenticated caller of a request context
+func AuthFromContext(ctx context.Context) (AuthContext, bool) {
+	authContext, ok := ctx.Value(authContextKey{}).(AuthContext)
+	return authContext, ok
+}
+
+// UserID returns the ID of the authenticated caller, empty for anonymous requests
+func UserID(r *http.Request) string {
+	authContext, _ := AuthFromContext(r.Context())
+	return authContext.UserID
+}
+
+// BearerToken extracts the token of an "Authorization: Bearer <token>" header.
+// Browsers cannot set headers on WebSocket requests, so upgrades may pass it as access_token.
+func BearerToken(r *http.Request) string {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// Middleware wraps an HTTP handler with cross-cutting behaviour such as logging or auth
+type Middleware func(http.Handler) http.Handler
+
+// Chain composes middlewares into one, the first listed being the outermost
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
@@ -1,30 +1,13 @@
 package middleware
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"log"
 	"net/http"
-)
-
-// HTTPError represents a standard HTTP error response
-type HTTPError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Details string `json:"details,omitempty"`
-}
-
-// NewHTTPError creates a new HTTPError
-func NewHTTPError(code int, message string, details ...string) *HTTPError {
-	detail := ""
-	if len(details) > 0 {
-		detail = details[0]
-	}
 
-	return &HTTPError{
-		Code:    code,
-		Message: message,
-		Details: detail,
-	}
-}
+	"github.com/miladev95/ddd-task/domain/errs"
+)
 
 // ErrorHandler provides error handling utilities
 type ErrorHandler struct{}
@@ -34,33 +17,56 @@ func NewErrorHandler() *ErrorHandler {
 	return &ErrorHandler{}
 }
 
-// HandleError converts an error to an HTTP error response
-func (h *ErrorHandler) HandleError(err error) *HTTPError {
+// HandleError converts an error to a problem details response.
+// Errors of no known kind are reported as internal errors; their text is logged
+// rather than sent, since it describes the server and not the request.
+func (h *ErrorHandler) HandleError(err error) *Problem {
 	if err == nil {
 		return nil
 	}
 
+	if problem := h.classify(err); problem != nil {
+		return problem
+	}
+
+	log.Printf("internal error: %v", err)
+	return NewProblem(ProblemInternal, http.StatusInternalServerError, "An unexpected error occurred")
+}
+
+// classify maps the kinds of domain and application error to their problem type, nil for
+// errors of no kind. Wrapped errors keep their kind, so it is matched anywhere in the chain.
+func (h *ErrorHandler) classify(err error) *Problem {
 	errMsg := err.Error()
 
-	// Map specific error messages to HTTP status codes
 	switch {
-	case errMsg == "task not found" || errMsg == "project not found" || errMsg == "user not found":
-		return NewHTTPError(http.StatusNotFound, "Resource not found", errMsg)
+	case errors.Is(err, context.DeadlineExceeded):
+		return NewProblem(ProblemTimeout, http.StatusGatewayTimeout, errMsg)
+
+	case errors.Is(err, errs.ErrUnauthenticated):
+		return NewProblem(ProblemUnauthorized, http.StatusUnauthorized, errMsg)
+
+	case errors.Is(err, errs.ErrQuotaExceeded):
+		return NewProblem(ProblemQuotaExceeded, http.StatusForbidden, errMsg)
+
+	case errors.Is(err, errs.ErrPermissionDenied):
+		return NewProblem(ProblemForbidden, http.StatusForbidden, errMsg)
 
-	case errMsg == "invalid task id" || errMsg == "invalid project id" || errMsg == "invalid user id":
-		return NewHTTPError(http.StatusBadRequest, "Invalid input", errMsg)
+	case errors.Is(err, errs.ErrNotFound):
+		return NewProblem(ProblemNotFound, http.StatusNotFound, errMsg)
 
-	case errMsg == "task is already assigned":
-		return NewHTTPError(http.StatusConflict, "Task already assigned", errMsg)
+	case errors.Is(err, errs.ErrInvalidTransition):
+		return NewProblem(ProblemInvalidTransition, http.StatusBadRequest, errMsg)
 
-	case errMsg == "cannot transition" || errMsg == "invalid status transition":
-		return NewHTTPError(http.StatusBadRequest, "Invalid state transition", errMsg)
+	case errors.Is(err, errs.ErrConcurrencyConflict):
+		return NewProblem(ProblemConcurrencyConflict, http.StatusConflict, errMsg)
+
+	case errors.Is(err, errs.ErrConflict):
+		return NewProblem(ProblemConflict, http.StatusConflict, errMsg)
+
+	case errors.Is(err, errs.ErrValidation):
+		return NewProblem(ProblemValidation, http.StatusBadRequest, errMsg)
 
 	default:
-		return NewHTTPError(
-			http.StatusInternalServerError,
-			"Internal server error",
-			fmt.Sprintf("An unexpected error occurred: %v", err),
-		)
+		return nil
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// IdempotencyKeyHeader carries the client-chosen key that makes a POST safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayHeader marks a response replayed from an earlier request with the same key
+const IdempotentReplayHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength bounds the keys clients may send
+const maxIdempotencyKeyLength = 255
+
+// replayedHeaders are the response headers kept with a stored result
+var replayedHeaders = []string{"Content-Type", "Location"}
+
+// IdempotencyStore keeps the results of requests by idempotency key
+type IdempotencyStore interface {
+	Begin(scope, key, fingerprint string) ([]byte, error)
+	Complete(scope, key string, response []byte)
+	Abandon(scope, key string)
+}
+
+// storedResponse is the result of a request as kept for replays
+type storedResponse struct {
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   []byte            `json:"body"`
+}
+
+// Idempotency lets clients retry a POST sent with an Idempotency-Key header: the first request
+// runs and its result is stored, and later requests with the same key and body get that result
+// back instead of running again. Server errors are not stored, so they can be retried. Keys are
+// scoped to the caller; it runs after RequireAuth.
+func Idempotency(store IdempotencyStore) Middleware {
+	errorHandler := NewErrorHandler()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				WriteProblem(w, StatusProblem(http.StatusBadRequest,
+					fmt.Sprintf("invalid idempotency key: longer than %d characters", maxIdempotencyKeyLength)))
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				WriteProblem(w, StatusProblem(http.StatusBadRequest, "invalid request body: "+err.Error()))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := UserID(r)
+			stored, err := store.Begin(scope, key, requestFingerprint(r, body))
+			if err != nil {
+				WriteProblem(w, errorHandler.HandleError(err))
+				return
+			}
+			if stored != nil {
+				replay(w, stored, errorHandler)
+				return
+			}
+
+			recorder := &bodyRecorder{ResponseWriter: w}
+			completed := false
+			defer func() {
+				if !completed {
+					store.Abandon(scope, key)
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			status := recorder.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			result := storedResponse{Status: status, Header: make(map[string]string), Body: recorder.body.Bytes()}
+			for _, name := range replayedHeaders {
+				if value := recorder.Header().Get(name); value != "" {
+					result.Header[name] = value
+				}
+			}
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return
+			}
+			store.Complete(scope, key, encoded)
+			completed = true
+		})
+	}
+}
+
+// requestFingerprint identifies what a request asks for, so a key reused for another request is noticed
+func requestFingerprint(r *http.Request, body []byte) string {
+	digest := sha256.New()
+	digest.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	digest.Write(body)
+	return hex.EncodeToString(digest.Sum(nil))
+}
+
+// replay writes a stored result again
+func replay(w http.ResponseWriter, stored []byte, errorHandler *ErrorHandler) {
+	var result storedResponse
+	if err := json.Unmarshal(stored, &result); err != nil {
+		WriteProblem(w, errorHandler.HandleError(fmt.Errorf("failed to replay stored response: %w", err)))
+		return
+	}
+
+	for name, value := range result.Header {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set(IdempotentReplayHeader, strconv.FormatBool(true))
+	w.WriteHeader(result.Status)
+	w.Write(result.Body)
+}
+
+// bodyRecorder keeps a copy of the status and body of a response while passing it through
+type bodyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status code
+func (r *bodyRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the body
+func (r *bodyRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// Logging writes one line per request with its status and duration
+func Logging(logger *log.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := newResponseRecorder(w)
+
+			next.ServeHTTP(recorder, r)
+
+			status := recorder.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			logger.Printf("%s %s %d %dB %s", r.Method, r.URL.RequestURI(), status, recorder.written, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details responses
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBase prefixes every problem type URI; each type is documented under its anchor in PROBLEMS.md
+const ProblemTypeBase = "https://github.com/miladev95/ddd-task/blob/main/PROBLEMS.md#"
+
+// ProblemType is a stable kind of error that clients can branch on
+type ProblemType struct {
+	URI   string
+	Title string
+}
+
+// The problem types the API reports. Their URIs are part of the public contract and must not change.
+var (
+	ProblemValidation          = ProblemType{URI: ProblemTypeBase + "validation", Title: "Validation failed"}
+	ProblemNotFound            = ProblemType{URI: ProblemTypeBase + "not-found", Title: "Resource not found"}
+	ProblemInvalidTransition   = ProblemType{URI: ProblemTypeBase + "invalid-transition", Title: "Invalid state transition"}
+	ProblemConcurrencyConflict = ProblemType{URI: ProblemTypeBase + "concurrency-conflict", Title: "Concurrent modification"}
+	ProblemConflict            = ProblemType{URI: ProblemTypeBase + "conflict", Title: "Conflict with current state"}
+	ProblemUnauthorized        = ProblemType{URI: ProblemTypeBase + "unauthorized", Title: "Authentication required"}
+	ProblemForbidden           = ProblemType{URI: ProblemTypeBase + "forbidden", Title: "Forbidden"}
+	ProblemMethodNotAllowed    = ProblemType{URI: ProblemTypeBase + "method-not-allowed", Title: "Method not allowed"}
+	ProblemTimeout             = ProblemType{URI: ProblemTypeBase + "timeout", Title: "Request timed out"}
+	ProblemQuotaExceeded       = ProblemType{URI: ProblemTypeBase + "quota-exceeded", Title: "Quota exceeded"}
+	ProblemInternal            = ProblemType{URI: ProblemTypeBase + "internal", Title: "Internal server error"}
+)
+
+// ProblemTypes lists every problem type, for documentation and contract generation
+func ProblemTypes() []ProblemType {
+	return []ProblemType{
+		ProblemValidation,
+		ProblemNotFound,
+		ProblemInvalidTransition,
+		ProblemConcurrencyConflict,
+		ProblemConflict,
+		ProblemUnauthorized,
+		ProblemForbidden,
+		ProblemMethodNotAllowed,
+		ProblemTimeout,
+		ProblemQuotaExceeded,
+		ProblemInternal,
+	}
+}
+
+// Problem is an RFC 7807 problem details response body
+type Problem struct {
+	Type   string `json:"type" binding:"required"`
+	Title  string `json:"title" binding:"required"`
+	Status int    `json:"status" binding:"required"`
+	Detail string `json:"detail,omitempty"`
+
+	// Errors lists the request fields that break an input rule, for validation problems
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// NewProblem creates a Problem of the given type
+func NewProblem(problemType ProblemType, status int, detail string) *Problem {
+	return &Problem{
+		Type:   problemType.URI,
+		Title:  problemType.Title,
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// StatusProblem creates a Problem of the generic type for an HTTP status,
+// for errors the handler detects itself such as a missing parameter
+func StatusProblem(status int, detail string) *Problem {
+	problemType := ProblemInternal
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		problemType = ProblemValidation
+	case http.StatusUnauthorized:
+		problemType = ProblemUnauthorized
+	case http.StatusForbidden:
+		problemType = ProblemForbidden
+	case http.StatusNotFound:
+		problemType = ProblemNotFound
+	case http.StatusMethodNotAllowed:
+		problemType = ProblemMethodNotAllowed
+	case http.StatusConflict:
+		problemType = ProblemConflict
+	case http.StatusGatewayTimeout:
+		problemType = ProblemTimeout
+	}
+
+	return NewProblem(problemType, status, detail)
+}
+
+// WriteProblem writes a problem details response
+func WriteProblem(w http.ResponseWriter, problem *Problem) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
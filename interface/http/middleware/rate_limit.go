@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APICallLimiter decides whether a caller may make another API call
+type APICallLimiter interface {
+	Allow(ctx context.Context, userID string) (time.Duration, error)
+}
+
+// RateLimit refuses calls over the caller's organization quota with 429 and a Retry-After header.
+// It runs after RequireAuth, so every request it sees has a caller.
+func RateLimit(limiter APICallLimiter) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			retryAfter, err := limiter.Allow(r.Context(), UserID(r))
+			if err != nil {
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				if seconds < 1 {
+					seconds = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				WriteProblem(w, NewProblem(ProblemQuotaExceeded, http.StatusTooManyRequests, err.Error()))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recovery turns a panicking handler into a 500 response instead of a dropped connection
+func Recovery(logger *log.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder := newResponseRecorder(w)
+
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+
+				// The server aborts such requests on purpose, leave them to it
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				logger.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, recovered, debug.Stack())
+
+				// Too late to change the response once it has started
+				if recorder.Status() != 0 {
+					return
+				}
+
+				WriteProblem(recorder, NewProblem(ProblemInternal, http.StatusInternalServerError, ""))
+			}()
+
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
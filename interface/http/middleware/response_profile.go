@@ -0,0 +1,257 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// APIVersionHeader is the request header naming the API version a client was written against
+const APIVersionHeader = "API-Version"
+
+// ResponseProfileHeader reports on every response which profile shaped its body
+const ResponseProfileHeader = "Response-Profile"
+
+// ResponseProfile is a shape of JSON success bodies. Problem responses are never reshaped.
+type ResponseProfile string
+
+const (
+	// ProfileLegacy leaves bodies as the handlers write them, snake_case and unwrapped
+	ProfileLegacy ResponseProfile = "legacy"
+	// ProfileEnvelope wraps snake_case bodies in {"data": ..., "meta": {...}}
+	ProfileEnvelope ResponseProfile = "envelope"
+	// ProfileCamel renames every object key to camelCase and leaves bodies unwrapped
+	ProfileCamel ResponseProfile = "camel"
+)
+
+// NewResponseProfile creates a ResponseProfile from its name
+func NewResponseProfile(name string) (ResponseProfile, error) {
+	profile := ResponseProfile(strings.ToLower(strings.TrimSpace(name)))
+	switch profile {
+	case ProfileLegacy, ProfileEnvelope, ProfileCamel:
+		return profile, nil
+	default:
+		return "", fmt.Errorf("invalid response profile %q: must be legacy, envelope or camel", name)
+	}
+}
+
+// ParseVersionProfiles parses a comma separated list of version=profile pairs such as "2=envelope,3=camel"
+func ParseVersionProfiles(spec string) (map[string]ResponseProfile, error) {
+	profiles := make(map[string]ResponseProfile)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		version, name, ok := strings.Cut(pair, "=")
+		version = strings.TrimSpace(version)
+		if !ok || version == "" {
+			return nil, fmt.Errorf("invalid version profile %q: expected version=profile", pair)
+		}
+		profile, err := NewResponseProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		profiles[version] = profile
+	}
+	return profiles, nil
+}
+
+// ShapeResponses reshapes JSON success bodies into the profile a client asks for, so new
+// endpoints can be standardized without breaking clients of the existing responses.
+// A profile parameter on an Accept media type wins, e.g. "application/json; profile=camel",
+// then the profile mapped to the API-Version header; everything else stays legacy.
+func ShapeResponses(versionProfiles map[string]ResponseProfile) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept, "+APIVersionHeader)
+
+			profile, err := negotiateProfile(r, versionProfiles)
+			if err != nil {
+				WriteProblem(w, NewProblem(ProblemValidation, http.StatusNotAcceptable, err.Error()))
+				return
+			}
+			w.Header().Set(ResponseProfileHeader, string(profile))
+
+			if profile == ProfileLegacy {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			shaper := &shapingWriter{ResponseWriter: w}
+			next.ServeHTTP(shaper, r)
+			shaper.finish(profile, r.Header.Get(APIVersionHeader))
+		})
+	}
+}
+
+// negotiateProfile picks the response profile of a request
+func negotiateProfile(r *http.Request, versionProfiles map[string]ResponseProfile) (ResponseProfile, error) {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(accepted)
+		if err != nil || params["profile"] == "" {
+			continue
+		}
+		if mediaType != "application/json" && mediaType != "*/*" {
+			continue
+		}
+		return NewResponseProfile(params["profile"])
+	}
+
+	if version := strings.TrimSpace(r.Header.Get(APIVersionHeader)); version != "" {
+		if profile, ok := versionProfiles[version]; ok {
+			return profile, nil
+		}
+	}
+	return ProfileLegacy, nil
+}
+
+// shapingWriter buffers a response until the handler returns so its body can be reshaped.
+// Flushed and hijacked responses are streams and pass through untouched from then on.
+type shapingWriter struct {
+	http.ResponseWriter
+	body        bytes.Buffer
+	status      int
+	passThrough bool
+}
+
+// WriteHeader buffers the status code
+func (sw *shapingWriter) WriteHeader(status int) {
+	if sw.passThrough {
+		sw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if sw.status == 0 {
+		sw.status = status
+	}
+}
+
+// Write buffers body bytes
+func (sw *shapingWriter) Write(data []byte) (int, error) {
+	if sw.passThrough {
+		return sw.ResponseWriter.Write(data)
+	}
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	return sw.body.Write(data)
+}
+
+// Flush sends what was buffered unchanged and streams the rest of the response
+func (sw *shapingWriter) Flush() {
+	if !sw.passThrough {
+		sw.writeThrough(sw.body.Bytes())
+	}
+	if flusher, ok := sw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack passes connection takeovers through so WebSocket upgrades keep working
+func (sw *shapingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := sw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+
+	sw.passThrough = true
+	return hijacker.Hijack()
+}
+
+// finish writes the buffered response, reshaped when it is a JSON success body
+func (sw *shapingWriter) finish(profile ResponseProfile, version string) {
+	if sw.passThrough {
+		return
+	}
+
+	body := sw.body.Bytes()
+	mediaType, _, _ := mime.ParseMediaType(sw.Header().Get("Content-Type"))
+	if mediaType == "application/json" && sw.status >= 200 && sw.status < 300 && len(body) > 0 {
+		if shaped, err := shapeBody(body, profile, version); err == nil {
+			body = shaped
+		}
+	}
+	sw.writeThrough(body)
+}
+
+// writeThrough sends the buffered status and the given body and stops buffering
+func (sw *shapingWriter) writeThrough(body []byte) {
+	sw.passThrough = true
+	if sw.status == 0 {
+		sw.status = http.StatusOK
+	}
+	sw.Header().Del("Content-Length")
+	sw.ResponseWriter.WriteHeader(sw.status)
+	sw.ResponseWriter.Write(body)
+}
+
+// shapeBody re-encodes a JSON body in a profile
+func shapeBody(body []byte, profile ResponseProfile, version string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	var shaped interface{}
+	switch profile {
+	case ProfileCamel:
+		shaped = camelKeys(data)
+	case ProfileEnvelope:
+		meta := map[string]interface{}{"profile": string(profile)}
+		if version != "" {
+			meta["api_version"] = version
+		}
+		shaped = map[string]interface{}{"data": data, "meta": meta}
+	default:
+		return body, nil
+	}
+
+	var out bytes.Buffer
+	if err := json.NewEncoder(&out).Encode(shaped); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// camelKeys renames the keys of every object in a decoded JSON value to camelCase
+func camelKeys(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(typed))
+		for key, nested := range typed {
+			renamed[camelCase(key)] = camelKeys(nested)
+		}
+		return renamed
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = camelKeys(nested)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+// camelCase turns a snake_case name into camelCase, e.g. project_id becomes projectId
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	var out strings.Builder
+	out.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		out.WriteString(string(runes))
+	}
+	return out.String()
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Timeout bounds a request by a deadline on its context. Handlers that outlive it are
+// answered with 504 and whatever they write afterwards is discarded; command handlers
+// see the expired context and abort before changing state. A handler that flushes streams
+// the rest of its response; past the deadline a stream is cut off rather than answered with 504.
+func Timeout(timeout time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			buffered := &timeoutWriter{ctx: ctx, w: w, header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+
+			go func() {
+				defer func() {
+					if recovered := recover(); recovered != nil {
+						panicked <- recovered
+					}
+				}()
+				next.ServeHTTP(buffered, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case recovered := <-panicked:
+				// Let the recovery middleware see the panic on the request goroutine
+				panic(recovered)
+
+			case <-done:
+				buffered.finish()
+
+			case <-ctx.Done():
+				if streamed := buffered.timeOut(); !streamed && ctx.Err() == context.DeadlineExceeded {
+					WriteProblem(w, NewProblem(ProblemTimeout, http.StatusGatewayTimeout,
+						fmt.Sprintf("request exceeded %s", timeout)))
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter buffers a response so it can be dropped when the deadline passes first.
+// Once flushed, the response is a stream and writes pass through to w until the deadline.
+type timeoutWriter struct {
+	ctx       context.Context
+	w         http.ResponseWriter
+	header    http.Header
+	body      bytes.Buffer
+	status    int
+	timedOut  bool
+	streaming bool
+	mu        sync.Mutex
+}
+
+// Header returns the buffered response headers
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+// WriteHeader buffers the status code
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.status == 0 {
+		tw.status = status
+	}
+}
+
+// Write buffers body bytes, or passes them through once flushed, failing once the request has timed out
+func (tw *timeoutWriter) Write(data []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.ctx.Err() != nil {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.streaming {
+		return tw.w.Write(data)
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	return tw.body.Write(data)
+}
+
+// Flush sends what was buffered and streams the rest of the response
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.ctx.Err() != nil {
+		return
+	}
+	if !tw.streaming {
+		tw.writeBuffered()
+		tw.streaming = true
+	}
+	if flusher, ok := tw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// timeOut makes later writes fail and reports whether the response was already streaming
+func (tw *timeoutWriter) timeOut() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.timedOut = true
+	return tw.streaming
+}
+
+// finish copies the buffered response to the real writer unless it was streamed
+func (tw *timeoutWriter) finish() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if !tw.streaming {
+		tw.writeBuffered()
+	}
+}
+
+// writeBuffered copies the buffered headers, status and body to the real writer
+func (tw *timeoutWriter) writeBuffered() {
+	for key, values := range tw.header {
+		tw.w.Header()[key] = values
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	tw.w.WriteHeader(tw.status)
+	tw.w.Write(tw.body.Bytes())
+	tw.body.Reset()
+}
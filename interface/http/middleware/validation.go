@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// FieldError reports one request field that breaks an input rule
+type FieldError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+// bodyField names the request body itself in a FieldError, when the body cannot be read as JSON at all
+const bodyField = "body"
+
+// ValidationProblem creates a validation Problem listing the fields that break an input rule
+func ValidationProblem(fieldErrors []FieldError) *Problem {
+	details := make([]string, len(fieldErrors))
+	for i, fieldError := range fieldErrors {
+		details[i] = fieldError.Field + ": " + fieldError.Error
+	}
+
+	problem := NewProblem(ProblemValidation, http.StatusBadRequest, strings.Join(details, "; "))
+	problem.Errors = fieldErrors
+	return problem
+}
+
+// DecodeRequest decodes the JSON body of a request into v and checks the binding rules
+// of its fields. It returns nil when the request may be handed on to the application layer.
+func DecodeRequest(r *http.Request, v interface{}) []FieldError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return []FieldError{decodeFieldError(err)}
+	}
+	return Validate(v)
+}
+
+// decodeFieldError turns a JSON decoding error into the field it is about
+func decodeFieldError(err error) FieldError {
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return FieldError{Field: bodyField, Error: "is required"}
+	case errors.As(err, &syntaxError):
+		return FieldError{Field: bodyField, Error: fmt.Sprintf("is not valid JSON at offset %d", syntaxError.Offset)}
+	case errors.As(err, &typeError) && typeError.Field != "":
+		return FieldError{Field: typeError.Field, Error: "must be " + jsonTypeName(typeError.Type)}
+	default:
+		return FieldError{Field: bodyField, Error: "is not valid JSON"}
+	}
+}
+
+// jsonTypeName describes the JSON value a Go type is decoded from
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Ptr:
+		return jsonTypeName(t.Elem())
+	default:
+		return "an object"
+	}
+}
+
+// Validate checks the binding rules of a request struct, including the structs nested in it.
+// The binding tag holds comma separated rules:
+//
+//	required      a string must not be blank and a slice or map must not be empty; numbers
+//	              and booleans are not checked, since their zero value can be meaningful
+//	oneof=A B C   a string that is set must be one of the listed values
+func Validate(v interface{}) []FieldError {
+	var fieldErrors []FieldError
+	validateValue(reflect.ValueOf(v), "", &fieldErrors)
+	return fieldErrors
+}
+
+// validateValue checks the fields of a struct, or of each struct in a slice, under the given path
+func validateValue(value reflect.Value, path string, fieldErrors *[]FieldError) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			validateValue(value.Index(i), fmt.Sprintf("%s[%d]", path, i), fieldErrors)
+		}
+	case reflect.Struct:
+		t := value.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" {
+				continue
+			}
+			if field.Anonymous && name == "" {
+				validateValue(value.Field(i), path, fieldErrors)
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+
+			if message := checkRules(value.Field(i), field.Tag.Get("binding")); message != "" {
+				*fieldErrors = append(*fieldErrors, FieldError{Field: name, Error: message})
+				continue
+			}
+			validateValue(value.Field(i), name, fieldErrors)
+		}
+	}
+}
+
+// checkRules returns why a field value breaks its binding rules, or "" when it keeps them
+func checkRules(value reflect.Value, binding string) string {
+	if binding == "" {
+		return ""
+	}
+
+	for _, rule := range strings.Split(binding, ",") {
+		switch {
+		case rule == "required":
+			if isMissing(value) {
+				return "is required"
+			}
+		case strings.HasPrefix(rule, "oneof="):
+			if value.Kind() != reflect.String || value.String() == "" {
+				continue
+			}
+			allowed := strings.Fields(strings.TrimPrefix(rule, "oneof="))
+			if !contains(allowed, value.String()) {
+				return "must be one of " + strings.Join(allowed, ", ")
+			}
+		}
+	}
+	return ""
+}
+
+// isMissing checks if a required field was left out of the request
+func isMissing(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(value.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+// contains checks if values holds value
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
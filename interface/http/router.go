@@ -3,7 +3,9 @@ package http
 import (
 	"net/http"
 
+	"github.com/miladev95/ddd-task/domain/value"
 	"github.com/miladev95/ddd-task/interface/http/handler"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
 	"github.com/miladev95/ddd-task/shared/di"
 )
 
@@ -12,6 +14,9 @@ type Router struct {
 	container    *di.Container
 	mux          *http.ServeMux
 	taskHandler  *handler.TaskHandler
+	paths        []string
+	middlewares  []middleware.Middleware
+	timeouts     TimeoutConfig
 }
 
 // NewRouter creates a new Router
@@ -20,9 +25,15 @@ func NewRouter(container *di.Container) *Router {
 		container:   container,
 		mux:         http.NewServeMux(),
 		taskHandler: handler.NewTaskHandler(container),
+		timeouts:    DefaultTimeouts(),
 	}
 }
 
+// SetTimeouts replaces the route timeouts, call it before SetupRoutes
+func (r *Router) SetTimeouts(timeouts TimeoutConfig) {
+	r.timeouts = timeouts
+}
+
 // SetupRoutes sets up all HTTP routes
 func (r *Router) SetupRoutes() {
 	// Initialize handlers
@@ -30,106 +41,402 @@ func (r *Router) SetupRoutes() {
 	projectHandler := handler.NewProjectHandler(r.container)
 	userHandler := handler.NewUserHandler(r.container)
 	workflowHandler := handler.NewWorkflowHandler(r.container)
+	widgetHandler := handler.NewWidgetHandler(r.container)
+	searchHandler := handler.NewSearchHandler(r.container)
+	adminHandler := handler.NewAdminHandler(r.container)
+	eventHandler := handler.NewEventHandler(r.container)
+	inboundEmailHandler := handler.NewInboundEmailHandler(r.container)
+	sprintHandler := handler.NewSprintHandler(r.container)
+	teamHandler := handler.NewTeamHandler(r.container)
+	organizationHandler := handler.NewOrganizationHandler(r.container)
+	holidayCalendarHandler := handler.NewHolidayCalendarHandler(r.container)
+	presenceHandler := handler.NewPresenceHandler(r.container)
+	authHandler := handler.NewAuthHandler(r.container)
+
+	// Auth routes
+	r.publicRoute("/api/auth/register", Methods{http.MethodPost: authHandler.Register})
+
+	r.publicRoute("/api/auth/signup", Methods{http.MethodPost: authHandler.SignUp})
+
+	r.publicRoute("/api/auth/login", Methods{http.MethodPost: authHandler.Login})
+
+	r.publicRoute("/api/auth/token", Methods{http.MethodPost: authHandler.IssueToken})
+
+	r.publicRoute("/api/auth/token/refresh", Methods{http.MethodPost: authHandler.RefreshToken})
+
+	r.publicRoute("/api/auth/logout", Methods{http.MethodPost: authHandler.Logout})
+
+	r.route("/api/auth/password", Methods{http.MethodPut: authHandler.ChangePassword})
 
 	// User routes
-	r.mux.HandleFunc("/api/users", func(w http.ResponseWriter, req *http.Request) {
-		switch req.Method {
-		case http.MethodPost:
-			userHandler.CreateUser(w, req)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/users", Methods{
+		http.MethodPost: userHandler.CreateUser,
+		http.MethodGet:  userHandler.ListUsers,
+		http.MethodPut:  userHandler.UpdateUser,
 	})
 
-	r.mux.HandleFunc("/api/users/get", func(w http.ResponseWriter, req *http.Request) {
-		if req.Method == http.MethodGet {
-			userHandler.GetUser(w, req)
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/users/get", Methods{http.MethodGet: userHandler.GetUser})
+
+	r.route("/api/users/recent", Methods{http.MethodGet: userHandler.GetRecentlyViewed})
+
+	r.publicRoute("/api/users/verify", Methods{http.MethodPost: userHandler.VerifyEmail})
+
+	r.route("/api/users/deactivate", Methods{http.MethodPost: userHandler.DeactivateUser})
+
+	r.route("/api/users/activate", Methods{http.MethodPost: userHandler.ActivateUser})
+
+	r.route("/api/users/email", Methods{http.MethodPut: userHandler.ChangeEmail})
+
+	r.route("/api/users/working-hours", Methods{
+		http.MethodPut:    userHandler.ChangeWorkingHours,
+		http.MethodDelete: userHandler.ChangeWorkingHours,
+	})
+
+	r.route("/api/users/locale", Methods{
+		http.MethodPut:    userHandler.ChangeLocale,
+		http.MethodDelete: userHandler.ChangeLocale,
+	})
+
+	r.route("/api/users/out-of-office", Methods{
+		http.MethodPut:    userHandler.ChangeOutOfOffice,
+		http.MethodDelete: userHandler.ChangeOutOfOffice,
+	})
+
+	r.route("/api/users/manager", Methods{
+		http.MethodPut:    userHandler.ChangeManager,
+		http.MethodDelete: userHandler.ChangeManager,
 	})
 
 	// Workflow routes
-	r.mux.HandleFunc("/api/workflows", func(w http.ResponseWriter, req *http.Request) {
-		switch req.Method {
-		case http.MethodPost:
-			workflowHandler.CreateWorkflow(w, req)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/workflows", Methods{
+		http.MethodPost: workflowHandler.CreateWorkflow,
+		http.MethodGet:  workflowHandler.ListWorkflows,
+		http.MethodPut:  workflowHandler.UpdateWorkflow,
 	})
 
-	r.mux.HandleFunc("/api/workflows/get", func(w http.ResponseWriter, req *http.Request) {
-		if req.Method == http.MethodGet {
-			workflowHandler.GetWorkflow(w, req)
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/workflows/get", Methods{http.MethodGet: workflowHandler.GetWorkflow})
+
+	r.route("/api/workflows/statuses", Methods{
+		http.MethodPost:   workflowHandler.AddStatus,
+		http.MethodPut:    workflowHandler.ReorderStatuses,
+		http.MethodDelete: workflowHandler.RemoveStatus,
+	})
+
+	r.route("/api/workflows/transitions", Methods{
+		http.MethodPost:   workflowHandler.AllowTransition,
+		http.MethodDelete: workflowHandler.DisallowTransition,
+	})
+
+	r.route("/api/workflows/transitions/rules", Methods{http.MethodPut: workflowHandler.SetTransitionRules})
+	r.route("/api/workflows/approval-steps", Methods{
+		http.MethodPut:    workflowHandler.SetApprovalStep,
+		http.MethodDelete: workflowHandler.RemoveApprovalStep,
 	})
 
 	// Project routes
-	r.mux.HandleFunc("/api/projects", func(w http.ResponseWriter, req *http.Request) {
-		switch req.Method {
-		case http.MethodPost:
-			projectHandler.CreateProject(w, req)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/projects", Methods{
+		http.MethodPost:   projectHandler.CreateProject,
+		http.MethodGet:    projectHandler.ListProjects,
+		http.MethodPut:    projectHandler.UpdateProject,
+		http.MethodDelete: projectHandler.DeleteProject,
 	})
 
-	r.mux.HandleFunc("/api/projects/get", func(w http.ResponseWriter, req *http.Request) {
-		if req.Method == http.MethodGet {
-			projectHandler.GetProject(w, req)
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/projects/get", Methods{http.MethodGet: projectHandler.GetProject})
+
+	r.route("/api/projects/stats", Methods{http.MethodGet: projectHandler.GetProjectStats})
+
+	r.route("/api/projects/activity", Methods{http.MethodGet: projectHandler.GetProjectActivity})
+
+	r.route("/api/projects/slo", Methods{http.MethodPut: projectHandler.SetProjectSLO})
+
+	r.route("/api/projects/board", Methods{http.MethodGet: projectHandler.GetProjectBoard})
+
+	r.route("/api/projects/board/sort", Methods{
+		http.MethodPut:    projectHandler.SetBoardSort,
+		http.MethodDelete: projectHandler.SetBoardSort,
+	})
+
+	r.route("/api/projects/settings", Methods{
+		http.MethodGet: projectHandler.GetProjectSettings,
+		http.MethodPut: projectHandler.SetProjectSettings,
+	})
+
+	r.route("/api/projects/settings/inbox", Methods{
+		http.MethodPost:   projectHandler.AddInboxAddress,
+		http.MethodDelete: projectHandler.RevokeInboxAddress,
+	})
+
+	r.route("/api/projects/access", Methods{http.MethodPut: projectHandler.SetProjectAccess})
+
+	r.route("/api/projects/budget", Methods{
+		http.MethodGet: projectHandler.GetProjectBudget,
+		http.MethodPut: projectHandler.SetProjectBudget,
+	})
+
+	r.route("/api/projects/notification-routes", Methods{
+		http.MethodGet: projectHandler.GetNotificationRoutes,
+		http.MethodPut: projectHandler.SetNotificationRoutes,
+	})
+
+	r.route("/api/projects/workflow", Methods{http.MethodPut: projectHandler.ChangeProjectWorkflow})
+
+	r.route("/api/projects/holiday-calendar", Methods{
+		http.MethodPut:    projectHandler.SetHolidayCalendar,
+		http.MethodDelete: projectHandler.SetHolidayCalendar,
+	})
+
+	r.route("/api/projects/priority-scheme", Methods{http.MethodPut: projectHandler.SetPriorityScheme})
+	r.route("/api/projects/workflow/migrate", Methods{http.MethodPost: projectHandler.MigrateProjectWorkflow})
+
+	r.route("/api/projects/workflow/migration", Methods{
+		http.MethodGet:  projectHandler.GetWorkflowMigration,
+		http.MethodPost: projectHandler.PlanWorkflowMigration,
+	})
+
+	r.route("/api/projects/workflow/migration/apply", Methods{http.MethodPost: projectHandler.ApplyWorkflowMigration})
+	r.route("/api/projects/workflow/migration/rollback", Methods{http.MethodPost: projectHandler.RollBackWorkflowMigration})
+	r.route("/api/projects/workflow/simulate", Methods{http.MethodPost: projectHandler.SimulateProjectWorkflow})
+
+	r.route("/api/projects/roles", Methods{http.MethodPut: projectHandler.AssignProjectRole})
+
+	r.route("/api/projects/archive", Methods{http.MethodPost: projectHandler.ArchiveProject})
+
+	r.route("/api/projects/milestones", Methods{
+		http.MethodPost:   projectHandler.CreateMilestone,
+		http.MethodGet:    projectHandler.ListMilestones,
+		http.MethodPut:    projectHandler.UpdateMilestone,
+		http.MethodDelete: projectHandler.DeleteMilestone,
 	})
 
 	// Task routes
-	r.mux.HandleFunc("/api/tasks", func(w http.ResponseWriter, req *http.Request) {
-		switch req.Method {
-		case http.MethodPost:
-			taskHandler.CreateTask(w, req)
-		case http.MethodGet:
-			taskHandler.ListTasksByProject(w, req)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/tasks", Methods{
+		http.MethodPost:   taskHandler.CreateTask,
+		http.MethodGet:    taskHandler.ListTasksByProject,
+		http.MethodDelete: taskHandler.DeleteTask,
 	})
 
-	r.mux.HandleFunc("/api/tasks/get", func(w http.ResponseWriter, req *http.Request) {
-		if req.Method == http.MethodGet {
-			taskHandler.GetTask(w, req)
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/tasks/get", Methods{http.MethodGet: taskHandler.GetTask})
+
+	r.route("/api/tasks/overdue", Methods{http.MethodGet: taskHandler.ListOverdueTasks})
+	r.route("/api/tasks/due", Methods{http.MethodGet: taskHandler.ListTasksDueWithin})
+
+	r.route("/api/tasks/history", Methods{http.MethodGet: taskHandler.GetTaskHistory})
+
+	r.route("/api/tasks/assign", Methods{http.MethodPost: taskHandler.AssignTask})
+	r.route("/api/tasks/acknowledge", Methods{http.MethodPost: taskHandler.AcknowledgeTask})
+
+	r.route("/api/tasks/assign-team", Methods{http.MethodPost: taskHandler.AssignTaskToTeam})
+
+	r.route("/api/tasks/reassign", Methods{http.MethodPost: taskHandler.ReassignAllTasks})
+
+	r.route("/api/tasks/status", Methods{http.MethodPut: taskHandler.UpdateTaskStatus})
+
+	r.route("/api/tasks/status/cas", Methods{http.MethodPost: taskHandler.CompareAndSetTaskStatus})
+
+	r.route("/api/tasks/comments", Methods{http.MethodPost: taskHandler.AddComment})
+
+	r.route("/api/tasks/attachments", Methods{http.MethodPost: taskHandler.AddAttachment})
+
+	r.route("/api/tasks/links", Methods{
+		http.MethodPost:   taskHandler.LinkTask,
+		http.MethodDelete: taskHandler.UnlinkTask,
 	})
 
-	r.mux.HandleFunc("/api/tasks/assign", func(w http.ResponseWriter, req *http.Request) {
-		if req.Method == http.MethodPost {
-			taskHandler.AssignTask(w, req)
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/tasks/duplicates", Methods{http.MethodGet: taskHandler.FindDuplicates})
+
+	r.route("/api/tasks/vote", Methods{
+		http.MethodPost:   taskHandler.VoteTask,
+		http.MethodDelete: taskHandler.VoteTask,
 	})
 
-	r.mux.HandleFunc("/api/tasks/status", func(w http.ResponseWriter, req *http.Request) {
-		if req.Method == http.MethodPut {
-			taskHandler.UpdateTaskStatus(w, req)
-		} else {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	r.route("/api/tasks/approve", Methods{http.MethodPost: taskHandler.ApproveTask})
+	r.route("/api/tasks/reject", Methods{http.MethodPost: taskHandler.RejectTask})
+
+	r.route("/api/tasks/edit-lock", Methods{
+		http.MethodPost:   taskHandler.ChangeEditLock,
+		http.MethodPut:    taskHandler.ChangeEditLock,
+		http.MethodDelete: taskHandler.ChangeEditLock,
+	})
+
+	r.route("/api/tasks/description", Methods{http.MethodPut: taskHandler.UpdateDescription})
+
+	r.route("/api/tasks/deadline", Methods{http.MethodPut: taskHandler.ChangeDeadline})
+
+	r.route("/api/tasks/costs", Methods{http.MethodPost: taskHandler.RecordCost})
+
+	// Workload routes
+	r.route("/api/workload/heatmap", Methods{http.MethodGet: taskHandler.GetWorkloadHeatmap})
+
+	// Dashboard widget routes
+	r.route("/api/widgets", Methods{
+		http.MethodPost:   widgetHandler.CreateWidget,
+		http.MethodGet:    widgetHandler.ListWidgets,
+		http.MethodPut:    widgetHandler.UpdateWidget,
+		http.MethodDelete: widgetHandler.DeleteWidget,
+	})
+
+	r.route("/api/widgets/evaluate", Methods{http.MethodGet: widgetHandler.EvaluateDashboard})
+
+	// Sprint routes
+	r.route("/api/sprints", Methods{
+		http.MethodPost: sprintHandler.CreateSprint,
+		http.MethodGet:  sprintHandler.ListSprints,
+	})
+
+	r.route("/api/sprints/start", Methods{http.MethodPost: sprintHandler.StartSprint})
+
+	r.route("/api/sprints/complete", Methods{http.MethodPost: sprintHandler.CompleteSprint})
+
+	r.route("/api/sprints/tasks", Methods{
+		http.MethodPost:   sprintHandler.AddTask,
+		http.MethodDelete: sprintHandler.RemoveTask,
+		http.MethodGet:    sprintHandler.ListTasks,
+	})
+
+	// Team routes
+	r.route("/api/teams", Methods{http.MethodPost: teamHandler.CreateTeam})
+
+	r.route("/api/teams/get", Methods{http.MethodGet: teamHandler.GetTeam})
+
+	r.route("/api/teams/members", Methods{
+		http.MethodPost:   teamHandler.AddMember,
+		http.MethodDelete: teamHandler.RemoveMember,
+	})
+
+	r.route("/api/teams/lead", Methods{http.MethodPut: teamHandler.ChangeLead})
+
+	r.route("/api/teams/tasks", Methods{http.MethodGet: teamHandler.ListTasks})
+
+	// Organization routes
+	r.route("/api/organizations", Methods{http.MethodPost: organizationHandler.CreateOrganization})
+
+	r.route("/api/organizations/members", Methods{http.MethodPost: organizationHandler.AddMember})
+
+	r.route("/api/organizations/quota", Methods{http.MethodPut: organizationHandler.SetQuota})
+
+	r.route("/api/organizations/working-hours", Methods{
+		http.MethodPut:    organizationHandler.ChangeWorkingHours,
+		http.MethodDelete: organizationHandler.ChangeWorkingHours,
+	})
+
+	r.route("/api/organizations/usage", Methods{http.MethodGet: organizationHandler.GetUsage})
+
+	r.route("/api/org/directory", Methods{http.MethodGet: organizationHandler.GetDirectory})
+
+	// Holiday calendar routes
+	r.route("/api/holiday-calendars", Methods{
+		http.MethodPost:   holidayCalendarHandler.CreateCalendar,
+		http.MethodGet:    holidayCalendarHandler.ListCalendars,
+		http.MethodPut:    holidayCalendarHandler.UpdateCalendar,
+		http.MethodDelete: holidayCalendarHandler.DeleteCalendar,
+	})
+
+	r.route("/api/holiday-calendars/get", Methods{http.MethodGet: holidayCalendarHandler.GetCalendar})
+
+	// Search routes
+	r.route("/api/search", Methods{http.MethodGet: searchHandler.Search})
+
+	r.route("/api/search/suggest", Methods{http.MethodGet: searchHandler.Suggest})
+
+	r.route("/api/search/tasks", Methods{http.MethodGet: searchHandler.SearchTasks})
+
+	// Event routes
+	r.publicRoute("/api/events/schemas", Methods{http.MethodGet: eventHandler.ListSchemas})
+
+	// Inbound email routes, authenticated by the relay's shared secret instead of a user
+	r.publicRoute("/api/inbound/email", Methods{http.MethodPost: inboundEmailHandler.ReceiveEmail})
+
+	// Presence routes
+	r.route("/api/presence", Methods{
+		http.MethodGet:    presenceHandler.GetPresence,
+		http.MethodPut:    presenceHandler.Heartbeat,
+		http.MethodDelete: presenceHandler.Leave,
 	})
 
+	r.route("/api/presence/ws", Methods{http.MethodGet: presenceHandler.Connect})
+
+	// Admin routes, for global admins only
+	requireAdmin := middleware.RequireRole(value.GlobalRoleAdmin.Value())
+
+	r.route("/api/admin/projections/rebuild", Methods{http.MethodPost: adminHandler.RebuildProjections}, requireAdmin)
+
+	r.route("/api/admin/search/reindex", Methods{http.MethodPost: adminHandler.ReindexSearch}, requireAdmin)
+
+	r.route("/api/admin/integrity", Methods{http.MethodGet: adminHandler.VerifyIntegrity}, requireAdmin)
+
+	r.route("/api/admin/backup", Methods{http.MethodGet: adminHandler.ExportBackup}, requireAdmin)
+
+	r.route("/api/admin/events/prune", Methods{http.MethodPost: adminHandler.PruneEvents}, requireAdmin)
+
+	r.route("/api/admin/events/metrics", Methods{http.MethodGet: adminHandler.GetEventMetrics}, requireAdmin)
+
+	r.route("/api/admin/events/stats", Methods{http.MethodGet: adminHandler.GetEventStats}, requireAdmin)
+
+	r.route("/api/admin/billing/usage", Methods{http.MethodGet: adminHandler.GetMonthlyUsage}, requireAdmin)
+
+	r.route("/api/admin/integrations/health", Methods{http.MethodGet: adminHandler.GetIntegrationHealth}, requireAdmin)
+
+	r.route("/api/events", Methods{http.MethodGet: eventHandler.ListEvents}, requireAdmin)
+
 	// Health check endpoint
-	r.mux.HandleFunc("/health", func(w http.ResponseWriter, req *http.Request) {
+	r.handleFunc("/health", func(w http.ResponseWriter, req *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"healthy"}`))
 	})
 }
 
-// Handler returns the HTTP handler
+// Use adds middlewares that wrap every route, in the order given
+func (r *Router) Use(middlewares ...middleware.Middleware) {
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// route registers a path that requires an authenticated caller, counted against their organization's
+// API quota and metered as an active user. POSTs sent with an Idempotency-Key can be retried safely.
+func (r *Router) route(path string, methods Methods, middlewares ...middleware.Middleware) {
+	guards := []middleware.Middleware{
+		middleware.RequireAuth,
+		middleware.RateLimit(r.container.APICallLimiter),
+		middleware.RecordActivity(r.container.UsageEmitter),
+		middleware.Idempotency(r.container.IdempotencyStore),
+	}
+	r.publicRoute(path, methods, append(guards, middlewares...)...)
+}
+
+// publicRoute registers the handlers of a path by method, wrapped in the given route middlewares.
+// Unsupported methods are rejected before any middleware runs, callers with a valid
+// access token are authenticated even on public routes, and each method runs under its timeout.
+func (r *Router) publicRoute(path string, methods Methods, middlewares ...middleware.Middleware) {
+	bounded := make(Methods, len(methods))
+	for method, handlerFunc := range methods {
+		bounded[method] = middleware.Timeout(r.timeouts.timeoutFor(path, method))(handlerFunc).ServeHTTP
+	}
+
+	authenticate := middleware.Authenticate(r.container.TokenIssuer, r.container.Callers)
+	handler := middleware.Chain(append([]middleware.Middleware{authenticate}, middlewares...)...)(bounded)
+
+	r.paths = append(r.paths, path)
+	r.mux.Handle(path, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if _, ok := methods[req.Method]; !ok {
+			methods.ServeHTTP(w, req)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	}))
+}
+
+// handleFunc registers a route and records its path for contract checks
+func (r *Router) handleFunc(path string, handlerFunc func(http.ResponseWriter, *http.Request)) {
+	r.paths = append(r.paths, path)
+	r.mux.HandleFunc(path, handlerFunc)
+}
+
+// Paths returns every registered route path in registration order
+func (r *Router) Paths() []string {
+	return append([]string{}, r.paths...)
+}
+
+// Handler returns the HTTP handler wrapped in the router middlewares
 func (r *Router) Handler() http.Handler {
-	return r.mux
+	return middleware.Chain(r.middlewares...)(r.mux)
 }
\ No newline at end of file
@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+	"time"
+)
+
+// TimeoutConfig sets how long routes may run before they are answered with 504
+type TimeoutConfig struct {
+	Read   time.Duration            // GET requests
+	Write  time.Duration            // every other method
+	Routes map[string]time.Duration // per-path overrides, 0 disables the timeout
+}
+
+// DefaultTimeouts keeps reads short and gives batch and admin routes longer
+func DefaultTimeouts() TimeoutConfig {
+	return TimeoutConfig{
+		Read:  5 * time.Second,
+		Write: 10 * time.Second,
+		Routes: map[string]time.Duration{
+			"/api/widgets/evaluate":          30 * time.Second,
+			"/api/admin/projections/rebuild": 2 * time.Minute,
+			"/api/admin/backup":              10 * time.Minute, // streams the whole event store
+			"/api/presence/ws":               0,                // long-lived WebSocket
+		},
+	}
+}
+
+// timeoutFor returns the timeout of a route and method
+func (c TimeoutConfig) timeoutFor(path, method string) time.Duration {
+	if timeout, ok := c.Routes[path]; ok {
+		return timeout
+	}
+	if method == http.MethodGet {
+		return c.Read
+	}
+	return c.Write
+}
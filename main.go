@@ -1,27 +1,371 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/presence"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/billing"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+	"github.com/miladev95/ddd-task/infrastructure/integration"
+	"github.com/miladev95/ddd-task/infrastructure/messaging"
+	"github.com/miladev95/ddd-task/infrastructure/repository"
+	"github.com/miladev95/ddd-task/infrastructure/scheduler"
 	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
 	"github.com/miladev95/ddd-task/shared/di"
+
+	_ "github.com/lib/pq"
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 func main() {
-	// Initialize DI container
-	container := di.NewContainer()
+	// ID_FORMAT chooses how identifiers are validated and generated: ANY (the default),
+	// UUID for version 4 UUIDs, or PREFIXED for typed ones such as tsk_<uuid> and prj_<uuid>
+	if configured := os.Getenv("ID_FORMAT"); configured != "" {
+		format, err := value.NewIDFormat(strings.ToUpper(configured))
+		if err != nil {
+			log.Fatalf("ID_FORMAT: %v", err)
+		}
+		value.SetIDFormat(format)
+	}
+
+	// Initialize DI container. TASK_MEMORY_CAP bounds how many tasks the in-memory
+	// backend keeps resident; finished tasks beyond it are spilled to TASK_SPILL_FILE.
+	repos := di.InMemoryRepositories()
+
+	// SQLITE_PATH keeps everything in a SQLite database file instead of memory, so a single
+	// binary serves a small team with durable storage; the tables are created on first start
+	sqlitePath := os.Getenv("SQLITE_PATH")
+	if sqlitePath != "" {
+		db, err := repository.OpenSQLite(sqlitePath)
+		if err != nil {
+			log.Fatalf("SQLite: %v", err)
+		}
+		defer db.Close()
+
+		repos, err = di.SQLiteRepositories(db)
+		if err != nil {
+			log.Fatalf("SQLite: %v", err)
+		}
+	}
+
+	if limit := os.Getenv("TASK_MEMORY_CAP"); limit != "" {
+		if sqlitePath != "" {
+			log.Fatalf("TASK_MEMORY_CAP only applies to the in-memory backend, not with SQLITE_PATH")
+		}
+
+		maxResident, err := strconv.Atoi(limit)
+		if err != nil || maxResident <= 0 {
+			log.Fatalf("TASK_MEMORY_CAP must be a positive number of tasks, got %q", limit)
+		}
+
+		spillPath := os.Getenv("TASK_SPILL_FILE")
+		if spillPath == "" {
+			spillPath = filepath.Join(os.TempDir(), "ddd-task-spill.ndjson")
+		}
+		spill, err := repository.OpenTaskSpillFile(spillPath)
+		if err != nil {
+			log.Fatalf("Task archival: %v", err)
+		}
+
+		tasks := repository.NewInMemoryTaskRepository()
+		tasks.EnableArchival(maxResident, spill)
+		repos.Task = tasks
+
+		// Archive least recently used finished tasks in the background
+		go func() {
+			for range time.Tick(time.Minute) {
+				if _, err := tasks.Compact(); err != nil {
+					log.Printf("Task archival: %v", err)
+				}
+			}
+		}()
+	}
+
+	// EVENT_STORE_DSN keeps the event log in PostgreSQL instead of memory, so task history
+	// and projections survive restarts. SQLite keeps its event log in its own database, where
+	// transactions write the events together with the aggregates, so the two do not mix.
+	if dsn := os.Getenv("EVENT_STORE_DSN"); dsn != "" {
+		if sqlitePath != "" {
+			log.Fatalf("EVENT_STORE_DSN cannot be combined with SQLITE_PATH, which keeps the event log in the SQLite database")
+		}
+
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			log.Fatalf("Event store: %v", err)
+		}
+		defer db.Close()
+
+		events := infraEvent.NewPostgresEventStore(db, infraEvent.NewEventSerializer())
+		if err := events.EnsureSchema(); err != nil {
+			log.Fatalf("Event store: %v", err)
+		}
+		repos.Events = events
+	}
+	container := di.NewContainerWithRepositories(repos)
+
+	// SNAPSHOT_DIR saves the in-memory data as JSON files every SNAPSHOT_INTERVAL_MINUTES (5 by
+	// default) and on shutdown, and loads them back on start, so demo data survives restarts
+	var snapshots *repository.SnapshotDirectory
+	if dir := os.Getenv("SNAPSHOT_DIR"); dir != "" {
+		interval := 5 * time.Minute
+		if minutes := os.Getenv("SNAPSHOT_INTERVAL_MINUTES"); minutes != "" {
+			parsed, err := strconv.Atoi(minutes)
+			if err != nil || parsed <= 0 {
+				log.Fatalf("SNAPSHOT_INTERVAL_MINUTES must be a positive number of minutes, got %q", minutes)
+			}
+			interval = time.Duration(parsed) * time.Minute
+		}
+
+		var err error
+		snapshots, err = repository.NewSnapshotDirectory(dir, container.Snapshotters()...)
+		if err != nil {
+			log.Fatalf("Snapshots: %v", err)
+		}
+		loaded, err := snapshots.Load()
+		if err != nil {
+			log.Fatalf("Snapshots: %v", err)
+		}
+		if loaded > 0 {
+			// Projections are kept in memory only, so they are replayed from the loaded events
+			if _, err := container.ProjectionRebuilder.Rebuild(context.Background(), nil); err != nil {
+				log.Fatalf("Snapshots: %v", err)
+			}
+			log.Printf("Loaded %d snapshot files from %s", loaded, dir)
+		}
+
+		container.Scheduler.Add("snapshot", scheduler.Every(interval), func(ctx context.Context, now time.Time) error {
+			return snapshots.Save()
+		})
+	}
+
+	// MAX_OPEN_TASKS_PER_USER caps each user's open tasks; ASSIGNMENT_CAPACITY_MODE
+	// chooses whether going over it is refused (REJECT) or only reported (WARN, the default)
+	if limit := os.Getenv("MAX_OPEN_TASKS_PER_USER"); limit != "" {
+		maxOpenTasks, err := strconv.Atoi(limit)
+		if err != nil || maxOpenTasks < 0 {
+			log.Fatalf("MAX_OPEN_TASKS_PER_USER must be a number of tasks, got %q", limit)
+		}
+
+		mode := service.CapacityModeWarn
+		if configured := os.Getenv("ASSIGNMENT_CAPACITY_MODE"); configured != "" {
+			mode, err = service.NewCapacityMode(strings.ToUpper(configured))
+			if err != nil {
+				log.Fatalf("ASSIGNMENT_CAPACITY_MODE: %v", err)
+			}
+		}
+		container.TaskAssignmentService.SetCapacityLimit(maxOpenTasks, mode)
+	}
+
+	// ASSIGNMENT_ACK_WINDOW_HOURS is how long assignees have to acknowledge a task before
+	// the assignment is escalated (24 by default, 0 never escalates)
+	if hours := os.Getenv("ASSIGNMENT_ACK_WINDOW_HOURS"); hours != "" {
+		window, err := strconv.Atoi(hours)
+		if err != nil || window < 0 {
+			log.Fatalf("ASSIGNMENT_ACK_WINDOW_HOURS must be a number of hours, got %q", hours)
+		}
+		container.TaskAssignmentService.SetAcknowledgementWindow(time.Duration(window) * time.Hour)
+	}
+
+	// OVERDUE_REMINDER_HOURS is how often an overdue task is reported again while it stays
+	// open (24 by default, 0 reports it on every deadline sweep)
+	if hours := os.Getenv("OVERDUE_REMINDER_HOURS"); hours != "" {
+		interval, err := strconv.Atoi(hours)
+		if err != nil || interval < 0 {
+			log.Fatalf("OVERDUE_REMINDER_HOURS must be a number of hours, got %q", hours)
+		}
+		container.DeadlineEnforcementService.SetOverdueReminderInterval(time.Duration(interval) * time.Hour)
+	}
+
+	// TASK_DELETION_MODE keeps deleted tasks aside (SOFT) instead of removing them (HARD, the default)
+	if configured := os.Getenv("TASK_DELETION_MODE"); configured != "" {
+		mode, err := command.NewTaskDeletionMode(strings.ToUpper(configured))
+		if err != nil {
+			log.Fatalf("TASK_DELETION_MODE: %v", err)
+		}
+		container.DeleteTaskCommandHandler.SetDeletionMode(mode)
+	}
+
+	// EVENT_DISPATCH_WORKERS hands event handlers to that many background workers instead of
+	// running them within the request; EVENT_DISPATCH_ATTEMPTS is how often a failing one is tried
+	if workers := os.Getenv("EVENT_DISPATCH_WORKERS"); workers != "" {
+		options := infraEvent.DefaultAsyncOptions()
+		var err error
+		if options.Workers, err = strconv.Atoi(workers); err != nil || options.Workers <= 0 {
+			log.Fatalf("EVENT_DISPATCH_WORKERS must be a positive number of workers, got %q", workers)
+		}
+		if attempts := os.Getenv("EVENT_DISPATCH_ATTEMPTS"); attempts != "" {
+			if options.MaxAttempts, err = strconv.Atoi(attempts); err != nil || options.MaxAttempts <= 0 {
+				log.Fatalf("EVENT_DISPATCH_ATTEMPTS must be a positive number of attempts, got %q", attempts)
+			}
+		}
+		options.OnFailure = func(evt event.DomainEvent, err error) {
+			log.Printf("Event dispatch: %s %s: %v", evt.AggregateType(), evt.AggregateID(), err)
+		}
+		container.EventDispatcher.StartAsync(options)
+	}
+
+	// Stream usage events to a file a billing system can tail
+	if path := os.Getenv("BILLING_USAGE_FILE"); path != "" {
+		usageFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			log.Fatalf("Billing usage file: %v", err)
+		}
+		defer usageFile.Close()
+
+		container.UsageTopic.Subscribe(billing.NDJSONSink(usageFile))
+	}
+
+	// AMQP_URL relays every domain event to the RabbitMQ exchange AMQP_EXCHANGE ("task.events"
+	// by default); consumers' queues dead-letter what they fail to process to "<exchange>.dlx"
+	if amqpURL := os.Getenv("AMQP_URL"); amqpURL != "" {
+		conn, err := amqp.Dial(amqpURL)
+		if err != nil {
+			log.Fatalf("AMQP: %v", err)
+		}
+		defer conn.Close()
+
+		channel, err := conn.Channel()
+		if err != nil {
+			log.Fatalf("AMQP: %v", err)
+		}
+
+		topology := messaging.DefaultAMQPTopology()
+		if exchange := os.Getenv("AMQP_EXCHANGE"); exchange != "" {
+			topology = messaging.AMQPTopology{
+				Exchange:           exchange,
+				DeadLetterExchange: exchange + ".dlx",
+				DeadLetterQueue:    exchange + ".dead",
+			}
+		}
+		if err := topology.Declare(channel); err != nil {
+			log.Fatalf("AMQP: %v", err)
+		}
+
+		publisher, err := messaging.NewAMQPPublisher(channel, container.EventSerializer, topology)
+		if err != nil {
+			log.Fatalf("AMQP: %v", err)
+		}
+		relay := infraEvent.NewMeasuredSubscriber(container.EventSubscriber, container.EventMetrics, "amqp_relay")
+		breaker := container.IntegrationHealth.Breaker("amqp", integration.KindMessageBroker)
+		forward := func(evt event.DomainEvent) error { return publisher.Publish(context.Background(), evt) }
+		for _, eventType := range container.EventSerializer.EventTypes() {
+			if err := relay.Subscribe(eventType, breaker.Guard(forward)); err != nil {
+				log.Fatalf("AMQP: %v", err)
+			}
+		}
+	}
+
+	// INBOUND_EMAIL_DOMAIN is the mail domain project inbox addresses are generated at;
+	// INBOUND_EMAIL_SECRET, when set, must be sent by the relay posting mail to the gateway
+	container.InboxAddressService.SetDomain(os.Getenv("INBOUND_EMAIL_DOMAIN"))
+	container.InboxAddressService.SetGatewaySecret(os.Getenv("INBOUND_EMAIL_SECRET"))
+
+	// Send notification digests as they come due
+	go func() {
+		for now := range time.Tick(time.Minute) {
+			container.NotificationDispatcher.FlushDue(now)
+		}
+	}()
+
+	// Escalate assignments nobody acknowledged in time
+	go func() {
+		for range time.Tick(time.Minute) {
+			projects, err := container.ProjectRepository.GetAll(context.Background())
+			if err != nil {
+				continue
+			}
+			for _, project := range projects {
+				container.EscalateUnacknowledgedAssignmentsCommandHandler.Handle(
+					context.Background(),
+					command.EscalateUnacknowledgedAssignmentsCommand{ProjectID: project.ID().Value()})
+			}
+		}
+	}()
+
+	// Run scheduled jobs, such as the deadline sweep
+	container.Scheduler.OnFailure(func(name string, err error) {
+		log.Printf("Scheduled job %s: %v", name, err)
+	})
+	container.Scheduler.Start(context.Background(), scheduler.DefaultTick)
+
+	// Retry the follow-ups to domain events that failed, such as closing a parent task
+	go func() {
+		for range time.Tick(time.Minute) {
+			if _, err := container.ProcessManager.RetryDue(context.Background()); err != nil {
+				log.Printf("Process retries: %v", err)
+			}
+		}
+	}()
+
+	// Drop presence of viewers that stopped sending heartbeats
+	go func() {
+		for range time.Tick(presence.DefaultTTL / 3) {
+			container.PresenceTracker.Sweep()
+		}
+	}()
 
 	// Create and setup HTTP router
 	router := httpServer.NewRouter(container)
 	router.SetupRoutes()
 
+	// Wrap every route in recovery, access logging, when configured CORS, and response shaping
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+	router.Use(middleware.Recovery(logger), middleware.Logging(logger))
+	if origins := os.Getenv("CORS_ALLOWED_ORIGINS"); origins != "" {
+		router.Use(middleware.CORS(strings.Split(origins, ",")))
+	}
+
+	// API_VERSION_PROFILES maps API-Version headers to response profiles, e.g. "2=envelope,3=camel";
+	// clients sending neither that header nor an Accept profile keep the legacy bodies
+	versionProfiles, err := middleware.ParseVersionProfiles(os.Getenv("API_VERSION_PROFILES"))
+	if err != nil {
+		log.Fatalf("API_VERSION_PROFILES: %v", err)
+	}
+	router.Use(middleware.ShapeResponses(versionProfiles))
+
 	// Start HTTP server
 	port := ":8080"
 	fmt.Printf("Starting Task Management API server on %s\n", port)
 
-	if err := http.ListenAndServe(port, router.Handler()); err != nil {
-		log.Fatalf("Server error: %v", err)
+	server := &http.Server{Addr: port, Handler: router.Handler()}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Server error: %v", err)
+		}
+	}()
+
+	// On SIGINT or SIGTERM finish the requests in progress, then deliver the queued events
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Shutdown: %v", err)
+	}
+	if err := container.EventDispatcher.Drain(ctx); err != nil {
+		log.Printf("Shutdown: %v", err)
+	}
+	if snapshots != nil {
+		if err := snapshots.Save(); err != nil {
+			log.Printf("Shutdown: %v", err)
+		}
 	}
 }
\ No newline at end of file
@@ -0,0 +1,314 @@
+package taskmanagement
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// CommandBus routes commands from the application/command package to their handlers
+type CommandBus struct {
+	container *di.Container
+}
+
+// NewCommandBus creates a new CommandBus
+func NewCommandBus(container *di.Container) *CommandBus {
+	return &CommandBus{
+		container: container,
+	}
+}
+
+// Dispatch executes a command and returns its handler's result,
+// e.g. a *command.CreateTaskResult for a command.CreateTaskCommand
+func (b *CommandBus) Dispatch(ctx context.Context, cmd interface{}) (interface{}, error) {
+	c := b.container
+
+	switch cmd := cmd.(type) {
+	case command.CreateTaskCommand:
+		return c.CreateTaskCommandHandler.Handle(ctx, cmd)
+	case command.AssignTaskCommand:
+		return c.AssignTaskCommandHandler.Handle(ctx, cmd)
+	case command.AcknowledgeTaskCommand:
+		return c.AcknowledgeTaskCommandHandler.Handle(ctx, cmd)
+	case command.UpdateTaskStatusCommand:
+		return c.UpdateTaskStatusCommandHandler.Handle(ctx, cmd)
+	case command.CompareAndSetTaskStatusCommand:
+		return c.CompareAndSetTaskStatusCommandHandler.Handle(ctx, cmd)
+	case command.AddCommentCommand:
+		return c.AddCommentCommandHandler.Handle(ctx, cmd)
+	case command.LinkTasksCommand:
+		return c.LinkTasksCommandHandler.Handle(ctx, cmd)
+	case command.UnlinkTasksCommand:
+		return c.UnlinkTasksCommandHandler.Handle(ctx, cmd)
+	case command.VoteTaskCommand:
+		return c.VoteTaskCommandHandler.Handle(ctx, cmd)
+	case command.ApproveTaskCommand:
+		return c.ApproveTaskCommandHandler.Handle(ctx, cmd)
+	case command.RejectTaskCommand:
+		return c.RejectTaskCommandHandler.Handle(ctx, cmd)
+	case command.EditLockCommand:
+		return c.EditLockCommandHandler.Handle(ctx, cmd)
+	case command.UpdateTaskDescriptionCommand:
+		return c.UpdateTaskDescriptionCommandHandler.Handle(ctx, cmd)
+	case command.ChangeTaskDeadlineCommand:
+		return c.ChangeTaskDeadlineCommandHandler.Handle(ctx, cmd)
+	case command.RecordViewCommand:
+		return c.RecordViewCommandHandler.Handle(ctx, cmd)
+	case command.SetProjectSLOCommand:
+		return c.SetProjectSLOCommandHandler.Handle(ctx, cmd)
+	case command.SetProjectSettingsCommand:
+		return c.SetProjectSettingsCommandHandler.Handle(ctx, cmd)
+	case command.SetProjectBudgetCommand:
+		return c.SetProjectBudgetCommandHandler.Handle(ctx, cmd)
+	case command.RecordTaskCostCommand:
+		return c.RecordTaskCostCommandHandler.Handle(ctx, cmd)
+	case command.EvaluateBudgetCommand:
+		return c.EvaluateBudgetCommandHandler.Handle(ctx, cmd)
+	case command.RegisterUserCommand:
+		return c.RegisterUserCommandHandler.Handle(ctx, cmd)
+	case command.SignUpTenantCommand:
+		return c.SignUpTenantCommandHandler.Handle(ctx, cmd)
+	case command.ChangePasswordCommand:
+		return c.ChangePasswordCommandHandler.Handle(ctx, cmd)
+	case command.LoginCommand:
+		return c.LoginCommandHandler.Handle(ctx, cmd)
+	case command.LogoutCommand:
+		return c.LogoutCommandHandler.Handle(ctx, cmd)
+	case command.IssueTokenCommand:
+		return c.IssueTokenCommandHandler.Handle(ctx, cmd)
+	case command.RefreshTokenCommand:
+		return c.RefreshTokenCommandHandler.Handle(ctx, cmd)
+	case command.SendVerificationEmailCommand:
+		return c.SendVerificationEmailCommandHandler.Handle(ctx, cmd)
+	case command.VerifyEmailCommand:
+		return c.VerifyEmailCommandHandler.Handle(ctx, cmd)
+	case command.CreateTeamCommand:
+		return c.CreateTeamCommandHandler.Handle(ctx, cmd)
+	case command.AddTeamMemberCommand:
+		return c.AddTeamMemberCommandHandler.Handle(ctx, cmd)
+	case command.RemoveTeamMemberCommand:
+		return c.RemoveTeamMemberCommandHandler.Handle(ctx, cmd)
+	case command.ChangeTeamLeadCommand:
+		return c.ChangeTeamLeadCommandHandler.Handle(ctx, cmd)
+	case command.CreateOrganizationCommand:
+		return c.CreateOrganizationCommandHandler.Handle(ctx, cmd)
+	case command.AddOrganizationMemberCommand:
+		return c.AddOrganizationMemberCommandHandler.Handle(ctx, cmd)
+	case command.SetOrganizationQuotaCommand:
+		return c.SetOrganizationQuotaCommandHandler.Handle(ctx, cmd)
+	case command.SetOrganizationWorkingHoursCommand:
+		return c.SetOrganizationWorkingHoursCommandHandler.Handle(ctx, cmd)
+	case command.CreateHolidayCalendarCommand:
+		return c.CreateHolidayCalendarCommandHandler.Handle(ctx, cmd)
+	case command.UpdateHolidayCalendarCommand:
+		return c.UpdateHolidayCalendarCommandHandler.Handle(ctx, cmd)
+	case command.DeleteHolidayCalendarCommand:
+		return c.DeleteHolidayCalendarCommandHandler.Handle(ctx, cmd)
+	case command.SetProjectPrioritySchemeCommand:
+		return c.SetProjectPrioritySchemeCommandHandler.Handle(ctx, cmd)
+	case command.SetProjectHolidayCalendarCommand:
+		return c.SetProjectHolidayCalendarCommandHandler.Handle(ctx, cmd)
+	case command.SetUserLocaleCommand:
+		return c.SetUserLocaleCommandHandler.Handle(ctx, cmd)
+	case command.SetBoardSortCommand:
+		return c.SetBoardSortCommandHandler.Handle(ctx, cmd)
+	case command.SetUserOutOfOfficeCommand:
+		return c.SetUserOutOfOfficeCommandHandler.Handle(ctx, cmd)
+	case command.SetUserManagerCommand:
+		return c.SetUserManagerCommandHandler.Handle(ctx, cmd)
+	case command.AddProjectInboxAddressCommand:
+		return c.AddProjectInboxAddressCommandHandler.Handle(ctx, cmd)
+	case command.RevokeProjectInboxAddressCommand:
+		return c.RevokeProjectInboxAddressCommandHandler.Handle(ctx, cmd)
+	case command.ReceiveInboundEmailCommand:
+		return c.ReceiveInboundEmailCommandHandler.Handle(ctx, cmd)
+	case command.AssignTaskToTeamCommand:
+		return c.AssignTaskToTeamCommandHandler.Handle(ctx, cmd)
+	case command.AddAttachmentCommand:
+		return c.AddAttachmentCommandHandler.Handle(ctx, cmd)
+	case command.SetProjectAccessCommand:
+		return c.SetProjectAccessCommandHandler.Handle(ctx, cmd)
+	case command.AssignProjectRoleCommand:
+		return c.AssignProjectRoleCommandHandler.Handle(ctx, cmd)
+	case command.ChangeProjectWorkflowCommand:
+		return c.ChangeProjectWorkflowCommandHandler.Handle(ctx, cmd)
+	case command.MigrateProjectWorkflowCommand:
+		return c.MigrateProjectWorkflowCommandHandler.Handle(ctx, cmd)
+	case command.PlanProjectWorkflowMigrationCommand:
+		return c.PlanProjectWorkflowMigrationCommandHandler.Handle(ctx, cmd)
+	case command.ApplyProjectWorkflowMigrationCommand:
+		return c.ApplyProjectWorkflowMigrationCommandHandler.Handle(ctx, cmd)
+	case command.RollBackProjectWorkflowMigrationCommand:
+		return c.RollBackProjectWorkflowMigrationCommandHandler.Handle(ctx, cmd)
+	case command.CreateWorkflowCommand:
+		return c.CreateWorkflowCommandHandler.Handle(ctx, cmd)
+	case command.UpdateWorkflowCommand:
+		return c.UpdateWorkflowCommandHandler.Handle(ctx, cmd)
+	case command.EditWorkflowStatusesCommand:
+		return c.EditWorkflowStatusesCommandHandler.Handle(ctx, cmd)
+	case command.EditWorkflowTransitionsCommand:
+		return c.EditWorkflowTransitionsCommandHandler.Handle(ctx, cmd)
+	case command.SetTransitionRulesCommand:
+		return c.SetTransitionRulesCommandHandler.Handle(ctx, cmd)
+	case command.SetWorkflowApprovalStepCommand:
+		return c.SetWorkflowApprovalStepCommandHandler.Handle(ctx, cmd)
+	case command.DeleteTaskCommand:
+		return c.DeleteTaskCommandHandler.Handle(ctx, cmd)
+	case command.ReassignAllTasksCommand:
+		return c.ReassignAllTasksCommandHandler.Handle(ctx, cmd)
+	case command.DeactivateUserCommand:
+		return c.DeactivateUserCommandHandler.Handle(ctx, cmd)
+	case command.ActivateUserCommand:
+		return c.ActivateUserCommandHandler.Handle(ctx, cmd)
+	case command.ChangeEmailCommand:
+		return c.ChangeEmailCommandHandler.Handle(ctx, cmd)
+	case command.UpdateUserCommand:
+		return c.UpdateUserCommandHandler.Handle(ctx, cmd)
+	case command.SetUserWorkingHoursCommand:
+		return c.SetUserWorkingHoursCommandHandler.Handle(ctx, cmd)
+	case command.SetNotificationRoutesCommand:
+		return c.SetNotificationRoutesCommandHandler.Handle(ctx, cmd)
+	case command.UpdateProjectCommand:
+		return c.UpdateProjectCommandHandler.Handle(ctx, cmd)
+	case command.ArchiveProjectCommand:
+		return c.ArchiveProjectCommandHandler.Handle(ctx, cmd)
+	case command.DeleteProjectCommand:
+		return c.DeleteProjectCommandHandler.Handle(ctx, cmd)
+	case command.CreateMilestoneCommand:
+		return c.CreateMilestoneCommandHandler.Handle(ctx, cmd)
+	case command.UpdateMilestoneCommand:
+		return c.UpdateMilestoneCommandHandler.Handle(ctx, cmd)
+	case command.DeleteMilestoneCommand:
+		return c.DeleteMilestoneCommandHandler.Handle(ctx, cmd)
+	case command.EvaluateMilestonesCommand:
+		return c.EvaluateMilestonesCommandHandler.Handle(ctx, cmd)
+	case command.CheckOverdueTasksCommand:
+		return c.CheckOverdueTasksCommandHandler.Handle(ctx, cmd)
+	case command.SweepOverdueTasksCommand:
+		return c.SweepOverdueTasksCommandHandler.Handle(ctx, cmd)
+	case command.EscalateUnacknowledgedAssignmentsCommand:
+		return c.EscalateUnacknowledgedAssignmentsCommandHandler.Handle(ctx, cmd)
+	case command.CreateWidgetCommand:
+		return c.CreateWidgetCommandHandler.Handle(ctx, cmd)
+	case command.UpdateWidgetCommand:
+		return c.UpdateWidgetCommandHandler.Handle(ctx, cmd)
+	case command.DeleteWidgetCommand:
+		return c.DeleteWidgetCommandHandler.Handle(ctx, cmd)
+	case command.CreateSprintCommand:
+		return c.CreateSprintCommandHandler.Handle(ctx, cmd)
+	case command.AddTaskToSprintCommand:
+		return c.AddTaskToSprintCommandHandler.Handle(ctx, cmd)
+	case command.RemoveTaskFromSprintCommand:
+		return c.RemoveTaskFromSprintCommandHandler.Handle(ctx, cmd)
+	case command.StartSprintCommand:
+		return c.StartSprintCommandHandler.Handle(ctx, cmd)
+	case command.CompleteSprintCommand:
+		return c.CompleteSprintCommandHandler.Handle(ctx, cmd)
+	default:
+		return nil, fmt.Errorf("unsupported command: %T", cmd)
+	}
+}
+
+// QueryBus routes queries from the application/query package to their handlers
+type QueryBus struct {
+	container *di.Container
+}
+
+// NewQueryBus creates a new QueryBus
+func NewQueryBus(container *di.Container) *QueryBus {
+	return &QueryBus{
+		container: container,
+	}
+}
+
+// Ask answers a query and returns its handler's result,
+// e.g. a *dto.TaskDTO for a query.GetTaskQuery
+func (b *QueryBus) Ask(ctx context.Context, q interface{}) (interface{}, error) {
+	c := b.container
+
+	switch q := q.(type) {
+	case query.GetTaskQuery:
+		return c.GetTaskQueryHandler.Handle(ctx, q)
+	case query.GetProjectQuery:
+		return c.GetProjectQueryHandler.Handle(ctx, q)
+	case query.GetUserQuery:
+		return c.GetUserQueryHandler.Handle(ctx, q)
+	case query.GetWorkflowQuery:
+		return c.GetWorkflowQueryHandler.Handle(ctx, q)
+	case query.ListProjectsQuery:
+		return c.ListProjectsQueryHandler.Handle(ctx, q)
+	case query.ListUsersQuery:
+		return c.ListUsersQueryHandler.Handle(ctx, q)
+	case query.ListWorkflowsQuery:
+		return c.ListWorkflowsQueryHandler.Handle(ctx, q)
+	case query.GetTaskHistoryQuery:
+		return c.GetTaskHistoryQueryHandler.Handle(ctx, q)
+	case query.ListEventsQuery:
+		return c.ListEventsQueryHandler.Handle(ctx, q)
+	case query.ListTasksByProjectQuery:
+		return c.ListTasksByProjectQueryHandler.Handle(ctx, q)
+	case query.ListProjectActivityQuery:
+		return c.ListProjectActivityQueryHandler.Handle(ctx, q)
+	case query.ListOverdueTasksQuery:
+		return c.ListOverdueTasksQueryHandler.Handle(ctx, q)
+	case query.ListTasksDueWithinQuery:
+		return c.ListTasksDueWithinQueryHandler.Handle(ctx, q)
+	case query.FindDuplicateTasksQuery:
+		return c.FindDuplicateTasksQueryHandler.Handle(ctx, q)
+	case query.GetProjectStatsQuery:
+		return c.GetProjectStatsQueryHandler.Handle(ctx, q)
+	case query.GetNotificationRoutesQuery:
+		return c.GetNotificationRoutesQueryHandler.Handle(ctx, q)
+	case query.GetProjectSettingsQuery:
+		return c.GetProjectSettingsQueryHandler.Handle(ctx, q)
+	case query.GetProjectWorkflowMigrationQuery:
+		return c.GetProjectWorkflowMigrationQueryHandler.Handle(ctx, q)
+	case query.GetProjectBoardQuery:
+		return c.GetProjectBoardQueryHandler.Handle(ctx, q)
+	case query.SimulateWorkflowQuery:
+		return c.SimulateWorkflowQueryHandler.Handle(ctx, q)
+	case query.GetProjectBudgetQuery:
+		return c.GetProjectBudgetQueryHandler.Handle(ctx, q)
+	case query.SearchWorkspaceQuery:
+		return c.SearchWorkspaceQueryHandler.Handle(ctx, q)
+	case query.SearchTasksQuery:
+		return c.SearchTasksQueryHandler.Handle(ctx, q)
+	case query.ListMilestonesQuery:
+		return c.ListMilestonesQueryHandler.Handle(ctx, q)
+	case query.GetWorkloadHeatmapQuery:
+		return c.GetWorkloadHeatmapQueryHandler.Handle(ctx, q)
+	case query.ListUnacknowledgedAssignmentsQuery:
+		return c.ListUnacknowledgedAssignmentsQueryHandler.Handle(ctx, q)
+	case query.ListWidgetsQuery:
+		return c.ListWidgetsQueryHandler.Handle(ctx, q)
+	case query.EvaluateDashboardQuery:
+		return c.EvaluateDashboardQueryHandler.Handle(ctx, q)
+	case query.SearchSuggestionsQuery:
+		return c.SearchSuggestionsQueryHandler.Handle(ctx, q)
+	case query.GetRecentlyViewedQuery:
+		return c.GetRecentlyViewedQueryHandler.Handle(ctx, q)
+	case query.ListSprintsQuery:
+		return c.ListSprintsQueryHandler.Handle(ctx, q)
+	case query.ListSprintTasksQuery:
+		return c.ListSprintTasksQueryHandler.Handle(ctx, q)
+	case query.GetTeamQuery:
+		return c.GetTeamQueryHandler.Handle(ctx, q)
+	case query.ListTeamTasksQuery:
+		return c.ListTeamTasksQueryHandler.Handle(ctx, q)
+	case query.GetOrganizationUsageQuery:
+		return c.GetOrganizationUsageQueryHandler.Handle(ctx, q)
+	case query.GetOrganizationDirectoryQuery:
+		return c.GetOrganizationDirectoryQueryHandler.Handle(ctx, q)
+	case query.VerifyIntegrityQuery:
+		return c.VerifyIntegrityQueryHandler.Handle(ctx, q)
+	case query.ListHolidayCalendarsQuery:
+		return c.ListHolidayCalendarsQueryHandler.Handle(ctx, q)
+	case query.GetHolidayCalendarQuery:
+		return c.GetHolidayCalendarQueryHandler.Handle(ctx, q)
+	default:
+		return nil, fmt.Errorf("unsupported query: %T", q)
+	}
+}
@@ -0,0 +1,76 @@
+// Package taskmanagement embeds the task engine in another Go service.
+// It wires the same domain, application and event layers the HTTP server uses,
+// without starting the server.
+package taskmanagement
+
+import (
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// Repositories groups the persistence implementations the engine runs on
+type Repositories = di.Repositories
+
+// Engine is an embeddable task engine
+type Engine struct {
+	container *di.Container
+	commands  *CommandBus
+	queries   *QueryBus
+}
+
+// Option configures an Engine
+type Option func(*options)
+
+type options struct {
+	repositories *Repositories
+}
+
+// WithRepositories runs the engine on the given repositories instead of in-memory ones,
+// for example repositories backed by a database shared with other services
+func WithRepositories(repos Repositories) Option {
+	return func(o *options) {
+		o.repositories = &repos
+	}
+}
+
+// New creates an Engine. Without options it keeps all state in memory.
+func New(opts ...Option) *Engine {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var container *di.Container
+	if o.repositories != nil {
+		container = di.NewContainerWithRepositories(*o.repositories)
+	} else {
+		container = di.NewContainer()
+	}
+
+	return &Engine{
+		container: container,
+		commands:  NewCommandBus(container),
+		queries:   NewQueryBus(container),
+	}
+}
+
+// Commands returns the bus that executes commands
+func (e *Engine) Commands() *CommandBus {
+	return e.commands
+}
+
+// Queries returns the bus that answers queries
+func (e *Engine) Queries() *QueryBus {
+	return e.queries
+}
+
+// Subscribe registers a handler for domain events of the given type
+func (e *Engine) Subscribe(eventType string, handler func(event.DomainEvent) error) error {
+	return e.container.EventSubscriber.Subscribe(eventType, handler)
+}
+
+// Container exposes the underlying dependencies, for seeding data through repositories
+// or reaching services the buses do not cover
+func (e *Engine) Container() *di.Container {
+	return e.container
+}
@@ -1,13 +1,30 @@
 package di
 
 import (
+	"context"
+	"database/sql"
+	"os"
+	"time"
+
 	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/presence"
+	"github.com/miladev95/ddd-task/application/process"
 	"github.com/miladev95/ddd-task/application/query"
 	"github.com/miladev95/ddd-task/domain"
 	"github.com/miladev95/ddd-task/domain/event"
 	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/infrastructure/activity"
+	"github.com/miladev95/ddd-task/infrastructure/auth"
+	"github.com/miladev95/ddd-task/infrastructure/billing"
 	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+	"github.com/miladev95/ddd-task/infrastructure/idempotency"
+	"github.com/miladev95/ddd-task/infrastructure/integration"
+	"github.com/miladev95/ddd-task/infrastructure/notification"
+	"github.com/miladev95/ddd-task/infrastructure/quota"
 	"github.com/miladev95/ddd-task/infrastructure/repository"
+	"github.com/miladev95/ddd-task/infrastructure/scheduler"
+	"github.com/miladev95/ddd-task/infrastructure/search"
+	"github.com/miladev95/ddd-task/infrastructure/translation"
 )
 
 // Container holds all application dependencies
@@ -17,42 +34,402 @@ type Container struct {
 	ProjectRepository   domain.ProjectRepository
 	UserRepository      domain.UserRepository
 	WorkflowRepository  domain.WorkflowRepository
+	WidgetRepository    domain.WidgetRepository
+	RecentViewRepository domain.RecentViewRepository
+	SprintRepository    domain.SprintRepository
+	TeamRepository      domain.TeamRepository
+	OrganizationRepository domain.OrganizationRepository
+	HolidayCalendarRepository domain.HolidayCalendarRepository
 
 	// Event
 	EventPublisher      event.EventPublisher
+	UnitOfWork          domain.UnitOfWorkFactory
+	EventSubscriber     event.EventSubscriber
+	EventDispatcher     *infraEvent.SimpleEventPublisher
+	EventStore          event.EventStore
+	EventSerializer     *infraEvent.EventSerializer
+	EventMetrics        *infraEvent.EventMetrics
+	EventExporter       *infraEvent.NDJSONExporter
 	NotificationService service.NotificationService
+	NotificationDispatcher *notification.Dispatcher
+	IntegrationHealth      *integration.Monitor
+	CommentTranslator      *translation.CommentTranslator
+
+	// Read models
+	SuggestionIndex *search.PrefixIndex
+	WorkspaceIndex  *search.WorkspaceIndex
+	ActivityFeed    *activity.Feed
+	ProjectionRebuilder *infraEvent.ProjectionRebuilder
+
+	// Realtime
+	PresenceTracker *presence.Tracker
+	PresenceBroadcaster *presence.Broadcaster
+
+	// Auth
+	PasswordHasher     *auth.PasswordHasher
+	SessionStore       *auth.SessionStore
+	TokenIssuer        *auth.TokenIssuer
+	VerificationTokens *auth.VerificationTokens
+	Callers            *auth.Callers
+
+	// Quotas and billing
+	APICallLimiter *quota.APICallLimiter
+	UsageTopic     *billing.UsageTopic
+	UsageEmitter   *billing.UsageEmitter
+
+	// Results of POST requests by idempotency key, for safe retries
+	IdempotencyStore *idempotency.Store
+
+	// Jobs run on a schedule, such as the deadline sweep
+	Scheduler *scheduler.Scheduler
+
+	// Process managers: follow-up commands to domain events, retried when they fail
+	ProcessStates  process.StateStore
+	ProcessManager *process.Manager
 
 	// Domain Services
 	TaskAssignmentService    *service.TaskAssignmentService
 	StatusTransitionService  *service.StatusTransitionService
 	DeadlineEnforcementService *service.DeadlineEnforcementService
+	SLICalculationService    *service.SLICalculationService
+	TaskLinkService          *service.TaskLinkService
+	DuplicateDetectionService *service.DuplicateDetectionService
+	WorkflowSimulationService *service.WorkflowSimulationService
+	MilestoneProgressService  *service.MilestoneProgressService
+	BudgetService             *service.BudgetService
+	QuotaEnforcementService   *service.QuotaEnforcementService
+	WorkingHoursService       *service.WorkingHoursService
+	ReportingLineService      *service.ReportingLineService
+	HolidayCalendarService    *service.HolidayCalendarService
+	InboxAddressService       *service.InboxAddressService
+	AuthorizationPolicy       service.AuthorizationPolicy
+	Authorizer                *command.Authorizer
 
 	// Command Handlers
 	CreateTaskCommandHandler       *command.CreateTaskCommandHandler
 	AssignTaskCommandHandler       *command.AssignTaskCommandHandler
+	AcknowledgeTaskCommandHandler  *command.AcknowledgeTaskCommandHandler
 	UpdateTaskStatusCommandHandler *command.UpdateTaskStatusCommandHandler
+	AddCommentCommandHandler       *command.AddCommentCommandHandler
+	SetProjectSLOCommandHandler    *command.SetProjectSLOCommandHandler
+	SetProjectSettingsCommandHandler *command.SetProjectSettingsCommandHandler
+	LinkTasksCommandHandler        *command.LinkTasksCommandHandler
+	UnlinkTasksCommandHandler      *command.UnlinkTasksCommandHandler
+	CreateWidgetCommandHandler     *command.CreateWidgetCommandHandler
+	UpdateWidgetCommandHandler     *command.UpdateWidgetCommandHandler
+	DeleteWidgetCommandHandler     *command.DeleteWidgetCommandHandler
+	VoteTaskCommandHandler         *command.VoteTaskCommandHandler
+	ApproveTaskCommandHandler      *command.ApproveTaskCommandHandler
+	RejectTaskCommandHandler       *command.RejectTaskCommandHandler
+	EditLockCommandHandler         *command.EditLockCommandHandler
+	UpdateTaskDescriptionCommandHandler *command.UpdateTaskDescriptionCommandHandler
+	ChangeTaskDeadlineCommandHandler *command.ChangeTaskDeadlineCommandHandler
+	RecordViewCommandHandler       *command.RecordViewCommandHandler
+	CompareAndSetTaskStatusCommandHandler *command.CompareAndSetTaskStatusCommandHandler
+	CreateSprintCommandHandler     *command.CreateSprintCommandHandler
+	AddTaskToSprintCommandHandler  *command.AddTaskToSprintCommandHandler
+	RemoveTaskFromSprintCommandHandler *command.RemoveTaskFromSprintCommandHandler
+	StartSprintCommandHandler      *command.StartSprintCommandHandler
+	CompleteSprintCommandHandler   *command.CompleteSprintCommandHandler
+	UpdateProjectCommandHandler    *command.UpdateProjectCommandHandler
+	ArchiveProjectCommandHandler   *command.ArchiveProjectCommandHandler
+	DeleteProjectCommandHandler    *command.DeleteProjectCommandHandler
+	SetNotificationRoutesCommandHandler *command.SetNotificationRoutesCommandHandler
+	CreateMilestoneCommandHandler  *command.CreateMilestoneCommandHandler
+	UpdateMilestoneCommandHandler  *command.UpdateMilestoneCommandHandler
+	DeleteMilestoneCommandHandler  *command.DeleteMilestoneCommandHandler
+	EvaluateMilestonesCommandHandler *command.EvaluateMilestonesCommandHandler
+	UpdateProjectProgressCommandHandler *command.UpdateProjectProgressCommandHandler
+	CheckOverdueTasksCommandHandler *command.CheckOverdueTasksCommandHandler
+	SweepOverdueTasksCommandHandler *command.SweepOverdueTasksCommandHandler
+	EscalateUnacknowledgedAssignmentsCommandHandler *command.EscalateUnacknowledgedAssignmentsCommandHandler
+	SetProjectBudgetCommandHandler *command.SetProjectBudgetCommandHandler
+	RecordTaskCostCommandHandler   *command.RecordTaskCostCommandHandler
+	EvaluateBudgetCommandHandler   *command.EvaluateBudgetCommandHandler
+	RegisterUserCommandHandler     *command.RegisterUserCommandHandler
+	SignUpTenantCommandHandler     *command.SignUpTenantCommandHandler
+	ChangePasswordCommandHandler   *command.ChangePasswordCommandHandler
+	LoginCommandHandler            *command.LoginCommandHandler
+	LogoutCommandHandler           *command.LogoutCommandHandler
+	IssueTokenCommandHandler       *command.IssueTokenCommandHandler
+	RefreshTokenCommandHandler     *command.RefreshTokenCommandHandler
+	AddAttachmentCommandHandler    *command.AddAttachmentCommandHandler
+	SetProjectAccessCommandHandler *command.SetProjectAccessCommandHandler
+	AssignProjectRoleCommandHandler     *command.AssignProjectRoleCommandHandler
+	ChangeProjectWorkflowCommandHandler *command.ChangeProjectWorkflowCommandHandler
+	MigrateProjectWorkflowCommandHandler *command.MigrateProjectWorkflowCommandHandler
+	PlanProjectWorkflowMigrationCommandHandler     *command.PlanProjectWorkflowMigrationCommandHandler
+	ApplyProjectWorkflowMigrationCommandHandler    *command.ApplyProjectWorkflowMigrationCommandHandler
+	RollBackProjectWorkflowMigrationCommandHandler *command.RollBackProjectWorkflowMigrationCommandHandler
+	CreateWorkflowCommandHandler        *command.CreateWorkflowCommandHandler
+	UpdateWorkflowCommandHandler        *command.UpdateWorkflowCommandHandler
+	EditWorkflowStatusesCommandHandler  *command.EditWorkflowStatusesCommandHandler
+	EditWorkflowTransitionsCommandHandler *command.EditWorkflowTransitionsCommandHandler
+	SetTransitionRulesCommandHandler    *command.SetTransitionRulesCommandHandler
+	SetWorkflowApprovalStepCommandHandler *command.SetWorkflowApprovalStepCommandHandler
+	DeleteTaskCommandHandler            *command.DeleteTaskCommandHandler
+	ReassignAllTasksCommandHandler      *command.ReassignAllTasksCommandHandler
+	DeactivateUserCommandHandler        *command.DeactivateUserCommandHandler
+	ActivateUserCommandHandler          *command.ActivateUserCommandHandler
+	ChangeEmailCommandHandler           *command.ChangeEmailCommandHandler
+	UpdateUserCommandHandler            *command.UpdateUserCommandHandler
+	SetUserWorkingHoursCommandHandler   *command.SetUserWorkingHoursCommandHandler
+	SendVerificationEmailCommandHandler *command.SendVerificationEmailCommandHandler
+	VerifyEmailCommandHandler           *command.VerifyEmailCommandHandler
+	CreateTeamCommandHandler            *command.CreateTeamCommandHandler
+	CreateOrganizationCommandHandler    *command.CreateOrganizationCommandHandler
+	AddOrganizationMemberCommandHandler *command.AddOrganizationMemberCommandHandler
+	SetOrganizationQuotaCommandHandler  *command.SetOrganizationQuotaCommandHandler
+	SetOrganizationWorkingHoursCommandHandler *command.SetOrganizationWorkingHoursCommandHandler
+	CreateHolidayCalendarCommandHandler *command.CreateHolidayCalendarCommandHandler
+	UpdateHolidayCalendarCommandHandler *command.UpdateHolidayCalendarCommandHandler
+	DeleteHolidayCalendarCommandHandler *command.DeleteHolidayCalendarCommandHandler
+	SetProjectHolidayCalendarCommandHandler *command.SetProjectHolidayCalendarCommandHandler
+	SetProjectPrioritySchemeCommandHandler  *command.SetProjectPrioritySchemeCommandHandler
+	AddProjectInboxAddressCommandHandler    *command.AddProjectInboxAddressCommandHandler
+	SetUserLocaleCommandHandler             *command.SetUserLocaleCommandHandler
+	SetBoardSortCommandHandler              *command.SetBoardSortCommandHandler
+	SetUserOutOfOfficeCommandHandler        *command.SetUserOutOfOfficeCommandHandler
+	SetUserManagerCommandHandler            *command.SetUserManagerCommandHandler
+	RevokeProjectInboxAddressCommandHandler *command.RevokeProjectInboxAddressCommandHandler
+	ReceiveInboundEmailCommandHandler       *command.ReceiveInboundEmailCommandHandler
+	AddTeamMemberCommandHandler         *command.AddTeamMemberCommandHandler
+	RemoveTeamMemberCommandHandler      *command.RemoveTeamMemberCommandHandler
+	ChangeTeamLeadCommandHandler        *command.ChangeTeamLeadCommandHandler
+	AssignTaskToTeamCommandHandler      *command.AssignTaskToTeamCommandHandler
 
 	// Query Handlers
 	GetTaskQueryHandler               *query.GetTaskQueryHandler
+	GetProjectQueryHandler            *query.GetProjectQueryHandler
+	GetUserQueryHandler               *query.GetUserQueryHandler
+	GetWorkflowQueryHandler           *query.GetWorkflowQueryHandler
+	ListProjectsQueryHandler          *query.ListProjectsQueryHandler
+	ListUsersQueryHandler             *query.ListUsersQueryHandler
+	ListWorkflowsQueryHandler         *query.ListWorkflowsQueryHandler
+	GetTaskHistoryQueryHandler        *query.GetTaskHistoryQueryHandler
+	ListEventsQueryHandler            *query.ListEventsQueryHandler
 	ListTasksByProjectQueryHandler    *query.ListTasksByProjectQueryHandler
+	ListProjectActivityQueryHandler   *query.ListProjectActivityQueryHandler
+	ListOverdueTasksQueryHandler      *query.ListOverdueTasksQueryHandler
+	ListTasksDueWithinQueryHandler    *query.ListTasksDueWithinQueryHandler
+	GetProjectStatsQueryHandler       *query.GetProjectStatsQueryHandler
+	GetWorkloadHeatmapQueryHandler    *query.GetWorkloadHeatmapQueryHandler
+	ListUnacknowledgedAssignmentsQueryHandler *query.ListUnacknowledgedAssignmentsQueryHandler
+	ListWidgetsQueryHandler           *query.ListWidgetsQueryHandler
+	EvaluateDashboardQueryHandler     *query.EvaluateDashboardQueryHandler
+	SearchSuggestionsQueryHandler     *query.SearchSuggestionsQueryHandler
+	GetRecentlyViewedQueryHandler     *query.GetRecentlyViewedQueryHandler
+	FindDuplicateTasksQueryHandler    *query.FindDuplicateTasksQueryHandler
+	ListSprintsQueryHandler           *query.ListSprintsQueryHandler
+	ListSprintTasksQueryHandler       *query.ListSprintTasksQueryHandler
+	GetTeamQueryHandler               *query.GetTeamQueryHandler
+	ListTeamTasksQueryHandler         *query.ListTeamTasksQueryHandler
+	GetOrganizationUsageQueryHandler  *query.GetOrganizationUsageQueryHandler
+	GetOrganizationDirectoryQueryHandler *query.GetOrganizationDirectoryQueryHandler
+	VerifyIntegrityQueryHandler       *query.VerifyIntegrityQueryHandler
+	ListHolidayCalendarsQueryHandler  *query.ListHolidayCalendarsQueryHandler
+	GetHolidayCalendarQueryHandler    *query.GetHolidayCalendarQueryHandler
+	ListMilestonesQueryHandler        *query.ListMilestonesQueryHandler
+	GetNotificationRoutesQueryHandler *query.GetNotificationRoutesQueryHandler
+	GetProjectSettingsQueryHandler    *query.GetProjectSettingsQueryHandler
+	GetProjectWorkflowMigrationQueryHandler *query.GetProjectWorkflowMigrationQueryHandler
+	GetProjectBoardQueryHandler       *query.GetProjectBoardQueryHandler
+	SimulateWorkflowQueryHandler      *query.SimulateWorkflowQueryHandler
+	GetProjectBudgetQueryHandler      *query.GetProjectBudgetQueryHandler
+	SearchWorkspaceQueryHandler       *query.SearchWorkspaceQueryHandler
+	SearchTasksQueryHandler           *query.SearchTasksQueryHandler
+}
+
+// Repositories groups the persistence implementations a container is built on
+type Repositories struct {
+	Task       domain.TaskRepository
+	Project    domain.ProjectRepository
+	User       domain.UserRepository
+	Workflow   domain.WorkflowRepository
+	Widget     domain.WidgetRepository
+	RecentView domain.RecentViewRepository
+	Sprint     domain.SprintRepository
+	Team       domain.TeamRepository
+	Organization domain.OrganizationRepository
+	HolidayCalendar domain.HolidayCalendarRepository
+
+	// Events is where published events are appended, in memory when nil
+	Events event.EventStore
+
+	// UnitOfWork opens the factory of the transactions commands save several aggregates in,
+	// delivering committed events through the container's outbox; in memory over the
+	// repositories above when nil
+	UnitOfWork func(outbox repository.Outbox) domain.UnitOfWorkFactory
+
+	// ProcessStates is where process managers keep the progress of their reactions, in memory when nil
+	ProcessStates process.StateStore
+}
+
+// DeadlineSweepInterval is how often the deadlines of open tasks are checked
+const DeadlineSweepInterval = 15 * time.Minute
+
+// InMemoryRepositories returns a fresh set of in-memory repositories
+func InMemoryRepositories() Repositories {
+	return Repositories{
+		Task:       repository.NewInMemoryTaskRepository(),
+		Project:    repository.NewInMemoryProjectRepository(),
+		User:       repository.NewInMemoryUserRepository(),
+		Workflow:   repository.NewInMemoryWorkflowRepository(),
+		Widget:     repository.NewInMemoryWidgetRepository(),
+		RecentView: repository.NewInMemoryRecentViewRepository(repository.DefaultRecentViewCapacity),
+		Sprint:     repository.NewInMemorySprintRepository(),
+		Team:       repository.NewInMemoryTeamRepository(),
+		Organization: repository.NewInMemoryOrganizationRepository(),
+		HolidayCalendar: repository.NewInMemoryHolidayCalendarRepository(),
+		Events:     infraEvent.NewInMemoryEventStore(),
+	}
+}
+
+// SQLiteRepositories returns the repositories, event store and process states kept in an open
+// SQLite database, creating their tables first when the database is new. Transactions run on
+// the database, so the writes and events of a command are kept or rolled back together.
+// Their unit of work appends to the returned event store, so Events must not be replaced.
+func SQLiteRepositories(db *sql.DB) (Repositories, error) {
+	if err := repository.EnsureSQLiteSchema(db); err != nil {
+		return Repositories{}, err
+	}
+
+	serializer := infraEvent.NewEventSerializer()
+	events := infraEvent.NewSQLiteEventStore(db, serializer)
+	if err := events.EnsureSchema(); err != nil {
+		return Repositories{}, err
+	}
+
+	return Repositories{
+		Task:            repository.NewSQLiteTaskRepository(db),
+		Project:         repository.NewSQLiteProjectRepository(db),
+		User:            repository.NewSQLiteUserRepository(db),
+		Workflow:        repository.NewSQLiteWorkflowRepository(db),
+		Widget:          repository.NewSQLiteWidgetRepository(db),
+		RecentView:      repository.NewSQLiteRecentViewRepository(db, repository.DefaultRecentViewCapacity),
+		Sprint:          repository.NewSQLiteSprintRepository(db),
+		Team:            repository.NewSQLiteTeamRepository(db),
+		Organization:    repository.NewSQLiteOrganizationRepository(db),
+		HolidayCalendar: repository.NewSQLiteHolidayCalendarRepository(db),
+		Events:          events,
+		ProcessStates:   repository.NewSQLiteProcessStateRepository(db, serializer),
+		UnitOfWork: func(outbox repository.Outbox) domain.UnitOfWorkFactory {
+			return repository.NewSQLiteUnitOfWorkFactory(db, events, outbox)
+		},
+	}, nil
 }
 
 // NewContainer creates and initializes a new dependency injection container
 func NewContainer() *Container {
+	// Initialize repositories (using in-memory implementations for demo)
+	return NewContainerWithRepositories(InMemoryRepositories())
+}
+
+// NewContainerWithRepositories creates a container on top of the given repositories,
+// for example ones backed by a database shared with other services
+func NewContainerWithRepositories(repos Repositories) *Container {
 	c := &Container{}
 
-	// Initialize repositories (using in-memory implementations for demo)
-	c.TaskRepository = repository.NewInMemoryTaskRepository()
-	c.ProjectRepository = repository.NewInMemoryProjectRepository()
-	c.UserRepository = repository.NewInMemoryUserRepository()
-	c.WorkflowRepository = repository.NewInMemoryWorkflowRepository()
+	c.TaskRepository = repos.Task
+	c.ProjectRepository = repos.Project
+	c.UserRepository = repos.User
+	c.WorkflowRepository = repos.Workflow
+	c.WidgetRepository = repos.Widget
+	c.RecentViewRepository = repos.RecentView
+	c.SprintRepository = repos.Sprint
+	c.TeamRepository = repos.Team
+	c.OrganizationRepository = repos.Organization
+	c.HolidayCalendarRepository = repos.HolidayCalendar
 
-	// Initialize event publisher
-	c.EventPublisher = infraEvent.NewSimpleEventPublisher()
+	// Initialize event store and publisher; every published event is stored first
+	c.EventStore = repos.Events
+	if c.EventStore == nil {
+		c.EventStore = infraEvent.NewInMemoryEventStore()
+	}
+	c.EventSerializer = infraEvent.NewEventSerializer()
+	c.EventExporter = infraEvent.NewNDJSONExporter(c.EventStore, c.EventSerializer)
+	c.EventMetrics = infraEvent.NewEventMetrics()
+	c.EventMetrics.SetProjectResolver(infraEvent.NewProjectResolver(c.TaskRepository, c.SprintRepository))
+	publisher := infraEvent.NewSimpleEventPublisher()
+	outbox := infraEvent.NewStoringEventPublisher(c.EventStore, publisher, c.EventMetrics)
+	c.EventPublisher = outbox
+	c.EventSubscriber = publisher
+	c.EventDispatcher = publisher
+
+	// Transactions append their events to the storing publisher when they commit
+	if repos.UnitOfWork != nil {
+		c.UnitOfWork = repos.UnitOfWork(outbox)
+	} else {
+		c.UnitOfWork = repository.NewInMemoryUnitOfWorkFactory(repository.UnitOfWorkRepositories{
+			Tasks:     c.TaskRepository,
+			Projects:  c.ProjectRepository,
+			Users:     c.UserRepository,
+			Workflows: c.WorkflowRepository,
+		}, outbox)
+	}
+
+	// Initialize read models fed by domain events
+	c.ProjectionRebuilder = infraEvent.NewProjectionRebuilder(c.EventStore)
+
+	c.SuggestionIndex = search.NewPrefixIndex()
+	suggestionProjector := search.NewSuggestionProjector(c.SuggestionIndex)
+	suggestionProjector.Register(infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, suggestionProjector.Name()))
+	c.ProjectionRebuilder.Register(suggestionProjector)
+
+	c.WorkspaceIndex = search.NewWorkspaceIndex()
+	workspaceProjector := search.NewWorkspaceProjector(c.WorkspaceIndex, c.TaskRepository)
+	workspaceProjector.Register(infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, workspaceProjector.Name()))
+	c.ProjectionRebuilder.Register(workspaceProjector)
+
+	c.ActivityFeed = activity.NewFeed()
+	activityProjector := activity.NewProjector(c.ActivityFeed, c.TaskRepository, c.UserRepository)
+	activityProjector.Register(infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, activityProjector.Name()))
+	c.ProjectionRebuilder.Register(activityProjector)
+
+	// Initialize presence tracking for collaborators viewing the same task or board
+	c.PresenceTracker = presence.NewTracker(presence.DefaultTTL)
+	c.PresenceBroadcaster = presence.NewBroadcaster()
+	c.PasswordHasher = auth.NewPasswordHasher()
+	c.SessionStore = auth.NewSessionStore(auth.DefaultSessionTTL)
+	c.TokenIssuer = auth.NewTokenIssuer(tokenSecret(), auth.DefaultAccessTokenTTL, auth.DefaultRefreshTokenTTL)
+	c.TokenIssuer.Register(infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "token_revocation"))
+	c.SessionStore.Register(infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "session_revocation"))
+	c.Callers = auth.NewCallers(c.UserRepository)
+	c.VerificationTokens = auth.NewVerificationTokens(auth.DefaultVerificationTTL)
+	c.APICallLimiter = quota.NewAPICallLimiter(c.OrganizationRepository)
+	c.IdempotencyStore = idempotency.NewStore(idempotency.DefaultTTL)
+
+	// Meter usage for billing on its own topic
+	c.UsageTopic = billing.NewUsageTopic()
+	c.UsageEmitter = billing.NewUsageEmitter(c.UsageTopic, c.OrganizationRepository, c.ProjectRepository, c.TaskRepository)
+	c.UsageEmitter.Register(infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "billing_usage"))
+	presence.NewEditLockRelay(c.PresenceBroadcaster).Register(
+		infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "presence_edit_locks"),
+	)
 
 	// Initialize notification service
 	c.NotificationService = infraEvent.NewSimpleNotificationService()
 
+	// Route events to channels following each project's rules
+	c.NotificationDispatcher = notification.NewDispatcher(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.SprintRepository,
+	)
+	c.IntegrationHealth = integration.NewMonitor(
+		integration.DefaultFailureThreshold,
+		integration.DefaultCooldown,
+		integration.DefaultFailureWindow,
+	)
+	c.NotificationDispatcher.TrackHealth(c.IntegrationHealth)
+	c.NotificationDispatcher.Register(
+		infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "notification_dispatcher"),
+		c.EventSerializer.EventTypes(),
+	)
+
+	// Translate comments on demand once a provider is plugged in
+	c.CommentTranslator = translation.NewCommentTranslator(translation.DefaultCacheSize)
+
 	// Initialize domain services
 	c.TaskAssignmentService = service.NewTaskAssignmentService(
 		c.UserRepository.(service.UserRepository),
@@ -61,44 +438,945 @@ func NewContainer() *Container {
 
 	c.StatusTransitionService = service.NewStatusTransitionService(
 		c.WorkflowRepository,
+		c.ProjectRepository,
 	)
 
+	// Seed the built-in workflow that projects without one follow
+	if _, err := c.StatusTransitionService.GetOrCreateDefaultWorkflow(context.Background()); err != nil {
+		panic(err)
+	}
+
 	c.DeadlineEnforcementService = service.NewDeadlineEnforcementService(
 		c.NotificationService,
+		c.UserRepository,
 	)
 
-	// Initialize command handlers
-	c.CreateTaskCommandHandler = command.NewCreateTaskCommandHandler(
-		c.TaskRepository,
+	c.SLICalculationService = service.NewSLICalculationService()
+
+	c.TaskLinkService = service.NewTaskLinkService()
+
+	c.DuplicateDetectionService = service.NewDuplicateDetectionService()
+
+	c.WorkflowSimulationService = service.NewWorkflowSimulationService()
+
+	c.MilestoneProgressService = service.NewMilestoneProgressService()
+
+	c.BudgetService = service.NewBudgetService()
+
+	c.QuotaEnforcementService = service.NewQuotaEnforcementService(
+		c.OrganizationRepository,
 		c.ProjectRepository,
+		c.TaskRepository,
+	)
+
+	c.WorkingHoursService = service.NewWorkingHoursService(
 		c.UserRepository,
-		c.WorkflowRepository,
+		c.OrganizationRepository,
+	)
+
+	c.ReportingLineService = service.NewReportingLineService(c.UserRepository)
+
+	c.HolidayCalendarService = service.NewHolidayCalendarService(
+		c.HolidayCalendarRepository,
+		c.ProjectRepository,
+	)
+
+	c.InboxAddressService = service.NewInboxAddressService()
+	c.AuthorizationPolicy = service.NewRoleBasedPolicy()
+	c.Authorizer = command.NewAuthorizer(c.UserRepository, c.AuthorizationPolicy)
+
+	// Initialize command handlers
+	c.CreateTaskCommandHandler = command.NewCreateTaskCommandHandler(
+		c.UnitOfWork,
 		c.EventPublisher,
+		c.Authorizer,
 		c.TaskAssignmentService,
 		c.DeadlineEnforcementService,
+		c.DuplicateDetectionService,
+		c.QuotaEnforcementService,
 	)
 
 	c.AssignTaskCommandHandler = command.NewAssignTaskCommandHandler(
 		c.TaskRepository,
+		c.ProjectRepository,
 		c.EventPublisher,
+		c.Authorizer,
 		c.TaskAssignmentService,
 	)
 
+	c.AcknowledgeTaskCommandHandler = command.NewAcknowledgeTaskCommandHandler(
+		c.TaskRepository,
+		c.EventPublisher,
+	)
+
 	c.UpdateTaskStatusCommandHandler = command.NewUpdateTaskStatusCommandHandler(
 		c.TaskRepository,
+		c.ProjectRepository,
 		c.EventPublisher,
 		c.StatusTransitionService,
+		c.Authorizer,
 	)
 
-	// Initialize query handlers
-	c.GetTaskQueryHandler = query.NewGetTaskQueryHandler(
+	c.AddCommentCommandHandler = command.NewAddCommentCommandHandler(
 		c.TaskRepository,
+		c.ProjectRepository,
 		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
 	)
 
-	c.ListTasksByProjectQueryHandler = query.NewListTasksByProjectQueryHandler(
+	c.SetProjectSLOCommandHandler = command.NewSetProjectSLOCommandHandler(
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.SetProjectSettingsCommandHandler = command.NewSetProjectSettingsCommandHandler(
+		c.ProjectRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.LinkTasksCommandHandler = command.NewLinkTasksCommandHandler(
 		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+		c.TaskLinkService,
 	)
 
-	return c
-}
\ No newline at end of file
+	c.UnlinkTasksCommandHandler = command.NewUnlinkTasksCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+		c.TaskLinkService,
+	)
+
+	c.CreateWidgetCommandHandler = command.NewCreateWidgetCommandHandler(
+		c.WidgetRepository,
+		c.UserRepository,
+	)
+
+	c.UpdateWidgetCommandHandler = command.NewUpdateWidgetCommandHandler(
+		c.WidgetRepository,
+	)
+
+	c.DeleteWidgetCommandHandler = command.NewDeleteWidgetCommandHandler(
+		c.WidgetRepository,
+	)
+
+	c.VoteTaskCommandHandler = command.NewVoteTaskCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+	c.ApproveTaskCommandHandler = command.NewApproveTaskCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.StatusTransitionService,
+		c.Authorizer,
+	)
+
+	c.RejectTaskCommandHandler = command.NewRejectTaskCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.StatusTransitionService,
+		c.Authorizer,
+	)
+
+	c.EditLockCommandHandler = command.NewEditLockCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.UpdateTaskDescriptionCommandHandler = command.NewUpdateTaskDescriptionCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.ChangeTaskDeadlineCommandHandler = command.NewChangeTaskDeadlineCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.DeadlineEnforcementService,
+		c.HolidayCalendarService,
+		c.Authorizer,
+	)
+
+	c.CompareAndSetTaskStatusCommandHandler = command.NewCompareAndSetTaskStatusCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.StatusTransitionService,
+		c.Authorizer,
+	)
+
+	c.RecordViewCommandHandler = command.NewRecordViewCommandHandler(
+		c.RecentViewRepository,
+	)
+
+	c.CreateSprintCommandHandler = command.NewCreateSprintCommandHandler(
+		c.SprintRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.AddTaskToSprintCommandHandler = command.NewAddTaskToSprintCommandHandler(
+		c.SprintRepository,
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.RemoveTaskFromSprintCommandHandler = command.NewRemoveTaskFromSprintCommandHandler(
+		c.SprintRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.StartSprintCommandHandler = command.NewStartSprintCommandHandler(
+		c.SprintRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.CompleteSprintCommandHandler = command.NewCompleteSprintCommandHandler(
+		c.SprintRepository,
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.UpdateProjectCommandHandler = command.NewUpdateProjectCommandHandler(
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.ArchiveProjectCommandHandler = command.NewArchiveProjectCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.DeleteProjectCommandHandler = command.NewDeleteProjectCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.SetNotificationRoutesCommandHandler = command.NewSetNotificationRoutesCommandHandler(
+		c.ProjectRepository,
+		c.Authorizer,
+	)
+
+	c.CreateMilestoneCommandHandler = command.NewCreateMilestoneCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.MilestoneProgressService,
+		c.Authorizer,
+	)
+
+	c.UpdateMilestoneCommandHandler = command.NewUpdateMilestoneCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.MilestoneProgressService,
+		c.Authorizer,
+	)
+
+	c.DeleteMilestoneCommandHandler = command.NewDeleteMilestoneCommandHandler(
+		c.ProjectRepository,
+		c.Authorizer,
+	)
+
+	c.EvaluateMilestonesCommandHandler = command.NewEvaluateMilestonesCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.MilestoneProgressService,
+	)
+
+	// Milestones are reached as their tasks are completed or cancelled
+	infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "milestone_evaluation").
+		Subscribe("TaskStatusChanged", c.EvaluateMilestonesCommandHandler.OnTaskStatusChanged)
+
+	c.UpdateProjectProgressCommandHandler = command.NewUpdateProjectProgressCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+	)
+
+	// Completing tasks updates project progress and closes parent tasks whose subtasks are all closed
+	c.ProcessStates = repos.ProcessStates
+	if c.ProcessStates == nil {
+		c.ProcessStates = repository.NewInMemoryProcessStateRepository()
+	}
+	c.ProcessManager = process.NewManager(c.ProcessStates, process.DefaultRetryPolicy(),
+		process.NewProjectProgressReaction(c.TaskRepository, c.ProjectRepository, c.UpdateProjectProgressCommandHandler),
+		process.NewParentTaskCompletionReaction(c.TaskRepository, c.ProjectRepository, c.TaskLinkService, c.UpdateTaskStatusCommandHandler),
+	)
+	c.ProcessManager.Register(infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "task_completion_process"))
+
+	c.CheckOverdueTasksCommandHandler = command.NewCheckOverdueTasksCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.DeadlineEnforcementService,
+	)
+
+	c.SweepOverdueTasksCommandHandler = command.NewSweepOverdueTasksCommandHandler(
+		c.ProjectRepository,
+		c.CheckOverdueTasksCommandHandler,
+	)
+
+	// Overdue tasks are reported, and their assignees reminded, by a periodic sweep
+	c.Scheduler = scheduler.NewScheduler()
+	c.Scheduler.Add("deadline_sweep", scheduler.Every(DeadlineSweepInterval), func(ctx context.Context, now time.Time) error {
+		_, err := c.SweepOverdueTasksCommandHandler.Handle(ctx, command.SweepOverdueTasksCommand{})
+		return err
+	})
+
+	c.EscalateUnacknowledgedAssignmentsCommandHandler = command.NewEscalateUnacknowledgedAssignmentsCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.TaskAssignmentService,
+	)
+
+	c.SetProjectBudgetCommandHandler = command.NewSetProjectBudgetCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.BudgetService,
+		c.Authorizer,
+	)
+
+	c.RecordTaskCostCommandHandler = command.NewRecordTaskCostCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.EvaluateBudgetCommandHandler = command.NewEvaluateBudgetCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.BudgetService,
+	)
+
+	// Budgets are checked as costs are recorded against their tasks
+	infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "budget_evaluation").
+		Subscribe("TaskCostRecorded", c.EvaluateBudgetCommandHandler.OnTaskCostRecorded)
+
+	c.RegisterUserCommandHandler = command.NewRegisterUserCommandHandler(
+		c.UserRepository,
+		c.PasswordHasher,
+		c.EventPublisher,
+	)
+
+	c.SendVerificationEmailCommandHandler = command.NewSendVerificationEmailCommandHandler(
+		c.UserRepository,
+		c.VerificationTokens,
+		c.NotificationService,
+	)
+
+	// New users, and users who change their address, are sent a link to verify it
+	emailVerification := infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "email_verification")
+	emailVerification.Subscribe("UserRegistered", c.SendVerificationEmailCommandHandler.OnUserRegistered)
+	emailVerification.Subscribe("UserEmailChanged", c.SendVerificationEmailCommandHandler.OnUserEmailChanged)
+
+	c.VerifyEmailCommandHandler = command.NewVerifyEmailCommandHandler(
+		c.UserRepository,
+		c.VerificationTokens,
+		c.EventPublisher,
+	)
+
+	c.ChangePasswordCommandHandler = command.NewChangePasswordCommandHandler(
+		c.UserRepository,
+		c.PasswordHasher,
+		c.SessionStore,
+		c.EventPublisher,
+	)
+
+	c.LoginCommandHandler = command.NewLoginCommandHandler(
+		c.UserRepository,
+		c.PasswordHasher,
+		c.SessionStore,
+	)
+
+	c.LogoutCommandHandler = command.NewLogoutCommandHandler(
+		c.SessionStore,
+	)
+
+	c.IssueTokenCommandHandler = command.NewIssueTokenCommandHandler(
+		c.UserRepository,
+		c.PasswordHasher,
+		c.TokenIssuer,
+	)
+
+	c.RefreshTokenCommandHandler = command.NewRefreshTokenCommandHandler(
+		c.UserRepository,
+		c.TokenIssuer,
+	)
+
+	c.SignUpTenantCommandHandler = command.NewSignUpTenantCommandHandler(
+		c.OrganizationRepository,
+		c.UserRepository,
+		c.WorkflowRepository,
+		c.ProjectRepository,
+		c.PasswordHasher,
+		c.EventPublisher,
+		c.TokenIssuer,
+	)
+
+	c.AddAttachmentCommandHandler = command.NewAddAttachmentCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+		c.QuotaEnforcementService,
+	)
+
+	c.SetProjectAccessCommandHandler = command.NewSetProjectAccessCommandHandler(
+		c.ProjectRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.AssignProjectRoleCommandHandler = command.NewAssignProjectRoleCommandHandler(
+		c.ProjectRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.ChangeProjectWorkflowCommandHandler = command.NewChangeProjectWorkflowCommandHandler(
+		c.ProjectRepository,
+		c.WorkflowRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.MigrateProjectWorkflowCommandHandler = command.NewMigrateProjectWorkflowCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.StatusTransitionService,
+		c.Authorizer,
+	)
+
+	c.PlanProjectWorkflowMigrationCommandHandler = command.NewPlanProjectWorkflowMigrationCommandHandler(
+		c.ProjectRepository,
+		c.WorkflowRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.StatusTransitionService,
+		c.Authorizer,
+	)
+
+	c.ApplyProjectWorkflowMigrationCommandHandler = command.NewApplyProjectWorkflowMigrationCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.RollBackProjectWorkflowMigrationCommandHandler = command.NewRollBackProjectWorkflowMigrationCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.CreateWorkflowCommandHandler = command.NewCreateWorkflowCommandHandler(
+		c.WorkflowRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+	c.UpdateWorkflowCommandHandler = command.NewUpdateWorkflowCommandHandler(
+		c.WorkflowRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+	c.EditWorkflowStatusesCommandHandler = command.NewEditWorkflowStatusesCommandHandler(
+		c.WorkflowRepository,
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.EditWorkflowTransitionsCommandHandler = command.NewEditWorkflowTransitionsCommandHandler(
+		c.WorkflowRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+	c.SetTransitionRulesCommandHandler = command.NewSetTransitionRulesCommandHandler(
+		c.WorkflowRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+	c.SetWorkflowApprovalStepCommandHandler = command.NewSetWorkflowApprovalStepCommandHandler(
+		c.WorkflowRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.DeleteTaskCommandHandler = command.NewDeleteTaskCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.ReassignAllTasksCommandHandler = command.NewReassignAllTasksCommandHandler(
+		c.UnitOfWork,
+		c.TaskAssignmentService,
+		c.Authorizer,
+	)
+
+	c.DeactivateUserCommandHandler = command.NewDeactivateUserCommandHandler(
+		c.UserRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.TaskAssignmentService,
+		c.Authorizer,
+	)
+
+	// Deactivated users' open tasks go to the fallback assignee or back to the pool
+	infraEvent.NewMeasuredSubscriber(publisher, c.EventMetrics, "user_deactivation").
+		Subscribe("UserDeactivated", c.DeactivateUserCommandHandler.OnUserDeactivated)
+
+	c.ActivateUserCommandHandler = command.NewActivateUserCommandHandler(
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.ChangeEmailCommandHandler = command.NewChangeEmailCommandHandler(
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.UpdateUserCommandHandler = command.NewUpdateUserCommandHandler(
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.SetUserWorkingHoursCommandHandler = command.NewSetUserWorkingHoursCommandHandler(
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.CreateOrganizationCommandHandler = command.NewCreateOrganizationCommandHandler(
+		c.OrganizationRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.AddOrganizationMemberCommandHandler = command.NewAddOrganizationMemberCommandHandler(
+		c.OrganizationRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.SetOrganizationQuotaCommandHandler = command.NewSetOrganizationQuotaCommandHandler(
+		c.OrganizationRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.SetOrganizationWorkingHoursCommandHandler = command.NewSetOrganizationWorkingHoursCommandHandler(
+		c.OrganizationRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.CreateHolidayCalendarCommandHandler = command.NewCreateHolidayCalendarCommandHandler(
+		c.HolidayCalendarRepository,
+		c.OrganizationRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+	c.UpdateHolidayCalendarCommandHandler = command.NewUpdateHolidayCalendarCommandHandler(
+		c.HolidayCalendarRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+	c.DeleteHolidayCalendarCommandHandler = command.NewDeleteHolidayCalendarCommandHandler(
+		c.HolidayCalendarRepository,
+		c.ProjectRepository,
+		c.Authorizer,
+	)
+	c.SetProjectHolidayCalendarCommandHandler = command.NewSetProjectHolidayCalendarCommandHandler(
+		c.ProjectRepository,
+		c.HolidayCalendarRepository,
+		c.OrganizationRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.SetProjectPrioritySchemeCommandHandler = command.NewSetProjectPrioritySchemeCommandHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.SetUserLocaleCommandHandler = command.NewSetUserLocaleCommandHandler(
+		c.UserRepository,
+		c.Authorizer,
+	)
+
+	c.SetBoardSortCommandHandler = command.NewSetBoardSortCommandHandler(
+		c.UserRepository,
+		c.ProjectRepository,
+	)
+
+	c.SetUserOutOfOfficeCommandHandler = command.NewSetUserOutOfOfficeCommandHandler(
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.SetUserManagerCommandHandler = command.NewSetUserManagerCommandHandler(
+		c.UserRepository,
+		c.ReportingLineService,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.AddProjectInboxAddressCommandHandler = command.NewAddProjectInboxAddressCommandHandler(
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.InboxAddressService,
+		c.Authorizer,
+	)
+
+	c.RevokeProjectInboxAddressCommandHandler = command.NewRevokeProjectInboxAddressCommandHandler(
+		c.ProjectRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.ReceiveInboundEmailCommandHandler = command.NewReceiveInboundEmailCommandHandler(
+		c.ProjectRepository,
+		c.UserRepository,
+		c.CreateTaskCommandHandler,
+	)
+
+	c.CreateTeamCommandHandler = command.NewCreateTeamCommandHandler(
+		c.TeamRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.AddTeamMemberCommandHandler = command.NewAddTeamMemberCommandHandler(
+		c.TeamRepository,
+		c.UserRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.RemoveTeamMemberCommandHandler = command.NewRemoveTeamMemberCommandHandler(
+		c.TeamRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.ChangeTeamLeadCommandHandler = command.NewChangeTeamLeadCommandHandler(
+		c.TeamRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	c.AssignTaskToTeamCommandHandler = command.NewAssignTaskToTeamCommandHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.TeamRepository,
+		c.EventPublisher,
+		c.Authorizer,
+	)
+
+	// Initialize query handlers
+	c.GetTaskQueryHandler = query.NewGetTaskQueryHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.UserRepository,
+		c.CommentTranslator,
+	)
+
+	c.GetProjectQueryHandler = query.NewGetProjectQueryHandler(
+		c.ProjectRepository,
+	)
+
+	c.GetUserQueryHandler = query.NewGetUserQueryHandler(
+		c.UserRepository,
+		c.WorkingHoursService,
+	)
+
+	c.GetWorkflowQueryHandler = query.NewGetWorkflowQueryHandler(
+		c.WorkflowRepository,
+	)
+
+	c.ListProjectsQueryHandler = query.NewListProjectsQueryHandler(
+		c.ProjectRepository,
+	)
+
+	c.ListUsersQueryHandler = query.NewListUsersQueryHandler(
+		c.UserRepository,
+		c.WorkingHoursService,
+	)
+
+	c.ListWorkflowsQueryHandler = query.NewListWorkflowsQueryHandler(
+		c.WorkflowRepository,
+	)
+
+	c.GetTaskHistoryQueryHandler = query.NewGetTaskHistoryQueryHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.EventStore,
+	)
+
+	c.ListEventsQueryHandler = query.NewListEventsQueryHandler(
+		c.EventStore,
+		c.EventSerializer,
+	)
+
+	c.ListTasksByProjectQueryHandler = query.NewListTasksByProjectQueryHandler(
+		c.TaskRepository,
+		c.ProjectRepository,
+	)
+
+	c.ListProjectActivityQueryHandler = query.NewListProjectActivityQueryHandler(
+		c.ActivityFeed,
+		c.ProjectRepository,
+	)
+
+	c.ListOverdueTasksQueryHandler = query.NewListOverdueTasksQueryHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.DeadlineEnforcementService,
+	)
+
+	c.ListTasksDueWithinQueryHandler = query.NewListTasksDueWithinQueryHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.DeadlineEnforcementService,
+	)
+
+	c.GetProjectStatsQueryHandler = query.NewGetProjectStatsQueryHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.SLICalculationService,
+		c.HolidayCalendarService,
+	)
+
+	c.GetWorkloadHeatmapQueryHandler = query.NewGetWorkloadHeatmapQueryHandler(
+		c.TaskRepository,
+		c.WorkingHoursService,
+		c.HolidayCalendarService,
+	)
+
+	c.ListUnacknowledgedAssignmentsQueryHandler = query.NewListUnacknowledgedAssignmentsQueryHandler(
+		c.TaskRepository,
+	)
+
+	c.ListWidgetsQueryHandler = query.NewListWidgetsQueryHandler(
+		c.WidgetRepository,
+	)
+
+	c.EvaluateDashboardQueryHandler = query.NewEvaluateDashboardQueryHandler(
+		c.WidgetRepository,
+		c.ListTasksByProjectQueryHandler,
+		c.GetProjectStatsQueryHandler,
+		c.GetWorkloadHeatmapQueryHandler,
+		c.ListUnacknowledgedAssignmentsQueryHandler,
+	)
+
+	c.SearchSuggestionsQueryHandler = query.NewSearchSuggestionsQueryHandler(
+		c.SuggestionIndex,
+		c.RecentViewRepository,
+		c.TaskRepository,
+		c.ProjectRepository,
+	)
+
+	c.ListSprintsQueryHandler = query.NewListSprintsQueryHandler(
+		c.SprintRepository,
+	)
+
+	c.ListSprintTasksQueryHandler = query.NewListSprintTasksQueryHandler(
+		c.SprintRepository,
+		c.TaskRepository,
+		c.ProjectRepository,
+	)
+
+	c.GetTeamQueryHandler = query.NewGetTeamQueryHandler(
+		c.TeamRepository,
+	)
+
+	c.ListTeamTasksQueryHandler = query.NewListTeamTasksQueryHandler(
+		c.TeamRepository,
+		c.TaskRepository,
+	)
+
+	c.GetOrganizationUsageQueryHandler = query.NewGetOrganizationUsageQueryHandler(
+		c.OrganizationRepository,
+		c.QuotaEnforcementService,
+		c.APICallLimiter,
+	)
+
+	c.VerifyIntegrityQueryHandler = query.NewVerifyIntegrityQueryHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+	)
+
+	c.GetOrganizationDirectoryQueryHandler = query.NewGetOrganizationDirectoryQueryHandler(
+		c.OrganizationRepository,
+		c.UserRepository,
+		c.TeamRepository,
+		c.ReportingLineService,
+	)
+
+	c.ListHolidayCalendarsQueryHandler = query.NewListHolidayCalendarsQueryHandler(
+		c.HolidayCalendarRepository,
+	)
+	c.GetHolidayCalendarQueryHandler = query.NewGetHolidayCalendarQueryHandler(
+		c.HolidayCalendarRepository,
+	)
+
+	c.GetNotificationRoutesQueryHandler = query.NewGetNotificationRoutesQueryHandler(
+		c.ProjectRepository,
+	)
+
+	c.GetProjectSettingsQueryHandler = query.NewGetProjectSettingsQueryHandler(
+		c.ProjectRepository,
+		c.InboxAddressService,
+	)
+
+	c.GetProjectWorkflowMigrationQueryHandler = query.NewGetProjectWorkflowMigrationQueryHandler(
+		c.ProjectRepository,
+	)
+
+	c.GetProjectBoardQueryHandler = query.NewGetProjectBoardQueryHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.WorkflowRepository,
+		c.UserRepository,
+	)
+
+	c.SimulateWorkflowQueryHandler = query.NewSimulateWorkflowQueryHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.WorkflowSimulationService,
+	)
+
+	c.GetProjectBudgetQueryHandler = query.NewGetProjectBudgetQueryHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.BudgetService,
+	)
+
+	c.SearchWorkspaceQueryHandler = query.NewSearchWorkspaceQueryHandler(
+		c.WorkspaceIndex,
+		c.ProjectRepository,
+	)
+
+	c.SearchTasksQueryHandler = query.NewSearchTasksQueryHandler(
+		c.WorkspaceIndex,
+		c.TaskRepository,
+		c.ProjectRepository,
+	)
+
+	c.ListMilestonesQueryHandler = query.NewListMilestonesQueryHandler(
+		c.ProjectRepository,
+		c.TaskRepository,
+		c.MilestoneProgressService,
+	)
+
+	c.FindDuplicateTasksQueryHandler = query.NewFindDuplicateTasksQueryHandler(
+		c.TaskRepository,
+		c.DuplicateDetectionService,
+	)
+
+	c.GetRecentlyViewedQueryHandler = query.NewGetRecentlyViewedQueryHandler(
+		c.RecentViewRepository,
+		c.TaskRepository,
+		c.ProjectRepository,
+	)
+
+	return c
+}
+
+// Snapshotters returns the container's in-memory repositories and event store, the stores
+// a snapshot directory can keep across restarts. Process states are not among them.
+func (c *Container) Snapshotters() []repository.Snapshotter {
+	stores := make([]repository.Snapshotter, 0)
+	for _, candidate := range []interface{}{
+		c.TaskRepository,
+		c.ProjectRepository,
+		c.UserRepository,
+		c.WorkflowRepository,
+		c.WidgetRepository,
+		c.RecentViewRepository,
+		c.SprintRepository,
+		c.TeamRepository,
+		c.OrganizationRepository,
+		c.HolidayCalendarRepository,
+	} {
+		if store, ok := candidate.(repository.Snapshotter); ok {
+			stores = append(stores, store)
+		}
+	}
+
+	if events, ok := c.EventStore.(*infraEvent.InMemoryEventStore); ok {
+		stores = append(stores, infraEvent.NewInMemoryEventStoreSnapshot(events, c.EventSerializer))
+	}
+	return stores
+}
+
+// tokenSecret returns the JWT signing secret from JWT_SECRET.
+// Without one a random secret is used, so tokens do not survive a restart.
+func tokenSecret() []byte {
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+
+	secret, err := auth.GenerateSecret()
+	if err != nil {
+		panic(err)
+	}
+	return secret
+}
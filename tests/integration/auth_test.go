@@ -0,0 +1,677 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+	infraIntegration "github.com/miladev95/ddd-task/infrastructure/integration"
+	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// TestTokenAuthenticationOverHTTP tests issuing, using, refreshing and revoking access tokens
+func TestTokenAuthenticationOverHTTP(t *testing.T) {
+	container := di.NewContainer()
+	registered, err := container.RegisterUserCommandHandler.Handle(context.Background(), command.RegisterUserCommand{
+		Email: "jwt@example.com", FirstName: "Token", LastName: "User", Password: "first-password",
+	})
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+
+	call := func(method, path, accessToken, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		if accessToken != "" {
+			request.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if code := call(http.MethodPost, "/api/auth/token", "", `{"email":"jwt@example.com","password":"wrong-password"}`).Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected wrong credentials to be refused with 401, got %d", code)
+	}
+
+	response := call(http.MethodPost, "/api/auth/token", "", `{"email":"jwt@example.com","password":"first-password"}`)
+	var tokens dto.TokenDTO
+	json.NewDecoder(response.Body).Decode(&tokens)
+	if response.Code != http.StatusOK || tokens.UserID != registered.UserID || tokens.ExpiresIn != 900 {
+		t.Fatalf("Expected tokens for %s, got %d %+v", registered.UserID, response.Code, tokens)
+	}
+
+	// The caller is taken from the token, not from a header
+	if code := call(http.MethodGet, "/api/users/recent?id="+registered.UserID, "", "").Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected an anonymous request to be refused with 401, got %d", code)
+	}
+	if code := call(http.MethodGet, "/api/users/recent?id="+registered.UserID, tokens.AccessToken, "").Code; code != http.StatusOK {
+		t.Errorf("Expected the access token to authenticate, got %d", code)
+	}
+
+	// Refresh tokens are single use
+	response = call(http.MethodPost, "/api/auth/token/refresh", "", `{"refresh_token":"`+tokens.RefreshToken+`"}`)
+	var refreshed dto.TokenDTO
+	json.NewDecoder(response.Body).Decode(&refreshed)
+	if response.Code != http.StatusOK || refreshed.AccessToken == "" {
+		t.Fatalf("Failed to refresh tokens: %d", response.Code)
+	}
+	if code := call(http.MethodPost, "/api/auth/token/refresh", "", `{"refresh_token":"`+tokens.RefreshToken+`"}`).Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected a used refresh token to be refused with 401, got %d", code)
+	}
+
+	// Changing the password revokes every token issued so far
+	response = call(http.MethodPut, "/api/auth/password", refreshed.AccessToken,
+		`{"current_password":"first-password","new_password":"second-password"}`)
+	if response.Code != http.StatusOK {
+		t.Fatalf("Failed to change password: %d %s", response.Code, response.Body.String())
+	}
+	if code := call(http.MethodGet, "/api/users/recent?id="+registered.UserID, refreshed.AccessToken, "").Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected the access token to be revoked, got %d", code)
+	}
+	if code := call(http.MethodPost, "/api/auth/token/refresh", "", `{"refresh_token":"`+refreshed.RefreshToken+`"}`).Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected the refresh token to be revoked, got %d", code)
+	}
+}
+
+// TestSignUpProvisionsTenantInOneCall tests that signing up creates a usable organization, workflow and project
+func TestSignUpProvisionsTenantInOneCall(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+
+	call := func(method, path, accessToken, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		if accessToken != "" {
+			request.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	signUp := `{"organization_name":"Acme","email":"founder@acme.example","first_name":"Ada","last_name":"Founder","password":"a-password"}`
+	response := call(http.MethodPost, "/api/auth/signup", "", signUp)
+	var tenant dto.SignUpDTO
+	json.NewDecoder(response.Body).Decode(&tenant)
+	if response.Code != http.StatusCreated || tenant.Tokens.AccessToken == "" || tenant.Tokens.UserID != tenant.UserID {
+		t.Fatalf("Expected the tenant with the owner signed in, got %d %+v", response.Code, tenant)
+	}
+
+	// The owner's token works right away on the sample project
+	response = call(http.MethodGet, "/api/projects/get?id="+tenant.ProjectID, tenant.Tokens.AccessToken, "")
+	if response.Code != http.StatusOK || !strings.Contains(response.Body.String(), command.SampleProjectName) {
+		t.Fatalf("Expected the sample project, got %d %s", response.Code, response.Body.String())
+	}
+
+	projectID, _ := value.NewProjectID(tenant.ProjectID)
+	project, _ := container.ProjectRepository.GetByID(ctx, projectID)
+	if project.WorkflowID().Value() != tenant.WorkflowID || tenant.WorkflowID == value.DefaultWorkflowID.Value() {
+		t.Errorf("Expected the project on the tenant's own workflow, got %s", project.WorkflowID().Value())
+	}
+
+	ownerID, _ := value.NewUserID(tenant.UserID)
+	organization, err := container.OrganizationRepository.GetByMemberID(ctx, ownerID)
+	if err != nil || organization.ID().Value() != tenant.OrganizationID {
+		t.Errorf("Expected the owner to be a member of %s, got %v", tenant.OrganizationID, err)
+	}
+
+	// The owner's registration is recorded, which sends the verification email
+	events, _ := container.EventStore.GetEvents(ctx, tenant.UserID)
+	if len(events) == 0 || events[0].EventType() != "UserRegistered" {
+		t.Errorf("Expected the owner's registration to be stored, got %d events", len(events))
+	}
+
+	if code := call(http.MethodPost, "/api/auth/signup", "", signUp).Code; code == http.StatusCreated {
+		t.Error("Expected a second signup with the same email to be refused")
+	}
+}
+
+// TestEmailVerificationGatesTaskAssignment tests that only users who verified their email can be assigned tasks
+func TestEmailVerificationGatesTaskAssignment(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+	registered, err := container.RegisterUserCommandHandler.Handle(context.Background(), command.RegisterUserCommand{
+		Email: "verify@example.com", FirstName: "New", LastName: "Hire", Password: "a-password",
+	})
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+	userID, _ := value.NewUserID(registered.UserID)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Onboarding", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	createTask := func() error {
+		_, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+			ProjectID: project.ID().Value(), Title: "First task", Priority: "LOW",
+			AssigneeID: registered.UserID, CreatedBy: registered.UserID,
+		})
+		return err
+	}
+
+	if err := createTask(); err == nil || !strings.Contains(err.Error(), "email is not verified") {
+		t.Fatalf("Expected an unverified user not to be assigned a task, got %v", err)
+	}
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+	verify := func(token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/users/verify", strings.NewReader(`{"token":"`+token+`"}`))
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// A new token replaces the one sent at registration
+	token, _, err := container.VerificationTokens.Generate(registered.UserID, "verify@example.com")
+	if err != nil {
+		t.Fatalf("Failed to generate verification token: %v", err)
+	}
+	if response := verify(token); response.Code != http.StatusOK {
+		t.Fatalf("Failed to verify email: %d %s", response.Code, response.Body.String())
+	}
+	if code := verify(token).Code; code != http.StatusBadRequest {
+		t.Errorf("Expected a used verification token to be refused with 400, got %d", code)
+	}
+
+	user, _ := container.UserRepository.GetByID(ctx, userID)
+	if !user.IsEmailVerified() || user.EmailVerifiedAt() == nil {
+		t.Fatal("Expected the email to be verified")
+	}
+	if err := createTask(); err != nil {
+		t.Errorf("Expected a verified user to be assigned a task, got %v", err)
+	}
+
+	// Changing the address takes the verification away again
+	user.UpdateEmail("moved@example.com")
+	if user.IsEmailVerified() {
+		t.Error("Expected a new email address to need verification")
+	}
+}
+
+// TestOrganizationQuotasLimitWorkAndAPICalls tests quota enforcement in commands and the API rate limit
+func TestOrganizationQuotasLimitWorkAndAPICalls(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "quota-admin@example.com", "Quota", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Save(ctx, admin)
+
+	memberID := value.GenerateUserID()
+	member, _ := aggregate.NewUser(memberID, "quota-member@example.com", "Quota", "Member")
+	container.UserRepository.Save(ctx, member)
+
+	created, err := container.CreateOrganizationCommandHandler.Handle(ctx, command.CreateOrganizationCommand{
+		Name:        "Acme",
+		MemberIDs:   []string{memberID.Value()},
+		Quota:       command.OrganizationQuotaInput{MaxProjects: 1, MaxTasks: 1, MaxAttachmentBytes: 100, APICallsPerMinute: 2},
+		RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create organization: %v", err)
+	}
+
+	// Commands refuse work over the quota
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Quota", "", memberID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	if err := container.QuotaEnforcementService.CheckProjectQuota(ctx, memberID); err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("Expected a second project to exceed the quota, got %v", err)
+	}
+
+	createTask := func(title string) (*command.CreateTaskResult, error) {
+		return container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+			ProjectID: project.ID().Value(), Title: title, Priority: "LOW", CreatedBy: memberID.Value(),
+		})
+	}
+	task, err := createTask("Within quota")
+	if err != nil {
+		t.Fatalf("Expected the first task within quota, got %v", err)
+	}
+	if _, err := createTask("Over quota"); err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("Expected the second task to exceed the quota, got %v", err)
+	}
+
+	attach := func(size int64) error {
+		_, err := container.AddAttachmentCommandHandler.Handle(ctx, command.AddAttachmentCommand{
+			TaskID: task.TaskID, FileName: "spec.pdf", ContentType: "application/pdf", SizeBytes: size, UploadedBy: memberID.Value(),
+		})
+		return err
+	}
+	if err := attach(60); err != nil {
+		t.Fatalf("Expected an attachment within quota, got %v", err)
+	}
+	if err := attach(60); err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("Expected attachment storage to exceed the quota, got %v", err)
+	}
+
+	// API calls over the per-minute limit are refused until the next minute
+	now := time.Date(2026, 1, 1, 9, 0, 10, 0, time.UTC)
+	container.APICallLimiter.SetClock(func() time.Time { return now })
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+	tokens, _ := container.TokenIssuer.Issue(memberID.Value(), nil)
+	getUsage := func() *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/api/organizations/usage", nil)
+		request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	getUsage()
+	response := getUsage()
+	var usage dto.OrganizationUsageDTO
+	json.NewDecoder(response.Body).Decode(&usage)
+	if response.Code != http.StatusOK || usage.OrganizationID != created.OrganizationID {
+		t.Fatalf("Failed to get usage: %d %s", response.Code, response.Body.String())
+	}
+	if usage.Projects.Used != 1 || usage.Tasks.Used != 1 || usage.AttachmentBytes.Used != 60 || usage.APICallsPerMinute.Used != 2 {
+		t.Errorf("Expected usage of 1 project, 1 task, 60 bytes and 2 calls, got %+v", usage)
+	}
+
+	response = getUsage()
+	if response.Code != http.StatusTooManyRequests || response.Header().Get("Retry-After") != "50" {
+		t.Errorf("Expected the third call to be refused for 50 seconds, got %d %q", response.Code, response.Header().Get("Retry-After"))
+	}
+
+	now = now.Add(time.Minute)
+	if code := getUsage().Code; code != http.StatusOK {
+		t.Errorf("Expected calls to be allowed in the next minute, got %d", code)
+	}
+}
+
+// TestUserLifecycleRaisesDomainEvents tests that email changes and (de)activation publish user events
+func TestUserLifecycleRaisesDomainEvents(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	registered, err := container.RegisterUserCommandHandler.Handle(ctx, command.RegisterUserCommand{
+		Email: "lifecycle@example.com", FirstName: "Life", LastName: "Cycle", Password: "a-password",
+	})
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "lifecycle-admin@example.com", "Life", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Save(ctx, admin)
+
+	// Users change their own address, but not someone else's
+	if _, err := container.ChangeEmailCommandHandler.Handle(ctx, command.ChangeEmailCommand{
+		UserID: adminID.Value(), NewEmail: "taken-over@example.com", RequestedBy: registered.UserID,
+	}); err == nil {
+		t.Error("Expected a user to be refused changing another user's email")
+	}
+	if _, err := container.ChangeEmailCommandHandler.Handle(ctx, command.ChangeEmailCommand{
+		UserID: registered.UserID, NewEmail: "lifecycle-admin@example.com", RequestedBy: registered.UserID,
+	}); err == nil {
+		t.Error("Expected an address in use to be refused")
+	}
+	changed, err := container.ChangeEmailCommandHandler.Handle(ctx, command.ChangeEmailCommand{
+		UserID: registered.UserID, NewEmail: "moved@example.com", RequestedBy: registered.UserID,
+	})
+	if err != nil || changed.Email != "moved@example.com" {
+		t.Fatalf("Failed to change email: %v", err)
+	}
+
+	// Admins update anyone's profile, keeping the fields they leave out
+	taken, lastName := "lifecycle-admin@example.com", "Renamed"
+	if _, err := container.UpdateUserCommandHandler.Handle(ctx, command.UpdateUserCommand{
+		UserID: registered.UserID, Email: &taken, RequestedBy: adminID.Value(),
+	}); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("Expected an address in use to be refused, got %v", err)
+	}
+	updated, err := container.UpdateUserCommandHandler.Handle(ctx, command.UpdateUserCommand{
+		UserID: registered.UserID, LastName: &lastName, RequestedBy: adminID.Value(),
+	})
+	if err != nil || updated.FirstName != "Life" || updated.LastName != "Renamed" || updated.Email != "moved@example.com" {
+		t.Fatalf("Expected only the last name to change, got %+v (%v)", updated, err)
+	}
+
+	if _, err := container.DeactivateUserCommandHandler.Handle(ctx, command.DeactivateUserCommand{
+		UserID: registered.UserID, RequestedBy: adminID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+	if _, err := container.ActivateUserCommandHandler.Handle(ctx, command.ActivateUserCommand{
+		UserID: registered.UserID, RequestedBy: registered.UserID,
+	}); err == nil {
+		t.Error("Expected activation to be limited to admins")
+	}
+	if _, err := container.ActivateUserCommandHandler.Handle(ctx, command.ActivateUserCommand{
+		UserID: registered.UserID, RequestedBy: adminID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to activate user: %v", err)
+	}
+
+	userID, _ := value.NewUserID(registered.UserID)
+	user, _ := container.UserRepository.GetByID(ctx, userID)
+	if !user.IsActive() || user.IsEmailVerified() || user.Email() != "moved@example.com" {
+		t.Errorf("Expected an active user with an unverified new address, got active=%v verified=%v email=%s",
+			user.IsActive(), user.IsEmailVerified(), user.Email())
+	}
+
+	events, _ := container.EventStore.GetEvents(ctx, registered.UserID)
+	types := make([]string, 0, len(events))
+	for _, stored := range events {
+		types = append(types, stored.EventType())
+	}
+	expected := "UserRegistered,UserEmailChanged,UserRenamed,UserDeactivated,UserActivated"
+	if strings.Join(types, ",") != expected {
+		t.Errorf("Expected events %s, got %v", expected, types)
+	}
+}
+
+// TestAdminOperationsOverHTTP tests the admin API the task-admin command calls, open to global admins only
+func TestAdminOperationsOverHTTP(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "ops-admin@example.com", "Ops", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Save(ctx, admin)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Operations", "", adminID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, project)
+	container.EventPublisher.PublishAll(ctx, project.DomainEvents())
+
+	created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(), Title: "Lost task", Priority: "LOW", CreatedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	lostID, _ := value.NewTaskID(created.TaskID)
+	container.TaskRepository.Delete(ctx, lostID)
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+	memberID := value.GenerateUserID()
+	member, _ := aggregate.NewUser(memberID, "ops-member@example.com", "Ops", "Member")
+	container.UserRepository.Save(ctx, member)
+
+	adminTokens, _ := container.TokenIssuer.Issue(adminID.Value(), []string{value.GlobalRoleAdmin.Value()})
+	memberTokens, _ := container.TokenIssuer.Issue(memberID.Value(), nil)
+	call := func(method, path, accessToken string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, nil)
+		request.Header.Set("Authorization", "Bearer "+accessToken)
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if code := call(http.MethodGet, "/api/admin/integrity", memberTokens.AccessToken).Code; code != http.StatusForbidden {
+		t.Errorf("Expected a non-admin to be refused with 403, got %d", code)
+	}
+
+	// The project still lists the deleted task
+	var report dto.IntegrityReportDTO
+	json.NewDecoder(call(http.MethodGet, "/api/admin/integrity", adminTokens.AccessToken).Body).Decode(&report)
+	if len(report.Issues) != 1 || report.Issues[0].Kind != query.IntegrityMissingTask || report.Issues[0].TaskID != created.TaskID {
+		t.Errorf("Expected the deleted task to be reported missing, got %+v", report)
+	}
+
+	response := call(http.MethodPost, "/api/admin/search/reindex", adminTokens.AccessToken)
+	if response.Code != http.StatusOK || !strings.Contains(response.Body.String(), "workspace_search") ||
+		strings.Contains(response.Body.String(), "token_revocation") {
+		t.Errorf("Expected only the search projections rebuilt, got %d %s", response.Code, response.Body.String())
+	}
+
+	stored, _ := container.EventStore.GetAllEvents(ctx)
+	response = call(http.MethodGet, "/api/admin/backup", adminTokens.AccessToken)
+	if lines := strings.Count(response.Body.String(), "\n"); response.Code != http.StatusOK || lines != len(stored) {
+		t.Errorf("Expected one backup line per stored event (%d), got %d %d", len(stored), response.Code, lines)
+	}
+
+	var page dto.EventPageDTO
+	json.NewDecoder(call(http.MethodGet, "/api/events?limit=1&aggregate_id="+created.TaskID, adminTokens.AccessToken).Body).Decode(&page)
+	if page.Count != 1 || page.Events[0].EventType != "TaskCreated" || page.Events[0].Payload["title"] != "Lost task" {
+		t.Fatalf("Expected the task's first event with its payload, got %+v", page)
+	}
+	json.NewDecoder(call(http.MethodGet, fmt.Sprintf("/api/events?aggregate_id=%s&after=%d", created.TaskID, page.NextAfter),
+		adminTokens.AccessToken).Body).Decode(&page)
+	for _, record := range page.Events {
+		if record.AggregateID != created.TaskID || record.EventType == "TaskCreated" {
+			t.Errorf("Expected only later events of the task on the next page, got %+v", record)
+		}
+	}
+	if code := call(http.MethodGet, "/api/events?since=yesterday", adminTokens.AccessToken).Code; code != http.StatusBadRequest {
+		t.Errorf("Expected an invalid since to be refused with 400, got %d", code)
+	}
+	if code := call(http.MethodGet, "/api/events", memberTokens.AccessToken).Code; code != http.StatusForbidden {
+		t.Errorf("Expected a non-admin to be refused the event log with 403, got %d", code)
+	}
+
+	before := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	var pruned struct {
+		EventsPruned int `json:"events_pruned"`
+	}
+	json.NewDecoder(call(http.MethodPost, "/api/admin/events/prune?before="+before, adminTokens.AccessToken).Body).Decode(&pruned)
+	if remaining, _ := container.EventStore.GetAllEvents(ctx); pruned.EventsPruned != len(stored) || len(remaining) != 0 {
+		t.Errorf("Expected every stream to be pruned, got %d pruned and %d left", pruned.EventsPruned, len(remaining))
+	}
+
+	var health dto.IntegrationHealthReportDTO
+	json.NewDecoder(call(http.MethodGet, "/api/admin/integrations/health", adminTokens.AccessToken).Body).Decode(&health)
+	if len(health.Integrations) != 3 || health.Integrations[0].Name != "notifications.email" ||
+		health.Integrations[0].State != string(infraIntegration.CircuitClosed) {
+		t.Errorf("Expected the three notification channels reported closed, got %+v", health.Integrations)
+	}
+}
+
+// TestProblemStatusesFollowErrorKinds tests that errors answer with the status of their kind
+func TestProblemStatusesFollowErrorKinds(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "owner@example.com", "Widget", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	created, err := container.CreateWidgetCommandHandler.Handle(ctx, command.CreateWidgetCommand{
+		OwnerID: ownerID.Value(), Title: "Mine", Type: "UNACKNOWLEDGED_ASSIGNMENTS", Width: 1, Height: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create widget: %v", err)
+	}
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+	otherID := value.GenerateUserID()
+	other, _ := aggregate.NewUser(otherID, "other@example.com", "Other", "User")
+	container.UserRepository.Save(ctx, other)
+	otherTokens, _ := container.TokenIssuer.Issue(otherID.Value(), nil)
+	call := func(method, path, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+otherTokens.AccessToken)
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Another user's widget is forbidden, not missing or malformed
+	if response := call(http.MethodPut, "/api/widgets?id="+created.WidgetID, `{"title":"Theirs"}`); response.Code != http.StatusForbidden {
+		t.Errorf("Expected updating another user's widget to be refused with 403, got %d %s", response.Code, response.Body.String())
+	}
+	if response := call(http.MethodDelete, "/api/widgets?id="+created.WidgetID, ""); response.Code != http.StatusForbidden {
+		t.Errorf("Expected deleting another user's widget to be refused with 403, got %d %s", response.Code, response.Body.String())
+	}
+
+	if response := call(http.MethodDelete, "/api/widgets?id="+value.GenerateWidgetID().Value(), ""); response.Code != http.StatusNotFound {
+		t.Errorf("Expected a missing widget to answer 404, got %d %s", response.Code, response.Body.String())
+	}
+}
+
+// TestProjectRolesGuardTaskCommands tests that every change to a project's tasks is checked against the caller's role
+func TestProjectRolesGuardTaskCommands(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	newUser := func(email string) value.UserID {
+		userID := value.GenerateUserID()
+		user, _ := aggregate.NewUser(userID, email, "Role", "Holder")
+		user.VerifyEmail()
+		container.UserRepository.Save(ctx, user)
+		return userID
+	}
+	ownerID := newUser("owner@example.com")
+	viewerID := newUser("viewer@example.com")
+	outsiderID := newUser("outsider@example.com")
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Guarded", "", ownerID, value.GenerateWorkflowID())
+	project.SetAccess(value.VisibilityRestricted, nil)
+	project.AssignRole(viewerID, value.ProjectRoleViewer)
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Guarded task", "", priority, ownerID)
+	other, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Other task", "", priority, ownerID)
+	container.TaskRepository.Save(ctx, task)
+	container.TaskRepository.Save(ctx, other)
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+	call := func(userID value.UserID, method, path, body string) *httptest.ResponseRecorder {
+		tokens, _ := container.TokenIssuer.Issue(userID.Value(), nil)
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		request.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	taskID := task.ID().Value()
+	start := time.Now()
+	forbidden := []struct{ method, path, body string }{
+		{http.MethodPost, "/api/tasks", `{"project_id":"` + project.ID().Value() + `","title":"Sneaky","priority":"LOW"}`},
+		{http.MethodPost, "/api/tasks/assign?id=" + taskID, `{"assignee_id":"` + viewerID.Value() + `"}`},
+		{http.MethodPut, "/api/tasks/description?id=" + taskID, `{"description":"Rewritten"}`},
+		{http.MethodPost, "/api/tasks/edit-lock?id=" + taskID, `{}`},
+		{http.MethodPost, "/api/tasks/links?id=" + taskID, `{"target_task_id":"` + other.ID().Value() + `","link_type":"RELATES_TO"}`},
+		{http.MethodPost, "/api/tasks/costs?id=" + taskID, `{"amount":"10.00","currency":"USD"}`},
+		{http.MethodPost, "/api/tasks/attachments?id=" + taskID, `{"file_name":"spec.pdf","size_bytes":1024}`},
+		{http.MethodPost, "/api/sprints", fmt.Sprintf(`{"project_id":"%s","name":"Sprint","start_date":"%s","end_date":"%s"}`,
+			project.ID().Value(), start.Format(time.RFC3339), start.AddDate(0, 0, 14).Format(time.RFC3339))},
+	}
+
+	// Viewers may read and discuss the project's tasks but not change them
+	for _, request := range forbidden {
+		if response := call(viewerID, request.method, request.path, request.body); response.Code != http.StatusForbidden {
+			t.Errorf("Expected %s %s by a viewer to be refused with 403, got %d %s",
+				request.method, request.path, response.Code, response.Body.String())
+		}
+	}
+	if response := call(viewerID, http.MethodPost, "/api/tasks/comments?id="+taskID, `{"content":"Looks good"}`); response.Code != http.StatusCreated {
+		t.Errorf("Expected a viewer to comment, got %d %s", response.Code, response.Body.String())
+	}
+
+	// Users without a role in a restricted project may not even discuss its tasks
+	if response := call(outsiderID, http.MethodPost, "/api/tasks/comments?id="+taskID, `{"content":"Drive-by"}`); response.Code != http.StatusForbidden {
+		t.Errorf("Expected a comment by an outsider to be refused with 403, got %d %s", response.Code, response.Body.String())
+	}
+	if response := call(outsiderID, http.MethodPost, "/api/tasks/vote?id="+taskID, ""); response.Code != http.StatusForbidden {
+		t.Errorf("Expected a vote by an outsider to be refused with 403, got %d %s", response.Code, response.Body.String())
+	}
+
+	// Workflows are shared between projects, so only admins create them
+	workflow := `{"name":"Mine","statuses":[{"name":"OPEN","order":1},{"name":"DONE","order":2,"is_final":true}]}`
+	if response := call(ownerID, http.MethodPost, "/api/workflows", workflow); response.Code != http.StatusForbidden {
+		t.Errorf("Expected a workflow created by a non-admin to be refused with 403, got %d %s", response.Code, response.Body.String())
+	}
+
+	// The owner may still do all of it
+	if response := call(ownerID, http.MethodPut, "/api/tasks/description?id="+taskID, `{"description":"Rewritten"}`); response.Code != http.StatusOK {
+		t.Errorf("Expected the owner to edit the description, got %d %s", response.Code, response.Body.String())
+	}
+	if stored, _ := container.TaskRepository.GetByID(ctx, task.ID()); stored.Description() != "Rewritten" || len(stored.Comments()) != 1 {
+		t.Errorf("Expected only the owner's edit and the viewer's comment, got %q with %d comments", stored.Description(), len(stored.Comments()))
+	}
+}
+
+// TestTokensFollowTheCallersCurrentState tests that deactivation and role changes apply to tokens already issued
+func TestTokensFollowTheCallersCurrentState(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	newUser := func(email string) *aggregate.User {
+		user, _ := aggregate.NewUser(value.GenerateUserID(), email, "Token", "Holder")
+		container.UserRepository.Save(ctx, user)
+		return user
+	}
+	admin := newUser("admin@example.com")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Update(ctx, admin)
+	member := newUser("member@example.com")
+	promoted := newUser("promoted@example.com")
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+	call := func(method, path, accessToken string) int {
+		request := httptest.NewRequest(method, path, nil)
+		request.Header.Set("Authorization", "Bearer "+accessToken)
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	// Roles are the user's current ones, not those the token was issued with
+	memberTokens, _ := container.TokenIssuer.Issue(member.ID().Value(), []string{value.GlobalRoleAdmin.Value()})
+	if code := call(http.MethodGet, "/api/admin/integrity", memberTokens.AccessToken); code != http.StatusForbidden {
+		t.Errorf("Expected a token claiming a role the user lacks to be refused with 403, got %d", code)
+	}
+
+	promotedTokens, _ := container.TokenIssuer.Issue(promoted.ID().Value(), nil)
+	promoted.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Update(ctx, promoted)
+	if code := call(http.MethodGet, "/api/admin/integrity", promotedTokens.AccessToken); code != http.StatusOK {
+		t.Errorf("Expected a role granted after the token was issued to apply, got %d", code)
+	}
+
+	// Deactivation revokes the user's tokens, and they stay revoked after reactivation
+	if code := call(http.MethodGet, "/api/users/recent?id="+member.ID().Value(), memberTokens.AccessToken); code != http.StatusOK {
+		t.Fatalf("Expected the member's token to authenticate, got %d", code)
+	}
+	_, err := container.DeactivateUserCommandHandler.Handle(ctx, command.DeactivateUserCommand{
+		UserID: member.ID().Value(), RequestedBy: admin.ID().Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+	if code := call(http.MethodGet, "/api/users/recent?id="+member.ID().Value(), memberTokens.AccessToken); code != http.StatusUnauthorized {
+		t.Errorf("Expected a deactivated user's token to be refused with 401, got %d", code)
+	}
+	if _, err := container.RefreshTokenCommandHandler.Handle(ctx, command.RefreshTokenCommand{RefreshToken: memberTokens.RefreshToken}); err == nil {
+		t.Error("Expected a deactivated user's refresh token to be refused")
+	}
+
+	_, err = container.ActivateUserCommandHandler.Handle(ctx, command.ActivateUserCommand{
+		UserID: member.ID().Value(), RequestedBy: admin.ID().Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to activate user: %v", err)
+	}
+	if code := call(http.MethodGet, "/api/users/recent?id="+member.ID().Value(), memberTokens.AccessToken); code != http.StatusUnauthorized {
+		t.Errorf("Expected tokens issued before the deactivation to stay revoked, got %d", code)
+	}
+}
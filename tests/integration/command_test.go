@@ -1,24 +1,42 @@
 package integration
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain"
 	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
 	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/billing"
+	"github.com/miladev95/ddd-task/infrastructure/repository"
+	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/pkg/taskmanagement"
 	"github.com/miladev95/ddd-task/shared/di"
 )
 
 // TestCreateTaskCommandFlow tests the complete create task command flow
 func TestCreateTaskCommandFlow(t *testing.T) {
+	ctx := context.Background()
 	// Setup
 	container := di.NewContainer()
 
 	// Create users
 	userID := value.GenerateUserID()
 	user, _ := aggregate.NewUser(userID, "user@example.com", "Test", "User")
-	container.UserRepository.Save(user)
+	user.VerifyEmail()
+	container.UserRepository.Save(ctx, user)
 
 	// Create project
 	projectID := value.GenerateProjectID()
@@ -32,10 +50,10 @@ func TestCreateTaskCommandFlow(t *testing.T) {
 			aggregate.NewWorkflowStatus("DONE", "Done", 2, true),
 		},
 	)
-	container.WorkflowRepository.Save(workflow)
+	container.WorkflowRepository.Save(ctx, workflow)
 
 	project, _ := aggregate.NewProject(projectID, "Test Project", "Test", userID, workflowID)
-	container.ProjectRepository.Save(project)
+	container.ProjectRepository.Save(ctx, project)
 
 	// Create command
 	cmd := command.CreateTaskCommand{
@@ -49,7 +67,7 @@ func TestCreateTaskCommandFlow(t *testing.T) {
 	}
 
 	// Execute
-	result, err := container.CreateTaskCommandHandler.Handle(cmd)
+	result, err := container.CreateTaskCommandHandler.Handle(context.Background(), cmd)
 
 	// Verify
 	if err != nil {
@@ -62,7 +80,7 @@ func TestCreateTaskCommandFlow(t *testing.T) {
 
 	// Verify task was saved
 	taskID, _ := value.NewTaskID(result.TaskID)
-	savedTask, err := container.TaskRepository.GetByID(taskID)
+	savedTask, err := container.TaskRepository.GetByID(ctx, taskID)
 	if err != nil {
 		t.Fatalf("Expected saved task, got error: %v", err)
 	}
@@ -82,24 +100,29 @@ func TestCreateTaskCommandFlow(t *testing.T) {
 
 // TestAssignTaskCommandFlow tests the complete assign task command flow
 func TestAssignTaskCommandFlow(t *testing.T) {
+	ctx := context.Background()
 	// Setup
 	container := di.NewContainer()
 
 	// Create users
 	creatorID := value.GenerateUserID()
 	creator, _ := aggregate.NewUser(creatorID, "creator@example.com", "Creator", "User")
-	container.UserRepository.Save(creator)
+	container.UserRepository.Save(ctx, creator)
 
 	assigneeID := value.GenerateUserID()
 	assignee, _ := aggregate.NewUser(assigneeID, "assignee@example.com", "Assignee", "User")
-	container.UserRepository.Save(assignee)
+	assignee.VerifyEmail()
+	container.UserRepository.Save(ctx, assignee)
 
-	// Create task
-	taskID := value.GenerateTaskID()
+	// Create project and task
 	projectID := value.GenerateProjectID()
+	project, _ := aggregate.NewProject(projectID, "Test Project", "", creatorID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	taskID := value.GenerateTaskID()
 	priority, _ := value.NewPriority("MEDIUM")
 	task, _ := aggregate.NewTask(taskID, projectID, "Test Task", "Description", priority, creatorID)
-	container.TaskRepository.Save(task)
+	container.TaskRepository.Save(ctx, task)
 
 	// Create command
 	cmd := command.AssignTaskCommand{
@@ -109,7 +132,7 @@ func TestAssignTaskCommandFlow(t *testing.T) {
 	}
 
 	// Execute
-	result, err := container.AssignTaskCommandHandler.Handle(cmd)
+	result, err := container.AssignTaskCommandHandler.Handle(context.Background(), cmd)
 
 	// Verify
 	if err != nil {
@@ -121,7 +144,7 @@ func TestAssignTaskCommandFlow(t *testing.T) {
 	}
 
 	// Verify task was updated
-	updatedTask, _ := container.TaskRepository.GetByID(taskID)
+	updatedTask, _ := container.TaskRepository.GetByID(ctx, taskID)
 	if updatedTask.Assignee() == nil {
 		t.Error("Expected task to be assigned")
 	}
@@ -129,6 +152,7 @@ func TestAssignTaskCommandFlow(t *testing.T) {
 
 // TestUpdateTaskStatusCommandFlow tests the complete update status command flow
 func TestUpdateTaskStatusCommandFlow(t *testing.T) {
+	ctx := context.Background()
 	// Setup
 	container := di.NewContainer()
 
@@ -139,7 +163,10 @@ func TestUpdateTaskStatusCommandFlow(t *testing.T) {
 	priority, _ := value.NewPriority("LOW")
 
 	user, _ := aggregate.NewUser(userID, "test@example.com", "Test", "User")
-	container.UserRepository.Save(user)
+	container.UserRepository.Save(ctx, user)
+
+	project, _ := aggregate.NewProject(projectID, "Test Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
 
 	task, _ := aggregate.NewTask(taskID, projectID, "Test Task", "Description", priority, userID)
 	// Assign task first (business rule: must be assigned before IN_PROGRESS)
@@ -147,17 +174,18 @@ func TestUpdateTaskStatusCommandFlow(t *testing.T) {
 	// Set deadline (business rule: must have deadline before completion)
 	futureDate := time.Now().AddDate(0, 0, 7) // 7 days from now
 	deadline, _ := value.NewDeadline(futureDate)
-	task.SetDeadline(deadline)
-	container.TaskRepository.Save(task)
+	task.SetDeadline(deadline, userID, "")
+	container.TaskRepository.Save(ctx, task)
 
 	// Create command
 	cmd := command.UpdateTaskStatusCommand{
-		TaskID:    taskID.Value(),
-		NewStatus: "IN_PROGRESS",
+		TaskID:      taskID.Value(),
+		NewStatus:   "IN_PROGRESS",
+		RequestedBy: userID.Value(),
 	}
 
 	// Execute
-	result, err := container.UpdateTaskStatusCommandHandler.Handle(cmd)
+	result, err := container.UpdateTaskStatusCommandHandler.Handle(context.Background(), cmd)
 
 	// Verify
 	if err != nil {
@@ -169,8 +197,2930 @@ func TestUpdateTaskStatusCommandFlow(t *testing.T) {
 	}
 
 	// Verify task was updated
-	updatedTask, _ := container.TaskRepository.GetByID(taskID)
+	updatedTask, _ := container.TaskRepository.GetByID(ctx, taskID)
 	if updatedTask.Status() != value.TaskStatusInProgress {
 		t.Errorf("Expected status IN_PROGRESS, got %s", updatedTask.Status().Value())
 	}
-}
\ No newline at end of file
+}
+// TestLinkTasksCommandFlow tests that links are recorded on both tasks
+func TestLinkTasksCommandFlow(t *testing.T) {
+	ctx := context.Background()
+	// Setup
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "links@example.com", "Link", "User")
+	container.UserRepository.Save(ctx, user)
+
+	projectID := value.GenerateProjectID()
+	project, _ := aggregate.NewProject(projectID, "Link Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	priority, _ := value.NewPriority("MEDIUM")
+
+	original, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Original", "Description", priority, userID)
+	duplicate, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Duplicate", "Description", priority, userID)
+	container.TaskRepository.Save(ctx, original)
+	container.TaskRepository.Save(ctx, duplicate)
+
+	// Execute
+	_, err := container.LinkTasksCommandHandler.Handle(context.Background(), command.LinkTasksCommand{
+		TaskID:       duplicate.ID().Value(),
+		TargetTaskID: original.ID().Value(),
+		LinkType:     "DUPLICATES",
+		LinkedBy:     userID.Value(),
+	})
+
+	// Verify
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !storedTask(t, container, duplicate.ID()).HasLink(original.ID(), value.LinkTypeDuplicates) {
+		t.Error("Expected duplicate to link to original")
+	}
+
+	if !storedTask(t, container, original.ID()).HasLink(duplicate.ID(), value.LinkTypeDuplicatedBy) {
+		t.Error("Expected original to carry the inverse link")
+	}
+
+	// Unlink
+	_, err = container.UnlinkTasksCommandHandler.Handle(context.Background(), command.UnlinkTasksCommand{
+		TaskID:       duplicate.ID().Value(),
+		TargetTaskID: original.ID().Value(),
+		LinkType:     "DUPLICATES",
+		RequestedBy:  userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error unlinking, got %v", err)
+	}
+
+	if len(storedTask(t, container, original.ID()).Links()) != 0 || len(storedTask(t, container, duplicate.ID()).Links()) != 0 {
+		t.Error("Expected links to be removed from both tasks")
+	}
+}
+
+// TestCompareAndSetTaskStatusFlow tests that a stale expectation reports the actual status
+func TestCompareAndSetTaskStatusFlow(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	taskID := value.GenerateTaskID()
+	priority, _ := value.NewPriority("LOW")
+
+	user, _ := aggregate.NewUser(userID, "test@example.com", "Test", "User")
+	container.UserRepository.Save(ctx, user)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Test Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	task, _ := aggregate.NewTask(taskID, project.ID(), "Test Task", "", priority, userID)
+	task.Assign(userID, userID)
+	container.TaskRepository.Save(ctx, task)
+
+	cmd := command.CompareAndSetTaskStatusCommand{
+		TaskID:         taskID.Value(),
+		ExpectedStatus: "TO_DO",
+		NewStatus:      "IN_PROGRESS",
+		RequestedBy:    userID.Value(),
+	}
+
+	result, err := container.CompareAndSetTaskStatusCommandHandler.Handle(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Applied || result.CurrentStatus != "IN_PROGRESS" {
+		t.Fatalf("Expected applied change to IN_PROGRESS, got %+v", result)
+	}
+
+	// Replaying the same expectation must not apply
+	result, err = container.CompareAndSetTaskStatusCommandHandler.Handle(context.Background(), cmd)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Applied || result.CurrentStatus != "IN_PROGRESS" {
+		t.Errorf("Expected rejected change reporting IN_PROGRESS, got %+v", result)
+	}
+}
+
+// TestCompareAndSetTaskStatusAppliesOnceUnderContention tests that of many concurrent
+// compare-and-sets with the same expectation exactly one applies
+func TestCompareAndSetTaskStatusAppliesOnceUnderContention(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	priority, _ := value.NewPriority("LOW")
+	user, _ := aggregate.NewUser(userID, "contention@example.com", "Contended", "User")
+	container.UserRepository.Save(ctx, user)
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Contended Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Contended Task", "", priority, userID)
+	task.Assign(userID, userID)
+	container.TaskRepository.Save(ctx, task)
+
+	// Every request reads the task before any of them stores its change
+	const attempts = 8
+	tasks := &gatedTaskRepository{TaskRepository: container.TaskRepository}
+	tasks.readers.Add(attempts)
+	handler := command.NewCompareAndSetTaskStatusCommandHandler(
+		tasks, container.ProjectRepository, container.EventPublisher, container.StatusTransitionService, container.Authorizer)
+
+	var wg sync.WaitGroup
+	applied := make(chan bool, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := handler.Handle(context.Background(), command.CompareAndSetTaskStatusCommand{
+				TaskID:         task.ID().Value(),
+				ExpectedStatus: "TO_DO",
+				NewStatus:      "IN_PROGRESS",
+				RequestedBy:    userID.Value(),
+			})
+			switch {
+			case errors.Is(err, errs.ErrConcurrencyConflict):
+				applied <- false
+			case err != nil:
+				t.Errorf("Expected an applied, rejected or conflicting change, got %v", err)
+			default:
+				applied <- result.Applied
+			}
+		}()
+	}
+	wg.Wait()
+	close(applied)
+
+	count := 0
+	for ok := range applied {
+		if ok {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected exactly one change to apply, got %d", count)
+	}
+
+	events, _ := container.EventStore.GetEvents(ctx, task.ID().Value())
+	changes := 0
+	for _, evt := range events {
+		if evt.EventType() == "TaskStatusChanged" {
+			changes++
+		}
+	}
+	if changes != 1 {
+		t.Errorf("Expected one status change stored, got %d", changes)
+	}
+}
+
+// TestSprintLifecycleFlow tests that completing a sprint carries unfinished tasks back to the backlog
+func TestSprintLifecycleFlow(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "sprints@example.com", "Sprint", "Owner")
+	container.UserRepository.Save(ctx, user)
+	priority, _ := value.NewPriority("MEDIUM")
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Sprint Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	open, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Open task", "", priority, userID)
+	cancelled, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Cancelled task", "", priority, userID)
+	cancelled.ChangeStatus(value.TaskStatusCancelled)
+	container.TaskRepository.Save(ctx, open)
+	container.TaskRepository.Save(ctx, cancelled)
+
+	start := time.Now()
+	created, err := container.CreateSprintCommandHandler.Handle(context.Background(), command.CreateSprintCommand{
+		ProjectID:   project.ID().Value(),
+		Name:        "Sprint 1",
+		StartDate:   start.Format(time.RFC3339),
+		EndDate:     start.AddDate(0, 0, 14).Format(time.RFC3339),
+		RequestedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sprint: %v", err)
+	}
+
+	for _, task := range []*aggregate.Task{open, cancelled} {
+		_, err = container.AddTaskToSprintCommandHandler.Handle(context.Background(), command.AddTaskToSprintCommand{
+			SprintID:    created.SprintID,
+			TaskID:      task.ID().Value(),
+			RequestedBy: userID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+
+	if _, err = container.StartSprintCommandHandler.Handle(context.Background(), command.StartSprintCommand{
+		SprintID: created.SprintID, RequestedBy: userID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to start sprint: %v", err)
+	}
+
+	result, err := container.CompleteSprintCommandHandler.Handle(context.Background(), command.CompleteSprintCommand{
+		SprintID: created.SprintID, RequestedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to complete sprint: %v", err)
+	}
+
+	if len(result.CarriedOverTaskIDs) != 1 || result.CarriedOverTaskIDs[0] != open.ID().Value() {
+		t.Errorf("Expected open task to be carried over, got %v", result.CarriedOverTaskIDs)
+	}
+
+	tasks, _ := container.ListSprintTasksQueryHandler.Handle(context.Background(), query.ListSprintTasksQuery{SprintID: created.SprintID})
+	if len(tasks) != 1 || tasks[0].ID != cancelled.ID().Value() {
+		t.Errorf("Expected only the closed task to remain in the sprint, got %d tasks", len(tasks))
+	}
+}
+
+// TestMilestoneReachedWhenTasksComplete tests that completing the last open task reaches a milestone
+func TestMilestoneReachedWhenTasksComplete(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	priority, _ := value.NewPriority("MEDIUM")
+	user, _ := aggregate.NewUser(userID, "milestones@example.com", "Milestone", "Owner")
+	container.UserRepository.Save(ctx, user)
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Milestone Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	inReview, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Review task", "", priority, userID)
+	inReview.Assign(userID, userID)
+	deadline, _ := value.NewDeadline(time.Now().AddDate(0, 0, 7))
+	inReview.SetDeadline(deadline, userID, "")
+	inReview.ChangeStatus(value.TaskStatusInProgress)
+	inReview.ChangeStatus(value.TaskStatusInReview)
+	cancelled, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Dropped task", "", priority, userID)
+	cancelled.ChangeStatus(value.TaskStatusCancelled)
+	container.TaskRepository.Save(ctx, inReview)
+	container.TaskRepository.Save(ctx, cancelled)
+
+	created, err := container.CreateMilestoneCommandHandler.Handle(context.Background(), command.CreateMilestoneCommand{
+		ProjectID:   project.ID().Value(),
+		Name:        "Beta",
+		DueDate:     time.Now().AddDate(0, 1, 0).Format(time.RFC3339),
+		TaskIDs:     []string{inReview.ID().Value(), cancelled.ID().Value()},
+		RequestedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create milestone: %v", err)
+	}
+
+	milestones, _ := container.ListMilestonesQueryHandler.Handle(context.Background(), query.ListMilestonesQuery{ProjectID: project.ID().Value()})
+	if len(milestones) != 1 || milestones[0].Progress.Total != 1 || milestones[0].ReachedAt != nil {
+		t.Fatalf("Expected one open milestone counting only the uncancelled task, got %+v", milestones)
+	}
+
+	_, err = container.UpdateTaskStatusCommandHandler.Handle(context.Background(), command.UpdateTaskStatusCommand{
+		TaskID:      inReview.ID().Value(),
+		NewStatus:   "COMPLETED",
+		RequestedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+
+	milestones, _ = container.ListMilestonesQueryHandler.Handle(context.Background(), query.ListMilestonesQuery{ProjectID: project.ID().Value()})
+	if milestones[0].ID != created.MilestoneID || milestones[0].ReachedAt == nil || milestones[0].Progress.Percent != 100 {
+		t.Errorf("Expected milestone to be reached at 100%%, got %+v", milestones[0])
+	}
+
+	events, _ := container.EventStore.GetAllEvents(ctx)
+	reached := 0
+	for _, evt := range events {
+		if evt.EventType() == "MilestoneReached" {
+			reached++
+		}
+	}
+	if reached != 1 {
+		t.Errorf("Expected one MilestoneReached event, got %d", reached)
+	}
+}
+
+// TestArchiveProjectCascadesToOpenTasks tests that archiving freezes open tasks and blocks new ones
+func TestArchiveProjectCascadesToOpenTasks(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	priority, _ := value.NewPriority("MEDIUM")
+	user, _ := aggregate.NewUser(userID, "archive@example.com", "Archive", "Owner")
+	container.UserRepository.Save(ctx, user)
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Archive Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	open, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Open task", "", priority, userID)
+	cancelled, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Cancelled task", "", priority, userID)
+	cancelled.ChangeStatus(value.TaskStatusCancelled)
+	container.TaskRepository.Save(ctx, open)
+	container.TaskRepository.Save(ctx, cancelled)
+
+	result, err := container.ArchiveProjectCommandHandler.Handle(context.Background(), command.ArchiveProjectCommand{
+		ProjectID:   project.ID().Value(),
+		RequestedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to archive project: %v", err)
+	}
+
+	if len(result.AffectedTaskIDs) != 1 || result.AffectedTaskIDs[0] != open.ID().Value() {
+		t.Errorf("Expected only the open task to be affected, got %v", result.AffectedTaskIDs)
+	}
+
+	frozen, _ := container.TaskRepository.GetByID(ctx, open.ID())
+	if !frozen.IsFrozen() || frozen.ChangeStatus(value.TaskStatusBacklog) == nil {
+		t.Error("Expected open task to be frozen")
+	}
+
+	_, err = container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Late task",
+		Priority:  "LOW",
+		CreatedBy: userID.Value(),
+	})
+	if err == nil {
+		t.Error("Expected task creation in an archived project to fail")
+	}
+}
+
+// TestUpdateAndDeleteProject tests renaming a project and deleting it along with its tasks
+func TestUpdateAndDeleteProject(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "owner@example.com", "Project", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "admin@example.com", "Project", "Admin")
+	container.UserRepository.Save(ctx, admin)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Draft", "Old", ownerID, value.GenerateWorkflowID())
+	project.AssignRole(adminID, value.ProjectRoleAdmin)
+	container.ProjectRepository.Save(ctx, project)
+
+	name := "Launch"
+	updated, err := container.UpdateProjectCommandHandler.Handle(context.Background(), command.UpdateProjectCommand{
+		ProjectID:   project.ID().Value(),
+		Name:        &name,
+		RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to update project: %v", err)
+	}
+	if updated.Name != "Launch" || updated.Description != "Old" {
+		t.Errorf("Expected only the name to change, got %q / %q", updated.Name, updated.Description)
+	}
+
+	created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Checklist",
+		Priority:  "LOW",
+		CreatedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// Project admins manage the project, but only its owner deletes it
+	_, err = container.DeleteProjectCommandHandler.Handle(context.Background(), command.DeleteProjectCommand{
+		ProjectID:   project.ID().Value(),
+		RequestedBy: adminID.Value(),
+	})
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("Expected a project admin to be denied deleting the project, got %v", err)
+	}
+
+	deleted, err := container.DeleteProjectCommandHandler.Handle(context.Background(), command.DeleteProjectCommand{
+		ProjectID:   project.ID().Value(),
+		RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to delete project: %v", err)
+	}
+	if len(deleted.DeletedTaskIDs) != 1 || deleted.DeletedTaskIDs[0] != created.TaskID {
+		t.Errorf("Expected the project's task to be deleted with it, got %v", deleted.DeletedTaskIDs)
+	}
+
+	if _, err := container.ProjectRepository.GetByID(ctx, project.ID()); err == nil {
+		t.Error("Expected the project to be gone")
+	}
+	taskID, _ := value.NewTaskID(created.TaskID)
+	if _, err := container.TaskRepository.GetByID(ctx, taskID); err == nil {
+		t.Error("Expected the task to be gone")
+	}
+
+	events, _ := container.EventStore.GetEvents(ctx, project.ID().Value())
+	if events[len(events)-1].EventType() != "ProjectDeleted" {
+		t.Errorf("Expected a ProjectDeleted event, got %s", events[len(events)-1].EventType())
+	}
+}
+
+// TestProjectSettingsApplyToTaskCommands tests that task commands consult project settings
+func TestProjectSettingsApplyToTaskCommands(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "settings@example.com", "Settings", "User")
+	user.VerifyEmail()
+	container.UserRepository.Save(ctx, user)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Settings Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	_, err := container.SetProjectSettingsCommandHandler.Handle(context.Background(), command.SetProjectSettingsCommand{
+		ProjectID:               project.ID().Value(),
+		DefaultPriority:         "HIGH",
+		DefaultAssigneeID:       userID.Value(),
+		RequireDeadlineOnCreate: true,
+		AllowComments:           false,
+		RequestedBy:             userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to set project settings: %v", err)
+	}
+
+	_, err = container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "No deadline",
+		CreatedBy: userID.Value(),
+	})
+	if err == nil {
+		t.Error("Expected task creation without a deadline to fail")
+	}
+
+	result, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "With deadline",
+		Deadline:  time.Now().AddDate(0, 0, 1).Format(time.RFC3339),
+		CreatedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	taskID, _ := value.NewTaskID(result.TaskID)
+	task, _ := container.TaskRepository.GetByID(ctx, taskID)
+	if task.Priority() != value.PriorityHigh {
+		t.Errorf("Expected default priority HIGH, got %s", task.Priority())
+	}
+	if task.Assignee() == nil || !task.Assignee().AssigneeID().Equals(userID) {
+		t.Error("Expected task to be assigned to the default assignee")
+	}
+
+	_, err = container.AddCommentCommandHandler.Handle(context.Background(), command.AddCommentCommand{
+		TaskID:   result.TaskID,
+		AuthorID: userID.Value(),
+		Content:  "Not allowed",
+	})
+	if err == nil {
+		t.Error("Expected comment to be rejected when comments are disabled")
+	}
+}
+
+// TestEditLockGuardsDescriptionEdits tests the edit lock flow through the command handlers
+func TestEditLockGuardsDescriptionEdits(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	aliceID := value.GenerateUserID()
+	alice, _ := aggregate.NewUser(aliceID, "alice@example.com", "Alice", "Smith")
+	container.UserRepository.Save(ctx, alice)
+	bobID := value.GenerateUserID()
+	bob, _ := aggregate.NewUser(bobID, "bob@example.com", "Bob", "Jones")
+	container.UserRepository.Save(ctx, bob)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Specs", "", aliceID, value.GenerateWorkflowID())
+	project.AssignRole(bobID, value.ProjectRoleMember)
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Spec", "", priority, aliceID)
+	container.TaskRepository.Save(ctx, task)
+
+	result, err := container.EditLockCommandHandler.Handle(context.Background(), command.EditLockCommand{
+		TaskID: task.ID().Value(),
+		UserID: aliceID.Value(),
+		Action: command.EditLockAcquire,
+	})
+	if err != nil || result.ExpiresAt == nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	_, err = container.UpdateTaskDescriptionCommandHandler.Handle(context.Background(), command.UpdateTaskDescriptionCommand{
+		TaskID:      task.ID().Value(),
+		EditorID:    bobID.Value(),
+		Description: "Overwritten",
+	})
+	if err == nil {
+		t.Error("Expected description edit to be refused while alice holds the lock")
+	}
+
+	taskDTO, _ := container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{TaskID: task.ID().Value()})
+	if taskDTO.EditLock == nil || taskDTO.EditLock.HolderID != aliceID.Value() {
+		t.Errorf("Expected lock state in the task DTO, got %+v", taskDTO.EditLock)
+	}
+
+	_, err = container.EditLockCommandHandler.Handle(context.Background(), command.EditLockCommand{
+		TaskID: task.ID().Value(),
+		UserID: aliceID.Value(),
+		Action: command.EditLockRelease,
+	})
+	if err != nil {
+		t.Fatalf("Failed to release lock: %v", err)
+	}
+
+	_, err = container.UpdateTaskDescriptionCommandHandler.Handle(context.Background(), command.UpdateTaskDescriptionCommand{
+		TaskID:      task.ID().Value(),
+		EditorID:    bobID.Value(),
+		Description: "Edited",
+	})
+	if err != nil {
+		t.Errorf("Expected edit to succeed after release, got %v", err)
+	}
+}
+
+// TestRecordingCostsPastBudgetRaisesBudgetExceeded tests budget tracking through the command handlers
+func TestRecordingCostsPastBudgetRaisesBudgetExceeded(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	exceeded := 0
+	container.EventSubscriber.Subscribe("BudgetExceeded", func(evt event.DomainEvent) error {
+		exceeded++
+		return nil
+	})
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "budget@example.com", "Budget", "Owner")
+	container.UserRepository.Save(ctx, user)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Budget Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Buy licenses", "", priority, userID)
+	container.TaskRepository.Save(ctx, task)
+
+	_, err := container.SetProjectBudgetCommandHandler.Handle(context.Background(), command.SetProjectBudgetCommand{
+		ProjectID:   project.ID().Value(),
+		Amount:      "100.00",
+		Currency:    "USD",
+		RequestedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to set budget: %v", err)
+	}
+
+	_, err = container.RecordTaskCostCommandHandler.Handle(context.Background(), command.RecordTaskCostCommand{
+		TaskID: task.ID().Value(), Amount: "10", Currency: "EUR", RecordedBy: userID.Value(),
+	})
+	if err == nil {
+		t.Error("Expected a cost in another currency than the budget to be rejected")
+	}
+
+	for _, amount := range []string{"60", "50.50", "5"} {
+		_, err = container.RecordTaskCostCommandHandler.Handle(context.Background(), command.RecordTaskCostCommand{
+			TaskID: task.ID().Value(), Amount: amount, Currency: "USD", Description: "License", RecordedBy: userID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to record cost %s: %v", amount, err)
+		}
+	}
+
+	if exceeded != 1 {
+		t.Errorf("Expected BudgetExceeded to be raised once, got %d", exceeded)
+	}
+
+	summary, err := container.GetProjectBudgetQueryHandler.Handle(context.Background(), query.GetProjectBudgetQuery{ProjectID: project.ID().Value()})
+	if err != nil {
+		t.Fatalf("Failed to get budget summary: %v", err)
+	}
+	if !summary.Exceeded || summary.Spent.Amount != "115.50" || summary.Remaining.Amount != "-15.50" {
+		t.Errorf("Unexpected summary: exceeded=%v spent=%s remaining=%s", summary.Exceeded, summary.Spent.Amount, summary.Remaining.Amount)
+	}
+	if len(summary.Tasks) != 1 {
+		t.Errorf("Expected 1 task with costs, got %d", len(summary.Tasks))
+	}
+}
+
+// TestPasswordAuthenticationFlow tests registering, signing in and changing a password
+func TestPasswordAuthenticationFlow(t *testing.T) {
+	container := di.NewContainer()
+
+	registered, err := container.RegisterUserCommandHandler.Handle(context.Background(), command.RegisterUserCommand{
+		Email:     "auth@example.com",
+		FirstName: "Auth",
+		LastName:  "User",
+		Password:  "first-password",
+	})
+	if err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	_, err = container.RegisterUserCommandHandler.Handle(context.Background(), command.RegisterUserCommand{
+		Email: "auth@example.com", FirstName: "Other", LastName: "User", Password: "other-password",
+	})
+	if err == nil {
+		t.Error("Expected registering the same email twice to fail")
+	}
+
+	_, err = container.LoginCommandHandler.Handle(context.Background(), command.LoginCommand{Email: "auth@example.com", Password: "wrong-password"})
+	if err == nil || err.Error() != "invalid email or password" {
+		t.Errorf("Expected a wrong password to be refused, got %v", err)
+	}
+
+	session, err := container.LoginCommandHandler.Handle(context.Background(), command.LoginCommand{Email: "auth@example.com", Password: "first-password"})
+	if err != nil {
+		t.Fatalf("Failed to log in: %v", err)
+	}
+	if session.UserID != registered.UserID {
+		t.Errorf("Expected session for %s, got %s", registered.UserID, session.UserID)
+	}
+
+	changed, err := container.ChangePasswordCommandHandler.Handle(context.Background(), command.ChangePasswordCommand{
+		UserID:          registered.UserID,
+		CurrentPassword: "first-password",
+		NewPassword:     "second-password",
+	})
+	if err != nil {
+		t.Fatalf("Failed to change password: %v", err)
+	}
+	if changed.RevokedSessions != 1 {
+		t.Errorf("Expected 1 revoked session, got %d", changed.RevokedSessions)
+	}
+	if _, ok := container.SessionStore.Resolve(session.Token); ok {
+		t.Error("Expected the old session to end with the password change")
+	}
+
+	if _, err := container.LoginCommandHandler.Handle(context.Background(), command.LoginCommand{Email: "auth@example.com", Password: "first-password"}); err == nil {
+		t.Error("Expected the old password to be refused")
+	}
+	if _, err := container.LoginCommandHandler.Handle(context.Background(), command.LoginCommand{Email: "auth@example.com", Password: "second-password"}); err != nil {
+		t.Errorf("Expected the new password to sign in, got %v", err)
+	}
+}
+
+// TestCommandsAbortWhenTheRequestTimedOut tests that an expired context stops a command before it saves
+func TestCommandsAbortWhenTheRequestTimedOut(t *testing.T) {
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "late@example.com", "Late", "User")
+	container.UserRepository.Save(context.Background(), user)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(context.Background(), project)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	_, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Never saved",
+		Priority:  "LOW",
+		CreatedBy: userID.Value(),
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the command to abort with the deadline, got %v", err)
+	}
+
+	tasks, _ := container.TaskRepository.GetByProjectID(context.Background(), project.ID())
+	if len(tasks) != 0 {
+		t.Errorf("Expected no task to be saved, got %d", len(tasks))
+	}
+}
+
+// TestQueriesAbortWhenTheRequestWasCancelled tests that a cancelled context stops a query before it reads
+func TestQueriesAbortWhenTheRequestWasCancelled(t *testing.T) {
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Project", "", userID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(context.Background(), project)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := container.GetProjectStatsQueryHandler.Handle(ctx, query.GetProjectStatsQuery{ProjectID: project.ID().Value()}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the query to abort with the cancellation, got %v", err)
+	}
+	if _, err := taskmanagement.NewQueryBus(container).Ask(ctx, query.VerifyIntegrityQuery{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the query bus to pass the context on, got %v", err)
+	}
+}
+
+// TestProjectRolesGateTaskDeletionAndWorkflowChanges tests that only project admins delete tasks or change workflows
+func TestProjectRolesGateTaskDeletionAndWorkflowChanges(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "owner@example.com", "Project", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	memberID := value.GenerateUserID()
+	member, _ := aggregate.NewUser(memberID, "member@example.com", "Team", "Member")
+	container.UserRepository.Save(ctx, member)
+
+	workflowID := value.GenerateWorkflowID()
+	workflow, _ := aggregate.NewWorkflow(workflowID, "Kanban", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("TO_DO", "To Do", 1, false),
+		aggregate.NewWorkflowStatus("DONE", "Done", 2, true),
+	})
+	container.WorkflowRepository.Save(ctx, workflow)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Roles", "", ownerID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Disposable",
+		Priority:  "LOW",
+		CreatedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	_, err = container.AssignProjectRoleCommandHandler.Handle(context.Background(), command.AssignProjectRoleCommand{
+		ProjectID:   project.ID().Value(),
+		UserID:      memberID.Value(),
+		Role:        "MEMBER",
+		RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to assign role: %v", err)
+	}
+
+	// Members can neither delete tasks nor change the workflow
+	_, err = container.DeleteTaskCommandHandler.Handle(context.Background(), command.DeleteTaskCommand{
+		TaskID:      created.TaskID,
+		RequestedBy: memberID.Value(),
+	})
+	if err == nil {
+		t.Fatal("Expected a member to be denied deleting a task")
+	}
+
+	_, err = container.ChangeProjectWorkflowCommandHandler.Handle(context.Background(), command.ChangeProjectWorkflowCommand{
+		ProjectID:   project.ID().Value(),
+		WorkflowID:  workflowID.Value(),
+		RequestedBy: memberID.Value(),
+	})
+	if err == nil {
+		t.Fatal("Expected a member to be denied changing the workflow")
+	}
+
+	// Promoting the member to admin allows both
+	_, err = container.AssignProjectRoleCommandHandler.Handle(context.Background(), command.AssignProjectRoleCommand{
+		ProjectID:   project.ID().Value(),
+		UserID:      memberID.Value(),
+		Role:        "ADMIN",
+		RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to promote member: %v", err)
+	}
+
+	_, err = container.ChangeProjectWorkflowCommandHandler.Handle(context.Background(), command.ChangeProjectWorkflowCommand{
+		ProjectID:   project.ID().Value(),
+		WorkflowID:  workflowID.Value(),
+		RequestedBy: memberID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Expected a project admin to change the workflow, got %v", err)
+	}
+
+	_, err = container.DeleteTaskCommandHandler.Handle(context.Background(), command.DeleteTaskCommand{
+		TaskID:      created.TaskID,
+		RequestedBy: memberID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Expected a project admin to delete the task, got %v", err)
+	}
+
+	updated, _ := container.ProjectRepository.GetByID(ctx, project.ID())
+	if updated.WorkflowID() != workflowID {
+		t.Error("Expected the project to use the new workflow")
+	}
+
+	taskID, _ := value.NewTaskID(created.TaskID)
+	if _, err := container.TaskRepository.GetByID(ctx, taskID); err == nil {
+		t.Error("Expected the task to be deleted")
+	}
+}
+
+// TestSoftDeletedTasksAreKeptOutOfLookups tests that a soft delete keeps the task aside instead of removing it
+func TestSoftDeletedTasksAreKeptOutOfLookups(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+	container.DeleteTaskCommandHandler.SetDeletionMode(command.TaskDeletionSoft)
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "owner@example.com", "Project", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Cleanup", "", ownerID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Obsolete",
+		Priority:  "LOW",
+		CreatedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	_, err = container.DeleteTaskCommandHandler.Handle(context.Background(), command.DeleteTaskCommand{
+		TaskID:      created.TaskID,
+		RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+
+	taskID, _ := value.NewTaskID(created.TaskID)
+	if _, err := container.TaskRepository.GetByID(ctx, taskID); err == nil {
+		t.Error("Expected the deleted task to be left out of lookups")
+	}
+	if tasks, _ := container.TaskRepository.GetByProjectID(ctx, project.ID()); len(tasks) != 0 {
+		t.Errorf("Expected no tasks in the project, got %d", len(tasks))
+	}
+
+	updated, _ := container.ProjectRepository.GetByID(ctx, project.ID())
+	if len(updated.TaskIDs()) != 0 {
+		t.Error("Expected the task to be removed from its project")
+	}
+
+	kept, err := container.TaskRepository.(*repository.InMemoryTaskRepository).GetDeleted(ctx, taskID)
+	if err != nil || !kept.IsDeleted() {
+		t.Fatalf("Expected the task to be kept marked deleted, got %v", err)
+	}
+
+	events, _ := container.EventStore.GetEvents(ctx, created.TaskID)
+	if events[len(events)-1].EventType() != "TaskDeleted" {
+		t.Errorf("Expected a TaskDeleted event, got %s", events[len(events)-1].EventType())
+	}
+}
+
+// TestOnlyTheAssigneeStartsATask tests that starting work is reserved for the assignee
+func TestOnlyTheAssigneeStartsATask(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "lead@example.com", "Team", "Lead")
+	container.UserRepository.Save(ctx, owner)
+
+	assigneeID := value.GenerateUserID()
+	assignee, _ := aggregate.NewUser(assigneeID, "dev@example.com", "Dev", "Eloper")
+	container.UserRepository.Save(ctx, assignee)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Start", "", ownerID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Build it", "", priority, ownerID)
+	task.Assign(assigneeID, ownerID)
+	container.TaskRepository.Save(ctx, task)
+
+	start := func(requestedBy value.UserID) error {
+		_, err := container.UpdateTaskStatusCommandHandler.Handle(context.Background(), command.UpdateTaskStatusCommand{
+			TaskID:      task.ID().Value(),
+			NewStatus:   "IN_PROGRESS",
+			RequestedBy: requestedBy.Value(),
+		})
+		return err
+	}
+
+	if err := start(ownerID); err == nil {
+		t.Fatal("Expected the project owner to be denied starting someone else's task")
+	}
+
+	if err := start(assigneeID); err != nil {
+		t.Fatalf("Expected the assignee to start the task, got %v", err)
+	}
+}
+
+// TestReassignAllTasksMovesOpenTasksAtomically tests bulk reassignment scoping, reporting and all-or-nothing permission checks
+func TestReassignAllTasksMovesOpenTasksAtomically(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	newUser := func(email string) value.UserID {
+		userID := value.GenerateUserID()
+		user, _ := aggregate.NewUser(userID, email, "Team", "Mate")
+		user.VerifyEmail()
+		container.UserRepository.Save(ctx, user)
+		return userID
+	}
+	leaverID := newUser("leaver@example.com")
+	successorID := newUser("successor@example.com")
+	leadID := newUser("lead@example.com")
+	otherLeadID := newUser("other-lead@example.com")
+
+	web, _ := aggregate.NewProject(value.GenerateProjectID(), "Web", "", leadID, value.GenerateWorkflowID())
+	mobile, _ := aggregate.NewProject(value.GenerateProjectID(), "Mobile", "", otherLeadID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, web)
+	container.ProjectRepository.Save(ctx, mobile)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	newTask := func(projectID value.ProjectID, title string) *aggregate.Task {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, title, "", priority, leadID)
+		task.Assign(leaverID, leadID)
+		task.ClearDomainEvents()
+		container.TaskRepository.Save(ctx, task)
+		return task
+	}
+	webOpen := newTask(web.ID(), "Web open")
+	webDone := newTask(web.ID(), "Web cancelled")
+	webDone.ChangeStatus(value.TaskStatusCancelled)
+	container.TaskRepository.Update(ctx, webDone)
+	mobileOpen := newTask(mobile.ID(), "Mobile open")
+
+	reassign := func(projectID string, requestedBy value.UserID) (*command.ReassignAllTasksResult, error) {
+		return container.ReassignAllTasksCommandHandler.Handle(context.Background(), command.ReassignAllTasksCommand{
+			FromUserID:  leaverID.Value(),
+			ToUserID:    successorID.Value(),
+			ProjectID:   projectID,
+			RequestedBy: requestedBy.Value(),
+		})
+	}
+
+	// The web lead cannot move the mobile task, so nothing moves
+	if _, err := reassign("", leadID); err == nil {
+		t.Fatal("Expected a reassignment spanning a foreign project to be denied")
+	}
+	if !storedTask(t, container, webOpen.ID()).Assignee().IsAssignedTo(leaverID) {
+		t.Fatal("Expected a denied reassignment to leave every task untouched")
+	}
+
+	// Scoped to their own project it succeeds
+	result, err := reassign(web.ID().Value(), leadID)
+	if err != nil {
+		t.Fatalf("Failed to reassign web tasks: %v", err)
+	}
+	if len(result.Reassigned) != 1 || result.Reassigned[0].TaskID != webOpen.ID().Value() || result.ByProject[web.ID().Value()] != 1 {
+		t.Errorf("Expected only the open web task to move, got %+v", result)
+	}
+	if !storedTask(t, container, webDone.ID()).Assignee().IsAssignedTo(leaverID) || !storedTask(t, container, mobileOpen.ID()).Assignee().IsAssignedTo(leaverID) {
+		t.Error("Expected closed and out-of-scope tasks to stay")
+	}
+
+	events, _ := container.EventStore.GetEvents(ctx, webOpen.ID().Value())
+	if len(events) != 1 || events[0].EventType() != "TaskAssigned" {
+		t.Errorf("Expected one TaskAssigned event for the moved task, got %d events", len(events))
+	}
+}
+
+// gatedTaskRepository holds every task read back until the expected number of readers read
+type gatedTaskRepository struct {
+	domain.TaskRepository
+	readers sync.WaitGroup
+}
+
+func (r *gatedTaskRepository) GetByID(ctx context.Context, id value.TaskID) (*aggregate.Task, error) {
+	task, err := r.TaskRepository.GetByID(ctx, id)
+	r.readers.Done()
+	r.readers.Wait()
+	return task, err
+}
+
+// storedTask reads a task back as the commands left it in the repository
+func storedTask(t *testing.T, container *di.Container, id value.TaskID) *aggregate.Task {
+	t.Helper()
+
+	task, err := container.TaskRepository.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Failed to read task %s: %v", id.Value(), err)
+	}
+	return task
+}
+
+// stubbornTaskRepository fails the failAt-th task update, counting from the first
+type stubbornTaskRepository struct {
+	domain.TaskRepository
+	failAt  int
+	updates int
+}
+
+func (r *stubbornTaskRepository) Update(ctx context.Context, task *aggregate.Task) error {
+	r.updates++
+	if r.updates == r.failAt {
+		return errors.New("disk full")
+	}
+	return r.TaskRepository.Update(ctx, task)
+}
+
+// TestReassignAllTasksRollsBackWhenATaskCannotBeSaved tests that a failed save undoes the tasks moved before it
+func TestReassignAllTasksRollsBackWhenATaskCannotBeSaved(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	newUser := func(email string) value.UserID {
+		userID := value.GenerateUserID()
+		user, _ := aggregate.NewUser(userID, email, "Team", "Mate")
+		user.VerifyEmail()
+		container.UserRepository.Save(ctx, user)
+		return userID
+	}
+	leaverID := newUser("leaver@example.com")
+	successorID := newUser("successor@example.com")
+	leadID := newUser("lead@example.com")
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Web", "", leadID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	taskIDs := make([]value.TaskID, 0, 3)
+	for _, title := range []string{"First", "Second", "Third"} {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), title, "", priority, leadID)
+		task.Assign(leaverID, leadID)
+		task.ClearDomainEvents()
+		container.TaskRepository.Save(ctx, task)
+		taskIDs = append(taskIDs, task.ID())
+	}
+
+	tasks := &stubbornTaskRepository{TaskRepository: container.TaskRepository, failAt: 3}
+	unitOfWork := repository.NewInMemoryUnitOfWorkFactory(repository.UnitOfWorkRepositories{
+		Tasks:     tasks,
+		Projects:  container.ProjectRepository,
+		Users:     container.UserRepository,
+		Workflows: container.WorkflowRepository,
+	}, container.EventPublisher.(repository.Outbox))
+	handler := command.NewReassignAllTasksCommandHandler(unitOfWork, container.TaskAssignmentService, container.Authorizer)
+
+	assigned := 0
+	container.EventSubscriber.Subscribe("TaskAssigned", func(evt event.DomainEvent) error {
+		assigned++
+		return nil
+	})
+
+	// The third save fails, so the two tasks saved before it go back to the leaver
+	_, err := handler.Handle(ctx, command.ReassignAllTasksCommand{
+		FromUserID:  leaverID.Value(),
+		ToUserID:    successorID.Value(),
+		RequestedBy: leadID.Value(),
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to save task") {
+		t.Fatalf("Expected the reassignment to fail, got %v", err)
+	}
+	for _, taskID := range taskIDs {
+		stored, _ := container.TaskRepository.GetByID(ctx, taskID)
+		if !stored.Assignee().IsAssignedTo(leaverID) {
+			t.Errorf("Expected task %s rolled back to the leaver", stored.Title())
+		}
+	}
+	if assigned != 0 {
+		t.Errorf("Expected no assignment events from a rolled back reassignment, got %d", assigned)
+	}
+
+	// Once the tasks can be saved, all of them move
+	tasks.failAt = 0
+	result, err := handler.Handle(ctx, command.ReassignAllTasksCommand{
+		FromUserID:  leaverID.Value(),
+		ToUserID:    successorID.Value(),
+		RequestedBy: leadID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to reassign tasks: %v", err)
+	}
+	if len(result.Reassigned) != 3 || assigned != 3 {
+		t.Errorf("Expected 3 tasks moved with 3 events, got %d moved and %d events", len(result.Reassigned), assigned)
+	}
+}
+
+// TestAssignmentCapacityWarnsOrRejects tests the per-user open task limit in both modes
+func TestAssignmentCapacityWarnsOrRejects(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+	container.TaskAssignmentService.SetCapacityLimit(2, service.CapacityModeWarn)
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "busy@example.com", "Busy", "Bee")
+	user.VerifyEmail()
+	container.UserRepository.Save(ctx, user)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Capacity", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("LOW")
+	assign := func() (*command.AssignTaskResult, error) {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Chore", "", priority, userID)
+		container.TaskRepository.Save(ctx, task)
+		return container.AssignTaskCommandHandler.Handle(context.Background(), command.AssignTaskCommand{
+			TaskID:     task.ID().Value(),
+			AssigneeID: userID.Value(),
+			AssignedBy: userID.Value(),
+		})
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := assign()
+		if err != nil || len(result.Warnings) != 0 {
+			t.Fatalf("Expected assignment %d within capacity, got %v %v", i+1, result, err)
+		}
+	}
+
+	// Warn mode still assigns the third task but reports it
+	result, err := assign()
+	if err != nil {
+		t.Fatalf("Expected warn mode to assign, got %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "3 open tasks") {
+		t.Errorf("Expected a capacity warning, got %v", result.Warnings)
+	}
+
+	count, _ := container.TaskAssignmentService.GetAssigneeTaskCount(ctx, userID)
+	if count != 3 {
+		t.Errorf("Expected 3 open tasks, got %d", count)
+	}
+
+	// Reject mode refuses to go further
+	container.TaskAssignmentService.SetCapacityLimit(3, service.CapacityModeReject)
+	if _, err := assign(); err == nil || !strings.Contains(err.Error(), "at capacity") {
+		t.Errorf("Expected reject mode to refuse, got %v", err)
+	}
+}
+
+// TestDeactivatingAUserHandsOffTheirOpenTasks tests the cascade that follows a user deactivation
+func TestDeactivatingAUserHandsOffTheirOpenTasks(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	newUser := func(email string) *aggregate.User {
+		user, _ := aggregate.NewUser(value.GenerateUserID(), email, "Dee", "Activated")
+		user.VerifyEmail()
+		user.ClearDomainEvents()
+		container.UserRepository.Save(ctx, user)
+		return user
+	}
+	admin := newUser("admin@example.com")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	leaver := newUser("leaver@example.com")
+	stayer := newUser("stayer@example.com")
+	fallback := newUser("fallback@example.com")
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Handoff", "", admin.ID(), value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	assigned := func(assignee *aggregate.User) *aggregate.Task {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Handoff", "", priority, admin.ID())
+		task.Assign(assignee.ID(), admin.ID())
+		task.ClearDomainEvents()
+		container.TaskRepository.Save(ctx, task)
+		return task
+	}
+	open := assigned(leaver)
+	done := assigned(leaver)
+	done.ChangeStatus(value.TaskStatusCancelled)
+	container.TaskRepository.Update(ctx, done)
+
+	// Only admins deactivate users
+	_, err := container.DeactivateUserCommandHandler.Handle(ctx, command.DeactivateUserCommand{
+		UserID:      leaver.ID().Value(),
+		RequestedBy: stayer.ID().Value(),
+	})
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("Expected non-admin to be refused, got %v", err)
+	}
+
+	// Without a fallback the open tasks are unassigned, finished ones keep their history
+	if _, err := container.DeactivateUserCommandHandler.Handle(ctx, command.DeactivateUserCommand{
+		UserID:      leaver.ID().Value(),
+		RequestedBy: admin.ID().Value(),
+	}); err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+	if saved, _ := container.TaskRepository.GetByID(ctx, open.ID()); saved.Assignee() != nil {
+		t.Errorf("Expected the open task to be unassigned")
+	}
+	if saved, _ := container.TaskRepository.GetByID(ctx, done.ID()); saved.Assignee() == nil {
+		t.Errorf("Expected the cancelled task to keep its assignee")
+	}
+
+	// Deactivated users cannot be given new work
+	newTask, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "New", "", priority, admin.ID())
+	container.TaskRepository.Save(ctx, newTask)
+	_, err = container.AssignTaskCommandHandler.Handle(ctx, command.AssignTaskCommand{
+		TaskID:     newTask.ID().Value(),
+		AssigneeID: leaver.ID().Value(),
+		AssignedBy: admin.ID().Value(),
+	})
+	if err == nil || !strings.Contains(err.Error(), "deactivated") {
+		t.Errorf("Expected assignment to a deactivated user to fail, got %v", err)
+	}
+
+	// With a fallback the open tasks move to them
+	moved := assigned(stayer)
+	if _, err := container.DeactivateUserCommandHandler.Handle(ctx, command.DeactivateUserCommand{
+		UserID:             stayer.ID().Value(),
+		FallbackAssigneeID: fallback.ID().Value(),
+		RequestedBy:        admin.ID().Value(),
+	}); err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+	if saved, _ := container.TaskRepository.GetByID(ctx, moved.ID()); saved.Assignee() == nil || !saved.Assignee().IsAssignedTo(fallback.ID()) {
+		t.Errorf("Expected the open task to move to the fallback assignee")
+	}
+}
+
+// TestTeamsOwnTasksAndManageMembership tests team membership permissions and team-scoped task listing
+func TestTeamsOwnTasksAndManageMembership(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	newUser := func(email string) value.UserID {
+		userID := value.GenerateUserID()
+		user, _ := aggregate.NewUser(userID, email, "Team", "Mate")
+		container.UserRepository.Save(ctx, user)
+		return userID
+	}
+	leadID := newUser("lead@example.com")
+	devID := newUser("dev@example.com")
+	outsiderID := newUser("outsider@example.com")
+
+	created, err := container.CreateTeamCommandHandler.Handle(ctx, command.CreateTeamCommand{
+		Name: "Platform", MemberIDs: []string{devID.Value()}, RequestedBy: leadID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+
+	// Only the lead manages the team, but members may leave on their own
+	_, err = container.AddTeamMemberCommandHandler.Handle(ctx, command.AddTeamMemberCommand{
+		TeamID: created.TeamID, UserID: outsiderID.Value(), RequestedBy: devID.Value(),
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "permission denied") {
+		t.Errorf("Expected a member not to add others, got %v", err)
+	}
+	_, err = container.AddTeamMemberCommandHandler.Handle(ctx, command.AddTeamMemberCommand{
+		TeamID: created.TeamID, UserID: outsiderID.Value(), RequestedBy: leadID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to add member: %v", err)
+	}
+	_, err = container.RemoveTeamMemberCommandHandler.Handle(ctx, command.RemoveTeamMemberCommand{
+		TeamID: created.TeamID, UserID: outsiderID.Value(), RequestedBy: outsiderID.Value(),
+	})
+	if err != nil {
+		t.Errorf("Expected a member to leave the team, got %v", err)
+	}
+
+	// One task belongs to the team, another to a member personally
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Platform work", "", leadID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	priority, _ := value.NewPriority("MEDIUM")
+	teamTask, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Upgrade cluster", "", priority, leadID)
+	memberTask, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Fix flaky test", "", priority, leadID)
+	memberTask.Assign(devID, leadID)
+	container.TaskRepository.Save(ctx, teamTask)
+	container.TaskRepository.Save(ctx, memberTask)
+
+	_, err = container.AssignTaskToTeamCommandHandler.Handle(ctx, command.AssignTaskToTeamCommand{
+		TaskID: teamTask.ID().Value(), TeamID: created.TeamID, AssignedBy: leadID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to assign task to team: %v", err)
+	}
+
+	teamOnly, err := container.ListTeamTasksQueryHandler.Handle(context.Background(), query.ListTeamTasksQuery{TeamID: created.TeamID})
+	if err != nil || len(teamOnly.Tasks) != 1 || teamOnly.Tasks[0].TeamID != created.TeamID {
+		t.Fatalf("Expected only the team's own task, got %v (%v)", teamOnly, err)
+	}
+	withMembers, _ := container.ListTeamTasksQueryHandler.Handle(context.Background(), query.ListTeamTasksQuery{TeamID: created.TeamID, IncludeMembers: true})
+	if len(withMembers.Tasks) != 2 || withMembers.Total != 2 {
+		t.Errorf("Expected the members' tasks to be included, got %d tasks", len(withMembers.Tasks))
+	}
+
+	team, _ := container.GetTeamQueryHandler.Handle(context.Background(), query.GetTeamQuery{TeamID: created.TeamID})
+	if team.LeadID != leadID.Value() || len(team.MemberIDs) != 2 {
+		t.Errorf("Expected the lead and one developer, got %+v", team)
+	}
+}
+
+// TestUsageEventsMeterOrganizationConsumption tests usage emission on the billing topic and its monthly preview
+func TestUsageEventsMeterOrganizationConsumption(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	published := make([]billing.UsageEvent, 0)
+	container.UsageTopic.Subscribe(func(usage billing.UsageEvent) {
+		published = append(published, usage)
+	})
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "billing-admin@example.com", "Billing", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Save(ctx, admin)
+
+	memberID := value.GenerateUserID()
+	member, _ := aggregate.NewUser(memberID, "billing-member@example.com", "Billing", "Member")
+	container.UserRepository.Save(ctx, member)
+
+	created, err := container.CreateOrganizationCommandHandler.Handle(ctx, command.CreateOrganizationCommand{
+		Name: "Metered", MemberIDs: []string{memberID.Value()}, RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create organization: %v", err)
+	}
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Metered", "", memberID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	unmetered, _ := aggregate.NewProject(value.GenerateProjectID(), "Unmetered", "", adminID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, unmetered)
+
+	task, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID: project.ID().Value(), Title: "Metered task", Priority: "LOW", CreatedBy: memberID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID: unmetered.ID().Value(), Title: "Outside any organization", Priority: "LOW", CreatedBy: adminID.Value(),
+	})
+	container.AddAttachmentCommandHandler.Handle(ctx, command.AddAttachmentCommand{
+		TaskID: task.TaskID, FileName: "invoice.pdf", ContentType: "application/pdf", SizeBytes: 2048, UploadedBy: memberID.Value(),
+	})
+
+	// Activity counts a user once per month
+	container.UsageEmitter.RecordActivity(ctx, memberID.Value())
+	container.UsageEmitter.RecordActivity(ctx, memberID.Value())
+	container.UsageEmitter.RecordActivity(ctx, adminID.Value())
+
+	if len(published) != 3 {
+		t.Fatalf("Expected 3 usage events for the organization, got %+v", published)
+	}
+	for _, usage := range published {
+		if usage.OrganizationID != created.OrganizationID || usage.Period != billing.Period(time.Now()) {
+			t.Errorf("Expected usage billed to the organization this month, got %+v", usage)
+		}
+	}
+
+	usage := container.UsageTopic.MonthlyUsage(created.OrganizationID, time.Now())
+	if usage.TasksCreated != 1 || usage.AttachmentBytes != 2048 || usage.ActiveUsers != 1 {
+		t.Errorf("Expected 1 task, 2048 bytes and 1 active user, got %+v", usage)
+	}
+
+	if previous := container.UsageTopic.MonthlyUsage(created.OrganizationID, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)); previous.Events != 0 {
+		t.Errorf("Expected no usage in another month, got %+v", previous)
+	}
+}
+
+// TestCreateAndUpdateWorkflowCommands tests that workflows are created and renamed through commands
+func TestCreateAndUpdateWorkflowCommands(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "admin@example.com", "Workflow", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Save(ctx, admin)
+
+	_, err := container.CreateWorkflowCommandHandler.Handle(ctx, command.CreateWorkflowCommand{
+		Name: "Empty", RequestedBy: adminID.Value(),
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "invalid workflow") {
+		t.Errorf("Expected a workflow without statuses to be invalid, got %v", err)
+	}
+
+	created, err := container.CreateWorkflowCommandHandler.Handle(ctx, command.CreateWorkflowCommand{
+		Name: "Review",
+		Statuses: []command.WorkflowStatusInput{
+			{Name: "OPEN", Order: 1},
+			{Name: "DONE", Order: 2, IsFinal: true},
+		},
+		Transitions: []command.WorkflowTransitionInput{{From: "OPEN", To: "DONE"}},
+		RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create workflow: %v", err)
+	}
+
+	name := "Review v2"
+	updated, err := container.UpdateWorkflowCommandHandler.Handle(ctx, command.UpdateWorkflowCommand{
+		WorkflowID:  created.WorkflowID,
+		Name:        &name,
+		RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to update workflow: %v", err)
+	}
+	if updated.Name != "Review v2" || updated.Version != 2 {
+		t.Errorf("Expected the rename as version 2, got %q v%d", updated.Name, updated.Version)
+	}
+
+	workflowID, _ := value.NewWorkflowID(created.WorkflowID)
+	workflow, _ := container.WorkflowRepository.GetByID(ctx, workflowID)
+	if !workflow.AllowsTransition("OPEN", "DONE") || workflow.AllowsTransition("DONE", "OPEN") {
+		t.Error("Expected the workflow to keep its transitions")
+	}
+
+	events, _ := container.EventStore.GetEvents(ctx, created.WorkflowID)
+	types := make([]string, 0, len(events))
+	for _, stored := range events {
+		types = append(types, stored.EventType())
+	}
+	if strings.Join(types, ",") != "WorkflowCreated,WorkflowDetailsChanged" {
+		t.Errorf("Expected the creation and rename to be recorded, got %v", types)
+	}
+}
+
+// TestWorkflowStatusesAndTransitionsCanBeEdited tests that an admin reshapes a workflow in use and tasks follow it
+func TestWorkflowStatusesAndTransitionsCanBeEdited(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "admin@example.com", "Workflow", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	admin.VerifyEmail()
+	container.UserRepository.Save(ctx, admin)
+
+	memberID := value.GenerateUserID()
+	member, _ := aggregate.NewUser(memberID, "member@example.com", "Team", "Member")
+	container.UserRepository.Save(ctx, member)
+
+	workflowID := value.GenerateWorkflowID()
+	workflow, _ := aggregate.NewWorkflow(workflowID, "Kanban", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("TO_DO", "To Do", 1, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "In Progress", 2, false),
+		aggregate.NewWorkflowStatus("COMPLETED", "Completed", 3, true),
+	})
+	container.WorkflowRepository.Save(ctx, workflow)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Editable", "", adminID, workflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:      "Ship it",
+		Priority:   "LOW",
+		AssigneeID: adminID.Value(),
+		Deadline:   time.Now().AddDate(0, 0, 7).Format(time.RFC3339),
+		CreatedBy:  adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	editStatuses := func(cmd command.EditWorkflowStatusesCommand) (*command.EditWorkflowStatusesResult, error) {
+		cmd.WorkflowID = workflowID.Value()
+		if cmd.RequestedBy == "" {
+			cmd.RequestedBy = adminID.Value()
+		}
+		return container.EditWorkflowStatusesCommandHandler.Handle(ctx, cmd)
+	}
+	editTransitions := func(action, from, to string) error {
+		_, err := container.EditWorkflowTransitionsCommandHandler.Handle(ctx, command.EditWorkflowTransitionsCommand{
+			WorkflowID:  workflowID.Value(),
+			Action:      action,
+			From:        from,
+			To:          to,
+			RequestedBy: adminID.Value(),
+		})
+		return err
+	}
+	moveTask := func(status string) error {
+		_, err := container.UpdateTaskStatusCommandHandler.Handle(ctx, command.UpdateTaskStatusCommand{
+			TaskID:      created.TaskID,
+			NewStatus:   status,
+			RequestedBy: adminID.Value(),
+		})
+		return err
+	}
+
+	// Only admins edit workflows, which every project on them shares
+	_, err = editStatuses(command.EditWorkflowStatusesCommand{Action: command.WorkflowStatusAdd, Name: "In Review", RequestedBy: memberID.Value()})
+	if err == nil || !strings.HasPrefix(err.Error(), "permission denied") {
+		t.Fatalf("Expected a member to be denied editing the workflow, got %v", err)
+	}
+
+	// New statuses go last until reordered
+	result, err := editStatuses(command.EditWorkflowStatusesCommand{Action: command.WorkflowStatusAdd, Name: "In Review"})
+	if err != nil {
+		t.Fatalf("Failed to add status: %v", err)
+	}
+	if got := strings.Join(result.Statuses, ","); got != "TO_DO,IN_PROGRESS,COMPLETED,In Review" {
+		t.Errorf("Expected the new status last, got %s", got)
+	}
+
+	result, err = editStatuses(command.EditWorkflowStatusesCommand{
+		Action: command.WorkflowStatusReorder,
+		Order:  []string{"TO_DO", "IN_PROGRESS", "IN_REVIEW", "COMPLETED"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to reorder statuses: %v", err)
+	}
+	if got := strings.Join(result.Statuses, ","); got != "TO_DO,IN_PROGRESS,In Review,COMPLETED" {
+		t.Errorf("Expected the statuses in the new order, got %s", got)
+	}
+
+	// A status holding tasks that follow the latest version stays
+	unpinned, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Unpinned", "", value.PriorityLow, adminID)
+	container.TaskRepository.Save(ctx, unpinned)
+	_, err = editStatuses(command.EditWorkflowStatusesCommand{Action: command.WorkflowStatusRemove, Name: "TO_DO"})
+	if err == nil || !strings.Contains(err.Error(), "is in use by 1 tasks") {
+		t.Fatalf("Expected removing a status in use to fail, got %v", err)
+	}
+	container.TaskRepository.Delete(ctx, unpinned.ID())
+
+	// Allow a shortcut past review, then close the way into review
+	if err := editTransitions(command.WorkflowTransitionAllow, "IN_PROGRESS", "COMPLETED"); err != nil {
+		t.Fatalf("Failed to allow transition: %v", err)
+	}
+	if err := editTransitions(command.WorkflowTransitionDisallow, "IN_PROGRESS", "IN_REVIEW"); err != nil {
+		t.Fatalf("Failed to disallow transition: %v", err)
+	}
+	if err := editTransitions(command.WorkflowTransitionDisallow, "IN_PROGRESS", "IN_REVIEW"); err == nil {
+		t.Error("Expected disallowing a transition twice to fail")
+	}
+
+	// The task stays on the version it was created under until the project migrates
+	if err := moveTask("IN_REVIEW"); err == nil {
+		t.Error("Expected the task to follow the version it was created under, without an In Review status")
+	}
+	migrated, err := container.MigrateProjectWorkflowCommandHandler.Handle(ctx, command.MigrateProjectWorkflowCommand{
+		ProjectID:   project.ID().Value(),
+		RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to migrate project: %v", err)
+	}
+	if migrated.Migrated != 1 || migrated.WorkflowVersion != workflowID.Value()+"/v5" {
+		t.Errorf("Expected the task migrated to version 5, got %+v", migrated)
+	}
+
+	if err := moveTask("IN_PROGRESS"); err != nil {
+		t.Fatalf("Expected the regular rules to carry over into the matrix, got %v", err)
+	}
+	if err := moveTask("IN_REVIEW"); err == nil {
+		t.Error("Expected the disallowed transition to be refused")
+	}
+	if err := moveTask("COMPLETED"); err != nil {
+		t.Fatalf("Expected the allowed shortcut, got %v", err)
+	}
+
+	// Once empty, the review status can go, with its transitions
+	if _, err := editStatuses(command.EditWorkflowStatusesCommand{Action: command.WorkflowStatusRemove, Name: "In Review"}); err != nil {
+		t.Fatalf("Failed to remove an unused status: %v", err)
+	}
+	latest, _ := container.WorkflowRepository.GetByID(ctx, workflowID)
+	for _, transition := range latest.Transitions() {
+		if transition.From == "IN_REVIEW" || transition.To == "IN_REVIEW" {
+			t.Errorf("Expected transitions of the removed status to go, found %s to %s", transition.From, transition.To)
+		}
+	}
+
+	stored, _ := container.EventStore.GetAllEvents(ctx)
+	published := make(map[string]int)
+	for _, evt := range stored {
+		published[evt.EventType()]++
+	}
+	for _, eventType := range []string{"WorkflowStatusAdded", "WorkflowStatusesReordered", "WorkflowStatusRemoved", "WorkflowTransitionAllowed", "WorkflowTransitionDisallowed"} {
+		if published[eventType] != 1 {
+			t.Errorf("Expected one %s event, got %d", eventType, published[eventType])
+		}
+	}
+}
+
+// TestWorkflowTransitionGuardsAndActions tests that transition guards block moves until met
+// and that transition actions run once the task has moved
+func TestWorkflowTransitionGuardsAndActions(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "admin@example.com", "Workflow", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	admin.VerifyEmail()
+	container.UserRepository.Save(ctx, admin)
+
+	reviewerID := value.GenerateUserID()
+	reviewer, _ := aggregate.NewUser(reviewerID, "reviewer@example.com", "Code", "Reviewer")
+	reviewer.VerifyEmail()
+	container.UserRepository.Save(ctx, reviewer)
+
+	approverID := value.GenerateUserID()
+	approver, _ := aggregate.NewUser(approverID, "approver@example.com", "Release", "Manager")
+	container.UserRepository.Save(ctx, approver)
+
+	workflowID := value.GenerateWorkflowID()
+	workflow, _ := aggregate.NewWorkflow(workflowID, "Reviewed", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("TO_DO", "To Do", 1, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "In Progress", 2, false),
+		aggregate.NewWorkflowStatus("IN_REVIEW", "In Review", 3, false),
+		aggregate.NewWorkflowStatus("COMPLETED", "Completed", 4, true),
+	})
+	container.WorkflowRepository.Save(ctx, workflow)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Guarded", "", adminID, workflowID)
+	project.AssignRole(reviewerID, value.ProjectRoleMember)
+	project.AssignRole(approverID, value.ProjectRoleMember)
+	project.ClearDomainEvents()
+	container.ProjectRepository.Save(ctx, project)
+
+	created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID:  project.ID().Value(),
+		Title:      "Ship it",
+		Priority:   "LOW",
+		AssigneeID: adminID.Value(),
+		CreatedBy:  adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	setRules := func(from, to string, guards []command.TransitionGuardInput, actions []command.TransitionActionInput) error {
+		_, err := container.SetTransitionRulesCommandHandler.Handle(ctx, command.SetTransitionRulesCommand{
+			WorkflowID:  workflowID.Value(),
+			From:        from,
+			To:          to,
+			Guards:      guards,
+			Actions:     actions,
+			RequestedBy: adminID.Value(),
+		})
+		return err
+	}
+	moveTask := func(status string) error {
+		_, err := container.UpdateTaskStatusCommandHandler.Handle(ctx, command.UpdateTaskStatusCommand{
+			TaskID:      created.TaskID,
+			NewStatus:   status,
+			RequestedBy: adminID.Value(),
+		})
+		return err
+	}
+	approve := func(userID value.UserID) error {
+		_, err := container.ApproveTaskCommandHandler.Handle(ctx, command.ApproveTaskCommand{
+			TaskID:     created.TaskID,
+			ApproverID: userID.Value(),
+		})
+		return err
+	}
+
+	// Rules are checked before they are stored
+	err = setRules("IN_REVIEW", "COMPLETED", []command.TransitionGuardInput{{Kind: "REQUIRES_APPROVALS"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid transition guard") {
+		t.Fatalf("Expected an approval guard without a count to be refused, got %v", err)
+	}
+	err = setRules("TO_DO", "IN_REVIEW", []command.TransitionGuardInput{{Kind: "REQUIRES_DEADLINE"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "is not allowed") {
+		t.Fatalf("Expected rules on a disallowed transition to be refused, got %v", err)
+	}
+
+	err = setRules("IN_PROGRESS", "IN_REVIEW",
+		[]command.TransitionGuardInput{{Kind: "REQUIRES_DEADLINE"}},
+		[]command.TransitionActionInput{
+			{Kind: "AUTO_ASSIGN", AssigneeID: reviewerID.Value()},
+			{Kind: "NOTIFY", Channel: "SLACK", Target: "#qa"},
+		})
+	if err != nil {
+		t.Fatalf("Failed to set review rules: %v", err)
+	}
+	err = setRules("IN_REVIEW", "COMPLETED", []command.TransitionGuardInput{{Kind: "REQUIRES_APPROVALS", Approvals: 2}}, nil)
+	if err != nil {
+		t.Fatalf("Failed to set completion rules: %v", err)
+	}
+
+	// The task was created under the workflow without rules, and follows them once migrated
+	if _, err := container.MigrateProjectWorkflowCommandHandler.Handle(ctx, command.MigrateProjectWorkflowCommand{
+		ProjectID:   project.ID().Value(),
+		RequestedBy: adminID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to migrate project: %v", err)
+	}
+
+	// Guards hold the task back until met
+	if err := moveTask("IN_PROGRESS"); err != nil {
+		t.Fatalf("Failed to start task: %v", err)
+	}
+	err = moveTask("IN_REVIEW")
+	if err == nil || !strings.Contains(err.Error(), "the task needs a deadline") {
+		t.Fatalf("Expected the deadline guard to refuse the move, got %v", err)
+	}
+
+	taskID, _ := value.NewTaskID(created.TaskID)
+	task, _ := container.TaskRepository.GetByID(ctx, taskID)
+	deadline, _ := value.NewDeadline(time.Now().AddDate(0, 0, 7))
+	task.SetDeadline(deadline, adminID, "")
+	task.ClearDomainEvents()
+	container.TaskRepository.Update(ctx, task)
+
+	if err := moveTask("IN_REVIEW"); err != nil {
+		t.Fatalf("Expected the move once the task has a deadline, got %v", err)
+	}
+
+	// Actions ran after the move
+	task, _ = container.TaskRepository.GetByID(ctx, taskID)
+	if task.Assignee() == nil || !task.Assignee().IsAssignedTo(reviewerID) {
+		t.Error("Expected the task to be auto-assigned to the reviewer")
+	}
+
+	// The assignee cannot approve their own work, and nobody approves twice
+	if err := approve(reviewerID); err == nil {
+		t.Error("Expected the assignee's approval to be refused")
+	}
+	if err := approve(adminID); err != nil {
+		t.Fatalf("Failed to approve task: %v", err)
+	}
+	if err := approve(adminID); err == nil {
+		t.Error("Expected a second approval by the same user to be refused")
+	}
+
+	err = moveTask("COMPLETED")
+	if err == nil || !strings.Contains(err.Error(), "needs 2 approvals, has 1") {
+		t.Fatalf("Expected the approval guard to refuse the move, got %v", err)
+	}
+
+	if err := approve(approverID); err != nil {
+		t.Fatalf("Failed to approve task: %v", err)
+	}
+	if err := moveTask("COMPLETED"); err != nil {
+		t.Fatalf("Expected the move once approved twice, got %v", err)
+	}
+
+	stored, _ := container.EventStore.GetAllEvents(ctx)
+	var notification *event.TaskNotificationRequestedEvent
+	approvals := 0
+	for _, evt := range stored {
+		switch e := evt.(type) {
+		case event.TaskNotificationRequestedEvent:
+			notification = &e
+		case event.TaskApprovedEvent:
+			approvals++
+		}
+	}
+	if notification == nil {
+		t.Fatal("Expected a notification to be requested for the move into review")
+	}
+	if notification.Channel != "SLACK" || notification.Target != "#qa" || !strings.Contains(notification.Message, "IN_PROGRESS to IN_REVIEW") {
+		t.Errorf("Unexpected notification request: %+v", *notification)
+	}
+	if approvals != 2 {
+		t.Errorf("Expected 2 TaskApproved events, got %d", approvals)
+	}
+}
+
+// TestApprovalStepNeedsDesignatedReviewers tests that a status with an approval step holds tasks
+// until enough designated reviewers sign off, and that a standing rejection holds them too
+func TestApprovalStepNeedsDesignatedReviewers(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "admin@example.com", "Workflow", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	admin.VerifyEmail()
+	container.UserRepository.Save(ctx, admin)
+
+	reviewerIDs := make([]value.UserID, 0, 2)
+	for _, email := range []string{"lead@example.com", "qa@example.com"} {
+		reviewerID := value.GenerateUserID()
+		reviewer, _ := aggregate.NewUser(reviewerID, email, "Designated", "Reviewer")
+		container.UserRepository.Save(ctx, reviewer)
+		reviewerIDs = append(reviewerIDs, reviewerID)
+	}
+
+	workflowID := value.GenerateWorkflowID()
+	workflow, _ := aggregate.NewWorkflow(workflowID, "Signed off", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("TO_DO", "To Do", 1, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "In Progress", 2, false),
+		aggregate.NewWorkflowStatus("IN_REVIEW", "In Review", 3, false),
+		aggregate.NewWorkflowStatus("COMPLETED", "Completed", 4, true),
+	})
+	container.WorkflowRepository.Save(ctx, workflow)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Signed off", "", adminID, workflowID)
+	for _, reviewerID := range reviewerIDs {
+		project.AssignRole(reviewerID, value.ProjectRoleMember)
+	}
+	project.ClearDomainEvents()
+	container.ProjectRepository.Save(ctx, project)
+
+	setStep := func(required int, reviewers ...value.UserID) error {
+		ids := make([]string, 0, len(reviewers))
+		for _, reviewerID := range reviewers {
+			ids = append(ids, reviewerID.Value())
+		}
+		_, err := container.SetWorkflowApprovalStepCommandHandler.Handle(ctx, command.SetWorkflowApprovalStepCommand{
+			WorkflowID:        workflowID.Value(),
+			Status:            "IN_REVIEW",
+			RequiredApprovals: required,
+			ReviewerIDs:       ids,
+			RequestedBy:       adminID.Value(),
+		})
+		return err
+	}
+
+	// Steps are checked before they are stored
+	if err := setStep(3, reviewerIDs...); err == nil || !strings.Contains(err.Error(), "invalid approval step") {
+		t.Fatalf("Expected a step needing more approvals than reviewers to be refused, got %v", err)
+	}
+	if err := setStep(2, reviewerIDs...); err != nil {
+		t.Fatalf("Failed to set approval step: %v", err)
+	}
+
+	created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID:  project.ID().Value(),
+		Title:      "Release notes",
+		Priority:   "LOW",
+		AssigneeID: adminID.Value(),
+		CreatedBy:  adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	taskID, _ := value.NewTaskID(created.TaskID)
+	task, _ := container.TaskRepository.GetByID(ctx, taskID)
+	deadline, _ := value.NewDeadline(time.Now().AddDate(0, 0, 7))
+	task.SetDeadline(deadline, adminID, "")
+	task.ClearDomainEvents()
+	container.TaskRepository.Update(ctx, task)
+
+	moveTask := func(status string) error {
+		_, err := container.UpdateTaskStatusCommandHandler.Handle(ctx, command.UpdateTaskStatusCommand{
+			TaskID:      created.TaskID,
+			NewStatus:   status,
+			RequestedBy: adminID.Value(),
+		})
+		return err
+	}
+	approve := func(userID value.UserID) error {
+		_, err := container.ApproveTaskCommandHandler.Handle(ctx, command.ApproveTaskCommand{
+			TaskID:     created.TaskID,
+			ApproverID: userID.Value(),
+		})
+		return err
+	}
+	reject := func(userID value.UserID, reason string) error {
+		_, err := container.RejectTaskCommandHandler.Handle(ctx, command.RejectTaskCommand{
+			TaskID:     created.TaskID,
+			ReviewerID: userID.Value(),
+			Reason:     reason,
+		})
+		return err
+	}
+
+	for _, status := range []string{"IN_PROGRESS", "IN_REVIEW"} {
+		if err := moveTask(status); err != nil {
+			t.Fatalf("Failed to move task to %s: %v", status, err)
+		}
+	}
+
+	// Only the designated reviewers sign off, and rejections need a reason
+	if err := approve(adminID); err == nil || !strings.Contains(err.Error(), "only the designated reviewers") {
+		t.Fatalf("Expected an approval by someone else to be refused, got %v", err)
+	}
+	if err := reject(reviewerIDs[0], " "); err == nil || !strings.Contains(err.Error(), "reason is required") {
+		t.Fatalf("Expected a rejection without a reason to be refused, got %v", err)
+	}
+
+	if err := approve(reviewerIDs[0]); err != nil {
+		t.Fatalf("Failed to approve task: %v", err)
+	}
+	err = moveTask("COMPLETED")
+	if err == nil || !strings.Contains(err.Error(), "needs sign-off from 2 of its reviewers, has 1") {
+		t.Fatalf("Expected the approval step to refuse the move, got %v", err)
+	}
+
+	// A standing rejection holds the task even with enough approvals
+	if err := reject(reviewerIDs[1], "changelog is missing"); err != nil {
+		t.Fatalf("Failed to reject task: %v", err)
+	}
+	err = moveTask("COMPLETED")
+	if err == nil || !strings.Contains(err.Error(), "changelog is missing") {
+		t.Fatalf("Expected the rejection to refuse the move, got %v", err)
+	}
+
+	// The reviewer changes their mind, replacing the rejection
+	if err := approve(reviewerIDs[1]); err != nil {
+		t.Fatalf("Failed to approve task: %v", err)
+	}
+	task, _ = container.TaskRepository.GetByID(ctx, taskID)
+	if task.ApprovalCount() != 2 || task.Rejection() != nil {
+		t.Errorf("Expected 2 approvals and no rejection, got %d and %v", task.ApprovalCount(), task.Rejection())
+	}
+	if err := moveTask("COMPLETED"); err != nil {
+		t.Fatalf("Expected the move once signed off, got %v", err)
+	}
+
+	stored, _ := container.EventStore.GetAllEvents(ctx)
+	approvals, rejections := 0, 0
+	for _, evt := range stored {
+		switch e := evt.(type) {
+		case event.TaskApprovedEvent:
+			approvals++
+			if e.Status != "IN_REVIEW" {
+				t.Errorf("Expected the approval to be for IN_REVIEW, got %s", e.Status)
+			}
+		case event.TaskRejectedEvent:
+			rejections++
+			if e.Reason != "changelog is missing" {
+				t.Errorf("Unexpected rejection reason: %s", e.Reason)
+			}
+		}
+	}
+	if approvals != 2 || rejections != 1 {
+		t.Errorf("Expected 2 TaskApproved and 1 TaskRejected events, got %d and %d", approvals, rejections)
+	}
+}
+
+// TestWorkflowVersionsPinTasksUntilMigrated tests that workflow edits create versions, that tasks
+// stay on the version they were created under and that a migration maps them onto the latest
+func TestWorkflowVersionsPinTasksUntilMigrated(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "admin@example.com", "Workflow", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	admin.VerifyEmail()
+	container.UserRepository.Save(ctx, admin)
+
+	workflowID := value.GenerateWorkflowID()
+	workflow, _ := aggregate.NewWorkflow(workflowID, "Kanban", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("TO_DO", "To Do", 1, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "In Progress", 2, false),
+		aggregate.NewWorkflowStatus("COMPLETED", "Completed", 3, true),
+	})
+	container.WorkflowRepository.Save(ctx, workflow)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Versioned", "", adminID, workflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	taskIDs := make([]value.TaskID, 0, 2)
+	for _, title := range []string{"Waiting", "Started"} {
+		created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+			ProjectID:  project.ID().Value(),
+			Title:      title,
+			Priority:   "LOW",
+			AssigneeID: adminID.Value(),
+			CreatedBy:  adminID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		taskID, _ := value.NewTaskID(created.TaskID)
+		taskIDs = append(taskIDs, taskID)
+	}
+	if _, err := container.UpdateTaskStatusCommandHandler.Handle(ctx, command.UpdateTaskStatusCommand{
+		TaskID:      taskIDs[1].Value(),
+		NewStatus:   "IN_PROGRESS",
+		RequestedBy: adminID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to start task: %v", err)
+	}
+
+	// Dropping a status creates version 2, the tasks keep version 1 and its statuses
+	result, err := container.EditWorkflowStatusesCommandHandler.Handle(ctx, command.EditWorkflowStatusesCommand{
+		WorkflowID:  workflowID.Value(),
+		Action:      command.WorkflowStatusRemove,
+		Name:        "IN_PROGRESS",
+		RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Expected a status held only by pinned tasks to go, got %v", err)
+	}
+	if result.Version != 2 {
+		t.Errorf("Expected the edit to create version 2, got %d", result.Version)
+	}
+	if len(workflow.Statuses()) != 3 || workflow.Version() != 1 {
+		t.Error("Expected version 1 to stay as it was")
+	}
+	started, _ := container.TaskRepository.GetByID(ctx, taskIDs[1])
+	if pinned := container.StatusTransitionService.WorkflowOf(ctx, started); pinned.Version() != 1 || !pinned.Covers("IN_PROGRESS") {
+		t.Errorf("Expected the task to follow version 1, got version %d", pinned.Version())
+	}
+	if err := container.WorkflowRepository.Update(ctx, workflow); err == nil || !strings.Contains(err.Error(), "is out of date") {
+		t.Errorf("Expected saving a superseded version to fail, got %v", err)
+	}
+
+	// Statuses the latest version dropped must be mapped, and nothing moves until they are
+	migrate := func(mapping map[string]string) (*command.MigrateProjectWorkflowResult, error) {
+		return container.MigrateProjectWorkflowCommandHandler.Handle(ctx, command.MigrateProjectWorkflowCommand{
+			ProjectID:     project.ID().Value(),
+			StatusMapping: mapping,
+			RequestedBy:   adminID.Value(),
+		})
+	}
+	if _, err := migrate(nil); err == nil || !strings.Contains(err.Error(), "map it to one of its statuses") {
+		t.Fatalf("Expected an unmapped dropped status to fail the migration, got %v", err)
+	}
+	waiting, _ := container.TaskRepository.GetByID(ctx, taskIDs[0])
+	if waiting.WorkflowVersion().Number() != 1 {
+		t.Error("Expected a failed migration to leave every task on its version")
+	}
+	if _, err := migrate(map[string]string{"IN_PROGRESS": "Blocked"}); err == nil || !strings.HasPrefix(err.Error(), "invalid status mapping") {
+		t.Fatalf("Expected a mapping onto a missing status to fail, got %v", err)
+	}
+
+	migrated, err := migrate(map[string]string{"IN_PROGRESS": "To Do"})
+	if err != nil {
+		t.Fatalf("Failed to migrate project: %v", err)
+	}
+	if migrated.Migrated != 2 || migrated.WorkflowVersion != workflowID.Value()+"/v2" {
+		t.Errorf("Expected both tasks migrated to version 2, got %+v", migrated)
+	}
+	for _, taskID := range taskIDs {
+		task, _ := container.TaskRepository.GetByID(ctx, taskID)
+		if task.Status() != value.TaskStatusToDo || task.WorkflowVersion().Number() != 2 {
+			t.Errorf("Expected task %s in TO_DO on version 2, got %s on %s", task.Title(), task.Status(), task.WorkflowVersion())
+		}
+	}
+
+	// Tasks on the latest version are left alone
+	if again, err := migrate(nil); err != nil || again.Migrated != 0 {
+		t.Errorf("Expected nothing left to migrate, got %+v, %v", again, err)
+	}
+
+	stored, _ := container.EventStore.GetAllEvents(ctx)
+	published := make(map[string]int)
+	for _, evt := range stored {
+		published[evt.EventType()]++
+	}
+	if published["TaskWorkflowMigrated"] != 2 {
+		t.Errorf("Expected two TaskWorkflowMigrated events, got %d", published["TaskWorkflowMigrated"])
+	}
+}
+
+// TestWorkflowMigrationKeepsCustomStatuses tests that a migration moves tasks into the custom
+// status they are mapped to, and that tasks in a custom status can be mapped by its name
+func TestWorkflowMigrationKeepsCustomStatuses(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "custom-admin@example.com", "Workflow", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	admin.VerifyEmail()
+	container.UserRepository.Save(ctx, admin)
+
+	workflowID := value.GenerateWorkflowID()
+	workflow, _ := aggregate.NewWorkflow(workflowID, "Board", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("TO_DO", "To Do", 1, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "In Progress", 2, false),
+		aggregate.NewWorkflowStatus("Parked", "Waiting on others", 3, false),
+		aggregate.NewWorkflowStatus("COMPLETED", "Completed", 4, true),
+	})
+	container.WorkflowRepository.Save(ctx, workflow)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Custom", "", adminID, workflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	taskIDs := make([]value.TaskID, 0, 2)
+	for _, status := range []string{"IN_PROGRESS", "Parked"} {
+		created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+			ProjectID:  project.ID().Value(),
+			Title:      "Task in " + status,
+			Priority:   "LOW",
+			AssigneeID: adminID.Value(),
+			CreatedBy:  adminID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		if _, err := container.UpdateTaskStatusCommandHandler.Handle(ctx, command.UpdateTaskStatusCommand{
+			TaskID:      created.TaskID,
+			NewStatus:   status,
+			RequestedBy: adminID.Value(),
+		}); err != nil {
+			t.Fatalf("Failed to move task to %s: %v", status, err)
+		}
+		taskID, _ := value.NewTaskID(created.TaskID)
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	// The latest version trades both statuses for a custom one standing for TO_DO
+	edits := []command.EditWorkflowStatusesCommand{
+		{Action: command.WorkflowStatusAdd, Name: "Doing"},
+		{Action: command.WorkflowStatusReorder, Order: []string{"TO_DO", "Doing", "IN_PROGRESS", "Parked", "COMPLETED"}},
+		{Action: command.WorkflowStatusRemove, Name: "IN_PROGRESS"},
+		{Action: command.WorkflowStatusRemove, Name: "Parked"},
+	}
+	for _, edit := range edits {
+		edit.WorkflowID = workflowID.Value()
+		edit.RequestedBy = adminID.Value()
+		if _, err := container.EditWorkflowStatusesCommandHandler.Handle(ctx, edit); err != nil {
+			t.Fatalf("Failed to %s workflow status: %v", edit.Action, err)
+		}
+	}
+
+	if _, err := container.MigrateProjectWorkflowCommandHandler.Handle(ctx, command.MigrateProjectWorkflowCommand{
+		ProjectID:     project.ID().Value(),
+		StatusMapping: map[string]string{"IN_PROGRESS": "Doing", "parked": "doing"},
+		RequestedBy:   adminID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to migrate project: %v", err)
+	}
+
+	latest := container.StatusTransitionService.LatestWorkflowOf(ctx, project.ID())
+	doing, _ := latest.ScopedStatus("Doing")
+	for _, taskID := range taskIDs {
+		task, _ := container.TaskRepository.GetByID(ctx, taskID)
+		if !task.WorkflowStatus().Equals(doing) {
+			t.Errorf("Expected %s in %s, got %s", task.Title(), doing, task.WorkflowStatus())
+		}
+		if task.Status() != value.TaskStatusToDo || task.StatusIn(latest) != "Doing" {
+			t.Errorf("Expected %s in Doing standing for TO_DO, got %s in %s", task.Title(), task.StatusIn(latest), task.Status())
+		}
+	}
+}
+
+func TestProjectInboxAddressesFeedTheEmailGateway(t *testing.T) {
+	container := di.NewContainer()
+	container.InboxAddressService.SetDomain("Inbound.Example.com")
+	container.InboxAddressService.SetGatewaySecret("relay-secret")
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "inbox-owner@example.com", "Inbox", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	memberID := value.GenerateUserID()
+	member, _ := aggregate.NewUser(memberID, "inbox-member@example.com", "Inbox", "Member")
+	container.UserRepository.Save(ctx, member)
+
+	support, _ := aggregate.NewProject(value.GenerateProjectID(), "Support", "", ownerID, value.DefaultWorkflowID)
+	support.AssignRole(memberID, value.ProjectRoleMember)
+	container.ProjectRepository.Save(ctx, support)
+	invoices, _ := aggregate.NewProject(value.GenerateProjectID(), "Invoices", "", ownerID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, invoices)
+
+	if _, err := container.AddProjectInboxAddressCommandHandler.Handle(ctx, command.AddProjectInboxAddressCommand{
+		ProjectID: support.ID().Value(), Label: "support list", RequestedBy: memberID.Value(),
+	}); err == nil {
+		t.Error("Expected a member who cannot manage the project to be refused")
+	}
+
+	addInbox := func(project *aggregate.Project, label string) *command.AddProjectInboxAddressResult {
+		added, err := container.AddProjectInboxAddressCommandHandler.Handle(ctx, command.AddProjectInboxAddressCommand{
+			ProjectID: project.ID().Value(), Label: label, RequestedBy: ownerID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to add inbox address: %v", err)
+		}
+		return added
+	}
+	supportInbox := addInbox(support, "support list")
+	invoicesInbox := addInbox(invoices, "invoices list")
+	if !strings.HasSuffix(supportInbox.Address, "@inbound.example.com") || supportInbox.Token == invoicesInbox.Token {
+		t.Fatalf("Expected distinct addresses at the inbound domain, got %s and %s", supportInbox.Address, invoicesInbox.Address)
+	}
+
+	settings, _ := container.GetProjectSettingsQueryHandler.Handle(context.Background(), query.GetProjectSettingsQuery{ProjectID: support.ID().Value()})
+	if len(settings.InboxAddresses) != 1 || settings.InboxAddresses[0].Address != supportInbox.Address {
+		t.Errorf("Expected the settings to list the support address, got %+v", settings.InboxAddresses)
+	}
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+	deliver := func(secret, to, from string) *httptest.ResponseRecorder {
+		body := `{"to":["someone@example.com","` + to + `"],"from":"` + from + `","subject":"Printer on fire","text":"Third floor."}`
+		request := httptest.NewRequest(http.MethodPost, "/api/inbound/email", strings.NewReader(body))
+		request.Header.Set("X-Inbound-Email-Secret", secret)
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if code := deliver("wrong", supportInbox.Address, "customer@example.com").Code; code != http.StatusUnauthorized {
+		t.Errorf("Expected a relay without the secret to be refused with 401, got %d", code)
+	}
+
+	// Each address feeds its own project, outsiders file through the project owner
+	createdBy := func(project *aggregate.Project, from string, address string) value.UserID {
+		if response := deliver("relay-secret", address, from); response.Code != http.StatusCreated {
+			t.Fatalf("Failed to deliver mail: %d %s", response.Code, response.Body.String())
+		}
+		tasks, _ := container.TaskRepository.GetByProjectID(ctx, project.ID())
+		latest := tasks[0]
+		for _, task := range tasks {
+			if task.CreatedAt().After(latest.CreatedAt()) {
+				latest = task
+			}
+		}
+		if latest.Title() != "Printer on fire" || !strings.Contains(latest.Description(), "Sent by") {
+			t.Errorf("Expected the subject and sender on the task, got %q: %q", latest.Title(), latest.Description())
+		}
+		return latest.CreatedBy()
+	}
+	if got := createdBy(invoices, "customer@example.com", "Invoices <"+invoicesInbox.Address+">"); !got.Equals(ownerID) {
+		t.Errorf("Expected mail from an outsider to be filed by the owner, got %s", got.Value())
+	}
+	if got := createdBy(support, "Inbox Member <inbox-member@example.com>", supportInbox.Address); !got.Equals(memberID) {
+		t.Errorf("Expected mail from a member to be filed by them, got %s", got.Value())
+	}
+
+	// A revoked address stops feeding the project
+	if _, err := container.RevokeProjectInboxAddressCommandHandler.Handle(ctx, command.RevokeProjectInboxAddressCommand{
+		ProjectID: support.ID().Value(), Token: supportInbox.Token, RequestedBy: ownerID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to revoke inbox address: %v", err)
+	}
+	if code := deliver("relay-secret", supportInbox.Address, "customer@example.com").Code; code != http.StatusNotFound {
+		t.Errorf("Expected mail to a revoked address to be refused with 404, got %d", code)
+	}
+}
+
+// TestUnacknowledgedAssignmentsAreEscalatedAndListed tests read receipts on assignments
+func TestUnacknowledgedAssignmentsAreEscalatedAndListed(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	escalations := make([]event.TaskAssignmentEscalatedEvent, 0)
+	container.EventSubscriber.Subscribe("TaskAssignmentEscalated", func(evt event.DomainEvent) error {
+		escalations = append(escalations, evt.(event.TaskAssignmentEscalatedEvent))
+		return nil
+	})
+
+	managerID := value.GenerateUserID()
+	manager, _ := aggregate.NewUser(managerID, "ack-manager@example.com", "Ack", "Manager")
+	container.UserRepository.Save(ctx, manager)
+
+	assigneeID := value.GenerateUserID()
+	assignee, _ := aggregate.NewUser(assigneeID, "ack-assignee@example.com", "Ack", "Assignee")
+	assignee.VerifyEmail()
+	assignee.ClearDomainEvents()
+	container.UserRepository.Save(ctx, assignee)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Receipts", "", managerID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	taskIDs := make([]string, 0, 2)
+	for _, title := range []string{"Read me", "Open me"} {
+		created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+			ProjectID: project.ID().Value(), Title: title, Priority: "MEDIUM", CreatedBy: managerID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		if _, err := container.AssignTaskCommandHandler.Handle(ctx, command.AssignTaskCommand{
+			TaskID: created.TaskID, AssigneeID: assigneeID.Value(), AssignedBy: managerID.Value(),
+		}); err != nil {
+			t.Fatalf("Failed to assign task: %v", err)
+		}
+		taskIDs = append(taskIDs, created.TaskID)
+	}
+
+	if _, err := container.CreateWidgetCommandHandler.Handle(ctx, command.CreateWidgetCommand{
+		OwnerID: managerID.Value(), Title: "Waiting on", Type: "UNACKNOWLEDGED_ASSIGNMENTS", Width: 1, Height: 1,
+	}); err != nil {
+		t.Fatalf("Failed to create widget: %v", err)
+	}
+	waitingOn := func() []*dto.TaskDTO {
+		results, err := container.EvaluateDashboardQueryHandler.Handle(context.Background(), query.EvaluateDashboardQuery{OwnerID: managerID.Value()})
+		if err != nil || len(results) != 1 || results[0].Error != "" {
+			t.Fatalf("Failed to evaluate dashboard: %v %+v", err, results)
+		}
+		return results[0].Data.([]*dto.TaskDTO)
+	}
+	if tasks := waitingOn(); len(tasks) != 2 {
+		t.Fatalf("Expected both assignments to be waiting, got %d", len(tasks))
+	}
+
+	// Only the assignee can acknowledge, and opening the task does it for them
+	if _, err := container.AcknowledgeTaskCommandHandler.Handle(ctx, command.AcknowledgeTaskCommand{
+		TaskID: taskIDs[0], UserID: managerID.Value(),
+	}); err == nil {
+		t.Error("Expected the manager not to acknowledge for the assignee")
+	}
+	if _, err := container.AcknowledgeTaskCommandHandler.Handle(ctx, command.AcknowledgeTaskCommand{
+		TaskID: taskIDs[0], UserID: assigneeID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to acknowledge task: %v", err)
+	}
+	viewed, err := container.AcknowledgeTaskCommandHandler.Handle(ctx, command.AcknowledgeTaskCommand{
+		TaskID: taskIDs[0], UserID: assigneeID.Value(), OnView: true,
+	})
+	if err != nil || viewed.Acknowledged {
+		t.Errorf("Expected viewing an acknowledged task to do nothing, got %+v, %v", viewed, err)
+	}
+
+	tasks := waitingOn()
+	if len(tasks) != 1 || tasks[0].ID != taskIDs[1] {
+		t.Fatalf("Expected only the second task to be waiting, got %+v", tasks)
+	}
+
+	// Past the window the waiting assignment is escalated, once
+	container.TaskAssignmentService.SetAcknowledgementWindow(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	for i := 0; i < 2; i++ {
+		if _, err := container.EscalateUnacknowledgedAssignmentsCommandHandler.Handle(ctx, command.EscalateUnacknowledgedAssignmentsCommand{
+			ProjectID: project.ID().Value(),
+		}); err != nil {
+			t.Fatalf("Failed to escalate: %v", err)
+		}
+	}
+	if len(escalations) != 1 || escalations[0].AggregateID() != taskIDs[1] || escalations[0].AssignedBy != managerID.Value() {
+		t.Fatalf("Expected one escalation of the second task to the manager, got %+v", escalations)
+	}
+	if tasks := waitingOn(); len(tasks) != 1 || tasks[0].Assignee.EscalatedAt == nil {
+		t.Errorf("Expected the waiting task to show it was escalated, got %+v", tasks)
+	}
+
+	viewed, err = container.AcknowledgeTaskCommandHandler.Handle(ctx, command.AcknowledgeTaskCommand{
+		TaskID: taskIDs[1], UserID: assigneeID.Value(), OnView: true,
+	})
+	if err != nil || !viewed.Acknowledged {
+		t.Fatalf("Expected the first view to acknowledge, got %+v, %v", viewed, err)
+	}
+	if tasks := waitingOn(); len(tasks) != 0 {
+		t.Errorf("Expected nothing left waiting, got %d", len(tasks))
+	}
+}
+
+// TestProjectPrioritySchemeValidatesAndRemapsTasks tests custom priority ladders
+func TestProjectPrioritySchemeValidatesAndRemapsTasks(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "priorities@example.com", "Priority", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Incidents", "", ownerID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	createTask := func(title, priority string) (string, error) {
+		created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+			ProjectID: project.ID().Value(), Title: title, Priority: priority, CreatedBy: ownerID.Value(),
+		})
+		if err != nil {
+			return "", err
+		}
+		return created.TaskID, nil
+	}
+	urgentID, _ := createTask("Database down", "CRITICAL")
+	minorID, _ := createTask("Typo on status page", "LOW")
+
+	sev := []command.PriorityLevelInput{{Name: "SEV3", Rank: 1}, {Name: "SEV2", Rank: 2}, {Name: "SEV1", Rank: 3}}
+	setScheme := func(cmd command.SetProjectPrioritySchemeCommand) (*command.SetProjectPrioritySchemeResult, error) {
+		cmd.ProjectID = project.ID().Value()
+		cmd.RequestedBy = ownerID.Value()
+		return container.SetProjectPrioritySchemeCommandHandler.Handle(ctx, cmd)
+	}
+
+	if _, err := setScheme(command.SetProjectPrioritySchemeCommand{Levels: sev, DefaultPriority: "SEV3"}); err == nil {
+		t.Error("Expected tasks left on dropped priorities to refuse the scheme")
+	}
+	if _, err := setScheme(command.SetProjectPrioritySchemeCommand{
+		Levels: sev, Remap: map[string]string{"CRITICAL": "SEV1", "LOW": "SEV3"},
+	}); err == nil {
+		t.Error("Expected a default priority the scheme drops to refuse the scheme")
+	}
+
+	result, err := setScheme(command.SetProjectPrioritySchemeCommand{
+		Levels: sev, DefaultPriority: "sev3", Remap: map[string]string{"critical": "SEV1", "LOW": "SEV3"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to set priority scheme: %v", err)
+	}
+	if result.Remapped != 2 || result.DefaultPriority != "SEV3" || strings.Join(result.Priorities, ",") != "SEV3,SEV2,SEV1" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	urgent, _ := container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{TaskID: urgentID})
+	minor, _ := container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{TaskID: minorID})
+	if urgent.Priority != "SEV1" || minor.Priority != "SEV3" {
+		t.Errorf("Expected tasks remapped to SEV1 and SEV3, got %s and %s", urgent.Priority, minor.Priority)
+	}
+
+	// New tasks are held to the project's scheme
+	if _, err := createTask("Old style", "HIGH"); err == nil {
+		t.Error("Expected a priority outside the scheme to be rejected")
+	}
+	defaultedID, err := createTask("No priority given", "")
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if defaulted, _ := container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{TaskID: defaultedID}); defaulted.Priority != "SEV3" {
+		t.Errorf("Expected the scheme's default priority, got %s", defaulted.Priority)
+	}
+
+	if _, err := container.SetProjectSettingsCommandHandler.Handle(ctx, command.SetProjectSettingsCommand{
+		ProjectID: project.ID().Value(), DefaultPriority: "HIGH", AllowComments: true, RequestedBy: ownerID.Value(),
+	}); err == nil {
+		t.Error("Expected a default priority outside the scheme to be rejected")
+	}
+
+	settings, err := container.GetProjectSettingsQueryHandler.Handle(context.Background(), query.GetProjectSettingsQuery{ProjectID: project.ID().Value()})
+	if err != nil {
+		t.Fatalf("Failed to get project settings: %v", err)
+	}
+	if len(settings.PriorityScheme) != 3 || settings.PriorityScheme[2].Name != "SEV1" || settings.PriorityScheme[2].Rank != 3 {
+		t.Errorf("Expected the scheme in the project settings, got %+v", settings.PriorityScheme)
+	}
+
+	// Empty levels restore the default ladder
+	if _, err := setScheme(command.SetProjectPrioritySchemeCommand{
+		DefaultPriority: "MEDIUM", Remap: map[string]string{"SEV1": "CRITICAL", "SEV2": "HIGH", "SEV3": "LOW"},
+	}); err != nil {
+		t.Fatalf("Failed to restore the default scheme: %v", err)
+	}
+	if _, err := createTask("New style again", "HIGH"); err != nil {
+		t.Errorf("Expected default priorities to be accepted again, got %v", err)
+	}
+}
+
+// TestDeadlinesWarnWhenTheAssigneeIsOutOfOffice tests deadline warnings for assignees away on the due date
+func TestDeadlinesWarnWhenTheAssigneeIsOutOfOffice(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "lead@example.com", "Team", "Lead")
+	container.UserRepository.Save(ctx, owner)
+
+	assigneeID := value.GenerateUserID()
+	assignee, _ := aggregate.NewUser(assigneeID, "traveller@example.com", "Away", "Often")
+	assignee.VerifyEmail()
+	assignee.ClearDomainEvents()
+	container.UserRepository.Save(ctx, assignee)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Launch", "", ownerID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	leaves := time.Now().Add(10 * 24 * time.Hour).Truncate(time.Hour)
+	returns := leaves.Add(7 * 24 * time.Hour)
+
+	// Only the user or an admin registers time away
+	periods := []command.OutOfOfficeInput{{Start: leaves.Format(time.RFC3339), End: returns.Format(time.RFC3339), Note: "ask the team lead"}}
+	if _, err := container.SetUserOutOfOfficeCommandHandler.Handle(ctx, command.SetUserOutOfOfficeCommand{
+		UserID: assigneeID.Value(), Periods: periods, RequestedBy: ownerID.Value(),
+	}); err == nil {
+		t.Error("Expected another user's out-of-office periods to be refused")
+	}
+	if _, err := container.SetUserOutOfOfficeCommandHandler.Handle(ctx, command.SetUserOutOfOfficeCommand{
+		UserID: assigneeID.Value(), Periods: periods, RequestedBy: assigneeID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to set out-of-office periods: %v", err)
+	}
+
+	created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID:  project.ID().Value(),
+		Title:      "Ship release notes",
+		AssigneeID: assigneeID.Value(),
+		Deadline:   leaves.Add(48 * time.Hour).Format(time.RFC3339),
+		CreatedBy:  ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if len(created.Warnings) != 1 || !strings.Contains(created.Warnings[0], "out of office") || !strings.Contains(created.Warnings[0], "ask the team lead") {
+		t.Errorf("Expected an out-of-office warning, got %v", created.Warnings)
+	}
+
+	// Moving the deadline after the assignee is back clears the warning
+	changed, err := container.ChangeTaskDeadlineCommandHandler.Handle(ctx, command.ChangeTaskDeadlineCommand{
+		TaskID:      created.TaskID,
+		Deadline:    returns.Format(time.RFC3339),
+		Reason:      "wait for the assignee",
+		RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to change deadline: %v", err)
+	}
+	if len(changed.Warnings) != 0 {
+		t.Errorf("Expected no warning once the assignee is back, got %v", changed.Warnings)
+	}
+
+	// A deadline pulled into the time away warns again
+	changed, err = container.ChangeTaskDeadlineCommandHandler.Handle(ctx, command.ChangeTaskDeadlineCommand{
+		TaskID:      created.TaskID,
+		Deadline:    leaves.Format(time.RFC3339),
+		RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to change deadline: %v", err)
+	}
+	if len(changed.Warnings) != 1 {
+		t.Errorf("Expected an out-of-office warning, got %v", changed.Warnings)
+	}
+
+	// Clearing the periods
+	if _, err := container.SetUserOutOfOfficeCommandHandler.Handle(ctx, command.SetUserOutOfOfficeCommand{
+		UserID: assigneeID.Value(), RequestedBy: assigneeID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to clear out-of-office periods: %v", err)
+	}
+	if user, _ := container.UserRepository.GetByID(ctx, assigneeID); len(user.OutOfOffice()) != 0 {
+		t.Error("Expected the out-of-office periods to be cleared")
+	}
+}
+
+// TestProjectWorkflowMigrationAppliesInBatchesAndRollsBack tests switching a live project's workflow in two phases
+func TestProjectWorkflowMigrationAppliesInBatchesAndRollsBack(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "switcher@example.com", "Workflow", "Owner")
+	owner.VerifyEmail()
+	owner.ClearDomainEvents()
+	container.UserRepository.Save(ctx, owner)
+
+	classicID := value.GenerateWorkflowID()
+	classic, _ := aggregate.NewWorkflow(classicID, "Classic", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("TO_DO", "To Do", 1, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "In Progress", 2, false),
+		aggregate.NewWorkflowStatus("COMPLETED", "Completed", 3, true),
+	})
+	container.WorkflowRepository.Save(ctx, classic)
+
+	kanbanID := value.GenerateWorkflowID()
+	kanban, _ := aggregate.NewWorkflow(kanbanID, "Kanban", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("BACKLOG", "Backlog", 1, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "In Progress", 2, false),
+		aggregate.NewWorkflowStatus("COMPLETED", "Completed", 3, true),
+	})
+	container.WorkflowRepository.Save(ctx, kanban)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Switching", "", ownerID, classicID)
+	container.ProjectRepository.Save(ctx, project)
+
+	createTask := func(title string) string {
+		created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+			ProjectID: project.ID().Value(), Title: title, Priority: "LOW", AssigneeID: ownerID.Value(), CreatedBy: ownerID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		return created.TaskID
+	}
+	taskIDs := []string{createTask("Write spec"), createTask("Review spec"), createTask("Build it")}
+	if _, err := container.UpdateTaskStatusCommandHandler.Handle(ctx, command.UpdateTaskStatusCommand{
+		TaskID: taskIDs[2], NewStatus: "IN_PROGRESS", RequestedBy: ownerID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to start task: %v", err)
+	}
+
+	plan := func(mapping map[string]string) (*command.PlanProjectWorkflowMigrationResult, error) {
+		return container.PlanProjectWorkflowMigrationCommandHandler.Handle(ctx, command.PlanProjectWorkflowMigrationCommand{
+			ProjectID: project.ID().Value(), WorkflowID: kanbanID.Value(), StatusMapping: mapping, RequestedBy: ownerID.Value(),
+		})
+	}
+	statusOf := func(taskID string) string {
+		task, _ := container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{TaskID: taskID})
+		return task.Status
+	}
+
+	// Phase one validates every task against the new workflow without moving any
+	if _, err := plan(nil); err == nil || !strings.Contains(err.Error(), "map it to one of its statuses") {
+		t.Fatalf("Expected unmapped TO_DO tasks to fail planning, got %v", err)
+	}
+	planned, err := plan(map[string]string{"TO_DO": "BACKLOG"})
+	if err != nil {
+		t.Fatalf("Failed to plan migration: %v", err)
+	}
+	if planned.Total != 3 || planned.Remapped != 2 {
+		t.Errorf("Expected 3 tasks with 2 remapped, got %+v", planned)
+	}
+	if statusOf(taskIDs[0]) != "TO_DO" {
+		t.Error("Expected planning to leave tasks where they are")
+	}
+
+	migration, err := container.GetProjectWorkflowMigrationQueryHandler.Handle(context.Background(), query.GetProjectWorkflowMigrationQuery{ProjectID: project.ID().Value()})
+	if err != nil {
+		t.Fatalf("Failed to get migration: %v", err)
+	}
+	if migration.Status != "PLANNED" || len(migration.Moves) != 2 || migration.Moves[1].FromStatus != "TO_DO" || migration.Moves[1].Tasks != 2 {
+		t.Errorf("Unexpected plan %+v", migration)
+	}
+
+	// A direct workflow switch waits while tasks are half moved, so apply reports progress per batch
+	progress := make([]int, 0)
+	container.EventSubscriber.Subscribe("ProjectWorkflowMigrationProgressed", func(evt event.DomainEvent) error {
+		progress = append(progress, evt.(event.ProjectWorkflowMigrationProgressedEvent).Applied)
+		return nil
+	})
+	migrated := 0
+	container.EventSubscriber.Subscribe("TaskWorkflowMigrated", func(evt event.DomainEvent) error {
+		migrated++
+		return nil
+	})
+
+	applied, err := container.ApplyProjectWorkflowMigrationCommandHandler.Handle(ctx, command.ApplyProjectWorkflowMigrationCommand{
+		ProjectID: project.ID().Value(), BatchSize: 2, RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to apply migration: %v", err)
+	}
+	if applied.Applied != 3 || applied.Status != "APPLIED" {
+		t.Errorf("Expected every task applied, got %+v", applied)
+	}
+	if len(progress) != 2 || progress[0] != 2 || progress[1] != 3 {
+		t.Errorf("Expected progress after each batch of 2, got %v", progress)
+	}
+	if migrated != 3 {
+		t.Errorf("Expected an event per migrated task, got %d", migrated)
+	}
+	if statusOf(taskIDs[0]) != "BACKLOG" || statusOf(taskIDs[2]) != "IN_PROGRESS" {
+		t.Error("Expected tasks moved as planned")
+	}
+	if switched, _ := container.ProjectRepository.GetByID(ctx, project.ID()); !switched.WorkflowID().Equals(kanbanID) {
+		t.Error("Expected the project switched to the new workflow")
+	}
+	if _, err := container.ApplyProjectWorkflowMigrationCommandHandler.Handle(ctx, command.ApplyProjectWorkflowMigrationCommand{
+		ProjectID: project.ID().Value(), RequestedBy: ownerID.Value(),
+	}); err == nil {
+		t.Error("Expected an applied migration not to apply twice")
+	}
+
+	// Rollback restores the recorded statuses and the previous workflow
+	rolledBack, err := container.RollBackProjectWorkflowMigrationCommandHandler.Handle(ctx, command.RollBackProjectWorkflowMigrationCommand{
+		ProjectID: project.ID().Value(), RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to roll back migration: %v", err)
+	}
+	if rolledBack.Restored != 3 || statusOf(taskIDs[0]) != "TO_DO" {
+		t.Errorf("Expected tasks restored, got %+v", rolledBack)
+	}
+	restored, _ := container.ProjectRepository.GetByID(ctx, project.ID())
+	if !restored.WorkflowID().Equals(classicID) {
+		t.Error("Expected the project back on its previous workflow")
+	}
+	reviewID, _ := value.NewTaskID(taskIDs[1])
+	if task, _ := container.TaskRepository.GetByID(ctx, reviewID); task.WorkflowVersion() == nil || !task.WorkflowVersion().WorkflowID().Equals(classicID) {
+		t.Error("Expected tasks pinned to the previous workflow again")
+	}
+
+	// A plan goes stale when the project changes before it is applied
+	if _, err := plan(map[string]string{"TO_DO": "BACKLOG"}); err != nil {
+		t.Fatalf("Failed to plan migration again: %v", err)
+	}
+	createTask("Late arrival")
+	if _, err := container.ApplyProjectWorkflowMigrationCommandHandler.Handle(ctx, command.ApplyProjectWorkflowMigrationCommand{
+		ProjectID: project.ID().Value(), RequestedBy: ownerID.Value(),
+	}); err == nil || !strings.Contains(err.Error(), "plan is stale") {
+		t.Errorf("Expected a stale plan to be refused, got %v", err)
+	}
+}
+
+// flakyProjectRepository fails the next failures project updates
+type flakyProjectRepository struct {
+	domain.ProjectRepository
+	failures int
+	updates  int
+}
+
+func (r *flakyProjectRepository) Update(ctx context.Context, project *aggregate.Project) error {
+	r.updates++
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("connection reset by peer")
+	}
+	return r.ProjectRepository.Update(ctx, project)
+}
+
+// TestCreateTaskRollsBackWhenTheProjectCannotBeUpdated tests that no task is orphaned outside its project
+func TestCreateTaskRollsBackWhenTheProjectCannotBeUpdated(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "flaky@example.com", "Flaky", "Storage")
+	container.UserRepository.Save(ctx, owner)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Unreliable", "", ownerID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	projects := &flakyProjectRepository{ProjectRepository: container.ProjectRepository}
+	unitOfWork := repository.NewInMemoryUnitOfWorkFactory(repository.UnitOfWorkRepositories{
+		Tasks:     container.TaskRepository,
+		Projects:  projects,
+		Users:     container.UserRepository,
+		Workflows: container.WorkflowRepository,
+	}, container.EventPublisher.(repository.Outbox))
+	handler := command.NewCreateTaskCommandHandler(
+		unitOfWork,
+		container.EventPublisher,
+		container.Authorizer,
+		container.TaskAssignmentService,
+		container.DeadlineEnforcementService,
+		container.DuplicateDetectionService,
+		container.QuotaEnforcementService,
+	)
+	handler.SetTransactionRetry(3, time.Millisecond)
+
+	failures := make([]event.TaskCreationFailedEvent, 0)
+	container.EventSubscriber.Subscribe("TaskCreationFailed", func(evt event.DomainEvent) error {
+		failures = append(failures, evt.(event.TaskCreationFailedEvent))
+		return nil
+	})
+	created := 0
+	container.EventSubscriber.Subscribe("TaskCreated", func(evt event.DomainEvent) error {
+		created++
+		return nil
+	})
+
+	createTask := func(title string) (*command.CreateTaskResult, error) {
+		return handler.Handle(ctx, command.CreateTaskCommand{
+			ProjectID: project.ID().Value(), Title: title, Priority: "LOW", CreatedBy: ownerID.Value(),
+		})
+	}
+
+	// Transient failures are retried
+	projects.failures = 2
+	result, err := createTask("Survives two hiccups")
+	if err != nil {
+		t.Fatalf("Expected the project update to be retried, got %v", err)
+	}
+	if projects.updates != 3 || len(failures) != 0 {
+		t.Errorf("Expected 3 updates and no failure, got %d updates and %d failures", projects.updates, len(failures))
+	}
+	stored, _ := container.ProjectRepository.GetByID(ctx, project.ID())
+	if len(stored.TaskIDs()) != 1 || stored.TaskIDs()[0].Value() != result.TaskID {
+		t.Errorf("Expected the task in its project, got %v", stored.TaskIDs())
+	}
+
+	// A lasting failure rolls the saved task back and reports it
+	projects.failures, projects.updates = 10, 0
+	if _, err := createTask("Never lands"); err == nil || !strings.Contains(err.Error(), "failed to update project") {
+		t.Fatalf("Expected the creation to fail, got %v", err)
+	}
+	if projects.updates != 3 {
+		t.Errorf("Expected 3 attempts, got %d", projects.updates)
+	}
+	if len(failures) != 1 || !failures[0].Compensated || failures[0].Title != "Never lands" || failures[0].Reason != "connection reset by peer" {
+		t.Fatalf("Expected a compensated TaskCreationFailed event, got %+v", failures)
+	}
+	orphanID, _ := value.NewTaskID(failures[0].AggregateID())
+	if _, err := container.TaskRepository.GetByID(ctx, orphanID); err == nil {
+		t.Error("Expected the orphaned task to be deleted")
+	}
+	tasks, _ := container.TaskRepository.GetByProjectID(ctx, project.ID())
+	if len(tasks) != 1 {
+		t.Errorf("Expected the orphaned task deleted, got %d tasks", len(tasks))
+	}
+	if stored, _ := container.ProjectRepository.GetByID(ctx, project.ID()); len(stored.TaskIDs()) != 1 {
+		t.Errorf("Expected the project to keep only the first task, got %v", stored.TaskIDs())
+	}
+	if created != 1 {
+		t.Errorf("Expected only the stored task announced, got %d TaskCreated events", created)
+	}
+}
+
+// TestCompletingSubtasksClosesTheParentAndUpdatesProgress tests the follow-ups to completed tasks
+func TestCompletingSubtasksClosesTheParentAndUpdatesProgress(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	priority, _ := value.NewPriority("MEDIUM")
+	user, _ := aggregate.NewUser(userID, "subtasks@example.com", "Subtask", "Owner")
+	container.UserRepository.Save(ctx, user)
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Subtask Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	deadline, _ := value.NewDeadline(time.Now().AddDate(0, 0, 7))
+	newTask := func(title string) *aggregate.Task {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), title, "", priority, userID)
+		task.Assign(userID, userID)
+		task.SetDeadline(deadline, userID, "")
+		task.ChangeStatus(value.TaskStatusInProgress)
+		task.ChangeStatus(value.TaskStatusInReview)
+		container.TaskRepository.Save(ctx, task)
+		project.AddTask(task.ID())
+		return task
+	}
+	parent := newTask("Parent")
+	first := newTask("First subtask")
+	second := newTask("Second subtask")
+	other := newTask("Other parent")
+
+	link := func(source, target *aggregate.Task, linkType string) error {
+		_, err := container.LinkTasksCommandHandler.Handle(context.Background(), command.LinkTasksCommand{
+			TaskID:       source.ID().Value(),
+			TargetTaskID: target.ID().Value(),
+			LinkType:     linkType,
+			LinkedBy:     userID.Value(),
+		})
+		return err
+	}
+	if err := link(first, parent, "SUBTASK_OF"); err != nil {
+		t.Fatalf("Failed to link subtask: %v", err)
+	}
+	if err := link(parent, second, "PARENT_OF"); err != nil {
+		t.Fatalf("Failed to link subtask: %v", err)
+	}
+	if err := link(other, second, "PARENT_OF"); err == nil || !strings.Contains(err.Error(), "already has a parent task") {
+		t.Errorf("Expected a second parent to be refused, got %v", err)
+	}
+
+	complete := func(task *aggregate.Task) {
+		_, err := container.UpdateTaskStatusCommandHandler.Handle(context.Background(), command.UpdateTaskStatusCommand{
+			TaskID:      task.ID().Value(),
+			NewStatus:   "COMPLETED",
+			RequestedBy: userID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to complete %s: %v", task.Title(), err)
+		}
+	}
+
+	complete(first)
+	if status := storedTask(t, container, parent.ID()).Status(); status != value.TaskStatusInReview {
+		t.Errorf("Expected the parent to stay open while a subtask is, got %s", status.Value())
+	}
+	if project.CompletedTaskCount() != 1 {
+		t.Errorf("Expected 1 completed task in the project, got %d", project.CompletedTaskCount())
+	}
+
+	complete(second)
+	if status := storedTask(t, container, parent.ID()).Status(); status != value.TaskStatusCompleted {
+		t.Errorf("Expected the parent to be completed with its last subtask, got %s", status.Value())
+	}
+	if project.CompletedTaskCount() != 3 {
+		t.Errorf("Expected the parent to count towards progress too, got %d", project.CompletedTaskCount())
+	}
+
+	failed, _ := container.ProcessManager.Failed(ctx)
+	if len(failed) != 0 {
+		t.Errorf("Expected no failed reactions, got %+v", failed)
+	}
+}
+
+// TestFailedProcessReactionsAreRetried tests that a parent the workflow cannot complete yet is closed by a retry
+func TestFailedProcessReactionsAreRetried(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	priority, _ := value.NewPriority("MEDIUM")
+	user, _ := aggregate.NewUser(userID, "retries@example.com", "Retry", "Owner")
+	container.UserRepository.Save(ctx, user)
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Retry Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	deadline, _ := value.NewDeadline(time.Now().AddDate(0, 0, 7))
+	parent, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Parent", "", priority, userID)
+	parent.Assign(userID, userID)
+	parent.SetDeadline(deadline, userID, "")
+	subtask, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Subtask", "", priority, userID)
+	subtask.Assign(userID, userID)
+	subtask.SetDeadline(deadline, userID, "")
+	subtask.ChangeStatus(value.TaskStatusInProgress)
+	subtask.ChangeStatus(value.TaskStatusInReview)
+	container.TaskRepository.Save(ctx, parent)
+	container.TaskRepository.Save(ctx, subtask)
+
+	_, err := container.LinkTasksCommandHandler.Handle(context.Background(), command.LinkTasksCommand{
+		TaskID:       subtask.ID().Value(),
+		TargetTaskID: parent.ID().Value(),
+		LinkType:     "SUBTASK_OF",
+		LinkedBy:     userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to link subtask: %v", err)
+	}
+
+	// The parent is still TO_DO, which the default workflow cannot complete from
+	_, err = container.UpdateTaskStatusCommandHandler.Handle(context.Background(), command.UpdateTaskStatusCommand{
+		TaskID:      subtask.ID().Value(),
+		NewStatus:   "COMPLETED",
+		RequestedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Expected the failed follow-up not to fail the command, got %v", err)
+	}
+	parent = storedTask(t, container, parent.ID())
+	if parent.Status() != value.TaskStatusToDo {
+		t.Fatalf("Expected the parent to stay TO_DO, got %s", parent.Status().Value())
+	}
+
+	pending, _ := container.ProcessStates.GetDue(ctx, time.Now().Add(time.Hour))
+	if len(pending) != 1 || pending[0].Reaction != "parent_task_completion" || pending[0].LastError == "" {
+		t.Fatalf("Expected the parent completion to wait for a retry, got %+v", pending)
+	}
+
+	// Nothing is retried before the backoff has passed
+	if completed, _ := container.ProcessManager.RetryDue(context.Background()); completed != 0 {
+		t.Errorf("Expected no retry before the backoff passed, got %d", completed)
+	}
+
+	parent.ChangeStatus(value.TaskStatusInProgress)
+	parent.ChangeStatus(value.TaskStatusInReview)
+	container.TaskRepository.Update(ctx, parent)
+	container.ProcessManager.SetClock(func() time.Time { return time.Now().Add(time.Hour) })
+
+	completed, err := container.ProcessManager.RetryDue(context.Background())
+	if err != nil || completed != 1 {
+		t.Fatalf("Expected the retry to complete, got %d, %v", completed, err)
+	}
+	if status := storedTask(t, container, parent.ID()).Status(); status != value.TaskStatusCompleted {
+		t.Errorf("Expected the retry to complete the parent, got %s", status.Value())
+	}
+}
@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/pkg/taskmanagement"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// TestEmbeddedEngineDispatchesCommandsAndQueries tests the library facade end to end
+func TestEmbeddedEngineDispatchesCommandsAndQueries(t *testing.T) {
+	ctx := context.Background()
+	repos := di.InMemoryRepositories()
+	engine := taskmanagement.New(taskmanagement.WithRepositories(repos))
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "embed@example.com", "Embed", "User")
+	repos.User.Save(ctx, user)
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Embedded", "", userID, value.GenerateWorkflowID())
+	repos.Project.Save(ctx, project)
+
+	created := 0
+	engine.Subscribe("TaskCreated", func(evt event.DomainEvent) error {
+		created++
+		return nil
+	})
+
+	result, err := engine.Commands().Dispatch(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Embedded task",
+		Priority:  "HIGH",
+		CreatedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to dispatch command: %v", err)
+	}
+
+	taskID := result.(*command.CreateTaskResult).TaskID
+	if created != 1 {
+		t.Errorf("Expected subscriber to see one TaskCreated event, got %d", created)
+	}
+
+	answer, err := engine.Queries().Ask(context.Background(), query.GetTaskQuery{TaskID: taskID})
+	if err != nil {
+		t.Fatalf("Failed to ask query: %v", err)
+	}
+
+	if answer.(*dto.TaskDTO).Title != "Embedded task" {
+		t.Errorf("Expected task title to round-trip, got %q", answer.(*dto.TaskDTO).Title)
+	}
+
+	if _, err := engine.Commands().Dispatch(context.Background(), "not a command"); err == nil {
+		t.Error("Expected unsupported command to fail")
+	}
+}
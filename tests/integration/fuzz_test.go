@@ -0,0 +1,217 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/interface/contract"
+	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// fuzzFixture is a container with an admin, a project and a task for fuzzed requests to target
+type fuzzFixture struct {
+	container *di.Container
+	router    *httpServer.Router
+	adminID   value.UserID
+	userID    value.UserID
+	project   *aggregate.Project
+	taskID    string
+}
+
+func newFuzzFixture(f *testing.F) *fuzzFixture {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	fixture := &fuzzFixture{container: container}
+	fixture.adminID = fuzzUser(f, container, "fuzz-admin@example.com", value.GlobalRoleAdmin)
+	fixture.userID = fuzzUser(f, container, "fuzz-user@example.com", "")
+
+	fixture.project, _ = aggregate.NewProject(value.GenerateProjectID(), "Fuzzing", "", fixture.adminID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, fixture.project)
+
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), fixture.project.ID(), "Fuzz target", "", value.PriorityMedium, fixture.adminID)
+	task.ClearDomainEvents()
+	container.TaskRepository.Save(ctx, task)
+	fixture.taskID = task.ID().Value()
+
+	fixture.router = httpServer.NewRouter(container)
+	fixture.router.SetupRoutes()
+	return fixture
+}
+
+func fuzzUser(f *testing.F, container *di.Container, email string, role value.GlobalRole) value.UserID {
+	ctx := context.Background()
+	userID := value.GenerateUserID()
+	user, err := aggregate.NewUser(userID, email, "Fuzz", "User")
+	if err != nil {
+		f.Fatalf("Failed to create user: %v", err)
+	}
+	if role != "" {
+		user.GrantRole(role)
+	}
+	user.VerifyEmail()
+	user.ClearDomainEvents()
+	container.UserRepository.Save(ctx, user)
+	return userID
+}
+
+// call sends a request as the admin. A fresh token is issued every time, since fuzzed
+// requests may log out or change the password and revoke the earlier ones.
+func (x *fuzzFixture) call(t *testing.T, method, path string, body []byte) *httptest.ResponseRecorder {
+	tokens, err := x.container.TokenIssuer.Issue(x.adminID.Value(), []string{value.GlobalRoleAdmin.Value()})
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+
+	request := httptest.NewRequest(method, path, bytes.NewReader(body))
+	request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	x.router.Handler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+// expectHandled fails on server errors and on responses that are not well-formed JSON
+func expectHandled(t *testing.T, method, path string, body []byte, response *httptest.ResponseRecorder) {
+	t.Helper()
+
+	if response.Code >= http.StatusInternalServerError {
+		t.Fatalf("%s %s answered %d for body %q: %s", method, path, response.Code, body, response.Body.String())
+	}
+	if response.Body.Len() > 0 && !json.Valid(response.Body.Bytes()) {
+		t.Fatalf("%s %s answered malformed JSON for body %q: %s", method, path, body, response.Body.String())
+	}
+}
+
+// FuzzCreateTaskRequest tests that any create task request is refused or creates a valid task
+func FuzzCreateTaskRequest(f *testing.F) {
+	ctx := context.Background()
+	fixture := newFuzzFixture(f)
+
+	f.Add("Write release notes", "", "HIGH", "", 0.0)
+	f.Add("Plan sprint", "Agenda for planning", "low", time.Now().Add(48*time.Hour).Format(time.RFC3339), 4.5)
+	f.Add("", "", "", "", 0.0)
+	f.Add("   ", "\x00", "URGENT", "tomorrow", -1.0)
+	f.Add(strings.Repeat("long title ", 100), "", "MEDIUM", "2000-01-01T00:00:00Z", 1e300)
+	f.Add("Überprüfung 🚀", "", "CRITICAL", "9999-12-31T23:59:59Z", 0.001)
+
+	f.Fuzz(func(t *testing.T, title, description, priority, deadline string, hours float64) {
+		if math.IsNaN(hours) || math.IsInf(hours, 0) {
+			t.Skip("JSON cannot carry NaN or infinity")
+		}
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"project_id":      fixture.project.ID().Value(),
+			"title":           title,
+			"description":     description,
+			"priority":        priority,
+			"deadline":        deadline,
+			"estimated_hours": hours,
+		})
+
+		response := fixture.call(t, http.MethodPost, "/api/tasks", body)
+		expectHandled(t, http.MethodPost, "/api/tasks", body, response)
+		if response.Code != http.StatusCreated {
+			return
+		}
+
+		var created struct {
+			TaskID string `json:"task_id"`
+		}
+		json.Unmarshal(response.Body.Bytes(), &created)
+		taskID, err := value.NewTaskID(created.TaskID)
+		if err != nil {
+			t.Fatalf("Created task has an invalid id %q", created.TaskID)
+		}
+		task, err := fixture.container.TaskRepository.GetByID(ctx, taskID)
+		if err != nil {
+			t.Fatalf("Created task %s is not stored: %v", created.TaskID, err)
+		}
+
+		if strings.TrimSpace(task.Title()) == "" || !utf8.ValidString(task.Title()) {
+			t.Errorf("Created a task with an invalid title %q", task.Title())
+		}
+		if _, err := value.NewPriority(task.Priority().Value()); err != nil {
+			t.Errorf("Created a task with an invalid priority %q", task.Priority().Value())
+		}
+		if task.EstimatedHours() < 0 {
+			t.Errorf("Created a task with a negative estimate %v", task.EstimatedHours())
+		}
+		if task.Deadline() != nil && task.Deadline().Value().Before(task.CreatedAt().Add(-time.Second)) {
+			t.Errorf("Created a task with a deadline in the past %s", task.Deadline())
+		}
+	})
+}
+
+// FuzzJSONRequestBodies tests that no endpoint taking a JSON body fails on malformed input.
+// Endpoints come from the contract, so new ones are fuzzed without listing them here.
+func FuzzJSONRequestBodies(f *testing.F) {
+	fixture := newFuzzFixture(f)
+
+	operations := make([]contract.Operation, 0)
+	for _, op := range contract.Operations() {
+		if op.Request != nil {
+			operations = append(operations, op)
+		}
+	}
+
+	malformed := [][]byte{
+		nil,
+		[]byte(`{`),
+		[]byte(`null`),
+		[]byte(`[]`),
+		[]byte(`"text"`),
+		[]byte(`{"id": {"nested": true}}`),
+		[]byte(`{"title": 1e400}`),
+		[]byte("{\"name\": \"\xff\xfe\"}"),
+	}
+	for i, op := range operations {
+		prototype, _ := json.Marshal(op.Request)
+		f.Add(uint16(i), prototype)
+		f.Add(uint16(i), malformed[i%len(malformed)])
+	}
+
+	f.Fuzz(func(t *testing.T, index uint16, body []byte) {
+		op := operations[int(index)%len(operations)]
+		path := fixture.pathFor(op)
+
+		response := fixture.call(t, op.Method, path, body)
+		expectHandled(t, op.Method, path, body, response)
+	})
+}
+
+// pathFor fills an operation's required parameters with the fixture's IDs
+func (x *fuzzFixture) pathFor(op contract.Operation) string {
+	query := url.Values{}
+	for _, param := range op.Params {
+		if !param.Required {
+			continue
+		}
+
+		switch {
+		case param.Name == "project_id" || (param.Name == "id" && op.Tag == "projects"):
+			query.Set(param.Name, x.project.ID().Value())
+		case param.Name == "user_id" || (param.Name == "id" && op.Tag == "users"):
+			query.Set(param.Name, x.userID.Value())
+		default:
+			query.Set(param.Name, x.taskID)
+		}
+	}
+
+	if len(query) == 0 {
+		return op.Path
+	}
+	return op.Path + "?" + query.Encode()
+}
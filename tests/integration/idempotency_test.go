@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/value"
+	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// TestIdempotencyKeyReplaysTaskCreation tests that a retried POST with the same key creates the task once
+func TestIdempotencyKeyReplaysTaskCreation(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+
+	call := func(accessToken, key, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/api/tasks", strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+accessToken)
+		if key != "" {
+			request.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	signUp := httptest.NewRecorder()
+	router.Handler().ServeHTTP(signUp, httptest.NewRequest(http.MethodPost, "/api/auth/signup", strings.NewReader(
+		`{"organization_name":"Retry","email":"retry@example.com","first_name":"Rita","last_name":"Retry","password":"a-password"}`)))
+	var tenant dto.SignUpDTO
+	json.NewDecoder(signUp.Body).Decode(&tenant)
+	token := tenant.Tokens.AccessToken
+	body := `{"project_id":"` + tenant.ProjectID + `","title":"Sent twice","priority":"LOW"}`
+
+	first := call(token, "create-1", body)
+	if first.Code != http.StatusCreated || first.Header().Get(middleware.IdempotentReplayHeader) != "" {
+		t.Fatalf("Expected the first request to create the task, got %d %s", first.Code, first.Body.String())
+	}
+	retry := call(token, "create-1", body)
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() || retry.Header().Get(middleware.IdempotentReplayHeader) != "true" {
+		t.Errorf("Expected the retry to replay the first response, got %d %s", retry.Code, retry.Body.String())
+	}
+
+	projectID, _ := value.NewProjectID(tenant.ProjectID)
+	countTasks := func() int {
+		tasks, _ := container.TaskRepository.GetByProjectID(ctx, projectID)
+		count := 0
+		for _, task := range tasks {
+			if task.Title() == "Sent twice" {
+				count++
+			}
+		}
+		return count
+	}
+	if count := countTasks(); count != 1 {
+		t.Errorf("Expected the task created once, got %d", count)
+	}
+
+	// A key belongs to one request
+	reused := call(token, "create-1", strings.Replace(body, "Sent twice", "Something else", 1))
+	if reused.Code != http.StatusBadRequest || !strings.Contains(reused.Body.String(), "different request") {
+		t.Errorf("Expected a reused key to be refused, got %d %s", reused.Code, reused.Body.String())
+	}
+
+	// Requests without a key, or with a new one, run again
+	if response := call(token, "", body); response.Code != http.StatusCreated {
+		t.Errorf("Expected a request without a key to run, got %d", response.Code)
+	}
+	if response := call(token, "create-2", body); response.Code != http.StatusCreated || response.Body.String() == first.Body.String() {
+		t.Errorf("Expected a new key to create another task, got %d %s", response.Code, response.Body.String())
+	}
+	if count := countTasks(); count != 3 {
+		t.Errorf("Expected three tasks, got %d", count)
+	}
+}
@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/notification"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+type recordingSender struct {
+	sent []notification.Notification
+}
+
+func (s *recordingSender) Send(n notification.Notification) error {
+	s.sent = append(s.sent, n)
+	return nil
+}
+
+// TestNotificationRoutingFollowsProjectRules tests immediate and digest delivery of routed events
+func TestNotificationRoutingFollowsProjectRules(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+	sender := &recordingSender{}
+	container.NotificationDispatcher.SetSender(value.NotificationChannelSlack, sender)
+	container.NotificationDispatcher.SetSender(value.NotificationChannelEmail, sender)
+
+	userID := value.GenerateUserID()
+	priority, _ := value.NewPriority("HIGH")
+	user, _ := aggregate.NewUser(userID, "routes@example.com", "Routes", "Owner")
+	container.UserRepository.Save(ctx, user)
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Routed", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Routed task", "", priority, userID)
+	container.TaskRepository.Save(ctx, task)
+
+	_, err := container.SetNotificationRoutesCommandHandler.Handle(context.Background(), command.SetNotificationRoutesCommand{
+		ProjectID: project.ID().Value(),
+		Routes: []command.NotificationRouteInput{
+			{EventType: "TaskOverdue", Channel: "SLACK", Target: "#alerts"},
+			{EventType: "TaskCompleted", Channel: "EMAIL", Target: "team@example.com", Delivery: "WEEKLY_DIGEST"},
+		},
+		RequestedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to set routes: %v", err)
+	}
+
+	container.EventPublisher.Publish(ctx, event.NewTaskOverdueEvent(task.ID().Value(), 2))
+	container.EventPublisher.Publish(ctx, event.NewTaskCompletedEvent(task.ID().Value(), userID.Value(), time.Now().Format(time.RFC3339)))
+	container.EventPublisher.Publish(ctx, event.NewTaskAssignedEvent(task.ID().Value(), userID.Value(), ""))
+
+	if len(sender.sent) != 1 || sender.sent[0].Target != "#alerts" {
+		t.Fatalf("Expected one immediate Slack alert, got %+v", sender.sent)
+	}
+
+	if pending := container.NotificationDispatcher.PendingDigestLines(project.ID().Value()); pending != 1 {
+		t.Errorf("Expected one pending digest line, got %d", pending)
+	}
+
+	container.NotificationDispatcher.FlushDue(time.Now().Add(24 * time.Hour))
+	if len(sender.sent) != 1 {
+		t.Error("Expected weekly digest to wait a full week")
+	}
+
+	container.NotificationDispatcher.FlushDue(time.Now().Add(8 * 24 * time.Hour))
+	if len(sender.sent) != 2 || sender.sent[1].Target != "team@example.com" {
+		t.Errorf("Expected the digest email after a week, got %+v", sender.sent)
+	}
+}
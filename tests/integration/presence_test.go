@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/interface/http/websocket"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// wsClient is a bare WebSocket client for exercising the presence protocol
+type wsClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// dialPresence opens the presence WebSocket with a user's access token
+func dialPresence(t *testing.T, server *httptest.Server, accessToken string) *wsClient {
+	t.Helper()
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+
+	key := "dGhlIHNhbXBsZSBub25jZQ=="
+	fmt.Fprintf(conn, "GET /api/presence/ws?access_token=%s HTTP/1.1\r\nHost: %s\r\n"+
+		"Upgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\n\r\n",
+		url.QueryEscape(accessToken), address, key)
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("Failed to read handshake: %v", err)
+	}
+	if response.StatusCode != http.StatusSwitchingProtocols || response.Header.Get("Sec-WebSocket-Accept") != websocket.AcceptKey(key) {
+		t.Fatalf("Unexpected handshake response: %d %v", response.StatusCode, response.Header)
+	}
+
+	return &wsClient{conn: conn, reader: reader}
+}
+
+// send writes a masked text frame
+func (c *wsClient) send(t *testing.T, message dto.PresenceMessage) {
+	t.Helper()
+
+	payload, _ := json.Marshal(message)
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x81, 0x80 | byte(len(payload))}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatalf("Failed to send: %v", err)
+	}
+}
+
+// receive reads text frames until one is a presence update
+func (c *wsClient) receive(t *testing.T) dto.PresenceMessage {
+	t.Helper()
+
+	c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(c.reader, header); err != nil {
+			t.Fatalf("Failed to receive: %v", err)
+		}
+
+		length := int(header[1] & 0x7F)
+		if length == 126 {
+			extended := make([]byte, 2)
+			io.ReadFull(c.reader, extended)
+			length = int(extended[0])<<8 | int(extended[1])
+		}
+		payload := make([]byte, length)
+		io.ReadFull(c.reader, payload)
+
+		if header[0]&0x0F != 0x1 {
+			continue
+		}
+
+		var message dto.PresenceMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			t.Fatalf("Invalid message %q: %v", payload, err)
+		}
+		return message
+	}
+}
+
+// TestPresenceOverWebSocketAndREST tests that collaborators see each other viewing a task
+func TestPresenceOverWebSocketAndREST(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+	aliceID := value.GenerateUserID()
+	alice, _ := aggregate.NewUser(aliceID, "alice@example.com", "Alice", "Smith")
+	container.UserRepository.Save(ctx, alice)
+	bobID := value.GenerateUserID()
+	bob, _ := aggregate.NewUser(bobID, "bob@example.com", "Bob", "Jones")
+	container.UserRepository.Save(ctx, bob)
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+	server := httptest.NewServer(router.Handler())
+	defer server.Close()
+
+	taskID := value.GenerateTaskID().Value()
+
+	aliceTokens, _ := container.TokenIssuer.Issue(aliceID.Value(), nil)
+	bobTokens, _ := container.TokenIssuer.Issue(bobID.Value(), nil)
+
+	client := dialPresence(t, server, aliceTokens.AccessToken)
+	defer client.conn.Close()
+
+	client.send(t, dto.PresenceMessage{Type: "view", Kind: "TASK", ItemID: taskID})
+	update := client.receive(t)
+	if update.Type != "presence" || len(update.Viewers) != 1 || update.Viewers[0].Name != "Alice Smith" {
+		t.Fatalf("Expected alice to see herself viewing the task, got %+v", update)
+	}
+
+	// A REST client joins and the WebSocket client is told
+	body := strings.NewReader(fmt.Sprintf(`{"kind":"TASK","item_id":%q}`, taskID))
+	request, _ := http.NewRequest(http.MethodPut, server.URL+"/api/presence", body)
+	request.Header.Set("Authorization", "Bearer "+bobTokens.AccessToken)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Fatalf("Heartbeat failed: %v", err)
+	}
+	response.Body.Close()
+
+	update = client.receive(t)
+	if len(update.Viewers) != 2 || update.Viewers[1].UserID != bobID.Value() {
+		t.Errorf("Expected bob to join the viewers, got %+v", update.Viewers)
+	}
+
+	// Closing the socket removes alice for REST readers
+	client.conn.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		var current dto.PresenceDTO
+		request, _ := http.NewRequest(http.MethodGet, server.URL+"/api/presence?kind=TASK&id="+taskID, nil)
+		request.Header.Set("Authorization", "Bearer "+bobTokens.AccessToken)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("Failed to get presence: %v", err)
+		}
+		json.NewDecoder(response.Body).Decode(&current)
+		response.Body.Close()
+
+		if len(current.Viewers) == 1 && current.Viewers[0].UserID == bobID.Value() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected only bob after alice disconnected, got %+v", current.Viewers)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
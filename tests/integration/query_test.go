@@ -0,0 +1,1392 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/value"
+	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// TestEvaluateDashboardQueryFlow tests batch evaluation of a user's widgets
+func TestEvaluateDashboardQueryFlow(t *testing.T) {
+	ctx := context.Background()
+	// Setup
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "lead@example.com", "Team", "Lead")
+	container.UserRepository.Save(ctx, user)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	widgets := []command.CreateWidgetCommand{
+		{OwnerID: userID.Value(), Title: "Stats", Type: "PROJECT_STATS", Parameters: map[string]string{"project_id": project.ID().Value()}, Width: 1, Height: 1},
+		{OwnerID: userID.Value(), Title: "Missing", Type: "PROJECT_STATS", Parameters: map[string]string{"project_id": "unknown"}, Row: 1, Width: 1, Height: 1},
+	}
+	for _, cmd := range widgets {
+		if _, err := container.CreateWidgetCommandHandler.Handle(context.Background(), cmd); err != nil {
+			t.Fatalf("Expected no error creating widget, got %v", err)
+		}
+	}
+
+	// Execute
+	results, err := container.EvaluateDashboardQueryHandler.Handle(context.Background(), query.EvaluateDashboardQuery{
+		OwnerID: userID.Value(),
+	})
+
+	// Verify
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 widget results, got %d", len(results))
+	}
+
+	if results[0].Error != "" || results[0].Data == nil {
+		t.Errorf("Expected first widget to evaluate, got error %q", results[0].Error)
+	}
+
+	if results[1].Error == "" {
+		t.Error("Expected second widget to report its error")
+	}
+}
+
+// TestRebuildProjectionsReplaysEventStore tests that a truncated read model is restored by replay
+func TestRebuildProjectionsReplaysEventStore(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Quarterly planning", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	container.EventPublisher.PublishAll(ctx, project.DomainEvents())
+
+	suggestions := func() int {
+		results, _ := container.SearchSuggestionsQueryHandler.Handle(context.Background(), query.SearchSuggestionsQuery{Text: "quarterly"})
+		return len(results)
+	}
+
+	if suggestions() != 1 {
+		t.Fatalf("Expected project to be indexed on publish")
+	}
+
+	container.SuggestionIndex.Clear()
+	if suggestions() != 0 {
+		t.Fatalf("Expected empty index after clear")
+	}
+
+	progressCalls := 0
+	report, err := container.ProjectionRebuilder.Rebuild(ctx, func(p command.RebuildProgress) {
+		progressCalls++
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.EventsReplayed != 1 || progressCalls != 1 {
+		t.Errorf("Expected 1 replayed event, got %d (progress calls %d)", report.EventsReplayed, progressCalls)
+	}
+
+	if suggestions() != 1 {
+		t.Errorf("Expected project to be indexed again after rebuild")
+	}
+}
+
+// TestProjectBoardGroupsTasksByWorkflowColumn tests the kanban board read model
+func TestProjectBoardGroupsTasksByWorkflowColumn(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	workflowID := value.GenerateWorkflowID()
+	workflow, _ := aggregate.NewWorkflow(workflowID, "Board Workflow", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "Doing", 2, false).WithWIPLimit(1),
+		aggregate.NewWorkflowStatus("TO_DO", "To Do", 1, false).WithWIPLimit(5),
+		aggregate.NewWorkflowStatus("COMPLETED", "Done", 3, true),
+	})
+	container.WorkflowRepository.Save(ctx, workflow)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Board Project", "", userID, workflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	for i := 0; i < 2; i++ {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Doing", "", priority, userID)
+		task.Assign(userID, userID)
+		task.ChangeStatus(value.TaskStatusInProgress)
+		container.TaskRepository.Save(ctx, task)
+	}
+	todo, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Todo", "", priority, userID)
+	container.TaskRepository.Save(ctx, todo)
+	review, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Review", "", priority, userID)
+	review.Assign(userID, userID)
+	review.ChangeStatus(value.TaskStatusInProgress)
+	review.ChangeStatus(value.TaskStatusInReview)
+	container.TaskRepository.Save(ctx, review)
+
+	board, err := container.GetProjectBoardQueryHandler.Handle(context.Background(), query.GetProjectBoardQuery{
+		ProjectID: project.ID().Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to get board: %v", err)
+	}
+
+	if board.TaskCount != 4 || len(board.Columns) != 4 {
+		t.Fatalf("Expected 4 tasks in 4 columns, got %d in %d", board.TaskCount, len(board.Columns))
+	}
+
+	expected := []struct {
+		status   string
+		count    int
+		wipState string
+	}{
+		{"TO_DO", 1, "UNDER_LIMIT"},
+		{"IN_PROGRESS", 2, "OVER_LIMIT"},
+		{"COMPLETED", 0, "NO_LIMIT"},
+		{"IN_REVIEW", 1, "NO_LIMIT"},
+	}
+	for i, want := range expected {
+		column := board.Columns[i]
+		if column.Status != want.status || column.Count != want.count || column.WIPState != want.wipState {
+			t.Errorf("Column %d: expected %s with %d tasks (%s), got %s with %d (%s)",
+				i, want.status, want.count, want.wipState, column.Status, column.Count, column.WIPState)
+		}
+	}
+}
+
+// TestProjectBoardSortsByPriorityThenDeadline tests the priority sort and the viewer's saved preference
+func TestProjectBoardSortsByPriorityThenDeadline(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "viewer@example.com", "Board", "Viewer")
+	container.UserRepository.Save(ctx, user)
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Sorted Board", "", userID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, project)
+
+	newTask := func(title string, priority value.Priority, dueIn time.Duration) {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), title, "", priority, userID)
+		if dueIn > 0 {
+			deadline, _ := value.NewDeadline(time.Now().Add(dueIn))
+			task.SetDeadline(deadline, userID, "")
+		}
+		container.TaskRepository.Save(ctx, task)
+	}
+	newTask("Low", value.PriorityLow, 0)
+	newTask("High, no deadline", value.PriorityHigh, 0)
+	newTask("High, due later", value.PriorityHigh, 72*time.Hour)
+	newTask("High, due soon", value.PriorityHigh, 24*time.Hour)
+	newTask("Critical", value.PriorityCritical, 0)
+
+	titles := func(board *dto.BoardDTO) []string {
+		titles := make([]string, 0)
+		for _, column := range board.Columns {
+			for _, task := range column.Tasks {
+				titles = append(titles, task.Title)
+			}
+		}
+		return titles
+	}
+
+	board, err := container.GetProjectBoardQueryHandler.Handle(context.Background(), query.GetProjectBoardQuery{
+		ProjectID: project.ID().Value(),
+		ViewerID:  userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to get board: %v", err)
+	}
+	if board.Sort != "CREATED" || titles(board)[0] != "Low" {
+		t.Fatalf("Expected creation order by default, got %s: %v", board.Sort, titles(board))
+	}
+
+	result, err := container.SetBoardSortCommandHandler.Handle(context.Background(), command.SetBoardSortCommand{
+		ProjectID:   project.ID().Value(),
+		Sort:        "PRIORITY",
+		RequestedBy: userID.Value(),
+	})
+	if err != nil || result.Sort != "PRIORITY" {
+		t.Fatalf("Failed to save the board sort: %v", err)
+	}
+
+	board, _ = container.GetProjectBoardQueryHandler.Handle(context.Background(), query.GetProjectBoardQuery{
+		ProjectID: project.ID().Value(),
+		ViewerID:  userID.Value(),
+	})
+	expected := []string{"Critical", "High, due soon", "High, due later", "High, no deadline", "Low"}
+	if board.Sort != "PRIORITY" || strings.Join(titles(board), "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected the saved priority sort %v, got %s: %v", expected, board.Sort, titles(board))
+	}
+
+	board, _ = container.GetProjectBoardQueryHandler.Handle(context.Background(), query.GetProjectBoardQuery{
+		ProjectID: project.ID().Value(),
+		Sort:      "CREATED",
+		ViewerID:  userID.Value(),
+	})
+	if board.Sort != "CREATED" || titles(board)[0] != "Low" {
+		t.Errorf("Expected an explicit sort to override the preference, got %s: %v", board.Sort, titles(board))
+	}
+
+	if _, err := container.GetProjectBoardQueryHandler.Handle(context.Background(), query.GetProjectBoardQuery{
+		ProjectID: project.ID().Value(),
+		Sort:      "RANDOM",
+	}); err == nil {
+		t.Error("Expected an unknown board sort to be rejected")
+	}
+}
+
+// TestSimulateWorkflowReportsTasksItWouldStrand tests a dry run of a workflow change against project tasks
+func TestSimulateWorkflowReportsTasksItWouldStrand(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Simulated Project", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	priority, _ := value.NewPriority("MEDIUM")
+	todo, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Todo", "", priority, userID)
+	todo.ChangeStatus(value.TaskStatusToDo)
+	container.TaskRepository.Save(ctx, todo)
+	review, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Review", "", priority, userID)
+	review.Assign(userID, userID)
+	review.ChangeStatus(value.TaskStatusInProgress)
+	review.ChangeStatus(value.TaskStatusInReview)
+	container.TaskRepository.Save(ctx, review)
+
+	// Without IN_REVIEW, the regular rules leave no way from IN_PROGRESS to COMPLETED
+	report, err := container.SimulateWorkflowQueryHandler.Handle(context.Background(), query.SimulateWorkflowQuery{
+		ProjectID: project.ID().Value(),
+		Statuses: []query.WorkflowStatusInput{
+			{Name: "TO_DO", Order: 1},
+			{Name: "IN_PROGRESS", Order: 2},
+			{Name: "COMPLETED", Order: 3, IsFinal: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to simulate workflow: %v", err)
+	}
+
+	if report.Valid || report.TasksChecked != 2 || report.TotalTasks != 2 {
+		t.Fatalf("Expected an invalid report over 2 tasks, got %+v", report)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Code != "CYCLE" {
+		t.Errorf("Expected the TO_DO/IN_PROGRESS loop to be reported, got %+v", report.Issues)
+	}
+
+	problems := make(map[string]string)
+	for _, invalid := range report.InvalidTasks {
+		problems[invalid.TaskID] = invalid.Problem
+	}
+	if problems[todo.ID().Value()] != "TRAPPED_IN_CYCLE" || problems[review.ID().Value()] != "STATUS_MISSING" {
+		t.Errorf("Unexpected task problems: %v", problems)
+	}
+
+	// Restoring the review step makes the workflow safe for the chosen task
+	report, err = container.SimulateWorkflowQueryHandler.Handle(context.Background(), query.SimulateWorkflowQuery{
+		ProjectID: project.ID().Value(),
+		Statuses: []query.WorkflowStatusInput{
+			{Name: "TO_DO", Order: 1},
+			{Name: "IN_PROGRESS", Order: 2},
+			{Name: "IN_REVIEW", Order: 3},
+			{Name: "COMPLETED", Order: 4, IsFinal: true},
+		},
+		TaskIDs: []string{review.ID().Value()},
+	})
+	if err != nil {
+		t.Fatalf("Failed to simulate workflow: %v", err)
+	}
+	if !report.Valid || report.TasksChecked != 1 {
+		t.Errorf("Expected a valid report over 1 task, got %+v", report)
+	}
+
+	if _, err := container.SimulateWorkflowQueryHandler.Handle(context.Background(), query.SimulateWorkflowQuery{
+		ProjectID: project.ID().Value(),
+		Statuses:  []query.WorkflowStatusInput{{Name: "TO_DO", Order: 1}, {Name: "TO_DO", Order: 2}},
+	}); err == nil {
+		t.Error("Expected duplicate statuses to be rejected")
+	}
+}
+
+// TestWorkspaceSearchTrimsRestrictedProjects tests that search only returns what the user may see
+func TestWorkspaceSearchTrimsRestrictedProjects(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "owner@example.com", "Project", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	outsiderID := value.GenerateUserID()
+	outsider, _ := aggregate.NewUser(outsiderID, "outsider@example.com", "Other", "Person")
+	container.UserRepository.Save(ctx, outsider)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Payroll", "", ownerID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Salary review",
+		Priority:  "HIGH",
+		CreatedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	_, err = container.AddCommentCommandHandler.Handle(context.Background(), command.AddCommentCommand{
+		TaskID:   created.TaskID,
+		AuthorID: ownerID.Value(),
+		Content:  "Confidential bonus figures attached",
+	})
+	if err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	_, err = container.AddAttachmentCommandHandler.Handle(context.Background(), command.AddAttachmentCommand{
+		TaskID:     created.TaskID,
+		FileName:   "bonus-figures.xlsx",
+		SizeBytes:  2048,
+		UploadedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to add attachment: %v", err)
+	}
+
+	search := func(userID string, types ...string) int {
+		results, err := container.SearchWorkspaceQueryHandler.Handle(context.Background(), query.SearchWorkspaceQuery{
+			Text:   "bonus",
+			Types:  types,
+			UserID: userID,
+		})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		return len(results)
+	}
+
+	if hits := search(outsiderID.Value()); hits != 2 {
+		t.Fatalf("Expected comment and attachment hits in a workspace project, got %d", hits)
+	}
+	if hits := search(outsiderID.Value(), query.SearchTypeAttachment); hits != 1 {
+		t.Errorf("Expected 1 attachment hit, got %d", hits)
+	}
+
+	_, err = container.SetProjectAccessCommandHandler.Handle(context.Background(), command.SetProjectAccessCommand{
+		ProjectID:   project.ID().Value(),
+		Visibility:  "RESTRICTED",
+		RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to restrict project: %v", err)
+	}
+
+	if hits := search(outsiderID.Value()); hits != 0 {
+		t.Errorf("Expected restricted project to be hidden from non-members, got %d hits", hits)
+	}
+	if hits := search(""); hits != 0 {
+		t.Errorf("Expected restricted project to be hidden from anonymous users, got %d hits", hits)
+	}
+	if hits := search(ownerID.Value()); hits != 2 {
+		t.Errorf("Expected the owner to still find 2 hits, got %d", hits)
+	}
+}
+
+// TestRestrictedProjectsAreHiddenFromNonMembers tests that the project and task queries and
+// search suggestions refuse or leave out a restricted project for anyone but its members
+func TestRestrictedProjectsAreHiddenFromNonMembers(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "ledger-owner@example.com", "Ledger", "Owner")
+	container.UserRepository.Save(ctx, owner)
+	outsiderID := value.GenerateUserID()
+	outsider, _ := aggregate.NewUser(outsiderID, "ledger-outsider@example.com", "Other", "Person")
+	container.UserRepository.Save(ctx, outsider)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Ledger", "", ownerID, value.GenerateWorkflowID())
+	project.SetAccess(value.VisibilityRestricted, nil)
+	container.ProjectRepository.Save(ctx, project)
+	projectID := project.ID().Value()
+
+	created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: projectID,
+		Title:     "Ledger reconciliation",
+		Priority:  "HIGH",
+		CreatedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	start := time.Now()
+	sprint, err := container.CreateSprintCommandHandler.Handle(context.Background(), command.CreateSprintCommand{
+		ProjectID:   projectID,
+		Name:        "Ledger sprint",
+		StartDate:   start.Format(time.RFC3339),
+		EndDate:     start.AddDate(0, 0, 14).Format(time.RFC3339),
+		RequestedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create sprint: %v", err)
+	}
+
+	queries := map[string]func(viewerID string) error{
+		"task": func(viewerID string) error {
+			_, err := container.GetTaskQueryHandler.Handle(ctx, query.GetTaskQuery{TaskID: created.TaskID, ViewerID: viewerID})
+			return err
+		},
+		"task history": func(viewerID string) error {
+			_, err := container.GetTaskHistoryQueryHandler.Handle(ctx, query.GetTaskHistoryQuery{TaskID: created.TaskID, ViewerID: viewerID})
+			return err
+		},
+		"project tasks": func(viewerID string) error {
+			_, err := container.ListTasksByProjectQueryHandler.Handle(ctx, query.ListTasksByProjectQuery{ProjectID: projectID, ViewerID: viewerID})
+			return err
+		},
+		"project": func(viewerID string) error {
+			_, err := container.GetProjectQueryHandler.Handle(ctx, query.GetProjectQuery{ProjectID: projectID, ViewerID: viewerID})
+			return err
+		},
+		"project stats": func(viewerID string) error {
+			_, err := container.GetProjectStatsQueryHandler.Handle(ctx, query.GetProjectStatsQuery{ProjectID: projectID, ViewerID: viewerID})
+			return err
+		},
+		"sprint tasks": func(viewerID string) error {
+			_, err := container.ListSprintTasksQueryHandler.Handle(ctx, query.ListSprintTasksQuery{SprintID: sprint.SprintID, ViewerID: viewerID})
+			return err
+		},
+		"milestones": func(viewerID string) error {
+			_, err := container.ListMilestonesQueryHandler.Handle(ctx, query.ListMilestonesQuery{ProjectID: projectID, ViewerID: viewerID})
+			return err
+		},
+		"budget": func(viewerID string) error {
+			_, err := container.GetProjectBudgetQueryHandler.Handle(ctx, query.GetProjectBudgetQuery{ProjectID: projectID, ViewerID: viewerID})
+			return err
+		},
+	}
+	for name, ask := range queries {
+		if err := ask(outsiderID.Value()); !errors.Is(err, errs.ErrPermissionDenied) {
+			t.Errorf("Expected %s to be refused to a non-member, got %v", name, err)
+		}
+		if err := ask(""); !errors.Is(err, errs.ErrPermissionDenied) {
+			t.Errorf("Expected %s to be refused to an anonymous caller, got %v", name, err)
+		}
+		if err := ask(ownerID.Value()); err != nil {
+			t.Errorf("Expected %s to be shown to the owner, got %v", name, err)
+		}
+	}
+
+	suggest := func(userID string) []string {
+		results, err := container.SearchSuggestionsQueryHandler.Handle(ctx, query.SearchSuggestionsQuery{Text: "ledger", UserID: userID})
+		if err != nil {
+			t.Fatalf("Suggestions failed: %v", err)
+		}
+		kinds := make([]string, 0, len(results))
+		for _, result := range results {
+			kinds = append(kinds, result.Type)
+		}
+		return kinds
+	}
+	if kinds := suggest(outsiderID.Value()); len(kinds) != 0 {
+		t.Errorf("Expected nothing from the restricted project suggested to a non-member, got %v", kinds)
+	}
+	if kinds := suggest(ownerID.Value()); len(kinds) != 2 {
+		t.Errorf("Expected the project and its task suggested to the owner, got %v", kinds)
+	}
+}
+
+// TestSearchTasksMatchesTextAndFilters tests task search by text in descriptions and comments, and by filters alone
+func TestSearchTasksMatchesTextAndFilters(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "billing@example.com", "Billing", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Billing", "", ownerID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	createTask := func(title, description, priority string) string {
+		created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+			ProjectID:   project.ID().Value(),
+			Title:       title,
+			Description: description,
+			Priority:    priority,
+			CreatedBy:   ownerID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		return created.TaskID
+	}
+	createTask("Export ledger", "Include every invoice of the quarter", "HIGH")
+	commented := createTask("Fix rounding", "", "LOW")
+	createTask("Rename plans", "", "LOW")
+
+	_, err := container.AddCommentCommandHandler.Handle(context.Background(), command.AddCommentCommand{
+		TaskID:   commented,
+		AuthorID: ownerID.Value(),
+		Content:  "Totals on the invoice are off by a cent",
+	})
+	if err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	search := func(q query.SearchTasksQuery) string {
+		results, err := container.SearchTasksQueryHandler.Handle(context.Background(), q)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		titles := make([]string, 0, len(results))
+		for _, result := range results {
+			titles = append(titles, result.Task.Title)
+		}
+		return strings.Join(titles, "|")
+	}
+
+	if found := search(query.SearchTasksQuery{Text: "invoice"}); found != "Export ledger|Fix rounding" {
+		t.Errorf("Expected matches in the description and a comment, own text first, got %s", found)
+	}
+	if found := search(query.SearchTasksQuery{Text: "invoice", Priority: "low"}); found != "Fix rounding" {
+		t.Errorf("Expected the priority filter to narrow the matches, got %s", found)
+	}
+	if found := search(query.SearchTasksQuery{ProjectID: project.ID().Value(), Priority: "LOW", Limit: 1}); found != "Fix rounding" {
+		t.Errorf("Expected the low priority task commented on last, got %s", found)
+	}
+
+	if _, err := container.SearchTasksQueryHandler.Handle(context.Background(), query.SearchTasksQuery{Status: "SHIPPED"}); err == nil {
+		t.Error("Expected an unknown status to be rejected")
+	}
+}
+
+// TestTaskHistoryRecordsStatusChangeReasons tests that backward moves need a reason that reaches the history feed
+func TestTaskHistoryRecordsStatusChangeReasons(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "qa@example.com", "Quality", "Analyst")
+	user.VerifyEmail()
+	container.UserRepository.Save(ctx, user)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "History", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID:  project.ID().Value(),
+		Title:      "Checkout flow",
+		Priority:   "HIGH",
+		AssigneeID: userID.Value(),
+		CreatedBy:  userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	move := func(status, reason string, metadata map[string]string) error {
+		_, err := container.UpdateTaskStatusCommandHandler.Handle(context.Background(), command.UpdateTaskStatusCommand{
+			TaskID:      created.TaskID,
+			NewStatus:   status,
+			Reason:      reason,
+			Metadata:    metadata,
+			RequestedBy: userID.Value(),
+		})
+		return err
+	}
+
+	if err := move("IN_PROGRESS", "", nil); err != nil {
+		t.Fatalf("Failed to start task: %v", err)
+	}
+	if err := move("IN_REVIEW", "", nil); err != nil {
+		t.Fatalf("Failed to request review: %v", err)
+	}
+	if err := move("IN_PROGRESS", "", nil); err == nil {
+		t.Fatal("Expected a backward move without a reason to be rejected")
+	}
+	if err := move("IN_PROGRESS", "failed QA", map[string]string{"qa_run": "1842"}); err != nil {
+		t.Fatalf("Failed to move task back with a reason: %v", err)
+	}
+
+	history, err := container.GetTaskHistoryQueryHandler.Handle(context.Background(), query.GetTaskHistoryQuery{TaskID: created.TaskID})
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+
+	last := history[len(history)-1]
+	if last.Summary != "moved back to IN_PROGRESS: failed QA" || last.FromStatus != "IN_REVIEW" {
+		t.Errorf("Expected the backward move with its reason, got %+v", last)
+	}
+	if last.Metadata["qa_run"] != "1842" {
+		t.Errorf("Expected metadata to be recorded, got %v", last.Metadata)
+	}
+
+	forward := 0
+	for _, entry := range history {
+		if entry.EventType == "TaskStatusChanged" && entry.Reason == "" {
+			forward++
+		}
+	}
+	if forward != 2 {
+		t.Errorf("Expected two forward moves without a reason, got %d", forward)
+	}
+}
+
+// TestDeadlineChangesAreAuditedAndSlipsCounted tests that deadline moves reach the history feed and project stats
+func TestDeadlineChangesAreAuditedAndSlipsCounted(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	userID := value.GenerateUserID()
+	user, _ := aggregate.NewUser(userID, "pm@example.com", "Project", "Manager")
+	user.VerifyEmail()
+	container.UserRepository.Save(ctx, user)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Deadlines", "", userID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	due := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	created, err := container.CreateTaskCommandHandler.Handle(context.Background(), command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Release notes",
+		Priority:  "MEDIUM",
+		Deadline:  due.Format(time.RFC3339),
+		CreatedBy: userID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	change := func(deadline time.Time, reason string) (*command.ChangeTaskDeadlineResult, error) {
+		return container.ChangeTaskDeadlineCommandHandler.Handle(context.Background(), command.ChangeTaskDeadlineCommand{
+			TaskID:      created.TaskID,
+			Deadline:    deadline.Format(time.RFC3339),
+			Reason:      reason,
+			RequestedBy: userID.Value(),
+		})
+	}
+
+	if _, err := change(due.Add(24*time.Hour), ""); err == nil {
+		t.Fatal("Expected postponing the deadline without a reason to be rejected")
+	}
+	if _, err := change(due.Add(24*time.Hour), "waiting on legal"); err != nil {
+		t.Fatalf("Failed to postpone deadline: %v", err)
+	}
+	if _, err := change(due.Add(-24*time.Hour), ""); err != nil {
+		t.Fatalf("Failed to bring the deadline forward without a reason: %v", err)
+	}
+	result, err := change(due.Add(72*time.Hour), "scope grew")
+	if err != nil {
+		t.Fatalf("Failed to postpone deadline again: %v", err)
+	}
+	if result.SlipCount != 2 {
+		t.Errorf("Expected 2 slips, got %d", result.SlipCount)
+	}
+
+	history, err := container.GetTaskHistoryQueryHandler.Handle(context.Background(), query.GetTaskHistoryQuery{TaskID: created.TaskID})
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+
+	var changes []string
+	for _, entry := range history {
+		if entry.EventType == "TaskDeadlineSet" {
+			changes = append(changes, entry.Summary)
+			if entry.ChangedBy != userID.Value() {
+				t.Errorf("Expected the change to record its author, got %+v", entry)
+			}
+		}
+	}
+	if len(changes) != 4 {
+		t.Fatalf("Expected 4 deadline entries, got %v", changes)
+	}
+	want := "deadline moved from " + due.Add(-24*time.Hour).Format(time.RFC3339) + " to " + due.Add(72*time.Hour).Format(time.RFC3339) + ": scope grew"
+	if changes[3] != want {
+		t.Errorf("Expected %q, got %q", want, changes[3])
+	}
+
+	stats, err := container.GetProjectStatsQueryHandler.Handle(context.Background(), query.GetProjectStatsQuery{ProjectID: project.ID().Value()})
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats.DeadlineSlipCount != 2 || stats.SlippedTaskCount != 1 {
+		t.Errorf("Expected 2 slips on 1 task, got %d on %d", stats.DeadlineSlipCount, stats.SlippedTaskCount)
+	}
+}
+
+// TestWorkingHoursSetTheHeatmapCapacity tests that users follow their organization's working hours
+// unless they set their own, and that the heatmap measures work due against them
+func TestWorkingHoursSetTheHeatmapCapacity(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "hours-admin@example.com", "Hours", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Save(ctx, admin)
+
+	memberID := value.GenerateUserID()
+	member, _ := aggregate.NewUser(memberID, "hours-member@example.com", "Hours", "Member")
+	member.VerifyEmail()
+	member.ClearDomainEvents()
+	container.UserRepository.Save(ctx, member)
+
+	organization, err := container.CreateOrganizationCommandHandler.Handle(ctx, command.CreateOrganizationCommand{
+		Name: "Part-time", MemberIDs: []string{memberID.Value()}, RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create organization: %v", err)
+	}
+
+	if _, err := container.SetOrganizationWorkingHoursCommandHandler.Handle(ctx, command.SetOrganizationWorkingHoursCommand{
+		OrganizationID: organization.OrganizationID,
+		WorkingHours:   &command.WorkingHoursInput{HoursPerDay: 6, WorkingDays: []string{"MON", "TUE", "WED", "THU"}},
+		RequestedBy:    memberID.Value(),
+	}); err == nil {
+		t.Fatal("Expected a non-admin to be refused")
+	}
+	if _, err := container.SetOrganizationWorkingHoursCommandHandler.Handle(ctx, command.SetOrganizationWorkingHoursCommand{
+		OrganizationID: organization.OrganizationID,
+		WorkingHours:   &command.WorkingHoursInput{HoursPerDay: 6, WorkingDays: []string{"MON", "TUE", "WED", "THU"}},
+		RequestedBy:    adminID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to set organization working hours: %v", err)
+	}
+
+	hours, own := container.WorkingHoursService.WorkingHoursOf(ctx, memberID)
+	if own || hours.WeeklyHours() != 24 {
+		t.Fatalf("Expected the organization's 24 hour week, got %v (own: %v)", hours.WeeklyHours(), own)
+	}
+
+	// Eight hours due on a Wednesday at least two days out
+	now := time.Now()
+	due := time.Date(now.Year(), now.Month(), now.Day()+2, 12, 0, 0, 0, now.Location())
+	for due.Weekday() != time.Wednesday {
+		due = due.AddDate(0, 0, 1)
+	}
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Capacity", "", memberID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	if _, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID:      project.ID().Value(),
+		Title:          "Quarterly report",
+		Priority:       "MEDIUM",
+		AssigneeID:     memberID.Value(),
+		Deadline:       due.Format(time.RFC3339),
+		EstimatedHours: 8,
+		CreatedBy:      memberID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	dueCell := func() dto.WorkloadCellDTO {
+		heatmap, err := container.GetWorkloadHeatmapQueryHandler.Handle(context.Background(), query.GetWorkloadHeatmapQuery{ProjectID: project.ID().Value()})
+		if err != nil {
+			t.Fatalf("Failed to get heatmap: %v", err)
+		}
+		if len(heatmap.Rows) != 1 {
+			t.Fatalf("Expected one assignee row, got %d", len(heatmap.Rows))
+		}
+		for _, cell := range heatmap.Rows[0].Cells {
+			if cell.TasksDue > 0 {
+				return cell
+			}
+		}
+		t.Fatal("Expected a cell with the task due")
+		return dto.WorkloadCellDTO{}
+	}
+
+	if cell := dueCell(); cell.CapacityHours != 6 || !cell.OverCapacity {
+		t.Errorf("Expected 8 hours due to exceed the 6 hour day, got %+v", cell)
+	}
+
+	// The member's own working hours win over the organization's
+	if _, err := container.SetUserWorkingHoursCommandHandler.Handle(ctx, command.SetUserWorkingHoursCommand{
+		UserID:       memberID.Value(),
+		WorkingHours: &command.WorkingHoursInput{HoursPerDay: 10, WorkingDays: []string{"MONDAY", "WEDNESDAY"}},
+		RequestedBy:  memberID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to set user working hours: %v", err)
+	}
+	if cell := dueCell(); cell.CapacityHours != 10 || cell.OverCapacity {
+		t.Errorf("Expected the 10 hour day to fit the work, got %+v", cell)
+	}
+
+	// Resetting returns the member to the organization's default
+	if _, err := container.SetUserWorkingHoursCommandHandler.Handle(ctx, command.SetUserWorkingHoursCommand{
+		UserID:      memberID.Value(),
+		RequestedBy: memberID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to reset user working hours: %v", err)
+	}
+	if _, own := container.WorkingHoursService.WorkingHoursOf(ctx, memberID); own {
+		t.Error("Expected the member to follow the organization's default again")
+	}
+}
+
+func TestHolidayCalendarShapesDeadlinesAndCapacity(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	adminID := value.GenerateUserID()
+	admin, _ := aggregate.NewUser(adminID, "holiday-admin@example.com", "Holiday", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Save(ctx, admin)
+
+	memberID := value.GenerateUserID()
+	member, _ := aggregate.NewUser(memberID, "holiday-member@example.com", "Holiday", "Member")
+	member.VerifyEmail()
+	member.ClearDomainEvents()
+	container.UserRepository.Save(ctx, member)
+
+	organization, err := container.CreateOrganizationCommandHandler.Handle(ctx, command.CreateOrganizationCommand{
+		Name: "Holidays", MemberIDs: []string{memberID.Value()}, RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create organization: %v", err)
+	}
+
+	// A one-off holiday on the next business day
+	now := time.Now()
+	holiday := container.HolidayCalendarService.AddBusinessDays(nil, now, 1)
+	calendar, err := container.CreateHolidayCalendarCommandHandler.Handle(ctx, command.CreateHolidayCalendarCommand{
+		OrganizationID: organization.OrganizationID,
+		Region:         "nl",
+		Name:           "Netherlands",
+		Holidays: []command.HolidayInput{
+			{Name: "King's Day", Rule: "ANNUAL", Month: 4, Day: 27},
+			{Name: "Office move", Rule: "DATE", Date: holiday.Format("2006-01-02")},
+		},
+		RequestedBy: adminID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create holiday calendar: %v", err)
+	}
+	if _, err := container.CreateHolidayCalendarCommandHandler.Handle(ctx, command.CreateHolidayCalendarCommand{
+		OrganizationID: organization.OrganizationID, Region: "NL", Name: "Again", RequestedBy: adminID.Value(),
+	}); err == nil {
+		t.Error("Expected a second calendar for the same region to be refused")
+	}
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Holidays", "", memberID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+	if _, err := container.SetProjectHolidayCalendarCommandHandler.Handle(ctx, command.SetProjectHolidayCalendarCommand{
+		ProjectID: project.ID().Value(), CalendarID: calendar.CalendarID, RequestedBy: memberID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to set project holiday calendar: %v", err)
+	}
+
+	task, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID:      project.ID().Value(),
+		Title:          "Pack the boxes",
+		Priority:       "MEDIUM",
+		AssigneeID:     memberID.Value(),
+		EstimatedHours: 4,
+		CreatedBy:      memberID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	// One business day out skips the holiday
+	changed, err := container.ChangeTaskDeadlineCommandHandler.Handle(ctx, command.ChangeTaskDeadlineCommand{
+		TaskID: task.TaskID, BusinessDays: 1, RequestedBy: memberID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to set deadline in business days: %v", err)
+	}
+	if want := container.HolidayCalendarService.AddBusinessDays(nil, holiday, 1); changed.Deadline.Format("2006-01-02") != want.Format("2006-01-02") {
+		t.Errorf("Expected the deadline to skip the holiday to %s, got %s", want.Format("2006-01-02"), changed.Deadline.Format("2006-01-02"))
+	}
+
+	heatmap, err := container.GetWorkloadHeatmapQueryHandler.Handle(context.Background(), query.GetWorkloadHeatmapQuery{ProjectID: project.ID().Value()})
+	if err != nil {
+		t.Fatalf("Failed to get heatmap: %v", err)
+	}
+	if len(heatmap.Rows) != 1 {
+		t.Fatalf("Expected one assignee row, got %d", len(heatmap.Rows))
+	}
+	found := false
+	for _, cell := range heatmap.Rows[0].Cells {
+		if cell.Date == holiday.Format("2006-01-02") {
+			found = true
+			if cell.Holiday != "Office move" || cell.CapacityHours != 0 {
+				t.Errorf("Expected no capacity on the holiday, got %+v", cell)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected the heatmap to cover the holiday")
+	}
+
+	// The calendar cannot go while the project observes it
+	if _, err := container.DeleteHolidayCalendarCommandHandler.Handle(ctx, command.DeleteHolidayCalendarCommand{
+		CalendarID: calendar.CalendarID, RequestedBy: adminID.Value(),
+	}); err == nil {
+		t.Fatal("Expected deleting a calendar in use to be refused")
+	}
+	if _, err := container.SetProjectHolidayCalendarCommandHandler.Handle(ctx, command.SetProjectHolidayCalendarCommand{
+		ProjectID: project.ID().Value(), RequestedBy: memberID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to clear project holiday calendar: %v", err)
+	}
+	if _, err := container.DeleteHolidayCalendarCommandHandler.Handle(ctx, command.DeleteHolidayCalendarCommand{
+		CalendarID: calendar.CalendarID, RequestedBy: adminID.Value(),
+	}); err != nil {
+		t.Errorf("Failed to delete holiday calendar: %v", err)
+	}
+}
+
+// upperCaseProvider is a translation provider that shouts, standing in for a real service
+type upperCaseProvider struct {
+	locales []string
+}
+
+func (p *upperCaseProvider) Translate(text, targetLocale string) (string, error) {
+	p.locales = append(p.locales, targetLocale)
+	return strings.ToUpper(text), nil
+}
+
+// TestTaskCommentsTranslateToTheViewersLocale tests on-demand comment translation
+func TestTaskCommentsTranslateToTheViewersLocale(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "translate-owner@example.com", "Translate", "Owner")
+	container.UserRepository.Save(ctx, owner)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Localization", "", ownerID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID: project.ID().Value(), Title: "Translate me", Priority: "LOW", CreatedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := container.AddCommentCommandHandler.Handle(ctx, command.AddCommentCommand{
+		TaskID: created.TaskID, AuthorID: ownerID.Value(), Content: "bonjour",
+	}); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	// Without a provider comments come back untranslated
+	taskDTO, err := container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{
+		TaskID: created.TaskID, Translate: true, ViewerID: ownerID.Value(), Locale: "en",
+	})
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if len(taskDTO.Comments) != 1 || taskDTO.Comments[0].TranslatedContent != "" {
+		t.Fatalf("Expected an untranslated comment, got %+v", taskDTO.Comments)
+	}
+	if taskDTO.Comments[0].Version != 1 {
+		t.Errorf("Expected comment version 1, got %d", taskDTO.Comments[0].Version)
+	}
+
+	provider := &upperCaseProvider{}
+	container.CommentTranslator.SetProvider(provider)
+
+	// The request locale applies until the viewer picks their own
+	taskDTO, _ = container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{
+		TaskID: created.TaskID, Translate: true, ViewerID: ownerID.Value(), Locale: "en",
+	})
+	if comment := taskDTO.Comments[0]; comment.TranslatedContent != "BONJOUR" || comment.TranslatedLocale != "en" {
+		t.Errorf("Expected the comment translated to en, got %+v", comment)
+	}
+
+	if _, err := container.SetUserLocaleCommandHandler.Handle(ctx, command.SetUserLocaleCommand{
+		UserID: ownerID.Value(), Locale: "de_de", RequestedBy: ownerID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to set locale: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		taskDTO, _ = container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{
+			TaskID: created.TaskID, Translate: true, ViewerID: ownerID.Value(), Locale: "en",
+		})
+	}
+	if comment := taskDTO.Comments[0]; comment.TranslatedLocale != "de-DE" {
+		t.Errorf("Expected the viewer's locale to win, got %+v", comment)
+	}
+	if len(provider.locales) != 2 {
+		t.Errorf("Expected one provider call per locale, got %v", provider.locales)
+	}
+
+	// Translation is opt-in
+	taskDTO, _ = container.GetTaskQueryHandler.Handle(context.Background(), query.GetTaskQuery{TaskID: created.TaskID, ViewerID: ownerID.Value()})
+	if taskDTO.Comments[0].TranslatedContent != "" {
+		t.Errorf("Expected no translation unless asked for, got %+v", taskDTO.Comments[0])
+	}
+}
+
+// TestOrganizationDirectoryShowsTeamsAndReportingLines tests the directory after managers are set
+func TestOrganizationDirectoryShowsTeamsAndReportingLines(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	newUser := func(first string) value.UserID {
+		userID := value.GenerateUserID()
+		user, _ := aggregate.NewUser(userID, strings.ToLower(first)+"@example.com", first, "Doe")
+		container.UserRepository.Save(ctx, user)
+		return userID
+	}
+	adminID := newUser("Admin")
+	admin, _ := container.UserRepository.GetByID(ctx, adminID)
+	admin.GrantRole(value.GlobalRoleAdmin)
+	container.UserRepository.Update(ctx, admin)
+	ceoID, leadID, devID := newUser("Carol"), newUser("Leo"), newUser("Dana")
+
+	organization, _ := aggregate.NewOrganization(value.GenerateOrganizationID(), "Acme", []value.UserID{adminID, ceoID, leadID, devID})
+	container.OrganizationRepository.Save(ctx, organization)
+	team, _ := aggregate.NewTeam(value.GenerateTeamID(), "Platform", leadID, []value.UserID{devID})
+	container.TeamRepository.Save(ctx, team)
+
+	setManager := func(userID, managerID value.UserID) error {
+		_, err := container.SetUserManagerCommandHandler.Handle(ctx, command.SetUserManagerCommand{
+			UserID: userID.Value(), ManagerID: managerID.Value(), RequestedBy: adminID.Value(),
+		})
+		return err
+	}
+	if err := setManager(leadID, ceoID); err != nil {
+		t.Fatalf("Failed to set the lead's manager: %v", err)
+	}
+	if err := setManager(devID, leadID); err != nil {
+		t.Fatalf("Failed to set the developer's manager: %v", err)
+	}
+	if err := setManager(ceoID, devID); err == nil {
+		t.Error("Expected a manager change closing a reporting cycle to be rejected")
+	}
+	if _, err := container.SetUserManagerCommandHandler.Handle(ctx, command.SetUserManagerCommand{
+		UserID: devID.Value(), ManagerID: ceoID.Value(), RequestedBy: leadID.Value(),
+	}); err == nil || !strings.HasPrefix(err.Error(), "permission denied") {
+		t.Errorf("Expected only admins to change managers, got %v", err)
+	}
+
+	directory, err := container.GetOrganizationDirectoryQueryHandler.Handle(context.Background(), query.GetOrganizationDirectoryQuery{UserID: devID.Value()})
+	if err != nil {
+		t.Fatalf("Failed to get the directory: %v", err)
+	}
+	if len(directory.Members) != 4 || len(directory.Teams) != 1 || directory.Teams[0].LeadID != leadID.Value() {
+		t.Fatalf("Expected 4 members and the Platform team, got %d and %+v", len(directory.Members), directory.Teams)
+	}
+
+	members := make(map[string]dto.DirectoryMemberDTO)
+	for _, member := range directory.Members {
+		members[member.UserID] = member
+	}
+	dev, lead := members[devID.Value()], members[leadID.Value()]
+	if dev.ManagerID != leadID.Value() || strings.Join(dev.EscalationChain, ",") != leadID.Value()+","+ceoID.Value() {
+		t.Errorf("Expected the developer to escalate to the lead and then the CEO, got %v", dev.EscalationChain)
+	}
+	if len(lead.DirectReportIDs) != 1 || lead.DirectReportIDs[0] != devID.Value() || len(lead.TeamIDs) != 1 {
+		t.Errorf("Expected the lead to have one report and one team, got %+v", lead)
+	}
+	if roles := members[adminID.Value()].Roles; len(roles) != 1 || roles[0] != "ADMIN" {
+		t.Errorf("Expected the admin's role in the directory, got %v", roles)
+	}
+
+	if _, err := container.GetOrganizationDirectoryQueryHandler.Handle(context.Background(), query.GetOrganizationDirectoryQuery{
+		UserID: value.GenerateUserID().Value(),
+	}); err == nil {
+		t.Error("Expected no directory for a user outside any organization")
+	}
+}
+
+// TestOverdueAndDueSoonTaskQueries tests listing tasks by deadline in one project and across visible projects
+func TestOverdueAndDueSoonTaskQueries(t *testing.T) {
+	ctx := context.Background()
+	container := di.NewContainer()
+
+	viewerID := value.GenerateUserID()
+	outsiderID := value.GenerateUserID()
+	shared, _ := aggregate.NewProject(value.GenerateProjectID(), "Shared", "", viewerID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, shared)
+	private, _ := aggregate.NewProject(value.GenerateProjectID(), "Private", "", outsiderID, value.DefaultWorkflowID)
+	private.SetAccess(value.VisibilityRestricted, nil)
+	container.ProjectRepository.Save(ctx, private)
+
+	now := time.Now()
+	newTask := func(project *aggregate.Project, title string, dueIn time.Duration) {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), title, "", value.PriorityMedium, viewerID)
+		deadline, _ := value.NewDeadline(now.Add(dueIn))
+		task.SetDeadline(deadline, viewerID, "")
+		container.TaskRepository.Save(ctx, task)
+	}
+	newTask(shared, "Due next week", 7*24*time.Hour)
+	newTask(shared, "Due tomorrow", 24*time.Hour)
+	newTask(shared, "Due in an hour", time.Hour)
+	newTask(private, "Private, due tomorrow", 24*time.Hour)
+
+	value.SetClock(func() time.Time { return now.Add(48 * time.Hour) })
+	defer value.SetClock(nil)
+
+	titles := func(tasks []*dto.TaskDTO) string {
+		titles := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			titles = append(titles, task.Title)
+		}
+		return strings.Join(titles, "|")
+	}
+
+	overdue, err := container.ListOverdueTasksQueryHandler.Handle(context.Background(), query.ListOverdueTasksQuery{
+		ViewerID: viewerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to list overdue tasks: %v", err)
+	}
+	if titles(overdue) != "Due in an hour|Due tomorrow" || !overdue[0].Deadline.IsOverdue {
+		t.Errorf("Expected the visible overdue tasks, most overdue first, got %s", titles(overdue))
+	}
+
+	overdue, _ = container.ListOverdueTasksQueryHandler.Handle(context.Background(), query.ListOverdueTasksQuery{
+		ProjectID: private.ID().Value(),
+	})
+	if titles(overdue) != "Private, due tomorrow" {
+		t.Errorf("Expected the overdue tasks of one project, got %s", titles(overdue))
+	}
+
+	due, err := container.ListTasksDueWithinQueryHandler.Handle(context.Background(), query.ListTasksDueWithinQuery{
+		ViewerID: viewerID.Value(),
+		Within:   6 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("Failed to list tasks due soon: %v", err)
+	}
+	if titles(due) != "Due next week" {
+		t.Errorf("Expected only tasks not yet overdue, got %s", titles(due))
+	}
+
+	if _, err := container.ListTasksDueWithinQueryHandler.Handle(context.Background(), query.ListTasksDueWithinQuery{}); err == nil {
+		t.Error("Expected a missing duration to be rejected")
+	}
+}
+
+// TestListProjectsUsersAndWorkflowsApplyFilters tests the list queries and their filters
+func TestListProjectsUsersAndWorkflowsApplyFilters(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "owner@example.com", "Project", "Owner")
+	container.UserRepository.Save(ctx, owner)
+	leaverID := value.GenerateUserID()
+	leaver, _ := aggregate.NewUser(leaverID, "leaver@example.com", "Former", "Colleague")
+	leaver.Deactivate(ownerID, nil)
+	container.UserRepository.Save(ctx, leaver)
+
+	active, _ := aggregate.NewProject(value.GenerateProjectID(), "Apollo", "", ownerID, value.DefaultWorkflowID)
+	container.ProjectRepository.Save(ctx, active)
+	archived, _ := aggregate.NewProject(value.GenerateProjectID(), "Zephyr", "", ownerID, value.DefaultWorkflowID)
+	archived.Archive(value.ArchivePolicyFreeze, nil)
+	container.ProjectRepository.Save(ctx, archived)
+	private, _ := aggregate.NewProject(value.GenerateProjectID(), "Hidden", "", leaverID, value.DefaultWorkflowID)
+	private.SetAccess(value.VisibilityRestricted, nil)
+	container.ProjectRepository.Save(ctx, private)
+
+	projectNames := func(q query.ListProjectsQuery) string {
+		page, err := container.ListProjectsQueryHandler.Handle(ctx, q)
+		if err != nil {
+			t.Fatalf("Failed to list projects: %v", err)
+		}
+		names := make([]string, 0, len(page.Projects))
+		for _, project := range page.Projects {
+			names = append(names, project.Name)
+		}
+		return strings.Join(names, "|")
+	}
+
+	if names := projectNames(query.ListProjectsQuery{ViewerID: ownerID.Value()}); names != "Apollo|Zephyr" {
+		t.Errorf("Expected the visible projects by name, got %s", names)
+	}
+	notArchived := false
+	if names := projectNames(query.ListProjectsQuery{ViewerID: ownerID.Value(), Archived: &notArchived}); names != "Apollo" {
+		t.Errorf("Expected only active projects, got %s", names)
+	}
+	if names := projectNames(query.ListProjectsQuery{ViewerID: leaverID.Value(), OwnerID: leaverID.Value()}); names != "Hidden" {
+		t.Errorf("Expected the owner to see their restricted project, got %s", names)
+	}
+
+	first, err := container.ListProjectsQueryHandler.Handle(ctx, query.ListProjectsQuery{ViewerID: ownerID.Value(), Page: domain.Page{Limit: 1}})
+	if err != nil || first.Count != 1 || first.Total != 2 || first.Projects[0].Name != "Apollo" || first.NextCursor == "" {
+		t.Fatalf("Expected the first of two projects with a cursor, got %+v (%v)", first, err)
+	}
+	next := domain.Page{Limit: 1, Cursor: first.NextCursor}
+	if names := projectNames(query.ListProjectsQuery{ViewerID: ownerID.Value(), Page: next}); names != "Zephyr" {
+		t.Errorf("Expected the cursor to continue with the second project, got %s", names)
+	}
+	if _, err := container.ListProjectsQueryHandler.Handle(ctx, query.ListProjectsQuery{Page: domain.Page{SortBy: "owner"}}); !errors.Is(err, domain.ErrInvalidPage) {
+		t.Errorf("Expected an unknown sort field to be refused, got %v", err)
+	}
+
+	onlyActive := true
+	users, err := container.ListUsersQueryHandler.Handle(ctx, query.ListUsersQuery{Active: &onlyActive})
+	if err != nil || len(users.Users) != 1 || users.Users[0].Email != "owner@example.com" || !users.Users[0].Active {
+		t.Errorf("Expected only the active user, got %+v (%v)", users, err)
+	}
+	users, _ = container.ListUsersQueryHandler.Handle(ctx, query.ListUsersQuery{})
+	if len(users.Users) != 2 || users.Users[0].Email != "leaver@example.com" {
+		t.Errorf("Expected every user by email address, got %+v", users)
+	}
+
+	workflow, _ := aggregate.NewWorkflow(value.GenerateWorkflowID(), "Retired", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("OPEN", "", 0, false),
+	})
+	workflow.Deactivate()
+	container.WorkflowRepository.Save(ctx, workflow)
+
+	all, _ := container.ListWorkflowsQueryHandler.Handle(ctx, query.ListWorkflowsQuery{})
+	workflows, err := container.ListWorkflowsQueryHandler.Handle(ctx, query.ListWorkflowsQuery{Active: &onlyActive})
+	if err != nil || len(workflows.Workflows) != len(all.Workflows)-1 || workflows.Total != all.Total-1 {
+		t.Fatalf("Expected the inactive workflow to be left out, got %d of %d (%v)", len(workflows.Workflows), len(all.Workflows), err)
+	}
+	for _, listed := range workflows.Workflows {
+		if listed.Name == "Retired" || len(listed.Statuses) == 0 {
+			t.Errorf("Expected active workflows with their statuses, got %+v", listed)
+		}
+	}
+}
+
+// TestListEndpointsPageTheirResults tests the page parameters of a list endpoint
+func TestListEndpointsPageTheirResults(t *testing.T) {
+	container := di.NewContainer()
+	for _, email := range []string{"carol@example.com", "alice@example.com", "bob@example.com"} {
+		_, err := container.RegisterUserCommandHandler.Handle(context.Background(), command.RegisterUserCommand{
+			Email: email, FirstName: "Paged", LastName: "User", Password: "paged-password",
+		})
+		if err != nil {
+			t.Fatalf("Failed to register user: %v", err)
+		}
+	}
+
+	router := httpServer.NewRouter(container)
+	router.SetupRoutes()
+
+	response := httptest.NewRecorder()
+	router.Handler().ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/api/auth/token",
+		strings.NewReader(`{"email":"alice@example.com","password":"paged-password"}`)))
+	var tokens dto.TokenDTO
+	json.NewDecoder(response.Body).Decode(&tokens)
+
+	list := func(path string) (*httptest.ResponseRecorder, dto.UserPageDTO) {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		recorder := httptest.NewRecorder()
+		router.Handler().ServeHTTP(recorder, request)
+		var page dto.UserPageDTO
+		json.NewDecoder(recorder.Body).Decode(&page)
+		return recorder, page
+	}
+
+	response, first := list("/api/users?limit=2&order=desc")
+	if response.Code != http.StatusOK || first.Count != 2 || first.Total != 3 || first.NextCursor == "" ||
+		first.Users[0].Email != "carol@example.com" || first.Users[1].Email != "bob@example.com" {
+		t.Fatalf("Expected the first two users by email descending, got %d %+v", response.Code, first)
+	}
+	response, second := list("/api/users?limit=2&order=desc&cursor=" + first.NextCursor)
+	if response.Code != http.StatusOK || second.Count != 1 || second.Offset != 2 || second.NextCursor != "" ||
+		second.Users[0].Email != "alice@example.com" {
+		t.Errorf("Expected the last user on the second page, got %d %+v", response.Code, second)
+	}
+
+	for _, path := range []string{"/api/users?limit=many", "/api/users?limit=501", "/api/users?sort=password", "/api/users?cursor=bogus"} {
+		if response, _ := list(path); response.Code != http.StatusBadRequest {
+			t.Errorf("Expected %s to be refused with 400, got %d", path, response.Code)
+		}
+	}
+}
+
+// TestProjectActivityFeedPagesHumanReadableEntries tests the activity stream projection, its paging and rebuild
+func TestProjectActivityFeedPagesHumanReadableEntries(t *testing.T) {
+	container := di.NewContainer()
+	ctx := context.Background()
+
+	ownerID := value.GenerateUserID()
+	owner, _ := aggregate.NewUser(ownerID, "ada@example.com", "Ada", "Lovelace")
+	owner.VerifyEmail()
+	container.UserRepository.Save(ctx, owner)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Website", "", ownerID, value.GenerateWorkflowID())
+	container.ProjectRepository.Save(ctx, project)
+
+	created, err := container.CreateTaskCommandHandler.Handle(ctx, command.CreateTaskCommand{
+		ProjectID: project.ID().Value(),
+		Title:     "Fix login",
+		Priority:  "HIGH",
+		CreatedBy: ownerID.Value(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := container.AssignTaskCommandHandler.Handle(ctx, command.AssignTaskCommand{
+		TaskID:     created.TaskID,
+		AssigneeID: ownerID.Value(),
+		AssignedBy: ownerID.Value(),
+	}); err != nil {
+		t.Fatalf("Failed to assign task: %v", err)
+	}
+	if _, err := container.AddCommentCommandHandler.Handle(ctx, command.AddCommentCommand{
+		TaskID:   created.TaskID,
+		AuthorID: ownerID.Value(),
+		Content:  "Reproduced on Safari",
+	}); err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+
+	activity := func(before, limit int) []dto.ActivityEntryDTO {
+		entries, err := container.ListProjectActivityQueryHandler.Handle(ctx, query.ListProjectActivityQuery{
+			ProjectID: project.ID().Value(),
+			Before:    before,
+			Limit:     limit,
+			ViewerID:  ownerID.Value(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to list activity: %v", err)
+		}
+		return entries
+	}
+
+	page := activity(0, 2)
+	if len(page) != 2 || page[0].Summary != `Ada Lovelace commented on "Fix login"` || page[1].Summary != `"Fix login" was assigned to Ada Lovelace` {
+		t.Fatalf("Expected the newest entries first, got %+v", page)
+	}
+	next := activity(page[1].Sequence, 2)
+	if len(next) != 1 || next[0].Summary != `Task "Fix login" was created` || next[0].Sequence != 1 {
+		t.Errorf("Expected the creation on the next page, got %+v", next)
+	}
+
+	container.ActivityFeed.Clear()
+	if _, err := container.ProjectionRebuilder.RebuildOnly(ctx, []string{"project_activity"}, nil); err != nil {
+		t.Fatalf("Failed to rebuild activity: %v", err)
+	}
+	if rebuilt := activity(0, 0); len(rebuilt) != 3 || rebuilt[0].Summary != page[0].Summary {
+		t.Errorf("Expected the rebuilt feed to match, got %+v", rebuilt)
+	}
+
+	project.SetAccess(value.VisibilityRestricted, nil)
+	container.ProjectRepository.Update(ctx, project)
+	_, err = container.ListProjectActivityQueryHandler.Handle(ctx, query.ListProjectActivityQuery{
+		ProjectID: project.ID().Value(),
+		ViewerID:  value.GenerateUserID().Value(),
+	})
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("Expected outsiders to be refused a restricted project's activity, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/tests/scenario"
+)
+
+// TestScenarioTaskGoesOverdue tests that an open task past its deadline is reported overdue
+func TestScenarioTaskGoesOverdue(t *testing.T) {
+	scenario.New(t).
+		CreateProject("Launch").
+		WithMembers("alice", "bob").
+		CreateTask("Write press release", scenario.DueIn(7*scenario.Day), scenario.AssignedTo("alice")).
+		CreateTask("Book venue", scenario.DueIn(30*scenario.Day)).
+		AdvanceClock(6*scenario.Day).
+		ExpectNoEvent("TaskOverdue").
+		AdvanceClock(2*scenario.Day).
+		ExpectEventFor("TaskOverdue", "Write press release").
+		AssignTask("Book venue", "bob").
+		ExpectEventFor("TaskAssigned", "Book venue")
+}
+
+// TestScenarioCancelledTaskIsNeverOverdue tests that closed tasks are left out of the overdue sweep
+func TestScenarioCancelledTaskIsNeverOverdue(t *testing.T) {
+	scenario.New(t).
+		CreateProject("Cleanup").
+		CreateTask("Drop legacy endpoint", scenario.DueIn(2*scenario.Day)).
+		MoveTask("Drop legacy endpoint", "CANCELLED").
+		AdvanceClock(3 * scenario.Day).
+		ExpectNoEvent("TaskOverdue")
+}
+
+// TestScenarioOverdueReminderIsSentOncePerInterval tests that the scheduled sweep reminds about an overdue task once a day
+func TestScenarioOverdueReminderIsSentOncePerInterval(t *testing.T) {
+	s := scenario.New(t).
+		CreateProject("Launch").
+		CreateTask("Write press release", scenario.DueIn(2*scenario.Day)).
+		AdvanceClock(3*scenario.Day).
+		ExpectEventFor("TaskOverdue", "Write press release")
+
+	sweep := func() {
+		t.Helper()
+		if err := s.Container().Scheduler.Run(context.Background(), "deadline_sweep"); err != nil {
+			t.Fatalf("Failed to run the deadline sweep: %v", err)
+		}
+	}
+
+	sweep()
+	s.AdvanceClock(12 * time.Hour)
+	sweep()
+	if reminders := len(s.Events("TaskOverdue")); reminders != 1 {
+		t.Errorf("Expected one reminder within the interval, got %d", reminders)
+	}
+
+	s.AdvanceClock(13 * time.Hour)
+	if reminders := len(s.Events("TaskOverdue")); reminders != 2 {
+		t.Errorf("Expected a second reminder once the interval passed, got %d", reminders)
+	}
+
+	s.MoveTask("Write press release", "CANCELLED").AdvanceClock(2 * scenario.Day)
+	sweep()
+	if reminders := len(s.Events("TaskOverdue")); reminders != 2 {
+		t.Errorf("Expected no reminders for a cancelled task, got %d", reminders)
+	}
+}
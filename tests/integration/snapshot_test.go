@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/repository"
+	"github.com/miladev95/ddd-task/shared/di"
+	"github.com/miladev95/ddd-task/tests/scenario"
+)
+
+// TestSnapshotsKeepInMemoryDataAcrossRestarts tests a business flow on the in-memory backend
+// and that its tasks, projects and events are back once a new container loads the snapshots
+func TestSnapshotsKeepInMemoryDataAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s := scenario.New(t).
+		CreateProject("Launch").
+		WithMembers("alice", "bob").
+		CreateTask("Write press release", scenario.DueIn(2*scenario.Day), scenario.AssignedTo("alice")).
+		CreateTask("Book venue", scenario.DueIn(30*scenario.Day)).
+		AssignTask("Book venue", "bob").
+		MoveTask("Book venue", "CANCELLED").
+		AdvanceClock(3*scenario.Day).
+		ExpectEventFor("TaskOverdue", "Write press release")
+
+	snapshots, err := repository.NewSnapshotDirectory(dir, s.Container().Snapshotters()...)
+	if err != nil {
+		t.Fatalf("Failed to create snapshot directory: %v", err)
+	}
+	if err := snapshots.Save(); err != nil {
+		t.Fatalf("Failed to save snapshots: %v", err)
+	}
+
+	taskID, _ := value.NewTaskID(s.TaskID("Write press release"))
+	projectID, _ := value.NewProjectID(s.ProjectID())
+
+	// Restart with a fresh container loading the same directory
+	container := di.NewContainer()
+	snapshots, err = repository.NewSnapshotDirectory(dir, container.Snapshotters()...)
+	if err != nil {
+		t.Fatalf("Failed to open snapshot directory: %v", err)
+	}
+	if _, err := snapshots.Load(); err != nil {
+		t.Fatalf("Failed to load snapshots: %v", err)
+	}
+	if _, err := container.ProjectionRebuilder.Rebuild(ctx, nil); err != nil {
+		t.Fatalf("Failed to rebuild projections: %v", err)
+	}
+
+	task, err := container.TaskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		t.Fatalf("Expected the task to survive the restart, got %v", err)
+	}
+	if task.Title() != "Write press release" || task.Assignee() == nil || task.OverdueRemindedAt() == nil {
+		t.Errorf("Expected the task restored as it was, got %+v", task.ToState())
+	}
+
+	project, err := container.ProjectRepository.GetByID(ctx, projectID)
+	if err != nil || !project.HasTask(taskID) {
+		t.Fatalf("Expected the project to still hold the task, got %v", err)
+	}
+
+	cancelled, _ := container.TaskRepository.FindByProjectIDAndStatus(ctx, projectID, value.TaskStatusCancelled)
+	if len(cancelled) != 1 || cancelled[0].Title() != "Book venue" {
+		t.Errorf("Expected the cancelled task found by status, got %d tasks", len(cancelled))
+	}
+
+	history, err := container.EventStore.GetEvents(ctx, taskID.Value())
+	if err != nil || len(history) == 0 || history[0].EventType() != "TaskCreated" {
+		t.Errorf("Expected the task's history kept from its creation, got %d events (%v)", len(history), err)
+	}
+	if overdue := scenario.NewWithContainer(t, container).Events("TaskOverdue"); len(overdue) != 1 {
+		t.Errorf("Expected the overdue event kept, got %d", len(overdue))
+	}
+}
@@ -0,0 +1,184 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/repository"
+	"github.com/miladev95/ddd-task/shared/di"
+	"github.com/miladev95/ddd-task/tests/scenario"
+)
+
+// TestSQLiteBackendKeepsDataAcrossRestarts tests a business flow on SQLite and that its
+// tasks, projects and events are still there once the database is opened again
+func TestSQLiteBackendKeepsDataAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tasks.db")
+
+	db, err := repository.OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	repos, err := di.SQLiteRepositories(db)
+	if err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	s := scenario.NewWithContainer(t, di.NewContainerWithRepositories(repos)).
+		CreateProject("Launch").
+		WithMembers("alice", "bob").
+		CreateTask("Write press release", scenario.DueIn(2*scenario.Day), scenario.AssignedTo("alice")).
+		CreateTask("Book venue", scenario.DueIn(30*scenario.Day)).
+		AssignTask("Book venue", "bob").
+		MoveTask("Book venue", "CANCELLED").
+		AdvanceClock(3*scenario.Day).
+		ExpectEventFor("TaskOverdue", "Write press release").
+		ExpectEventFor("TaskAssigned", "Book venue")
+
+	taskID, _ := value.NewTaskID(s.TaskID("Write press release"))
+	projectID, _ := value.NewProjectID(s.ProjectID())
+	db.Close()
+
+	// Restart on the same file; the schema is left as it is
+	db, err = repository.OpenSQLite(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer db.Close()
+	repos, err = di.SQLiteRepositories(db)
+	if err != nil {
+		t.Fatalf("Failed to open existing schema: %v", err)
+	}
+	container := di.NewContainerWithRepositories(repos)
+
+	task, err := container.TaskRepository.GetByID(ctx, taskID)
+	if err != nil {
+		t.Fatalf("Expected the task to survive the restart, got %v", err)
+	}
+	if task.Title() != "Write press release" || task.Assignee() == nil || task.OverdueRemindedAt() == nil {
+		t.Errorf("Expected the task restored as it was, got %+v", task.ToState())
+	}
+
+	project, err := container.ProjectRepository.GetByID(ctx, projectID)
+	if err != nil || !project.HasTask(taskID) {
+		t.Fatalf("Expected the project to still hold the task, got %v", err)
+	}
+
+	cancelled, _ := container.TaskRepository.FindByProjectIDAndStatus(ctx, projectID, value.TaskStatusCancelled)
+	if len(cancelled) != 1 || cancelled[0].Title() != "Book venue" {
+		t.Errorf("Expected the cancelled task found by status, got %d tasks", len(cancelled))
+	}
+
+	history, err := container.EventStore.GetEvents(ctx, taskID.Value())
+	if err != nil || len(history) == 0 || history[0].EventType() != "TaskCreated" {
+		t.Errorf("Expected the task's history kept from its creation, got %d events (%v)", len(history), err)
+	}
+	if overdue := scenario.NewWithContainer(t, container).Events("TaskOverdue"); len(overdue) != 1 {
+		t.Errorf("Expected the overdue event kept, got %d", len(overdue))
+	}
+}
+
+// TestSQLiteTransactionsKeepWritesAndEventsTogether tests that a command's writes and events
+// on SQLite are committed or rolled back as one
+func TestSQLiteTransactionsKeepWritesAndEventsTogether(t *testing.T) {
+	ctx := context.Background()
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	repos, err := di.SQLiteRepositories(db)
+	if err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	container := di.NewContainerWithRepositories(repos)
+
+	ownerID := value.GenerateUserID()
+	priority, _ := value.NewPriority("LOW")
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Atomic", "", ownerID, value.DefaultWorkflowID)
+	if err := container.ProjectRepository.Save(ctx, project); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+
+	save := func(task *aggregate.Task, project *aggregate.Project) error {
+		uow := container.UnitOfWork()
+		if err := uow.BeginTransaction(ctx); err != nil {
+			return err
+		}
+		if err := uow.GetTaskRepository().Save(ctx, task); err != nil {
+			uow.Rollback()
+			return err
+		}
+		if err := uow.GetProjectRepository().Update(ctx, project); err != nil {
+			uow.Rollback()
+			return err
+		}
+		uow.RecordEvents(task.DomainEvents()...)
+		return uow.Commit()
+	}
+
+	// A project that was never stored fails the update and takes the task with it
+	orphan, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Orphan", "", priority, ownerID)
+	missing, _ := aggregate.NewProject(value.GenerateProjectID(), "Missing", "", ownerID, value.DefaultWorkflowID)
+	if err := save(orphan, missing); err == nil {
+		t.Fatal("Expected the update of a missing project to fail")
+	}
+	if _, err := container.TaskRepository.GetByID(ctx, orphan.ID()); err == nil {
+		t.Error("Expected the task rolled back with the failed update")
+	}
+	if history, _ := container.EventStore.GetEvents(ctx, orphan.ID().Value()); len(history) != 0 {
+		t.Errorf("Expected no events of the rolled back task, got %d", len(history))
+	}
+
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Kept", "", priority, ownerID)
+	project.AddTask(task.ID())
+	if err := save(task, project); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	if stored, err := container.ProjectRepository.GetByID(ctx, project.ID()); err != nil || !stored.HasTask(task.ID()) {
+		t.Errorf("Expected the project stored with the task, got %v", err)
+	}
+	if history, _ := container.EventStore.GetEvents(ctx, task.ID().Value()); len(history) != 1 {
+		t.Errorf("Expected the task's event stored with it, got %d", len(history))
+	}
+}
+
+// TestSQLiteStopsQueriesOfCancelledRequests tests that SQLite reads, writes and transactions
+// fail with the context's error once the request is cancelled
+func TestSQLiteStopsQueriesOfCancelledRequests(t *testing.T) {
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	repos, err := di.SQLiteRepositories(db)
+	if err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	container := di.NewContainerWithRepositories(repos)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Cancelled", "", value.GenerateUserID(), value.DefaultWorkflowID)
+	if err := container.ProjectRepository.Save(context.Background(), project); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := container.ProjectRepository.GetByID(ctx, project.ID()); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the read to stop with the cancellation, got %v", err)
+	}
+	if err := container.ProjectRepository.Update(ctx, project); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the write to stop with the cancellation, got %v", err)
+	}
+	if _, err := container.EventStore.GetAllEvents(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the event read to stop with the cancellation, got %v", err)
+	}
+	if err := container.UnitOfWork().BeginTransaction(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected no transaction to begin, got %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+// Package scenario is a small DSL for end-to-end business-flow tests. A scenario drives a
+// fresh container through its command handlers, owns the domain clock and reads back every
+// published event, so a regression test reads like the flow it covers:
+//
+//	scenario.New(t).
+//		CreateProject("Launch").
+//		WithMembers("alice").
+//		CreateTask("Write press release", scenario.DueIn(7*scenario.Day), scenario.AssignedTo("alice")).
+//		AdvanceClock(8 * scenario.Day).
+//		ExpectEvent("TaskOverdue")
+//
+// The domain clock is process wide, so scenarios must not run in parallel.
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/command"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// Day is a calendar day on the scenario clock
+const Day = 24 * time.Hour
+
+// Scenario is a business flow run against a fresh container. Each step fails the test on error.
+type Scenario struct {
+	t         testing.TB
+	ctx       context.Context
+	container *di.Container
+	now       time.Time
+	ownerID   value.UserID
+	project   *aggregate.Project
+	users     map[string]value.UserID
+	tasks     map[string]string
+}
+
+// New starts a scenario with a fresh container and a verified project owner.
+// The domain clock is pinned to the current time until the test ends.
+func New(t testing.TB) *Scenario {
+	t.Helper()
+
+	return NewWithContainer(t, di.NewContainer())
+}
+
+// NewWithContainer starts a scenario like New against a container of the caller's,
+// for example one on other repositories
+func NewWithContainer(t testing.TB, container *di.Container) *Scenario {
+	t.Helper()
+
+	s := &Scenario{
+		t:         t,
+		ctx:       context.Background(),
+		container: container,
+		now:       time.Now().Truncate(time.Second),
+		users:     make(map[string]value.UserID),
+		tasks:     make(map[string]string),
+	}
+
+	value.SetClock(func() time.Time { return s.now })
+	t.Cleanup(func() { value.SetClock(nil) })
+
+	s.ownerID = s.addUser("owner")
+	return s
+}
+
+// Container returns the container the scenario runs against
+func (s *Scenario) Container() *di.Container {
+	return s.container
+}
+
+// Now returns the current time on the scenario clock
+func (s *Scenario) Now() time.Time {
+	return s.now
+}
+
+// UserID returns the ID of a named user, "owner" being the project owner
+func (s *Scenario) UserID(name string) string {
+	s.t.Helper()
+
+	userID, ok := s.users[name]
+	if !ok {
+		s.t.Fatalf("scenario: unknown user %q", name)
+	}
+	return userID.Value()
+}
+
+// TaskID returns the ID of a task by its title
+func (s *Scenario) TaskID(title string) string {
+	s.t.Helper()
+
+	taskID, ok := s.tasks[title]
+	if !ok {
+		s.t.Fatalf("scenario: unknown task %q", title)
+	}
+	return taskID
+}
+
+// ProjectID returns the ID of the scenario's project
+func (s *Scenario) ProjectID() string {
+	s.t.Helper()
+
+	if s.project == nil {
+		s.t.Fatal("scenario: no project, call CreateProject first")
+	}
+	return s.project.ID().Value()
+}
+
+// CreateProject creates the project the following steps work in, owned by "owner"
+func (s *Scenario) CreateProject(name string) *Scenario {
+	s.t.Helper()
+
+	project, err := aggregate.NewProject(value.GenerateProjectID(), name, "", s.ownerID, value.GenerateWorkflowID())
+	if err != nil {
+		s.t.Fatalf("scenario: failed to create project %q: %v", name, err)
+	}
+	if err := s.container.ProjectRepository.Save(s.ctx, project); err != nil {
+		s.t.Fatalf("scenario: failed to save project %q: %v", name, err)
+	}
+	if err := s.container.EventPublisher.PublishAll(s.ctx, project.DomainEvents()); err != nil {
+		s.t.Fatalf("scenario: failed to publish project events: %v", err)
+	}
+	project.ClearDomainEvents()
+
+	s.project = project
+	return s
+}
+
+// WithMembers creates verified users by name and makes them members of the project
+func (s *Scenario) WithMembers(names ...string) *Scenario {
+	s.t.Helper()
+
+	memberIDs := make([]string, 0, len(names))
+	for _, name := range names {
+		memberIDs = append(memberIDs, s.addUser(name).Value())
+	}
+
+	_, err := s.container.SetProjectAccessCommandHandler.Handle(s.ctx, command.SetProjectAccessCommand{
+		ProjectID:   s.ProjectID(),
+		Visibility:  value.VisibilityWorkspace.Value(),
+		MemberIDs:   memberIDs,
+		RequestedBy: s.ownerID.Value(),
+	})
+	if err != nil {
+		s.t.Fatalf("scenario: failed to add members %v: %v", names, err)
+	}
+	return s
+}
+
+// TaskOption configures a task created by CreateTask
+type TaskOption func(s *Scenario, cmd *command.CreateTaskCommand)
+
+// DueIn sets the task's deadline relative to the scenario clock
+func DueIn(d time.Duration) TaskOption {
+	return func(s *Scenario, cmd *command.CreateTaskCommand) {
+		cmd.Deadline = s.now.Add(d).Format(time.RFC3339)
+	}
+}
+
+// AssignedTo assigns the task to a named user on creation
+func AssignedTo(name string) TaskOption {
+	return func(s *Scenario, cmd *command.CreateTaskCommand) {
+		cmd.AssigneeID = s.UserID(name)
+	}
+}
+
+// WithPriority sets the task's priority
+func WithPriority(priority string) TaskOption {
+	return func(s *Scenario, cmd *command.CreateTaskCommand) {
+		cmd.Priority = priority
+	}
+}
+
+// CreateTask creates a task in the project, created by "owner". Later steps refer to it by title.
+func (s *Scenario) CreateTask(title string, options ...TaskOption) *Scenario {
+	s.t.Helper()
+
+	cmd := command.CreateTaskCommand{
+		ProjectID: s.ProjectID(),
+		Title:     title,
+		CreatedBy: s.ownerID.Value(),
+	}
+	for _, option := range options {
+		option(s, &cmd)
+	}
+
+	result, err := s.container.CreateTaskCommandHandler.Handle(s.ctx, cmd)
+	if err != nil {
+		s.t.Fatalf("scenario: failed to create task %q: %v", title, err)
+	}
+
+	s.tasks[title] = result.TaskID
+	return s
+}
+
+// AssignTask assigns a task to a named user, on behalf of "owner"
+func (s *Scenario) AssignTask(title, name string) *Scenario {
+	s.t.Helper()
+
+	_, err := s.container.AssignTaskCommandHandler.Handle(s.ctx, command.AssignTaskCommand{
+		TaskID:     s.TaskID(title),
+		AssigneeID: s.UserID(name),
+		AssignedBy: s.ownerID.Value(),
+	})
+	if err != nil {
+		s.t.Fatalf("scenario: failed to assign task %q to %s: %v", title, name, err)
+	}
+	return s
+}
+
+// MoveTask changes a task's status, on behalf of "owner"
+func (s *Scenario) MoveTask(title, status string) *Scenario {
+	s.t.Helper()
+
+	_, err := s.container.UpdateTaskStatusCommandHandler.Handle(s.ctx, command.UpdateTaskStatusCommand{
+		TaskID:      s.TaskID(title),
+		NewStatus:   status,
+		RequestedBy: s.ownerID.Value(),
+	})
+	if err != nil {
+		s.t.Fatalf("scenario: failed to move task %q to %s: %v", title, status, err)
+	}
+	return s
+}
+
+// AdvanceClock moves the scenario clock forward and runs the time-driven jobs,
+// such as the overdue sweep of the project, as a scheduler would
+func (s *Scenario) AdvanceClock(d time.Duration) *Scenario {
+	s.t.Helper()
+
+	s.now = s.now.Add(d)
+
+	if s.project == nil {
+		return s
+	}
+
+	_, err := s.container.CheckOverdueTasksCommandHandler.Handle(s.ctx, command.CheckOverdueTasksCommand{
+		ProjectID: s.ProjectID(),
+	})
+	if err != nil {
+		s.t.Fatalf("scenario: failed to check overdue tasks: %v", err)
+	}
+	return s
+}
+
+// Events returns the published events of a type, oldest first
+func (s *Scenario) Events(eventType string) []event.DomainEvent {
+	s.t.Helper()
+
+	stored, err := s.container.EventStore.GetAllEvents(s.ctx)
+	if err != nil {
+		s.t.Fatalf("scenario: failed to read events: %v", err)
+	}
+
+	events := make([]event.DomainEvent, 0)
+	for _, evt := range stored {
+		if evt.EventType() == eventType {
+			events = append(events, evt)
+		}
+	}
+	return events
+}
+
+// ExpectEvent fails the test unless an event of the type has been published
+func (s *Scenario) ExpectEvent(eventType string) *Scenario {
+	s.t.Helper()
+
+	if len(s.Events(eventType)) == 0 {
+		s.t.Errorf("scenario: expected a %s event at %s", eventType, s.describeClock())
+	}
+	return s
+}
+
+// ExpectEventFor fails the test unless an event of the type has been published for a task
+func (s *Scenario) ExpectEventFor(eventType, title string) *Scenario {
+	s.t.Helper()
+
+	taskID := s.TaskID(title)
+	for _, evt := range s.Events(eventType) {
+		if evt.AggregateID() == taskID {
+			return s
+		}
+	}
+
+	s.t.Errorf("scenario: expected a %s event for task %q at %s", eventType, title, s.describeClock())
+	return s
+}
+
+// ExpectNoEvent fails the test if an event of the type has been published
+func (s *Scenario) ExpectNoEvent(eventType string) *Scenario {
+	s.t.Helper()
+
+	if events := s.Events(eventType); len(events) > 0 {
+		s.t.Errorf("scenario: expected no %s event at %s, got %d", eventType, s.describeClock(), len(events))
+	}
+	return s
+}
+
+// addUser creates a verified user the scenario knows by name
+func (s *Scenario) addUser(name string) value.UserID {
+	s.t.Helper()
+
+	if _, exists := s.users[name]; exists {
+		s.t.Fatalf("scenario: user %q already exists", name)
+	}
+
+	userID := value.GenerateUserID()
+	user, err := aggregate.NewUser(userID, name+"@scenario.test", name, "Scenario")
+	if err != nil {
+		s.t.Fatalf("scenario: failed to create user %q: %v", name, err)
+	}
+
+	// Scenario users are fixtures, verified up front without the registration flow
+	user.VerifyEmail()
+	user.ClearDomainEvents()
+
+	if err := s.container.UserRepository.Save(s.ctx, user); err != nil {
+		s.t.Fatalf("scenario: failed to save user %q: %v", name, err)
+	}
+
+	s.users[name] = userID
+	return userID
+}
+
+// describeClock reports the scenario clock in failures
+func (s *Scenario) describeClock() string {
+	return fmt.Sprintf("%s on the scenario clock", s.now.Format(time.RFC3339))
+}
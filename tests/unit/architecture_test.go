@@ -0,0 +1,159 @@
+package unit
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// modulePath is the import path of this module, as in go.mod
+const modulePath = "github.com/miladev95/ddd-task"
+
+// forbiddenImports lists, for each layer, the layers it must not depend on.
+// Dependencies point inwards: interface and infrastructure on application, application on domain.
+var forbiddenImports = map[string][]string{
+	"domain":         {"application", "infrastructure", "interface", "shared", "pkg", "cmd"},
+	"application":    {"infrastructure", "interface", "shared", "pkg", "cmd"},
+	"infrastructure": {"interface", "shared", "pkg", "cmd"},
+	"interface":      {"infrastructure", "pkg", "cmd"},
+	"shared":         {"interface", "pkg", "cmd"},
+	"pkg":            {"interface", "cmd"},
+}
+
+// allowedExceptions are known layer violations, by importing and imported package.
+// The list may only shrink: an entry that no longer matches an import fails the test.
+var allowedExceptions = map[string]string{}
+
+// TestLayerDependencies tests that no package imports a layer it must not depend on
+func TestLayerDependencies(t *testing.T) {
+	imports, err := packageImports("../..")
+	if err != nil {
+		t.Fatalf("Failed to read package imports: %v", err)
+	}
+
+	used := make(map[string]bool)
+	violations := make([]string, 0)
+	for pkg, imported := range imports {
+		for _, dependency := range imported {
+			if !isForbidden(pkg, dependency) {
+				continue
+			}
+
+			pair := pkg + " -> " + dependency
+			if _, allowed := allowedExceptions[pair]; allowed {
+				used[pair] = true
+				continue
+			}
+			violations = append(violations, pair)
+		}
+	}
+
+	sort.Strings(violations)
+	for _, violation := range violations {
+		t.Errorf("Layer violation: %s", violation)
+	}
+
+	for pair := range allowedExceptions {
+		if !used[pair] {
+			t.Errorf("Allowed exception %q no longer occurs, remove it", pair)
+		}
+	}
+}
+
+// domainLibraries are the external libraries the domain may use
+var domainLibraries = map[string]bool{
+	"github.com/google/uuid": true, // identifier generation
+}
+
+// TestDomainHasNoFrameworkDependencies tests that the domain layer imports only the standard library,
+// itself and the libraries it is allowed
+func TestDomainHasNoFrameworkDependencies(t *testing.T) {
+	imports, err := packageImports("../..")
+	if err != nil {
+		t.Fatalf("Failed to read package imports: %v", err)
+	}
+
+	for pkg, imported := range imports {
+		if layerOf(pkg) != "domain" {
+			continue
+		}
+		for _, dependency := range imported {
+			if strings.Contains(dependency, ".") && !domainLibraries[dependency] {
+				t.Errorf("Domain package %s imports %s, keep the domain free of external libraries", pkg, dependency)
+			}
+		}
+	}
+}
+
+// packageImports maps every non-test package of the module, relative to its root, to the
+// packages it imports. Module packages are relative too, others keep their import path.
+func packageImports(root string) (map[string][]string, error) {
+	imports := make(map[string][]string)
+	seen := make(map[string]map[string]bool)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			switch info.Name() {
+			case "tests", "examples", "testdata", ".git":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+
+		dir, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		pkg := filepath.ToSlash(dir)
+		if seen[pkg] == nil {
+			seen[pkg] = make(map[string]bool)
+			imports[pkg] = make([]string, 0)
+		}
+
+		for _, spec := range file.Imports {
+			dependency, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return err
+			}
+			dependency = strings.TrimPrefix(dependency, modulePath+"/")
+			if !seen[pkg][dependency] {
+				seen[pkg][dependency] = true
+				imports[pkg] = append(imports[pkg], dependency)
+			}
+		}
+		return nil
+	})
+
+	return imports, err
+}
+
+// layerOf returns the top-level directory of a module package, its layer
+func layerOf(pkg string) string {
+	return strings.SplitN(pkg, "/", 2)[0]
+}
+
+// isForbidden reports whether a package may not import a dependency
+func isForbidden(pkg, dependency string) bool {
+	for _, layer := range forbiddenImports[layerOf(pkg)] {
+		if layerOf(dependency) == layer {
+			return true
+		}
+	}
+	return false
+}
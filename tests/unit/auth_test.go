@@ -0,0 +1,128 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/auth"
+)
+
+// TestPasswordHasherRoundTrip tests hashing, verifying and restoring a password hash
+func TestPasswordHasherRoundTrip(t *testing.T) {
+	hasher := auth.NewPasswordHasher()
+
+	if _, err := hasher.Hash("short"); err == nil {
+		t.Error("Expected a short password to be rejected")
+	}
+
+	hash, err := hasher.Hash("correct horse battery")
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	if !strings.HasPrefix(hash.String(), "$argon2id$") {
+		t.Errorf("Expected an Argon2id hash, got %q", hash.String())
+	}
+	if !hasher.Matches(hash, "correct horse battery") || hasher.Matches(hash, "correct horse") {
+		t.Error("Expected the hash to match only its own password")
+	}
+
+	restored, err := value.ParsePasswordHash(hash.String())
+	if err != nil {
+		t.Fatalf("Failed to parse password hash: %v", err)
+	}
+	if !hasher.Matches(restored, "correct horse battery") {
+		t.Error("Expected the restored hash to match the password")
+	}
+
+	if _, err := value.ParsePasswordHash("not a hash"); err == nil {
+		t.Error("Expected an unencoded hash to be rejected")
+	}
+	if hasher.Matches(value.PasswordHash{}, "correct horse battery") {
+		t.Error("Expected a zero hash never to match")
+	}
+	if other, _ := value.ParsePasswordHash("pbkdf2-sha256$1000$c2FsdA$a2V5"); hasher.Matches(other, "correct horse battery") {
+		t.Error("Expected a hash of another scheme never to match")
+	}
+}
+
+// TestSessionStoreExpiresAndRevokes tests session token lifetimes
+func TestSessionStoreExpiresAndRevokes(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	store := auth.NewSessionStore(time.Hour)
+	store.SetClock(func() time.Time { return now })
+
+	first, _, err := store.Issue("user-1")
+	if err != nil {
+		t.Fatalf("Failed to issue session: %v", err)
+	}
+	second, _, _ := store.Issue("user-1")
+
+	if session, ok := store.Resolve(first); !ok || session.UserID != "user-1" {
+		t.Error("Expected the token to resolve to its user")
+	}
+
+	if !store.Revoke(first) || store.Revoke(first) {
+		t.Error("Expected a token to be revoked exactly once")
+	}
+	if _, ok := store.Resolve(first); ok {
+		t.Error("Expected a revoked token not to resolve")
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, ok := store.Resolve(second); ok {
+		t.Error("Expected an expired token not to resolve")
+	}
+}
+
+// TestTokenIssuerVerifiesRefreshesAndRevokes tests token verification, single-use refresh and revocation
+func TestTokenIssuerVerifiesRefreshesAndRevokes(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	issuer := auth.NewTokenIssuer([]byte("secret"), 15*time.Minute, time.Hour)
+	issuer.SetClock(func() time.Time { return now })
+
+	tokens, err := issuer.Issue("user-1", []string{"admin"})
+	if err != nil {
+		t.Fatalf("Failed to issue tokens: %v", err)
+	}
+
+	claims, err := issuer.Verify(tokens.AccessToken)
+	if err != nil || claims.UserID != "user-1" || len(claims.Roles) != 1 || claims.Roles[0] != "admin" {
+		t.Fatalf("Expected a valid access token for user-1, got %+v %v", claims, err)
+	}
+
+	if _, err := issuer.Verify(tokens.RefreshToken); err == nil {
+		t.Error("Expected a refresh token not to authenticate requests")
+	}
+	if _, err := auth.NewTokenIssuer([]byte("other"), 0, 0).Verify(tokens.AccessToken); err == nil {
+		t.Error("Expected a token signed with another secret to be rejected")
+	}
+
+	if _, err := issuer.Redeem(tokens.RefreshToken); err != nil {
+		t.Fatalf("Failed to redeem refresh token: %v", err)
+	}
+	if _, err := issuer.Redeem(tokens.RefreshToken); err == nil {
+		t.Error("Expected a refresh token to be usable once")
+	}
+
+	now = now.Add(16 * time.Minute)
+	if _, err := issuer.Verify(tokens.AccessToken); err == nil {
+		t.Error("Expected the access token to expire")
+	}
+
+	fresh, _ := issuer.Issue("user-1", nil)
+	issuer.RevokeUser("user-1")
+	if _, err := issuer.Verify(fresh.AccessToken); err == nil {
+		t.Error("Expected tokens issued before revocation to be rejected")
+	}
+
+	// The clock has not moved, yet tokens issued after the revocation are valid
+	later, _ := issuer.Issue("user-1", nil)
+	if _, err := issuer.Verify(later.AccessToken); err != nil {
+		t.Errorf("Expected tokens issued after revocation to be valid, got %v", err)
+	}
+	if _, err := issuer.Verify(fresh.AccessToken); err == nil {
+		t.Error("Expected tokens issued before revocation to stay rejected")
+	}
+}
@@ -0,0 +1,83 @@
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+	"github.com/miladev95/ddd-task/interface/contract"
+	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// TestContractsAreUpToDate tests that the committed contract files match the generator output
+func TestContractsAreUpToDate(t *testing.T) {
+	stale, err := contract.Check("../../api", infraEvent.NewEventSerializer())
+	if err != nil {
+		t.Fatalf("Failed to generate contracts: %v", err)
+	}
+
+	if len(stale) > 0 {
+		t.Errorf("Contracts out of date, run make contracts: %v", stale)
+	}
+}
+
+// TestContractCatalogMatchesRouter tests that every route is documented with the methods it serves
+func TestContractCatalogMatchesRouter(t *testing.T) {
+	router := httpServer.NewRouter(di.NewContainer())
+	router.SetupRoutes()
+
+	documented := make(map[string]map[string]bool)
+	public := make(map[string]bool)
+	for _, op := range contract.Operations() {
+		public[op.Method+" "+op.Path] = op.Public
+		if documented[op.Path] == nil {
+			documented[op.Path] = make(map[string]bool)
+		}
+		documented[op.Path][op.Method] = true
+	}
+
+	routed := router.Paths()
+	documentedPaths := make([]string, 0, len(documented))
+	for path := range documented {
+		documentedPaths = append(documentedPaths, path)
+	}
+	sort.Strings(routed)
+	sort.Strings(documentedPaths)
+
+	if len(routed) != len(documentedPaths) {
+		t.Fatalf("Router paths %v do not match documented paths %v", routed, documentedPaths)
+	}
+	for i := range routed {
+		if routed[i] != documentedPaths[i] {
+			t.Fatalf("Router paths %v do not match documented paths %v", routed, documentedPaths)
+		}
+	}
+
+	// Probe every method anonymously: undocumented ones must be rejected, documented ones must not be,
+	// and only public ones may be served without a token.
+	// The health check answers any method and is documented as GET only,
+	// and logout answers 401 itself when no session token is given.
+	for _, path := range routed {
+		if path == "/health" {
+			continue
+		}
+
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete} {
+			recorder := httptest.NewRecorder()
+			router.Handler().ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+
+			allowed := recorder.Code != http.StatusMethodNotAllowed
+			if allowed != documented[path][method] {
+				t.Errorf("%s %s: served=%v documented=%v", method, path, allowed, documented[path][method])
+			}
+
+			unauthorized := recorder.Code == http.StatusUnauthorized
+			if allowed && unauthorized == public[method+" "+path] && path != "/api/auth/logout" {
+				t.Errorf("%s %s: unauthorized=%v public=%v", method, path, unauthorized, public[method+" "+path])
+			}
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package unit
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/miladev95/ddd-task/domain/aggregate"
 	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/service"
 	"github.com/miladev95/ddd-task/domain/value"
 )
 
@@ -228,4 +231,511 @@ func TestValueObjectEquality(t *testing.T) {
 	if !id1.Equals(id1) {
 		t.Error("Expected same ID to be equal")
 	}
-}
\ No newline at end of file
+}
+
+// TestTaskVoting tests that each user can vote only once
+func TestTaskVoting(t *testing.T) {
+	priority, _ := value.NewPriority("LOW")
+	creatorID := value.GenerateUserID()
+	voterID := value.GenerateUserID()
+
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Task", "Description", priority, creatorID)
+
+	if err := task.Vote(voterID); err != nil {
+		t.Fatalf("Expected no error voting, got %v", err)
+	}
+
+	if err := task.Vote(voterID); err == nil {
+		t.Fatal("Expected error for duplicate vote")
+	}
+
+	if task.VoteCount() != 1 {
+		t.Errorf("Expected 1 vote, got %d", task.VoteCount())
+	}
+
+	if err := task.RemoveVote(voterID); err != nil {
+		t.Fatalf("Expected no error removing vote, got %v", err)
+	}
+
+	if task.VoteCount() != 0 {
+		t.Errorf("Expected 0 votes, got %d", task.VoteCount())
+	}
+}
+
+// TestProjectRaisesDomainEvents tests that project changes are recorded as events
+func TestProjectRaisesDomainEvents(t *testing.T) {
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Old Name", "", value.GenerateUserID(), value.GenerateWorkflowID())
+	project.ClearDomainEvents()
+
+	project.UpdateName("New Name")
+	project.UpdateName("New Name")
+	project.AddTask(value.GenerateTaskID())
+
+	events := project.DomainEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if events[0].EventType() != "ProjectRenamed" || events[1].EventType() != "TaskAddedToProject" {
+		t.Errorf("Unexpected events: %s, %s", events[0].EventType(), events[1].EventType())
+	}
+}
+
+// TestTaskEditLock tests acquiring, expiring and releasing the description edit lock
+func TestTaskEditLock(t *testing.T) {
+	alice := value.GenerateUserID()
+	bob := value.GenerateUserID()
+	priority, _ := value.NewPriority("MEDIUM")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Locked", "", priority, alice)
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	if err := task.AcquireEditLock(alice, now, time.Minute); err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	if err := task.AcquireEditLock(bob, now.Add(30*time.Second), time.Minute); err == nil {
+		t.Error("Expected a held lock to refuse another user")
+	}
+	if err := task.EditDescription(bob, "overwrite", now.Add(30*time.Second)); err == nil {
+		t.Error("Expected description edit by another user to be refused while locked")
+	}
+	if err := task.EditDescription(alice, "mine", now.Add(30*time.Second)); err != nil || task.Description() != "mine" {
+		t.Errorf("Expected holder to edit the description, got %v", err)
+	}
+
+	if err := task.RenewEditLock(alice, now.Add(50*time.Second), time.Minute); err != nil {
+		t.Fatalf("Failed to renew lock: %v", err)
+	}
+	if task.ActiveEditLock(now.Add(100*time.Second)) == nil {
+		t.Error("Expected renewed lock to still be active")
+	}
+
+	// Once expired, anyone may take the lock
+	if err := task.AcquireEditLock(bob, now.Add(3*time.Minute), time.Minute); err != nil {
+		t.Fatalf("Expected expired lock to be taken over, got %v", err)
+	}
+	if err := task.ReleaseEditLock(alice, now.Add(3*time.Minute)); err == nil {
+		t.Error("Expected releasing someone else's lock to fail")
+	}
+	if err := task.ReleaseEditLock(bob, now.Add(3*time.Minute)); err != nil || task.EditLock() != nil {
+		t.Errorf("Expected lock to be released, got %v", err)
+	}
+}
+
+// TestMoneyArithmetic tests parsing and adding money
+func TestMoneyArithmetic(t *testing.T) {
+	price, err := value.ParseMoney("99.95", "USD")
+	if err != nil {
+		t.Fatalf("Failed to parse money: %v", err)
+	}
+	if price.MinorUnits() != 9995 || price.Amount() != "99.95" {
+		t.Errorf("Expected 9995 minor units, got %d (%s)", price.MinorUnits(), price.Amount())
+	}
+
+	total, err := price.Add(value.ZeroMoney("USD"))
+	if err != nil || !total.Equals(price) {
+		t.Errorf("Expected adding zero to keep the amount, got %s (%v)", total, err)
+	}
+
+	euros, _ := value.ParseMoney("5", "EUR")
+	if _, err := price.Add(euros); err == nil {
+		t.Error("Expected adding different currencies to fail")
+	}
+
+	for _, invalid := range []string{"", "1.234", "abc", "1."} {
+		if _, err := value.ParseMoney(invalid, "USD"); err == nil {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+	if _, err := value.ParseMoney("1", "usd"); err == nil {
+		t.Error("Expected a lowercase currency code to be rejected")
+	}
+}
+
+// TestTaskStatusBackwardTransitions tests which moves send work back to an earlier stage
+func TestTaskStatusBackwardTransitions(t *testing.T) {
+	if !value.TaskStatusInReview.IsBackwardTo(value.TaskStatusInProgress) {
+		t.Error("Expected IN_REVIEW to IN_PROGRESS to be backward")
+	}
+	if value.TaskStatusInProgress.IsBackwardTo(value.TaskStatusInReview) {
+		t.Error("Expected IN_PROGRESS to IN_REVIEW to be forward")
+	}
+	if value.TaskStatusInProgress.IsBackwardTo(value.TaskStatusCancelled) {
+		t.Error("Expected cancelling not to count as backward")
+	}
+}
+
+// TestTeamMembershipKeepsTheLeadAMember tests team membership rules
+func TestTeamMembershipKeepsTheLeadAMember(t *testing.T) {
+	leadID := value.GenerateUserID()
+	memberID := value.GenerateUserID()
+
+	team, err := aggregate.NewTeam(value.GenerateTeamID(), "  Platform  ", leadID, []value.UserID{memberID, leadID})
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	if team.Name() != "Platform" || len(team.MemberIDs()) != 2 || !team.HasMember(leadID) {
+		t.Fatalf("Expected the lead and one member once each, got %v", team.MemberIDs())
+	}
+
+	if err := team.AddMember(memberID); err == nil {
+		t.Error("Expected adding an existing member to fail")
+	}
+	if err := team.RemoveMember(leadID); err == nil {
+		t.Error("Expected removing the lead to fail")
+	}
+	if err := team.ChangeLead(value.GenerateUserID()); err == nil {
+		t.Error("Expected an outsider not to become lead")
+	}
+
+	if err := team.ChangeLead(memberID); err != nil {
+		t.Fatalf("Failed to change lead: %v", err)
+	}
+	if err := team.RemoveMember(leadID); err != nil {
+		t.Errorf("Expected the former lead to be removable, got %v", err)
+	}
+	if !team.IsLead(memberID) || len(team.MemberIDs()) != 1 {
+		t.Errorf("Expected only the new lead to remain, got %v", team.MemberIDs())
+	}
+
+	if _, err := aggregate.NewTeam(value.GenerateTeamID(), " ", leadID, nil); err == nil {
+		t.Error("Expected a blank team name to be rejected")
+	}
+}
+
+// TestWorkingHoursParseAndCapacity tests parsing working days and the hours each day offers
+func TestWorkingHoursParseAndCapacity(t *testing.T) {
+	hours, err := value.ParseWorkingHours(6, []string{"friday", "Mon", "WEDNESDAY", "mon"})
+	if err != nil {
+		t.Fatalf("Failed to parse working hours: %v", err)
+	}
+	if got := hours.WorkingDayNames(); len(got) != 3 || got[0] != "MONDAY" || got[2] != "FRIDAY" {
+		t.Errorf("Expected Monday, Wednesday and Friday once each, got %v", got)
+	}
+	if hours.WeeklyHours() != 18 {
+		t.Errorf("Expected 18 weekly hours, got %v", hours.WeeklyHours())
+	}
+
+	tuesday := time.Date(2026, time.October, 13, 9, 0, 0, 0, time.UTC)
+	if hours.HoursOn(tuesday) != 0 || hours.HoursOn(tuesday.AddDate(0, 0, 1)) != 6 {
+		t.Errorf("Expected no hours on Tuesday and 6 on Wednesday")
+	}
+
+	if value.DefaultWorkingHours().WeeklyHours() != 40 {
+		t.Errorf("Expected the default to be a 40 hour week, got %v", value.DefaultWorkingHours().WeeklyHours())
+	}
+
+	for _, invalid := range []struct {
+		hours float64
+		days  []string
+	}{
+		{0, []string{"MONDAY"}},
+		{25, []string{"MONDAY"}},
+		{8, nil},
+		{8, []string{"Funday"}},
+	} {
+		if _, err := value.ParseWorkingHours(invalid.hours, invalid.days); err == nil {
+			t.Errorf("Expected %v hours on %v to be rejected", invalid.hours, invalid.days)
+		}
+	}
+}
+
+func TestHolidayRulesAndBusinessDays(t *testing.T) {
+	newYear, _ := value.NewAnnualHoliday("New Year's Day", time.January, 1)
+	thanksgiving, _ := value.NewNthWeekdayHoliday("Thanksgiving", time.November, time.Thursday, 4)
+	memorialDay, _ := value.NewNthWeekdayHoliday("Memorial Day", time.May, time.Monday, -1)
+	closure, _ := value.NewDateHoliday("Office move", time.Date(2026, time.October, 16, 15, 0, 0, 0, time.UTC))
+
+	for _, tc := range []struct {
+		holiday value.Holiday
+		date    time.Time
+		want    bool
+	}{
+		{newYear, time.Date(2027, time.January, 1, 9, 0, 0, 0, time.UTC), true},
+		{newYear, time.Date(2027, time.January, 2, 9, 0, 0, 0, time.UTC), false},
+		{thanksgiving, time.Date(2026, time.November, 26, 9, 0, 0, 0, time.UTC), true},
+		{thanksgiving, time.Date(2026, time.November, 19, 9, 0, 0, 0, time.UTC), false},
+		{memorialDay, time.Date(2026, time.May, 25, 9, 0, 0, 0, time.UTC), true},
+		{memorialDay, time.Date(2026, time.May, 18, 9, 0, 0, 0, time.UTC), false},
+		{closure, time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), true},
+		{closure, time.Date(2027, time.October, 16, 0, 0, 0, 0, time.UTC), false},
+	} {
+		if got := tc.holiday.OccursOn(tc.date); got != tc.want {
+			t.Errorf("Expected %s on %s to be %v", tc.holiday.Name(), tc.date.Format("2006-01-02"), tc.want)
+		}
+	}
+
+	calendar, err := aggregate.NewHolidayCalendar(value.GenerateHolidayCalendarID(), value.GenerateOrganizationID(),
+		"us", "United States", []value.Holiday{newYear, thanksgiving, memorialDay, closure})
+	if err != nil {
+		t.Fatalf("Failed to create holiday calendar: %v", err)
+	}
+	if calendar.Region() != "US" {
+		t.Errorf("Expected the region to be upper-cased, got %s", calendar.Region())
+	}
+
+	// Two business days after Wednesday are Thursday and, past the Friday closure, Monday
+	wednesday := time.Date(2026, time.October, 14, 9, 0, 0, 0, time.UTC)
+	if got := service.NewHolidayCalendarService(nil, nil).AddBusinessDays(calendar, wednesday, 2); !got.Equal(wednesday.AddDate(0, 0, 5)) {
+		t.Errorf("Expected two business days after Wednesday to skip the closure and the weekend, got %s", got.Weekday())
+	}
+	if calendar.IsBusinessDay(time.Date(2026, time.November, 26, 9, 0, 0, 0, time.UTC)) {
+		t.Error("Expected Thanksgiving not to be a business day")
+	}
+
+	for _, invalid := range []struct {
+		rule, date string
+		month, day int
+		weekday    string
+		nth        int
+	}{
+		{"ANNUAL", "", 2, 30, "", 0},
+		{"NTH_WEEKDAY", "", 11, 0, "THURSDAY", 5},
+		{"NTH_WEEKDAY", "", 11, 0, "Thorsday", 1},
+		{"DATE", "26-10-16", 0, 0, "", 0},
+		{"EASTER", "", 0, 0, "", 0},
+	} {
+		if _, err := value.ParseHoliday("Holiday", invalid.rule, invalid.date, invalid.month, invalid.day, invalid.weekday, invalid.nth); err == nil {
+			t.Errorf("Expected %+v to be rejected", invalid)
+		}
+	}
+}
+
+func TestInboxAddressTokens(t *testing.T) {
+	inbox, err := value.GenerateInboxAddress("  support@lists.example.com ")
+	if err != nil {
+		t.Fatalf("Failed to generate inbox address: %v", err)
+	}
+	if inbox.Label() != "support@lists.example.com" {
+		t.Errorf("Expected the label to be trimmed, got %q", inbox.Label())
+	}
+
+	address := inbox.Address("inbound.example.com")
+	for _, recipient := range []string{
+		address,
+		"Support Inbox <" + address + ">",
+		inbox.Token() + "@inbound.example.com",
+		"TASKS+" + strings.ToUpper(inbox.Token()) + "@INBOUND.EXAMPLE.COM",
+	} {
+		if token, ok := value.InboxTokenOf(recipient); !ok || token != inbox.Token() {
+			t.Errorf("Expected %q to carry the inbox token, got %q", recipient, token)
+		}
+	}
+
+	for _, recipient := range []string{"alice@example.com", "tasks+nothex@inbound.example.com", "no-at-sign"} {
+		if _, ok := value.InboxTokenOf(recipient); ok {
+			t.Errorf("Expected %q not to be an inbox address", recipient)
+		}
+	}
+
+	if other, _ := value.GenerateInboxAddress(""); other.Token() == inbox.Token() {
+		t.Error("Expected every inbox address to get its own token")
+	}
+}
+
+func TestLocaleNormalization(t *testing.T) {
+	for tag, expected := range map[string]string{
+		"de":         "de",
+		" pt_br ":    "pt-BR",
+		"EN-us":      "en-US",
+		"zh-Hant-TW": "zh-Hant-TW",
+	} {
+		locale, err := value.NewLocale(tag)
+		if err != nil {
+			t.Errorf("Expected %q to be a valid locale, got %v", tag, err)
+			continue
+		}
+		if locale.Value() != expected {
+			t.Errorf("Expected %q to normalize to %q, got %q", tag, expected, locale.Value())
+		}
+	}
+
+	for _, tag := range []string{"", "e", "english!", "de-", "de-x"} {
+		if _, err := value.NewLocale(tag); err == nil {
+			t.Errorf("Expected %q to be rejected", tag)
+		}
+	}
+
+	if locale, _ := value.NewLocale("pt-BR"); locale.Language() != "pt" {
+		t.Errorf("Expected language pt, got %q", locale.Language())
+	}
+}
+
+func TestUserOutOfOfficePeriods(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, time.August, d, 0, 0, 0, 0, time.UTC) }
+
+	if _, err := value.NewOutOfOffice(day(10), day(10), ""); err == nil {
+		t.Error("Expected a period ending when it starts to be rejected")
+	}
+
+	user, _ := aggregate.NewUser(value.GenerateUserID(), "away@example.com", "Away", "User")
+	summer, _ := value.NewOutOfOffice(day(10), day(20), "ask the team lead")
+	conference, _ := value.NewOutOfOffice(day(2), day(4), "")
+	overlapping, _ := value.NewOutOfOffice(day(19), day(25), "")
+
+	if err := user.SetOutOfOffice([]value.OutOfOffice{summer, overlapping}); err == nil {
+		t.Error("Expected overlapping periods to be rejected")
+	}
+	if err := user.SetOutOfOffice([]value.OutOfOffice{summer, conference}); err != nil {
+		t.Fatalf("Failed to set out-of-office periods: %v", err)
+	}
+	if periods := user.OutOfOffice(); len(periods) != 2 || !periods[0].Start().Equal(day(2)) {
+		t.Errorf("Expected periods ordered by start, got %v", periods)
+	}
+
+	if period, away := user.OutOfOfficeAt(day(15)); !away || period.Note() != "ask the team lead" {
+		t.Error("Expected the user to be away mid-August")
+	}
+	if _, away := user.OutOfOfficeAt(day(20)); away {
+		t.Error("Expected the user to be back on the day a period ends")
+	}
+}
+
+func TestCommentVersionBumpsOnEdit(t *testing.T) {
+	comment, err := entity.NewComment(value.GenerateTaskID(), value.GenerateUserID(), "First draft")
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	if comment.Version() != 1 {
+		t.Errorf("Expected a new comment to be version 1, got %d", comment.Version())
+	}
+
+	if err := comment.Update("Second draft"); err != nil {
+		t.Fatalf("Failed to update comment: %v", err)
+	}
+	if comment.Version() != 2 {
+		t.Errorf("Expected an edited comment to be version 2, got %d", comment.Version())
+	}
+}
+
+func TestAssignmentAcknowledgementAndEscalation(t *testing.T) {
+	priority, _ := value.NewPriority("MEDIUM")
+	managerID := value.GenerateUserID()
+	assigneeID := value.GenerateUserID()
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Task", "", priority, managerID)
+
+	if err := task.AcknowledgeAssignment(assigneeID, aggregate.AcknowledgementExplicit); err == nil {
+		t.Error("Expected an unassigned task not to be acknowledged")
+	}
+
+	task.Assign(assigneeID, managerID)
+	task.ClearDomainEvents()
+	assignedAt := task.Assignee().AssignedAt()
+
+	if task.EscalateUnacknowledgedAssignment(assignedAt.Add(time.Hour), 2*time.Hour) {
+		t.Error("Expected no escalation within the window")
+	}
+	if !task.EscalateUnacknowledgedAssignment(assignedAt.Add(3*time.Hour), 2*time.Hour) {
+		t.Fatal("Expected the assignment to be escalated after the window")
+	}
+	if task.EscalateUnacknowledgedAssignment(assignedAt.Add(6*time.Hour), 2*time.Hour) {
+		t.Error("Expected an assignment to be escalated only once")
+	}
+	escalated, ok := task.DomainEvents()[0].(event.TaskAssignmentEscalatedEvent)
+	if !ok || escalated.AssignedBy != managerID.Value() || escalated.UnacknowledgedHours != 3 {
+		t.Errorf("Expected an escalation to the manager after 3 hours, got %+v", task.DomainEvents()[0])
+	}
+
+	if err := task.AcknowledgeAssignment(managerID, aggregate.AcknowledgementExplicit); err == nil {
+		t.Error("Expected only the assignee to acknowledge")
+	}
+	if err := task.AcknowledgeAssignment(assigneeID, aggregate.AcknowledgementOnView); err != nil {
+		t.Fatalf("Failed to acknowledge: %v", err)
+	}
+	if !task.Assignee().IsAcknowledged() {
+		t.Error("Expected the assignment to be acknowledged")
+	}
+	if err := task.AcknowledgeAssignment(assigneeID, aggregate.AcknowledgementExplicit); err == nil {
+		t.Error("Expected a second acknowledgement to be refused")
+	}
+
+	// A new assignment has to be acknowledged again
+	task.Assign(managerID, managerID)
+	if task.Assignee().IsAcknowledged() || task.Assignee().EscalatedAt() != nil {
+		t.Error("Expected reassignment to reset the read receipt")
+	}
+}
+
+func TestPrioritySchemeLevelsAndValidation(t *testing.T) {
+	level := func(name string, rank int) value.PriorityLevel {
+		l, err := value.NewPriorityLevel(name, rank)
+		if err != nil {
+			t.Fatalf("Failed to create priority level %s: %v", name, err)
+		}
+		return l
+	}
+
+	scheme, err := value.NewPriorityScheme([]value.PriorityLevel{level("sev-1", 30), level("SEV-3", 10), level("Sev-2", 20)})
+	if err != nil {
+		t.Fatalf("Failed to create priority scheme: %v", err)
+	}
+	levels := scheme.Levels()
+	if levels[0].Priority() != "SEV-3" || levels[2].Priority() != "SEV-1" {
+		t.Errorf("Expected levels least urgent first and upper cased, got %+v", levels)
+	}
+	if scheme.Rank("SEV-2") != 20 || scheme.Rank(value.PriorityHigh) != 0 {
+		t.Errorf("Expected ranks from the scheme, got %d and %d", scheme.Rank("SEV-2"), scheme.Rank(value.PriorityHigh))
+	}
+	if priority, err := scheme.Parse(" sev-2 "); err != nil || priority != "SEV-2" {
+		t.Errorf("Expected a case insensitive parse, got %q, %v", priority, err)
+	}
+	if _, err := scheme.Parse("HIGH"); err == nil {
+		t.Error("Expected a priority outside the scheme to be rejected")
+	}
+	if scheme.IsDefault() || !value.DefaultPriorityScheme().IsDefault() {
+		t.Error("Expected only the LOW to CRITICAL ladder to be the default scheme")
+	}
+
+	for name, levels := range map[string][]value.PriorityLevel{
+		"one level":      {level("ONLY", 1)},
+		"duplicate name": {level("P1", 1), level("p1", 2)},
+		"duplicate rank": {level("P1", 1), level("P2", 1)},
+	} {
+		if _, err := value.NewPriorityScheme(levels); err == nil {
+			t.Errorf("Expected a scheme with %s to be rejected", name)
+		}
+	}
+	for _, name := range []string{"", "-P1", "P 1", "URGENT!", strings.Repeat("P", 33)} {
+		if _, err := value.NewPriorityLevel(name, 1); err == nil {
+			t.Errorf("Expected priority name %q to be rejected", name)
+		}
+	}
+}
+
+func TestIdentifierFormats(t *testing.T) {
+	defer value.SetIDFormat(value.CurrentIDFormat())
+
+	if _, err := value.NewTaskID("task-1"); err != nil {
+		t.Fatalf("Expected any non-empty id by default, got %v", err)
+	}
+
+	value.SetIDFormat(value.IDFormatUUID)
+	generated := value.GenerateTaskID()
+	if _, err := value.NewTaskID(generated.Value()); err != nil {
+		t.Errorf("Expected a generated id to parse, got %v", err)
+	}
+	for _, id := range []string{"task-1", "tsk_" + generated.Value(), "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "{" + generated.Value() + "}"} {
+		if _, err := value.NewTaskID(id); err == nil || !strings.HasPrefix(err.Error(), "invalid task id") {
+			t.Errorf("Expected %q to be rejected as an invalid task id, got %v", id, err)
+		}
+	}
+
+	value.SetIDFormat(value.IDFormatPrefixed)
+	project := value.GenerateProjectID()
+	if !strings.HasPrefix(project.Value(), "prj_") {
+		t.Errorf("Expected a prj_ prefixed project id, got %s", project.Value())
+	}
+	if _, err := value.NewProjectID(project.Value()); err != nil {
+		t.Errorf("Expected a generated project id to parse, got %v", err)
+	}
+	if _, err := value.NewTaskID(project.Value()); err == nil {
+		t.Error("Expected a project id to be rejected as a task id")
+	}
+	if id, err := value.NewWorkflowID(value.DefaultWorkflowID.Value()); err != nil || !id.Equals(value.DefaultWorkflowID) {
+		t.Errorf("Expected the default workflow id in every format, got %v", err)
+	}
+
+	if _, err := value.NewIDFormat("SHORT"); err == nil {
+		t.Error("Expected an unknown id format to be rejected")
+	}
+}
@@ -0,0 +1,92 @@
+package unit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+)
+
+// TestEventMetricsTrackBacklogAndSubscriberErrors tests delivery metrics around a failing subscriber
+func TestEventMetricsTrackBacklogAndSubscriberErrors(t *testing.T) {
+	ctx := context.Background()
+	metrics := infraEvent.NewEventMetrics()
+	simple := infraEvent.NewSimpleEventPublisher()
+	publisher := infraEvent.NewStoringEventPublisher(infraEvent.NewInMemoryEventStore(), simple, metrics)
+
+	healthy := infraEvent.NewMeasuredSubscriber(simple, metrics, "healthy")
+	healthy.Subscribe("TaskVoted", func(evt event.DomainEvent) error { return nil })
+
+	failing := infraEvent.NewMeasuredSubscriber(simple, metrics, "failing")
+	failing.Subscribe("TaskVoted", func(evt event.DomainEvent) error { return fmt.Errorf("boom") })
+
+	publisher.Publish(ctx, event.NewTaskVotedEvent("task-1", "user-1", 1))
+	publisher.Publish(ctx, event.NewTaskVotedEvent("task-1", "user-2", 2))
+
+	snapshot := metrics.Snapshot()
+	if snapshot.EventsStored != 2 || snapshot.OutboxBacklog != 2 {
+		t.Errorf("Expected 2 stored and undelivered events, got %+v", snapshot)
+	}
+
+	if len(snapshot.Subscribers) != 2 {
+		t.Fatalf("Expected 2 subscribers, got %d", len(snapshot.Subscribers))
+	}
+
+	failingMetrics, healthyMetrics := snapshot.Subscribers[0], snapshot.Subscribers[1]
+	if failingMetrics.ErrorRate != 1 || failingMetrics.LastEventAt != nil {
+		t.Errorf("Expected failing subscriber to have no progress, got %+v", failingMetrics)
+	}
+
+	if healthyMetrics.Processed != 2 || healthyMetrics.Errors != 0 || healthyMetrics.LastEventAt == nil || healthyMetrics.LagSeconds != 0 {
+		t.Errorf("Expected healthy subscriber to be caught up, got %+v", healthyMetrics)
+	}
+}
+
+// TestEventStatsCountTypesAndTopProducersPerProject tests the capacity breakdown of stored events
+func TestEventStatsCountTypesAndTopProducersPerProject(t *testing.T) {
+	ctx := context.Background()
+	metrics := infraEvent.NewEventMetrics()
+	metrics.SetProjectResolver(func(evt event.DomainEvent) string {
+		if evt.AggregateType() == "Task" {
+			return "project-1"
+		}
+		return ""
+	})
+	simple := infraEvent.NewSimpleEventPublisher()
+	publisher := infraEvent.NewStoringEventPublisher(infraEvent.NewInMemoryEventStore(), simple, metrics)
+
+	slow := infraEvent.NewMeasuredSubscriber(simple, metrics, "slow")
+	slow.Subscribe("TaskVoted", func(evt event.DomainEvent) error {
+		time.Sleep(2 * time.Millisecond)
+		return nil
+	})
+
+	publisher.Publish(ctx, event.NewTaskVotedEvent("task-1", "user-1", 1))
+	publisher.Publish(ctx, event.NewTaskVotedEvent("task-1", "user-2", 2))
+	publisher.Publish(ctx, event.NewTaskVotedEvent("task-2", "user-1", 1))
+	publisher.Publish(ctx, event.NewUserEmailVerifiedEvent("user-1", "user@example.com"))
+
+	stats := metrics.Stats("", 1)
+	if len(stats.EventTypes) != 2 || stats.EventTypes[0].EventType != "TaskVoted" || stats.EventTypes[0].Count != 3 {
+		t.Errorf("Expected TaskVoted to lead with 3 events, got %+v", stats.EventTypes)
+	}
+	if len(stats.AggregateTypes) != 2 || stats.AggregateTypes[1].AggregateType != "User" {
+		t.Errorf("Expected Task and User aggregates, got %+v", stats.AggregateTypes)
+	}
+
+	if len(stats.Projects) != 1 || stats.Projects[0].Events != 3 {
+		t.Fatalf("Expected user events to stay out of project stats, got %+v", stats.Projects)
+	}
+	producers := stats.Projects[0].TopProducers
+	if len(producers) != 1 || producers[0].AggregateID != "task-1" || producers[0].Events != 2 {
+		t.Errorf("Expected task-1 as the top producer, got %+v", producers)
+	}
+
+	subscriber := metrics.Snapshot().Subscribers[0]
+	if subscriber.AvgLatencyMs < 2 || subscriber.MaxLatencyMs < subscriber.AvgLatencyMs {
+		t.Errorf("Expected handler latency to be measured, got %+v", subscriber)
+	}
+}
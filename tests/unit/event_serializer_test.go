@@ -0,0 +1,123 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/event"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+)
+
+// TestEventSerializerEnvelope tests the wire format of a serialized event
+func TestEventSerializerEnvelope(t *testing.T) {
+	serializer := infraEvent.NewEventSerializer()
+
+	data, err := serializer.Serialize(event.NewTaskAssignedEvent("task-1", "user-2", "user-1"))
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+
+	var envelope dto.EventEnvelopeDTO
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		t.Fatalf("Failed to decode envelope: %v", err)
+	}
+
+	if envelope.EventType != "TaskAssigned" || envelope.SchemaVersion != 1 || envelope.AggregateID != "task-1" {
+		t.Errorf("Unexpected envelope header: %+v", envelope)
+	}
+
+	if envelope.Payload["previous_assignee_id"] != "user-1" {
+		t.Errorf("Expected snake_case payload field, got %v", envelope.Payload)
+	}
+}
+
+// TestEventSerializerKeepsEventIDs tests that every event gets its own id and keeps it on a round trip
+func TestEventSerializerKeepsEventIDs(t *testing.T) {
+	serializer := infraEvent.NewEventSerializer()
+	first := event.NewTaskCreatedEvent("task-1", "project-1", "Title", "", "", "HIGH")
+	second := event.NewTaskCreatedEvent("task-1", "project-1", "Title", "", "", "HIGH")
+	if first.EventID() == "" || first.EventID() == second.EventID() {
+		t.Fatalf("Expected distinct event ids, got %q and %q", first.EventID(), second.EventID())
+	}
+
+	data, _ := serializer.Serialize(first)
+	restored, err := serializer.Deserialize(data)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	if restored.EventID() != first.EventID() || !restored.OccurredAt().Equal(first.OccurredAt()) {
+		t.Errorf("Expected the id and time kept, got %q at %s", restored.EventID(), restored.OccurredAt())
+	}
+
+	// Envelopes written before events had ids still decode
+	legacy, err := serializer.Deserialize([]byte(`{"event_type":"TaskDeleted","schema_version":1,` +
+		`"aggregate_id":"task-1","aggregate_type":"Task","occurred_at":"2024-01-01T00:00:00Z","payload":{}}`))
+	if err != nil || legacy.EventID() != "" {
+		t.Errorf("Expected an envelope without id to decode with an empty id, got %v", err)
+	}
+}
+
+// TestEventSerializerSchemas tests that schemas describe payload fields
+func TestEventSerializerSchemas(t *testing.T) {
+	serializer := infraEvent.NewEventSerializer()
+
+	for _, schema := range serializer.Schemas() {
+		if schema.EventType != "TaskVoted" {
+			continue
+		}
+
+		payload := schema.Schema["properties"].(map[string]interface{})["payload"].(map[string]interface{})
+		voteCount := payload["properties"].(map[string]interface{})["vote_count"].(map[string]interface{})
+		if voteCount["type"] != "integer" {
+			t.Errorf("Expected vote_count to be an integer, got %v", voteCount)
+		}
+		return
+	}
+
+	t.Fatal("Expected TaskVoted schema to be registered")
+}
+
+// TestEventStreamExportImportRemapsIDs tests an NDJSON round trip with seeded ID re-mapping
+func TestEventStreamExportImportRemapsIDs(t *testing.T) {
+	ctx := context.Background()
+	serializer := infraEvent.NewEventSerializer()
+	source := infraEvent.NewInMemoryEventStore()
+	source.Store(ctx, event.NewTaskCreatedEvent("task-1", "project-1", "Title", "", "", "HIGH"))
+	source.Store(ctx, event.NewSprintCompletedEvent("sprint-1", "project-1", []string{"task-1"}, []string{}))
+
+	var exported bytes.Buffer
+	if _, err := infraEvent.ExportNDJSON(ctx, &exported, source, serializer); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	var again bytes.Buffer
+	infraEvent.ExportNDJSON(ctx, &again, source, serializer)
+	if !bytes.Equal(exported.Bytes(), again.Bytes()) {
+		t.Error("Expected export to be deterministic")
+	}
+
+	mapper := infraEvent.NewSeededIDMapper("staging")
+	target := infraEvent.NewInMemoryEventStore()
+	count, err := infraEvent.ImportNDJSON(ctx, bytes.NewReader(exported.Bytes()), target, serializer, mapper)
+	if err != nil || count != 2 {
+		t.Fatalf("Expected 2 imported events, got %d (%v)", count, err)
+	}
+
+	events, _ := target.GetAllEvents(ctx)
+	created, ok := events[0].(event.TaskCreatedEvent)
+	if !ok {
+		t.Fatalf("Expected TaskCreatedEvent, got %T", events[0])
+	}
+
+	if created.AggregateID() != mapper.Map("task-1") || created.ProjectID != mapper.Map("project-1") || created.Title != "Title" {
+		t.Errorf("Unexpected imported task event: %+v", created)
+	}
+
+	completed := events[1].(event.SprintCompletedEvent)
+	if len(completed.CompletedTaskIDs) != 1 || completed.CompletedTaskIDs[0] != created.AggregateID() {
+		t.Errorf("Expected task references to be re-mapped consistently, got %v", completed.CompletedTaskIDs)
+	}
+}
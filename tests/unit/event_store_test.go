@@ -0,0 +1,455 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+	"github.com/miladev95/ddd-task/infrastructure/repository"
+
+	_ "github.com/lib/pq"
+)
+
+// benchmarkEventCount is how many events each benchmark iteration appends
+const benchmarkEventCount = 1000
+
+// buildEvents creates a stream of task events for store tests
+func buildEvents(count int) []event.DomainEvent {
+	events := make([]event.DomainEvent, 0, count)
+	for i := 0; i < count; i++ {
+		taskID := fmt.Sprintf("task-%d", i)
+		events = append(events, event.NewTaskCreatedEvent(taskID, "project-1", "Title", "", "", "HIGH"))
+	}
+	return events
+}
+
+// TestEventStoreAppendBatchKeepsOrder tests that a batch is appended after existing events in order
+func TestEventStoreAppendBatchKeepsOrder(t *testing.T) {
+	ctx := context.Background()
+	store := infraEvent.NewInMemoryEventStore()
+	store.Store(ctx, event.NewTaskCreatedEvent("task-first", "project-1", "First", "", "", "LOW"))
+
+	if err := store.AppendBatch(ctx, buildEvents(3)); err != nil {
+		t.Fatalf("Failed to append batch: %v", err)
+	}
+
+	events, _ := store.GetAllEvents(ctx)
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(events))
+	}
+
+	if events[0].AggregateID() != "task-first" || events[3].AggregateID() != "task-2" {
+		t.Errorf("Expected batch appended in order after existing events, got %s ... %s",
+			events[0].AggregateID(), events[3].AggregateID())
+	}
+}
+
+// failingEventStore refuses every append
+type failingEventStore struct {
+	*infraEvent.InMemoryEventStore
+}
+
+func (failingEventStore) AppendBatch(ctx context.Context, events []event.DomainEvent) error {
+	return errors.New("store unavailable")
+}
+
+// TestStoringPublisherStoresBatchBeforeDelivery tests that events a store refuses are never delivered
+func TestStoringPublisherStoresBatchBeforeDelivery(t *testing.T) {
+	ctx := context.Background()
+	delivered := 0
+	publisher := infraEvent.NewSimpleEventPublisher()
+	publisher.Subscribe("TaskCreated", func(evt event.DomainEvent) error {
+		delivered++
+		return nil
+	})
+
+	store := infraEvent.NewInMemoryEventStore()
+	if err := infraEvent.NewStoringEventPublisher(store, publisher, nil).PublishAll(ctx, buildEvents(2)); err != nil {
+		t.Fatalf("Failed to publish events: %v", err)
+	}
+	if stored, _ := store.GetAllEvents(ctx); len(stored) != 2 || delivered != 2 {
+		t.Errorf("Expected 2 events stored and delivered, got %d and %d", len(stored), delivered)
+	}
+
+	failing := failingEventStore{infraEvent.NewInMemoryEventStore()}
+	if err := infraEvent.NewStoringEventPublisher(failing, publisher, nil).PublishAll(ctx, buildEvents(2)); err == nil {
+		t.Fatal("Expected a refused append to fail the publish")
+	}
+	if delivered != 2 {
+		t.Errorf("Expected nothing delivered after a refused append, got %d more", delivered-2)
+	}
+}
+
+// TestEventStorePagesAggregateStream tests that pages continue from the version of the last event read
+func TestEventStorePagesAggregateStream(t *testing.T) {
+	ctx := context.Background()
+	store := infraEvent.NewInMemoryEventStore()
+	store.AppendBatch(ctx, []event.DomainEvent{
+		event.NewTaskCreatedEvent("task-1", "project-1", "Title", "", "", "HIGH"),
+		event.NewTaskCreatedEvent("task-other", "project-1", "Other", "", "", "LOW"),
+		event.NewTaskAssignedEvent("task-1", "user-1", "user-2"),
+		event.NewTaskCompletedEvent("task-1", "user-1", time.Now().Format(time.RFC3339)),
+	})
+
+	first, err := store.GetEventsPage(ctx, "task-1", 0, 2)
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+	if len(first) != 2 || first[1].EventType() != "TaskAssigned" {
+		t.Fatalf("Expected the first 2 task events, got %d", len(first))
+	}
+
+	rest, _ := store.GetEventsPage(ctx, "task-1", 2, 2)
+	if len(rest) != 1 || rest[0].EventType() != "TaskCompleted" {
+		t.Errorf("Expected only the completed event after version 2, got %d", len(rest))
+	}
+
+	if all, _ := store.GetEventsPage(ctx, "task-1", 0, 0); len(all) != 3 {
+		t.Errorf("Expected limit 0 to return all 3 events, got %d", len(all))
+	}
+
+	if _, err := store.GetEventsPage(ctx, "task-1", -1, 2); err == nil {
+		t.Error("Expected a negative version to be rejected")
+	}
+}
+
+// TestEventStorePrunesWholeStreams tests that only streams untouched since the cutoff are removed
+func TestEventStorePrunesWholeStreams(t *testing.T) {
+	ctx := context.Background()
+	store := infraEvent.NewInMemoryEventStore()
+	store.AppendBatch(ctx, buildEvents(2))
+	cutoff := time.Now().Add(2 * time.Second).UTC().Format(time.RFC3339)
+
+	// task-1 is touched again after the cutoff, so its whole stream stays
+	later := event.NewTaskAssignedEvent("task-1", "user-1", "user-2")
+	later.BaseDomainEvent = event.RestoreBaseDomainEvent("TaskAssigned", "task-1", "Task", time.Now().Add(time.Hour))
+	store.Store(ctx, later)
+
+	pruned, err := store.PruneStreams(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("Failed to prune: %v", err)
+	}
+	if remaining, _ := store.GetAllEvents(ctx); pruned != 1 || len(remaining) != 2 || remaining[0].AggregateID() != "task-1" {
+		t.Errorf("Expected only task-0 pruned, got %d pruned and %d left", pruned, len(remaining))
+	}
+
+	if _, err := store.PruneStreams(ctx, "yesterday"); err == nil {
+		t.Error("Expected an invalid timestamp to be rejected")
+	}
+}
+
+// TestEventStoreQueriesTheLogByFilter tests filtering the whole log and paging by stable positions
+func TestEventStoreQueriesTheLogByFilter(t *testing.T) {
+	ctx := context.Background()
+	store := infraEvent.NewInMemoryEventStore()
+	store.AppendBatch(ctx, buildEvents(3))
+	store.Store(ctx, event.NewTaskAssignedEvent("task-2", "user-1", "user-2"))
+
+	assigned, err := store.QueryEvents(ctx, event.EventFilter{EventType: "TaskAssigned"})
+	if err != nil || len(assigned) != 1 || assigned[0].Position != 4 {
+		t.Fatalf("Expected the assigned event at position 4, got %+v, %v", assigned, err)
+	}
+
+	page, _ := store.QueryEvents(ctx, event.EventFilter{AggregateID: "task-2", Limit: 1})
+	if len(page) != 1 || page[0].Event.EventType() != "TaskCreated" {
+		t.Fatalf("Expected the first event of task-2, got %+v", page)
+	}
+	next, _ := store.QueryEvents(ctx, event.EventFilter{AggregateID: "task-2", AfterPosition: page[0].Position})
+	if len(next) != 1 || next[0].Position != 4 {
+		t.Errorf("Expected the assigned event after position %d, got %+v", page[0].Position, next)
+	}
+
+	// Pruning keeps the positions of the remaining events
+	store.PruneStreams(ctx, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	store.Store(ctx, event.NewTaskCreatedEvent("task-9", "project-1", "Title", "", "", "LOW"))
+	if rest, _ := store.QueryEvents(ctx, event.EventFilter{}); len(rest) != 1 || rest[0].Position != 5 {
+		t.Errorf("Expected the event stored after pruning at position 5, got %+v", rest)
+	}
+
+	if _, err := store.QueryEvents(ctx, event.EventFilter{Since: "last week"}); err == nil {
+		t.Error("Expected an invalid since to be rejected")
+	}
+}
+
+// TestSimplePublisherDispatchesAsynchronously tests retries, give-ups and draining of the worker pool
+func TestSimplePublisherDispatchesAsynchronously(t *testing.T) {
+	publisher := infraEvent.NewSimpleEventPublisher()
+
+	release := make(chan struct{})
+	var mu sync.Mutex
+	attempts, delivered := make(map[string]int), 0
+	var givenUp []error
+	publisher.Subscribe("TaskCreated", func(evt event.DomainEvent) error {
+		<-release
+		mu.Lock()
+		defer mu.Unlock()
+		delivered++
+		return nil
+	})
+	publisher.Subscribe("TaskCreated", func(evt event.DomainEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[evt.AggregateID()]++
+		if evt.AggregateID() == "task-0" && attempts["task-0"] < 2 {
+			return errors.New("temporarily unavailable")
+		}
+		if evt.AggregateID() == "task-1" {
+			return errors.New("always failing")
+		}
+		return nil
+	})
+
+	publisher.StartAsync(infraEvent.AsyncOptions{
+		Workers:        2,
+		QueueSize:      8,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		OnFailure: func(evt event.DomainEvent, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			givenUp = append(givenUp, err)
+		},
+	})
+
+	// Publishing returns while the first handler is still blocked
+	if err := publisher.PublishAll(context.Background(), buildEvents(2)); err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+	close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := publisher.Drain(ctx); err != nil {
+		t.Fatalf("Failed to drain: %v", err)
+	}
+
+	mu.Lock()
+	if delivered != 2 {
+		t.Errorf("Expected both events delivered to the blocked handler, got %d", delivered)
+	}
+	if attempts["task-0"] != 2 || attempts["task-1"] != 3 {
+		t.Errorf("Expected 2 attempts for the retried event and 3 for the failing one, got %v", attempts)
+	}
+	if len(givenUp) != 1 {
+		t.Errorf("Expected only the failing event given up, got %v", givenUp)
+	}
+	mu.Unlock()
+
+	// Events published after draining are handled before Publish returns
+	publisher.Publish(ctx, event.NewTaskCreatedEvent("task-late", "project-1", "Title", "", "", "LOW"))
+	if delivered != 3 {
+		t.Errorf("Expected the late event delivered synchronously, got %d deliveries", delivered)
+	}
+}
+
+// TestSimplePublisherHandlesEventsPublishedByHandlersWhenTheQueueIsFull tests that a worker
+// publishing into a full queue does not deadlock the pool
+func TestSimplePublisherHandlesEventsPublishedByHandlersWhenTheQueueIsFull(t *testing.T) {
+	publisher := infraEvent.NewSimpleEventPublisher()
+
+	var mu sync.Mutex
+	assigned := 0
+	publisher.Subscribe("TaskAssigned", func(evt event.DomainEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		assigned++
+		return nil
+	})
+	// Like the deactivation cascade, the handler publishes events of its own
+	publisher.Subscribe("TaskCreated", func(evt event.DomainEvent) error {
+		for i := 0; i < 4; i++ {
+			publisher.Publish(context.Background(), event.NewTaskAssignedEvent(evt.AggregateID(), fmt.Sprintf("user-%d", i), ""))
+		}
+		return nil
+	})
+
+	publisher.StartAsync(infraEvent.AsyncOptions{Workers: 1, QueueSize: 1, MaxAttempts: 1})
+	if err := publisher.PublishAll(context.Background(), buildEvents(3)); err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := publisher.Drain(ctx); err != nil {
+		t.Fatalf("Expected the pool to drain, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assigned != 12 {
+		t.Errorf("Expected every event published by a handler delivered, got %d", assigned)
+	}
+}
+
+// TestPostgresEventStore runs against the database in TEST_POSTGRES_DSN, skipped when unset
+func TestPostgresEventStore(t *testing.T) {
+	ctx := context.Background()
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	store := infraEvent.NewPostgresEventStore(db, infraEvent.NewEventSerializer())
+	if err := store.EnsureSchema(); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	taskID := fmt.Sprintf("task-%d", time.Now().UnixNano())
+	before := time.Now().Add(-time.Second).UTC().Format(time.RFC3339)
+	if err := store.Store(ctx, event.NewTaskCreatedEvent(taskID, "project-1", "Title", "", "", "HIGH")); err != nil {
+		t.Fatalf("Failed to store event: %v", err)
+	}
+	if err := store.AppendBatch(ctx, []event.DomainEvent{
+		event.NewTaskAssignedEvent(taskID, "user-1", "user-2"),
+		event.NewTaskCompletedEvent(taskID, "user-1", time.Now().Format(time.RFC3339)),
+	}); err != nil {
+		t.Fatalf("Failed to append batch: %v", err)
+	}
+
+	events, err := store.GetEvents(ctx, taskID)
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if len(events) != 3 || events[0].EventType() != "TaskCreated" || events[2].EventType() != "TaskCompleted" {
+		t.Fatalf("Expected the 3 events back in append order, got %d", len(events))
+	}
+	if created, ok := events[0].(event.TaskCreatedEvent); !ok || created.Title != "Title" || created.EventID() == "" {
+		t.Errorf("Expected the created event payload and id to round trip, got %+v", events[0])
+	}
+
+	if page, err := store.GetEventsPage(ctx, taskID, 1, 1); err != nil || len(page) != 1 || page[0].EventType() != "TaskAssigned" {
+		t.Errorf("Expected the assigned event as the page after version 1, got %d, %v", len(page), err)
+	}
+
+	since, err := store.GetEventsSince(ctx, taskID, before)
+	if err != nil || len(since) != 3 {
+		t.Errorf("Expected 3 events since %s, got %d, %v", before, len(since), err)
+	}
+
+	completed, err := store.QueryEvents(ctx, event.EventFilter{AggregateID: taskID, EventType: "TaskCompleted", Since: before})
+	if err != nil || len(completed) != 1 || completed[0].Position == 0 {
+		t.Errorf("Expected the completed event with its position, got %+v, %v", completed, err)
+	}
+}
+
+// TestSQLiteEventStore tests the SQLite event store on a fresh database file
+func TestSQLiteEventStore(t *testing.T) {
+	ctx := context.Background()
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "events.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	store := infraEvent.NewSQLiteEventStore(db, infraEvent.NewEventSerializer())
+	if err := store.EnsureSchema(); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	before := time.Now().Add(-time.Second).UTC().Format(time.RFC3339)
+	if err := store.Store(ctx, event.NewTaskCreatedEvent("task-1", "project-1", "Title", "", "", "HIGH")); err != nil {
+		t.Fatalf("Failed to store event: %v", err)
+	}
+	if err := store.AppendBatch(ctx, []event.DomainEvent{
+		event.NewTaskCreatedEvent("task-other", "project-1", "Other", "", "", "LOW"),
+		event.NewTaskAssignedEvent("task-1", "user-1", "user-2"),
+		event.NewTaskCompletedEvent("task-1", "user-1", time.Now().Format(time.RFC3339)),
+	}); err != nil {
+		t.Fatalf("Failed to append batch: %v", err)
+	}
+
+	events, err := store.GetEvents(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if len(events) != 3 || events[0].EventType() != "TaskCreated" || events[2].EventType() != "TaskCompleted" {
+		t.Fatalf("Expected the 3 events back in append order, got %d", len(events))
+	}
+	if created, ok := events[0].(event.TaskCreatedEvent); !ok || created.Title != "Title" || created.EventID() == "" {
+		t.Errorf("Expected the created event payload and id to round trip, got %+v", events[0])
+	}
+
+	if page, err := store.GetEventsPage(ctx, "task-1", 1, 1); err != nil || len(page) != 1 || page[0].EventType() != "TaskAssigned" {
+		t.Errorf("Expected the assigned event as the page after version 1, got %d, %v", len(page), err)
+	}
+	if all, _ := store.GetEventsPage(ctx, "task-1", 0, 0); len(all) != 3 {
+		t.Errorf("Expected limit 0 to return all 3 events, got %d", len(all))
+	}
+
+	if since, err := store.GetEventsSince(ctx, "task-1", before); err != nil || len(since) != 3 {
+		t.Errorf("Expected 3 events since %s, got %d, %v", before, len(since), err)
+	}
+
+	assigned, err := store.QueryEvents(ctx, event.EventFilter{EventType: "TaskAssigned", Since: before})
+	if err != nil || len(assigned) != 1 || assigned[0].Position != 3 {
+		t.Errorf("Expected the assigned event at position 3, got %+v, %v", assigned, err)
+	}
+
+	// Pruning keeps the positions of the remaining events and never reuses pruned ones
+	pruned, err := store.PruneStreams(ctx, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	if err != nil || pruned != 4 {
+		t.Fatalf("Expected every stream pruned, got %d, %v", pruned, err)
+	}
+	store.Store(ctx, event.NewTaskCreatedEvent("task-9", "project-1", "Title", "", "", "LOW"))
+	if rest, _ := store.QueryEvents(ctx, event.EventFilter{}); len(rest) != 1 || rest[0].Position != 5 {
+		t.Errorf("Expected the event stored after pruning at position 5, got %+v", rest)
+	}
+}
+
+// BenchmarkEventStoreStore appends events one by one
+func BenchmarkEventStoreStore(b *testing.B) {
+	ctx := context.Background()
+	events := buildEvents(benchmarkEventCount)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		store := infraEvent.NewInMemoryEventStore()
+		for _, evt := range events {
+			store.Store(ctx, evt)
+		}
+	}
+}
+
+// BenchmarkEventStoreAppendBatch appends the same events in a single batch
+func BenchmarkEventStoreAppendBatch(b *testing.B) {
+	ctx := context.Background()
+	events := buildEvents(benchmarkEventCount)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		store := infraEvent.NewInMemoryEventStore()
+		store.AppendBatch(ctx, events)
+	}
+}
+
+// BenchmarkImportNDJSON imports an exported stream, which appends in batches
+func BenchmarkImportNDJSON(b *testing.B) {
+	ctx := context.Background()
+	serializer := infraEvent.NewEventSerializer()
+	source := infraEvent.NewInMemoryEventStore()
+	source.AppendBatch(ctx, buildEvents(benchmarkEventCount))
+
+	var exported bytes.Buffer
+	infraEvent.ExportNDJSON(ctx, &exported, source, serializer)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		target := infraEvent.NewInMemoryEventStore()
+		infraEvent.ImportNDJSON(ctx, bytes.NewReader(exported.Bytes()), target, serializer, nil)
+	}
+}
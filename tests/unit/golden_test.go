@@ -0,0 +1,323 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+)
+
+// updateGolden rewrites the golden files from the current types:
+//
+//	go test ./tests/unit -run Golden -update
+var updateGolden = flag.Bool("update", false, "rewrite golden files of the wire formats")
+
+// goldenTime is the timestamp of every time field in a golden file
+var goldenTime = time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+// goldenDTOs lists every DTO with its wire format under testdata/golden/dto
+var goldenDTOs = []interface{}{
+	dto.SessionDTO{}, dto.RegisterRequest{}, dto.SignUpRequest{}, dto.SignUpDTO{}, dto.LoginRequest{}, dto.ChangePasswordRequest{},
+	dto.TokenDTO{}, dto.RefreshTokenRequest{}, dto.VerifyEmailRequest{}, dto.DeactivateUserRequest{},
+	dto.ChangeEmailRequest{}, dto.UpdateUserRequest{}, dto.WorkingHoursRequest{}, dto.WorkingHoursDTO{}, dto.UserLocaleRequest{},
+	dto.OutOfOfficeDTO{}, dto.UserOutOfOfficeRequest{}, dto.UserManagerRequest{},
+	dto.BoardDTO{}, dto.BoardColumnDTO{}, dto.SetBoardSortRequest{},
+	dto.OrganizationQuotaRequest{}, dto.CreateOrganizationRequest{}, dto.OrganizationMemberRequest{},
+	dto.QuotaLineDTO{}, dto.OrganizationUsageDTO{}, dto.OrganizationDirectoryDTO{}, dto.DirectoryMemberDTO{}, dto.MonthlyUsageDTO{},
+	dto.HolidayDTO{}, dto.HolidayCalendarDTO{}, dto.CreateHolidayCalendarRequest{}, dto.UpdateHolidayCalendarRequest{},
+	dto.IntegrityIssueDTO{}, dto.IntegrityReportDTO{}, dto.EventRecordDTO{}, dto.EventPageDTO{},
+	dto.EventEnvelopeDTO{}, dto.EventSchemaDTO{}, dto.SubscriberMetricsDTO{}, dto.EventMetricsSnapshotDTO{},
+	dto.EventTypeCountDTO{}, dto.AggregateTypeCountDTO{}, dto.EventProducerDTO{}, dto.ProjectEventStatsDTO{}, dto.EventStatsDTO{},
+	dto.IntegrationHealthDTO{}, dto.IntegrationHealthReportDTO{},
+	dto.PageDTO{}, dto.ProjectPageDTO{}, dto.UserPageDTO{}, dto.WorkflowPageDTO{}, dto.TaskPageDTO{},
+	dto.SprintPageDTO{}, dto.WidgetPageDTO{}, dto.HolidayCalendarPageDTO{},
+	dto.SetProjectHolidayCalendarRequest{}, dto.InboxAddressDTO{}, dto.AddInboxAddressRequest{}, dto.InboundEmailRequest{},
+	dto.PresenceViewerDTO{}, dto.PresenceDTO{}, dto.PresenceHeartbeatRequest{}, dto.PresenceMessage{},
+	dto.ProjectDTO{}, dto.UserDTO{}, dto.WorkflowDTO{}, dto.WorkflowStatusDTO{}, dto.CreateProjectRequest{}, dto.UpdateProjectRequest{}, dto.ProjectStatsDTO{}, dto.ActivityEntryDTO{},
+	dto.SLIStatsDTO{}, dto.SetProjectSLORequest{}, dto.ArchiveProjectRequest{}, dto.NotificationRouteDTO{},
+	dto.SetNotificationRoutesRequest{}, dto.MilestoneDTO{}, dto.MilestoneProgressDTO{}, dto.MilestoneRequest{},
+	dto.ProjectSettingsDTO{}, dto.SetProjectSettingsRequest{}, dto.PriorityLevelDTO{}, dto.SetPrioritySchemeRequest{}, dto.MoneyDTO{}, dto.TaskCostDTO{},
+	dto.BudgetSummaryDTO{}, dto.SetProjectBudgetRequest{}, dto.SetProjectAccessRequest{},
+	dto.ChangeProjectWorkflowRequest{}, dto.MigrateProjectWorkflowRequest{}, dto.AssignProjectRoleRequest{},
+	dto.PlanWorkflowMigrationRequest{}, dto.ApplyWorkflowMigrationRequest{}, dto.WorkflowMigrationDTO{}, dto.WorkflowMigrationMoveDTO{},
+	dto.SuggestionDTO{}, dto.RecentViewDTO{}, dto.SearchResultDTO{}, dto.TaskSearchResultDTO{},
+	dto.SprintDTO{}, dto.CreateSprintRequest{}, dto.SprintTaskRequest{},
+	dto.TaskDTO{}, dto.CommentDTO{}, dto.AttachmentDTO{}, dto.CostEntryDTO{}, dto.TaskLinkDTO{},
+	dto.AssignmentDTO{}, dto.DeadlineDTO{}, dto.EditLockDTO{}, dto.CreateTaskRequest{}, dto.UpdateTaskRequest{},
+	dto.AssignTaskRequest{}, dto.UpdateTaskStatusRequest{}, dto.CompareAndSetStatusRequest{},
+	dto.EditLockRequest{}, dto.UpdateTaskDescriptionRequest{}, dto.ChangeTaskDeadlineRequest{}, dto.AddCommentRequest{},
+	dto.AddAttachmentRequest{}, dto.SetDeadlineRequest{}, dto.LinkTaskRequest{}, dto.DuplicateCandidateDTO{},
+	dto.RecordCostRequest{}, dto.ReassignAllTasksRequest{}, dto.ReassignedTaskDTO{}, dto.SkippedTaskDTO{},
+	dto.ReassignmentReportDTO{}, dto.TaskHistoryEntryDTO{},
+	dto.TeamDTO{}, dto.CreateTeamRequest{}, dto.TeamMemberRequest{}, dto.AssignTaskToTeamRequest{},
+	dto.WidgetDTO{}, dto.WidgetLayoutDTO{}, dto.WidgetResultDTO{}, dto.CreateWidgetRequest{},
+	dto.UpdateWidgetRequest{},
+	dto.WorkflowStatusInput{}, dto.WorkflowTransitionInput{}, dto.SimulateWorkflowRequest{},
+	dto.WorkflowSimulationDTO{}, dto.WorkflowIssueDTO{}, dto.InvalidWorkflowTaskDTO{},
+	dto.UpdateWorkflowRequest{}, dto.AddWorkflowStatusRequest{}, dto.ReorderWorkflowStatusesRequest{},
+	dto.TransitionGuardInput{}, dto.TransitionActionInput{}, dto.TransitionRulesInput{},
+	dto.WorkflowApprovalStepRequest{}, dto.WorkflowApprovalStepDTO{}, dto.RejectTaskRequest{},
+	dto.WorkloadHeatmapDTO{}, dto.WorkloadRowDTO{}, dto.WorkloadCellDTO{},
+}
+
+// TestGoldenDTOsCoverEveryDTO tests that no DTO is left out of the golden files
+func TestGoldenDTOsCoverEveryDTO(t *testing.T) {
+	listed := make(map[string]bool, len(goldenDTOs))
+	for _, prototype := range goldenDTOs {
+		listed[reflect.TypeOf(prototype).Name()] = true
+	}
+
+	packages, err := parser.ParseDir(token.NewFileSet(), "../../application/dto", nil, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse the dto package: %v", err)
+	}
+
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					name := spec.(*ast.TypeSpec).Name.Name
+					if ast.IsExported(name) && !listed[name] {
+						t.Errorf("DTO %s has no golden file, add it to goldenDTOs and run with -update", name)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestGoldenDTOWireFormats tests that every DTO decodes its golden JSON strictly and encodes it back unchanged
+func TestGoldenDTOWireFormats(t *testing.T) {
+	for _, prototype := range goldenDTOs {
+		typ := reflect.TypeOf(prototype)
+		path := filepath.Join("testdata", "golden", "dto", typ.Name()+".json")
+
+		if *updateGolden {
+			sample := reflect.New(typ).Elem()
+			fillSample(sample, "")
+			writeGolden(t, path, sample.Interface())
+		}
+
+		golden := readGolden(t, path)
+
+		decoded := reflect.New(typ)
+		decoder := json.NewDecoder(bytes.NewReader(golden))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(decoded.Interface()); err != nil {
+			t.Errorf("%s no longer decodes its golden file: %v", typ.Name(), err)
+			continue
+		}
+
+		encoded, err := json.Marshal(decoded.Interface())
+		if err != nil {
+			t.Errorf("Failed to encode %s: %v", typ.Name(), err)
+			continue
+		}
+		expectSameJSON(t, typ.Name(), golden, encoded)
+	}
+}
+
+// TestGoldenEventWireFormats tests that every registered event deserializes its golden
+// envelope and serializes it back unchanged
+func TestGoldenEventWireFormats(t *testing.T) {
+	serializer := infraEvent.NewEventSerializer()
+	registered := make(map[string]bool)
+
+	for _, schema := range serializer.Schemas() {
+		registered[schema.EventType] = true
+		path := filepath.Join("testdata", "golden", "events", schema.EventType+".json")
+
+		if *updateGolden {
+			writeGolden(t, path, sampleEnvelope(schema))
+		}
+
+		golden := readGolden(t, path)
+
+		evt, err := serializer.Deserialize(golden)
+		if err != nil {
+			t.Errorf("%s no longer deserializes its golden file: %v", schema.EventType, err)
+			continue
+		}
+
+		encoded, err := serializer.Serialize(evt)
+		if err != nil {
+			t.Errorf("Failed to serialize %s: %v", schema.EventType, err)
+			continue
+		}
+		expectSameJSON(t, schema.EventType, golden, encoded)
+	}
+
+	// A golden file without a registered event means an event was renamed or dropped
+	files, _ := filepath.Glob(filepath.Join("testdata", "golden", "events", "*.json"))
+	for _, file := range files {
+		eventType := strings.TrimSuffix(filepath.Base(file), ".json")
+		if !registered[eventType] {
+			t.Errorf("Golden file %s belongs to no registered event", file)
+		}
+	}
+}
+
+// sampleEnvelope builds an event envelope with a sample value for every field of the schema
+func sampleEnvelope(schema dto.EventSchemaDTO) map[string]interface{} {
+	payloadSchema := schema.Schema["properties"].(map[string]interface{})["payload"].(map[string]interface{})
+
+	payload := make(map[string]interface{})
+	for name, property := range payloadSchema["properties"].(map[string]interface{}) {
+		payload[name] = sampleForSchema(property.(map[string]interface{}), name)
+	}
+
+	return map[string]interface{}{
+		"event_type":     schema.EventType,
+		"schema_version": schema.Version,
+		"aggregate_id":   "aggregate-1",
+		"aggregate_type": "Aggregate",
+		"occurred_at":    goldenTime,
+		"payload":        payload,
+	}
+}
+
+// sampleForSchema returns a sample value matching a JSON Schema fragment
+func sampleForSchema(schema map[string]interface{}, name string) interface{} {
+	switch schema["type"] {
+	case "string":
+		if schema["format"] == "date-time" {
+			return goldenTime
+		}
+		return name
+	case "integer":
+		return 7
+	case "number":
+		return 1.5
+	case "boolean":
+		return true
+	case "array":
+		return []interface{}{sampleForSchema(schema["items"].(map[string]interface{}), name)}
+	case "object":
+		return map[string]interface{}{"key": sampleForSchema(schema["additionalProperties"].(map[string]interface{}), name)}
+	default:
+		return nil
+	}
+}
+
+// fillSample sets every field of a value to a non-zero sample, so each one shows up in the golden file
+func fillSample(v reflect.Value, name string) {
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		v.Set(reflect.ValueOf(goldenTime))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(name)
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(7)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(7)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillSample(v.Elem(), name)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillSample(v.Index(0), name)
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		fillSample(key, "key")
+		elem := reflect.New(v.Type().Elem()).Elem()
+		fillSample(elem, name)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, elem)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(name))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.IsExported() {
+				fillSample(v.Field(i), jsonName(field))
+			}
+		}
+	}
+}
+
+// jsonName returns the JSON key of a struct field
+func jsonName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// writeGolden writes a value as indented JSON to a golden file
+func writeGolden(t *testing.T, path string, v interface{}) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to encode %s: %v", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+// readGolden reads a golden file, failing when it is missing
+func readGolden(t *testing.T, path string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Missing golden file %s, run with -update: %v", path, err)
+	}
+	return data
+}
+
+// expectSameJSON fails when two JSON documents differ, reporting the keys that do
+func expectSameJSON(t *testing.T, name string, golden, actual []byte) {
+	t.Helper()
+
+	var want, got interface{}
+	json.Unmarshal(golden, &want)
+	json.Unmarshal(actual, &got)
+	if reflect.DeepEqual(want, got) {
+		return
+	}
+
+	wantMap, _ := want.(map[string]interface{})
+	gotMap, _ := got.(map[string]interface{})
+	differing := make([]string, 0)
+	for key := range wantMap {
+		if !reflect.DeepEqual(wantMap[key], gotMap[key]) {
+			differing = append(differing, key)
+		}
+	}
+	for key := range gotMap {
+		if _, exists := wantMap[key]; !exists {
+			differing = append(differing, key)
+		}
+	}
+	sort.Strings(differing)
+
+	t.Errorf("%s wire format changed in %v, run with -update if intended:\nwant %s\ngot  %s", name, differing, golden, actual)
+}
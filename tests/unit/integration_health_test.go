@@ -0,0 +1,53 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/infrastructure/integration"
+)
+
+// TestBreakerOpensAndRecovers tests that a failing integration is rested and retried after the cooldown
+func TestBreakerOpensAndRecovers(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	monitor := integration.NewMonitor(2, time.Minute, time.Hour)
+	monitor.SetClock(func() time.Time { return now })
+	breaker := monitor.Breaker("notifications.webhook", integration.KindWebhook)
+
+	calls := 0
+	failing := func() error { calls++; return errors.New("connection refused") }
+	breaker.Call(failing)
+	breaker.Call(failing)
+
+	if err := breaker.Call(failing); err == nil || calls != 2 {
+		t.Fatalf("Expected the open breaker to refuse the third call, got %v after %d calls", err, calls)
+	}
+
+	health := monitor.Report().Integrations[0]
+	if health.State != string(integration.CircuitOpen) || health.RecentFailures != 2 || health.Rejected != 1 ||
+		health.NextRetryAt == nil || !health.NextRetryAt.Equal(now.Add(time.Minute)) || health.LastError != "connection refused" {
+		t.Errorf("Expected an open breaker retrying in a minute, got %+v", health)
+	}
+
+	// After the cooldown one trial call goes through; its failure reopens the breaker
+	now = now.Add(2 * time.Minute)
+	breaker.Call(failing)
+	if health := monitor.Report().Integrations[0]; calls != 3 || health.State != string(integration.CircuitOpen) {
+		t.Errorf("Expected a failed trial call to reopen the breaker, got %d calls and %s", calls, health.State)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if err := breaker.Call(func() error { return nil }); err != nil {
+		t.Fatalf("Expected the trial call to go through, got %v", err)
+	}
+	health = monitor.Report().Integrations[0]
+	if health.State != string(integration.CircuitClosed) || !health.Healthy || health.LastSuccessAt == nil || health.RecentFailures != 3 {
+		t.Errorf("Expected a healthy closed breaker that still counts recent failures, got %+v", health)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if health := monitor.Report().Integrations[0]; health.RecentFailures != 0 {
+		t.Errorf("Expected failures outside the window forgotten, got %d", health.RecentFailures)
+	}
+}
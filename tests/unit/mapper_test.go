@@ -0,0 +1,67 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/mapper"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/value"
+)
+
+// TestTaskToDTOCarriesAssignmentCommentsAndDeadline tests that the task mapper fills in more than the task's own fields
+func TestTaskToDTOCarriesAssignmentCommentsAndDeadline(t *testing.T) {
+	priority, _ := value.NewPriority("HIGH")
+	creatorID := value.GenerateUserID()
+	assigneeID := value.GenerateUserID()
+
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Ship it", "", priority, creatorID)
+	task.Assign(assigneeID, creatorID)
+	comment, _ := entity.NewComment(task.ID(), assigneeID, "On it")
+	task.AddComment(comment)
+	dueDate := time.Now().Add(48 * time.Hour)
+	deadline, _ := value.NewDeadline(dueDate)
+	task.SetDeadline(deadline, creatorID, "")
+
+	taskDTO := mapper.TaskToDTO(task)
+	if taskDTO.Status != task.Status().Value() || taskDTO.Priority != "HIGH" || taskDTO.CreatedBy != creatorID.Value() {
+		t.Errorf("Expected the task's own fields, got %+v", taskDTO)
+	}
+	if taskDTO.Assignee == nil || taskDTO.Assignee.AssigneeID != assigneeID.Value() {
+		t.Errorf("Expected the assignment, got %+v", taskDTO.Assignee)
+	}
+	if len(taskDTO.Comments) != 1 || taskDTO.Comments[0].Content != "On it" {
+		t.Errorf("Expected the comment, got %+v", taskDTO.Comments)
+	}
+	if taskDTO.Deadline == nil || taskDTO.Deadline.IsOverdue {
+		t.Fatalf("Expected a deadline that is not overdue, got %+v", taskDTO.Deadline)
+	}
+
+	value.SetClock(func() time.Time { return dueDate.AddDate(0, 0, 3) })
+	defer value.SetClock(nil)
+
+	if overdue := mapper.TaskToDTO(task).Deadline; !overdue.IsOverdue || overdue.DaysUntil >= 0 {
+		t.Errorf("Expected the deadline to be overdue, got %+v", overdue)
+	}
+}
+
+// TestWorkflowToDTOListsStatuses tests that the workflow mapper lists statuses in order with their limits
+func TestWorkflowToDTOListsStatuses(t *testing.T) {
+	workflow, _ := aggregate.NewWorkflow(value.GenerateWorkflowID(), "Kanban", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("TO_DO", "", 0, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "", 1, false).WithWIPLimit(3),
+		aggregate.NewWorkflowStatus("DONE", "", 2, true),
+	})
+
+	workflowDTO := mapper.WorkflowToDTO(workflow)
+	if len(workflowDTO.Statuses) != 3 || workflowDTO.Version != 1 {
+		t.Fatalf("Expected three statuses of version 1, got %+v", workflowDTO)
+	}
+	if status := workflowDTO.Statuses[1]; status.Name != "IN_PROGRESS" || status.WIPLimit != 3 {
+		t.Errorf("Expected the WIP limit of IN_PROGRESS, got %+v", status)
+	}
+	if !workflowDTO.Statuses[2].IsFinal || workflowDTO.Transitions != nil {
+		t.Errorf("Expected a final DONE status and no transition matrix, got %+v", workflowDTO)
+	}
+}
@@ -0,0 +1,156 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/miladev95/ddd-task/domain/event"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+	"github.com/miladev95/ddd-task/infrastructure/messaging"
+)
+
+// fakeAMQPChannel records what is declared and published, confirming publishes with acks in turn
+type fakeAMQPChannel struct {
+	acks       []bool
+	published  []amqp.Publishing
+	keys       []string
+	queueArgs  map[string]amqp.Table
+	confirms   chan amqp.Confirmation
+	deliveries chan amqp.Delivery
+}
+
+func newFakeAMQPChannel(acks ...bool) *fakeAMQPChannel {
+	return &fakeAMQPChannel{acks: acks, queueArgs: make(map[string]amqp.Table), deliveries: make(chan amqp.Delivery, 4)}
+}
+
+func (c *fakeAMQPChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (c *fakeAMQPChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	c.queueArgs[name] = args
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c *fakeAMQPChannel) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (c *fakeAMQPChannel) Confirm(noWait bool) error {
+	return nil
+}
+
+func (c *fakeAMQPChannel) NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation {
+	c.confirms = make(chan amqp.Confirmation, len(c.acks))
+	return c.confirms
+}
+
+func (c *fakeAMQPChannel) PublishWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
+	c.published = append(c.published, msg)
+	c.keys = append(c.keys, exchange+" "+key)
+	if len(c.published) <= len(c.acks) {
+		c.confirms <- amqp.Confirmation{DeliveryTag: uint64(len(c.published)), Ack: c.acks[len(c.published)-1]}
+	}
+	return nil
+}
+
+func (c *fakeAMQPChannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	return c.deliveries, nil
+}
+
+// recordingAcknowledger remembers how deliveries were settled
+type recordingAcknowledger struct {
+	settled []string
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.settled = append(a.settled, "ack")
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	if requeue {
+		a.settled = append(a.settled, "requeue")
+	} else {
+		a.settled = append(a.settled, "dead-letter")
+	}
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// TestAMQPPublisherRoutesAndWaitsForConfirms tests routing keys, overrides and broker rejections
+func TestAMQPPublisherRoutesAndWaitsForConfirms(t *testing.T) {
+	ctx := context.Background()
+	channel := newFakeAMQPChannel(true, true, false)
+	publisher, err := messaging.NewAMQPPublisher(channel, infraEvent.NewEventSerializer(), messaging.DefaultAMQPTopology())
+	if err != nil {
+		t.Fatalf("Failed to create publisher: %v", err)
+	}
+	publisher.SetRoute("TaskCompleted", messaging.AMQPRoute{RoutingKey: "task.done"})
+
+	events := []event.DomainEvent{
+		event.NewTaskCreatedEvent("task-1", "project-1", "Title", "", "", "HIGH"),
+		event.NewTaskCompletedEvent("task-1", "user-1", time.Now().Format(time.RFC3339)),
+	}
+	if err := publisher.PublishAll(ctx, events); err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+
+	if strings.Join(channel.keys, ",") != "task.events task.TaskCreated,task.events task.done" {
+		t.Errorf("Expected default and overridden routes, got %v", channel.keys)
+	}
+	if channel.published[0].DeliveryMode != amqp.Persistent || channel.published[0].Type != "TaskCreated" ||
+		!strings.Contains(string(channel.published[0].Body), `"event_type":"TaskCreated"`) {
+		t.Errorf("Expected a persistent JSON envelope, got %+v", channel.published[0])
+	}
+
+	if err := publisher.Publish(ctx, events[0]); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("Expected a nack to fail the publish, got %v", err)
+	}
+
+	publisher.SetConfirmTimeout(10 * time.Millisecond)
+	if err := publisher.Publish(ctx, events[0]); err == nil || !strings.Contains(err.Error(), "did not confirm") {
+		t.Errorf("Expected a missing confirm to time out, got %v", err)
+	}
+}
+
+// TestAMQPConsumerDeadLettersFailedEvents tests that handler failures are rejected without requeueing
+func TestAMQPConsumerDeadLettersFailedEvents(t *testing.T) {
+	channel := newFakeAMQPChannel()
+	topology := messaging.DefaultAMQPTopology()
+	if err := topology.DeclareQueue(channel, "notifications", []string{"task.*"}); err != nil {
+		t.Fatalf("Failed to declare queue: %v", err)
+	}
+	if channel.queueArgs["notifications"]["x-dead-letter-exchange"] != topology.DeadLetterExchange {
+		t.Errorf("Expected the queue to dead-letter to %s, got %v", topology.DeadLetterExchange, channel.queueArgs["notifications"])
+	}
+
+	serializer := infraEvent.NewEventSerializer()
+	acknowledger := &recordingAcknowledger{}
+	for _, title := range []string{"Works", "Fails"} {
+		body, _ := serializer.Serialize(event.NewTaskCreatedEvent("task-"+title, "project-1", title, "", "", "LOW"))
+		channel.deliveries <- amqp.Delivery{Acknowledger: acknowledger, Body: body}
+	}
+	channel.deliveries <- amqp.Delivery{Acknowledger: acknowledger, Body: []byte("not an envelope")}
+	close(channel.deliveries)
+
+	consumer := messaging.NewAMQPConsumer(channel, serializer)
+	consumer.Consume(context.Background(), "notifications", "test", func(evt event.DomainEvent) error {
+		if evt.(event.TaskCreatedEvent).Title == "Fails" {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+
+	if strings.Join(acknowledger.settled, ",") != "ack,dead-letter,dead-letter" {
+		t.Errorf("Expected processed events acked and failures dead-lettered, got %v", acknowledger.settled)
+	}
+}
@@ -0,0 +1,313 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/dto"
+	"github.com/miladev95/ddd-task/domain/errs"
+	httpServer "github.com/miladev95/ddd-task/interface/http"
+	"github.com/miladev95/ddd-task/interface/http/middleware"
+	"github.com/miladev95/ddd-task/shared/di"
+)
+
+// TestChainRunsMiddlewaresOutermostFirst tests middleware ordering
+func TestChainRunsMiddlewaresOutermostFirst(t *testing.T) {
+	var order []string
+	tag := func(name string) middleware.Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	handler := middleware.Chain(tag("outer"), tag("inner"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if strings.Join(order, ",") != "outer,inner,handler" {
+		t.Errorf("Expected outer,inner,handler, got %v", order)
+	}
+}
+
+// TestRecoveryAnswersPanicsWithInternalServerError tests that a panicking handler yields a 500
+func TestRecoveryAnswersPanicsWithInternalServerError(t *testing.T) {
+	var logs bytes.Buffer
+	handler := middleware.Recovery(log.New(&logs, "", 0))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/tasks", nil))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", recorder.Code)
+	}
+	if !strings.Contains(logs.String(), "boom") {
+		t.Errorf("Expected the panic to be logged, got %q", logs.String())
+	}
+}
+
+// TestCORSAnswersPreflightForAllowedOrigins tests preflight handling
+func TestCORSAnswersPreflightForAllowedOrigins(t *testing.T) {
+	reached := false
+	handler := middleware.CORS([]string{"https://app.example.com"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/api/tasks", nil)
+	preflight.Header.Set("Origin", "https://app.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, preflight)
+
+	if recorder.Code != http.StatusNoContent || reached {
+		t.Errorf("Expected preflight to be answered by the middleware, got %d reached=%v", recorder.Code, reached)
+	}
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("Expected origin to be allowed, got %q", recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	other := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+	other.Header.Set("Origin", "https://evil.example.com")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, other)
+
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("Expected unknown origin not to be allowed")
+	}
+}
+
+// TestRouterAppliesGlobalMiddlewareAndReportsAllowedMethods tests Use and the 405 Allow header
+func TestRouterAppliesGlobalMiddlewareAndReportsAllowedMethods(t *testing.T) {
+	router := httpServer.NewRouter(di.NewContainer())
+	router.SetupRoutes()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Wrapped", "yes")
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	recorder := httptest.NewRecorder()
+	router.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/api/projects/budget", nil))
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected 405, got %d", recorder.Code)
+	}
+	if recorder.Header().Get("Allow") != "GET, PUT" {
+		t.Errorf("Expected Allow: GET, PUT, got %q", recorder.Header().Get("Allow"))
+	}
+	if recorder.Header().Get("X-Wrapped") != "yes" {
+		t.Error("Expected global middleware to wrap the route")
+	}
+}
+
+// TestTimeoutAnswersSlowHandlersWithGatewayTimeout tests the 504 body and the cancelled context
+func TestTimeoutAnswersSlowHandlersWithGatewayTimeout(t *testing.T) {
+	cancelled := make(chan bool, 1)
+	slow := middleware.Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		cancelled <- true
+		w.Write([]byte("too late"))
+	}))
+
+	recorder := httptest.NewRecorder()
+	slow.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/tasks", nil))
+
+	var body middleware.Problem
+	json.NewDecoder(recorder.Body).Decode(&body)
+	if recorder.Code != http.StatusGatewayTimeout || body.Type != middleware.ProblemTimeout.URI {
+		t.Errorf("Expected a 504 error body, got %d %+v", recorder.Code, body)
+	}
+	if !<-cancelled {
+		t.Error("Expected the handler context to be cancelled")
+	}
+
+	fast := middleware.Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Handled", "yes")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("done"))
+	}))
+
+	recorder = httptest.NewRecorder()
+	fast.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/tasks", nil))
+	if recorder.Code != http.StatusCreated || recorder.Body.String() != "done" || recorder.Header().Get("X-Handled") != "yes" {
+		t.Errorf("Expected the handler response to pass through, got %d %q", recorder.Code, recorder.Body.String())
+	}
+}
+
+// TestTimeoutStreamsFlushedResponses tests that a flushed response passes through and is cut off at the deadline
+func TestTimeoutStreamsFlushedResponses(t *testing.T) {
+	streaming := middleware.Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte("first\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+		w.Write([]byte("too late\n"))
+	}))
+
+	recorder := httptest.NewRecorder()
+	streaming.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/admin/backup", nil))
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "first\n" || !recorder.Flushed {
+		t.Errorf("Expected the streamed part and no 504, got %d %q", recorder.Code, recorder.Body.String())
+	}
+	if recorder.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("Expected the handler headers sent with the stream, got %v", recorder.Header())
+	}
+}
+
+// TestErrorHandlerReportsStableProblemTypes tests that wrapped domain errors map to problem details
+func TestErrorHandlerReportsStableProblemTypes(t *testing.T) {
+	errorHandler := middleware.NewErrorHandler()
+
+	cases := []struct {
+		err      error
+		expected middleware.ProblemType
+		status   int
+	}{
+		{fmt.Errorf("task not found: %w", errs.NotFound("task not found")), middleware.ProblemNotFound, http.StatusNotFound},
+		{fmt.Errorf("failed to update status: %w", errs.InvalidTransition("invalid status transition from TO_DO to DONE")), middleware.ProblemInvalidTransition, http.StatusBadRequest},
+		{fmt.Errorf("failed to update description: %w", errs.ConcurrencyConflict("description is locked by another user")), middleware.ProblemConcurrencyConflict, http.StatusConflict},
+		{fmt.Errorf("invalid task id: %w", errs.Invalid("task id cannot be empty")), middleware.ProblemValidation, http.StatusBadRequest},
+		{errs.PermissionDenied("permission denied: only project admins can delete tasks"), middleware.ProblemForbidden, http.StatusForbidden},
+		{errs.PermissionDenied("widget belongs to another user"), middleware.ProblemForbidden, http.StatusForbidden},
+		{fmt.Errorf("failed to create task: %w", errs.Invalid("task title cannot be empty")), middleware.ProblemValidation, http.StatusBadRequest},
+		{fmt.Errorf("failed to set SLO targets: %w", errs.Conflict("cannot change SLO targets of an archived project")), middleware.ProblemConflict, http.StatusConflict},
+		{errs.QuotaExceeded("quota exceeded: organization o-1 is limited to 3 projects"), middleware.ProblemQuotaExceeded, http.StatusForbidden},
+		{errs.Unauthenticated("invalid email or password"), middleware.ProblemUnauthorized, http.StatusUnauthorized},
+		{fmt.Errorf("failed to list tasks: %w", context.DeadlineExceeded), middleware.ProblemTimeout, http.StatusGatewayTimeout},
+		{errs.Invalid("invalid due date format: %w", errors.New("parsing time")), middleware.ProblemValidation, http.StatusBadRequest},
+		{errors.New("disk on fire"), middleware.ProblemInternal, http.StatusInternalServerError},
+		{errors.New("invalid state read from disk"), middleware.ProblemInternal, http.StatusInternalServerError},
+		{fmt.Errorf("project not found: %w", errors.New("database is locked")), middleware.ProblemInternal, http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		problem := errorHandler.HandleError(tc.err)
+		if problem.Type != tc.expected.URI || problem.Title != tc.expected.Title || problem.Status != tc.status {
+			t.Errorf("%v: expected %s %d, got %+v", tc.err, tc.expected.URI, tc.status, problem)
+		}
+	}
+
+	if problem := errorHandler.HandleError(errors.New("disk on fire")); strings.Contains(problem.Detail, "disk") {
+		t.Errorf("Expected an internal error not to reach the client, got %q", problem.Detail)
+	}
+
+	recorder := httptest.NewRecorder()
+	middleware.WriteProblem(recorder, errorHandler.HandleError(errs.NotFound("project not found")))
+
+	var body map[string]interface{}
+	json.NewDecoder(recorder.Body).Decode(&body)
+	if recorder.Code != http.StatusNotFound || recorder.Header().Get("Content-Type") != middleware.ProblemContentType {
+		t.Errorf("Expected a 404 problem+json response, got %d %s", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if body["type"] != middleware.ProblemNotFound.URI || body["status"] != float64(http.StatusNotFound) {
+		t.Errorf("Expected RFC 7807 members, got %v", body)
+	}
+}
+
+// TestShapeResponsesNegotiatesProfiles tests enveloped and camelCase bodies next to the legacy ones
+func TestShapeResponsesNegotiatesProfiles(t *testing.T) {
+	handler := middleware.ShapeResponses(map[string]middleware.ResponseProfile{"2": middleware.ProfileEnvelope})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/missing" {
+				middleware.WriteProblem(w, middleware.StatusProblem(http.StatusNotFound, "task not found"))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"task_id": "task-1",
+				"links":   []interface{}{map[string]interface{}{"linked_task_id": "task-2"}},
+			})
+		}))
+
+	serve := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	legacy := serve("/", nil)
+	if !strings.Contains(legacy.Body.String(), `"task_id":"task-1"`) || legacy.Header().Get(middleware.ResponseProfileHeader) != "legacy" {
+		t.Errorf("Expected the legacy body untouched, got %s", legacy.Body.String())
+	}
+
+	camel := serve("/", map[string]string{"Accept": "application/json; profile=camel"})
+	if camel.Code != http.StatusCreated || !strings.Contains(camel.Body.String(), `"linkedTaskId":"task-2"`) ||
+		!strings.Contains(camel.Body.String(), `"taskId":"task-1"`) {
+		t.Errorf("Expected nested camelCase keys with the original status, got %d %s", camel.Code, camel.Body.String())
+	}
+
+	var enveloped map[string]map[string]interface{}
+	json.NewDecoder(serve("/", map[string]string{middleware.APIVersionHeader: "2"}).Body).Decode(&enveloped)
+	if enveloped["data"]["task_id"] != "task-1" || enveloped["meta"]["api_version"] != "2" {
+		t.Errorf("Expected a data/meta envelope for API version 2, got %v", enveloped)
+	}
+
+	problem := serve("/missing", map[string]string{middleware.APIVersionHeader: "2"})
+	if !strings.Contains(problem.Body.String(), middleware.ProblemNotFound.URI) || strings.Contains(problem.Body.String(), `"data"`) {
+		t.Errorf("Expected problem details never to be enveloped, got %s", problem.Body.String())
+	}
+
+	if unknown := serve("/", map[string]string{"Accept": "application/json; profile=xml"}); unknown.Code != http.StatusNotAcceptable {
+		t.Errorf("Expected 406 for an unknown profile, got %d", unknown.Code)
+	}
+
+	if _, err := middleware.ParseVersionProfiles("2=envelope,3"); err == nil {
+		t.Error("Expected a pair without a profile to be rejected")
+	}
+}
+
+// TestDecodeRequestReportsFieldErrors tests the field-level errors of the request validation stage
+func TestDecodeRequestReportsFieldErrors(t *testing.T) {
+	decode := func(body string, v interface{}) []middleware.FieldError {
+		return middleware.DecodeRequest(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), v)
+	}
+
+	var access dto.SetProjectAccessRequest
+	if fieldErrors := decode(`{"visibility":"PUBLIC"}`, &access); len(fieldErrors) != 1 ||
+		fieldErrors[0] != (middleware.FieldError{Field: "visibility", Error: "must be one of WORKSPACE, RESTRICTED"}) {
+		t.Errorf("Expected the visibility to break its oneof rule, got %+v", fieldErrors)
+	}
+	if fieldErrors := decode(`{"visibility":"RESTRICTED","member_ids":["u-1"]}`, &access); fieldErrors != nil {
+		t.Errorf("Expected a valid request to pass, got %+v", fieldErrors)
+	}
+
+	var rules dto.TransitionRulesInput
+	fieldErrors := decode(`{"from":"TO_DO","to":" ","guards":[{"kind":"REQUIRES_DEADLINE"},{"kind":"REQUIRES_LUCK"}]}`, &rules)
+	if len(fieldErrors) != 2 || fieldErrors[0].Field != "to" || fieldErrors[1].Field != "guards[1].kind" {
+		t.Errorf("Expected the nested fields to be reported by path, got %+v", fieldErrors)
+	}
+
+	var task dto.CreateTaskRequest
+	if fieldErrors := decode(`{"project_id":"p-1","title":"Ship it","priority":3}`, &task); len(fieldErrors) != 1 ||
+		fieldErrors[0] != (middleware.FieldError{Field: "priority", Error: "must be a string"}) {
+		t.Errorf("Expected the priority type to be reported, got %+v", fieldErrors)
+	}
+	if fieldErrors := decode(`{"title":`, &task); len(fieldErrors) != 1 || fieldErrors[0].Field != "body" {
+		t.Errorf("Expected a malformed body to be reported, got %+v", fieldErrors)
+	}
+
+	problem := middleware.ValidationProblem([]middleware.FieldError{{Field: "title", Error: "is required"}})
+	if problem.Type != middleware.ProblemValidation.URI || problem.Status != http.StatusBadRequest || problem.Detail != "title: is required" {
+		t.Errorf("Expected a validation problem listing the field, got %+v", problem)
+	}
+}
@@ -0,0 +1,50 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/presence"
+)
+
+// TestPresenceTrackerExpiresAndNotifies tests viewer de-duplication, moves and expiry
+func TestPresenceTrackerExpiresAndNotifies(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	tracker := presence.NewTracker(30 * time.Second)
+	tracker.SetClock(func() time.Time { return now })
+
+	task := presence.Resource{Kind: presence.KindTask, ID: "task-1"}
+	board := presence.Resource{Kind: presence.KindBoard, ID: "project-1"}
+
+	updates := make(map[presence.Resource]int)
+	tracker.Subscribe(func(resource presence.Resource, viewers []presence.Viewer) {
+		updates[resource] = len(viewers)
+	})
+
+	tracker.View("alice-tab-1", "alice", task)
+	tracker.View("alice-tab-2", "alice", task)
+	tracker.View("bob-tab", "bob", task)
+
+	if viewers := tracker.Viewers(task); len(viewers) != 2 || viewers[0].UserID != "alice" {
+		t.Fatalf("Expected alice then bob viewing the task once each, got %+v", viewers)
+	}
+
+	tracker.View("bob-tab", "bob", board)
+	if updates[task] != 1 || updates[board] != 1 {
+		t.Errorf("Expected moving bob to update both resources, got %v", updates)
+	}
+
+	now = now.Add(20 * time.Second)
+	tracker.Touch("alice-tab-1")
+	now = now.Add(20 * time.Second)
+
+	if removed := tracker.Sweep(); removed != 2 {
+		t.Errorf("Expected 2 resources to change on sweep, got %d", removed)
+	}
+	if viewers := tracker.Viewers(task); len(viewers) != 1 || viewers[0].UserID != "alice" {
+		t.Errorf("Expected only the refreshed alice session to remain, got %+v", viewers)
+	}
+	if updates[board] != 0 {
+		t.Errorf("Expected board viewers to drop to 0, got %d", updates[board])
+	}
+}
@@ -0,0 +1,745 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain"
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/errs"
+	"github.com/miladev95/ddd-task/domain/event"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/auth"
+	infraEvent "github.com/miladev95/ddd-task/infrastructure/event"
+	"github.com/miladev95/ddd-task/infrastructure/repository"
+)
+
+// TestTaskArchivalSpillsAndRestoresFinishedTasks tests the memory cap of the in-memory task repository
+func TestTaskArchivalSpillsAndRestoresFinishedTasks(t *testing.T) {
+	ctx := context.Background()
+	spill, err := repository.OpenTaskSpillFile(filepath.Join(t.TempDir(), "spill.ndjson"))
+	if err != nil {
+		t.Fatalf("Failed to open spill file: %v", err)
+	}
+	defer spill.Close()
+
+	repo := repository.NewInMemoryTaskRepository()
+	repo.EnableArchival(2, spill)
+
+	priority, _ := value.NewPriority("HIGH")
+	userID := value.GenerateUserID()
+	projectID := value.GenerateProjectID()
+
+	finished := make([]*aggregate.Task, 0, 3)
+	for i := 0; i < 3; i++ {
+		task, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Done", "", priority, userID)
+		task.Assign(userID, userID)
+		task.ChangeStatus(value.TaskStatusInProgress)
+		task.ChangeStatus(value.TaskStatusInReview)
+		task.ChangeStatus(value.TaskStatusCompleted)
+		comment, _ := entity.NewComment(task.ID(), userID, "Shipped")
+		task.AddComment(comment)
+		repo.Save(ctx, task)
+		finished = append(finished, task)
+	}
+	open, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Open", "", priority, userID)
+	repo.Save(ctx, open)
+
+	// Touch the first task so the other two are the least recently used
+	repo.GetByID(ctx, finished[0].ID())
+
+	archived, err := repo.Compact()
+	if err != nil {
+		t.Fatalf("Failed to compact: %v", err)
+	}
+	if archived != 2 || repo.ArchivedCount() != 2 || spill.Len() != 2 {
+		t.Fatalf("Expected 2 tasks archived, got %d (%d in spill file)", archived, spill.Len())
+	}
+
+	restored, err := repo.GetByID(ctx, finished[1].ID())
+	if err != nil {
+		t.Fatalf("Expected archived task to be restored, got %v", err)
+	}
+	if restored == finished[1] {
+		t.Fatal("Expected a task rebuilt from the spill file")
+	}
+	if restored.Status() != value.TaskStatusCompleted || len(restored.Comments()) != 1 ||
+		!restored.Assignee().IsAssignedTo(userID) || restored.CompletedAt() == nil {
+		t.Errorf("Expected restored task to keep its state, got %+v", restored.ToState())
+	}
+	if repo.ArchivedCount() != 1 {
+		t.Errorf("Expected 1 task left in the spill file, got %d", repo.ArchivedCount())
+	}
+
+	// Scans restore the archived tasks they match
+	tasks, _ := repo.GetByProjectID(ctx, projectID)
+	if len(tasks) != 4 || repo.ArchivedCount() != 0 {
+		t.Errorf("Expected all 4 project tasks in memory, got %d with %d archived", len(tasks), repo.ArchivedCount())
+	}
+}
+
+// TestAggregateStatesRoundTrip tests that every aggregate survives being stored as its state and rebuilt
+func TestAggregateStatesRoundTrip(t *testing.T) {
+	userID := value.GenerateUserID()
+	otherID := value.GenerateUserID()
+	priority, _ := value.NewPriority("HIGH")
+
+	user, _ := aggregate.NewUser(userID, "ada@example.com", "Ada", "Lovelace")
+	hash, _ := auth.NewPasswordHasher().Hash("correct horse battery")
+	user.SetPassword(hash)
+	user.GrantRole(value.GlobalRoleAdmin)
+	user.SetPreference("theme", "dark")
+	user.VerifyEmail()
+	hours, _ := value.ParseWorkingHours(6, []string{"MONDAY", "TUESDAY", "THURSDAY"})
+	user.SetWorkingHours(&hours)
+	away, _ := value.NewOutOfOffice(time.Date(2030, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 8, 15, 0, 0, 0, 0, time.UTC), "ask Grace")
+	user.SetOutOfOffice([]value.OutOfOffice{away})
+	user.SetManager(&otherID)
+	roundTripState(t, "user", user.ToState, aggregate.UserFromState, (*aggregate.User).ToState)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Apollo", "Moon", userID, value.DefaultWorkflowID)
+	taskID := value.GenerateTaskID()
+	project.AddTask(taskID)
+	project.RecordProgress(1)
+	project.AssignRole(otherID, value.ProjectRoleMember)
+	budget, _ := value.NewMoney(500000, "EUR")
+	project.SetBudget(&budget)
+	project.AddMilestone("Launch", "", time.Now().Add(24*time.Hour), []value.TaskID{taskID})
+	route, _ := value.NewNotificationRoute("TaskCompleted", value.NotificationChannelSlack, "#apollo", value.NotificationDeliveryImmediate)
+	project.SetNotificationRoutes([]value.NotificationRoute{route})
+	calendarID := value.GenerateHolidayCalendarID()
+	project.SetHolidayCalendar(&calendarID)
+	inbox, _ := value.GenerateInboxAddress("support list")
+	project.AddInboxAddress(inbox)
+	p1, _ := value.NewPriorityLevel("P1", 10)
+	p2, _ := value.NewPriorityLevel("p2", 5)
+	scheme, _ := value.NewPriorityScheme([]value.PriorityLevel{p1, p2})
+	project.SetPriorityScheme(scheme, value.Priority("P2"))
+	fromVersion, _ := value.NewWorkflowVersion(value.DefaultWorkflowID, 1)
+	toVersion, _ := value.NewWorkflowVersion(value.GenerateWorkflowID(), 2)
+	migration, _ := entity.NewWorkflowMigration(value.DefaultWorkflowID, toVersion, []entity.WorkflowMigrationStep{
+		{TaskID: taskID, FromVersion: fromVersion, FromStatus: value.DefaultScopedStatus(value.TaskStatusToDo),
+			ToStatus: mustScopedStatus(t, toVersion.WorkflowID(), "Doing", value.TaskStatusInProgress)},
+		{TaskID: value.GenerateTaskID(), FromVersion: fromVersion, FromStatus: value.DefaultScopedStatus(value.TaskStatusToDo),
+			ToStatus: value.DefaultScopedStatus(value.TaskStatusToDo)},
+	}, userID)
+	project.PlanWorkflowMigration(migration)
+	project.RecordWorkflowMigrationProgress(1)
+	roundTripState(t, "project", project.ToState, aggregate.ProjectFromState, (*aggregate.Project).ToState)
+
+	task, _ := aggregate.NewTask(taskID, project.ID(), "Land", "", priority, userID)
+	task.Assign(otherID, userID)
+	task.EscalateUnacknowledgedAssignment(time.Now(), 0)
+	task.AcknowledgeAssignment(otherID, aggregate.AcknowledgementExplicit)
+	task.Approve(userID)
+	task.Reject(value.GenerateUserID(), "needs a landing site")
+	roundTripState(t, "task", task.ToState, aggregate.TaskFromState, (*aggregate.Task).ToState)
+
+	sprint, _ := aggregate.NewSprint(value.GenerateSprintID(), project.ID(), "Sprint 1", "Lift off", time.Now(), time.Now().Add(14*24*time.Hour))
+	sprint.AddTask(taskID)
+	roundTripState(t, "sprint", sprint.ToState, aggregate.SprintFromState, (*aggregate.Sprint).ToState)
+
+	team, _ := aggregate.NewTeam(value.GenerateTeamID(), "Flight", userID, []value.UserID{otherID})
+	roundTripState(t, "team", team.ToState, aggregate.TeamFromState, (*aggregate.Team).ToState)
+
+	organization, _ := aggregate.NewOrganization(value.GenerateOrganizationID(), "NASA", []value.UserID{userID})
+	defaultHours := value.DefaultWorkingHours()
+	organization.SetDefaultWorkingHours(&defaultHours)
+	roundTripState(t, "organization", organization.ToState, aggregate.OrganizationFromState, (*aggregate.Organization).ToState)
+
+	newYear, _ := value.NewAnnualHoliday("New Year's Day", time.January, 1)
+	laborDay, _ := value.NewNthWeekdayHoliday("Labor Day", time.September, time.Monday, 1)
+	closure, _ := value.NewDateHoliday("Office move", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC))
+	calendar, _ := aggregate.NewHolidayCalendar(calendarID, organization.ID(), "US", "United States", []value.Holiday{newYear, laborDay, closure})
+	roundTripState(t, "holiday calendar", calendar.ToState, aggregate.HolidayCalendarFromState, (*aggregate.HolidayCalendar).ToState)
+
+	layout, _ := value.NewWidgetLayout(0, 0, 2, 1)
+	widget, _ := aggregate.NewWidget(value.GenerateWidgetID(), userID, "Mine", value.WidgetTypeTaskList, map[string]string{"project_id": project.ID().Value()}, layout)
+	roundTripState(t, "widget", widget.ToState, aggregate.WidgetFromState, (*aggregate.Widget).ToState)
+
+	workflow := aggregate.NewDefaultWorkflow()
+	restored := roundTripState(t, "default workflow", workflow.ToState, aggregate.WorkflowFromState, (*aggregate.Workflow).ToState)
+	if restored.ToState().Transitions != nil {
+		t.Error("Expected a workflow following the regular rules to keep following them")
+	}
+
+	guard, _ := value.NewTransitionGuard("REQUIRES_APPROVALS", 2)
+	assign, _ := value.NewAutoAssignAction(otherID)
+	workflow.SetTransitionRules("IN_REVIEW", "COMPLETED", aggregate.TransitionRules{
+		Guards:  []value.TransitionGuard{guard},
+		Actions: []value.TransitionAction{assign},
+	})
+	workflow.DisallowTransition("TODO", "CANCELLED")
+	step, _ := value.NewApprovalStep(1, []value.UserID{userID, otherID})
+	workflow.SetApprovalStep("IN_REVIEW", &step)
+	roundTripState(t, "custom workflow", workflow.ToState, aggregate.WorkflowFromState, (*aggregate.Workflow).ToState)
+}
+
+// roundTripState stores an aggregate's state as JSON, rebuilds the aggregate from it and
+// checks that the rebuilt aggregate has the same state
+func roundTripState[S any, A any](t *testing.T, name string, toState func() S, fromState func(S) (A, error), restate func(A) S) A {
+	t.Helper()
+
+	stored, err := json.Marshal(toState())
+	if err != nil {
+		t.Fatalf("Failed to store %s state: %v", name, err)
+	}
+
+	var state S
+	if err := json.Unmarshal(stored, &state); err != nil {
+		t.Fatalf("Failed to load %s state: %v", name, err)
+	}
+
+	restored, err := fromState(state)
+	if err != nil {
+		t.Fatalf("Failed to rebuild %s: %v", name, err)
+	}
+
+	again, _ := json.Marshal(restate(restored))
+	if !bytes.Equal(stored, again) {
+		t.Errorf("Expected %s to keep its state\n got %s\nwant %s", name, again, stored)
+	}
+
+	// Rebuilding from storage must not raise the events of the history it restores
+	if evented, ok := any(restored).(interface{ DomainEvents() []event.DomainEvent }); ok && len(evented.DomainEvents()) > 0 {
+		t.Errorf("Expected rebuilding %s to raise no domain events, got %d", name, len(evented.DomainEvents()))
+	}
+	return restored
+}
+
+// TestOverdueTaskSurvivesRoundTrip tests that a deadline passed since it was set is restored as is
+func TestOverdueTaskSurvivesRoundTrip(t *testing.T) {
+	userID := value.GenerateUserID()
+	priority, _ := value.NewPriority("MEDIUM")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Late", "", priority, userID)
+
+	dueDate := time.Now().Add(time.Hour).Truncate(time.Second)
+	deadline, err := value.NewDeadline(dueDate)
+	if err != nil {
+		t.Fatalf("Failed to create deadline: %v", err)
+	}
+	task.SetDeadline(deadline, userID, "")
+
+	// A week later the deadline has passed and NewDeadline would refuse it
+	value.SetClock(func() time.Time { return dueDate.AddDate(0, 0, 7) })
+	defer value.SetClock(nil)
+	if _, err := value.NewDeadline(dueDate); err == nil {
+		t.Fatal("Expected NewDeadline to reject the passed due date")
+	}
+
+	data, _ := json.Marshal(task.ToState())
+	var state aggregate.TaskState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("Failed to decode task state: %v", err)
+	}
+	restored, err := aggregate.TaskFromState(state)
+	if err != nil {
+		t.Fatalf("Expected an overdue task to be restored, got %v", err)
+	}
+	if restored.Deadline() == nil || !restored.Deadline().Value().Equal(dueDate) {
+		t.Fatalf("Expected deadline %v to be kept, got %v", dueDate, restored.Deadline())
+	}
+	if !restored.Deadline().IsOverdue() {
+		t.Error("Expected the restored deadline to be overdue")
+	}
+}
+
+// TestInMemoryUnitOfWorkCommitsOrRollsBackTogether tests that writes and outbox events of a transaction are kept or undone as one
+func TestInMemoryUnitOfWorkCommitsOrRollsBackTogether(t *testing.T) {
+	ctx := context.Background()
+	tasks := repository.NewInMemoryTaskRepository()
+	projects := repository.NewInMemoryProjectRepository()
+	subscribers := infraEvent.NewSimpleEventPublisher()
+	published := 0
+	subscribers.Subscribe("TaskCreated", func(evt event.DomainEvent) error {
+		published++
+		return nil
+	})
+	outbox := infraEvent.NewStoringEventPublisher(infraEvent.NewInMemoryEventStore(), subscribers, nil)
+	uow := repository.NewInMemoryUnitOfWork(repository.UnitOfWorkRepositories{Tasks: tasks, Projects: projects}, outbox)
+
+	ownerID := value.GenerateUserID()
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Atomic", "", ownerID, value.DefaultWorkflowID)
+	projects.Save(ctx, project)
+	priority, _ := value.NewPriority("LOW")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "All or nothing", "", priority, ownerID)
+
+	write := func() {
+		if err := uow.BeginTransaction(ctx); err != nil {
+			t.Fatalf("Failed to begin transaction: %v", err)
+		}
+		if err := uow.GetTaskRepository().Save(ctx, task); err != nil {
+			t.Fatalf("Failed to save task: %v", err)
+		}
+		if err := uow.GetProjectRepository().Update(ctx, project); err != nil {
+			t.Fatalf("Failed to update project: %v", err)
+		}
+		uow.RecordEvents(task.DomainEvents()...)
+	}
+
+	write()
+	if err := uow.Rollback(); err != nil {
+		t.Fatalf("Failed to roll back: %v", err)
+	}
+	if _, err := tasks.GetByID(ctx, task.ID()); err == nil || published != 0 {
+		t.Fatalf("Expected the rolled back task gone and no events, got %d events", published)
+	}
+
+	write()
+	if err := uow.Commit(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	if _, err := tasks.GetByID(ctx, task.ID()); err != nil || published != 1 {
+		t.Errorf("Expected the committed task stored and announced once, got %v and %d events", err, published)
+	}
+	if err := uow.Commit(); err == nil {
+		t.Error("Expected a commit without a transaction to be refused")
+	}
+}
+
+// TestInMemoryUnitOfWorkKeepsCommitsWhenSubscribersFail tests that a failing subscriber neither
+// fails the commit nor leaves stored events for writes that were undone
+func TestInMemoryUnitOfWorkKeepsCommitsWhenSubscribersFail(t *testing.T) {
+	ctx := context.Background()
+	tasks := repository.NewInMemoryTaskRepository()
+	projects := repository.NewInMemoryProjectRepository()
+	store := infraEvent.NewInMemoryEventStore()
+	subscribers := infraEvent.NewSimpleEventPublisher()
+	subscribers.Subscribe("TaskCreated", func(evt event.DomainEvent) error {
+		return errors.New("search index unavailable")
+	})
+	uow := repository.NewInMemoryUnitOfWork(repository.UnitOfWorkRepositories{Tasks: tasks, Projects: projects},
+		infraEvent.NewStoringEventPublisher(store, subscribers, nil))
+
+	ownerID := value.GenerateUserID()
+	priority, _ := value.NewPriority("LOW")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Indexed later", "", priority, ownerID)
+
+	uow.BeginTransaction(ctx)
+	uow.GetTaskRepository().Save(ctx, task)
+	uow.RecordEvents(task.DomainEvents()...)
+	if err := uow.Commit(); err != nil {
+		t.Fatalf("Expected the commit kept despite the subscriber, got %v", err)
+	}
+	if _, err := tasks.GetByID(ctx, task.ID()); err != nil {
+		t.Errorf("Expected the task kept, got %v", err)
+	}
+	if stored, _ := store.GetEvents(ctx, task.ID().Value()); len(stored) != 1 {
+		t.Errorf("Expected the event stored for replay, got %d", len(stored))
+	}
+}
+
+// TestInMemoryUnitOfWorkRollsBackToTheLoadedProject tests that a rollback stores a shared project
+// back as it was loaded, not as changed by the caller
+func TestInMemoryUnitOfWorkRollsBackToTheLoadedProject(t *testing.T) {
+	ctx := context.Background()
+	projects := repository.NewInMemoryProjectRepository()
+	uow := repository.NewInMemoryUnitOfWork(repository.UnitOfWorkRepositories{Projects: projects},
+		infraEvent.NewStoringEventPublisher(infraEvent.NewInMemoryEventStore(), infraEvent.NewSimpleEventPublisher(), nil))
+
+	ownerID := value.GenerateUserID()
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Shared", "", ownerID, value.DefaultWorkflowID)
+	projects.Save(ctx, project)
+
+	loaded, _ := uow.GetProjectRepository().GetByID(ctx, project.ID())
+	taskID := value.GenerateTaskID()
+	loaded.AddTask(taskID)
+
+	uow.BeginTransaction(ctx)
+	if err := uow.GetProjectRepository().Update(ctx, loaded); err != nil {
+		t.Fatalf("Failed to update project: %v", err)
+	}
+	if err := uow.Rollback(); err != nil {
+		t.Fatalf("Failed to roll back: %v", err)
+	}
+
+	stored, _ := projects.GetByID(ctx, project.ID())
+	if stored.HasTask(taskID) {
+		t.Error("Expected the project stored back without the task added in the transaction")
+	}
+}
+
+// TestSQLiteRepositoriesKeepLookupsAndVersions tests the SQLite repositories where they go beyond storing states
+func TestSQLiteRepositoriesKeepLookupsAndVersions(t *testing.T) {
+	ctx := context.Background()
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := repository.EnsureSQLiteSchema(db); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	if err := repository.EnsureSQLiteSchema(db); err != nil {
+		t.Fatalf("Expected creating the schema again to leave it as is, got %v", err)
+	}
+
+	// Soft-deleted tasks are only found by GetDeleted
+	tasks := repository.NewSQLiteTaskRepository(db)
+	ownerID := value.GenerateUserID()
+	priority, _ := value.NewPriority("LOW")
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Stored", "", priority, ownerID)
+	if err := tasks.Save(ctx, task); err != nil {
+		t.Fatalf("Failed to save task: %v", err)
+	}
+	if found, _ := tasks.GetByProjectID(ctx, task.ProjectID()); len(found) != 1 || found[0].Title() != "Stored" {
+		t.Fatalf("Expected the task found by project, got %d", len(found))
+	}
+	task.MarkDeleted()
+	if err := tasks.Update(ctx, task); err != nil {
+		t.Fatalf("Failed to soft delete task: %v", err)
+	}
+	if _, err := tasks.GetByID(ctx, task.ID()); err == nil || err.Error() != "task not found" {
+		t.Errorf("Expected a soft-deleted task not found, got %v", err)
+	}
+	if _, err := tasks.GetDeleted(ctx, task.ID()); err != nil {
+		t.Errorf("Expected the soft-deleted task kept, got %v", err)
+	}
+
+	// Every version of a workflow is kept and lookups return the latest
+	workflows := repository.NewSQLiteWorkflowRepository(db)
+	workflow := aggregate.NewDefaultWorkflow()
+	workflows.Save(ctx, workflow)
+	next := workflow.NextVersion()
+	next.AddStatus(aggregate.NewWorkflowStatus("QA", "QA", 0, false))
+	if err := workflows.Update(ctx, next); err != nil {
+		t.Fatalf("Failed to save the next version: %v", err)
+	}
+	if latest, _ := workflows.GetByID(ctx, workflow.ID()); latest.Version() != 2 || !latest.Covers("QA") {
+		t.Errorf("Expected the latest version by ID, got version %d", latest.Version())
+	}
+	if first, err := workflows.GetVersion(ctx, workflow.WorkflowVersion()); err != nil || first.Covers("QA") {
+		t.Errorf("Expected the first version kept as it was, got %v", err)
+	}
+	if err := workflows.Update(ctx, workflow); err == nil {
+		t.Error("Expected an out of date version to be refused")
+	}
+
+	// Recent views are de-duplicated and bounded per user
+	views := repository.NewSQLiteRecentViewRepository(db, 2)
+	for _, itemID := range []string{"task-1", "task-2", "task-1", "task-3"} {
+		view, _ := value.NewRecentView(value.ViewedItemTask, itemID, time.Now())
+		views.Record(ctx, ownerID, view)
+	}
+	if recent, _ := views.GetRecent(ctx, ownerID, 0); len(recent) != 2 || recent[0].ItemID() != "task-3" || recent[1].ItemID() != "task-1" {
+		t.Errorf("Expected the 2 most recent distinct views, got %+v", recent)
+	}
+
+	// Each region of an organization has one holiday calendar
+	calendars := repository.NewSQLiteHolidayCalendarRepository(db)
+	organizationID := value.GenerateOrganizationID()
+	first, _ := aggregate.NewHolidayCalendar(value.GenerateHolidayCalendarID(), organizationID, "de", "Germany", nil)
+	second, _ := aggregate.NewHolidayCalendar(value.GenerateHolidayCalendarID(), organizationID, "DE", "Deutschland", nil)
+	if err := calendars.Save(ctx, first); err != nil {
+		t.Fatalf("Failed to save calendar: %v", err)
+	}
+	if err := calendars.Save(ctx, second); err == nil {
+		t.Error("Expected a second calendar for the region to be refused")
+	}
+}
+
+// TestTaskRepositoriesRefuseStaleUpdatesOnEveryBackend tests that an update of a task read
+// before another update was stored is refused instead of overwriting it
+func TestTaskRepositoriesRefuseStaleUpdatesOnEveryBackend(t *testing.T) {
+	ctx := context.Background()
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := repository.EnsureSQLiteSchema(db); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	backends := []struct {
+		name  string
+		tasks domain.TaskRepository
+	}{
+		{"memory", repository.NewInMemoryTaskRepository()},
+		{"sqlite", repository.NewSQLiteTaskRepository(db)},
+	}
+
+	priority, _ := value.NewPriority("LOW")
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Contended", "", priority, value.GenerateUserID())
+			if err := backend.tasks.Save(ctx, task); err != nil {
+				t.Fatalf("Failed to save task: %v", err)
+			}
+
+			first, _ := backend.tasks.GetByID(ctx, task.ID())
+			second, _ := backend.tasks.GetByID(ctx, task.ID())
+
+			first.ChangeStatus(value.TaskStatusInProgress)
+			if err := backend.tasks.Update(ctx, first); err != nil {
+				t.Fatalf("Failed to update task: %v", err)
+			}
+
+			second.ChangeStatus(value.TaskStatusCancelled)
+			if err := backend.tasks.Update(ctx, second); !errors.Is(err, errs.ErrConcurrencyConflict) {
+				t.Fatalf("Expected a stale update to be refused, got %v", err)
+			}
+			if stored, _ := backend.tasks.GetByID(ctx, task.ID()); stored.Status() != value.TaskStatusInProgress {
+				t.Errorf("Expected the first update kept, got %s", stored.Status().Value())
+			}
+
+			// The first writer's copy is current again and keeps updating
+			first.ChangeStatus(value.TaskStatusInReview)
+			if err := backend.tasks.Update(ctx, first); err != nil {
+				t.Errorf("Expected the current copy to update, got %v", err)
+			}
+		})
+	}
+}
+
+// TestSnapshotDirectoryRestoresInMemoryRepositories tests that a snapshot of the in-memory
+// repositories and event store loads back into fresh ones as it was saved
+func TestSnapshotDirectoryRestoresInMemoryRepositories(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	serializer := infraEvent.NewEventSerializer()
+
+	spill, err := repository.OpenTaskSpillFile(filepath.Join(t.TempDir(), "spill.ndjson"))
+	if err != nil {
+		t.Fatalf("Failed to open spill file: %v", err)
+	}
+	defer spill.Close()
+
+	// A finished task is archived to the spill file and another one soft-deleted
+	tasks := repository.NewInMemoryTaskRepository()
+	tasks.EnableArchival(1, spill)
+	ownerID := value.GenerateUserID()
+	projectID := value.GenerateProjectID()
+	priority, _ := value.NewPriority("LOW")
+	open, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Open", "", priority, ownerID)
+	done, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Done", "", priority, ownerID)
+	done.ChangeStatus(value.TaskStatusCancelled)
+	removed, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Removed", "", priority, ownerID)
+	for _, task := range []*aggregate.Task{open, done, removed} {
+		tasks.Save(ctx, task)
+	}
+	removed.MarkDeleted()
+	tasks.Update(ctx, removed)
+	if archived, err := tasks.Compact(); err != nil || archived != 1 {
+		t.Fatalf("Expected the finished task archived, got %d (%v)", archived, err)
+	}
+
+	workflows := repository.NewInMemoryWorkflowRepository()
+	workflow := aggregate.NewDefaultWorkflow()
+	workflows.Save(ctx, workflow)
+	next := workflow.NextVersion()
+	next.AddStatus(aggregate.NewWorkflowStatus("QA", "QA", 0, false))
+	workflows.Update(ctx, next)
+
+	views := repository.NewInMemoryRecentViewRepository(repository.DefaultRecentViewCapacity)
+	for _, itemID := range []string{"task-1", "task-2"} {
+		view, _ := value.NewRecentView(value.ViewedItemTask, itemID, time.Now())
+		views.Record(ctx, ownerID, view)
+	}
+
+	events := infraEvent.NewInMemoryEventStore()
+	events.Store(ctx, event.NewTaskCreatedEvent(open.ID().Value(), projectID.Value(), "Open", "", "", priority.Value()))
+
+	snapshots, err := repository.NewSnapshotDirectory(dir, tasks, workflows, views,
+		infraEvent.NewInMemoryEventStoreSnapshot(events, serializer))
+	if err != nil {
+		t.Fatalf("Failed to create snapshot directory: %v", err)
+	}
+	if err := snapshots.Save(); err != nil {
+		t.Fatalf("Failed to save snapshots: %v", err)
+	}
+
+	// Load into fresh stores, which already hold something the snapshot replaces
+	restoredTasks := repository.NewInMemoryTaskRepository()
+	stale, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Stale", "", priority, ownerID)
+	restoredTasks.Save(ctx, stale)
+	restoredWorkflows := repository.NewInMemoryWorkflowRepository()
+	restoredViews := repository.NewInMemoryRecentViewRepository(repository.DefaultRecentViewCapacity)
+	restoredEvents := infraEvent.NewInMemoryEventStore()
+	restored, _ := repository.NewSnapshotDirectory(dir, restoredTasks, restoredWorkflows, restoredViews,
+		infraEvent.NewInMemoryEventStoreSnapshot(restoredEvents, serializer))
+	if loaded, err := restored.Load(); err != nil || loaded != 4 {
+		t.Fatalf("Expected 4 snapshot files loaded, got %d (%v)", loaded, err)
+	}
+
+	if all, _ := restoredTasks.GetAll(ctx); len(all) != 2 {
+		t.Errorf("Expected the open and the archived task restored in place of the stale one, got %d", len(all))
+	}
+	if task, err := restoredTasks.GetByID(ctx, done.ID()); err != nil || task.Status() != value.TaskStatusCancelled {
+		t.Errorf("Expected the archived task restored as it was, got %v", err)
+	}
+	if _, err := restoredTasks.GetDeleted(ctx, removed.ID()); err != nil {
+		t.Errorf("Expected the soft-deleted task restored, got %v", err)
+	}
+	if latest, _ := restoredWorkflows.GetByID(ctx, workflow.ID()); latest.Version() != 2 || !latest.Covers("QA") {
+		t.Errorf("Expected the latest workflow version restored, got version %d", latest.Version())
+	}
+	if first, err := restoredWorkflows.GetVersion(ctx, workflow.WorkflowVersion()); err != nil || first.Covers("QA") {
+		t.Errorf("Expected the first workflow version restored, got %v", err)
+	}
+	if recent, _ := restoredViews.GetRecent(ctx, ownerID, 0); len(recent) != 2 || recent[0].ItemID() != "task-2" {
+		t.Errorf("Expected the recent views restored newest first, got %+v", recent)
+	}
+	if history, _ := restoredEvents.GetEvents(ctx, open.ID().Value()); len(history) != 1 || history[0].EventType() != "TaskCreated" {
+		t.Errorf("Expected the event log restored, got %d events", len(history))
+	}
+
+	// Stores without a snapshot file are left as they are
+	empty, _ := repository.NewSnapshotDirectory(t.TempDir(), restoredTasks)
+	if loaded, err := empty.Load(); err != nil || loaded != 0 {
+		t.Errorf("Expected nothing loaded from an empty directory, got %d (%v)", loaded, err)
+	}
+	if all, _ := restoredTasks.GetAll(ctx); len(all) != 2 {
+		t.Errorf("Expected the tasks left as they were, got %d", len(all))
+	}
+}
+
+// TestRepositoryListsPageFilterAndSortOnEveryBackend tests that the in-memory and SQLite
+// repositories return the same pages, totals and cursors
+func TestRepositoryListsPageFilterAndSortOnEveryBackend(t *testing.T) {
+	ctx := context.Background()
+	db, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := repository.EnsureSQLiteSchema(db); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	backends := []struct {
+		name     string
+		tasks    domain.TaskRepository
+		projects domain.ProjectRepository
+		teams    domain.TeamRepository
+		sprints  domain.SprintRepository
+		widgets  domain.WidgetRepository
+	}{
+		{"memory", repository.NewInMemoryTaskRepository(), repository.NewInMemoryProjectRepository(), repository.NewInMemoryTeamRepository(),
+			repository.NewInMemorySprintRepository(), repository.NewInMemoryWidgetRepository()},
+		{"sqlite", repository.NewSQLiteTaskRepository(db), repository.NewSQLiteProjectRepository(db), repository.NewSQLiteTeamRepository(db),
+			repository.NewSQLiteSprintRepository(db), repository.NewSQLiteWidgetRepository(db)},
+	}
+
+	ownerID := value.GenerateUserID()
+	memberID := value.GenerateUserID()
+	projectID := value.GenerateProjectID()
+	priority, _ := value.NewPriority("LOW")
+	deadline, _ := value.NewDeadline(time.Now().Add(48 * time.Hour))
+	titles := []string{"Echo", "Alpha", "Delta", "Charlie", "Bravo"}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			for i, title := range titles {
+				task, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, title, "", priority, ownerID)
+				if i%2 == 0 {
+					task.SetDeadline(deadline, ownerID, "")
+				}
+				if err := backend.tasks.Save(ctx, task); err != nil {
+					t.Fatalf("Failed to save task: %v", err)
+				}
+			}
+			other, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Other", "", priority, ownerID)
+			backend.tasks.Save(ctx, other)
+
+			// Pages follow each other through their cursors
+			filter := domain.TaskFilter{ProjectID: projectID.Value()}
+			first, result, err := backend.tasks.List(ctx, filter, domain.Page{Limit: 2, SortBy: "title"})
+			if err != nil {
+				t.Fatalf("Failed to list tasks: %v", err)
+			}
+			if result.Total != 5 || len(first) != 2 || first[0].Title() != "Alpha" || first[1].Title() != "Bravo" {
+				t.Fatalf("Expected the first 2 of 5 tasks by title, got %d of %d", len(first), result.Total)
+			}
+			secondPage := result.NextCursor
+			titlesSeen := []string{first[0].Title(), first[1].Title()}
+			for result.NextCursor != "" {
+				var page []*aggregate.Task
+				page, result, err = backend.tasks.List(ctx, filter, domain.Page{Limit: 2, Cursor: result.NextCursor, SortBy: "title"})
+				if err != nil {
+					t.Fatalf("Failed to list the next page: %v", err)
+				}
+				for _, task := range page {
+					titlesSeen = append(titlesSeen, task.Title())
+				}
+			}
+			if got := strings.Join(titlesSeen, ","); got != "Alpha,Bravo,Charlie,Delta,Echo" {
+				t.Errorf("Expected every task once in title order, got %s", got)
+			}
+
+			// Tasks without a deadline sort first; descending reverses both the field and the ID order
+			byDeadline, _, _ := backend.tasks.List(ctx, filter, domain.Page{SortBy: "deadline", SortDir: domain.SortDescending})
+			if len(byDeadline) != 5 || byDeadline[0].Deadline() == nil || byDeadline[4].Deadline() != nil {
+				t.Errorf("Expected tasks with a deadline first when descending")
+			}
+			byOffset, result, _ := backend.tasks.List(ctx, filter, domain.Page{Offset: 4, SortBy: "title", SortDir: "desc"})
+			if len(byOffset) != 1 || byOffset[0].Title() != "Alpha" || result.Offset != 4 || result.NextCursor != "" {
+				t.Errorf("Expected the last task of a descending list at offset 4, got %d", len(byOffset))
+			}
+
+			// Projects filter out archived ones and teams match a member
+			active, _ := aggregate.NewProject(value.GenerateProjectID(), "Active", "", ownerID, value.GenerateWorkflowID())
+			archived, _ := aggregate.NewProject(value.GenerateProjectID(), "Archived", "", ownerID, value.GenerateWorkflowID())
+			archived.Archive(value.ArchivePolicyFreeze, nil)
+			backend.projects.Save(ctx, active)
+			backend.projects.Save(ctx, archived)
+			unarchived := false
+			projects, result, _ := backend.projects.List(ctx, domain.ProjectFilter{OwnerID: ownerID.Value(), Archived: &unarchived}, domain.Page{})
+			if len(projects) != 1 || result.Total != 1 || projects[0].Name() != "Active" {
+				t.Errorf("Expected only the active project, got %d", len(projects))
+			}
+
+			withMember, _ := aggregate.NewTeam(value.GenerateTeamID(), "Platform", ownerID, []value.UserID{memberID})
+			without, _ := aggregate.NewTeam(value.GenerateTeamID(), "Design", ownerID, nil)
+			backend.teams.Save(ctx, withMember)
+			backend.teams.Save(ctx, without)
+			teams, _, _ := backend.teams.List(ctx, domain.TeamFilter{MemberID: memberID.Value()}, domain.Page{SortBy: "name"})
+			if len(teams) != 1 || teams[0].Name() != "Platform" {
+				t.Errorf("Expected the member's team only, got %d", len(teams))
+			}
+
+			// Sprints page by start date and widgets by their grid position
+			for _, day := range []int{3, 1, 2} {
+				start := time.Now().Add(time.Duration(day) * 24 * time.Hour)
+				sprint, _ := aggregate.NewSprint(value.GenerateSprintID(), projectID, "Sprint "+strconv.Itoa(day), "", start, start.Add(time.Hour))
+				backend.sprints.Save(ctx, sprint)
+			}
+			sprints, result, _ := backend.sprints.List(ctx, domain.SprintFilter{ProjectID: projectID.Value()}, domain.Page{Limit: 2})
+			if len(sprints) != 2 || result.Total != 3 || sprints[0].Name() != "Sprint 1" || sprints[1].Name() != "Sprint 2" || result.NextCursor == "" {
+				t.Errorf("Expected the first two sprints by start date, got %d", len(sprints))
+			}
+
+			for _, position := range [][2]int{{1, 2}, {0, 1}, {2, 1}} {
+				layout, _ := value.NewWidgetLayout(position[0], position[1], 1, 1)
+				widget, _ := aggregate.NewWidget(value.GenerateWidgetID(), ownerID, strconv.Itoa(position[1])+":"+strconv.Itoa(position[0]),
+					value.WidgetTypeWorkloadHeatmap, nil, layout)
+				backend.widgets.Save(ctx, widget)
+			}
+			widgets, _, _ := backend.widgets.List(ctx, domain.WidgetFilter{OwnerID: ownerID.Value()}, domain.Page{})
+			if len(widgets) != 3 || widgets[0].Title() != "1:0" || widgets[1].Title() != "1:2" || widgets[2].Title() != "2:1" {
+				t.Errorf("Expected widgets ordered by row then column")
+			}
+
+			for _, page := range []domain.Page{
+				{Limit: domain.MaxPageLimit + 1},
+				{Limit: -1},
+				{SortBy: "priority"},
+				{SortDir: "SIDEWAYS"},
+				{Cursor: "not-a-cursor"},
+				{Offset: 2, Cursor: secondPage},
+			} {
+				if _, _, err := backend.tasks.List(ctx, filter, page); err == nil || !strings.HasPrefix(err.Error(), "invalid") {
+					t.Errorf("Expected page %+v to be refused as invalid, got %v", page, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/infrastructure/scheduler"
+)
+
+// TestSchedulerRunsDueJobsOnTheirSchedule tests interval and daily schedules, failures and missed runs
+func TestSchedulerRunsDueJobsOnTheirSchedule(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	jobs := scheduler.NewScheduler()
+	jobs.SetClock(func() time.Time { return now })
+
+	var failures []string
+	jobs.OnFailure(func(name string, err error) { failures = append(failures, name+": "+err.Error()) })
+
+	sweeps := 0
+	jobs.Add("sweep", scheduler.Every(15*time.Minute), func(ctx context.Context, at time.Time) error {
+		sweeps++
+		return nil
+	})
+	daily, err := scheduler.DailyAt(6, 30, time.UTC)
+	if err != nil {
+		t.Fatalf("Failed to create daily schedule: %v", err)
+	}
+	jobs.Add("digest", daily, func(ctx context.Context, at time.Time) error {
+		return errors.New("mail relay down")
+	})
+
+	if err := jobs.Add("sweep", scheduler.Every(time.Hour), nil); err == nil {
+		t.Error("Expected a second job with the same name to be refused")
+	}
+	if _, err := scheduler.DailyAt(24, 0, time.UTC); err == nil {
+		t.Error("Expected an invalid time of day to be refused")
+	}
+
+	if ran := jobs.RunDue(context.Background()); ran != 0 {
+		t.Errorf("Expected nothing due right after adding the jobs, got %d", ran)
+	}
+
+	// An hour later the sweep missed several steps; it is made up once
+	now = now.Add(time.Hour)
+	if ran := jobs.RunDue(context.Background()); ran != 1 || sweeps != 1 {
+		t.Errorf("Expected the sweep to run once, got %d runs and %d sweeps", ran, sweeps)
+	}
+
+	// The digest is due at half past six the next morning
+	now = time.Date(2024, 3, 2, 6, 30, 0, 0, time.UTC)
+	jobs.RunDue(context.Background())
+	if len(failures) != 1 || failures[0] != "digest: mail relay down" {
+		t.Errorf("Expected the digest failure reported, got %v", failures)
+	}
+
+	statuses := jobs.Status()
+	if len(statuses) != 2 || statuses[0].Name != "digest" || statuses[0].Failures != 1 || statuses[0].LastError != "mail relay down" ||
+		!statuses[0].NextRunAt.Equal(time.Date(2024, 3, 3, 6, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the digest to fail and be due the next morning, got %+v", statuses)
+	}
+	if statuses[1].Runs != 2 || statuses[1].LastError != "" || !statuses[1].NextRunAt.Equal(now.Add(15*time.Minute)) {
+		t.Errorf("Expected the sweep to be due a step after its last run, got %+v", statuses[1])
+	}
+
+	if err := jobs.Run(context.Background(), "sweep"); err != nil || sweeps != 3 {
+		t.Errorf("Expected the sweep to run on demand, got %v after %d sweeps", err, sweeps)
+	}
+	if err := jobs.Run(context.Background(), "missing"); err == nil {
+		t.Error("Expected an unknown job to be refused")
+	}
+}
@@ -0,0 +1,113 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/application/query"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/repository"
+	"github.com/miladev95/ddd-task/infrastructure/search"
+)
+
+// TestPrefixIndexRanksByRelevanceThenRecency tests suggestion ordering
+func TestPrefixIndexRanksByRelevanceThenRecency(t *testing.T) {
+	index := search.NewPrefixIndex()
+	now := time.Now()
+
+	index.Upsert(search.KindTask, "t1", "Fix login redirect", now.Add(-72*time.Hour))
+	index.Upsert(search.KindTask, "t2", "Login page redesign", now.Add(-72*time.Hour))
+	index.Upsert(search.KindProject, "p1", "Mobile login", now)
+	index.Upsert(search.KindUser, "u1", "Alice Logan", now)
+
+	suggestions := index.Suggest("login", 10)
+	if len(suggestions) != 3 {
+		t.Fatalf("Expected 3 suggestions, got %d", len(suggestions))
+	}
+
+	if suggestions[0].ID != "t2" {
+		t.Errorf("Expected full prefix match first, got %s", suggestions[0].ID)
+	}
+
+	if suggestions[1].ID != "p1" {
+		t.Errorf("Expected recent word match second, got %s", suggestions[1].ID)
+	}
+}
+
+// TestPrefixIndexRemove tests that removed entries no longer match
+func TestPrefixIndexRemove(t *testing.T) {
+	index := search.NewPrefixIndex()
+	index.Upsert(search.KindTask, "t1", "Quarterly report", time.Now())
+	index.Remove(search.KindTask, "t1")
+
+	if len(index.Suggest("quarter", 10)) != 0 {
+		t.Error("Expected no suggestions after removal")
+	}
+}
+
+// TestRecentViewRepositoryDeduplicates tests that re-viewing an item moves it to the front
+func TestRecentViewRepositoryDeduplicates(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInMemoryRecentViewRepository(2)
+	userID := value.GenerateUserID()
+	now := time.Now()
+
+	record := func(itemID string, at time.Time) {
+		view, err := value.NewRecentView(value.ViewedItemTask, itemID, at)
+		if err != nil {
+			t.Fatalf("Failed to create view: %v", err)
+		}
+		if err := repo.Record(ctx, userID, view); err != nil {
+			t.Fatalf("Failed to record view: %v", err)
+		}
+	}
+
+	record("a", now)
+	record("b", now.Add(time.Minute))
+	record("a", now.Add(2*time.Minute))
+	record("c", now.Add(3*time.Minute))
+
+	views, err := repo.GetRecent(ctx, userID, 10)
+	if err != nil {
+		t.Fatalf("Failed to get recent views: %v", err)
+	}
+
+	if len(views) != 2 || views[0].ItemID() != "c" || views[1].ItemID() != "a" {
+		t.Errorf("Expected [c a], got %v", views)
+	}
+}
+
+// TestWorkspaceIndexFiltersTypesAndHighlights tests full text matching across task documents
+func TestWorkspaceIndexFiltersTypesAndHighlights(t *testing.T) {
+	index := search.NewWorkspaceIndex()
+	now := time.Now()
+
+	index.ReplaceTask("t1", "p1", "Invoice export", []search.Document{
+		{Type: query.SearchTypeTask, ID: "t1", Text: "Invoice export\nExport invoices as CSV", UpdatedAt: now},
+		{Type: query.SearchTypeComment, ID: "c1", Text: "The <b>invoice</b> totals are off by one cent", UpdatedAt: now},
+		{Type: query.SearchTypeAttachment, ID: "a1", Text: "invoice-2024-03.pdf", UpdatedAt: now},
+	})
+
+	hits := index.Search("invoice", nil)
+	if len(hits) != 3 {
+		t.Fatalf("Expected 3 hits, got %d", len(hits))
+	}
+	if hits[0].Type != query.SearchTypeTask {
+		t.Errorf("Expected the task itself to rank first, got %s", hits[0].Type)
+	}
+
+	comments := index.Search("invoice totals", []string{query.SearchTypeComment})
+	if len(comments) != 1 || comments[0].TaskID != "t1" || comments[0].Title != "Invoice export" {
+		t.Fatalf("Expected the comment hit with its task, got %+v", comments)
+	}
+	expected := "The &lt;b&gt;<mark>invoice</mark>&lt;/b&gt; <mark>totals</mark> are off by one cent"
+	if comments[0].Snippet != expected {
+		t.Errorf("Expected snippet %q, got %q", expected, comments[0].Snippet)
+	}
+
+	index.ReplaceTask("t1", "p1", "Invoice export", nil)
+	if len(index.Search("invoice", nil)) != 0 {
+		t.Error("Expected reindexing a task to drop its old documents")
+	}
+}
@@ -0,0 +1,388 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/miladev95/ddd-task/domain/aggregate"
+	"github.com/miladev95/ddd-task/domain/entity"
+	"github.com/miladev95/ddd-task/domain/service"
+	"github.com/miladev95/ddd-task/domain/value"
+	"github.com/miladev95/ddd-task/infrastructure/repository"
+	"github.com/miladev95/ddd-task/infrastructure/translation"
+)
+
+// TestSLIFirstResponseIgnoresCreatorComments tests that only other users' comments count as a response
+func TestSLIFirstResponseIgnoresCreatorComments(t *testing.T) {
+	priority, _ := value.NewPriority("MEDIUM")
+	creatorID := value.GenerateUserID()
+	responderID := value.GenerateUserID()
+
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Task", "Description", priority, creatorID)
+
+	own, _ := entity.NewComment(task.ID(), creatorID, "Any update?")
+	task.AddComment(own)
+
+	if task.FirstResponseAt() != nil {
+		t.Fatal("Expected creator comment not to count as first response")
+	}
+
+	reply, _ := entity.NewComment(task.ID(), responderID, "Looking into it")
+	task.AddComment(reply)
+
+	if task.FirstResponseAt() == nil {
+		t.Fatal("Expected first response to be recorded")
+	}
+}
+
+// TestSLIProjectSummaryCountsBreaches tests breach counting against project SLO targets
+func TestSLIProjectSummaryCountsBreaches(t *testing.T) {
+	priority, _ := value.NewPriority("HIGH")
+	ownerID := value.GenerateUserID()
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Support", "", ownerID, value.GenerateWorkflowID())
+	targets, err := value.NewSLOTargets(time.Hour, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Expected valid targets, got %v", err)
+	}
+	project.SetSLOTargets(targets)
+
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Ticket", "", priority, ownerID)
+
+	sliService := service.NewSLICalculationService()
+
+	summary := sliService.SummarizeProject(project, []*aggregate.Task{task}, time.Now(), nil)
+	if summary.FirstResponseBreachCount != 0 || summary.ResolutionBreachCount != 0 {
+		t.Fatalf("Expected no breaches for a fresh task, got %+v", summary)
+	}
+
+	summary = sliService.SummarizeProject(project, []*aggregate.Task{task}, time.Now().Add(2*time.Hour), nil)
+	if summary.FirstResponseBreachCount != 1 {
+		t.Errorf("Expected 1 first response breach, got %d", summary.FirstResponseBreachCount)
+	}
+	if summary.ResolutionBreachCount != 0 {
+		t.Errorf("Expected no resolution breach, got %d", summary.ResolutionBreachCount)
+	}
+}
+
+// TestDuplicateDetectionFindsSimilarTitles tests fuzzy title matching
+func TestDuplicateDetectionFindsSimilarTitles(t *testing.T) {
+	priority, _ := value.NewPriority("MEDIUM")
+	projectID := value.GenerateProjectID()
+	creatorID := value.GenerateUserID()
+
+	typo, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Fix login redirect", "", priority, creatorID)
+	reordered, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Redirect login fix", "", priority, creatorID)
+	unrelated, _ := aggregate.NewTask(value.GenerateTaskID(), projectID, "Write release notes", "", priority, creatorID)
+
+	detector := service.NewDuplicateDetectionService()
+	candidates := detector.FindSimilar("Fix logn redirect!", []*aggregate.Task{typo, reordered, unrelated}, 0)
+
+	if len(candidates) != 1 || !candidates[0].Task.ID().Equals(typo.ID()) {
+		t.Fatalf("Expected only the typo match, got %d candidates", len(candidates))
+	}
+
+	if service.TitleSimilarity("fix login redirect", "redirect login fix") != 1 {
+		t.Error("Expected reordered words to be fully similar")
+	}
+}
+
+// TestRoleBasedPolicyEnforcesGlobalAndProjectRoles tests the permissions granted by each role
+func TestRoleBasedPolicyEnforcesGlobalAndProjectRoles(t *testing.T) {
+	policy := service.NewRoleBasedPolicy()
+	priority, _ := value.NewPriority("MEDIUM")
+
+	owner, _ := aggregate.NewUser(value.GenerateUserID(), "owner@example.com", "Project", "Owner")
+	member, _ := aggregate.NewUser(value.GenerateUserID(), "member@example.com", "Team", "Member")
+	viewer, _ := aggregate.NewUser(value.GenerateUserID(), "viewer@example.com", "Read", "Only")
+	admin, _ := aggregate.NewUser(value.GenerateUserID(), "admin@example.com", "Global", "Admin")
+	admin.GrantRole(value.GlobalRoleAdmin)
+
+	project, _ := aggregate.NewProject(value.GenerateProjectID(), "Policy", "", owner.ID(), value.GenerateWorkflowID())
+	project.AssignRole(viewer.ID(), value.ProjectRoleViewer)
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), project.ID(), "Task", "", priority, owner.ID())
+	task.Assign(member.ID(), owner.ID())
+
+	cases := []struct {
+		name       string
+		actor      *aggregate.User
+		permission service.Permission
+		allowed    bool
+	}{
+		{"owner manages", owner, service.PermissionManageProject, true},
+		{"member cannot manage", member, service.PermissionManageProject, false},
+		{"global admin manages", admin, service.PermissionManageProject, true},
+		{"owner deletes the project", owner, service.PermissionDeleteProject, true},
+		{"member cannot delete the project", member, service.PermissionDeleteProject, false},
+		{"owner deletes", owner, service.PermissionDeleteTask, true},
+		{"member cannot delete", member, service.PermissionDeleteTask, false},
+		{"member transitions", member, service.PermissionTransitionTask, true},
+		{"viewer cannot transition", viewer, service.PermissionTransitionTask, false},
+		{"assignee starts", member, service.PermissionStartTask, true},
+		{"owner cannot start another's task", owner, service.PermissionStartTask, false},
+		{"global admin cannot start another's task", admin, service.PermissionStartTask, false},
+		{"member creates tasks", member, service.PermissionCreateTask, true},
+		{"viewer cannot create tasks", viewer, service.PermissionCreateTask, false},
+		{"viewer cannot assign", viewer, service.PermissionAssignTask, false},
+		{"viewer cannot edit", viewer, service.PermissionEditTask, false},
+		{"member plans sprints", member, service.PermissionPlanSprints, true},
+		{"viewer cannot plan sprints", viewer, service.PermissionPlanSprints, false},
+		{"viewer discusses", viewer, service.PermissionDiscussTask, true},
+	}
+
+	for _, tc := range cases {
+		err := policy.Authorize(tc.actor, tc.permission, project, task)
+		if tc.allowed && err != nil {
+			t.Errorf("%s: expected allowed, got %v", tc.name, err)
+		}
+		if !tc.allowed && err == nil {
+			t.Errorf("%s: expected permission denied", tc.name)
+		}
+	}
+}
+
+// TestWorkflowSimulationFindsDeadEndsAndCycles tests the structural checks of a proposed workflow
+func TestWorkflowSimulationFindsDeadEndsAndCycles(t *testing.T) {
+	priority, _ := value.NewPriority("MEDIUM")
+	creatorID := value.GenerateUserID()
+
+	workflow, _ := aggregate.NewWorkflow(value.GenerateWorkflowID(), "Proposed", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("BACKLOG", "", 0, false),
+		aggregate.NewWorkflowStatus("TO_DO", "", 1, false),
+		aggregate.NewWorkflowStatus("IN_PROGRESS", "", 2, false),
+		aggregate.NewWorkflowStatus("IN_REVIEW", "", 3, false),
+		aggregate.NewWorkflowStatus("COMPLETED", "", 4, true),
+	})
+
+	transitions := []service.WorkflowTransition{
+		{From: "BACKLOG", To: "TO_DO"},
+		{From: "TO_DO", To: "COMPLETED"},
+		{From: "IN_PROGRESS", To: "IN_REVIEW"},
+		{From: "IN_REVIEW", To: "IN_PROGRESS"},
+		{From: "IN_REVIEW", To: "ARCHIVED"},
+	}
+
+	backlog, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Backlog", "", priority, creatorID)
+	cancelled, _ := aggregate.NewTask(value.GenerateTaskID(), value.GenerateProjectID(), "Cancelled", "", priority, creatorID)
+	cancelled.ChangeStatus(value.TaskStatusCancelled)
+
+	simulation := service.NewWorkflowSimulationService().Simulate(workflow, transitions, []*aggregate.Task{backlog, cancelled})
+
+	codes := make(map[string][]string)
+	for _, issue := range simulation.Issues {
+		codes[issue.Code] = issue.Statuses
+	}
+	if statuses := codes[service.WorkflowIssueCycle]; len(statuses) != 2 || statuses[0] != "IN_PROGRESS" {
+		t.Errorf("Expected IN_PROGRESS and IN_REVIEW to loop, got %v", statuses)
+	}
+	if statuses := codes[service.WorkflowIssueUnknownTransition]; len(statuses) != 1 || statuses[0] != "ARCHIVED" {
+		t.Errorf("Expected the ARCHIVED transition to be reported, got %v", statuses)
+	}
+	if _, exists := codes[service.WorkflowIssueFinalUnreachable]; exists {
+		t.Error("Expected every status outside the loop to reach COMPLETED")
+	}
+
+	if simulation.Valid() || len(simulation.InvalidTasks) != 1 {
+		t.Fatalf("Expected only the cancelled task to be invalid, got %d", len(simulation.InvalidTasks))
+	}
+	if simulation.InvalidTasks[0].Problem != service.TaskProblemStatusMissing {
+		t.Errorf("Expected a missing status, got %s", simulation.InvalidTasks[0].Problem)
+	}
+}
+
+// TestStatusTransitionsFollowTheProjectWorkflow tests workflow statuses and transition matrices over the default rules
+func TestStatusTransitionsFollowTheProjectWorkflow(t *testing.T) {
+	ctx := context.Background()
+	priority, _ := value.NewPriority("MEDIUM")
+	ownerID := value.GenerateUserID()
+	workflows := repository.NewInMemoryWorkflowRepository()
+	projects := repository.NewInMemoryProjectRepository()
+	transitionService := service.NewStatusTransitionService(workflows, projects)
+
+	// A short workflow that goes straight from TO_DO to COMPLETED
+	workflow, _ := aggregate.NewWorkflow(value.GenerateWorkflowID(), "Short", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("Backlog", "", 0, false),
+		aggregate.NewWorkflowStatus("To Do", "", 1, false),
+		aggregate.NewWorkflowStatus("Completed", "", 2, true),
+	})
+	if err := workflow.SetTransitions([]aggregate.WorkflowTransition{{From: "TO_DO", To: "ARCHIVED"}}); err == nil {
+		t.Error("Expected a transition to an unknown status to be refused")
+	}
+	workflow.SetTransitions([]aggregate.WorkflowTransition{
+		{From: "Backlog", To: "To Do"},
+		{From: "To Do", To: "Completed"},
+	})
+	workflows.Save(ctx, workflow)
+
+	short, _ := aggregate.NewProject(value.GenerateProjectID(), "Short", "", ownerID, workflow.ID())
+	projects.Save(ctx, short)
+	unmanaged, _ := aggregate.NewProject(value.GenerateProjectID(), "Unmanaged", "", ownerID, value.GenerateWorkflowID())
+	projects.Save(ctx, unmanaged)
+
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), short.ID(), "Short task", "", priority, ownerID)
+	deadline, _ := value.NewDeadline(time.Now().Add(24 * time.Hour))
+	task.SetDeadline(deadline, ownerID, "")
+
+	if err := transitionService.TransitionTask(ctx, task, value.TaskStatusCancelled); err == nil {
+		t.Error("Expected the matrix to refuse TO_DO to CANCELLED, which the default rules allow")
+	}
+	if next := transitionService.GetValidNextStatusesFor(ctx, task); len(next) != 1 || next[0] != value.TaskStatusCompleted {
+		t.Errorf("Expected only COMPLETED after TO_DO, got %v", next)
+	}
+	if err := transitionService.TransitionTask(ctx, task, value.TaskStatusInProgress); err == nil {
+		t.Error("Expected IN_PROGRESS to be refused, the workflow lacks it")
+	}
+	if err := transitionService.CompleteTask(ctx, task); err != nil {
+		t.Fatalf("Expected the workflow to complete an unassigned task straight from TO_DO, got %v", err)
+	}
+
+	// Without a stored workflow the regular rules apply
+	fallback, _ := aggregate.NewTask(value.GenerateTaskID(), unmanaged.ID(), "Fallback task", "", priority, ownerID)
+	if err := transitionService.TransitionTask(ctx, fallback, value.TaskStatusCancelled); err != nil {
+		t.Errorf("Expected the default rules to allow TO_DO to CANCELLED, got %v", err)
+	}
+}
+
+// TestStatusesResolveInTheOwningWorkflow tests that status names are validated by the task's
+// workflow, and that projects without one follow the default workflow
+func TestStatusesResolveInTheOwningWorkflow(t *testing.T) {
+	ctx := context.Background()
+	priority, _ := value.NewPriority("MEDIUM")
+	ownerID := value.GenerateUserID()
+	workflows := repository.NewInMemoryWorkflowRepository()
+	projects := repository.NewInMemoryProjectRepository()
+	transitionService := service.NewStatusTransitionService(workflows, projects)
+
+	workflow, _ := aggregate.NewWorkflow(value.GenerateWorkflowID(), "Review", "", []aggregate.WorkflowStatus{
+		aggregate.NewWorkflowStatus("To Do", "", 1, false),
+		aggregate.NewWorkflowStatus("In Review", "", 2, false),
+		aggregate.NewWorkflowStatus("QA", "", 3, false),
+	})
+	workflow.SetTransitions([]aggregate.WorkflowTransition{
+		{From: "To Do", To: "In Review"},
+		{From: "In Review", To: "QA"},
+	})
+	workflows.Save(ctx, workflow)
+
+	reviewed, _ := aggregate.NewProject(value.GenerateProjectID(), "Reviewed", "", ownerID, workflow.ID())
+	projects.Save(ctx, reviewed)
+	unmanaged, _ := aggregate.NewProject(value.GenerateProjectID(), "Unmanaged", "", ownerID, value.GenerateWorkflowID())
+	projects.Save(ctx, unmanaged)
+
+	task, _ := aggregate.NewTask(value.GenerateTaskID(), reviewed.ID(), "Reviewed task", "", priority, ownerID)
+
+	// Names resolve in the workflow, whatever their spelling
+	status, err := transitionService.ResolveStatus(ctx, task, "in-review")
+	if err != nil || !status.Equals(mustScopedStatus(t, workflow.ID(), "In Review", value.TaskStatusInReview)) {
+		t.Errorf("Expected in-review to resolve to the workflow's In Review, got %s, %v", status, err)
+	}
+	if stage := transitionService.StageOf(ctx, task, status); stage != value.TaskStatusInReview {
+		t.Errorf("Expected In Review to stand for IN_REVIEW, got %s", stage)
+	}
+	if _, err := transitionService.ResolveStatus(ctx, task, "IN_PROGRESS"); err == nil {
+		t.Error("Expected a status the workflow lacks to be refused")
+	}
+	qa, err := transitionService.ResolveStatus(ctx, task, "qa")
+	if err != nil || qa.Name() != "QA" {
+		t.Fatalf("Expected a custom workflow status to resolve, got %s, %v", qa, err)
+	}
+	if stage := transitionService.StageOf(ctx, task, qa); stage != value.TaskStatusInReview {
+		t.Errorf("Expected QA to stand for the regular status before it, got %s", stage)
+	}
+	if got := transitionService.ScopedStatusOf(ctx, task); !got.Equals(mustScopedStatus(t, workflow.ID(), "To Do", value.TaskStatusToDo)) {
+		t.Errorf("Expected the task's status in its workflow, got %s", got)
+	}
+
+	// Tasks move into custom statuses and keep them
+	actorID := value.GenerateUserID()
+	if err := transitionService.TransitionTaskBy(ctx, task, status, "", nil, actorID); err != nil {
+		t.Fatalf("Expected To Do to move to In Review, got %v", err)
+	}
+	if err := transitionService.TransitionTaskBy(ctx, task, qa, "", nil, actorID); err != nil {
+		t.Fatalf("Expected In Review to move to QA, got %v", err)
+	}
+	if !task.WorkflowStatus().Equals(qa) || task.Status() != value.TaskStatusInReview {
+		t.Errorf("Expected the task in QA at the IN_REVIEW stage, got %s at %s", task.WorkflowStatus(), task.Status())
+	}
+	restored, err := aggregate.TaskFromState(task.ToState())
+	if err != nil || !restored.WorkflowStatus().Equals(qa) {
+		t.Errorf("Expected the workflow status to survive the memento, got %v", err)
+	}
+
+	// Projects on a missing workflow follow the default workflow, which a lookup does not store
+	fallback, _ := aggregate.NewTask(value.GenerateTaskID(), unmanaged.ID(), "Fallback task", "", priority, ownerID)
+	if got := transitionService.WorkflowOf(ctx, fallback); got == nil || !got.ID().Equals(value.DefaultWorkflowID) {
+		t.Fatal("Expected the project to follow the default workflow")
+	}
+	if _, err := workflows.GetByID(ctx, value.DefaultWorkflowID); err == nil {
+		t.Error("Expected looking the workflow up to store nothing")
+	}
+	if _, err := transitionService.ResolveStatus(ctx, fallback, "IN_PROGRESS"); err != nil {
+		t.Errorf("Expected the regular statuses, got %v", err)
+	}
+	if got := transitionService.ScopedStatusOf(ctx, fallback); !got.Equals(value.DefaultScopedStatus(value.TaskStatusToDo)) {
+		t.Errorf("Expected TO_DO of the default workflow, got %s", got)
+	}
+	if err := transitionService.TransitionTask(ctx, fallback, value.TaskStatusCancelled); err != nil {
+		t.Errorf("Expected the default workflow to follow the regular rules, got %v", err)
+	}
+}
+
+// mustScopedStatus creates a ScopedStatus or fails the test
+func mustScopedStatus(t *testing.T, workflowID value.WorkflowID, name string, stage value.TaskStatus) value.ScopedStatus {
+	t.Helper()
+	scoped, err := value.NewScopedStatus(workflowID, name, stage)
+	if err != nil {
+		t.Fatalf("Failed to create scoped status: %v", err)
+	}
+	return scoped
+}
+
+// countingProvider is a translation provider recording how often it is asked
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Translate(text, targetLocale string) (string, error) {
+	p.calls++
+	return "[" + targetLocale + "] " + text, nil
+}
+
+// TestCommentTranslatorCachesPerVersionAndLocale tests that a comment is only translated again once edited
+func TestCommentTranslatorCachesPerVersionAndLocale(t *testing.T) {
+	translator := translation.NewCommentTranslator(2)
+	if _, err := translator.TranslateComment("c1", 1, "Hallo", "en"); !errors.Is(err, translation.ErrNoProvider) {
+		t.Fatalf("Expected ErrNoProvider without a provider, got %v", err)
+	}
+
+	provider := &countingProvider{}
+	translator.SetProvider(provider)
+
+	for i := 0; i < 3; i++ {
+		translated, err := translator.TranslateComment("c1", 1, "Hallo", "en")
+		if err != nil {
+			t.Fatalf("Failed to translate comment: %v", err)
+		}
+		if translated != "[en] Hallo" {
+			t.Errorf("Expected provider output, got %q", translated)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("Expected one provider call for a cached version, got %d", provider.calls)
+	}
+
+	// An edit or another locale is a new translation
+	translator.TranslateComment("c1", 2, "Hallo Welt", "en")
+	translator.TranslateComment("c1", 2, "Hallo Welt", "fr")
+	if provider.calls != 3 {
+		t.Errorf("Expected edited and other-locale comments to be translated, got %d calls", provider.calls)
+	}
+
+	// The cache holds two translations, so the first was dropped
+	translator.TranslateComment("c1", 1, "Hallo", "en")
+	if provider.calls != 4 {
+		t.Errorf("Expected the oldest translation to be evicted, got %d calls", provider.calls)
+	}
+}